@@ -0,0 +1,133 @@
+// Package analytics computes market analytics, such as realized volatility,
+// from the historic candles of a currency pair
+package analytics
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
+)
+
+// Realized volatility estimators
+const (
+	// CloseToClose measures the variance of log returns between closes
+	CloseToClose = "close-to-close"
+	// Parkinson measures variance from each candle's high low range
+	Parkinson = "parkinson"
+	// GarmanKlass measures variance from each candle's open, high, low and
+	// close
+	GarmanKlass = "garman-klass"
+)
+
+// Estimators are the supported realized volatility estimators
+var Estimators = []string{CloseToClose, Parkinson, GarmanKlass}
+
+// periodsPerYear is the length of a year, markets trade continuously
+const periodsPerYear = 365 * 24 * time.Hour
+
+// Errors returned when computing volatility
+var (
+	ErrUnsupportedEstimator = errors.New("unsupported volatility estimator")
+	ErrInvalidWindow        = errors.New("volatility window must be at least two candles")
+	ErrInsufficientCandles  = errors.New("not enough candles for the volatility window")
+	ErrInvalidCandle        = errors.New("candle prices must be greater than zero")
+	ErrInvalidInterval      = errors.New("candle interval must be greater than zero")
+)
+
+// Volatility is the realized volatility of the window of candles ending with
+// the candle opening at Time. Variance is per candle and Volatility is
+// annualised.
+type Volatility struct {
+	Time       time.Time `json:"time"`
+	Variance   float64   `json:"variance"`
+	Volatility float64   `json:"volatility"`
+}
+
+// RealizedVariance returns the realized variance per candle of candles in
+// time order. Close to close variance ignores drift, the mean return is
+// assumed to be zero.
+func RealizedVariance(estimator string, candles []kline.Candle) (float64, error) {
+	if len(candles) < 2 {
+		return 0, ErrInsufficientCandles
+	}
+	for x := range candles {
+		c := candles[x]
+		if c.Open <= 0 || c.High <= 0 || c.Low <= 0 || c.Close <= 0 {
+			return 0, ErrInvalidCandle
+		}
+	}
+
+	var sum float64
+	switch estimator {
+	case CloseToClose:
+		for x := 1; x < len(candles); x++ {
+			r := math.Log(candles[x].Close / candles[x-1].Close)
+			sum += r * r
+		}
+		return sum / float64(len(candles)-1), nil
+	case Parkinson:
+		for x := range candles {
+			hl := math.Log(candles[x].High / candles[x].Low)
+			sum += hl * hl
+		}
+		return sum / (4 * math.Ln2 * float64(len(candles))), nil
+	case GarmanKlass:
+		for x := range candles {
+			hl := math.Log(candles[x].High / candles[x].Low)
+			co := math.Log(candles[x].Close / candles[x].Open)
+			sum += 0.5*hl*hl - (2*math.Ln2-1)*co*co
+		}
+		// The estimate of a window of doji candles with long wicks can be
+		// slightly negative
+		return math.Max(sum/float64(len(candles)), 0), nil
+	}
+	return 0, ErrUnsupportedEstimator
+}
+
+// Annualise returns the annualised volatility of a per candle variance
+func Annualise(variance float64, interval kline.Interval) float64 {
+	return math.Sqrt(variance * float64(periodsPerYear) / float64(interval.Duration()))
+}
+
+// RollingVolatility returns the realized volatility of each window of candles
+// in time order, the first result ends with candle window-1
+func RollingVolatility(estimator string, candles []kline.Candle, interval kline.Interval, window int) ([]Volatility, error) {
+	if interval <= 0 {
+		return nil, ErrInvalidInterval
+	}
+	if window < 2 {
+		return nil, ErrInvalidWindow
+	}
+	if len(candles) < window {
+		return nil, ErrInsufficientCandles
+	}
+
+	result := make([]Volatility, 0, len(candles)-window+1)
+	for end := window; end <= len(candles); end++ {
+		variance, err := RealizedVariance(estimator, candles[end-window:end])
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, Volatility{
+			Time:       candles[end-1].Time,
+			Variance:   variance,
+			Volatility: Annualise(variance, interval),
+		})
+	}
+	return result, nil
+}
+
+// LatestVolatility returns the annualised realized volatility of the last
+// window of candles, for sizing positions by volatility
+func LatestVolatility(estimator string, candles []kline.Candle, interval kline.Interval, window int) (float64, error) {
+	if len(candles) > window && window > 0 {
+		candles = candles[len(candles)-window:]
+	}
+	v, err := RollingVolatility(estimator, candles, interval, window)
+	if err != nil {
+		return 0, err
+	}
+	return v[len(v)-1].Volatility, nil
+}
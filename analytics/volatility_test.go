@@ -0,0 +1,82 @@
+package analytics
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
+)
+
+func testCandles() []kline.Candle {
+	start := time.Date(2018, 9, 1, 0, 0, 0, 0, time.UTC)
+	return []kline.Candle{
+		{Time: start, Open: 100, High: 110, Low: 90, Close: 100},
+		{Time: start.Add(time.Hour), Open: 100, High: 121, Low: 99, Close: 110},
+		{Time: start.Add(2 * time.Hour), Open: 110, High: 115, Low: 105, Close: 110},
+	}
+}
+
+func TestRealizedVariance(t *testing.T) {
+	candles := testCandles()
+	hl := func(c kline.Candle) float64 { return math.Log(c.High / c.Low) }
+	co := func(c kline.Candle) float64 { return math.Log(c.Close / c.Open) }
+
+	expected := map[string]float64{
+		CloseToClose: math.Pow(math.Log(1.1), 2) / 2,
+		Parkinson: (math.Pow(hl(candles[0]), 2) + math.Pow(hl(candles[1]), 2) +
+			math.Pow(hl(candles[2]), 2)) / (4 * math.Ln2 * 3),
+		GarmanKlass: (0.5*math.Pow(hl(candles[0]), 2) +
+			0.5*math.Pow(hl(candles[1]), 2) - (2*math.Ln2-1)*math.Pow(co(candles[1]), 2) +
+			0.5*math.Pow(hl(candles[2]), 2)) / 3,
+	}
+	for estimator, e := range expected {
+		v, err := RealizedVariance(estimator, candles)
+		if err != nil || math.Abs(v-e) > 1e-12 {
+			t.Errorf("Test Failed - RealizedVariance(%s) expected %v received %v %v", estimator, e, v, err)
+		}
+	}
+
+	if _, err := RealizedVariance("ewma", candles); err != ErrUnsupportedEstimator {
+		t.Error("Test Failed - RealizedVariance() expected unsupported estimator error", err)
+	}
+	if _, err := RealizedVariance(CloseToClose, candles[:1]); err != ErrInsufficientCandles {
+		t.Error("Test Failed - RealizedVariance() expected insufficient candles error", err)
+	}
+	candles[1].Low = 0
+	if _, err := RealizedVariance(Parkinson, candles); err != ErrInvalidCandle {
+		t.Error("Test Failed - RealizedVariance() expected invalid candle error", err)
+	}
+}
+
+func TestAnnualise(t *testing.T) {
+	if v := Annualise(0.0001, kline.OneDay); math.Abs(v-math.Sqrt(0.0365)) > 1e-12 {
+		t.Error("Test Failed - Annualise() incorrect volatility", v)
+	}
+}
+
+func TestRollingVolatility(t *testing.T) {
+	candles := testCandles()
+	if _, err := RollingVolatility(Parkinson, candles, kline.OneHour, 1); err != ErrInvalidWindow {
+		t.Error("Test Failed - RollingVolatility() expected invalid window error", err)
+	}
+	if _, err := RollingVolatility(Parkinson, candles, kline.OneHour, 4); err != ErrInsufficientCandles {
+		t.Error("Test Failed - RollingVolatility() expected insufficient candles error", err)
+	}
+	if _, err := RollingVolatility(Parkinson, candles, 0, 2); err != ErrInvalidInterval {
+		t.Error("Test Failed - RollingVolatility() expected invalid interval error", err)
+	}
+
+	v, err := RollingVolatility(CloseToClose, candles, kline.OneHour, 2)
+	if err != nil || len(v) != 2 || !v[0].Time.Equal(candles[1].Time) || v[1].Variance != 0 {
+		t.Fatal("Test Failed - RollingVolatility() incorrect volatility", v, err)
+	}
+	if math.Abs(v[0].Volatility-math.Log(1.1)*math.Sqrt(365*24)) > 1e-9 {
+		t.Error("Test Failed - RollingVolatility() incorrect annualised volatility", v[0].Volatility)
+	}
+
+	latest, err := LatestVolatility(CloseToClose, candles, kline.OneHour, 2)
+	if err != nil || latest != v[1].Volatility {
+		t.Error("Test Failed - LatestVolatility() incorrect volatility", latest, err)
+	}
+}
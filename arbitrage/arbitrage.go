@@ -0,0 +1,318 @@
+// Package arbitrage scans the shared ticker and orderbook stores of the
+// enabled exchanges for spot pairs trading at different prices, and ranks the
+// opportunities to buy on one exchange, withdraw to another and sell there by
+// their profit after trading fees and withdrawal costs.
+package arbitrage
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/abbo"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+// Scanner defaults
+const (
+	DefaultAmount       = 1
+	DefaultMaxAge       = time.Minute
+	DefaultScanInterval = 10 * time.Second
+)
+
+// opportunitiesBuffer is the number of scans held for consumers before
+// further scans are dropped
+const opportunitiesBuffer = 16
+
+// Errors returned when evaluating opportunities
+var (
+	ErrNoQuote         = errors.New("no current ticker or orderbook")
+	ErrNoLiquidity     = errors.New("no liquidity on buy or sell side")
+	ErrWithdrawalCost  = errors.New("withdrawal fee exceeds amount bought")
+	ErrFeesUnavailable = errors.New("exchange does not estimate fees")
+)
+
+// Quote is an exchange's market for a currency pair, Bids and Asks are in
+// best price order. Quotes from tickers have a single level of unknown depth
+// with a zero Amount.
+type Quote struct {
+	Exchange string
+	Pair     pair.CurrencyPair
+	Bids     []orderbook.Item
+	Asks     []orderbook.Item
+	Updated  time.Time
+}
+
+// Fees are an exchange's costs of an arbitrage leg. TakerRate is the fraction
+// of each fill charged as a trading fee and WithdrawalFee the amount of the
+// base currency charged to withdraw it.
+type Fees struct {
+	TakerRate     float64
+	WithdrawalFee float64
+}
+
+// FeeSource returns the fees of a currency pair on an exchange
+type FeeSource func(exchName string, p pair.CurrencyPair) (Fees, error)
+
+// Opportunity is buying Amount of a pair on BuyExchange, withdrawing it to
+// SellExchange and selling it there. Prices are volume weighted over the
+// amount, Costs are the trading fees and withdrawal fee valued in the quote
+// currency and Profit is net of them.
+type Opportunity struct {
+	Pair          pair.CurrencyPair `json:"pair"`
+	BuyExchange   string            `json:"buyExchange"`
+	BuyPrice      float64           `json:"buyPrice"`
+	SellExchange  string            `json:"sellExchange"`
+	SellPrice     float64           `json:"sellPrice"`
+	Amount        float64           `json:"amount"`
+	SpreadPercent float64           `json:"spreadPercent"`
+	Costs         float64           `json:"costs"`
+	Profit        float64           `json:"profit"`
+	ProfitPercent float64           `json:"profitPercent"`
+	Time          time.Time         `json:"time"`
+}
+
+// Scanner finds arbitrage opportunities between the spot markets of the
+// exchanges. Pairs are matched across exchanges in their canonical form, so
+// XBT-USD on one exchange is compared with BTC-USD on another. Amount is the
+// base currency amount evaluated, reduced to the depth of the orderbooks, and
+// only opportunities returning at least MinProfitPercent after costs are
+// reported. Each scan's ranked opportunities are sent on Opportunities,
+// dropped when consumers are not keeping up.
+type Scanner struct {
+	Amount           float64
+	MinProfitPercent float64
+	MaxAge           time.Duration
+	Fees             FeeSource
+	Opportunities    chan []Opportunity
+
+	latest []Opportunity
+	m      sync.Mutex
+}
+
+// NewScanner returns a scanner evaluating amount of each pair with the fees
+// from fees
+func NewScanner(amount, minProfitPercent float64, maxAge time.Duration, fees FeeSource) *Scanner {
+	if amount <= 0 {
+		amount = DefaultAmount
+	}
+	if maxAge <= 0 {
+		maxAge = DefaultMaxAge
+	}
+	return &Scanner{
+		Amount:           amount,
+		MinProfitPercent: minProfitPercent,
+		MaxAge:           maxAge,
+		Fees:             fees,
+		Opportunities:    make(chan []Opportunity, opportunitiesBuffer),
+	}
+}
+
+// Scan compares the stored markets of the enabled spot pairs of the exchanges
+// and returns the opportunities ranked by profit percentage
+func (s *Scanner) Scan(exchs []exchange.IBotExchange) []Opportunity {
+	markets := make(map[string][]Quote)
+	var keys []string
+	for _, exch := range exchs {
+		if exch == nil || !exch.IsEnabled() {
+			continue
+		}
+		for _, p := range exch.GetEnabledPairs(ticker.Spot) {
+			q, err := s.quote(exch.GetName(), p)
+			if err != nil {
+				continue
+			}
+			key := abbo.CanonicalPair(p).Pair().String()
+			if _, ok := markets[key]; !ok {
+				keys = append(keys, key)
+			}
+			markets[key] = append(markets[key], q)
+		}
+	}
+
+	var result []Opportunity
+	for _, key := range keys {
+		quotes := markets[key]
+		for i := range quotes {
+			for j := range quotes {
+				if i == j || quotes[j].Bids[0].Price <= quotes[i].Asks[0].Price {
+					continue
+				}
+				o, err := s.Evaluate(&quotes[i], &quotes[j])
+				if err != nil || o.Profit <= 0 || o.ProfitPercent < s.MinProfitPercent {
+					continue
+				}
+				result = append(result, o)
+			}
+		}
+	}
+	Rank(result)
+
+	s.m.Lock()
+	s.latest = result
+	s.m.Unlock()
+	select {
+	case s.Opportunities <- result:
+	default:
+	}
+	return result
+}
+
+// Latest returns the ranked opportunities of the last scan
+func (s *Scanner) Latest() []Opportunity {
+	s.m.Lock()
+	defer s.m.Unlock()
+	result := make([]Opportunity, len(s.latest))
+	copy(result, s.latest)
+	return result
+}
+
+// Evaluate returns the opportunity of buying on buy's exchange and selling on
+// sell's exchange. The base currency bought is charged buy's taker fee and
+// its exchange's withdrawal fee before being sold, and the proceeds are
+// charged sell's taker fee.
+func (s *Scanner) Evaluate(buy, sell *Quote) (Opportunity, error) {
+	o := Opportunity{
+		Pair:         abbo.CanonicalPair(buy.Pair),
+		BuyExchange:  buy.Exchange,
+		SellExchange: sell.Exchange,
+		Time:         time.Now(),
+	}
+	if s.Fees == nil {
+		return o, ErrFeesUnavailable
+	}
+
+	amount := s.Amount
+	if d := depth(buy.Asks); d > 0 && d < amount {
+		amount = d
+	}
+	if d := depth(sell.Bids); d > 0 && d < amount {
+		amount = d
+	}
+	o.Amount = amount
+	o.BuyPrice = fillPrice(buy.Asks, amount)
+	o.SellPrice = fillPrice(sell.Bids, amount)
+	if o.Amount <= 0 || o.BuyPrice <= 0 || o.SellPrice <= 0 {
+		return o, ErrNoLiquidity
+	}
+	o.SpreadPercent = (o.SellPrice - o.BuyPrice) / o.BuyPrice * 100
+
+	buyFees, err := s.Fees(buy.Exchange, buy.Pair)
+	if err != nil {
+		return o, err
+	}
+	sellFees, err := s.Fees(sell.Exchange, sell.Pair)
+	if err != nil {
+		return o, err
+	}
+
+	cost := amount * o.BuyPrice
+	transferred := amount*(1-buyFees.TakerRate) - buyFees.WithdrawalFee
+	if transferred <= 0 {
+		return o, ErrWithdrawalCost
+	}
+	proceeds := transferred * o.SellPrice * (1 - sellFees.TakerRate)
+	o.Costs = amount*o.SellPrice - proceeds
+	o.Profit = proceeds - cost
+	o.ProfitPercent = o.Profit / cost * 100
+	return o, nil
+}
+
+// Rank sorts opportunities by profit percentage, then profit, best first
+func Rank(opportunities []Opportunity) {
+	sort.SliceStable(opportunities, func(i, j int) bool {
+		a, b := &opportunities[i], &opportunities[j]
+		if a.ProfitPercent != b.ProfitPercent {
+			return a.ProfitPercent > b.ProfitPercent
+		}
+		return a.Profit > b.Profit
+	})
+}
+
+// Run scans the exchanges returned by exchs every interval until stop is
+// closed
+func (s *Scanner) Run(exchs func() []exchange.IBotExchange, interval time.Duration, stop <-chan struct{}) {
+	if interval <= 0 {
+		interval = DefaultScanInterval
+	}
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		s.Scan(exchs())
+		select {
+		case <-stop:
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// quote returns an exchange's stored market for a pair, preferring its
+// orderbook and falling back to the best bid and ask of its ticker. Markets
+// older than MaxAge are not used.
+func (s *Scanner) quote(exchName string, p pair.CurrencyPair) (Quote, error) {
+	cutoff := time.Now().Add(-s.MaxAge)
+	ob, err := orderbook.GetOrderbook(exchName, p, ticker.Spot)
+	if err == nil && ob.LastUpdated.After(cutoff) && len(ob.Bids) > 0 && len(ob.Asks) > 0 {
+		return Quote{
+			Exchange: exchName,
+			Pair:     p,
+			Bids:     ob.Bids,
+			Asks:     ob.Asks,
+			Updated:  ob.LastUpdated,
+		}, nil
+	}
+
+	t, err := ticker.GetTicker(exchName, p, ticker.Spot)
+	if err == nil && t.LastUpdated.After(cutoff) && t.Bid > 0 && t.Ask > 0 {
+		return Quote{
+			Exchange: exchName,
+			Pair:     p,
+			Bids:     []orderbook.Item{{Price: t.Bid}},
+			Asks:     []orderbook.Item{{Price: t.Ask}},
+			Updated:  t.LastUpdated,
+		}, nil
+	}
+	return Quote{}, ErrNoQuote
+}
+
+// depth returns the base currency amount of price levels, zero when a level
+// is of unknown depth
+func depth(levels []orderbook.Item) float64 {
+	var total float64
+	for i := range levels {
+		if levels[i].Amount <= 0 {
+			return 0
+		}
+		total += levels[i].Amount
+	}
+	return total
+}
+
+// fillPrice returns the volume weighted price of filling amount against price
+// levels, a level of unknown depth fills the remainder
+func fillPrice(levels []orderbook.Item, amount float64) float64 {
+	var filled, value float64
+	for i := range levels {
+		if filled >= amount {
+			break
+		}
+		if levels[i].Price <= 0 {
+			continue
+		}
+		fill := amount - filled
+		if levels[i].Amount > 0 && levels[i].Amount < fill {
+			fill = levels[i].Amount
+		}
+		filled += fill
+		value += fill * levels[i].Price
+	}
+	if filled <= 0 {
+		return 0
+	}
+	return value / filled
+}
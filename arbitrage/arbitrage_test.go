@@ -0,0 +1,169 @@
+package arbitrage
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+type testExchange struct {
+	exchange.IBotExchange
+	name     string
+	pairs    []pair.CurrencyPair
+	rate     float64
+	withdraw float64
+	calls    int
+}
+
+func (e *testExchange) GetName() string { return e.name }
+
+func (e *testExchange) IsEnabled() bool { return true }
+
+func (e *testExchange) GetEnabledPairs(assetType string) []pair.CurrencyPair { return e.pairs }
+
+func (e *testExchange) GetFeeByType(f exchange.FeeBuilder) (float64, error) {
+	e.calls++
+	switch f.FeeType {
+	case exchange.CryptocurrencyTradeFee:
+		if e.rate == 0 {
+			return 0, errors.New("fee tier requires authentication")
+		}
+		return e.rate * f.PurchasePrice * f.Amount, nil
+	case exchange.CryptocurrencyWithdrawalFee:
+		return e.withdraw, nil
+	}
+	return 0, errors.New("unsupported fee type")
+}
+
+func fixedFees(rate, withdraw float64) FeeSource {
+	return func(exchName string, p pair.CurrencyPair) (Fees, error) {
+		return Fees{TakerRate: rate, WithdrawalFee: withdraw}, nil
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	s := NewScanner(2, 0, 0, fixedFees(0.001, 0.01))
+	buy := Quote{
+		Exchange: "A",
+		Pair:     pair.NewCurrencyPair("XBT", "USD"),
+		Asks:     []orderbook.Item{{Price: 100, Amount: 1}, {Price: 102, Amount: 5}},
+	}
+	sell := Quote{
+		Exchange: "B",
+		Pair:     pair.NewCurrencyPair("BTC", "USD"),
+		Bids:     []orderbook.Item{{Price: 110}},
+	}
+
+	o, err := s.Evaluate(&buy, &sell)
+	if err != nil {
+		t.Fatal("Test Failed - Evaluate() error", err)
+	}
+	cost := 100.0 + 102
+	proceeds := (2*0.999 - 0.01) * 110 * 0.999
+	if o.Pair.Pair().String() != "BTC-USD" || o.Amount != 2 || o.BuyPrice != 101 ||
+		o.SellPrice != 110 || math.Abs(o.Profit-(proceeds-cost)) > 1e-9 ||
+		math.Abs(o.Costs-(220-proceeds)) > 1e-9 ||
+		math.Abs(o.ProfitPercent-(proceeds-cost)/cost*100) > 1e-9 {
+		t.Errorf("Test Failed - Evaluate() incorrect opportunity %+v", o)
+	}
+
+	// The amount is reduced to the orderbook depth
+	buy.Asks = buy.Asks[:1]
+	o, err = s.Evaluate(&buy, &sell)
+	if err != nil || o.Amount != 1 || o.BuyPrice != 100 {
+		t.Errorf("Test Failed - Evaluate() expected amount reduced to depth %+v %v", o, err)
+	}
+
+	s.Fees = fixedFees(0, 1)
+	if _, err = s.Evaluate(&buy, &sell); err != ErrWithdrawalCost {
+		t.Error("Test Failed - Evaluate() expected withdrawal cost error", err)
+	}
+	sell.Bids = nil
+	if _, err = s.Evaluate(&buy, &sell); err != ErrNoLiquidity {
+		t.Error("Test Failed - Evaluate() expected no liquidity error", err)
+	}
+	s.Fees = nil
+	if _, err = s.Evaluate(&buy, &sell); err != ErrFeesUnavailable {
+		t.Error("Test Failed - Evaluate() expected fees unavailable error", err)
+	}
+}
+
+func TestRank(t *testing.T) {
+	o := []Opportunity{
+		{BuyExchange: "A", ProfitPercent: 1, Profit: 1},
+		{BuyExchange: "B", ProfitPercent: 2, Profit: 1},
+		{BuyExchange: "C", ProfitPercent: 1, Profit: 3},
+	}
+	Rank(o)
+	if o[0].BuyExchange != "B" || o[1].BuyExchange != "C" || o[2].BuyExchange != "A" {
+		t.Error("Test Failed - Rank() incorrect order", o)
+	}
+}
+
+func TestScan(t *testing.T) {
+	btcusd := pair.NewCurrencyPair("BTC", "USD")
+	ethusd := pair.NewCurrencyPair("ETH", "USD")
+	a := &testExchange{name: "ArbitrageA", pairs: []pair.CurrencyPair{btcusd, ethusd}, rate: 0.001, withdraw: 0.001}
+	b := &testExchange{name: "ArbitrageB", pairs: []pair.CurrencyPair{pair.NewCurrencyPair("XBT", "USD"), ethusd}, withdraw: 0.001}
+	c := &testExchange{name: "ArbitrageC", pairs: []pair.CurrencyPair{btcusd}, rate: 0.002, withdraw: 0.001}
+	exchs := []exchange.IBotExchange{a, b, c}
+
+	ticker.ProcessTicker(a.name, btcusd, ticker.Price{Bid: 99, Ask: 100}, ticker.Spot)
+	orderbook.ProcessOrderbook(b.name, b.pairs[0], orderbook.Base{
+		Bids: []orderbook.Item{{Price: 105, Amount: 0.5}},
+		Asks: []orderbook.Item{{Price: 106, Amount: 0.5}},
+	}, ticker.Spot)
+	ticker.ProcessTicker(c.name, btcusd, ticker.Price{Bid: 103, Ask: 104}, ticker.Spot)
+	ticker.ProcessTicker(a.name, ethusd, ticker.Price{Bid: 10, Ask: 10.01}, ticker.Spot)
+	ticker.ProcessTicker(b.name, ethusd, ticker.Price{Bid: 10, Ask: 10.01}, ticker.Spot)
+
+	s := NewScanner(1, 0.5, time.Minute, ExchangeFees(func() []exchange.IBotExchange { return exchs }, 0.0025, 0))
+	result := s.Scan(exchs)
+	if len(result) != 2 {
+		t.Fatalf("Test Failed - Scan() expected 2 opportunities, received %+v", result)
+	}
+	if result[0].BuyExchange != a.name || result[0].SellExchange != b.name ||
+		result[0].Amount != 0.5 || result[0].Pair.Pair().String() != "BTC-USD" ||
+		result[1].BuyExchange != a.name || result[1].SellExchange != c.name ||
+		result[0].ProfitPercent < result[1].ProfitPercent {
+		t.Errorf("Test Failed - Scan() incorrect opportunities %+v", result)
+	}
+
+	select {
+	case published := <-s.Opportunities:
+		if len(published) != 2 {
+			t.Error("Test Failed - Scan() expected opportunities published", published)
+		}
+	default:
+		t.Error("Test Failed - Scan() expected opportunities published")
+	}
+	if len(s.Latest()) != 2 {
+		t.Error("Test Failed - Latest() expected last scan's opportunities")
+	}
+
+	// Fees are cached between scans and the default taker rate applies to
+	// exchanges which cannot estimate it
+	calls := a.calls + b.calls
+	s.Scan(exchs)
+	if a.calls+b.calls != calls {
+		t.Error("Test Failed - ExchangeFees() expected cached estimates")
+	}
+	fees, err := s.Fees(b.name, btcusd)
+	if err != nil || fees.TakerRate != 0.0025 || fees.WithdrawalFee != 0.001 {
+		t.Error("Test Failed - ExchangeFees() expected default taker rate", fees, err)
+	}
+	if _, err = s.Fees("ArbitrageD", btcusd); err != ErrFeesUnavailable {
+		t.Error("Test Failed - ExchangeFees() expected fees unavailable error", err)
+	}
+
+	s.MinProfitPercent = 100
+	if result = s.Scan(exchs); len(result) != 0 {
+		t.Error("Test Failed - Scan() expected no opportunities above minimum profit", result)
+	}
+}
@@ -0,0 +1,85 @@
+package arbitrage
+
+import (
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+)
+
+// DefaultFeeCacheDuration is how long an exchange's estimated fees are reused
+const DefaultFeeCacheDuration = time.Hour
+
+// FeeEstimator is implemented by exchange wrappers which estimate their fees
+type FeeEstimator interface {
+	GetFeeByType(feeBuilder exchange.FeeBuilder) (float64, error)
+}
+
+// cachedFees holds the last estimated fees of an exchange pair
+type cachedFees struct {
+	fees      Fees
+	estimated time.Time
+}
+
+// ExchangeFees returns a FeeSource estimating fees with the GetFeeByType of
+// the exchanges returned by exchs. Exchanges which cannot estimate their
+// trading fee, typically as it depends on the account's tier, are charged
+// defaultTakerRate. Estimates are cached for cacheDuration as they may be
+// requested from the exchange.
+func ExchangeFees(exchs func() []exchange.IBotExchange, defaultTakerRate float64, cacheDuration time.Duration) FeeSource {
+	if cacheDuration <= 0 {
+		cacheDuration = DefaultFeeCacheDuration
+	}
+	var m sync.Mutex
+	cache := make(map[string]cachedFees)
+
+	return func(exchName string, p pair.CurrencyPair) (Fees, error) {
+		key := exchName + " " + p.Pair().String()
+		m.Lock()
+		c, ok := cache[key]
+		m.Unlock()
+		if ok && time.Since(c.estimated) < cacheDuration {
+			return c.fees, nil
+		}
+
+		var estimator FeeEstimator
+		for _, exch := range exchs() {
+			if exch != nil && exch.GetName() == exchName {
+				estimator, _ = exch.(FeeEstimator)
+				break
+			}
+		}
+		if estimator == nil {
+			return Fees{}, ErrFeesUnavailable
+		}
+
+		fees := Fees{TakerRate: defaultTakerRate}
+		rate, err := estimator.GetFeeByType(exchange.FeeBuilder{
+			FeeType:        exchange.CryptocurrencyTradeFee,
+			FirstCurrency:  p.FirstCurrency.String(),
+			SecondCurrency: p.SecondCurrency.String(),
+			Delimiter:      p.Delimiter,
+			PurchasePrice:  1,
+			Amount:         1,
+		})
+		if err == nil && rate > 0 {
+			fees.TakerRate = rate
+		}
+
+		fees.WithdrawalFee, err = estimator.GetFeeByType(exchange.FeeBuilder{
+			FeeType:       exchange.CryptocurrencyWithdrawalFee,
+			FirstCurrency: p.FirstCurrency.String(),
+			PurchasePrice: 1,
+			Amount:        1,
+		})
+		if err != nil {
+			return Fees{}, err
+		}
+
+		m.Lock()
+		cache[key] = cachedFees{fees: fees, estimated: time.Now()}
+		m.Unlock()
+		return fees, nil
+	}
+}
@@ -0,0 +1,316 @@
+// Package availability journals the outcome of exchange health checks,
+// reports each exchange's availability, uptime and incidents over a window and
+// tracks which exchanges are degraded by consecutive failed checks
+package availability
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+)
+
+// JournalFile is the default file name of the journal in the data directory
+const JournalFile = "availability.json"
+
+// ErrInvalidWindow is returned when a report's start is not before its end
+var ErrInvalidWindow = errors.New("report start must be before end")
+
+// Check is the outcome of a single exchange health check
+type Check struct {
+	Exchange string        `json:"exchange"`
+	Time     time.Time     `json:"time"`
+	Up       bool          `json:"up"`
+	Latency  time.Duration `json:"latency"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// Incident is a period an exchange failed its health checks. End is zero
+// while the incident is ongoing, Duration is then measured to the report end.
+type Incident struct {
+	Exchange string        `json:"exchange"`
+	Start    time.Time     `json:"start"`
+	End      time.Time     `json:"end,omitempty"`
+	Duration time.Duration `json:"duration"`
+	Checks   int           `json:"checks"`
+	Error    string        `json:"error"`
+}
+
+// Report is the availability of an exchange over a window. Uptime is the
+// percentage of the monitored time the exchange was up, time before the first
+// check is not monitored.
+type Report struct {
+	Exchange  string        `json:"exchange"`
+	Start     time.Time     `json:"start"`
+	End       time.Time     `json:"end"`
+	Checks    int           `json:"checks"`
+	Monitored time.Duration `json:"monitored"`
+	Downtime  time.Duration `json:"downtime"`
+	Uptime    float64       `json:"uptime_percent"`
+	Incidents []Incident    `json:"incidents"`
+}
+
+// Journal persists health check outcomes to an append only file of JSON
+// lines. Checks older than the retention window are dropped from memory as
+// checks are recorded, keeping each exchange's latest check before the window
+// as its outcome holds until the next, and the file is compacted once the
+// dropped checks make up half of it. A zero retention keeps every check.
+type Journal struct {
+	path      string
+	retention time.Duration
+	checks    map[string][]Check
+	stale     int
+	m         sync.Mutex
+}
+
+// Open loads the journal at path, creating it when it does not exist, and
+// compacts the file when it holds checks older than the retention window
+func Open(path string, retention time.Duration) (*Journal, error) {
+	j := &Journal{path: path, retention: retention, checks: make(map[string][]Check)}
+	f, err := os.OpenFile(path, os.O_RDONLY|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var c Check
+		err = common.JSONDecode(scanner.Bytes(), &c)
+		if err != nil {
+			return nil, err
+		}
+		j.add(c)
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	j.prune(time.Now())
+	if j.stale > 0 {
+		if err = j.compact(); err != nil {
+			return nil, err
+		}
+	}
+	return j, nil
+}
+
+// Record persists the outcome of a health check
+func (j *Journal) Record(c Check) error {
+	data, err := common.JSONEncode(c)
+	if err != nil {
+		return err
+	}
+
+	j.m.Lock()
+	defer j.m.Unlock()
+	f, err := os.OpenFile(j.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = f.Write(append(data, '\n'))
+	if err != nil {
+		return err
+	}
+	j.add(c)
+	j.prune(c.Time)
+	if j.stale > 0 && j.stale >= j.count() {
+		return j.compact()
+	}
+	return nil
+}
+
+// prune drops the checks older than the retention window at now, keeping each
+// exchange's latest check before the window. The mutex must be held by the
+// caller.
+func (j *Journal) prune(now time.Time) {
+	if j.retention <= 0 {
+		return
+	}
+	cutoff := now.Add(-j.retention)
+	for name, checks := range j.checks {
+		i := sort.Search(len(checks), func(i int) bool {
+			return !checks[i].Time.Before(cutoff)
+		})
+		if i <= 1 {
+			continue
+		}
+		j.stale += i - 1
+		j.checks[name] = append([]Check(nil), checks[i-1:]...)
+	}
+}
+
+// count returns the number of checks held. The mutex must be held by the
+// caller.
+func (j *Journal) count() int {
+	var n int
+	for _, checks := range j.checks {
+		n += len(checks)
+	}
+	return n
+}
+
+// compact rewrites the file with the checks held, replacing it once written
+// so an interrupted compaction leaves the previous file intact. The mutex
+// must be held by the caller.
+func (j *Journal) compact() error {
+	tmp := j.path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+
+	w := bufio.NewWriter(f)
+	for _, checks := range j.checks {
+		for i := range checks {
+			data, err := common.JSONEncode(checks[i])
+			if err != nil {
+				f.Close()
+				return err
+			}
+			if _, err = w.Write(append(data, '\n')); err != nil {
+				f.Close()
+				return err
+			}
+		}
+	}
+	if err = w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	if err = os.Rename(tmp, j.path); err != nil {
+		return err
+	}
+	j.stale = 0
+	return nil
+}
+
+// add inserts a check keeping each exchange's checks sorted by time
+func (j *Journal) add(c Check) {
+	checks := j.checks[c.Exchange]
+	i := sort.Search(len(checks), func(i int) bool {
+		return checks[i].Time.After(c.Time)
+	})
+	checks = append(checks, Check{})
+	copy(checks[i+1:], checks[i:])
+	checks[i] = c
+	j.checks[c.Exchange] = checks
+}
+
+// Exchanges returns the names of the exchanges with journaled checks
+func (j *Journal) Exchanges() []string {
+	j.m.Lock()
+	defer j.m.Unlock()
+	names := make([]string, 0, len(j.checks))
+	for name := range j.checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Reports returns the availability of every journaled exchange over a window
+func (j *Journal) Reports(start, end time.Time) ([]Report, error) {
+	var reports []Report
+	for _, name := range j.Exchanges() {
+		r, err := j.Report(name, start, end)
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, r)
+	}
+	return reports, nil
+}
+
+// Report returns the availability of an exchange over a window, each check's
+// outcome holds until the next check
+func (j *Journal) Report(exchangeName string, start, end time.Time) (Report, error) {
+	r := Report{Exchange: exchangeName, Start: start, End: end, Incidents: []Incident{}}
+	if !start.Before(end) {
+		return r, ErrInvalidWindow
+	}
+
+	j.m.Lock()
+	checks := append([]Check(nil), j.checks[exchangeName]...)
+	j.m.Unlock()
+
+	var incident *Incident
+	for i := range checks {
+		if !checks[i].Time.Before(end) {
+			break
+		}
+		from := checks[i].Time
+		to := end
+		if i+1 < len(checks) && checks[i+1].Time.Before(end) {
+			to = checks[i+1].Time
+		}
+		if !to.After(start) {
+			// Checks before the window only matter for an ongoing
+			// incident
+			if checks[i].Up {
+				incident = nil
+				continue
+			}
+			if incident == nil {
+				incident = &Incident{Exchange: exchangeName, Start: from, Error: checks[i].Error}
+			}
+			incident.Checks++
+			continue
+		}
+
+		if !from.Before(start) {
+			r.Checks++
+		} else {
+			from = start
+		}
+		r.Monitored += to.Sub(from)
+
+		if checks[i].Up {
+			if incident != nil {
+				incident.End = checks[i].Time
+				r.addIncident(incident)
+				incident = nil
+			}
+			continue
+		}
+
+		r.Downtime += to.Sub(from)
+		if incident == nil {
+			incident = &Incident{Exchange: exchangeName, Start: checks[i].Time, Error: checks[i].Error}
+		}
+		incident.Checks++
+	}
+	if incident != nil {
+		r.addIncident(incident)
+	}
+
+	if r.Monitored > 0 {
+		r.Uptime = float64(r.Monitored-r.Downtime) / float64(r.Monitored) * 100
+	}
+	return r, nil
+}
+
+// addIncident adds an incident which overlaps the report window
+func (r *Report) addIncident(i *Incident) {
+	to := i.End
+	if to.IsZero() {
+		to = r.End
+	}
+	if !to.After(r.Start) {
+		return
+	}
+	i.Duration = to.Sub(i.Start)
+	r.Incidents = append(r.Incidents, *i)
+}
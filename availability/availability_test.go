@@ -0,0 +1,180 @@
+package availability
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestJournal(t *testing.T) {
+	dir, err := ioutil.TempDir("", "availability")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, JournalFile)
+
+	j, err := Open(path, 0)
+	if err != nil {
+		t.Fatal("Test Failed - Open() error", err)
+	}
+
+	start := time.Date(2018, 9, 1, 0, 0, 0, 0, time.UTC)
+	checks := []Check{
+		{Exchange: "Huobi", Time: start, Up: true},
+		{Exchange: "Huobi", Time: start.Add(time.Hour * 2), Up: false, Error: "timeout"},
+		{Exchange: "Huobi", Time: start.Add(time.Hour * 3), Up: false, Error: "timeout"},
+		{Exchange: "Huobi", Time: start.Add(time.Hour * 4), Up: true},
+		{Exchange: "Huobi", Time: start.Add(time.Hour * 9), Up: false, Error: "502"},
+		// Recorded out of order
+		{Exchange: "Huobi", Time: start.Add(time.Hour), Up: true},
+		{Exchange: "Bithumb", Time: start, Up: true},
+	}
+	for i := range checks {
+		if err = j.Record(checks[i]); err != nil {
+			t.Fatal("Test Failed - Record() error", err)
+		}
+	}
+
+	// Reopening loads the persisted checks
+	j, err = Open(path, 0)
+	if err != nil {
+		t.Fatal("Test Failed - Open() error", err)
+	}
+	if names := j.Exchanges(); len(names) != 2 || names[0] != "Bithumb" {
+		t.Error("Test Failed - Exchanges() incorrect exchanges", names)
+	}
+
+	r, err := j.Report("Huobi", start, start.Add(time.Hour*10))
+	if err != nil {
+		t.Fatal("Test Failed - Report() error", err)
+	}
+	if r.Checks != 6 || r.Monitored != time.Hour*10 || r.Downtime != time.Hour*3 ||
+		r.Uptime != 70 {
+		t.Error("Test Failed - Report() incorrect availability", r)
+	}
+	if len(r.Incidents) != 2 || r.Incidents[0].Duration != time.Hour*2 ||
+		r.Incidents[0].Checks != 2 || r.Incidents[0].Error != "timeout" ||
+		!r.Incidents[1].End.IsZero() || r.Incidents[1].Duration != time.Hour {
+		t.Error("Test Failed - Report() incorrect incidents", r.Incidents)
+	}
+
+	// An incident ongoing at the start of the window is reported from its
+	// first failed check
+	r, err = j.Report("Huobi", start.Add(time.Hour*3), start.Add(time.Hour*5))
+	if err != nil {
+		t.Fatal("Test Failed - Report() error", err)
+	}
+	if r.Downtime != time.Hour || r.Uptime != 50 || len(r.Incidents) != 1 ||
+		!r.Incidents[0].Start.Equal(start.Add(time.Hour*2)) {
+		t.Error("Test Failed - Report() incorrect window", r)
+	}
+
+	if _, err = j.Report("Huobi", start, start); err != ErrInvalidWindow {
+		t.Error("Test Failed - Report() expected invalid window", err)
+	}
+	reports, err := j.Reports(start, start.Add(time.Hour))
+	if err != nil || len(reports) != 2 || reports[0].Uptime != 100 {
+		t.Error("Test Failed - Reports() incorrect reports", reports, err)
+	}
+}
+
+func TestJournalRetention(t *testing.T) {
+	dir, err := ioutil.TempDir("", "availability")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, JournalFile)
+
+	j, err := Open(path, 3*time.Hour)
+	if err != nil {
+		t.Fatal("Test Failed - Open() error", err)
+	}
+	start := time.Date(2018, 9, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 12; i++ {
+		err = j.Record(Check{Exchange: "Huobi", Time: start.Add(time.Duration(i) * time.Hour), Up: i != 9})
+		if err != nil {
+			t.Fatal("Test Failed - Record() error", err)
+		}
+	}
+
+	// The check before the window is kept as it holds into the window
+	r, err := j.Report("Huobi", start, start.Add(12*time.Hour))
+	if err != nil {
+		t.Fatal("Test Failed - Report() error", err)
+	}
+	if r.Checks != 5 || r.Monitored != 5*time.Hour || r.Downtime != time.Hour {
+		t.Error("Test Failed - Record() expected checks outside the retention window pruned", r)
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Count(string(data), "\n"); lines != 7 {
+		t.Error("Test Failed - Record() expected the journal file compacted", lines)
+	}
+
+	// Reopening compacts every check older than the window at open
+	j, err = Open(path, time.Hour)
+	if err != nil {
+		t.Fatal("Test Failed - Open() error", err)
+	}
+	if data, err = ioutil.ReadFile(path); err != nil || strings.Count(string(data), "\n") != 1 {
+		t.Error("Test Failed - Open() expected the journal file compacted", string(data), err)
+	}
+	if names := j.Exchanges(); len(names) != 1 {
+		t.Error("Test Failed - Open() expected the latest check kept", names)
+	}
+}
+
+func TestMonitor(t *testing.T) {
+	var changes []Status
+	m := NewMonitor(0)
+	m.OnChange = func(s Status) {
+		changes = append(changes, s)
+	}
+	if m.FailureThreshold != DefaultFailureThreshold {
+		t.Error("Test Failed - NewMonitor() expected default failure threshold", m.FailureThreshold)
+	}
+
+	start := time.Date(2018, 9, 1, 0, 0, 0, 0, time.UTC)
+	checks := []Check{
+		{Exchange: "Huobi", Time: start, Up: true, Latency: time.Second},
+		{Exchange: "Huobi", Time: start.Add(time.Minute), Error: "timeout"},
+		{Exchange: "Huobi", Time: start.Add(2 * time.Minute), Error: "timeout"},
+		{Exchange: "Huobi", Time: start.Add(3 * time.Minute), Error: "502"},
+		{Exchange: "Huobi", Time: start.Add(4 * time.Minute), Error: "502"},
+	}
+	var s Status
+	for i := range checks {
+		s = m.Update(checks[i])
+	}
+	if !s.Degraded || !s.DegradedSince.Equal(start.Add(3*time.Minute)) || s.ConsecutiveFailures != 4 ||
+		s.Failures != 4 || s.Checks != 5 || s.LastError != "502" {
+		t.Error("Test Failed - Update() expected degraded exchange", s)
+	}
+	if len(changes) != 1 || !changes[0].Degraded {
+		t.Fatal("Test Failed - Update() expected a single degraded change", changes)
+	}
+
+	s = m.Update(Check{Exchange: "Huobi", Time: start.Add(5 * time.Minute), Up: true, Latency: 3 * time.Second})
+	if s.Degraded || s.ConsecutiveFailures != 0 || s.AverageLatency != 2*time.Second || s.LastError != "" {
+		t.Error("Test Failed - Update() expected recovered exchange", s)
+	}
+	if len(changes) != 2 || changes[1].Degraded {
+		t.Error("Test Failed - Update() expected a recovered change", changes)
+	}
+
+	m.Update(Check{Exchange: "Bithumb", Time: start, Up: true})
+	if statuses := m.Statuses(); len(statuses) != 2 || statuses[0].Exchange != "Bithumb" {
+		t.Error("Test Failed - Statuses() unexpected statuses", statuses)
+	}
+	if _, ok := m.Status("Kraken"); ok {
+		t.Error("Test Failed - Status() expected unchecked exchange")
+	}
+}
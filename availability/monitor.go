@@ -0,0 +1,116 @@
+package availability
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultFailureThreshold is the number of consecutive failed health checks
+// after which an exchange is degraded
+const DefaultFailureThreshold = 3
+
+// Status is the current health of an exchange. An exchange is degraded once
+// its consecutive failed checks reach the monitor's failure threshold and
+// recovers with its next successful check. AverageLatency is the mean latency
+// of the successful checks.
+type Status struct {
+	Exchange            string        `json:"exchange"`
+	Degraded            bool          `json:"degraded"`
+	DegradedSince       time.Time     `json:"degraded_since,omitempty"`
+	ConsecutiveFailures int           `json:"consecutive_failures"`
+	Checks              int           `json:"checks"`
+	Failures            int           `json:"failures"`
+	LastCheck           time.Time     `json:"last_check"`
+	LastLatency         time.Duration `json:"last_latency"`
+	AverageLatency      time.Duration `json:"average_latency"`
+	LastError           string        `json:"last_error,omitempty"`
+}
+
+// Monitor tracks the health of exchanges from their health checks, calling
+// OnChange when an exchange becomes degraded or recovers
+type Monitor struct {
+	FailureThreshold int
+	OnChange         func(Status)
+
+	statuses map[string]*Status
+	latency  map[string]time.Duration
+	m        sync.Mutex
+}
+
+// NewMonitor returns a monitor degrading exchanges after failureThreshold
+// consecutive failed checks, DefaultFailureThreshold when not positive
+func NewMonitor(failureThreshold int) *Monitor {
+	if failureThreshold <= 0 {
+		failureThreshold = DefaultFailureThreshold
+	}
+	return &Monitor{
+		FailureThreshold: failureThreshold,
+		statuses:         make(map[string]*Status),
+		latency:          make(map[string]time.Duration),
+	}
+}
+
+// Update applies the outcome of a health check and returns the exchange's
+// status
+func (m *Monitor) Update(c Check) Status {
+	m.m.Lock()
+	s, ok := m.statuses[c.Exchange]
+	if !ok {
+		s = &Status{Exchange: c.Exchange}
+		m.statuses[c.Exchange] = s
+	}
+	wasDegraded := s.Degraded
+
+	s.Checks++
+	s.LastCheck = c.Time
+	s.LastLatency = c.Latency
+	if c.Up {
+		m.latency[c.Exchange] += c.Latency
+		s.AverageLatency = m.latency[c.Exchange] / time.Duration(s.Checks-s.Failures)
+		s.ConsecutiveFailures = 0
+		s.LastError = ""
+		s.Degraded = false
+		s.DegradedSince = time.Time{}
+	} else {
+		s.Failures++
+		s.ConsecutiveFailures++
+		s.LastError = c.Error
+		if !s.Degraded && s.ConsecutiveFailures >= m.FailureThreshold {
+			s.Degraded = true
+			s.DegradedSince = c.Time
+		}
+	}
+	status := *s
+	m.m.Unlock()
+
+	if status.Degraded != wasDegraded && m.OnChange != nil {
+		m.OnChange(status)
+	}
+	return status
+}
+
+// Status returns the status of an exchange and whether it has been checked
+func (m *Monitor) Status(exchangeName string) (Status, bool) {
+	m.m.Lock()
+	defer m.m.Unlock()
+	s, ok := m.statuses[exchangeName]
+	if !ok {
+		return Status{Exchange: exchangeName}, false
+	}
+	return *s, true
+}
+
+// Statuses returns the status of every checked exchange ordered by name
+func (m *Monitor) Statuses() []Status {
+	m.m.Lock()
+	defer m.m.Unlock()
+	statuses := make([]Status, 0, len(m.statuses))
+	for _, s := range m.statuses {
+		statuses = append(statuses, *s)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Exchange < statuses[j].Exchange
+	})
+	return statuses
+}
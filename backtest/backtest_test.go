@@ -0,0 +1,658 @@
+package backtest
+
+import (
+	"archive/zip"
+	"errors"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	"github.com/thrasher-/gocryptotrader/db"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+func TestCalculateStatistics(t *testing.T) {
+	_, err := CalculateStatistics(0, nil)
+	if err != ErrInvalidStartingFunds {
+		t.Error("Test Failed - CalculateStatistics() error", err)
+	}
+
+	trades := []Trade{
+		{ProfitLoss: 100, Fee: 1, EntryMaker: true},
+		{ProfitLoss: -300, Fee: 1},
+		{ProfitLoss: 50, Fee: 1, Rebate: 0.5, EntryMaker: true, ExitMaker: true},
+	}
+	s, err := CalculateStatistics(1000, trades)
+	if err != nil {
+		t.Fatal("Test Failed - CalculateStatistics() error", err)
+	}
+
+	if s.NetProfit != -150 {
+		t.Errorf("Test Failed - CalculateStatistics() net profit %v", s.NetProfit)
+	}
+	if s.TotalTrades != 3 || s.WinningTrades != 2 || s.LosingTrades != 1 {
+		t.Error("Test Failed - CalculateStatistics() trade counts incorrect")
+	}
+	if s.TotalFees != 3 || s.TotalRebates != 0.5 || s.NetFees != 2.5 {
+		t.Error("Test Failed - CalculateStatistics() fees incorrect")
+	}
+	if s.MakerRatio != 0.5 {
+		t.Errorf("Test Failed - CalculateStatistics() maker ratio %v", s.MakerRatio)
+	}
+	// peak 1100 trough 800
+	if s.MaxDrawdown < 0.2727 || s.MaxDrawdown > 0.2728 {
+		t.Errorf("Test Failed - CalculateStatistics() max drawdown %v", s.MaxDrawdown)
+	}
+	if s.ProfitFactor != 0.5 {
+		t.Errorf("Test Failed - CalculateStatistics() profit factor %v", s.ProfitFactor)
+	}
+}
+
+func TestSharpeRatio(t *testing.T) {
+	if SharpeRatio([]float64{0.1}) != 0 {
+		t.Error("Test Failed - SharpeRatio() expected zero for single return")
+	}
+	if SharpeRatio([]float64{0.1, 0.1, 0.1}) != 0 {
+		t.Error("Test Failed - SharpeRatio() expected zero for no deviation")
+	}
+	if SharpeRatio([]float64{0.1, 0.2, 0.3}) <= 0 {
+		t.Error("Test Failed - SharpeRatio() expected positive ratio")
+	}
+}
+
+func TestGenerateGrid(t *testing.T) {
+	_, err := GenerateGrid(nil)
+	if err != ErrNoParameterRanges {
+		t.Error("Test Failed - GenerateGrid() error", err)
+	}
+
+	sets, err := GenerateGrid([]ParameterRange{
+		{Name: "period", Min: 10, Max: 20, Step: 5},
+		{Name: "threshold", Min: 1, Max: 2, Step: 1},
+	})
+	if err != nil {
+		t.Fatal("Test Failed - GenerateGrid() error", err)
+	}
+	if len(sets) != 6 {
+		t.Errorf("Test Failed - GenerateGrid() expected 6 sets received %d", len(sets))
+	}
+
+	_, err = GenerateGrid([]ParameterRange{{Name: "period", Min: 10, Max: 5, Step: 1}})
+	if err == nil {
+		t.Error("Test Failed - GenerateGrid() expected error on invalid range")
+	}
+}
+
+func TestGenerateRandom(t *testing.T) {
+	sets, err := GenerateRandom([]ParameterRange{
+		{Name: "period", Min: 10, Max: 20, Step: 1},
+	}, 25, 1)
+	if err != nil {
+		t.Fatal("Test Failed - GenerateRandom() error", err)
+	}
+	if len(sets) != 25 {
+		t.Errorf("Test Failed - GenerateRandom() expected 25 sets received %d", len(sets))
+	}
+	for i := range sets {
+		if sets[i]["period"] < 10 || sets[i]["period"] > 20 {
+			t.Error("Test Failed - GenerateRandom() value out of range")
+		}
+	}
+}
+
+func TestWalkForwardWindows(t *testing.T) {
+	start := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 100)
+	day := time.Hour * 24
+
+	_, err := WalkForwardWindows(start, end, 0, day, false)
+	if err != ErrInvalidWindow {
+		t.Error("Test Failed - WalkForwardWindows() error", err)
+	}
+
+	windows, err := WalkForwardWindows(start, end, 30*day, 10*day, false)
+	if err != nil {
+		t.Fatal("Test Failed - WalkForwardWindows() error", err)
+	}
+	if len(windows) != 7 {
+		t.Errorf("Test Failed - WalkForwardWindows() expected 7 windows received %d", len(windows))
+	}
+	if !windows[1].InSampleStart.Equal(start.Add(10 * day)) {
+		t.Error("Test Failed - WalkForwardWindows() rolling window start incorrect")
+	}
+
+	windows, err = WalkForwardWindows(start, end, 30*day, 10*day, true)
+	if err != nil {
+		t.Fatal("Test Failed - WalkForwardWindows() error", err)
+	}
+	if !windows[len(windows)-1].InSampleStart.Equal(start) {
+		t.Error("Test Failed - WalkForwardWindows() anchored window start incorrect")
+	}
+}
+
+func TestOptimiserRun(t *testing.T) {
+	start := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 60)
+	day := time.Hour * 24
+
+	// profit peaks when period == 15
+	run := func(p Parameters, s, e time.Time) (Statistics, error) {
+		if p["period"] == 10 {
+			return Statistics{}, errors.New("bad period")
+		}
+		pl := 100 - (p["period"]-15)*(p["period"]-15)
+		return CalculateStatistics(1000, []Trade{{ProfitLoss: pl}})
+	}
+
+	o := Optimiser{
+		Ranges:  []ParameterRange{{Name: "period", Min: 10, Max: 20, Step: 1}},
+		Workers: 4,
+	}
+	report, err := o.Run(start, end, 20*day, 10*day, run)
+	if err != nil {
+		t.Fatal("Test Failed - Optimiser Run() error", err)
+	}
+	if len(report.Windows) != 4 {
+		t.Errorf("Test Failed - Optimiser Run() expected 4 windows received %d", len(report.Windows))
+	}
+	for i := range report.Windows {
+		if report.Windows[i].BestParameters["period"] != 15 {
+			t.Error("Test Failed - Optimiser Run() best parameter incorrect",
+				report.Windows[i].BestParameters)
+		}
+	}
+	if report.Efficiency != 1 {
+		t.Errorf("Test Failed - Optimiser Run() efficiency %v", report.Efficiency)
+	}
+	if report.String() == "" {
+		t.Error("Test Failed - Report String() empty")
+	}
+	if o.Objective != nil {
+		t.Error("Test Failed - Optimiser Run() modified the optimiser's objective")
+	}
+
+	o.SearchMethod = "bad"
+	_, err = o.Run(start, end, 20*day, 10*day, run)
+	if err != ErrInvalidSearchMethod {
+		t.Error("Test Failed - Optimiser Run() error", err)
+	}
+}
+
+func TestMonteCarloRun(t *testing.T) {
+	m := MonteCarlo{StartingFunds: 1000, Iterations: 500, Seed: 1}
+	_, err := m.Run(nil)
+	if err != ErrNoTrades {
+		t.Error("Test Failed - MonteCarlo Run() error", err)
+	}
+
+	trades := []Trade{
+		{ProfitLoss: 100},
+		{ProfitLoss: -50},
+		{ProfitLoss: 75},
+		{ProfitLoss: -120},
+		{ProfitLoss: 60},
+	}
+
+	m.Method = Shuffle
+	result, err := m.Run(trades)
+	if err != nil {
+		t.Fatal("Test Failed - MonteCarlo Run() error", err)
+	}
+	if result.FinalEquity.Min != 1065 || result.FinalEquity.Max != 1065 {
+		t.Error("Test Failed - MonteCarlo Run() shuffle should not alter final equity")
+	}
+	if result.ProbabilityOfLoss != 0 {
+		t.Error("Test Failed - MonteCarlo Run() probability of loss incorrect")
+	}
+
+	m.Method = ""
+	result, err = m.Run(trades)
+	if err != nil {
+		t.Fatal("Test Failed - MonteCarlo Run() error", err)
+	}
+	if result.Method != Bootstrap || result.Iterations != 500 {
+		t.Error("Test Failed - MonteCarlo Run() incorrect defaults")
+	}
+	low, high := result.FinalEquity.ConfidenceInterval(95)
+	if low > high || low < result.FinalEquity.Min || high > result.FinalEquity.Max {
+		t.Error("Test Failed - MonteCarlo Run() confidence interval incorrect")
+	}
+	if p := result.ProbabilityOfDrawdown(0); p != 1 {
+		t.Errorf("Test Failed - ProbabilityOfDrawdown() expected 1 received %v", p)
+	}
+	if result.String() == "" {
+		t.Error("Test Failed - MonteCarloResult String() empty")
+	}
+
+	m.Method = "bad"
+	_, err = m.Run(trades)
+	if err == nil {
+		t.Error("Test Failed - MonteCarlo Run() expected error on invalid method")
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	d := newDistribution([]float64{5, 1, 3, 2, 4})
+	if d.Percentile(50) != 3 {
+		t.Error("Test Failed - Percentile() median incorrect")
+	}
+	if d.Percentile(25) != 2 {
+		t.Error("Test Failed - Percentile() lower quartile incorrect")
+	}
+	if d.Percentile(0) != 1 || d.Percentile(100) != 5 {
+		t.Error("Test Failed - Percentile() bounds incorrect")
+	}
+	if d.Mean != 3 {
+		t.Error("Test Failed - newDistribution() mean incorrect")
+	}
+}
+
+// testMomentum buys after a rising candle and sells after a falling candle
+type testMomentum struct {
+	prev float64
+}
+
+func (s *testMomentum) OnCandle(c Candle, p Position) (Signal, error) {
+	defer func() { s.prev = c.Close }()
+	switch {
+	case s.prev == 0:
+		return Signal{}, nil
+	case c.Close > s.prev && p.Amount == 0:
+		return Signal{Side: exchange.Buy, Amount: 1}, nil
+	case c.Close < s.prev && p.Amount > 0:
+		return Signal{Side: exchange.Sell}, nil
+	}
+	return Signal{}, nil
+}
+
+var testCloses = []float64{100, 105, 110, 104, 102, 108, 120, 115}
+
+func testCandles() []Candle {
+	start := time.Date(2018, 9, 1, 0, 0, 0, 0, time.UTC)
+	var candles []Candle
+	for i, c := range testCloses {
+		candles = append(candles, Candle{Time: start.Add(time.Duration(i) * time.Hour), Close: c})
+	}
+	return candles
+}
+
+func TestRun(t *testing.T) {
+	if _, _, err := Run(&testMomentum{}, &Simulator{}, 1000, nil); err != ErrNoCandles {
+		t.Error("Test Failed - Run() expected no candles error", err)
+	}
+	if _, _, err := Run(nil, &Simulator{}, 1000, testCandles()); err != ErrNoStrategy {
+		t.Error("Test Failed - Run() expected no strategy error", err)
+	}
+
+	s, trades, err := Run(&testMomentum{}, &Simulator{FeeRate: 0.001}, 1000, testCandles())
+	if err != nil {
+		t.Fatal("Test Failed - Run() error", err)
+	}
+	// buy 105 sell 104, buy 108 sell 115
+	if len(trades) != 2 || trades[0].EntryPrice != 105 || trades[0].ExitPrice != 104 ||
+		trades[1].EntryPrice != 108 || trades[1].ExitPrice != 115 {
+		t.Fatal("Test Failed - Run() incorrect trades", trades)
+	}
+	if math.Abs(trades[0].Fee-0.209) > 1e-9 || math.Abs(s.NetProfit-(6-0.209-0.223)) > 1e-9 {
+		t.Errorf("Test Failed - Run() incorrect net profit %v", s.NetProfit)
+	}
+
+	candles := testCandles()
+	candles[3].Time = candles[2].Time
+	if _, _, err = Run(&testMomentum{}, &Simulator{}, 1000, candles); err != ErrCandleOutOfOrder {
+		t.Error("Test Failed - Run() expected candle out of order error", err)
+	}
+}
+
+// testAllIn buys with all available funds on the first candle
+type testAllIn struct{}
+
+func (s testAllIn) OnCandle(c Candle, p Position) (Signal, error) {
+	if p.Amount > 0 {
+		return Signal{}, nil
+	}
+	return Signal{Side: exchange.Buy}, nil
+}
+
+func TestRunnerFullAllocation(t *testing.T) {
+	simulators := []*Simulator{
+		{FeeRate: 0.001, Slippage: 0.002},
+		{
+			SlippageModel: VolumeSlippage{Base: 0.001, Impact: 0.1},
+			FeeModel:      PercentageFee{Rate: 0.001, Minimum: 0.5},
+		},
+	}
+	for i := range simulators {
+		r, err := NewRunner(testAllIn{}, simulators[i], 1000)
+		if err != nil {
+			t.Fatal("Test Failed - NewRunner() error", err)
+		}
+		err = r.OnCandle(Candle{Close: 100, High: 110, Low: 90, Volume: 50})
+		if err != nil {
+			t.Fatal("Test Failed - OnCandle() error", err)
+		}
+		p := r.Position()
+		if p.Funds < 0 || p.Funds > 1 || p.Amount <= 0 || p.Amount >= 10 {
+			t.Errorf("Test Failed - OnCandle() %d expected all funds allocated without overspending %+v", i, p)
+		}
+	}
+
+	r, _ := NewRunner(testAllIn{}, &Simulator{FeeRate: 0.001, Slippage: 0.002}, 1000)
+	if err := r.OnCandle(Candle{Close: 100}); err != nil {
+		t.Fatal("Test Failed - OnCandle() error", err)
+	}
+	expected := 1000 / (100 * 1.002 * 1.001)
+	if p := r.Position(); math.Abs(p.Amount-expected) > 1e-9 {
+		t.Errorf("Test Failed - OnCandle() expected amount %v, received %v", expected, p.Amount)
+	}
+}
+
+func TestSimulatorModels(t *testing.T) {
+	c := Candle{Close: 100, High: 101, Low: 98, Volume: 10}
+	s := &Simulator{
+		SlippageModel: VolumeSlippage{Base: 0.001, Impact: 0.1},
+		FeeModel:      PercentageFee{Rate: 0.001, Minimum: 0.5},
+	}
+	e, err := s.Execute(exchange.Buy, 0.5, c)
+	if err != nil || math.Abs(e.Price-100.6) > 1e-9 || e.Fee != 0.5 {
+		t.Error("Test Failed - Execute() incorrect buy fill", e, err)
+	}
+	e, _ = s.Execute(exchange.Buy, 2, c)
+	if e.Price != 101 {
+		t.Error("Test Failed - Execute() buy slippage not capped at high", e.Price)
+	}
+	e, _ = s.Execute(exchange.Sell, 50, c)
+	if e.Price != 98 {
+		t.Error("Test Failed - Execute() sell slippage not capped at low", e.Price)
+	}
+	e, _ = (&Simulator{Slippage: 0.01}).Execute(exchange.Sell, 1, c)
+	if e.Price != 99 || e.Fee != 0 {
+		t.Error("Test Failed - Execute() incorrect fixed slippage", e.Price, e.Fee)
+	}
+}
+
+func TestAggregateTicks(t *testing.T) {
+	start := time.Date(2018, 9, 1, 0, 0, 0, 0, time.UTC)
+	candles := aggregateTicks([]tick{
+		{start.Add(time.Minute), 100, 1},
+		{start.Add(20 * time.Minute), 104, 2},
+		{start.Add(40 * time.Minute), 99, 1},
+		{start.Add(2*time.Hour + time.Minute), 101, 3},
+	}, time.Hour)
+	if len(candles) != 2 {
+		t.Fatal("Test Failed - aggregateTicks() incorrect candles", candles)
+	}
+	expected := Candle{Time: start, Open: 100, High: 104, Low: 99, Close: 99, Volume: 4}
+	if candles[0] != expected || !candles[1].Time.Equal(start.Add(2*time.Hour)) {
+		t.Error("Test Failed - aggregateTicks() incorrect candles", candles)
+	}
+}
+
+func TestDatabaseCandleSource(t *testing.T) {
+	d, err := db.Open(db.Config{Driver: db.DriverSQLite, Database: ":memory:"})
+	if err != nil {
+		t.Skip("sqlite unavailable", err)
+	}
+	defer d.Close()
+
+	p := pair.NewCurrencyPair("BTC", "USD")
+	start := time.Date(2018, 9, 1, 0, 0, 0, 0, time.UTC)
+	source := DatabaseCandleSource{DB: d}
+	if _, err = source.LoadCandles("Bitstamp", p, 0, start, time.Time{}); err != ErrInvalidInterval {
+		t.Error("Test Failed - LoadCandles() expected invalid interval error", err)
+	}
+
+	for i, last := range []float64{100, 102, 101} {
+		err = d.InsertTicker(db.Ticker{Exchange: "Bitstamp", Pair: "BTCUSD", Last: last,
+			Timestamp: start.Add(time.Duration(i) * 30 * time.Minute)})
+		if err != nil {
+			t.Fatal("Test Failed - InsertTicker() error", err)
+		}
+	}
+	candles, err := source.LoadCandles("Bitstamp", p, time.Hour, start, time.Time{})
+	if err != nil || len(candles) != 2 || candles[0].High != 102 || candles[1].Close != 101 {
+		t.Error("Test Failed - LoadCandles() incorrect ticker candles", candles, err)
+	}
+
+	err = d.InsertTrade(db.Trade{Exchange: "Bitstamp", Pair: "BTCUSD", Price: 105,
+		Amount: 2, Timestamp: start})
+	if err != nil {
+		t.Fatal("Test Failed - InsertTrade() error", err)
+	}
+	candles, err = source.LoadCandles("Bitstamp", p, time.Hour, start, time.Time{})
+	if err != nil || len(candles) != 1 || candles[0].Close != 105 || candles[0].Volume != 2 {
+		t.Error("Test Failed - LoadCandles() incorrect trade candles", candles, err)
+	}
+}
+
+// testTickerExchange returns a fixed live price
+type testTickerExchange struct {
+	exchange.IBotExchange
+	price float64
+}
+
+func (e *testTickerExchange) GetName() string {
+	return "TickerExchange"
+}
+
+func (e *testTickerExchange) GetTickerPrice(p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	return ticker.Price{Bid: e.price, Ask: e.price, Last: e.price}, nil
+}
+
+func TestForwardTest(t *testing.T) {
+	exch := &testTickerExchange{}
+	p := pair.NewCurrencyPair("BTC", "USD")
+	if _, err := NewForwardTest(exch, p, &testMomentum{}, 0, 1000, 0); err != ErrInvalidInterval {
+		t.Error("Test Failed - NewForwardTest() expected invalid interval error", err)
+	}
+	f, err := NewForwardTest(exch, p, &testMomentum{}, time.Hour, 1000, 0.001)
+	if err != nil {
+		t.Fatal("Test Failed - NewForwardTest() error", err)
+	}
+
+	// Each hour receives two prices ending at the candle close, fills happen
+	// at the live price when the next hour's first price closes the candle
+	for _, c := range testCandles() {
+		if err = f.OnPrice(c.Close-1, c.Time.Add(time.Minute)); err != nil {
+			t.Fatal("Test Failed - OnPrice() error", err)
+		}
+		if err = f.OnPrice(c.Close, c.Time.Add(30*time.Minute)); err != nil {
+			t.Fatal("Test Failed - OnPrice() error", err)
+		}
+		exch.price = c.Close
+	}
+	if err = f.OnPrice(1, testCandles()[0].Time); err != ErrCandleOutOfOrder {
+		t.Error("Test Failed - OnPrice() expected candle out of order error", err)
+	}
+	// Close the final candle
+	if err = f.OnPrice(115, testCandles()[len(testCloses)-1].Time.Add(time.Hour)); err != nil {
+		t.Fatal("Test Failed - OnPrice() error", err)
+	}
+
+	forward, err := f.Statistics()
+	if err != nil {
+		t.Fatal("Test Failed - Statistics() error", err)
+	}
+	backtest, _, err := Run(&testMomentum{}, &Simulator{FeeRate: 0.001}, 1000, testCandles())
+	if err != nil {
+		t.Fatal("Test Failed - Run() error", err)
+	}
+	if forward.TotalTrades != backtest.TotalTrades ||
+		math.Abs(forward.NetProfit-backtest.NetProfit) > 1e-9 {
+		t.Errorf("Test Failed - ForwardTest statistics differ from backtest %v %v",
+			forward.NetProfit, backtest.NetProfit)
+	}
+
+	if err = f.Stop(); err != ErrForwardTestNotRunning {
+		t.Error("Test Failed - Stop() expected not running error", err)
+	}
+	f.PollInterval = time.Millisecond
+	if err = f.Start(); err != nil {
+		t.Fatal("Test Failed - Start() error", err)
+	}
+	if err = f.Start(); err != ErrForwardTestRunning {
+		t.Error("Test Failed - Start() expected already running error", err)
+	}
+	time.Sleep(5 * time.Millisecond)
+	if err = f.Stop(); err != nil {
+		t.Error("Test Failed - Stop() error", err)
+	}
+}
+
+const testKaggleCSV = `Timestamp,Open,High,Low,Close,Volume_(BTC),Volume_(Currency),Weighted_Price
+1535760000,7000,7100,6950,7050,10,70500,7050
+1535763600,NaN,NaN,NaN,NaN,NaN,NaN,NaN
+1535767200,7050,7080,6900,6950,5,34750,6950
+`
+
+const testBinanceCSV = `open_time,open,high,low,close,volume,close_time,quote_volume,count,taker_buy_volume,taker_buy_quote_volume,ignore
+1535760000000000,7000,7100,6950,7050,10,1535763599999999,70500,100,5,35250,0
+1535763600000000,7050,7060,7000,7010,2,1535767199999999,14020,20,1,7010,0
+1535763600000000,7050,7060,7000,7020,3,1535767199999999,21060,30,1,7020,0
+1535770800000,7020,7030,6990,7000,1,1535774399999,7000,10,1,7000,0
+`
+
+func TestParseCSV(t *testing.T) {
+	candles, invalid, err := ParseCSV(strings.NewReader(testKaggleCSV), GenericCSVFormat)
+	if err != nil {
+		t.Fatal("Test Failed - ParseCSV() error", err)
+	}
+	if len(candles) != 2 || invalid != 1 || candles[1].Close != 6950 || candles[0].Volume != 10 ||
+		!candles[1].Time.Equal(time.Unix(1535767200, 0)) {
+		t.Error("Test Failed - ParseCSV() incorrect generic candles", candles, invalid)
+	}
+
+	candles, invalid, err = ParseCSV(strings.NewReader(testBinanceCSV), BinanceKlineFormat)
+	if err != nil {
+		t.Fatal("Test Failed - ParseCSV() error", err)
+	}
+	if len(candles) != 4 || invalid != 0 || !candles[0].Time.Equal(time.Unix(1535760000, 0)) ||
+		!candles[3].Time.Equal(time.Unix(1535770800, 0)) {
+		t.Error("Test Failed - ParseCSV() incorrect Binance candles", candles)
+	}
+
+	_, _, err = ParseCSV(strings.NewReader("time,open,high,low\n1,1,1,1\n"), GenericCSVFormat)
+	if err == nil {
+		t.Error("Test Failed - ParseCSV() expected missing column error")
+	}
+}
+
+func TestValidateCandles(t *testing.T) {
+	start := time.Unix(1535760000, 0)
+	candles := []Candle{
+		{Time: start.Add(3 * time.Hour), Open: 1, High: 2, Low: 1, Close: 2},
+		{Time: start, Open: 1, High: 2, Low: 1, Close: 2},
+		{Time: start, Open: 1, High: 3, Low: 1, Close: 3},
+		{Time: start.Add(time.Hour), Open: 1, High: 0.5, Low: 1, Close: 2},
+	}
+	valid, report := ValidateCandles(candles, time.Hour)
+	if len(valid) != 2 || valid[0].Close != 3 || report.Candles != 2 ||
+		report.Invalid != 1 || report.Duplicates != 1 || report.Gaps != 2 {
+		t.Error("Test Failed - ValidateCandles() incorrect report", report)
+	}
+}
+
+func TestMapSymbol(t *testing.T) {
+	tests := map[string]string{
+		"BTCUSDT": "BTCUSDT",
+		"ethbtc":  "ETHBTC",
+		"XBT-EUR": "XBTEUR",
+		"ltc_usd": "LTCUSD",
+	}
+	for symbol, expected := range tests {
+		p, err := MapSymbol(symbol, nil)
+		if err != nil || p.Pair().String() != expected {
+			t.Errorf("Test Failed - MapSymbol(%s) got %s %v", symbol, p.Pair(), err)
+		}
+	}
+	p, err := MapSymbol("BTCUSDT", map[string]pair.CurrencyPair{"BTCUSDT": pair.NewCurrencyPair("XBT", "USDT")})
+	if err != nil || p.FirstCurrency != "XBT" {
+		t.Error("Test Failed - MapSymbol() expected explicit mapping", p, err)
+	}
+	if _, err = MapSymbol("ABCXYZ", nil); err == nil {
+		t.Error("Test Failed - MapSymbol() expected unknown symbol error")
+	}
+}
+
+func TestParseBinanceDumpName(t *testing.T) {
+	symbol, interval, err := ParseBinanceDumpName("/data/BTCUSDT-1h-2018-09.zip")
+	if err != nil || symbol != "BTCUSDT" || interval != time.Hour {
+		t.Error("Test Failed - ParseBinanceDumpName() incorrect result", symbol, interval, err)
+	}
+	if _, _, err = ParseBinanceDumpName("BTCUSDT-1mo-2018-09.zip"); err == nil {
+		t.Error("Test Failed - ParseBinanceDumpName() expected unsupported interval error")
+	}
+	if _, _, err = ParseBinanceDumpName("BTCUSDT.csv"); err == nil {
+		t.Error("Test Failed - ParseBinanceDumpName() expected invalid name error")
+	}
+}
+
+func TestImporter(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gct-import")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	store, err := NewFileCandleStore(filepath.Join(dir, "candles"))
+	if err != nil {
+		t.Fatal("Test Failed - NewFileCandleStore() error", err)
+	}
+	i := Importer{Store: store}
+
+	dump := filepath.Join(dir, "BTCUSDT-1h-2018-09.zip")
+	file, err := os.Create(dump)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zw := zip.NewWriter(file)
+	w, err := zw.Create("BTCUSDT-1h-2018-09.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = w.Write([]byte(testBinanceCSV)); err != nil {
+		t.Fatal(err)
+	}
+	if err = zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	file.Close()
+
+	r, err := i.ImportBinanceDump(dump)
+	if err != nil {
+		t.Fatal("Test Failed - ImportBinanceDump() error", err)
+	}
+	if r.Exchange != "Binance" || r.Pair.Pair().String() != "BTCUSDT" || r.Candles != 3 ||
+		r.Duplicates != 1 || r.Gaps != 1 {
+		t.Error("Test Failed - ImportBinanceDump() incorrect result", r)
+	}
+
+	r, err = i.Import(strings.NewReader(testKaggleCSV), GenericCSVFormat, "Binance", "BTC-USDT", time.Hour)
+	if err != nil || r.Invalid != 1 {
+		t.Fatal("Test Failed - Import() error", err, r)
+	}
+
+	p := pair.NewCurrencyPair("BTC", "USDT")
+	candles, err := store.LoadCandles("Binance", p, time.Hour, time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatal("Test Failed - LoadCandles() error", err)
+	}
+	// The generic import replaces the first hour and adds 02:00
+	if len(candles) != 4 || candles[0].Close != 7050 || candles[1].Close != 7020 || candles[2].Close != 6950 {
+		t.Error("Test Failed - LoadCandles() incorrect merged candles", candles)
+	}
+	candles, err = store.LoadCandles("Binance", p, time.Hour, time.Unix(1535763600, 0), time.Unix(1535767200, 0))
+	if err != nil || len(candles) != 2 {
+		t.Error("Test Failed - LoadCandles() incorrect range", candles, err)
+	}
+
+	if _, err = (&Importer{}).Import(strings.NewReader(testKaggleCSV), GenericCSVFormat, "Binance", "BTCUSDT", time.Hour); err != ErrNoCandleStore {
+		t.Error("Test Failed - Import() expected no candle store error", err)
+	}
+}
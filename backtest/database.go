@@ -0,0 +1,84 @@
+package backtest
+
+import (
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	"github.com/thrasher-/gocryptotrader/db"
+)
+
+// DatabaseCandleSource builds candles from the trades and ticker snapshots
+// persisted by the bot
+type DatabaseCandleSource struct {
+	DB db.Storage
+}
+
+// LoadCandles returns the candles of an exchange pair from start until end.
+// Candles are built from the stored trades, or from the last price of the
+// stored ticker snapshots when no trades were stored for the period.
+func (d *DatabaseCandleSource) LoadCandles(exchName string, p pair.CurrencyPair, interval time.Duration, start, end time.Time) ([]Candle, error) {
+	if interval <= 0 {
+		return nil, ErrInvalidInterval
+	}
+	trades, err := d.DB.Trades(exchName, p.Pair().String(), start, end)
+	if err != nil {
+		return nil, err
+	}
+	if len(trades) > 0 {
+		ticks := make([]tick, len(trades))
+		for i := range trades {
+			ticks[i] = tick{trades[i].Timestamp, trades[i].Price, trades[i].Amount}
+		}
+		return aggregateTicks(ticks, interval), nil
+	}
+
+	tickers, err := d.DB.Tickers(exchName, p.Pair().String(), start, end)
+	if err != nil {
+		return nil, err
+	}
+	ticks := make([]tick, 0, len(tickers))
+	for i := range tickers {
+		if tickers[i].Last <= 0 {
+			continue
+		}
+		ticks = append(ticks, tick{tickers[i].Timestamp, tickers[i].Last, 0})
+	}
+	return aggregateTicks(ticks, interval), nil
+}
+
+// tick is a price observed at a time with the volume traded at it
+type tick struct {
+	time   time.Time
+	price  float64
+	volume float64
+}
+
+// aggregateTicks builds candles of interval from ticks in time order.
+// Intervals without ticks are skipped.
+func aggregateTicks(ticks []tick, interval time.Duration) []Candle {
+	var candles []Candle
+	for _, t := range ticks {
+		start := t.time.Truncate(interval)
+		if n := len(candles); n > 0 && candles[n-1].Time.Equal(start) {
+			c := &candles[n-1]
+			if t.price > c.High {
+				c.High = t.price
+			}
+			if t.price < c.Low {
+				c.Low = t.price
+			}
+			c.Close = t.price
+			c.Volume += t.volume
+			continue
+		}
+		candles = append(candles, Candle{
+			Time:   start,
+			Open:   t.price,
+			High:   t.price,
+			Low:    t.price,
+			Close:  t.price,
+			Volume: t.volume,
+		})
+	}
+	return candles
+}
@@ -0,0 +1,170 @@
+package backtest
+
+import (
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+// DefaultPollInterval is how often a forward test polls the live ticker
+const DefaultPollInterval = 10 * time.Second
+
+// Errors returned by forward tests
+var (
+	ErrInvalidInterval       = errors.New("candle interval must be greater than zero")
+	ErrForwardTestRunning    = errors.New("forward test is already running")
+	ErrForwardTestNotRunning = errors.New("forward test is not running")
+)
+
+// PaperExecutor fills orders at the live price of an exchange pair, the ask
+// for buys and the bid for sells, without placing an order on the exchange.
+// FeeRate of the notional is charged as the taker fee.
+type PaperExecutor struct {
+	Exchange exchange.IBotExchange
+	Pair     pair.CurrencyPair
+	FeeRate  float64
+}
+
+// Execute fills an order at the live price
+func (p *PaperExecutor) Execute(side exchange.OrderSide, amount float64, c Candle) (Execution, error) {
+	price, err := exchange.MarketPrice(p.Exchange, p.Pair, side)
+	if err != nil {
+		return Execution{}, err
+	}
+	return Execution{
+		Time:   time.Now(),
+		Price:  price,
+		Amount: amount,
+		Fee:    price * amount * p.FeeRate,
+	}, nil
+}
+
+// ForwardTest runs a backtest strategy against live prices in paper trading
+// mode. Live prices are aggregated into candles of Interval which are passed
+// to the same Runner used by Run, so statistics are directly comparable with
+// the strategy's backtests.
+type ForwardTest struct {
+	*Runner
+	Exchange     exchange.IBotExchange
+	Pair         pair.CurrencyPair
+	AssetType    string
+	Interval     time.Duration
+	PollInterval time.Duration
+
+	candle   *Candle
+	shutdown chan struct{}
+	wg       sync.WaitGroup
+	m        sync.Mutex
+}
+
+// NewForwardTest returns a forward test of the strategy on an exchange pair
+// filled by a PaperExecutor
+func NewForwardTest(exch exchange.IBotExchange, p pair.CurrencyPair, s Strategy, interval time.Duration, startingFunds, feeRate float64) (*ForwardTest, error) {
+	if interval <= 0 {
+		return nil, ErrInvalidInterval
+	}
+	r, err := NewRunner(s, &PaperExecutor{Exchange: exch, Pair: p, FeeRate: feeRate}, startingFunds)
+	if err != nil {
+		return nil, err
+	}
+	return &ForwardTest{
+		Runner:       r,
+		Exchange:     exch,
+		Pair:         p,
+		AssetType:    ticker.Spot,
+		Interval:     interval,
+		PollInterval: DefaultPollInterval,
+	}, nil
+}
+
+// OnPrice adds a live price at t to the current candle. When t falls in a
+// later interval the current candle is closed and passed to the strategy.
+func (f *ForwardTest) OnPrice(price float64, t time.Time) error {
+	if price <= 0 {
+		return nil
+	}
+	start := t.Truncate(f.Interval)
+
+	f.m.Lock()
+	var closed *Candle
+	switch {
+	case f.candle == nil:
+	case start.After(f.candle.Time):
+		closed = f.candle
+	case start.Before(f.candle.Time):
+		f.m.Unlock()
+		return ErrCandleOutOfOrder
+	default:
+		if price > f.candle.High {
+			f.candle.High = price
+		}
+		if price < f.candle.Low {
+			f.candle.Low = price
+		}
+		f.candle.Close = price
+		f.m.Unlock()
+		return nil
+	}
+	f.candle = &Candle{Time: start, Open: price, High: price, Low: price, Close: price}
+	f.m.Unlock()
+
+	if closed != nil {
+		return f.Runner.OnCandle(*closed)
+	}
+	return nil
+}
+
+// Start polls the live ticker every PollInterval until Stop is called
+func (f *ForwardTest) Start() error {
+	f.m.Lock()
+	defer f.m.Unlock()
+	if f.shutdown != nil {
+		return ErrForwardTestRunning
+	}
+	f.shutdown = make(chan struct{})
+	f.wg.Add(1)
+	go f.poll(f.shutdown)
+	return nil
+}
+
+// Stop stops polling the live ticker, the open candle is discarded
+func (f *ForwardTest) Stop() error {
+	f.m.Lock()
+	if f.shutdown == nil {
+		f.m.Unlock()
+		return ErrForwardTestNotRunning
+	}
+	close(f.shutdown)
+	f.shutdown = nil
+	f.m.Unlock()
+	f.wg.Wait()
+	return nil
+}
+
+// poll fetches the live ticker until shutdown is closed
+func (f *ForwardTest) poll(shutdown chan struct{}) {
+	defer f.wg.Done()
+	tick := time.NewTicker(f.PollInterval)
+	defer tick.Stop()
+	for {
+		select {
+		case <-shutdown:
+			return
+		case <-tick.C:
+			t, err := f.Exchange.GetTickerPrice(f.Pair, f.AssetType)
+			if err != nil {
+				log.Printf("%s forward test failed to get %s ticker. Err: %s",
+					f.Exchange.GetName(), f.Pair.Pair(), err)
+				continue
+			}
+			if err = f.OnPrice(t.Last, time.Now()); err != nil {
+				log.Printf("%s forward test strategy error: %s", f.Exchange.GetName(), err)
+			}
+		}
+	}
+}
@@ -0,0 +1,327 @@
+package backtest
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+)
+
+// Errors returned when importing historical data
+var (
+	ErrNoCandleStore   = errors.New("no candle store supplied")
+	ErrMissingColumn   = errors.New("required OHLCV column not found")
+	ErrUnknownSymbol   = errors.New("unable to map symbol to a currency pair")
+	ErrInvalidDumpName = errors.New("invalid Binance dump file name")
+	ErrNoCSVInArchive  = errors.New("archive does not contain a CSV file")
+)
+
+// DefaultQuoteCurrencies are the quote currencies tried, in order, when a
+// symbol without a delimiter is split into a currency pair
+var DefaultQuoteCurrencies = []string{"USDT", "BUSD", "USDC", "TUSD", "USD", "EUR", "GBP", "BTC", "ETH", "BNB"}
+
+// CSVFormat describes the columns of an OHLCV CSV file. Column indexes below
+// zero are detected from the header row by name. Without a header a first row
+// whose time does not parse is skipped as a header. Times are unix timestamps
+// in any precision or date strings in Location, UTC when nil.
+type CSVFormat struct {
+	Comma    rune
+	Header   bool
+	Time     int
+	Open     int
+	High     int
+	Low      int
+	Close    int
+	Volume   int
+	Location *time.Location
+}
+
+// BinanceKlineFormat is the column layout of Binance public kline dumps
+var BinanceKlineFormat = CSVFormat{Time: 0, Open: 1, High: 2, Low: 3, Close: 4, Volume: 5}
+
+// GenericCSVFormat detects the OHLCV columns from a header row, matching the
+// column layouts of most published datasets
+var GenericCSVFormat = CSVFormat{Header: true, Time: -1, Open: -1, High: -1, Low: -1, Close: -1, Volume: -1}
+
+// columnNames holds the header names recognised for each column
+var columnNames = map[string][]string{
+	"time":  {"time", "timestamp", "date", "datetime", "open time", "open_time", "unix"},
+	"open":  {"open"},
+	"high":  {"high"},
+	"low":   {"low"},
+	"close": {"close"},
+}
+
+// detectColumns sets the column indexes below zero from a header row
+func (f *CSVFormat) detectColumns(header []string) error {
+	find := func(column string) int {
+		for i := range header {
+			name := strings.ToLower(strings.TrimSpace(header[i]))
+			if column == "volume" && strings.HasPrefix(name, "volume") {
+				return i
+			}
+			if common.StringDataCompare(columnNames[column], name) {
+				return i
+			}
+		}
+		return -1
+	}
+	for column, index := range map[string]*int{
+		"time":   &f.Time,
+		"open":   &f.Open,
+		"high":   &f.High,
+		"low":    &f.Low,
+		"close":  &f.Close,
+		"volume": &f.Volume,
+	} {
+		if *index >= 0 {
+			continue
+		}
+		if *index = find(column); *index < 0 {
+			return fmt.Errorf("%s %s", column, ErrMissingColumn)
+		}
+	}
+	return nil
+}
+
+// ParseCSV reads OHLCV candles in the format. Rows which fail to parse are
+// skipped and counted as invalid.
+func ParseCSV(r io.Reader, format CSVFormat) ([]Candle, int, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	if format.Comma != 0 {
+		reader.Comma = format.Comma
+	}
+
+	var candles []Candle
+	var invalid int
+	for row := 0; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return candles, invalid, nil
+		}
+		if err != nil {
+			return nil, invalid, err
+		}
+		if row == 0 && format.Header {
+			if err = format.detectColumns(record); err != nil {
+				return nil, invalid, err
+			}
+			continue
+		}
+
+		c, err := format.parse(record)
+		if err != nil {
+			if row == 0 {
+				// Headerless formats may still include a header row
+				continue
+			}
+			invalid++
+			continue
+		}
+		candles = append(candles, c)
+	}
+}
+
+// parse converts a record to a candle
+func (f *CSVFormat) parse(record []string) (Candle, error) {
+	var c Candle
+	columns := []int{f.Time, f.Open, f.High, f.Low, f.Close, f.Volume}
+	for _, i := range columns {
+		if i < 0 || i >= len(record) {
+			return c, ErrMissingColumn
+		}
+	}
+
+	var err error
+	if c.Time, err = common.ParseTimestamp(record[f.Time], f.Location); err != nil {
+		return c, err
+	}
+	for i, v := range []*float64{&c.Open, &c.High, &c.Low, &c.Close, &c.Volume} {
+		if *v, err = strconv.ParseFloat(strings.TrimSpace(record[columns[i+1]]), 64); err != nil {
+			return c, err
+		}
+		if math.IsNaN(*v) || math.IsInf(*v, 0) {
+			return c, fmt.Errorf("invalid value %s", record[columns[i+1]])
+		}
+	}
+	return c, nil
+}
+
+// ValidationReport holds the result of validating imported candles. Gaps is
+// the number of intervals missing between candles.
+type ValidationReport struct {
+	Candles    int
+	Invalid    int
+	Duplicates int
+	Gaps       int
+}
+
+// ValidateCandles sorts candles by time, removing duplicate times, keeping the
+// last, and candles with inconsistent OHLC values or negative volume
+func ValidateCandles(candles []Candle, interval time.Duration) ([]Candle, ValidationReport) {
+	var report ValidationReport
+	sorted := make([]Candle, len(candles))
+	copy(sorted, candles)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Time.Before(sorted[j].Time) })
+
+	var valid []Candle
+	for _, c := range sorted {
+		if c.Open <= 0 || c.High <= 0 || c.Low <= 0 || c.Close <= 0 || c.Volume < 0 ||
+			c.High < c.Low || c.High < math.Max(c.Open, c.Close) || c.Low > math.Min(c.Open, c.Close) {
+			report.Invalid++
+			continue
+		}
+		if n := len(valid); n > 0 && valid[n-1].Time.Equal(c.Time) {
+			report.Duplicates++
+			valid[n-1] = c
+			continue
+		}
+		valid = append(valid, c)
+	}
+
+	if interval > 0 {
+		for i := 1; i < len(valid); i++ {
+			if missing := int(valid[i].Time.Sub(valid[i-1].Time)/interval) - 1; missing > 0 {
+				report.Gaps += missing
+			}
+		}
+	}
+	report.Candles = len(valid)
+	return valid, report
+}
+
+// MapSymbol maps a dataset symbol to a currency pair using the mapping when
+// it contains the symbol, otherwise by its delimiter or a known quote
+// currency suffix
+func MapSymbol(symbol string, mapping map[string]pair.CurrencyPair) (pair.CurrencyPair, error) {
+	if p, ok := mapping[symbol]; ok {
+		return p, nil
+	}
+	s := strings.ToUpper(strings.TrimSpace(symbol))
+	for _, delimiter := range []string{"-", "_", "/"} {
+		if parts := strings.Split(s, delimiter); len(parts) == 2 {
+			return pair.NewCurrencyPair(parts[0], parts[1]), nil
+		}
+	}
+	for _, quote := range DefaultQuoteCurrencies {
+		if len(s) > len(quote) && strings.HasSuffix(s, quote) {
+			return pair.NewCurrencyPair(s[:len(s)-len(quote)], quote), nil
+		}
+	}
+	return pair.CurrencyPair{}, fmt.Errorf("%s %s", symbol, ErrUnknownSymbol)
+}
+
+// binanceIntervals holds the fixed length kline intervals of Binance dumps
+var binanceIntervals = map[string]time.Duration{
+	"1s": time.Second, "1m": time.Minute, "3m": 3 * time.Minute,
+	"5m": 5 * time.Minute, "15m": 15 * time.Minute, "30m": 30 * time.Minute,
+	"1h": time.Hour, "2h": 2 * time.Hour, "4h": 4 * time.Hour,
+	"6h": 6 * time.Hour, "8h": 8 * time.Hour, "12h": 12 * time.Hour,
+	"1d": 24 * time.Hour, "3d": 72 * time.Hour, "1w": 168 * time.Hour,
+}
+
+// ParseBinanceDumpName returns the symbol and interval of a Binance public
+// kline dump named SYMBOL-INTERVAL-YYYY-MM[-DD].zip or .csv
+func ParseBinanceDumpName(name string) (string, time.Duration, error) {
+	base := strings.TrimSuffix(strings.TrimSuffix(filepath.Base(name), ".zip"), ".csv")
+	parts := strings.Split(base, "-")
+	if len(parts) < 4 {
+		return "", 0, fmt.Errorf("%s %s", name, ErrInvalidDumpName)
+	}
+	interval, ok := binanceIntervals[parts[1]]
+	if !ok {
+		return "", 0, fmt.Errorf("%s unsupported interval %s", name, parts[1])
+	}
+	return parts[0], interval, nil
+}
+
+// ImportResult holds the outcome of importing a dataset
+type ImportResult struct {
+	Exchange string
+	Pair     pair.CurrencyPair
+	Interval time.Duration
+	ValidationReport
+}
+
+// Importer validates third party historical datasets and saves them to a
+// candle store. Symbols maps dataset symbols to currency pairs when they
+// cannot be split by MapSymbol.
+type Importer struct {
+	Store   CandleStore
+	Symbols map[string]pair.CurrencyPair
+}
+
+// Import parses, validates and stores a CSV dataset of an exchange symbol
+func (i *Importer) Import(r io.Reader, format CSVFormat, exchName, symbol string, interval time.Duration) (ImportResult, error) {
+	result := ImportResult{Exchange: exchName, Interval: interval}
+	if i.Store == nil {
+		return result, ErrNoCandleStore
+	}
+	if interval <= 0 {
+		return result, ErrInvalidInterval
+	}
+	p, err := MapSymbol(symbol, i.Symbols)
+	if err != nil {
+		return result, err
+	}
+	result.Pair = p
+
+	candles, invalid, err := ParseCSV(r, format)
+	if err != nil {
+		return result, err
+	}
+	candles, result.ValidationReport = ValidateCandles(candles, interval)
+	result.Invalid += invalid
+	if len(candles) == 0 {
+		return result, ErrNoCandles
+	}
+	return result, i.Store.SaveCandles(exchName, p, interval, candles)
+}
+
+// ImportBinanceDump imports a Binance public kline dump, either the zip
+// archive as published or its extracted CSV file
+func (i *Importer) ImportBinanceDump(path string) (ImportResult, error) {
+	symbol, interval, err := ParseBinanceDumpName(path)
+	if err != nil {
+		return ImportResult{}, err
+	}
+
+	if !strings.HasSuffix(path, ".zip") {
+		file, err := os.Open(path)
+		if err != nil {
+			return ImportResult{}, err
+		}
+		defer file.Close()
+		return i.Import(file, BinanceKlineFormat, "Binance", symbol, interval)
+	}
+
+	archive, err := zip.OpenReader(path)
+	if err != nil {
+		return ImportResult{}, err
+	}
+	defer archive.Close()
+	for _, f := range archive.File {
+		if !strings.HasSuffix(f.Name, ".csv") {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return ImportResult{}, err
+		}
+		defer rc.Close()
+		return i.Import(rc, BinanceKlineFormat, "Binance", symbol, interval)
+	}
+	return ImportResult{}, ErrNoCSVInArchive
+}
@@ -0,0 +1,75 @@
+package backtest
+
+import (
+	"math"
+
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+)
+
+// FeeModel returns the fee charged for a simulated fill
+type FeeModel interface {
+	Fee(side exchange.OrderSide, price, amount float64) float64
+}
+
+// SlippageModel returns the price a simulated order is filled at on a candle
+type SlippageModel interface {
+	FillPrice(side exchange.OrderSide, amount float64, c Candle) float64
+}
+
+// PercentageFee charges Rate of the notional with a fee of at least Minimum
+type PercentageFee struct {
+	Rate    float64
+	Minimum float64
+}
+
+// Fee returns the fee of a fill
+func (f PercentageFee) Fee(side exchange.OrderSide, price, amount float64) float64 {
+	return math.Max(price*amount*f.Rate, f.Minimum)
+}
+
+// FixedSlippage fills at the candle close moved against the order by Rate, a
+// fraction of the price
+type FixedSlippage struct {
+	Rate float64
+}
+
+// FillPrice returns the fill price of an order
+func (s FixedSlippage) FillPrice(side exchange.OrderSide, amount float64, c Candle) float64 {
+	return slippedPrice(side, c.Close, s.Rate)
+}
+
+// VolumeSlippage fills at the candle close moved against the order by Base
+// plus Impact times the order's share of the candle volume. The slippage is
+// capped at the candle's high for buys and low for sells, orders on candles
+// without volume slip by Base only.
+type VolumeSlippage struct {
+	Base   float64
+	Impact float64
+}
+
+// FillPrice returns the fill price of an order
+func (s VolumeSlippage) FillPrice(side exchange.OrderSide, amount float64, c Candle) float64 {
+	rate := s.Base
+	if c.Volume > 0 {
+		rate += s.Impact * amount / c.Volume
+	}
+	price := slippedPrice(side, c.Close, rate)
+	if side == exchange.Sell {
+		if c.Low > 0 && price < c.Low {
+			return c.Low
+		}
+		return price
+	}
+	if c.High > 0 && price > c.High {
+		return c.High
+	}
+	return price
+}
+
+// slippedPrice moves a price against an order by a fraction of the price
+func slippedPrice(side exchange.OrderSide, price, rate float64) float64 {
+	if side == exchange.Sell {
+		return price * (1 - rate)
+	}
+	return price * (1 + rate)
+}
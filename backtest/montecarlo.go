@@ -0,0 +1,200 @@
+package backtest
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+)
+
+// Resampling methods supported by the Monte Carlo simulation
+const (
+	// Bootstrap draws trades with replacement, varying both the trade order
+	// and the final equity
+	Bootstrap = "BOOTSTRAP"
+	// Shuffle reorders the existing trades without replacement, the final
+	// equity is fixed but the path and therefore drawdown varies
+	Shuffle = "SHUFFLE"
+
+	defaultMonteCarloIterations = 1000
+)
+
+// ErrNoTrades is returned when a simulation is requested without trades
+var ErrNoTrades = errors.New("no trades supplied")
+
+// MonteCarlo holds the settings for a Monte Carlo resampling of backtest
+// trades
+type MonteCarlo struct {
+	Iterations    int
+	Method        string
+	Seed          int64
+	StartingFunds float64
+}
+
+// Distribution holds the sorted outcomes of a simulated metric
+type Distribution struct {
+	Values []float64
+	Mean   float64
+	StdDev float64
+	Min    float64
+	Max    float64
+}
+
+// MonteCarloResult holds the outcome of a Monte Carlo simulation
+type MonteCarloResult struct {
+	Iterations        int
+	Method            string
+	StartingFunds     float64
+	FinalEquity       Distribution
+	MaxDrawdown       Distribution
+	ProbabilityOfLoss float64
+}
+
+// newDistribution sorts the supplied values and calculates summary statistics
+func newDistribution(values []float64) Distribution {
+	d := Distribution{Values: values}
+	if len(values) == 0 {
+		return d
+	}
+
+	sort.Float64s(d.Values)
+	d.Min = d.Values[0]
+	d.Max = d.Values[len(d.Values)-1]
+
+	var sum float64
+	for i := range d.Values {
+		sum += d.Values[i]
+	}
+	d.Mean = sum / float64(len(d.Values))
+
+	var variance float64
+	for i := range d.Values {
+		variance += (d.Values[i] - d.Mean) * (d.Values[i] - d.Mean)
+	}
+	d.StdDev = math.Sqrt(variance / float64(len(d.Values)))
+	return d
+}
+
+// Percentile returns the value at the supplied percentile (0-100) using
+// linear interpolation between the closest ranks
+func (d *Distribution) Percentile(p float64) float64 {
+	if len(d.Values) == 0 {
+		return 0
+	}
+	if p <= 0 {
+		return d.Min
+	}
+	if p >= 100 {
+		return d.Max
+	}
+
+	rank := p / 100 * float64(len(d.Values)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	if lower == upper {
+		return d.Values[lower]
+	}
+	return d.Values[lower] + (rank-float64(lower))*(d.Values[upper]-d.Values[lower])
+}
+
+// ConfidenceInterval returns the two sided interval containing the supplied
+// confidence level (e.g. 95) of outcomes
+func (d *Distribution) ConfidenceInterval(level float64) (low, high float64) {
+	tail := (100 - level) / 2
+	return d.Percentile(tail), d.Percentile(100 - tail)
+}
+
+// Run resamples the supplied trades and returns the distribution of final
+// equity and maximum drawdown across all iterations
+func (m *MonteCarlo) Run(trades []Trade) (MonteCarloResult, error) {
+	var result MonteCarloResult
+	if len(trades) == 0 {
+		return result, ErrNoTrades
+	}
+	if m.StartingFunds <= 0 {
+		return result, ErrInvalidStartingFunds
+	}
+
+	iterations := m.Iterations
+	if iterations <= 0 {
+		iterations = defaultMonteCarloIterations
+	}
+
+	method := strings.ToUpper(m.Method)
+	switch method {
+	case "":
+		method = Bootstrap
+	case Bootstrap, Shuffle:
+	default:
+		return result, fmt.Errorf("invalid Monte Carlo method %s", m.Method)
+	}
+
+	r := rand.New(rand.NewSource(m.Seed))
+	finalEquity := make([]float64, iterations)
+	drawdowns := make([]float64, iterations)
+	equity := make([]float64, len(trades)+1)
+	order := make([]int, len(trades))
+	for i := range order {
+		order[i] = i
+	}
+
+	var losses int
+	for i := 0; i < iterations; i++ {
+		if method == Shuffle {
+			r.Shuffle(len(order), func(a, b int) {
+				order[a], order[b] = order[b], order[a]
+			})
+		} else {
+			for j := range order {
+				order[j] = r.Intn(len(trades))
+			}
+		}
+
+		equity[0] = m.StartingFunds
+		for j := range order {
+			equity[j+1] = equity[j] + trades[order[j]].ProfitLoss
+		}
+
+		finalEquity[i] = equity[len(equity)-1]
+		drawdowns[i] = MaxDrawdown(equity)
+		if finalEquity[i] < m.StartingFunds {
+			losses++
+		}
+	}
+
+	result.Iterations = iterations
+	result.Method = method
+	result.StartingFunds = m.StartingFunds
+	result.FinalEquity = newDistribution(finalEquity)
+	result.MaxDrawdown = newDistribution(drawdowns)
+	result.ProbabilityOfLoss = float64(losses) / float64(iterations)
+	return result, nil
+}
+
+// ProbabilityOfDrawdown returns the fraction of simulations in which the
+// maximum drawdown met or exceeded the supplied fraction
+func (m *MonteCarloResult) ProbabilityOfDrawdown(drawdown float64) float64 {
+	if len(m.MaxDrawdown.Values) == 0 {
+		return 0
+	}
+	i := sort.SearchFloat64s(m.MaxDrawdown.Values, drawdown)
+	return float64(len(m.MaxDrawdown.Values)-i) / float64(len(m.MaxDrawdown.Values))
+}
+
+// String returns a summary of the simulation with 95% confidence intervals
+func (m *MonteCarloResult) String() string {
+	eqLow, eqHigh := m.FinalEquity.ConfidenceInterval(95)
+	ddLow, ddHigh := m.MaxDrawdown.ConfidenceInterval(95)
+	return fmt.Sprintf("Monte Carlo (%s, %d iterations): Final equity mean %.2f 95%% CI [%.2f, %.2f] - Max drawdown mean %.2f%% 95%% CI [%.2f%%, %.2f%%] - Probability of loss %.2f%%",
+		m.Method,
+		m.Iterations,
+		m.FinalEquity.Mean,
+		eqLow,
+		eqHigh,
+		m.MaxDrawdown.Mean*100,
+		ddLow*100,
+		ddHigh*100,
+		m.ProbabilityOfLoss*100)
+}
@@ -0,0 +1,374 @@
+package backtest
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Search methods supported by the optimiser
+const (
+	GridSearch   = "GRID"
+	RandomSearch = "RANDOM"
+
+	defaultRandomSamples = 100
+)
+
+// Errors returned by the optimiser
+var (
+	ErrNoParameterRanges   = errors.New("no parameter ranges supplied")
+	ErrInvalidSearchMethod = errors.New("invalid search method")
+	ErrInvalidWindow       = errors.New("in-sample and out-of-sample durations must be greater than zero")
+	ErrNoRunFunc           = errors.New("no run function supplied")
+	ErrNoResults           = errors.New("no parameter set produced a result")
+)
+
+// Parameters holds a named set of strategy parameter values
+type Parameters map[string]float64
+
+// String returns the parameters in a stable, human readable format
+func (p Parameters) String() string {
+	var keys []string
+	for k := range p {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var out []string
+	for _, k := range keys {
+		out = append(out, fmt.Sprintf("%s=%v", k, p[k]))
+	}
+	return strings.Join(out, " ")
+}
+
+// ParameterRange defines the inclusive search space of a single parameter
+type ParameterRange struct {
+	Name string
+	Min  float64
+	Max  float64
+	Step float64
+}
+
+// values returns every value in the range stepping from Min to Max
+func (p ParameterRange) values() ([]float64, error) {
+	if p.Name == "" {
+		return nil, errors.New("parameter range name cannot be empty")
+	}
+	if p.Max < p.Min {
+		return nil, fmt.Errorf("parameter %s max is less than min", p.Name)
+	}
+	if p.Step <= 0 {
+		if p.Max != p.Min {
+			return nil, fmt.Errorf("parameter %s step must be greater than zero", p.Name)
+		}
+		return []float64{p.Min}, nil
+	}
+
+	var vals []float64
+	steps := int(math.Floor((p.Max-p.Min)/p.Step + 1e-9))
+	for i := 0; i <= steps; i++ {
+		vals = append(vals, p.Min+float64(i)*p.Step)
+	}
+	return vals, nil
+}
+
+// GenerateGrid returns the cartesian product of all supplied parameter ranges
+func GenerateGrid(ranges []ParameterRange) ([]Parameters, error) {
+	if len(ranges) == 0 {
+		return nil, ErrNoParameterRanges
+	}
+
+	result := []Parameters{{}}
+	for i := range ranges {
+		vals, err := ranges[i].values()
+		if err != nil {
+			return nil, err
+		}
+
+		var next []Parameters
+		for j := range result {
+			for k := range vals {
+				p := make(Parameters, len(result[j])+1)
+				for name, v := range result[j] {
+					p[name] = v
+				}
+				p[ranges[i].Name] = vals[k]
+				next = append(next, p)
+			}
+		}
+		result = next
+	}
+	return result, nil
+}
+
+// GenerateRandom returns n parameter sets sampled uniformly from the grid
+// defined by the supplied ranges
+func GenerateRandom(ranges []ParameterRange, n int, seed int64) ([]Parameters, error) {
+	if len(ranges) == 0 {
+		return nil, ErrNoParameterRanges
+	}
+	if n <= 0 {
+		n = defaultRandomSamples
+	}
+
+	values := make([][]float64, len(ranges))
+	for i := range ranges {
+		vals, err := ranges[i].values()
+		if err != nil {
+			return nil, err
+		}
+		values[i] = vals
+	}
+
+	r := rand.New(rand.NewSource(seed))
+	result := make([]Parameters, n)
+	for i := 0; i < n; i++ {
+		p := make(Parameters, len(ranges))
+		for j := range ranges {
+			p[ranges[j].Name] = values[j][r.Intn(len(values[j]))]
+		}
+		result[i] = p
+	}
+	return result, nil
+}
+
+// Window defines a single walk-forward split
+type Window struct {
+	InSampleStart  time.Time
+	InSampleEnd    time.Time
+	OutSampleStart time.Time
+	OutSampleEnd   time.Time
+}
+
+// WalkForwardWindows splits the start and end period into consecutive
+// in-sample/out-of-sample windows. The out-of-sample periods never overlap and
+// each window rolls forward by the out-of-sample duration. When anchored is
+// true every in-sample period begins at start.
+func WalkForwardWindows(start, end time.Time, inSample, outSample time.Duration, anchored bool) ([]Window, error) {
+	if inSample <= 0 || outSample <= 0 {
+		return nil, ErrInvalidWindow
+	}
+	if !end.After(start) {
+		return nil, errors.New("end time must be after start time")
+	}
+
+	var windows []Window
+	for isStart := start; ; isStart = isStart.Add(outSample) {
+		w := Window{
+			InSampleStart: isStart,
+			InSampleEnd:   isStart.Add(inSample),
+		}
+		if anchored {
+			w.InSampleStart = start
+			w.InSampleEnd = isStart.Add(inSample)
+		}
+		w.OutSampleStart = w.InSampleEnd
+		w.OutSampleEnd = w.OutSampleStart.Add(outSample)
+		if w.OutSampleEnd.After(end) {
+			break
+		}
+		windows = append(windows, w)
+	}
+
+	if len(windows) == 0 {
+		return nil, errors.New("period too short for a single walk-forward window")
+	}
+	return windows, nil
+}
+
+// RunFunc executes a strategy with the supplied parameters over a time period
+// and returns its statistics
+type RunFunc func(p Parameters, start, end time.Time) (Statistics, error)
+
+// ObjectiveFunc scores a set of statistics, a higher score is better
+type ObjectiveFunc func(s *Statistics) float64
+
+// ObjectiveNetProfit scores results by net profit
+func ObjectiveNetProfit(s *Statistics) float64 {
+	return s.NetProfit
+}
+
+// ObjectiveSharpe scores results by Sharpe ratio
+func ObjectiveSharpe(s *Statistics) float64 {
+	return s.SharpeRatio
+}
+
+// Optimiser runs a parameter search across walk-forward windows
+type Optimiser struct {
+	Ranges        []ParameterRange
+	SearchMethod  string
+	RandomSamples int
+	Seed          int64
+	Workers       int
+	Anchored      bool
+	Objective     ObjectiveFunc
+}
+
+// WindowResult holds the outcome of a single walk-forward window
+type WindowResult struct {
+	Window
+	BestParameters   Parameters
+	InSample         Statistics
+	OutOfSample      Statistics
+	InSampleScore    float64
+	OutOfSampleScore float64
+}
+
+// Report holds the outcome of a walk-forward optimisation
+type Report struct {
+	Windows                 []WindowResult
+	AverageInSampleScore    float64
+	AverageOutOfSampleScore float64
+	// Efficiency is the ratio of out-of-sample to in-sample score, values
+	// close to one indicate the parameters generalise well
+	Efficiency float64
+}
+
+type evaluation struct {
+	params Parameters
+	stats  Statistics
+	score  float64
+	err    error
+}
+
+// parameterSets returns the parameter sets for the configured search method
+func (o *Optimiser) parameterSets() ([]Parameters, error) {
+	switch strings.ToUpper(o.SearchMethod) {
+	case GridSearch, "":
+		return GenerateGrid(o.Ranges)
+	case RandomSearch:
+		return GenerateRandom(o.Ranges, o.RandomSamples, o.Seed)
+	default:
+		return nil, ErrInvalidSearchMethod
+	}
+}
+
+// evaluate runs every parameter set over the period in parallel, scoring
+// each with objective
+func (o *Optimiser) evaluate(sets []Parameters, start, end time.Time, fn RunFunc, objective ObjectiveFunc) []evaluation {
+	workers := o.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	results := make([]evaluation, len(sets))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for x := range jobs {
+				stats, err := fn(sets[x], start, end)
+				results[x] = evaluation{params: sets[x], stats: stats, err: err}
+				if err == nil {
+					results[x].score = objective(&results[x].stats)
+				}
+			}
+		}()
+	}
+
+	for i := range sets {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return results
+}
+
+// Run performs the walk-forward optimisation between start and end. For each
+// window all parameter sets are evaluated in-sample, the best scoring set is
+// then run over the following out-of-sample period.
+func (o *Optimiser) Run(start, end time.Time, inSample, outSample time.Duration, fn RunFunc) (Report, error) {
+	var report Report
+	if fn == nil {
+		return report, ErrNoRunFunc
+	}
+	objective := o.Objective
+	if objective == nil {
+		objective = ObjectiveNetProfit
+	}
+
+	sets, err := o.parameterSets()
+	if err != nil {
+		return report, err
+	}
+
+	windows, err := WalkForwardWindows(start, end, inSample, outSample, o.Anchored)
+	if err != nil {
+		return report, err
+	}
+
+	for i := range windows {
+		evals := o.evaluate(sets, windows[i].InSampleStart, windows[i].InSampleEnd, fn, objective)
+
+		best := -1
+		for j := range evals {
+			if evals[j].err != nil {
+				continue
+			}
+			if best == -1 || evals[j].score > evals[best].score {
+				best = j
+			}
+		}
+		if best == -1 {
+			return report, fmt.Errorf("window %d: %s", i, ErrNoResults)
+		}
+
+		oos, err := fn(evals[best].params, windows[i].OutSampleStart, windows[i].OutSampleEnd)
+		if err != nil {
+			return report, fmt.Errorf("window %d out-of-sample run error: %s", i, err)
+		}
+
+		report.Windows = append(report.Windows, WindowResult{
+			Window:           windows[i],
+			BestParameters:   evals[best].params,
+			InSample:         evals[best].stats,
+			OutOfSample:      oos,
+			InSampleScore:    evals[best].score,
+			OutOfSampleScore: objective(&oos),
+		})
+	}
+
+	for i := range report.Windows {
+		report.AverageInSampleScore += report.Windows[i].InSampleScore
+		report.AverageOutOfSampleScore += report.Windows[i].OutOfSampleScore
+	}
+	report.AverageInSampleScore /= float64(len(report.Windows))
+	report.AverageOutOfSampleScore /= float64(len(report.Windows))
+	if report.AverageInSampleScore != 0 {
+		report.Efficiency = report.AverageOutOfSampleScore / report.AverageInSampleScore
+	}
+	return report, nil
+}
+
+// String returns a table comparing in-sample and out-of-sample metrics for
+// each window
+func (r *Report) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%-4s %-21s %-21s %12s %12s %10s %10s  %s\n",
+		"#", "In-sample", "Out-of-sample", "IS score", "OOS score", "IS DD", "OOS DD", "Parameters")
+	for i := range r.Windows {
+		w := r.Windows[i]
+		fmt.Fprintf(&b, "%-4d %-21s %-21s %12.4f %12.4f %9.2f%% %9.2f%%  %s\n",
+			i,
+			w.InSampleStart.Format("2006-01-02")+"/"+w.InSampleEnd.Format("2006-01-02"),
+			w.OutSampleStart.Format("2006-01-02")+"/"+w.OutSampleEnd.Format("2006-01-02"),
+			w.InSampleScore,
+			w.OutOfSampleScore,
+			w.InSample.MaxDrawdown*100,
+			w.OutOfSample.MaxDrawdown*100,
+			w.BestParameters)
+	}
+	fmt.Fprintf(&b, "Average in-sample score: %.4f Average out-of-sample score: %.4f Efficiency: %.2f\n",
+		r.AverageInSampleScore,
+		r.AverageOutOfSampleScore,
+		r.Efficiency)
+	return b.String()
+}
@@ -0,0 +1,158 @@
+package backtest
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// Trade holds a completed round trip generated during a backtest run. Fee
+// holds the fees paid and Rebate the maker rebates received, ProfitLoss is net
+// of both. EntryMaker and ExitMaker are set when that leg was filled as the
+// maker.
+type Trade struct {
+	EntryTime  time.Time
+	ExitTime   time.Time
+	EntryPrice float64
+	ExitPrice  float64
+	Amount     float64
+	Fee        float64
+	Rebate     float64
+	EntryMaker bool
+	ExitMaker  bool
+	ProfitLoss float64
+}
+
+// Statistics holds the performance metrics for a backtest run
+type Statistics struct {
+	StartingFunds float64
+	FinalEquity   float64
+	NetProfit     float64
+	TotalReturn   float64
+	TotalTrades   int
+	WinningTrades int
+	LosingTrades  int
+	WinRate       float64
+	MaxDrawdown   float64
+	SharpeRatio   float64
+	TotalFees     float64
+	TotalRebates  float64
+	NetFees       float64
+	MakerRatio    float64
+	AverageProfit float64
+	LargestWinner float64
+	LargestLoser  float64
+	EquityCurve   []float64
+	ProfitFactor  float64
+}
+
+// ErrInvalidStartingFunds is returned when statistics are requested for a
+// run without any starting capital
+var ErrInvalidStartingFunds = errors.New("starting funds must be greater than zero")
+
+// CalculateStatistics derives performance metrics from a sequence of trades
+// executed in order against the supplied starting funds
+func CalculateStatistics(startingFunds float64, trades []Trade) (Statistics, error) {
+	s := Statistics{StartingFunds: startingFunds}
+	if startingFunds <= 0 {
+		return s, ErrInvalidStartingFunds
+	}
+
+	var grossProfit, grossLoss float64
+	var makerFills int
+	var returns []float64
+	equity := startingFunds
+	s.EquityCurve = append(s.EquityCurve, equity)
+
+	for i := range trades {
+		prev := equity
+		equity += trades[i].ProfitLoss
+		s.EquityCurve = append(s.EquityCurve, equity)
+		s.TotalFees += trades[i].Fee
+		s.TotalRebates += trades[i].Rebate
+		if trades[i].EntryMaker {
+			makerFills++
+		}
+		if trades[i].ExitMaker {
+			makerFills++
+		}
+
+		if prev != 0 {
+			returns = append(returns, (equity-prev)/prev)
+		}
+
+		switch {
+		case trades[i].ProfitLoss > 0:
+			s.WinningTrades++
+			grossProfit += trades[i].ProfitLoss
+		case trades[i].ProfitLoss < 0:
+			s.LosingTrades++
+			grossLoss -= trades[i].ProfitLoss
+		}
+
+		if trades[i].ProfitLoss > s.LargestWinner {
+			s.LargestWinner = trades[i].ProfitLoss
+		}
+		if trades[i].ProfitLoss < s.LargestLoser {
+			s.LargestLoser = trades[i].ProfitLoss
+		}
+	}
+
+	s.TotalTrades = len(trades)
+	s.FinalEquity = equity
+	s.NetProfit = equity - startingFunds
+	s.TotalReturn = s.NetProfit / startingFunds
+	if s.TotalTrades > 0 {
+		s.WinRate = float64(s.WinningTrades) / float64(s.TotalTrades)
+		s.AverageProfit = s.NetProfit / float64(s.TotalTrades)
+		s.MakerRatio = float64(makerFills) / float64(2*s.TotalTrades)
+	}
+	s.NetFees = s.TotalFees - s.TotalRebates
+	if grossLoss > 0 {
+		s.ProfitFactor = grossProfit / grossLoss
+	}
+	s.MaxDrawdown = MaxDrawdown(s.EquityCurve)
+	s.SharpeRatio = SharpeRatio(returns)
+	return s, nil
+}
+
+// MaxDrawdown returns the largest peak to trough decline of an equity curve
+// expressed as a fraction of the peak
+func MaxDrawdown(equity []float64) float64 {
+	var peak, maxDD float64
+	for i := range equity {
+		if equity[i] > peak {
+			peak = equity[i]
+		}
+		if peak > 0 {
+			if dd := (peak - equity[i]) / peak; dd > maxDD {
+				maxDD = dd
+			}
+		}
+	}
+	return maxDD
+}
+
+// SharpeRatio returns the non-annualised Sharpe ratio of a series of periodic
+// returns using a zero risk free rate
+func SharpeRatio(returns []float64) float64 {
+	if len(returns) < 2 {
+		return 0
+	}
+
+	var sum float64
+	for i := range returns {
+		sum += returns[i]
+	}
+	mean := sum / float64(len(returns))
+
+	var variance float64
+	for i := range returns {
+		variance += (returns[i] - mean) * (returns[i] - mean)
+	}
+	stdDev := math.Sqrt(variance / float64(len(returns)-1))
+	if stdDev < 1e-12 {
+		return 0
+	}
+	return mean / stdDev
+}
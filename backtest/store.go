@@ -0,0 +1,173 @@
+package backtest
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+)
+
+// CandleStore persists the historical candles of an exchange pair at an
+// interval
+type CandleStore interface {
+	SaveCandles(exchName string, p pair.CurrencyPair, interval time.Duration, candles []Candle) error
+	LoadCandles(exchName string, p pair.CurrencyPair, interval time.Duration, start, end time.Time) ([]Candle, error)
+}
+
+// FileCandleStore stores candles as a CSV file per exchange, pair and
+// interval in Dir
+type FileCandleStore struct {
+	Dir string
+	m   sync.Mutex
+}
+
+// NewFileCandleStore returns a file candle store, creating the directory if
+// required
+func NewFileCandleStore(dir string) (*FileCandleStore, error) {
+	if err := os.MkdirAll(dir, 0770); err != nil {
+		return nil, err
+	}
+	return &FileCandleStore{Dir: dir}, nil
+}
+
+// path returns the file storing the candles of an exchange pair and interval
+func (f *FileCandleStore) path(exchName string, p pair.CurrencyPair, interval time.Duration) string {
+	name := fmt.Sprintf("%s_%s_%s_%d.csv", strings.ToLower(exchName),
+		p.FirstCurrency.Upper(), p.SecondCurrency.Upper(), int64(interval/time.Second))
+	return filepath.Join(f.Dir, name)
+}
+
+// SaveCandles merges candles into the stored candles, replacing stored
+// candles with the same time
+func (f *FileCandleStore) SaveCandles(exchName string, p pair.CurrencyPair, interval time.Duration, candles []Candle) error {
+	f.m.Lock()
+	defer f.m.Unlock()
+
+	path := f.path(exchName, p, interval)
+	stored, err := readCandleFile(path)
+	if err != nil {
+		return err
+	}
+
+	merged := make(map[int64]Candle, len(stored)+len(candles))
+	for _, c := range stored {
+		merged[c.Time.UnixNano()] = c
+	}
+	for _, c := range candles {
+		merged[c.Time.UnixNano()] = c
+	}
+	all := make([]Candle, 0, len(merged))
+	for _, c := range merged {
+		all = append(all, c)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].Time.Before(all[j].Time) })
+
+	tmp := path + ".tmp"
+	if err = writeCandleFile(tmp, all); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// LoadCandles returns the stored candles from start until end inclusive, a
+// zero end returns every candle from start
+func (f *FileCandleStore) LoadCandles(exchName string, p pair.CurrencyPair, interval time.Duration, start, end time.Time) ([]Candle, error) {
+	f.m.Lock()
+	stored, err := readCandleFile(f.path(exchName, p, interval))
+	f.m.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	var candles []Candle
+	for _, c := range stored {
+		if c.Time.Before(start) || (!end.IsZero() && c.Time.After(end)) {
+			continue
+		}
+		candles = append(candles, c)
+	}
+	return candles, nil
+}
+
+// readCandleFile reads a candle file, a missing file holds no candles
+func readCandleFile(path string) ([]Candle, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	r := csv.NewReader(file)
+	var candles []Candle
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			return candles, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(record) != 6 {
+			return nil, fmt.Errorf("%s invalid candle record %v", path, record)
+		}
+		var values [5]float64
+		for i := range values {
+			if values[i], err = strconv.ParseFloat(record[i+1], 64); err != nil {
+				return nil, err
+			}
+		}
+		ts, err := strconv.ParseInt(record[0], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		candles = append(candles, Candle{
+			Time:   common.UnixTimestampToUTC(ts),
+			Open:   values[0],
+			High:   values[1],
+			Low:    values[2],
+			Close:  values[3],
+			Volume: values[4],
+		})
+	}
+}
+
+// writeCandleFile writes candles with millisecond unix timestamps
+func writeCandleFile(path string, candles []Candle) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	w := csv.NewWriter(file)
+	for _, c := range candles {
+		err = w.Write([]string{
+			strconv.FormatInt(c.Time.UnixNano()/int64(time.Millisecond), 10),
+			strconv.FormatFloat(c.Open, 'f', -1, 64),
+			strconv.FormatFloat(c.High, 'f', -1, 64),
+			strconv.FormatFloat(c.Low, 'f', -1, 64),
+			strconv.FormatFloat(c.Close, 'f', -1, 64),
+			strconv.FormatFloat(c.Volume, 'f', -1, 64),
+		})
+		if err != nil {
+			file.Close()
+			return err
+		}
+	}
+	w.Flush()
+	if err = w.Error(); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,271 @@
+package backtest
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+)
+
+// maxBuyResizes is the number of times a buy is refilled at a smaller amount
+// before its fill is scaled to the available funds
+const maxBuyResizes = 3
+
+// Errors returned when running a strategy
+var (
+	ErrNoStrategy       = errors.New("no strategy supplied")
+	ErrNoExecutor       = errors.New("no executor supplied")
+	ErrNoCandles        = errors.New("no candles supplied")
+	ErrCandleOutOfOrder = errors.New("candle is not after the previous candle")
+)
+
+// Candle holds the OHLCV values of a single interval starting at Time
+type Candle struct {
+	Time   time.Time
+	Open   float64
+	High   float64
+	Low    float64
+	Close  float64
+	Volume float64
+}
+
+// Signal is a strategy's instruction for the current candle. An empty side
+// holds, a buy opens a position of Amount, or with all available funds when
+// Amount is zero, and a sell closes the open position.
+type Signal struct {
+	Side   exchange.OrderSide
+	Amount float64
+}
+
+// Position is the strategy's open position, Amount is zero when flat. Funds
+// is the cash available to open a position.
+type Position struct {
+	Amount     float64
+	EntryPrice float64
+	EntryTime  time.Time
+	Funds      float64
+}
+
+// Strategy generates trading signals from candles. The same implementation
+// is run by Run over historical candles and by a ForwardTest over candles
+// built from live prices.
+type Strategy interface {
+	OnCandle(c Candle, p Position) (Signal, error)
+}
+
+// Execution is a filled strategy order
+type Execution struct {
+	Time   time.Time
+	Price  float64
+	Amount float64
+	Fee    float64
+	Maker  bool
+}
+
+// Executor fills strategy orders, a Simulator in backtests and a
+// PaperExecutor in forward tests
+type Executor interface {
+	Execute(side exchange.OrderSide, amount float64, c Candle) (Execution, error)
+}
+
+// Simulator fills orders at the candle close moved against the order by
+// Slippage, a fraction of the price, and charges FeeRate of the notional.
+// SlippageModel and FeeModel replace the fixed rates when set.
+type Simulator struct {
+	FeeRate       float64
+	Slippage      float64
+	SlippageModel SlippageModel
+	FeeModel      FeeModel
+}
+
+// Execute fills an order at the candle close adjusted for slippage
+func (s *Simulator) Execute(side exchange.OrderSide, amount float64, c Candle) (Execution, error) {
+	var slippage SlippageModel = FixedSlippage{Rate: s.Slippage}
+	if s.SlippageModel != nil {
+		slippage = s.SlippageModel
+	}
+	var fees FeeModel = PercentageFee{Rate: s.FeeRate}
+	if s.FeeModel != nil {
+		fees = s.FeeModel
+	}
+	price := slippage.FillPrice(side, amount, c)
+	return Execution{
+		Time:   c.Time,
+		Price:  price,
+		Amount: amount,
+		Fee:    fees.Fee(side, price, amount),
+	}, nil
+}
+
+// Runner passes candles to a strategy, fills its signals with an executor and
+// records the round trip trades. Positions are long only, a buy while a
+// position is open and a sell while flat are ignored. A buy costing more than
+// the available funds after slippage and fees is reduced to what they afford.
+type Runner struct {
+	Strategy      Strategy
+	Executor      Executor
+	StartingFunds float64
+
+	position Position
+	entry    Execution
+	last     time.Time
+	trades   []Trade
+	m        sync.Mutex
+}
+
+// NewRunner returns a new strategy runner
+func NewRunner(s Strategy, e Executor, startingFunds float64) (*Runner, error) {
+	if s == nil {
+		return nil, ErrNoStrategy
+	}
+	if e == nil {
+		return nil, ErrNoExecutor
+	}
+	if startingFunds <= 0 {
+		return nil, ErrInvalidStartingFunds
+	}
+	return &Runner{
+		Strategy:      s,
+		Executor:      e,
+		StartingFunds: startingFunds,
+		position:      Position{Funds: startingFunds},
+	}, nil
+}
+
+// OnCandle passes a closed candle to the strategy and executes its signal
+func (r *Runner) OnCandle(c Candle) error {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	if !r.last.IsZero() && !c.Time.After(r.last) {
+		return ErrCandleOutOfOrder
+	}
+	r.last = c.Time
+
+	signal, err := r.Strategy.OnCandle(c, r.position)
+	if err != nil {
+		return err
+	}
+
+	switch signal.Side {
+	case "":
+		return nil
+	case exchange.Buy:
+		if r.position.Amount > 0 || c.Close <= 0 {
+			return nil
+		}
+		amount := signal.Amount
+		if amount <= 0 {
+			amount = r.position.Funds / c.Close
+		}
+		e, err := r.buy(amount, c)
+		if err != nil {
+			return err
+		}
+		r.entry = e
+		r.position.Amount = e.Amount
+		r.position.EntryPrice = e.Price
+		r.position.EntryTime = e.Time
+		r.position.Funds -= e.Price*e.Amount + e.Fee
+	case exchange.Sell:
+		if r.position.Amount == 0 {
+			return nil
+		}
+		e, err := r.Executor.Execute(exchange.Sell, r.position.Amount, c)
+		if err != nil {
+			return err
+		}
+		r.close(e)
+	default:
+		return fmt.Errorf("unsupported signal side %s", signal.Side)
+	}
+	return nil
+}
+
+// buy fills a buy order, resizing it to the available funds when its cost
+// including slippage and fees exceeds them. The fill is repeated at the
+// resized amount so non-linear slippage and fee models are priced, and scaled
+// down when still unaffordable. The mutex must be held by the caller.
+func (r *Runner) buy(amount float64, c Candle) (Execution, error) {
+	funds := r.position.Funds
+	e, err := r.Executor.Execute(exchange.Buy, amount, c)
+	for i := 0; i < maxBuyResizes && err == nil; i++ {
+		cost := e.Price*e.Amount + e.Fee
+		if cost <= funds || cost <= 0 {
+			return e, nil
+		}
+		e, err = r.Executor.Execute(exchange.Buy, e.Amount*funds/cost, c)
+	}
+	if err != nil {
+		return Execution{}, err
+	}
+	if cost := e.Price*e.Amount + e.Fee; cost > funds {
+		e.Amount *= funds / cost
+		e.Fee = funds - e.Price*e.Amount
+	}
+	return e, nil
+}
+
+// close records the round trip trade of the open position. The mutex must be
+// held by the caller.
+func (r *Runner) close(exit Execution) {
+	fee := r.entry.Fee + exit.Fee
+	r.trades = append(r.trades, Trade{
+		EntryTime:  r.entry.Time,
+		ExitTime:   exit.Time,
+		EntryPrice: r.entry.Price,
+		ExitPrice:  exit.Price,
+		Amount:     exit.Amount,
+		Fee:        fee,
+		EntryMaker: r.entry.Maker,
+		ExitMaker:  exit.Maker,
+		ProfitLoss: (exit.Price-r.entry.Price)*exit.Amount - fee,
+	})
+	r.position.Funds += exit.Price*exit.Amount - exit.Fee
+	r.position.Amount = 0
+	r.position.EntryPrice = 0
+	r.position.EntryTime = time.Time{}
+	r.entry = Execution{}
+}
+
+// Position returns the strategy's current position
+func (r *Runner) Position() Position {
+	r.m.Lock()
+	defer r.m.Unlock()
+	return r.position
+}
+
+// Trades returns a copy of the completed round trip trades
+func (r *Runner) Trades() []Trade {
+	r.m.Lock()
+	defer r.m.Unlock()
+	trades := make([]Trade, len(r.trades))
+	copy(trades, r.trades)
+	return trades
+}
+
+// Statistics returns the performance statistics of the completed trades
+func (r *Runner) Statistics() (Statistics, error) {
+	return CalculateStatistics(r.StartingFunds, r.Trades())
+}
+
+// Run replays historical candles through a strategy with the executor and
+// returns the statistics and trades of the run
+func Run(s Strategy, e Executor, startingFunds float64, candles []Candle) (Statistics, []Trade, error) {
+	if len(candles) == 0 {
+		return Statistics{}, nil, ErrNoCandles
+	}
+	r, err := NewRunner(s, e, startingFunds)
+	if err != nil {
+		return Statistics{}, nil, err
+	}
+	for i := range candles {
+		if err = r.OnCandle(candles[i]); err != nil {
+			return Statistics{}, nil, err
+		}
+	}
+	stats, err := r.Statistics()
+	return stats, r.Trades(), err
+}
@@ -0,0 +1,305 @@
+// Package basis monitors the basis between the spot market of a currency pair
+// and its dated futures or perpetual swaps across exchanges, annualises the
+// carry it offers and executes cash-and-carry trades, buying spot and selling
+// the future, through the order manager.
+package basis
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+// Monitor defaults
+const (
+	DefaultMaxAge       = time.Minute
+	DefaultScanInterval = time.Minute
+)
+
+// year is the period carry is annualised over
+const year = 365 * 24 * time.Hour
+
+// Errors returned when calculating the basis
+var (
+	ErrNoQuote             = errors.New("no current ticker")
+	ErrContractExpired     = errors.New("futures contract has expired")
+	ErrFundingUnavailable  = errors.New("exchange does not report perpetual swap funding rates")
+	ErrFuturesNotSupported = errors.New("exchange does not support futures orders")
+	ErrInvalidAmount       = errors.New("cash and carry amount must be greater than zero")
+)
+
+// Market is an exchange's market in a currency pair, priced from the ticker
+// of Pair and AssetType. Futures markets are ordered by InstrumentID. Dated
+// futures have an Expiry and perpetual swaps have none.
+type Market struct {
+	Exchange     string            `json:"exchange"`
+	Pair         pair.CurrencyPair `json:"pair"`
+	AssetType    string            `json:"assetType"`
+	InstrumentID string            `json:"instrumentID,omitempty"`
+	Expiry       time.Time         `json:"expiry,omitempty"`
+}
+
+// Perpetual returns whether the market is a perpetual swap
+func (m Market) Perpetual() bool {
+	return m.Expiry.IsZero()
+}
+
+// instrumentID returns the market's instrument ID, the pair when it has none
+func (m Market) instrumentID() string {
+	if m.InstrumentID == "" {
+		return m.Pair.Pair().String()
+	}
+	return m.InstrumentID
+}
+
+func (m Market) String() string {
+	if m.InstrumentID != "" {
+		return m.Exchange + " " + m.InstrumentID
+	}
+	return fmt.Sprintf("%s %s %s", m.Exchange, m.AssetType, m.Pair.Pair())
+}
+
+// Spread is a spot market and a future on the same underlying, possibly on
+// different exchanges
+type Spread struct {
+	Spot   Market `json:"spot"`
+	Future Market `json:"future"`
+}
+
+func (s Spread) String() string {
+	return s.Spot.String() + "/" + s.Future.String()
+}
+
+// Basis is the difference between the price the future sells at and the
+// price spot buys at. AnnualisedPercent is the carry earned by holding spot
+// against a short future, from the basis until expiry for dated futures and
+// from the funding rate for perpetual swaps.
+type Basis struct {
+	Spread            Spread        `json:"spread"`
+	SpotPrice         float64       `json:"spotPrice"`
+	FuturePrice       float64       `json:"futurePrice"`
+	Basis             float64       `json:"basis"`
+	BasisPercent      float64       `json:"basisPercent"`
+	FundingRate       float64       `json:"fundingRate,omitempty"`
+	TimeToExpiry      time.Duration `json:"timeToExpiry,omitempty"`
+	AnnualisedPercent float64       `json:"annualisedPercent"`
+	Time              time.Time     `json:"time"`
+}
+
+// PriceSource returns the best bid and ask of a market
+type PriceSource func(m Market) (bid, ask float64, err error)
+
+// FundingSource returns the current funding rate of a perpetual swap
+type FundingSource func(m Market) (exchange.FundingRate, error)
+
+// TickerPrices returns a PriceSource reading the shared ticker store, falling
+// back to the last price when a side is missing. Tickers older than maxAge
+// are not current.
+func TickerPrices(maxAge time.Duration) PriceSource {
+	if maxAge <= 0 {
+		maxAge = DefaultMaxAge
+	}
+	return func(m Market) (float64, float64, error) {
+		t, err := ticker.GetTicker(m.Exchange, m.Pair, m.AssetType)
+		if err != nil || time.Since(t.LastUpdated) > maxAge {
+			return 0, 0, ErrNoQuote
+		}
+		bid, ask := t.Bid, t.Ask
+		if bid <= 0 {
+			bid = t.Last
+		}
+		if ask <= 0 {
+			ask = t.Last
+		}
+		if bid <= 0 || ask <= 0 {
+			return 0, 0, ErrNoQuote
+		}
+		return bid, ask, nil
+	}
+}
+
+// ExchangeFunding returns a FundingSource asking the exchanges returned by
+// getExchange which implement exchange.FundingRateExchange
+func ExchangeFunding(getExchange exchange.ExchangeGetter) FundingSource {
+	return func(m Market) (exchange.FundingRate, error) {
+		exch := getExchange(m.Exchange)
+		if exch == nil {
+			return exchange.FundingRate{}, fmt.Errorf("%s exchange not found", m.Exchange)
+		}
+		f, ok := exch.(exchange.FundingRateExchange)
+		if !ok {
+			return exchange.FundingRate{}, ErrFundingUnavailable
+		}
+		return f.GetFundingRate(m.instrumentID())
+	}
+}
+
+// Calculate returns the basis of buying spot at spotAsk and selling the future
+// at futureBid at now. Dated futures annualise the basis over their time to
+// expiry, perpetual swaps annualise funding, which is nil when it is unknown.
+func Calculate(s Spread, spotAsk, futureBid float64, funding *exchange.FundingRate, now time.Time) (Basis, error) {
+	if spotAsk <= 0 || futureBid <= 0 {
+		return Basis{}, ErrNoQuote
+	}
+	b := Basis{
+		Spread:       s,
+		SpotPrice:    spotAsk,
+		FuturePrice:  futureBid,
+		Basis:        futureBid - spotAsk,
+		BasisPercent: (futureBid - spotAsk) / spotAsk * 100,
+		Time:         now,
+	}
+	if !s.Future.Perpetual() {
+		b.TimeToExpiry = s.Future.Expiry.Sub(now)
+		if b.TimeToExpiry <= 0 {
+			return Basis{}, ErrContractExpired
+		}
+		b.AnnualisedPercent = b.BasisPercent * float64(year) / float64(b.TimeToExpiry)
+		return b, nil
+	}
+	if funding == nil {
+		return b, nil
+	}
+	b.FundingRate = funding.Rate
+	if funding.Interval > 0 {
+		b.AnnualisedPercent = funding.Rate * 100 * float64(year) / float64(funding.Interval)
+	}
+	return b, nil
+}
+
+// Monitor calculates the basis of each of its spreads. Scans report the
+// spreads whose annualised carry is at least MinAnnualisedPercent to
+// OnOpportunity and, when AutoExecute is set, execute a cash-and-carry of
+// Amount with Executor once per spread until it is reset.
+type Monitor struct {
+	Spreads              []Spread
+	Prices               PriceSource
+	Funding              FundingSource
+	MinAnnualisedPercent float64
+	AutoExecute          bool
+	Amount               float64
+	Executor             *Executor
+	OnOpportunity        func(Basis)
+	OnExecution          func(Result)
+
+	latest   []Basis
+	executed map[string]bool
+	m        sync.Mutex
+}
+
+// NewMonitor returns a monitor of spreads priced from the ticker store
+func NewMonitor(spreads []Spread, minAnnualisedPercent float64) *Monitor {
+	return &Monitor{
+		Spreads:              spreads,
+		Prices:               TickerPrices(DefaultMaxAge),
+		MinAnnualisedPercent: minAnnualisedPercent,
+	}
+}
+
+// Scan calculates the basis of each spread at now and returns them ranked by
+// annualised carry. Spreads without current prices are skipped.
+func (m *Monitor) Scan(now time.Time) []Basis {
+	var result []Basis
+	for _, s := range m.Spreads {
+		_, spotAsk, err := m.Prices(s.Spot)
+		if err != nil {
+			continue
+		}
+		futureBid, _, err := m.Prices(s.Future)
+		if err != nil {
+			continue
+		}
+		var funding *exchange.FundingRate
+		if s.Future.Perpetual() && m.Funding != nil {
+			if f, err := m.Funding(s.Future); err == nil {
+				funding = &f
+			}
+		}
+		b, err := Calculate(s, spotAsk, futureBid, funding, now)
+		if err != nil {
+			continue
+		}
+		result = append(result, b)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].AnnualisedPercent > result[j].AnnualisedPercent
+	})
+
+	m.m.Lock()
+	m.latest = result
+	m.m.Unlock()
+
+	for i := range result {
+		if !m.opportunity(result[i]) {
+			continue
+		}
+		if m.OnOpportunity != nil {
+			m.OnOpportunity(result[i])
+		}
+		if m.AutoExecute && m.Executor != nil && m.claim(result[i].Spread) {
+			r := m.Executor.Execute(result[i], m.Amount)
+			if m.OnExecution != nil {
+				m.OnExecution(r)
+			}
+		}
+	}
+	return result
+}
+
+// opportunity returns whether a basis offers positive carry of at least
+// MinAnnualisedPercent
+func (m *Monitor) opportunity(b Basis) bool {
+	return b.AnnualisedPercent > 0 && b.AnnualisedPercent >= m.MinAnnualisedPercent
+}
+
+// claim returns whether a spread has not been executed and marks it executed
+func (m *Monitor) claim(s Spread) bool {
+	m.m.Lock()
+	defer m.m.Unlock()
+	if m.executed == nil {
+		m.executed = make(map[string]bool)
+	}
+	if m.executed[s.String()] {
+		return false
+	}
+	m.executed[s.String()] = true
+	return true
+}
+
+// Reset allows a spread to be executed automatically again, once its
+// position has been closed
+func (m *Monitor) Reset(s Spread) {
+	m.m.Lock()
+	delete(m.executed, s.String())
+	m.m.Unlock()
+}
+
+// Latest returns the ranked basis of the last scan
+func (m *Monitor) Latest() []Basis {
+	m.m.Lock()
+	defer m.m.Unlock()
+	return append([]Basis(nil), m.latest...)
+}
+
+// Run scans the spreads every interval until stop is closed
+func (m *Monitor) Run(interval time.Duration, stop <-chan struct{}) {
+	if interval <= 0 {
+		interval = DefaultScanInterval
+	}
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		m.Scan(time.Now())
+		select {
+		case <-stop:
+			return
+		case <-t.C:
+		}
+	}
+}
@@ -0,0 +1,235 @@
+package basis
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/assets"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+var (
+	btcusd = pair.NewCurrencyPair("BTC", "USD")
+	now    = time.Date(2018, 9, 20, 0, 0, 0, 0, time.UTC)
+
+	spot  = Market{Exchange: "BasisSpot", Pair: btcusd, AssetType: ticker.Spot}
+	dated = Market{Exchange: "BasisFutures", Pair: btcusd, AssetType: assets.Futures,
+		InstrumentID: "BTC-USD-181228", Expiry: now.Add(year / 4)}
+	perp = Market{Exchange: "BasisFutures", Pair: btcusd, AssetType: assets.PerpetualSwap,
+		InstrumentID: "BTC-USD-SWAP"}
+)
+
+// testOrder records a submitted spot order
+type testOrder struct {
+	strategy string
+	side     exchange.OrderSide
+	amount   float64
+}
+
+// testOrderer records spot orders, rejecting them when err is set
+type testOrderer struct {
+	orders []testOrder
+	err    error
+}
+
+func (o *testOrderer) SubmitStrategyOrder(strategy string, exch exchange.IBotExchange, s exchange.OrderSubmission) (int, error) {
+	if o.err != nil {
+		return 0, o.err
+	}
+	o.orders = append(o.orders, testOrder{strategy, s.Side, s.BaseAmount})
+	return len(o.orders), nil
+}
+
+// testFuturesExchange records futures orders and reports a funding rate
+type testFuturesExchange struct {
+	exchange.IBotExchange
+	instruments []string
+	sides       []exchange.OrderSide
+	fail        bool
+}
+
+func (e *testFuturesExchange) GetName() string {
+	return "BasisFutures"
+}
+
+func (e *testFuturesExchange) GetFuturesContracts() ([]exchange.FuturesContract, error) {
+	return nil, nil
+}
+
+func (e *testFuturesExchange) SubmitFuturesOrder(instrumentID string, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, reduceOnly bool, clientID string) (exchange.SubmitOrderResponse, error) {
+	if e.fail {
+		return exchange.SubmitOrderResponse{}, errors.New("insufficient margin")
+	}
+	e.instruments = append(e.instruments, instrumentID)
+	e.sides = append(e.sides, side)
+	return exchange.SubmitOrderResponse{IsOrderPlaced: true, OrderID: "1"}, nil
+}
+
+func (e *testFuturesExchange) GetFundingRate(instrumentID string) (exchange.FundingRate, error) {
+	return exchange.FundingRate{InstrumentID: instrumentID, Rate: 0.0001, Interval: 8 * time.Hour}, nil
+}
+
+// testSpotExchange is a spot only exchange
+type testSpotExchange struct {
+	exchange.IBotExchange
+}
+
+func (e *testSpotExchange) GetName() string {
+	return "BasisSpot"
+}
+
+func testExchanges(futures *testFuturesExchange) exchange.ExchangeGetter {
+	return func(name string) exchange.IBotExchange {
+		switch name {
+		case "BasisSpot":
+			return &testSpotExchange{}
+		case "BasisFutures":
+			return futures
+		}
+		return nil
+	}
+}
+
+func TestCalculate(t *testing.T) {
+	b, err := Calculate(Spread{spot, dated}, 6000, 6150, nil, now)
+	if err != nil {
+		t.Fatal("Test Failed - Calculate() error", err)
+	}
+	if b.Basis != 150 || b.BasisPercent != 2.5 || b.TimeToExpiry != year/4 ||
+		math.Abs(b.AnnualisedPercent-10) > 1e-9 {
+		t.Errorf("Test Failed - Calculate() unexpected dated basis %+v", b)
+	}
+
+	funding := exchange.FundingRate{Rate: 0.0001, Interval: 8 * time.Hour}
+	b, err = Calculate(Spread{spot, perp}, 6000, 5994, &funding, now)
+	if err != nil {
+		t.Fatal("Test Failed - Calculate() error", err)
+	}
+	if b.BasisPercent != -0.1 || b.FundingRate != 0.0001 || math.Abs(b.AnnualisedPercent-10.95) > 1e-9 {
+		t.Errorf("Test Failed - Calculate() unexpected perpetual basis %+v", b)
+	}
+	if b, err = Calculate(Spread{spot, perp}, 6000, 6010, nil, now); err != nil || b.AnnualisedPercent != 0 {
+		t.Error("Test Failed - Calculate() expected no carry without funding", b, err)
+	}
+
+	if _, err = Calculate(Spread{spot, dated}, 6000, 6150, nil, dated.Expiry); err != ErrContractExpired {
+		t.Error("Test Failed - Calculate() expected contract expired error", err)
+	}
+	if _, err = Calculate(Spread{spot, dated}, 0, 6150, nil, now); err != ErrNoQuote {
+		t.Error("Test Failed - Calculate() expected no quote error", err)
+	}
+}
+
+func TestTickerPrices(t *testing.T) {
+	m := Market{Exchange: "BasisTicker", Pair: btcusd, AssetType: assets.Futures}
+	ticker.ProcessTicker(m.Exchange, btcusd, ticker.Price{Pair: btcusd, Last: 6100, Bid: 6099}, assets.Futures)
+
+	bid, ask, err := TickerPrices(time.Minute)(m)
+	if err != nil || bid != 6099 || ask != 6100 {
+		t.Error("Test Failed - TickerPrices() unexpected prices", bid, ask, err)
+	}
+	m.AssetType = ticker.Spot
+	if _, _, err = TickerPrices(time.Minute)(m); err != ErrNoQuote {
+		t.Error("Test Failed - TickerPrices() expected no quote error", err)
+	}
+}
+
+func TestMonitorScan(t *testing.T) {
+	prices := map[string][2]float64{
+		spot.String():  {5999, 6000},
+		dated.String(): {6150, 6151},
+		perp.String():  {6001, 6002},
+	}
+	futures := &testFuturesExchange{}
+	orderer := &testOrderer{}
+	m := NewMonitor([]Spread{{spot, perp}, {spot, dated}, {Spot: spot, Future: Market{Exchange: "Unquoted"}}}, 10)
+	m.Prices = func(mkt Market) (float64, float64, error) {
+		p, ok := prices[mkt.String()]
+		if !ok {
+			return 0, 0, ErrNoQuote
+		}
+		return p[0], p[1], nil
+	}
+	m.Funding = ExchangeFunding(testExchanges(futures))
+	m.AutoExecute = true
+	m.Amount = 0.5
+	m.Executor = &Executor{Orderer: orderer, GetExchange: testExchanges(futures)}
+
+	var opportunities []Basis
+	var results []Result
+	m.OnOpportunity = func(b Basis) { opportunities = append(opportunities, b) }
+	m.OnExecution = func(r Result) { results = append(results, r) }
+
+	scan := m.Scan(now)
+	if len(scan) != 2 || scan[0].Spread.Future.InstrumentID != "BTC-USD-SWAP" ||
+		scan[1].Spread.Future.InstrumentID != "BTC-USD-181228" || len(m.Latest()) != 2 {
+		t.Fatalf("Test Failed - Scan() unexpected ranking %+v", scan)
+	}
+	if len(opportunities) != 2 || len(results) != 2 || results[1].Err != nil ||
+		!results[1].SpotPlaced || !results[1].FuturesPlaced {
+		t.Errorf("Test Failed - Scan() unexpected executions %+v", results)
+	}
+
+	// Executed spreads are not executed again until reset
+	m.Scan(now)
+	if len(opportunities) != 4 || len(results) != 2 {
+		t.Error("Test Failed - Scan() expected spreads executed once", len(opportunities), len(results))
+	}
+	m.Reset(Spread{spot, dated})
+	m.Scan(now)
+	if len(results) != 3 || results[2].Basis.Spread.Future.InstrumentID != "BTC-USD-181228" {
+		t.Error("Test Failed - Scan() expected reset spread executed", results)
+	}
+
+	m.MinAnnualisedPercent = 11
+	opportunities = nil
+	m.Scan(now)
+	if len(opportunities) != 0 {
+		t.Error("Test Failed - Scan() expected no opportunities above threshold", opportunities)
+	}
+}
+
+func TestExecute(t *testing.T) {
+	futures := &testFuturesExchange{}
+	orderer := &testOrderer{}
+	e := &Executor{Orderer: orderer, GetExchange: testExchanges(futures)}
+	b, err := Calculate(Spread{spot, dated}, 6000, 6150, nil, now)
+	if err != nil {
+		t.Fatal("Test Failed - Calculate() error", err)
+	}
+
+	r := e.Execute(b, 2)
+	if r.Err != nil || !r.SpotPlaced || !r.FuturesPlaced || r.SpotOrderID != 1 || r.FuturesOrderID == 0 {
+		t.Fatalf("Test Failed - Execute() unexpected result %+v", r)
+	}
+	if len(orderer.orders) != 1 || orderer.orders[0] != (testOrder{StrategyName, exchange.Buy, 2}) ||
+		len(futures.instruments) != 1 || futures.instruments[0] != "BTC-USD-181228" || futures.sides[0] != exchange.Sell {
+		t.Errorf("Test Failed - Execute() unexpected orders %+v %+v", orderer.orders, futures)
+	}
+
+	// A failed futures leg sells the spot bought
+	futures.fail = true
+	r = e.Execute(b, 2)
+	if r.Err == nil || !r.SpotPlaced || r.FuturesPlaced || !r.Unwound ||
+		len(orderer.orders) != 3 || orderer.orders[2].side != exchange.Sell {
+		t.Errorf("Test Failed - Execute() expected spot leg unwound %+v", r)
+	}
+
+	// A throttled spot leg places nothing
+	futures.fail = false
+	orderer.err = errors.New("max open orders")
+	if r = e.Execute(b, 2); r.Err == nil || r.SpotPlaced || len(futures.instruments) != 1 {
+		t.Errorf("Test Failed - Execute() expected nothing placed %+v", r)
+	}
+
+	if r = e.Execute(b, 0); r.Err != ErrInvalidAmount {
+		t.Error("Test Failed - Execute() expected invalid amount error", r.Err)
+	}
+	if r = e.Execute(Basis{Spread: Spread{dated, spot}}, 1); r.Err != ErrFuturesNotSupported {
+		t.Error("Test Failed - Execute() expected futures not supported error", r.Err)
+	}
+}
@@ -0,0 +1,99 @@
+package basis
+
+import (
+	"fmt"
+
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/orders"
+)
+
+// StrategyName is the strategy cash-and-carry spot orders are submitted and
+// throttled as
+const StrategyName = "cashandcarry"
+
+// Result holds the outcome of a cash-and-carry. The order IDs are local order
+// manager IDs, FuturesOrderID is only valid when FuturesPlaced is set. When
+// the futures leg fails the spot leg is sold back and Unwound is set once it
+// has been.
+type Result struct {
+	Basis          Basis
+	Amount         float64
+	SpotOrderID    int
+	FuturesOrderID int
+	SpotPlaced     bool
+	FuturesPlaced  bool
+	Unwound        bool
+	Err            error
+}
+
+// Executor executes cash-and-carry trades, buying spot through Orderer and
+// selling the future on exchanges implementing exchange.FuturesExchange
+type Executor struct {
+	Orderer     exchange.Orderer
+	GetExchange exchange.ExchangeGetter
+}
+
+// Execute buys amount of the spread's spot market and sells the same amount of
+// its future, both at market. The spot leg is placed first so a throttled or
+// rejected order leaves no position.
+func (e *Executor) Execute(b Basis, amount float64) Result {
+	result := Result{Basis: b, Amount: amount}
+	if amount <= 0 {
+		result.Err = ErrInvalidAmount
+		return result
+	}
+	s := b.Spread
+	spotExch := e.GetExchange(s.Spot.Exchange)
+	if spotExch == nil {
+		result.Err = fmt.Errorf("%s exchange not found", s.Spot.Exchange)
+		return result
+	}
+	futuresExch := e.GetExchange(s.Future.Exchange)
+	if futuresExch == nil {
+		result.Err = fmt.Errorf("%s exchange not found", s.Future.Exchange)
+		return result
+	}
+	f, ok := futuresExch.(exchange.FuturesExchange)
+	if !ok {
+		result.Err = ErrFuturesNotSupported
+		return result
+	}
+
+	id, err := e.Orderer.SubmitStrategyOrder(StrategyName, spotExch, exchange.OrderSubmission{
+		Pair:       s.Spot.Pair,
+		Side:       exchange.Buy,
+		Type:       exchange.Market,
+		BaseAmount: amount,
+	})
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	result.SpotPlaced = true
+	result.SpotOrderID = id
+
+	instrumentID := s.Future.instrumentID()
+	resp, err := f.SubmitFuturesOrder(instrumentID, exchange.Sell, exchange.Market, amount, 0, false, "")
+	if err == nil && !resp.IsOrderPlaced {
+		err = fmt.Errorf("%s sell order for %s was not placed", s.Future.Exchange, instrumentID)
+	}
+	if err != nil {
+		result.Err = err
+		_, unwindErr := e.Orderer.SubmitStrategyOrder(StrategyName, spotExch, exchange.OrderSubmission{
+			Pair:       s.Spot.Pair,
+			Side:       exchange.Sell,
+			Type:       exchange.Market,
+			BaseAmount: amount,
+		})
+		if unwindErr != nil {
+			result.Err = fmt.Errorf("%s, spot leg not unwound: %s", err, unwindErr)
+		} else {
+			result.Unwound = true
+		}
+		return result
+	}
+	result.FuturesPlaced = true
+	result.FuturesOrderID = orders.TrackOrder(s.Future.Exchange, resp.OrderID, s.Future.Pair,
+		exchange.Sell, exchange.Market, amount, 0)
+	return result
+}
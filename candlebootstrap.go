@@ -0,0 +1,212 @@
+package main
+
+import (
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/backtest"
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	"github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+// Candle bootstrap files and defaults
+const (
+	candleBootstrapDir      = "candles"
+	candleBootstrapFile     = "candlebootstrap.json"
+	candleBootstrapPageSize = 500
+)
+
+// CandleBootstrap backfills the historic candles of every enabled spot pair of
+// the exchanges at each interval into a candle store. Candles are fetched a
+// page at a time through the exchanges' rate limited requesters, and the
+// progress of each pair and interval is saved after every page so an
+// interrupted bootstrap resumes where it stopped. Later runs only fetch the
+// candles closed since the previous run.
+type CandleBootstrap struct {
+	Days      int
+	Intervals []kline.Interval
+	Store     backtest.CandleStore
+	PageSize  int
+	StateFile string
+
+	progress map[string]time.Time
+	m        sync.Mutex
+}
+
+// NewCandleBootstrap returns a candle bootstrap saving candles to store and
+// its progress to stateFile, loading the progress of an earlier run
+func NewCandleBootstrap(days int, intervals []kline.Interval, store backtest.CandleStore, stateFile string) (*CandleBootstrap, error) {
+	c := &CandleBootstrap{
+		Days:      days,
+		Intervals: intervals,
+		Store:     store,
+		PageSize:  candleBootstrapPageSize,
+		StateFile: stateFile,
+		progress:  make(map[string]time.Time),
+	}
+	data, err := common.ReadFile(stateFile)
+	if os.IsNotExist(err) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = common.JSONDecode(data, &c.progress); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Progress returns the time an exchange pair's candles at an interval have
+// been backfilled until, zero when not yet started
+func (c *CandleBootstrap) Progress(exchName string, p pair.CurrencyPair, interval kline.Interval) time.Time {
+	c.m.Lock()
+	defer c.m.Unlock()
+	return c.progress[candleBootstrapKey(exchName, p, interval)]
+}
+
+// Bootstrap backfills the candles of the exchanges until now and returns the
+// number of candles saved. Exchanges without historic candle capability are
+// skipped, a pair whose candles fail to be fetched or saved is left to resume
+// on the next run.
+func (c *CandleBootstrap) Bootstrap(exchs []exchange.IBotExchange) int {
+	return c.bootstrap(exchs, time.Now())
+}
+
+// Run bootstraps the candles of the bot's exchanges
+func (c *CandleBootstrap) Run() {
+	log.Println("Starting candle bootstrap routine.")
+	saved := c.Bootstrap(bot.exchanges)
+	log.Printf("Candle bootstrap finished, %d candles saved.\n", saved)
+}
+
+// bootstrap backfills the candles of the exchanges closed by now
+func (c *CandleBootstrap) bootstrap(exchs []exchange.IBotExchange, now time.Time) int {
+	var saved int
+	for _, exch := range exchs {
+		if exch == nil || !exch.IsEnabled() {
+			continue
+		}
+		if !exch.GetCapabilities().CanGetHistoricCandles {
+			log.Printf("Candle bootstrap skipping %s, historic candles are not supported.\n",
+				exch.GetName())
+			continue
+		}
+		for _, p := range exch.GetEnabledCurrencies() {
+			for _, interval := range c.Intervals {
+				n, err := c.backfill(exch, p, interval, now)
+				saved += n
+				if err != nil {
+					log.Printf("Candle bootstrap failed to backfill %s %s %s candles. Err: %s\n",
+						exch.GetName(), p.Pair(), interval.Short(), err)
+				}
+			}
+		}
+	}
+	return saved
+}
+
+// backfill fetches and saves the candles of an exchange pair at an interval
+// from where the previous run stopped until the last closed candle
+func (c *CandleBootstrap) backfill(exch exchange.IBotExchange, p pair.CurrencyPair, interval kline.Interval, now time.Time) (int, error) {
+	d := interval.Duration()
+	end := now.Truncate(d)
+	start := end.Add(-time.Duration(c.Days) * 24 * time.Hour)
+	if from := c.Progress(exch.GetName(), p, interval); from.After(start) {
+		start = from
+	}
+
+	var saved int
+	for _, r := range kline.CalculateRanges(interval, start, end, c.PageSize) {
+		candles, err := exch.GetHistoricCandles(p, ticker.Spot, interval, r.Start, r.End)
+		if err != nil {
+			return saved, err
+		}
+		if len(candles) > 0 {
+			err = c.Store.SaveCandles(exch.GetName(), p, d, toBacktestCandles(candles))
+			if err != nil {
+				return saved, err
+			}
+			saved += len(candles)
+		}
+		if err = c.setProgress(exch.GetName(), p, interval, r.End); err != nil {
+			return saved, err
+		}
+	}
+	return saved, nil
+}
+
+// setProgress records the time a pair's candles have been backfilled until
+// and saves the progress to the state file
+func (c *CandleBootstrap) setProgress(exchName string, p pair.CurrencyPair, interval kline.Interval, t time.Time) error {
+	c.m.Lock()
+	defer c.m.Unlock()
+	c.progress[candleBootstrapKey(exchName, p, interval)] = t
+	if c.StateFile == "" {
+		return nil
+	}
+	data, err := common.JSONEncode(c.progress)
+	if err != nil {
+		return err
+	}
+	return common.WriteFile(c.StateFile, data)
+}
+
+// candleBootstrapKey identifies the candles of an exchange pair at an interval
+func candleBootstrapKey(exchName string, p pair.CurrencyPair, interval kline.Interval) string {
+	return common.StringToLower(exchName) + " " + p.Pair().Upper().String() + " " + interval.Short()
+}
+
+// toBacktestCandles converts exchange candles to stored candles
+func toBacktestCandles(candles []kline.Candle) []backtest.Candle {
+	result := make([]backtest.Candle, len(candles))
+	for i := range candles {
+		result[i] = backtest.Candle{
+			Time:   candles[i].Time,
+			Open:   candles[i].Open,
+			High:   candles[i].High,
+			Low:    candles[i].Low,
+			Close:  candles[i].Close,
+			Volume: candles[i].Volume,
+		}
+	}
+	return result
+}
+
+// SetupCandleBootstrap starts backfilling historic candles into the data
+// directory when enabled in the config
+func SetupCandleBootstrap() {
+	cfg := bot.config.CandleBootstrap
+	if !cfg.Enabled {
+		log.Println("Candle bootstrap disabled.")
+		return
+	}
+
+	var intervals []kline.Interval
+	for _, i := range cfg.Intervals {
+		d, err := time.ParseDuration(i)
+		if err != nil {
+			log.Printf("Candle bootstrap skipping invalid interval %s. Err: %s", i, err)
+			continue
+		}
+		intervals = append(intervals, kline.Interval(d))
+	}
+
+	dir := bot.dataDir + common.GetOSPathSlash()
+	store, err := backtest.NewFileCandleStore(dir + candleBootstrapDir)
+	if err != nil {
+		log.Printf("Failed to open candle store. Err: %s", err)
+		return
+	}
+	bootstrap, err := NewCandleBootstrap(cfg.Days, intervals, store, dir+candleBootstrapFile)
+	if err != nil {
+		log.Printf("Failed to load candle bootstrap progress. Err: %s", err)
+		return
+	}
+	go bootstrap.Run()
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/backtest"
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	"github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
+)
+
+// testCandleExchange returns a candle per interval of each request, failing
+// the request numbered fail
+type testCandleExchange struct {
+	exchange.IBotExchange
+	name        string
+	unsupported bool
+	fail        int
+	requests    int
+}
+
+func (e *testCandleExchange) GetName() string {
+	return e.name
+}
+
+func (e *testCandleExchange) IsEnabled() bool {
+	return true
+}
+
+func (e *testCandleExchange) GetEnabledCurrencies() []pair.CurrencyPair {
+	return []pair.CurrencyPair{pair.NewCurrencyPair("BTC", "USD")}
+}
+
+func (e *testCandleExchange) GetCapabilities() exchange.Features {
+	return exchange.Features{CanGetHistoricCandles: !e.unsupported}
+}
+
+func (e *testCandleExchange) GetHistoricCandles(p pair.CurrencyPair, assetType string, interval kline.Interval, start, end time.Time) ([]kline.Candle, error) {
+	if e.unsupported {
+		return nil, common.ErrFunctionNotSupported
+	}
+	e.requests++
+	if e.fail--; e.fail == 0 {
+		return nil, errors.New("connection reset")
+	}
+	var candles []kline.Candle
+	for t := start; t.Before(end); t = t.Add(interval.Duration()) {
+		candles = append(candles, kline.Candle{Time: t, Open: 1, High: 2, Low: 0.5, Close: 1.5, Volume: 10})
+	}
+	return candles, nil
+}
+
+func TestCandleBootstrap(t *testing.T) {
+	dir, err := ioutil.TempDir("", "candlebootstrap")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	store, err := backtest.NewFileCandleStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stateFile := filepath.Join(dir, candleBootstrapFile)
+	c, err := NewCandleBootstrap(1, []kline.Interval{kline.OneHour}, store, stateFile)
+	if err != nil {
+		t.Fatal("Test failed. NewCandleBootstrap() error", err)
+	}
+	c.PageSize = 10
+
+	now := time.Date(2018, 10, 2, 0, 30, 0, 0, time.UTC)
+	exch := &testCandleExchange{name: "CandleTest", fail: 2}
+	unsupported := &testCandleExchange{name: "Unsupported", unsupported: true}
+	exchs := []exchange.IBotExchange{unsupported, exch}
+
+	// The second page fails, leaving the first saved
+	if saved := c.bootstrap(exchs, now); saved != 10 {
+		t.Error("Test failed. bootstrap() expected 10 candles saved", saved)
+	}
+	p := pair.NewCurrencyPair("BTC", "USD")
+	start := time.Date(2018, 10, 1, 0, 0, 0, 0, time.UTC)
+	if progress := c.Progress("CandleTest", p, kline.OneHour); !progress.Equal(start.Add(10 * time.Hour)) {
+		t.Error("Test failed. Progress() unexpected progress", progress)
+	}
+
+	// A new bootstrap resumes from the saved progress
+	c, err = NewCandleBootstrap(1, []kline.Interval{kline.OneHour}, store, stateFile)
+	if err != nil {
+		t.Fatal("Test failed. NewCandleBootstrap() error", err)
+	}
+	c.PageSize = 10
+	exch.requests = 0
+	if saved := c.bootstrap(exchs, now); saved != 14 || exch.requests != 2 {
+		t.Error("Test failed. bootstrap() expected remaining 14 candles saved", saved, exch.requests)
+	}
+	candles, err := store.LoadCandles("CandleTest", p, time.Hour, start, time.Time{})
+	if err != nil || len(candles) != 24 {
+		t.Error("Test failed. LoadCandles() expected 24 candles", len(candles), err)
+	}
+
+	// Later runs only fetch newly closed candles
+	if saved := c.bootstrap(exchs, now.Add(time.Hour)); saved != 1 {
+		t.Error("Test failed. bootstrap() expected 1 new candle saved", saved)
+	}
+	if progress := c.Progress("Unsupported", p, kline.OneHour); !progress.IsZero() {
+		t.Error("Test failed. Progress() expected unsupported exchange skipped", progress)
+	}
+}
@@ -269,6 +269,83 @@ func StringToLower(input string) string {
 	return strings.ToLower(input)
 }
 
+// RoundingMode is the rounding behaviour used when snapping a value to an
+// increment such as an exchange price tick or amount step
+type RoundingMode string
+
+// Rounding modes, RoundHalfEven is banker's rounding and RoundTruncate rounds
+// towards zero. RoundBySide is resolved by the caller to RoundDown for buys
+// and RoundUp for sells.
+const (
+	RoundHalfUp   RoundingMode = "halfUp"
+	RoundHalfEven RoundingMode = "halfEven"
+	RoundDown     RoundingMode = "down"
+	RoundUp       RoundingMode = "up"
+	RoundTruncate RoundingMode = "truncate"
+	RoundBySide   RoundingMode = "bySide"
+)
+
+// incrementTolerance absorbs floating point error when a value is already a
+// multiple of the increment
+const incrementTolerance = 1e-9
+
+// ParseRoundingMode returns the rounding mode for a case insensitive name
+func ParseRoundingMode(mode string) (RoundingMode, error) {
+	for _, m := range []RoundingMode{RoundHalfUp, RoundHalfEven, RoundDown,
+		RoundUp, RoundTruncate, RoundBySide} {
+		if strings.EqualFold(mode, string(m)) {
+			return m, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported rounding mode %q", mode)
+}
+
+// RoundToIncrement snaps a value to a multiple of increment using the
+// rounding mode. The result is rounded to the decimal places of the increment
+// so it formats cleanly. A zero increment returns the value unchanged and
+// RoundBySide is treated as RoundHalfUp.
+func RoundToIncrement(value, increment float64, mode RoundingMode) float64 {
+	if increment <= 0 {
+		return value
+	}
+
+	steps := value / increment
+	nearest := math.Round(steps)
+	if math.Abs(steps-nearest) < incrementTolerance {
+		steps = nearest
+	} else {
+		floor := math.Floor(steps)
+		half := math.Abs(steps-floor-0.5) < incrementTolerance
+		switch mode {
+		case RoundHalfEven:
+			if half {
+				steps = floor + math.Mod(math.Abs(floor), 2)
+			} else {
+				steps = nearest
+			}
+		case RoundDown:
+			steps = floor
+		case RoundUp:
+			steps = math.Ceil(steps)
+		case RoundTruncate:
+			steps = math.Trunc(steps)
+		default:
+			if half {
+				steps = floor + 1
+			} else {
+				steps = nearest
+			}
+		}
+	}
+
+	decimals := 0
+	if s := strconv.FormatFloat(increment, 'f', -1, 64); strings.Contains(s, ".") {
+		decimals = len(s) - strings.Index(s, ".") - 1
+	}
+	pow := math.Pow(10, float64(decimals))
+	return math.Round(steps*increment*pow) / pow
+}
+
 // RoundFloat rounds your floating point number to the desired decimal place
 func RoundFloat(x float64, prec int) float64 {
 	var rounder float64
@@ -506,6 +583,100 @@ func UnixTimestampStrToTime(timeStr string) (time.Time, error) {
 	return time.Unix(i, 0), nil
 }
 
+// TimestampPrecision is the precision of a unix timestamp sent by an
+// exchange
+type TimestampPrecision uint8
+
+// Unix timestamp precisions
+const (
+	PrecisionSeconds TimestampPrecision = iota
+	PrecisionMilliseconds
+	PrecisionMicroseconds
+	PrecisionNanoseconds
+)
+
+// timestampLayouts are the date string layouts accepted by ParseTimestamp
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	time.RFC1123,
+	time.RFC1123Z,
+}
+
+// DetectTimestampPrecision returns the precision of a unix timestamp from
+// its magnitude. Second timestamps are valid until the year 5138 and the
+// smaller units until 1973 and later, so any current timestamp is detected
+// correctly.
+func DetectTimestampPrecision(ts int64) TimestampPrecision {
+	if ts < 0 {
+		ts = -ts
+	}
+	switch {
+	case ts < 1e11:
+		return PrecisionSeconds
+	case ts < 1e14:
+		return PrecisionMilliseconds
+	case ts < 1e17:
+		return PrecisionMicroseconds
+	default:
+		return PrecisionNanoseconds
+	}
+}
+
+// UnixTimestampToUTC converts a unix timestamp in seconds, milliseconds,
+// microseconds or nanoseconds to a UTC time keeping its full precision
+func UnixTimestampToUTC(ts int64) time.Time {
+	switch DetectTimestampPrecision(ts) {
+	case PrecisionSeconds:
+		return time.Unix(ts, 0).UTC()
+	case PrecisionMilliseconds:
+		return time.Unix(0, ts*int64(time.Millisecond)).UTC()
+	case PrecisionMicroseconds:
+		return time.Unix(0, ts*int64(time.Microsecond)).UTC()
+	default:
+		return time.Unix(0, ts).UTC()
+	}
+}
+
+// ParseTimestamp parses a timestamp string sent by an exchange into UTC. The
+// string can be a unix timestamp in any precision, a decimal unix timestamp
+// in seconds or a date string. Date strings without a zone are in loc, or UTC
+// if loc is nil.
+func ParseTimestamp(s string, loc *time.Location) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, errors.New("empty timestamp")
+	}
+
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return UnixTimestampToUTC(i), nil
+	}
+
+	// Decimal seconds are parsed from the digits rather than as a float so
+	// the fraction is kept exactly, up to nanoseconds
+	if parts := strings.Split(s, "."); len(parts) == 2 && len(parts[1]) > 0 &&
+		len(parts[1]) <= 9 {
+		sec, errSec := strconv.ParseInt(parts[0], 10, 64)
+		nsec, errNsec := strconv.ParseUint(parts[1]+strings.Repeat("0", 9-len(parts[1])), 10, 64)
+		if errSec == nil && errNsec == nil {
+			return time.Unix(sec, int64(nsec)).UTC(), nil
+		}
+	}
+
+	if loc == nil {
+		loc = time.UTC
+	}
+	for i := range timestampLayouts {
+		t, err := time.ParseInLocation(timestampLayouts[i], s, loc)
+		if err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unable to parse timestamp %s", s)
+}
+
 // ReadFile reads a file and returns read data as byte array.
 func ReadFile(path string) ([]byte, error) {
 	file, err := ioutil.ReadFile(path)
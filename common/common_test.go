@@ -955,3 +955,111 @@ func TestTimeFromUnixTimestampFloat(t *testing.T) {
 		t.Error("Test failed. Common TimeFromUnixTimestampFloat. Converted invalid syntax.")
 	}
 }
+
+func TestUnixTimestampToUTC(t *testing.T) {
+	t.Parallel()
+	expected := time.Date(2018, 6, 26, 8, 0, 0, 0, time.UTC)
+	tester := []struct {
+		Timestamp int64
+		Precision TimestampPrecision
+		Expected  time.Time
+	}{
+		{1530000000, PrecisionSeconds, expected},
+		{1530000000123, PrecisionMilliseconds, expected.Add(123 * time.Millisecond)},
+		{1530000000123456, PrecisionMicroseconds, expected.Add(123456 * time.Microsecond)},
+		{1530000000123456789, PrecisionNanoseconds, expected.Add(123456789)},
+	}
+
+	for i := range tester {
+		if p := DetectTimestampPrecision(tester[i].Timestamp); p != tester[i].Precision {
+			t.Errorf("Test failed. DetectTimestampPrecision(%d) expected %d received %d",
+				tester[i].Timestamp, tester[i].Precision, p)
+		}
+		result := UnixTimestampToUTC(tester[i].Timestamp)
+		if !result.Equal(tester[i].Expected) || result.Location() != time.UTC {
+			t.Errorf("Test failed. UnixTimestampToUTC(%d) expected %s received %s",
+				tester[i].Timestamp, tester[i].Expected, result)
+		}
+	}
+}
+
+func TestParseTimestamp(t *testing.T) {
+	t.Parallel()
+	expected := time.Date(2018, 6, 26, 8, 0, 0, 0, time.UTC)
+	seoul := time.FixedZone("KST", 9*60*60)
+
+	tester := []struct {
+		Timestamp string
+		Location  *time.Location
+		Expected  time.Time
+	}{
+		{"1530000000", nil, expected},
+		{"1530000000123", nil, expected.Add(123 * time.Millisecond)},
+		{"1530000000.123456789", nil, expected.Add(123456789)},
+		{"1530000000.5", nil, expected.Add(500 * time.Millisecond)},
+		{"2018-06-26T08:00:00.25Z", nil, expected.Add(250 * time.Millisecond)},
+		{"2018-06-26T10:00:00+02:00", nil, expected},
+		{"2018-06-26 08:00:00", nil, expected},
+		{"2018-06-26 17:00:00", seoul, expected},
+		{"2018-06-26T17:00:00", seoul, expected},
+	}
+
+	for i := range tester {
+		result, err := ParseTimestamp(tester[i].Timestamp, tester[i].Location)
+		if err != nil {
+			t.Errorf("Test failed. ParseTimestamp(%s) error %s", tester[i].Timestamp, err)
+			continue
+		}
+		if !result.Equal(tester[i].Expected) || result.Location() != time.UTC {
+			t.Errorf("Test failed. ParseTimestamp(%s) expected %s received %s",
+				tester[i].Timestamp, tester[i].Expected, result)
+		}
+	}
+
+	if _, err := ParseTimestamp("DINGDONG", nil); err == nil {
+		t.Error("Test failed. ParseTimestamp() expected error")
+	}
+	if _, err := ParseTimestamp("", nil); err == nil {
+		t.Error("Test failed. ParseTimestamp() expected empty timestamp error")
+	}
+}
+
+func TestRoundToIncrement(t *testing.T) {
+	t.Parallel()
+	tester := []struct {
+		Value     float64
+		Increment float64
+		Mode      RoundingMode
+		Expected  float64
+	}{
+		{0.3, 0.1, RoundDown, 0.3},
+		{1.2345, 0.01, RoundDown, 1.23},
+		{1.2345, 0.01, RoundUp, 1.24},
+		{1.2345, 0.01, RoundTruncate, 1.23},
+		{-1.2345, 0.01, RoundTruncate, -1.23},
+		{-1.2345, 0.01, RoundDown, -1.24},
+		{0.35, 0.1, RoundHalfUp, 0.4},
+		{0.25, 0.1, RoundHalfUp, 0.3},
+		{0.35, 0.1, RoundHalfEven, 0.4},
+		{0.25, 0.1, RoundHalfEven, 0.2},
+		{0.26, 0.1, RoundHalfEven, 0.3},
+		{6401.3, 0.5, RoundBySide, 6401.5},
+		{123.456, 0, RoundDown, 123.456},
+		{1234, 5, RoundDown, 1230},
+	}
+
+	for i := range tester {
+		result := RoundToIncrement(tester[i].Value, tester[i].Increment, tester[i].Mode)
+		if result != tester[i].Expected {
+			t.Errorf("Test failed. RoundToIncrement(%v, %v, %s) expected %v received %v",
+				tester[i].Value, tester[i].Increment, tester[i].Mode, tester[i].Expected, result)
+		}
+	}
+
+	if m, err := ParseRoundingMode("HALFEVEN"); err != nil || m != RoundHalfEven {
+		t.Error("Test failed. ParseRoundingMode() unexpected result", m, err)
+	}
+	if _, err := ParseRoundingMode("sideways"); err == nil {
+		t.Error("Test failed. ParseRoundingMode() expected error")
+	}
+}
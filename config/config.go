@@ -10,6 +10,7 @@ import (
 	"path"
 	"runtime"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -17,7 +18,10 @@ import (
 	"github.com/thrasher-/gocryptotrader/currency"
 	"github.com/thrasher-/gocryptotrader/currency/forexprovider"
 	"github.com/thrasher-/gocryptotrader/currency/forexprovider/base"
+	"github.com/thrasher-/gocryptotrader/currency/locale"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
+	"github.com/thrasher-/gocryptotrader/exchanges/assets"
+	"github.com/thrasher-/gocryptotrader/exchanges/fees"
 	"github.com/thrasher-/gocryptotrader/portfolio"
 )
 
@@ -33,6 +37,50 @@ const (
 	configPairsLastUpdatedWarningThreshold = 30 // 30 days
 	configDefaultHTTPTimeout               = time.Duration(time.Second * 15)
 	configMaxAuthFailres                   = 3
+	configDefaultCandleBootstrapDays       = 30
+	configDefaultCandleBootstrapInterval   = "1h"
+	configDefaultMetadataCacheSizeMB       = 50
+	configDefaultMetadataCacheMaxAge       = "24h"
+	configDefaultArbitrageScanInterval     = "10s"
+	configDefaultArbitrageMaxAge           = "1m"
+	configDefaultArbitrageAmount           = 1
+	configDefaultStatementFormats          = "html,pdf"
+	configDefaultPortfolioSyncInterval     = "5m"
+	configDefaultStrategyCandleInterval    = "1m"
+	configDefaultStrategyBridgeAddress     = "localhost:9053"
+	configDefaultBasisScanInterval         = "1m"
+	configDefaultHedgingScanInterval       = "1h"
+	configDefaultHedgingCandleInterval     = "1h"
+	configDefaultHedgingWindow             = 30
+	configDefaultHedgingMinCorrelation     = 0.7
+	configDefaultLiquidationPollInterval   = "1m"
+	configDefaultLiquidationMaxMarginRatio = 0.8
+	configDefaultLiquidationMinDistance    = 10
+	configDefaultCalendarPollInterval      = "1h"
+	configDefaultCalendarRollWindow        = "72h"
+	configDefaultMarginPollInterval        = "30s"
+	configDefaultMarginAlertRatio          = 0.7
+	configDefaultDatabasePruneInterval     = "1h"
+	configDefaultDustSweepInterval         = "24h"
+	configDefaultDustSweepQuoteCurrency    = "USDT"
+	configDefaultHealthCheckInterval       = "1m"
+	configDefaultHealthCheckTimeout        = "30s"
+	configDefaultHealthFailureThreshold    = 3
+	configDefaultHealthRetention           = "720h"
+	configDefaultAPIKeyExpiryInterval      = "12h"
+	configDefaultAPIKeyExpiryAlertDays     = 14
+	configDefaultSyncWebsocketTimeout      = "30s"
+	configDefaultWithdrawalBatchInterval   = "10m"
+	configDefaultWithdrawalBatchMaxDelay   = "24h"
+)
+
+// Sync sources keeping an exchange's tickers and orderbooks updated, either
+// REST polling, websocket updates or websocket updates falling back to REST
+// polling while the websocket is down
+const (
+	SyncSourceREST              = "REST"
+	SyncSourceWebsocket         = "WEBSOCKET"
+	SyncSourceWebsocketFallback = "WEBSOCKET_FALLBACK"
 )
 
 // Constants here hold some messages
@@ -47,15 +95,68 @@ const (
 	ErrFailureOpeningConfig                         = "Fatal error opening %s file. Error: %s"
 	ErrCheckingConfigValues                         = "Fatal error checking config values. Error: %s"
 	ErrSavingConfigBytesMismatch                    = "Config file %q bytes comparison doesn't match, read %s expected %s."
+	ErrOrderThrottleStrategyEmpty                   = "Order throttle: Strategy name is empty."
+	ErrOrderThrottleValuesInvalid                   = "Order throttle %s: Limits cannot be negative."
 	WarningSMSGlobalDefaultOrEmptyValues            = "WARNING -- SMS Support disabled due to default or empty Username/Password values."
 	WarningSSMSGlobalSMSContactDefaultOrEmptyValues = "WARNING -- SMS contact #%d Name/Number disabled due to default or empty values."
 	WarningSSMSGlobalSMSNoContacts                  = "WARNING -- SMS Support disabled due to no enabled contacts."
 	WarningWebserverCredentialValuesEmpty           = "WARNING -- Webserver support disabled due to empty Username/Password values."
 	WarningWebserverListenAddressInvalid            = "WARNING -- Webserver support disabled due to invalid listen address."
 	WarningWebserverRootWebFolderNotFound           = "WARNING -- Webserver support disabled due to missing web folder."
+	WarningGRPCCredentialValuesEmpty                = "WARNING -- gRPC support disabled due to empty Username/Password values."
+	WarningGRPCListenAddressInvalid                 = "WARNING -- gRPC support disabled due to invalid listen address."
+	WarningDatabaseDriverUnsupported                = "WARNING -- Database support disabled due to an unsupported driver, use sqlite or postgres."
+	WarningDatabaseNameEmpty                        = "WARNING -- Database support disabled due to an empty database name."
+	WarningDatabaseHostEmpty                        = "WARNING -- Database support disabled due to an empty postgres host."
+	WarningDatabaseRetentionInvalid                 = "WARNING -- Database support disabled due to invalid retention duration %q, use durations such as 24h or 720h."
+	WarningCandleBootstrapIntervalInvalid           = "WARNING -- Candle bootstrap disabled due to invalid interval %q, use durations such as 1h or 24h."
+	WarningMetadataCacheMaxAgeInvalid               = "WARNING -- Metadata cache disabled due to invalid max age %q, use durations such as 1h or 24h."
+	WarningArbitrageDurationInvalid                 = "WARNING -- Arbitrage scanner disabled due to invalid duration %q, use durations such as 10s or 1m."
+	WarningArbitrageValuesInvalid                   = "WARNING -- Arbitrage scanner disabled due to a negative minimum profit or taker fee."
+	WarningStatementsFormatInvalid                  = "WARNING -- Account statements disabled due to unsupported format %q, use html or pdf."
+	WarningStatementsEmailUnavailable               = "WARNING -- Account statements will not be emailed as SMTP is not enabled."
+	WarningPortfolioSyncIntervalInvalid             = "WARNING -- Portfolio sync disabled due to invalid interval %q, use durations such as 5m or 1h."
+	WarningPortfolioSyncMaxSnapshotsInvalid         = "WARNING -- Portfolio sync disabled due to a negative max snapshots."
+	WarningStrategiesCandleIntervalInvalid          = "WARNING -- Strategies disabled due to invalid candle interval %q, use durations such as 1m or 1h."
+	WarningStrategyRSIInvalid                       = "WARNING -- Strategies disabled due to RSI strategy %d requiring an exchange, a pair such as BTC-USD and an order size greater than zero."
+	WarningStrategyMarketMakerInvalid               = "WARNING -- Strategies disabled due to market maker strategy %d requiring an exchange, a pair such as BTC-USD, a spread between 0 and 1, an order size greater than zero and no negative limits."
+	WarningStrategyNameDuplicate                    = "WARNING -- Strategies disabled due to duplicate strategy name %q."
+	WarningStrategyBridgeTokenEmpty                 = "WARNING -- Strategy bridge disabled due to an empty token."
+	WarningStrategyBridgeListenAddressInvalid       = "WARNING -- Strategy bridge disabled due to invalid listen address %q, use addresses such as localhost:9053."
+	WarningBasisScanIntervalInvalid                 = "WARNING -- Basis monitor disabled due to invalid scan interval %q, use durations such as 30s or 1m."
+	WarningBasisAmountInvalid                       = "WARNING -- Basis monitor disabled due to automatic execution without an amount greater than zero."
+	WarningBasisSpreadInvalid                       = "WARNING -- Basis monitor disabled due to spread %d requiring spot and futures exchanges, a pair such as BTC-USD and a FUTURES or PERPETUAL_SWAP asset type."
+	WarningHedgingIntervalInvalid                   = "WARNING -- Hedging assistant disabled due to invalid %s interval %q, use durations such as 1h or 4h."
+	WarningHedgingValuesInvalid                     = "WARNING -- Hedging assistant disabled due to a window below 2, or a minimum correlation, target exposure or tolerance outside of 0 to 1."
+	WarningHedgingHoldingInvalid                    = "WARNING -- Hedging assistant disabled due to holding %d requiring an exchange, a pair such as BTC-USD and hedges with an exchange, a pair and a SPOT, FUTURES or PERPETUAL_SWAP asset type."
+	WarningLiquidationIntervalInvalid               = "WARNING -- Liquidation monitor disabled due to invalid poll interval %q, use durations such as 30s or 1m."
+	WarningLiquidationThresholdsInvalid             = "WARNING -- Liquidation monitor disabled due to a maximum margin ratio outside of 0 to 1 or a minimum distance outside of 0 to 100 percent."
+	WarningCalendarIntervalInvalid                  = "WARNING -- Futures calendar disabled due to invalid %s interval %q, use durations such as 1h or 72h."
+	WarningCalendarLimitsInvalid                    = "WARNING -- Futures calendar disabled due to negative roll order limits."
+	WarningMarginIntervalInvalid                    = "WARNING -- Margin monitor disabled due to invalid %s interval %q, use durations such as 30s or 1m."
+	WarningMarginRuleInvalid                        = "WARNING -- Margin monitor disabled due to rule %d requiring a margin ratio between 0 and 1 and an alert action, a reduce action with a reduce fraction above 0 and at most 1 or an addCollateral action with a positive collateral amount."
+	WarningHealthMonitorIntervalInvalid             = "WARNING -- Health monitor %s interval %q invalid, use durations such as 30s or 1m. Reset to %s."
+	WarningDustSweepIntervalInvalid                 = "WARNING -- Dust sweep disabled due to invalid interval %q, use durations such as 12h or 24h."
+	WarningDustThresholdInvalid                     = "WARNING -- Exchange %s: Dust threshold of %s ignored as it is negative."
+	WarningAddressBookEncryptionKeyEmpty            = "WARNING -- Withdrawal address book disabled due to an empty encryption key."
+	WarningAddressBookDatabaseDisabled              = "WARNING -- Withdrawal address book disabled as it is stored in the database, which is disabled."
+	WarningWithdrawalsDatabaseDisabled              = "WARNING -- Withdrawals disabled as withdrawal attempts are recorded in the database, which is disabled."
+	WarningWithdrawalWhitelistInvalid               = "WARNING -- Withdrawals disabled due to whitelist entry %d requiring a currency and an address."
+	WarningWithdrawalDailyLimitInvalid              = "WARNING -- Withdrawals disabled due to a negative %s daily limit."
+	WarningWithdrawalBatchIntervalInvalid           = "WARNING -- Withdrawals disabled due to invalid batching %s %q, use durations such as 10m or 24h."
+	WarningWithdrawalBatchValuesInvalid             = "WARNING -- Withdrawals disabled due to a negative batching minimum amount or fee threshold."
+	WarningGRPCTLSFilesIncomplete                   = "WARNING -- gRPC support disabled as only one of the TLS certificate and key files is set."
 	WarningExchangeAuthAPIDefaultOrEmptyValues      = "WARNING -- Exchange %s: Authenticated API support disabled due to default/empty APIKey/Secret/ClientID values."
+	WarningExchangeAPIKeyDateInvalid                = "WARNING -- Exchange %s: API key %s date %q ignored, use dates such as 2019-06-30 or RFC3339 times."
+	WarningExchangeAPIKeyExpired                    = "WARNING -- Exchange %s: Authenticated API support disabled as the API key expired on %s."
+	WarningAPIKeyExpiryIntervalInvalid              = "WARNING -- API key expiry check interval %q invalid, use durations such as 1h or 12h. Reset to %s."
+	WarningExchangeSyncSourceInvalid                = "WARNING -- Exchange %s: %s sync source %q invalid, use REST, WEBSOCKET or WEBSOCKET_FALLBACK. Reset to the default."
+	WarningExchangeSyncSourceWebsocketDisabled      = "WARNING -- Exchange %s: %s sync source %s requires websocket support, which is disabled. Reset to REST."
+	WarningSyncWebsocketTimeoutInvalid              = "WARNING -- Sync manager websocket timeout %q invalid, use durations such as 30s or 1m. Reset to %s."
 	WarningCurrencyExchangeProvider                 = "WARNING -- Currency exchange provider invalid valid. Reset to Fixer."
+	WarningDisplayLocaleUnsupported                 = "WARNING -- Display locale %q unsupported. Reset to %s."
 	WarningPairsLastUpdatedThresholdExceeded        = "WARNING -- Exchange %s: Last manual update of available currency pairs has exceeded %d days. Manual update required!"
+	WarningSimulationBalancesEmpty                  = "WARNING -- Exchange %s: Paper trading enabled without simulation balances, orders will be rejected."
 	APIURLNonDefaultMessage                         = "NON_DEFAULT_HTTP_LINK_TO_EXCHANGE_API"
 	WebsocketURLNonDefaultMessage                   = "NON_DEFAULT_HTTP_LINK_TO_WEBSOCKET_EXCHANGE_API"
 )
@@ -79,6 +180,379 @@ type WebserverConfig struct {
 	WebsocketAllowInsecureOrigin bool   `json:"websocketAllowInsecureOrigin"`
 }
 
+// GRPCConfig holds the settings for the gRPC management server. Connections
+// use TLS when the certificate and key files are set.
+type GRPCConfig struct {
+	Enabled       bool   `json:"enabled"`
+	ListenAddress string `json:"listenAddress"`
+	Username      string `json:"username"`
+	Password      string `json:"password"`
+	TLSCertFile   string `json:"tlsCertFile,omitempty"`
+	TLSKeyFile    string `json:"tlsKeyFile,omitempty"`
+}
+
+// DatabaseConfig holds the settings for persisting market data, orders and
+// withdrawals. Database is the file path for the sqlite driver and the
+// database name for the postgres driver, the remaining connection settings
+// only apply to postgres. Exchanges opt in to persistence individually.
+type DatabaseConfig struct {
+	Enabled   bool            `json:"enabled"`
+	Driver    string          `json:"driver"`
+	Database  string          `json:"database"`
+	Host      string          `json:"host,omitempty"`
+	Port      uint16          `json:"port,omitempty"`
+	Username  string          `json:"username,omitempty"`
+	Password  string          `json:"password,omitempty"`
+	SSLMode   string          `json:"sslMode,omitempty"`
+	Retention RetentionConfig `json:"retention"`
+}
+
+// RetentionConfig holds how long each type of market data is kept, as
+// durations such as 720h. Empty durations keep the data indefinitely. Data
+// past its retention is pruned every PruneInterval and, when Compact is set,
+// the database is compacted after rows are pruned. When ArchiveInterval is set
+// trades and orderbook snapshots are archived into candles and summaries of
+// the interval before they are pruned.
+type RetentionConfig struct {
+	Tickers         string `json:"tickers,omitempty"`
+	Trades          string `json:"trades,omitempty"`
+	Orderbooks      string `json:"orderbooks,omitempty"`
+	Candles         string `json:"candles,omitempty"`
+	PruneInterval   string `json:"pruneInterval"`
+	ArchiveInterval string `json:"archiveInterval,omitempty"`
+	Compact         bool   `json:"compact"`
+}
+
+// RecordingConfig selects the market data an exchange persists when
+// PersistData is set. Pairs is a comma separated list of pairs in config
+// format, empty records every enabled pair. Orders and withdrawals are always
+// persisted.
+type RecordingConfig struct {
+	Pairs      string `json:"pairs,omitempty"`
+	Tickers    bool   `json:"tickers"`
+	Trades     bool   `json:"trades"`
+	Orderbooks bool   `json:"orderbooks"`
+	Candles    bool   `json:"candles"`
+}
+
+// OrderThrottleConfig holds the order throttle limits of a strategy, zero
+// values are not enforced
+type OrderThrottleConfig struct {
+	MaxOrdersPerMinute int           `json:"maxOrdersPerMinute"`
+	MaxOpenOrders      int           `json:"maxOpenOrders"`
+	MinPairInterval    time.Duration `json:"minPairInterval"`
+}
+
+// CandleBootstrapConfig holds the settings for backfilling the historic
+// candles of every enabled pair when the bot first starts, so indicators and
+// strategies have warm-up data. Intervals are durations such as 1h or 24h.
+type CandleBootstrapConfig struct {
+	Enabled   bool     `json:"enabled"`
+	Days      int      `json:"days"`
+	Intervals []string `json:"intervals"`
+}
+
+// MetadataCacheConfig holds the settings for caching large static exchange
+// responses, such as symbol lists, on disk between restarts. MaxAge is the
+// duration such as 24h a response is served before it is revalidated with the
+// exchange.
+type MetadataCacheConfig struct {
+	Enabled   bool   `json:"enabled"`
+	MaxSizeMB int    `json:"maxSizeMB"`
+	MaxAge    string `json:"maxAge"`
+}
+
+// ArbitrageConfig holds the settings for scanning the enabled exchanges for
+// arbitrage opportunities. Amount is the base currency amount evaluated for
+// each pair, MaxAge is how old a ticker or orderbook may be to be compared and
+// DefaultTakerFeePercent is charged on exchanges which cannot estimate their
+// trading fee.
+type ArbitrageConfig struct {
+	Enabled                bool    `json:"enabled"`
+	ScanInterval           string  `json:"scanInterval"`
+	MaxAge                 string  `json:"maxAge"`
+	Amount                 float64 `json:"amount"`
+	MinProfitPercent       float64 `json:"minProfitPercent"`
+	DefaultTakerFeePercent float64 `json:"defaultTakerFeePercent"`
+}
+
+// StatementsConfig holds the settings for publishing monthly account
+// statements. Statements are stored in Directory, the statements folder of
+// the data directory when empty, in each of Formats and emailed with the SMTP
+// communications settings when Email is set.
+type StatementsConfig struct {
+	Enabled   bool     `json:"enabled"`
+	Formats   []string `json:"formats"`
+	Directory string   `json:"directory"`
+	Email     bool     `json:"email"`
+}
+
+// PortfolioSyncConfig holds the settings for syncing the portfolio with the
+// balances of the enabled exchanges every Interval and valuing it in
+// BaseCurrency, the fiat display currency when empty. The latest MaxSnapshots
+// valuations are kept, the package default when zero.
+type PortfolioSyncConfig struct {
+	Enabled      bool   `json:"enabled"`
+	Interval     string `json:"interval"`
+	BaseCurrency string `json:"baseCurrency"`
+	MaxSnapshots int    `json:"maxSnapshots"`
+}
+
+// RSIStrategyConfig holds the settings of an RSI strategy trading Pair on
+// Exchange. Zero parameters take the strategy defaults and Name, which its
+// orders are throttled under, defaults to rsi-<exchange>-<pair>.
+type RSIStrategyConfig struct {
+	Name       string  `json:"name"`
+	Exchange   string  `json:"exchange"`
+	Pair       string  `json:"pair"`
+	Period     int     `json:"period"`
+	Oversold   float64 `json:"oversold"`
+	Overbought float64 `json:"overbought"`
+	OrderSize  float64 `json:"orderSize"`
+}
+
+// MarketMakerStrategyConfig holds the settings of a market maker quoting Pair
+// on Exchange. Quotes are resized to the non-zero risk limits and Name, which
+// its orders are throttled under, defaults to marketmaker-<exchange>-<pair>.
+type MarketMakerStrategyConfig struct {
+	Name             string  `json:"name"`
+	Exchange         string  `json:"exchange"`
+	Pair             string  `json:"pair"`
+	Spread           float64 `json:"spread"`
+	OrderSize        float64 `json:"orderSize"`
+	MaxInventory     float64 `json:"maxInventory"`
+	SkewFactor       float64 `json:"skewFactor"`
+	RequoteThreshold float64 `json:"requoteThreshold"`
+	MaxOrderAmount   float64 `json:"maxOrderAmount"`
+	MaxOrderNotional float64 `json:"maxOrderNotional"`
+	MaxPosition      float64 `json:"maxPosition"`
+	MaxOpenOrders    int     `json:"maxOpenOrders"`
+}
+
+// StrategyBridgeConfig holds the settings of the strategy bridge, which
+// serves the strategy runner to strategies written in other languages over
+// WebSocket on ListenAddress. Strategies must send Token to register, Token
+// may be a secret placeholder.
+type StrategyBridgeConfig struct {
+	Enabled       bool   `json:"enabled"`
+	ListenAddress string `json:"listenAddress"`
+	Token         string `json:"token"`
+}
+
+// StrategiesConfig holds the strategy runner settings. Candles of
+// CandleInterval are built from the exchanges' tickers and passed to the
+// strategies.
+type StrategiesConfig struct {
+	Enabled        bool                        `json:"enabled"`
+	CandleInterval string                      `json:"candleInterval"`
+	RSI            []RSIStrategyConfig         `json:"rsi"`
+	MarketMaker    []MarketMakerStrategyConfig `json:"marketMaker"`
+	Bridge         StrategyBridgeConfig        `json:"bridge"`
+}
+
+// BasisSpreadConfig holds a spot market and a future on the same underlying.
+// FuturesAssetType is FUTURES for dated contracts, whose expiry is looked up
+// by InstrumentID, or PERPETUAL_SWAP. InstrumentID defaults to the pair.
+type BasisSpreadConfig struct {
+	SpotExchange     string `json:"spotExchange"`
+	Pair             string `json:"pair"`
+	FuturesExchange  string `json:"futuresExchange"`
+	FuturesAssetType string `json:"futuresAssetType"`
+	InstrumentID     string `json:"instrumentID"`
+}
+
+// BasisConfig holds the settings for monitoring the basis of Spreads every
+// ScanInterval. Spreads whose annualised carry is at least
+// MinAnnualisedPercent are alerted and, when AutoExecute is set, a
+// cash-and-carry of Amount is executed once per spread.
+type BasisConfig struct {
+	Enabled              bool                `json:"enabled"`
+	ScanInterval         string              `json:"scanInterval"`
+	MinAnnualisedPercent float64             `json:"minAnnualisedPercent"`
+	AutoExecute          bool                `json:"autoExecute"`
+	Amount               float64             `json:"amount"`
+	Spreads              []BasisSpreadConfig `json:"spreads"`
+}
+
+// HedgeInstrumentConfig holds a hedging instrument. AssetType defaults to
+// SPOT, FUTURES and PERPETUAL_SWAP hedges are placed as futures orders of
+// InstrumentID, which defaults to the pair.
+type HedgeInstrumentConfig struct {
+	Exchange     string `json:"exchange"`
+	Pair         string `json:"pair"`
+	AssetType    string `json:"assetType"`
+	InstrumentID string `json:"instrumentID"`
+}
+
+// HedgeHoldingConfig holds a spot holding and the instruments it may be
+// hedged with. Amount is the amount held, the exchange's balance of the
+// pair's base currency is used when zero.
+type HedgeHoldingConfig struct {
+	Exchange string                  `json:"exchange"`
+	Pair     string                  `json:"pair"`
+	Amount   float64                 `json:"amount"`
+	Hedges   []HedgeInstrumentConfig `json:"hedges"`
+}
+
+// HedgingConfig holds the settings for hedging Holdings every ScanInterval
+// with the instrument whose returns over the last Window candles of
+// CandleInterval correlate most strongly, at least MinCorrelation, with the
+// holding's. Hedges target keeping TargetExposure, a fraction of the
+// holding's exposure, and are only suggested once the order exceeds
+// Tolerance of the holding's notional. When AutoExecute is set they are
+// placed.
+type HedgingConfig struct {
+	Enabled        bool                 `json:"enabled"`
+	ScanInterval   string               `json:"scanInterval"`
+	CandleInterval string               `json:"candleInterval"`
+	Window         int                  `json:"window"`
+	MinCorrelation float64              `json:"minCorrelation"`
+	TargetExposure float64              `json:"targetExposure"`
+	Tolerance      float64              `json:"tolerance"`
+	AutoExecute    bool                 `json:"autoExecute"`
+	Holdings       []HedgeHoldingConfig `json:"holdings"`
+}
+
+// LiquidationConfig holds the settings for polling the futures positions of
+// Exchanges, every enabled exchange reporting positions when empty, every
+// PollInterval and alerting when a position's margin ratio reaches
+// MaxMarginRatio or it comes within MinDistancePercent of its liquidation
+// price. Streamed mark prices reprice positions between polls.
+type LiquidationConfig struct {
+	Enabled            bool     `json:"enabled"`
+	PollInterval       string   `json:"pollInterval"`
+	MaxMarginRatio     float64  `json:"maxMarginRatio"`
+	MinDistancePercent float64  `json:"minDistancePercent"`
+	Exchanges          []string `json:"exchanges"`
+}
+
+// CalendarConfig holds the settings for tracking the dated futures contracts
+// of Exchanges, every enabled futures exchange when empty, every PollInterval
+// and reminding of contracts within RollWindow of expiry. Positions are rolled
+// to the next contract when AutoRoll is set, each roll order is checked
+// against the non-zero MaxOrderAmount, MaxOrderNotional and MaxPosition
+// limits.
+type CalendarConfig struct {
+	Enabled          bool     `json:"enabled"`
+	PollInterval     string   `json:"pollInterval"`
+	RollWindow       string   `json:"rollWindow"`
+	AutoRoll         bool     `json:"autoRoll"`
+	MaxOrderAmount   float64  `json:"maxOrderAmount"`
+	MaxOrderNotional float64  `json:"maxOrderNotional"`
+	MaxPosition      float64  `json:"maxPosition"`
+	Exchanges        []string `json:"exchanges"`
+}
+
+// MarginRuleConfig is a defensive action taken when the margin ratio of a
+// futures position or isolated margin account reaches MarginRatio, Action is
+// one of alert, reduce or addCollateral. Reduce closes ReduceFraction of a
+// position and addCollateral transfers CollateralAmount of
+// CollateralCurrency, the quote currency when empty, from the spot account.
+type MarginRuleConfig struct {
+	MarginRatio        float64 `json:"marginRatio"`
+	Action             string  `json:"action"`
+	ReduceFraction     float64 `json:"reduceFraction,omitempty"`
+	CollateralCurrency string  `json:"collateralCurrency,omitempty"`
+	CollateralAmount   float64 `json:"collateralAmount,omitempty"`
+}
+
+// MarginMonitorConfig holds the settings for polling the futures positions
+// and isolated margin accounts of Exchanges, every enabled exchange reporting
+// either when empty, every PollInterval and executing Rules as margin ratios
+// reach them. Streamed mark prices re-estimate position ratios between polls.
+// A rule executes again every Cooldown while its ratio remains reached, or
+// only once until the ratio recovers when Cooldown is empty.
+type MarginMonitorConfig struct {
+	Enabled      bool               `json:"enabled"`
+	PollInterval string             `json:"pollInterval"`
+	Cooldown     string             `json:"cooldown"`
+	Exchanges    []string           `json:"exchanges"`
+	Rules        []MarginRuleConfig `json:"rules"`
+}
+
+// DustSweepConfig holds the settings for sweeping balances below the smallest
+// sellable amount every Interval. Exchanges with a dust conversion endpoint
+// use it, otherwise dust is topped up and sold for QuoteCurrency when TopUp is
+// set. Currencies in Exclude are never swept.
+type DustSweepConfig struct {
+	Enabled       bool   `json:"enabled"`
+	Interval      string `json:"interval"`
+	TopUp         bool   `json:"topUp"`
+	QuoteCurrency string `json:"quoteCurrency"`
+	Exclude       string `json:"exclude"`
+}
+
+// HealthMonitorConfig holds the settings for checking every enabled exchange's
+// system status or ping endpoint each Interval. A check fails when it errors
+// or takes longer than Timeout, and an exchange is degraded, pausing its order
+// submission, after FailureThreshold consecutive failed checks. Checks older
+// than Retention are pruned from the availability journal.
+type HealthMonitorConfig struct {
+	Interval         string `json:"interval"`
+	Timeout          string `json:"timeout"`
+	FailureThreshold int    `json:"failureThreshold"`
+	Retention        string `json:"retention"`
+}
+
+// APIKeyExpiryConfig holds the settings for checking the expiry of exchange
+// API keys each Interval. Expiry dates are set per exchange or queried from
+// exchanges exposing their key metadata, an alert is sent AlertDays before a
+// key expires and authenticated API support is disabled once it has.
+type APIKeyExpiryConfig struct {
+	Interval  string `json:"interval"`
+	AlertDays int    `json:"alertDays"`
+}
+
+// SyncManagerConfig holds the settings for keeping exchange tickers and
+// orderbooks updated. Data types synced by websocket with REST fallback are
+// polled over REST while the exchange's websocket is disconnected or has sent
+// no update of the data type within WebsocketTimeout.
+type SyncManagerConfig struct {
+	WebsocketTimeout string `json:"websocketTimeout"`
+}
+
+// WithdrawalAddressBookConfig holds the settings of the withdrawal address
+// book, whose entries are encrypted under EncryptionKey and stored in the
+// database. EncryptionKey may be a secret placeholder.
+type WithdrawalAddressBookConfig struct {
+	Enabled       bool   `json:"enabled"`
+	EncryptionKey string `json:"encryptionKey"`
+}
+
+// WithdrawalWhitelistEntry is a destination address withdrawals of Currency
+// may be sent to, entries without an exchange apply to every exchange
+type WithdrawalWhitelistEntry struct {
+	Exchange string `json:"exchange,omitempty"`
+	Currency string `json:"currency"`
+	Address  string `json:"address"`
+}
+
+// WithdrawalBatchingConfig holds the withdrawal batching settings. Requested
+// withdrawals are queued and consolidated per destination, and each
+// ProcessInterval the batches which reach MinBatchAmount while the currency's
+// network fee is at or below its FeeThresholds entry, in sat/vB for BTC and
+// gwei for ETH, are requested for approval. Batches are requested regardless
+// once their oldest withdrawal has waited MaxDelay.
+type WithdrawalBatchingConfig struct {
+	Enabled         bool               `json:"enabled"`
+	ProcessInterval string             `json:"processInterval"`
+	MinBatchAmount  float64            `json:"minBatchAmount"`
+	MaxDelay        string             `json:"maxDelay"`
+	FeeThresholds   map[string]float64 `json:"feeThresholds,omitempty"`
+}
+
+// WithdrawalsConfig holds the settings of the withdrawal approval workflow.
+// Withdrawals are requested and then approved over gRPC before being sent,
+// only to whitelisted or address book addresses and within the rolling 24 hour
+// DailyLimits keyed by currency. Currencies without a limit are not limited.
+// Every attempt is recorded in the database.
+type WithdrawalsConfig struct {
+	Enabled     bool                       `json:"enabled"`
+	Whitelist   []WithdrawalWhitelistEntry `json:"whitelist"`
+	DailyLimits map[string]float64         `json:"dailyLimits"`
+	Batching    WithdrawalBatchingConfig   `json:"batching"`
+}
+
 // Post holds the bot configuration data
 type Post struct {
 	Data Config `json:"data"`
@@ -103,45 +577,170 @@ type Config struct {
 	Communications    CommunicationsConfig `json:"communications"`
 	Portfolio         portfolio.Base       `json:"portfolioAddresses"`
 	Webserver         WebserverConfig      `json:"webserver"`
+	GRPC              GRPCConfig           `json:"grpc"`
+	Database          DatabaseConfig       `json:"database"`
 	Exchanges         []ExchangeConfig     `json:"exchanges"`
 	BankAccounts      []BankAccount        `json:"bankAccounts"`
 
+	// OrderThrottles holds the order throttle limits keyed by strategy name
+	OrderThrottles map[string]OrderThrottleConfig `json:"orderThrottles,omitempty"`
+
+	// CandleBootstrap holds the historic candle backfill settings
+	CandleBootstrap CandleBootstrapConfig `json:"candleBootstrap"`
+
+	// MetadataCache holds the exchange metadata disk cache settings
+	MetadataCache MetadataCacheConfig `json:"metadataCache"`
+
+	// Arbitrage holds the cross exchange arbitrage scanner settings
+	Arbitrage ArbitrageConfig `json:"arbitrage"`
+
+	// Statements holds the monthly account statement settings
+	Statements StatementsConfig `json:"statements"`
+
+	// PortfolioSync holds the portfolio sync and valuation settings
+	PortfolioSync PortfolioSyncConfig `json:"portfolioSync"`
+
+	// Strategies holds the strategy runner and built-in strategy settings
+	Strategies StrategiesConfig `json:"strategies"`
+
+	// Basis holds the spot-futures basis monitor settings
+	Basis BasisConfig `json:"basis"`
+
+	// Hedging holds the correlation-based hedging assistant settings
+	Hedging HedgingConfig `json:"hedging"`
+
+	// Liquidation holds the futures liquidation monitor settings
+	Liquidation LiquidationConfig `json:"liquidation"`
+
+	// Calendar holds the futures contract calendar settings
+	Calendar CalendarConfig `json:"calendar"`
+
+	// MarginMonitor holds the margin level monitor settings
+	MarginMonitor MarginMonitorConfig `json:"marginMonitor"`
+
+	// HealthMonitor holds the exchange health monitor settings
+	HealthMonitor HealthMonitorConfig `json:"healthMonitor"`
+
+	// DustSweep holds the dust sweep settings
+	DustSweep DustSweepConfig `json:"dustSweep"`
+
+	// APIKeyExpiry holds the exchange API key expiry alert settings
+	APIKeyExpiry APIKeyExpiryConfig `json:"apiKeyExpiry"`
+
+	// SyncManager holds the ticker and orderbook sync settings
+	SyncManager SyncManagerConfig `json:"syncManager"`
+
+	// WithdrawalAddressBook holds the withdrawal address book settings
+	WithdrawalAddressBook WithdrawalAddressBookConfig `json:"withdrawalAddressBook"`
+
+	// Withdrawals holds the withdrawal approval workflow settings
+	Withdrawals WithdrawalsConfig `json:"withdrawals"`
+
 	// Deprecated config settings, will be removed at a future date
 	CurrencyPairFormat  *CurrencyPairFormatConfig `json:"currencyPairFormat,omitempty"`
 	FiatDisplayCurrency string                    `json:"fiatDispayCurrency,omitempty"`
 	Cryptocurrencies    string                    `json:"cryptocurrencies,omitempty"`
 	SMS                 *SMSGlobalConfig          `json:"smsGlobal,omitempty"`
+
+	// secrets holds credentials resolved from placeholders
+	secrets map[string]secret
 }
 
 // ExchangeConfig holds all the information needed for each enabled Exchange.
 type ExchangeConfig struct {
-	Name                      string                    `json:"name"`
-	Enabled                   bool                      `json:"enabled"`
-	Verbose                   bool                      `json:"verbose"`
-	Websocket                 bool                      `json:"websocket"`
-	UseSandbox                bool                      `json:"useSandbox"`
-	RESTPollingDelay          time.Duration             `json:"restPollingDelay"`
-	HTTPTimeout               time.Duration             `json:"httpTimeout"`
-	HTTPUserAgent             string                    `json:"httpUserAgent"`
-	AuthenticatedAPISupport   bool                      `json:"authenticatedApiSupport"`
-	APIKey                    string                    `json:"apiKey"`
-	APISecret                 string                    `json:"apiSecret"`
-	APIAuthPEMKeySupport      bool                      `json:"apiAuthPemKeySupport,omitempty"`
-	APIAuthPEMKey             string                    `json:"apiAuthPemKey,omitempty"`
-	APIURL                    string                    `json:"apiUrl"`
-	APIURLSecondary           string                    `json:"apiUrlSecondary"`
-	ProxyAddress              string                    `json:"proxyAddress"`
-	WebsocketURL              string                    `json:"websocketUrl"`
-	ClientID                  string                    `json:"clientId,omitempty"`
+	Name                      string                       `json:"name"`
+	Enabled                   bool                         `json:"enabled"`
+	Verbose                   bool                         `json:"verbose"`
+	Websocket                 bool                         `json:"websocket"`
+	UseSandbox                bool                         `json:"useSandbox"`
+	RESTPollingDelay          time.Duration                `json:"restPollingDelay"`
+	HTTPTimeout               time.Duration                `json:"httpTimeout"`
+	HTTPUserAgent             string                       `json:"httpUserAgent"`
+	HTTPHeaders               map[string]string            `json:"httpHeaders,omitempty"`
+	BrokerTag                 string                       `json:"brokerTag,omitempty"`
+	AuthenticatedAPISupport   bool                         `json:"authenticatedApiSupport"`
+	APIKey                    string                       `json:"apiKey"`
+	APISecret                 string                       `json:"apiSecret"`
+	APIKeyCreated             string                       `json:"apiKeyCreated,omitempty"`
+	APIKeyExpiry              string                       `json:"apiKeyExpiry,omitempty"`
+	APIAuthPEMKeySupport      bool                         `json:"apiAuthPemKeySupport,omitempty"`
+	APIAuthPEMKey             string                       `json:"apiAuthPemKey,omitempty"`
+	APIURL                    string                       `json:"apiUrl"`
+	APIURLSecondary           string                       `json:"apiUrlSecondary"`
+	ProxyAddress              string                       `json:"proxyAddress"`
+	WebsocketURL              string                       `json:"websocketUrl"`
+	ClientID                  string                       `json:"clientId,omitempty"`
+	OrderTransport            string                       `json:"orderTransport,omitempty"`
+	TickerSync                string                       `json:"tickerSync,omitempty"`
+	OrderbookSync             string                       `json:"orderbookSync,omitempty"`
+	TLSPins                   []string                     `json:"tlsPins,omitempty"`
+	LocalAddress              string                       `json:"localAddress,omitempty"`
+	RoundingModes             map[string]RoundingConfig    `json:"roundingModes,omitempty"`
+	AccountTier               string                       `json:"accountTier,omitempty"`
+	DustThresholds            map[string]float64           `json:"dustThresholds,omitempty"`
+	FeeDiscount               bool                         `json:"feeDiscount,omitempty"`
+	RateLimitTiers            map[string]RateLimitConfig   `json:"rateLimitTiers,omitempty"`
+	Simulate                  bool                         `json:"simulate,omitempty"`
+	SimulationBalances        map[string]float64           `json:"simulationBalances,omitempty"`
+	Simulator                 *SimulatorConfig             `json:"simulator,omitempty"`
+	PersistData               bool                         `json:"persistData,omitempty"`
+	Recording                 *RecordingConfig             `json:"recording,omitempty"`
+	AvailablePairs            string                       `json:"availablePairs"`
+	EnabledPairs              string                       `json:"enabledPairs"`
+	BaseCurrencies            string                       `json:"baseCurrencies"`
+	AssetTypes                string                       `json:"assetTypes"`
+	AssetPairs                map[string]*AssetPairsConfig `json:"assetPairs,omitempty"`
+	SupportsAutoPairUpdates   bool                         `json:"supportsAutoPairUpdates"`
+	PairsLastUpdated          int64                        `json:"pairsLastUpdated,omitempty"`
+	ConfigCurrencyPairFormat  *CurrencyPairFormatConfig    `json:"configCurrencyPairFormat"`
+	RequestCurrencyPairFormat *CurrencyPairFormatConfig    `json:"requestCurrencyPairFormat"`
+	BankAccounts              []BankAccount                `json:"bankAccounts"`
+}
+
+// RoundingConfig holds the rounding modes used to snap order prices and
+// amounts to a currency pair's tick and step sizes, keyed by currency pair or
+// "default" for all pairs
+type RoundingConfig struct {
+	Price  string `json:"price,omitempty"`
+	Amount string `json:"amount,omitempty"`
+}
+
+// AssetPairsConfig holds the currency pairs and pair formats of an asset type
+// other than spot, keyed by asset type. Nil pair formats use the exchange's
+// spot pair formats.
+type AssetPairsConfig struct {
 	AvailablePairs            string                    `json:"availablePairs"`
 	EnabledPairs              string                    `json:"enabledPairs"`
-	BaseCurrencies            string                    `json:"baseCurrencies"`
-	AssetTypes                string                    `json:"assetTypes"`
-	SupportsAutoPairUpdates   bool                      `json:"supportsAutoPairUpdates"`
-	PairsLastUpdated          int64                     `json:"pairsLastUpdated,omitempty"`
-	ConfigCurrencyPairFormat  *CurrencyPairFormatConfig `json:"configCurrencyPairFormat"`
-	RequestCurrencyPairFormat *CurrencyPairFormatConfig `json:"requestCurrencyPairFormat"`
-	BankAccounts              []BankAccount             `json:"bankAccounts"`
+	ConfigCurrencyPairFormat  *CurrencyPairFormatConfig `json:"configCurrencyPairFormat,omitempty"`
+	RequestCurrencyPairFormat *CurrencyPairFormatConfig `json:"requestCurrencyPairFormat,omitempty"`
+}
+
+// RateLimitConfig holds the REST rate limits of an account tier as the number
+// of authenticated and unauthenticated requests allowed per Interval, and the
+// request weight allowed in a Burst. Zero values keep the exchange's default
+// limits.
+type RateLimitConfig struct {
+	Interval   time.Duration `json:"interval,omitempty"`
+	AuthRate   int           `json:"authRate,omitempty"`
+	UnauthRate int           `json:"unauthRate,omitempty"`
+	Burst      int           `json:"burst,omitempty"`
+}
+
+// SimulatorConfig holds the synthetic market and matching engine settings of
+// the Simulator exchange. Prices holds the starting mid price of each currency
+// pair, Spread and Volatility are percentages of the mid price and each side
+// of the book quotes LevelAmount at DepthLevels prices, so larger orders fill
+// partially. Latency delays every order request, FeeTiers replace the default
+// fee schedule and a non-zero Seed makes the market reproducible.
+type SimulatorConfig struct {
+	Latency     time.Duration      `json:"latency,omitempty"`
+	Prices      map[string]float64 `json:"prices,omitempty"`
+	Spread      float64            `json:"spread,omitempty"`
+	Volatility  float64            `json:"volatility,omitempty"`
+	DepthLevels int                `json:"depthLevels,omitempty"`
+	LevelAmount float64            `json:"levelAmount,omitempty"`
+	FeeTiers    []fees.Tier        `json:"feeTiers,omitempty"`
+	Seed        int64              `json:"seed,omitempty"`
 }
 
 // BankAccount holds differing bank account details by supported funding
@@ -172,6 +771,7 @@ type CurrencyConfig struct {
 	Cryptocurrencies    string                    `json:"cryptocurrencies"`
 	CurrencyPairFormat  *CurrencyPairFormatConfig `json:"currencyPairFormat"`
 	FiatDisplayCurrency string                    `json:"fiatDisplayCurrency"`
+	DisplayLocale       string                    `json:"displayLocale"`
 }
 
 // CommunicationsConfig holds all the information needed for each
@@ -685,6 +1285,42 @@ func (c *Config) UpdateExchangeConfig(e ExchangeConfig) error {
 	return fmt.Errorf(ErrExchangeNotFound, e.Name)
 }
 
+// migrateAssetTypes upgrades asset types written by older configs to their
+// first class names, such as "perpetual" to "PERPETUAL_SWAP", and drops
+// duplicates. Asset types without a first class name, such as "OPTIONS", are
+// kept upper cased. Asset pairs are rekeyed the same way.
+func migrateAssetTypes(assetTypes string, assetPairs map[string]*AssetPairsConfig) (string, map[string]*AssetPairsConfig) {
+	var migrated []string
+	for _, a := range common.SplitStrings(assetTypes, ",") {
+		a = common.StringToUpper(strings.TrimSpace(a))
+		if a == "" {
+			continue
+		}
+		if n, err := assets.Normalise(a); err == nil {
+			a = n
+		}
+		if !common.StringDataCompare(migrated, a) {
+			migrated = append(migrated, a)
+		}
+	}
+
+	if len(assetPairs) == 0 {
+		return common.JoinStrings(migrated, ","), assetPairs
+	}
+	pairs := make(map[string]*AssetPairsConfig, len(assetPairs))
+	for a, p := range assetPairs {
+		if p == nil {
+			continue
+		}
+		a = common.StringToUpper(strings.TrimSpace(a))
+		if n, err := assets.Normalise(a); err == nil {
+			a = n
+		}
+		pairs[a] = p
+	}
+	return common.JoinStrings(migrated, ","), pairs
+}
+
 // CheckExchangeConfigValues returns configuation values for all enabled
 // exchanges
 func (c *Config) CheckExchangeConfigValues() error {
@@ -694,6 +1330,8 @@ func (c *Config) CheckExchangeConfigValues() error {
 			c.Exchanges[i].Name = "CoinbasePro"
 		}
 
+		c.Exchanges[i].AssetTypes, c.Exchanges[i].AssetPairs = migrateAssetTypes(exch.AssetTypes, exch.AssetPairs)
+
 		if exch.WebsocketURL != WebsocketURLNonDefaultMessage {
 			if exch.WebsocketURL == "" {
 				c.Exchanges[i].WebsocketURL = WebsocketURLNonDefaultMessage
@@ -738,6 +1376,8 @@ func (c *Config) CheckExchangeConfigValues() error {
 					}
 				}
 			}
+			c.checkAPIKeyDates(i, time.Now())
+			c.checkSyncSources(i)
 			if !exch.SupportsAutoPairUpdates {
 				lastUpdated := common.UnixTimestampToTime(exch.PairsLastUpdated)
 				lastUpdated = lastUpdated.AddDate(0, 0, configPairsLastUpdatedWarningThreshold)
@@ -746,6 +1386,22 @@ func (c *Config) CheckExchangeConfigValues() error {
 				}
 			}
 
+			if exch.Simulate && len(exch.SimulationBalances) == 0 {
+				log.Printf(WarningSimulationBalancesEmpty, exch.Name)
+			}
+
+			if len(exch.DustThresholds) > 0 {
+				thresholds := make(map[string]float64)
+				for currency, threshold := range exch.DustThresholds {
+					if threshold < 0 {
+						log.Printf(WarningDustThresholdInvalid, exch.Name, currency)
+						continue
+					}
+					thresholds[common.StringToUpper(currency)] = threshold
+				}
+				c.Exchanges[i].DustThresholds = thresholds
+			}
+
 			if exch.HTTPTimeout <= 0 {
 				log.Printf("Exchange %s HTTP Timeout value not set, defaulting to %v.", exch.Name, configDefaultHTTPTimeout)
 				c.Exchanges[i].HTTPTimeout = configDefaultHTTPTimeout
@@ -825,6 +1481,630 @@ func (c *Config) CheckWebserverConfigValues() error {
 	return nil
 }
 
+// CheckGRPCConfigValues checks information before the gRPC server starts and
+// returns an error if values are incorrect.
+func (c *Config) CheckGRPCConfigValues() error {
+	if c.GRPC.Username == "" || c.GRPC.Password == "" {
+		return errors.New(WarningGRPCCredentialValuesEmpty)
+	}
+
+	if !common.StringContains(c.GRPC.ListenAddress, ":") {
+		return errors.New(WarningGRPCListenAddressInvalid)
+	}
+
+	portStr := common.SplitStrings(c.GRPC.ListenAddress, ":")[1]
+	port, err := strconv.Atoi(portStr)
+	if err != nil || port < 1 || port > 65355 {
+		return errors.New(WarningGRPCListenAddressInvalid)
+	}
+
+	if (c.GRPC.TLSCertFile == "") != (c.GRPC.TLSKeyFile == "") {
+		return errors.New(WarningGRPCTLSFilesIncomplete)
+	}
+	return nil
+}
+
+// CheckDatabaseConfigValues checks information before the database is opened,
+// defaulting the retention prune interval when unset, and returns an error if
+// values are incorrect.
+func (c *Config) CheckDatabaseConfigValues() error {
+	switch c.Database.Driver {
+	case "sqlite":
+	case "postgres":
+		if c.Database.Host == "" {
+			return errors.New(WarningDatabaseHostEmpty)
+		}
+	default:
+		return errors.New(WarningDatabaseDriverUnsupported)
+	}
+
+	if c.Database.Database == "" {
+		return errors.New(WarningDatabaseNameEmpty)
+	}
+
+	r := &c.Database.Retention
+	if r.PruneInterval == "" {
+		r.PruneInterval = configDefaultDatabasePruneInterval
+	}
+	for _, v := range []string{r.Tickers, r.Trades, r.Orderbooks, r.Candles, r.ArchiveInterval} {
+		if v == "" {
+			continue
+		}
+		if d, err := time.ParseDuration(v); err != nil || d <= 0 {
+			return fmt.Errorf(WarningDatabaseRetentionInvalid, v)
+		}
+	}
+	if d, err := time.ParseDuration(r.PruneInterval); err != nil || d <= 0 {
+		return fmt.Errorf(WarningDatabaseRetentionInvalid, r.PruneInterval)
+	}
+	return nil
+}
+
+// CheckCandleBootstrapConfigValues checks the candle bootstrap settings,
+// defaulting the days and intervals when unset, and returns an error if values
+// are incorrect.
+func (c *Config) CheckCandleBootstrapConfigValues() error {
+	if c.CandleBootstrap.Days <= 0 {
+		log.Printf("Candle bootstrap days not set, defaulting to %d.", configDefaultCandleBootstrapDays)
+		c.CandleBootstrap.Days = configDefaultCandleBootstrapDays
+	}
+	if len(c.CandleBootstrap.Intervals) == 0 {
+		c.CandleBootstrap.Intervals = []string{configDefaultCandleBootstrapInterval}
+	}
+	for _, interval := range c.CandleBootstrap.Intervals {
+		d, err := time.ParseDuration(interval)
+		if err != nil || d < time.Minute {
+			return fmt.Errorf(WarningCandleBootstrapIntervalInvalid, interval)
+		}
+	}
+	return nil
+}
+
+// CheckMetadataCacheConfigValues checks the metadata cache settings,
+// defaulting the size and max age when unset, and returns an error if values
+// are incorrect.
+func (c *Config) CheckMetadataCacheConfigValues() error {
+	if c.MetadataCache.MaxSizeMB <= 0 {
+		log.Printf("Metadata cache size not set, defaulting to %dMB.", configDefaultMetadataCacheSizeMB)
+		c.MetadataCache.MaxSizeMB = configDefaultMetadataCacheSizeMB
+	}
+	if c.MetadataCache.MaxAge == "" {
+		c.MetadataCache.MaxAge = configDefaultMetadataCacheMaxAge
+	}
+	d, err := time.ParseDuration(c.MetadataCache.MaxAge)
+	if err != nil || d < 0 {
+		return fmt.Errorf(WarningMetadataCacheMaxAgeInvalid, c.MetadataCache.MaxAge)
+	}
+	return nil
+}
+
+// CheckArbitrageConfigValues checks the arbitrage scanner settings,
+// defaulting the scan interval, max age and amount when unset, and returns an
+// error if values are incorrect.
+func (c *Config) CheckArbitrageConfigValues() error {
+	if c.Arbitrage.ScanInterval == "" {
+		c.Arbitrage.ScanInterval = configDefaultArbitrageScanInterval
+	}
+	if c.Arbitrage.MaxAge == "" {
+		c.Arbitrage.MaxAge = configDefaultArbitrageMaxAge
+	}
+	if c.Arbitrage.Amount <= 0 {
+		log.Printf("Arbitrage amount not set, defaulting to %v.", configDefaultArbitrageAmount)
+		c.Arbitrage.Amount = configDefaultArbitrageAmount
+	}
+	for _, duration := range []string{c.Arbitrage.ScanInterval, c.Arbitrage.MaxAge} {
+		d, err := time.ParseDuration(duration)
+		if err != nil || d <= 0 {
+			return fmt.Errorf(WarningArbitrageDurationInvalid, duration)
+		}
+	}
+	if c.Arbitrage.MinProfitPercent < 0 || c.Arbitrage.DefaultTakerFeePercent < 0 {
+		return errors.New(WarningArbitrageValuesInvalid)
+	}
+	return nil
+}
+
+// CheckStatementsConfigValues checks the account statement settings,
+// defaulting the formats when unset, and returns an error if values are
+// incorrect. Emailing is turned off when SMTP is not enabled.
+func (c *Config) CheckStatementsConfigValues() error {
+	if len(c.Statements.Formats) == 0 {
+		c.Statements.Formats = common.SplitStrings(configDefaultStatementFormats, ",")
+	}
+	for i, format := range c.Statements.Formats {
+		format = common.StringToLower(format)
+		if format != "html" && format != "pdf" {
+			return fmt.Errorf(WarningStatementsFormatInvalid, format)
+		}
+		c.Statements.Formats[i] = format
+	}
+	if c.Statements.Email && !c.Communications.SMTPConfig.Enabled {
+		log.Print(WarningStatementsEmailUnavailable)
+		c.Statements.Email = false
+	}
+	return nil
+}
+
+// CheckPortfolioSyncConfigValues checks the portfolio sync settings,
+// defaulting the interval when unset, and returns an error if values are
+// incorrect.
+func (c *Config) CheckPortfolioSyncConfigValues() error {
+	if c.PortfolioSync.Interval == "" {
+		c.PortfolioSync.Interval = configDefaultPortfolioSyncInterval
+	}
+	d, err := time.ParseDuration(c.PortfolioSync.Interval)
+	if err != nil || d <= 0 {
+		return fmt.Errorf(WarningPortfolioSyncIntervalInvalid, c.PortfolioSync.Interval)
+	}
+	if c.PortfolioSync.MaxSnapshots < 0 {
+		return errors.New(WarningPortfolioSyncMaxSnapshotsInvalid)
+	}
+	c.PortfolioSync.BaseCurrency = common.StringToUpper(c.PortfolioSync.BaseCurrency)
+	return nil
+}
+
+// CheckStrategiesConfigValues checks the strategy settings, defaulting the
+// candle interval and strategy names when unset, and returns an error if
+// values are incorrect.
+func (c *Config) CheckStrategiesConfigValues() error {
+	if c.Strategies.CandleInterval == "" {
+		c.Strategies.CandleInterval = configDefaultStrategyCandleInterval
+	}
+	d, err := time.ParseDuration(c.Strategies.CandleInterval)
+	if err != nil || d <= 0 {
+		return fmt.Errorf(WarningStrategiesCandleIntervalInvalid, c.Strategies.CandleInterval)
+	}
+
+	names := make(map[string]bool)
+	for i := range c.Strategies.RSI {
+		s := &c.Strategies.RSI[i]
+		if s.Exchange == "" || len(s.Pair) < 4 || s.OrderSize <= 0 {
+			return fmt.Errorf(WarningStrategyRSIInvalid, i)
+		}
+		s.Pair = common.StringToUpper(s.Pair)
+		if s.Name == "" {
+			s.Name = common.StringToLower(fmt.Sprintf("rsi-%s-%s", s.Exchange, s.Pair))
+		}
+		if names[s.Name] {
+			return fmt.Errorf(WarningStrategyNameDuplicate, s.Name)
+		}
+		names[s.Name] = true
+	}
+	for i := range c.Strategies.MarketMaker {
+		s := &c.Strategies.MarketMaker[i]
+		if s.Exchange == "" || len(s.Pair) < 4 || s.Spread <= 0 || s.Spread >= 1 || s.OrderSize <= 0 ||
+			s.MaxInventory < 0 || s.SkewFactor < 0 || s.RequoteThreshold < 0 || s.MaxOrderAmount < 0 ||
+			s.MaxOrderNotional < 0 || s.MaxPosition < 0 || s.MaxOpenOrders < 0 {
+			return fmt.Errorf(WarningStrategyMarketMakerInvalid, i)
+		}
+		s.Pair = common.StringToUpper(s.Pair)
+		if s.Name == "" {
+			s.Name = common.StringToLower(fmt.Sprintf("marketmaker-%s-%s", s.Exchange, s.Pair))
+		}
+		if names[s.Name] {
+			return fmt.Errorf(WarningStrategyNameDuplicate, s.Name)
+		}
+		names[s.Name] = true
+	}
+	return nil
+}
+
+// CheckStrategyBridgeConfigValues checks the strategy bridge settings,
+// defaulting the listen address when unset, and returns an error if values
+// are incorrect.
+func (c *Config) CheckStrategyBridgeConfigValues() error {
+	if c.Strategies.Bridge.ListenAddress == "" {
+		c.Strategies.Bridge.ListenAddress = configDefaultStrategyBridgeAddress
+	}
+	if !common.StringContains(c.Strategies.Bridge.ListenAddress, ":") {
+		return fmt.Errorf(WarningStrategyBridgeListenAddressInvalid, c.Strategies.Bridge.ListenAddress)
+	}
+	if c.Strategies.Bridge.Token == "" {
+		return errors.New(WarningStrategyBridgeTokenEmpty)
+	}
+	return nil
+}
+
+// CheckBasisConfigValues checks the basis monitor settings, defaulting the
+// scan interval when unset, and returns an error if values are incorrect.
+func (c *Config) CheckBasisConfigValues() error {
+	if c.Basis.ScanInterval == "" {
+		c.Basis.ScanInterval = configDefaultBasisScanInterval
+	}
+	d, err := time.ParseDuration(c.Basis.ScanInterval)
+	if err != nil || d <= 0 {
+		return fmt.Errorf(WarningBasisScanIntervalInvalid, c.Basis.ScanInterval)
+	}
+	if c.Basis.AutoExecute && c.Basis.Amount <= 0 {
+		return errors.New(WarningBasisAmountInvalid)
+	}
+
+	for i := range c.Basis.Spreads {
+		s := &c.Basis.Spreads[i]
+		if s.SpotExchange == "" || s.FuturesExchange == "" || len(s.Pair) < 4 {
+			return fmt.Errorf(WarningBasisSpreadInvalid, i)
+		}
+		assetType, err := assets.Normalise(s.FuturesAssetType)
+		if err != nil || (assetType != assets.Futures && assetType != assets.PerpetualSwap) {
+			return fmt.Errorf(WarningBasisSpreadInvalid, i)
+		}
+		s.FuturesAssetType = assetType
+		s.Pair = common.StringToUpper(s.Pair)
+	}
+	return nil
+}
+
+// CheckHedgingConfigValues checks the hedging assistant settings, defaulting
+// the intervals, window and minimum correlation when unset, and returns an
+// error if values are incorrect.
+func (c *Config) CheckHedgingConfigValues() error {
+	if c.Hedging.ScanInterval == "" {
+		c.Hedging.ScanInterval = configDefaultHedgingScanInterval
+	}
+	d, err := time.ParseDuration(c.Hedging.ScanInterval)
+	if err != nil || d <= 0 {
+		return fmt.Errorf(WarningHedgingIntervalInvalid, "scan", c.Hedging.ScanInterval)
+	}
+	if c.Hedging.CandleInterval == "" {
+		c.Hedging.CandleInterval = configDefaultHedgingCandleInterval
+	}
+	d, err = time.ParseDuration(c.Hedging.CandleInterval)
+	if err != nil || d <= 0 {
+		return fmt.Errorf(WarningHedgingIntervalInvalid, "candle", c.Hedging.CandleInterval)
+	}
+	if c.Hedging.Window == 0 {
+		c.Hedging.Window = configDefaultHedgingWindow
+	}
+	if c.Hedging.MinCorrelation == 0 {
+		c.Hedging.MinCorrelation = configDefaultHedgingMinCorrelation
+	}
+	if c.Hedging.Window < 2 || c.Hedging.MinCorrelation < 0 || c.Hedging.MinCorrelation > 1 ||
+		c.Hedging.TargetExposure < 0 || c.Hedging.TargetExposure > 1 ||
+		c.Hedging.Tolerance < 0 || c.Hedging.Tolerance > 1 {
+		return errors.New(WarningHedgingValuesInvalid)
+	}
+
+	for i := range c.Hedging.Holdings {
+		h := &c.Hedging.Holdings[i]
+		if h.Exchange == "" || len(h.Pair) < 4 || h.Amount < 0 || len(h.Hedges) == 0 {
+			return fmt.Errorf(WarningHedgingHoldingInvalid, i)
+		}
+		h.Pair = common.StringToUpper(h.Pair)
+		for j := range h.Hedges {
+			hedge := &h.Hedges[j]
+			if hedge.Exchange == "" || len(hedge.Pair) < 4 {
+				return fmt.Errorf(WarningHedgingHoldingInvalid, i)
+			}
+			if hedge.AssetType == "" {
+				hedge.AssetType = assets.Spot
+			}
+			assetType, err := assets.Normalise(hedge.AssetType)
+			if err != nil || (assetType != assets.Spot && assetType != assets.Futures &&
+				assetType != assets.PerpetualSwap) {
+				return fmt.Errorf(WarningHedgingHoldingInvalid, i)
+			}
+			hedge.AssetType = assetType
+			hedge.Pair = common.StringToUpper(hedge.Pair)
+		}
+	}
+	return nil
+}
+
+// CheckLiquidationConfigValues checks the liquidation monitor settings,
+// defaulting the poll interval and thresholds when unset, and returns an error
+// if values are incorrect.
+func (c *Config) CheckLiquidationConfigValues() error {
+	if c.Liquidation.PollInterval == "" {
+		c.Liquidation.PollInterval = configDefaultLiquidationPollInterval
+	}
+	d, err := time.ParseDuration(c.Liquidation.PollInterval)
+	if err != nil || d <= 0 {
+		return fmt.Errorf(WarningLiquidationIntervalInvalid, c.Liquidation.PollInterval)
+	}
+	if c.Liquidation.MaxMarginRatio == 0 {
+		c.Liquidation.MaxMarginRatio = configDefaultLiquidationMaxMarginRatio
+	}
+	if c.Liquidation.MinDistancePercent == 0 {
+		c.Liquidation.MinDistancePercent = configDefaultLiquidationMinDistance
+	}
+	if c.Liquidation.MaxMarginRatio < 0 || c.Liquidation.MaxMarginRatio > 1 ||
+		c.Liquidation.MinDistancePercent < 0 || c.Liquidation.MinDistancePercent >= 100 {
+		return errors.New(WarningLiquidationThresholdsInvalid)
+	}
+	return nil
+}
+
+// CheckCalendarConfigValues checks the futures calendar settings, defaulting
+// the poll interval and roll window when unset, and returns an error if values
+// are incorrect.
+func (c *Config) CheckCalendarConfigValues() error {
+	if c.Calendar.PollInterval == "" {
+		c.Calendar.PollInterval = configDefaultCalendarPollInterval
+	}
+	d, err := time.ParseDuration(c.Calendar.PollInterval)
+	if err != nil || d <= 0 {
+		return fmt.Errorf(WarningCalendarIntervalInvalid, "poll", c.Calendar.PollInterval)
+	}
+	if c.Calendar.RollWindow == "" {
+		c.Calendar.RollWindow = configDefaultCalendarRollWindow
+	}
+	d, err = time.ParseDuration(c.Calendar.RollWindow)
+	if err != nil || d <= 0 {
+		return fmt.Errorf(WarningCalendarIntervalInvalid, "roll window", c.Calendar.RollWindow)
+	}
+	if c.Calendar.MaxOrderAmount < 0 || c.Calendar.MaxOrderNotional < 0 || c.Calendar.MaxPosition < 0 {
+		return errors.New(WarningCalendarLimitsInvalid)
+	}
+	return nil
+}
+
+// CheckMarginMonitorConfigValues checks the margin monitor settings,
+// defaulting the poll interval and an alert rule when unset, and returns an
+// error if values are incorrect.
+func (c *Config) CheckMarginMonitorConfigValues() error {
+	if c.MarginMonitor.PollInterval == "" {
+		c.MarginMonitor.PollInterval = configDefaultMarginPollInterval
+	}
+	d, err := time.ParseDuration(c.MarginMonitor.PollInterval)
+	if err != nil || d <= 0 {
+		return fmt.Errorf(WarningMarginIntervalInvalid, "poll", c.MarginMonitor.PollInterval)
+	}
+	if c.MarginMonitor.Cooldown != "" {
+		d, err = time.ParseDuration(c.MarginMonitor.Cooldown)
+		if err != nil || d <= 0 {
+			return fmt.Errorf(WarningMarginIntervalInvalid, "cooldown", c.MarginMonitor.Cooldown)
+		}
+	}
+
+	if len(c.MarginMonitor.Rules) == 0 {
+		c.MarginMonitor.Rules = []MarginRuleConfig{
+			{MarginRatio: configDefaultMarginAlertRatio, Action: "alert"},
+		}
+	}
+	for i, r := range c.MarginMonitor.Rules {
+		valid := r.MarginRatio > 0 && r.MarginRatio < 1
+		switch r.Action {
+		case "alert":
+		case "reduce":
+			valid = valid && r.ReduceFraction > 0 && r.ReduceFraction <= 1
+		case "addCollateral":
+			valid = valid && r.CollateralAmount > 0
+		default:
+			valid = false
+		}
+		if !valid {
+			return fmt.Errorf(WarningMarginRuleInvalid, i)
+		}
+	}
+	return nil
+}
+
+// CheckHealthMonitorConfigValues checks the exchange health monitor settings,
+// defaulting the intervals, failure threshold and journal retention when unset
+// or incorrect.
+func (c *Config) CheckHealthMonitorConfigValues() {
+	intervals := []struct {
+		name, fallback string
+		value          *string
+	}{
+		{"check", configDefaultHealthCheckInterval, &c.HealthMonitor.Interval},
+		{"timeout", configDefaultHealthCheckTimeout, &c.HealthMonitor.Timeout},
+		{"retention", configDefaultHealthRetention, &c.HealthMonitor.Retention},
+	}
+	for _, interval := range intervals {
+		if *interval.value == "" {
+			*interval.value = interval.fallback
+			continue
+		}
+		d, err := time.ParseDuration(*interval.value)
+		if err != nil || d <= 0 {
+			log.Printf(WarningHealthMonitorIntervalInvalid, interval.name, *interval.value, interval.fallback)
+			*interval.value = interval.fallback
+		}
+	}
+	if c.HealthMonitor.FailureThreshold <= 0 {
+		c.HealthMonitor.FailureThreshold = configDefaultHealthFailureThreshold
+	}
+}
+
+// CheckAPIKeyExpiryConfigValues checks the API key expiry settings, resetting
+// invalid values to their defaults
+func (c *Config) CheckAPIKeyExpiryConfigValues() {
+	if c.APIKeyExpiry.Interval == "" {
+		c.APIKeyExpiry.Interval = configDefaultAPIKeyExpiryInterval
+	} else if d, err := time.ParseDuration(c.APIKeyExpiry.Interval); err != nil || d <= 0 {
+		log.Printf(WarningAPIKeyExpiryIntervalInvalid, c.APIKeyExpiry.Interval, configDefaultAPIKeyExpiryInterval)
+		c.APIKeyExpiry.Interval = configDefaultAPIKeyExpiryInterval
+	}
+	if c.APIKeyExpiry.AlertDays <= 0 {
+		c.APIKeyExpiry.AlertDays = configDefaultAPIKeyExpiryAlertDays
+	}
+}
+
+// CheckSyncManagerConfigValues checks the sync manager settings, resetting
+// invalid values to their defaults
+func (c *Config) CheckSyncManagerConfigValues() {
+	if c.SyncManager.WebsocketTimeout == "" {
+		c.SyncManager.WebsocketTimeout = configDefaultSyncWebsocketTimeout
+	} else if d, err := time.ParseDuration(c.SyncManager.WebsocketTimeout); err != nil || d <= 0 {
+		log.Printf(WarningSyncWebsocketTimeoutInvalid, c.SyncManager.WebsocketTimeout, configDefaultSyncWebsocketTimeout)
+		c.SyncManager.WebsocketTimeout = configDefaultSyncWebsocketTimeout
+	}
+}
+
+// ParseAPIKeyDate parses an exchange API key creation or expiry date, either
+// a date such as 2019-06-30, starting at midnight UTC, or an RFC3339 time. An
+// empty date returns the zero time.
+func ParseAPIKeyDate(date string) (time.Time, error) {
+	if date == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse("2006-01-02", date); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, date)
+}
+
+// checkAPIKeyDates ignores an exchange's invalid API key dates and disables
+// its authenticated API support once its API key has expired
+func (c *Config) checkAPIKeyDates(i int, now time.Time) {
+	exch := &c.Exchanges[i]
+	dates := []struct {
+		name string
+		date *string
+	}{
+		{"creation", &exch.APIKeyCreated},
+		{"expiry", &exch.APIKeyExpiry},
+	}
+	for _, d := range dates {
+		if _, err := ParseAPIKeyDate(*d.date); err != nil {
+			log.Printf(WarningExchangeAPIKeyDateInvalid, exch.Name, d.name, *d.date)
+			*d.date = ""
+		}
+	}
+
+	expiry, _ := ParseAPIKeyDate(exch.APIKeyExpiry)
+	if exch.AuthenticatedAPISupport && !expiry.IsZero() && !now.Before(expiry) {
+		exch.AuthenticatedAPISupport = false
+		log.Printf(WarningExchangeAPIKeyExpired, exch.Name, exch.APIKeyExpiry)
+	}
+}
+
+// checkSyncSources normalises an exchange's ticker and orderbook sync sources,
+// resetting invalid sources to the default and websocket sources of exchanges
+// with websocket support disabled to REST
+func (c *Config) checkSyncSources(i int) {
+	exch := &c.Exchanges[i]
+	sources := []struct {
+		name   string
+		source *string
+	}{
+		{"ticker", &exch.TickerSync},
+		{"orderbook", &exch.OrderbookSync},
+	}
+	for _, s := range sources {
+		source := common.StringToUpper(*s.source)
+		switch source {
+		case "", SyncSourceREST:
+		case SyncSourceWebsocket, SyncSourceWebsocketFallback:
+			if !exch.Websocket {
+				log.Printf(WarningExchangeSyncSourceWebsocketDisabled, exch.Name, s.name, source)
+				source = SyncSourceREST
+			}
+		default:
+			log.Printf(WarningExchangeSyncSourceInvalid, exch.Name, s.name, *s.source)
+			source = ""
+		}
+		*s.source = source
+	}
+}
+
+// CheckDustSweepConfigValues checks the dust sweep settings, defaulting the
+// interval and quote currency when unset, and returns an error if values are
+// incorrect.
+func (c *Config) CheckDustSweepConfigValues() error {
+	if c.DustSweep.Interval == "" {
+		c.DustSweep.Interval = configDefaultDustSweepInterval
+	}
+	d, err := time.ParseDuration(c.DustSweep.Interval)
+	if err != nil || d <= 0 {
+		return fmt.Errorf(WarningDustSweepIntervalInvalid, c.DustSweep.Interval)
+	}
+	if c.DustSweep.QuoteCurrency == "" {
+		c.DustSweep.QuoteCurrency = configDefaultDustSweepQuoteCurrency
+	}
+	c.DustSweep.QuoteCurrency = common.StringToUpper(c.DustSweep.QuoteCurrency)
+	c.DustSweep.Exclude = common.StringToUpper(c.DustSweep.Exclude)
+	return nil
+}
+
+// CheckWithdrawalAddressBookConfigValues checks the withdrawal address book
+// settings and returns an error if values are incorrect. The database must be
+// checked first.
+func (c *Config) CheckWithdrawalAddressBookConfigValues() error {
+	if c.WithdrawalAddressBook.EncryptionKey == "" {
+		return errors.New(WarningAddressBookEncryptionKeyEmpty)
+	}
+	if !c.Database.Enabled {
+		return errors.New(WarningAddressBookDatabaseDisabled)
+	}
+	return nil
+}
+
+// CheckWithdrawalsConfigValues checks the withdrawal approval workflow
+// settings, uppercasing currencies, and returns an error if values are
+// incorrect. The database must be checked first.
+func (c *Config) CheckWithdrawalsConfigValues() error {
+	if !c.Database.Enabled {
+		return errors.New(WarningWithdrawalsDatabaseDisabled)
+	}
+	for i := range c.Withdrawals.Whitelist {
+		entry := &c.Withdrawals.Whitelist[i]
+		if entry.Currency == "" || entry.Address == "" {
+			return fmt.Errorf(WarningWithdrawalWhitelistInvalid, i)
+		}
+		entry.Currency = common.StringToUpper(entry.Currency)
+	}
+	limits := make(map[string]float64, len(c.Withdrawals.DailyLimits))
+	for currency, limit := range c.Withdrawals.DailyLimits {
+		if limit < 0 {
+			return fmt.Errorf(WarningWithdrawalDailyLimitInvalid, currency)
+		}
+		limits[common.StringToUpper(currency)] = limit
+	}
+	c.Withdrawals.DailyLimits = limits
+	if c.Withdrawals.Batching.Enabled {
+		return c.checkWithdrawalBatchingConfigValues()
+	}
+	return nil
+}
+
+// checkWithdrawalBatchingConfigValues checks the withdrawal batching
+// settings, defaulting the intervals when unset and uppercasing currencies
+func (c *Config) checkWithdrawalBatchingConfigValues() error {
+	b := &c.Withdrawals.Batching
+	if b.ProcessInterval == "" {
+		b.ProcessInterval = configDefaultWithdrawalBatchInterval
+	}
+	d, err := time.ParseDuration(b.ProcessInterval)
+	if err != nil || d <= 0 {
+		return fmt.Errorf(WarningWithdrawalBatchIntervalInvalid, "process interval", b.ProcessInterval)
+	}
+	if b.MaxDelay == "" {
+		b.MaxDelay = configDefaultWithdrawalBatchMaxDelay
+	}
+	d, err = time.ParseDuration(b.MaxDelay)
+	if err != nil || d <= 0 {
+		return fmt.Errorf(WarningWithdrawalBatchIntervalInvalid, "maximum delay", b.MaxDelay)
+	}
+	if b.MinBatchAmount < 0 {
+		return errors.New(WarningWithdrawalBatchValuesInvalid)
+	}
+	thresholds := make(map[string]float64, len(b.FeeThresholds))
+	for currency, threshold := range b.FeeThresholds {
+		if threshold < 0 {
+			return errors.New(WarningWithdrawalBatchValuesInvalid)
+		}
+		thresholds[common.StringToUpper(currency)] = threshold
+	}
+	b.FeeThresholds = thresholds
+	return nil
+}
+
+// CheckOrderThrottleConfigValues checks the strategy order throttle limits
+// and returns an error if values are incorrect.
+func (c *Config) CheckOrderThrottleConfigValues() error {
+	for name, t := range c.OrderThrottles {
+		if name == "" {
+			return errors.New(ErrOrderThrottleStrategyEmpty)
+		}
+		if t.MaxOrdersPerMinute < 0 || t.MaxOpenOrders < 0 || t.MinPairInterval < 0 {
+			return fmt.Errorf(ErrOrderThrottleValuesInvalid, name)
+		}
+	}
+	return nil
+}
+
 // CheckCurrencyConfigValues checks to see if the currency config values are correct or not
 func (c *Config) CheckCurrencyConfigValues() error {
 	if len(c.Currency.ForexProviders) == 0 {
@@ -906,9 +2186,25 @@ func (c *Config) CheckCurrencyConfigValues() error {
 			c.Currency.FiatDisplayCurrency = "USD"
 		}
 	}
+
+	if c.Currency.DisplayLocale == "" {
+		c.Currency.DisplayLocale = locale.DefaultName
+	} else if _, err := locale.Get(c.Currency.DisplayLocale); err != nil {
+		log.Printf(WarningDisplayLocaleUnsupported, c.Currency.DisplayLocale, locale.DefaultName)
+		c.Currency.DisplayLocale = locale.DefaultName
+	}
 	return nil
 }
 
+// GetDisplayLocale returns the locale fiat amounts are displayed in
+func (c *Config) GetDisplayLocale() locale.Locale {
+	l, err := locale.Get(c.Currency.DisplayLocale)
+	if err != nil {
+		return locale.Default
+	}
+	return l
+}
+
 // RetrieveConfigCurrencyPairs splits, assigns and verifies enabled currency
 // pairs either cryptoCurrencies or fiatCurrencies
 func (c *Config) RetrieveConfigCurrencyPairs(enabledOnly bool) error {
@@ -1121,7 +2417,8 @@ func (c *Config) SaveConfig(configPath string) error {
 		return err
 	}
 
-	payload, err := json.MarshalIndent(c, "", " ")
+	saved := c.withSecretPlaceholders()
+	payload, err := json.MarshalIndent(&saved, "", " ")
 	if err != nil {
 		return err
 	}
@@ -1152,7 +2449,12 @@ func (c *Config) SaveConfig(configPath string) error {
 
 // CheckConfig checks all config settings
 func (c *Config) CheckConfig() error {
-	err := c.CheckExchangeConfigValues()
+	err := c.ResolveSecrets()
+	if err != nil {
+		return fmt.Errorf(ErrCheckingConfigValues, err)
+	}
+
+	err = c.CheckExchangeConfigValues()
 	if err != nil {
 		return fmt.Errorf(ErrCheckingConfigValues, err)
 	}
@@ -1169,11 +2471,156 @@ func (c *Config) CheckConfig() error {
 		}
 	}
 
+	if c.GRPC.Enabled {
+		err = c.CheckGRPCConfigValues()
+		if err != nil {
+			log.Print(fmt.Errorf(ErrCheckingConfigValues, err))
+			c.GRPC.Enabled = false
+		}
+	}
+
+	if c.Database.Enabled {
+		err = c.CheckDatabaseConfigValues()
+		if err != nil {
+			log.Print(fmt.Errorf(ErrCheckingConfigValues, err))
+			c.Database.Enabled = false
+		}
+	}
+
+	if c.CandleBootstrap.Enabled {
+		err = c.CheckCandleBootstrapConfigValues()
+		if err != nil {
+			log.Print(fmt.Errorf(ErrCheckingConfigValues, err))
+			c.CandleBootstrap.Enabled = false
+		}
+	}
+
+	if c.MetadataCache.Enabled {
+		err = c.CheckMetadataCacheConfigValues()
+		if err != nil {
+			log.Print(fmt.Errorf(ErrCheckingConfigValues, err))
+			c.MetadataCache.Enabled = false
+		}
+	}
+
+	if c.Arbitrage.Enabled {
+		err = c.CheckArbitrageConfigValues()
+		if err != nil {
+			log.Print(fmt.Errorf(ErrCheckingConfigValues, err))
+			c.Arbitrage.Enabled = false
+		}
+	}
+
+	if c.Statements.Enabled {
+		err = c.CheckStatementsConfigValues()
+		if err != nil {
+			log.Print(fmt.Errorf(ErrCheckingConfigValues, err))
+			c.Statements.Enabled = false
+		}
+	}
+
+	if c.PortfolioSync.Enabled {
+		err = c.CheckPortfolioSyncConfigValues()
+		if err != nil {
+			log.Print(fmt.Errorf(ErrCheckingConfigValues, err))
+			c.PortfolioSync.Enabled = false
+		}
+	}
+
+	if c.Strategies.Enabled {
+		err = c.CheckStrategiesConfigValues()
+		if err != nil {
+			log.Print(fmt.Errorf(ErrCheckingConfigValues, err))
+			c.Strategies.Enabled = false
+		}
+	}
+
+	if c.Strategies.Enabled && c.Strategies.Bridge.Enabled {
+		err = c.CheckStrategyBridgeConfigValues()
+		if err != nil {
+			log.Print(fmt.Errorf(ErrCheckingConfigValues, err))
+			c.Strategies.Bridge.Enabled = false
+		}
+	}
+
+	if c.Basis.Enabled {
+		err = c.CheckBasisConfigValues()
+		if err != nil {
+			log.Print(fmt.Errorf(ErrCheckingConfigValues, err))
+			c.Basis.Enabled = false
+		}
+	}
+
+	if c.Hedging.Enabled {
+		err = c.CheckHedgingConfigValues()
+		if err != nil {
+			log.Print(fmt.Errorf(ErrCheckingConfigValues, err))
+			c.Hedging.Enabled = false
+		}
+	}
+
+	if c.Liquidation.Enabled {
+		err = c.CheckLiquidationConfigValues()
+		if err != nil {
+			log.Print(fmt.Errorf(ErrCheckingConfigValues, err))
+			c.Liquidation.Enabled = false
+		}
+	}
+
+	if c.Calendar.Enabled {
+		err = c.CheckCalendarConfigValues()
+		if err != nil {
+			log.Print(fmt.Errorf(ErrCheckingConfigValues, err))
+			c.Calendar.Enabled = false
+		}
+	}
+
+	if c.MarginMonitor.Enabled {
+		err = c.CheckMarginMonitorConfigValues()
+		if err != nil {
+			log.Print(fmt.Errorf(ErrCheckingConfigValues, err))
+			c.MarginMonitor.Enabled = false
+		}
+	}
+
+	c.CheckHealthMonitorConfigValues()
+	c.CheckAPIKeyExpiryConfigValues()
+	c.CheckSyncManagerConfigValues()
+
+	if c.DustSweep.Enabled {
+		err = c.CheckDustSweepConfigValues()
+		if err != nil {
+			log.Print(fmt.Errorf(ErrCheckingConfigValues, err))
+			c.DustSweep.Enabled = false
+		}
+	}
+
+	if c.WithdrawalAddressBook.Enabled {
+		err = c.CheckWithdrawalAddressBookConfigValues()
+		if err != nil {
+			log.Print(fmt.Errorf(ErrCheckingConfigValues, err))
+			c.WithdrawalAddressBook.Enabled = false
+		}
+	}
+
+	if c.Withdrawals.Enabled {
+		err = c.CheckWithdrawalsConfigValues()
+		if err != nil {
+			log.Print(fmt.Errorf(ErrCheckingConfigValues, err))
+			c.Withdrawals.Enabled = false
+		}
+	}
+
 	err = c.CheckCurrencyConfigValues()
 	if err != nil {
 		return err
 	}
 
+	err = c.CheckOrderThrottleConfigValues()
+	if err != nil {
+		return fmt.Errorf(ErrCheckingConfigValues, err)
+	}
+
 	if c.GlobalHTTPTimeout <= 0 {
 		log.Printf("Global HTTP Timeout value not set, defaulting to %v.", configDefaultHTTPTimeout)
 		c.GlobalHTTPTimeout = configDefaultHTTPTimeout
@@ -1212,6 +2659,7 @@ func (c *Config) UpdateConfig(configPath string, newCfg Config) error {
 	c.Communications = newCfg.Communications
 	c.Webserver = newCfg.Webserver
 	c.Exchanges = newCfg.Exchanges
+	c.secrets = newCfg.secrets
 
 	err = c.SaveConfig(configPath)
 	if err != nil {
@@ -0,0 +1,145 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/thrasher-/gocryptotrader/common"
+)
+
+// Credential placeholder formats, values in the config file matching these
+// are resolved at load time so credentials do not need to be stored in the
+// config file itself e.g.
+//
+//	"apiKey": "${env:GCT_BITFINEX_API_KEY}"
+//	"apiSecret": "${file:/run/secrets/bitfinex_api_secret}"
+const (
+	secretPlaceholderPrefix = "${"
+	secretPlaceholderSuffix = "}"
+	secretSourceEnv         = "env"
+	secretSourceFile        = "file"
+)
+
+// secret holds a resolved credential and the placeholder it was resolved from
+// so the placeholder, not the credential, is written back on save
+type secret struct {
+	placeholder string
+	resolved    string
+}
+
+// IsSecretPlaceholder returns whether the supplied value references an
+// environment variable or mounted secret file
+func IsSecretPlaceholder(value string) bool {
+	_, _, ok := parseSecretPlaceholder(value)
+	return ok
+}
+
+// parseSecretPlaceholder splits a placeholder into its source and reference
+func parseSecretPlaceholder(value string) (source, ref string, ok bool) {
+	if !strings.HasPrefix(value, secretPlaceholderPrefix) ||
+		!strings.HasSuffix(value, secretPlaceholderSuffix) {
+		return "", "", false
+	}
+
+	inner := value[len(secretPlaceholderPrefix) : len(value)-len(secretPlaceholderSuffix)]
+	split := strings.SplitN(inner, ":", 2)
+	if len(split) != 2 || split[1] == "" {
+		return "", "", false
+	}
+
+	switch strings.ToLower(split[0]) {
+	case secretSourceEnv, secretSourceFile:
+		return strings.ToLower(split[0]), split[1], true
+	}
+	return "", "", false
+}
+
+// ResolveSecret returns the credential referenced by a placeholder. Values
+// which are not placeholders are returned unchanged.
+func ResolveSecret(value string) (string, error) {
+	source, ref, ok := parseSecretPlaceholder(value)
+	if !ok {
+		return value, nil
+	}
+
+	switch source {
+	case secretSourceEnv:
+		v, found := os.LookupEnv(ref)
+		if !found {
+			return "", fmt.Errorf("environment variable %s referenced by config is not set", ref)
+		}
+		return v, nil
+	default:
+		data, err := common.ReadFile(ref)
+		if err != nil {
+			return "", fmt.Errorf("unable to read secret file %s referenced by config: %s", ref, err)
+		}
+		// Docker and Kubernetes secrets are frequently written with a
+		// trailing newline
+		return strings.TrimRight(string(data), "\r\n"), nil
+	}
+}
+
+// secretFields returns pointers to all config values which may hold a
+// credential placeholder, keyed by a stable field path
+func (c *Config) secretFields() map[string]*string {
+	fields := map[string]*string{
+		"webserver.adminPassword":             &c.Webserver.AdminPassword,
+		"withdrawalAddressBook.encryptionKey": &c.WithdrawalAddressBook.EncryptionKey,
+		"strategies.bridge.token":             &c.Strategies.Bridge.Token,
+	}
+	for i := range c.Exchanges {
+		prefix := "exchanges." + c.Exchanges[i].Name + "."
+		fields[prefix+"apiKey"] = &c.Exchanges[i].APIKey
+		fields[prefix+"apiSecret"] = &c.Exchanges[i].APISecret
+		fields[prefix+"apiAuthPemKey"] = &c.Exchanges[i].APIAuthPEMKey
+		fields[prefix+"clientId"] = &c.Exchanges[i].ClientID
+	}
+	return fields
+}
+
+// ResolveSecrets replaces all credential placeholders in the config with the
+// values from their environment variable or secret file
+func (c *Config) ResolveSecrets() error {
+	m.Lock()
+	defer m.Unlock()
+
+	for field, value := range c.secretFields() {
+		if !IsSecretPlaceholder(*value) {
+			continue
+		}
+
+		resolved, err := ResolveSecret(*value)
+		if err != nil {
+			return fmt.Errorf("%s: %s", field, err)
+		}
+
+		if c.secrets == nil {
+			c.secrets = make(map[string]secret)
+		}
+		c.secrets[field] = secret{placeholder: *value, resolved: resolved}
+		*value = resolved
+	}
+	return nil
+}
+
+// withSecretPlaceholders returns a copy of the config with resolved
+// credentials swapped back to their placeholders, values changed since they
+// were resolved are kept as is
+func (c *Config) withSecretPlaceholders() Config {
+	cfg := *c
+	if len(c.secrets) == 0 {
+		return cfg
+	}
+
+	cfg.Exchanges = make([]ExchangeConfig, len(c.Exchanges))
+	copy(cfg.Exchanges, c.Exchanges)
+	for field, value := range cfg.secretFields() {
+		s, ok := c.secrets[field]
+		if ok && *value == s.resolved {
+			*value = s.placeholder
+		}
+	}
+	return cfg
+}
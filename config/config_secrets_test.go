@@ -0,0 +1,114 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIsSecretPlaceholder(t *testing.T) {
+	tests := map[string]bool{
+		"${env:GCT_KEY}":           true,
+		"${ENV:GCT_KEY}":           true,
+		"${file:/run/secrets/key}": true,
+		"${env:}":                  false,
+		"${vault:secret/key}":      false,
+		"env:GCT_KEY":              false,
+		"Key":                      false,
+		"${file:/run/secrets/key":  false,
+		"":                         false,
+	}
+	for value, expected := range tests {
+		if IsSecretPlaceholder(value) != expected {
+			t.Errorf("Test failed. IsSecretPlaceholder(%s) expected %v", value, expected)
+		}
+	}
+}
+
+func TestResolveSecret(t *testing.T) {
+	v, err := ResolveSecret("Key")
+	if err != nil || v != "Key" {
+		t.Error("Test failed. ResolveSecret() should not modify plain values")
+	}
+
+	os.Setenv("GCT_TEST_SECRET", "envsecret")
+	defer os.Unsetenv("GCT_TEST_SECRET")
+	v, err = ResolveSecret("${env:GCT_TEST_SECRET}")
+	if err != nil || v != "envsecret" {
+		t.Errorf("Test failed. ResolveSecret() env expected envsecret got %s %v", v, err)
+	}
+
+	_, err = ResolveSecret("${env:GCT_TEST_SECRET_NOT_SET}")
+	if err == nil {
+		t.Error("Test failed. ResolveSecret() expected error on unset variable")
+	}
+
+	dir, err := ioutil.TempDir("", "gctsecrets")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	secretFile := filepath.Join(dir, "api_secret")
+	err = ioutil.WriteFile(secretFile, []byte("filesecret\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v, err = ResolveSecret("${file:" + secretFile + "}")
+	if err != nil || v != "filesecret" {
+		t.Errorf("Test failed. ResolveSecret() file expected filesecret got %s %v", v, err)
+	}
+
+	_, err = ResolveSecret("${file:" + filepath.Join(dir, "missing") + "}")
+	if err == nil {
+		t.Error("Test failed. ResolveSecret() expected error on missing file")
+	}
+}
+
+func TestResolveSecrets(t *testing.T) {
+	os.Setenv("GCT_TEST_API_KEY", "resolvedkey")
+	defer os.Unsetenv("GCT_TEST_API_KEY")
+
+	c := Config{
+		Exchanges: []ExchangeConfig{
+			{
+				Name:      "Bitfinex",
+				APIKey:    "${env:GCT_TEST_API_KEY}",
+				APISecret: "Secret",
+			},
+		},
+	}
+
+	err := c.ResolveSecrets()
+	if err != nil {
+		t.Fatal("Test failed. ResolveSecrets() error", err)
+	}
+	if c.Exchanges[0].APIKey != "resolvedkey" {
+		t.Error("Test failed. ResolveSecrets() API key not resolved")
+	}
+	if c.Exchanges[0].APISecret != "Secret" {
+		t.Error("Test failed. ResolveSecrets() plain value modified")
+	}
+
+	saved := c.withSecretPlaceholders()
+	if saved.Exchanges[0].APIKey != "${env:GCT_TEST_API_KEY}" {
+		t.Error("Test failed. withSecretPlaceholders() placeholder not restored")
+	}
+	if c.Exchanges[0].APIKey != "resolvedkey" {
+		t.Error("Test failed. withSecretPlaceholders() modified the running config")
+	}
+
+	c.Exchanges[0].APIKey = "newkey"
+	saved = c.withSecretPlaceholders()
+	if saved.Exchanges[0].APIKey != "newkey" {
+		t.Error("Test failed. withSecretPlaceholders() overwrote an updated value")
+	}
+
+	c.Exchanges[0].APISecret = "${env:GCT_TEST_API_SECRET_NOT_SET}"
+	err = c.ResolveSecrets()
+	if err == nil {
+		t.Error("Test failed. ResolveSecrets() expected error on unset variable")
+	}
+}
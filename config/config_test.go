@@ -1,9 +1,12 @@
 package config
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/currency/locale"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
 )
 
@@ -391,7 +394,7 @@ func TestGetEnabledExchanges(t *testing.T) {
 	}
 
 	exchanges := cfg.GetEnabledExchanges()
-	if len(exchanges) != 30 {
+	if len(exchanges) != 36 {
 		t.Error(
 			"Test failed. TestGetEnabledExchanges. Enabled exchanges value mismatch",
 		)
@@ -443,7 +446,7 @@ func TestGetDisabledExchanges(t *testing.T) {
 }
 
 func TestCountEnabledExchanges(t *testing.T) {
-	defaultEnabledExchanges := 30
+	defaultEnabledExchanges := 36
 	GetConfigEnabledExchanges := GetConfig()
 	err := GetConfigEnabledExchanges.LoadConfig(ConfigTestFile)
 	if err != nil {
@@ -638,6 +641,12 @@ func TestCheckExchangeConfigValues(t *testing.T) {
 		t.Fatalf("Test failed. Expected exchange %s to have updated HTTPTimeout value", checkExchangeConfigValues.Exchanges[0].Name)
 	}
 
+	checkExchangeConfigValues.Exchanges[0].DustThresholds = map[string]float64{"btc": 0.001, "ETH": -1}
+	checkExchangeConfigValues.CheckExchangeConfigValues()
+	if th := checkExchangeConfigValues.Exchanges[0].DustThresholds; len(th) != 1 || th["BTC"] != 0.001 {
+		t.Error("Test failed. Expected dust thresholds normalised and negative thresholds removed", th)
+	}
+
 	checkExchangeConfigValues.Exchanges[0].APIKey = "Key"
 	checkExchangeConfigValues.Exchanges[0].APISecret = "Secret"
 	checkExchangeConfigValues.Exchanges[0].AuthenticatedAPISupport = true
@@ -905,3 +914,652 @@ func TestUpdateConfig(t *testing.T) {
 		t.Fatalf("Test failed. Cryptocurrencies should have been repopulated")
 	}
 }
+
+func TestCheckGRPCConfigValues(t *testing.T) {
+	c := &Config{GRPC: GRPCConfig{
+		Enabled:       true,
+		ListenAddress: "localhost:9052",
+		Username:      "admin",
+		Password:      "Password",
+	}}
+	err := c.CheckGRPCConfigValues()
+	if err != nil {
+		t.Error("Test failed. CheckGRPCConfigValues error", err)
+	}
+
+	c.GRPC.TLSCertFile = "cert.pem"
+	err = c.CheckGRPCConfigValues()
+	if err == nil || err.Error() != WarningGRPCTLSFilesIncomplete {
+		t.Error("Test failed. CheckGRPCConfigValues expected TLS files error", err)
+	}
+
+	c.GRPC.TLSCertFile = ""
+	c.GRPC.ListenAddress = "localhost:0"
+	err = c.CheckGRPCConfigValues()
+	if err == nil || err.Error() != WarningGRPCListenAddressInvalid {
+		t.Error("Test failed. CheckGRPCConfigValues expected listen address error", err)
+	}
+
+	c.GRPC.Password = ""
+	err = c.CheckGRPCConfigValues()
+	if err == nil || err.Error() != WarningGRPCCredentialValuesEmpty {
+		t.Error("Test failed. CheckGRPCConfigValues expected credentials error", err)
+	}
+}
+
+func TestCheckDatabaseConfigValues(t *testing.T) {
+	c := &Config{Database: DatabaseConfig{Enabled: true, Driver: "sqlite", Database: "gct.db"}}
+	err := c.CheckDatabaseConfigValues()
+	if err != nil {
+		t.Error("Test failed. CheckDatabaseConfigValues error", err)
+	}
+
+	c.Database.Driver = "postgres"
+	err = c.CheckDatabaseConfigValues()
+	if err == nil || err.Error() != WarningDatabaseHostEmpty {
+		t.Error("Test failed. CheckDatabaseConfigValues expected host error", err)
+	}
+
+	c.Database.Driver = "mysql"
+	err = c.CheckDatabaseConfigValues()
+	if err == nil || err.Error() != WarningDatabaseDriverUnsupported {
+		t.Error("Test failed. CheckDatabaseConfigValues expected driver error", err)
+	}
+
+	c.Database.Driver = "sqlite"
+	if c.Database.Retention.PruneInterval != configDefaultDatabasePruneInterval {
+		t.Error("Test failed. CheckDatabaseConfigValues expected default prune interval", c.Database.Retention)
+	}
+	c.Database.Retention.Trades = "7d"
+	err = c.CheckDatabaseConfigValues()
+	if err == nil || err.Error() != fmt.Sprintf(WarningDatabaseRetentionInvalid, "7d") {
+		t.Error("Test failed. CheckDatabaseConfigValues expected retention error", err)
+	}
+	c.Database.Retention.Trades = "168h"
+	c.Database.Retention.ArchiveInterval = "-1m"
+	err = c.CheckDatabaseConfigValues()
+	if err == nil || err.Error() != fmt.Sprintf(WarningDatabaseRetentionInvalid, "-1m") {
+		t.Error("Test failed. CheckDatabaseConfigValues expected archive interval error", err)
+	}
+}
+
+func TestCheckCandleBootstrapConfigValues(t *testing.T) {
+	c := &Config{CandleBootstrap: CandleBootstrapConfig{Enabled: true}}
+	err := c.CheckCandleBootstrapConfigValues()
+	if err != nil {
+		t.Error("Test failed. CheckCandleBootstrapConfigValues error", err)
+	}
+	if c.CandleBootstrap.Days != configDefaultCandleBootstrapDays ||
+		len(c.CandleBootstrap.Intervals) != 1 ||
+		c.CandleBootstrap.Intervals[0] != configDefaultCandleBootstrapInterval {
+		t.Error("Test failed. CheckCandleBootstrapConfigValues expected defaults", c.CandleBootstrap)
+	}
+
+	c.CandleBootstrap.Intervals = []string{"15m", "1d"}
+	err = c.CheckCandleBootstrapConfigValues()
+	if err == nil {
+		t.Error("Test failed. CheckCandleBootstrapConfigValues expected interval error")
+	}
+}
+
+func TestCheckMetadataCacheConfigValues(t *testing.T) {
+	c := &Config{MetadataCache: MetadataCacheConfig{Enabled: true}}
+	err := c.CheckMetadataCacheConfigValues()
+	if err != nil {
+		t.Error("Test failed. CheckMetadataCacheConfigValues error", err)
+	}
+	if c.MetadataCache.MaxSizeMB != configDefaultMetadataCacheSizeMB ||
+		c.MetadataCache.MaxAge != configDefaultMetadataCacheMaxAge {
+		t.Error("Test failed. CheckMetadataCacheConfigValues expected defaults", c.MetadataCache)
+	}
+
+	c.MetadataCache.MaxAge = "1d"
+	err = c.CheckMetadataCacheConfigValues()
+	if err == nil {
+		t.Error("Test failed. CheckMetadataCacheConfigValues expected max age error")
+	}
+}
+
+func TestCheckArbitrageConfigValues(t *testing.T) {
+	c := &Config{Arbitrage: ArbitrageConfig{Enabled: true}}
+	err := c.CheckArbitrageConfigValues()
+	if err != nil {
+		t.Error("Test failed. CheckArbitrageConfigValues error", err)
+	}
+	if c.Arbitrage.ScanInterval != configDefaultArbitrageScanInterval ||
+		c.Arbitrage.MaxAge != configDefaultArbitrageMaxAge ||
+		c.Arbitrage.Amount != configDefaultArbitrageAmount {
+		t.Error("Test failed. CheckArbitrageConfigValues expected defaults", c.Arbitrage)
+	}
+
+	c.Arbitrage.ScanInterval = "10"
+	err = c.CheckArbitrageConfigValues()
+	if err == nil {
+		t.Error("Test failed. CheckArbitrageConfigValues expected scan interval error")
+	}
+
+	c.Arbitrage.ScanInterval = "30s"
+	c.Arbitrage.MinProfitPercent = -1
+	err = c.CheckArbitrageConfigValues()
+	if err == nil || err.Error() != WarningArbitrageValuesInvalid {
+		t.Error("Test failed. CheckArbitrageConfigValues expected minimum profit error", err)
+	}
+}
+
+func TestCheckStatementsConfigValues(t *testing.T) {
+	c := &Config{Statements: StatementsConfig{Enabled: true, Email: true}}
+	err := c.CheckStatementsConfigValues()
+	if err != nil {
+		t.Error("Test failed. CheckStatementsConfigValues error", err)
+	}
+	if len(c.Statements.Formats) != 2 || c.Statements.Formats[0] != "html" || c.Statements.Email {
+		t.Error("Test failed. CheckStatementsConfigValues expected defaults without email", c.Statements)
+	}
+
+	c.Communications.SMTPConfig.Enabled = true
+	c.Statements.Email = true
+	c.Statements.Formats = []string{"PDF"}
+	err = c.CheckStatementsConfigValues()
+	if err != nil || c.Statements.Formats[0] != "pdf" || !c.Statements.Email {
+		t.Error("Test failed. CheckStatementsConfigValues expected pdf emailed", c.Statements, err)
+	}
+
+	c.Statements.Formats = []string{"docx"}
+	err = c.CheckStatementsConfigValues()
+	if err == nil {
+		t.Error("Test failed. CheckStatementsConfigValues expected format error")
+	}
+}
+
+func TestCheckPortfolioSyncConfigValues(t *testing.T) {
+	c := &Config{PortfolioSync: PortfolioSyncConfig{Enabled: true, BaseCurrency: "eur"}}
+	err := c.CheckPortfolioSyncConfigValues()
+	if err != nil {
+		t.Error("Test failed. CheckPortfolioSyncConfigValues error", err)
+	}
+	if c.PortfolioSync.Interval != configDefaultPortfolioSyncInterval || c.PortfolioSync.BaseCurrency != "EUR" {
+		t.Error("Test failed. CheckPortfolioSyncConfigValues expected defaults", c.PortfolioSync)
+	}
+
+	c.PortfolioSync.Interval = "-5m"
+	err = c.CheckPortfolioSyncConfigValues()
+	if err == nil {
+		t.Error("Test failed. CheckPortfolioSyncConfigValues expected interval error")
+	}
+
+	c.PortfolioSync.Interval = "1h"
+	c.PortfolioSync.MaxSnapshots = -1
+	err = c.CheckPortfolioSyncConfigValues()
+	if err == nil || err.Error() != WarningPortfolioSyncMaxSnapshotsInvalid {
+		t.Error("Test failed. CheckPortfolioSyncConfigValues expected max snapshots error", err)
+	}
+}
+
+func TestCheckLiquidationConfigValues(t *testing.T) {
+	c := &Config{Liquidation: LiquidationConfig{Enabled: true}}
+	err := c.CheckLiquidationConfigValues()
+	if err != nil {
+		t.Error("Test failed. CheckLiquidationConfigValues error", err)
+	}
+	if c.Liquidation.PollInterval != configDefaultLiquidationPollInterval ||
+		c.Liquidation.MaxMarginRatio != configDefaultLiquidationMaxMarginRatio ||
+		c.Liquidation.MinDistancePercent != configDefaultLiquidationMinDistance {
+		t.Error("Test failed. CheckLiquidationConfigValues expected defaults", c.Liquidation)
+	}
+
+	c.Liquidation.MaxMarginRatio = 1.5
+	err = c.CheckLiquidationConfigValues()
+	if err == nil {
+		t.Error("Test failed. CheckLiquidationConfigValues expected margin ratio error")
+	}
+
+	c.Liquidation.MaxMarginRatio = 0.9
+	c.Liquidation.PollInterval = "1"
+	err = c.CheckLiquidationConfigValues()
+	if err == nil {
+		t.Error("Test failed. CheckLiquidationConfigValues expected poll interval error")
+	}
+}
+
+func TestCheckCalendarConfigValues(t *testing.T) {
+	c := &Config{Calendar: CalendarConfig{Enabled: true}}
+	err := c.CheckCalendarConfigValues()
+	if err != nil {
+		t.Error("Test failed. CheckCalendarConfigValues error", err)
+	}
+	if c.Calendar.PollInterval != configDefaultCalendarPollInterval ||
+		c.Calendar.RollWindow != configDefaultCalendarRollWindow {
+		t.Error("Test failed. CheckCalendarConfigValues expected defaults", c.Calendar)
+	}
+
+	c.Calendar.MaxPosition = -1
+	err = c.CheckCalendarConfigValues()
+	if err == nil {
+		t.Error("Test failed. CheckCalendarConfigValues expected limits error")
+	}
+
+	c.Calendar.MaxPosition = 10
+	c.Calendar.RollWindow = "3d"
+	err = c.CheckCalendarConfigValues()
+	if err == nil {
+		t.Error("Test failed. CheckCalendarConfigValues expected roll window error")
+	}
+}
+
+func TestCheckMarginMonitorConfigValues(t *testing.T) {
+	c := &Config{MarginMonitor: MarginMonitorConfig{Enabled: true}}
+	err := c.CheckMarginMonitorConfigValues()
+	if err != nil {
+		t.Error("Test failed. CheckMarginMonitorConfigValues error", err)
+	}
+	if c.MarginMonitor.PollInterval != configDefaultMarginPollInterval ||
+		len(c.MarginMonitor.Rules) != 1 ||
+		c.MarginMonitor.Rules[0].MarginRatio != configDefaultMarginAlertRatio {
+		t.Error("Test failed. CheckMarginMonitorConfigValues expected defaults", c.MarginMonitor)
+	}
+
+	c.MarginMonitor.Rules = append(c.MarginMonitor.Rules,
+		MarginRuleConfig{MarginRatio: 0.85, Action: "reduce", ReduceFraction: 0.25},
+		MarginRuleConfig{MarginRatio: 0.8, Action: "addCollateral"})
+	err = c.CheckMarginMonitorConfigValues()
+	if err == nil {
+		t.Error("Test failed. CheckMarginMonitorConfigValues expected collateral amount error")
+	}
+
+	c.MarginMonitor.Rules[2].CollateralAmount = 100
+	c.MarginMonitor.Cooldown = "5"
+	err = c.CheckMarginMonitorConfigValues()
+	if err == nil {
+		t.Error("Test failed. CheckMarginMonitorConfigValues expected cooldown error")
+	}
+
+	c.MarginMonitor.Cooldown = "5m"
+	err = c.CheckMarginMonitorConfigValues()
+	if err != nil {
+		t.Error("Test failed. CheckMarginMonitorConfigValues error", err)
+	}
+}
+
+func TestCheckHealthMonitorConfigValues(t *testing.T) {
+	c := &Config{}
+	c.CheckHealthMonitorConfigValues()
+	if c.HealthMonitor.Interval != configDefaultHealthCheckInterval ||
+		c.HealthMonitor.Timeout != configDefaultHealthCheckTimeout ||
+		c.HealthMonitor.FailureThreshold != configDefaultHealthFailureThreshold ||
+		c.HealthMonitor.Retention != configDefaultHealthRetention {
+		t.Error("Test failed. CheckHealthMonitorConfigValues expected defaults", c.HealthMonitor)
+	}
+
+	c.HealthMonitor = HealthMonitorConfig{Interval: "5", Timeout: "10s", FailureThreshold: 5}
+	c.CheckHealthMonitorConfigValues()
+	if c.HealthMonitor.Interval != configDefaultHealthCheckInterval ||
+		c.HealthMonitor.Timeout != "10s" || c.HealthMonitor.FailureThreshold != 5 {
+		t.Error("Test failed. CheckHealthMonitorConfigValues expected invalid interval reset", c.HealthMonitor)
+	}
+}
+
+func TestCheckAPIKeyExpiryConfigValues(t *testing.T) {
+	c := &Config{}
+	c.CheckAPIKeyExpiryConfigValues()
+	if c.APIKeyExpiry.Interval != configDefaultAPIKeyExpiryInterval ||
+		c.APIKeyExpiry.AlertDays != configDefaultAPIKeyExpiryAlertDays {
+		t.Error("Test failed. CheckAPIKeyExpiryConfigValues expected defaults", c.APIKeyExpiry)
+	}
+
+	c.APIKeyExpiry = APIKeyExpiryConfig{Interval: "-1h", AlertDays: 30}
+	c.CheckAPIKeyExpiryConfigValues()
+	if c.APIKeyExpiry.Interval != configDefaultAPIKeyExpiryInterval || c.APIKeyExpiry.AlertDays != 30 {
+		t.Error("Test failed. CheckAPIKeyExpiryConfigValues expected invalid interval reset", c.APIKeyExpiry)
+	}
+}
+
+func TestCheckAPIKeyDates(t *testing.T) {
+	now := time.Date(2019, 6, 30, 12, 0, 0, 0, time.UTC)
+	c := &Config{Exchanges: []ExchangeConfig{
+		{Name: "Binance", AuthenticatedAPISupport: true, APIKeyCreated: "30/06/2018", APIKeyExpiry: "2019-07-01"},
+		{Name: "Bybit", AuthenticatedAPISupport: true, APIKeyExpiry: "2019-06-30T11:00:00Z"},
+	}}
+	c.checkAPIKeyDates(0, now)
+	c.checkAPIKeyDates(1, now)
+
+	if c.Exchanges[0].APIKeyCreated != "" || !c.Exchanges[0].AuthenticatedAPISupport {
+		t.Error("Test failed. checkAPIKeyDates expected invalid date ignored", c.Exchanges[0])
+	}
+	if c.Exchanges[1].AuthenticatedAPISupport {
+		t.Error("Test failed. checkAPIKeyDates expected expired key to disable authenticated support")
+	}
+
+	expiry, err := ParseAPIKeyDate("2019-07-01")
+	if err != nil || !expiry.Equal(time.Date(2019, 7, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Error("Test failed. ParseAPIKeyDate incorrect date", expiry, err)
+	}
+}
+
+func TestCheckSyncSources(t *testing.T) {
+	c := &Config{Exchanges: []ExchangeConfig{
+		{Name: "Binance", Websocket: true, TickerSync: "websocket_fallback", OrderbookSync: "carrier pigeon"},
+		{Name: "Bybit", TickerSync: "rest", OrderbookSync: "WEBSOCKET"},
+	}}
+	c.checkSyncSources(0)
+	c.checkSyncSources(1)
+
+	if c.Exchanges[0].TickerSync != SyncSourceWebsocketFallback || c.Exchanges[0].OrderbookSync != "" {
+		t.Error("Test failed. checkSyncSources expected invalid source reset", c.Exchanges[0])
+	}
+	if c.Exchanges[1].TickerSync != SyncSourceREST || c.Exchanges[1].OrderbookSync != SyncSourceREST {
+		t.Error("Test failed. checkSyncSources expected REST without websocket support", c.Exchanges[1])
+	}
+}
+
+func TestCheckSyncManagerConfigValues(t *testing.T) {
+	c := &Config{}
+	c.CheckSyncManagerConfigValues()
+	if c.SyncManager.WebsocketTimeout != configDefaultSyncWebsocketTimeout {
+		t.Error("Test failed. CheckSyncManagerConfigValues expected default", c.SyncManager)
+	}
+
+	c.SyncManager.WebsocketTimeout = "soon"
+	c.CheckSyncManagerConfigValues()
+	if c.SyncManager.WebsocketTimeout != configDefaultSyncWebsocketTimeout {
+		t.Error("Test failed. CheckSyncManagerConfigValues expected invalid timeout reset", c.SyncManager)
+	}
+}
+
+func TestCheckDustSweepConfigValues(t *testing.T) {
+	c := &Config{DustSweep: DustSweepConfig{Enabled: true, Exclude: "bnb,kcs"}}
+	err := c.CheckDustSweepConfigValues()
+	if err != nil {
+		t.Error("Test failed. CheckDustSweepConfigValues error", err)
+	}
+	if c.DustSweep.Interval != configDefaultDustSweepInterval ||
+		c.DustSweep.QuoteCurrency != configDefaultDustSweepQuoteCurrency || c.DustSweep.Exclude != "BNB,KCS" {
+		t.Error("Test failed. CheckDustSweepConfigValues expected defaults", c.DustSweep)
+	}
+
+	c.DustSweep.Interval = "-1h"
+	err = c.CheckDustSweepConfigValues()
+	if err == nil {
+		t.Error("Test failed. CheckDustSweepConfigValues expected interval error")
+	}
+}
+
+func TestCheckWithdrawalAddressBookConfigValues(t *testing.T) {
+	c := &Config{WithdrawalAddressBook: WithdrawalAddressBookConfig{Enabled: true}}
+	err := c.CheckWithdrawalAddressBookConfigValues()
+	if err == nil || err.Error() != WarningAddressBookEncryptionKeyEmpty {
+		t.Error("Test failed. CheckWithdrawalAddressBookConfigValues expected encryption key error", err)
+	}
+
+	c.WithdrawalAddressBook.EncryptionKey = "hunter2"
+	err = c.CheckWithdrawalAddressBookConfigValues()
+	if err == nil || err.Error() != WarningAddressBookDatabaseDisabled {
+		t.Error("Test failed. CheckWithdrawalAddressBookConfigValues expected database error", err)
+	}
+
+	c.Database.Enabled = true
+	err = c.CheckWithdrawalAddressBookConfigValues()
+	if err != nil {
+		t.Error("Test failed. CheckWithdrawalAddressBookConfigValues error", err)
+	}
+}
+
+func TestCheckWithdrawalsConfigValues(t *testing.T) {
+	c := &Config{Withdrawals: WithdrawalsConfig{
+		Enabled:     true,
+		Whitelist:   []WithdrawalWhitelistEntry{{Currency: "btc", Address: "1Addr"}},
+		DailyLimits: map[string]float64{"btc": 1},
+	}}
+	err := c.CheckWithdrawalsConfigValues()
+	if err == nil || err.Error() != WarningWithdrawalsDatabaseDisabled {
+		t.Error("Test failed. CheckWithdrawalsConfigValues expected database error", err)
+	}
+
+	c.Database.Enabled = true
+	err = c.CheckWithdrawalsConfigValues()
+	if err != nil {
+		t.Error("Test failed. CheckWithdrawalsConfigValues error", err)
+	}
+	if c.Withdrawals.Whitelist[0].Currency != "BTC" || c.Withdrawals.DailyLimits["BTC"] != 1 {
+		t.Error("Test failed. CheckWithdrawalsConfigValues expected currencies uppercased", c.Withdrawals)
+	}
+
+	c.Withdrawals.DailyLimits["ETH"] = -1
+	if err = c.CheckWithdrawalsConfigValues(); err == nil {
+		t.Error("Test failed. CheckWithdrawalsConfigValues expected negative limit error")
+	}
+
+	delete(c.Withdrawals.DailyLimits, "ETH")
+	c.Withdrawals.Whitelist = append(c.Withdrawals.Whitelist, WithdrawalWhitelistEntry{Currency: "ETH"})
+	if err = c.CheckWithdrawalsConfigValues(); err == nil {
+		t.Error("Test failed. CheckWithdrawalsConfigValues expected whitelist entry error")
+	}
+	c.Withdrawals.Whitelist = c.Withdrawals.Whitelist[:1]
+
+	c.Withdrawals.Batching = WithdrawalBatchingConfig{Enabled: true, FeeThresholds: map[string]float64{"btc": 10}}
+	if err = c.CheckWithdrawalsConfigValues(); err != nil {
+		t.Error("Test failed. CheckWithdrawalsConfigValues batching error", err)
+	}
+	if b := c.Withdrawals.Batching; b.ProcessInterval != configDefaultWithdrawalBatchInterval ||
+		b.MaxDelay != configDefaultWithdrawalBatchMaxDelay || b.FeeThresholds["BTC"] != 10 {
+		t.Error("Test failed. CheckWithdrawalsConfigValues expected batching defaults", b)
+	}
+
+	c.Withdrawals.Batching.MaxDelay = "soon"
+	if err = c.CheckWithdrawalsConfigValues(); err == nil {
+		t.Error("Test failed. CheckWithdrawalsConfigValues expected batching delay error")
+	}
+	c.Withdrawals.Batching.MaxDelay = "1h"
+	c.Withdrawals.Batching.FeeThresholds["ETH"] = -1
+	if err = c.CheckWithdrawalsConfigValues(); err == nil {
+		t.Error("Test failed. CheckWithdrawalsConfigValues expected negative fee threshold error")
+	}
+}
+
+func TestGetDisplayLocale(t *testing.T) {
+	c := &Config{}
+	err := c.CheckCurrencyConfigValues()
+	if err != nil {
+		t.Error("Test failed. CheckCurrencyConfigValues error", err)
+	}
+	if c.Currency.DisplayLocale != locale.DefaultName || c.GetDisplayLocale().Name != locale.DefaultName {
+		t.Error("Test failed. GetDisplayLocale expected default locale", c.Currency.DisplayLocale)
+	}
+
+	c.Currency.DisplayLocale = "de_DE"
+	err = c.CheckCurrencyConfigValues()
+	if err != nil || c.GetDisplayLocale().Name != "de-DE" {
+		t.Error("Test failed. GetDisplayLocale expected de-DE", c.Currency.DisplayLocale, err)
+	}
+
+	c.Currency.DisplayLocale = "xx-XX"
+	err = c.CheckCurrencyConfigValues()
+	if err != nil || c.Currency.DisplayLocale != locale.DefaultName {
+		t.Error("Test failed. CheckCurrencyConfigValues expected unsupported locale reset", c.Currency.DisplayLocale, err)
+	}
+}
+
+func TestCheckOrderThrottleConfigValues(t *testing.T) {
+	c := &Config{OrderThrottles: map[string]OrderThrottleConfig{
+		"marketmaker": {MaxOrdersPerMinute: 60, MaxOpenOrders: 10, MinPairInterval: time.Second},
+	}}
+	err := c.CheckOrderThrottleConfigValues()
+	if err != nil {
+		t.Error("Test failed. CheckOrderThrottleConfigValues error", err)
+	}
+
+	c.OrderThrottles["marketmaker"] = OrderThrottleConfig{MaxOpenOrders: -1}
+	err = c.CheckOrderThrottleConfigValues()
+	if err == nil {
+		t.Error("Test failed. CheckOrderThrottleConfigValues expected negative limits error")
+	}
+}
+
+func TestMigrateAssetTypes(t *testing.T) {
+	pairs := map[string]*AssetPairsConfig{
+		"perpetual": {AvailablePairs: "BTC-USDT,ETH-USDT", EnabledPairs: "BTC-USDT"},
+		"margin":    {AvailablePairs: "BTC-USDT", EnabledPairs: "BTC-USDT"},
+		"futures":   nil,
+	}
+	assetTypes, migrated := migrateAssetTypes("spot, MARGIN,perpetual,swap,options", pairs)
+	if assetTypes != "SPOT,MARGIN,PERPETUAL_SWAP,OPTIONS" {
+		t.Error("Test failed. migrateAssetTypes unexpected asset types", assetTypes)
+	}
+	if len(migrated) != 2 || migrated["PERPETUAL_SWAP"] == nil ||
+		migrated["PERPETUAL_SWAP"].EnabledPairs != "BTC-USDT" || migrated["MARGIN"] == nil {
+		t.Error("Test failed. migrateAssetTypes unexpected asset pairs", migrated)
+	}
+
+	assetTypes, migrated = migrateAssetTypes("", nil)
+	if assetTypes != "" || migrated != nil {
+		t.Error("Test failed. migrateAssetTypes expected empty asset types", assetTypes, migrated)
+	}
+}
+
+func TestCheckStrategiesConfigValues(t *testing.T) {
+	c := &Config{Strategies: StrategiesConfig{Enabled: true, RSI: []RSIStrategyConfig{
+		{Exchange: "Bitstamp", Pair: "btc-usd", OrderSize: 0.1},
+		{Name: "rsi-kraken", Exchange: "Kraken", Pair: "ETHUSD", OrderSize: 1},
+	}}}
+	err := c.CheckStrategiesConfigValues()
+	if err != nil {
+		t.Error("Test failed. CheckStrategiesConfigValues error", err)
+	}
+	if c.Strategies.CandleInterval != configDefaultStrategyCandleInterval ||
+		c.Strategies.RSI[0].Name != "rsi-bitstamp-btc-usd" || c.Strategies.RSI[0].Pair != "BTC-USD" {
+		t.Error("Test failed. CheckStrategiesConfigValues expected defaults", c.Strategies)
+	}
+
+	c.Strategies.RSI[1].Name = c.Strategies.RSI[0].Name
+	err = c.CheckStrategiesConfigValues()
+	if err == nil {
+		t.Error("Test failed. CheckStrategiesConfigValues expected duplicate name error")
+	}
+
+	c.Strategies.RSI[1].Name = ""
+	c.Strategies.MarketMaker = []MarketMakerStrategyConfig{
+		{Exchange: "Bitstamp", Pair: "btcusd", Spread: 0.002, OrderSize: 0.01},
+	}
+	err = c.CheckStrategiesConfigValues()
+	if err != nil || c.Strategies.MarketMaker[0].Name != "marketmaker-bitstamp-btcusd" {
+		t.Error("Test failed. CheckStrategiesConfigValues expected market maker defaults", err, c.Strategies.MarketMaker)
+	}
+
+	c.Strategies.MarketMaker[0].Spread = 1
+	err = c.CheckStrategiesConfigValues()
+	if err == nil {
+		t.Error("Test failed. CheckStrategiesConfigValues expected market maker spread error")
+	}
+
+	c.Strategies.MarketMaker = nil
+	c.Strategies.RSI[1].OrderSize = 0
+	err = c.CheckStrategiesConfigValues()
+	if err == nil {
+		t.Error("Test failed. CheckStrategiesConfigValues expected order size error")
+	}
+
+	c.Strategies.CandleInterval = "1"
+	err = c.CheckStrategiesConfigValues()
+	if err == nil {
+		t.Error("Test failed. CheckStrategiesConfigValues expected candle interval error")
+	}
+}
+
+func TestCheckStrategyBridgeConfigValues(t *testing.T) {
+	c := &Config{}
+	c.Strategies.Bridge.Enabled = true
+	err := c.CheckStrategyBridgeConfigValues()
+	if err == nil || err.Error() != WarningStrategyBridgeTokenEmpty {
+		t.Error("Test failed. CheckStrategyBridgeConfigValues expected token error", err)
+	}
+	if c.Strategies.Bridge.ListenAddress != configDefaultStrategyBridgeAddress {
+		t.Error("Test failed. CheckStrategyBridgeConfigValues expected default listen address",
+			c.Strategies.Bridge.ListenAddress)
+	}
+
+	c.Strategies.Bridge.Token = "hunter2"
+	c.Strategies.Bridge.ListenAddress = "localhost"
+	err = c.CheckStrategyBridgeConfigValues()
+	if err == nil {
+		t.Error("Test failed. CheckStrategyBridgeConfigValues expected listen address error")
+	}
+
+	c.Strategies.Bridge.ListenAddress = ":9053"
+	err = c.CheckStrategyBridgeConfigValues()
+	if err != nil {
+		t.Error("Test failed. CheckStrategyBridgeConfigValues error", err)
+	}
+}
+
+func TestCheckBasisConfigValues(t *testing.T) {
+	c := &Config{Basis: BasisConfig{Enabled: true, Spreads: []BasisSpreadConfig{
+		{SpotExchange: "OKX", Pair: "btc-usdt", FuturesExchange: "OKX", FuturesAssetType: "swap"},
+		{SpotExchange: "Bitstamp", Pair: "BTC-USD", FuturesExchange: "OKX", FuturesAssetType: "FUTURES", InstrumentID: "BTC-USD-181228"},
+	}}}
+	err := c.CheckBasisConfigValues()
+	if err != nil {
+		t.Error("Test failed. CheckBasisConfigValues error", err)
+	}
+	if c.Basis.ScanInterval != configDefaultBasisScanInterval ||
+		c.Basis.Spreads[0].Pair != "BTC-USDT" || c.Basis.Spreads[0].FuturesAssetType != "PERPETUAL_SWAP" {
+		t.Error("Test failed. CheckBasisConfigValues expected defaults", c.Basis)
+	}
+
+	c.Basis.AutoExecute = true
+	err = c.CheckBasisConfigValues()
+	if err == nil {
+		t.Error("Test failed. CheckBasisConfigValues expected amount error")
+	}
+
+	c.Basis.AutoExecute = false
+	c.Basis.Spreads[1].FuturesAssetType = "SPOT"
+	err = c.CheckBasisConfigValues()
+	if err == nil {
+		t.Error("Test failed. CheckBasisConfigValues expected asset type error")
+	}
+
+	c.Basis.Spreads[1].FuturesAssetType = "FUTURES"
+	c.Basis.ScanInterval = "1"
+	err = c.CheckBasisConfigValues()
+	if err == nil {
+		t.Error("Test failed. CheckBasisConfigValues expected scan interval error")
+	}
+}
+
+func TestCheckHedgingConfigValues(t *testing.T) {
+	c := &Config{Hedging: HedgingConfig{Enabled: true, Holdings: []HedgeHoldingConfig{
+		{Exchange: "Binance", Pair: "eth-usdt", Hedges: []HedgeInstrumentConfig{
+			{Exchange: "Binance", Pair: "btc-usdt"},
+			{Exchange: "OKX", Pair: "BTC-USDT", AssetType: "swap", InstrumentID: "BTC-USDT-SWAP"},
+		}},
+	}}}
+	err := c.CheckHedgingConfigValues()
+	if err != nil {
+		t.Error("Test failed. CheckHedgingConfigValues error", err)
+	}
+	h := c.Hedging.Holdings[0]
+	if c.Hedging.ScanInterval != configDefaultHedgingScanInterval ||
+		c.Hedging.Window != configDefaultHedgingWindow || h.Pair != "ETH-USDT" ||
+		h.Hedges[0].AssetType != "SPOT" || h.Hedges[1].AssetType != "PERPETUAL_SWAP" {
+		t.Error("Test failed. CheckHedgingConfigValues expected defaults", c.Hedging)
+	}
+
+	c.Hedging.TargetExposure = 1.5
+	err = c.CheckHedgingConfigValues()
+	if err == nil {
+		t.Error("Test failed. CheckHedgingConfigValues expected target exposure error")
+	}
+
+	c.Hedging.TargetExposure = 0.5
+	c.Hedging.Holdings[0].Hedges[1].AssetType = "MARGIN"
+	err = c.CheckHedgingConfigValues()
+	if err == nil {
+		t.Error("Test failed. CheckHedgingConfigValues expected asset type error")
+	}
+
+	c.Hedging.Holdings[0].Hedges[1].AssetType = "FUTURES"
+	c.Hedging.CandleInterval = "1"
+	err = c.CheckHedgingConfigValues()
+	if err == nil {
+		t.Error("Test failed. CheckHedgingConfigValues expected candle interval error")
+	}
+}
@@ -0,0 +1,157 @@
+// Package locale formats fiat amounts for reports and notifications with the
+// digit grouping, decimal separator and symbol placement of a locale, and the
+// number of decimal places of the currency.
+package locale
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/currency/symbol"
+)
+
+// DefaultName is the locale used when none is configured
+const DefaultName = "en-US"
+
+// MaxPriceDecimals is the most decimal places prices are written with
+const MaxPriceDecimals = 8
+
+// ErrUnknownLocale is returned for locales which are not supported
+var ErrUnknownLocale = errors.New("unknown locale")
+
+// Locale is how a locale writes amounts of money. GroupSeparator separates
+// each group of three integer digits, SymbolAfter places the currency symbol
+// after the amount and SymbolSpace separates the symbol and amount with a
+// space.
+type Locale struct {
+	Name             string
+	GroupSeparator   string
+	DecimalSeparator string
+	SymbolAfter      bool
+	SymbolSpace      bool
+}
+
+// Locales are the supported locales keyed by their language tag
+var Locales = map[string]Locale{
+	"en-US": {Name: "en-US", GroupSeparator: ",", DecimalSeparator: "."},
+	"en-GB": {Name: "en-GB", GroupSeparator: ",", DecimalSeparator: "."},
+	"en-AU": {Name: "en-AU", GroupSeparator: ",", DecimalSeparator: "."},
+	"ja-JP": {Name: "ja-JP", GroupSeparator: ",", DecimalSeparator: "."},
+	"ko-KR": {Name: "ko-KR", GroupSeparator: ",", DecimalSeparator: "."},
+	"zh-CN": {Name: "zh-CN", GroupSeparator: ",", DecimalSeparator: "."},
+	"de-DE": {Name: "de-DE", GroupSeparator: ".", DecimalSeparator: ",", SymbolAfter: true, SymbolSpace: true},
+	"es-ES": {Name: "es-ES", GroupSeparator: ".", DecimalSeparator: ",", SymbolAfter: true, SymbolSpace: true},
+	"it-IT": {Name: "it-IT", GroupSeparator: ".", DecimalSeparator: ",", SymbolAfter: true, SymbolSpace: true},
+	"nl-NL": {Name: "nl-NL", GroupSeparator: ".", DecimalSeparator: ",", SymbolSpace: true},
+	"pt-BR": {Name: "pt-BR", GroupSeparator: ".", DecimalSeparator: ",", SymbolSpace: true},
+	"fr-FR": {Name: "fr-FR", GroupSeparator: " ", DecimalSeparator: ",", SymbolAfter: true, SymbolSpace: true},
+	"ru-RU": {Name: "ru-RU", GroupSeparator: " ", DecimalSeparator: ",", SymbolAfter: true, SymbolSpace: true},
+	"de-CH": {Name: "de-CH", GroupSeparator: "'", DecimalSeparator: ".", SymbolSpace: true},
+}
+
+// Default is the locale used when none is configured
+var Default = Locales[DefaultName]
+
+// minorUnits are the ISO 4217 currencies which do not have two decimal places
+var minorUnits = map[string]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0,
+	"KRW": 0, "PYG": 0, "RWF": 0, "UGX": 0, "VND": 0, "VUV": 0, "XAF": 0,
+	"XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+}
+
+// Get returns a supported locale by its language tag, ignoring case and
+// accepting an underscore separator such as en_us
+func Get(name string) (Locale, error) {
+	tag := strings.Replace(name, "_", "-", 1)
+	for k, l := range Locales {
+		if strings.EqualFold(k, tag) {
+			return l, nil
+		}
+	}
+	return Locale{}, ErrUnknownLocale
+}
+
+// Decimals returns the number of decimal places amounts of a fiat currency are
+// written with
+func Decimals(currency string) int {
+	if d, ok := minorUnits[common.StringToUpper(currency)]; ok {
+		return d
+	}
+	return 2
+}
+
+// FormatNumber writes a number rounded to decimals with the locale's
+// separators
+func (l Locale) FormatNumber(v float64, decimals int) string {
+	s := strconv.FormatFloat(math.Abs(v), 'f', decimals, 64)
+	integer, fraction := s, ""
+	if i := strings.IndexByte(s, '.'); i != -1 {
+		integer, fraction = s[:i], s[i+1:]
+	}
+
+	var b strings.Builder
+	if v < 0 && strings.Trim(s, "0.") != "" {
+		b.WriteByte('-')
+	}
+	for i := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			b.WriteString(l.GroupSeparator)
+		}
+		b.WriteByte(integer[i])
+	}
+	if fraction != "" {
+		b.WriteString(l.DecimalSeparator)
+		b.WriteString(fraction)
+	}
+	return b.String()
+}
+
+// FormatFiat writes an amount of a fiat currency with its symbol, or its code
+// when it has no symbol, rounded to the currency's decimal places
+func (l Locale) FormatFiat(v float64, currency string) string {
+	return l.formatFiat(v, currency, Decimals(currency))
+}
+
+// FormatPrice writes a price in a fiat currency with its symbol. Prices are
+// written with the currency's decimal places, or as many as they are quoted
+// with up to MaxPriceDecimals, so prices of low value coins are not rounded
+// away.
+func (l Locale) FormatPrice(v float64, currency string) string {
+	decimals := Decimals(currency)
+	s := strconv.FormatFloat(math.Abs(v), 'f', -1, 64)
+	if i := strings.IndexByte(s, '.'); i != -1 && len(s)-i-1 > decimals {
+		decimals = len(s) - i - 1
+		if decimals > MaxPriceDecimals {
+			decimals = MaxPriceDecimals
+		}
+	}
+	return l.formatFiat(v, currency, decimals)
+}
+
+// formatFiat writes an amount of a fiat currency with its symbol rounded to
+// decimals
+func (l Locale) formatFiat(v float64, currency string, decimals int) string {
+	currency = common.StringToUpper(currency)
+	sym, err := symbol.GetSymbolByCurrencyName(currency)
+	if err != nil {
+		sym = currency
+	}
+	amount := l.FormatNumber(v, decimals)
+	sign := ""
+	if strings.HasPrefix(amount, "-") {
+		sign, amount = "-", amount[1:]
+	}
+
+	space := ""
+	if l.SymbolSpace {
+		space = " "
+	}
+	if l.SymbolAfter {
+		return sign + amount + space + sym
+	}
+	return sign + sym + space + amount
+}
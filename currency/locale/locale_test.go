@@ -0,0 +1,91 @@
+package locale
+
+import "testing"
+
+func TestGet(t *testing.T) {
+	l, err := Get("de_de")
+	if err != nil || l.Name != "de-DE" {
+		t.Error("Test failed. Get() error", l, err)
+	}
+	if _, err = Get("xx-XX"); err != ErrUnknownLocale {
+		t.Error("Test failed. Get() expected unknown locale error", err)
+	}
+}
+
+func TestDecimals(t *testing.T) {
+	for currency, expected := range map[string]int{"USD": 2, "krw": 0, "JPY": 0, "KWD": 3} {
+		if d := Decimals(currency); d != expected {
+			t.Errorf("Test failed. Decimals(%s) expected %d received %d", currency, expected, d)
+		}
+	}
+}
+
+func TestFormatNumber(t *testing.T) {
+	tests := []struct {
+		locale   string
+		v        float64
+		decimals int
+		expected string
+	}{
+		{"en-US", 1234567.891, 2, "1,234,567.89"},
+		{"en-US", 999.999, 2, "1,000.00"},
+		{"en-US", -1234.6, 0, "-1,235"},
+		{"en-US", -0.001, 2, "0.00"},
+		{"de-DE", 1234567.891, 2, "1.234.567,89"},
+		{"fr-FR", 1234.5, 2, "1 234,50"},
+		{"de-CH", 123456, 2, "123'456.00"},
+		{"en-US", 12, 2, "12.00"},
+	}
+	for _, test := range tests {
+		if s := Locales[test.locale].FormatNumber(test.v, test.decimals); s != test.expected {
+			t.Errorf("Test failed. %s FormatNumber(%v) expected %q received %q",
+				test.locale, test.v, test.expected, s)
+		}
+	}
+}
+
+func TestFormatFiat(t *testing.T) {
+	tests := []struct {
+		locale   string
+		v        float64
+		currency string
+		expected string
+	}{
+		{"en-US", 1234.5, "USD", "$1,234.50"},
+		{"en-US", -1234.5, "usd", "-$1,234.50"},
+		{"en-US", 1234567.8, "KRW", "₩1,234,568"},
+		{"ja-JP", 98765.4, "JPY", "¥98,765"},
+		{"de-DE", 1234.5, "EUR", "1.234,50 €"},
+		{"de-DE", -1234.5, "EUR", "-1.234,50 €"},
+		{"nl-NL", 1234.5, "EUR", "€ 1.234,50"},
+		{"en-GB", 1.2346, "KWD", "KWD1.235"},
+		{"en-US", 10, "XYZ", "XYZ10.00"},
+	}
+	for _, test := range tests {
+		if s := Locales[test.locale].FormatFiat(test.v, test.currency); s != test.expected {
+			t.Errorf("Test failed. %s FormatFiat(%v %s) expected %q received %q",
+				test.locale, test.v, test.currency, test.expected, s)
+		}
+	}
+}
+
+func TestFormatPrice(t *testing.T) {
+	tests := []struct {
+		locale   string
+		v        float64
+		currency string
+		expected string
+	}{
+		{"en-US", 65000.1, "USD", "$65,000.10"},
+		{"en-US", 0.071234, "USD", "$0.071234"},
+		{"de-DE", 0.000123456789, "EUR", "0,00012346 €"},
+		{"ko-KR", 95000000, "KRW", "₩95,000,000"},
+		{"ko-KR", 312.5, "KRW", "₩312.5"},
+	}
+	for _, test := range tests {
+		if s := Locales[test.locale].FormatPrice(test.v, test.currency); s != test.expected {
+			t.Errorf("Test failed. %s FormatPrice(%v %s) expected %q received %q",
+				test.locale, test.v, test.currency, test.expected, s)
+		}
+	}
+}
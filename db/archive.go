@@ -0,0 +1,304 @@
+package db
+
+import (
+	"math"
+	"strings"
+	"time"
+)
+
+// TradeSummary holds the statistics of the trades of an exchange currency
+// pair within an interval, kept after the trades are pruned. Timestamp is the
+// interval's open time and VWAP the volume weighted average price.
+type TradeSummary struct {
+	Exchange   string
+	Pair       string
+	AssetType  string
+	Interval   time.Duration
+	Timestamp  time.Time
+	Trades     int64
+	Volume     float64
+	BuyVolume  float64
+	SellVolume float64
+	VWAP       float64
+}
+
+// OrderbookSummary holds the statistics of the orderbook snapshots of an
+// exchange currency pair within an interval, kept after the snapshots are
+// pruned. Mid prices are summarised as OHLC, depths are the amounts of the
+// stored levels of each side.
+type OrderbookSummary struct {
+	Exchange        string
+	Pair            string
+	AssetType       string
+	Interval        time.Duration
+	Timestamp       time.Time
+	Snapshots       int64
+	MidOpen         float64
+	MidHigh         float64
+	MidLow          float64
+	MidClose        float64
+	AverageSpread   float64
+	MaxSpread       float64
+	AverageBidDepth float64
+	AverageAskDepth float64
+}
+
+// ArchiveRepository stores the candles and summaries raw market data is
+// archived into before it is pruned
+type ArchiveRepository interface {
+	UpsertTradeSummaries(summaries []TradeSummary) error
+	TradeSummaries(exchName, pair string, interval time.Duration, start, end time.Time) ([]TradeSummary, error)
+	UpsertOrderbookSummaries(summaries []OrderbookSummary) error
+	OrderbookSummaries(exchName, pair string, interval time.Duration, start, end time.Time) ([]OrderbookSummary, error)
+}
+
+// seriesKey identifies the interval of an exchange currency pair market data
+// is archived into
+type seriesKey struct {
+	exchange  string
+	pair      string
+	assetType string
+	timestamp time.Time
+}
+
+// ArchiveTrades returns the candles and summaries of trades at an interval,
+// trades must be in time order
+func ArchiveTrades(trades []Trade, interval time.Duration) ([]Candle, []TradeSummary) {
+	var candles []Candle
+	var summaries []TradeSummary
+	index := make(map[seriesKey]int)
+	for i := range trades {
+		t := &trades[i]
+		key := seriesKey{t.Exchange, t.Pair, t.AssetType, t.Timestamp.UTC().Truncate(interval)}
+		x, ok := index[key]
+		if !ok {
+			x = len(candles)
+			index[key] = x
+			candles = append(candles, Candle{
+				Exchange:  t.Exchange,
+				Pair:      t.Pair,
+				AssetType: t.AssetType,
+				Interval:  interval,
+				Timestamp: key.timestamp,
+				Open:      t.Price,
+				High:      t.Price,
+				Low:       t.Price,
+			})
+			summaries = append(summaries, TradeSummary{
+				Exchange:  t.Exchange,
+				Pair:      t.Pair,
+				AssetType: t.AssetType,
+				Interval:  interval,
+				Timestamp: key.timestamp,
+			})
+		}
+
+		c := &candles[x]
+		c.High = math.Max(c.High, t.Price)
+		c.Low = math.Min(c.Low, t.Price)
+		c.Close = t.Price
+		c.Volume += t.Amount
+
+		s := &summaries[x]
+		s.Trades++
+		s.Volume += t.Amount
+		s.VWAP += t.Price * t.Amount
+		switch strings.ToLower(t.Side) {
+		case "buy", "bid":
+			s.BuyVolume += t.Amount
+		case "sell", "ask":
+			s.SellVolume += t.Amount
+		}
+	}
+
+	for i := range summaries {
+		if summaries[i].Volume > 0 {
+			summaries[i].VWAP /= summaries[i].Volume
+		}
+	}
+	return candles, summaries
+}
+
+// ArchiveOrderbooks returns the summaries of orderbook snapshots at an
+// interval, snapshots must be in time order and those missing a side are
+// skipped
+func ArchiveOrderbooks(books []Orderbook, interval time.Duration) []OrderbookSummary {
+	var summaries []OrderbookSummary
+	index := make(map[seriesKey]int)
+	for i := range books {
+		b := &books[i]
+		if len(b.Bids) == 0 || len(b.Asks) == 0 {
+			continue
+		}
+		mid := (b.Bids[0].Price + b.Asks[0].Price) / 2
+		spread := b.Asks[0].Price - b.Bids[0].Price
+
+		key := seriesKey{b.Exchange, b.Pair, b.AssetType, b.Timestamp.UTC().Truncate(interval)}
+		x, ok := index[key]
+		if !ok {
+			x = len(summaries)
+			index[key] = x
+			summaries = append(summaries, OrderbookSummary{
+				Exchange:  b.Exchange,
+				Pair:      b.Pair,
+				AssetType: b.AssetType,
+				Interval:  interval,
+				Timestamp: key.timestamp,
+				MidOpen:   mid,
+				MidHigh:   mid,
+				MidLow:    mid,
+				MaxSpread: spread,
+			})
+		}
+
+		s := &summaries[x]
+		s.Snapshots++
+		s.MidHigh = math.Max(s.MidHigh, mid)
+		s.MidLow = math.Min(s.MidLow, mid)
+		s.MidClose = mid
+		s.MaxSpread = math.Max(s.MaxSpread, spread)
+		s.AverageSpread += spread
+		s.AverageBidDepth += levelsDepth(b.Bids)
+		s.AverageAskDepth += levelsDepth(b.Asks)
+	}
+
+	for i := range summaries {
+		n := float64(summaries[i].Snapshots)
+		summaries[i].AverageSpread /= n
+		summaries[i].AverageBidDepth /= n
+		summaries[i].AverageAskDepth /= n
+	}
+	return summaries
+}
+
+// levelsDepth returns the total amount of a side of an orderbook snapshot
+func levelsDepth(levels []OrderbookLevel) float64 {
+	var depth float64
+	for i := range levels {
+		depth += levels[i].Amount
+	}
+	return depth
+}
+
+// UpsertTradeSummaries stores trade summaries in a single transaction,
+// replacing summaries already stored with the same exchange, pair, interval
+// and time
+func (d *DB) UpsertTradeSummaries(summaries []TradeSummary) error {
+	tx, err := d.SQL.Begin()
+	if err != nil {
+		return err
+	}
+	for i := range summaries {
+		s := &summaries[i]
+		_, err = tx.Exec(d.rebind(`INSERT INTO trade_summaries (exchange, pair, asset_type, interval_seconds,
+				timestamp, trades, volume, buy_volume, sell_volume, vwap)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT (exchange, pair, interval_seconds, timestamp) DO UPDATE SET trades = excluded.trades,
+				volume = excluded.volume, buy_volume = excluded.buy_volume,
+				sell_volume = excluded.sell_volume, vwap = excluded.vwap`),
+			s.Exchange, s.Pair, s.AssetType, int64(s.Interval/time.Second), s.Timestamp.UTC(),
+			s.Trades, s.Volume, s.BuyVolume, s.SellVolume, s.VWAP)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// TradeSummaries returns the trade summaries of an exchange currency pair at
+// an interval opening within [start, end) in time order. Empty values and
+// zero times are not filtered.
+func (d *DB) TradeSummaries(exchName, pair string, interval time.Duration, start, end time.Time) ([]TradeSummary, error) {
+	var f filter
+	f.equal("exchange", exchName)
+	f.equal("pair", pair)
+	f.conditions = append(f.conditions, "interval_seconds = ?")
+	f.args = append(f.args, int64(interval/time.Second))
+	f.between("timestamp", start, end)
+	rows, err := d.query(`SELECT exchange, pair, asset_type, interval_seconds, timestamp, trades, volume,
+			buy_volume, sell_volume, vwap
+		FROM trade_summaries`+f.where()+` ORDER BY timestamp`, f.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []TradeSummary
+	for rows.Next() {
+		var s TradeSummary
+		var seconds int64
+		err = rows.Scan(&s.Exchange, &s.Pair, &s.AssetType, &seconds, &s.Timestamp,
+			&s.Trades, &s.Volume, &s.BuyVolume, &s.SellVolume, &s.VWAP)
+		if err != nil {
+			return nil, err
+		}
+		s.Interval = time.Duration(seconds) * time.Second
+		result = append(result, s)
+	}
+	return result, rows.Err()
+}
+
+// UpsertOrderbookSummaries stores orderbook summaries in a single
+// transaction, replacing summaries already stored with the same exchange,
+// pair, interval and time
+func (d *DB) UpsertOrderbookSummaries(summaries []OrderbookSummary) error {
+	tx, err := d.SQL.Begin()
+	if err != nil {
+		return err
+	}
+	for i := range summaries {
+		s := &summaries[i]
+		_, err = tx.Exec(d.rebind(`INSERT INTO orderbook_summaries (exchange, pair, asset_type, interval_seconds,
+				timestamp, snapshots, mid_open, mid_high, mid_low, mid_close, average_spread, max_spread,
+				average_bid_depth, average_ask_depth)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT (exchange, pair, interval_seconds, timestamp) DO UPDATE SET snapshots = excluded.snapshots,
+				mid_open = excluded.mid_open, mid_high = excluded.mid_high, mid_low = excluded.mid_low,
+				mid_close = excluded.mid_close, average_spread = excluded.average_spread,
+				max_spread = excluded.max_spread, average_bid_depth = excluded.average_bid_depth,
+				average_ask_depth = excluded.average_ask_depth`),
+			s.Exchange, s.Pair, s.AssetType, int64(s.Interval/time.Second), s.Timestamp.UTC(),
+			s.Snapshots, s.MidOpen, s.MidHigh, s.MidLow, s.MidClose, s.AverageSpread,
+			s.MaxSpread, s.AverageBidDepth, s.AverageAskDepth)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// OrderbookSummaries returns the orderbook summaries of an exchange currency
+// pair at an interval opening within [start, end) in time order. Empty values
+// and zero times are not filtered.
+func (d *DB) OrderbookSummaries(exchName, pair string, interval time.Duration, start, end time.Time) ([]OrderbookSummary, error) {
+	var f filter
+	f.equal("exchange", exchName)
+	f.equal("pair", pair)
+	f.conditions = append(f.conditions, "interval_seconds = ?")
+	f.args = append(f.args, int64(interval/time.Second))
+	f.between("timestamp", start, end)
+	rows, err := d.query(`SELECT exchange, pair, asset_type, interval_seconds, timestamp, snapshots, mid_open,
+			mid_high, mid_low, mid_close, average_spread, max_spread, average_bid_depth, average_ask_depth
+		FROM orderbook_summaries`+f.where()+` ORDER BY timestamp`, f.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []OrderbookSummary
+	for rows.Next() {
+		var s OrderbookSummary
+		var seconds int64
+		err = rows.Scan(&s.Exchange, &s.Pair, &s.AssetType, &seconds, &s.Timestamp,
+			&s.Snapshots, &s.MidOpen, &s.MidHigh, &s.MidLow, &s.MidClose,
+			&s.AverageSpread, &s.MaxSpread, &s.AverageBidDepth, &s.AverageAskDepth)
+		if err != nil {
+			return nil, err
+		}
+		s.Interval = time.Duration(seconds) * time.Second
+		result = append(result, s)
+	}
+	return result, rows.Err()
+}
@@ -0,0 +1,124 @@
+// Package db persists ticker snapshots, executed trades, candles, orderbook
+// snapshots, orders and withdrawal history through a pluggable storage driver, with SQLite and
+// PostgreSQL drivers built in. The schema is created and upgraded by
+// migrations when the database is opened. Raw trades and orderbook snapshots
+// can be archived into candles and summaries before they are pruned.
+package db
+
+import (
+	"database/sql"
+	"errors"
+)
+
+// Supported database drivers
+const (
+	DriverSQLite   = "sqlite"
+	DriverPostgres = "postgres"
+)
+
+// Errors returned by the db package
+var (
+	ErrUnsupportedDriver = errors.New("unsupported database driver")
+	ErrDatabaseRequired  = errors.New("database name or file path required")
+	ErrHostRequired      = errors.New("postgres requires a host")
+)
+
+// Config holds the database connection settings. Database is the file path
+// for SQLite and the database name for PostgreSQL, the remaining settings
+// only apply to PostgreSQL.
+type Config struct {
+	Driver   string
+	Database string
+	Host     string
+	Port     uint16
+	Username string
+	Password string
+	SSLMode  string
+}
+
+// Validate checks the connection settings
+func (c *Config) Validate() error {
+	if !DriverRegistered(c.Driver) {
+		return ErrUnsupportedDriver
+	}
+	if c.Driver == DriverPostgres && c.Host == "" {
+		return ErrHostRequired
+	}
+	if c.Database == "" {
+		return ErrDatabaseRequired
+	}
+	return nil
+}
+
+// dialect holds the differences between the SQL databases of each driver
+type dialect interface {
+	// sqlDriver returns the database/sql driver name
+	sqlDriver() string
+	// dataSource returns the data source name of the connection settings
+	dataSource(c *Config) string
+	// idColumn returns the auto incrementing primary key column type
+	idColumn() string
+	// rebind converts the ? placeholders of a query to the dialect's syntax
+	rebind(query string) string
+	// configure sets the connection pool limits of a connection
+	configure(conn *sql.DB)
+	// compact returns the statement reclaiming the space of deleted rows
+	compact() string
+}
+
+// registerDialect registers a SQL driver using a dialect
+func registerDialect(name string, d dialect) {
+	RegisterDriver(name, func(c Config) Storage {
+		return &DB{config: c, dialect: d}
+	})
+}
+
+// DB is a SQL database with the bot's schema, its SQL dialect differs by
+// driver
+type DB struct {
+	SQL     *sql.DB
+	config  Config
+	dialect dialect
+}
+
+// Connect opens the database connection and checks it is reachable
+func (d *DB) Connect() error {
+	conn, err := sql.Open(d.dialect.sqlDriver(), d.dialect.dataSource(&d.config))
+	if err != nil {
+		return err
+	}
+	d.dialect.configure(conn)
+	if err = conn.Ping(); err != nil {
+		conn.Close()
+		return err
+	}
+	d.SQL = conn
+	return nil
+}
+
+// Close closes the database connection
+func (d *DB) Close() error {
+	return d.SQL.Close()
+}
+
+// Driver returns the database driver, sqlite or postgres
+func (d *DB) Driver() string {
+	return d.config.Driver
+}
+
+// rebind converts the ? placeholders of a query to the driver's placeholder
+// syntax
+func (d *DB) rebind(query string) string {
+	return d.dialect.rebind(query)
+}
+
+// exec runs a statement with ? placeholders
+func (d *DB) exec(query string, args ...interface{}) error {
+	_, err := d.SQL.Exec(d.rebind(query), args...)
+	return err
+}
+
+// query runs a query with ? placeholders
+func (d *DB) query(query string, args ...interface{}) (*sql.Rows, error) {
+	return d.SQL.Query(d.rebind(query), args...)
+}
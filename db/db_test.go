@@ -0,0 +1,307 @@
+package db
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestValidate(t *testing.T) {
+	c := Config{Driver: "mysql", Database: "gct"}
+	if err := c.Validate(); err != ErrUnsupportedDriver {
+		t.Error("Test Failed - Validate() expected unsupported driver error", err)
+	}
+	c.Driver = DriverPostgres
+	if err := c.Validate(); err != ErrHostRequired {
+		t.Error("Test Failed - Validate() expected host required error", err)
+	}
+	c = Config{Driver: DriverSQLite}
+	if err := c.Validate(); err != ErrDatabaseRequired {
+		t.Error("Test Failed - Validate() expected database required error", err)
+	}
+	c.Database = "gct.db"
+	if err := c.Validate(); err != nil {
+		t.Error("Test Failed - Validate() error", err)
+	}
+}
+
+func TestDataSource(t *testing.T) {
+	c := Config{Driver: DriverSQLite, Database: "gct.db"}
+	if d := (sqliteDialect{}); d.sqlDriver() != "sqlite3" || d.dataSource(&c) != "gct.db" {
+		t.Error("Test Failed - dataSource() unexpected sqlite source", d.dataSource(&c))
+	}
+
+	c = Config{
+		Driver:   DriverPostgres,
+		Database: "gct",
+		Host:     "localhost",
+		Port:     5432,
+		Username: "gct",
+		Password: `pa'ss`,
+	}
+	expected := `host='localhost' dbname='gct' sslmode='disable' port=5432 user='gct' password='pa\'ss'`
+	if d := (postgresDialect{}); d.sqlDriver() != "postgres" || d.dataSource(&c) != expected {
+		t.Error("Test Failed - dataSource() unexpected postgres source", d.dataSource(&c))
+	}
+}
+
+func TestRebind(t *testing.T) {
+	query := "SELECT * FROM orders WHERE exchange = ? AND status = ?"
+	d := &DB{dialect: sqliteDialect{}}
+	if d.rebind(query) != query {
+		t.Error("Test Failed - rebind() changed a sqlite query")
+	}
+	d.dialect = postgresDialect{}
+	if r := d.rebind(query); r != "SELECT * FROM orders WHERE exchange = $1 AND status = $2" {
+		t.Error("Test Failed - rebind() unexpected postgres query", r)
+	}
+}
+
+// testStorage is a storage driver which fails to connect
+type testStorage struct {
+	Storage
+}
+
+func (testStorage) Connect() error {
+	return errors.New("connection refused")
+}
+
+func TestRegisterDriver(t *testing.T) {
+	if d := Drivers(); len(d) < 2 || !DriverRegistered(DriverSQLite) || !DriverRegistered(DriverPostgres) {
+		t.Error("Test Failed - Drivers() expected built in drivers registered", d)
+	}
+
+	RegisterDriver("test", func(c Config) Storage { return testStorage{} })
+	if !DriverRegistered("test") {
+		t.Fatal("Test Failed - RegisterDriver() driver not registered")
+	}
+	if _, err := Open(Config{Driver: "test", Database: "gct"}); err == nil {
+		t.Error("Test Failed - Open() expected connection error")
+	}
+}
+
+func TestRepositories(t *testing.T) {
+	d, err := Open(Config{Driver: DriverSQLite, Database: ":memory:"})
+	if err != nil {
+		t.Skip("sqlite unavailable", err)
+	}
+	defer d.Close()
+
+	if v, err := d.SchemaVersion(); err != nil || v != migrations[len(migrations)-1].version {
+		t.Error("Test Failed - SchemaVersion() unexpected version", v, err)
+	}
+	if err = d.Migrate(); err != nil {
+		t.Error("Test Failed - Migrate() reapplying migrations error", err)
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	err = d.InsertTicker(Ticker{Exchange: "Bitstamp", Pair: "BTCUSD", AssetType: "SPOT", Last: 100, Timestamp: now})
+	if err != nil {
+		t.Fatal("Test Failed - InsertTicker() error", err)
+	}
+	tickers, err := d.Tickers("Bitstamp", "BTCUSD", now.Add(-time.Minute), now.Add(time.Minute))
+	if err != nil || len(tickers) != 1 || tickers[0].Last != 100 {
+		t.Error("Test Failed - Tickers() unexpected tickers", tickers, err)
+	}
+
+	err = d.InsertTrade(Trade{Exchange: "Bitstamp", Pair: "BTCUSD", TradeID: "1", Price: 100, Amount: 1, Timestamp: now})
+	if err != nil {
+		t.Fatal("Test Failed - InsertTrade() error", err)
+	}
+	trades, err := d.Trades("Bitstamp", "", time.Time{}, time.Time{})
+	if err != nil || len(trades) != 1 || trades[0].TradeID != "1" {
+		t.Error("Test Failed - Trades() unexpected trades", trades, err)
+	}
+
+	o := Order{Exchange: "Bitstamp", OrderID: "1337", Pair: "BTCUSD", Status: "NEW", Amount: 1, Created: now}
+	if err = d.UpsertOrder(o); err != nil {
+		t.Fatal("Test Failed - UpsertOrder() error", err)
+	}
+	o.Status = "FILLED"
+	o.ExecutedAmount = 1
+	if err = d.UpsertOrder(o); err != nil {
+		t.Fatal("Test Failed - UpsertOrder() update error", err)
+	}
+	orders, err := d.Orders("Bitstamp", "")
+	if err != nil || len(orders) != 1 || orders[0].Status != "FILLED" || orders[0].ExecutedAmount != 1 {
+		t.Error("Test Failed - Orders() unexpected orders", orders, err)
+	}
+
+	candles := []Candle{
+		{Exchange: "Bitstamp", Pair: "BTCUSD", AssetType: "SPOT", Interval: time.Hour, Timestamp: now, Close: 100},
+		{Exchange: "Bitstamp", Pair: "BTCUSD", AssetType: "SPOT", Interval: time.Hour, Timestamp: now.Add(time.Hour), Close: 101},
+		{Exchange: "Bitstamp", Pair: "BTCUSD", AssetType: "SPOT", Interval: time.Minute, Timestamp: now, Close: 99},
+	}
+	if err = d.UpsertCandles(candles); err != nil {
+		t.Fatal("Test Failed - UpsertCandles() error", err)
+	}
+	candles[1].Close = 102
+	if err = d.UpsertCandles(candles[1:2]); err != nil {
+		t.Fatal("Test Failed - UpsertCandles() update error", err)
+	}
+	stored, err := d.Candles("Bitstamp", "BTCUSD", time.Hour, now, time.Time{})
+	if err != nil || len(stored) != 2 || stored[1].Close != 102 || stored[1].Interval != time.Hour {
+		t.Error("Test Failed - Candles() unexpected candles", stored, err)
+	}
+
+	w := Withdrawal{Exchange: "Bitstamp", WithdrawalID: "42", Currency: "BTC", Amount: 1, Status: "PENDING", Timestamp: now}
+	if err = d.InsertWithdrawal(w); err != nil {
+		t.Fatal("Test Failed - InsertWithdrawal() error", err)
+	}
+	w.Status = "COMPLETE"
+	if err = d.InsertWithdrawal(w); err != nil {
+		t.Fatal("Test Failed - InsertWithdrawal() update error", err)
+	}
+	withdrawals, err := d.Withdrawals("Bitstamp", time.Time{}, now.Add(time.Second))
+	if err != nil || len(withdrawals) != 1 || withdrawals[0].Status != "COMPLETE" {
+		t.Error("Test Failed - Withdrawals() unexpected withdrawals", withdrawals, err)
+	}
+
+	attempts := []WithdrawalAttempt{
+		{RequestID: 1, Exchange: "Bitstamp", Currency: "BTC", Address: "1Addr", Amount: 1, Status: "PENDING", Timestamp: now},
+		{RequestID: 1, Exchange: "Bitstamp", Currency: "BTC", Address: "1Addr", Amount: 1, Status: "SUBMITTED", TxID: "0x1", Timestamp: now},
+	}
+	for i := range attempts {
+		if err = d.InsertWithdrawalAttempt(attempts[i]); err != nil {
+			t.Fatal("Test Failed - InsertWithdrawalAttempt() error", err)
+		}
+	}
+	storedAttempts, err := d.WithdrawalAttempts("Bitstamp", time.Time{}, time.Time{})
+	if err != nil || len(storedAttempts) != 2 || storedAttempts[1].Status != "SUBMITTED" || storedAttempts[1].TxID != "0x1" {
+		t.Error("Test Failed - WithdrawalAttempts() unexpected attempts", storedAttempts, err)
+	}
+
+	a := WithdrawalAddress{ID: 1, Data: []byte{0, 1, 2}}
+	if err = d.UpsertWithdrawalAddress(a); err != nil {
+		t.Fatal("Test Failed - UpsertWithdrawalAddress() error", err)
+	}
+	a.Data = []byte{3, 4}
+	if err = d.UpsertWithdrawalAddress(a); err != nil {
+		t.Fatal("Test Failed - UpsertWithdrawalAddress() update error", err)
+	}
+	addresses, err := d.WithdrawalAddresses()
+	if err != nil || len(addresses) != 1 || string(addresses[0].Data) != string(a.Data) {
+		t.Error("Test Failed - WithdrawalAddresses() unexpected addresses", addresses, err)
+	}
+	if err = d.DeleteWithdrawalAddress(1); err != nil {
+		t.Error("Test Failed - DeleteWithdrawalAddress() error", err)
+	}
+	if addresses, _ = d.WithdrawalAddresses(); len(addresses) != 0 {
+		t.Error("Test Failed - DeleteWithdrawalAddress() expected address removed", addresses)
+	}
+
+	book := Orderbook{
+		Exchange:  "Bitstamp",
+		Pair:      "BTCUSD",
+		AssetType: "SPOT",
+		Bids:      []OrderbookLevel{{Price: 99, Amount: 1}},
+		Asks:      []OrderbookLevel{{Price: 101, Amount: 2}},
+		Timestamp: now,
+	}
+	if err = d.InsertOrderbook(book); err != nil {
+		t.Fatal("Test Failed - InsertOrderbook() error", err)
+	}
+	books, err := d.Orderbooks("Bitstamp", "BTCUSD", time.Time{}, time.Time{})
+	if err != nil || len(books) != 1 || books[0].Asks[0].Amount != 2 || books[0].Bids[0].Price != 99 {
+		t.Error("Test Failed - Orderbooks() unexpected orderbooks", books, err)
+	}
+
+	deleted, err := d.Prune(TableCandles, now.Add(time.Minute))
+	if err != nil || deleted != 2 {
+		t.Error("Test Failed - Prune() expected the candles opening at now deleted", deleted, err)
+	}
+	if stored, _ = d.Candles("Bitstamp", "BTCUSD", time.Hour, time.Time{}, time.Time{}); len(stored) != 1 {
+		t.Error("Test Failed - Prune() expected the later candle kept", stored)
+	}
+	if err = d.Compact(); err != nil {
+		t.Error("Test Failed - Compact() error", err)
+	}
+}
+
+func TestPrune(t *testing.T) {
+	d := &DB{dialect: sqliteDialect{}}
+	if _, err := d.Prune("orders", time.Now()); err != ErrTableNotPrunable {
+		t.Error("Test Failed - Prune() expected orders not prunable error", err)
+	}
+}
+
+func TestArchiveTrades(t *testing.T) {
+	start := time.Date(2018, 9, 20, 0, 0, 0, 0, time.UTC)
+	trades := []Trade{
+		{Exchange: "Bitstamp", Pair: "BTCUSD", Side: "buy", Price: 100, Amount: 1, Timestamp: start.Add(10 * time.Second)},
+		{Exchange: "Bitstamp", Pair: "ETHUSD", Side: "SELL", Price: 10, Amount: 5, Timestamp: start.Add(20 * time.Second)},
+		{Exchange: "Bitstamp", Pair: "BTCUSD", Side: "sell", Price: 104, Amount: 3, Timestamp: start.Add(30 * time.Second)},
+		{Exchange: "Bitstamp", Pair: "BTCUSD", Side: "buy", Price: 98, Amount: 2, Timestamp: start.Add(50 * time.Second)},
+		{Exchange: "Bitstamp", Pair: "BTCUSD", Side: "buy", Price: 99, Amount: 1, Timestamp: start.Add(70 * time.Second)},
+	}
+	candles, summaries := ArchiveTrades(trades, time.Minute)
+	if len(candles) != 3 || len(summaries) != 3 {
+		t.Fatal("Test Failed - ArchiveTrades() expected three intervals", candles, summaries)
+	}
+	c := candles[0]
+	if c.Pair != "BTCUSD" || !c.Timestamp.Equal(start) || c.Interval != time.Minute || c.Open != 100 ||
+		c.High != 104 || c.Low != 98 || c.Close != 98 || c.Volume != 6 {
+		t.Error("Test Failed - ArchiveTrades() incorrect candle", c)
+	}
+	s := summaries[0]
+	if s.Trades != 3 || s.BuyVolume != 3 || s.SellVolume != 3 || s.VWAP != 101.33333333333333 {
+		t.Error("Test Failed - ArchiveTrades() incorrect summary", s)
+	}
+	if summaries[1].Pair != "ETHUSD" || summaries[1].SellVolume != 5 || !candles[2].Timestamp.Equal(start.Add(time.Minute)) {
+		t.Error("Test Failed - ArchiveTrades() incorrect intervals", candles, summaries)
+	}
+}
+
+func TestArchiveOrderbooks(t *testing.T) {
+	start := time.Date(2018, 9, 20, 0, 0, 0, 0, time.UTC)
+	books := []Orderbook{
+		{Exchange: "Bitstamp", Pair: "BTCUSD", Timestamp: start,
+			Bids: []OrderbookLevel{{Price: 99, Amount: 1}, {Price: 98, Amount: 2}},
+			Asks: []OrderbookLevel{{Price: 101, Amount: 1}}},
+		{Exchange: "Bitstamp", Pair: "BTCUSD", Timestamp: start.Add(10 * time.Second),
+			Bids: []OrderbookLevel{{Price: 100, Amount: 1}}},
+		{Exchange: "Bitstamp", Pair: "BTCUSD", Timestamp: start.Add(20 * time.Second),
+			Bids: []OrderbookLevel{{Price: 102, Amount: 1}},
+			Asks: []OrderbookLevel{{Price: 106, Amount: 3}}},
+	}
+	summaries := ArchiveOrderbooks(books, time.Minute)
+	if len(summaries) != 1 {
+		t.Fatal("Test Failed - ArchiveOrderbooks() expected one interval", summaries)
+	}
+	s := summaries[0]
+	if s.Snapshots != 2 || s.MidOpen != 100 || s.MidHigh != 104 || s.MidLow != 100 || s.MidClose != 104 ||
+		s.AverageSpread != 3 || s.MaxSpread != 4 || s.AverageBidDepth != 2 || s.AverageAskDepth != 2 {
+		t.Error("Test Failed - ArchiveOrderbooks() incorrect summary", s)
+	}
+}
+
+func TestArchiveRepositories(t *testing.T) {
+	d, err := Open(Config{Driver: DriverSQLite, Database: ":memory:"})
+	if err != nil {
+		t.Skip("sqlite unavailable", err)
+	}
+	defer d.Close()
+
+	now := time.Now().UTC().Truncate(time.Minute)
+	trades := []TradeSummary{{Exchange: "Bitstamp", Pair: "BTCUSD", Interval: time.Minute, Timestamp: now, Trades: 1, VWAP: 100}}
+	if err = d.UpsertTradeSummaries(trades); err != nil {
+		t.Fatal("Test Failed - UpsertTradeSummaries() error", err)
+	}
+	trades[0].Trades = 2
+	if err = d.UpsertTradeSummaries(trades); err != nil {
+		t.Fatal("Test Failed - UpsertTradeSummaries() update error", err)
+	}
+	storedTrades, err := d.TradeSummaries("Bitstamp", "", time.Minute, time.Time{}, time.Time{})
+	if err != nil || len(storedTrades) != 1 || storedTrades[0].Trades != 2 || storedTrades[0].Interval != time.Minute {
+		t.Error("Test Failed - TradeSummaries() unexpected summaries", storedTrades, err)
+	}
+
+	books := []OrderbookSummary{{Exchange: "Bitstamp", Pair: "BTCUSD", Interval: time.Minute, Timestamp: now, Snapshots: 3, MaxSpread: 2}}
+	if err = d.UpsertOrderbookSummaries(books); err != nil {
+		t.Fatal("Test Failed - UpsertOrderbookSummaries() error", err)
+	}
+	storedBooks, err := d.OrderbookSummaries("Bitstamp", "BTCUSD", time.Minute, now, now.Add(time.Minute))
+	if err != nil || len(storedBooks) != 1 || storedBooks[0].Snapshots != 3 || storedBooks[0].MaxSpread != 2 {
+		t.Error("Test Failed - OrderbookSummaries() unexpected summaries", storedBooks, err)
+	}
+}
@@ -0,0 +1,245 @@
+package db
+
+import (
+	"strings"
+	"time"
+)
+
+// migration upgrades the schema to its version. Statements use {{id}} for an
+// auto incrementing primary key column, which differs between drivers.
+type migration struct {
+	version    int
+	name       string
+	statements []string
+}
+
+// migrations are applied in order, new migrations must be appended with the
+// next version and applied migrations never changed
+var migrations = []migration{
+	{
+		version: 1,
+		name:    "create tickers, trades, orders and withdrawals",
+		statements: []string{
+			`CREATE TABLE tickers (
+				id {{id}},
+				exchange VARCHAR(64) NOT NULL,
+				pair VARCHAR(32) NOT NULL,
+				asset_type VARCHAR(32) NOT NULL,
+				last DOUBLE PRECISION NOT NULL,
+				high DOUBLE PRECISION NOT NULL,
+				low DOUBLE PRECISION NOT NULL,
+				bid DOUBLE PRECISION NOT NULL,
+				ask DOUBLE PRECISION NOT NULL,
+				volume DOUBLE PRECISION NOT NULL,
+				timestamp TIMESTAMP NOT NULL
+			)`,
+			`CREATE INDEX tickers_exchange_pair_timestamp ON tickers (exchange, pair, timestamp)`,
+			`CREATE TABLE trades (
+				id {{id}},
+				exchange VARCHAR(64) NOT NULL,
+				pair VARCHAR(32) NOT NULL,
+				asset_type VARCHAR(32) NOT NULL,
+				trade_id VARCHAR(128) NOT NULL,
+				side VARCHAR(16) NOT NULL,
+				price DOUBLE PRECISION NOT NULL,
+				amount DOUBLE PRECISION NOT NULL,
+				timestamp TIMESTAMP NOT NULL
+			)`,
+			`CREATE INDEX trades_exchange_pair_timestamp ON trades (exchange, pair, timestamp)`,
+			`CREATE TABLE orders (
+				id {{id}},
+				exchange VARCHAR(64) NOT NULL,
+				order_id VARCHAR(128) NOT NULL,
+				pair VARCHAR(32) NOT NULL,
+				side VARCHAR(16) NOT NULL,
+				order_type VARCHAR(32) NOT NULL,
+				status VARCHAR(32) NOT NULL,
+				price DOUBLE PRECISION NOT NULL,
+				amount DOUBLE PRECISION NOT NULL,
+				executed_amount DOUBLE PRECISION NOT NULL,
+				average_executed_price DOUBLE PRECISION NOT NULL,
+				strategy VARCHAR(64) NOT NULL,
+				created TIMESTAMP NOT NULL,
+				updated TIMESTAMP NOT NULL,
+				UNIQUE (exchange, order_id)
+			)`,
+			`CREATE TABLE withdrawals (
+				id {{id}},
+				exchange VARCHAR(64) NOT NULL,
+				withdrawal_id VARCHAR(128) NOT NULL,
+				currency VARCHAR(32) NOT NULL,
+				amount DOUBLE PRECISION NOT NULL,
+				fee DOUBLE PRECISION NOT NULL,
+				address VARCHAR(256) NOT NULL,
+				tx_id VARCHAR(256) NOT NULL,
+				status VARCHAR(32) NOT NULL,
+				timestamp TIMESTAMP NOT NULL,
+				UNIQUE (exchange, withdrawal_id)
+			)`,
+		},
+	},
+	{
+		version: 2,
+		name:    "create candles",
+		statements: []string{
+			`CREATE TABLE candles (
+				id {{id}},
+				exchange VARCHAR(64) NOT NULL,
+				pair VARCHAR(32) NOT NULL,
+				asset_type VARCHAR(32) NOT NULL,
+				interval_seconds BIGINT NOT NULL,
+				timestamp TIMESTAMP NOT NULL,
+				open DOUBLE PRECISION NOT NULL,
+				high DOUBLE PRECISION NOT NULL,
+				low DOUBLE PRECISION NOT NULL,
+				close DOUBLE PRECISION NOT NULL,
+				volume DOUBLE PRECISION NOT NULL,
+				UNIQUE (exchange, pair, interval_seconds, timestamp)
+			)`,
+		},
+	},
+	{
+		version: 3,
+		name:    "create orderbooks and retention indexes",
+		statements: []string{
+			`CREATE TABLE orderbooks (
+				id {{id}},
+				exchange VARCHAR(64) NOT NULL,
+				pair VARCHAR(32) NOT NULL,
+				asset_type VARCHAR(32) NOT NULL,
+				bids TEXT NOT NULL,
+				asks TEXT NOT NULL,
+				timestamp TIMESTAMP NOT NULL
+			)`,
+			`CREATE INDEX orderbooks_exchange_pair_timestamp ON orderbooks (exchange, pair, timestamp)`,
+			`CREATE INDEX orderbooks_timestamp ON orderbooks (timestamp)`,
+			`CREATE INDEX tickers_timestamp ON tickers (timestamp)`,
+			`CREATE INDEX trades_timestamp ON trades (timestamp)`,
+			`CREATE INDEX candles_timestamp ON candles (timestamp)`,
+		},
+	},
+	{
+		version: 4,
+		name:    "create withdrawal addresses",
+		statements: []string{
+			`CREATE TABLE withdrawal_addresses (
+				id BIGINT PRIMARY KEY,
+				data TEXT NOT NULL,
+				updated TIMESTAMP NOT NULL
+			)`,
+		},
+	},
+	{
+		version: 5,
+		name:    "create trade and orderbook summaries",
+		statements: []string{
+			`CREATE TABLE trade_summaries (
+				id {{id}},
+				exchange VARCHAR(64) NOT NULL,
+				pair VARCHAR(32) NOT NULL,
+				asset_type VARCHAR(32) NOT NULL,
+				interval_seconds BIGINT NOT NULL,
+				timestamp TIMESTAMP NOT NULL,
+				trades BIGINT NOT NULL,
+				volume DOUBLE PRECISION NOT NULL,
+				buy_volume DOUBLE PRECISION NOT NULL,
+				sell_volume DOUBLE PRECISION NOT NULL,
+				vwap DOUBLE PRECISION NOT NULL,
+				UNIQUE (exchange, pair, interval_seconds, timestamp)
+			)`,
+			`CREATE TABLE orderbook_summaries (
+				id {{id}},
+				exchange VARCHAR(64) NOT NULL,
+				pair VARCHAR(32) NOT NULL,
+				asset_type VARCHAR(32) NOT NULL,
+				interval_seconds BIGINT NOT NULL,
+				timestamp TIMESTAMP NOT NULL,
+				snapshots BIGINT NOT NULL,
+				mid_open DOUBLE PRECISION NOT NULL,
+				mid_high DOUBLE PRECISION NOT NULL,
+				mid_low DOUBLE PRECISION NOT NULL,
+				mid_close DOUBLE PRECISION NOT NULL,
+				average_spread DOUBLE PRECISION NOT NULL,
+				max_spread DOUBLE PRECISION NOT NULL,
+				average_bid_depth DOUBLE PRECISION NOT NULL,
+				average_ask_depth DOUBLE PRECISION NOT NULL,
+				UNIQUE (exchange, pair, interval_seconds, timestamp)
+			)`,
+		},
+	},
+	{
+		version: 6,
+		name:    "create withdrawal attempts",
+		statements: []string{
+			`CREATE TABLE withdrawal_attempts (
+				id {{id}},
+				request_id BIGINT NOT NULL,
+				exchange VARCHAR(64) NOT NULL,
+				currency VARCHAR(16) NOT NULL,
+				address VARCHAR(256) NOT NULL,
+				amount DOUBLE PRECISION NOT NULL,
+				status VARCHAR(32) NOT NULL,
+				reason TEXT NOT NULL,
+				tx_id VARCHAR(256) NOT NULL,
+				timestamp TIMESTAMP NOT NULL
+			)`,
+			`CREATE INDEX withdrawal_attempts_exchange_timestamp ON withdrawal_attempts (exchange, timestamp)`,
+		},
+	},
+}
+
+// Migrate applies the migrations newer than the schema version of the
+// database, each in its own transaction
+func (d *DB) Migrate() error {
+	err := d.exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version INTEGER PRIMARY KEY,
+		name VARCHAR(256) NOT NULL,
+		applied TIMESTAMP NOT NULL
+	)`)
+	if err != nil {
+		return err
+	}
+
+	current, err := d.SchemaVersion()
+	if err != nil {
+		return err
+	}
+	for _, m := range migrations {
+		if m.version <= current {
+			continue
+		}
+		if err = d.apply(m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SchemaVersion returns the version of the last migration applied
+func (d *DB) SchemaVersion() (int, error) {
+	var version int
+	err := d.SQL.QueryRow(`SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&version)
+	return version, err
+}
+
+// apply runs a migration and records it
+func (d *DB) apply(m migration) error {
+	tx, err := d.SQL.Begin()
+	if err != nil {
+		return err
+	}
+	for _, s := range m.statements {
+		_, err = tx.Exec(strings.Replace(s, "{{id}}", d.dialect.idColumn(), -1))
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	_, err = tx.Exec(d.rebind(`INSERT INTO schema_migrations (version, name, applied) VALUES (?, ?, ?)`),
+		m.version, m.name, time.Now().UTC())
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
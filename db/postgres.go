@@ -0,0 +1,78 @@
+package db
+
+import (
+	"database/sql"
+	"strconv"
+	"strings"
+
+	// PostgreSQL database/sql driver
+	_ "github.com/lib/pq"
+)
+
+func init() {
+	registerDialect(DriverPostgres, postgresDialect{})
+}
+
+// postgresDialect is the SQL dialect of PostgreSQL databases
+type postgresDialect struct{}
+
+func (postgresDialect) sqlDriver() string {
+	return "postgres"
+}
+
+// dataSource returns the connection string of the connection settings, the
+// SSL mode defaults to disabled
+func (postgresDialect) dataSource(c *Config) string {
+	sslMode := c.SSLMode
+	if sslMode == "" {
+		sslMode = "disable"
+	}
+	dsn := []string{
+		"host=" + quoteDSNValue(c.Host),
+		"dbname=" + quoteDSNValue(c.Database),
+		"sslmode=" + quoteDSNValue(sslMode),
+	}
+	if c.Port != 0 {
+		dsn = append(dsn, "port="+strconv.Itoa(int(c.Port)))
+	}
+	if c.Username != "" {
+		dsn = append(dsn, "user="+quoteDSNValue(c.Username))
+	}
+	if c.Password != "" {
+		dsn = append(dsn, "password="+quoteDSNValue(c.Password))
+	}
+	return strings.Join(dsn, " ")
+}
+
+func (postgresDialect) idColumn() string {
+	return "BIGSERIAL PRIMARY KEY"
+}
+
+// rebind converts ? placeholders to numbered $n placeholders
+func (postgresDialect) rebind(query string) string {
+	var b strings.Builder
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			b.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func (postgresDialect) configure(conn *sql.DB) {}
+
+// compact marks the space of deleted rows for reuse and refreshes the query
+// planner statistics
+func (postgresDialect) compact() string {
+	return "VACUUM ANALYZE"
+}
+
+// quoteDSNValue quotes a PostgreSQL connection string value
+func quoteDSNValue(v string) string {
+	v = strings.Replace(v, `\`, `\\`, -1)
+	return "'" + strings.Replace(v, "'", `\'`, -1) + "'"
+}
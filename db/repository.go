@@ -0,0 +1,496 @@
+package db
+
+import (
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+)
+
+// Ticker is a ticker snapshot
+type Ticker struct {
+	Exchange  string
+	Pair      string
+	AssetType string
+	Last      float64
+	High      float64
+	Low       float64
+	Bid       float64
+	Ask       float64
+	Volume    float64
+	Timestamp time.Time
+}
+
+// Trade is an executed trade
+type Trade struct {
+	Exchange  string
+	Pair      string
+	AssetType string
+	TradeID   string
+	Side      string
+	Price     float64
+	Amount    float64
+	Timestamp time.Time
+}
+
+// Candle is an OHLCV candle of an exchange currency pair, Timestamp is the
+// candle's open time
+type Candle struct {
+	Exchange  string
+	Pair      string
+	AssetType string
+	Interval  time.Duration
+	Timestamp time.Time
+	Open      float64
+	High      float64
+	Low       float64
+	Close     float64
+	Volume    float64
+}
+
+// OrderbookLevel is a price level of an orderbook snapshot
+type OrderbookLevel struct {
+	Price  float64 `json:"price"`
+	Amount float64 `json:"amount"`
+}
+
+// Orderbook is an orderbook snapshot of an exchange currency pair, bids and
+// asks are stored best price first
+type Orderbook struct {
+	Exchange  string
+	Pair      string
+	AssetType string
+	Bids      []OrderbookLevel
+	Asks      []OrderbookLevel
+	Timestamp time.Time
+}
+
+// Order is an order placed by the bot, identified by its exchange and
+// exchange order ID
+type Order struct {
+	Exchange             string
+	OrderID              string
+	Pair                 string
+	Side                 string
+	Type                 string
+	Status               string
+	Price                float64
+	Amount               float64
+	ExecutedAmount       float64
+	AverageExecutedPrice float64
+	Strategy             string
+	Created              time.Time
+	Updated              time.Time
+}
+
+// Withdrawal is a withdrawal from an exchange, identified by its exchange and
+// exchange withdrawal ID
+type Withdrawal struct {
+	Exchange     string
+	WithdrawalID string
+	Currency     string
+	Amount       float64
+	Fee          float64
+	Address      string
+	TxID         string
+	Status       string
+	Timestamp    time.Time
+}
+
+// WithdrawalAttempt records a step of a withdrawal through the approval
+// workflow, RequestID is the workflow's ID of the withdrawal and Reason holds
+// why it was rejected or failed
+type WithdrawalAttempt struct {
+	RequestID int64
+	Exchange  string
+	Currency  string
+	Address   string
+	Amount    float64
+	Status    string
+	Reason    string
+	TxID      string
+	Timestamp time.Time
+}
+
+// WithdrawalAddress is an encrypted withdrawal address book entry, Data is
+// opaque to the database
+type WithdrawalAddress struct {
+	ID      int64
+	Data    []byte
+	Updated time.Time
+}
+
+// filter builds the WHERE clause of a query from the conditions whose values
+// are set
+type filter struct {
+	conditions []string
+	args       []interface{}
+}
+
+// equal adds an equality condition when the value is not empty
+func (f *filter) equal(column, value string) {
+	if value != "" {
+		f.conditions = append(f.conditions, column+" = ?")
+		f.args = append(f.args, value)
+	}
+}
+
+// between adds a time range condition for each bound which is not zero
+func (f *filter) between(column string, start, end time.Time) {
+	if !start.IsZero() {
+		f.conditions = append(f.conditions, column+" >= ?")
+		f.args = append(f.args, start.UTC())
+	}
+	if !end.IsZero() {
+		f.conditions = append(f.conditions, column+" < ?")
+		f.args = append(f.args, end.UTC())
+	}
+}
+
+// where returns the WHERE clause, empty without conditions
+func (f *filter) where() string {
+	if len(f.conditions) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(f.conditions, " AND ")
+}
+
+// InsertTicker stores a ticker snapshot
+func (d *DB) InsertTicker(t Ticker) error {
+	return d.exec(`INSERT INTO tickers (exchange, pair, asset_type, last, high, low, bid, ask, volume, timestamp)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		t.Exchange, t.Pair, t.AssetType, t.Last, t.High, t.Low, t.Bid, t.Ask,
+		t.Volume, t.Timestamp.UTC())
+}
+
+// Tickers returns the ticker snapshots of an exchange currency pair within
+// [start, end) in time order. Empty values and zero times are not filtered.
+func (d *DB) Tickers(exchName, pair string, start, end time.Time) ([]Ticker, error) {
+	var f filter
+	f.equal("exchange", exchName)
+	f.equal("pair", pair)
+	f.between("timestamp", start, end)
+	rows, err := d.query(`SELECT exchange, pair, asset_type, last, high, low, bid, ask, volume, timestamp
+		FROM tickers`+f.where()+` ORDER BY timestamp`, f.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []Ticker
+	for rows.Next() {
+		var t Ticker
+		err = rows.Scan(&t.Exchange, &t.Pair, &t.AssetType, &t.Last, &t.High,
+			&t.Low, &t.Bid, &t.Ask, &t.Volume, &t.Timestamp)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, t)
+	}
+	return result, rows.Err()
+}
+
+// InsertTrade stores an executed trade
+func (d *DB) InsertTrade(t Trade) error {
+	return d.exec(`INSERT INTO trades (exchange, pair, asset_type, trade_id, side, price, amount, timestamp)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		t.Exchange, t.Pair, t.AssetType, t.TradeID, t.Side, t.Price, t.Amount,
+		t.Timestamp.UTC())
+}
+
+// Trades returns the trades of an exchange currency pair within [start, end)
+// in time order. Empty values and zero times are not filtered.
+func (d *DB) Trades(exchName, pair string, start, end time.Time) ([]Trade, error) {
+	var f filter
+	f.equal("exchange", exchName)
+	f.equal("pair", pair)
+	f.between("timestamp", start, end)
+	rows, err := d.query(`SELECT exchange, pair, asset_type, trade_id, side, price, amount, timestamp
+		FROM trades`+f.where()+` ORDER BY timestamp`, f.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []Trade
+	for rows.Next() {
+		var t Trade
+		err = rows.Scan(&t.Exchange, &t.Pair, &t.AssetType, &t.TradeID, &t.Side,
+			&t.Price, &t.Amount, &t.Timestamp)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, t)
+	}
+	return result, rows.Err()
+}
+
+// UpsertCandles stores candles in a single transaction, replacing candles
+// already stored with the same exchange, pair, interval and time
+func (d *DB) UpsertCandles(candles []Candle) error {
+	tx, err := d.SQL.Begin()
+	if err != nil {
+		return err
+	}
+	for i := range candles {
+		c := &candles[i]
+		_, err = tx.Exec(d.rebind(`INSERT INTO candles (exchange, pair, asset_type, interval_seconds, timestamp,
+				open, high, low, close, volume)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT (exchange, pair, interval_seconds, timestamp) DO UPDATE SET open = excluded.open,
+				high = excluded.high, low = excluded.low, close = excluded.close,
+				volume = excluded.volume`),
+			c.Exchange, c.Pair, c.AssetType, int64(c.Interval/time.Second), c.Timestamp.UTC(),
+			c.Open, c.High, c.Low, c.Close, c.Volume)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// Candles returns the candles of an exchange currency pair at an interval
+// opening within [start, end) in time order. Empty values and zero times are
+// not filtered.
+func (d *DB) Candles(exchName, pair string, interval time.Duration, start, end time.Time) ([]Candle, error) {
+	var f filter
+	f.equal("exchange", exchName)
+	f.equal("pair", pair)
+	f.conditions = append(f.conditions, "interval_seconds = ?")
+	f.args = append(f.args, int64(interval/time.Second))
+	f.between("timestamp", start, end)
+	rows, err := d.query(`SELECT exchange, pair, asset_type, interval_seconds, timestamp, open, high, low, close, volume
+		FROM candles`+f.where()+` ORDER BY timestamp`, f.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []Candle
+	for rows.Next() {
+		var c Candle
+		var seconds int64
+		err = rows.Scan(&c.Exchange, &c.Pair, &c.AssetType, &seconds, &c.Timestamp,
+			&c.Open, &c.High, &c.Low, &c.Close, &c.Volume)
+		if err != nil {
+			return nil, err
+		}
+		c.Interval = time.Duration(seconds) * time.Second
+		result = append(result, c)
+	}
+	return result, rows.Err()
+}
+
+// InsertOrderbook stores an orderbook snapshot, its levels are stored as JSON
+func (d *DB) InsertOrderbook(o Orderbook) error {
+	bids, err := common.JSONEncode(o.Bids)
+	if err != nil {
+		return err
+	}
+	asks, err := common.JSONEncode(o.Asks)
+	if err != nil {
+		return err
+	}
+	return d.exec(`INSERT INTO orderbooks (exchange, pair, asset_type, bids, asks, timestamp)
+		VALUES (?, ?, ?, ?, ?, ?)`,
+		o.Exchange, o.Pair, o.AssetType, string(bids), string(asks), o.Timestamp.UTC())
+}
+
+// Orderbooks returns the orderbook snapshots of an exchange currency pair
+// within [start, end) in time order. Empty values and zero times are not
+// filtered.
+func (d *DB) Orderbooks(exchName, pair string, start, end time.Time) ([]Orderbook, error) {
+	var f filter
+	f.equal("exchange", exchName)
+	f.equal("pair", pair)
+	f.between("timestamp", start, end)
+	rows, err := d.query(`SELECT exchange, pair, asset_type, bids, asks, timestamp
+		FROM orderbooks`+f.where()+` ORDER BY timestamp`, f.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []Orderbook
+	for rows.Next() {
+		var o Orderbook
+		var bids, asks string
+		err = rows.Scan(&o.Exchange, &o.Pair, &o.AssetType, &bids, &asks, &o.Timestamp)
+		if err != nil {
+			return nil, err
+		}
+		if err = common.JSONDecode([]byte(bids), &o.Bids); err != nil {
+			return nil, err
+		}
+		if err = common.JSONDecode([]byte(asks), &o.Asks); err != nil {
+			return nil, err
+		}
+		result = append(result, o)
+	}
+	return result, rows.Err()
+}
+
+// UpsertOrder stores an order, replacing the state of an order already stored
+// with the same exchange and order ID
+func (d *DB) UpsertOrder(o Order) error {
+	if o.Updated.IsZero() {
+		o.Updated = time.Now()
+	}
+	return d.exec(`INSERT INTO orders (exchange, order_id, pair, side, order_type, status, price, amount,
+			executed_amount, average_executed_price, strategy, created, updated)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (exchange, order_id) DO UPDATE SET status = excluded.status,
+			price = excluded.price, amount = excluded.amount,
+			executed_amount = excluded.executed_amount,
+			average_executed_price = excluded.average_executed_price,
+			updated = excluded.updated`,
+		o.Exchange, o.OrderID, o.Pair, o.Side, o.Type, o.Status, o.Price, o.Amount,
+		o.ExecutedAmount, o.AverageExecutedPrice, o.Strategy, o.Created.UTC(),
+		o.Updated.UTC())
+}
+
+// Orders returns the orders of an exchange with a status in creation order.
+// Empty values are not filtered.
+func (d *DB) Orders(exchName, status string) ([]Order, error) {
+	var f filter
+	f.equal("exchange", exchName)
+	f.equal("status", status)
+	rows, err := d.query(`SELECT exchange, order_id, pair, side, order_type, status, price, amount,
+			executed_amount, average_executed_price, strategy, created, updated
+		FROM orders`+f.where()+` ORDER BY created`, f.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []Order
+	for rows.Next() {
+		var o Order
+		err = rows.Scan(&o.Exchange, &o.OrderID, &o.Pair, &o.Side, &o.Type,
+			&o.Status, &o.Price, &o.Amount, &o.ExecutedAmount,
+			&o.AverageExecutedPrice, &o.Strategy, &o.Created, &o.Updated)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, o)
+	}
+	return result, rows.Err()
+}
+
+// InsertWithdrawal stores a withdrawal, withdrawals already stored with the
+// same exchange and withdrawal ID have their status and transaction ID
+// updated
+func (d *DB) InsertWithdrawal(w Withdrawal) error {
+	return d.exec(`INSERT INTO withdrawals (exchange, withdrawal_id, currency, amount, fee, address,
+			tx_id, status, timestamp)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (exchange, withdrawal_id) DO UPDATE SET status = excluded.status,
+			tx_id = excluded.tx_id`,
+		w.Exchange, w.WithdrawalID, w.Currency, w.Amount, w.Fee, w.Address,
+		w.TxID, w.Status, w.Timestamp.UTC())
+}
+
+// Withdrawals returns the withdrawals of an exchange within [start, end) in
+// time order. Empty values and zero times are not filtered.
+func (d *DB) Withdrawals(exchName string, start, end time.Time) ([]Withdrawal, error) {
+	var f filter
+	f.equal("exchange", exchName)
+	f.between("timestamp", start, end)
+	rows, err := d.query(`SELECT exchange, withdrawal_id, currency, amount, fee, address, tx_id, status, timestamp
+		FROM withdrawals`+f.where()+` ORDER BY timestamp`, f.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []Withdrawal
+	for rows.Next() {
+		var w Withdrawal
+		err = rows.Scan(&w.Exchange, &w.WithdrawalID, &w.Currency, &w.Amount,
+			&w.Fee, &w.Address, &w.TxID, &w.Status, &w.Timestamp)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, w)
+	}
+	return result, rows.Err()
+}
+
+// InsertWithdrawalAttempt stores a withdrawal attempt
+func (d *DB) InsertWithdrawalAttempt(a WithdrawalAttempt) error {
+	return d.exec(`INSERT INTO withdrawal_attempts (request_id, exchange, currency, address, amount,
+			status, reason, tx_id, timestamp)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		a.RequestID, a.Exchange, a.Currency, a.Address, a.Amount, a.Status, a.Reason,
+		a.TxID, a.Timestamp.UTC())
+}
+
+// WithdrawalAttempts returns the withdrawal attempts of an exchange within
+// [start, end) in the order they were made. Empty values and zero times are
+// not filtered.
+func (d *DB) WithdrawalAttempts(exchName string, start, end time.Time) ([]WithdrawalAttempt, error) {
+	var f filter
+	f.equal("exchange", exchName)
+	f.between("timestamp", start, end)
+	rows, err := d.query(`SELECT request_id, exchange, currency, address, amount, status, reason, tx_id, timestamp
+		FROM withdrawal_attempts`+f.where()+` ORDER BY timestamp, id`, f.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []WithdrawalAttempt
+	for rows.Next() {
+		var a WithdrawalAttempt
+		err = rows.Scan(&a.RequestID, &a.Exchange, &a.Currency, &a.Address, &a.Amount,
+			&a.Status, &a.Reason, &a.TxID, &a.Timestamp)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, a)
+	}
+	return result, rows.Err()
+}
+
+// UpsertWithdrawalAddress stores a withdrawal address book entry, replacing
+// the entry already stored with the same ID
+func (d *DB) UpsertWithdrawalAddress(a WithdrawalAddress) error {
+	if a.Updated.IsZero() {
+		a.Updated = time.Now()
+	}
+	return d.exec(`INSERT INTO withdrawal_addresses (id, data, updated) VALUES (?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET data = excluded.data, updated = excluded.updated`,
+		a.ID, base64.StdEncoding.EncodeToString(a.Data), a.Updated.UTC())
+}
+
+// WithdrawalAddresses returns the withdrawal address book entries in ID order
+func (d *DB) WithdrawalAddresses() ([]WithdrawalAddress, error) {
+	rows, err := d.query(`SELECT id, data, updated FROM withdrawal_addresses ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []WithdrawalAddress
+	for rows.Next() {
+		var a WithdrawalAddress
+		var data string
+		err = rows.Scan(&a.ID, &data, &a.Updated)
+		if err != nil {
+			return nil, err
+		}
+		a.Data, err = base64.StdEncoding.DecodeString(data)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, a)
+	}
+	return result, rows.Err()
+}
+
+// DeleteWithdrawalAddress removes a withdrawal address book entry
+func (d *DB) DeleteWithdrawalAddress(id int64) error {
+	return d.exec(`DELETE FROM withdrawal_addresses WHERE id = ?`, id)
+}
@@ -0,0 +1,44 @@
+package db
+
+import (
+	"errors"
+	"time"
+)
+
+// Market data tables which can be pruned
+const (
+	TableTickers    = "tickers"
+	TableTrades     = "trades"
+	TableOrderbooks = "orderbooks"
+	TableCandles    = "candles"
+)
+
+// ErrTableNotPrunable is returned when pruning a table which is not market
+// data, orders and withdrawals are kept as records of the bot's activity
+var ErrTableNotPrunable = errors.New("table cannot be pruned")
+
+// prunable are the market data tables, each pruned by its timestamp column
+var prunable = map[string]bool{
+	TableTickers:    true,
+	TableTrades:     true,
+	TableOrderbooks: true,
+	TableCandles:    true,
+}
+
+// Prune deletes the rows of a market data table with a timestamp before
+// before and returns the number of rows deleted
+func (d *DB) Prune(table string, before time.Time) (int64, error) {
+	if !prunable[table] {
+		return 0, ErrTableNotPrunable
+	}
+	res, err := d.SQL.Exec(d.rebind(`DELETE FROM `+table+` WHERE timestamp < ?`), before.UTC())
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// Compact reclaims the space of pruned rows
+func (d *DB) Compact() error {
+	return d.exec(d.dialect.compact())
+}
@@ -0,0 +1,44 @@
+package db
+
+import (
+	"database/sql"
+
+	// SQLite database/sql driver
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func init() {
+	registerDialect(DriverSQLite, sqliteDialect{})
+}
+
+// sqliteDialect is the SQL dialect of SQLite databases
+type sqliteDialect struct{}
+
+func (sqliteDialect) sqlDriver() string {
+	return "sqlite3"
+}
+
+// dataSource returns the database file path
+func (sqliteDialect) dataSource(c *Config) string {
+	return c.Database
+}
+
+func (sqliteDialect) idColumn() string {
+	return "INTEGER PRIMARY KEY AUTOINCREMENT"
+}
+
+func (sqliteDialect) rebind(query string) string {
+	return query
+}
+
+// configure serialises access to the database, SQLite allows a single writer
+// so this avoids busy errors between the bot's routines
+func (sqliteDialect) configure(conn *sql.DB) {
+	conn.SetMaxOpenConns(1)
+}
+
+// compact rebuilds the database file, SQLite does not shrink the file when
+// rows are deleted
+func (sqliteDialect) compact() string {
+	return "VACUUM"
+}
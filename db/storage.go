@@ -0,0 +1,147 @@
+package db
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// TickerRepository stores and queries ticker snapshots
+type TickerRepository interface {
+	InsertTicker(t Ticker) error
+	Tickers(exchName, pair string, start, end time.Time) ([]Ticker, error)
+}
+
+// TradeRepository stores and queries executed trades
+type TradeRepository interface {
+	InsertTrade(t Trade) error
+	Trades(exchName, pair string, start, end time.Time) ([]Trade, error)
+}
+
+// CandleRepository stores and queries candles
+type CandleRepository interface {
+	UpsertCandles(candles []Candle) error
+	Candles(exchName, pair string, interval time.Duration, start, end time.Time) ([]Candle, error)
+}
+
+// OrderbookRepository stores and queries orderbook snapshots
+type OrderbookRepository interface {
+	InsertOrderbook(o Orderbook) error
+	Orderbooks(exchName, pair string, start, end time.Time) ([]Orderbook, error)
+}
+
+// OrderRepository stores and queries orders
+type OrderRepository interface {
+	UpsertOrder(o Order) error
+	Orders(exchName, status string) ([]Order, error)
+}
+
+// WithdrawalRepository stores and queries withdrawal history
+type WithdrawalRepository interface {
+	InsertWithdrawal(w Withdrawal) error
+	Withdrawals(exchName string, start, end time.Time) ([]Withdrawal, error)
+}
+
+// WithdrawalAttemptRepository stores and queries the attempts of the
+// withdrawal approval workflow
+type WithdrawalAttemptRepository interface {
+	InsertWithdrawalAttempt(a WithdrawalAttempt) error
+	WithdrawalAttempts(exchName string, start, end time.Time) ([]WithdrawalAttempt, error)
+}
+
+// WithdrawalAddressRepository stores the entries of the withdrawal address
+// book, which are encrypted before being stored
+type WithdrawalAddressRepository interface {
+	UpsertWithdrawalAddress(a WithdrawalAddress) error
+	WithdrawalAddresses() ([]WithdrawalAddress, error)
+	DeleteWithdrawalAddress(id int64) error
+}
+
+// RetentionRepository removes market data past its retention window and
+// reclaims the space it used
+type RetentionRepository interface {
+	Prune(table string, before time.Time) (int64, error)
+	Compact() error
+}
+
+// Storage is a storage driver's database, the rest of the bot only uses
+// storage through this interface. Connect opens the database and Migrate
+// creates or upgrades its schema to the latest version.
+type Storage interface {
+	Connect() error
+	Migrate() error
+	SchemaVersion() (int, error)
+	Close() error
+	Driver() string
+
+	TickerRepository
+	TradeRepository
+	CandleRepository
+	OrderbookRepository
+	OrderRepository
+	WithdrawalRepository
+	WithdrawalAttemptRepository
+	WithdrawalAddressRepository
+	RetentionRepository
+	ArchiveRepository
+}
+
+// DriverFactory returns the unconnected storage of the connection settings
+type DriverFactory func(c Config) Storage
+
+var (
+	drivers   = make(map[string]DriverFactory)
+	driversMu sync.RWMutex
+)
+
+// RegisterDriver makes a storage driver available by name, replacing a
+// driver already registered with the name
+func RegisterDriver(name string, factory DriverFactory) {
+	driversMu.Lock()
+	defer driversMu.Unlock()
+	drivers[name] = factory
+}
+
+// DriverRegistered returns whether a storage driver is registered
+func DriverRegistered(name string) bool {
+	driversMu.RLock()
+	defer driversMu.RUnlock()
+	_, ok := drivers[name]
+	return ok
+}
+
+// Drivers returns the names of the registered storage drivers
+func Drivers() []string {
+	driversMu.RLock()
+	defer driversMu.RUnlock()
+	names := make([]string, 0, len(drivers))
+	for name := range drivers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Open connects to the database of the configured driver and migrates its
+// schema to the latest version
+func Open(c Config) (Storage, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	driversMu.RLock()
+	factory := drivers[c.Driver]
+	driversMu.RUnlock()
+
+	s := factory(c)
+	err := s.Connect()
+	if err == nil {
+		if err = s.Migrate(); err != nil {
+			s.Close()
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s database %s: %s", c.Driver, c.Database, err)
+	}
+	return s, nil
+}
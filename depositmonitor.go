@@ -0,0 +1,254 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/communications/base"
+	"github.com/thrasher-/gocryptotrader/exchanges"
+)
+
+const depositMonitorPollInterval = time.Minute
+
+// DepositEventResumed is the event type emitted when a strategy paused
+// awaiting funds is resumed
+const DepositEventResumed = "strategy_resumed"
+
+// Errors returned by the deposit monitor
+var (
+	ErrInvalidFundsWait     = errors.New("funds wait requires a strategy, exchange, currency, amount greater than zero and resume func")
+	ErrAlreadyAwaitingFunds = errors.New("strategy is already awaiting funds")
+	ErrNotAwaitingFunds     = errors.New("strategy is not awaiting funds")
+)
+
+// FundsWait is a strategy paused until deposits totalling Amount of Currency
+// arrive on Exchange after Since. Resume is called once they have arrived.
+type FundsWait struct {
+	Strategy string
+	Exchange string
+	Currency string
+	Amount   float64
+	Since    time.Time
+	Received float64
+	Resume   func() error
+
+	credited map[string]bool
+}
+
+// String returns a one line description of the wait
+func (w FundsWait) String() string {
+	return fmt.Sprintf("strategy %s awaiting %v %s on %s: %v received",
+		w.Strategy, w.Amount, w.Currency, w.Exchange, w.Received)
+}
+
+// DepositMonitor watches the funding history of exchanges for the deposits
+// and transfers in which strategies paused awaiting funds are waiting on, and
+// resumes them once the expected amount has arrived. A deposit counts towards
+// every strategy waiting on its exchange and currency.
+type DepositMonitor struct {
+	PollInterval time.Duration
+	OnResume     func(w FundsWait, err error)
+
+	waits []*FundsWait
+	m     sync.Mutex
+}
+
+// NewDepositMonitor returns a new deposit monitor
+func NewDepositMonitor() *DepositMonitor {
+	return &DepositMonitor{PollInterval: depositMonitorPollInterval}
+}
+
+// AwaitFunds adds a strategy paused awaiting funds. Waits without a Since
+// time count deposits from now.
+func (d *DepositMonitor) AwaitFunds(w FundsWait) error {
+	if w.Strategy == "" || w.Exchange == "" || w.Currency == "" || w.Amount <= 0 || w.Resume == nil {
+		return ErrInvalidFundsWait
+	}
+
+	d.m.Lock()
+	defer d.m.Unlock()
+	if d.wait(w.Strategy) != nil {
+		return ErrAlreadyAwaitingFunds
+	}
+	if w.Since.IsZero() {
+		w.Since = time.Now()
+	}
+	w.Currency = common.StringToUpper(w.Currency)
+	w.Received = 0
+	w.credited = make(map[string]bool)
+	d.waits = append(d.waits, &w)
+	return nil
+}
+
+// CancelWait removes a strategy awaiting funds without resuming it
+func (d *DepositMonitor) CancelWait(strategy string) error {
+	d.m.Lock()
+	defer d.m.Unlock()
+	for i := range d.waits {
+		if d.waits[i].Strategy == strategy {
+			d.waits = append(d.waits[:i], d.waits[i+1:]...)
+			return nil
+		}
+	}
+	return ErrNotAwaitingFunds
+}
+
+// Waiting returns the strategies awaiting funds
+func (d *DepositMonitor) Waiting() []FundsWait {
+	d.m.Lock()
+	defer d.m.Unlock()
+	result := make([]FundsWait, len(d.waits))
+	for i := range d.waits {
+		result[i] = *d.waits[i]
+		result[i].credited = nil
+	}
+	return result
+}
+
+// Poll credits the deposits in the funding history of the exchanges to the
+// strategies awaiting them, resumes those whose funds have arrived and
+// returns them. Strategies which fail to resume are removed and reported to
+// OnResume with the error.
+func (d *DepositMonitor) Poll(exchs []exchange.IBotExchange) []FundsWait {
+	d.m.Lock()
+	var arrived []*FundsWait
+	for _, exch := range exchs {
+		if exch == nil || !exch.IsEnabled() || !exch.GetAuthenticatedAPISupport() ||
+			!exch.GetCapabilities().CanGetFundingHistory || !d.awaitingExchange(exch.GetName()) {
+			continue
+		}
+		history, err := exch.GetFundingHistory()
+		if err != nil {
+			log.Printf("Deposit monitor failed to get %s funding history. Err: %s",
+				exch.GetName(), err)
+			continue
+		}
+		for i := range history {
+			d.credit(exch.GetName(), &history[i])
+		}
+	}
+	remaining := d.waits[:0]
+	for _, w := range d.waits {
+		if w.Received >= w.Amount {
+			arrived = append(arrived, w)
+			continue
+		}
+		remaining = append(remaining, w)
+	}
+	d.waits = remaining
+	d.m.Unlock()
+
+	var resumed []FundsWait
+	for _, w := range arrived {
+		err := w.Resume()
+		result := *w
+		result.credited = nil
+		if err == nil {
+			resumed = append(resumed, result)
+		}
+		if d.OnResume != nil {
+			d.OnResume(result, err)
+		}
+	}
+	return resumed
+}
+
+// Run polls the bot's exchanges until the bot shuts down
+func (d *DepositMonitor) Run() {
+	log.Println("Starting deposit monitor routine.")
+	for {
+		d.Poll(bot.exchanges)
+		time.Sleep(d.PollInterval)
+	}
+}
+
+// credit adds an exchange funding history item to the strategies awaiting it
+// if it is a deposit which has arrived. The mutex must be held by the caller.
+func (d *DepositMonitor) credit(exchName string, f *exchange.FundHistory) {
+	if !isDeposit(f.TransferType) || !depositArrived(f.Status) || f.Amount <= 0 {
+		return
+	}
+	id := depositID(f)
+	for _, w := range d.waits {
+		if common.StringToUpper(w.Exchange) != common.StringToUpper(exchName) ||
+			common.StringToUpper(f.Currency) != w.Currency ||
+			f.Timestamp.Before(w.Since) || w.credited[id] {
+			continue
+		}
+		w.credited[id] = true
+		w.Received += f.Amount
+	}
+}
+
+// awaitingExchange returns whether a strategy is awaiting funds on an
+// exchange. The mutex must be held by the caller.
+func (d *DepositMonitor) awaitingExchange(exchName string) bool {
+	for _, w := range d.waits {
+		if common.StringToUpper(w.Exchange) == common.StringToUpper(exchName) {
+			return true
+		}
+	}
+	return false
+}
+
+// wait returns the wait of a strategy. The mutex must be held by the caller.
+func (d *DepositMonitor) wait(strategy string) *FundsWait {
+	for _, w := range d.waits {
+		if w.Strategy == strategy {
+			return w
+		}
+	}
+	return nil
+}
+
+// isDeposit returns whether a funding history transfer type is a deposit or
+// an incoming transfer
+func isDeposit(transferType string) bool {
+	t := strings.NewReplacer("_", " ", "-", " ").Replace(common.StringToLower(transferType))
+	return common.StringContains(t, "deposit") ||
+		common.StringContains(t, "transfer in") ||
+		common.StringContains(t, "incoming")
+}
+
+// depositArrived returns whether a deposit status reports the funds as
+// credited, exchanges without statuses only report credited deposits
+func depositArrived(status string) bool {
+	s := common.StringToLower(status)
+	for _, pending := range []string{"pending", "processing", "fail", "cancel", "reject"} {
+		if common.StringContains(s, pending) {
+			return false
+		}
+	}
+	return true
+}
+
+// depositID identifies a deposit by its transfer ID, or transaction ID when
+// the exchange has no transfer ID, falling back to its time and amount
+func depositID(f *exchange.FundHistory) string {
+	switch {
+	case f.TransferID != 0:
+		return strconv.FormatInt(f.TransferID, 10)
+	case f.CryptoTxID != "":
+		return f.CryptoTxID
+	}
+	return f.Currency + " " + strconv.FormatInt(f.Timestamp.UnixNano(), 10) + " " +
+		strconv.FormatFloat(f.Amount, 'f', -1, 64)
+}
+
+// depositMonitorAlert logs and relays resumed strategies to the communication
+// mediums
+func depositMonitorAlert(w FundsWait, err error) {
+	if err != nil {
+		log.Printf("Failed to resume %s. Err: %s", w, err)
+		return
+	}
+	message := "Funds arrived, resumed " + w.String()
+	log.Println(message)
+	bot.comms.PushEvent(base.Event{Type: DepositEventResumed, TradeDetails: message})
+}
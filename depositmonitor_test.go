@@ -0,0 +1,103 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/exchanges"
+)
+
+// testDepositExchange returns a queued funding history
+type testDepositExchange struct {
+	exchange.IBotExchange
+	history []exchange.FundHistory
+}
+
+func (e *testDepositExchange) GetName() string {
+	return "DepositMonitorTest"
+}
+
+func (e *testDepositExchange) IsEnabled() bool {
+	return true
+}
+
+func (e *testDepositExchange) GetAuthenticatedAPISupport() bool {
+	return true
+}
+
+func (e *testDepositExchange) GetCapabilities() exchange.Features {
+	return exchange.Features{CanGetFundingHistory: true}
+}
+
+func (e *testDepositExchange) GetFundingHistory() ([]exchange.FundHistory, error) {
+	return e.history, nil
+}
+
+func TestDepositMonitorPoll(t *testing.T) {
+	exch := &testDepositExchange{}
+	d := NewDepositMonitor()
+	since := time.Now().Add(-time.Hour)
+	var resumed, failed int
+	d.OnResume = func(w FundsWait, err error) {
+		if err != nil {
+			failed++
+		}
+	}
+
+	err := d.AwaitFunds(FundsWait{Strategy: "marketmaker", Exchange: "depositmonitortest",
+		Currency: "btc", Amount: 1, Since: since, Resume: func() error {
+			resumed++
+			return nil
+		}})
+	if err != nil {
+		t.Fatal("Test failed. AwaitFunds() error", err)
+	}
+	err = d.AwaitFunds(FundsWait{Strategy: "marketmaker", Exchange: "DepositMonitorTest",
+		Currency: "BTC", Amount: 1, Resume: func() error { return nil }})
+	if err != ErrAlreadyAwaitingFunds {
+		t.Error("Test failed. AwaitFunds() expected already awaiting error", err)
+	}
+	err = d.AwaitFunds(FundsWait{Strategy: "arbitrage", Exchange: "DepositMonitorTest",
+		Currency: "BTC", Amount: 2, Since: since, Resume: func() error {
+			return errors.New("exchange offline")
+		}})
+	if err != nil {
+		t.Fatal("Test failed. AwaitFunds() error", err)
+	}
+	if err = d.AwaitFunds(FundsWait{Strategy: "twap", Amount: 1}); err != ErrInvalidFundsWait {
+		t.Error("Test failed. AwaitFunds() expected invalid wait error", err)
+	}
+
+	exch.history = []exchange.FundHistory{
+		{TransferType: "deposit", Currency: "BTC", Amount: 5, Timestamp: since.Add(-time.Minute), TransferID: 1},
+		{TransferType: "withdrawal", Currency: "BTC", Amount: 5, Timestamp: since.Add(time.Minute), TransferID: 2},
+		{TransferType: "deposit", Status: "Pending", Currency: "BTC", Amount: 5, Timestamp: since.Add(time.Minute), TransferID: 3},
+		{TransferType: "deposit", Currency: "BTC", Amount: 0.6, Timestamp: since.Add(time.Minute), TransferID: 4},
+	}
+	if r := d.Poll([]exchange.IBotExchange{exch}); len(r) != 0 {
+		t.Error("Test failed. Poll() resumed before funds arrived", r)
+	}
+	// Deposits are credited once across polls
+	if r := d.Poll([]exchange.IBotExchange{exch}); len(r) != 0 {
+		t.Error("Test failed. Poll() credited a deposit twice", r)
+	}
+	if w := d.Waiting(); len(w) != 2 || w[0].Received != 0.6 {
+		t.Error("Test failed. Waiting() unexpected received amount", w)
+	}
+
+	exch.history[2].Status = "Completed"
+	exch.history = append(exch.history, exchange.FundHistory{
+		TransferType: "TRANSFER_IN", Currency: "btc", Amount: 0.4, Timestamp: since.Add(2 * time.Minute), CryptoTxID: "0x1",
+	})
+	r := d.Poll([]exchange.IBotExchange{exch})
+	if len(r) != 1 || r[0].Strategy != "marketmaker" || r[0].Received != 6 || resumed != 1 {
+		t.Error("Test failed. Poll() expected marketmaker resumed", r)
+	}
+	if failed != 1 || len(d.Waiting()) != 0 {
+		t.Error("Test failed. Poll() expected failed resume reported and removed", failed, d.Waiting())
+	}
+	if err = d.CancelWait("marketmaker"); err != ErrNotAwaitingFunds {
+		t.Error("Test failed. CancelWait() expected not awaiting error", err)
+	}
+}
@@ -0,0 +1,276 @@
+// Package dust identifies balances too small to be sold on an exchange, dust,
+// and sweeps them into a single currency using the exchange's dust conversion
+// endpoint or, where there is none, by topping each balance up to a sellable
+// amount through the order manager.
+package dust
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/assets"
+)
+
+// StrategyName is the strategy dust sweep orders are submitted and throttled
+// as
+const StrategyName = "dustsweep"
+
+// Sweeper defaults
+const (
+	DefaultInterval      = 24 * time.Hour
+	DefaultQuoteCurrency = "USDT"
+)
+
+// Errors returned when sweeping dust
+var (
+	ErrNoDust            = errors.New("no dust balances")
+	ErrNoConversionPair  = errors.New("no enabled pair to sell dust against the quote currency")
+	ErrConversionMissing = errors.New("exchange does not support dust conversion and top up is disabled")
+)
+
+// Thresholder returns the smallest sellable balance of a currency, implemented
+// by every exchange through exchange.Base
+type Thresholder interface {
+	DustThreshold(currency string) float64
+}
+
+// Balance is a dust balance of Currency on Exchange, Amount is below the
+// smallest sellable Threshold
+type Balance struct {
+	Exchange  string  `json:"exchange"`
+	Currency  string  `json:"currency"`
+	Amount    float64 `json:"amount"`
+	Threshold float64 `json:"threshold"`
+}
+
+// Threshold returns the dust threshold of a currency on an exchange, the
+// override when one is configured and otherwise the exchange's smallest
+// sellable amount. Zero is returned when neither is known.
+func Threshold(exch exchange.IBotExchange, currency string, overrides map[string]float64) float64 {
+	if t, ok := overrides[common.StringToUpper(currency)]; ok {
+		return t
+	}
+	if t, ok := exch.(Thresholder); ok {
+		return t.DustThreshold(currency)
+	}
+	return 0
+}
+
+// Identify sets Dust on the account's balances which are above zero and below
+// their threshold on exch and returns them. Only spot balances can be swept so
+// balances of other asset types are skipped.
+func Identify(exch exchange.IBotExchange, info *exchange.AccountInfo, overrides map[string]float64) []Balance {
+	var dust []Balance
+	for i := range info.Currencies {
+		c := &info.Currencies[i]
+		if c.AssetType != "" && c.AssetType != assets.Spot {
+			continue
+		}
+		threshold := Threshold(exch, c.CurrencyName, overrides)
+		c.Dust = c.TotalValue > 0 && c.TotalValue < threshold
+		if !c.Dust {
+			continue
+		}
+		dust = append(dust, Balance{
+			Exchange:  info.ExchangeName,
+			Currency:  common.StringToUpper(c.CurrencyName),
+			Amount:    c.TotalValue,
+			Threshold: threshold,
+		})
+	}
+	return dust
+}
+
+// Result holds the outcome of sweeping an exchange's dust. Conversion is set
+// when the exchange's dust conversion endpoint was used, otherwise OrderIDs
+// holds the local order IDs of the top up and sale orders. Currencies which
+// could not be swept are in Skipped.
+type Result struct {
+	Exchange   string
+	Dust       []Balance
+	Conversion *exchange.DustConversion
+	OrderIDs   []int
+	Skipped    []string
+	Err        error
+}
+
+// Sweeper periodically sweeps the dust of Exchanges. Dust is converted with
+// exchange.DustConverter where implemented. Otherwise, when TopUp is set, the
+// dust of each currency with an enabled pair against QuoteCurrency is topped
+// up by buying the pair's smallest order and sold together with it.
+type Sweeper struct {
+	Exchanges     func() []exchange.IBotExchange
+	Overrides     func(exchange string) map[string]float64
+	Orderer       exchange.Orderer
+	QuoteCurrency string
+	TopUp         bool
+	Exclude       []string
+	OnSweep       func(Result)
+
+	mu       sync.Mutex
+	lastDust map[string][]Balance
+}
+
+// Dust returns the dust balances found on each exchange by the last sweep
+func (s *Sweeper) Dust() map[string][]Balance {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	dust := make(map[string][]Balance, len(s.lastDust))
+	for k, v := range s.lastDust {
+		dust[k] = append([]Balance(nil), v...)
+	}
+	return dust
+}
+
+// excluded returns whether currency is never swept
+func (s *Sweeper) excluded(currency string) bool {
+	if common.StringDataCompareUpper([]string{s.quoteCurrency()}, currency) {
+		return true
+	}
+	return common.StringDataCompareUpper(s.Exclude, currency)
+}
+
+// quoteCurrency returns the currency dust is sold for
+func (s *Sweeper) quoteCurrency() string {
+	if s.QuoteCurrency == "" {
+		return DefaultQuoteCurrency
+	}
+	return common.StringToUpper(s.QuoteCurrency)
+}
+
+// Sweep identifies and sweeps the dust of an exchange
+func (s *Sweeper) Sweep(exch exchange.IBotExchange) Result {
+	result := Result{Exchange: exch.GetName()}
+	info, err := exch.GetAccountInfo()
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	var overrides map[string]float64
+	if s.Overrides != nil {
+		overrides = s.Overrides(exch.GetName())
+	}
+	for _, b := range Identify(exch, &info, overrides) {
+		if !s.excluded(b.Currency) {
+			result.Dust = append(result.Dust, b)
+		}
+	}
+
+	s.mu.Lock()
+	if s.lastDust == nil {
+		s.lastDust = make(map[string][]Balance)
+	}
+	s.lastDust[result.Exchange] = result.Dust
+	s.mu.Unlock()
+
+	if len(result.Dust) == 0 {
+		result.Err = ErrNoDust
+		return result
+	}
+
+	if converter, ok := exch.(exchange.DustConverter); ok {
+		currencies := make([]string, len(result.Dust))
+		for i := range result.Dust {
+			currencies[i] = result.Dust[i].Currency
+		}
+		conversion, err := converter.ConvertDust(currencies)
+		if err != nil {
+			result.Err = err
+			return result
+		}
+		result.Conversion = &conversion
+		return result
+	}
+
+	if !s.TopUp {
+		result.Err = ErrConversionMissing
+		return result
+	}
+	for _, b := range result.Dust {
+		ids, err := s.topUp(exch, b)
+		result.OrderIDs = append(result.OrderIDs, ids...)
+		if err != nil {
+			result.Skipped = append(result.Skipped, b.Currency)
+			if result.Err == nil {
+				result.Err = fmt.Errorf("%s: %s", b.Currency, err)
+			}
+		}
+	}
+	return result
+}
+
+// topUp buys the smallest order of the dust balance's pair against the quote
+// currency then sells the balance together with it
+func (s *Sweeper) topUp(exch exchange.IBotExchange, b Balance) ([]int, error) {
+	p, ok := s.conversionPair(exch, b.Currency)
+	if !ok {
+		return nil, ErrNoConversionPair
+	}
+	buyID, err := s.Orderer.SubmitStrategyOrder(StrategyName, exch, exchange.OrderSubmission{
+		Pair:       p,
+		Side:       exchange.Buy,
+		Type:       exchange.Market,
+		BaseAmount: b.Threshold,
+	})
+	if err != nil {
+		return nil, err
+	}
+	sellID, err := s.Orderer.SubmitStrategyOrder(StrategyName, exch, exchange.OrderSubmission{
+		Pair:       p,
+		Side:       exchange.Sell,
+		Type:       exchange.Market,
+		BaseAmount: b.Amount + b.Threshold,
+	})
+	if err != nil {
+		return []int{buyID}, err
+	}
+	return []int{buyID, sellID}, nil
+}
+
+// conversionPair returns the exchange's enabled pair selling currency for the
+// quote currency
+func (s *Sweeper) conversionPair(exch exchange.IBotExchange, currency string) (pair.CurrencyPair, bool) {
+	quote := s.quoteCurrency()
+	for _, p := range exch.GetEnabledCurrencies() {
+		if common.StringToUpper(p.FirstCurrency.String()) == currency &&
+			common.StringToUpper(p.SecondCurrency.String()) == quote {
+			return p, true
+		}
+	}
+	return pair.CurrencyPair{}, false
+}
+
+// SweepAll sweeps the dust of every exchange
+func (s *Sweeper) SweepAll() []Result {
+	var results []Result
+	for _, exch := range s.Exchanges() {
+		r := s.Sweep(exch)
+		if s.OnSweep != nil {
+			s.OnSweep(r)
+		}
+		results = append(results, r)
+	}
+	return results
+}
+
+// Run sweeps dust every interval until stop is closed
+func (s *Sweeper) Run(interval time.Duration, stop <-chan struct{}) {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-t.C:
+			s.SweepAll()
+		}
+	}
+}
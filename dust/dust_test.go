@@ -0,0 +1,147 @@
+package dust
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+)
+
+// testOrder records a submitted order
+type testOrder struct {
+	strategy string
+	pair     string
+	side     exchange.OrderSide
+	amount   float64
+}
+
+// testOrderer records orders, rejecting them when err is set
+type testOrderer struct {
+	orders []testOrder
+	err    error
+}
+
+func (o *testOrderer) SubmitStrategyOrder(strategy string, exch exchange.IBotExchange, s exchange.OrderSubmission) (int, error) {
+	if o.err != nil {
+		return 0, o.err
+	}
+	o.orders = append(o.orders, testOrder{strategy, s.Pair.Pair().String(), s.Side, s.BaseAmount})
+	return len(o.orders), nil
+}
+
+// testExchange reports balances and the smallest sellable amounts
+type testExchange struct {
+	exchange.IBotExchange
+	balances   []exchange.AccountCurrencyInfo
+	thresholds map[string]float64
+	pairs      []pair.CurrencyPair
+}
+
+func (e *testExchange) GetName() string {
+	return "DustExchange"
+}
+
+func (e *testExchange) GetAccountInfo() (exchange.AccountInfo, error) {
+	balances := append([]exchange.AccountCurrencyInfo(nil), e.balances...)
+	return exchange.AccountInfo{ExchangeName: e.GetName(), Currencies: balances}, nil
+}
+
+func (e *testExchange) GetEnabledCurrencies() []pair.CurrencyPair {
+	return e.pairs
+}
+
+func (e *testExchange) DustThreshold(currency string) float64 {
+	return e.thresholds[currency]
+}
+
+// testConverter also converts dust
+type testConverter struct {
+	testExchange
+	converted []string
+}
+
+func (e *testConverter) ConvertDust(currencies []string) (exchange.DustConversion, error) {
+	e.converted = currencies
+	return exchange.DustConversion{Target: "BNB", Converted: currencies, Amount: 0.01}, nil
+}
+
+func newTestExchange() testExchange {
+	return testExchange{
+		balances: []exchange.AccountCurrencyInfo{
+			{CurrencyName: "BTC", TotalValue: 0.5},
+			{CurrencyName: "ETH", TotalValue: 0.0005},
+			{CurrencyName: "LTC", TotalValue: 0.005},
+			{CurrencyName: "XRP"},
+			{CurrencyName: "USDT", TotalValue: 0.5},
+		},
+		thresholds: map[string]float64{"BTC": 0.001, "ETH": 0.001, "LTC": 0.01, "USDT": 1},
+		pairs: []pair.CurrencyPair{
+			pair.NewCurrencyPair("BTC", "USDT"),
+			pair.NewCurrencyPair("ETH", "USDT"),
+			pair.NewCurrencyPair("LTC", "BTC"),
+		},
+	}
+}
+
+func TestIdentify(t *testing.T) {
+	e := newTestExchange()
+	info, _ := e.GetAccountInfo()
+	dust := Identify(&e, &info, map[string]float64{"LTC": 0.001})
+	if len(dust) != 2 || dust[0].Currency != "ETH" || dust[0].Threshold != 0.001 || dust[1].Currency != "USDT" {
+		t.Fatalf("Test Failed - Identify() unexpected dust %+v", dust)
+	}
+	for _, c := range info.Currencies {
+		if c.Dust != (c.CurrencyName == "ETH" || c.CurrencyName == "USDT") {
+			t.Errorf("Test Failed - Identify() unexpected dust flag for %s", c.CurrencyName)
+		}
+	}
+	if th := Threshold(&e, "ltc", map[string]float64{"LTC": 0.001}); th != 0.001 {
+		t.Error("Test Failed - Threshold() expected override", th)
+	}
+}
+
+func TestSweepConversion(t *testing.T) {
+	e := &testConverter{testExchange: newTestExchange()}
+	s := &Sweeper{Exchanges: func() []exchange.IBotExchange { return []exchange.IBotExchange{e} }}
+	results := s.SweepAll()
+	if len(results) != 1 || results[0].Err != nil || results[0].Conversion == nil {
+		t.Fatalf("Test Failed - SweepAll() unexpected results %+v", results)
+	}
+	// The quote currency is never swept
+	if len(e.converted) != 2 || e.converted[0] != "ETH" || e.converted[1] != "LTC" {
+		t.Error("Test Failed - Sweep() unexpected converted currencies", e.converted)
+	}
+	if d := s.Dust()["DustExchange"]; len(d) != 2 {
+		t.Error("Test Failed - Dust() expected last sweep's dust", d)
+	}
+}
+
+func TestSweepTopUp(t *testing.T) {
+	e := newTestExchange()
+	o := &testOrderer{}
+	s := &Sweeper{Orderer: o, Exclude: []string{"XRP"}}
+	if r := s.Sweep(&e); r.Err != ErrConversionMissing || len(o.orders) != 0 {
+		t.Error("Test Failed - Sweep() expected conversion missing error", r.Err)
+	}
+
+	s.TopUp = true
+	r := s.Sweep(&e)
+	if len(o.orders) != 2 || len(r.OrderIDs) != 2 || len(r.Skipped) != 1 || r.Skipped[0] != "LTC" || r.Err == nil {
+		t.Fatalf("Test Failed - Sweep() unexpected result %+v orders %+v", r, o.orders)
+	}
+	if o.orders[0] != (testOrder{StrategyName, "ETHUSDT", exchange.Buy, 0.001}) ||
+		o.orders[1] != (testOrder{StrategyName, "ETHUSDT", exchange.Sell, 0.0015}) {
+		t.Errorf("Test Failed - Sweep() unexpected orders %+v", o.orders)
+	}
+
+	o.err = errors.New("throttled")
+	if r = s.Sweep(&e); len(r.OrderIDs) != 0 || len(r.Skipped) != 2 {
+		t.Errorf("Test Failed - Sweep() expected rejected orders skipped %+v", r)
+	}
+
+	e.balances = e.balances[:1]
+	if r = s.Sweep(&e); r.Err != ErrNoDust {
+		t.Error("Test Failed - Sweep() expected no dust error", r.Err)
+	}
+}
@@ -1,9 +1,12 @@
 package main
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"log"
 	"sync"
+	"time"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
@@ -17,6 +20,8 @@ import (
 	"github.com/thrasher-/gocryptotrader/exchanges/bittrex"
 	"github.com/thrasher-/gocryptotrader/exchanges/btcc"
 	"github.com/thrasher-/gocryptotrader/exchanges/btcmarkets"
+	"github.com/thrasher-/gocryptotrader/exchanges/bybit"
+	"github.com/thrasher-/gocryptotrader/exchanges/coinbase"
 	"github.com/thrasher-/gocryptotrader/exchanges/coinbasepro"
 	"github.com/thrasher-/gocryptotrader/exchanges/coinut"
 	"github.com/thrasher-/gocryptotrader/exchanges/exmo"
@@ -27,12 +32,16 @@ import (
 	"github.com/thrasher-/gocryptotrader/exchanges/huobihadax"
 	"github.com/thrasher-/gocryptotrader/exchanges/itbit"
 	"github.com/thrasher-/gocryptotrader/exchanges/kraken"
+	"github.com/thrasher-/gocryptotrader/exchanges/kucoin"
 	"github.com/thrasher-/gocryptotrader/exchanges/lakebtc"
 	"github.com/thrasher-/gocryptotrader/exchanges/liqui"
 	"github.com/thrasher-/gocryptotrader/exchanges/localbitcoins"
+	"github.com/thrasher-/gocryptotrader/exchanges/mexc"
 	"github.com/thrasher-/gocryptotrader/exchanges/okcoin"
 	"github.com/thrasher-/gocryptotrader/exchanges/okex"
+	"github.com/thrasher-/gocryptotrader/exchanges/okx"
 	"github.com/thrasher-/gocryptotrader/exchanges/poloniex"
+	"github.com/thrasher-/gocryptotrader/exchanges/simulator"
 	"github.com/thrasher-/gocryptotrader/exchanges/wex"
 	"github.com/thrasher-/gocryptotrader/exchanges/yobit"
 	"github.com/thrasher-/gocryptotrader/exchanges/zb"
@@ -40,12 +49,55 @@ import (
 
 // vars related to exchange functions
 var (
-	ErrNoExchangesLoaded     = errors.New("no exchanges have been loaded")
-	ErrExchangeNotFound      = errors.New("exchange not found")
-	ErrExchangeAlreadyLoaded = errors.New("exchange already loaded")
-	ErrExchangeFailedToLoad  = errors.New("exchange failed to load")
+	ErrNoExchangesLoaded      = errors.New("no exchanges have been loaded")
+	ErrExchangeNotFound       = errors.New("exchange not found")
+	ErrExchangeAlreadyLoaded  = errors.New("exchange already loaded")
+	ErrExchangeFailedToLoad   = errors.New("exchange failed to load")
+	ErrExchangeAlreadyStarted = errors.New("exchange already started")
+	ErrExchangeAlreadyStopped = errors.New("exchange already stopped")
 )
 
+// Exchange wrapper startup supervision, a failed startup is retried with a
+// doubling delay before the exchange is disabled
+const (
+	exchangeStartTimeout = time.Minute
+	exchangeStartRetries = 3
+)
+
+var exchangeStartRetryDelay = 5 * time.Second
+
+// exchangeWatchdogInterval is how often the watchdog checks the wrappers of
+// enabled exchanges are running
+const exchangeWatchdogInterval = time.Minute
+
+// watchdogRestarts holds when the watchdog last restarted each exchange and
+// watchdogRestarting the exchanges it is restarting
+var (
+	watchdogRestarts   = make(map[string]time.Time)
+	watchdogRestarting = make(map[string]bool)
+	watchdogRestartsM  sync.Mutex
+)
+
+// exchangeLocks guard each exchange against being set up again by a restart
+// while the polling routines are using it
+var (
+	exchangeLocks  = make(map[string]*sync.RWMutex)
+	exchangeLocksM sync.Mutex
+)
+
+// exchangeLock returns the lock guarding an exchange, polling routines hold
+// it for reading and RestartExchange for writing while it sets the exchange up
+func exchangeLock(name string) *sync.RWMutex {
+	exchangeLocksM.Lock()
+	defer exchangeLocksM.Unlock()
+	l, ok := exchangeLocks[name]
+	if !ok {
+		l = new(sync.RWMutex)
+		exchangeLocks[name] = l
+	}
+	return l
+}
+
 // CheckExchangeExists returns true whether or not an exchange has already
 // been loaded
 func CheckExchangeExists(exchName string) bool {
@@ -115,7 +167,7 @@ func UnloadExchange(name string) error {
 
 	for x := range bot.exchanges {
 		if bot.exchanges[x].GetName() == name {
-			bot.exchanges[x].SetEnabled(false)
+			stopExchange(bot.exchanges[x])
 			bot.exchanges = append(bot.exchanges[:x], bot.exchanges[x+1:]...)
 			return nil
 		}
@@ -124,6 +176,206 @@ func UnloadExchange(name string) error {
 	return ErrExchangeNotFound
 }
 
+// StopExchange stops a loaded exchange's REST polling, websocket and order
+// manager participation without unloading it, unlike UnloadExchange its config
+// is left enabled so it is started again on the next run
+func StopExchange(name string) error {
+	exch := GetExchangeByName(name)
+	if exch == nil {
+		return ErrExchangeNotFound
+	}
+
+	if !exch.IsEnabled() {
+		return ErrExchangeAlreadyStopped
+	}
+
+	err := stopExchange(exch)
+	if err != nil {
+		return err
+	}
+	log.Printf("%s exchange stopped.\n", exch.GetName())
+	return nil
+}
+
+// StartExchange starts a stopped exchange, starting its wrapper to refresh its
+// currency pairs before reconnecting its websocket
+func StartExchange(name string) error {
+	exch := GetExchangeByName(name)
+	if exch == nil {
+		return ErrExchangeNotFound
+	}
+
+	if exch.IsEnabled() {
+		return ErrExchangeAlreadyStarted
+	}
+
+	err := startExchange(exch)
+	if err != nil {
+		return err
+	}
+	log.Printf("%s exchange started.\n", exch.GetName())
+	return nil
+}
+
+// RestartExchange stops an exchange, reloads its config and starts it again.
+// The exchange's polling routines and websocket are stopped before it is set
+// up, and it stays disabled until it has been set up.
+func RestartExchange(name string) error {
+	exch := GetExchangeByName(name)
+	if exch == nil {
+		return ErrExchangeNotFound
+	}
+
+	exchCfg, err := bot.config.GetExchangeConfig(exch.GetName())
+	if err != nil {
+		return err
+	}
+
+	l := exchangeLock(exch.GetName())
+	l.Lock()
+	if exch.IsEnabled() {
+		err = stopExchange(exch)
+		if err != nil {
+			l.Unlock()
+			return err
+		}
+	}
+	exch.Setup(exchCfg)
+	exch.SetEnabled(false)
+	l.Unlock()
+
+	err = startExchange(exch)
+	if err != nil {
+		return err
+	}
+	log.Printf("%s exchange restarted.\n", exch.GetName())
+	return nil
+}
+
+// stopExchange disables an exchange, which the polling routines and order
+// manager skip, and stops its wrapper, shutting down its websocket
+func stopExchange(exch exchange.IBotExchange) error {
+	exch.SetEnabled(false)
+
+	err := exch.Stop()
+	if err != nil && err != exchange.ErrWrapperNotStarted {
+		return err
+	}
+	return nil
+}
+
+// startExchange enables an exchange, starts its wrapper and connects its
+// websocket
+func startExchange(exch exchange.IBotExchange) error {
+	exch.SetEnabled(true)
+
+	err := superviseStart(exch)
+	if err != nil {
+		return err
+	}
+
+	go StartWebsocket(exch, bot.verbose)
+	return nil
+}
+
+// superviseStart starts an exchange's wrapper, retrying a failed startup with
+// a doubling delay. Each attempt is cancelled once it times out and the
+// wrapper waits for it to return before the next attempt runs. The exchange is
+// disabled when every attempt fails.
+func superviseStart(exch exchange.IBotExchange) error {
+	delay := exchangeStartRetryDelay
+	for attempt := 0; ; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), exchangeStartTimeout)
+		err := exch.Start(ctx)
+		cancel()
+		if err == nil || err == exchange.ErrWrapperAlreadyStarted {
+			return nil
+		}
+
+		if attempt == exchangeStartRetries {
+			exch.SetEnabled(false)
+			log.Printf("%s failed to start, exchange disabled. Err: %s\n",
+				exch.GetName(), err)
+			return err
+		}
+		log.Printf("%s failed to start, retrying in %s. Err: %s\n",
+			exch.GetName(), delay, err)
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+// ExchangeWatchdogRoutine restarts the wrappers of enabled exchanges which
+// have stopped running or stay degraded, every interval until the bot shuts
+// down. Each exchange is watched in its own goroutine so one exchange's
+// startup retries do not hold up the others.
+func ExchangeWatchdogRoutine(interval time.Duration) {
+	log.Println("Starting exchange watchdog routine.")
+	for {
+		time.Sleep(interval)
+		for _, exch := range bot.exchanges {
+			go watchExchange(exch, interval, time.Now())
+		}
+	}
+}
+
+// watchExchange restarts an enabled exchange whose wrapper is not running, or
+// which has been degraded by failed health checks for longer than interval
+// without a restart, and returns whether it was restarted. An exchange which
+// is still being restarted is skipped.
+func watchExchange(exch exchange.IBotExchange, interval time.Duration, now time.Time) bool {
+	if exch == nil || !exch.IsEnabled() {
+		return false
+	}
+
+	name := exch.GetName()
+	watchdogRestartsM.Lock()
+	lastRestart := watchdogRestarts[name]
+	restarting := watchdogRestarting[name]
+	watchdogRestartsM.Unlock()
+	if restarting {
+		return false
+	}
+
+	var reason string
+	if !exch.IsStarted() {
+		reason = "wrapper not running"
+	} else if bot.health != nil {
+		s, ok := bot.health.Status(name)
+		if ok && s.Degraded && now.Sub(s.DegradedSince) > interval &&
+			lastRestart.Before(s.DegradedSince) {
+			reason = fmt.Sprintf("degraded since %s", s.DegradedSince.Format(time.RFC3339))
+		}
+	}
+	if reason == "" {
+		return false
+	}
+
+	watchdogRestartsM.Lock()
+	if watchdogRestarting[name] {
+		watchdogRestartsM.Unlock()
+		return false
+	}
+	watchdogRestarts[name] = now
+	watchdogRestarting[name] = true
+	watchdogRestartsM.Unlock()
+	defer func() {
+		watchdogRestartsM.Lock()
+		delete(watchdogRestarting, name)
+		watchdogRestartsM.Unlock()
+	}()
+
+	log.Printf("%s watchdog restarting exchange, %s.\n", name, reason)
+	err := stopExchange(exch)
+	if err == nil {
+		err = startExchange(exch)
+	}
+	if err != nil {
+		log.Printf("%s watchdog failed to restart exchange. Err: %s\n", name, err)
+	}
+	return true
+}
+
 // LoadExchange loads an exchange by name
 func LoadExchange(name string, useWG bool, wg *sync.WaitGroup) error {
 	nameLower := common.StringToLower(name)
@@ -156,10 +408,14 @@ func LoadExchange(name string, useWG bool, wg *sync.WaitGroup) error {
 		exch = new(btcc.BTCC)
 	case "btc markets":
 		exch = new(btcmarkets.BTCMarkets)
+	case "bybit":
+		exch = new(bybit.Bybit)
 	case "coinut":
 		exch = new(coinut.COINUT)
 	case "exmo":
 		exch = new(exmo.EXMO)
+	case "coinbase":
+		exch = new(coinbase.Coinbase)
 	case "coinbasepro":
 		exch = new(coinbasepro.CoinbasePro)
 	case "gateio":
@@ -176,20 +432,28 @@ func LoadExchange(name string, useWG bool, wg *sync.WaitGroup) error {
 		exch = new(itbit.ItBit)
 	case "kraken":
 		exch = new(kraken.Kraken)
+	case "kucoin":
+		exch = new(kucoin.KuCoin)
 	case "lakebtc":
 		exch = new(lakebtc.LakeBTC)
 	case "liqui":
 		exch = new(liqui.Liqui)
 	case "localbitcoins":
 		exch = new(localbitcoins.LocalBitcoins)
+	case "mexc":
+		exch = new(mexc.MEXC)
 	case "okcoin china":
 		exch = new(okcoin.OKCoin)
 	case "okcoin international":
 		exch = new(okcoin.OKCoin)
 	case "okex":
 		exch = new(okex.OKEX)
+	case "okx":
+		exch = new(okx.OKX)
 	case "poloniex":
 		exch = new(poloniex.Poloniex)
+	case "simulator":
+		exch = new(simulator.Simulator)
 	case "wex":
 		exch = new(wex.WEX)
 	case "yobit":
@@ -213,15 +477,20 @@ func LoadExchange(name string, useWG bool, wg *sync.WaitGroup) error {
 
 	exchCfg.Enabled = true
 	exch.Setup(exchCfg)
+	if exchCfg.BrokerTag != "" && !exch.SupportsBrokerTag() {
+		log.Printf("%s does not support broker tags, brokerTag %s ignored.\n",
+			exch.GetName(), exchCfg.BrokerTag)
+	}
 
 	if useWG {
-		exch.Start(wg)
-	} else {
-		wg := sync.WaitGroup{}
-		exch.Start(&wg)
-		wg.Wait()
+		wg.Add(1)
+		go func() {
+			superviseStart(exch)
+			wg.Done()
+		}()
+		return nil
 	}
-	return nil
+	return superviseStart(exch)
 }
 
 // SetupExchanges sets up the exchanges used by the bot
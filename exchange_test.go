@@ -1,9 +1,14 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"testing"
+	"time"
 
+	"github.com/thrasher-/gocryptotrader/availability"
 	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/exchanges"
 )
 
 var testSetup = false
@@ -131,6 +136,192 @@ func TestUnloadExchange(t *testing.T) {
 	CleanupTest(t)
 }
 
+// testLifecycleExchange counts its wrapper starts and stops, failing the
+// first fail starts, and records the state it was set up in
+type testLifecycleExchange struct {
+	exchange.IBotExchange
+	enabled      bool
+	started      bool
+	starts       int
+	stops        int
+	fail         int
+	setups       int
+	setupRunning bool
+	setupLocked  bool
+}
+
+func (e *testLifecycleExchange) Setup(exch config.ExchangeConfig) {
+	e.setups++
+	e.setupRunning = e.started
+	l := exchangeLock(e.GetName())
+	e.setupLocked = !l.TryRLock()
+	if !e.setupLocked {
+		l.RUnlock()
+	}
+	e.enabled = exch.Enabled
+}
+
+func (e *testLifecycleExchange) GetName() string {
+	return "LifecycleTest"
+}
+
+func (e *testLifecycleExchange) IsEnabled() bool {
+	return e.enabled
+}
+
+func (e *testLifecycleExchange) SetEnabled(enabled bool) {
+	e.enabled = enabled
+}
+
+func (e *testLifecycleExchange) Start(ctx context.Context) error {
+	e.starts++
+	if e.fail > 0 {
+		e.fail--
+		return errors.New("exchange unavailable")
+	}
+	e.started = true
+	return nil
+}
+
+func (e *testLifecycleExchange) IsStarted() bool {
+	return e.started
+}
+
+func (e *testLifecycleExchange) Stop() error {
+	if !e.started {
+		return exchange.ErrWrapperNotStarted
+	}
+	e.stops++
+	e.started = false
+	return nil
+}
+
+func (e *testLifecycleExchange) GetWebsocket() (*exchange.Websocket, error) {
+	return nil, errors.New("websocket not supported")
+}
+
+func TestStartStopExchange(t *testing.T) {
+	exch := &testLifecycleExchange{enabled: true}
+	bot.exchanges = append(bot.exchanges, exch)
+	defer func() {
+		bot.exchanges = bot.exchanges[:len(bot.exchanges)-1]
+	}()
+
+	if err := StartExchange("lifecycletest"); err != ErrExchangeAlreadyStarted {
+		t.Error("Test failed. StartExchange: Expected already started error", err)
+	}
+	if err := StopExchange("lifecycletest"); err != nil || exch.enabled {
+		t.Error("Test failed. StopExchange: Exchange not stopped", err)
+	}
+	if err := StopExchange("LifecycleTest"); err != ErrExchangeAlreadyStopped {
+		t.Error("Test failed. StopExchange: Expected already stopped error", err)
+	}
+	if err := StartExchange("LifecycleTest"); err != nil || !exch.enabled || exch.starts != 1 {
+		t.Error("Test failed. StartExchange: Exchange not started", err, exch.starts)
+	}
+	if exch.stops != 0 {
+		t.Error("Test failed. StopExchange: Stopped a wrapper which never started", exch.stops)
+	}
+	if err := StopExchange("LifecycleTest"); err != nil || exch.started || exch.stops != 1 {
+		t.Error("Test failed. StopExchange: Wrapper not stopped", err, exch.stops)
+	}
+	if err := StartExchange("asdf"); err != ErrExchangeNotFound {
+		t.Error("Test failed. StartExchange: Incorrect result", err)
+	}
+	if err := RestartExchange("asdf"); err != ErrExchangeNotFound {
+		t.Error("Test failed. RestartExchange: Incorrect result", err)
+	}
+}
+
+func TestRestartExchange(t *testing.T) {
+	SetupTest(t)
+	exch := &testLifecycleExchange{enabled: true, started: true}
+	bot.exchanges = append(bot.exchanges, exch)
+	bot.config.Exchanges = append(bot.config.Exchanges,
+		config.ExchangeConfig{Name: exch.GetName(), Enabled: true})
+	defer func() {
+		bot.exchanges = bot.exchanges[:len(bot.exchanges)-1]
+		bot.config.Exchanges = bot.config.Exchanges[:len(bot.config.Exchanges)-1]
+	}()
+
+	if err := RestartExchange(exch.GetName()); err != nil {
+		t.Fatal("Test failed. RestartExchange: Error", err)
+	}
+	if exch.setups != 1 || exch.stops != 1 || exch.starts != 1 || !exch.started || !exch.enabled {
+		t.Error("Test failed. RestartExchange: Exchange not restarted",
+			exch.setups, exch.stops, exch.starts)
+	}
+	if exch.setupRunning || !exch.setupLocked {
+		t.Error("Test failed. RestartExchange: Exchange set up while in use")
+	}
+}
+
+func TestSuperviseStart(t *testing.T) {
+	delay := exchangeStartRetryDelay
+	exchangeStartRetryDelay = time.Millisecond
+	defer func() {
+		exchangeStartRetryDelay = delay
+	}()
+
+	exch := &testLifecycleExchange{enabled: true, fail: exchangeStartRetries}
+	if err := superviseStart(exch); err != nil || !exch.started || exch.starts != exchangeStartRetries+1 {
+		t.Error("Test failed. superviseStart: Expected start after retries", err, exch.starts)
+	}
+
+	exch = &testLifecycleExchange{enabled: true, fail: exchangeStartRetries + 1}
+	if err := superviseStart(exch); err == nil || exch.enabled {
+		t.Error("Test failed. superviseStart: Expected exchange disabled after failed retries", err)
+	}
+}
+
+func TestWatchExchange(t *testing.T) {
+	health := bot.health
+	bot.health = availability.NewMonitor(1)
+	defer func() {
+		bot.health = health
+	}()
+	now := time.Now()
+
+	exch := &testLifecycleExchange{enabled: true, started: true}
+	if watchExchange(exch, time.Minute, now) {
+		t.Error("Test failed. watchExchange: Restarted a running exchange")
+	}
+	exch.started = false
+	if !watchExchange(exch, time.Minute, now) || !exch.started || exch.starts != 1 {
+		t.Error("Test failed. watchExchange: Expected stopped wrapper restarted", exch.starts)
+	}
+
+	degraded := now.Add(time.Second)
+	bot.health.Update(availability.Check{Exchange: exch.GetName(), Time: degraded, Error: "timeout"})
+	if watchExchange(exch, time.Minute, degraded.Add(time.Second)) {
+		t.Error("Test failed. watchExchange: Restarted a newly degraded exchange")
+	}
+	later := now.Add(time.Minute * 2)
+	if !watchExchange(exch, time.Minute, later) || exch.stops != 1 || exch.starts != 2 {
+		t.Error("Test failed. watchExchange: Expected degraded exchange restarted", exch.stops, exch.starts)
+	}
+	if watchExchange(exch, time.Minute, later.Add(time.Minute)) {
+		t.Error("Test failed. watchExchange: Restarted a degraded exchange twice")
+	}
+
+	exch.started = false
+	watchdogRestartsM.Lock()
+	watchdogRestarting[exch.GetName()] = true
+	watchdogRestartsM.Unlock()
+	if watchExchange(exch, time.Minute, later) {
+		t.Error("Test failed. watchExchange: Restarted an exchange already restarting")
+	}
+	watchdogRestartsM.Lock()
+	delete(watchdogRestarting, exch.GetName())
+	watchdogRestartsM.Unlock()
+
+	exch.enabled = false
+	exch.started = false
+	if watchExchange(exch, time.Minute, later) {
+		t.Error("Test failed. watchExchange: Restarted a disabled exchange")
+	}
+}
+
 func TestSetupExchanges(t *testing.T) {
 	SetupTest(t)
 	SetupExchanges()
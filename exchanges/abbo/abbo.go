@@ -0,0 +1,212 @@
+// Package abbo computes the aggregated best bid and offer (ABBO) for each
+// currency pair across all exchanges which report a ticker for it. Pairs are
+// normalised to a canonical form so the same market on different exchanges,
+// e.g. XBT-USD and BTC-USD, aggregates into a single quote.
+package abbo
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+// Exchange is the name the aggregated ticker is published under in the
+// ticker package
+const Exchange = "ABBO"
+
+// DefaultMaxAge is the default period an exchange quote contributes to the
+// ABBO before it is considered stale
+const DefaultMaxAge = time.Minute
+
+// Errors returned by the ABBO book
+var (
+	ErrNoQuotes     = errors.New("no current quotes for currency pair and asset type")
+	ErrInvalidQuote = errors.New("quote must contain a bid or ask")
+)
+
+// aliases maps exchange specific currency codes to their canonical code.
+// USDT is deliberately not mapped to USD as they trade at different prices.
+var aliases = map[pair.CurrencyItem]pair.CurrencyItem{
+	"XBT":  "BTC",
+	"XETH": "ETH",
+	"XDG":  "DOGE",
+}
+
+// Item holds the aggregated best bid and offer for a currency pair along
+// with the exchange each side came from
+type Item struct {
+	Pair        pair.CurrencyPair
+	AssetType   string
+	Bid         float64
+	BidExchange string
+	Ask         float64
+	AskExchange string
+	Exchanges   int
+	LastUpdated time.Time
+}
+
+// Crossed returns whether the best bid is at or above the best ask, which
+// only occurs when the best bid and ask are on different exchanges
+func (i Item) Crossed() bool {
+	return i.Bid > 0 && i.Ask > 0 && i.Bid >= i.Ask
+}
+
+// quote holds an individual exchange's best bid and ask
+type quote struct {
+	bid     float64
+	ask     float64
+	updated time.Time
+}
+
+// Book stores the latest quote from each exchange per canonical currency
+// pair and asset type
+type Book struct {
+	MaxAge time.Duration
+	quotes map[string]map[string]map[string]quote
+	m      sync.Mutex
+}
+
+// Default is the shared ABBO book used by the bot
+var Default = NewBook(DefaultMaxAge)
+
+// NewBook returns a new ABBO book with the supplied maximum quote age
+func NewBook(maxAge time.Duration) *Book {
+	if maxAge <= 0 {
+		maxAge = DefaultMaxAge
+	}
+	return &Book{
+		MaxAge: maxAge,
+		quotes: make(map[string]map[string]map[string]quote),
+	}
+}
+
+// CanonicalPair returns the pair in its canonical form, upper case with a
+// "-" delimiter and exchange specific currency codes replaced
+func CanonicalPair(p pair.CurrencyPair) pair.CurrencyPair {
+	first := p.FirstCurrency.Upper()
+	second := p.SecondCurrency.Upper()
+	if alias, ok := aliases[first]; ok {
+		first = alias
+	}
+	if alias, ok := aliases[second]; ok {
+		second = alias
+	}
+	return pair.NewCurrencyPairDelimiter(first.String()+"-"+second.String(), "-")
+}
+
+// Update stores an exchange's best bid and ask and publishes the resulting
+// ABBO as a ticker. A zero bid or ask means that side is unavailable on the
+// exchange.
+func (b *Book) Update(exchName string, p pair.CurrencyPair, assetType string, bid, ask float64) (Item, error) {
+	if bid <= 0 && ask <= 0 {
+		return Item{}, ErrInvalidQuote
+	}
+
+	c := CanonicalPair(p)
+	key := c.Pair().String()
+
+	b.m.Lock()
+	assets, ok := b.quotes[key]
+	if !ok {
+		assets = make(map[string]map[string]quote)
+		b.quotes[key] = assets
+	}
+	exchanges, ok := assets[assetType]
+	if !ok {
+		exchanges = make(map[string]quote)
+		assets[assetType] = exchanges
+	}
+	exchanges[exchName] = quote{bid: bid, ask: ask, updated: time.Now()}
+	item, err := b.compute(c, assetType)
+	b.m.Unlock()
+	if err != nil {
+		return item, err
+	}
+
+	ticker.ProcessTicker(Exchange, c, ticker.Price{
+		Pair: c,
+		Bid:  item.Bid,
+		Ask:  item.Ask,
+	}, assetType)
+	return item, nil
+}
+
+// Remove removes all quotes for an exchange, for example when it is disabled
+func (b *Book) Remove(exchName string) {
+	b.m.Lock()
+	defer b.m.Unlock()
+	for _, assets := range b.quotes {
+		for _, exchanges := range assets {
+			delete(exchanges, exchName)
+		}
+	}
+}
+
+// Get returns the current ABBO for a currency pair and asset type
+func (b *Book) Get(p pair.CurrencyPair, assetType string) (Item, error) {
+	b.m.Lock()
+	defer b.m.Unlock()
+	return b.compute(CanonicalPair(p), assetType)
+}
+
+// MarketablePrice returns the price and exchange at which an order on the
+// supplied side would execute immediately, the best ask for buys and the best
+// bid for sells
+func (b *Book) MarketablePrice(p pair.CurrencyPair, assetType string, side exchange.OrderSide) (float64, string, error) {
+	item, err := b.Get(p, assetType)
+	if err != nil {
+		return 0, "", err
+	}
+
+	switch side {
+	case exchange.Buy:
+		if item.Ask == 0 {
+			return 0, "", fmt.Errorf("no ask available for %s", item.Pair.Pair())
+		}
+		return item.Ask, item.AskExchange, nil
+	case exchange.Sell:
+		if item.Bid == 0 {
+			return 0, "", fmt.Errorf("no bid available for %s", item.Pair.Pair())
+		}
+		return item.Bid, item.BidExchange, nil
+	default:
+		return 0, "", fmt.Errorf("unsupported order side %s", side)
+	}
+}
+
+// compute calculates the ABBO from quotes that are within the maximum age.
+// The mutex must be held by the caller.
+func (b *Book) compute(c pair.CurrencyPair, assetType string) (Item, error) {
+	item := Item{Pair: c, AssetType: assetType}
+	exchanges := b.quotes[c.Pair().String()][assetType]
+	cutoff := time.Now().Add(-b.MaxAge)
+
+	for exchName, q := range exchanges {
+		if q.updated.Before(cutoff) {
+			continue
+		}
+
+		item.Exchanges++
+		if q.updated.After(item.LastUpdated) {
+			item.LastUpdated = q.updated
+		}
+		if q.bid > 0 && (q.bid > item.Bid || (q.bid == item.Bid && exchName < item.BidExchange)) {
+			item.Bid = q.bid
+			item.BidExchange = exchName
+		}
+		if q.ask > 0 && (item.Ask == 0 || q.ask < item.Ask || (q.ask == item.Ask && exchName < item.AskExchange)) {
+			item.Ask = q.ask
+			item.AskExchange = exchName
+		}
+	}
+
+	if item.Exchanges == 0 {
+		return item, ErrNoQuotes
+	}
+	return item, nil
+}
@@ -0,0 +1,141 @@
+package abbo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+func TestCanonicalPair(t *testing.T) {
+	c := CanonicalPair(pair.NewCurrencyPairDelimiter("xbt_usd", "_"))
+	if c.Pair().String() != "BTC-USD" {
+		t.Errorf("Test Failed - CanonicalPair() expected BTC-USD, received %s",
+			c.Pair())
+	}
+
+	c = CanonicalPair(pair.NewCurrencyPair("ETH", "USDT"))
+	if c.Pair().String() != "ETH-USDT" {
+		t.Errorf("Test Failed - CanonicalPair() expected ETH-USDT, received %s",
+			c.Pair())
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	b := NewBook(time.Minute)
+
+	_, err := b.Update("Kraken", pair.NewCurrencyPair("XBT", "USD"), ticker.Spot, 0, 0)
+	if err != ErrInvalidQuote {
+		t.Error("Test Failed - Update() expected invalid quote error", err)
+	}
+
+	_, err = b.Update("Kraken", pair.NewCurrencyPair("XBT", "USD"), ticker.Spot, 100, 102)
+	if err != nil {
+		t.Fatal("Test Failed - Update() error", err)
+	}
+	_, err = b.Update("Bitstamp", pair.NewCurrencyPair("BTC", "USD"), ticker.Spot, 101, 103)
+	if err != nil {
+		t.Fatal("Test Failed - Update() error", err)
+	}
+	item, err := b.Update("Gemini", pair.NewCurrencyPair("btc", "usd"), ticker.Spot, 0, 101.5)
+	if err != nil {
+		t.Fatal("Test Failed - Update() error", err)
+	}
+
+	if item.Bid != 101 || item.BidExchange != "Bitstamp" ||
+		item.Ask != 101.5 || item.AskExchange != "Gemini" || item.Exchanges != 3 {
+		t.Error("Test Failed - Update() incorrect ABBO", item)
+	}
+
+	tick, err := ticker.GetTicker(Exchange, pair.NewCurrencyPair("BTC", "USD"), ticker.Spot)
+	if err != nil {
+		t.Fatal("Test Failed - Update() ticker not published", err)
+	}
+	if tick.Bid != 101 || tick.Ask != 101.5 {
+		t.Error("Test Failed - Update() incorrect published ticker", tick)
+	}
+}
+
+func TestGetStaleQuotes(t *testing.T) {
+	b := NewBook(time.Minute)
+	p := pair.NewCurrencyPair("LTC", "USD")
+
+	_, err := b.Get(p, ticker.Spot)
+	if err != ErrNoQuotes {
+		t.Error("Test Failed - Get() expected no quotes error", err)
+	}
+
+	_, err = b.Update("Kraken", p, ticker.Spot, 50, 51)
+	if err != nil {
+		t.Fatal("Test Failed - Update() error", err)
+	}
+	_, err = b.Update("Bitstamp", p, ticker.Spot, 52, 53)
+	if err != nil {
+		t.Fatal("Test Failed - Update() error", err)
+	}
+
+	b.m.Lock()
+	q := b.quotes["LTC-USD"][ticker.Spot]["Bitstamp"]
+	q.updated = time.Now().Add(-time.Hour)
+	b.quotes["LTC-USD"][ticker.Spot]["Bitstamp"] = q
+	b.m.Unlock()
+
+	item, err := b.Get(p, ticker.Spot)
+	if err != nil {
+		t.Fatal("Test Failed - Get() error", err)
+	}
+	if item.Bid != 50 || item.BidExchange != "Kraken" || item.Exchanges != 1 {
+		t.Error("Test Failed - Get() stale quote was not excluded", item)
+	}
+
+	b.Remove("Kraken")
+	_, err = b.Get(p, ticker.Spot)
+	if err != ErrNoQuotes {
+		t.Error("Test Failed - Remove() expected no quotes error", err)
+	}
+}
+
+func TestMarketablePrice(t *testing.T) {
+	b := NewBook(time.Minute)
+	p := pair.NewCurrencyPair("ETH", "BTC")
+
+	_, err := b.Update("Binance", p, ticker.Spot, 0.05, 0.051)
+	if err != nil {
+		t.Fatal("Test Failed - Update() error", err)
+	}
+	_, err = b.Update("Bittrex", p, ticker.Spot, 0.0505, 0)
+	if err != nil {
+		t.Fatal("Test Failed - Update() error", err)
+	}
+
+	price, exch, err := b.MarketablePrice(p, ticker.Spot, exchange.Buy)
+	if err != nil || price != 0.051 || exch != "Binance" {
+		t.Errorf("Test Failed - MarketablePrice() buy expected 0.051 Binance, received %f %s %v",
+			price, exch, err)
+	}
+
+	price, exch, err = b.MarketablePrice(p, ticker.Spot, exchange.Sell)
+	if err != nil || price != 0.0505 || exch != "Bittrex" {
+		t.Errorf("Test Failed - MarketablePrice() sell expected 0.0505 Bittrex, received %f %s %v",
+			price, exch, err)
+	}
+
+	_, _, err = b.MarketablePrice(p, ticker.Spot, exchange.OrderSide("SHORT"))
+	if err == nil {
+		t.Error("Test Failed - MarketablePrice() expected unsupported side error")
+	}
+}
+
+func TestCrossed(t *testing.T) {
+	if (Item{Bid: 100, Ask: 101}).Crossed() {
+		t.Error("Test Failed - Crossed() expected false")
+	}
+	if !(Item{Bid: 101, Ask: 100}).Crossed() {
+		t.Error("Test Failed - Crossed() expected true")
+	}
+	if (Item{Bid: 101}).Crossed() {
+		t.Error("Test Failed - Crossed() expected false without an ask")
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/gorilla/websocket"
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/assets"
 	"github.com/thrasher-/gocryptotrader/exchanges/request"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
@@ -56,6 +57,22 @@ func (a *Alphapoint) SetDefaults() {
 	a.SupportsAutoPairUpdating = false
 	a.SupportsRESTTickerBatching = false
 	a.APIWithdrawPermissions = exchange.WithdrawCryptoWith2FA | exchange.AutoWithdrawCryptoWithAPIPermission
+	a.Features = exchange.Features{
+		CanGetTicker:         true,
+		CanGetOrderbook:      true,
+		CanGetAccountInfo:    true,
+		CanSubmitOrder:       true,
+		CanCancelOrder:       true,
+		CanCancelAllOrders:   true,
+		CanGetOrderInfo:      true,
+		CanGetDepositAddress: true,
+	}
+	a.Features.Orders = map[string]exchange.OrderCapabilities{
+		assets.Spot: {
+			OrderTypes:  []exchange.OrderType{exchange.Limit, exchange.Market},
+			TimeInForce: []exchange.TimeInForce{exchange.GTC},
+		},
+	}
 	a.Requester = request.New(a.Name,
 		request.NewRateLimit(time.Minute*10, alphapointAuthRate),
 		request.NewRateLimit(time.Minute*10, alphapointUnauthRate),
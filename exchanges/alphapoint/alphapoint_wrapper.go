@@ -109,6 +109,10 @@ func (a *Alphapoint) GetExchangeHistory(p pair.CurrencyPair, assetType string) (
 // SubmitOrder submits a new order and returns a true value when
 // successfully submitted
 func (a *Alphapoint) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	if a.IsSimulated() {
+		return a.SimulateSubmitOrder(p, side, orderType, amount, price, clientID)
+	}
+
 	var submitOrderResponse exchange.SubmitOrderResponse
 
 	response, err := a.CreateOrder(p.Pair().String(), side.ToString(), orderType.ToString(), amount, price)
@@ -126,11 +130,19 @@ func (a *Alphapoint) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, o
 // ModifyOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (a *Alphapoint) ModifyOrder(action exchange.ModifyOrder) (string, error) {
+	if a.IsSimulated() {
+		return a.SimulateModifyOrder(action)
+	}
+
 	return "", common.ErrNotYetImplemented
 }
 
 // CancelOrder cancels an order by its corresponding ID number
 func (a *Alphapoint) CancelOrder(order exchange.OrderCancellation) error {
+	if a.IsSimulated() {
+		return a.SimulateCancelOrder(order)
+	}
+
 	orderIDInt, err := strconv.ParseInt(order.OrderID, 10, 64)
 	if err != nil {
 		return err
@@ -143,6 +155,10 @@ func (a *Alphapoint) CancelOrder(order exchange.OrderCancellation) error {
 
 // CancelAllOrders cancels all orders for a given account
 func (a *Alphapoint) CancelAllOrders(orderCancellation exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
+	if a.IsSimulated() {
+		return a.SimulateCancelAllOrders(orderCancellation)
+	}
+
 	return exchange.CancelAllOrdersResponse{}, a.CancelAllExistingOrders(orderCancellation.AccountID)
 }
 
@@ -13,6 +13,7 @@ import (
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/config"
 	"github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/assets"
 	"github.com/thrasher-/gocryptotrader/exchanges/request"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
@@ -61,6 +62,20 @@ func (a *ANX) SetDefaults() {
 	a.ConfigCurrencyPairFormat.Index = ""
 	a.APIWithdrawPermissions = exchange.WithdrawCryptoWithEmail | exchange.AutoWithdrawCryptoWithSetup |
 		exchange.WithdrawCryptoWith2FA | exchange.WithdrawFiatViaWebsiteOnly
+	a.Features = exchange.Features{
+		CanGetTicker:       true,
+		CanGetOrderbook:    true,
+		CanGetAccountInfo:  true,
+		CanSubmitOrder:     true,
+		CanCancelOrder:     true,
+		CanCancelAllOrders: true,
+	}
+	a.Features.Orders = map[string]exchange.OrderCapabilities{
+		assets.Spot: {
+			OrderTypes:  []exchange.OrderType{exchange.Limit, exchange.Market},
+			TimeInForce: []exchange.TimeInForce{exchange.GTC},
+		},
+	}
 	a.AssetTypes = []string{ticker.Spot}
 	a.SupportsAutoPairUpdating = true
 	a.SupportsRESTTickerBatching = false
@@ -108,6 +123,27 @@ func (a *ANX) Setup(exch config.ExchangeConfig) {
 		if err != nil {
 			log.Fatal(err)
 		}
+		err = a.SetTLSPins(exch.TLSPins)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = a.SetLocalAddress(exch.LocalAddress)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = a.SetRoundingModes(exch.RoundingModes)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = a.SetRateLimitTiers(exch.RateLimitTiers, exch.AccountTier)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = a.SetHTTPClientHeaders(exch.HTTPHeaders)
+		if err != nil {
+			log.Fatal(err)
+		}
+		a.SetSimulation(exch.Simulate, exch.SimulationBalances)
 	}
 }
 
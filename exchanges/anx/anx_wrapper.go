@@ -1,9 +1,9 @@
 package anx
 
 import (
+	"context"
 	"log"
 	"strconv"
-	"sync"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
@@ -12,17 +12,13 @@ import (
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
 
-// Start starts the ANX go routine
-func (a *ANX) Start(wg *sync.WaitGroup) {
-	wg.Add(1)
-	go func() {
-		a.Run()
-		wg.Done()
-	}()
+// Start starts the ANX wrapper, refreshing its currency pairs
+func (a *ANX) Start(ctx context.Context) error {
+	return a.StartWrapper(ctx, a.Run)
 }
 
 // Run implements the ANX wrapper
-func (a *ANX) Run() {
+func (a *ANX) Run(ctx context.Context) error {
 	if a.Verbose {
 		log.Printf("%s polling delay: %ds.\n", a.GetName(), a.RESTPollingDelay)
 		log.Printf("%s %d currencies enabled: %s.\n", a.GetName(), len(a.EnabledPairs), a.EnabledPairs)
@@ -51,6 +47,7 @@ func (a *ANX) Run() {
 			log.Printf("%s Failed to get config.\n", a.GetName())
 		}
 	}
+	return nil
 }
 
 // GetTradablePairs returns a list of available
@@ -220,6 +217,10 @@ func (a *ANX) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]excha
 
 // SubmitOrder submits a new order
 func (a *ANX) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	if a.IsSimulated() {
+		return a.SimulateSubmitOrder(p, side, orderType, amount, price, clientID)
+	}
+
 	var submitOrderResponse exchange.SubmitOrderResponse
 
 	var isBuying bool
@@ -258,11 +259,19 @@ func (a *ANX) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderTyp
 // ModifyOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (a *ANX) ModifyOrder(action exchange.ModifyOrder) (string, error) {
+	if a.IsSimulated() {
+		return a.SimulateModifyOrder(action)
+	}
+
 	return "", common.ErrFunctionNotSupported
 }
 
 // CancelOrder cancels an order by its corresponding ID number
 func (a *ANX) CancelOrder(order exchange.OrderCancellation) error {
+	if a.IsSimulated() {
+		return a.SimulateCancelOrder(order)
+	}
+
 	orderIDs := []string{order.OrderID}
 	_, err := a.CancelOrderByIDs(orderIDs)
 	return err
@@ -270,6 +279,10 @@ func (a *ANX) CancelOrder(order exchange.OrderCancellation) error {
 
 // CancelAllOrders cancels all orders associated with a currency pair
 func (a *ANX) CancelAllOrders(orderCancellation exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
+	if a.IsSimulated() {
+		return a.SimulateCancelAllOrders(orderCancellation)
+	}
+
 	cancelAllOrdersResponse := exchange.CancelAllOrdersResponse{
 		OrderStatus: make(map[string]string),
 	}
@@ -299,6 +312,10 @@ func (a *ANX) CancelAllOrders(orderCancellation exchange.OrderCancellation) (exc
 
 // GetOrderInfo returns information on a current open order
 func (a *ANX) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
+	if a.IsSimulated() {
+		return a.SimulateGetOrderInfo(orderID)
+	}
+
 	var orderDetail exchange.OrderDetail
 	return orderDetail, common.ErrNotYetImplemented
 }
@@ -0,0 +1,57 @@
+// Package assets defines the asset types exchanges trade, each of which has
+// its own available and enabled currency pairs
+package assets
+
+import (
+	"errors"
+	"strings"
+)
+
+// Asset types, spot matches ticker.Spot and orderbook.Spot
+const (
+	Spot          = "SPOT"
+	Margin        = "MARGIN"
+	Futures       = "FUTURES"
+	PerpetualSwap = "PERPETUAL_SWAP"
+	Index         = "INDEX"
+)
+
+// ErrUnsupportedAsset is returned when an asset type is not supported
+var ErrUnsupportedAsset = errors.New("unsupported asset type")
+
+// Supported are the first class asset types
+var Supported = []string{Spot, Margin, Futures, PerpetualSwap, Index}
+
+// aliases maps the asset type names used by older configs and exchange APIs
+// to their asset type
+var aliases = map[string]string{
+	"PERPETUAL":  PerpetualSwap,
+	"PERPETUALS": PerpetualSwap,
+	"PERP":       PerpetualSwap,
+	"SWAP":       PerpetualSwap,
+	"FUTURE":     Futures,
+	"LENDING":    Margin,
+}
+
+// IsSupported returns whether an asset type is a first class asset type
+func IsSupported(assetType string) bool {
+	for x := range Supported {
+		if Supported[x] == assetType {
+			return true
+		}
+	}
+	return false
+}
+
+// Normalise returns the asset type of a name in any case or an alias of it
+func Normalise(name string) (string, error) {
+	name = strings.ToUpper(strings.TrimSpace(name))
+	name = strings.Replace(name, "-", "_", -1)
+	if a, ok := aliases[name]; ok {
+		return a, nil
+	}
+	if IsSupported(name) {
+		return name, nil
+	}
+	return "", ErrUnsupportedAsset
+}
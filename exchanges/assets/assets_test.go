@@ -0,0 +1,28 @@
+package assets
+
+import "testing"
+
+func TestNormalise(t *testing.T) {
+	tester := map[string]string{
+		"spot":           Spot,
+		" Margin ":       Margin,
+		"perpetual-swap": PerpetualSwap,
+		"SWAP":           PerpetualSwap,
+		"future":         Futures,
+		"index":          Index,
+	}
+	for name, expected := range tester {
+		if a, err := Normalise(name); err != nil || a != expected {
+			t.Errorf("Test Failed - Normalise(%s) expected %s received %s %v", name, expected, a, err)
+		}
+	}
+	if _, err := Normalise("OPTIONS"); err != ErrUnsupportedAsset {
+		t.Error("Test Failed - Normalise() expected unsupported asset error", err)
+	}
+}
+
+func TestIsSupported(t *testing.T) {
+	if !IsSupported(PerpetualSwap) || IsSupported("BINARY") {
+		t.Error("Test Failed - IsSupported() incorrect result")
+	}
+}
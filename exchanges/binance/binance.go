@@ -2,6 +2,7 @@ package binance
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"log"
@@ -12,7 +13,10 @@ import (
 	"github.com/gorilla/websocket"
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/assets"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/request"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
@@ -21,6 +25,8 @@ import (
 type Binance struct {
 	exchange.Base
 	WebsocketConn *websocket.Conn
+	listenKey     string
+	wsBuffers     map[string]*orderbook.Buffer
 
 	// Valid string list that is required by the exchange
 	validLimits    []int
@@ -31,6 +37,7 @@ const (
 	apiURL = "https://api.binance.com"
 
 	// Public endpoints
+	ping             = "/api/v1/ping"
 	exchangeInfo     = "/api/v1/exchangeInfo"
 	orderBookDepth   = "/api/v1/depth"
 	recentTrades     = "/api/v1/trades"
@@ -51,12 +58,45 @@ const (
 	openOrders   = "/api/v3/openOrders"
 	allOrders    = "/api/v3/allOrders"
 
-	// binance authenticated and unauthenticated limit rates
-	// to-do
-	binanceAuthRate   = 0
-	binanceUnauthRate = 0
+	// User data stream endpoint, authenticated by API key only
+	userDataStream = "/api/v3/userDataStream"
+
+	// Simple earn endpoints
+	simpleEarnFlexiblePosition = "/sapi/v1/simple-earn/flexible/position"
+	simpleEarnLockedPosition   = "/sapi/v1/simple-earn/locked/position"
+	simpleEarnLockedSubscribe  = "/sapi/v1/simple-earn/locked/subscribe"
+	simpleEarnLockedRedeem     = "/sapi/v1/simple-earn/locked/redeem"
+	simpleEarnPageSize         = 100
+
+	// Dust transfer endpoint, converting small balances to BNB
+	dustTransfer = "/sapi/v1/asset/dust"
+	dustTarget   = "BNB"
+
+	// API key permission endpoint, reporting the key's creation time
+	apiRestrictions = "/sapi/v1/account/apiRestrictions"
+
+	// Trailing stop delta limits in basis points
+	minTrailingDelta = 10
+	maxTrailingDelta = 2000
+
+	// Binance limits the request weight of an IP address per minute, shared
+	// by authenticated and unauthenticated requests
+	binanceRequestWeight    = 1200
+	binanceUsedWeightHeader = "X-MBX-USED-WEIGHT-1M"
 )
 
+// binanceEndpointWeights are the request weights of endpoints weighing more
+// than one. Weights which depend on the request parameters, such as the depth
+// limit, use the weight of the default parameters and are corrected from the
+// used weight header.
+var binanceEndpointWeights = map[string]int{
+	historicalTrades:    5,
+	accountInfo:         5,
+	"GET " + queryOrder: 2,
+	openOrders:          3,
+	allOrders:           5,
+}
+
 // SetDefaults sets the basic defaults for Binance
 func (b *Binance) SetDefaults() {
 	b.Name = "Binance"
@@ -71,11 +111,37 @@ func (b *Binance) SetDefaults() {
 	b.SupportsAutoPairUpdating = true
 	b.SupportsRESTTickerBatching = true
 	b.APIWithdrawPermissions = exchange.AutoWithdrawCrypto
+	b.BrokerTagFormat = exchange.BrokerTagFormat{Prefix: "x-", MaxLength: 16}
+	b.Features = exchange.Features{
+		CanGetTicker:          true,
+		CanGetOrderbook:       true,
+		CanGetAccountInfo:     true,
+		CanSubmitOrder:        true,
+		IdempotentClientID:    true,
+		CanCancelOrder:        true,
+		CanCancelAllOrders:    true,
+		CanStreamTicker:       true,
+		CanStreamOrderbook:    true,
+		CanStreamTrades:       true,
+		CanGetHistoricCandles: true,
+		CanGetActiveOrders:    true,
+		CanGetOrderHistory:    true,
+	}
+	b.Features.Orders = map[string]exchange.OrderCapabilities{
+		assets.Spot: {
+			OrderTypes:   []exchange.OrderType{exchange.Limit, exchange.Market},
+			TimeInForce:  []exchange.TimeInForce{exchange.GTC, exchange.IOC, exchange.FOK},
+			TriggerTypes: []exchange.TriggerType{exchange.StopLoss, exchange.TakeProfit},
+		},
+	}
 	b.SetValues()
-	b.Requester = request.New(b.Name,
-		request.NewRateLimit(time.Second, binanceAuthRate),
-		request.NewRateLimit(time.Second, binanceUnauthRate),
+	weightLimit := request.NewRateLimit(time.Minute, binanceRequestWeight)
+	b.Requester = request.New(b.Name, weightLimit, weightLimit,
 		common.NewHTTPClientWithTimeout(exchange.DefaultHTTPTimeout))
+	for endpoint, weight := range binanceEndpointWeights {
+		b.Requester.SetEndpointWeight(endpoint, weight)
+	}
+	b.Requester.SetRateLimitHeaders(request.RateLimitHeaders{Used: binanceUsedWeightHeader})
 	b.APIUrlDefault = apiURL
 	b.APIUrl = b.APIUrlDefault
 	b.WebsocketInit()
@@ -116,6 +182,31 @@ func (b *Binance) Setup(exch config.ExchangeConfig) {
 		if err != nil {
 			log.Fatal(err)
 		}
+		err = b.SetTLSPins(exch.TLSPins)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = b.SetLocalAddress(exch.LocalAddress)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = b.SetRoundingModes(exch.RoundingModes)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = b.SetRateLimitTiers(exch.RateLimitTiers, exch.AccountTier)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = b.SetHTTPClientHeaders(exch.HTTPHeaders)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = b.SetBrokerTag(exch.BrokerTag)
+		if err != nil {
+			log.Fatal(err)
+		}
+		b.SetSimulation(exch.Simulate, exch.SimulationBalances)
 		err = b.WebsocketSetup(b.WSConnect,
 			exch.Name,
 			exch.Websocket,
@@ -138,9 +229,24 @@ func (b *Binance) GetExchangeValidCurrencyPairs() ([]string, error) {
 	}
 
 	for _, symbol := range info.Symbols {
-		if symbol.Status == "TRADING" {
-			validCurrencyPairs = append(validCurrencyPairs, symbol.BaseAsset+"-"+symbol.QuoteAsset)
+		if symbol.Status != "TRADING" {
+			continue
+		}
+		validCurrencyPairs = append(validCurrencyPairs, symbol.BaseAsset+"-"+symbol.QuoteAsset)
+
+		var tickSize, stepSize, minAmount float64
+		for _, filter := range symbol.Filters {
+			switch filter.FilterType {
+			case "PRICE_FILTER":
+				tickSize = filter.TickSize
+			case "LOT_SIZE":
+				stepSize = filter.StepSize
+				minAmount = filter.MinQty
+			}
 		}
+		p := pair.NewCurrencyPair(symbol.BaseAsset, symbol.QuoteAsset)
+		b.SetPairIncrements(p, tickSize, stepSize)
+		b.SetPairMinimumAmount(p, minAmount)
 	}
 	return validCurrencyPairs, nil
 }
@@ -151,7 +257,13 @@ func (b *Binance) GetExchangeInfo() (ExchangeInfo, error) {
 	var resp ExchangeInfo
 	path := b.APIUrl + exchangeInfo
 
-	return resp, b.SendHTTPRequest(path, &resp)
+	return resp, b.SendCachedHTTPRequest(path, &resp)
+}
+
+// Ping tests connectivity to the REST API
+func (b *Binance) Ping() error {
+	var resp struct{}
+	return b.SendHTTPRequest(b.APIUrl+ping, &resp)
 }
 
 // GetOrderBook returns full orderbook information
@@ -160,6 +272,12 @@ func (b *Binance) GetExchangeInfo() (ExchangeInfo, error) {
 // symbol: string of currency pair
 // limit: returned limit amount
 func (b *Binance) GetOrderBook(obd OrderBookDataRequestParams) (OrderBook, error) {
+	return b.GetOrderBookContext(context.Background(), obd)
+}
+
+// GetOrderBookContext returns the orderbook for a symbol, cancelled with the
+// context
+func (b *Binance) GetOrderBookContext(ctx context.Context, obd OrderBookDataRequestParams) (OrderBook, error) {
 	orderbook, resp := OrderBook{}, OrderBookData{}
 
 	if err := b.CheckLimit(obd.Limit); err != nil {
@@ -175,7 +293,7 @@ func (b *Binance) GetOrderBook(obd OrderBookDataRequestParams) (OrderBook, error
 
 	path := fmt.Sprintf("%s%s?%s", b.APIUrl, orderBookDepth, params.Encode())
 
-	if err := b.SendHTTPRequest(path, &resp); err != nil {
+	if err := b.SendHTTPRequestContext(ctx, path, &resp); err != nil {
 		return orderbook, err
 	}
 
@@ -378,9 +496,15 @@ func (b *Binance) GetPriceChangeStats(symbol string) (PriceChangeStats, error) {
 
 // GetTickers returns the ticker data for the last 24 hrs
 func (b *Binance) GetTickers() ([]PriceChangeStats, error) {
+	return b.GetTickersContext(context.Background())
+}
+
+// GetTickersContext returns the ticker data for the last 24 hrs, cancelled
+// with the context
+func (b *Binance) GetTickersContext(ctx context.Context) ([]PriceChangeStats, error) {
 	var resp []PriceChangeStats
 	path := fmt.Sprintf("%s%s", b.APIUrl, priceChange)
-	return resp, b.SendHTTPRequest(path, &resp)
+	return resp, b.SendHTTPRequestContext(ctx, path, &resp)
 }
 
 // GetLatestSpotPrice returns latest spot price of symbol
@@ -421,6 +545,11 @@ func (b *Binance) GetBestPrice(symbol string) (BestPrice, error) {
 
 // NewOrder sends a new order to Binance
 func (b *Binance) NewOrder(o NewOrderRequest) (NewOrderResponse, error) {
+	return b.NewOrderContext(context.Background(), o)
+}
+
+// NewOrderContext sends a new order to Binance, cancelled with the context
+func (b *Binance) NewOrderContext(ctx context.Context, o NewOrderRequest) (NewOrderResponse, error) {
 	var resp NewOrderResponse
 
 	path := fmt.Sprintf("%s%s", b.APIUrl, newOrder)
@@ -430,7 +559,11 @@ func (b *Binance) NewOrder(o NewOrderRequest) (NewOrderResponse, error) {
 	params.Set("side", string(o.Side))
 	params.Set("type", string(o.TradeType))
 	params.Set("quantity", strconv.FormatFloat(o.Quantity, 'f', -1, 64))
-	if o.TradeType == "LIMIT" {
+	switch o.TradeType {
+	case BinanceRequestParamsOrderLimit,
+		BinanceRequestParamsOrderStopLossLimit,
+		BinanceRequestParamsOrderTakeProfitLimit,
+		BinanceRequestParamsOrderLimitMarker:
 		params.Set("price", strconv.FormatFloat(o.Price, 'f', -1, 64))
 	}
 	if o.TimeInForce != "" {
@@ -438,7 +571,7 @@ func (b *Binance) NewOrder(o NewOrderRequest) (NewOrderResponse, error) {
 	}
 
 	if o.NewClientOrderID != "" {
-		params.Set("newClientOrderID", o.NewClientOrderID)
+		params.Set("newClientOrderId", o.NewClientOrderID)
 	}
 
 	if o.StopPrice != 0 {
@@ -449,22 +582,32 @@ func (b *Binance) NewOrder(o NewOrderRequest) (NewOrderResponse, error) {
 		params.Set("icebergQty", strconv.FormatFloat(o.IcebergQty, 'f', -1, 64))
 	}
 
+	if o.TrailingDelta != 0 {
+		params.Set("trailingDelta", strconv.FormatInt(o.TrailingDelta, 10))
+	}
+
 	if o.NewOrderRespType != "" {
 		params.Set("newOrderRespType", o.NewOrderRespType)
 	}
 
-	if err := b.SendAuthHTTPRequest("POST", path, params, &resp); err != nil {
+	if err := b.SendAuthHTTPRequestContext(ctx, "POST", path, params, &resp); err != nil {
 		return resp, err
 	}
 
 	if resp.Code != 0 {
-		return resp, errors.New(resp.Msg)
+		return resp, fmt.Errorf("%s error code %d: %s", b.Name, resp.Code, resp.Msg)
 	}
 	return resp, nil
 }
 
 // CancelExistingOrder sends a cancel order to Binance
 func (b *Binance) CancelExistingOrder(symbol string, orderID int64, origClientOrderID string) (CancelOrderResponse, error) {
+	return b.CancelExistingOrderContext(context.Background(), symbol, orderID, origClientOrderID)
+}
+
+// CancelExistingOrderContext sends a cancel order to Binance, cancelled with
+// the context
+func (b *Binance) CancelExistingOrderContext(ctx context.Context, symbol string, orderID int64, origClientOrderID string) (CancelOrderResponse, error) {
 	var resp CancelOrderResponse
 
 	path := fmt.Sprintf("%s%s", b.APIUrl, cancelOrder)
@@ -480,7 +623,7 @@ func (b *Binance) CancelExistingOrder(symbol string, orderID int64, origClientOr
 		params.Set("origClientOrderId", origClientOrderID)
 	}
 
-	return resp, b.SendAuthHTTPRequest("DELETE", path, params, &resp)
+	return resp, b.SendAuthHTTPRequestContext(ctx, "DELETE", path, params, &resp)
 }
 
 // OpenOrders Current open orders
@@ -551,6 +694,11 @@ func (b *Binance) QueryOrder(symbol, origClientOrderID string, orderID int64) (Q
 
 // GetAccount returns binance user accounts
 func (b *Binance) GetAccount() (*Account, error) {
+	return b.GetAccountContext(context.Background())
+}
+
+// GetAccountContext returns binance user accounts, cancelled with the context
+func (b *Binance) GetAccountContext(ctx context.Context) (*Account, error) {
 	type response struct {
 		Response
 		Account
@@ -561,7 +709,7 @@ func (b *Binance) GetAccount() (*Account, error) {
 	path := fmt.Sprintf("%s%s", b.APIUrl, accountInfo)
 	params := url.Values{}
 
-	if err := b.SendAuthHTTPRequest("GET", path, params, &resp); err != nil {
+	if err := b.SendAuthHTTPRequestContext(ctx, "GET", path, params, &resp); err != nil {
 		return &resp.Account, err
 	}
 
@@ -572,13 +720,197 @@ func (b *Binance) GetAccount() (*Account, error) {
 	return &resp.Account, nil
 }
 
+// GetSimpleEarnFlexiblePositions returns every simple earn flexible product
+// position
+func (b *Binance) GetSimpleEarnFlexiblePositions() ([]SimpleEarnFlexiblePosition, error) {
+	var positions []SimpleEarnFlexiblePosition
+	for page := 1; ; page++ {
+		var resp struct {
+			Response
+			Rows  []SimpleEarnFlexiblePosition `json:"rows"`
+			Total int                          `json:"total"`
+		}
+		err := b.getSimpleEarnPage(simpleEarnFlexiblePosition, page, &resp)
+		if err != nil {
+			return nil, err
+		}
+		if resp.Code != 0 {
+			return nil, errors.New(resp.Msg)
+		}
+		positions = append(positions, resp.Rows...)
+		if len(resp.Rows) < simpleEarnPageSize || len(positions) >= resp.Total {
+			return positions, nil
+		}
+	}
+}
+
+// GetSimpleEarnLockedPositions returns every simple earn locked product
+// position
+func (b *Binance) GetSimpleEarnLockedPositions() ([]SimpleEarnLockedPosition, error) {
+	var positions []SimpleEarnLockedPosition
+	for page := 1; ; page++ {
+		var resp struct {
+			Response
+			Rows  []SimpleEarnLockedPosition `json:"rows"`
+			Total int                        `json:"total"`
+		}
+		err := b.getSimpleEarnPage(simpleEarnLockedPosition, page, &resp)
+		if err != nil {
+			return nil, err
+		}
+		if resp.Code != 0 {
+			return nil, errors.New(resp.Msg)
+		}
+		positions = append(positions, resp.Rows...)
+		if len(resp.Rows) < simpleEarnPageSize || len(positions) >= resp.Total {
+			return positions, nil
+		}
+	}
+}
+
+// SubscribeSimpleEarnLocked subscribes an amount to a simple earn locked
+// product
+func (b *Binance) SubscribeSimpleEarnLocked(productID string, amount float64) (SimpleEarnSubscribeResponse, error) {
+	var resp struct {
+		Response
+		SimpleEarnSubscribeResponse
+	}
+	params := url.Values{}
+	params.Set("productId", productID)
+	params.Set("amount", strconv.FormatFloat(amount, 'f', -1, 64))
+
+	err := b.SendAuthHTTPRequest("POST", b.APIUrl+simpleEarnLockedSubscribe, params, &resp)
+	if err != nil {
+		return resp.SimpleEarnSubscribeResponse, err
+	}
+	if resp.Code != 0 {
+		return resp.SimpleEarnSubscribeResponse, errors.New(resp.Msg)
+	}
+	if !resp.Success {
+		return resp.SimpleEarnSubscribeResponse, errors.New("simple earn subscription failed")
+	}
+	return resp.SimpleEarnSubscribeResponse, nil
+}
+
+// RedeemSimpleEarnLocked redeems a simple earn locked position
+func (b *Binance) RedeemSimpleEarnLocked(positionID string) (SimpleEarnRedeemResponse, error) {
+	var resp struct {
+		Response
+		SimpleEarnRedeemResponse
+	}
+	params := url.Values{}
+	params.Set("positionId", positionID)
+
+	err := b.SendAuthHTTPRequest("POST", b.APIUrl+simpleEarnLockedRedeem, params, &resp)
+	if err != nil {
+		return resp.SimpleEarnRedeemResponse, err
+	}
+	if resp.Code != 0 {
+		return resp.SimpleEarnRedeemResponse, errors.New(resp.Msg)
+	}
+	if !resp.Success {
+		return resp.SimpleEarnRedeemResponse, errors.New("simple earn redemption failed")
+	}
+	return resp.SimpleEarnRedeemResponse, nil
+}
+
+// getSimpleEarnPage requests a page of simple earn positions
+func (b *Binance) getSimpleEarnPage(endpoint string, page int, result interface{}) error {
+	params := url.Values{}
+	params.Set("current", strconv.Itoa(page))
+	params.Set("size", strconv.Itoa(simpleEarnPageSize))
+	return b.SendAuthHTTPRequest("GET", b.APIUrl+endpoint, params, result)
+}
+
+// CreateListenKey starts a user data stream and returns its listen key, keys
+// expire after 60 minutes unless kept alive
+func (b *Binance) CreateListenKey() (string, error) {
+	var resp struct {
+		ListenKey string `json:"listenKey"`
+	}
+	path := fmt.Sprintf("%s%s", b.APIUrl, userDataStream)
+	return resp.ListenKey, b.SendAPIKeyHTTPRequest("POST", path, nil, &resp)
+}
+
+// KeepAliveListenKey extends a listen key's validity by 60 minutes
+func (b *Binance) KeepAliveListenKey(listenKey string) error {
+	params := url.Values{}
+	params.Set("listenKey", listenKey)
+	path := fmt.Sprintf("%s%s", b.APIUrl, userDataStream)
+	return b.SendAPIKeyHTTPRequest("PUT", path, params, nil)
+}
+
+// CloseListenKey closes a user data stream
+func (b *Binance) CloseListenKey(listenKey string) error {
+	params := url.Values{}
+	params.Set("listenKey", listenKey)
+	path := fmt.Sprintf("%s%s", b.APIUrl, userDataStream)
+	return b.SendAPIKeyHTTPRequest("DELETE", path, params, nil)
+}
+
 // SendHTTPRequest sends an unauthenticated request
 func (b *Binance) SendHTTPRequest(path string, result interface{}) error {
-	return b.SendPayload("GET", path, nil, nil, result, false, b.Verbose)
+	return b.SendHTTPRequestContext(context.Background(), path, result)
+}
+
+// SendHTTPRequestContext sends an unauthenticated request, cancelled with the
+// context
+func (b *Binance) SendHTTPRequestContext(ctx context.Context, path string, result interface{}) error {
+	return b.SendPayloadContext(ctx, "GET", path, nil, nil, result, false, b.Verbose)
+}
+
+// DustTransfer converts the small balances of assets to BNB
+func (b *Binance) DustTransfer(assets []string) (DustTransferResponse, error) {
+	var resp struct {
+		Response
+		DustTransferResponse
+	}
+	params := url.Values{}
+	for _, asset := range assets {
+		params.Add("asset", common.StringToUpper(asset))
+	}
+
+	err := b.SendAuthHTTPRequest("POST", b.APIUrl+dustTransfer, params, &resp)
+	if err != nil {
+		return resp.DustTransferResponse, err
+	}
+	if resp.Code != 0 {
+		return resp.DustTransferResponse, errors.New(resp.Msg)
+	}
+	return resp.DustTransferResponse, nil
+}
+
+// GetAPIRestrictions returns the permissions of the API key in use and when
+// it was created
+func (b *Binance) GetAPIRestrictions() (APIRestrictions, error) {
+	var resp struct {
+		Response
+		APIRestrictions
+	}
+	err := b.SendAuthHTTPRequest("GET", b.APIUrl+apiRestrictions, url.Values{}, &resp)
+	if err != nil {
+		return resp.APIRestrictions, err
+	}
+	if resp.Code != 0 {
+		return resp.APIRestrictions, errors.New(resp.Msg)
+	}
+	return resp.APIRestrictions, nil
+}
+
+// SendCachedHTTPRequest sends an unauthenticated HTTP request for static data
+// through the metadata cache
+func (b *Binance) SendCachedHTTPRequest(path string, result interface{}) error {
+	return b.SendCachedPayload(path, nil, result, b.Verbose)
 }
 
 // SendAuthHTTPRequest sends an authenticated HTTP request
 func (b *Binance) SendAuthHTTPRequest(method, path string, params url.Values, result interface{}) error {
+	return b.SendAuthHTTPRequestContext(context.Background(), method, path, params, result)
+}
+
+// SendAuthHTTPRequestContext sends an authenticated HTTP request, cancelled
+// with the context
+func (b *Binance) SendAuthHTTPRequestContext(ctx context.Context, method, path string, params url.Values, result interface{}) error {
 	if !b.AuthenticatedAPISupport {
 		return fmt.Errorf(exchange.WarningAuthenticatedRequestWithoutCredentialsSet, b.Name)
 	}
@@ -602,6 +934,22 @@ func (b *Binance) SendAuthHTTPRequest(method, path string, params url.Values, re
 	}
 	path = common.EncodeURLValues(path, params)
 
+	return b.SendPayloadContext(ctx, method, path, headers, bytes.NewBufferString(""), result, true, b.Verbose)
+}
+
+// SendAPIKeyHTTPRequest sends an HTTP request authenticated by the API key
+// header only, as used by the user data stream endpoints
+func (b *Binance) SendAPIKeyHTTPRequest(method, path string, params url.Values, result interface{}) error {
+	if !b.AuthenticatedAPISupport {
+		return fmt.Errorf(exchange.WarningAuthenticatedRequestWithoutCredentialsSet, b.Name)
+	}
+
+	headers := make(map[string]string)
+	headers["X-MBX-APIKEY"] = b.APIKey
+	if params != nil {
+		path = common.EncodeURLValues(path, params)
+	}
+
 	return b.SendPayload(method, path, headers, bytes.NewBufferString(""), result, true, b.Verbose)
 }
 
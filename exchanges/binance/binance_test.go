@@ -1,13 +1,20 @@
 package binance
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/thrasher-/gocryptotrader/currency/pair"
 	"github.com/thrasher-/gocryptotrader/currency/symbol"
 
 	"github.com/thrasher-/gocryptotrader/config"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/assets"
+	"github.com/thrasher-/gocryptotrader/exchanges/conformance"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
 
 // Please supply your own keys here for due diligence testing
@@ -37,6 +44,17 @@ func TestSetup(t *testing.T) {
 	b.Setup(binanceConfig)
 }
 
+func TestConformance(t *testing.T) {
+	cfg := config.GetConfig()
+	cfg.LoadConfig("../../testdata/configtest.json")
+	binanceConfig, err := cfg.GetExchangeConfig("Binance")
+	if err != nil {
+		t.Fatal("Test Failed - Binance conformance init error", err)
+	}
+
+	conformance.Run(t, func() exchange.IBotExchange { return new(Binance) }, binanceConfig)
+}
+
 func TestGetExchangeValidCurrencyPairs(t *testing.T) {
 	t.Parallel()
 	_, err := b.GetExchangeValidCurrencyPairs()
@@ -45,6 +63,14 @@ func TestGetExchangeValidCurrencyPairs(t *testing.T) {
 	}
 }
 
+func TestCheckSystemStatus(t *testing.T) {
+	t.Parallel()
+	err := b.CheckSystemStatus()
+	if err != nil {
+		t.Error("Test Failed - Binance CheckSystemStatus() error", err)
+	}
+}
+
 func TestGetOrderBook(t *testing.T) {
 	t.Parallel()
 	_, err := b.GetOrderBook(OrderBookDataRequestParams{
@@ -98,6 +124,23 @@ func TestGetSpotKline(t *testing.T) {
 	}
 }
 
+func TestGetHistoricCandles(t *testing.T) {
+	t.Parallel()
+	p := pair.NewCurrencyPair(symbol.BTC, symbol.USDT)
+	_, err := b.GetHistoricCandles(p, "SPOT", kline.TenMin, time.Now().Add(-time.Hour), time.Now())
+	if err != kline.ErrUnsupportedInterval {
+		t.Error("Test Failed - Binance GetHistoricCandles() expected unsupported interval error", err)
+	}
+
+	end := time.Now().Truncate(time.Hour)
+	candles, err := b.GetHistoricCandles(p, "SPOT", kline.OneHour, end.Add(-24*time.Hour), end)
+	if err != nil {
+		t.Error("Test Failed - Binance GetHistoricCandles() error", err)
+	} else if len(candles) != 24 {
+		t.Errorf("Test Failed - Binance GetHistoricCandles() expected 24 candles, received %d", len(candles))
+	}
+}
+
 func TestGetAveragePrice(t *testing.T) {
 	t.Parallel()
 	_, err := b.GetAveragePrice("BTCUSDT")
@@ -232,6 +275,45 @@ func TestGetAccount(t *testing.T) {
 	t.Logf("Current takerFee: %d", account.TakerCommission)
 }
 
+func TestGetEarnBalances(t *testing.T) {
+	if testAPIKey == "" || testAPISecret == "" {
+		t.Skip()
+	}
+	t.Parallel()
+	b.SetDefaults()
+	TestSetup(t)
+	_, err := b.GetEarnBalances()
+	if err != nil {
+		t.Error("Test Failed - Binance GetEarnBalances() error", err)
+	}
+}
+
+func TestGetStakingPositions(t *testing.T) {
+	if testAPIKey == "" || testAPISecret == "" {
+		t.Skip()
+	}
+	t.Parallel()
+	b.SetDefaults()
+	TestSetup(t)
+	_, err := b.GetStakingPositions()
+	if err != nil {
+		t.Error("Test Failed - Binance GetStakingPositions() error", err)
+	}
+}
+
+func TestGetAPIKeyInfo(t *testing.T) {
+	if testAPIKey == "" || testAPISecret == "" {
+		t.Skip()
+	}
+	t.Parallel()
+	b.SetDefaults()
+	TestSetup(t)
+	_, err := b.GetAPIKeyInfo()
+	if err != nil {
+		t.Error("Test Failed - Binance GetAPIKeyInfo() error", err)
+	}
+}
+
 func setFeeBuilder() exchange.FeeBuilder {
 	return exchange.FeeBuilder{
 		Amount:         1,
@@ -437,3 +519,140 @@ func TestModifyOrder(t *testing.T) {
 		t.Error("Test failed - ModifyOrder() error")
 	}
 }
+
+func TestSupportsTrailingStop(t *testing.T) {
+	tester := []struct {
+		Params   exchange.TrailingStopParams
+		Expected bool
+	}{
+		{exchange.TrailingStopParams{CallbackRate: 0.01}, true},
+		{exchange.TrailingStopParams{CallbackRate: 0.2}, true},
+		{exchange.TrailingStopParams{CallbackRate: 0.0005}, false},
+		{exchange.TrailingStopParams{CallbackRate: 0.25}, false},
+		{exchange.TrailingStopParams{TrailValue: 10}, false},
+		{exchange.TrailingStopParams{CallbackRate: 0.01, ActivationPrice: 100}, false},
+	}
+	for i := range tester {
+		if b.SupportsTrailingStop(tester[i].Params) != tester[i].Expected {
+			t.Errorf("Test failed - SupportsTrailingStop() %+v expected %v",
+				tester[i].Params, tester[i].Expected)
+		}
+	}
+}
+
+func TestWsHandleUserData(t *testing.T) {
+	var bn Binance
+	bn.Websocket = &exchange.Websocket{DataHandler: make(chan interface{}, 2)}
+
+	err := bn.wsHandleUserData([]byte(`{"e":"executionReport","E":1499405658658,"s":"ETHBTC","c":"mUvoqJxFIILMdfAW5iGSOW","S":"BUY","o":"LIMIT","f":"GTC","q":"1.00000000","p":"0.10264410","P":"0.00000000","F":"0.00000000","g":-1,"C":"","x":"TRADE","X":"PARTIALLY_FILLED","r":"NONE","i":4293153,"l":"0.40000000","z":"0.40000000","L":"0.10264410","n":"0.00040000","N":"ETH","T":1499405658657,"t":718,"I":8641984,"w":true,"m":false,"M":false,"O":1499405658657,"Z":"0.04105764","Y":"0.04105764","Q":"0.00000000"}`))
+	if err != nil {
+		t.Fatal("Test Failed - wsHandleUserData() error", err)
+	}
+	report, ok := (<-bn.Websocket.DataHandler).(WsExecutionReport)
+	if !ok || report.OrderID != 4293153 || report.Side != "BUY" || report.OrderStatus != "PARTIALLY_FILLED" ||
+		report.ClientOrderID != "mUvoqJxFIILMdfAW5iGSOW" || report.OrderType != "LIMIT" ||
+		report.CumulativeFilledQuantity != 0.4 || report.LastExecutedPrice != 0.1026441 {
+		t.Errorf("Test Failed - wsHandleUserData() unexpected execution report %+v", report)
+	}
+
+	err = bn.wsHandleUserData([]byte(`{"e":"outboundAccountPosition","E":1564034571105,"u":1564034571073,"B":[{"a":"ETH","f":"10000.000000","l":"0.000000"}]}`))
+	if err != nil {
+		t.Fatal("Test Failed - wsHandleUserData() error", err)
+	}
+	position, ok := (<-bn.Websocket.DataHandler).(WsAccountPosition)
+	if !ok || len(position.Balances) != 1 || position.Balances[0].Asset != "ETH" || position.Balances[0].Free != 10000 {
+		t.Errorf("Test Failed - wsHandleUserData() unexpected account position %+v", position)
+	}
+
+	if err = bn.wsHandleUserData([]byte(`{"e":"listenKeyExpired","E":1576653824250}`)); err == nil {
+		t.Error("Test Failed - wsHandleUserData() expected listen key expired error")
+	}
+}
+
+func TestUpdateLocalCache(t *testing.T) {
+	var bn Binance
+	bn.Name = "BinanceDepthTest"
+	p := pair.NewCurrencyPair(symbol.BTC, symbol.USDT)
+	buffer := orderbook.NewBuffer(bn.Name, p, "SPOT")
+	fetches := 0
+	buffer.Fetch = func() (orderbook.Base, int64, error) {
+		fetches++
+		return orderbook.Base{
+			Bids: []orderbook.Item{{Price: 6500, Amount: 1}},
+			Asks: []orderbook.Item{{Price: 6501, Amount: 1}},
+		}, 100, nil
+	}
+	bn.wsBuffers = map[string]*orderbook.Buffer{"BTCUSDT": buffer}
+
+	depth := WebsocketDepthStream{
+		Pair:          "BTCUSDT",
+		FirstUpdateID: 95,
+		LastUpdateID:  105,
+		UpdateBids:    []interface{}{[]interface{}{"6500", "2"}},
+		UpdateAsks:    []interface{}{[]interface{}{"6501", "0"}, []interface{}{"6502", "3"}},
+	}
+	if err := bn.UpdateLocalCache(depth); err != nil {
+		t.Fatal("Test Failed - UpdateLocalCache() error", err)
+	}
+	ob := buffer.Orderbook()
+	if !buffer.Synced() || fetches != 1 || ob.Bids[0].Amount != 2 || len(ob.Asks) != 1 || ob.Asks[0].Price != 6502 {
+		t.Errorf("Test Failed - UpdateLocalCache() unexpected orderbook %+v", ob)
+	}
+
+	// A gap fetches a new snapshot
+	depth.FirstUpdateID, depth.LastUpdateID = 110, 111
+	if err := bn.UpdateLocalCache(depth); err == nil || fetches != 4 {
+		t.Error("Test Failed - UpdateLocalCache() expected resync error", err, fetches)
+	}
+	depth.Pair = "ETHUSDT"
+	if err := bn.UpdateLocalCache(depth); err == nil {
+		t.Error("Test Failed - UpdateLocalCache() expected unsubscribed pair error")
+	}
+}
+
+func TestOrderDetail(t *testing.T) {
+	p := pair.NewCurrencyPair(symbol.BTC, symbol.USDT)
+	detail := b.orderDetail(p, &QueryOrderData{
+		OrderID:     1337,
+		Price:       6500,
+		OrigQty:     2,
+		ExecutedQty: 0.5,
+		QuoteQty:    3249,
+		Status:      "PARTIALLY_FILLED",
+		TimeInForce: "IOC",
+		Type:        "LIMIT",
+		Side:        "BUY",
+		Time:        1540000000000,
+	})
+	if detail.ID != "1337" || detail.OrderSide != string(exchange.Buy) ||
+		detail.OrderType != string(exchange.ImmediateOrCancel) || detail.OpenVolume != 1.5 ||
+		detail.AverageExecutedPrice != 6498 || detail.BaseCurrency != "BTC" ||
+		!detail.CreationTime.Equal(time.Unix(1540000000, 0)) {
+		t.Errorf("Test Failed - orderDetail() unexpected order detail %+v", detail)
+	}
+}
+
+func TestUpdateTickerContext(t *testing.T) {
+	var _ exchange.ContextExchange = &b
+	var _ exchange.OrderContextExchange = &b
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := exchange.UpdateTickerContext(ctx, &b, pair.NewCurrencyPair("BTC", "USDT"), ticker.Spot)
+	if err != context.Canceled {
+		t.Error("Test Failed - UpdateTickerContext() expected cancelled request", err)
+	}
+}
+
+func TestSubmitOrderSubmission(t *testing.T) {
+	var _ exchange.OrderSubmissionSubmitter = &b
+	p := pair.NewCurrencyPair(symbol.BTC, symbol.USDT)
+	for _, o := range []exchange.OrderSubmission{
+		{AssetType: assets.Futures, Pair: p, Side: exchange.Buy, Type: exchange.Limit, BaseAmount: 1, Price: 1},
+		{Pair: p, Side: exchange.Buy, Type: exchange.Market, BaseAmount: 1, TimeInForce: exchange.FOK},
+		{Pair: p, Side: exchange.Sell, Type: exchange.Market, BaseAmount: 1, Trigger: exchange.TrailingStop, TriggerPrice: 1},
+	} {
+		if _, err := b.SubmitOrderSubmission(o); err == nil {
+			t.Errorf("Test Failed - SubmitOrderSubmission() expected %+v rejected", o)
+		}
+	}
+}
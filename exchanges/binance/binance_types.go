@@ -192,6 +192,58 @@ type TickerStream struct {
 	NumberOfTrades         int64  `json:"n"`
 }
 
+// WsExecutionReport is a user data stream order update, sent on every order
+// state change and fill. Single letter keys differing only by case are all
+// declared as JSON decoding matches keys case insensitively.
+type WsExecutionReport struct {
+	EventType                string  `json:"e"`
+	EventTime                int64   `json:"E"`
+	Symbol                   string  `json:"s"`
+	ClientOrderID            string  `json:"c"`
+	Side                     string  `json:"S"`
+	OrderType                string  `json:"o"`
+	TimeInForce              string  `json:"f"`
+	Quantity                 float64 `json:"q,string"`
+	Price                    float64 `json:"p,string"`
+	StopPrice                float64 `json:"P,string"`
+	IcebergQuantity          float64 `json:"F,string"`
+	OrderListID              int64   `json:"g"`
+	OriginalClientOrderID    string  `json:"C"`
+	ExecutionType            string  `json:"x"`
+	OrderStatus              string  `json:"X"`
+	RejectReason             string  `json:"r"`
+	OrderID                  int64   `json:"i"`
+	LastExecutedQuantity     float64 `json:"l,string"`
+	CumulativeFilledQuantity float64 `json:"z,string"`
+	LastExecutedPrice        float64 `json:"L,string"`
+	Commission               float64 `json:"n,string"`
+	CommissionAsset          string  `json:"N"`
+	TransactionTime          int64   `json:"T"`
+	TradeID                  int64   `json:"t"`
+	Ignore                   int64   `json:"I"`
+	IsOnBook                 bool    `json:"w"`
+	IsMaker                  bool    `json:"m"`
+	IgnoreFlag               bool    `json:"M"`
+	CreationTime             int64   `json:"O"`
+	CumulativeQuoteQuantity  float64 `json:"Z,string"`
+	LastQuoteQuantity        float64 `json:"Y,string"`
+	QuoteOrderQuantity       float64 `json:"Q,string"`
+	WorkingTime              int64   `json:"W"`
+}
+
+// WsAccountPosition is a user data stream update of the balances changed by
+// an account event
+type WsAccountPosition struct {
+	EventType      string `json:"e"`
+	EventTime      int64  `json:"E"`
+	LastUpdateTime int64  `json:"u"`
+	Balances       []struct {
+		Asset  string  `json:"a"`
+		Free   float64 `json:"f,string"`
+		Locked float64 `json:"l,string"`
+	} `json:"B"`
+}
+
 // HistoricalTrade holds recent trade data
 type HistoricalTrade struct {
 	Code         int     `json:"code"`
@@ -292,6 +344,7 @@ type NewOrderRequest struct {
 	NewClientOrderID string
 	StopPrice        float64 //Used with STOP_LOSS, STOP_LOSS_LIMIT, TAKE_PROFIT, and TAKE_PROFIT_LIMIT orders.
 	IcebergQty       float64 //Used with LIMIT, STOP_LOSS_LIMIT, and TAKE_PROFIT_LIMIT to create an iceberg order.
+	TrailingDelta    int64   //Used with STOP_LOSS, STOP_LOSS_LIMIT, TAKE_PROFIT, and TAKE_PROFIT_LIMIT to create a trailing stop in basis points.
 	NewOrderRespType string
 }
 
@@ -336,6 +389,7 @@ type QueryOrderData struct {
 	Price         float64 `json:"price,string"`
 	OrigQty       float64 `json:"origQty,string"`
 	ExecutedQty   float64 `json:"executedQty,string"`
+	QuoteQty      float64 `json:"cummulativeQuoteQty,string"`
 	Status        string  `json:"status"`
 	TimeInForce   string  `json:"timeInForce"`
 	Type          string  `json:"type"`
@@ -366,6 +420,76 @@ type Account struct {
 	Balances         []Balance `json:"balances"`
 }
 
+// SimpleEarnFlexiblePosition holds a simple earn flexible product position.
+// Rates are annual fractions.
+type SimpleEarnFlexiblePosition struct {
+	Asset                      string  `json:"asset"`
+	ProductID                  string  `json:"productId"`
+	TotalAmount                float64 `json:"totalAmount,string"`
+	LatestAnnualPercentageRate float64 `json:"latestAnnualPercentageRate,string"`
+	CumulativeTotalRewards     float64 `json:"cumulativeTotalRewards,string"`
+	CanRedeem                  bool    `json:"canRedeem"`
+}
+
+// SimpleEarnLockedPosition holds a simple earn locked product position
+type SimpleEarnLockedPosition struct {
+	PositionID     int64   `json:"positionId"`
+	ProductID      string  `json:"productId"`
+	Asset          string  `json:"asset"`
+	Amount         float64 `json:"amount,string"`
+	APY            float64 `json:"APY,string"`
+	RewardAmount   float64 `json:"rewardAmt,string"`
+	PurchaseTime   int64   `json:"purchaseTime,string"`
+	Duration       int     `json:"duration,string"`
+	RewardsEndDate int64   `json:"rewardsEndDate,string"`
+	Status         string  `json:"status"`
+}
+
+// SimpleEarnSubscribeResponse holds the result of a simple earn subscription
+type SimpleEarnSubscribeResponse struct {
+	PurchaseID int64       `json:"purchaseId"`
+	PositionID json.Number `json:"positionId"`
+	Success    bool        `json:"success"`
+}
+
+// SimpleEarnRedeemResponse holds the result of a simple earn redemption
+type SimpleEarnRedeemResponse struct {
+	RedeemID int64 `json:"redeemId"`
+	Success  bool  `json:"success"`
+}
+
+// DustTransferResponse holds the result of converting small balances to BNB,
+// amounts are in BNB except the converted amount of each asset
+type DustTransferResponse struct {
+	TotalServiceCharge float64 `json:"totalServiceCharge,string"`
+	TotalTransfered    float64 `json:"totalTransfered,string"`
+	TransferResult     []struct {
+		Amount              float64 `json:"amount,string"`
+		FromAsset           string  `json:"fromAsset"`
+		OperateTime         int64   `json:"operateTime"`
+		ServiceChargeAmount float64 `json:"serviceChargeAmount,string"`
+		TransactionID       int64   `json:"tranId"`
+		TransferedAmount    float64 `json:"transferedAmount,string"`
+	} `json:"transferResult"`
+}
+
+// APIRestrictions holds the permissions of an API key, times are in unix
+// milliseconds. TradingAuthorityExpirationTime is when the spot and margin
+// trading permission of a key without IP restrictions expires, zero when it
+// does not.
+type APIRestrictions struct {
+	IPRestrict                     bool  `json:"ipRestrict"`
+	CreateTime                     int64 `json:"createTime"`
+	EnableReading                  bool  `json:"enableReading"`
+	EnableWithdrawals              bool  `json:"enableWithdrawals"`
+	EnableInternalTransfer         bool  `json:"enableInternalTransfer"`
+	EnableMargin                   bool  `json:"enableMargin"`
+	EnableFutures                  bool  `json:"enableFutures"`
+	PermitsUniversalTransfer       bool  `json:"permitsUniversalTransfer"`
+	EnableSpotAndMarginTrading     bool  `json:"enableSpotAndMarginTrading"`
+	TradingAuthorityExpirationTime int64 `json:"tradingAuthorityExpirationTime"`
+}
+
 // RequestParamsSideType trade order side (buy or sell)
 type RequestParamsSideType string
 
@@ -7,7 +7,6 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -19,35 +18,30 @@ import (
 
 const (
 	binanceDefaultWebsocketURL = "wss://stream.binance.com:9443"
-)
-
-var lastUpdateID map[string]int64
-var m sync.Mutex
 
-// SeedLocalCache seeds depth data
-func (b *Binance) SeedLocalCache(p pair.CurrencyPair) error {
-	var newOrderBook orderbook.Base
+	// User data stream events
+	binanceWsExecutionReport  = "executionReport"
+	binanceWsAccountPosition  = "outboundAccountPosition"
+	binanceWsListenKeyExpired = "listenKeyExpired"
+	binanceWsBalanceUpdate    = "balanceUpdate"
 
-	formattedPair := exchange.FormatExchangeCurrency(b.Name, p)
+	// Listen keys expire after 60 minutes
+	binanceWsListenKeyKeepAlive = time.Minute * 30
+)
 
+// wsDepthSnapshot fetches a REST depth snapshot and the update ID it is
+// current to for the orderbook buffer
+func (b *Binance) wsDepthSnapshot(symbol string) (orderbook.Base, int64, error) {
+	var newOrderBook orderbook.Base
 	orderbookNew, err := b.GetOrderBook(
 		OrderBookDataRequestParams{
-			Symbol: formattedPair.String(),
+			Symbol: symbol,
 			Limit:  1000,
 		})
-
 	if err != nil {
-		return err
+		return newOrderBook, 0, err
 	}
 
-	m.Lock()
-	if lastUpdateID == nil {
-		lastUpdateID = make(map[string]int64)
-	}
-
-	lastUpdateID[formattedPair.String()] = orderbookNew.LastUpdateID
-	m.Unlock()
-
 	for _, bids := range orderbookNew.Bids {
 		newOrderBook.Bids = append(newOrderBook.Bids,
 			orderbook.Item{Amount: bids.Quantity, Price: bids.Price})
@@ -56,79 +50,75 @@ func (b *Binance) SeedLocalCache(p pair.CurrencyPair) error {
 		newOrderBook.Asks = append(newOrderBook.Asks,
 			orderbook.Item{Amount: Asks.Quantity, Price: Asks.Price})
 	}
-
-	newOrderBook.Pair = pair.NewCurrencyPairFromString(formattedPair.String())
-	newOrderBook.CurrencyPair = formattedPair.String()
+	newOrderBook.CurrencyPair = symbol
 	newOrderBook.LastUpdated = time.Now()
-	newOrderBook.AssetType = "SPOT"
+	return newOrderBook, orderbookNew.LastUpdateID, nil
+}
 
-	return b.Websocket.Orderbook.LoadSnapshot(newOrderBook, b.GetName())
+// wsInitBuffers creates an orderbook buffer for each enabled pair, each
+// buffer fetches a depth snapshot on the first update and again whenever a
+// sequence gap is detected
+func (b *Binance) wsInitBuffers() {
+	b.wsBuffers = make(map[string]*orderbook.Buffer)
+	for _, p := range b.GetEnabledCurrencies() {
+		symbol := exchange.FormatExchangeCurrency(b.Name, p).String()
+		buffer := orderbook.NewBuffer(b.GetName(), p, "SPOT")
+		buffer.Fetch = func() (orderbook.Base, int64, error) {
+			return b.wsDepthSnapshot(symbol)
+		}
+		b.wsBuffers[symbol] = buffer
+	}
 }
 
-// UpdateLocalCache updates and returns the most recent iteration of the orderbook
+// UpdateLocalCache applies a depth update to the pair's orderbook buffer,
+// each update must start at the update following the previous one
 func (b *Binance) UpdateLocalCache(ob WebsocketDepthStream) error {
-	m.Lock()
-	ID, ok := lastUpdateID[ob.Pair]
+	buffer, ok := b.wsBuffers[ob.Pair]
 	if !ok {
-		m.Unlock()
-		return errors.New("binance_websocket.go - Unable to find lastUpdateID")
+		return fmt.Errorf("binance_websocket.go - orderbook for %s not subscribed", ob.Pair)
 	}
 
-	if ob.LastUpdateID+1 <= ID || ID >= ob.LastUpdateID+1 {
-		// Drop update, out of order
-		m.Unlock()
-		return nil
-	}
-
-	lastUpdateID[ob.Pair] = ob.LastUpdateID
-	m.Unlock()
-
-	var updateBid, updateAsk []orderbook.Item
-
-	for _, bidsToUpdate := range ob.UpdateBids {
-		var priceToBeUpdated orderbook.Item
-		for i, bids := range bidsToUpdate.([]interface{}) {
-			switch i {
-			case 0:
-				priceToBeUpdated.Price, _ = strconv.ParseFloat(bids.(string), 64)
-			case 1:
-				priceToBeUpdated.Amount, _ = strconv.ParseFloat(bids.(string), 64)
-			}
-		}
-		updateBid = append(updateBid, priceToBeUpdated)
+	err := buffer.Apply(orderbook.Update{
+		Bids:          wsDepthLevels(ob.UpdateBids),
+		Asks:          wsDepthLevels(ob.UpdateAsks),
+		UpdateID:      ob.LastUpdateID,
+		FirstUpdateID: ob.FirstUpdateID,
+		UpdateTime:    common.UnixTimestampToUTC(ob.Timestamp),
+	})
+	if err != nil {
+		return fmt.Errorf("binance_websocket.go - %s orderbook resyncing: %s", ob.Pair, err)
 	}
+	return nil
+}
 
-	for _, asksToUpdate := range ob.UpdateAsks {
-		var priceToBeUpdated orderbook.Item
-		for i, asks := range asksToUpdate.([]interface{}) {
-			switch i {
-			case 0:
-				priceToBeUpdated.Price, _ = strconv.ParseFloat(asks.(string), 64)
-			case 1:
-				priceToBeUpdated.Amount, _ = strconv.ParseFloat(asks.(string), 64)
-			}
+// wsDepthLevels converts [price, quantity] depth update levels
+func wsDepthLevels(levels []interface{}) []orderbook.Item {
+	var items []orderbook.Item
+	for i := range levels {
+		level, ok := levels[i].([]interface{})
+		if !ok || len(level) < 2 {
+			continue
 		}
-		updateAsk = append(updateBid, priceToBeUpdated)
+		price, _ := level[0].(string)
+		amount, _ := level[1].(string)
+		var item orderbook.Item
+		item.Price, _ = strconv.ParseFloat(price, 64)
+		item.Amount, _ = strconv.ParseFloat(amount, 64)
+		items = append(items, item)
 	}
-
-	updatedTime := time.Unix(ob.Timestamp, 0)
-	currencyPair := pair.NewCurrencyPairFromString(ob.Pair)
-
-	return b.Websocket.Orderbook.Update(updateBid,
-		updateAsk,
-		currencyPair,
-		updatedTime,
-		b.GetName(),
-		"SPOT")
+	return items
 }
 
-// WSConnect intiates a websocket connection
+// WSConnect intiates a websocket connection, when authenticated a user data
+// stream is started and combined with the market streams
 func (b *Binance) WSConnect() error {
 	if !b.Websocket.IsEnabled() || !b.IsEnabled() {
 		return errors.New(exchange.WebsocketNotEnabled)
 	}
 
 	var Dialer websocket.Dialer
+	Dialer.TLSClientConfig = b.GetTLSConfig()
+	Dialer.NetDial = b.GetWebsocketNetDial()
 	var err error
 
 	ticker := strings.ToLower(
@@ -154,6 +144,17 @@ func (b *Binance) WSConnect() error {
 		"/" +
 		depth
 
+	b.listenKey = ""
+	if b.AuthenticatedAPISupport {
+		listenKey, err := b.CreateListenKey()
+		if err != nil {
+			return fmt.Errorf("binance_websocket.go - Unable to create listen key. Error: %s",
+				err)
+		}
+		b.listenKey = listenKey
+		wsurl += "/" + listenKey
+	}
+
 	if b.Websocket.GetProxyAddress() != "" {
 		url, err := url.Parse(b.Websocket.GetProxyAddress())
 		if err != nil {
@@ -164,12 +165,7 @@ func (b *Binance) WSConnect() error {
 		Dialer.Proxy = http.ProxyURL(url)
 	}
 
-	for _, ePair := range b.GetEnabledCurrencies() {
-		err := b.SeedLocalCache(ePair)
-		if err != nil {
-			return err
-		}
-	}
+	b.wsInitBuffers()
 
 	b.WebsocketConn, _, err = Dialer.Dial(wsurl, http.Header{})
 	if err != nil {
@@ -178,7 +174,70 @@ func (b *Binance) WSConnect() error {
 	}
 
 	go b.WsHandleData()
+	if b.listenKey != "" {
+		go b.wsListenKeyHandler()
+	}
+
+	return nil
+}
+
+// wsListenKeyHandler extends the listen key before it expires
+func (b *Binance) wsListenKeyHandler() {
+	b.Websocket.Wg.Add(1)
+	defer b.Websocket.Wg.Done()
 
+	t := time.NewTicker(binanceWsListenKeyKeepAlive)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-b.Websocket.ShutdownC:
+			return
+
+		case <-t.C:
+			err := b.KeepAliveListenKey(b.listenKey)
+			if err != nil {
+				b.Websocket.DataHandler <- fmt.Errorf("binance_websocket.go - Unable to keep listen key alive. Error: %s",
+					err)
+			}
+		}
+	}
+}
+
+// wsHandleUserData sends user data stream order and balance updates to the
+// data handler
+func (b *Binance) wsHandleUserData(data []byte) error {
+	var event struct {
+		EventType string `json:"e"`
+		EventTime int64  `json:"E"`
+	}
+	err := common.JSONDecode(data, &event)
+	if err != nil {
+		return err
+	}
+
+	switch event.EventType {
+	case binanceWsExecutionReport:
+		var report WsExecutionReport
+		err = common.JSONDecode(data, &report)
+		if err != nil {
+			return err
+		}
+		b.Websocket.DataHandler <- report
+	case binanceWsAccountPosition:
+		var position WsAccountPosition
+		err = common.JSONDecode(data, &position)
+		if err != nil {
+			return err
+		}
+		b.Websocket.DataHandler <- position
+	case binanceWsListenKeyExpired:
+		return errors.New("listen key expired, user data stream closed")
+	case binanceWsBalanceUpdate:
+		// Deposits and withdrawals are also reported by an account position
+	default:
+		return fmt.Errorf("unhandled user data event %s", event.EventType)
+	}
 	return nil
 }
 
@@ -238,7 +297,15 @@ func (b *Binance) WsHandleData() {
 					continue
 				}
 
-				if strings.Contains(multiStreamData.Stream, "trade") {
+				if b.listenKey != "" && multiStreamData.Stream == b.listenKey {
+					err = b.wsHandleUserData(multiStreamData.Data)
+					if err != nil {
+						b.Websocket.DataHandler <- fmt.Errorf("binance_websocket.go - User data stream error: %s",
+							err)
+					}
+					continue
+
+				} else if strings.Contains(multiStreamData.Stream, "trade") {
 					trade := TradeStream{}
 
 					err := common.JSONDecode(multiStreamData.Data, &trade)
@@ -264,7 +331,7 @@ func (b *Binance) WsHandleData() {
 
 					b.Websocket.DataHandler <- exchange.TradeData{
 						CurrencyPair: pair.NewCurrencyPairFromString(trade.Symbol),
-						Timestamp:    time.Unix(0, trade.TimeStamp),
+						Timestamp:    common.UnixTimestampToUTC(trade.TimeStamp),
 						Price:        price,
 						Amount:       amount,
 						Exchange:     b.GetName(),
@@ -285,7 +352,7 @@ func (b *Binance) WsHandleData() {
 
 					var wsTicker exchange.TickerData
 
-					wsTicker.Timestamp = time.Unix(0, ticker.EventTime)
+					wsTicker.Timestamp = common.UnixTimestampToUTC(ticker.EventTime)
 					wsTicker.Pair = pair.NewCurrencyPairFromString(ticker.Symbol)
 					wsTicker.AssetType = "SPOT"
 					wsTicker.Exchange = b.GetName()
@@ -310,12 +377,12 @@ func (b *Binance) WsHandleData() {
 
 					var wsKline exchange.KlineData
 
-					wsKline.Timestamp = time.Unix(0, kline.EventTime)
+					wsKline.Timestamp = common.UnixTimestampToUTC(kline.EventTime)
 					wsKline.Pair = pair.NewCurrencyPairFromString(kline.Symbol)
 					wsKline.AssetType = "SPOT"
 					wsKline.Exchange = b.GetName()
-					wsKline.StartTime = time.Unix(0, kline.Kline.StartTime)
-					wsKline.CloseTime = time.Unix(0, kline.Kline.CloseTime)
+					wsKline.StartTime = common.UnixTimestampToUTC(kline.Kline.StartTime)
+					wsKline.CloseTime = common.UnixTimestampToUTC(kline.Kline.CloseTime)
 					wsKline.Interval = kline.Kline.Interval
 					wsKline.OpenPrice, _ = strconv.ParseFloat(kline.Kline.OpenPrice, 64)
 					wsKline.ClosePrice, _ = strconv.ParseFloat(kline.Kline.ClosePrice, 64)
@@ -343,11 +410,14 @@ func (b *Binance) WsHandleData() {
 						continue
 					}
 
-					currencyPair := pair.NewCurrencyPairFromString(depth.Pair)
+					buffer := b.wsBuffers[depth.Pair]
+					if !buffer.Synced() {
+						continue
+					}
 
 					b.Websocket.DataHandler <- exchange.WebsocketOrderbookUpdate{
-						Pair:     currencyPair,
-						Asset:    "SPOT",
+						Pair:     buffer.Pair,
+						Asset:    buffer.AssetType,
 						Exchange: b.GetName(),
 					}
 					continue
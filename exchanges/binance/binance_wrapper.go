@@ -1,30 +1,31 @@
 package binance
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
+	"math"
 	"strconv"
-	"sync"
+	"strings"
+	"time"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/assets"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
 
-// Start starts the OKEX go routine
-func (b *Binance) Start(wg *sync.WaitGroup) {
-	wg.Add(1)
-	go func() {
-		b.Run()
-		wg.Done()
-	}()
+// Start starts the Binance wrapper, refreshing its currency pairs
+func (b *Binance) Start(ctx context.Context) error {
+	return b.StartWrapper(ctx, b.Run)
 }
 
 // Run implements the OKEX wrapper
-func (b *Binance) Run() {
+func (b *Binance) Run(ctx context.Context) error {
 	if b.Verbose {
 		log.Printf("%s Websocket: %s. (url: %s).\n%s polling delay: %ds.\n%s %d currencies enabled: %s.\n",
 			b.GetName(),
@@ -61,12 +62,19 @@ func (b *Binance) Run() {
 			log.Printf("%s Failed to get config.\n", b.GetName())
 		}
 	}
+	return nil
 }
 
 // UpdateTicker updates and returns the ticker for a currency pair
 func (b *Binance) UpdateTicker(p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	return b.UpdateTickerContext(context.Background(), p, assetType)
+}
+
+// UpdateTickerContext updates and returns the ticker for a currency pair,
+// cancelled with the context
+func (b *Binance) UpdateTickerContext(ctx context.Context, p pair.CurrencyPair, assetType string) (ticker.Price, error) {
 	var tickerPrice ticker.Price
-	tick, err := b.GetTickers()
+	tick, err := b.GetTickersContext(ctx)
 	if err != nil {
 		return tickerPrice, err
 	}
@@ -109,8 +117,14 @@ func (b *Binance) GetOrderbookEx(currency pair.CurrencyPair, assetType string) (
 
 // UpdateOrderbook updates and returns the orderbook for a currency pair
 func (b *Binance) UpdateOrderbook(p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	return b.UpdateOrderbookContext(context.Background(), p, assetType)
+}
+
+// UpdateOrderbookContext updates and returns the orderbook for a currency
+// pair, cancelled with the context
+func (b *Binance) UpdateOrderbookContext(ctx context.Context, p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
 	var orderBook orderbook.Base
-	orderbookNew, err := b.GetOrderBook(OrderBookDataRequestParams{Symbol: exchange.FormatExchangeCurrency(b.Name, p).String(), Limit: 1000})
+	orderbookNew, err := b.GetOrderBookContext(ctx, OrderBookDataRequestParams{Symbol: exchange.FormatExchangeCurrency(b.Name, p).String(), Limit: 1000})
 	if err != nil {
 		return orderBook, err
 	}
@@ -132,8 +146,14 @@ func (b *Binance) UpdateOrderbook(p pair.CurrencyPair, assetType string) (orderb
 // GetAccountInfo retrieves balances for all enabled currencies for the
 // Bithumb exchange
 func (b *Binance) GetAccountInfo() (exchange.AccountInfo, error) {
+	return b.GetAccountInfoContext(context.Background())
+}
+
+// GetAccountInfoContext retrieves balances for all enabled currencies,
+// cancelled with the context
+func (b *Binance) GetAccountInfoContext(ctx context.Context) (exchange.AccountInfo, error) {
 	var info exchange.AccountInfo
-	raw, err := b.GetAccount()
+	raw, err := b.GetAccountContext(ctx)
 	if err != nil {
 		return info, err
 	}
@@ -175,8 +195,73 @@ func (b *Binance) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]e
 	return resp, common.ErrNotYetImplemented
 }
 
+// binanceKlineLimit is the most candles returned by a kline request
+const binanceKlineLimit = 1000
+
+// klineIntervals maps the candle intervals supported by Binance to its
+// intervals
+var klineIntervals = map[kline.Interval]TimeInterval{
+	kline.OneMin:     TimeIntervalMinute,
+	kline.ThreeMin:   TimeIntervalThreeMinutes,
+	kline.FiveMin:    TimeIntervalFiveMinutes,
+	kline.FifteenMin: TimeIntervalFifteenMinutes,
+	kline.ThirtyMin:  TimeIntervalThirtyMinutes,
+	kline.OneHour:    TimeIntervalHour,
+	kline.FourHour:   TimeIntervalFourHours,
+	kline.SixHour:    TimeIntervalSixHours,
+	kline.TwelveHour: TimeIntervalTwelveHours,
+	kline.OneDay:     TimeIntervalDay,
+	kline.OneWeek:    TimeIntervalWeek,
+}
+
+// GetHistoricCandles returns the candles of a currency pair opening between
+// start and end, paging through the klines endpoint
+func (b *Binance) GetHistoricCandles(p pair.CurrencyPair, assetType string, interval kline.Interval, start, end time.Time) ([]kline.Candle, error) {
+	binanceInterval, ok := klineIntervals[interval]
+	if !ok {
+		return nil, kline.ErrUnsupportedInterval
+	}
+	if !start.Before(end) {
+		return nil, kline.ErrInvalidTimeRange
+	}
+
+	var candles []kline.Candle
+	for _, r := range kline.CalculateRanges(interval, start, end, binanceKlineLimit) {
+		klines, err := b.GetSpotKline(KlinesRequestParams{
+			Symbol:    exchange.FormatExchangeCurrency(b.Name, p).String(),
+			Interval:  binanceInterval,
+			Limit:     binanceKlineLimit,
+			StartTime: r.Start.UnixNano() / int64(time.Millisecond),
+			EndTime:   r.End.UnixNano()/int64(time.Millisecond) - 1,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for x := range klines {
+			candles = append(candles, kline.Candle{
+				Time:   time.Unix(0, int64(klines[x].OpenTime)*int64(time.Millisecond)).UTC(),
+				Open:   klines[x].Open,
+				High:   klines[x].High,
+				Low:    klines[x].Low,
+				Close:  klines[x].Close,
+				Volume: klines[x].Volume,
+			})
+		}
+	}
+	return kline.FilterCandles(candles, start, end), nil
+}
+
 // SubmitOrder submits a new order
 func (b *Binance) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	return b.SubmitOrderContext(context.Background(), p, side, orderType, amount, price, clientID)
+}
+
+// SubmitOrderContext submits a new order, cancelled with the context
+func (b *Binance) SubmitOrderContext(ctx context.Context, p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	if b.IsSimulated() {
+		return b.SimulateSubmitOrder(p, side, orderType, amount, price, clientID)
+	}
+
 	var submitOrderResponse exchange.SubmitOrderResponse
 
 	var sideType RequestParamsSideType
@@ -196,15 +281,17 @@ func (b *Binance) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orde
 		return submitOrderResponse, errors.New("Unsupported order type")
 	}
 
+	price, amount = b.RoundOrder(p, side, price, amount)
 	var orderRequest = NewOrderRequest{
-		Symbol:    p.FirstCurrency.String() + p.SecondCurrency.String(),
-		Side:      sideType,
-		Price:     price,
-		Quantity:  amount,
-		TradeType: requestParamsOrderType,
+		Symbol:           p.FirstCurrency.String() + p.SecondCurrency.String(),
+		Side:             sideType,
+		Price:            price,
+		Quantity:         amount,
+		TradeType:        requestParamsOrderType,
+		NewClientOrderID: b.BrokerClientOrderID(clientID),
 	}
 
-	response, err := b.NewOrder(orderRequest)
+	response, err := b.NewOrderContext(ctx, orderRequest)
 
 	if response.OrderID > 0 {
 		submitOrderResponse.OrderID = fmt.Sprintf("%v", response.OrderID)
@@ -217,37 +304,213 @@ func (b *Binance) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orde
 	return submitOrderResponse, err
 }
 
+// SubmitOrderSubmission submits a spot order carrying its time in force and
+// its stop loss or take profit trigger, triggered limit orders are placed as
+// the stop limit order types
+func (b *Binance) SubmitOrderSubmission(o exchange.OrderSubmission) (exchange.SubmitOrderResponse, error) {
+	var submitOrderResponse exchange.SubmitOrderResponse
+	if o.Asset() != assets.Spot {
+		return submitOrderResponse, fmt.Errorf("%s %s %s", b.Name, o.Asset(), exchange.ErrOrderNotCarried)
+	}
+
+	sideType := BinanceRequestParamsSideSell
+	if o.Side == exchange.Buy {
+		sideType = BinanceRequestParamsSideBuy
+	}
+
+	price, amount := b.RoundOrder(o.Pair, o.Side, o.Price, o.BaseAmount)
+	orderRequest := NewOrderRequest{
+		Symbol:           exchange.FormatExchangeCurrency(b.Name, o.Pair).String(),
+		Side:             sideType,
+		Quantity:         amount,
+		StopPrice:        o.TriggerPrice,
+		NewClientOrderID: b.BrokerClientOrderID(o.ClientID),
+	}
+
+	switch o.Type {
+	case exchange.Market:
+		if o.TimeInForce != "" && o.TimeInForce != exchange.GTC {
+			return submitOrderResponse, fmt.Errorf("%s market orders do not support time in force %s",
+				b.Name, o.TimeInForce)
+		}
+		orderRequest.TradeType = BinanceRequestParamsOrderMarket
+	case exchange.Limit:
+		orderRequest.TradeType = BinanceRequestParamsOrderLimit
+		orderRequest.Price = price
+		orderRequest.TimeInForce = BinanceRequestParamsTimeGTC
+		if o.TimeInForce != "" {
+			orderRequest.TimeInForce = RequestParamsTimeForceType(o.TimeInForce)
+		}
+	default:
+		return submitOrderResponse, fmt.Errorf("%s unsupported order type %s", b.Name, o.Type)
+	}
+
+	switch o.Trigger {
+	case "":
+	case exchange.StopLoss:
+		orderRequest.TradeType = BinanceRequestParamsOrderStopLoss
+		if o.Type == exchange.Limit {
+			orderRequest.TradeType = BinanceRequestParamsOrderStopLossLimit
+		}
+	case exchange.TakeProfit:
+		orderRequest.TradeType = BinanceRequestParamsOrderTakeProfit
+		if o.Type == exchange.Limit {
+			orderRequest.TradeType = BinanceRequestParamsOrderTakeProfitLimit
+		}
+	default:
+		return submitOrderResponse, fmt.Errorf("%s unsupported trigger type %s", b.Name, o.Trigger)
+	}
+
+	response, err := b.NewOrder(orderRequest)
+	if err != nil {
+		return submitOrderResponse, err
+	}
+
+	submitOrderResponse.OrderID = strconv.FormatInt(response.OrderID, 10)
+	submitOrderResponse.IsOrderPlaced = true
+	return submitOrderResponse, nil
+}
+
+// SubmitIcebergOrder submits a GTC limit order showing only visibleAmount on
+// the book
+func (b *Binance) SubmitIcebergOrder(p pair.CurrencyPair, side exchange.OrderSide, amount, visibleAmount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	if b.IsSimulated() {
+		return b.SimulateSubmitOrder(p, side, exchange.Limit, amount, price, clientID)
+	}
+
+	var submitOrderResponse exchange.SubmitOrderResponse
+
+	sideType := BinanceRequestParamsSideSell
+	if side == exchange.Buy {
+		sideType = BinanceRequestParamsSideBuy
+	}
+
+	response, err := b.NewOrder(NewOrderRequest{
+		Symbol:           p.FirstCurrency.String() + p.SecondCurrency.String(),
+		Side:             sideType,
+		Price:            price,
+		Quantity:         amount,
+		TradeType:        BinanceRequestParamsOrderLimit,
+		TimeInForce:      BinanceRequestParamsTimeGTC,
+		IcebergQty:       visibleAmount,
+		NewClientOrderID: b.BrokerClientOrderID(clientID),
+	})
+	if err != nil {
+		return submitOrderResponse, err
+	}
+
+	submitOrderResponse.OrderID = strconv.FormatInt(response.OrderID, 10)
+	submitOrderResponse.IsOrderPlaced = true
+	return submitOrderResponse, nil
+}
+
+// SupportsTrailingStop returns whether a native trailing stop can be placed,
+// Binance trails by a callback rate of 0.1% to 20% without an activation
+// price
+func (b *Binance) SupportsTrailingStop(params exchange.TrailingStopParams) bool {
+	if b.IsSimulated() {
+		return false
+	}
+
+	delta := math.Round(params.CallbackRate * 10000)
+	return params.TrailValue == 0 && params.ActivationPrice == 0 &&
+		delta >= minTrailingDelta && delta <= maxTrailingDelta
+}
+
+// SubmitTrailingStopOrder submits a native trailing stop which places a market
+// order once the price retraces by the callback rate
+func (b *Binance) SubmitTrailingStopOrder(p pair.CurrencyPair, side exchange.OrderSide, amount float64, params exchange.TrailingStopParams, clientID string) (exchange.SubmitOrderResponse, error) {
+	var submitOrderResponse exchange.SubmitOrderResponse
+	if !b.SupportsTrailingStop(params) {
+		return submitOrderResponse, common.ErrFunctionNotSupported
+	}
+
+	sideType := BinanceRequestParamsSideSell
+	if side == exchange.Buy {
+		sideType = BinanceRequestParamsSideBuy
+	}
+
+	response, err := b.NewOrder(NewOrderRequest{
+		Symbol:           p.FirstCurrency.String() + p.SecondCurrency.String(),
+		Side:             sideType,
+		Quantity:         amount,
+		TradeType:        BinanceRequestParamsOrderStopLoss,
+		TrailingDelta:    int64(math.Round(params.CallbackRate * 10000)),
+		NewClientOrderID: b.BrokerClientOrderID(clientID),
+	})
+	if err != nil {
+		return submitOrderResponse, err
+	}
+
+	submitOrderResponse.OrderID = strconv.FormatInt(response.OrderID, 10)
+	submitOrderResponse.IsOrderPlaced = true
+	return submitOrderResponse, nil
+}
+
+// CancelTrailingStopOrder cancels a native trailing stop, which Binance
+// treats as a regular order
+func (b *Binance) CancelTrailingStopOrder(order exchange.OrderCancellation) error {
+	return b.CancelOrder(order)
+}
+
 // ModifyOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (b *Binance) ModifyOrder(action exchange.ModifyOrder) (string, error) {
+	if b.IsSimulated() {
+		return b.SimulateModifyOrder(action)
+	}
+
 	return "", common.ErrFunctionNotSupported
 }
 
 // CancelOrder cancels an order by its corresponding ID number
 func (b *Binance) CancelOrder(order exchange.OrderCancellation) error {
+	return b.CancelOrderContext(context.Background(), order)
+}
+
+// CancelOrderContext cancels an order by its corresponding ID number,
+// cancelled with the context
+func (b *Binance) CancelOrderContext(ctx context.Context, order exchange.OrderCancellation) error {
+	if b.IsSimulated() {
+		return b.SimulateCancelOrder(order)
+	}
+
 	orderIDInt, err := strconv.ParseInt(order.OrderID, 10, 64)
 	if err != nil {
 		return err
 	}
 
-	_, err = b.CancelExistingOrder(exchange.FormatExchangeCurrency(b.Name, order.CurrencyPair).String(),
+	_, err = b.CancelExistingOrderContext(ctx, exchange.FormatExchangeCurrency(b.Name, order.CurrencyPair).String(),
 		orderIDInt,
 		order.AccountID)
 
 	return err
 }
 
-// CancelAllOrders cancels all orders associated with a currency pair
+// CancelAllOrders cancels all open orders, restricted to the currency pair
+// and side of the order cancellation when they are set
 func (b *Binance) CancelAllOrders(orderCancellation exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
+	if b.IsSimulated() {
+		return b.SimulateCancelAllOrders(orderCancellation)
+	}
+
 	cancelAllOrdersResponse := exchange.CancelAllOrdersResponse{
 		OrderStatus: make(map[string]string),
 	}
-	openOrders, err := b.OpenOrders("")
+	var symbol string
+	if !orderCancellation.CurrencyPair.Empty() {
+		symbol = exchange.FormatExchangeCurrency(b.Name, orderCancellation.CurrencyPair).String()
+	}
+	openOrders, err := b.OpenOrders(symbol)
 	if err != nil {
 		return cancelAllOrdersResponse, err
 	}
 
 	for _, order := range openOrders {
+		if orderCancellation.Side != "" &&
+			!strings.EqualFold(order.Side, string(orderCancellation.Side)) {
+			continue
+		}
 		_, err = b.CancelExistingOrder(order.Symbol, order.OrderID, "")
 		if err != nil {
 			cancelAllOrdersResponse.OrderStatus[strconv.FormatInt(order.OrderID, 10)] = err.Error()
@@ -257,12 +520,116 @@ func (b *Binance) CancelAllOrders(orderCancellation exchange.OrderCancellation)
 	return cancelAllOrdersResponse, nil
 }
 
+// SupportsCancelAllScope returns whether CancelAllOrders enforces the scope,
+// Binance open orders can be cancelled by currency pair and side
+func (b *Binance) SupportsCancelAllScope(scope exchange.CancelAllScope) bool {
+	return scope.OrderType == "" && scope.MinAge == 0
+}
+
 // GetOrderInfo returns information on a current open order
 func (b *Binance) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
+	if b.IsSimulated() {
+		return b.SimulateGetOrderInfo(orderID)
+	}
+
 	var orderDetail exchange.OrderDetail
 	return orderDetail, common.ErrNotYetImplemented
 }
 
+// binanceOrderHistoryLimit is the most orders returned per symbol by an
+// order history request
+const binanceOrderHistoryLimit = "1000"
+
+// GetActiveOrders returns the open orders matching the request, each pair
+// is queried separately as querying every symbol is heavily weighted
+func (b *Binance) GetActiveOrders(req exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	if b.IsSimulated() {
+		return b.SimulateGetOrders(req, true)
+	}
+
+	var orders []exchange.OrderDetail
+	for _, p := range b.GetOrdersRequestPairs(req) {
+		resp, err := b.OpenOrders(exchange.FormatExchangeCurrency(b.Name, p).String())
+		if err != nil {
+			return nil, err
+		}
+		for i := range resp {
+			orders = append(orders, b.orderDetail(p, &resp[i]))
+		}
+	}
+	return exchange.FilterOrders(orders, req), nil
+}
+
+// GetOrderHistory returns the filled, cancelled, rejected and expired orders
+// matching the request, Binance requires a symbol so each pair is queried
+// separately
+func (b *Binance) GetOrderHistory(req exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	if b.IsSimulated() {
+		return b.SimulateGetOrders(req, false)
+	}
+
+	var orders []exchange.OrderDetail
+	for _, p := range b.GetOrdersRequestPairs(req) {
+		resp, err := b.AllOrders(exchange.FormatExchangeCurrency(b.Name, p).String(),
+			"",
+			binanceOrderHistoryLimit)
+		if err != nil {
+			return nil, err
+		}
+		for i := range resp {
+			if isOpenOrderStatus(resp[i].Status) {
+				continue
+			}
+			orders = append(orders, b.orderDetail(p, &resp[i]))
+		}
+	}
+	return exchange.FilterOrders(orders, req), nil
+}
+
+// isOpenOrderStatus returns whether a Binance order status is an open order
+func isOpenOrderStatus(status string) bool {
+	return status == "NEW" || status == "PARTIALLY_FILLED" || status == "PENDING_NEW"
+}
+
+// orderDetail converts a Binance order of a currency pair to its order
+// details
+func (b *Binance) orderDetail(p pair.CurrencyPair, o *QueryOrderData) exchange.OrderDetail {
+	side := exchange.Sell
+	if o.Side == string(BinanceRequestParamsSideBuy) {
+		side = exchange.Buy
+	}
+	orderType := exchange.Limit
+	switch o.Type {
+	case string(BinanceRequestParamsOrderMarket):
+		orderType = exchange.Market
+	case string(BinanceRequestParamsOrderLimit):
+		if o.TimeInForce == string(BinanceRequestParamsTimeIOC) {
+			orderType = exchange.ImmediateOrCancel
+		}
+	}
+
+	detail := exchange.OrderDetail{
+		Exchange:       b.Name,
+		ID:             strconv.FormatInt(o.OrderID, 10),
+		BaseCurrency:   p.FirstCurrency.Upper().String(),
+		QuoteCurrency:  p.SecondCurrency.Upper().String(),
+		OrderSide:      string(side),
+		OrderType:      string(orderType),
+		CreationTime:   time.Unix(0, int64(o.Time)*int64(time.Millisecond)).UTC(),
+		Status:         o.Status,
+		Price:          o.Price,
+		Amount:         o.OrigQty,
+		ExecutedAmount: o.ExecutedQty,
+	}
+	if o.ExecutedQty > 0 {
+		detail.AverageExecutedPrice = o.QuoteQty / o.ExecutedQty
+	}
+	if isOpenOrderStatus(o.Status) {
+		detail.OpenVolume = o.OrigQty - o.ExecutedQty
+	}
+	return detail
+}
+
 // GetDepositAddress returns a deposit address for a specified currency
 func (b *Binance) GetDepositAddress(cryptocurrency pair.CurrencyItem) (string, error) {
 	return "", common.ErrNotYetImplemented
@@ -300,3 +667,117 @@ func (b *Binance) GetFeeByType(feeBuilder exchange.FeeBuilder) (float64, error)
 func (b *Binance) GetWithdrawCapabilities() uint32 {
 	return b.GetWithdrawPermissions()
 }
+
+// GetEarnBalances returns the simple earn flexible and locked product balances
+func (b *Binance) GetEarnBalances() ([]exchange.EarnBalance, error) {
+	flexible, err := b.GetSimpleEarnFlexiblePositions()
+	if err != nil {
+		return nil, err
+	}
+	locked, err := b.GetSimpleEarnLockedPositions()
+	if err != nil {
+		return nil, err
+	}
+
+	var balances []exchange.EarnBalance
+	for i := range flexible {
+		balances = append(balances, exchange.EarnBalance{
+			ProductID:       flexible[i].ProductID,
+			ProductType:     exchange.EarnFlexible,
+			Currency:        flexible[i].Asset,
+			Amount:          flexible[i].TotalAmount,
+			AccruedInterest: flexible[i].CumulativeTotalRewards,
+			APR:             flexible[i].LatestAnnualPercentageRate,
+		})
+	}
+	for i := range locked {
+		balances = append(balances, exchange.EarnBalance{
+			ProductID:       strconv.FormatInt(locked[i].PositionID, 10),
+			ProductType:     exchange.EarnLocked,
+			Currency:        locked[i].Asset,
+			Amount:          locked[i].Amount,
+			AccruedInterest: locked[i].RewardAmount,
+			APR:             locked[i].APY,
+		})
+	}
+	return balances, nil
+}
+
+// Stake subscribes to a simple earn locked product and returns the position
+// ID, the product determines the currency staked
+func (b *Binance) Stake(productID, currency string, amount float64) (string, error) {
+	resp, err := b.SubscribeSimpleEarnLocked(productID, amount)
+	if err != nil {
+		return "", err
+	}
+	return resp.PositionID.String(), nil
+}
+
+// Unstake redeems a simple earn locked position
+func (b *Binance) Unstake(positionID string) error {
+	_, err := b.RedeemSimpleEarnLocked(positionID)
+	return err
+}
+
+// GetStakingPositions returns the simple earn locked positions
+func (b *Binance) GetStakingPositions() ([]exchange.StakingPosition, error) {
+	locked, err := b.GetSimpleEarnLockedPositions()
+	if err != nil {
+		return nil, err
+	}
+	positions := make([]exchange.StakingPosition, 0, len(locked))
+	for i := range locked {
+		p := exchange.StakingPosition{
+			PositionID: strconv.FormatInt(locked[i].PositionID, 10),
+			ProductID:  locked[i].ProductID,
+			Currency:   locked[i].Asset,
+			Amount:     locked[i].Amount,
+			APR:        locked[i].APY,
+			Start:      common.UnixTimestampToUTC(locked[i].PurchaseTime),
+		}
+		if locked[i].RewardsEndDate > 0 {
+			p.Unlock = common.UnixTimestampToUTC(locked[i].RewardsEndDate)
+		}
+		positions = append(positions, p)
+	}
+	return positions, nil
+}
+
+// ConvertDust converts the small balances of currencies to BNB
+func (b *Binance) ConvertDust(currencies []string) (exchange.DustConversion, error) {
+	resp, err := b.DustTransfer(currencies)
+	if err != nil {
+		return exchange.DustConversion{}, err
+	}
+	conversion := exchange.DustConversion{
+		Target: dustTarget,
+		Amount: resp.TotalTransfered,
+		Fee:    resp.TotalServiceCharge,
+	}
+	for i := range resp.TransferResult {
+		conversion.Converted = append(conversion.Converted, resp.TransferResult[i].FromAsset)
+	}
+	return conversion, nil
+}
+
+// CheckSystemStatus pings the REST API
+func (b *Binance) CheckSystemStatus() error {
+	return b.Ping()
+}
+
+// GetAPIKeyInfo returns when the API key was created and when its trading
+// permission expires
+func (b *Binance) GetAPIKeyInfo() (exchange.APIKeyInfo, error) {
+	restrictions, err := b.GetAPIRestrictions()
+	if err != nil {
+		return exchange.APIKeyInfo{}, err
+	}
+	var info exchange.APIKeyInfo
+	if restrictions.CreateTime > 0 {
+		info.Created = common.UnixTimestampToUTC(restrictions.CreateTime)
+	}
+	if restrictions.TradingAuthorityExpirationTime > 0 {
+		info.Expiry = common.UnixTimestampToUTC(restrictions.TradingAuthorityExpirationTime)
+	}
+	return info, nil
+}
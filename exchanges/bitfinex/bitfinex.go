@@ -12,6 +12,7 @@ import (
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/config"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/assets"
 	"github.com/thrasher-/gocryptotrader/exchanges/request"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
@@ -94,6 +95,23 @@ func (b *Bitfinex) SetDefaults() {
 	b.RESTPollingDelay = 10
 	b.WebsocketSubdChannels = make(map[int]WebsocketChanInfo)
 	b.APIWithdrawPermissions = exchange.AutoWithdrawCryptoWithAPIPermission | exchange.AutoWithdrawFiatWithAPIPermission
+	b.Features = exchange.Features{
+		CanGetTicker:       true,
+		CanGetOrderbook:    true,
+		CanGetAccountInfo:  true,
+		CanSubmitOrder:     true,
+		CanCancelOrder:     true,
+		CanCancelAllOrders: true,
+		CanStreamTicker:    true,
+		CanStreamOrderbook: true,
+		CanStreamTrades:    true,
+	}
+	b.Features.Orders = map[string]exchange.OrderCapabilities{
+		assets.Spot: {
+			OrderTypes:  []exchange.OrderType{exchange.Limit, exchange.Market},
+			TimeInForce: []exchange.TimeInForce{exchange.GTC},
+		},
+	}
 	b.RequestCurrencyPairFormat.Delimiter = ""
 	b.RequestCurrencyPairFormat.Uppercase = true
 	b.ConfigCurrencyPairFormat.Delimiter = ""
@@ -146,6 +164,27 @@ func (b *Bitfinex) Setup(exch config.ExchangeConfig) {
 		if err != nil {
 			log.Fatal(err)
 		}
+		err = b.SetTLSPins(exch.TLSPins)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = b.SetLocalAddress(exch.LocalAddress)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = b.SetRoundingModes(exch.RoundingModes)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = b.SetRateLimitTiers(exch.RateLimitTiers, exch.AccountTier)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = b.SetHTTPClientHeaders(exch.HTTPHeaders)
+		if err != nil {
+			log.Fatal(err)
+		}
+		b.SetSimulation(exch.Simulate, exch.SimulationBalances)
 		err = b.WebsocketSetup(b.WsConnect,
 			exch.Name,
 			exch.Websocket,
@@ -688,8 +727,8 @@ func (b *Bitfinex) GetOrderStatus(OrderID int64) (Order, error) {
 		b.SendAuthenticatedHTTPRequest("POST", bitfinexOrderStatus, request, &orderStatus)
 }
 
-// GetActiveOrders returns all active orders and statuses
-func (b *Bitfinex) GetActiveOrders() ([]Order, error) {
+// GetOpenOrders returns all active orders and statuses
+func (b *Bitfinex) GetOpenOrders() ([]Order, error) {
 	response := []Order{}
 
 	return response,
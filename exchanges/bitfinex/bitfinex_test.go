@@ -443,15 +443,15 @@ func TestGetOrderStatus(t *testing.T) {
 	}
 }
 
-func TestGetActiveOrders(t *testing.T) {
+func TestGetOpenOrders(t *testing.T) {
 	if b.APIKey == "" || b.APISecret == "" {
 		t.SkipNow()
 	}
 	t.Parallel()
 
-	_, err := b.GetActiveOrders()
+	_, err := b.GetOpenOrders()
 	if err == nil {
-		t.Error("Test Failed - GetActiveOrders() error")
+		t.Error("Test Failed - GetOpenOrders() error")
 	}
 }
 
@@ -132,6 +132,8 @@ func (b *Bitfinex) WsConnect() error {
 
 	var channels = []string{"book", "trades", "ticker"}
 	var Dialer websocket.Dialer
+	Dialer.TLSClientConfig = b.GetTLSConfig()
+	Dialer.NetDial = b.GetWebsocketNetDial()
 	var err error
 
 	if b.Websocket.GetProxyAddress() != "" {
@@ -493,7 +495,7 @@ func (b *Bitfinex) WsDataHandler() {
 
 								b.Websocket.DataHandler <- exchange.TradeData{
 									CurrencyPair: pair.NewCurrencyPairFromString(chanInfo.Pair),
-									Timestamp:    time.Unix(trades[0].Timestamp, 0),
+									Timestamp:    common.UnixTimestampToUTC(trades[0].Timestamp),
 									Price:        trades[0].Price,
 									Amount:       newAmount,
 									Exchange:     b.GetName(),
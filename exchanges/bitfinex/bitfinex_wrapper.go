@@ -1,11 +1,11 @@
 package bitfinex
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/url"
 	"strconv"
-	"sync"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
@@ -14,17 +14,13 @@ import (
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
 
-// Start starts the Bitfinex go routine
-func (b *Bitfinex) Start(wg *sync.WaitGroup) {
-	wg.Add(1)
-	go func() {
-		b.Run()
-		wg.Done()
-	}()
+// Start starts the Bitfinex wrapper, refreshing its currency pairs
+func (b *Bitfinex) Start(ctx context.Context) error {
+	return b.StartWrapper(ctx, b.Run)
 }
 
 // Run implements the Bitfinex wrapper
-func (b *Bitfinex) Run() {
+func (b *Bitfinex) Run(ctx context.Context) error {
 	if b.Verbose {
 		log.Printf("%s Websocket: %s.", b.GetName(), common.IsEnabled(b.Websocket.IsEnabled()))
 		log.Printf("%s polling delay: %ds.\n", b.GetName(), b.RESTPollingDelay)
@@ -40,6 +36,7 @@ func (b *Bitfinex) Run() {
 			log.Printf("%s Failed to update available symbols.\n", b.GetName())
 		}
 	}
+	return nil
 }
 
 // UpdateTicker updates and returns the ticker for a currency pair
@@ -176,6 +173,10 @@ func (b *Bitfinex) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]
 
 // SubmitOrder submits a new order
 func (b *Bitfinex) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	if b.IsSimulated() {
+		return b.SimulateSubmitOrder(p, side, orderType, amount, price, clientID)
+	}
+
 	var submitOrderResponse exchange.SubmitOrderResponse
 	var isBuying bool
 
@@ -199,11 +200,19 @@ func (b *Bitfinex) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, ord
 // ModifyOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (b *Bitfinex) ModifyOrder(action exchange.ModifyOrder) (string, error) {
+	if b.IsSimulated() {
+		return b.SimulateModifyOrder(action)
+	}
+
 	return "", common.ErrFunctionNotSupported
 }
 
 // CancelOrder cancels an order by its corresponding ID number
 func (b *Bitfinex) CancelOrder(order exchange.OrderCancellation) error {
+	if b.IsSimulated() {
+		return b.SimulateCancelOrder(order)
+	}
+
 	orderIDInt, err := strconv.ParseInt(order.OrderID, 10, 64)
 
 	if err != nil {
@@ -217,12 +226,20 @@ func (b *Bitfinex) CancelOrder(order exchange.OrderCancellation) error {
 
 // CancelAllOrders cancels all orders associated with a currency pair
 func (b *Bitfinex) CancelAllOrders(orderCancellation exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
+	if b.IsSimulated() {
+		return b.SimulateCancelAllOrders(orderCancellation)
+	}
+
 	_, err := b.CancelAllExistingOrders()
 	return exchange.CancelAllOrdersResponse{}, err
 }
 
 // GetOrderInfo returns information on a current open order
 func (b *Bitfinex) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
+	if b.IsSimulated() {
+		return b.SimulateGetOrderInfo(orderID)
+	}
+
 	var orderDetail exchange.OrderDetail
 	return orderDetail, common.ErrNotYetImplemented
 }
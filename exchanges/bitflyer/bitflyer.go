@@ -82,6 +82,11 @@ func (b *Bitflyer) SetDefaults() {
 	b.Verbose = false
 	b.RESTPollingDelay = 10
 	b.APIWithdrawPermissions = exchange.WithdrawCryptoViaWebsiteOnly | exchange.AutoWithdrawFiat
+	b.Features = exchange.Features{
+		CanGetTicker:      true,
+		CanGetOrderbook:   true,
+		CanGetAccountInfo: true,
+	}
 	b.RequestCurrencyPairFormat.Delimiter = "_"
 	b.RequestCurrencyPairFormat.Uppercase = true
 	b.ConfigCurrencyPairFormat.Delimiter = "_"
@@ -136,6 +141,27 @@ func (b *Bitflyer) Setup(exch config.ExchangeConfig) {
 		if err != nil {
 			log.Fatal(err)
 		}
+		err = b.SetTLSPins(exch.TLSPins)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = b.SetLocalAddress(exch.LocalAddress)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = b.SetRoundingModes(exch.RoundingModes)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = b.SetRateLimitTiers(exch.RateLimitTiers, exch.AccountTier)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = b.SetHTTPClientHeaders(exch.HTTPHeaders)
+		if err != nil {
+			log.Fatal(err)
+		}
+		b.SetSimulation(exch.Simulate, exch.SimulationBalances)
 	}
 }
 
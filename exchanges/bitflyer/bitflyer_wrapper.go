@@ -1,9 +1,9 @@
 package bitflyer
 
 import (
+	"context"
 	"errors"
 	"log"
-	"sync"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
@@ -12,17 +12,13 @@ import (
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
 
-// Start starts the Bitflyer go routine
-func (b *Bitflyer) Start(wg *sync.WaitGroup) {
-	wg.Add(1)
-	go func() {
-		b.Run()
-		wg.Done()
-	}()
+// Start starts the Bitflyer wrapper, refreshing its currency pairs
+func (b *Bitflyer) Start(ctx context.Context) error {
+	return b.StartWrapper(ctx, b.Run)
 }
 
 // Run implements the Bitflyer wrapper
-func (b *Bitflyer) Run() {
+func (b *Bitflyer) Run(ctx context.Context) error {
 	if b.Verbose {
 		log.Printf("%s Websocket: %s.", b.GetName(), common.IsEnabled(b.Websocket.IsEnabled()))
 		log.Printf("%s polling delay: %ds.\n", b.GetName(), b.RESTPollingDelay)
@@ -46,6 +42,7 @@ func (b *Bitflyer) Run() {
 			}
 		}
 	*/
+	return nil
 }
 
 // UpdateTicker updates and returns the ticker for a currency pair
@@ -154,6 +151,10 @@ func (b *Bitflyer) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]
 
 // SubmitOrder submits a new order
 func (b *Bitflyer) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	if b.IsSimulated() {
+		return b.SimulateSubmitOrder(p, side, orderType, amount, price, clientID)
+	}
+
 	var submitOrderResponse exchange.SubmitOrderResponse
 
 	return submitOrderResponse, common.ErrNotYetImplemented
@@ -162,16 +163,28 @@ func (b *Bitflyer) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, ord
 // ModifyOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (b *Bitflyer) ModifyOrder(action exchange.ModifyOrder) (string, error) {
+	if b.IsSimulated() {
+		return b.SimulateModifyOrder(action)
+	}
+
 	return "", common.ErrFunctionNotSupported
 }
 
 // CancelOrder cancels an order by its corresponding ID number
 func (b *Bitflyer) CancelOrder(order exchange.OrderCancellation) error {
+	if b.IsSimulated() {
+		return b.SimulateCancelOrder(order)
+	}
+
 	return common.ErrNotYetImplemented
 }
 
 // CancelAllOrders cancels all orders associated with a currency pair
 func (b *Bitflyer) CancelAllOrders(orderCancellation exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
+	if b.IsSimulated() {
+		return b.SimulateCancelAllOrders(orderCancellation)
+	}
+
 	// TODO, implement BitFlyer API
 	b.CancelAllExistingOrders()
 	return exchange.CancelAllOrdersResponse{}, common.ErrNotYetImplemented
@@ -179,6 +192,10 @@ func (b *Bitflyer) CancelAllOrders(orderCancellation exchange.OrderCancellation)
 
 // GetOrderInfo returns information on a current open order
 func (b *Bitflyer) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
+	if b.IsSimulated() {
+		return b.SimulateGetOrderInfo(orderID)
+	}
+
 	var orderDetail exchange.OrderDetail
 	return orderDetail, common.ErrNotYetImplemented
 }
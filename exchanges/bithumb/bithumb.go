@@ -15,6 +15,8 @@ import (
 	"github.com/thrasher-/gocryptotrader/config"
 	"github.com/thrasher-/gocryptotrader/currency/symbol"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/assets"
+	"github.com/thrasher-/gocryptotrader/exchanges/fees"
 	"github.com/thrasher-/gocryptotrader/exchanges/request"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
@@ -30,6 +32,7 @@ const (
 	publicTicker             = "/public/ticker/"
 	publicOrderBook          = "/public/orderbook/"
 	publicTransactionHistory = "/public/transaction_history/"
+	publicCandlestick        = "/public/candlestick/"
 
 	// Private API
 	requestsPerSecondPrivateAPI = 10
@@ -51,6 +54,9 @@ const (
 
 	bithumbAuthRate   = 10
 	bithumbUnauthRate = 20
+
+	// bithumbTradeFee is the published base trading fee rate
+	bithumbTradeFee = 0.0015
 )
 
 // Bithumb is the overarching type across the Bithumb package
@@ -65,6 +71,24 @@ func (b *Bithumb) SetDefaults() {
 	b.Verbose = false
 	b.RESTPollingDelay = 10
 	b.APIWithdrawPermissions = exchange.AutoWithdrawCrypto | exchange.AutoWithdrawFiat
+	b.Features = exchange.Features{
+		CanGetTicker:          true,
+		CanGetOrderbook:       true,
+		CanGetHistoricCandles: true,
+		CanGetAccountInfo:     true,
+		CanGetFundingHistory:  true,
+		CanSubmitOrder:        true,
+		CanModifyOrder:        true,
+		CanCancelOrder:        true,
+		CanCancelAllOrders:    true,
+		CanGetActiveOrders:    true,
+		CanGetOrderHistory:    true,
+	}
+	b.Features.Orders = map[string]exchange.OrderCapabilities{
+		assets.Spot: {
+			OrderTypes: []exchange.OrderType{exchange.Market},
+		},
+	}
 	b.RequestCurrencyPairFormat.Delimiter = ""
 	b.RequestCurrencyPairFormat.Uppercase = true
 	b.ConfigCurrencyPairFormat.Delimiter = ""
@@ -80,6 +104,10 @@ func (b *Bithumb) SetDefaults() {
 	b.APIUrlDefault = apiURL
 	b.APIUrl = b.APIUrlDefault
 	b.WebsocketInit()
+	err := fees.Register(b.Name, FeeSchedule)
+	if err != nil {
+		log.Fatal(err)
+	}
 }
 
 // Setup takes in the supplied exchange configuration details and sets params
@@ -118,6 +146,31 @@ func (b *Bithumb) Setup(exch config.ExchangeConfig) {
 		if err != nil {
 			log.Fatal(err)
 		}
+		err = b.SetTLSPins(exch.TLSPins)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = b.SetLocalAddress(exch.LocalAddress)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = b.SetRoundingModes(exch.RoundingModes)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = b.SetRateLimitTiers(exch.RateLimitTiers, exch.AccountTier)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = b.SetHTTPClientHeaders(exch.HTTPHeaders)
+		if err != nil {
+			log.Fatal(err)
+		}
+		b.SetSimulation(exch.Simulate, exch.SimulationBalances)
+		err = fees.SetDiscount(b.Name, exch.FeeDiscount)
+		if err != nil {
+			log.Fatal(err)
+		}
 	}
 }
 
@@ -239,6 +292,68 @@ func (b *Bithumb) GetTransactionHistory(symbol string) (TransactionHistory, erro
 	return response, nil
 }
 
+// GetCandlestick returns the candles of a symbol quoted in a payment
+// currency, interval is one of 1m, 3m, 5m, 10m, 30m, 1h, 6h, 12h or 24h
+func (b *Bithumb) GetCandlestick(symbol, paymentCurrency, interval string) ([]Candlestick, error) {
+	response := struct {
+		Status  string          `json:"status"`
+		Data    [][]interface{} `json:"data"`
+		Message string          `json:"message"`
+	}{}
+	path := fmt.Sprintf("%s%s%s_%s/%s", b.APIUrl, publicCandlestick,
+		common.StringToUpper(symbol), common.StringToUpper(paymentCurrency), interval)
+
+	err := b.SendHTTPRequest(path, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.Status != noError {
+		return nil, errors.New(response.Message)
+	}
+
+	candles := make([]Candlestick, 0, len(response.Data))
+	for _, data := range response.Data {
+		candle, err := parseCandlestick(data)
+		if err != nil {
+			return nil, err
+		}
+		candles = append(candles, candle)
+	}
+	return candles, nil
+}
+
+// parseCandlestick converts a candle of timestamp, open, close, high, low and
+// volume, where prices are returned as strings
+func parseCandlestick(data []interface{}) (Candlestick, error) {
+	if len(data) < 6 {
+		return Candlestick{}, errors.New("invalid candlestick data")
+	}
+	var values [6]float64
+	for x := range values {
+		switch v := data[x].(type) {
+		case float64:
+			values[x] = v
+		case string:
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return Candlestick{}, err
+			}
+			values[x] = f
+		default:
+			return Candlestick{}, errors.New("invalid candlestick value")
+		}
+	}
+	return Candlestick{
+		Time:   time.Unix(0, int64(values[0])*int64(time.Millisecond)),
+		Open:   values[1],
+		Close:  values[2],
+		High:   values[3],
+		Low:    values[4],
+		Volume: values[5],
+	}, nil
+}
+
 // GetAccountInformation returns account information by singular currency
 func (b *Bithumb) GetAccountInformation(currency string) (Account, error) {
 	response := Account{}
@@ -355,11 +470,29 @@ func (b *Bithumb) GetOrders(orderID, transactionType, count, after, currency str
 }
 
 // GetUserTransactions returns customer transactions
-func (b *Bithumb) GetUserTransactions() (UserTransactions, error) {
+//
+// searchGb: transaction type, one of the UserTransaction constants
+// currency: BTC, ETH, DASH, LTC, ETC, XRP, BCH, XMR, ZEC, QTUM, BTG, EOS
+// (default value: BTC)
+// offset: Value : 0 ~ (default : 0)
+// count: Value : 1 ~ 50 (default : 20)
+func (b *Bithumb) GetUserTransactions(searchGb, currency string, offset, count int) (UserTransactions, error) {
 	response := UserTransactions{}
 
+	params := url.Values{}
+	if searchGb != "" {
+		params.Set("searchGb", searchGb)
+	}
+	if currency != "" {
+		params.Set("currency", common.StringToUpper(currency))
+	}
+	params.Set("offset", strconv.Itoa(offset))
+	if count != 0 {
+		params.Set("count", strconv.Itoa(count))
+	}
+
 	return response,
-		b.SendAuthenticatedHTTPRequest(privateUserTrans, nil, &response)
+		b.SendAuthenticatedHTTPRequest(privateUserTrans, params, &response)
 }
 
 // PlaceTrade executes a trade order
@@ -536,7 +669,7 @@ func (b *Bithumb) SendAuthenticatedHTTPRequest(path string, params url.Values, r
 
 	params.Set("endpoint", path)
 	payload := params.Encode()
-	hmacPayload := path + string(0) + payload + string(0) + b.Nonce.String()
+	hmacPayload := path + "\x00" + payload + "\x00" + b.Nonce.String()
 	hmac := common.GetHMAC(common.HashSHA512,
 		[]byte(hmacPayload),
 		[]byte(b.APISecret))
@@ -578,19 +711,25 @@ func (b *Bithumb) SendAuthenticatedHTTPRequest(path string, params url.Values, r
 	return common.JSONDecode(intermediary, result)
 }
 
-// GetFee returns an estimate of fee based on type of transaction
+// GetFee returns an estimate of fee based on type of transaction. Trading fees
+// use the account fee rate when credentials are set, otherwise the published
+// base rate.
 func (b *Bithumb) GetFee(feeBuilder exchange.FeeBuilder) (float64, error) {
 	var fee float64
 
 	switch feeBuilder.FeeType {
 	case exchange.CryptocurrencyTradeFee:
-		fee = calculateTradingFee(feeBuilder.PurchasePrice, feeBuilder.Amount)
+		fee = calculateTradingFee(b.getTradeFeeRate(feeBuilder.FirstCurrency),
+			feeBuilder.PurchasePrice, feeBuilder.Amount)
 	case exchange.CyptocurrencyDepositFee:
 		fee = getDepositFee(feeBuilder.FirstCurrency, feeBuilder.Amount)
 	case exchange.CryptocurrencyWithdrawalFee:
 		fee = getWithdrawalFee(feeBuilder.FirstCurrency)
-	case exchange.InternationalBankWithdrawalFee:
+	case exchange.BankFee, exchange.InternationalBankWithdrawalFee:
 		fee = getWithdrawalFee(feeBuilder.CurrencyItem)
+	case exchange.InternationalBankDepositFee:
+		// KRW deposits are free and no other fiat currency is supported
+		fee = 0
 	}
 	if fee < 0 {
 		fee = 0
@@ -598,11 +737,33 @@ func (b *Bithumb) GetFee(feeBuilder exchange.FeeBuilder) (float64, error) {
 	return fee, nil
 }
 
-// calculateTradingFee returns fee when performing a trade
-func calculateTradingFee(purchasePrice float64, amount float64) float64 {
-	fee := 0.0015
+// getTradeFeeRate returns the account fee rate of a currency, falling back
+// to the published base rate when it cannot be retrieved
+func (b *Bithumb) getTradeFeeRate(currency string) float64 {
+	if !b.AuthenticatedAPISupport || b.APIKey == "" {
+		return b.scheduleTradeFeeRate()
+	}
+	account, err := b.GetAccountInformation(currency)
+	if err != nil || account.Status != noError {
+		return b.scheduleTradeFeeRate()
+	}
+	return account.Data.TradeFee
+}
+
+// scheduleTradeFeeRate returns the fee schedule's rate for the account's
+// trading volume, the published base rate when no schedule is registered.
+// Bithumb charges makers and takers alike, the taker rate is used.
+func (b *Bithumb) scheduleTradeFeeRate() float64 {
+	rate, err := fees.TradeRate(b.Name, false)
+	if err != nil {
+		return bithumbTradeFee
+	}
+	return rate
+}
 
-	return fee * amount * purchasePrice
+// calculateTradingFee returns fee when performing a trade
+func calculateTradingFee(feeRate, purchasePrice, amount float64) float64 {
+	return feeRate * amount * purchasePrice
 }
 
 // getDepositFee returns fee on a currency when depositing small amounts to bithumb
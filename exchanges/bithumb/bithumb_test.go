@@ -2,11 +2,13 @@ package bithumb
 
 import (
 	"testing"
+	"time"
 
 	"github.com/thrasher-/gocryptotrader/config"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
 	"github.com/thrasher-/gocryptotrader/currency/symbol"
 	"github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 )
 
 // Please supply your own keys here for due diligence testing
@@ -77,6 +79,43 @@ func TestGetTransactionHistory(t *testing.T) {
 	}
 }
 
+func TestGetCandlestick(t *testing.T) {
+	t.Parallel()
+	_, err := b.GetCandlestick("btc", "krw", "1h")
+	if err != nil {
+		t.Error("test failed - Bithumb GetCandlestick() error", err)
+	}
+}
+
+func TestParseCandlestick(t *testing.T) {
+	t.Parallel()
+	c, err := parseCandlestick([]interface{}{float64(1536537600000), "7100000", "7150000", "7200000", "7050000", "12.5"})
+	if err != nil {
+		t.Fatal("test failed - Bithumb parseCandlestick() error", err)
+	}
+	if c.Time.Unix() != 1536537600 || c.Open != 7100000 || c.Close != 7150000 ||
+		c.High != 7200000 || c.Low != 7050000 || c.Volume != 12.5 {
+		t.Error("test failed - Bithumb parseCandlestick() incorrect candle", c)
+	}
+	if _, err = parseCandlestick([]interface{}{float64(1)}); err == nil {
+		t.Error("test failed - Bithumb parseCandlestick() expected error")
+	}
+}
+
+func TestGetHistoricCandles(t *testing.T) {
+	t.Parallel()
+	p := pair.NewCurrencyPair("BTC", "KRW")
+	end := time.Now()
+	_, err := b.GetHistoricCandles(p, "SPOT", kline.FourHour, end.Add(-time.Hour*24), end)
+	if err != kline.ErrUnsupportedInterval {
+		t.Error("test failed - Bithumb GetHistoricCandles() expected unsupported interval", err)
+	}
+	_, err = b.GetHistoricCandles(p, "SPOT", kline.OneHour, end.Add(-time.Hour*24), end)
+	if err != nil {
+		t.Error("test failed - Bithumb GetHistoricCandles() error", err)
+	}
+}
+
 func TestGetAccountBalance(t *testing.T) {
 	t.Parallel()
 	if testAPIKey == "" || testAPISecret == "" {
@@ -119,7 +158,7 @@ func TestGetOrders(t *testing.T) {
 
 func TestGetUserTransactions(t *testing.T) {
 	t.Parallel()
-	_, err := b.GetUserTransactions()
+	_, err := b.GetUserTransactions(UserTransactionDeposit, "BTC", 0, 10)
 	if err == nil {
 		t.Error("test failed - Bithumb GetUserTransactions() error", err)
 	}
@@ -273,6 +312,45 @@ func TestGetFee(t *testing.T) {
 		t.Errorf("Test Failed - GetFee() error. Expected: %f, Received: %f", float64(0), resp)
 		t.Error(err)
 	}
+
+	// InternationalBankWithdrawalFee KRW
+	feeBuilder = setFeeBuilder()
+	feeBuilder.FeeType = exchange.InternationalBankWithdrawalFee
+	feeBuilder.CurrencyItem = symbol.KRW
+	if resp, err := b.GetFee(feeBuilder); resp != float64(1000) || err != nil {
+		t.Errorf("Test Failed - GetFee() error. Expected: %f, Received: %f", float64(1000), resp)
+		t.Error(err)
+	}
+
+	// BankFee KRW
+	feeBuilder = setFeeBuilder()
+	feeBuilder.FeeType = exchange.BankFee
+	feeBuilder.CurrencyItem = symbol.KRW
+	if resp, err := b.GetFee(feeBuilder); resp != float64(1000) || err != nil {
+		t.Errorf("Test Failed - GetFee() error. Expected: %f, Received: %f", float64(1000), resp)
+		t.Error(err)
+	}
+
+	// CyptocurrencyDepositFee small amount
+	feeBuilder = setFeeBuilder()
+	feeBuilder.FeeType = exchange.CyptocurrencyDepositFee
+	feeBuilder.Amount = 0.001
+	if resp, err := b.GetFee(feeBuilder); resp != float64(0.001) || err != nil {
+		t.Errorf("Test Failed - GetFee() error. Expected: %f, Received: %f", float64(0.001), resp)
+		t.Error(err)
+	}
+}
+
+func TestFeeSchedule(t *testing.T) {
+	if err := FeeSchedule.Validate(); err != nil {
+		t.Error("Test Failed - FeeSchedule Validate() error", err)
+	}
+	if tier := FeeSchedule.Tier(2000000000); tier.Taker != 0.0012 {
+		t.Error("Test Failed - FeeSchedule Tier() unexpected tier", tier)
+	}
+	if fee, err := FeeSchedule.WithdrawalFee(symbol.KRW); err != nil || fee != 1000 {
+		t.Error("Test Failed - FeeSchedule WithdrawalFee() unexpected fee", fee, err)
+	}
 }
 
 func TestFormatWithdrawPermissions(t *testing.T) {
@@ -401,3 +479,45 @@ func TestModifyOrder(t *testing.T) {
 		t.Error("Test Failed - ModifyOrder() error")
 	}
 }
+
+func TestOrderDetail(t *testing.T) {
+	b.SetDefaults()
+	p := pair.NewCurrencyPair("BTC", "KRW")
+	o := OrderData{OrderID: "C0101000007408440032", OrderCurrency: "btc", PaymentCurrency: "krw",
+		OrderDate: 1538352000000000, Type: "bid", Status: "placed", Units: 2, UnitsRemaining: 0.5, Price: 7000000}
+	d := b.orderDetail(p, &o)
+	if d.ID != o.OrderID || d.BaseCurrency != "BTC" || d.QuoteCurrency != "KRW" || d.OrderSide != string(exchange.Buy) {
+		t.Errorf("Test Failed - orderDetail() unexpected order %+v", d)
+	}
+	if d.OpenVolume != 0.5 || d.ExecutedAmount != 1.5 ||
+		!d.CreationTime.Equal(time.Date(2018, 10, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Test Failed - orderDetail() unexpected amounts %+v", d)
+	}
+
+	o.Type = "ask"
+	o.Status = "completed"
+	if d = b.orderDetail(p, &o); d.OpenVolume != 0 || d.OrderSide != string(exchange.Sell) {
+		t.Errorf("Test Failed - orderDetail() unexpected completed order %+v", d)
+	}
+}
+
+func TestFundHistory(t *testing.T) {
+	b.SetDefaults()
+	tx := UserTransaction{Search: UserTransactionWithdrawal, TransferDate: 1538352000000000,
+		OrderCurrency: "btc", Units: "- 1,000.5", Fee: "0.0005 BTC"}
+	f := b.fundHistory("ETH", &tx)
+	if f.TransferType != "withdrawal" || f.Status != "COMPLETED" || f.Currency != "BTC" ||
+		f.Amount != 1000.5 || f.Fee != 0.0005 ||
+		!f.Timestamp.Equal(time.Date(2018, 10, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Test Failed - fundHistory() unexpected withdrawal %+v", f)
+	}
+
+	tx.Search = UserTransactionWithdrawing
+	if f = b.fundHistory("ETH", &tx); f.Status != "PROCESSING" {
+		t.Errorf("Test Failed - fundHistory() unexpected pending withdrawal %+v", f)
+	}
+	tx = UserTransaction{Search: UserTransactionDeposit, Units: "+ 2"}
+	if f = b.fundHistory("ETH", &tx); f.TransferType != "deposit" || f.Currency != "ETH" || f.Amount != 2 {
+		t.Errorf("Test Failed - fundHistory() unexpected deposit %+v", f)
+	}
+}
@@ -1,6 +1,11 @@
 package bithumb
 
-import "github.com/thrasher-/gocryptotrader/currency/symbol"
+import (
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency/symbol"
+	"github.com/thrasher-/gocryptotrader/exchanges/fees"
+)
 
 // Ticker holds ticker data
 type Ticker struct {
@@ -65,6 +70,16 @@ type TransactionHistory struct {
 	Message string `json:"message"`
 }
 
+// Candlestick holds a candle of a symbol, Volume is in the symbol currency
+type Candlestick struct {
+	Time   time.Time
+	Open   float64
+	Close  float64
+	High   float64
+	Low    float64
+	Volume float64
+}
+
 // Account holds account details
 type Account struct {
 	Status string `json:"status"`
@@ -138,20 +153,38 @@ type OrderData struct {
 
 // UserTransactions holds users full transaction list
 type UserTransactions struct {
-	Status string `json:"status"`
-	Data   []struct {
-		Search       string  `json:"search"`
-		TransferDate int64   `json:"transfer_date"`
-		Units        string  `json:"units"`
-		Price        float64 `json:"price,string"`
-		BTC1KRW      float64 `json:"btc1krw,string"`
-		Fee          string  `json:"fee"`
-		BTCRemain    float64 `json:"btc_remain,string"`
-		KRWRemain    float64 `json:"krw_remain,string"`
-	} `json:"data"`
-	Message string `json:"message"`
+	Status  string            `json:"status"`
+	Data    []UserTransaction `json:"data"`
+	Message string            `json:"message"`
 }
 
+// UserTransaction holds a user transaction, Search is the transaction type
+// and TransferDate is in microseconds
+type UserTransaction struct {
+	Search          string  `json:"search"`
+	TransferDate    int64   `json:"transfer_date,string"`
+	OrderCurrency   string  `json:"order_currency"`
+	PaymentCurrency string  `json:"payment_currency"`
+	Units           string  `json:"units"`
+	Price           float64 `json:"price,string"`
+	BTC1KRW         float64 `json:"btc1krw,string"`
+	Fee             string  `json:"fee"`
+	FeeCurrency     string  `json:"fee_currency"`
+	BTCRemain       float64 `json:"btc_remain,string"`
+	KRWRemain       float64 `json:"krw_remain,string"`
+}
+
+// User transaction types
+const (
+	UserTransactionAll         = "0"
+	UserTransactionBuy         = "1"
+	UserTransactionSell        = "2"
+	UserTransactionWithdrawing = "3"
+	UserTransactionDeposit     = "4"
+	UserTransactionWithdrawal  = "5"
+	UserTransactionKRWDeposit  = "9"
+)
+
 // OrderPlace contains order information
 type OrderPlace struct {
 	Status string `json:"status"`
@@ -223,6 +256,20 @@ type MarketSell struct {
 	Message string `json:"message"`
 }
 
+// FeeSchedule is the published fee schedule, membership tiers by 30 day KRW
+// trading volume. Bithumb fee coupons are not modelled.
+// Prone to change
+var FeeSchedule = fees.Schedule{
+	VolumeCurrency: symbol.KRW,
+	Tiers: []fees.Tier{
+		{MinVolume: 0, Maker: 0.0015, Taker: 0.0015},
+		{MinVolume: 1000000000, Maker: 0.0012, Taker: 0.0012},
+		{MinVolume: 10000000000, Maker: 0.001, Taker: 0.001},
+		{MinVolume: 50000000000, Maker: 0.0008, Taker: 0.0008},
+	},
+	Withdrawal: WithdrawalFees,
+}
+
 // WithdrawalFees the large list of predefined withdrawal fees
 // Prone to change
 var WithdrawalFees = map[string]float64{
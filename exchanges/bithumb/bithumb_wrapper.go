@@ -1,28 +1,28 @@
 package bithumb
 
 import (
+	"context"
 	"fmt"
 	"log"
-	"sync"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
 
-// Start starts the OKEX go routine
-func (b *Bithumb) Start(wg *sync.WaitGroup) {
-	wg.Add(1)
-	go func() {
-		b.Run()
-		wg.Done()
-	}()
+// Start starts the Bithumb wrapper, refreshing its currency pairs
+func (b *Bithumb) Start(ctx context.Context) error {
+	return b.StartWrapper(ctx, b.Run)
 }
 
 // Run implements the OKEX wrapper
-func (b *Bithumb) Run() {
+func (b *Bithumb) Run(ctx context.Context) error {
 	if b.Verbose {
 		log.Printf("%s Websocket: %s. (url: %s).\n", b.GetName(), common.IsEnabled(b.Websocket.IsEnabled()), b.WebsocketURL)
 		log.Printf("%s polling delay: %ds.\n", b.GetName(), b.RESTPollingDelay)
@@ -38,6 +38,7 @@ func (b *Bithumb) Run() {
 			log.Printf("%s Failed to update available symbols.\n", b.GetName())
 		}
 	}
+	return nil
 }
 
 // GetTradingPairs gets the available trading currencies
@@ -151,7 +152,68 @@ func (b *Bithumb) GetAccountInfo() (exchange.AccountInfo, error) {
 // withdrawals
 func (b *Bithumb) GetFundingHistory() ([]exchange.FundHistory, error) {
 	var fundHistory []exchange.FundHistory
-	return fundHistory, common.ErrFunctionNotSupported
+	seen := make(map[string]bool)
+	for _, p := range b.GetEnabledCurrencies() {
+		currency := p.FirstCurrency.Upper().String()
+		if seen[currency] {
+			continue
+		}
+		seen[currency] = true
+		for _, search := range []string{
+			UserTransactionDeposit,
+			UserTransactionWithdrawing,
+			UserTransactionWithdrawal,
+		} {
+			resp, err := b.GetUserTransactions(search, currency, 0, bithumbFundingHistoryLimit)
+			if err != nil {
+				return nil, err
+			}
+			for i := range resp.Data {
+				fundHistory = append(fundHistory, b.fundHistory(currency, &resp.Data[i]))
+			}
+		}
+	}
+	return fundHistory, nil
+}
+
+// bithumbFundingHistoryLimit is the number of transactions queried per
+// currency and transaction type
+const bithumbFundingHistoryLimit = 50
+
+// fundHistory converts a Bithumb deposit or withdrawal transaction of a
+// currency to its funding history, Bithumb doesn't return transaction IDs or
+// chains
+func (b *Bithumb) fundHistory(currency string, t *UserTransaction) exchange.FundHistory {
+	f := exchange.FundHistory{
+		ExchangeName: b.Name,
+		Timestamp:    time.Unix(0, t.TransferDate*int64(time.Microsecond)).UTC(),
+		Currency:     currency,
+		Amount:       parseTransactionAmount(t.Units),
+		Fee:          parseTransactionAmount(t.Fee),
+		TransferType: "withdrawal",
+		Status:       "COMPLETED",
+	}
+	if t.OrderCurrency != "" {
+		f.Currency = common.StringToUpper(t.OrderCurrency)
+	}
+	switch t.Search {
+	case UserTransactionDeposit:
+		f.TransferType = "deposit"
+	case UserTransactionWithdrawing:
+		f.Status = "PROCESSING"
+	}
+	return f
+}
+
+// parseTransactionAmount parses the absolute value of a transaction amount
+// such as "- 1,000.5" or "0.0005 BTC"
+func parseTransactionAmount(amount string) float64 {
+	fields := strings.Fields(strings.TrimLeft(amount, "+- "))
+	if len(fields) == 0 {
+		return 0
+	}
+	v, _ := strconv.ParseFloat(common.ReplaceString(fields[0], ",", "", -1), 64)
+	return v
 }
 
 // GetExchangeHistory returns historic trade data since exchange opening.
@@ -161,8 +223,57 @@ func (b *Bithumb) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]e
 	return resp, common.ErrNotYetImplemented
 }
 
+// klineIntervals maps the candle intervals supported by Bithumb to its chart
+// intervals
+var klineIntervals = map[kline.Interval]string{
+	kline.OneMin:     "1m",
+	kline.ThreeMin:   "3m",
+	kline.FiveMin:    "5m",
+	kline.TenMin:     "10m",
+	kline.ThirtyMin:  "30m",
+	kline.OneHour:    "1h",
+	kline.SixHour:    "6h",
+	kline.TwelveHour: "12h",
+	kline.OneDay:     "24h",
+}
+
+// GetHistoricCandles returns the candles of a currency pair opening between
+// start and end
+func (b *Bithumb) GetHistoricCandles(p pair.CurrencyPair, assetType string, interval kline.Interval, start, end time.Time) ([]kline.Candle, error) {
+	chartInterval, ok := klineIntervals[interval]
+	if !ok {
+		return nil, kline.ErrUnsupportedInterval
+	}
+	if !start.Before(end) {
+		return nil, kline.ErrInvalidTimeRange
+	}
+
+	candlesticks, err := b.GetCandlestick(p.FirstCurrency.String(),
+		p.SecondCurrency.String(), chartInterval)
+	if err != nil {
+		return nil, err
+	}
+
+	candles := make([]kline.Candle, len(candlesticks))
+	for x := range candlesticks {
+		candles[x] = kline.Candle{
+			Time:   candlesticks[x].Time,
+			Open:   candlesticks[x].Open,
+			High:   candlesticks[x].High,
+			Low:    candlesticks[x].Low,
+			Close:  candlesticks[x].Close,
+			Volume: candlesticks[x].Volume,
+		}
+	}
+	return kline.FilterCandles(candles, start, end), nil
+}
+
 // SubmitOrder submits a new order
 func (b *Bithumb) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	if b.IsSimulated() {
+		return b.SimulateSubmitOrder(p, side, orderType, amount, price, clientID)
+	}
+
 	var submitOrderResponse exchange.SubmitOrderResponse
 	var err error
 	var orderID string
@@ -190,6 +301,10 @@ func (b *Bithumb) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orde
 // ModifyOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (b *Bithumb) ModifyOrder(action exchange.ModifyOrder) (string, error) {
+	if b.IsSimulated() {
+		return b.SimulateModifyOrder(action)
+	}
+
 	order, err := b.ModifyTrade(action.OrderID,
 		action.Currency.FirstCurrency.String(),
 		common.StringToLower(action.OrderSide.ToString()),
@@ -205,12 +320,20 @@ func (b *Bithumb) ModifyOrder(action exchange.ModifyOrder) (string, error) {
 
 // CancelOrder cancels an order by its corresponding ID number
 func (b *Bithumb) CancelOrder(order exchange.OrderCancellation) error {
+	if b.IsSimulated() {
+		return b.SimulateCancelOrder(order)
+	}
+
 	_, err := b.CancelTrade(order.Side.ToString(), order.OrderID, order.CurrencyPair.FirstCurrency.String())
 	return err
 }
 
 // CancelAllOrders cancels all orders associated with a currency pair
 func (b *Bithumb) CancelAllOrders(orderCancellation exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
+	if b.IsSimulated() {
+		return b.SimulateCancelAllOrders(orderCancellation)
+	}
+
 	cancelAllOrdersResponse := exchange.CancelAllOrdersResponse{
 		OrderStatus: make(map[string]string),
 	}
@@ -239,10 +362,100 @@ func (b *Bithumb) CancelAllOrders(orderCancellation exchange.OrderCancellation)
 
 // GetOrderInfo returns information on a current open order
 func (b *Bithumb) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
+	if b.IsSimulated() {
+		return b.SimulateGetOrderInfo(orderID)
+	}
+
 	var orderDetail exchange.OrderDetail
 	return orderDetail, common.ErrNotYetImplemented
 }
 
+// Bithumb order query limit and the status of orders still on the book
+const (
+	bithumbOrderQueryLimit = "1000"
+	bithumbOrderPlaced     = "placed"
+)
+
+// GetActiveOrders returns the open orders matching the request
+func (b *Bithumb) GetActiveOrders(req exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	if b.IsSimulated() {
+		return b.SimulateGetOrders(req, true)
+	}
+	return b.getOrders(req, true)
+}
+
+// GetOrderHistory returns the completed and cancelled orders matching the
+// request
+func (b *Bithumb) GetOrderHistory(req exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	if b.IsSimulated() {
+		return b.SimulateGetOrders(req, false)
+	}
+	return b.getOrders(req, false)
+}
+
+// getOrders returns the orders of the requested currencies which are placed
+// when active is true, otherwise those which are not
+func (b *Bithumb) getOrders(req exchange.GetOrdersRequest, active bool) ([]exchange.OrderDetail, error) {
+	var transactionType, after string
+	switch req.OrderSide {
+	case exchange.Buy:
+		transactionType = "bid"
+	case exchange.Sell:
+		transactionType = "ask"
+	}
+	if !req.StartTime.IsZero() {
+		after = strconv.FormatInt(req.StartTime.UnixNano()/int64(time.Millisecond), 10)
+	}
+
+	var orders []exchange.OrderDetail
+	for _, p := range b.GetOrdersRequestPairs(req) {
+		resp, err := b.GetOrders("",
+			transactionType,
+			bithumbOrderQueryLimit,
+			after,
+			p.FirstCurrency.String())
+		if err != nil {
+			return nil, err
+		}
+		for i := range resp.Data {
+			if (resp.Data[i].Status == bithumbOrderPlaced) != active {
+				continue
+			}
+			orders = append(orders, b.orderDetail(p, &resp.Data[i]))
+		}
+	}
+	return exchange.FilterOrders(orders, req), nil
+}
+
+// orderDetail converts a Bithumb order of a currency pair to its order
+// details, order dates are in microseconds
+func (b *Bithumb) orderDetail(p pair.CurrencyPair, o *OrderData) exchange.OrderDetail {
+	detail := exchange.OrderDetail{
+		Exchange:       b.Name,
+		ID:             o.OrderID,
+		BaseCurrency:   p.FirstCurrency.Upper().String(),
+		QuoteCurrency:  p.SecondCurrency.Upper().String(),
+		OrderSide:      string(exchange.Sell),
+		OrderType:      string(exchange.Limit),
+		CreationTime:   time.Unix(0, o.OrderDate*int64(time.Microsecond)).UTC(),
+		Status:         o.Status,
+		Price:          o.Price,
+		Amount:         o.Units,
+		ExecutedAmount: o.Units - o.UnitsRemaining,
+	}
+	if o.OrderCurrency != "" && o.PaymentCurrency != "" {
+		detail.BaseCurrency = common.StringToUpper(o.OrderCurrency)
+		detail.QuoteCurrency = common.StringToUpper(o.PaymentCurrency)
+	}
+	if o.Type == "bid" {
+		detail.OrderSide = string(exchange.Buy)
+	}
+	if o.Status == bithumbOrderPlaced {
+		detail.OpenVolume = o.UnitsRemaining
+	}
+	return detail
+}
+
 // GetDepositAddress returns a deposit address for a specified currency
 func (b *Bithumb) GetDepositAddress(cryptocurrency pair.CurrencyItem) (string, error) {
 	return "", common.ErrNotYetImplemented
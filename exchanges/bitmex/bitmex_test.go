@@ -1,7 +1,7 @@
 package bitmex
 
 import (
-	"sync"
+	"context"
 	"testing"
 	"time"
 
@@ -41,9 +41,13 @@ func TestSetup(t *testing.T) {
 }
 
 func TestStart(t *testing.T) {
-	var testWg sync.WaitGroup
-	b.Start(&testWg)
-	testWg.Wait()
+	err := b.Start(context.Background())
+	if err != nil {
+		t.Error("test failed - Start() error", err)
+	}
+	if err = b.Start(context.Background()); err != exchange.ErrWrapperAlreadyStarted {
+		t.Error("test failed - Start() expected already started error", err)
+	}
 }
 
 func TestGetUrgentAnnouncement(t *testing.T) {
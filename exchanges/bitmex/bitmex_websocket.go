@@ -70,6 +70,8 @@ func (b *Bitmex) WsConnector() error {
 	}
 
 	var dialer websocket.Dialer
+	dialer.TLSClientConfig = b.GetTLSConfig()
+	dialer.NetDial = b.GetWebsocketNetDial()
 	var err error
 
 	if b.Websocket.GetProxyAddress() != "" {
@@ -1,10 +1,11 @@
 package bitmex
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"log"
 	"math"
-	"sync"
 	"time"
 
 	"github.com/thrasher-/gocryptotrader/common"
@@ -14,17 +15,13 @@ import (
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
 
-// Start starts the Bitmex go routine
-func (b *Bitmex) Start(wg *sync.WaitGroup) {
-	wg.Add(1)
-	go func() {
-		b.Run()
-		wg.Done()
-	}()
+// Start starts the Bitmex wrapper, refreshing its currency pairs
+func (b *Bitmex) Start(ctx context.Context) error {
+	return b.StartWrapper(ctx, b.Run)
 }
 
 // Run implements the Bitmex wrapper
-func (b *Bitmex) Run() {
+func (b *Bitmex) Run(ctx context.Context) error {
 	if b.Verbose {
 		log.Printf("%s Websocket: %s. (url: %s).\n", b.GetName(), common.IsEnabled(b.Websocket.IsEnabled()), b.WebsocketURL)
 		log.Printf("%s polling delay: %ds.\n", b.GetName(), b.RESTPollingDelay)
@@ -46,6 +43,7 @@ func (b *Bitmex) Run() {
 			log.Printf("%s Failed to update available currencies.\n", b.GetName())
 		}
 	}
+	return nil
 }
 
 // UpdateTicker updates and returns the ticker for a currency pair
@@ -164,6 +162,10 @@ func (b *Bitmex) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]ex
 
 // SubmitOrder submits a new order
 func (b *Bitmex) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	if b.IsSimulated() {
+		return b.SimulateSubmitOrder(p, side, orderType, amount, price, clientID)
+	}
+
 	var submitOrderResponse exchange.SubmitOrderResponse
 
 	if math.Mod(amount, 1) != 0 {
@@ -197,6 +199,10 @@ func (b *Bitmex) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, order
 // ModifyOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (b *Bitmex) ModifyOrder(action exchange.ModifyOrder) (string, error) {
+	if b.IsSimulated() {
+		return b.SimulateModifyOrder(action)
+	}
+
 	var params OrderAmendParams
 
 	if math.Mod(action.Amount, 1) != 0 {
@@ -217,6 +223,10 @@ func (b *Bitmex) ModifyOrder(action exchange.ModifyOrder) (string, error) {
 
 // CancelOrder cancels an order by its corresponding ID number
 func (b *Bitmex) CancelOrder(order exchange.OrderCancellation) error {
+	if b.IsSimulated() {
+		return b.SimulateCancelOrder(order)
+	}
+
 	var params = OrderCancelParams{
 		OrderID: order.OrderID,
 	}
@@ -227,6 +237,10 @@ func (b *Bitmex) CancelOrder(order exchange.OrderCancellation) error {
 
 // CancelAllOrders cancels all orders associated with a currency pair
 func (b *Bitmex) CancelAllOrders(orderCancellation exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
+	if b.IsSimulated() {
+		return b.SimulateCancelAllOrders(orderCancellation)
+	}
+
 	cancelAllOrdersResponse := exchange.CancelAllOrdersResponse{
 		OrderStatus: make(map[string]string),
 	}
@@ -245,6 +259,10 @@ func (b *Bitmex) CancelAllOrders(orderCancellation exchange.OrderCancellation) (
 
 // GetOrderInfo returns information on a current open order
 func (b *Bitmex) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
+	if b.IsSimulated() {
+		return b.SimulateGetOrderInfo(orderID)
+	}
+
 	var orderDetail exchange.OrderDetail
 	return orderDetail, common.ErrNotYetImplemented
 }
@@ -286,3 +304,32 @@ func (b *Bitmex) GetFeeByType(feeBuilder exchange.FeeBuilder) (float64, error) {
 func (b *Bitmex) GetWithdrawCapabilities() uint32 {
 	return b.GetWithdrawPermissions()
 }
+
+// bitmexIntervalEpoch is the time Bitmex reports intervals relative to, an
+// eight hour funding interval is 2000-01-01T08:00:00.000Z
+var bitmexIntervalEpoch = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// GetFundingRate returns the current funding rate of a perpetual swap
+func (b *Bitmex) GetFundingRate(instrumentID string) (exchange.FundingRate, error) {
+	instruments, err := b.GetActiveInstruments(GenericRequestParams{Symbol: instrumentID})
+	if err != nil {
+		return exchange.FundingRate{}, err
+	}
+	for i := range instruments {
+		if instruments[i].Symbol != instrumentID || instruments[i].FundingInterval == "" {
+			continue
+		}
+		rate := exchange.FundingRate{
+			InstrumentID: instrumentID,
+			Rate:         instruments[i].FundingRate,
+		}
+		if interval, err := time.Parse(time.RFC3339, instruments[i].FundingInterval); err == nil {
+			rate.Interval = interval.Sub(bitmexIntervalEpoch)
+		}
+		if next, err := time.Parse(time.RFC3339, instruments[i].FundingTimestamp); err == nil {
+			rate.NextFunding = next
+		}
+		return rate, nil
+	}
+	return exchange.FundingRate{}, fmt.Errorf("%s perpetual swap %s not found", b.Name, instrumentID)
+}
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"log"
 	"net/url"
-	"reflect"
 	"strconv"
 	"strings"
 	"time"
@@ -14,6 +13,7 @@ import (
 	"github.com/thrasher-/gocryptotrader/config"
 	"github.com/thrasher-/gocryptotrader/currency/symbol"
 	"github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/assets"
 	"github.com/thrasher-/gocryptotrader/exchanges/request"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
@@ -47,8 +47,14 @@ const (
 	bitstampAPITransferFromMain   = "transfer-from-main"
 	bitstampAPIXrpWithdrawal      = "xrp_withdrawal"
 	bitstampAPIXrpDeposit         = "xrp_address"
-	bitstampAPIReturnType         = "string"
 	bitstampAPITradingPairsInfo   = "trading-pairs-info"
+	bitstampAPIWithdrawalOpen     = "withdrawal/open"
+	bitstampAPIWithdrawalStatus   = "withdrawal/status"
+	bitstampAPIWithdrawalCancel   = "withdrawal/cancel"
+
+	// Bank withdrawal types
+	bitstampBankWithdrawalSEPA          = "sepa"
+	bitstampBankWithdrawalInternational = "international"
 
 	bitstampAuthRate   = 600
 	bitstampUnauthRate = 600
@@ -67,7 +73,23 @@ func (b *Bitstamp) SetDefaults() {
 	b.Enabled = false
 	b.Verbose = false
 	b.RESTPollingDelay = 10
-	b.APIWithdrawPermissions = exchange.AutoWithdrawCrypto | exchange.AutoWithdrawFiat
+	b.APIWithdrawPermissions = exchange.AutoWithdrawCryptoWithAPIPermission | exchange.AutoWithdrawFiatWithAPIPermission
+	b.Features = exchange.Features{
+		CanGetTicker:       true,
+		CanGetOrderbook:    true,
+		CanGetAccountInfo:  true,
+		CanSubmitOrder:     true,
+		CanCancelOrder:     true,
+		CanCancelAllOrders: true,
+		CanStreamOrderbook: true,
+		CanStreamTrades:    true,
+	}
+	b.Features.Orders = map[string]exchange.OrderCapabilities{
+		assets.Spot: {
+			OrderTypes:  []exchange.OrderType{exchange.Limit, exchange.Market},
+			TimeInForce: []exchange.TimeInForce{exchange.GTC},
+		},
+	}
 	b.RequestCurrencyPairFormat.Delimiter = ""
 	b.RequestCurrencyPairFormat.Uppercase = true
 	b.ConfigCurrencyPairFormat.Delimiter = ""
@@ -124,6 +146,27 @@ func (b *Bitstamp) Setup(exch config.ExchangeConfig) {
 		if err != nil {
 			log.Fatal(err)
 		}
+		err = b.SetTLSPins(exch.TLSPins)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = b.SetLocalAddress(exch.LocalAddress)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = b.SetRoundingModes(exch.RoundingModes)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = b.SetRateLimitTiers(exch.RateLimitTiers, exch.AccountTier)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = b.SetHTTPClientHeaders(exch.HTTPHeaders)
+		if err != nil {
+			log.Fatal(err)
+		}
+		b.SetSimulation(exch.Simulate, exch.SimulationBalances)
 		err = b.WebsocketSetup(b.WsConnect,
 			exch.Name,
 			exch.Websocket,
@@ -330,15 +373,16 @@ func (b *Bitstamp) GetBalance() (Balances, error) {
 	return balance, b.SendHTTPRequest(path, &balance)
 }
 
-// GetUserTransactions returns an array of transactions
+// GetUserTransactions returns an array of transactions, optionally limited
+// to a currency pair
 func (b *Bitstamp) GetUserTransactions(currencyPair string) ([]UserTransactions, error) {
 	type Response struct {
 		Date    string      `json:"datetime"`
 		TransID int64       `json:"id"`
 		Type    int         `json:"type,string"`
 		USD     interface{} `json:"usd"`
-		EUR     float64     `json:"eur"`
-		XRP     float64     `json:"xrp"`
+		EUR     interface{} `json:"eur"`
+		XRP     interface{} `json:"xrp"`
 		BTC     interface{} `json:"btc"`
 		BTCUSD  interface{} `json:"btc_usd"`
 		Fee     float64     `json:"fee,string"`
@@ -346,57 +390,46 @@ func (b *Bitstamp) GetUserTransactions(currencyPair string) ([]UserTransactions,
 	}
 	response := []Response{}
 
+	path := bitstampAPIUserTransactions
 	if currencyPair != "" {
-		if err := b.SendAuthenticatedHTTPRequest(bitstampAPIUserTransactions, true, url.Values{}, &response); err != nil {
-			return nil, err
-		}
-	} else {
-		if err := b.SendAuthenticatedHTTPRequest(bitstampAPIUserTransactions+"/"+currencyPair, true, url.Values{}, &response); err != nil {
-			return nil, err
-		}
+		path += "/" + common.StringToLower(currencyPair)
+	}
+	if err := b.SendAuthenticatedHTTPRequest(path, true, url.Values{}, &response); err != nil {
+		return nil, err
 	}
 
 	transactions := []UserTransactions{}
-
 	for _, y := range response {
-		tx := UserTransactions{}
-		tx.Date = y.Date
-		tx.TransID = y.TransID
-		tx.Type = y.Type
-
-		/* Hack due to inconsistent JSON values... */
-		varType := reflect.TypeOf(y.USD).String()
-		if varType == bitstampAPIReturnType {
-			tx.USD, _ = strconv.ParseFloat(y.USD.(string), 64)
-		} else {
-			tx.USD = y.USD.(float64)
-		}
-
-		tx.EUR = y.EUR
-		tx.XRP = y.XRP
-
-		varType = reflect.TypeOf(y.BTC).String()
-		if varType == bitstampAPIReturnType {
-			tx.BTC, _ = strconv.ParseFloat(y.BTC.(string), 64)
-		} else {
-			tx.BTC = y.BTC.(float64)
-		}
-
-		varType = reflect.TypeOf(y.BTCUSD).String()
-		if varType == bitstampAPIReturnType {
-			tx.BTCUSD, _ = strconv.ParseFloat(y.BTCUSD.(string), 64)
-		} else {
-			tx.BTCUSD = y.BTCUSD.(float64)
-		}
-
-		tx.Fee = y.Fee
-		tx.OrderID = y.OrderID
-		transactions = append(transactions, tx)
+		transactions = append(transactions, UserTransactions{
+			Date:    y.Date,
+			TransID: y.TransID,
+			Type:    y.Type,
+			USD:     transactionValue(y.USD),
+			EUR:     transactionValue(y.EUR),
+			XRP:     transactionValue(y.XRP),
+			BTC:     transactionValue(y.BTC),
+			BTCUSD:  transactionValue(y.BTCUSD),
+			Fee:     y.Fee,
+			OrderID: y.OrderID,
+		})
 	}
 
 	return transactions, nil
 }
 
+// transactionValue parses a user transaction value, which is returned as
+// either a string or a number and is omitted for unrelated currencies
+func transactionValue(v interface{}) float64 {
+	switch value := v.(type) {
+	case string:
+		f, _ := strconv.ParseFloat(value, 64)
+		return f
+	case float64:
+		return value
+	}
+	return 0
+}
+
 // GetOpenOrders returns all open orders on the exchange
 func (b *Bitstamp) GetOpenOrders(currencyPair string) ([]Order, error) {
 	resp := []Order{}
@@ -577,6 +610,74 @@ func (b *Bitstamp) TransferAccountBalance(amount float64, currency, subAccount s
 	return true, nil
 }
 
+// OpenBankWithdrawal requests a SEPA or international wire withdrawal to a
+// bank account and returns the withdrawal ID, the API key requires the bank
+// withdrawal permission
+func (b *Bitstamp) OpenBankWithdrawal(withdrawal BankWithdrawalRequest) (int64, error) {
+	if withdrawal.Amount <= 0 {
+		return 0, errors.New("withdrawal amount must be greater than zero")
+	}
+	if withdrawal.Name == "" || withdrawal.IBAN == "" || withdrawal.BIC == "" {
+		return 0, errors.New("account holder name, IBAN and BIC are required")
+	}
+
+	var req = url.Values{}
+	req.Add("amount", strconv.FormatFloat(withdrawal.Amount, 'f', -1, 64))
+	req.Add("account_currency", withdrawal.AccountCurrency)
+	req.Add("name", withdrawal.Name)
+	req.Add("iban", withdrawal.IBAN)
+	req.Add("bic", withdrawal.BIC)
+	req.Add("address", withdrawal.Address)
+	req.Add("postal_code", withdrawal.PostalCode)
+	req.Add("city", withdrawal.City)
+	req.Add("country", withdrawal.Country)
+	req.Add("type", withdrawal.Type)
+	if withdrawal.Type == bitstampBankWithdrawalInternational {
+		req.Add("bank_name", withdrawal.BankName)
+		req.Add("bank_address", withdrawal.BankAddress)
+		req.Add("bank_postal_code", withdrawal.BankPostalCode)
+		req.Add("bank_city", withdrawal.BankCity)
+		req.Add("bank_country", withdrawal.BankCountry)
+		req.Add("currency", withdrawal.Currency)
+	}
+	if withdrawal.Comment != "" {
+		req.Add("comment", withdrawal.Comment)
+	}
+
+	resp := struct {
+		WithdrawalID int64       `json:"withdrawal_id"`
+		Status       string      `json:"status"`
+		Reason       interface{} `json:"reason"`
+	}{}
+	err := b.SendAuthenticatedHTTPRequest(bitstampAPIWithdrawalOpen, true, req, &resp)
+	if err != nil {
+		return 0, err
+	}
+	if resp.Status == "error" {
+		return 0, fmt.Errorf("%s bank withdrawal error: %v", b.Name, resp.Reason)
+	}
+	return resp.WithdrawalID, nil
+}
+
+// GetBankWithdrawalStatus returns the status of a bank withdrawal
+func (b *Bitstamp) GetBankWithdrawalStatus(withdrawalID int64) (BankWithdrawalStatus, error) {
+	resp := BankWithdrawalStatus{}
+	var req = url.Values{}
+	req.Add("id", strconv.FormatInt(withdrawalID, 10))
+
+	return resp,
+		b.SendAuthenticatedHTTPRequest(bitstampAPIWithdrawalStatus, true, req, &resp)
+}
+
+// CancelBankWithdrawal cancels a bank withdrawal which has not yet been
+// processed
+func (b *Bitstamp) CancelBankWithdrawal(withdrawalID int64) error {
+	var req = url.Values{}
+	req.Add("id", strconv.FormatInt(withdrawalID, 10))
+
+	return b.SendAuthenticatedHTTPRequest(bitstampAPIWithdrawalCancel, true, req, nil)
+}
+
 // SendHTTPRequest sends an unauthenticated HTTP request
 func (b *Bitstamp) SendHTTPRequest(path string, result interface{}) error {
 	return b.SendPayload("GET", path, nil, nil, result, false, b.Verbose)
@@ -356,10 +356,101 @@ func TestTransferAccountBalance(t *testing.T) {
 	}
 }
 
+func TestOpenBankWithdrawal(t *testing.T) {
+	t.Parallel()
+	_, err := b.OpenBankWithdrawal(BankWithdrawalRequest{Name: "Satoshi", IBAN: "DE89", BIC: "COBADEFF"})
+	if err == nil {
+		t.Error("Test Failed - OpenBankWithdrawal() expected amount error")
+	}
+	_, err = b.OpenBankWithdrawal(BankWithdrawalRequest{Amount: 100, Name: "Satoshi"})
+	if err == nil {
+		t.Error("Test Failed - OpenBankWithdrawal() expected bank details error")
+	}
+}
+
+func TestBankWithdrawalRequest(t *testing.T) {
+	t.Parallel()
+	bd := config.BankAccount{
+		BankName:    "Commerzbank",
+		BankAddress: "Kaiserplatz, Frankfurt",
+		AccountName: "Satoshi Nakamoto",
+		SWIFTCode:   "COBADEFF",
+		IBAN:        "DE89370400440532013000",
+	}
+
+	withdrawal := bankWithdrawalRequest(bd, symbol.EUR, 100, false)
+	if withdrawal.Type != bitstampBankWithdrawalSEPA || withdrawal.BIC != bd.SWIFTCode ||
+		withdrawal.AccountCurrency != symbol.EUR || withdrawal.BankName != "" {
+		t.Error("Test Failed - bankWithdrawalRequest() incorrect SEPA withdrawal", withdrawal)
+	}
+
+	withdrawal = bankWithdrawalRequest(bd, symbol.USD, 100, true)
+	if withdrawal.Type != bitstampBankWithdrawalInternational || withdrawal.Currency != symbol.USD ||
+		withdrawal.BankName != bd.BankName || withdrawal.BankAddress != bd.BankAddress {
+		t.Error("Test Failed - bankWithdrawalRequest() incorrect international withdrawal", withdrawal)
+	}
+}
+
+func TestTransactionValue(t *testing.T) {
+	t.Parallel()
+	if v := transactionValue("-12.50"); v != -12.5 {
+		t.Error("Test Failed - transactionValue() incorrect string value", v)
+	}
+	if v := transactionValue(float64(3)); v != 3 {
+		t.Error("Test Failed - transactionValue() incorrect number value", v)
+	}
+	if v := transactionValue(nil); v != 0 {
+		t.Error("Test Failed - transactionValue() expected zero for omitted value", v)
+	}
+}
+
+func TestFundingHistory(t *testing.T) {
+	t.Parallel()
+	var exch Bitstamp
+	exch.Name = "Bitstamp"
+	history := exch.fundingHistory([]UserTransactions{
+		{Date: "2018-09-20 10:00:00", TransID: 1, Type: UserTransactionDeposit, EUR: 500},
+		{Date: "2018-09-20 11:00:00", TransID: 2, Type: UserTransactionWithdrawal, USD: -200},
+		{Date: "2018-09-20 12:00:00", TransID: 3, Type: UserTransactionMarketTrade, USD: -100, BTC: 0.01},
+		{Date: "2018-09-20 13:00:00.123456", TransID: 4, Type: UserTransactionDeposit, BTC: 0.5},
+		{Date: "2018-09-20 14:00:00", TransID: 5, Type: UserTransactionDeposit, LTC: 2},
+		{Date: "2018-09-20 15:00:00", TransID: 6, Type: UserTransactionDeposit},
+	}, []WithdrawalRequests{
+		{OrderID: 5, Date: "2018-09-21 10:00:00", Type: WithdrawalTypeSEPA, Amount: 100, Status: 1},
+		{OrderID: 6, Date: "2018-09-21 11:00:00", Type: WithdrawalTypeWire, Amount: 200, Status: 2, Currency: "usd"},
+		{OrderID: 7, Date: "2018-09-21 12:00:00", Type: WithdrawalTypeBTC, Amount: 0.1, Status: 4,
+			Currency: "btc", Address: "1Addr", TransactionID: "abc"},
+	})
+	if len(history) != 6 {
+		t.Fatal("Test Failed - fundingHistory() expected three deposits and three withdrawals", history)
+	}
+	if history[0].Currency != symbol.EUR || history[0].Amount != 500 || history[0].TransferType != "deposit" ||
+		history[0].Timestamp != time.Date(2018, 9, 20, 10, 0, 0, 0, time.UTC) {
+		t.Error("Test Failed - fundingHistory() incorrect fiat deposit", history[0])
+	}
+	if history[1].Currency != symbol.BTC || history[1].Timestamp.IsZero() {
+		t.Error("Test Failed - fundingHistory() incorrect crypto deposit", history[1])
+	}
+	if history[2].Currency != symbol.LTC || history[2].Amount != 2 {
+		t.Error("Test Failed - fundingHistory() incorrect LTC deposit", history[2])
+	}
+	if history[3].Currency != symbol.EUR || history[3].BankTo != bitstampBankWithdrawalSEPA ||
+		history[3].Status != "in process" {
+		t.Error("Test Failed - fundingHistory() incorrect SEPA withdrawal", history[3])
+	}
+	if history[4].Currency != symbol.USD || history[4].BankTo != bitstampBankWithdrawalInternational ||
+		history[4].Status != "finished" {
+		t.Error("Test Failed - fundingHistory() incorrect wire withdrawal", history[4])
+	}
+	if history[5].CryptoTxID != "abc" || history[5].CryptoToAddress != "1Addr" || history[5].Status != "failed" {
+		t.Error("Test Failed - fundingHistory() incorrect crypto withdrawal", history[5])
+	}
+}
+
 func TestFormatWithdrawPermissions(t *testing.T) {
 	// Arrange
 	b.SetDefaults()
-	expectedResult := exchange.AutoWithdrawCryptoText + " & " + exchange.AutoWithdrawFiatText
+	expectedResult := exchange.AutoWithdrawCryptoWithAPIPermissionText + " & " + exchange.AutoWithdrawFiatWithAPIPermissionText
 	// Act
 	withdrawPermissions := b.FormatWithdrawPermissions()
 	// Assert
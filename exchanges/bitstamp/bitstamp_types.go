@@ -84,6 +84,9 @@ type UserTransactions struct {
 	EUR     float64 `json:"eur"`
 	BTC     float64 `json:"btc"`
 	XRP     float64 `json:"xrp"`
+	ETH     float64 `json:"eth"`
+	LTC     float64 `json:"ltc"`
+	BCH     float64 `json:"bch"`
 	BTCUSD  float64 `json:"btc_usd"`
 	Fee     float64 `json:"fee,string"`
 	OrderID int64   `json:"order_id"`
@@ -118,8 +121,67 @@ type WithdrawalRequests struct {
 	Amount        float64 `json:"amount,string"`
 	Status        int     `json:"status"`
 	Data          interface{}
-	Address       string `json:"address"`        // Bitcoin withdrawals only
-	TransactionID string `json:"transaction_id"` // Bitcoin withdrawals only
+	Currency      string `json:"currency"`
+	Address       string `json:"address"`        // Crypto withdrawals only
+	TransactionID string `json:"transaction_id"` // Crypto withdrawals only
+}
+
+// User transaction types
+const (
+	UserTransactionDeposit            = 0
+	UserTransactionWithdrawal         = 1
+	UserTransactionMarketTrade        = 2
+	UserTransactionSubAccountTransfer = 14
+)
+
+// Withdrawal request types, SEPA and wire transfers are fiat withdrawals
+const (
+	WithdrawalTypeSEPA = 0
+	WithdrawalTypeBTC  = 1
+	WithdrawalTypeWire = 2
+	WithdrawalTypeXRP  = 14
+	WithdrawalTypeBCH  = 15
+	WithdrawalTypeLTC  = 16
+	WithdrawalTypeETH  = 17
+)
+
+// withdrawalStatus maps withdrawal request statuses to their description
+var withdrawalStatus = map[int]string{
+	0: "open",
+	1: "in process",
+	2: "finished",
+	3: "canceled",
+	4: "failed",
+}
+
+// BankWithdrawalRequest holds the account holder and bank details of a SEPA
+// or international wire withdrawal, bank details and currency are only
+// required for international withdrawals
+type BankWithdrawalRequest struct {
+	Amount          float64
+	AccountCurrency string
+	Name            string
+	IBAN            string
+	BIC             string
+	Address         string
+	PostalCode      string
+	City            string
+	Country         string
+	Type            string
+	BankName        string
+	BankAddress     string
+	BankPostalCode  string
+	BankCity        string
+	BankCountry     string
+	Currency        string
+	Comment         string
+}
+
+// BankWithdrawalStatus holds the status of a bank withdrawal
+type BankWithdrawalStatus struct {
+	Status        string `json:"status"`
+	Reason        string `json:"reason"`
+	TransactionID string `json:"transaction_id"`
 }
 
 // UnconfirmedBTCTransactions holds address information about unconfirmed
@@ -129,7 +129,7 @@ func (b *Bitstamp) WsConnect() error {
 		newOrderbook.Bids = bids
 		newOrderbook.CurrencyPair = p.Pair().String()
 		newOrderbook.Pair = p
-		newOrderbook.LastUpdated = time.Unix(0, orderbookSeed.Timestamp)
+		newOrderbook.LastUpdated = common.UnixTimestampToUTC(orderbookSeed.Timestamp)
 		newOrderbook.AssetType = "SPOT"
 
 		err = b.Websocket.Orderbook.LoadSnapshot(newOrderbook, b.GetName())
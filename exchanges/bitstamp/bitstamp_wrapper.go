@@ -1,31 +1,31 @@
 package bitstamp
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
+	"math"
 	"strconv"
 	"strings"
-	"sync"
+	"time"
 
 	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/config"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
+	"github.com/thrasher-/gocryptotrader/currency/symbol"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
 
-// Start starts the Bitstamp go routine
-func (b *Bitstamp) Start(wg *sync.WaitGroup) {
-	wg.Add(1)
-	go func() {
-		b.Run()
-		wg.Done()
-	}()
+// Start starts the Bitstamp wrapper, refreshing its currency pairs
+func (b *Bitstamp) Start(ctx context.Context) error {
+	return b.StartWrapper(ctx, b.Run)
 }
 
 // Run implements the Bitstamp wrapper
-func (b *Bitstamp) Run() {
+func (b *Bitstamp) Run(ctx context.Context) error {
 	if b.Verbose {
 		log.Printf("%s Websocket: %s.", b.GetName(), common.IsEnabled(b.Websocket.IsEnabled()))
 		log.Printf("%s polling delay: %ds.\n", b.GetName(), b.RESTPollingDelay)
@@ -49,6 +49,7 @@ func (b *Bitstamp) Run() {
 			log.Printf("%s Failed to update available currencies.\n", b.Name)
 		}
 	}
+	return nil
 }
 
 // UpdateTicker updates and returns the ticker for a currency pair
@@ -155,8 +156,96 @@ func (b *Bitstamp) GetAccountInfo() (exchange.AccountInfo, error) {
 // GetFundingHistory returns funding history, deposits and
 // withdrawals
 func (b *Bitstamp) GetFundingHistory() ([]exchange.FundHistory, error) {
+	transactions, err := b.GetUserTransactions("")
+	if err != nil {
+		return nil, err
+	}
+	withdrawals, err := b.GetWithdrawalRequests(0)
+	if err != nil {
+		return nil, err
+	}
+	return b.fundingHistory(transactions, withdrawals), nil
+}
+
+// fundingHistory converts deposits from the user transactions and withdrawal
+// requests, which carry their status and are used instead of withdrawal
+// transactions
+func (b *Bitstamp) fundingHistory(transactions []UserTransactions, withdrawals []WithdrawalRequests) []exchange.FundHistory {
 	var fundHistory []exchange.FundHistory
-	return fundHistory, common.ErrFunctionNotSupported
+	for i := range transactions {
+		if transactions[i].Type != UserTransactionDeposit {
+			continue
+		}
+		currency, amount, err := transactionAmount(transactions[i])
+		if err != nil {
+			log.Printf("%s funding history skipped deposit %d. Err: %s", b.Name, transactions[i].TransID, err)
+			continue
+		}
+		fundHistory = append(fundHistory, exchange.FundHistory{
+			ExchangeName: b.Name,
+			Status:       withdrawalStatus[2],
+			TransferID:   transactions[i].TransID,
+			Timestamp:    parseDateTime(transactions[i].Date),
+			Currency:     currency,
+			Amount:       amount,
+			Fee:          transactions[i].Fee,
+			TransferType: "deposit",
+		})
+	}
+
+	for i := range withdrawals {
+		history := exchange.FundHistory{
+			ExchangeName: b.Name,
+			Status:       withdrawalStatus[withdrawals[i].Status],
+			TransferID:   withdrawals[i].OrderID,
+			Timestamp:    parseDateTime(withdrawals[i].Date),
+			Currency:     common.StringToUpper(withdrawals[i].Currency),
+			Amount:       withdrawals[i].Amount,
+			TransferType: "withdrawal",
+		}
+		switch withdrawals[i].Type {
+		case WithdrawalTypeSEPA:
+			history.BankTo = bitstampBankWithdrawalSEPA
+			if history.Currency == "" {
+				history.Currency = symbol.EUR
+			}
+		case WithdrawalTypeWire:
+			history.BankTo = bitstampBankWithdrawalInternational
+		default:
+			history.CryptoToAddress = withdrawals[i].Address
+			history.CryptoTxID = withdrawals[i].TransactionID
+		}
+		fundHistory = append(fundHistory, history)
+	}
+	return fundHistory
+}
+
+// transactionAmount returns the currency and amount of a deposit or
+// withdrawal transaction, only the transferred currency is set
+func transactionAmount(tx UserTransactions) (string, float64, error) {
+	switch {
+	case tx.USD != 0:
+		return symbol.USD, math.Abs(tx.USD), nil
+	case tx.EUR != 0:
+		return symbol.EUR, math.Abs(tx.EUR), nil
+	case tx.BTC != 0:
+		return symbol.BTC, math.Abs(tx.BTC), nil
+	case tx.XRP != 0:
+		return symbol.XRP, math.Abs(tx.XRP), nil
+	case tx.ETH != 0:
+		return symbol.ETH, math.Abs(tx.ETH), nil
+	case tx.LTC != 0:
+		return symbol.LTC, math.Abs(tx.LTC), nil
+	case tx.BCH != 0:
+		return symbol.BCH, math.Abs(tx.BCH), nil
+	}
+	return "", 0, errors.New("transaction has no amount in a supported currency")
+}
+
+// parseDateTime parses a transaction date time in UTC
+func parseDateTime(date string) time.Time {
+	t, _ := time.Parse("2006-01-02 15:04:05", date)
+	return t
 }
 
 // GetExchangeHistory returns historic trade data since exchange opening.
@@ -168,6 +257,10 @@ func (b *Bitstamp) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]
 
 // SubmitOrder submits a new order
 func (b *Bitstamp) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	if b.IsSimulated() {
+		return b.SimulateSubmitOrder(p, side, orderType, amount, price, clientID)
+	}
+
 	var submitOrderResponse exchange.SubmitOrderResponse
 	buy := side == exchange.Buy
 	market := orderType == exchange.Market
@@ -187,11 +280,19 @@ func (b *Bitstamp) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, ord
 // ModifyOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (b *Bitstamp) ModifyOrder(action exchange.ModifyOrder) (string, error) {
+	if b.IsSimulated() {
+		return b.SimulateModifyOrder(action)
+	}
+
 	return "", common.ErrFunctionNotSupported
 }
 
 // CancelOrder cancels an order by its corresponding ID number
 func (b *Bitstamp) CancelOrder(order exchange.OrderCancellation) error {
+	if b.IsSimulated() {
+		return b.SimulateCancelOrder(order)
+	}
+
 	orderIDInt, err := strconv.ParseInt(order.OrderID, 10, 64)
 
 	if err != nil {
@@ -204,6 +305,10 @@ func (b *Bitstamp) CancelOrder(order exchange.OrderCancellation) error {
 
 // CancelAllOrders cancels all orders associated with a currency pair
 func (b *Bitstamp) CancelAllOrders(orderCancellation exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
+	if b.IsSimulated() {
+		return b.SimulateCancelAllOrders(orderCancellation)
+	}
+
 	isCancelAllSuccessful, err := b.CancelAllExistingOrders()
 	if !isCancelAllSuccessful {
 		err = errors.New("Cancel all failed. Bitstamp provides no further information. Check order status to verify")
@@ -214,6 +319,10 @@ func (b *Bitstamp) CancelAllOrders(orderCancellation exchange.OrderCancellation)
 
 // GetOrderInfo returns information on a current open order
 func (b *Bitstamp) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
+	if b.IsSimulated() {
+		return b.SimulateGetOrderInfo(orderID)
+	}
+
 	var orderDetail exchange.OrderDetail
 	return orderDetail, common.ErrNotYetImplemented
 }
@@ -230,15 +339,50 @@ func (b *Bitstamp) WithdrawCryptocurrencyFunds(address string, cryptocurrency pa
 }
 
 // WithdrawFiatFunds returns a withdrawal ID when a
-// withdrawal is submitted
+// withdrawal is submitted, EUR is withdrawn via SEPA and other currencies via
+// international wire
 func (b *Bitstamp) WithdrawFiatFunds(currency pair.CurrencyItem, amount float64) (string, error) {
-	return "", common.ErrNotYetImplemented
+	return b.withdrawFiat(currency, amount, currency.Upper().String() != symbol.EUR)
 }
 
 // WithdrawFiatFundsToInternationalBank returns a withdrawal ID when a
 // withdrawal is submitted
 func (b *Bitstamp) WithdrawFiatFundsToInternationalBank(currency pair.CurrencyItem, amount float64) (string, error) {
-	return "", common.ErrNotYetImplemented
+	return b.withdrawFiat(currency, amount, true)
+}
+
+// withdrawFiat withdraws to the client bank account configured for the
+// currency
+func (b *Bitstamp) withdrawFiat(currency pair.CurrencyItem, amount float64, international bool) (string, error) {
+	bd, err := b.GetClientBankAccounts(b.Name, currency.Upper().String())
+	if err != nil {
+		return "", err
+	}
+	id, err := b.OpenBankWithdrawal(bankWithdrawalRequest(bd, currency.Upper().String(), amount, international))
+	if err != nil {
+		return "", err
+	}
+	return strconv.FormatInt(id, 10), nil
+}
+
+// bankWithdrawalRequest builds a bank withdrawal from client bank account
+// details
+func bankWithdrawalRequest(bd config.BankAccount, currency string, amount float64, international bool) BankWithdrawalRequest {
+	withdrawal := BankWithdrawalRequest{
+		Amount:          amount,
+		AccountCurrency: currency,
+		Name:            bd.AccountName,
+		IBAN:            bd.IBAN,
+		BIC:             bd.SWIFTCode,
+		Type:            bitstampBankWithdrawalSEPA,
+	}
+	if international {
+		withdrawal.Type = bitstampBankWithdrawalInternational
+		withdrawal.BankName = bd.BankName
+		withdrawal.BankAddress = bd.BankAddress
+		withdrawal.Currency = currency
+	}
+	return withdrawal
 }
 
 // GetWebsocket returns a pointer to the exchange websocket
@@ -48,6 +48,8 @@ func (b *BTCC) WsConnect() error {
 	}
 
 	var dialer websocket.Dialer
+	dialer.TLSClientConfig = b.GetTLSConfig()
+	dialer.NetDial = b.GetWebsocketNetDial()
 	var err error
 
 	if b.Websocket.GetProxyAddress() != "" {
@@ -230,7 +232,7 @@ func (b *BTCC) WsHandleData() {
 				tick.OpenPrice = ticker.Open
 				tick.Pair = pair.NewCurrencyPairFromString(ticker.Symbol)
 				tick.Quantity = ticker.Volume
-				timestamp := time.Unix(ticker.Timestamp, 0)
+				timestamp := common.UnixTimestampToUTC(ticker.Timestamp)
 				tick.Timestamp = timestamp
 
 				b.Websocket.DataHandler <- tick
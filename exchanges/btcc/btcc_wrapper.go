@@ -1,9 +1,9 @@
 package btcc
 
 import (
+	"context"
 	"errors"
 	"log"
-	"sync"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/config"
@@ -13,17 +13,13 @@ import (
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
 
-// Start starts the BTCC go routine
-func (b *BTCC) Start(wg *sync.WaitGroup) {
-	wg.Add(1)
-	go func() {
-		b.Run()
-		wg.Done()
-	}()
+// Start starts the BTCC wrapper, refreshing its currency pairs
+func (b *BTCC) Start(ctx context.Context) error {
+	return b.StartWrapper(ctx, b.Run)
 }
 
 // Run implements the BTCC wrapper
-func (b *BTCC) Run() {
+func (b *BTCC) Run(ctx context.Context) error {
 	if b.Verbose {
 		log.Printf("%s Websocket: %s.", b.GetName(), common.IsEnabled(b.Websocket.IsEnabled()))
 		log.Printf("%s polling delay: %ds.\n", b.GetName(), b.RESTPollingDelay)
@@ -37,7 +33,7 @@ func (b *BTCC) Run() {
 		exchCfg, err := cfg.GetExchangeConfig(b.Name)
 		if err != nil {
 			log.Printf("%s failed to get exchange config. %s\n", b.Name, err)
-			return
+			return nil
 		}
 
 		exchCfg.BaseCurrencies = "USD"
@@ -58,9 +54,10 @@ func (b *BTCC) Run() {
 		err = cfg.UpdateExchangeConfig(exchCfg)
 		if err != nil {
 			log.Printf("%s failed to update config. %s\n", b.Name, err)
-			return
+			return nil
 		}
 	}
+	return nil
 }
 
 // UpdateTicker updates and returns the ticker for a currency pair
@@ -152,6 +149,10 @@ func (b *BTCC) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]exch
 
 // SubmitOrder submits a new order
 func (b *BTCC) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	if b.IsSimulated() {
+		return b.SimulateSubmitOrder(p, side, orderType, amount, price, clientID)
+	}
+
 	var submitOrderResponse exchange.SubmitOrderResponse
 
 	return submitOrderResponse, common.ErrNotYetImplemented
@@ -160,21 +161,37 @@ func (b *BTCC) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderTy
 // ModifyOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (b *BTCC) ModifyOrder(action exchange.ModifyOrder) (string, error) {
+	if b.IsSimulated() {
+		return b.SimulateModifyOrder(action)
+	}
+
 	return "", common.ErrNotYetImplemented
 }
 
 // CancelOrder cancels an order by its corresponding ID number
 func (b *BTCC) CancelOrder(order exchange.OrderCancellation) error {
+	if b.IsSimulated() {
+		return b.SimulateCancelOrder(order)
+	}
+
 	return common.ErrNotYetImplemented
 }
 
 // CancelAllOrders cancels all orders associated with a currency pair
 func (b *BTCC) CancelAllOrders(orderCancellation exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
+	if b.IsSimulated() {
+		return b.SimulateCancelAllOrders(orderCancellation)
+	}
+
 	return exchange.CancelAllOrdersResponse{}, common.ErrNotYetImplemented
 }
 
 // GetOrderInfo returns information on a current open order
 func (b *BTCC) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
+	if b.IsSimulated() {
+		return b.SimulateGetOrderInfo(orderID)
+	}
+
 	var orderDetail exchange.OrderDetail
 	return orderDetail, common.ErrNotYetImplemented
 }
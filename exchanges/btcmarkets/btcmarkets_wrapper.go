@@ -1,11 +1,11 @@
 package btcmarkets
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
 	"strconv"
-	"sync"
 
 	"github.com/thrasher-/gocryptotrader/common"
 
@@ -15,17 +15,13 @@ import (
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
 
-// Start starts the BTC Markets go routine
-func (b *BTCMarkets) Start(wg *sync.WaitGroup) {
-	wg.Add(1)
-	go func() {
-		b.Run()
-		wg.Done()
-	}()
+// Start starts the BTCMarkets wrapper, refreshing its currency pairs
+func (b *BTCMarkets) Start(ctx context.Context) error {
+	return b.StartWrapper(ctx, b.Run)
 }
 
 // Run implements the BTC Markets wrapper
-func (b *BTCMarkets) Run() {
+func (b *BTCMarkets) Run(ctx context.Context) error {
 	if b.Verbose {
 		log.Printf("%s polling delay: %ds.\n", b.GetName(), b.RESTPollingDelay)
 		log.Printf("%s %d currencies enabled: %s.\n", b.GetName(), len(b.EnabledPairs), b.EnabledPairs)
@@ -59,6 +55,7 @@ func (b *BTCMarkets) Run() {
 			log.Printf("%s failed to update currencies. Err: %s", b.Name, err)
 		}
 	}
+	return nil
 }
 
 // UpdateTicker updates and returns the ticker for a currency pair
@@ -156,6 +153,10 @@ func (b *BTCMarkets) GetExchangeHistory(p pair.CurrencyPair, assetType string) (
 
 // SubmitOrder submits a new order
 func (b *BTCMarkets) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	if b.IsSimulated() {
+		return b.SimulateSubmitOrder(p, side, orderType, amount, price, clientID)
+	}
+
 	var submitOrderResponse exchange.SubmitOrderResponse
 	response, err := b.NewOrder(p.FirstCurrency.Upper().String(), p.SecondCurrency.Upper().String(), price, amount, side.ToString(), orderType.ToString(), clientID)
 
@@ -173,11 +174,19 @@ func (b *BTCMarkets) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, o
 // ModifyOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (b *BTCMarkets) ModifyOrder(action exchange.ModifyOrder) (string, error) {
+	if b.IsSimulated() {
+		return b.SimulateModifyOrder(action)
+	}
+
 	return "", common.ErrFunctionNotSupported
 }
 
 // CancelOrder cancels an order by its corresponding ID number
 func (b *BTCMarkets) CancelOrder(order exchange.OrderCancellation) error {
+	if b.IsSimulated() {
+		return b.SimulateCancelOrder(order)
+	}
+
 	orderIDInt, err := strconv.ParseInt(order.OrderID, 10, 64)
 	if err != nil {
 		return err
@@ -189,6 +198,10 @@ func (b *BTCMarkets) CancelOrder(order exchange.OrderCancellation) error {
 
 // CancelAllOrders cancels all orders associated with a currency pair
 func (b *BTCMarkets) CancelAllOrders(orderCancellation exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
+	if b.IsSimulated() {
+		return b.SimulateCancelAllOrders(orderCancellation)
+	}
+
 	cancelAllOrdersResponse := exchange.CancelAllOrdersResponse{
 		OrderStatus: make(map[string]string),
 	}
@@ -223,6 +236,10 @@ func (b *BTCMarkets) CancelAllOrders(orderCancellation exchange.OrderCancellatio
 
 // GetOrderInfo returns information on a current open order
 func (b *BTCMarkets) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
+	if b.IsSimulated() {
+		return b.SimulateGetOrderInfo(orderID)
+	}
+
 	var OrderDetail exchange.OrderDetail
 
 	orders, err := b.GetOrderDetail([]int64{orderID})
@@ -241,7 +258,7 @@ func (b *BTCMarkets) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
 	for _, order := range orders {
 		OrderDetail.Amount = order.Volume
 		OrderDetail.BaseCurrency = order.Currency
-		OrderDetail.CreationTime = int64(order.CreationTime)
+		OrderDetail.CreationTime = common.UnixTimestampToUTC(int64(order.CreationTime))
 		OrderDetail.Exchange = b.GetName()
 		OrderDetail.ID = order.ID
 		OrderDetail.OpenVolume = order.OpenVolume
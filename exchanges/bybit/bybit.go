@@ -0,0 +1,811 @@
+package bybit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/config"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/assets"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/request"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+const (
+	bybitAPIURL        = "https://api.bybit.com"
+	bybitTestnetAPIURL = "https://api-testnet.bybit.com"
+	bybitAPIVersion    = "/v5/"
+
+	// Public endpoints
+	bybitInstruments = "market/instruments-info"
+	bybitServerTime  = "market/time"
+	bybitTickers     = "market/tickers"
+	bybitOrderbook   = "market/orderbook"
+	bybitTrades      = "market/recent-trade"
+	bybitKlines      = "market/kline"
+
+	// Authenticated endpoints
+	bybitWalletBalance     = "account/wallet-balance"
+	bybitFeeRate           = "account/fee-rate"
+	bybitPlaceOrder        = "order/create"
+	bybitAmendOrder        = "order/amend"
+	bybitCancelOrder       = "order/cancel"
+	bybitCancelAllOrders   = "order/cancel-all"
+	bybitOpenOrders        = "order/realtime"
+	bybitOrderHistory      = "order/history"
+	bybitPositions         = "position/list"
+	bybitCoinInfo          = "asset/coin/query-info"
+	bybitDepositAddress    = "asset/deposit/query-address"
+	bybitWithdraw          = "asset/withdraw/create"
+	bybitDepositRecords    = "asset/deposit/query-record"
+	bybitWithdrawalRecords = "asset/withdraw/query-record"
+	bybitAPIKeyInfo        = "user/query-api"
+
+	// Bybit allows 600 public requests per 5 seconds per IP and 10 requests
+	// per second on most private endpoints
+	bybitAuthRate   = 10
+	bybitUnauthRate = 600
+
+	bybitRecvWindow  = "5000"
+	bybitSuccessCode = 0
+
+	bybitOrdersLimit = 50
+	bybitKlineLimit  = 1000
+
+	// Spot fee rates of the lowest regular user tier
+	bybitDefaultMakerFee = 0.001
+	bybitDefaultTakerFee = 0.001
+)
+
+// Bybit is the overarching type across the Bybit package
+type Bybit struct {
+	exchange.Base
+
+	// Testnet routes requests to the Bybit testnet
+	Testnet bool
+
+	wsConns     map[string]*wsConnection
+	wsConnsLock sync.Mutex
+	wsTickers   map[string]*Ticker
+
+	wsBuffers     map[string]*orderbook.Buffer
+	wsBuffersLock sync.Mutex
+
+	instruments     map[string][]Instrument
+	instrumentsLock sync.Mutex
+}
+
+// SetDefaults sets the basic defaults for Bybit
+func (b *Bybit) SetDefaults() {
+	b.Name = "Bybit"
+	b.Enabled = false
+	b.Verbose = false
+	b.RESTPollingDelay = 10
+	b.APIWithdrawPermissions = exchange.AutoWithdrawCryptoWithAPIPermission
+	b.BrokerTagFormat = exchange.BrokerTagFormat{MaxLength: 32}
+	b.Features = exchange.Features{
+		CanGetTicker:          true,
+		CanGetOrderbook:       true,
+		CanGetTradeHistory:    true,
+		CanGetHistoricCandles: true,
+		CanGetAccountInfo:     true,
+		CanGetFundingHistory:  true,
+		CanSubmitOrder:        true,
+		IdempotentClientID:    true,
+		CanModifyOrder:        true,
+		CanCancelOrder:        true,
+		CanCancelAllOrders:    true,
+		CanGetOrderInfo:       true,
+		CanGetActiveOrders:    true,
+		CanGetOrderHistory:    true,
+		CanGetDepositAddress:  true,
+		CanWithdrawCrypto:     true,
+		CanStreamTicker:       true,
+		CanStreamOrderbook:    true,
+		CanStreamTrades:       true,
+	}
+	b.Features.Orders = map[string]exchange.OrderCapabilities{
+		assets.Spot: {
+			OrderTypes:  []exchange.OrderType{exchange.Limit, exchange.Market, exchange.ImmediateOrCancel},
+			TimeInForce: []exchange.TimeInForce{exchange.GTC, exchange.IOC, exchange.FOK, exchange.PostOnly},
+		},
+		assets.Futures: {
+			OrderTypes:  []exchange.OrderType{exchange.Limit, exchange.Market},
+			TimeInForce: []exchange.TimeInForce{exchange.GTC, exchange.IOC, exchange.FOK, exchange.PostOnly},
+		},
+	}
+	b.RequestCurrencyPairFormat.Delimiter = ""
+	b.RequestCurrencyPairFormat.Uppercase = true
+	b.ConfigCurrencyPairFormat.Delimiter = "-"
+	b.ConfigCurrencyPairFormat.Uppercase = true
+	b.SupportsAutoPairUpdating = true
+	b.SupportsRESTTickerBatching = true
+	b.Requester = request.New(b.Name,
+		request.NewRateLimit(time.Second, bybitAuthRate),
+		request.NewRateLimit(time.Second*5, bybitUnauthRate),
+		common.NewHTTPClientWithTimeout(exchange.DefaultHTTPTimeout))
+	b.APIUrlDefault = bybitAPIURL
+	b.APIUrl = b.APIUrlDefault
+	b.AssetTypes = []string{ticker.Spot,
+		assets.Margin,
+		assets.PerpetualSwap,
+		assets.Futures}
+	// Pairs of every asset type share the spot pair formats, futures pairs
+	// are the underlying coin against the quote coin of the dated contracts
+	b.AssetPairs = map[string]*exchange.AssetPairs{
+		assets.Margin: {
+			AvailablePairs: []string{"BTC-USDT", "ETH-USDT"},
+			EnabledPairs:   []string{"BTC-USDT"},
+		},
+		assets.PerpetualSwap: {
+			AvailablePairs: []string{"BTC-USDT", "ETH-USDT"},
+			EnabledPairs:   []string{"BTC-USDT"},
+		},
+		assets.Futures: {
+			AvailablePairs: []string{"BTC-USD", "ETH-USD"},
+			EnabledPairs:   []string{"BTC-USD"},
+		},
+	}
+	b.wsConns = make(map[string]*wsConnection)
+	b.wsTickers = make(map[string]*Ticker)
+	b.instruments = make(map[string][]Instrument)
+	b.WebsocketInit()
+}
+
+// Setup takes in the supplied exchange configuration details and sets params
+func (b *Bybit) Setup(exch config.ExchangeConfig) {
+	if !exch.Enabled {
+		b.SetEnabled(false)
+	} else {
+		b.Enabled = true
+		b.AuthenticatedAPISupport = exch.AuthenticatedAPISupport
+		b.SetAPIKeys(exch.APIKey, exch.APISecret, "", false)
+		b.SetHTTPClientTimeout(exch.HTTPTimeout)
+		b.SetHTTPClientUserAgent(exch.HTTPUserAgent)
+		b.RESTPollingDelay = exch.RESTPollingDelay
+		b.Verbose = exch.Verbose
+		b.Testnet = exch.UseSandbox
+		b.BaseCurrencies = common.SplitStrings(exch.BaseCurrencies, ",")
+		b.AvailablePairs = common.SplitStrings(exch.AvailablePairs, ",")
+		b.EnabledPairs = common.SplitStrings(exch.EnabledPairs, ",")
+		err := b.SetCurrencyPairFormat()
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = b.SetAssetTypes()
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = b.SetAutoPairDefaults()
+		if err != nil {
+			log.Fatal(err)
+		}
+		if b.Testnet {
+			b.APIUrl = bybitTestnetAPIURL
+		}
+		err = b.SetAPIURL(exch)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = b.SetClientProxyAddress(exch.ProxyAddress)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = b.SetTLSPins(exch.TLSPins)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = b.SetLocalAddress(exch.LocalAddress)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = b.SetRoundingModes(exch.RoundingModes)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = b.SetRateLimitTiers(exch.RateLimitTiers, exch.AccountTier)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = b.SetHTTPClientHeaders(exch.HTTPHeaders)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = b.SetBrokerTag(exch.BrokerTag)
+		if err != nil {
+			log.Fatal(err)
+		}
+		b.SetSimulation(exch.Simulate, exch.SimulationBalances)
+		wsDefaultURL := bybitWebsocketPublicURL + CategorySpot
+		if b.Testnet {
+			wsDefaultURL = bybitWsTestnetPublicURL + CategorySpot
+		}
+		err = b.WebsocketSetup(b.WsConnect,
+			exch.Name,
+			exch.Websocket,
+			wsDefaultURL,
+			exch.WebsocketURL)
+		if err != nil {
+			log.Fatal(err)
+		}
+		b.Websocket.SetSubscriber(b.wsDefaultSubscriptions, b.WsSubscribe, b.WsUnsubscribe)
+	}
+}
+
+// GetInstruments returns the instruments of a category, paging through the
+// instruments endpoint
+func (b *Bybit) GetInstruments(category string) ([]Instrument, error) {
+	var instruments []Instrument
+	var cursor string
+	for {
+		var resp struct {
+			List           []Instrument `json:"list"`
+			NextPageCursor string       `json:"nextPageCursor"`
+		}
+		params := url.Values{}
+		params.Set("category", category)
+		params.Set("limit", "1000")
+		if cursor != "" {
+			params.Set("cursor", cursor)
+		}
+
+		path := common.EncodeURLValues(b.APIUrl+bybitAPIVersion+bybitInstruments, params)
+		err := b.SendHTTPRequest(path, &resp)
+		if err != nil {
+			return nil, err
+		}
+		instruments = append(instruments, resp.List...)
+		// Spot instruments are returned in a single page with no cursor
+		if resp.NextPageCursor == "" || resp.NextPageCursor == cursor {
+			return instruments, nil
+		}
+		cursor = resp.NextPageCursor
+	}
+}
+
+// GetServerTime returns the Bybit server time
+func (b *Bybit) GetServerTime() (time.Time, error) {
+	var resp struct {
+		TimeNano string `json:"timeNano"`
+	}
+
+	err := b.SendHTTPRequest(b.APIUrl+bybitAPIVersion+bybitServerTime, &resp)
+	if err != nil {
+		return time.Time{}, err
+	}
+	ns, err := strconv.ParseInt(resp.TimeNano, 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(0, ns).UTC(), nil
+}
+
+// GetTickers returns the tickers of a category, symbol is optional and
+// required for inverse futures
+func (b *Bybit) GetTickers(category, symbol string) ([]Ticker, error) {
+	return b.GetTickersContext(context.Background(), category, symbol)
+}
+
+// GetTickersContext returns the tickers of a category, cancelled with the
+// context
+func (b *Bybit) GetTickersContext(ctx context.Context, category, symbol string) ([]Ticker, error) {
+	var resp struct {
+		List []Ticker `json:"list"`
+	}
+	params := url.Values{}
+	params.Set("category", category)
+	if symbol != "" {
+		params.Set("symbol", symbol)
+	}
+
+	path := common.EncodeURLValues(b.APIUrl+bybitAPIVersion+bybitTickers, params)
+	return resp.List, b.SendHTTPRequestContext(ctx, path, &resp)
+}
+
+// GetOrderbook returns the orderbook of a symbol, depth is capped at 200
+// levels per side for spot and 500 for derivatives
+func (b *Bybit) GetOrderbook(category, symbol string, depth int64) (Orderbook, error) {
+	return b.GetOrderbookContext(context.Background(), category, symbol, depth)
+}
+
+// GetOrderbookContext returns the orderbook of a symbol, cancelled with the
+// context
+func (b *Bybit) GetOrderbookContext(ctx context.Context, category, symbol string, depth int64) (Orderbook, error) {
+	var resp OrderbookResponse
+	params := url.Values{}
+	params.Set("category", category)
+	params.Set("symbol", symbol)
+	if depth > 0 {
+		params.Set("limit", strconv.FormatInt(depth, 10))
+	}
+
+	path := common.EncodeURLValues(b.APIUrl+bybitAPIVersion+bybitOrderbook, params)
+	err := b.SendHTTPRequestContext(ctx, path, &resp)
+	if err != nil {
+		return Orderbook{}, err
+	}
+	return parseOrderbook(&resp)
+}
+
+// parseOrderbook converts raw orderbook levels
+func parseOrderbook(raw *OrderbookResponse) (Orderbook, error) {
+	ob := Orderbook{Timestamp: raw.Timestamp.Time()}
+	var err error
+	ob.Bids, err = parseOrderbookLevels(raw.Bids)
+	if err != nil {
+		return ob, err
+	}
+	ob.Asks, err = parseOrderbookLevels(raw.Asks)
+	return ob, err
+}
+
+// parseOrderbookLevels converts [price, size] levels
+func parseOrderbookLevels(levels [][2]string) ([]OrderbookItem, error) {
+	items := make([]OrderbookItem, len(levels))
+	for i := range levels {
+		price, err := strconv.ParseFloat(levels[i][0], 64)
+		if err != nil {
+			return nil, err
+		}
+		amount, err := strconv.ParseFloat(levels[i][1], 64)
+		if err != nil {
+			return nil, err
+		}
+		items[i] = OrderbookItem{Price: price, Amount: amount}
+	}
+	return items, nil
+}
+
+// GetTrades returns recent public trades of a symbol, limit is capped at 60
+// for spot and 1000 for derivatives
+func (b *Bybit) GetTrades(category, symbol string, limit int64) ([]Trade, error) {
+	var resp struct {
+		List []Trade `json:"list"`
+	}
+	params := url.Values{}
+	params.Set("category", category)
+	params.Set("symbol", symbol)
+	if limit > 0 {
+		params.Set("limit", strconv.FormatInt(limit, 10))
+	}
+
+	path := common.EncodeURLValues(b.APIUrl+bybitAPIVersion+bybitTrades, params)
+	return resp.List, b.SendHTTPRequest(path, &resp)
+}
+
+// GetKlines returns up to 1000 candles of a symbol, newest first. Interval is
+// one of 1 3 5 15 30 60 120 240 360 720 D W M, start and end are optional.
+func (b *Bybit) GetKlines(category, symbol, interval string, start, end time.Time, limit int64) ([]Candle, error) {
+	var resp struct {
+		List [][]string `json:"list"`
+	}
+	params := url.Values{}
+	params.Set("category", category)
+	params.Set("symbol", symbol)
+	params.Set("interval", interval)
+	if !start.IsZero() {
+		params.Set("start", strconv.FormatInt(common.UnixMillis(start), 10))
+	}
+	if !end.IsZero() {
+		params.Set("end", strconv.FormatInt(common.UnixMillis(end), 10))
+	}
+	if limit > 0 {
+		params.Set("limit", strconv.FormatInt(limit, 10))
+	}
+
+	path := common.EncodeURLValues(b.APIUrl+bybitAPIVersion+bybitKlines, params)
+	err := b.SendHTTPRequest(path, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return parseKlines(resp.List)
+}
+
+// parseKlines converts [start, open, high, low, close, volume, turnover]
+// candles
+func parseKlines(raw [][]string) ([]Candle, error) {
+	candles := make([]Candle, 0, len(raw))
+	for i := range raw {
+		if len(raw[i]) < 6 {
+			return nil, fmt.Errorf("unexpected candle length %d", len(raw[i]))
+		}
+
+		var values [6]float64
+		for j := 0; j < 6; j++ {
+			v, err := strconv.ParseFloat(raw[i][j], 64)
+			if err != nil {
+				return nil, err
+			}
+			values[j] = v
+		}
+
+		candles = append(candles, Candle{
+			Timestamp: common.UnixTimestampToUTC(int64(values[0])),
+			Open:      values[1],
+			High:      values[2],
+			Low:       values[3],
+			Close:     values[4],
+			Volume:    values[5],
+		})
+	}
+	return candles, nil
+}
+
+// GetWalletBalance returns the unified trading account balance, coin is
+// optional and may be a comma separated list
+func (b *Bybit) GetWalletBalance(coin string) ([]WalletBalance, error) {
+	return b.GetWalletBalanceContext(context.Background(), coin)
+}
+
+// GetWalletBalanceContext returns the unified trading account balance,
+// cancelled with the context
+func (b *Bybit) GetWalletBalanceContext(ctx context.Context, coin string) ([]WalletBalance, error) {
+	var resp struct {
+		List []WalletBalance `json:"list"`
+	}
+	params := url.Values{}
+	params.Set("accountType", "UNIFIED")
+	if coin != "" {
+		params.Set("coin", coin)
+	}
+	return resp.List, b.SendAuthenticatedHTTPRequestContext(ctx, "GET", bybitWalletBalance, params, nil, &resp)
+}
+
+// GetFeeRate returns the account fee rates of a category, symbol is optional
+func (b *Bybit) GetFeeRate(category, symbol string) ([]FeeRate, error) {
+	var resp struct {
+		List []FeeRate `json:"list"`
+	}
+	params := url.Values{}
+	params.Set("category", category)
+	if symbol != "" {
+		params.Set("symbol", symbol)
+	}
+	return resp.List, b.SendAuthenticatedHTTPRequest("GET", bybitFeeRate, params, nil, &resp)
+}
+
+// PlaceOrder places a new order
+func (b *Bybit) PlaceOrder(arg PlaceOrderRequest) (OrderResponse, error) {
+	return b.PlaceOrderContext(context.Background(), arg)
+}
+
+// PlaceOrderContext places a new order, cancelled with the context
+func (b *Bybit) PlaceOrderContext(ctx context.Context, arg PlaceOrderRequest) (OrderResponse, error) {
+	var resp OrderResponse
+	return resp, b.SendAuthenticatedHTTPRequestContext(ctx, "POST", bybitPlaceOrder, nil, arg, &resp)
+}
+
+// AmendOrder amends the quantity and/or price of an open order
+func (b *Bybit) AmendOrder(arg AmendOrderRequest) (OrderResponse, error) {
+	var resp OrderResponse
+	return resp, b.SendAuthenticatedHTTPRequest("POST", bybitAmendOrder, nil, arg, &resp)
+}
+
+// CancelExistingOrder cancels an order by order ID or order link ID
+func (b *Bybit) CancelExistingOrder(arg CancelOrderRequest) (OrderResponse, error) {
+	return b.CancelExistingOrderContext(context.Background(), arg)
+}
+
+// CancelExistingOrderContext cancels an order by order ID or order link ID,
+// cancelled with the context
+func (b *Bybit) CancelExistingOrderContext(ctx context.Context, arg CancelOrderRequest) (OrderResponse, error) {
+	var resp OrderResponse
+	return resp, b.SendAuthenticatedHTTPRequestContext(ctx, "POST", bybitCancelOrder, nil, arg, &resp)
+}
+
+// CancelAll cancels all open orders of a category and returns the cancelled
+// orders
+func (b *Bybit) CancelAll(arg CancelAllRequest) ([]OrderResponse, error) {
+	var resp struct {
+		List []OrderResponse `json:"list"`
+	}
+	return resp.List, b.SendAuthenticatedHTTPRequest("POST", bybitCancelAllOrders, nil, arg, &resp)
+}
+
+// GetOpenOrders returns the open orders of a category, symbol and order ID
+// are optional
+func (b *Bybit) GetOpenOrders(category, symbol, orderID string) ([]Order, error) {
+	return b.getOrders(bybitOpenOrders, category, symbol, orderID)
+}
+
+// GetClosedOrders returns the closed orders of a category from the last 7
+// days, symbol and order ID are optional
+func (b *Bybit) GetClosedOrders(category, symbol, orderID string) ([]Order, error) {
+	return b.getOrders(bybitOrderHistory, category, symbol, orderID)
+}
+
+// getOrders pages through an order query endpoint
+func (b *Bybit) getOrders(endpoint, category, symbol, orderID string) ([]Order, error) {
+	var orders []Order
+	var cursor string
+	for {
+		var resp struct {
+			List           []Order `json:"list"`
+			NextPageCursor string  `json:"nextPageCursor"`
+		}
+		params := url.Values{}
+		params.Set("category", category)
+		params.Set("limit", strconv.Itoa(bybitOrdersLimit))
+		if symbol != "" {
+			params.Set("symbol", symbol)
+		}
+		if orderID != "" {
+			params.Set("orderId", orderID)
+		}
+		if cursor != "" {
+			params.Set("cursor", cursor)
+		}
+
+		err := b.SendAuthenticatedHTTPRequest("GET", endpoint, params, nil, &resp)
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, resp.List...)
+		if resp.NextPageCursor == "" || resp.NextPageCursor == cursor || len(resp.List) == 0 {
+			return orders, nil
+		}
+		cursor = resp.NextPageCursor
+	}
+}
+
+// GetPositions returns the open positions of a linear or inverse category.
+// Symbol or settle coin is required for linear positions.
+func (b *Bybit) GetPositions(category, symbol, settleCoin string) ([]Position, error) {
+	var resp struct {
+		List []Position `json:"list"`
+	}
+	params := url.Values{}
+	params.Set("category", category)
+	if symbol != "" {
+		params.Set("symbol", symbol)
+	}
+	if settleCoin != "" {
+		params.Set("settleCoin", settleCoin)
+	}
+	return resp.List, b.SendAuthenticatedHTTPRequest("GET", bybitPositions, params, nil, &resp)
+}
+
+// GetCoinInfo returns the deposit and withdrawal chains of a coin, all coins
+// are returned when empty
+func (b *Bybit) GetCoinInfo(coin string) ([]CoinInfo, error) {
+	var resp struct {
+		Rows []CoinInfo `json:"rows"`
+	}
+	params := url.Values{}
+	if coin != "" {
+		params.Set("coin", coin)
+	}
+	return resp.Rows, b.SendAuthenticatedHTTPRequest("GET", bybitCoinInfo, params, nil, &resp)
+}
+
+// GetDepositAddresses returns the deposit addresses of a coin on all chains
+func (b *Bybit) GetDepositAddresses(coin string) (DepositAddress, error) {
+	var resp DepositAddress
+	params := url.Values{}
+	params.Set("coin", coin)
+	return resp, b.SendAuthenticatedHTTPRequest("GET", bybitDepositAddress, params, nil, &resp)
+}
+
+// Withdraw submits an on chain withdrawal and returns its ID
+func (b *Bybit) Withdraw(arg WithdrawalRequest) (string, error) {
+	var resp struct {
+		ID string `json:"id"`
+	}
+	if arg.Timestamp == 0 {
+		arg.Timestamp = common.UnixMillis(time.Now())
+	}
+	return resp.ID, b.SendAuthenticatedHTTPRequest("POST", bybitWithdraw, nil, arg, &resp)
+}
+
+// GetDepositRecords returns deposits from the last 30 days, coin is optional
+func (b *Bybit) GetDepositRecords(coin string) ([]DepositRecord, error) {
+	var resp struct {
+		Rows []DepositRecord `json:"rows"`
+	}
+	params := url.Values{}
+	if coin != "" {
+		params.Set("coin", coin)
+	}
+	return resp.Rows, b.SendAuthenticatedHTTPRequest("GET", bybitDepositRecords, params, nil, &resp)
+}
+
+// GetWithdrawalRecords returns withdrawals from the last 30 days, coin is
+// optional
+func (b *Bybit) GetWithdrawalRecords(coin string) ([]WithdrawalRecord, error) {
+	var resp struct {
+		Rows []WithdrawalRecord `json:"rows"`
+	}
+	params := url.Values{}
+	if coin != "" {
+		params.Set("coin", coin)
+	}
+	return resp.Rows, b.SendAuthenticatedHTTPRequest("GET", bybitWithdrawalRecords, params, nil, &resp)
+}
+
+// GetAPIKeyInformation returns the permissions of the API key in use, when it
+// was created and when it expires
+func (b *Bybit) GetAPIKeyInformation() (APIKeyInformation, error) {
+	var resp APIKeyInformation
+	return resp, b.SendAuthenticatedHTTPRequest("GET", bybitAPIKeyInfo, nil, nil, &resp)
+}
+
+// SendHTTPRequest sends an unauthenticated HTTP request
+func (b *Bybit) SendHTTPRequest(path string, result interface{}) error {
+	return b.SendHTTPRequestContext(context.Background(), path, result)
+}
+
+// SendHTTPRequestContext sends an unauthenticated HTTP request, cancelled
+// with the context
+func (b *Bybit) SendHTTPRequestContext(ctx context.Context, path string, result interface{}) error {
+	var resp Response
+	err := b.SendPayloadContext(ctx, "GET", path, nil, nil, &resp, false, b.Verbose)
+	if err != nil {
+		return err
+	}
+	return b.decodeResponse(&resp, result)
+}
+
+// SendAuthenticatedHTTPRequest sends an authenticated HTTP request, params
+// are encoded in the query string and data is sent as the JSON body
+func (b *Bybit) SendAuthenticatedHTTPRequest(method, endpoint string, params url.Values, data, result interface{}) error {
+	return b.SendAuthenticatedHTTPRequestContext(context.Background(), method, endpoint, params, data, result)
+}
+
+// SendAuthenticatedHTTPRequestContext sends an authenticated HTTP request,
+// cancelled with the context. The query string of GET requests and the body
+// of POST requests are signed.
+func (b *Bybit) SendAuthenticatedHTTPRequestContext(ctx context.Context, method, endpoint string, params url.Values, data, result interface{}) error {
+	if !b.AuthenticatedAPISupport {
+		return fmt.Errorf(exchange.WarningAuthenticatedRequestWithoutCredentialsSet, b.Name)
+	}
+
+	path := b.APIUrl + bybitAPIVersion + endpoint
+	var signed string
+	if len(params) > 0 {
+		signed = params.Encode()
+		path += "?" + signed
+	}
+
+	var payload []byte
+	if data != nil {
+		var err error
+		payload, err = common.JSONEncode(data)
+		if err != nil {
+			return errors.New("SendAuthenticatedHTTPRequest: Unable to JSON request")
+		}
+		signed = string(payload)
+
+		if b.Verbose {
+			log.Printf("Request JSON: %s\n", payload)
+		}
+	}
+
+	timestamp := strconv.FormatInt(common.UnixMillis(time.Now()), 10)
+	headers := make(map[string]string)
+	headers["X-BAPI-API-KEY"] = b.APIKey
+	headers["X-BAPI-TIMESTAMP"] = timestamp
+	headers["X-BAPI-RECV-WINDOW"] = bybitRecvWindow
+	headers["X-BAPI-SIGN"] = b.sign(timestamp, signed)
+	headers["Content-Type"] = "application/json"
+	if b.BrokerTag != "" {
+		headers["Referer"] = b.BrokerTag
+	}
+
+	var resp Response
+	err := b.SendPayloadContext(ctx,
+		method,
+		path,
+		headers,
+		bytes.NewBuffer(payload),
+		&resp,
+		true,
+		b.Verbose)
+	if err != nil {
+		return err
+	}
+	return b.decodeResponse(&resp, result)
+}
+
+// sign returns the hex encoded HMAC-SHA256 request signature of the
+// timestamp, API key, receive window and query string or body
+func (b *Bybit) sign(timestamp, payload string) string {
+	hmac := common.GetHMAC(common.HashSHA256,
+		[]byte(timestamp+b.APIKey+bybitRecvWindow+payload),
+		[]byte(b.APISecret))
+	return common.HexEncodeToString(hmac)
+}
+
+// decodeResponse checks the return code and decodes the response result
+func (b *Bybit) decodeResponse(resp *Response, result interface{}) error {
+	if resp.RetCode != bybitSuccessCode {
+		return fmt.Errorf("%s error code %d: %s", b.Name, resp.RetCode, resp.RetMsg)
+	}
+	if result == nil || len(resp.Result) == 0 {
+		return nil
+	}
+	return json.Unmarshal(resp.Result, result)
+}
+
+// GetFee returns an estimate of fee based on type of transaction
+func (b *Bybit) GetFee(feeBuilder exchange.FeeBuilder) (float64, error) {
+	var fee float64
+	switch feeBuilder.FeeType {
+	case exchange.CryptocurrencyTradeFee:
+		rate := bybitDefaultTakerFee
+		if feeBuilder.IsMaker {
+			rate = bybitDefaultMakerFee
+		}
+
+		if b.AuthenticatedAPISupport {
+			rates, err := b.GetFeeRate(CategorySpot,
+				common.StringToUpper(feeBuilder.FirstCurrency+feeBuilder.SecondCurrency))
+			if err != nil {
+				return 0, err
+			}
+			if len(rates) > 0 {
+				rate = rates[0].TakerFeeRate.Float64()
+				if feeBuilder.IsMaker {
+					rate = rates[0].MakerFeeRate.Float64()
+				}
+			}
+		}
+		fee = rate * feeBuilder.PurchasePrice * feeBuilder.Amount
+	case exchange.CryptocurrencyWithdrawalFee:
+		if !b.AuthenticatedAPISupport {
+			return 0, fmt.Errorf(exchange.WarningAuthenticatedRequestWithoutCredentialsSet, b.Name)
+		}
+		coins, err := b.GetCoinInfo(feeBuilder.FirstCurrency)
+		if err != nil {
+			return 0, err
+		}
+		c, err := selectChain(coins, feeBuilder.FirstCurrency)
+		if err != nil {
+			return 0, err
+		}
+		fee = c.WithdrawFee.Float64()
+	}
+
+	if fee < 0 {
+		fee = 0
+	}
+	return fee, nil
+}
+
+// selectChain returns the withdrawable chain of a coin named after it, such
+// as BTC on the BTC chain, falling back to the first withdrawable chain
+func selectChain(coins []CoinInfo, coin string) (CoinChain, error) {
+	coin = common.StringToUpper(coin)
+	var chains []CoinChain
+	for i := range coins {
+		if common.StringToUpper(coins[i].Coin) == coin {
+			chains = append(chains, coins[i].Chains...)
+		}
+	}
+
+	var fallback *CoinChain
+	for i := range chains {
+		if chains[i].ChainWithdraw != "1" {
+			continue
+		}
+		if common.StringToUpper(chains[i].Chain) == coin {
+			return chains[i], nil
+		}
+		if fallback == nil {
+			fallback = &chains[i]
+		}
+	}
+	if fallback == nil {
+		return CoinChain{}, fmt.Errorf("no withdrawable chain found for coin %s", coin)
+	}
+	return *fallback, nil
+}
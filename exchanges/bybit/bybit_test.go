@@ -0,0 +1,629 @@
+package bybit
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	"github.com/thrasher-/gocryptotrader/currency/symbol"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/assets"
+	"github.com/thrasher-/gocryptotrader/exchanges/conformance"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+var b Bybit
+
+// Please supply you own test keys here for due diligence testing.
+const (
+	apiKey                  = ""
+	apiSecret               = ""
+	canManipulateRealOrders = false
+)
+
+func TestSetDefaults(t *testing.T) {
+	b.SetDefaults()
+	if b.GetName() != "Bybit" {
+		t.Error("Test Failed - Bybit - SetDefaults() error")
+	}
+}
+
+func TestSetup(t *testing.T) {
+	cfg := config.GetConfig()
+	cfg.LoadConfig("../../testdata/configtest.json")
+	bybitConfig, err := cfg.GetExchangeConfig("Bybit")
+	if err != nil {
+		t.Error("Test Failed - Bybit Setup() init error")
+	}
+
+	bybitConfig.AuthenticatedAPISupport = true
+	bybitConfig.APIKey = apiKey
+	bybitConfig.APISecret = apiSecret
+
+	b.Setup(bybitConfig)
+}
+
+func TestConformance(t *testing.T) {
+	cfg := config.GetConfig()
+	cfg.LoadConfig("../../testdata/configtest.json")
+	bybitConfig, err := cfg.GetExchangeConfig("Bybit")
+	if err != nil {
+		t.Fatal("Test Failed - Bybit conformance init error", err)
+	}
+
+	conformance.Run(t, func() exchange.IBotExchange { return new(Bybit) }, bybitConfig)
+}
+
+func TestGetInstruments(t *testing.T) {
+	t.Parallel()
+	_, err := b.GetInstruments(CategorySpot)
+	if err != nil {
+		t.Error("Test Failed - Bybit GetInstruments() error", err)
+	}
+	_, err = b.GetInstruments(CategoryInverse)
+	if err != nil {
+		t.Error("Test Failed - Bybit GetInstruments() error", err)
+	}
+}
+
+func TestGetServerTime(t *testing.T) {
+	t.Parallel()
+	_, err := b.GetServerTime()
+	if err != nil {
+		t.Error("Test Failed - Bybit GetServerTime() error", err)
+	}
+}
+
+func TestCheckSystemStatus(t *testing.T) {
+	t.Parallel()
+	err := b.CheckSystemStatus()
+	if err != nil {
+		t.Error("Test Failed - Bybit CheckSystemStatus() error", err)
+	}
+}
+
+func TestGetTickers(t *testing.T) {
+	t.Parallel()
+	_, err := b.GetTickers(CategoryLinear, "BTCUSDT")
+	if err != nil {
+		t.Error("Test Failed - Bybit GetTickers() error", err)
+	}
+}
+
+func TestGetOrderbook(t *testing.T) {
+	t.Parallel()
+	_, err := b.GetOrderbook(CategorySpot, "BTCUSDT", 50)
+	if err != nil {
+		t.Error("Test Failed - Bybit GetOrderbook() error", err)
+	}
+}
+
+func TestGetTrades(t *testing.T) {
+	t.Parallel()
+	_, err := b.GetTrades(CategorySpot, "BTCUSDT", 10)
+	if err != nil {
+		t.Error("Test Failed - Bybit GetTrades() error", err)
+	}
+}
+
+func TestGetKlines(t *testing.T) {
+	t.Parallel()
+	_, err := b.GetKlines(CategorySpot, "BTCUSDT", "60", time.Time{}, time.Time{}, 10)
+	if err != nil {
+		t.Error("Test Failed - Bybit GetKlines() error", err)
+	}
+}
+
+func TestParseKlines(t *testing.T) {
+	t.Parallel()
+	candles, err := parseKlines([][]string{
+		{"1670608800000", "17071", "17073", "17027", "17055.5", "268611", "15.74462667"},
+	})
+	if err != nil {
+		t.Fatal("Test Failed - parseKlines() error", err)
+	}
+	if len(candles) != 1 || candles[0].Close != 17055.5 ||
+		candles[0].Timestamp.UnixNano()/int64(time.Millisecond) != 1670608800000 {
+		t.Error("Test Failed - parseKlines() incorrect values", candles)
+	}
+
+	_, err = parseKlines([][]string{{"1670608800000", "17071"}})
+	if err == nil {
+		t.Error("Test Failed - parseKlines() expected error on short candle")
+	}
+}
+
+func TestNumberUnmarshal(t *testing.T) {
+	t.Parallel()
+	var resp struct {
+		A Number `json:"a"`
+		B Number `json:"b"`
+		C Time   `json:"c"`
+	}
+	err := json.Unmarshal([]byte(`{"a":"1.5","b":"","c":"1670608800000"}`), &resp)
+	if err != nil {
+		t.Fatal("Test Failed - Number UnmarshalJSON() error", err)
+	}
+	if resp.A.Float64() != 1.5 || resp.B.Float64() != 0 {
+		t.Error("Test Failed - Number UnmarshalJSON() incorrect values")
+	}
+	if resp.C.Time().UnixNano()/int64(time.Millisecond) != 1670608800000 {
+		t.Error("Test Failed - Time UnmarshalJSON() incorrect value")
+	}
+}
+
+func TestNearestFuture(t *testing.T) {
+	t.Parallel()
+	now := time.Unix(1700000000, 0)
+	future := func(symbol string, d time.Duration) Instrument {
+		return Instrument{
+			Symbol:       symbol,
+			ContractType: ContractInverseFutures,
+			Status:       StatusTrading,
+			BaseCoin:     "BTC",
+			QuoteCoin:    "USD",
+			DeliveryTime: Time(now.Add(d)),
+		}
+	}
+	instruments := []Instrument{
+		future("BTCUSDZ23", -time.Hour),
+		future("BTCUSDM24", 180*24*time.Hour),
+		future("BTCUSDH24", 90*24*time.Hour),
+		{Symbol: "BTCUSD", ContractType: ContractInversePerpetual, Status: StatusTrading, BaseCoin: "BTC", QuoteCoin: "USD"},
+	}
+
+	symbol, err := nearestFuture(instruments, pair.NewCurrencyPair("BTC", "USD"), now)
+	if err != nil || symbol != "BTCUSDH24" {
+		t.Error("Test Failed - nearestFuture() error", symbol, err)
+	}
+
+	_, err = nearestFuture(instruments, pair.NewCurrencyPair("ETH", "USD"), now)
+	if err == nil {
+		t.Error("Test Failed - nearestFuture() expected error")
+	}
+}
+
+func TestFormatSymbol(t *testing.T) {
+	b.SetDefaults()
+	p := pair.NewCurrencyPairDelimiter("BTC-USDT", "-")
+
+	category, symbol, err := b.FormatSymbol(p, assets.Margin)
+	if err != nil || category != CategorySpot || symbol != "BTCUSDT" {
+		t.Error("Test Failed - FormatSymbol() margin error", category, symbol, err)
+	}
+
+	category, symbol, err = b.FormatSymbol(p, assets.PerpetualSwap)
+	if err != nil || category != CategoryLinear || symbol != "BTCUSDT" {
+		t.Error("Test Failed - FormatSymbol() swap error", category, symbol, err)
+	}
+
+	_, _, err = b.FormatSymbol(p, assets.Index)
+	if err == nil {
+		t.Error("Test Failed - FormatSymbol() expected index error")
+	}
+}
+
+func TestPairFromSymbol(t *testing.T) {
+	b.SetDefaults()
+	b.instruments[CategoryInverse] = []Instrument{
+		{Symbol: "BTCUSDH24", ContractType: ContractInverseFutures, BaseCoin: "BTC", QuoteCoin: "USD"},
+		{Symbol: "BTCUSD", ContractType: ContractInversePerpetual, BaseCoin: "BTC", QuoteCoin: "USD"},
+	}
+
+	tests := []struct {
+		category  string
+		symbol    string
+		pair      string
+		assetType string
+	}{
+		{CategorySpot, "ETHUSDT", "ETH-USDT", assets.Spot},
+		{CategorySpot, "ETHBTC", "ETH-BTC", assets.Spot},
+		{CategoryLinear, "BTCUSDC", "BTC-USDC", assets.PerpetualSwap},
+		{CategoryInverse, "BTCUSDH24", "BTC-USD", assets.Futures},
+	}
+	for _, test := range tests {
+		p, assetType, err := b.pairFromSymbol(test.category, test.symbol)
+		if err != nil {
+			t.Error("Test Failed - pairFromSymbol() error", err)
+			continue
+		}
+		if p.Pair().String() != test.pair || assetType != test.assetType {
+			t.Errorf("Test Failed - pairFromSymbol() %s expected %s %s, received %s %s",
+				test.symbol, test.pair, test.assetType, p.Pair(), assetType)
+		}
+	}
+
+	_, _, err := b.pairFromSymbol(CategoryInverse, "BTCUSD")
+	if err == nil {
+		t.Error("Test Failed - pairFromSymbol() expected inverse perpetual error")
+	}
+	_, err = SymbolToPair("USDT")
+	if err == nil {
+		t.Error("Test Failed - SymbolToPair() expected error")
+	}
+}
+
+func TestBuildSpotOrder(t *testing.T) {
+	b.SetDefaults()
+	p := pair.NewCurrencyPairDelimiter("BTC-USDT", "-")
+
+	req, err := b.buildSpotOrder(p, exchange.Buy, exchange.Limit, 0.5, 10000, "abc")
+	if err != nil {
+		t.Fatal("Test Failed - buildSpotOrder() error", err)
+	}
+	if req.Category != CategorySpot || req.Symbol != "BTCUSDT" || req.Side != "Buy" ||
+		req.OrderType != OrderTypeLimit || req.Price != "10000" ||
+		req.Qty != "0.5" || req.TimeInForce != TimeInForceGTC || req.OrderLinkID != "abc" {
+		t.Error("Test Failed - buildSpotOrder() incorrect limit order", req)
+	}
+
+	req, err = b.buildSpotOrder(p, exchange.Sell, exchange.Market, 1, 0, "")
+	if err != nil {
+		t.Fatal("Test Failed - buildSpotOrder() error", err)
+	}
+	if req.Price != "" || req.MarketUnit != MarketUnitBase || req.Side != "Sell" {
+		t.Error("Test Failed - buildSpotOrder() incorrect market order", req)
+	}
+
+	req, err = b.buildSpotOrder(p, exchange.Buy, exchange.ImmediateOrCancel, 1, 1, "")
+	if err != nil || req.OrderType != OrderTypeLimit || req.TimeInForce != TimeInForceIOC {
+		t.Error("Test Failed - buildSpotOrder() incorrect IOC order", req, err)
+	}
+
+	_, err = b.buildSpotOrder(p, exchange.Buy, exchange.OrderType("STOP"), 1, 1, "")
+	if err == nil {
+		t.Error("Test Failed - buildSpotOrder() expected unsupported order type error")
+	}
+}
+
+func TestSign(t *testing.T) {
+	b.SetDefaults()
+	b.APIKey = "key"
+	b.APISecret = "secret"
+	defer func() {
+		b.APIKey = apiKey
+		b.APISecret = apiSecret
+	}()
+
+	sig := b.sign("1658384314791", "category=spot")
+	if len(sig) != 64 {
+		t.Error("Test Failed - sign() expected hex encoded SHA256", sig)
+	}
+	if sig == b.sign("1658384314791", "category=linear") {
+		t.Error("Test Failed - sign() payload not included in signature")
+	}
+	if b.wsAuthSign("1662350400000") == b.wsAuthSign("1662350400001") {
+		t.Error("Test Failed - wsAuthSign() expiry not included in signature")
+	}
+}
+
+func TestSelectChain(t *testing.T) {
+	t.Parallel()
+	coins := []CoinInfo{{
+		Coin: "USDT",
+		Chains: []CoinChain{
+			{Chain: "TRX", ChainWithdraw: "1"},
+			{Chain: "USDT", ChainWithdraw: "0"},
+			{Chain: "ETH", ChainWithdraw: "1"},
+		},
+	}}
+	c, err := selectChain(coins, "usdt")
+	if err != nil || c.Chain != "TRX" {
+		t.Error("Test Failed - selectChain() error", c.Chain, err)
+	}
+
+	coins[0].Chains[1].ChainWithdraw = "1"
+	c, err = selectChain(coins, "usdt")
+	if err != nil || c.Chain != "USDT" {
+		t.Error("Test Failed - selectChain() expected coin chain", c.Chain, err)
+	}
+
+	_, err = selectChain(nil, "usdt")
+	if err == nil {
+		t.Error("Test Failed - selectChain() expected error")
+	}
+}
+
+func setFeeBuilder() exchange.FeeBuilder {
+	return exchange.FeeBuilder{
+		Amount:         1,
+		Delimiter:      "-",
+		FeeType:        exchange.CryptocurrencyTradeFee,
+		FirstCurrency:  symbol.BTC,
+		SecondCurrency: symbol.USDT,
+		IsMaker:        false,
+		PurchasePrice:  1,
+	}
+}
+
+func TestGetFee(t *testing.T) {
+	b.SetDefaults()
+	if apiKey != "" || apiSecret != "" {
+		t.Skip()
+	}
+	b.AuthenticatedAPISupport = false
+
+	var feeBuilder = setFeeBuilder()
+	// CryptocurrencyTradeFee Basic
+	if resp, err := b.GetFee(feeBuilder); resp != float64(0.001) || err != nil {
+		t.Errorf("Test Failed - GetFee() error. Expected: %f, Received: %f", float64(0.001), resp)
+		t.Error(err)
+	}
+
+	// CryptocurrencyTradeFee IsMaker
+	feeBuilder = setFeeBuilder()
+	feeBuilder.IsMaker = true
+	if resp, err := b.GetFee(feeBuilder); resp != float64(0.001) || err != nil {
+		t.Errorf("Test Failed - GetFee() error. Expected: %f, Received: %f", float64(0.001), resp)
+		t.Error(err)
+	}
+
+	// CryptocurrencyTradeFee Negative purchase price
+	feeBuilder = setFeeBuilder()
+	feeBuilder.PurchasePrice = -1000
+	if resp, err := b.GetFee(feeBuilder); resp != float64(0) || err != nil {
+		t.Errorf("Test Failed - GetFee() error. Expected: %f, Received: %f", float64(0), resp)
+		t.Error(err)
+	}
+
+	// CryptocurrencyWithdrawalFee requires credentials
+	feeBuilder = setFeeBuilder()
+	feeBuilder.FeeType = exchange.CryptocurrencyWithdrawalFee
+	if _, err := b.GetFee(feeBuilder); err == nil {
+		t.Error("Test Failed - GetFee() expected error without credentials")
+	}
+
+	// InternationalBankDepositFee Basic
+	feeBuilder = setFeeBuilder()
+	feeBuilder.FeeType = exchange.InternationalBankDepositFee
+	if resp, err := b.GetFee(feeBuilder); resp != float64(0) || err != nil {
+		t.Errorf("Test Failed - GetFee() error. Expected: %f, Received: %f", float64(0), resp)
+		t.Error(err)
+	}
+}
+
+func TestFormatWithdrawPermissions(t *testing.T) {
+	b.SetDefaults()
+	expectedResult := exchange.AutoWithdrawCryptoWithAPIPermissionText
+	withdrawPermissions := b.FormatWithdrawPermissions()
+	if withdrawPermissions != expectedResult {
+		t.Errorf("Expected: %s, Received: %s", expectedResult, withdrawPermissions)
+	}
+}
+
+func TestUpdateTickerContext(t *testing.T) {
+	var _ exchange.ContextExchange = &b
+	var _ exchange.OrderContextExchange = &b
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := exchange.UpdateTickerContext(ctx, &b, pair.NewCurrencyPair("BTC", "USDT"), ticker.Spot)
+	if err != context.Canceled {
+		t.Error("Test Failed - UpdateTickerContext() expected cancelled request", err)
+	}
+}
+
+func TestGetAccountInfo(t *testing.T) {
+	if apiKey != "" || apiSecret != "" {
+		_, err := b.GetAccountInfo()
+		if err != nil {
+			t.Error("Test Failed - GetAccountInfo() error", err)
+		}
+	} else {
+		_, err := b.GetAccountInfo()
+		if err == nil {
+			t.Error("Test Failed - GetAccountInfo() error")
+		}
+	}
+}
+
+func TestGetAPIKeyInfo(t *testing.T) {
+	if apiKey != "" || apiSecret != "" {
+		_, err := b.GetAPIKeyInfo()
+		if err != nil {
+			t.Error("Test Failed - GetAPIKeyInfo() error", err)
+		}
+	} else {
+		_, err := b.GetAPIKeyInfo()
+		if err == nil {
+			t.Error("Test Failed - GetAPIKeyInfo() error")
+		}
+	}
+}
+
+func TestWalletBalances(t *testing.T) {
+	var wallets []WalletBalance
+	err := json.Unmarshal([]byte(`[{"accountType":"UNIFIED","coin":[
+		{"coin":"btc","equity":"1.1","walletBalance":"1","locked":"0.25","totalOrderIM":"0.125","totalPositionIM":"0.125","unrealisedPnl":"0.1"},
+		{"coin":"USDT","equity":"-500","walletBalance":"-500","locked":"","totalOrderIM":"0","totalPositionIM":"0","borrowAmount":"500"}]}]`), &wallets)
+	if err != nil {
+		t.Fatal("Test Failed - walletBalances() decode error", err)
+	}
+
+	currencies := walletBalances(wallets)
+	if len(currencies) != 2 {
+		t.Fatalf("Test Failed - walletBalances() expected 2 currencies received %v", currencies)
+	}
+	if c := currencies[0]; c.CurrencyName != "BTC" || c.TotalValue != 1 || c.Hold != 0.5 {
+		t.Error("Test Failed - walletBalances() expected BTC wallet balance", c)
+	}
+	if c := currencies[1]; c.CurrencyName != "USDT" || c.TotalValue != -500 {
+		t.Error("Test Failed - walletBalances() expected borrowed USDT", c)
+	}
+}
+
+func TestWsHandleTickerDelta(t *testing.T) {
+	b.SetDefaults()
+	b.Websocket.DataHandler = make(chan interface{}, 2)
+
+	err := b.wsHandleMessage(CategoryLinear, []byte(`{"topic":"tickers.BTCUSDT","type":"snapshot","ts":1673853746003,
+		"data":{"symbol":"BTCUSDT","lastPrice":"21000","prevPrice24h":"20000","highPrice24h":"21500","lowPrice24h":"19500","volume24h":"100"}}`))
+	if err != nil {
+		t.Fatal("Test Failed - wsHandleMessage() ticker snapshot error", err)
+	}
+	<-b.Websocket.DataHandler
+
+	err = b.wsHandleMessage(CategoryLinear, []byte(`{"topic":"tickers.BTCUSDT","type":"delta","ts":1673853747003,
+		"data":{"symbol":"BTCUSDT","lastPrice":"21100"}}`))
+	if err != nil {
+		t.Fatal("Test Failed - wsHandleMessage() ticker delta error", err)
+	}
+	tick, ok := (<-b.Websocket.DataHandler).(exchange.TickerData)
+	if !ok {
+		t.Fatal("Test Failed - wsHandleMessage() expected ticker data")
+	}
+	if tick.ClosePrice != 21100 || tick.OpenPrice != 20000 || tick.HighPrice != 21500 ||
+		tick.AssetType != assets.PerpetualSwap || tick.Pair.Pair().String() != "BTC-USDT" {
+		t.Error("Test Failed - wsHandleMessage() delta not merged into ticker", tick)
+	}
+
+	err = b.wsHandleMessage(CategorySpot, []byte(`{"success":false,"ret_msg":"invalid topic","op":"subscribe"}`))
+	if err == nil {
+		t.Error("Test Failed - wsHandleMessage() expected failed subscription error")
+	}
+}
+
+func TestWsHandleMarkPrice(t *testing.T) {
+	b.SetDefaults()
+	b.Websocket.DataHandler = make(chan interface{}, 2)
+
+	err := b.wsHandleMessage(CategoryLinear, []byte(`{"topic":"tickers.ETHUSDT","type":"snapshot","ts":1673853746003,
+		"data":{"symbol":"ETHUSDT","lastPrice":"1600","markPrice":"1601.5","indexPrice":"1600.9"}}`))
+	if err != nil {
+		t.Fatal("Test Failed - wsHandleMessage() ticker snapshot error", err)
+	}
+	<-b.Websocket.DataHandler
+	mark, ok := (<-b.Websocket.DataHandler).(exchange.MarkPriceData)
+	if !ok {
+		t.Fatal("Test Failed - wsHandleMessage() expected mark price data")
+	}
+	if mark.MarkPrice != 1601.5 || mark.IndexPrice != 1600.9 || mark.InstrumentID != "ETHUSDT" ||
+		mark.AssetType != assets.PerpetualSwap {
+		t.Error("Test Failed - wsHandleMessage() incorrect mark price", mark)
+	}
+}
+
+func TestWsProcessOrderbook(t *testing.T) {
+	var exch Bybit
+	exch.SetDefaults()
+	exch.Name = "BybitBooksTest"
+	exch.Websocket.DataHandler = make(chan interface{}, 3)
+
+	err := exch.wsHandleMessage(CategoryLinear, []byte(`{"topic":"orderbook.50.BTCUSDT","type":"snapshot","ts":1673853746003,
+		"data":{"s":"BTCUSDT","b":[["16493.50","0.006"]],"a":[["16611.00","0.029"]],"u":18521288,"seq":7961638724}}`))
+	if err != nil {
+		t.Fatal("Test Failed - wsHandleMessage() snapshot error", err)
+	}
+	err = exch.wsHandleMessage(CategoryLinear, []byte(`{"topic":"orderbook.50.BTCUSDT","type":"delta","ts":1673853746103,
+		"data":{"s":"BTCUSDT","b":[],"a":[["16611.00","0"],["16612.00","1"]],"u":18521289,"seq":7961638730}}`))
+	if err != nil {
+		t.Fatal("Test Failed - wsHandleMessage() delta error", err)
+	}
+	ob := exch.wsBuffers[CategoryLinear+".BTCUSDT"].Orderbook()
+	if len(ob.Asks) != 1 || ob.Asks[0].Price != 16612 || ob.Bids[0].Amount != 0.006 {
+		t.Errorf("Test Failed - wsHandleMessage() unexpected orderbook %+v", ob)
+	}
+
+	// A gap resubscribes, which fails without a connection
+	err = exch.wsHandleMessage(CategoryLinear, []byte(`{"topic":"orderbook.50.BTCUSDT","type":"delta","ts":1673853746203,
+		"data":{"s":"BTCUSDT","b":[["16493.00","1"]],"a":[],"u":18521291,"seq":7961638740}}`))
+	if err == nil || exch.wsBuffers[CategoryLinear+".BTCUSDT"].Synced() {
+		t.Error("Test Failed - wsHandleMessage() expected sequence gap", err)
+	}
+	if c := orderbook.Sequences.Counter(exch.Name); c.Gaps != 1 || c.Updates != 2 {
+		t.Errorf("Test Failed - wsHandleMessage() unexpected sequence counters %+v", c)
+	}
+}
+
+func TestModifyOrder(t *testing.T) {
+	_, err := b.ModifyOrder(exchange.ModifyOrder{})
+	if err == nil {
+		t.Error("Test failed - ModifyOrder() error")
+	}
+}
+
+// Any tests below this line have the ability to impact your orders on the exchange. Enable canManipulateRealOrders to run them
+// ----------------------------------------------------------------------------------------------------------------------------
+func isRealOrderTestEnabled() bool {
+	if b.APIKey == "" || b.APISecret == "" ||
+		b.APIKey == "Key" || b.APISecret == "Secret" ||
+		!canManipulateRealOrders {
+		return false
+	}
+	return true
+}
+
+func TestSubmitOrder(t *testing.T) {
+	b.SetDefaults()
+	TestSetup(t)
+
+	if !isRealOrderTestEnabled() {
+		t.Skip()
+	}
+
+	var p = pair.CurrencyPair{
+		Delimiter:      "-",
+		FirstCurrency:  symbol.BTC,
+		SecondCurrency: symbol.USDT,
+	}
+	response, err := b.SubmitOrder(p, exchange.Buy, exchange.Limit, 0.001, 10, "")
+	if err != nil || !response.IsOrderPlaced {
+		t.Errorf("Order failed to be placed: %v", err)
+	}
+}
+
+func TestCancelExchangeOrder(t *testing.T) {
+	b.SetDefaults()
+	TestSetup(t)
+
+	if !isRealOrderTestEnabled() {
+		t.Skip()
+	}
+
+	currencyPair := pair.NewCurrencyPairDelimiter("BTC-USDT", "-")
+
+	var orderCancellation = exchange.OrderCancellation{
+		OrderID:      "1",
+		CurrencyPair: currencyPair,
+	}
+
+	err := b.CancelOrder(orderCancellation)
+	if err != nil {
+		t.Errorf("Could not cancel order: %s", err)
+	}
+}
+
+func TestCancelAllExchangeOrders(t *testing.T) {
+	b.SetDefaults()
+	TestSetup(t)
+
+	if !isRealOrderTestEnabled() {
+		t.Skip()
+	}
+
+	resp, err := b.CancelAllOrders(exchange.OrderCancellation{})
+	if err != nil {
+		t.Errorf("Could not cancel order: %s", err)
+	}
+
+	if len(resp.OrderStatus) > 0 {
+		t.Errorf("%v orders failed to cancel", len(resp.OrderStatus))
+	}
+}
+
+func TestSubmitOrderSubmission(t *testing.T) {
+	var _ exchange.OrderSubmissionSubmitter = &b
+	p := pair.NewCurrencyPair(symbol.BTC, symbol.USDT)
+	for _, o := range []exchange.OrderSubmission{
+		{AssetType: assets.Margin, Pair: p, Side: exchange.Buy, Type: exchange.Limit, BaseAmount: 1, Price: 1},
+		{Pair: p, Side: exchange.Buy, Type: exchange.Limit, BaseAmount: 1, Price: 1, Trigger: exchange.StopLoss, TriggerPrice: 1},
+		{Pair: p, Side: exchange.Buy, Type: exchange.Market, BaseAmount: 1, TimeInForce: exchange.FOK},
+	} {
+		if _, err := b.SubmitOrderSubmission(o); err == nil {
+			t.Errorf("Test Failed - SubmitOrderSubmission() expected %+v rejected", o)
+		}
+	}
+}
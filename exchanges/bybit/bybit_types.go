@@ -0,0 +1,481 @@
+package bybit
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+)
+
+// Bybit v5 product categories, each asset type trades in one of them
+const (
+	CategorySpot    = "spot"
+	CategoryLinear  = "linear"
+	CategoryInverse = "inverse"
+)
+
+// Contract types of linear and inverse instruments
+const (
+	ContractLinearPerpetual  = "LinearPerpetual"
+	ContractLinearFutures    = "LinearFutures"
+	ContractInversePerpetual = "InversePerpetual"
+	ContractInverseFutures   = "InverseFutures"
+)
+
+// Instrument statuses
+const (
+	StatusTrading = "Trading"
+)
+
+// Order types and time in force
+const (
+	OrderTypeLimit  = "Limit"
+	OrderTypeMarket = "Market"
+
+	TimeInForceGTC      = "GTC"
+	TimeInForceIOC      = "IOC"
+	TimeInForceFOK      = "FOK"
+	TimeInForcePostOnly = "PostOnly"
+)
+
+// Market order units, spot market buys are sized in the quote currency unless
+// the base currency is requested
+const (
+	MarketUnitBase  = "baseCoin"
+	MarketUnitQuote = "quoteCoin"
+)
+
+// Number is a Bybit string encoded number, empty strings decode to zero
+type Number float64
+
+// UnmarshalJSON implements the json.Unmarshaler interface
+func (n *Number) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "" || s == "null" {
+		*n = 0
+		return nil
+	}
+
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return err
+	}
+	*n = Number(f)
+	return nil
+}
+
+// Float64 returns the number as a float64
+func (n Number) Float64() float64 {
+	return float64(n)
+}
+
+// Time is a Bybit millisecond timestamp, encoded as either a string or a
+// number
+type Time time.Time
+
+// UnmarshalJSON implements the json.Unmarshaler interface
+func (t *Time) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "" || s == "null" || s == "0" {
+		*t = Time(time.Time{})
+		return nil
+	}
+
+	ms, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return err
+	}
+	*t = Time(common.UnixTimestampToUTC(ms))
+	return nil
+}
+
+// Time returns the timestamp as a time.Time
+func (t Time) Time() time.Time {
+	return time.Time(t)
+}
+
+// Response is the response envelope for all REST requests
+type Response struct {
+	RetCode int             `json:"retCode"`
+	RetMsg  string          `json:"retMsg"`
+	Result  json.RawMessage `json:"result"`
+	Time    Time            `json:"time"`
+}
+
+// Instrument holds instrument details, spot instruments report their
+// quantity step as the base precision and derivatives as the quantity step
+type Instrument struct {
+	Symbol          string `json:"symbol"`
+	ContractType    string `json:"contractType"`
+	Status          string `json:"status"`
+	BaseCoin        string `json:"baseCoin"`
+	QuoteCoin       string `json:"quoteCoin"`
+	SettleCoin      string `json:"settleCoin"`
+	LaunchTime      Time   `json:"launchTime"`
+	DeliveryTime    Time   `json:"deliveryTime"`
+	MarginTrading   string `json:"marginTrading"`
+	FundingInterval int64  `json:"fundingInterval"`
+	LotSizeFilter   struct {
+		BasePrecision Number `json:"basePrecision"`
+		QtyStep       Number `json:"qtyStep"`
+		MinOrderQty   Number `json:"minOrderQty"`
+		MaxOrderQty   Number `json:"maxOrderQty"`
+		MinOrderAmt   Number `json:"minOrderAmt"`
+	} `json:"lotSizeFilter"`
+	PriceFilter struct {
+		TickSize Number `json:"tickSize"`
+	} `json:"priceFilter"`
+}
+
+// StepSize returns the instrument's order quantity increment
+func (i *Instrument) StepSize() float64 {
+	if i.LotSizeFilter.QtyStep > 0 {
+		return i.LotSizeFilter.QtyStep.Float64()
+	}
+	return i.LotSizeFilter.BasePrecision.Float64()
+}
+
+// Ticker holds ticker data, funding and delivery fields are only set for
+// derivatives
+type Ticker struct {
+	Symbol          string `json:"symbol"`
+	LastPrice       Number `json:"lastPrice"`
+	IndexPrice      Number `json:"indexPrice"`
+	MarkPrice       Number `json:"markPrice"`
+	PrevPrice24h    Number `json:"prevPrice24h"`
+	Price24hPcnt    Number `json:"price24hPcnt"`
+	HighPrice24h    Number `json:"highPrice24h"`
+	LowPrice24h     Number `json:"lowPrice24h"`
+	Volume24h       Number `json:"volume24h"`
+	Turnover24h     Number `json:"turnover24h"`
+	Bid1Price       Number `json:"bid1Price"`
+	Bid1Size        Number `json:"bid1Size"`
+	Ask1Price       Number `json:"ask1Price"`
+	Ask1Size        Number `json:"ask1Size"`
+	OpenInterest    Number `json:"openInterest"`
+	FundingRate     Number `json:"fundingRate"`
+	NextFundingTime Time   `json:"nextFundingTime"`
+	DeliveryTime    Time   `json:"deliveryTime"`
+}
+
+// OrderbookResponse holds the raw orderbook, each level is [price, size]
+type OrderbookResponse struct {
+	Symbol    string      `json:"s"`
+	Bids      [][2]string `json:"b"`
+	Asks      [][2]string `json:"a"`
+	Timestamp Time        `json:"ts"`
+	UpdateID  int64       `json:"u"`
+	Sequence  int64       `json:"seq"`
+}
+
+// OrderbookItem holds a single orderbook level
+type OrderbookItem struct {
+	Price  float64
+	Amount float64
+}
+
+// Orderbook holds parsed orderbook data
+type Orderbook struct {
+	Bids      []OrderbookItem
+	Asks      []OrderbookItem
+	Timestamp time.Time
+}
+
+// Trade holds a public trade
+type Trade struct {
+	ExecID       string `json:"execId"`
+	Symbol       string `json:"symbol"`
+	Price        Number `json:"price"`
+	Size         Number `json:"size"`
+	Side         string `json:"side"`
+	Time         Time   `json:"time"`
+	IsBlockTrade bool   `json:"isBlockTrade"`
+}
+
+// Candle holds candlestick data
+type Candle struct {
+	Timestamp time.Time
+	Open      float64
+	High      float64
+	Low       float64
+	Close     float64
+	Volume    float64
+}
+
+// WalletBalance holds the balance of an account type
+type WalletBalance struct {
+	AccountType            string        `json:"accountType"`
+	TotalEquity            Number        `json:"totalEquity"`
+	TotalWalletBalance     Number        `json:"totalWalletBalance"`
+	TotalAvailableBalance  Number        `json:"totalAvailableBalance"`
+	TotalInitialMargin     Number        `json:"totalInitialMargin"`
+	TotalMaintenanceMargin Number        `json:"totalMaintenanceMargin"`
+	Coins                  []CoinBalance `json:"coin"`
+}
+
+// CoinBalance holds the balance of a coin in an account. Locked is held by
+// open spot orders and the order and position initial margins by open
+// derivatives orders and positions.
+type CoinBalance struct {
+	Coin                string `json:"coin"`
+	Equity              Number `json:"equity"`
+	WalletBalance       Number `json:"walletBalance"`
+	Locked              Number `json:"locked"`
+	TotalOrderIM        Number `json:"totalOrderIM"`
+	TotalPositionIM     Number `json:"totalPositionIM"`
+	UnrealisedPnl       Number `json:"unrealisedPnl"`
+	BorrowAmount        Number `json:"borrowAmount"`
+	AvailableToWithdraw Number `json:"availableToWithdraw"`
+}
+
+// PlaceOrderRequest holds the parameters of a new order
+type PlaceOrderRequest struct {
+	Category    string `json:"category"`
+	Symbol      string `json:"symbol"`
+	IsLeverage  int    `json:"isLeverage,omitempty"`
+	Side        string `json:"side"`
+	OrderType   string `json:"orderType"`
+	Qty         string `json:"qty"`
+	MarketUnit  string `json:"marketUnit,omitempty"`
+	Price       string `json:"price,omitempty"`
+	TimeInForce string `json:"timeInForce,omitempty"`
+	OrderLinkID string `json:"orderLinkId,omitempty"`
+	ReduceOnly  bool   `json:"reduceOnly,omitempty"`
+}
+
+// AmendOrderRequest holds the new quantity and/or price of an open order
+type AmendOrderRequest struct {
+	Category    string `json:"category"`
+	Symbol      string `json:"symbol"`
+	OrderID     string `json:"orderId,omitempty"`
+	OrderLinkID string `json:"orderLinkId,omitempty"`
+	Qty         string `json:"qty,omitempty"`
+	Price       string `json:"price,omitempty"`
+}
+
+// CancelOrderRequest identifies an order to cancel by order ID or order link
+// ID
+type CancelOrderRequest struct {
+	Category    string `json:"category"`
+	Symbol      string `json:"symbol"`
+	OrderID     string `json:"orderId,omitempty"`
+	OrderLinkID string `json:"orderLinkId,omitempty"`
+}
+
+// CancelAllRequest cancels all open orders of a category, optionally of a
+// single symbol
+type CancelAllRequest struct {
+	Category   string `json:"category"`
+	Symbol     string `json:"symbol,omitempty"`
+	SettleCoin string `json:"settleCoin,omitempty"`
+}
+
+// OrderResponse holds the IDs of a placed, amended or cancelled order
+type OrderResponse struct {
+	OrderID     string `json:"orderId"`
+	OrderLinkID string `json:"orderLinkId"`
+}
+
+// Order holds order details, Category is only set by websocket updates
+type Order struct {
+	Category     string `json:"category"`
+	OrderID      string `json:"orderId"`
+	OrderLinkID  string `json:"orderLinkId"`
+	Symbol       string `json:"symbol"`
+	Price        Number `json:"price"`
+	Qty          Number `json:"qty"`
+	Side         string `json:"side"`
+	IsLeverage   string `json:"isLeverage"`
+	OrderStatus  string `json:"orderStatus"`
+	OrderType    string `json:"orderType"`
+	TimeInForce  string `json:"timeInForce"`
+	AvgPrice     Number `json:"avgPrice"`
+	LeavesQty    Number `json:"leavesQty"`
+	CumExecQty   Number `json:"cumExecQty"`
+	CumExecValue Number `json:"cumExecValue"`
+	CumExecFee   Number `json:"cumExecFee"`
+	ReduceOnly   bool   `json:"reduceOnly"`
+	CreatedTime  Time   `json:"createdTime"`
+	UpdatedTime  Time   `json:"updatedTime"`
+}
+
+// Execution is a private fill of an order
+type Execution struct {
+	Category    string `json:"category"`
+	Symbol      string `json:"symbol"`
+	OrderID     string `json:"orderId"`
+	OrderLinkID string `json:"orderLinkId"`
+	Side        string `json:"side"`
+	ExecID      string `json:"execId"`
+	ExecPrice   Number `json:"execPrice"`
+	ExecQty     Number `json:"execQty"`
+	ExecFee     Number `json:"execFee"`
+	FeeRate     Number `json:"feeRate"`
+	IsMaker     bool   `json:"isMaker"`
+	ExecTime    Time   `json:"execTime"`
+}
+
+// Liquidity returns whether the execution was as the maker or taker
+func (e *Execution) Liquidity() exchange.Liquidity {
+	if e.IsMaker {
+		return exchange.Maker
+	}
+	return exchange.Taker
+}
+
+// Position holds an open derivatives position, Category is only set by
+// websocket updates
+type Position struct {
+	Category        string `json:"category"`
+	Symbol          string `json:"symbol"`
+	Side            string `json:"side"`
+	Size            Number `json:"size"`
+	AvgPrice        Number `json:"avgPrice"`
+	PositionValue   Number `json:"positionValue"`
+	PositionMM      Number `json:"positionMM"`
+	PositionBalance Number `json:"positionBalance"`
+	Leverage        Number `json:"leverage"`
+	MarkPrice       Number `json:"markPrice"`
+	LiqPrice        Number `json:"liqPrice"`
+	UnrealisedPnl   Number `json:"unrealisedPnl"`
+	CumRealisedPnl  Number `json:"cumRealisedPnl"`
+	PositionIdx     int    `json:"positionIdx"`
+	UpdatedTime     Time   `json:"updatedTime"`
+}
+
+// FeeRate holds the account fee rates of a symbol
+type FeeRate struct {
+	Symbol       string `json:"symbol"`
+	BaseCoin     string `json:"baseCoin"`
+	TakerFeeRate Number `json:"takerFeeRate"`
+	MakerFeeRate Number `json:"makerFeeRate"`
+}
+
+// APIKeyInformation holds the metadata of an API key. CreatedAt and ExpiredAt
+// are RFC3339 times, ExpiredAt is empty for keys bound to IP addresses which
+// do not expire and DeadlineDay the days until the key expires.
+type APIKeyInformation struct {
+	ID          string   `json:"id"`
+	Note        string   `json:"note"`
+	APIKey      string   `json:"apiKey"`
+	ReadOnly    int      `json:"readOnly"`
+	IPs         []string `json:"ips"`
+	Type        int      `json:"type"`
+	DeadlineDay int      `json:"deadlineDay"`
+	ExpiredAt   string   `json:"expiredAt"`
+	CreatedAt   string   `json:"createdAt"`
+}
+
+// CoinInfo holds the chains a coin can be deposited and withdrawn on
+type CoinInfo struct {
+	Name   string      `json:"name"`
+	Coin   string      `json:"coin"`
+	Chains []CoinChain `json:"chains"`
+}
+
+// CoinChain holds the deposit and withdrawal details of a coin on a chain,
+// ChainDeposit and ChainWithdraw are "1" when enabled
+type CoinChain struct {
+	Chain         string `json:"chain"`
+	ChainType     string `json:"chainType"`
+	Confirmation  string `json:"confirmation"`
+	WithdrawFee   Number `json:"withdrawFee"`
+	DepositMin    Number `json:"depositMin"`
+	WithdrawMin   Number `json:"withdrawMin"`
+	ChainDeposit  string `json:"chainDeposit"`
+	ChainWithdraw string `json:"chainWithdraw"`
+}
+
+// DepositAddress holds a coin's deposit address on each chain
+type DepositAddress struct {
+	Coin   string `json:"coin"`
+	Chains []struct {
+		ChainType      string `json:"chainType"`
+		AddressDeposit string `json:"addressDeposit"`
+		TagDeposit     string `json:"tagDeposit"`
+		Chain          string `json:"chain"`
+	} `json:"chains"`
+}
+
+// WithdrawalRequest holds the parameters of an on chain withdrawal
+type WithdrawalRequest struct {
+	Coin        string `json:"coin"`
+	Chain       string `json:"chain"`
+	Address     string `json:"address"`
+	Tag         string `json:"tag,omitempty"`
+	Amount      string `json:"amount"`
+	Timestamp   int64  `json:"timestamp"`
+	AccountType string `json:"accountType,omitempty"`
+}
+
+// DepositRecord holds a deposit
+type DepositRecord struct {
+	ID         string `json:"id"`
+	Coin       string `json:"coin"`
+	Chain      string `json:"chain"`
+	Amount     Number `json:"amount"`
+	TxID       string `json:"txID"`
+	Status     int    `json:"status"`
+	ToAddress  string `json:"toAddress"`
+	Tag        string `json:"tag"`
+	DepositFee Number `json:"depositFee"`
+	SuccessAt  Time   `json:"successAt"`
+}
+
+// WithdrawalRecord holds a withdrawal
+type WithdrawalRecord struct {
+	WithdrawID  string `json:"withdrawId"`
+	TxID        string `json:"txID"`
+	Coin        string `json:"coin"`
+	Chain       string `json:"chain"`
+	Amount      Number `json:"amount"`
+	WithdrawFee Number `json:"withdrawFee"`
+	Status      string `json:"status"`
+	ToAddress   string `json:"toAddress"`
+	Tag         string `json:"tag"`
+	CreateTime  Time   `json:"createTime"`
+}
+
+// depositStatuses maps deposit status codes to descriptions
+var depositStatuses = map[int]string{
+	0:     "UNKNOWN",
+	1:     "TO_BE_CONFIRMED",
+	2:     "PROCESSING",
+	3:     "SUCCESS",
+	4:     "FAILED",
+	10011: "PENDING_CREDIT",
+	10012: "CREDITED",
+}
+
+// WsRequest is a websocket operation request
+type WsRequest struct {
+	ReqID     string        `json:"req_id,omitempty"`
+	Operation string        `json:"op"`
+	Arguments []interface{} `json:"args"`
+}
+
+// WsResponse is the generic websocket message used to route operation
+// responses and topic pushes
+type WsResponse struct {
+	Success   *bool           `json:"success"`
+	RetMsg    string          `json:"ret_msg"`
+	Operation string          `json:"op"`
+	ConnID    string          `json:"conn_id"`
+	Topic     string          `json:"topic"`
+	Type      string          `json:"type"`
+	Timestamp Time            `json:"ts"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// WsTrade is a public trade pushed by the publicTrade topic
+type WsTrade struct {
+	Timestamp Time   `json:"T"`
+	Symbol    string `json:"s"`
+	Side      string `json:"S"`
+	Size      Number `json:"v"`
+	Price     Number `json:"p"`
+	TradeID   string `json:"i"`
+}
@@ -0,0 +1,676 @@
+package bybit
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/assets"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/trade"
+)
+
+const (
+	// Public URLs are suffixed with the category of the connection
+	bybitWebsocketPublicURL  = "wss://stream.bybit.com/v5/public/"
+	bybitWebsocketPrivateURL = "wss://stream.bybit.com/v5/private"
+
+	bybitWsTestnetPublicURL  = "wss://stream-testnet.bybit.com/v5/public/"
+	bybitWsTestnetPrivateURL = "wss://stream-testnet.bybit.com/v5/private"
+
+	// Public topics
+	bybitWsTickers   = "tickers"
+	bybitWsOrderbook = "orderbook"
+	bybitWsTrades    = "publicTrade"
+
+	// Private topics
+	bybitWsOrder     = "order"
+	bybitWsExecution = "execution"
+	bybitWsWallet    = "wallet"
+	bybitWsPosition  = "position"
+
+	// Operations
+	bybitWsOpSubscribe   = "subscribe"
+	bybitWsOpUnsubscribe = "unsubscribe"
+	bybitWsOpAuth        = "auth"
+	bybitWsOpPing        = "ping"
+
+	// bybitWsOrderbookDepth is the orderbook depth streamed for every
+	// category
+	bybitWsOrderbookDepth = "50"
+	// bybitWsMaxArgs is the number of topics sent per subscription request
+	bybitWsMaxArgs = 10
+
+	bybitWsPingInterval = time.Second * 20
+	bybitWsAuthTimeout  = time.Second * 10
+	bybitWsAuthExpiry   = time.Second * 10
+
+	wsPrivate = "private"
+)
+
+// wsConnectionKeys are the keys of the websocket connections, the index of
+// each is carried in exchange.WebsocketResponse.Type to route its messages
+var wsConnectionKeys = []string{CategorySpot, CategoryLinear, CategoryInverse, wsPrivate}
+
+// wsConnection is a websocket connection and the lock serialising its writes
+type wsConnection struct {
+	conn *websocket.Conn
+	lock sync.Mutex
+}
+
+// WsConnect initiates a public websocket connection per category with
+// enabled pairs and, when authenticated API support is enabled, the private
+// connection. Bybit streams each category on its own public endpoint.
+func (b *Bybit) WsConnect() error {
+	if !b.Websocket.IsEnabled() || !b.IsEnabled() {
+		return errors.New(exchange.WebsocketNotEnabled)
+	}
+
+	b.Websocket.Orderbook.FlushCache()
+	b.wsConnsLock.Lock()
+	b.wsConns = make(map[string]*wsConnection)
+	b.wsTickers = make(map[string]*Ticker)
+	b.wsConnsLock.Unlock()
+
+	spotURL := b.Websocket.GetWebsocketURL()
+	err := b.wsConnect(CategorySpot, spotURL)
+	if err != nil {
+		return err
+	}
+
+	for _, assetType := range []string{assets.PerpetualSwap, assets.Futures} {
+		if len(b.GetEnabledPairs(assetType)) == 0 {
+			continue
+		}
+		category, _ := assetCategory(assetType)
+		err = b.wsConnect(category, strings.TrimSuffix(spotURL, CategorySpot)+category)
+		if err != nil {
+			return err
+		}
+	}
+
+	go b.WsHandleData()
+
+	if !b.AuthenticatedAPISupport {
+		return nil
+	}
+
+	privateURL := bybitWebsocketPrivateURL
+	if b.Testnet {
+		privateURL = bybitWsTestnetPrivateURL
+	}
+
+	conn, err := b.wsDial(privateURL)
+	if err != nil {
+		return err
+	}
+
+	err = b.wsAuth(conn)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+
+	b.wsStart(wsPrivate, conn)
+	return b.WsSubscribePrivate()
+}
+
+// wsConnect dials a connection and starts reading from it
+func (b *Bybit) wsConnect(key, address string) error {
+	conn, err := b.wsDial(address)
+	if err != nil {
+		return err
+	}
+	b.wsStart(key, conn)
+	return nil
+}
+
+// wsStart registers a connection and starts its reader and ping handler
+func (b *Bybit) wsStart(key string, conn *websocket.Conn) {
+	c := &wsConnection{conn: conn}
+	b.wsConnsLock.Lock()
+	b.wsConns[key] = c
+	b.wsConnsLock.Unlock()
+
+	index := -1
+	for i := range wsConnectionKeys {
+		if wsConnectionKeys[i] == key {
+			index = i
+		}
+	}
+
+	go b.WsReadData(conn, index)
+	go b.wsPingHandler(c)
+}
+
+// wsDial dials a websocket URL using the configured proxy
+func (b *Bybit) wsDial(address string) (*websocket.Conn, error) {
+	var dialer websocket.Dialer
+	dialer.TLSClientConfig = b.GetTLSConfig()
+	dialer.NetDial = b.GetWebsocketNetDial()
+	if b.Websocket.GetProxyAddress() != "" {
+		proxy, err := url.Parse(b.Websocket.GetProxyAddress())
+		if err != nil {
+			return nil, err
+		}
+		dialer.Proxy = http.ProxyURL(proxy)
+	}
+
+	conn, _, err := dialer.Dial(address, http.Header{})
+	if err != nil {
+		return nil, fmt.Errorf("%s unable to connect to websocket %s. Error: %s",
+			b.Name,
+			address,
+			err)
+	}
+	return conn, nil
+}
+
+// wsWrite sends a JSON message over the connection of key
+func (b *Bybit) wsWrite(key string, data interface{}) error {
+	b.wsConnsLock.Lock()
+	c, ok := b.wsConns[key]
+	b.wsConnsLock.Unlock()
+	if !ok {
+		return fmt.Errorf("websocket %s connection not established", key)
+	}
+
+	payload, err := common.JSONEncode(data)
+	if err != nil {
+		return err
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.conn.WriteMessage(websocket.TextMessage, payload)
+}
+
+// wsAuthSign returns the websocket authentication signature of an expiry
+func (b *Bybit) wsAuthSign(expires string) string {
+	hmac := common.GetHMAC(common.HashSHA256,
+		[]byte("GET/realtime"+expires),
+		[]byte(b.APISecret))
+	return common.HexEncodeToString(hmac)
+}
+
+// wsAuth authenticates the private connection and waits for the result
+func (b *Bybit) wsAuth(conn *websocket.Conn) error {
+	expires := strconv.FormatInt(time.Now().Add(bybitWsAuthExpiry).UnixNano()/int64(time.Millisecond), 10)
+	payload, err := common.JSONEncode(WsRequest{
+		Operation: bybitWsOpAuth,
+		Arguments: []interface{}{b.APIKey, expires, b.wsAuthSign(expires)},
+	})
+	if err != nil {
+		return err
+	}
+
+	err = conn.WriteMessage(websocket.TextMessage, payload)
+	if err != nil {
+		return err
+	}
+
+	err = conn.SetReadDeadline(time.Now().Add(bybitWsAuthTimeout))
+	if err != nil {
+		return err
+	}
+	defer conn.SetReadDeadline(time.Time{})
+
+	for {
+		_, raw, err := conn.ReadMessage()
+		if err != nil {
+			return fmt.Errorf("%s websocket auth error: %s", b.Name, err)
+		}
+
+		var resp WsResponse
+		if common.JSONDecode(raw, &resp) != nil || resp.Operation != bybitWsOpAuth {
+			continue
+		}
+		if resp.Success == nil || !*resp.Success {
+			return fmt.Errorf("%s websocket auth failed: %s", b.Name, resp.RetMsg)
+		}
+		return nil
+	}
+}
+
+// wsDefaultSubscriptions returns the public ticker, trade and orderbook
+// topics of all enabled spot, perpetual swap and futures pairs
+func (b *Bybit) wsDefaultSubscriptions() []exchange.ChannelSubscription {
+	var subs []exchange.ChannelSubscription
+	for _, assetType := range []string{assets.Spot, assets.PerpetualSwap, assets.Futures} {
+		for _, p := range b.GetEnabledPairs(assetType) {
+			for _, channel := range []string{bybitWsTickers, bybitWsTrades, bybitWsOrderbook} {
+				subs = append(subs, exchange.ChannelSubscription{
+					Channel:   channel,
+					Currency:  p,
+					AssetType: assetType,
+				})
+			}
+		}
+	}
+	return subs
+}
+
+// WsSubscribe subscribes to public topics
+func (b *Bybit) WsSubscribe(subs []exchange.ChannelSubscription) error {
+	return b.wsWriteSubscriptions(bybitWsOpSubscribe, subs)
+}
+
+// WsUnsubscribe unsubscribes from public topics
+func (b *Bybit) WsUnsubscribe(subs []exchange.ChannelSubscription) error {
+	return b.wsWriteSubscriptions(bybitWsOpUnsubscribe, subs)
+}
+
+// wsWriteSubscriptions sends subscribe or unsubscribe operations for public
+// topics on the connection of each subscription's category
+func (b *Bybit) wsWriteSubscriptions(operation string, subs []exchange.ChannelSubscription) error {
+	topics := make(map[string][]interface{})
+	for i := range subs {
+		category, symbol, err := b.FormatSymbol(subs[i].Currency, subs[i].AssetType)
+		if err != nil {
+			return err
+		}
+		topics[category] = append(topics[category], wsTopic(subs[i].Channel, symbol))
+	}
+
+	for _, category := range wsConnectionKeys {
+		args := topics[category]
+		for len(args) > 0 {
+			n := len(args)
+			if n > bybitWsMaxArgs {
+				n = bybitWsMaxArgs
+			}
+			err := b.wsWrite(category, WsRequest{
+				Operation: operation,
+				Arguments: args[:n],
+			})
+			if err != nil {
+				return err
+			}
+			args = args[n:]
+		}
+	}
+	return nil
+}
+
+// wsTopic returns the topic of a public channel and symbol
+func wsTopic(channel, symbol string) string {
+	if channel == bybitWsOrderbook {
+		return channel + "." + bybitWsOrderbookDepth + "." + symbol
+	}
+	return channel + "." + symbol
+}
+
+// WsSubscribePrivate subscribes to order, execution, wallet and position
+// updates of every category
+func (b *Bybit) WsSubscribePrivate() error {
+	return b.wsWrite(wsPrivate, WsRequest{
+		Operation: bybitWsOpSubscribe,
+		Arguments: []interface{}{bybitWsOrder, bybitWsExecution, bybitWsWallet, bybitWsPosition},
+	})
+}
+
+// WsReadData reads data from a websocket connection, tagging each message
+// with the index of its connection key
+func (b *Bybit) WsReadData(conn *websocket.Conn, index int) {
+	b.Websocket.Wg.Add(1)
+
+	defer func() {
+		err := conn.Close()
+		if err != nil {
+			b.Websocket.DataHandler <- fmt.Errorf("bybit_websocket.go - Unable to to close Websocket connection. Error: %s",
+				err)
+		}
+		b.Websocket.Wg.Done()
+	}()
+
+	for {
+		select {
+		case <-b.Websocket.ShutdownC:
+			return
+
+		default:
+			_, resp, err := conn.ReadMessage()
+			if err != nil {
+				b.Websocket.DataHandler <- err
+				return
+			}
+
+			b.Websocket.TrafficAlert <- struct{}{}
+			b.Websocket.Intercomm <- exchange.WebsocketResponse{Type: index, Raw: resp}
+		}
+	}
+}
+
+// wsPingHandler keeps a connection alive, Bybit recommends a ping every 20
+// seconds
+func (b *Bybit) wsPingHandler(c *wsConnection) {
+	b.Websocket.Wg.Add(1)
+	defer b.Websocket.Wg.Done()
+
+	t := time.NewTicker(bybitWsPingInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-b.Websocket.ShutdownC:
+			return
+
+		case <-t.C:
+			c.lock.Lock()
+			err := c.conn.WriteJSON(WsRequest{Operation: bybitWsOpPing})
+			c.lock.Unlock()
+			if err != nil {
+				b.Websocket.DataHandler <- err
+				return
+			}
+		}
+	}
+}
+
+// WsHandleData handles the read data from the websocket connections
+func (b *Bybit) WsHandleData() {
+	b.Websocket.Wg.Add(1)
+	defer b.Websocket.Wg.Done()
+
+	for {
+		select {
+		case <-b.Websocket.ShutdownC:
+			return
+
+		case resp := <-b.Websocket.Intercomm:
+			if resp.Type < 0 || resp.Type >= len(wsConnectionKeys) {
+				continue
+			}
+
+			err := b.wsHandleMessage(wsConnectionKeys[resp.Type], resp.Raw)
+			if err != nil {
+				b.Websocket.DataHandler <- fmt.Sprintf("%s websocket handling error: %s",
+					b.Name,
+					err)
+			}
+		}
+	}
+}
+
+// wsHandleMessage routes a single websocket message received on the
+// connection of key
+func (b *Bybit) wsHandleMessage(key string, raw []byte) error {
+	var resp WsResponse
+	err := common.JSONDecode(raw, &resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Operation != "" {
+		if resp.Success != nil && !*resp.Success {
+			return fmt.Errorf("%s failed: %s", resp.Operation, resp.RetMsg)
+		}
+		return nil
+	}
+
+	topic := resp.Topic
+	if i := strings.Index(topic, "."); i > 0 {
+		topic = topic[:i]
+	}
+
+	switch topic {
+	case bybitWsTickers:
+		return b.wsProcessTicker(key, &resp)
+	case bybitWsTrades:
+		return b.wsProcessTrades(key, &resp)
+	case bybitWsOrderbook:
+		return b.wsProcessOrderbook(key, &resp)
+	case bybitWsOrder:
+		var orders []Order
+		err = common.JSONDecode(resp.Data, &orders)
+		if err != nil {
+			return err
+		}
+		for i := range orders {
+			b.Websocket.DataHandler <- orders[i]
+		}
+	case bybitWsExecution:
+		var executions []Execution
+		err = common.JSONDecode(resp.Data, &executions)
+		if err != nil {
+			return err
+		}
+		for i := range executions {
+			b.Websocket.DataHandler <- executions[i]
+		}
+	case bybitWsWallet:
+		var wallets []WalletBalance
+		err = common.JSONDecode(resp.Data, &wallets)
+		if err != nil {
+			return err
+		}
+		for i := range wallets {
+			b.Websocket.DataHandler <- wallets[i]
+		}
+	case bybitWsPosition:
+		var positions []Position
+		err = common.JSONDecode(resp.Data, &positions)
+		if err != nil {
+			return err
+		}
+		for i := range positions {
+			p, assetType, err := b.pairFromSymbol(positions[i].Category, positions[i].Symbol)
+			if err != nil {
+				return err
+			}
+			b.Websocket.DataHandler <- exchange.WebsocketPositionUpdated{
+				Timestamp: positions[i].UpdatedTime.Time(),
+				Pair:      p,
+				AssetType: assetType,
+				Exchange:  b.Name,
+			}
+		}
+	}
+	return nil
+}
+
+// wsProcessTicker sends ticker updates to the data handler, along with the
+// mark and index price of derivatives. Derivatives tickers are pushed as deltas
+// holding only changed fields, which are merged into the last ticker of the
+// symbol.
+func (b *Bybit) wsProcessTicker(category string, resp *WsResponse) error {
+	var update Ticker
+	err := common.JSONDecode(resp.Data, &update)
+	if err != nil {
+		return err
+	}
+
+	key := category + update.Symbol
+	b.wsConnsLock.Lock()
+	t, ok := b.wsTickers[key]
+	if !ok || resp.Type != "delta" {
+		t = &update
+		b.wsTickers[key] = t
+	} else {
+		err = common.JSONDecode(resp.Data, t)
+	}
+	tick := *t
+	b.wsConnsLock.Unlock()
+	if err != nil {
+		return err
+	}
+
+	p, assetType, err := b.pairFromSymbol(category, tick.Symbol)
+	if err != nil {
+		return err
+	}
+
+	b.Websocket.DataHandler <- exchange.TickerData{
+		Timestamp:  resp.Timestamp.Time(),
+		Pair:       p,
+		AssetType:  assetType,
+		Exchange:   b.Name,
+		ClosePrice: tick.LastPrice.Float64(),
+		Quantity:   tick.Volume24h.Float64(),
+		OpenPrice:  tick.PrevPrice24h.Float64(),
+		HighPrice:  tick.HighPrice24h.Float64(),
+		LowPrice:   tick.LowPrice24h.Float64(),
+	}
+	if category != CategorySpot && tick.MarkPrice.Float64() > 0 {
+		b.Websocket.DataHandler <- exchange.MarkPriceData{
+			Timestamp:    resp.Timestamp.Time(),
+			Pair:         p,
+			AssetType:    assetType,
+			Exchange:     b.Name,
+			InstrumentID: tick.Symbol,
+			MarkPrice:    tick.MarkPrice.Float64(),
+			IndexPrice:   tick.IndexPrice.Float64(),
+		}
+	}
+	return nil
+}
+
+// wsProcessTrades normalises public trades into the trade store and sends
+// each to the data handler
+func (b *Bybit) wsProcessTrades(category string, resp *WsResponse) error {
+	var trades []WsTrade
+	err := common.JSONDecode(resp.Data, &trades)
+	if err != nil || len(trades) == 0 {
+		return err
+	}
+
+	p, assetType, err := b.pairFromSymbol(category, trades[0].Symbol)
+	if err != nil {
+		return err
+	}
+
+	normalised := make([]trade.Trade, len(trades))
+	for i := range trades {
+		normalised[i] = trade.Trade{
+			TradeID:   trades[i].TradeID,
+			Price:     trades[i].Price.Float64(),
+			Amount:    trades[i].Size.Float64(),
+			Side:      strings.ToLower(trades[i].Side),
+			Timestamp: trades[i].Timestamp.Time(),
+		}
+	}
+
+	err = trade.ProcessTrades(b.Name, p, assetType, normalised)
+	for i := range normalised {
+		if normalised[i].Price <= 0 || normalised[i].Amount <= 0 {
+			continue
+		}
+		b.Websocket.DataHandler <- exchange.TradeData{
+			Timestamp:    normalised[i].Timestamp,
+			CurrencyPair: p,
+			AssetType:    assetType,
+			Exchange:     b.Name,
+			Price:        normalised[i].Price,
+			Amount:       normalised[i].Amount,
+			Side:         trade.Side(normalised[i].Side),
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("%s trades: %s", trades[0].Symbol, err)
+	}
+	return nil
+}
+
+// wsProcessOrderbook loads orderbook snapshots and applies delta updates to
+// the local orderbook cache, a zero size removes a level
+func (b *Bybit) wsProcessOrderbook(category string, resp *WsResponse) error {
+	var book OrderbookResponse
+	err := common.JSONDecode(resp.Data, &book)
+	if err != nil {
+		return err
+	}
+	book.Timestamp = resp.Timestamp
+
+	p, assetType, err := b.pairFromSymbol(category, book.Symbol)
+	if err != nil {
+		return err
+	}
+
+	ob, err := parseOrderbook(&book)
+	if err != nil {
+		return err
+	}
+
+	asks := make([]orderbook.Item, len(ob.Asks))
+	for x := range ob.Asks {
+		asks[x] = orderbook.Item{Price: ob.Asks[x].Price, Amount: ob.Asks[x].Amount}
+	}
+	bids := make([]orderbook.Item, len(ob.Bids))
+	for x := range ob.Bids {
+		bids[x] = orderbook.Item{Price: ob.Bids[x].Price, Amount: ob.Bids[x].Amount}
+	}
+
+	buffer := b.wsBuffer(category, book.Symbol, p, assetType)
+	if resp.Type == "snapshot" {
+		err = buffer.LoadSnapshot(orderbook.Base{
+			CurrencyPair: p.Pair().String(),
+			Asks:         asks,
+			Bids:         bids,
+			LastUpdated:  ob.Timestamp,
+		}, book.UpdateID)
+	} else {
+		err = buffer.Apply(orderbook.Update{
+			Bids:       bids,
+			Asks:       asks,
+			UpdateID:   book.UpdateID,
+			UpdateTime: ob.Timestamp,
+		})
+	}
+	if err != nil {
+		return fmt.Errorf("%s %s orderbook resyncing: %s", category, book.Symbol, err)
+	}
+
+	if buffer.Synced() {
+		b.Websocket.DataHandler <- exchange.WebsocketOrderbookUpdate{
+			Pair:     p,
+			Asset:    assetType,
+			Exchange: b.Name,
+		}
+	}
+	return nil
+}
+
+// wsBuffer returns the orderbook buffer of a category's symbol, creating it on
+// its first snapshot. Update IDs are consecutive for each symbol.
+func (b *Bybit) wsBuffer(category, symbol string, p pair.CurrencyPair, assetType string) *orderbook.Buffer {
+	b.wsBuffersLock.Lock()
+	defer b.wsBuffersLock.Unlock()
+	if b.wsBuffers == nil {
+		b.wsBuffers = make(map[string]*orderbook.Buffer)
+	}
+	key := category + "." + symbol
+	buffer, ok := b.wsBuffers[key]
+	if !ok {
+		buffer = orderbook.NewBuffer(b.Name, p, assetType)
+		buffer.Resubscribe = func() error {
+			return b.wsResubscribeBook(category, symbol)
+		}
+		b.wsBuffers[key] = buffer
+	}
+	return buffer
+}
+
+// wsResubscribeBook resubscribes to a symbol's orderbook topic, which starts
+// with a fresh snapshot
+func (b *Bybit) wsResubscribeBook(category, symbol string) error {
+	topic := wsTopic(bybitWsOrderbook, symbol)
+	for _, operation := range []string{bybitWsOpUnsubscribe, bybitWsOpSubscribe} {
+		err := b.wsWrite(category, WsRequest{
+			Operation: operation,
+			Arguments: []interface{}{topic},
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,1149 @@
+package bybit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/assets"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+// candleIntervals maps candle intervals to Bybit kline intervals
+var candleIntervals = map[kline.Interval]string{
+	kline.OneMin:     "1",
+	kline.ThreeMin:   "3",
+	kline.FiveMin:    "5",
+	kline.FifteenMin: "15",
+	kline.ThirtyMin:  "30",
+	kline.OneHour:    "60",
+	kline.FourHour:   "240",
+	kline.SixHour:    "360",
+	kline.TwelveHour: "720",
+	kline.OneDay:     "D",
+	kline.OneWeek:    "W",
+}
+
+// quoteCoins are the quote coins symbols are split on when their instrument
+// has not been loaded, longest first so USDT is not read as USD
+var quoteCoins = []string{"USDT", "USDC", "EUR", "USD", "BTC", "ETH"}
+
+// Start starts the Bybit wrapper, refreshing its currency pairs
+func (b *Bybit) Start(ctx context.Context) error {
+	return b.StartWrapper(ctx, b.Run)
+}
+
+// Run implements the Bybit wrapper
+func (b *Bybit) Run(ctx context.Context) error {
+	if b.Verbose {
+		log.Printf("%s Websocket: %s. (url: %s).\n", b.GetName(), common.IsEnabled(b.Websocket.IsEnabled()), b.Websocket.GetWebsocketURL())
+		log.Printf("%s polling delay: %ds.\n", b.GetName(), b.RESTPollingDelay)
+		log.Printf("%s %d currencies enabled: %s.\n", b.GetName(), len(b.EnabledPairs), b.EnabledPairs)
+	}
+
+	instruments, err := b.loadInstruments(CategorySpot)
+	if err != nil {
+		log.Printf("%s failed to obtain available spot instruments. Err: %s", b.Name, err)
+		return nil
+	}
+
+	var pairs, margin []string
+	for i := range instruments {
+		if instruments[i].Status != StatusTrading {
+			continue
+		}
+		p := pair.NewCurrencyPair(instruments[i].BaseCoin, instruments[i].QuoteCoin)
+		pairs = append(pairs, instruments[i].BaseCoin+"-"+instruments[i].QuoteCoin)
+		if instruments[i].MarginTrading != "" && instruments[i].MarginTrading != "none" {
+			margin = append(margin, instruments[i].BaseCoin+"-"+instruments[i].QuoteCoin)
+		}
+		b.SetPairIncrements(p, instruments[i].PriceFilter.TickSize.Float64(), instruments[i].StepSize())
+		b.SetPairMinimumAmount(p, instruments[i].LotSizeFilter.MinOrderQty.Float64())
+	}
+
+	err = b.UpdateCurrencies(pairs, false, false)
+	if err != nil {
+		log.Printf("%s failed to update available currencies. Err: %s", b.Name, err)
+	}
+	if len(margin) > 0 {
+		err = b.UpdateAssetCurrencies(assets.Margin, margin, false, false)
+		if err != nil {
+			log.Printf("%s failed to update available margin currencies. Err: %s", b.Name, err)
+		}
+	}
+
+	b.updateContractPairs()
+	return nil
+}
+
+// updateContractPairs updates the available perpetual swap pairs from the
+// USDT and USDC margined perpetuals and the available futures pairs from the
+// underlyings of the coin margined dated futures
+func (b *Bybit) updateContractPairs() {
+	linear, err := b.loadInstruments(CategoryLinear)
+	if err != nil {
+		log.Printf("%s failed to obtain available linear instruments. Err: %s", b.Name, err)
+	} else {
+		var swaps []string
+		for i := range linear {
+			// USDC perpetuals such as BTCPERP are not named after their
+			// pair and are left to be addressed by symbol
+			if linear[i].Status != StatusTrading ||
+				linear[i].ContractType != ContractLinearPerpetual ||
+				linear[i].Symbol != linear[i].BaseCoin+linear[i].QuoteCoin {
+				continue
+			}
+			swaps = append(swaps, linear[i].BaseCoin+"-"+linear[i].QuoteCoin)
+		}
+		err = b.UpdateAssetCurrencies(assets.PerpetualSwap, swaps, false, false)
+		if err != nil {
+			log.Printf("%s failed to update available swap currencies. Err: %s", b.Name, err)
+		}
+	}
+
+	inverse, err := b.loadInstruments(CategoryInverse)
+	if err != nil {
+		log.Printf("%s failed to obtain available inverse instruments. Err: %s", b.Name, err)
+		return
+	}
+	var futures []string
+	for i := range inverse {
+		underlying := inverse[i].BaseCoin + "-" + inverse[i].QuoteCoin
+		if inverse[i].Status == StatusTrading &&
+			inverse[i].ContractType == ContractInverseFutures &&
+			!common.StringDataCompare(futures, underlying) {
+			futures = append(futures, underlying)
+		}
+	}
+	err = b.UpdateAssetCurrencies(assets.Futures, futures, false, false)
+	if err != nil {
+		log.Printf("%s failed to update available futures currencies. Err: %s", b.Name, err)
+	}
+}
+
+// loadInstruments fetches and caches the instruments of a category
+func (b *Bybit) loadInstruments(category string) ([]Instrument, error) {
+	instruments, err := b.GetInstruments(category)
+	if err != nil {
+		return nil, err
+	}
+
+	b.instrumentsLock.Lock()
+	b.instruments[category] = instruments
+	b.instrumentsLock.Unlock()
+	return instruments, nil
+}
+
+// cachedInstruments returns the cached instruments of a category, loading
+// them when they have not been fetched
+func (b *Bybit) cachedInstruments(category string) ([]Instrument, error) {
+	b.instrumentsLock.Lock()
+	instruments, ok := b.instruments[category]
+	b.instrumentsLock.Unlock()
+	if ok {
+		return instruments, nil
+	}
+	return b.loadInstruments(category)
+}
+
+// assetCategory returns the category an asset type trades in. Margin trades
+// spot symbols with leverage enabled, perpetual swaps are the linear
+// perpetuals and futures the inverse dated futures.
+func assetCategory(assetType string) (string, error) {
+	switch assetType {
+	case assets.Spot, assets.Margin:
+		return CategorySpot, nil
+	case assets.PerpetualSwap:
+		return CategoryLinear, nil
+	case assets.Futures:
+		return CategoryInverse, nil
+	}
+	return "", fmt.Errorf("asset type %s not supported", assetType)
+}
+
+// categoryAsset returns the asset type of a category's streamed and queried
+// instruments
+func categoryAsset(category string) string {
+	switch category {
+	case CategoryLinear:
+		return assets.PerpetualSwap
+	case CategoryInverse:
+		return assets.Futures
+	}
+	return assets.Spot
+}
+
+// FormatSymbol returns the category and symbol of a pair and asset type.
+// Spot, margin and perpetual swap pairs share their symbol, such as BTCUSDT,
+// and futures pairs resolve to the nearest expiring contract of the
+// underlying, such as BTCUSDH25.
+func (b *Bybit) FormatSymbol(p pair.CurrencyPair, assetType string) (string, string, error) {
+	category, err := assetCategory(assetType)
+	if err != nil {
+		return "", "", err
+	}
+	if assetType != assets.Futures {
+		return category, exchange.FormatExchangeCurrency(b.Name, p).String(), nil
+	}
+
+	instruments, err := b.cachedInstruments(CategoryInverse)
+	if err != nil {
+		return "", "", err
+	}
+	symbol, err := nearestFuture(instruments, p, time.Now())
+	return category, symbol, err
+}
+
+// nearestFuture returns the trading inverse futures contract of an underlying
+// with the closest delivery after the supplied time
+func nearestFuture(instruments []Instrument, underlying pair.CurrencyPair, now time.Time) (string, error) {
+	base := underlying.FirstCurrency.Upper().String()
+	quote := underlying.SecondCurrency.Upper().String()
+
+	var candidates []Instrument
+	for i := range instruments {
+		if instruments[i].ContractType == ContractInverseFutures &&
+			instruments[i].Status == StatusTrading &&
+			instruments[i].BaseCoin == base &&
+			instruments[i].QuoteCoin == quote &&
+			instruments[i].DeliveryTime.Time().After(now) {
+			candidates = append(candidates, instruments[i])
+		}
+	}
+
+	if len(candidates) == 0 {
+		return "", fmt.Errorf("no trading futures contract found for %s-%s", base, quote)
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].DeliveryTime.Time().Before(candidates[j].DeliveryTime.Time())
+	})
+	return candidates[0].Symbol, nil
+}
+
+// pairFromSymbol returns the pair and asset type of a category's symbol.
+// Symbols are looked up in the cached instruments and otherwise split on a
+// known quote coin, futures symbols must be cached.
+func (b *Bybit) pairFromSymbol(category, symbol string) (pair.CurrencyPair, string, error) {
+	b.instrumentsLock.Lock()
+	instruments := b.instruments[category]
+	b.instrumentsLock.Unlock()
+
+	for i := range instruments {
+		if instruments[i].Symbol != symbol {
+			continue
+		}
+		if category == CategoryInverse && instruments[i].ContractType != ContractInverseFutures {
+			break
+		}
+		return pair.NewCurrencyPairDelimiter(instruments[i].BaseCoin+"-"+instruments[i].QuoteCoin, "-"),
+			categoryAsset(category),
+			nil
+	}
+
+	if category != CategoryInverse {
+		if p, err := SymbolToPair(symbol); err == nil {
+			return p, categoryAsset(category), nil
+		}
+	}
+	return pair.CurrencyPair{}, "", fmt.Errorf("unknown %s symbol %s", category, symbol)
+}
+
+// SymbolToPair splits a spot or linear symbol such as BTCUSDT into its pair
+// on a known quote coin
+func SymbolToPair(symbol string) (pair.CurrencyPair, error) {
+	symbol = strings.ToUpper(symbol)
+	for _, quote := range quoteCoins {
+		if len(symbol) > len(quote) && strings.HasSuffix(symbol, quote) {
+			return pair.NewCurrencyPairDelimiter(strings.TrimSuffix(symbol, quote)+"-"+quote, "-"), nil
+		}
+	}
+	return pair.CurrencyPair{}, fmt.Errorf("unable to split symbol %s into a pair", symbol)
+}
+
+// GetFuturesContracts returns the trading inverse dated futures contracts
+func (b *Bybit) GetFuturesContracts() ([]exchange.FuturesContract, error) {
+	instruments, err := b.loadInstruments(CategoryInverse)
+	if err != nil {
+		return nil, err
+	}
+
+	var contracts []exchange.FuturesContract
+	for i := range instruments {
+		if instruments[i].Status != StatusTrading ||
+			instruments[i].ContractType != ContractInverseFutures {
+			continue
+		}
+		contracts = append(contracts, exchange.FuturesContract{
+			InstrumentID: instruments[i].Symbol,
+			Underlying:   pair.NewCurrencyPairDelimiter(instruments[i].BaseCoin+"-"+instruments[i].QuoteCoin, "-"),
+			Expiry:       instruments[i].DeliveryTime.Time(),
+		})
+	}
+	return contracts, nil
+}
+
+// SubmitFuturesOrder submits an order for an inverse futures contract by its
+// symbol
+func (b *Bybit) SubmitFuturesOrder(instrumentID string, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, reduceOnly bool, clientID string) (exchange.SubmitOrderResponse, error) {
+	var submitOrderResponse exchange.SubmitOrderResponse
+	req, err := buildOrder(CategoryInverse, instrumentID, side, orderType, amount, price, clientID)
+	if err != nil {
+		return submitOrderResponse, err
+	}
+	req.ReduceOnly = reduceOnly
+
+	resp, err := b.PlaceOrder(req)
+	if err != nil {
+		return submitOrderResponse, err
+	}
+
+	submitOrderResponse.OrderID = resp.OrderID
+	submitOrderResponse.IsOrderPlaced = true
+	return submitOrderResponse, nil
+}
+
+// GetFundingRate returns the current funding rate of a linear perpetual by
+// its symbol
+func (b *Bybit) GetFundingRate(instrumentID string) (exchange.FundingRate, error) {
+	tickers, err := b.GetTickers(CategoryLinear, instrumentID)
+	if err != nil {
+		return exchange.FundingRate{}, err
+	}
+	if len(tickers) == 0 {
+		return exchange.FundingRate{}, fmt.Errorf("no ticker returned for %s", instrumentID)
+	}
+
+	rate := exchange.FundingRate{
+		InstrumentID: instrumentID,
+		Rate:         tickers[0].FundingRate.Float64(),
+		NextFunding:  tickers[0].NextFundingTime.Time(),
+	}
+	if instruments, err := b.cachedInstruments(CategoryLinear); err == nil {
+		for i := range instruments {
+			if instruments[i].Symbol == instrumentID {
+				rate.Interval = time.Duration(instruments[i].FundingInterval) * time.Minute
+				break
+			}
+		}
+	}
+	return rate, nil
+}
+
+// derivativeCategory returns the category of a derivative's symbol, inverse
+// for cached inverse contracts and otherwise linear
+func (b *Bybit) derivativeCategory(instrumentID string) string {
+	if instruments, err := b.cachedInstruments(CategoryInverse); err == nil {
+		for i := range instruments {
+			if instruments[i].Symbol == instrumentID {
+				return CategoryInverse
+			}
+		}
+	}
+	return CategoryLinear
+}
+
+// GetMarkPrice returns the mark and index price of a linear perpetual or
+// inverse contract by its symbol
+func (b *Bybit) GetMarkPrice(instrumentID string) (exchange.MarkPrice, error) {
+	category := b.derivativeCategory(instrumentID)
+	tickers, err := b.GetTickers(category, instrumentID)
+	if err != nil {
+		return exchange.MarkPrice{}, err
+	}
+	if len(tickers) == 0 {
+		return exchange.MarkPrice{}, fmt.Errorf("no ticker returned for %s", instrumentID)
+	}
+
+	p, assetType, err := b.pairFromSymbol(category, instrumentID)
+	if err != nil {
+		return exchange.MarkPrice{}, err
+	}
+	return exchange.MarkPrice{
+		InstrumentID: instrumentID,
+		Pair:         p,
+		AssetType:    assetType,
+		MarkPrice:    tickers[0].MarkPrice.Float64(),
+		IndexPrice:   tickers[0].IndexPrice.Float64(),
+		Time:         time.Now(),
+	}, nil
+}
+
+// GetFuturesPositions returns the open USDT settled linear and inverse
+// positions. The margin ratio is the position's maintenance margin over its
+// margin balance, which Bybit only reports for isolated margin positions.
+func (b *Bybit) GetFuturesPositions() ([]exchange.FuturesPosition, error) {
+	linear, err := b.GetPositions(CategoryLinear, "", "USDT")
+	if err != nil {
+		return nil, err
+	}
+	inverse, err := b.GetPositions(CategoryInverse, "", "")
+	if err != nil {
+		return nil, err
+	}
+	if _, err = b.cachedInstruments(CategoryInverse); err != nil {
+		return nil, err
+	}
+
+	var positions []exchange.FuturesPosition
+	for category, list := range map[string][]Position{CategoryLinear: linear, CategoryInverse: inverse} {
+		for i := range list {
+			amount := list[i].Size.Float64()
+			if amount == 0 {
+				continue
+			}
+			if list[i].Side == "Sell" {
+				amount = -amount
+			}
+			p, assetType, err := b.pairFromSymbol(category, list[i].Symbol)
+			if err != nil {
+				// Inverse perpetuals are not an enabled asset type but their
+				// positions can still be liquidated
+				p, _ = SymbolToPair(list[i].Symbol)
+				assetType = assets.PerpetualSwap
+			}
+			position := exchange.FuturesPosition{
+				Exchange:         b.Name,
+				InstrumentID:     list[i].Symbol,
+				Pair:             p,
+				AssetType:        assetType,
+				Amount:           amount,
+				EntryPrice:       list[i].AvgPrice.Float64(),
+				MarkPrice:        list[i].MarkPrice.Float64(),
+				LiquidationPrice: list[i].LiqPrice.Float64(),
+				Leverage:         list[i].Leverage.Float64(),
+				UnrealisedPNL:    list[i].UnrealisedPnl.Float64(),
+				UpdateTime:       list[i].UpdatedTime.Time(),
+			}
+			if balance := list[i].PositionBalance.Float64(); balance > 0 {
+				position.MarginRatio = list[i].PositionMM.Float64() / balance
+			}
+			positions = append(positions, position)
+		}
+	}
+	sort.Slice(positions, func(i, j int) bool {
+		return positions[i].InstrumentID < positions[j].InstrumentID
+	})
+	return positions, nil
+}
+
+// UpdateTicker updates and returns the ticker for a currency pair
+func (b *Bybit) UpdateTicker(p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	return b.UpdateTickerContext(context.Background(), p, assetType)
+}
+
+// UpdateTickerContext updates and returns the ticker for a currency pair,
+// cancelled with the context. Spot, margin and perpetual swap tickers are
+// fetched for the whole category and processed for every enabled pair.
+func (b *Bybit) UpdateTickerContext(ctx context.Context, p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	var tickerPrice ticker.Price
+	category, symbol, err := b.FormatSymbol(p, assetType)
+	if err != nil {
+		return tickerPrice, err
+	}
+
+	if category == CategoryInverse {
+		tickers, err := b.GetTickersContext(ctx, category, symbol)
+		if err != nil {
+			return tickerPrice, err
+		}
+		if len(tickers) == 0 {
+			return tickerPrice, fmt.Errorf("no ticker returned for %s", symbol)
+		}
+		ticker.ProcessTicker(b.GetName(), p, tickerToPrice(p, &tickers[0]), assetType)
+		return ticker.GetTicker(b.Name, p, assetType)
+	}
+
+	tickers, err := b.GetTickersContext(ctx, category, "")
+	if err != nil {
+		return tickerPrice, err
+	}
+
+	tickerMap := make(map[string]*Ticker, len(tickers))
+	for i := range tickers {
+		tickerMap[tickers[i].Symbol] = &tickers[i]
+	}
+
+	for _, x := range b.GetEnabledPairs(assetType) {
+		t, ok := tickerMap[exchange.FormatExchangeCurrency(b.Name, x).String()]
+		if !ok {
+			continue
+		}
+		ticker.ProcessTicker(b.GetName(), x, tickerToPrice(x, t), assetType)
+	}
+	return ticker.GetTicker(b.Name, p, assetType)
+}
+
+// tickerToPrice converts a Bybit ticker
+func tickerToPrice(p pair.CurrencyPair, t *Ticker) ticker.Price {
+	return ticker.Price{
+		Pair:        p,
+		Last:        t.LastPrice.Float64(),
+		High:        t.HighPrice24h.Float64(),
+		Low:         t.LowPrice24h.Float64(),
+		Bid:         t.Bid1Price.Float64(),
+		Ask:         t.Ask1Price.Float64(),
+		Volume:      t.Volume24h.Float64(),
+		LastUpdated: time.Now(),
+	}
+}
+
+// GetTickerPrice returns the ticker for a currency pair
+func (b *Bybit) GetTickerPrice(p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	tickerNew, err := ticker.GetTicker(b.GetName(), p, assetType)
+	if err != nil {
+		return b.UpdateTicker(p, assetType)
+	}
+	return tickerNew, nil
+}
+
+// GetOrderbookEx returns orderbook base on the currency pair
+func (b *Bybit) GetOrderbookEx(p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	ob, err := orderbook.GetOrderbook(b.GetName(), p, assetType)
+	if err != nil {
+		return b.UpdateOrderbook(p, assetType)
+	}
+	return ob, nil
+}
+
+// UpdateOrderbook updates and returns the orderbook for a currency pair
+func (b *Bybit) UpdateOrderbook(p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	return b.UpdateOrderbookContext(context.Background(), p, assetType)
+}
+
+// UpdateOrderbookContext updates and returns the orderbook for a currency
+// pair, cancelled with the context
+func (b *Bybit) UpdateOrderbookContext(ctx context.Context, p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	var orderBook orderbook.Base
+	category, symbol, err := b.FormatSymbol(p, assetType)
+	if err != nil {
+		return orderBook, err
+	}
+
+	depth := int64(500)
+	if category == CategorySpot {
+		depth = 200
+	}
+
+	orderbookNew, err := b.GetOrderbookContext(ctx, category, symbol, depth)
+	if err != nil {
+		return orderBook, err
+	}
+
+	for x := range orderbookNew.Bids {
+		orderBook.Bids = append(orderBook.Bids, orderbook.Item{
+			Amount: orderbookNew.Bids[x].Amount,
+			Price:  orderbookNew.Bids[x].Price,
+		})
+	}
+
+	for x := range orderbookNew.Asks {
+		orderBook.Asks = append(orderBook.Asks, orderbook.Item{
+			Amount: orderbookNew.Asks[x].Amount,
+			Price:  orderbookNew.Asks[x].Price,
+		})
+	}
+
+	orderbook.ProcessOrderbook(b.GetName(), p, orderBook, assetType)
+	return orderbook.GetOrderbook(b.Name, p, assetType)
+}
+
+// GetAccountInfo retrieves balances for all currencies in the unified trading
+// account. Hold includes funds locked by open spot orders and the initial
+// margin of open derivatives orders and positions.
+func (b *Bybit) GetAccountInfo() (exchange.AccountInfo, error) {
+	return b.GetAccountInfoContext(context.Background())
+}
+
+// GetAccountInfoContext retrieves balances for all currencies in the unified
+// trading account, cancelled with the context
+func (b *Bybit) GetAccountInfoContext(ctx context.Context) (exchange.AccountInfo, error) {
+	var info exchange.AccountInfo
+	wallets, err := b.GetWalletBalanceContext(ctx, "")
+	if err != nil {
+		return info, err
+	}
+
+	info.ExchangeName = b.GetName()
+	info.Currencies = walletBalances(wallets)
+	return info, nil
+}
+
+// walletBalances converts the unified account's coin balances. The wallet
+// balance is used rather than equity, which includes the unrealised profit
+// and loss of positions.
+func walletBalances(wallets []WalletBalance) []exchange.AccountCurrencyInfo {
+	var currencies []exchange.AccountCurrencyInfo
+	for i := range wallets {
+		for j := range wallets[i].Coins {
+			c := &wallets[i].Coins[j]
+			currencies = append(currencies, exchange.AccountCurrencyInfo{
+				CurrencyName: common.StringToUpper(c.Coin),
+				TotalValue:   c.WalletBalance.Float64(),
+				Hold:         c.Locked.Float64() + c.TotalOrderIM.Float64() + c.TotalPositionIM.Float64(),
+			})
+		}
+	}
+	return currencies
+}
+
+// GetFundingHistory returns funding history, deposits and
+// withdrawals
+func (b *Bybit) GetFundingHistory() ([]exchange.FundHistory, error) {
+	var fundHistory []exchange.FundHistory
+	deposits, err := b.GetDepositRecords("")
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range deposits {
+		id, _ := strconv.ParseInt(deposits[i].ID, 10, 64)
+		fundHistory = append(fundHistory, exchange.FundHistory{
+			ExchangeName:    b.Name,
+			Status:          depositStatuses[deposits[i].Status],
+			TransferID:      id,
+			Timestamp:       deposits[i].SuccessAt.Time(),
+			Currency:        deposits[i].Coin,
+			Amount:          deposits[i].Amount.Float64(),
+			Fee:             deposits[i].DepositFee.Float64(),
+			TransferType:    "deposit",
+			Chain:           deposits[i].Chain,
+			CryptoToAddress: deposits[i].ToAddress,
+			CryptoTxID:      deposits[i].TxID,
+		})
+	}
+
+	withdrawals, err := b.GetWithdrawalRecords("")
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range withdrawals {
+		id, _ := strconv.ParseInt(withdrawals[i].WithdrawID, 10, 64)
+		fundHistory = append(fundHistory, exchange.FundHistory{
+			ExchangeName:    b.Name,
+			Status:          withdrawals[i].Status,
+			TransferID:      id,
+			Timestamp:       withdrawals[i].CreateTime.Time(),
+			Currency:        withdrawals[i].Coin,
+			Amount:          withdrawals[i].Amount.Float64(),
+			NetworkFee:      withdrawals[i].WithdrawFee.Float64(),
+			TransferType:    "withdrawal",
+			Chain:           withdrawals[i].Chain,
+			CryptoToAddress: withdrawals[i].ToAddress,
+			CryptoTxID:      withdrawals[i].TxID,
+		})
+	}
+	return fundHistory, nil
+}
+
+// GetExchangeHistory returns the most recent public trades
+func (b *Bybit) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]exchange.TradeHistory, error) {
+	category, symbol, err := b.FormatSymbol(p, assetType)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := int64(1000)
+	if category == CategorySpot {
+		limit = 60
+	}
+
+	trades, err := b.GetTrades(category, symbol, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]exchange.TradeHistory, len(trades))
+	for i := range trades {
+		tid, _ := strconv.ParseInt(trades[i].ExecID, 10, 64)
+		resp[i] = exchange.TradeHistory{
+			Timestamp: trades[i].Time.Time(),
+			TID:       tid,
+			Price:     trades[i].Price.Float64(),
+			Amount:    trades[i].Size.Float64(),
+			Exchange:  b.Name,
+			Type:      strings.ToLower(trades[i].Side),
+		}
+	}
+	return resp, nil
+}
+
+// GetHistoricCandles returns the candles of a currency pair opening between
+// start and end, paging through the kline endpoint
+func (b *Bybit) GetHistoricCandles(p pair.CurrencyPair, assetType string, interval kline.Interval, start, end time.Time) ([]kline.Candle, error) {
+	klineInterval, ok := candleIntervals[interval]
+	if !ok {
+		return nil, kline.ErrUnsupportedInterval
+	}
+	if !start.Before(end) {
+		return nil, kline.ErrInvalidTimeRange
+	}
+
+	category, symbol, err := b.FormatSymbol(p, assetType)
+	if err != nil {
+		return nil, err
+	}
+
+	var candles []kline.Candle
+	for _, r := range kline.CalculateRanges(interval, start, end, bybitKlineLimit) {
+		resp, err := b.GetKlines(category, symbol, klineInterval, r.Start, r.End, bybitKlineLimit)
+		if err != nil {
+			return nil, err
+		}
+		for x := range resp {
+			candles = append(candles, kline.Candle{
+				Time:   resp[x].Timestamp,
+				Open:   resp[x].Open,
+				High:   resp[x].High,
+				Low:    resp[x].Low,
+				Close:  resp[x].Close,
+				Volume: resp[x].Volume,
+			})
+		}
+	}
+	return kline.FilterCandles(candles, start, end), nil
+}
+
+// buildOrder converts order parameters into an order request of a category.
+// Spot market orders are sized in the base currency to match limit orders.
+func buildOrder(category, symbol string, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (PlaceOrderRequest, error) {
+	req := PlaceOrderRequest{
+		Category:    category,
+		Symbol:      symbol,
+		Qty:         strconv.FormatFloat(amount, 'f', -1, 64),
+		OrderLinkID: clientID,
+	}
+
+	switch side {
+	case exchange.Buy:
+		req.Side = "Buy"
+	case exchange.Sell:
+		req.Side = "Sell"
+	default:
+		return req, fmt.Errorf("unsupported order side %s", side)
+	}
+
+	switch orderType {
+	case exchange.Limit:
+		req.OrderType = OrderTypeLimit
+		req.Price = strconv.FormatFloat(price, 'f', -1, 64)
+		req.TimeInForce = TimeInForceGTC
+	case exchange.Market:
+		req.OrderType = OrderTypeMarket
+		if category == CategorySpot {
+			req.MarketUnit = MarketUnitBase
+		}
+	case exchange.ImmediateOrCancel:
+		req.OrderType = OrderTypeLimit
+		req.Price = strconv.FormatFloat(price, 'f', -1, 64)
+		req.TimeInForce = TimeInForceIOC
+	default:
+		return req, fmt.Errorf("unsupported order type %s", orderType)
+	}
+	return req, nil
+}
+
+// buildSpotOrder converts order parameters into a spot order request rounded
+// to the pair's increments
+func (b *Bybit) buildSpotOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (PlaceOrderRequest, error) {
+	price, amount = b.RoundOrder(p, side, price, amount)
+	return buildOrder(CategorySpot,
+		exchange.FormatExchangeCurrency(b.Name, p).String(),
+		side,
+		orderType,
+		amount,
+		price,
+		clientID)
+}
+
+// SubmitOrder submits a new spot order
+func (b *Bybit) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	return b.SubmitOrderContext(context.Background(), p, side, orderType, amount, price, clientID)
+}
+
+// SubmitOrderContext submits a new spot order, cancelled with the context
+func (b *Bybit) SubmitOrderContext(ctx context.Context, p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	if b.IsSimulated() {
+		return b.SimulateSubmitOrder(p, side, orderType, amount, price, clientID)
+	}
+
+	var submitOrderResponse exchange.SubmitOrderResponse
+	req, err := b.buildSpotOrder(p, side, orderType, amount, price, clientID)
+	if err != nil {
+		return submitOrderResponse, err
+	}
+
+	resp, err := b.PlaceOrderContext(ctx, req)
+	if err != nil {
+		return submitOrderResponse, err
+	}
+
+	submitOrderResponse.OrderID = resp.OrderID
+	submitOrderResponse.IsOrderPlaced = true
+	return submitOrderResponse, nil
+}
+
+// SubmitOrderSubmission submits a spot or inverse futures order carrying its
+// time in force, futures orders are sized in contracts
+func (b *Bybit) SubmitOrderSubmission(o exchange.OrderSubmission) (exchange.SubmitOrderResponse, error) {
+	var submitOrderResponse exchange.SubmitOrderResponse
+	if o.Trigger != "" || (o.Asset() != assets.Spot && o.Asset() != assets.Futures) {
+		return submitOrderResponse, fmt.Errorf("%s %s %s", b.Name, o.Asset(), exchange.ErrOrderNotCarried)
+	}
+
+	var req PlaceOrderRequest
+	var err error
+	if o.Asset() == assets.Spot {
+		req, err = b.buildSpotOrder(o.Pair, o.Side, o.Type, o.BaseAmount, o.Price, o.ClientID)
+	} else {
+		var category, symbol string
+		category, symbol, err = b.FormatSymbol(o.Pair, o.Asset())
+		if err != nil {
+			return submitOrderResponse, err
+		}
+		req, err = buildOrder(category, symbol, o.Side, o.Type, o.BaseAmount, o.Price, o.ClientID)
+	}
+	if err != nil {
+		return submitOrderResponse, err
+	}
+
+	if o.TimeInForce != "" && o.TimeInForce != exchange.GTC {
+		if o.Type != exchange.Limit {
+			return submitOrderResponse, fmt.Errorf("%s market orders do not support time in force %s",
+				b.Name, o.TimeInForce)
+		}
+		switch o.TimeInForce {
+		case exchange.IOC:
+			req.TimeInForce = TimeInForceIOC
+		case exchange.FOK:
+			req.TimeInForce = TimeInForceFOK
+		case exchange.PostOnly:
+			req.TimeInForce = TimeInForcePostOnly
+		default:
+			return submitOrderResponse, fmt.Errorf("%s unsupported time in force %s", b.Name, o.TimeInForce)
+		}
+	}
+
+	resp, err := b.PlaceOrder(req)
+	if err != nil {
+		return submitOrderResponse, err
+	}
+
+	submitOrderResponse.OrderID = resp.OrderID
+	submitOrderResponse.IsOrderPlaced = true
+	return submitOrderResponse, nil
+}
+
+// SubmitQuoteAmountOrder submits a market spot order sized by the quote
+// currency notional
+func (b *Bybit) SubmitQuoteAmountOrder(p pair.CurrencyPair, side exchange.OrderSide, quoteAmount float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	if b.IsSimulated() {
+		return b.SimulateSubmitQuoteAmountOrder(p, side, quoteAmount, clientID)
+	}
+
+	var submitOrderResponse exchange.SubmitOrderResponse
+	req, err := b.buildSpotOrder(p, side, exchange.Market, 0, 0, clientID)
+	if err != nil {
+		return submitOrderResponse, err
+	}
+	req.Qty = strconv.FormatFloat(quoteAmount, 'f', -1, 64)
+	req.MarketUnit = MarketUnitQuote
+
+	resp, err := b.PlaceOrder(req)
+	if err != nil {
+		return submitOrderResponse, err
+	}
+
+	submitOrderResponse.OrderID = resp.OrderID
+	submitOrderResponse.IsOrderPlaced = true
+	return submitOrderResponse, nil
+}
+
+// ModifyOrder will allow of changing orderbook placement and limit to
+// market conversion
+func (b *Bybit) ModifyOrder(action exchange.ModifyOrder) (string, error) {
+	if b.IsSimulated() {
+		return b.SimulateModifyOrder(action)
+	}
+
+	if action.OrderID == "" {
+		return "", errors.New("order ID must be set")
+	}
+
+	req := AmendOrderRequest{
+		Category: CategorySpot,
+		Symbol:   exchange.FormatExchangeCurrency(b.Name, action.Currency).String(),
+		OrderID:  action.OrderID,
+	}
+	if action.Amount > 0 {
+		req.Qty = strconv.FormatFloat(action.Amount, 'f', -1, 64)
+	}
+	if action.Price > 0 {
+		req.Price = strconv.FormatFloat(action.Price, 'f', -1, 64)
+	}
+
+	resp, err := b.AmendOrder(req)
+	if err != nil {
+		return "", err
+	}
+	return resp.OrderID, nil
+}
+
+// CancelOrder cancels a spot order by its corresponding ID number
+func (b *Bybit) CancelOrder(order exchange.OrderCancellation) error {
+	return b.CancelOrderContext(context.Background(), order)
+}
+
+// CancelOrderContext cancels an order by its corresponding ID number,
+// cancelled with the context
+func (b *Bybit) CancelOrderContext(ctx context.Context, order exchange.OrderCancellation) error {
+	if b.IsSimulated() {
+		return b.SimulateCancelOrder(order)
+	}
+
+	_, err := b.CancelExistingOrderContext(ctx, CancelOrderRequest{
+		Category: CategorySpot,
+		Symbol:   exchange.FormatExchangeCurrency(b.Name, order.CurrencyPair).String(),
+		OrderID:  order.OrderID,
+	})
+	return err
+}
+
+// CancelAllOrders cancels all spot orders for all enabled currencies
+func (b *Bybit) CancelAllOrders(orderCancellation exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
+	if b.IsSimulated() {
+		return b.SimulateCancelAllOrders(orderCancellation)
+	}
+
+	cancelAllOrdersResponse := exchange.CancelAllOrdersResponse{
+		OrderStatus: make(map[string]string),
+	}
+
+	for _, p := range b.GetEnabledCurrencies() {
+		symbol := exchange.FormatExchangeCurrency(b.Name, p).String()
+		_, err := b.CancelAll(CancelAllRequest{
+			Category: CategorySpot,
+			Symbol:   symbol,
+		})
+		if err != nil {
+			cancelAllOrdersResponse.OrderStatus[symbol] = err.Error()
+		}
+	}
+
+	return cancelAllOrdersResponse, nil
+}
+
+// GetOrderInfo returns information on a spot order, open orders are searched
+// before the last 7 days of order history
+func (b *Bybit) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
+	if b.IsSimulated() {
+		return b.SimulateGetOrderInfo(orderID)
+	}
+
+	var orderDetail exchange.OrderDetail
+	id := strconv.FormatInt(orderID, 10)
+
+	orders, err := b.GetOpenOrders(CategorySpot, "", id)
+	if err != nil {
+		return orderDetail, err
+	}
+	if len(orders) == 0 {
+		orders, err = b.GetClosedOrders(CategorySpot, "", id)
+		if err != nil {
+			return orderDetail, err
+		}
+	}
+
+	for i := range orders {
+		if orders[i].OrderID == id {
+			return b.orderDetail(CategorySpot, &orders[i])
+		}
+	}
+	return orderDetail, fmt.Errorf("order %d not found", orderID)
+}
+
+// GetActiveOrders retrieves the open spot orders matching the request
+func (b *Bybit) GetActiveOrders(req exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	if b.IsSimulated() {
+		return b.SimulateGetOrders(req, true)
+	}
+
+	orders, err := b.GetOpenOrders(CategorySpot, "", "")
+	if err != nil {
+		return nil, err
+	}
+	return b.filterOrders(orders, req)
+}
+
+// GetOrderHistory retrieves the closed spot orders of the last 7 days
+// matching the request
+func (b *Bybit) GetOrderHistory(req exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	if b.IsSimulated() {
+		return b.SimulateGetOrders(req, false)
+	}
+
+	orders, err := b.GetClosedOrders(CategorySpot, "", "")
+	if err != nil {
+		return nil, err
+	}
+	return b.filterOrders(orders, req)
+}
+
+// filterOrders converts spot orders and returns those matching the request
+func (b *Bybit) filterOrders(orders []Order, req exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	req.Currencies = b.GetOrdersRequestPairs(req)
+	details := make([]exchange.OrderDetail, 0, len(orders))
+	for i := range orders {
+		d, err := b.orderDetail(CategorySpot, &orders[i])
+		if err != nil {
+			return nil, err
+		}
+		details = append(details, d)
+	}
+	return exchange.FilterOrders(details, req), nil
+}
+
+// orderDetail converts a Bybit order to its order details
+func (b *Bybit) orderDetail(category string, o *Order) (exchange.OrderDetail, error) {
+	p, _, err := b.pairFromSymbol(category, o.Symbol)
+	if err != nil {
+		return exchange.OrderDetail{}, err
+	}
+
+	side := exchange.Sell
+	if o.Side == "Buy" {
+		side = exchange.Buy
+	}
+	orderType := exchange.Limit
+	switch {
+	case o.OrderType == OrderTypeMarket:
+		orderType = exchange.Market
+	case o.TimeInForce == TimeInForceIOC:
+		orderType = exchange.ImmediateOrCancel
+	}
+
+	return exchange.OrderDetail{
+		Exchange:             b.Name,
+		ID:                   o.OrderID,
+		BaseCurrency:         p.FirstCurrency.Upper().String(),
+		QuoteCurrency:        p.SecondCurrency.Upper().String(),
+		OrderSide:            string(side),
+		OrderType:            string(orderType),
+		CreationTime:         o.CreatedTime.Time(),
+		Status:               o.OrderStatus,
+		Price:                o.Price.Float64(),
+		Amount:               o.Qty.Float64(),
+		OpenVolume:           o.LeavesQty.Float64(),
+		ExecutedAmount:       o.CumExecQty.Float64(),
+		AverageExecutedPrice: o.AvgPrice.Float64(),
+	}, nil
+}
+
+// GetDepositAddress returns a deposit address for a specified currency,
+// preferring the chain named after it
+func (b *Bybit) GetDepositAddress(cryptocurrency pair.CurrencyItem) (string, error) {
+	addresses, err := b.GetDepositAddresses(cryptocurrency.Upper().String())
+	if err != nil {
+		return "", err
+	}
+	if len(addresses.Chains) == 0 {
+		return "", fmt.Errorf("no deposit address found for %s", cryptocurrency)
+	}
+
+	for i := range addresses.Chains {
+		if strings.EqualFold(addresses.Chains[i].Chain, cryptocurrency.String()) {
+			return addresses.Chains[i].AddressDeposit, nil
+		}
+	}
+	return addresses.Chains[0].AddressDeposit, nil
+}
+
+// WithdrawCryptocurrencyFunds returns a withdrawal ID when a withdrawal is
+// submitted from the funding account. The chain named after the currency is
+// used where withdrawals are enabled on it.
+func (b *Bybit) WithdrawCryptocurrencyFunds(address string, cryptocurrency pair.CurrencyItem, amount float64) (string, error) {
+	coins, err := b.GetCoinInfo(cryptocurrency.Upper().String())
+	if err != nil {
+		return "", err
+	}
+
+	chain, err := selectChain(coins, cryptocurrency.String())
+	if err != nil {
+		return "", err
+	}
+
+	return b.Withdraw(WithdrawalRequest{
+		Coin:        cryptocurrency.Upper().String(),
+		Chain:       chain.Chain,
+		Address:     address,
+		Amount:      strconv.FormatFloat(amount, 'f', -1, 64),
+		AccountType: "FUND",
+	})
+}
+
+// WithdrawFiatFunds returns a withdrawal ID when a
+// withdrawal is submitted
+func (b *Bybit) WithdrawFiatFunds(currency pair.CurrencyItem, amount float64) (string, error) {
+	return "", common.ErrFunctionNotSupported
+}
+
+// WithdrawFiatFundsToInternationalBank returns a withdrawal ID when a
+// withdrawal is submitted
+func (b *Bybit) WithdrawFiatFundsToInternationalBank(currency pair.CurrencyItem, amount float64) (string, error) {
+	return "", common.ErrFunctionNotSupported
+}
+
+// GetWebsocket returns a pointer to the exchange websocket
+func (b *Bybit) GetWebsocket() (*exchange.Websocket, error) {
+	return b.Websocket, nil
+}
+
+// GetFeeByType returns an estimate of fee based on type of transaction
+func (b *Bybit) GetFeeByType(feeBuilder exchange.FeeBuilder) (float64, error) {
+	return b.GetFee(feeBuilder)
+}
+
+// GetWithdrawCapabilities returns the types of withdrawal methods permitted by the exchange
+func (b *Bybit) GetWithdrawCapabilities() uint32 {
+	return b.GetWithdrawPermissions()
+}
+
+// CheckSystemStatus requests the server time to test connectivity to the
+// REST API
+func (b *Bybit) CheckSystemStatus() error {
+	_, err := b.GetServerTime()
+	return err
+}
+
+// GetAPIKeyInfo returns when the API key was created and when it expires
+func (b *Bybit) GetAPIKeyInfo() (exchange.APIKeyInfo, error) {
+	resp, err := b.GetAPIKeyInformation()
+	if err != nil {
+		return exchange.APIKeyInfo{}, err
+	}
+	var info exchange.APIKeyInfo
+	if resp.CreatedAt != "" {
+		info.Created, err = time.Parse(time.RFC3339, resp.CreatedAt)
+		if err != nil {
+			return info, err
+		}
+	}
+	if resp.ExpiredAt != "" {
+		info.Expiry, err = time.Parse(time.RFC3339, resp.ExpiredAt)
+		if err != nil {
+			return info, err
+		}
+	}
+	return info, nil
+}
@@ -0,0 +1,585 @@
+// Package calendar tracks the expiries of dated futures contracts across
+// exchanges, emits roll reminders as contracts approach expiry and rolls
+// positions from an expiring contract to the next through the order manager.
+package calendar
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/orders"
+	"github.com/thrasher-/gocryptotrader/risk"
+)
+
+// Calendar defaults
+const (
+	DefaultRollWindow   = 72 * time.Hour
+	DefaultPollInterval = time.Hour
+)
+
+// Errors returned by the instrument calendar
+var (
+	ErrContractNotFound      = errors.New("futures contract not found")
+	ErrNoNextContract        = errors.New("no later futures contract to roll to")
+	ErrFuturesNotSupported   = errors.New("exchange does not support futures contracts")
+	ErrInvalidPosition       = errors.New("position requires a buy or sell side and an amount greater than zero")
+	ErrPositionsNotSupported = errors.New("exchange does not report futures positions")
+)
+
+// Contract is a futures contract listed on an exchange
+type Contract struct {
+	Exchange string
+	exchange.FuturesContract
+}
+
+// Reminder is emitted once when a contract enters its roll window. Next is
+// the contract to roll to and is empty when none is listed yet.
+type Reminder struct {
+	Contract     Contract
+	Next         Contract
+	TimeToExpiry time.Duration
+}
+
+// Position is an open position in a futures contract. Price is the mark
+// price the roll orders are checked against the risk limits at.
+type Position struct {
+	Exchange     string
+	InstrumentID string
+	Side         exchange.OrderSide
+	Amount       float64
+	Price        float64
+}
+
+// RollResult holds the outcome of rolling a position. The order IDs are the
+// local order manager IDs of the closing and opening orders, OpenOrderID is
+// only valid when Opened is set.
+type RollResult struct {
+	Position     Position
+	From         Contract
+	To           Contract
+	CloseOrderID int
+	OpenOrderID  int
+	Closed       bool
+	Opened       bool
+	Err          error
+}
+
+// PositionSource returns the open futures positions of an exchange
+type PositionSource func(exchName string) ([]Position, error)
+
+// ExchangePositions returns a position source which fetches positions from the
+// exchanges returned by getExchange
+func ExchangePositions(getExchange exchange.ExchangeGetter) PositionSource {
+	return func(exchName string) ([]Position, error) {
+		exch := getExchange(exchName)
+		if exch == nil {
+			return nil, fmt.Errorf("%s exchange not found", exchName)
+		}
+		f, ok := exch.(exchange.FuturesPositionExchange)
+		if !ok {
+			return nil, fmt.Errorf("%s %s", exchName, ErrPositionsNotSupported)
+		}
+		held, err := f.GetFuturesPositions()
+		if err != nil {
+			return nil, err
+		}
+		var positions []Position
+		for i := range held {
+			if held[i].Amount == 0 {
+				continue
+			}
+			p := Position{
+				Exchange:     exchName,
+				InstrumentID: held[i].InstrumentID,
+				Side:         exchange.Buy,
+				Amount:       held[i].Amount,
+				Price:        held[i].MarkPrice,
+			}
+			if p.Amount < 0 {
+				p.Side = exchange.Sell
+				p.Amount = -p.Amount
+			}
+			positions = append(positions, p)
+		}
+		return positions, nil
+	}
+}
+
+// Calendar holds the futures contracts of each exchange and underlying sorted
+// by expiry. Positions are rolled automatically by Process when AutoRoll is
+// set, OnReminder is called for each roll reminder. Run polls the contracts of
+// Exchanges and, when rolling, their Positions, calling OnRoll for each roll.
+// Roll orders are checked against Limits when set.
+type Calendar struct {
+	RollWindow  time.Duration
+	AutoRoll    bool
+	Exchanges   []string
+	GetExchange exchange.ExchangeGetter
+	Positions   PositionSource
+	Limits      *risk.Limits
+	OnReminder  func(Reminder)
+	OnRoll      func(RollResult)
+	OnError     func(exchName string, err error)
+
+	contracts map[string][]Contract
+	windows   map[string]time.Duration
+	reminded  map[string]bool
+	m         sync.Mutex
+}
+
+// NewCalendar returns a new instrument calendar, a zero roll window uses the
+// default
+func NewCalendar(rollWindow time.Duration) *Calendar {
+	if rollWindow <= 0 {
+		rollWindow = DefaultRollWindow
+	}
+	return &Calendar{
+		RollWindow: rollWindow,
+		contracts:  make(map[string][]Contract),
+		windows:    make(map[string]time.Duration),
+		reminded:   make(map[string]bool),
+	}
+}
+
+// underlyingKey returns the calendar key of an exchange underlying
+func underlyingKey(exchName string, underlying pair.CurrencyPair) string {
+	return strings.ToLower(exchName) + "|" + underlying.FirstCurrency.Upper().String() +
+		"-" + underlying.SecondCurrency.Upper().String()
+}
+
+// contractKey returns the calendar key of an exchange contract
+func contractKey(exchName, instrumentID string) string {
+	return strings.ToLower(exchName) + "|" + strings.ToUpper(instrumentID)
+}
+
+// Add adds or updates futures contracts listed on an exchange
+func (c *Calendar) Add(exchName string, contracts ...exchange.FuturesContract) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	for i := range contracts {
+		c.add(Contract{Exchange: exchName, FuturesContract: contracts[i]})
+	}
+}
+
+// add inserts a contract in expiry order replacing any contract with the same
+// instrument ID. The mutex must be held by the caller.
+func (c *Calendar) add(contract Contract) {
+	key := underlyingKey(contract.Exchange, contract.Underlying)
+	list := c.contracts[key]
+	for i := range list {
+		if strings.EqualFold(list[i].InstrumentID, contract.InstrumentID) {
+			list = append(list[:i], list[i+1:]...)
+			break
+		}
+	}
+	list = append(list, contract)
+	sort.SliceStable(list, func(i, j int) bool { return list[i].Expiry.Before(list[j].Expiry) })
+	c.contracts[key] = list
+}
+
+// Update replaces an exchange's contracts with those currently listed
+func (c *Calendar) Update(exch exchange.IBotExchange) error {
+	f, ok := exch.(exchange.FuturesExchange)
+	if !ok {
+		return fmt.Errorf("%s %s", exch.GetName(), ErrFuturesNotSupported)
+	}
+	contracts, err := f.GetFuturesContracts()
+	if err != nil {
+		return err
+	}
+
+	c.m.Lock()
+	defer c.m.Unlock()
+	prefix := strings.ToLower(exch.GetName()) + "|"
+	for key := range c.contracts {
+		if strings.HasPrefix(key, prefix) {
+			delete(c.contracts, key)
+		}
+	}
+	for i := range contracts {
+		c.add(Contract{Exchange: exch.GetName(), FuturesContract: contracts[i]})
+	}
+	return nil
+}
+
+// Contracts returns the contracts of an exchange underlying in expiry order
+func (c *Calendar) Contracts(exchName string, underlying pair.CurrencyPair) []Contract {
+	c.m.Lock()
+	defer c.m.Unlock()
+	list := c.contracts[underlyingKey(exchName, underlying)]
+	contracts := make([]Contract, len(list))
+	copy(contracts, list)
+	return contracts
+}
+
+// Contract returns an exchange contract by its instrument ID
+func (c *Calendar) Contract(exchName, instrumentID string) (Contract, error) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	contract, _, err := c.find(exchName, instrumentID)
+	return contract, err
+}
+
+// Front returns the contract of an exchange underlying with the nearest
+// expiry after now
+func (c *Calendar) Front(exchName string, underlying pair.CurrencyPair, now time.Time) (Contract, error) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	for _, contract := range c.contracts[underlyingKey(exchName, underlying)] {
+		if contract.Expiry.After(now) {
+			return contract, nil
+		}
+	}
+	return Contract{}, ErrContractNotFound
+}
+
+// Next returns the contract of the same underlying expiring after a contract
+func (c *Calendar) Next(exchName, instrumentID string) (Contract, error) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	contract, list, err := c.find(exchName, instrumentID)
+	if err != nil {
+		return Contract{}, err
+	}
+	return next(contract, list)
+}
+
+// find returns a contract and the contracts of its underlying. The mutex must
+// be held by the caller.
+func (c *Calendar) find(exchName, instrumentID string) (Contract, []Contract, error) {
+	prefix := strings.ToLower(exchName) + "|"
+	for key, list := range c.contracts {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		for i := range list {
+			if strings.EqualFold(list[i].InstrumentID, instrumentID) {
+				return list[i], list, nil
+			}
+		}
+	}
+	return Contract{}, nil, fmt.Errorf("%s %s %s", exchName, instrumentID, ErrContractNotFound)
+}
+
+// next returns the first contract in list expiring after contract
+func next(contract Contract, list []Contract) (Contract, error) {
+	for i := range list {
+		if list[i].Expiry.After(contract.Expiry) {
+			return list[i], nil
+		}
+	}
+	return Contract{}, ErrNoNextContract
+}
+
+// SetRollWindow sets the roll window of an exchange underlying, zero reverts
+// to the calendar's roll window
+func (c *Calendar) SetRollWindow(exchName string, underlying pair.CurrencyPair, window time.Duration) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	key := underlyingKey(exchName, underlying)
+	if window <= 0 {
+		delete(c.windows, key)
+		return
+	}
+	c.windows[key] = window
+}
+
+// GetRollWindow returns the roll window of an exchange underlying
+func (c *Calendar) GetRollWindow(exchName string, underlying pair.CurrencyPair) time.Duration {
+	c.m.Lock()
+	defer c.m.Unlock()
+	return c.window(underlyingKey(exchName, underlying))
+}
+
+// window returns the roll window for a key. The mutex must be held by the
+// caller.
+func (c *Calendar) window(key string) time.Duration {
+	if w, ok := c.windows[key]; ok {
+		return w
+	}
+	return c.RollWindow
+}
+
+// InRollWindow returns whether a contract is within its roll window at now
+func (c *Calendar) InRollWindow(contract Contract, now time.Time) bool {
+	c.m.Lock()
+	defer c.m.Unlock()
+	return c.inWindow(contract, now)
+}
+
+// inWindow returns whether a contract is within its roll window at now. The
+// mutex must be held by the caller.
+func (c *Calendar) inWindow(contract Contract, now time.Time) bool {
+	w := c.window(underlyingKey(contract.Exchange, contract.Underlying))
+	return !contract.Expiry.Before(now) && contract.Expiry.Sub(now) <= w
+}
+
+// Prune removes contracts which expired before now
+func (c *Calendar) Prune(now time.Time) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	for key, list := range c.contracts {
+		var live []Contract
+		for i := range list {
+			if list[i].Expiry.Before(now) {
+				delete(c.reminded, contractKey(list[i].Exchange, list[i].InstrumentID))
+				continue
+			}
+			live = append(live, list[i])
+		}
+		if len(live) == 0 {
+			delete(c.contracts, key)
+			continue
+		}
+		c.contracts[key] = live
+	}
+}
+
+// Reminders returns a reminder for each contract which has entered its roll
+// window since the last call, calling OnReminder for each
+func (c *Calendar) Reminders(now time.Time) []Reminder {
+	c.m.Lock()
+	var reminders []Reminder
+	for _, list := range c.contracts {
+		for i := range list {
+			key := contractKey(list[i].Exchange, list[i].InstrumentID)
+			if c.reminded[key] || !c.inWindow(list[i], now) {
+				continue
+			}
+			c.reminded[key] = true
+			r := Reminder{
+				Contract:     list[i],
+				TimeToExpiry: list[i].Expiry.Sub(now),
+			}
+			r.Next, _ = next(list[i], list)
+			reminders = append(reminders, r)
+		}
+	}
+	handler := c.OnReminder
+	c.m.Unlock()
+
+	sort.Slice(reminders, func(i, j int) bool {
+		return reminders[i].Contract.Expiry.Before(reminders[j].Contract.Expiry)
+	})
+	if handler != nil {
+		for i := range reminders {
+			handler(reminders[i])
+		}
+	}
+	return reminders
+}
+
+// Roll closes a position in its contract with a reduce only market order and
+// opens the same position in the next contract, tracking both orders in the
+// order manager. Both orders are checked against the risk limits before the
+// position is closed and each is refused while the exchange's order
+// submission is paused.
+func (c *Calendar) Roll(exch exchange.IBotExchange, p Position) RollResult {
+	result := RollResult{Position: p}
+	if (p.Side != exchange.Buy && p.Side != exchange.Sell) || p.Amount <= 0 {
+		result.Err = ErrInvalidPosition
+		return result
+	}
+	f, ok := exch.(exchange.FuturesExchange)
+	if !ok {
+		result.Err = fmt.Errorf("%s %s", exch.GetName(), ErrFuturesNotSupported)
+		return result
+	}
+
+	c.m.Lock()
+	from, list, err := c.find(p.Exchange, p.InstrumentID)
+	if err == nil {
+		result.From = from
+		result.To, err = next(from, list)
+	}
+	c.m.Unlock()
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	closeSide := exchange.Sell
+	if p.Side == exchange.Sell {
+		closeSide = exchange.Buy
+	}
+	err = c.checkRoll(p, closeSide)
+	if err == nil {
+		err = exchange.CheckOrderSubmission(exch.GetName())
+	}
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	resp, err := f.SubmitFuturesOrder(result.From.InstrumentID, closeSide, exchange.Market, p.Amount, 0, true, "")
+	if err == nil && !resp.IsOrderPlaced {
+		err = fmt.Errorf("%s close order for %s was not placed", p.Exchange, result.From.InstrumentID)
+	}
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	result.Closed = true
+	result.CloseOrderID = orders.TrackOrder(p.Exchange, resp.OrderID, result.From.Underlying,
+		closeSide, exchange.Market, p.Amount, 0)
+
+	err = exchange.CheckOrderSubmission(exch.GetName())
+	if err == nil {
+		resp, err = f.SubmitFuturesOrder(result.To.InstrumentID, p.Side, exchange.Market, p.Amount, 0, false, "")
+	}
+	if err == nil && !resp.IsOrderPlaced {
+		err = fmt.Errorf("%s open order for %s was not placed", p.Exchange, result.To.InstrumentID)
+	}
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	result.Opened = true
+	result.OpenOrderID = orders.TrackOrder(p.Exchange, resp.OrderID, result.To.Underlying,
+		p.Side, exchange.Market, p.Amount, 0)
+	return result
+}
+
+// checkRoll checks the closing and opening orders of a roll against the risk
+// limits, the closing order reducing the position and the opening order
+// restoring it from flat
+func (c *Calendar) checkRoll(p Position, closeSide exchange.OrderSide) error {
+	c.m.Lock()
+	limits := c.Limits
+	c.m.Unlock()
+	if limits == nil {
+		return nil
+	}
+
+	var open int
+	for _, o := range orders.GetOrdersByExchange(p.Exchange) {
+		if o.IsOpen() {
+			open++
+		}
+	}
+	position := p.Amount
+	if p.Side == exchange.Sell {
+		position = -position
+	}
+	err := limits.CheckOrder(risk.Order{
+		Side:       closeSide,
+		Amount:     p.Amount,
+		Price:      p.Price,
+		Position:   position,
+		OpenOrders: open,
+	})
+	if err != nil {
+		return fmt.Errorf("%s close order for %s: %s", p.Exchange, p.InstrumentID, err)
+	}
+	err = limits.CheckOrder(risk.Order{
+		Side:       p.Side,
+		Amount:     p.Amount,
+		Price:      p.Price,
+		OpenOrders: open,
+	})
+	if err != nil {
+		return fmt.Errorf("%s open order for %s: %s", p.Exchange, p.InstrumentID, err)
+	}
+	return nil
+}
+
+// Process emits the roll reminders due at now and, when AutoRoll is set, rolls
+// the positions whose contracts are within their roll window
+func (c *Calendar) Process(now time.Time, positions []Position, getExchange exchange.ExchangeGetter) ([]Reminder, []RollResult) {
+	reminders := c.Reminders(now)
+
+	c.m.Lock()
+	autoRoll := c.AutoRoll
+	c.m.Unlock()
+	if !autoRoll {
+		return reminders, nil
+	}
+
+	var results []RollResult
+	for i := range positions {
+		contract, err := c.Contract(positions[i].Exchange, positions[i].InstrumentID)
+		if err != nil || !c.InRollWindow(contract, now) {
+			continue
+		}
+		exch := getExchange(positions[i].Exchange)
+		if exch == nil {
+			results = append(results, RollResult{
+				Position: positions[i],
+				From:     contract,
+				Err:      fmt.Errorf("%s exchange not found", positions[i].Exchange),
+			})
+			continue
+		}
+		results = append(results, c.Roll(exch, positions[i]))
+	}
+	return reminders, results
+}
+
+// Poll updates the contracts of each exchange, prunes expired contracts and
+// processes the reminders and rolls due at now, fetching the exchanges'
+// positions when AutoRoll is set
+func (c *Calendar) Poll(now time.Time) ([]Reminder, []RollResult) {
+	c.m.Lock()
+	autoRoll := c.AutoRoll
+	c.m.Unlock()
+
+	var positions []Position
+	for _, name := range c.Exchanges {
+		exch := c.GetExchange(name)
+		if exch == nil {
+			c.error(name, fmt.Errorf("%s exchange not found", name))
+			continue
+		}
+		if err := c.Update(exch); err != nil {
+			c.error(name, err)
+			continue
+		}
+		if !autoRoll || c.Positions == nil {
+			continue
+		}
+		held, err := c.Positions(name)
+		if err != nil {
+			c.error(name, err)
+			continue
+		}
+		positions = append(positions, held...)
+	}
+	c.Prune(now)
+
+	reminders, results := c.Process(now, positions, c.GetExchange)
+	if c.OnRoll != nil {
+		for i := range results {
+			c.OnRoll(results[i])
+		}
+	}
+	return reminders, results
+}
+
+// error reports an exchange error to OnError when set
+func (c *Calendar) error(exchName string, err error) {
+	if c.OnError != nil {
+		c.OnError(exchName, err)
+	}
+}
+
+// Run polls the calendar every interval until stop is closed
+func (c *Calendar) Run(interval time.Duration, stop <-chan struct{}) {
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		c.Poll(time.Now())
+		select {
+		case <-stop:
+			return
+		case <-t.C:
+		}
+	}
+}
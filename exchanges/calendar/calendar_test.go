@@ -0,0 +1,251 @@
+package calendar
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/orders"
+	"github.com/thrasher-/gocryptotrader/risk"
+)
+
+var (
+	btcusd = pair.NewCurrencyPair("BTC", "USD")
+	now    = time.Date(2018, 9, 20, 0, 0, 0, 0, time.UTC)
+)
+
+// testFuturesOrder records a submitted futures order
+type testFuturesOrder struct {
+	instrumentID string
+	side         exchange.OrderSide
+	amount       float64
+	reduceOnly   bool
+}
+
+// testFuturesExchange lists contracts and records submitted orders
+type testFuturesExchange struct {
+	exchange.IBotExchange
+	contracts []exchange.FuturesContract
+	positions []exchange.FuturesPosition
+	submitted []testFuturesOrder
+	failOpen  bool
+}
+
+func (e *testFuturesExchange) GetName() string {
+	return "FuturesExchange"
+}
+
+func (e *testFuturesExchange) GetFuturesContracts() ([]exchange.FuturesContract, error) {
+	return e.contracts, nil
+}
+
+func (e *testFuturesExchange) GetFuturesPositions() ([]exchange.FuturesPosition, error) {
+	return e.positions, nil
+}
+
+func (e *testFuturesExchange) SubmitFuturesOrder(instrumentID string, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, reduceOnly bool, clientID string) (exchange.SubmitOrderResponse, error) {
+	if e.failOpen && !reduceOnly {
+		return exchange.SubmitOrderResponse{}, errors.New("insufficient margin")
+	}
+	e.submitted = append(e.submitted, testFuturesOrder{instrumentID, side, amount, reduceOnly})
+	return exchange.SubmitOrderResponse{IsOrderPlaced: true, OrderID: instrumentID}, nil
+}
+
+func newTestExchange() *testFuturesExchange {
+	return &testFuturesExchange{
+		contracts: []exchange.FuturesContract{
+			{InstrumentID: "BTC-USD-181228", Underlying: btcusd, Expiry: now.Add(99 * 24 * time.Hour)},
+			{InstrumentID: "BTC-USD-180921", Underlying: btcusd, Expiry: now.Add(32 * time.Hour)},
+			{InstrumentID: "BTC-USD-180928", Underlying: btcusd, Expiry: now.Add(8 * 24 * time.Hour)},
+		},
+	}
+}
+
+func TestCalendarContracts(t *testing.T) {
+	c := NewCalendar(0)
+	if c.RollWindow != DefaultRollWindow {
+		t.Error("Test Failed - NewCalendar() expected default roll window")
+	}
+	if err := c.Update(newTestExchange()); err != nil {
+		t.Fatal("Test Failed - Update() error", err)
+	}
+
+	contracts := c.Contracts("futuresexchange", btcusd)
+	if len(contracts) != 3 || contracts[0].InstrumentID != "BTC-USD-180921" ||
+		contracts[2].InstrumentID != "BTC-USD-181228" {
+		t.Fatal("Test Failed - Contracts() expected contracts in expiry order", contracts)
+	}
+
+	front, err := c.Front("FuturesExchange", btcusd, now)
+	if err != nil || front.InstrumentID != "BTC-USD-180921" {
+		t.Error("Test Failed - Front() incorrect contract", front.InstrumentID, err)
+	}
+	front, err = c.Front("FuturesExchange", btcusd, now.Add(48*time.Hour))
+	if err != nil || front.InstrumentID != "BTC-USD-180928" {
+		t.Error("Test Failed - Front() incorrect contract after expiry", front.InstrumentID, err)
+	}
+	next, err := c.Next("FuturesExchange", "BTC-USD-180928")
+	if err != nil || next.InstrumentID != "BTC-USD-181228" {
+		t.Error("Test Failed - Next() incorrect contract", next.InstrumentID, err)
+	}
+	if _, err = c.Next("FuturesExchange", "BTC-USD-181228"); err != ErrNoNextContract {
+		t.Error("Test Failed - Next() expected no next contract error", err)
+	}
+	if _, err = c.Contract("FuturesExchange", "ETH-USD-180921"); err == nil {
+		t.Error("Test Failed - Contract() expected contract not found error")
+	}
+
+	c.Prune(now.Add(48 * time.Hour))
+	if len(c.Contracts("FuturesExchange", btcusd)) != 2 {
+		t.Error("Test Failed - Prune() expected expired contract removed")
+	}
+}
+
+func TestCalendarReminders(t *testing.T) {
+	c := NewCalendar(48 * time.Hour)
+	var emitted []Reminder
+	c.OnReminder = func(r Reminder) { emitted = append(emitted, r) }
+	c.Add("FuturesExchange", newTestExchange().contracts...)
+
+	reminders := c.Reminders(now)
+	if len(reminders) != 1 || len(emitted) != 1 ||
+		reminders[0].Contract.InstrumentID != "BTC-USD-180921" ||
+		reminders[0].Next.InstrumentID != "BTC-USD-180928" ||
+		reminders[0].TimeToExpiry != 32*time.Hour {
+		t.Fatal("Test Failed - Reminders() incorrect reminders", reminders)
+	}
+	if len(c.Reminders(now.Add(time.Hour))) != 0 {
+		t.Error("Test Failed - Reminders() expected reminder emitted once")
+	}
+
+	c.SetRollWindow("FuturesExchange", btcusd, 10*24*time.Hour)
+	if c.GetRollWindow("FuturesExchange", btcusd) != 10*24*time.Hour {
+		t.Error("Test Failed - SetRollWindow() roll window not set")
+	}
+	reminders = c.Reminders(now)
+	if len(reminders) != 1 || reminders[0].Contract.InstrumentID != "BTC-USD-180928" {
+		t.Error("Test Failed - Reminders() expected reminder with custom roll window", reminders)
+	}
+}
+
+func TestCalendarRoll(t *testing.T) {
+	exch := newTestExchange()
+	c := NewCalendar(48 * time.Hour)
+	c.AutoRoll = true
+	if err := c.Update(exch); err != nil {
+		t.Fatal("Test Failed - Update() error", err)
+	}
+	getExchange := func(name string) exchange.IBotExchange {
+		if name == "FuturesExchange" {
+			return exch
+		}
+		return nil
+	}
+
+	positions := []Position{
+		{Exchange: "FuturesExchange", InstrumentID: "BTC-USD-180921", Side: exchange.Buy, Amount: 5},
+		{Exchange: "FuturesExchange", InstrumentID: "BTC-USD-180928", Side: exchange.Sell, Amount: 2},
+	}
+	reminders, results := c.Process(now, positions, getExchange)
+	if len(reminders) != 1 || len(results) != 1 {
+		t.Fatal("Test Failed - Process() expected one reminder and roll", reminders, results)
+	}
+	r := results[0]
+	if r.Err != nil || !r.Closed || !r.Opened || r.To.InstrumentID != "BTC-USD-180928" {
+		t.Fatal("Test Failed - Process() incorrect roll result", r)
+	}
+	if len(exch.submitted) != 2 ||
+		exch.submitted[0] != (testFuturesOrder{"BTC-USD-180921", exchange.Sell, 5, true}) ||
+		exch.submitted[1] != (testFuturesOrder{"BTC-USD-180928", exchange.Buy, 5, false}) {
+		t.Error("Test Failed - Process() incorrect roll orders", exch.submitted)
+	}
+	if o := orders.GetOrderByOrderID(r.OpenOrderID); o == nil || o.ExchangeOrderID != "BTC-USD-180928" {
+		t.Error("Test Failed - Roll() open order not tracked")
+	}
+
+	exch.failOpen = true
+	r = c.Roll(exch, positions[1])
+	if r.Err == nil || !r.Closed || r.Opened || r.To.InstrumentID != "BTC-USD-181228" {
+		t.Error("Test Failed - Roll() expected open failure after close", r)
+	}
+
+	r = c.Roll(exch, Position{Exchange: "FuturesExchange", InstrumentID: "BTC-USD-181228", Side: exchange.Buy, Amount: 1})
+	if r.Err != ErrNoNextContract {
+		t.Error("Test Failed - Roll() expected no next contract error", r.Err)
+	}
+	r = c.Roll(exch, Position{Exchange: "FuturesExchange", InstrumentID: "BTC-USD-180921"})
+	if r.Err != ErrInvalidPosition {
+		t.Error("Test Failed - Roll() expected invalid position error", r.Err)
+	}
+}
+
+func TestCalendarRollChecks(t *testing.T) {
+	exch := newTestExchange()
+	c := NewCalendar(48 * time.Hour)
+	c.Limits = &risk.Limits{MaxOrderNotional: 50000}
+	if err := c.Update(exch); err != nil {
+		t.Fatal("Test Failed - Update() error", err)
+	}
+	p := Position{Exchange: "FuturesExchange", InstrumentID: "BTC-USD-180921", Side: exchange.Buy, Amount: 5}
+
+	if r := c.Roll(exch, p); r.Err == nil || r.Closed {
+		t.Error("Test Failed - Roll() expected unpriced roll refused with limits set", r)
+	}
+	p.Price = 20000
+	if r := c.Roll(exch, p); r.Err == nil || r.Closed || len(exch.submitted) != 0 {
+		t.Error("Test Failed - Roll() expected roll over the notional limit refused", r)
+	}
+
+	p.Amount = 2
+	exchange.PauseOrderSubmission("FuturesExchange", "degraded")
+	r := c.Roll(exch, p)
+	exchange.ResumeOrderSubmission("FuturesExchange")
+	if r.Err == nil || r.Closed || len(exch.submitted) != 0 {
+		t.Error("Test Failed - Roll() expected roll refused while submission is paused", r)
+	}
+
+	if r = c.Roll(exch, p); r.Err != nil || !r.Closed || !r.Opened {
+		t.Error("Test Failed - Roll() expected roll within limits", r)
+	}
+}
+
+func TestCalendarPoll(t *testing.T) {
+	exch := newTestExchange()
+	exch.positions = []exchange.FuturesPosition{
+		{InstrumentID: "BTC-USD-180921", Amount: -3, MarkPrice: 6500},
+		{InstrumentID: "BTC-USD-180928"},
+	}
+	getExchange := func(name string) exchange.IBotExchange {
+		if name == "FuturesExchange" {
+			return exch
+		}
+		return nil
+	}
+
+	c := NewCalendar(48 * time.Hour)
+	c.AutoRoll = true
+	c.Exchanges = []string{"FuturesExchange", "Missing"}
+	c.GetExchange = getExchange
+	c.Positions = ExchangePositions(getExchange)
+	c.Limits = &risk.Limits{MaxPosition: 5}
+	var rolls []RollResult
+	var failed []string
+	c.OnRoll = func(r RollResult) { rolls = append(rolls, r) }
+	c.OnError = func(exchName string, err error) { failed = append(failed, exchName) }
+
+	reminders, results := c.Poll(now)
+	if len(reminders) != 1 || len(results) != 1 || len(rolls) != 1 {
+		t.Fatal("Test Failed - Poll() expected one reminder and roll", reminders, results)
+	}
+	if len(failed) != 1 || failed[0] != "Missing" {
+		t.Error("Test Failed - Poll() expected missing exchange error", failed)
+	}
+	r := rolls[0]
+	if r.Err != nil || r.Position.Side != exchange.Sell || r.Position.Amount != 3 ||
+		r.Position.Price != 6500 || len(exch.submitted) != 2 ||
+		exch.submitted[1] != (testFuturesOrder{"BTC-USD-180928", exchange.Sell, 3, false}) {
+		t.Error("Test Failed - Poll() incorrect roll", r, exch.submitted)
+	}
+}
@@ -0,0 +1,657 @@
+package coinbase
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/config"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/assets"
+	"github.com/thrasher-/gocryptotrader/exchanges/request"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+const (
+	coinbaseAPIURL     = "https://api.coinbase.com"
+	coinbaseAPIVersion = "/api/v3/brokerage/"
+	coinbaseV2         = "/v2/"
+
+	// Public endpoints, these do not require authentication
+	coinbaseProducts    = "market/products"
+	coinbaseProductBook = "market/product_book"
+	coinbaseTicker      = "ticker"
+	coinbaseCandles     = "candles"
+
+	// Authenticated endpoints
+	coinbaseAccounts           = "accounts"
+	coinbaseOrders             = "orders"
+	coinbaseBatchCancel        = "orders/batch_cancel"
+	coinbaseHistoricalOrders   = "orders/historical"
+	coinbaseHistoricalBatch    = "orders/historical/batch"
+	coinbaseTransactionSummary = "transaction_summary"
+	coinbaseAddresses          = "accounts/%s/addresses"
+	coinbaseTransactions       = "accounts/%s/transactions"
+
+	// Coinbase allows 10 public and 30 private requests per second
+	coinbaseAuthRate   = 30
+	coinbaseUnauthRate = 10
+
+	coinbaseJWTIssuer = "cdp"
+	// JWTs are valid for two minutes so one is created per request
+	coinbaseJWTExpiry = time.Minute * 2
+
+	coinbaseAccountsLimit = 250
+	coinbaseCandleLimit   = 350
+	coinbaseCancelLimit   = 100
+
+	// Fees of the lowest pricing tier
+	coinbaseDefaultTakerFee = 0.006
+	coinbaseDefaultMakerFee = 0.004
+)
+
+// Errors returned by the Coinbase package
+var (
+	ErrInvalidKey     = errors.New("API secret is not a PEM encoded EC private key")
+	ErrAccountMissing = errors.New("no account found for currency")
+)
+
+// Coinbase is the overarching type across the Coinbase package
+type Coinbase struct {
+	exchange.Base
+	WebsocketConn *websocket.Conn
+	wsWriteLock   sync.Mutex
+
+	keyLock   sync.Mutex
+	key       *ecdsa.PrivateKey
+	keySecret string
+}
+
+// SetDefaults sets the basic defaults for Coinbase
+func (c *Coinbase) SetDefaults() {
+	c.Name = "Coinbase"
+	c.Enabled = false
+	c.Verbose = false
+	c.RESTPollingDelay = 10
+	c.APIWithdrawPermissions = exchange.AutoWithdrawCryptoWithAPIPermission
+	c.BrokerTagFormat = exchange.BrokerTagFormat{MaxLength: 12}
+	c.Features = exchange.Features{
+		CanGetTicker:          true,
+		CanGetOrderbook:       true,
+		CanGetTradeHistory:    true,
+		CanGetHistoricCandles: true,
+		CanGetAccountInfo:     true,
+		CanGetFundingHistory:  true,
+		CanSubmitOrder:        true,
+		IdempotentClientID:    true,
+		CanCancelOrder:        true,
+		CanCancelAllOrders:    true,
+		CanGetActiveOrders:    true,
+		CanGetOrderHistory:    true,
+		CanGetDepositAddress:  true,
+		CanWithdrawCrypto:     true,
+		CanStreamTicker:       true,
+		CanStreamOrderbook:    true,
+		CanStreamTrades:       true,
+	}
+	c.Features.Orders = map[string]exchange.OrderCapabilities{
+		assets.Spot: {
+			OrderTypes:  []exchange.OrderType{exchange.Limit, exchange.Market, exchange.ImmediateOrCancel},
+			TimeInForce: []exchange.TimeInForce{exchange.GTC, exchange.IOC},
+		},
+	}
+	c.RequestCurrencyPairFormat.Delimiter = "-"
+	c.RequestCurrencyPairFormat.Uppercase = true
+	c.ConfigCurrencyPairFormat.Delimiter = "-"
+	c.ConfigCurrencyPairFormat.Uppercase = true
+	c.AssetTypes = []string{ticker.Spot}
+	c.SupportsAutoPairUpdating = true
+	c.SupportsRESTTickerBatching = false
+	// Market buys are sized in the quote currency
+	c.MarketBuyInQuote = true
+	c.Requester = request.New(c.Name,
+		request.NewRateLimit(time.Second, coinbaseAuthRate),
+		request.NewRateLimit(time.Second, coinbaseUnauthRate),
+		common.NewHTTPClientWithTimeout(exchange.DefaultHTTPTimeout))
+	c.APIUrlDefault = coinbaseAPIURL
+	c.APIUrl = c.APIUrlDefault
+	c.WebsocketInit()
+}
+
+// Setup takes in the supplied exchange configuration details and sets params
+func (c *Coinbase) Setup(exch config.ExchangeConfig) {
+	if !exch.Enabled {
+		c.SetEnabled(false)
+	} else {
+		c.Enabled = true
+		c.AuthenticatedAPISupport = exch.AuthenticatedAPISupport
+		c.SetAPIKeys(exch.APIKey, exch.APISecret, "", false)
+		c.SetHTTPClientTimeout(exch.HTTPTimeout)
+		c.SetHTTPClientUserAgent(exch.HTTPUserAgent)
+		c.RESTPollingDelay = exch.RESTPollingDelay
+		c.Verbose = exch.Verbose
+		c.BaseCurrencies = common.SplitStrings(exch.BaseCurrencies, ",")
+		c.AvailablePairs = common.SplitStrings(exch.AvailablePairs, ",")
+		c.EnabledPairs = common.SplitStrings(exch.EnabledPairs, ",")
+		err := c.SetCurrencyPairFormat()
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = c.SetAssetTypes()
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = c.SetAutoPairDefaults()
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = c.SetAPIURL(exch)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = c.SetClientProxyAddress(exch.ProxyAddress)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = c.SetTLSPins(exch.TLSPins)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = c.SetLocalAddress(exch.LocalAddress)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = c.SetRoundingModes(exch.RoundingModes)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = c.SetRateLimitTiers(exch.RateLimitTiers, exch.AccountTier)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = c.SetHTTPClientHeaders(exch.HTTPHeaders)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = c.SetBrokerTag(exch.BrokerTag)
+		if err != nil {
+			log.Fatal(err)
+		}
+		c.SetSimulation(exch.Simulate, exch.SimulationBalances)
+		err = c.WebsocketSetup(c.WsConnect,
+			exch.Name,
+			exch.Websocket,
+			coinbaseWebsocketURL,
+			exch.WebsocketURL)
+		if err != nil {
+			log.Fatal(err)
+		}
+		c.Websocket.SetSubscriber(c.wsDefaultSubscriptions, c.WsSubscribe, c.WsUnsubscribe)
+	}
+}
+
+// GetProducts returns all spot products
+func (c *Coinbase) GetProducts() ([]Product, error) {
+	var resp Products
+	params := url.Values{}
+	params.Set("product_type", "SPOT")
+
+	path := common.EncodeURLValues(c.APIUrl+coinbaseAPIVersion+coinbaseProducts, params)
+	err := c.SendHTTPRequest(path, &resp)
+	return resp.Products, err
+}
+
+// GetProduct returns a single product by its product ID, such as BTC-USD
+func (c *Coinbase) GetProduct(productID string) (Product, error) {
+	return c.GetProductContext(context.Background(), productID)
+}
+
+// GetProductContext returns a single product by its product ID, cancelled
+// with the context
+func (c *Coinbase) GetProductContext(ctx context.Context, productID string) (Product, error) {
+	var resp Product
+	path := c.APIUrl + coinbaseAPIVersion + coinbaseProducts + "/" + productID
+	return resp, c.SendHTTPRequestContext(ctx, path, &resp)
+}
+
+// GetProductBook returns bids and asks for a product, limit is optional
+func (c *Coinbase) GetProductBook(productID string, limit int64) (ProductBook, error) {
+	return c.GetProductBookContext(context.Background(), productID, limit)
+}
+
+// GetProductBookContext returns bids and asks for a product, cancelled with
+// the context
+func (c *Coinbase) GetProductBookContext(ctx context.Context, productID string, limit int64) (ProductBook, error) {
+	var resp struct {
+		Pricebook ProductBook `json:"pricebook"`
+	}
+	params := url.Values{}
+	params.Set("product_id", productID)
+	if limit > 0 {
+		params.Set("limit", strconv.FormatInt(limit, 10))
+	}
+
+	path := common.EncodeURLValues(c.APIUrl+coinbaseAPIVersion+coinbaseProductBook, params)
+	err := c.SendHTTPRequestContext(ctx, path, &resp)
+	return resp.Pricebook, err
+}
+
+// GetMarketTrades returns the most recent trades of a product along with its
+// best bid and ask
+func (c *Coinbase) GetMarketTrades(productID string, limit int64) (MarketTrades, error) {
+	return c.GetMarketTradesContext(context.Background(), productID, limit)
+}
+
+// GetMarketTradesContext returns the most recent trades of a product along
+// with its best bid and ask, cancelled with the context
+func (c *Coinbase) GetMarketTradesContext(ctx context.Context, productID string, limit int64) (MarketTrades, error) {
+	var resp MarketTrades
+	params := url.Values{}
+	params.Set("limit", strconv.FormatInt(limit, 10))
+
+	path := common.EncodeURLValues(c.APIUrl+coinbaseAPIVersion+coinbaseProducts+"/"+productID+"/"+coinbaseTicker, params)
+	return resp, c.SendHTTPRequestContext(ctx, path, &resp)
+}
+
+// GetCandles returns up to 350 candles of a product between start and end.
+// Granularity is one of ONE_MINUTE, FIVE_MINUTE, FIFTEEN_MINUTE,
+// THIRTY_MINUTE, ONE_HOUR, TWO_HOUR, SIX_HOUR or ONE_DAY.
+func (c *Coinbase) GetCandles(productID, granularity string, start, end time.Time) ([]Candle, error) {
+	var resp struct {
+		Candles []Candle `json:"candles"`
+	}
+	params := url.Values{}
+	params.Set("start", strconv.FormatInt(start.Unix(), 10))
+	params.Set("end", strconv.FormatInt(end.Unix(), 10))
+	params.Set("granularity", granularity)
+
+	path := common.EncodeURLValues(c.APIUrl+coinbaseAPIVersion+coinbaseProducts+"/"+productID+"/"+coinbaseCandles, params)
+	err := c.SendHTTPRequest(path, &resp)
+	return resp.Candles, err
+}
+
+// GetAccounts returns a page of accounts, cursor is empty for the first page
+func (c *Coinbase) GetAccounts(cursor string) (Accounts, error) {
+	return c.GetAccountsContext(context.Background(), cursor)
+}
+
+// GetAccountsContext returns a page of accounts, cancelled with the context
+func (c *Coinbase) GetAccountsContext(ctx context.Context, cursor string) (Accounts, error) {
+	var resp Accounts
+	params := url.Values{}
+	params.Set("limit", strconv.Itoa(coinbaseAccountsLimit))
+	if cursor != "" {
+		params.Set("cursor", cursor)
+	}
+	return resp, c.SendAuthHTTPRequestContext(ctx, "GET", coinbaseAPIVersion+coinbaseAccounts, params, nil, &resp)
+}
+
+// GetAllAccounts returns every account, paging through GetAccounts
+func (c *Coinbase) GetAllAccounts() ([]Account, error) {
+	return c.GetAllAccountsContext(context.Background())
+}
+
+// GetAllAccountsContext returns every account, cancelled with the context
+func (c *Coinbase) GetAllAccountsContext(ctx context.Context) ([]Account, error) {
+	var accounts []Account
+	var cursor string
+	for {
+		resp, err := c.GetAccountsContext(ctx, cursor)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, resp.Accounts...)
+		if !resp.HasNext || resp.Cursor == "" {
+			return accounts, nil
+		}
+		cursor = resp.Cursor
+	}
+}
+
+// getAccountID returns the account UUID of a currency
+func (c *Coinbase) getAccountID(currency string) (string, error) {
+	accounts, err := c.GetAllAccounts()
+	if err != nil {
+		return "", err
+	}
+	for i := range accounts {
+		if strings.EqualFold(accounts[i].Currency, currency) {
+			return accounts[i].UUID, nil
+		}
+	}
+	return "", ErrAccountMissing
+}
+
+// CreateOrder places a new order
+func (c *Coinbase) CreateOrder(arg CreateOrderRequest) (CreateOrderResponse, error) {
+	return c.CreateOrderContext(context.Background(), arg)
+}
+
+// CreateOrderContext places a new order, cancelled with the context
+func (c *Coinbase) CreateOrderContext(ctx context.Context, arg CreateOrderRequest) (CreateOrderResponse, error) {
+	var resp CreateOrderResponse
+	err := c.SendAuthHTTPRequestContext(ctx, "POST", coinbaseAPIVersion+coinbaseOrders, nil, arg, &resp)
+	if err != nil {
+		return resp, err
+	}
+	if !resp.Success {
+		reason := resp.ErrorResponse.Message
+		if reason == "" {
+			reason = resp.ErrorResponse.PreviewFailureReason
+		}
+		return resp, fmt.Errorf("%s order rejected: %s %s",
+			c.Name,
+			resp.ErrorResponse.Error,
+			reason)
+	}
+	return resp, nil
+}
+
+// CancelOrders cancels up to 100 orders by their order IDs
+func (c *Coinbase) CancelOrders(orderIDs []string) ([]CancelResult, error) {
+	return c.CancelOrdersContext(context.Background(), orderIDs)
+}
+
+// CancelOrdersContext cancels up to 100 orders by their order IDs, cancelled
+// with the context
+func (c *Coinbase) CancelOrdersContext(ctx context.Context, orderIDs []string) ([]CancelResult, error) {
+	var resp struct {
+		Results []CancelResult `json:"results"`
+	}
+	req := struct {
+		OrderIDs []string `json:"order_ids"`
+	}{OrderIDs: orderIDs}
+	err := c.SendAuthHTTPRequestContext(ctx, "POST", coinbaseAPIVersion+coinbaseBatchCancel, nil, req, &resp)
+	return resp.Results, err
+}
+
+// ListOrders returns a page of orders matching the request
+func (c *Coinbase) ListOrders(req OrdersRequest) (Orders, error) {
+	var resp Orders
+	params := url.Values{}
+	for i := range req.ProductIDs {
+		params.Add("product_ids", req.ProductIDs[i])
+	}
+	for i := range req.Status {
+		params.Add("order_status", req.Status[i])
+	}
+	if req.Side != "" {
+		params.Set("order_side", req.Side)
+	}
+	if !req.Start.IsZero() {
+		params.Set("start_date", req.Start.UTC().Format(time.RFC3339))
+	}
+	if !req.End.IsZero() {
+		params.Set("end_date", req.End.UTC().Format(time.RFC3339))
+	}
+	if req.Cursor != "" {
+		params.Set("cursor", req.Cursor)
+	}
+	return resp, c.SendAuthHTTPRequest("GET", coinbaseAPIVersion+coinbaseHistoricalBatch, params, nil, &resp)
+}
+
+// GetAllOrders returns every order matching the request, paging through
+// ListOrders
+func (c *Coinbase) GetAllOrders(req OrdersRequest) ([]Order, error) {
+	var orders []Order
+	for {
+		resp, err := c.ListOrders(req)
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, resp.Orders...)
+		if !resp.HasNext || resp.Cursor == "" {
+			return orders, nil
+		}
+		req.Cursor = resp.Cursor
+	}
+}
+
+// QueryOrder returns an order by its order ID
+func (c *Coinbase) QueryOrder(orderID string) (Order, error) {
+	var resp struct {
+		Order Order `json:"order"`
+	}
+	err := c.SendAuthHTTPRequest("GET", coinbaseAPIVersion+coinbaseHistoricalOrders+"/"+orderID, nil, nil, &resp)
+	return resp.Order, err
+}
+
+// GetTransactionSummary returns the account's fee tier and 30 day volume
+func (c *Coinbase) GetTransactionSummary() (TransactionSummary, error) {
+	var resp TransactionSummary
+	return resp, c.SendAuthHTTPRequest("GET", coinbaseAPIVersion+coinbaseTransactionSummary, nil, nil, &resp)
+}
+
+// CreateAddress creates a deposit address for an account
+func (c *Coinbase) CreateAddress(accountID string) (Address, error) {
+	var resp struct {
+		Data Address `json:"data"`
+	}
+	path := coinbaseV2 + fmt.Sprintf(coinbaseAddresses, accountID)
+	err := c.SendAuthHTTPRequest("POST", path, nil, struct{}{}, &resp)
+	return resp.Data, err
+}
+
+// SendMoney withdraws crypto from an account to an address and returns the
+// transaction
+func (c *Coinbase) SendMoney(accountID string, arg SendRequest) (Transaction, error) {
+	var resp struct {
+		Data Transaction `json:"data"`
+	}
+	arg.Type = "send"
+	path := coinbaseV2 + fmt.Sprintf(coinbaseTransactions, accountID)
+	err := c.SendAuthHTTPRequest("POST", path, nil, arg, &resp)
+	return resp.Data, err
+}
+
+// GetTransactions returns a page of an account's transactions, startingAfter
+// is the last transaction ID of the previous page and empty for the first
+func (c *Coinbase) GetTransactions(accountID, startingAfter string) ([]Transaction, Pagination, error) {
+	var resp struct {
+		Pagination Pagination    `json:"pagination"`
+		Data       []Transaction `json:"data"`
+	}
+	params := url.Values{}
+	params.Set("limit", "100")
+	if startingAfter != "" {
+		params.Set("starting_after", startingAfter)
+	}
+	path := coinbaseV2 + fmt.Sprintf(coinbaseTransactions, accountID)
+	err := c.SendAuthHTTPRequest("GET", path, params, nil, &resp)
+	return resp.Data, resp.Pagination, err
+}
+
+// SendHTTPRequest sends an unauthenticated HTTP request
+func (c *Coinbase) SendHTTPRequest(path string, result interface{}) error {
+	return c.SendHTTPRequestContext(context.Background(), path, result)
+}
+
+// SendHTTPRequestContext sends an unauthenticated HTTP request, cancelled with
+// the context
+func (c *Coinbase) SendHTTPRequestContext(ctx context.Context, path string, result interface{}) error {
+	return c.SendPayloadContext(ctx, "GET", path, nil, nil, result, false, c.Verbose)
+}
+
+// SendAuthHTTPRequest sends an authenticated HTTP request to an API path,
+// the body is JSON encoded when supplied
+func (c *Coinbase) SendAuthHTTPRequest(method, path string, params url.Values, body, result interface{}) error {
+	return c.SendAuthHTTPRequestContext(context.Background(), method, path, params, body, result)
+}
+
+// SendAuthHTTPRequestContext sends an authenticated HTTP request to an API
+// path, cancelled with the context
+func (c *Coinbase) SendAuthHTTPRequestContext(ctx context.Context, method, path string, params url.Values, body, result interface{}) error {
+	if !c.AuthenticatedAPISupport {
+		return fmt.Errorf(exchange.WarningAuthenticatedRequestWithoutCredentialsSet, c.Name)
+	}
+
+	target, err := url.Parse(c.APIUrl + path)
+	if err != nil {
+		return err
+	}
+
+	token, err := c.newJWT(method + " " + target.Host + target.Path)
+	if err != nil {
+		return err
+	}
+
+	var payload []byte
+	if body != nil {
+		payload, err = common.JSONEncode(body)
+		if err != nil {
+			return err
+		}
+	}
+
+	headers := make(map[string]string)
+	headers["Authorization"] = "Bearer " + token
+	headers["Content-Type"] = "application/json"
+
+	if c.Verbose {
+		log.Printf("%s sending authenticated request to %s", c.Name, path)
+	}
+	return c.SendPayloadContext(ctx, method, common.EncodeURLValues(c.APIUrl+path, params), headers, bytes.NewReader(payload), result, true, c.Verbose)
+}
+
+// newJWT returns an ES256 signed JWT for the API key. The URI binds the
+// token to a single request as "METHOD host/path" and is empty for websocket
+// subscriptions.
+func (c *Coinbase) newJWT(uri string) (string, error) {
+	key, err := c.signingKey()
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, 16)
+	_, err = rand.Read(nonce)
+	if err != nil {
+		return "", err
+	}
+
+	header, err := common.JSONEncode(jwtHeader{
+		Algorithm: "ES256",
+		KeyID:     c.APIKey,
+		Nonce:     common.HexEncodeToString(nonce),
+		Type:      "JWT",
+	})
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	claims, err := common.JSONEncode(jwtClaims{
+		Subject:   c.APIKey,
+		Issuer:    coinbaseJWTIssuer,
+		NotBefore: now.Unix(),
+		Expires:   now.Add(coinbaseJWTExpiry).Unix(),
+		URI:       uri,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." +
+		base64.RawURLEncoding.EncodeToString(claims)
+	hash := sha256.Sum256([]byte(unsigned))
+	r, s, err := ecdsa.Sign(rand.Reader, key, hash[:])
+	if err != nil {
+		return "", err
+	}
+
+	// ES256 signatures are the fixed width concatenation of r and s
+	signature := make([]byte, 64)
+	r.FillBytes(signature[:32])
+	s.FillBytes(signature[32:])
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// signingKey returns the parsed API secret, it is cached until the secret
+// changes
+func (c *Coinbase) signingKey() (*ecdsa.PrivateKey, error) {
+	c.keyLock.Lock()
+	defer c.keyLock.Unlock()
+
+	if c.key != nil && c.keySecret == c.APISecret {
+		return c.key, nil
+	}
+
+	key, err := parsePrivateKey(c.APISecret)
+	if err != nil {
+		return nil, err
+	}
+	c.key = key
+	c.keySecret = c.APISecret
+	return key, nil
+}
+
+// parsePrivateKey parses a SEC 1 or PKCS #8 PEM encoded EC private key.
+// Escaped newlines, as stored in JSON config files, are accepted.
+func parsePrivateKey(secret string) (*ecdsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(strings.Replace(secret, `\n`, "\n", -1)))
+	if block == nil {
+		return nil, ErrInvalidKey
+	}
+
+	key, err := x509.ParseECPrivateKey(block.Bytes)
+	if err == nil {
+		return key, nil
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, ErrInvalidKey
+	}
+	ecKey, ok := parsed.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, ErrInvalidKey
+	}
+	return ecKey, nil
+}
+
+// GetFee returns an estimate of fee based on type of transaction
+func (c *Coinbase) GetFee(feeBuilder exchange.FeeBuilder) (float64, error) {
+	var fee float64
+	switch feeBuilder.FeeType {
+	case exchange.CryptocurrencyTradeFee:
+		rate := coinbaseDefaultTakerFee
+		if feeBuilder.IsMaker {
+			rate = coinbaseDefaultMakerFee
+		}
+		if c.AuthenticatedAPISupport {
+			summary, err := c.GetTransactionSummary()
+			if err != nil {
+				return 0, err
+			}
+			// The summary reports the pricing tier, apply its rate limits
+			// when it has changed
+			if summary.FeeTier.PricingTier != "" {
+				c.SetAccountTier(summary.FeeTier.PricingTier)
+			}
+			rate = summary.FeeTier.TakerFeeRate.Float64()
+			if feeBuilder.IsMaker {
+				rate = summary.FeeTier.MakerFeeRate.Float64()
+			}
+		}
+		fee = rate * feeBuilder.PurchasePrice * feeBuilder.Amount
+	}
+
+	if fee < 0 {
+		fee = 0
+	}
+	return fee, nil
+}
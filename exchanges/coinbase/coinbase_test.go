@@ -0,0 +1,360 @@
+package coinbase
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/assets"
+	"github.com/thrasher-/gocryptotrader/exchanges/conformance"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-/gocryptotrader/exchanges/trade"
+)
+
+var c Coinbase
+
+// Please supply you own test keys here for due diligence testing.
+const (
+	apiKey                  = ""
+	apiSecret               = ""
+	canManipulateRealOrders = false
+)
+
+func TestSetDefaults(t *testing.T) {
+	c.SetDefaults()
+	if c.GetName() != "Coinbase" {
+		t.Error("Test Failed - Coinbase - SetDefaults() error")
+	}
+}
+
+func TestSetup(t *testing.T) {
+	cfg := config.GetConfig()
+	cfg.LoadConfig("../../testdata/configtest.json")
+	coinbaseConfig, err := cfg.GetExchangeConfig("Coinbase")
+	if err != nil {
+		t.Error("Test Failed - Coinbase Setup() init error")
+	}
+
+	coinbaseConfig.AuthenticatedAPISupport = true
+	coinbaseConfig.APIKey = apiKey
+	coinbaseConfig.APISecret = apiSecret
+
+	c.Setup(coinbaseConfig)
+}
+
+func TestConformance(t *testing.T) {
+	cfg := config.GetConfig()
+	cfg.LoadConfig("../../testdata/configtest.json")
+	coinbaseConfig, err := cfg.GetExchangeConfig("Coinbase")
+	if err != nil {
+		t.Fatal("Test Failed - Coinbase conformance init error", err)
+	}
+
+	conformance.Run(t, func() exchange.IBotExchange { return new(Coinbase) }, coinbaseConfig)
+}
+
+func TestGetProducts(t *testing.T) {
+	t.Parallel()
+	_, err := c.GetProducts()
+	if err != nil {
+		t.Error("Test Failed - Coinbase GetProducts() error", err)
+	}
+}
+
+func TestGetProductBook(t *testing.T) {
+	t.Parallel()
+	_, err := c.GetProductBook("BTC-USD", 10)
+	if err != nil {
+		t.Error("Test Failed - Coinbase GetProductBook() error", err)
+	}
+}
+
+func TestGetMarketTrades(t *testing.T) {
+	t.Parallel()
+	_, err := c.GetMarketTrades("BTC-USD", 10)
+	if err != nil {
+		t.Error("Test Failed - Coinbase GetMarketTrades() error", err)
+	}
+}
+
+func TestGetHistoricCandles(t *testing.T) {
+	var cb Coinbase
+	cb.SetDefaults()
+	p := pair.NewCurrencyPairDelimiter("BTC-USD", "-")
+	end := time.Now()
+	_, err := cb.GetHistoricCandles(p, assets.Spot, kline.FourHour, end.Add(-time.Hour*24), end)
+	if err != kline.ErrUnsupportedInterval {
+		t.Error("Test Failed - GetHistoricCandles() expected unsupported interval error", err)
+	}
+	_, err = cb.GetHistoricCandles(p, assets.Spot, kline.OneHour, end, end)
+	if err != kline.ErrInvalidTimeRange {
+		t.Error("Test Failed - GetHistoricCandles() expected invalid time range error", err)
+	}
+}
+
+// testKey returns a new P-256 private key and its SEC 1 PEM encoding
+func testKey(t *testing.T) (*ecdsa.PrivateKey, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key, string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}))
+}
+
+func TestParsePrivateKey(t *testing.T) {
+	key, encoded := testKey(t)
+
+	// Keys copied into JSON config files have escaped newlines
+	parsed, err := parsePrivateKey(strings.Replace(encoded, "\n", `\n`, -1))
+	if err != nil {
+		t.Fatal("Test Failed - parsePrivateKey() error", err)
+	}
+	if parsed.D.Cmp(key.D) != 0 {
+		t.Error("Test Failed - parsePrivateKey() returned a different key")
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = parsePrivateKey(string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})))
+	if err != nil {
+		t.Error("Test Failed - parsePrivateKey() PKCS #8 error", err)
+	}
+
+	if _, err = parsePrivateKey("Secret"); err != ErrInvalidKey {
+		t.Error("Test Failed - parsePrivateKey() expected invalid key error", err)
+	}
+}
+
+func TestNewJWT(t *testing.T) {
+	var cb Coinbase
+	cb.SetDefaults()
+	key, encoded := testKey(t)
+	cb.APIKey = "organizations/org/apiKeys/key"
+	cb.APISecret = encoded
+
+	token, err := cb.newJWT("GET api.coinbase.com/api/v3/brokerage/accounts")
+	if err != nil {
+		t.Fatal("Test Failed - newJWT() error", err)
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		t.Fatal("Test Failed - newJWT() unexpected token", token)
+	}
+
+	var header jwtHeader
+	var claims jwtClaims
+	for i, v := range []interface{}{&header, &claims} {
+		data, err := base64.RawURLEncoding.DecodeString(parts[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = common.JSONDecode(data, v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if header.Algorithm != "ES256" || header.KeyID != cb.APIKey || header.Nonce == "" {
+		t.Errorf("Test Failed - newJWT() unexpected header %+v", header)
+	}
+	if claims.Subject != cb.APIKey || claims.Issuer != "cdp" ||
+		claims.URI != "GET api.coinbase.com/api/v3/brokerage/accounts" ||
+		claims.Expires-claims.NotBefore != 120 {
+		t.Errorf("Test Failed - newJWT() unexpected claims %+v", claims)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || len(signature) != 64 {
+		t.Fatal("Test Failed - newJWT() unexpected signature", err)
+	}
+	hash := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	r := new(big.Int).SetBytes(signature[:32])
+	s := new(big.Int).SetBytes(signature[32:])
+	if !ecdsa.Verify(&key.PublicKey, hash[:], r, s) {
+		t.Error("Test Failed - newJWT() signature does not verify")
+	}
+
+	// Websocket JWTs are not bound to a request
+	token, err = cb.newJWT("")
+	if err != nil {
+		t.Fatal("Test Failed - newJWT() websocket error", err)
+	}
+	data, _ := base64.RawURLEncoding.DecodeString(strings.Split(token, ".")[1])
+	if strings.Contains(string(data), "uri") {
+		t.Error("Test Failed - newJWT() websocket JWT has a uri claim", string(data))
+	}
+}
+
+func TestBuildOrder(t *testing.T) {
+	var cb Coinbase
+	cb.SetDefaults()
+	p := pair.NewCurrencyPairDelimiter("BTC-USD", "-")
+
+	req, err := cb.buildOrder(p, exchange.Buy, exchange.Market, 100, 0, "abc")
+	if err != nil {
+		t.Fatal("Test Failed - buildOrder() error", err)
+	}
+	if req.ProductID != "BTC-USD" || req.Side != "BUY" || req.ClientOrderID != "abc" ||
+		req.OrderConfiguration.MarketIOC == nil || req.OrderConfiguration.MarketIOC.QuoteSize != "100" {
+		t.Errorf("Test Failed - buildOrder() unexpected market buy %+v", req)
+	}
+
+	req, err = cb.buildOrder(p, exchange.Sell, exchange.Limit, 0.5, 6500.25, "")
+	if err != nil {
+		t.Fatal("Test Failed - buildOrder() error", err)
+	}
+	if req.ClientOrderID == "" || req.OrderConfiguration.LimitGTC == nil ||
+		req.OrderConfiguration.LimitGTC.BaseSize != "0.5" ||
+		req.OrderConfiguration.LimitGTC.LimitPrice != "6500.25" {
+		t.Errorf("Test Failed - buildOrder() unexpected limit sell %+v", req)
+	}
+
+	if _, err = cb.buildOrder(p, exchange.Buy, exchange.OrderType("STOP"), 1, 1, ""); err == nil {
+		t.Error("Test Failed - buildOrder() expected unsupported order type error")
+	}
+}
+
+func TestSubmitOrder(t *testing.T) {
+	if apiKey == "" || apiSecret == "" || !canManipulateRealOrders {
+		t.Skip()
+	}
+
+	p := pair.NewCurrencyPairDelimiter("BTC-USD", "-")
+	_, err := c.SubmitOrder(p, exchange.Buy, exchange.Limit, 0.001, 1, "")
+	if err != nil {
+		t.Error("Test Failed - Coinbase SubmitOrder() error", err)
+	}
+}
+
+func TestOrderDetail(t *testing.T) {
+	var cb Coinbase
+	cb.SetDefaults()
+
+	var o Order
+	err := common.JSONDecode([]byte(`{"order_id":"0000-000000-000000","product_id":"BTC-USD","order_configuration":{"limit_limit_gtc":{"base_size":"2","limit_price":"6500","post_only":false}},"side":"SELL","client_order_id":"11111","status":"OPEN","created_time":"2018-10-20T07:49:37Z","filled_size":"0.5","average_filled_price":"6500.5","order_type":"LIMIT"}`), &o)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	detail := cb.orderDetail(&o)
+	if detail.ID != "0000-000000-000000" || detail.BaseCurrency != "BTC" || detail.QuoteCurrency != "USD" ||
+		detail.OrderSide != string(exchange.Sell) || detail.OrderType != string(exchange.Limit) ||
+		detail.Price != 6500 || detail.Amount != 2 || detail.OpenVolume != 1.5 ||
+		detail.ExecutedAmount != 0.5 || detail.AverageExecutedPrice != 6500.5 ||
+		!detail.CreationTime.Equal(time.Date(2018, 10, 20, 7, 49, 37, 0, time.UTC)) {
+		t.Errorf("Test Failed - orderDetail() unexpected detail %+v", detail)
+	}
+
+	o.Status = coinbaseOrderCancelled
+	o.OrderConfiguration = OrderConfiguration{MarketIOC: &MarketIOC{QuoteSize: "100"}}
+	detail = cb.orderDetail(&o)
+	if detail.OrderType != string(exchange.Market) || detail.Amount != 0.5 || detail.OpenVolume != 0 {
+		t.Errorf("Test Failed - orderDetail() unexpected market detail %+v", detail)
+	}
+}
+
+func TestWsHandleMessage(t *testing.T) {
+	var cb Coinbase
+	cb.SetDefaults()
+	cb.Websocket = &exchange.Websocket{DataHandler: make(chan interface{}, 10)}
+
+	for _, msg := range []string{
+		`{"channel":"subscriptions","client_id":"","timestamp":"2018-10-20T07:49:37.708706Z","sequence_num":0,"events":[{"subscriptions":{"ticker":["BTC-USD"]}}]}`,
+		`{"channel":"heartbeats","client_id":"","timestamp":"2018-10-20T07:49:37.708706Z","sequence_num":1,"events":[{"current_time":"2018-10-20 07:49:37","heartbeat_counter":"3049"}]}`,
+	} {
+		if err := cb.wsHandleMessage([]byte(msg)); err != nil {
+			t.Error("Test Failed - wsHandleMessage() error", err)
+		}
+	}
+	if err := cb.wsHandleMessage([]byte(`{"type":"error","message":"failure to subscribe"}`)); err == nil {
+		t.Error("Test Failed - wsHandleMessage() expected subscription error")
+	}
+
+	err := cb.wsHandleMessage([]byte(`{"channel":"ticker","client_id":"","timestamp":"2018-10-20T07:49:37.708706Z","sequence_num":2,"events":[{"type":"snapshot","tickers":[{"type":"ticker","product_id":"BTC-USD","price":"6500","volume_24_h":"1200.5","low_24_h":"6400","high_24_h":"6600","price_percent_chg_24_h":"1.5625","best_bid":"6499.9","best_ask":"6500.1"}]}]}`))
+	if err != nil {
+		t.Fatal("Test Failed - wsHandleMessage() ticker error", err)
+	}
+	tick, ok := (<-cb.Websocket.DataHandler).(exchange.TickerData)
+	if !ok || tick.Pair.Pair().String() != "BTC-USD" || tick.ClosePrice != 6500 || tick.OpenPrice != 6400 {
+		t.Errorf("Test Failed - wsHandleMessage() unexpected ticker %+v", tick)
+	}
+
+	err = cb.wsHandleMessage([]byte(`{"channel":"market_trades","client_id":"","timestamp":"2018-10-20T07:49:37.708706Z","sequence_num":3,"events":[{"type":"update","trades":[{"trade_id":"4665906","product_id":"ETH-USD","price":"200.5","size":"3","side":"SELL","time":"2018-10-20T07:49:37.708706Z"}]}]}`))
+	if err != nil {
+		t.Fatal("Test Failed - wsHandleMessage() trade error", err)
+	}
+	td, ok := (<-cb.Websocket.DataHandler).(exchange.TradeData)
+	if !ok || td.CurrencyPair.FirstCurrency != "ETH" || td.Side != trade.Sell || td.Amount != 3 {
+		t.Errorf("Test Failed - wsHandleMessage() unexpected trade %+v", td)
+	}
+	if last, err := trade.LastTrade(cb.Name, td.CurrencyPair, assets.Spot); err != nil || last.TradeID != "4665906" {
+		t.Error("Test Failed - wsHandleMessage() expected the trade stored", last, err)
+	}
+
+	err = cb.wsHandleMessage([]byte(`{"channel":"l2_data","client_id":"","timestamp":"2018-10-20T07:49:37.708706Z","sequence_num":4,"events":[{"type":"snapshot","product_id":"BTC-USD","updates":[{"side":"bid","event_time":"2018-10-20T07:49:37Z","price_level":"6499.9","new_quantity":"1.5"},{"side":"bid","event_time":"2018-10-20T07:49:37Z","price_level":"6499","new_quantity":"3"},{"side":"offer","event_time":"2018-10-20T07:49:37Z","price_level":"6500.1","new_quantity":"2"}]}]}`))
+	if err != nil {
+		t.Fatal("Test Failed - wsHandleMessage() book snapshot error", err)
+	}
+	<-cb.Websocket.DataHandler
+	err = cb.wsHandleMessage([]byte(`{"channel":"l2_data","client_id":"","timestamp":"2018-10-20T07:49:38.708706Z","sequence_num":5,"events":[{"type":"update","product_id":"BTC-USD","updates":[{"side":"offer","event_time":"2018-10-20T07:49:38Z","price_level":"6500.1","new_quantity":"0.5"}]}]}`))
+	if err != nil {
+		t.Fatal("Test Failed - wsHandleMessage() book update error", err)
+	}
+	<-cb.Websocket.DataHandler
+	ob, err := orderbook.GetOrderbook(cb.Name, tick.Pair, assets.Spot)
+	if err != nil || len(ob.Bids) != 2 || len(ob.Asks) != 1 || ob.Asks[0].Amount != 0.5 {
+		t.Errorf("Test Failed - wsHandleMessage() unexpected orderbook %+v %v", ob, err)
+	}
+
+	err = cb.wsHandleMessage([]byte(`{"channel":"user","client_id":"","timestamp":"2018-10-20T07:49:39.708706Z","sequence_num":6,"events":[{"type":"update","orders":[{"order_id":"0000-000000-000000","client_order_id":"11111","cumulative_quantity":"0.5","leaves_quantity":"1.5","avg_price":"6499","total_fees":"0.01","status":"OPEN","product_id":"BTC-USD","creation_time":"2018-10-20T07:49:37Z","order_side":"BUY","order_type":"Limit"}]}]}`))
+	if err != nil {
+		t.Fatal("Test Failed - wsHandleMessage() user error", err)
+	}
+	order, ok := (<-cb.Websocket.DataHandler).(WsOrder)
+	if !ok || order.OrderID != "0000-000000-000000" || order.CumulativeQuantity != 0.5 || order.Status != "OPEN" {
+		t.Errorf("Test Failed - wsHandleMessage() unexpected order %+v", order)
+	}
+}
+
+func TestDetectAccountTier(t *testing.T) {
+	var _ exchange.AccountTierDetector = &c
+	_, err := c.DetectAccountTier()
+	if apiKey != "" || apiSecret != "" {
+		if err != nil {
+			t.Error("Test Failed - DetectAccountTier() error", err)
+		}
+	} else if err == nil {
+		t.Error("Test Failed - DetectAccountTier() expected error without credentials")
+	}
+}
+
+func TestUpdateTickerContext(t *testing.T) {
+	var _ exchange.ContextExchange = &c
+	var _ exchange.OrderContextExchange = &c
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := exchange.UpdateTickerContext(ctx, &c, pair.NewCurrencyPair("BTC", "USD"), ticker.Spot)
+	if err != context.Canceled {
+		t.Error("Test Failed - UpdateTickerContext() expected cancelled request", err)
+	}
+}
@@ -0,0 +1,387 @@
+package coinbase
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Number is a Coinbase string encoded number, empty strings decode to zero
+type Number float64
+
+// UnmarshalJSON implements the json.Unmarshaler interface
+func (n *Number) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "" || s == "null" {
+		*n = 0
+		return nil
+	}
+
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return err
+	}
+	*n = Number(f)
+	return nil
+}
+
+// Float64 returns the number as a float64
+func (n Number) Float64() float64 {
+	return float64(n)
+}
+
+// Product holds the trading details and 24 hour statistics of a product
+type Product struct {
+	ProductID                 string `json:"product_id"`
+	Price                     Number `json:"price"`
+	PricePercentageChange24h  Number `json:"price_percentage_change_24h"`
+	Volume24h                 Number `json:"volume_24h"`
+	BaseIncrement             Number `json:"base_increment"`
+	QuoteIncrement            Number `json:"quote_increment"`
+	QuoteMinSize              Number `json:"quote_min_size"`
+	QuoteMaxSize              Number `json:"quote_max_size"`
+	BaseMinSize               Number `json:"base_min_size"`
+	BaseMaxSize               Number `json:"base_max_size"`
+	BaseCurrencyID            string `json:"base_currency_id"`
+	QuoteCurrencyID           string `json:"quote_currency_id"`
+	Status                    string `json:"status"`
+	TradingDisabled           bool   `json:"trading_disabled"`
+	CancelOnly                bool   `json:"cancel_only"`
+	LimitOnly                 bool   `json:"limit_only"`
+	PostOnly                  bool   `json:"post_only"`
+	ProductType               string `json:"product_type"`
+	AuctionMode               bool   `json:"auction_mode"`
+	PriceIncrement            Number `json:"price_increment"`
+	DisplayName               string `json:"display_name"`
+	ProductVenue              string `json:"product_venue"`
+	ApproximateQuote24hVolume Number `json:"approximate_quote_24h_volume"`
+}
+
+// Products holds the products response
+type Products struct {
+	Products    []Product `json:"products"`
+	NumProducts int64     `json:"num_products"`
+}
+
+// MarketTrade is a public trade
+type MarketTrade struct {
+	TradeID   string    `json:"trade_id"`
+	ProductID string    `json:"product_id"`
+	Price     Number    `json:"price"`
+	Size      Number    `json:"size"`
+	Time      time.Time `json:"time"`
+	Side      string    `json:"side"`
+}
+
+// MarketTrades holds recent trades and the best bid and ask of a product
+type MarketTrades struct {
+	Trades  []MarketTrade `json:"trades"`
+	BestBid Number        `json:"best_bid"`
+	BestAsk Number        `json:"best_ask"`
+}
+
+// BookLevel is a price level of a product book
+type BookLevel struct {
+	Price Number `json:"price"`
+	Size  Number `json:"size"`
+}
+
+// ProductBook is the orderbook of a product
+type ProductBook struct {
+	ProductID string      `json:"product_id"`
+	Bids      []BookLevel `json:"bids"`
+	Asks      []BookLevel `json:"asks"`
+	Time      time.Time   `json:"time"`
+}
+
+// Candle is a product candle, Start is the open time in unix seconds
+type Candle struct {
+	Start  Number `json:"start"`
+	Low    Number `json:"low"`
+	High   Number `json:"high"`
+	Open   Number `json:"open"`
+	Close  Number `json:"close"`
+	Volume Number `json:"volume"`
+}
+
+// Balance is an account balance amount
+type Balance struct {
+	Value    Number `json:"value"`
+	Currency string `json:"currency"`
+}
+
+// Account is a currency account
+type Account struct {
+	UUID             string  `json:"uuid"`
+	Name             string  `json:"name"`
+	Currency         string  `json:"currency"`
+	AvailableBalance Balance `json:"available_balance"`
+	Default          bool    `json:"default"`
+	Active           bool    `json:"active"`
+	Type             string  `json:"type"`
+	Ready            bool    `json:"ready"`
+	Hold             Balance `json:"hold"`
+}
+
+// Accounts holds a page of accounts
+type Accounts struct {
+	Accounts []Account `json:"accounts"`
+	HasNext  bool      `json:"has_next"`
+	Cursor   string    `json:"cursor"`
+	Size     int64     `json:"size"`
+}
+
+// MarketIOC is a market order configuration, buys are sized in the quote
+// currency and sells in the base currency
+type MarketIOC struct {
+	QuoteSize string `json:"quote_size,omitempty"`
+	BaseSize  string `json:"base_size,omitempty"`
+}
+
+// LimitGTC is a good till cancelled limit order configuration
+type LimitGTC struct {
+	BaseSize   string `json:"base_size"`
+	LimitPrice string `json:"limit_price"`
+	PostOnly   bool   `json:"post_only"`
+}
+
+// LimitIOC is an immediate or cancel limit order configuration
+type LimitIOC struct {
+	BaseSize   string `json:"base_size"`
+	LimitPrice string `json:"limit_price"`
+}
+
+// OrderConfiguration holds the configuration of an order, exactly one field
+// is set
+type OrderConfiguration struct {
+	MarketIOC *MarketIOC `json:"market_market_ioc,omitempty"`
+	LimitGTC  *LimitGTC  `json:"limit_limit_gtc,omitempty"`
+	LimitIOC  *LimitIOC  `json:"sor_limit_ioc,omitempty"`
+}
+
+// CreateOrderRequest holds the parameters of a new order
+type CreateOrderRequest struct {
+	ClientOrderID      string             `json:"client_order_id"`
+	ProductID          string             `json:"product_id"`
+	Side               string             `json:"side"`
+	OrderConfiguration OrderConfiguration `json:"order_configuration"`
+}
+
+// CreateOrderResponse is the response to a new order
+type CreateOrderResponse struct {
+	Success         bool `json:"success"`
+	SuccessResponse struct {
+		OrderID       string `json:"order_id"`
+		ProductID     string `json:"product_id"`
+		Side          string `json:"side"`
+		ClientOrderID string `json:"client_order_id"`
+	} `json:"success_response"`
+	ErrorResponse struct {
+		Error                string `json:"error"`
+		Message              string `json:"message"`
+		ErrorDetails         string `json:"error_details"`
+		PreviewFailureReason string `json:"preview_failure_reason"`
+	} `json:"error_response"`
+}
+
+// CancelResult is the result of cancelling a single order
+type CancelResult struct {
+	Success       bool   `json:"success"`
+	FailureReason string `json:"failure_reason"`
+	OrderID       string `json:"order_id"`
+}
+
+// Order is an order's details
+type Order struct {
+	OrderID               string             `json:"order_id"`
+	ProductID             string             `json:"product_id"`
+	UserID                string             `json:"user_id"`
+	OrderConfiguration    OrderConfiguration `json:"order_configuration"`
+	Side                  string             `json:"side"`
+	ClientOrderID         string             `json:"client_order_id"`
+	Status                string             `json:"status"`
+	TimeInForce           string             `json:"time_in_force"`
+	CreatedTime           time.Time          `json:"created_time"`
+	CompletionPercentage  Number             `json:"completion_percentage"`
+	FilledSize            Number             `json:"filled_size"`
+	AverageFilledPrice    Number             `json:"average_filled_price"`
+	Fee                   Number             `json:"fee"`
+	NumberOfFills         Number             `json:"number_of_fills"`
+	FilledValue           Number             `json:"filled_value"`
+	TotalFees             Number             `json:"total_fees"`
+	OrderType             string             `json:"order_type"`
+	OutstandingHoldAmount Number             `json:"outstanding_hold_amount"`
+}
+
+// OrdersRequest holds the filters of an order list request, all are
+// optional
+type OrdersRequest struct {
+	ProductIDs []string
+	Status     []string
+	Side       string
+	Start      time.Time
+	End        time.Time
+	Cursor     string
+}
+
+// Orders holds a page of orders
+type Orders struct {
+	Orders  []Order `json:"orders"`
+	HasNext bool    `json:"has_next"`
+	Cursor  string  `json:"cursor"`
+}
+
+// TransactionSummary holds the account's fee tier and trading volume
+type TransactionSummary struct {
+	TotalVolume Number `json:"total_volume"`
+	TotalFees   Number `json:"total_fees"`
+	FeeTier     struct {
+		PricingTier  string `json:"pricing_tier"`
+		TakerFeeRate Number `json:"taker_fee_rate"`
+		MakerFeeRate Number `json:"maker_fee_rate"`
+	} `json:"fee_tier"`
+}
+
+// Amount is a v2 API currency amount
+type Amount struct {
+	Amount   Number `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// Address is a v2 API deposit address
+type Address struct {
+	ID      string `json:"id"`
+	Address string `json:"address"`
+	Network string `json:"network"`
+}
+
+// SendRequest holds the parameters of a v2 API crypto withdrawal
+type SendRequest struct {
+	Type     string `json:"type"`
+	To       string `json:"to"`
+	Amount   string `json:"amount"`
+	Currency string `json:"currency"`
+	Idem     string `json:"idem,omitempty"`
+}
+
+// Transaction is a v2 API account transaction
+type Transaction struct {
+	ID        string    `json:"id"`
+	Type      string    `json:"type"`
+	Status    string    `json:"status"`
+	Amount    Amount    `json:"amount"`
+	CreatedAt time.Time `json:"created_at"`
+	Network   struct {
+		Status         string `json:"status"`
+		Hash           string `json:"hash"`
+		TransactionFee Amount `json:"transaction_fee"`
+	} `json:"network"`
+	To struct {
+		Address string `json:"address"`
+	} `json:"to"`
+}
+
+// Pagination is the v2 API pagination details
+type Pagination struct {
+	NextStartingAfter string `json:"next_starting_after"`
+}
+
+// jwtHeader is the header of a request JWT
+type jwtHeader struct {
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+	Nonce     string `json:"nonce"`
+	Type      string `json:"typ"`
+}
+
+// jwtClaims are the claims of a request JWT, URI is omitted for websocket
+// JWTs
+type jwtClaims struct {
+	Subject   string `json:"sub"`
+	Issuer    string `json:"iss"`
+	NotBefore int64  `json:"nbf"`
+	Expires   int64  `json:"exp"`
+	URI       string `json:"uri,omitempty"`
+}
+
+// WsSubscription is a websocket subscribe or unsubscribe request
+type WsSubscription struct {
+	Type       string   `json:"type"`
+	ProductIDs []string `json:"product_ids,omitempty"`
+	Channel    string   `json:"channel"`
+	JWT        string   `json:"jwt,omitempty"`
+}
+
+// WsResponse is the websocket message envelope
+type WsResponse struct {
+	Type        string          `json:"type"`
+	Message     string          `json:"message"`
+	Channel     string          `json:"channel"`
+	Timestamp   time.Time       `json:"timestamp"`
+	SequenceNum int64           `json:"sequence_num"`
+	Events      json.RawMessage `json:"events"`
+}
+
+// WsTicker is a websocket ticker update
+type WsTicker struct {
+	Type               string `json:"type"`
+	ProductID          string `json:"product_id"`
+	Price              Number `json:"price"`
+	Volume24h          Number `json:"volume_24_h"`
+	Low24h             Number `json:"low_24_h"`
+	High24h            Number `json:"high_24_h"`
+	PricePercentChg24h Number `json:"price_percent_chg_24_h"`
+	BestBid            Number `json:"best_bid"`
+	BestAsk            Number `json:"best_ask"`
+}
+
+// WsTickerEvent holds ticker updates
+type WsTickerEvent struct {
+	Type    string     `json:"type"`
+	Tickers []WsTicker `json:"tickers"`
+}
+
+// WsTradeEvent holds market trades
+type WsTradeEvent struct {
+	Type   string        `json:"type"`
+	Trades []MarketTrade `json:"trades"`
+}
+
+// WsBookUpdate is a level2 price level update, a zero quantity removes the
+// level
+type WsBookUpdate struct {
+	Side        string    `json:"side"`
+	EventTime   time.Time `json:"event_time"`
+	PriceLevel  Number    `json:"price_level"`
+	NewQuantity Number    `json:"new_quantity"`
+}
+
+// WsBookEvent holds a level2 snapshot or update of a product
+type WsBookEvent struct {
+	Type      string         `json:"type"`
+	ProductID string         `json:"product_id"`
+	Updates   []WsBookUpdate `json:"updates"`
+}
+
+// WsOrder is a user channel order update
+type WsOrder struct {
+	OrderID            string    `json:"order_id"`
+	ClientOrderID      string    `json:"client_order_id"`
+	CumulativeQuantity Number    `json:"cumulative_quantity"`
+	LeavesQuantity     Number    `json:"leaves_quantity"`
+	AvgPrice           Number    `json:"avg_price"`
+	TotalFees          Number    `json:"total_fees"`
+	Status             string    `json:"status"`
+	ProductID          string    `json:"product_id"`
+	CreationTime       time.Time `json:"creation_time"`
+	OrderSide          string    `json:"order_side"`
+	OrderType          string    `json:"order_type"`
+	LimitPrice         Number    `json:"limit_price"`
+}
+
+// WsUserEvent holds user channel order updates
+type WsUserEvent struct {
+	Type   string    `json:"type"`
+	Orders []WsOrder `json:"orders"`
+}
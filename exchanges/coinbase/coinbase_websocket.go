@@ -0,0 +1,390 @@
+package coinbase
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gorilla/websocket"
+	"github.com/thrasher-/gocryptotrader/common"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/assets"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/trade"
+)
+
+const (
+	coinbaseWebsocketURL = "wss://advanced-trade-ws.coinbase.com"
+
+	// Subscription channels
+	coinbaseWsTicker       = "ticker"
+	coinbaseWsMarketTrades = "market_trades"
+	coinbaseWsLevel2       = "level2"
+	coinbaseWsUser         = "user"
+	coinbaseWsHeartbeats   = "heartbeats"
+
+	// Level2 updates are pushed on a channel named differently to the
+	// subscription channel
+	coinbaseWsLevel2Data    = "l2_data"
+	coinbaseWsSubscriptions = "subscriptions"
+
+	coinbaseWsSubscribe   = "subscribe"
+	coinbaseWsUnsubscribe = "unsubscribe"
+	coinbaseWsError       = "error"
+	coinbaseWsSnapshot    = "snapshot"
+)
+
+// WsConnect initiates a websocket connection. Heartbeats are subscribed so
+// the connection is not closed while quiet, and the user channel is
+// subscribed when authenticated.
+func (c *Coinbase) WsConnect() error {
+	if !c.Websocket.IsEnabled() || !c.IsEnabled() {
+		return errors.New(exchange.WebsocketNotEnabled)
+	}
+
+	var dialer websocket.Dialer
+	dialer.TLSClientConfig = c.GetTLSConfig()
+	dialer.NetDial = c.GetWebsocketNetDial()
+	if c.Websocket.GetProxyAddress() != "" {
+		proxy, err := url.Parse(c.Websocket.GetProxyAddress())
+		if err != nil {
+			return err
+		}
+		dialer.Proxy = http.ProxyURL(proxy)
+	}
+
+	var err error
+	c.WebsocketConn, _, err = dialer.Dial(c.Websocket.GetWebsocketURL(), http.Header{})
+	if err != nil {
+		return fmt.Errorf("%s unable to connect to websocket. Error: %s",
+			c.Name,
+			err)
+	}
+
+	go c.WsReadData()
+	go c.WsHandleData()
+
+	err = c.wsSend(coinbaseWsSubscribe, coinbaseWsHeartbeats, nil)
+	if err != nil {
+		return fmt.Errorf("%s could not subscribe to websocket heartbeats. Error: %s",
+			c.Name,
+			err)
+	}
+
+	if c.AuthenticatedAPISupport {
+		err = c.wsSend(coinbaseWsSubscribe, coinbaseWsUser, nil)
+		if err != nil {
+			return fmt.Errorf("%s could not subscribe to websocket user channel. Error: %s",
+				c.Name,
+				err)
+		}
+	}
+	return nil
+}
+
+// wsWrite sends a JSON message over the websocket connection
+func (c *Coinbase) wsWrite(data interface{}) error {
+	if c.WebsocketConn == nil {
+		return errors.New("websocket connection not established")
+	}
+
+	payload, err := common.JSONEncode(data)
+	if err != nil {
+		return err
+	}
+
+	c.wsWriteLock.Lock()
+	defer c.wsWriteLock.Unlock()
+	return c.WebsocketConn.WriteMessage(websocket.TextMessage, payload)
+}
+
+// wsSend sends a subscribe or unsubscribe request for a channel. A JWT is
+// attached when authenticated, it is required by the user channel and lifts
+// the public channel connection limits.
+func (c *Coinbase) wsSend(requestType, channel string, productIDs []string) error {
+	req := WsSubscription{
+		Type:       requestType,
+		ProductIDs: productIDs,
+		Channel:    channel,
+	}
+	if c.AuthenticatedAPISupport {
+		token, err := c.newJWT("")
+		if err != nil {
+			return err
+		}
+		req.JWT = token
+	}
+	return c.wsWrite(req)
+}
+
+// wsDefaultSubscriptions returns the ticker, market trades and level2
+// channels of all enabled pairs
+func (c *Coinbase) wsDefaultSubscriptions() []exchange.ChannelSubscription {
+	var subs []exchange.ChannelSubscription
+	for _, p := range c.GetEnabledCurrencies() {
+		for _, channel := range []string{coinbaseWsTicker, coinbaseWsMarketTrades, coinbaseWsLevel2} {
+			subs = append(subs, exchange.ChannelSubscription{
+				Channel:   channel,
+				Currency:  p,
+				AssetType: assets.Spot,
+			})
+		}
+	}
+	return subs
+}
+
+// wsProductsByChannel groups the product IDs of subscriptions by channel,
+// Coinbase accepts a single channel per request
+func (c *Coinbase) wsProductsByChannel(subs []exchange.ChannelSubscription) ([]string, map[string][]string) {
+	var channels []string
+	products := make(map[string][]string)
+	for i := range subs {
+		if _, ok := products[subs[i].Channel]; !ok {
+			channels = append(channels, subs[i].Channel)
+		}
+		products[subs[i].Channel] = append(products[subs[i].Channel], c.productID(subs[i].Currency))
+	}
+	return channels, products
+}
+
+// WsSubscribe subscribes to channels of the supplied products
+func (c *Coinbase) WsSubscribe(subs []exchange.ChannelSubscription) error {
+	channels, products := c.wsProductsByChannel(subs)
+	for _, channel := range channels {
+		err := c.wsSend(coinbaseWsSubscribe, channel, products[channel])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WsUnsubscribe unsubscribes from channels of the supplied products
+func (c *Coinbase) WsUnsubscribe(subs []exchange.ChannelSubscription) error {
+	channels, products := c.wsProductsByChannel(subs)
+	for _, channel := range channels {
+		err := c.wsSend(coinbaseWsUnsubscribe, channel, products[channel])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WsReadData reads data from the websocket connection
+func (c *Coinbase) WsReadData() {
+	c.Websocket.Wg.Add(1)
+
+	defer func() {
+		err := c.WebsocketConn.Close()
+		if err != nil {
+			c.Websocket.DataHandler <- fmt.Errorf("coinbase_websocket.go - Unable to to close Websocket connection. Error: %s",
+				err)
+		}
+		c.Websocket.Wg.Done()
+	}()
+
+	for {
+		select {
+		case <-c.Websocket.ShutdownC:
+			return
+
+		default:
+			_, resp, err := c.WebsocketConn.ReadMessage()
+			if err != nil {
+				c.Websocket.DataHandler <- err
+				return
+			}
+
+			c.Websocket.TrafficAlert <- struct{}{}
+			c.Websocket.Intercomm <- exchange.WebsocketResponse{Raw: resp}
+		}
+	}
+}
+
+// WsHandleData handles the read data from the websocket connection
+func (c *Coinbase) WsHandleData() {
+	c.Websocket.Wg.Add(1)
+	defer c.Websocket.Wg.Done()
+
+	for {
+		select {
+		case <-c.Websocket.ShutdownC:
+			return
+
+		case resp := <-c.Websocket.Intercomm:
+			err := c.wsHandleMessage(resp.Raw)
+			if err != nil {
+				c.Websocket.DataHandler <- fmt.Sprintf("%s websocket handling error: %s",
+					c.Name,
+					err)
+			}
+		}
+	}
+}
+
+// wsHandleMessage routes a single websocket message
+func (c *Coinbase) wsHandleMessage(raw []byte) error {
+	var resp WsResponse
+	err := common.JSONDecode(raw, &resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Type == coinbaseWsError {
+		return errors.New(resp.Message)
+	}
+
+	switch resp.Channel {
+	case coinbaseWsHeartbeats, coinbaseWsSubscriptions:
+		return nil
+	case coinbaseWsTicker:
+		return c.wsProcessTickers(&resp)
+	case coinbaseWsMarketTrades:
+		return c.wsProcessTrades(&resp)
+	case coinbaseWsLevel2Data:
+		return c.wsProcessBooks(&resp)
+	case coinbaseWsUser:
+		var events []WsUserEvent
+		err = common.JSONDecode(resp.Events, &events)
+		if err != nil {
+			return err
+		}
+		for i := range events {
+			for j := range events[i].Orders {
+				c.Websocket.DataHandler <- events[i].Orders[j]
+			}
+		}
+	}
+	return nil
+}
+
+// wsProcessTickers sends ticker updates to the data handler
+func (c *Coinbase) wsProcessTickers(resp *WsResponse) error {
+	var events []WsTickerEvent
+	err := common.JSONDecode(resp.Events, &events)
+	if err != nil {
+		return err
+	}
+
+	for i := range events {
+		for _, t := range events[i].Tickers {
+			openPrice := t.Price.Float64()
+			if change := t.PricePercentChg24h.Float64(); change > -100 {
+				openPrice /= 1 + change/100
+			}
+			c.Websocket.DataHandler <- exchange.TickerData{
+				Timestamp:  resp.Timestamp,
+				Pair:       productToPair(t.ProductID),
+				AssetType:  assets.Spot,
+				Exchange:   c.Name,
+				ClosePrice: t.Price.Float64(),
+				Quantity:   t.Volume24h.Float64(),
+				OpenPrice:  openPrice,
+				HighPrice:  t.High24h.Float64(),
+				LowPrice:   t.Low24h.Float64(),
+			}
+		}
+	}
+	return nil
+}
+
+// wsProcessTrades normalises market trades into the trade store and sends
+// each to the data handler
+func (c *Coinbase) wsProcessTrades(resp *WsResponse) error {
+	var events []WsTradeEvent
+	err := common.JSONDecode(resp.Events, &events)
+	if err != nil {
+		return err
+	}
+
+	byProduct := make(map[string][]trade.Trade)
+	var productIDs []string
+	for i := range events {
+		for _, t := range events[i].Trades {
+			if _, ok := byProduct[t.ProductID]; !ok {
+				productIDs = append(productIDs, t.ProductID)
+			}
+			byProduct[t.ProductID] = append(byProduct[t.ProductID], trade.Trade{
+				TradeID:   t.TradeID,
+				Price:     t.Price.Float64(),
+				Amount:    t.Size.Float64(),
+				Side:      strings.ToLower(t.Side),
+				Timestamp: t.Time,
+			})
+		}
+	}
+
+	for _, id := range productIDs {
+		p := productToPair(id)
+		normalised := byProduct[id]
+		err = trade.ProcessTrades(c.Name, p, assets.Spot, normalised)
+		for i := range normalised {
+			if normalised[i].Price <= 0 || normalised[i].Amount <= 0 {
+				continue
+			}
+			c.Websocket.DataHandler <- exchange.TradeData{
+				Timestamp:    normalised[i].Timestamp,
+				CurrencyPair: p,
+				AssetType:    assets.Spot,
+				Exchange:     c.Name,
+				Price:        normalised[i].Price,
+				Amount:       normalised[i].Amount,
+				Side:         trade.Side(normalised[i].Side),
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("%s trades: %s", id, err)
+		}
+	}
+	return nil
+}
+
+// wsProcessBooks loads level2 snapshots and applies updates to the local
+// orderbook cache
+func (c *Coinbase) wsProcessBooks(resp *WsResponse) error {
+	var events []WsBookEvent
+	err := common.JSONDecode(resp.Events, &events)
+	if err != nil {
+		return err
+	}
+
+	for i := range events {
+		p := productToPair(events[i].ProductID)
+		var bids, asks []orderbook.Item
+		for _, u := range events[i].Updates {
+			item := orderbook.Item{Price: u.PriceLevel.Float64(), Amount: u.NewQuantity.Float64()}
+			if u.Side == "bid" {
+				bids = append(bids, item)
+			} else {
+				asks = append(asks, item)
+			}
+		}
+
+		if events[i].Type == coinbaseWsSnapshot {
+			err = c.Websocket.Orderbook.LoadSnapshot(orderbook.Base{
+				Pair:         p,
+				CurrencyPair: p.Pair().String(),
+				Bids:         bids,
+				Asks:         asks,
+				AssetType:    assets.Spot,
+				LastUpdated:  resp.Timestamp,
+			}, c.Name)
+		} else {
+			err = c.Websocket.Orderbook.Update(bids, asks, p, resp.Timestamp, c.Name, assets.Spot)
+		}
+		if err != nil {
+			return err
+		}
+
+		c.Websocket.DataHandler <- exchange.WebsocketOrderbookUpdate{
+			Pair:     p,
+			Asset:    assets.Spot,
+			Exchange: c.Name,
+		}
+	}
+	return nil
+}
+
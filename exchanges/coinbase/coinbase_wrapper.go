@@ -0,0 +1,648 @@
+package coinbase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+// Order statuses used when querying orders
+const (
+	coinbaseOrderOpen      = "OPEN"
+	coinbaseOrderFilled    = "FILLED"
+	coinbaseOrderCancelled = "CANCELLED"
+	coinbaseOrderExpired   = "EXPIRED"
+	coinbaseOrderFailed    = "FAILED"
+)
+
+// candleGranularities maps candle intervals to Coinbase granularities
+var candleGranularities = map[kline.Interval]string{
+	kline.OneMin:     "ONE_MINUTE",
+	kline.FiveMin:    "FIVE_MINUTE",
+	kline.FifteenMin: "FIFTEEN_MINUTE",
+	kline.ThirtyMin:  "THIRTY_MINUTE",
+	kline.OneHour:    "ONE_HOUR",
+	kline.SixHour:    "SIX_HOUR",
+	kline.OneDay:     "ONE_DAY",
+}
+
+// Start starts the Coinbase wrapper, refreshing its currency pairs
+func (c *Coinbase) Start(ctx context.Context) error {
+	return c.StartWrapper(ctx, c.Run)
+}
+
+// Run implements the Coinbase wrapper
+func (c *Coinbase) Run(ctx context.Context) error {
+	if c.Verbose {
+		log.Printf("%s Websocket: %s. (url: %s).\n", c.GetName(), common.IsEnabled(c.Websocket.IsEnabled()), c.Websocket.GetWebsocketURL())
+		log.Printf("%s polling delay: %ds.\n", c.GetName(), c.RESTPollingDelay)
+		log.Printf("%s %d currencies enabled: %s.\n", c.GetName(), len(c.EnabledPairs), c.EnabledPairs)
+	}
+
+	products, err := c.GetProducts()
+	if err != nil {
+		log.Printf("%s failed to obtain available products. Err: %s", c.Name, err)
+		return nil
+	}
+
+	var pairs []string
+	for i := range products {
+		if products[i].TradingDisabled || products[i].Status != "online" {
+			continue
+		}
+		pairs = append(pairs, products[i].ProductID)
+	}
+
+	err = c.UpdateCurrencies(pairs, false, false)
+	if err != nil {
+		log.Printf("%s failed to update available currencies. Err: %s", c.Name, err)
+	}
+	return nil
+}
+
+// productID returns the product ID of a currency pair, such as BTC-USD
+func (c *Coinbase) productID(p pair.CurrencyPair) string {
+	return exchange.FormatExchangeCurrency(c.Name, p).String()
+}
+
+// productToPair returns the currency pair of a product ID
+func productToPair(productID string) pair.CurrencyPair {
+	return pair.NewCurrencyPairDelimiter(productID, "-")
+}
+
+// UpdateTicker updates and returns the ticker for a currency pair
+func (c *Coinbase) UpdateTicker(p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	return c.UpdateTickerContext(context.Background(), p, assetType)
+}
+
+// UpdateTickerContext updates and returns the ticker for a currency pair,
+// cancelled with the context
+func (c *Coinbase) UpdateTickerContext(ctx context.Context, p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	var tickerPrice ticker.Price
+	product, err := c.GetProductContext(ctx, c.productID(p))
+	if err != nil {
+		return tickerPrice, err
+	}
+
+	trades, err := c.GetMarketTradesContext(ctx, c.productID(p), 1)
+	if err != nil {
+		return tickerPrice, err
+	}
+
+	tickerPrice = ticker.Price{
+		Pair:        p,
+		Last:        product.Price.Float64(),
+		Bid:         trades.BestBid.Float64(),
+		Ask:         trades.BestAsk.Float64(),
+		Volume:      product.Volume24h.Float64(),
+		LastUpdated: time.Now(),
+	}
+	if len(trades.Trades) > 0 {
+		tickerPrice.Last = trades.Trades[0].Price.Float64()
+		tickerPrice.LastUpdated = trades.Trades[0].Time
+	}
+
+	ticker.ProcessTicker(c.GetName(), p, tickerPrice, assetType)
+	return ticker.GetTicker(c.Name, p, assetType)
+}
+
+// GetTickerPrice returns the ticker for a currency pair
+func (c *Coinbase) GetTickerPrice(p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	tickerNew, err := ticker.GetTicker(c.GetName(), p, assetType)
+	if err != nil {
+		return c.UpdateTicker(p, assetType)
+	}
+	return tickerNew, nil
+}
+
+// GetOrderbookEx returns orderbook base on the currency pair
+func (c *Coinbase) GetOrderbookEx(p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	ob, err := orderbook.GetOrderbook(c.GetName(), p, assetType)
+	if err != nil {
+		return c.UpdateOrderbook(p, assetType)
+	}
+	return ob, nil
+}
+
+// UpdateOrderbook updates and returns the orderbook for a currency pair
+func (c *Coinbase) UpdateOrderbook(p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	return c.UpdateOrderbookContext(context.Background(), p, assetType)
+}
+
+// UpdateOrderbookContext updates and returns the orderbook for a currency
+// pair, cancelled with the context
+func (c *Coinbase) UpdateOrderbookContext(ctx context.Context, p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	var orderBook orderbook.Base
+	orderbookNew, err := c.GetProductBookContext(ctx, c.productID(p), 1000)
+	if err != nil {
+		return orderBook, err
+	}
+
+	for x := range orderbookNew.Bids {
+		orderBook.Bids = append(orderBook.Bids, orderbook.Item{
+			Amount: orderbookNew.Bids[x].Size.Float64(),
+			Price:  orderbookNew.Bids[x].Price.Float64(),
+		})
+	}
+
+	for x := range orderbookNew.Asks {
+		orderBook.Asks = append(orderBook.Asks, orderbook.Item{
+			Amount: orderbookNew.Asks[x].Size.Float64(),
+			Price:  orderbookNew.Asks[x].Price.Float64(),
+		})
+	}
+
+	orderbook.ProcessOrderbook(c.GetName(), p, orderBook, assetType)
+	return orderbook.GetOrderbook(c.Name, p, assetType)
+}
+
+// GetAccountInfo retrieves balances for all currencies
+func (c *Coinbase) GetAccountInfo() (exchange.AccountInfo, error) {
+	return c.GetAccountInfoContext(context.Background())
+}
+
+// GetAccountInfoContext retrieves balances for all currencies, cancelled with
+// the context
+func (c *Coinbase) GetAccountInfoContext(ctx context.Context) (exchange.AccountInfo, error) {
+	var info exchange.AccountInfo
+	accounts, err := c.GetAllAccountsContext(ctx)
+	if err != nil {
+		return info, err
+	}
+
+	for i := range accounts {
+		info.Currencies = append(info.Currencies, exchange.AccountCurrencyInfo{
+			CurrencyName: accounts[i].Currency,
+			TotalValue:   accounts[i].AvailableBalance.Value.Float64() + accounts[i].Hold.Value.Float64(),
+			Hold:         accounts[i].Hold.Value.Float64(),
+		})
+	}
+
+	info.ExchangeName = c.GetName()
+	return info, nil
+}
+
+// GetFundingHistory returns funding history, deposits and withdrawals of
+// every account
+func (c *Coinbase) GetFundingHistory() ([]exchange.FundHistory, error) {
+	accounts, err := c.GetAllAccounts()
+	if err != nil {
+		return nil, err
+	}
+
+	var fundHistory []exchange.FundHistory
+	for i := range accounts {
+		var after string
+		for {
+			transactions, pagination, err := c.GetTransactions(accounts[i].UUID, after)
+			if err != nil {
+				return nil, err
+			}
+			for j := range transactions {
+				if history, ok := c.fundHistory(&transactions[j]); ok {
+					fundHistory = append(fundHistory, history)
+				}
+			}
+			if pagination.NextStartingAfter == "" {
+				break
+			}
+			after = pagination.NextStartingAfter
+		}
+	}
+	return fundHistory, nil
+}
+
+// fundHistory converts a deposit or withdrawal transaction, other
+// transaction types are skipped
+func (c *Coinbase) fundHistory(t *Transaction) (exchange.FundHistory, bool) {
+	amount := t.Amount.Amount.Float64()
+	var transferType string
+	switch t.Type {
+	case "send":
+		transferType = "deposit"
+		if amount < 0 {
+			transferType = "withdrawal"
+		}
+	case "fiat_deposit":
+		transferType = "deposit"
+	case "fiat_withdrawal":
+		transferType = "withdrawal"
+	default:
+		return exchange.FundHistory{}, false
+	}
+
+	if amount < 0 {
+		amount = -amount
+	}
+	return exchange.FundHistory{
+		ExchangeName:    c.Name,
+		Status:          t.Status,
+		Description:     t.ID,
+		Timestamp:       t.CreatedAt.UTC(),
+		Currency:        t.Amount.Currency,
+		Amount:          amount,
+		NetworkFee:      t.Network.TransactionFee.Amount.Float64(),
+		TransferType:    transferType,
+		CryptoToAddress: t.To.Address,
+		CryptoTxID:      t.Network.Hash,
+	}, true
+}
+
+// GetExchangeHistory returns the most recent public trades
+func (c *Coinbase) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]exchange.TradeHistory, error) {
+	trades, err := c.GetMarketTrades(c.productID(p), 100)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]exchange.TradeHistory, len(trades.Trades))
+	for i := range trades.Trades {
+		tid, _ := strconv.ParseInt(trades.Trades[i].TradeID, 10, 64)
+		resp[i] = exchange.TradeHistory{
+			Timestamp: trades.Trades[i].Time,
+			TID:       tid,
+			Price:     trades.Trades[i].Price.Float64(),
+			Amount:    trades.Trades[i].Size.Float64(),
+			Exchange:  c.Name,
+			Type:      strings.ToLower(trades.Trades[i].Side),
+		}
+	}
+	return resp, nil
+}
+
+// GetHistoricCandles returns the candles of a currency pair opening between
+// start and end, paging through the candles endpoint
+func (c *Coinbase) GetHistoricCandles(p pair.CurrencyPair, assetType string, interval kline.Interval, start, end time.Time) ([]kline.Candle, error) {
+	granularity, ok := candleGranularities[interval]
+	if !ok {
+		return nil, kline.ErrUnsupportedInterval
+	}
+	if !start.Before(end) {
+		return nil, kline.ErrInvalidTimeRange
+	}
+
+	var candles []kline.Candle
+	for _, r := range kline.CalculateRanges(interval, start, end, coinbaseCandleLimit) {
+		resp, err := c.GetCandles(c.productID(p), granularity, r.Start, r.End)
+		if err != nil {
+			return nil, err
+		}
+		for x := range resp {
+			candles = append(candles, kline.Candle{
+				Time:   time.Unix(int64(resp[x].Start), 0).UTC(),
+				Open:   resp[x].Open.Float64(),
+				High:   resp[x].High.Float64(),
+				Low:    resp[x].Low.Float64(),
+				Close:  resp[x].Close.Float64(),
+				Volume: resp[x].Volume.Float64(),
+			})
+		}
+	}
+	return kline.FilterCandles(candles, start, end), nil
+}
+
+// buildOrder converts order parameters into an order request. Market buys
+// are sized in the quote currency, all other orders in the base currency.
+func (c *Coinbase) buildOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (CreateOrderRequest, error) {
+	req := CreateOrderRequest{
+		ClientOrderID: c.BrokerClientOrderID(clientID),
+		ProductID:     c.productID(p),
+	}
+	if req.ClientOrderID == "" {
+		// Coinbase requires a client order ID to deduplicate orders
+		req.ClientOrderID = strconv.FormatInt(time.Now().UnixNano(), 10)
+	}
+
+	switch side {
+	case exchange.Buy:
+		req.Side = "BUY"
+	case exchange.Sell:
+		req.Side = "SELL"
+	default:
+		return req, fmt.Errorf("unsupported order side %s", side)
+	}
+
+	size := strconv.FormatFloat(amount, 'f', -1, 64)
+	limitPrice := strconv.FormatFloat(price, 'f', -1, 64)
+	switch orderType {
+	case exchange.Limit:
+		req.OrderConfiguration.LimitGTC = &LimitGTC{BaseSize: size, LimitPrice: limitPrice}
+	case exchange.Market:
+		req.OrderConfiguration.MarketIOC = &MarketIOC{BaseSize: size}
+		if c.MarketOrderInQuote(side) {
+			req.OrderConfiguration.MarketIOC = &MarketIOC{QuoteSize: size}
+		}
+	case exchange.ImmediateOrCancel:
+		req.OrderConfiguration.LimitIOC = &LimitIOC{BaseSize: size, LimitPrice: limitPrice}
+	default:
+		return req, fmt.Errorf("unsupported order type %s", orderType)
+	}
+	return req, nil
+}
+
+// SubmitOrder submits a new spot order
+func (c *Coinbase) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	return c.SubmitOrderContext(context.Background(), p, side, orderType, amount, price, clientID)
+}
+
+// SubmitOrderContext submits a new spot order, cancelled with the context
+func (c *Coinbase) SubmitOrderContext(ctx context.Context, p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	if c.IsSimulated() {
+		return c.SimulateSubmitOrder(p, side, orderType, amount, price, clientID)
+	}
+
+	var submitOrderResponse exchange.SubmitOrderResponse
+	req, err := c.buildOrder(p, side, orderType, amount, price, clientID)
+	if err != nil {
+		return submitOrderResponse, err
+	}
+
+	resp, err := c.CreateOrderContext(ctx, req)
+	if err != nil {
+		return submitOrderResponse, err
+	}
+
+	submitOrderResponse.OrderID = resp.SuccessResponse.OrderID
+	submitOrderResponse.IsOrderPlaced = true
+	return submitOrderResponse, nil
+}
+
+// ModifyOrder will allow of changing orderbook placement and limit to
+// market conversion
+func (c *Coinbase) ModifyOrder(action exchange.ModifyOrder) (string, error) {
+	if c.IsSimulated() {
+		return c.SimulateModifyOrder(action)
+	}
+
+	return "", common.ErrFunctionNotSupported
+}
+
+// CancelOrder cancels an order by its corresponding ID
+func (c *Coinbase) CancelOrder(order exchange.OrderCancellation) error {
+	return c.CancelOrderContext(context.Background(), order)
+}
+
+// CancelOrderContext cancels an order by its corresponding ID, cancelled with
+// the context
+func (c *Coinbase) CancelOrderContext(ctx context.Context, order exchange.OrderCancellation) error {
+	if c.IsSimulated() {
+		return c.SimulateCancelOrder(order)
+	}
+
+	results, err := c.CancelOrdersContext(ctx, []string{order.OrderID})
+	if err != nil {
+		return err
+	}
+	for i := range results {
+		if !results[i].Success {
+			return fmt.Errorf("%s unable to cancel order %s: %s",
+				c.Name,
+				results[i].OrderID,
+				results[i].FailureReason)
+		}
+	}
+	return nil
+}
+
+// CancelAllOrders cancels all open orders of the enabled currencies
+func (c *Coinbase) CancelAllOrders(orderCancellation exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
+	if c.IsSimulated() {
+		return c.SimulateCancelAllOrders(orderCancellation)
+	}
+
+	cancelAllOrdersResponse := exchange.CancelAllOrdersResponse{
+		OrderStatus: make(map[string]string),
+	}
+
+	var productIDs []string
+	for _, p := range c.GetEnabledCurrencies() {
+		productIDs = append(productIDs, c.productID(p))
+	}
+
+	orders, err := c.GetAllOrders(OrdersRequest{
+		ProductIDs: productIDs,
+		Status:     []string{coinbaseOrderOpen},
+	})
+	if err != nil {
+		return cancelAllOrdersResponse, err
+	}
+
+	for i := 0; i < len(orders); i += coinbaseCancelLimit {
+		end := i + coinbaseCancelLimit
+		if end > len(orders) {
+			end = len(orders)
+		}
+
+		var ids []string
+		for j := i; j < end; j++ {
+			ids = append(ids, orders[j].OrderID)
+		}
+
+		results, err := c.CancelOrders(ids)
+		if err != nil {
+			return cancelAllOrdersResponse, err
+		}
+		for j := range results {
+			if !results[j].Success {
+				cancelAllOrdersResponse.OrderStatus[results[j].OrderID] = results[j].FailureReason
+			}
+		}
+	}
+
+	return cancelAllOrdersResponse, nil
+}
+
+// GetOrderInfo returns information on a current open order. Coinbase order
+// IDs are UUIDs, use QueryOrder to look up an order by its ID.
+func (c *Coinbase) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
+	if c.IsSimulated() {
+		return c.SimulateGetOrderInfo(orderID)
+	}
+
+	var orderDetail exchange.OrderDetail
+	return orderDetail, common.ErrFunctionNotSupported
+}
+
+// GetActiveOrders retrieves the open orders matching the request
+func (c *Coinbase) GetActiveOrders(req exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	if c.IsSimulated() {
+		return c.SimulateGetOrders(req, true)
+	}
+
+	return c.getOrders(req, []string{coinbaseOrderOpen})
+}
+
+// GetOrderHistory retrieves the filled, cancelled and expired orders matching
+// the request
+func (c *Coinbase) GetOrderHistory(req exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	if c.IsSimulated() {
+		return c.SimulateGetOrders(req, false)
+	}
+
+	return c.getOrders(req, []string{
+		coinbaseOrderFilled,
+		coinbaseOrderCancelled,
+		coinbaseOrderExpired,
+		coinbaseOrderFailed,
+	})
+}
+
+// getOrders returns the orders of the request's pairs with the supplied
+// statuses
+func (c *Coinbase) getOrders(req exchange.GetOrdersRequest, status []string) ([]exchange.OrderDetail, error) {
+	var productIDs []string
+	for _, p := range c.GetOrdersRequestPairs(req) {
+		productIDs = append(productIDs, c.productID(p))
+	}
+
+	resp, err := c.GetAllOrders(OrdersRequest{
+		ProductIDs: productIDs,
+		Status:     status,
+		Start:      req.StartTime,
+		End:        req.EndTime,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	orders := make([]exchange.OrderDetail, len(resp))
+	for i := range resp {
+		orders[i] = c.orderDetail(&resp[i])
+	}
+	return exchange.FilterOrders(orders, req), nil
+}
+
+// orderDetail converts a Coinbase order to its order details, the amount and
+// price are taken from the order's configuration
+func (c *Coinbase) orderDetail(o *Order) exchange.OrderDetail {
+	p := productToPair(o.ProductID)
+
+	side := exchange.Sell
+	if o.Side == "BUY" {
+		side = exchange.Buy
+	}
+
+	var amount, price float64
+	orderType := exchange.Limit
+	switch {
+	case o.OrderConfiguration.MarketIOC != nil:
+		orderType = exchange.Market
+		amount, _ = strconv.ParseFloat(o.OrderConfiguration.MarketIOC.BaseSize, 64)
+		if amount == 0 {
+			// Market buys sized in the quote currency are reported by
+			// their filled base amount
+			amount = o.FilledSize.Float64()
+		}
+	case o.OrderConfiguration.LimitGTC != nil:
+		amount, _ = strconv.ParseFloat(o.OrderConfiguration.LimitGTC.BaseSize, 64)
+		price, _ = strconv.ParseFloat(o.OrderConfiguration.LimitGTC.LimitPrice, 64)
+	case o.OrderConfiguration.LimitIOC != nil:
+		orderType = exchange.ImmediateOrCancel
+		amount, _ = strconv.ParseFloat(o.OrderConfiguration.LimitIOC.BaseSize, 64)
+		price, _ = strconv.ParseFloat(o.OrderConfiguration.LimitIOC.LimitPrice, 64)
+	}
+
+	openVolume := amount - o.FilledSize.Float64()
+	if o.Status != coinbaseOrderOpen || openVolume < 0 {
+		openVolume = 0
+	}
+
+	return exchange.OrderDetail{
+		Exchange:             c.Name,
+		ID:                   o.OrderID,
+		BaseCurrency:         p.FirstCurrency.Upper().String(),
+		QuoteCurrency:        p.SecondCurrency.Upper().String(),
+		OrderSide:            string(side),
+		OrderType:            string(orderType),
+		CreationTime:         o.CreatedTime.UTC(),
+		Status:               o.Status,
+		Price:                price,
+		Amount:               amount,
+		OpenVolume:           openVolume,
+		ExecutedAmount:       o.FilledSize.Float64(),
+		AverageExecutedPrice: o.AverageFilledPrice.Float64(),
+	}
+}
+
+// GetDepositAddress returns a new deposit address for a specified currency
+func (c *Coinbase) GetDepositAddress(cryptocurrency pair.CurrencyItem) (string, error) {
+	accountID, err := c.getAccountID(cryptocurrency.Upper().String())
+	if err != nil {
+		return "", err
+	}
+
+	address, err := c.CreateAddress(accountID)
+	if err != nil {
+		return "", err
+	}
+	if address.Address == "" {
+		return "", errors.New("no deposit address returned")
+	}
+	return address.Address, nil
+}
+
+// WithdrawCryptocurrencyFunds returns a withdrawal ID when a withdrawal is
+// submitted
+func (c *Coinbase) WithdrawCryptocurrencyFunds(address string, cryptocurrency pair.CurrencyItem, amount float64) (string, error) {
+	accountID, err := c.getAccountID(cryptocurrency.Upper().String())
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := c.SendMoney(accountID, SendRequest{
+		To:       address,
+		Amount:   strconv.FormatFloat(amount, 'f', -1, 64),
+		Currency: cryptocurrency.Upper().String(),
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.ID, nil
+}
+
+// WithdrawFiatFunds returns a withdrawal ID when a
+// withdrawal is submitted
+func (c *Coinbase) WithdrawFiatFunds(currency pair.CurrencyItem, amount float64) (string, error) {
+	return "", common.ErrFunctionNotSupported
+}
+
+// WithdrawFiatFundsToInternationalBank returns a withdrawal ID when a
+// withdrawal is submitted
+func (c *Coinbase) WithdrawFiatFundsToInternationalBank(currency pair.CurrencyItem, amount float64) (string, error) {
+	return "", common.ErrFunctionNotSupported
+}
+
+// GetWebsocket returns a pointer to the exchange websocket
+func (c *Coinbase) GetWebsocket() (*exchange.Websocket, error) {
+	return c.Websocket, nil
+}
+
+// GetFeeByType returns an estimate of fee based on type of transaction
+func (c *Coinbase) GetFeeByType(feeBuilder exchange.FeeBuilder) (float64, error) {
+	return c.GetFee(feeBuilder)
+}
+
+// GetWithdrawCapabilities returns the types of withdrawal methods permitted by the exchange
+func (c *Coinbase) GetWithdrawCapabilities() uint32 {
+	return c.GetWithdrawPermissions()
+}
+
+// DetectAccountTier returns the pricing tier of the account, e.g. Advanced 1
+func (c *Coinbase) DetectAccountTier() (string, error) {
+	summary, err := c.GetTransactionSummary()
+	if err != nil {
+		return "", err
+	}
+	return summary.FeeTier.PricingTier, nil
+}
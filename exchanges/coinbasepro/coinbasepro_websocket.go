@@ -62,6 +62,8 @@ func (c *CoinbasePro) WsConnect() error {
 	}
 
 	var dialer websocket.Dialer
+	dialer.TLSClientConfig = c.GetTLSConfig()
+	dialer.NetDial = c.GetWebsocketNetDial()
 
 	if c.Websocket.GetProxyAddress() != "" {
 		proxy, err := url.Parse(c.Websocket.GetProxyAddress())
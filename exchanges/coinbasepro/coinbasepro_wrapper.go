@@ -1,9 +1,9 @@
 package coinbasepro
 
 import (
+	"context"
 	"errors"
 	"log"
-	"sync"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
@@ -12,17 +12,13 @@ import (
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
 
-// Start starts the coinbasepro go routine
-func (c *CoinbasePro) Start(wg *sync.WaitGroup) {
-	wg.Add(1)
-	go func() {
-		c.Run()
-		wg.Done()
-	}()
+// Start starts the CoinbasePro wrapper, refreshing its currency pairs
+func (c *CoinbasePro) Start(ctx context.Context) error {
+	return c.StartWrapper(ctx, c.Run)
 }
 
 // Run implements the coinbasepro wrapper
-func (c *CoinbasePro) Run() {
+func (c *CoinbasePro) Run(ctx context.Context) error {
 	if c.Verbose {
 		log.Printf("%s Websocket: %s. (url: %s).\n", c.GetName(), common.IsEnabled(c.Websocket.IsEnabled()), coinbaseproWebsocketURL)
 		log.Printf("%s polling delay: %ds.\n", c.GetName(), c.RESTPollingDelay)
@@ -44,6 +40,7 @@ func (c *CoinbasePro) Run() {
 			log.Printf("%s Failed to update available currencies.\n", c.GetName())
 		}
 	}
+	return nil
 }
 
 // GetAccountInfo retrieves balances for all enabled currencies for the
@@ -145,6 +142,10 @@ func (c *CoinbasePro) GetExchangeHistory(p pair.CurrencyPair, assetType string)
 
 // SubmitOrder submits a new order
 func (c *CoinbasePro) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	if c.IsSimulated() {
+		return c.SimulateSubmitOrder(p, side, orderType, amount, price, clientID)
+	}
+
 	var submitOrderResponse exchange.SubmitOrderResponse
 	var response string
 	var err error
@@ -171,16 +172,28 @@ func (c *CoinbasePro) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide,
 // ModifyOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (c *CoinbasePro) ModifyOrder(action exchange.ModifyOrder) (string, error) {
+	if c.IsSimulated() {
+		return c.SimulateModifyOrder(action)
+	}
+
 	return "", common.ErrFunctionNotSupported
 }
 
 // CancelOrder cancels an order by its corresponding ID number
 func (c *CoinbasePro) CancelOrder(order exchange.OrderCancellation) error {
+	if c.IsSimulated() {
+		return c.SimulateCancelOrder(order)
+	}
+
 	return c.CancelExistingOrder(order.OrderID)
 }
 
 // CancelAllOrders cancels all orders associated with a currency pair
 func (c *CoinbasePro) CancelAllOrders(orderCancellation exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
+	if c.IsSimulated() {
+		return c.SimulateCancelAllOrders(orderCancellation)
+	}
+
 	// CancellAllExisting orders returns a list of successful cancellations, we're only interested in failures
 	_, err := c.CancelAllExistingOrders("")
 	return exchange.CancelAllOrdersResponse{}, err
@@ -188,6 +201,10 @@ func (c *CoinbasePro) CancelAllOrders(orderCancellation exchange.OrderCancellati
 
 // GetOrderInfo returns information on a current open order
 func (c *CoinbasePro) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
+	if c.IsSimulated() {
+		return c.SimulateGetOrderInfo(orderID)
+	}
+
 	var orderDetail exchange.OrderDetail
 	return orderDetail, common.ErrNotYetImplemented
 }
@@ -88,7 +88,7 @@ func (c *COINUT) WsHandleData() {
 				}
 
 				c.Websocket.DataHandler <- exchange.TickerData{
-					Timestamp:  time.Unix(0, ticker.Timestamp),
+					Timestamp:  common.UnixTimestampToUTC(ticker.Timestamp),
 					Exchange:   c.GetName(),
 					AssetType:  "SPOT",
 					HighPrice:  ticker.HighestBuy,
@@ -154,7 +154,7 @@ func (c *COINUT) WsHandleData() {
 				currencyPair := instrumentListByCode[tradeUpdate.InstID]
 
 				c.Websocket.DataHandler <- exchange.TradeData{
-					Timestamp:    time.Unix(tradeUpdate.Timestamp, 0),
+					Timestamp:    common.UnixTimestampToUTC(tradeUpdate.Timestamp),
 					CurrencyPair: pair.NewCurrencyPairFromString(currencyPair),
 					AssetType:    "SPOT",
 					Exchange:     c.GetName(),
@@ -173,6 +173,8 @@ func (c *COINUT) WsConnect() error {
 	}
 
 	var Dialer websocket.Dialer
+	Dialer.TLSClientConfig = c.GetTLSConfig()
+	Dialer.NetDial = c.GetWebsocketNetDial()
 
 	if c.Websocket.GetProxyAddress() != "" {
 		proxy, err := url.Parse(c.Websocket.GetProxyAddress())
@@ -1,11 +1,11 @@
 package coinut
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
 	"strconv"
-	"sync"
 
 	"github.com/thrasher-/gocryptotrader/currency/symbol"
 
@@ -16,17 +16,13 @@ import (
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
 
-// Start starts the COINUT go routine
-func (c *COINUT) Start(wg *sync.WaitGroup) {
-	wg.Add(1)
-	go func() {
-		c.Run()
-		wg.Done()
-	}()
+// Start starts the COINUT wrapper, refreshing its currency pairs
+func (c *COINUT) Start(ctx context.Context) error {
+	return c.StartWrapper(ctx, c.Run)
 }
 
 // Run implements the COINUT wrapper
-func (c *COINUT) Run() {
+func (c *COINUT) Run(ctx context.Context) error {
 	if c.Verbose {
 		log.Printf("%s Websocket: %s. (url: %s).\n", c.GetName(), common.IsEnabled(c.Websocket.IsEnabled()), coinutWebsocketURL)
 		log.Printf("%s polling delay: %ds.\n", c.GetName(), c.RESTPollingDelay)
@@ -36,7 +32,7 @@ func (c *COINUT) Run() {
 	exchangeProducts, err := c.GetInstruments()
 	if err != nil {
 		log.Printf("%s Failed to get available products.\n", c.GetName())
-		return
+		return nil
 	}
 
 	currencies := []string{}
@@ -50,6 +46,7 @@ func (c *COINUT) Run() {
 	if err != nil {
 		log.Printf("%s Failed to update available currencies.\n", c.GetName())
 	}
+	return nil
 }
 
 // GetAccountInfo retrieves balances for all enabled currencies for the
@@ -210,6 +207,10 @@ func (c *COINUT) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]ex
 
 // SubmitOrder submits a new order
 func (c *COINUT) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	if c.IsSimulated() {
+		return c.SimulateSubmitOrder(p, side, orderType, amount, price, clientID)
+	}
+
 	var submitOrderResponse exchange.SubmitOrderResponse
 	var err error
 	var APIresponse interface{}
@@ -260,11 +261,19 @@ func (c *COINUT) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, order
 // ModifyOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (c *COINUT) ModifyOrder(action exchange.ModifyOrder) (string, error) {
+	if c.IsSimulated() {
+		return c.SimulateModifyOrder(action)
+	}
+
 	return "", common.ErrFunctionNotSupported
 }
 
 // CancelOrder cancels an order by its corresponding ID number
 func (c *COINUT) CancelOrder(order exchange.OrderCancellation) error {
+	if c.IsSimulated() {
+		return c.SimulateCancelOrder(order)
+	}
+
 	orderIDInt, err := strconv.ParseInt(order.OrderID, 10, 64)
 
 	if err != nil {
@@ -287,6 +296,10 @@ func (c *COINUT) CancelOrder(order exchange.OrderCancellation) error {
 
 // CancelAllOrders cancels all orders associated with a currency pair
 func (c *COINUT) CancelAllOrders(orderCancellation exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
+	if c.IsSimulated() {
+		return c.SimulateCancelAllOrders(orderCancellation)
+	}
+
 	// TODO, this is a terrible implementation. Requires DB to improve
 	// Coinut provides no way of retrieving orders without a currency
 	// So we need to retrieve all currencies, then retrieve orders for each currency
@@ -341,6 +354,10 @@ func (c *COINUT) CancelAllOrders(orderCancellation exchange.OrderCancellation) (
 
 // GetOrderInfo returns information on a current open order
 func (c *COINUT) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
+	if c.IsSimulated() {
+		return c.SimulateGetOrderInfo(orderID)
+	}
+
 	var orderDetail exchange.OrderDetail
 	return orderDetail, common.ErrNotYetImplemented
 }
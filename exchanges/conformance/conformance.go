@@ -0,0 +1,239 @@
+// Package conformance provides a test harness which checks that an exchange
+// wrapper honours the IBotExchange contract. All checks run offline and
+// without API credentials so they are safe to run in CI for every exchange.
+package conformance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/assets"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+// Run runs all conformance checks. newExchange must return a new, zero value
+// exchange instance on each call and exchCfg is the exchange's entry from the
+// test configuration.
+func Run(t *testing.T, newExchange func() exchange.IBotExchange, exchCfg config.ExchangeConfig) {
+	t.Run("Defaults", func(t *testing.T) {
+		checkDefaults(t, newExchange(), exchCfg.Name)
+	})
+	t.Run("Setup", func(t *testing.T) {
+		checkSetup(t, newExchange(), exchCfg)
+	})
+	t.Run("Unauthenticated", func(t *testing.T) {
+		checkUnauthenticated(t, newExchange(), exchCfg)
+	})
+	t.Run("Capabilities", func(t *testing.T) {
+		checkCapabilities(t, newExchange(), exchCfg)
+	})
+}
+
+// offlineConfig returns a copy of the exchange config with websocket and
+// authenticated API support disabled
+func offlineConfig(exchCfg config.ExchangeConfig) config.ExchangeConfig {
+	exchCfg.Enabled = true
+	exchCfg.Websocket = false
+	exchCfg.AuthenticatedAPISupport = false
+	exchCfg.APIKey = ""
+	exchCfg.APISecret = ""
+	exchCfg.ClientID = ""
+	return exchCfg
+}
+
+// checkDefaults verifies the state of an exchange after SetDefaults
+func checkDefaults(t *testing.T, e exchange.IBotExchange, name string) {
+	e.SetDefaults()
+
+	if e.GetName() != name {
+		t.Errorf("Test Failed - SetDefaults() name expected %s, received %s",
+			name,
+			e.GetName())
+	}
+
+	if e.IsEnabled() {
+		t.Error("Test Failed - SetDefaults() exchange should not be enabled by default")
+	}
+
+	if e.GetAuthenticatedAPISupport() {
+		t.Error("Test Failed - SetDefaults() authenticated API support should not be enabled by default")
+	}
+
+	if len(e.GetAssetTypes()) == 0 {
+		t.Error("Test Failed - SetDefaults() no asset types set")
+	}
+
+	if e.FormatWithdrawPermissions() == "" {
+		t.Error("Test Failed - SetDefaults() withdraw permissions not set")
+	}
+}
+
+// checkSetup verifies the exchange loads its configuration correctly
+func checkSetup(t *testing.T, e exchange.IBotExchange, exchCfg config.ExchangeConfig) {
+	e.SetDefaults()
+	e.Setup(offlineConfig(exchCfg))
+
+	if !e.IsEnabled() {
+		t.Error("Test Failed - Setup() exchange not enabled")
+	}
+
+	enabled := e.GetEnabledCurrencies()
+	if len(enabled) == 0 {
+		t.Fatal("Test Failed - Setup() no enabled currency pairs")
+	}
+
+	available := e.GetAvailableCurrencies()
+	for i := range enabled {
+		if !pair.Contains(available, enabled[i], true) {
+			t.Errorf("Test Failed - Setup() enabled pair %s is not an available pair",
+				enabled[i].Pair())
+		}
+	}
+
+	configAssets := common.SplitStrings(exchCfg.AssetTypes, ",")
+	for i := range configAssets {
+		if !common.StringDataCompare(e.GetAssetTypes(), configAssets[i]) {
+			t.Errorf("Test Failed - Setup() config asset type %s not supported",
+				configAssets[i])
+		}
+	}
+
+	if err := e.Stop(); err != exchange.ErrWrapperNotStarted {
+		t.Error("Test Failed - Stop() expected not started error before Start", err)
+	}
+
+	ws, err := e.GetWebsocket()
+	if err == nil && ws == nil {
+		t.Error("Test Failed - GetWebsocket() returned a nil websocket without error")
+	}
+}
+
+// checkUnauthenticated verifies authenticated wrapper functions fail without
+// sending requests when authenticated API support is disabled
+func checkUnauthenticated(t *testing.T, e exchange.IBotExchange, exchCfg config.ExchangeConfig) {
+	e.SetDefaults()
+	e.Setup(offlineConfig(exchCfg))
+
+	enabled := e.GetEnabledCurrencies()
+	if len(enabled) == 0 {
+		t.Fatal("Test Failed - Setup() no enabled currency pairs")
+	}
+	p := enabled[0]
+
+	if _, err := e.GetAccountInfo(); err == nil {
+		t.Error("Test Failed - GetAccountInfo() expected error without credentials")
+	}
+
+	if _, err := e.GetFundingHistory(); err == nil {
+		t.Error("Test Failed - GetFundingHistory() expected error without credentials")
+	}
+
+	resp, err := e.SubmitOrder(p, exchange.Buy, exchange.Limit, 1, 1, "")
+	if err == nil || resp.IsOrderPlaced {
+		t.Error("Test Failed - SubmitOrder() expected error without credentials")
+	}
+
+	err = e.CancelOrder(exchange.OrderCancellation{OrderID: "1", CurrencyPair: p})
+	if err == nil {
+		t.Error("Test Failed - CancelOrder() expected error without credentials")
+	}
+
+	cancelAll, err := e.CancelAllOrders(exchange.OrderCancellation{CurrencyPair: p})
+	if err == nil && len(cancelAll.OrderStatus) == 0 {
+		t.Error("Test Failed - CancelAllOrders() expected error without credentials")
+	}
+
+	if _, err := e.GetOrderInfo(1); err == nil {
+		t.Error("Test Failed - GetOrderInfo() expected error without credentials")
+	}
+
+	if _, err := e.GetDepositAddress(p.FirstCurrency); err == nil {
+		t.Error("Test Failed - GetDepositAddress() expected error without credentials")
+	}
+
+	_, err = e.WithdrawCryptocurrencyFunds("address", p.FirstCurrency, 1)
+	if err == nil {
+		t.Error("Test Failed - WithdrawCryptocurrencyFunds() expected error without credentials")
+	}
+}
+
+// checkCapabilities verifies wrapper functions the exchange reports as
+// unsupported in its capability matrix return a not supported error without
+// sending requests
+func checkCapabilities(t *testing.T, e exchange.IBotExchange, exchCfg config.ExchangeConfig) {
+	e.SetDefaults()
+	e.Setup(offlineConfig(exchCfg))
+
+	f := e.GetCapabilities()
+	if len(f.Capabilities()) == 0 {
+		t.Error("Test Failed - GetCapabilities() no capabilities set")
+	}
+	if f.CanSubmitOrder {
+		if c, ok := f.Orders[assets.Spot]; !ok || len(c.OrderTypes) == 0 {
+			t.Error("Test Failed - GetCapabilities() supports order submission without declaring spot order types")
+		}
+	} else if len(f.Orders) > 0 {
+		t.Error("Test Failed - GetCapabilities() declares order types without supporting order submission")
+	}
+
+	enabled := e.GetEnabledCurrencies()
+	if len(enabled) == 0 {
+		t.Fatal("Test Failed - Setup() no enabled currency pairs")
+	}
+	p := enabled[0]
+
+	unsupported := func(name string, supported bool, call func() error) {
+		if supported {
+			return
+		}
+		if err := call(); err != common.ErrNotYetImplemented && err != common.ErrFunctionNotSupported {
+			t.Errorf("Test Failed - %s() reported unsupported but returned %v", name, err)
+		}
+	}
+
+	unsupported("GetExchangeHistory", f.CanGetTradeHistory, func() error {
+		_, err := e.GetExchangeHistory(p, ticker.Spot)
+		return err
+	})
+	unsupported("GetHistoricCandles", f.CanGetHistoricCandles, func() error {
+		_, err := e.GetHistoricCandles(p, ticker.Spot, kline.OneHour, time.Now().Add(-time.Hour), time.Now())
+		return err
+	})
+	unsupported("GetFundingHistory", f.CanGetFundingHistory, func() error {
+		_, err := e.GetFundingHistory()
+		return err
+	})
+	unsupported("ModifyOrder", f.CanModifyOrder, func() error {
+		_, err := e.ModifyOrder(exchange.ModifyOrder{OrderID: "1", Currency: p})
+		return err
+	})
+	unsupported("GetOrderInfo", f.CanGetOrderInfo, func() error {
+		_, err := e.GetOrderInfo(1)
+		return err
+	})
+	unsupported("GetActiveOrders", f.CanGetActiveOrders, func() error {
+		_, err := e.GetActiveOrders(exchange.GetOrdersRequest{})
+		return err
+	})
+	unsupported("GetOrderHistory", f.CanGetOrderHistory, func() error {
+		_, err := e.GetOrderHistory(exchange.GetOrdersRequest{})
+		return err
+	})
+	unsupported("GetDepositAddress", f.CanGetDepositAddress, func() error {
+		_, err := e.GetDepositAddress(p.FirstCurrency)
+		return err
+	})
+	unsupported("WithdrawCryptocurrencyFunds", f.CanWithdrawCrypto, func() error {
+		_, err := e.WithdrawCryptocurrencyFunds("address", p.FirstCurrency, 1)
+		return err
+	})
+	unsupported("WithdrawFiatFunds", f.CanWithdrawFiat, func() error {
+		_, err := e.WithdrawFiatFunds(p.SecondCurrency, 1)
+		return err
+	})
+}
@@ -1,20 +1,24 @@
 package exchange
 
 import (
+	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"net/url"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/config"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/nonce"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/paper"
 	"github.com/thrasher-/gocryptotrader/exchanges/request"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
@@ -98,6 +102,24 @@ type OrderCancellation struct {
 	Side          OrderSide
 }
 
+// CancelAllScope restricts a cancel all to open orders of a currency pair,
+// side and order type which were placed at least MinAge ago. Empty fields
+// match every order.
+type CancelAllScope struct {
+	Pair      pair.CurrencyPair
+	Side      OrderSide
+	OrderType OrderType
+	MinAge    time.Duration
+}
+
+// ScopedCancelAller is implemented by exchanges whose CancelAllOrders only
+// cancels orders of the OrderCancellation currency pair and side when they are
+// set. SupportsCancelAllScope reports whether the exchange enforces the whole
+// scope itself, otherwise matching orders are cancelled individually.
+type ScopedCancelAller interface {
+	SupportsCancelAllScope(scope CancelAllScope) bool
+}
+
 // Definitions for each type of withdrawal method for a given exchange
 const (
 	// No withdraw
@@ -150,16 +172,21 @@ type AccountInfo struct {
 	Currencies   []AccountCurrencyInfo
 }
 
-// AccountCurrencyInfo is a sub type to store currency name and value
+// AccountCurrencyInfo is a sub type to store currency name and value. Dust is
+// set by the bot when the balance is below the smallest sellable amount.
+// AssetType is set by exchanges which hold balances of an asset type, such as
+// margin, apart from their spot balances and is empty otherwise.
 type AccountCurrencyInfo struct {
 	CurrencyName string
 	TotalValue   float64
 	Hold         float64
+	Dust         bool
+	AssetType    string
 }
 
-// TradeHistory holds exchange history data
+// TradeHistory holds exchange history data, Timestamp is in UTC
 type TradeHistory struct {
-	Timestamp int64
+	Timestamp time.Time
 	TID       int64
 	Price     float64
 	Amount    float64
@@ -167,32 +194,41 @@ type TradeHistory struct {
 	Type      string
 }
 
-// OrderDetail holds order detail data
+// OrderDetail holds order detail data. CreationTime is in UTC,
+// ExecutedAmount is the cumulative filled amount, AverageExecutedPrice is the
+// volume weighted price of those fills and OpenVolume is the amount remaining
+// on the order.
 type OrderDetail struct {
-	Exchange      string
-	ID            string
-	BaseCurrency  string
-	QuoteCurrency string
-	OrderSide     string
-	OrderType     string
-	CreationTime  int64
-	Status        string
-	Price         float64
-	Amount        float64
-	OpenVolume    float64
+	Exchange             string
+	ID                   string
+	BaseCurrency         string
+	QuoteCurrency        string
+	OrderSide            string
+	OrderType            string
+	CreationTime         time.Time
+	Status               string
+	Price                float64
+	Amount               float64
+	OpenVolume           float64
+	ExecutedAmount       float64
+	AverageExecutedPrice float64
 }
 
-// FundHistory holds exchange funding history data
+// FundHistory holds exchange funding history data, Timestamp is in UTC. Fee
+// is charged by the exchange and NetworkFee is paid to the blockchain network
+// of a crypto transfer on Chain.
 type FundHistory struct {
 	ExchangeName      string
 	Status            string
 	TransferID        int64
 	Description       string
-	Timestamp         int64
+	Timestamp         time.Time
 	Currency          string
 	Amount            float64
 	Fee               float64
+	NetworkFee        float64
 	TransferType      string
+	Chain             string
 	CryptoToAddress   string
 	CryptoFromAddress string
 	CryptoTxID        string
@@ -216,11 +252,17 @@ type Base struct {
 	AvailablePairs                             []string
 	EnabledPairs                               []string
 	AssetTypes                                 []string
+	AssetPairs                                 map[string]*AssetPairs
 	PairsLastUpdated                           int64
 	SupportsAutoPairUpdating                   bool
 	SupportsRESTTickerBatching                 bool
+	Features                                   Features
+	MarketBuyInQuote                           bool
 	HTTPTimeout                                time.Duration
 	HTTPUserAgent                              string
+	HTTPHeaders                                map[string]string
+	BrokerTag                                  string
+	BrokerTagFormat                            BrokerTagFormat
 	WebsocketURL                               string
 	APIUrl                                     string
 	APIUrlDefault                              string
@@ -230,13 +272,23 @@ type Base struct {
 	ConfigCurrencyPairFormat                   config.CurrencyPairFormatConfig
 	Websocket                                  *Websocket
 	*request.Requester
+
+	orderTransport orderTransport
+	tlsPins        *request.PinSet
+	localAddress   net.IP
+	rounding       *pairRounding
+	rateLimits     *rateLimitTiers
+	simulator      *paper.Engine
+	lifecycle      lifecycle
 }
 
 // IBotExchange enforces standard functions for all exchanges supported in
 // GoCryptoTrader
 type IBotExchange interface {
 	Setup(exch config.ExchangeConfig)
-	Start(wg *sync.WaitGroup)
+	Start(ctx context.Context) error
+	Stop() error
+	IsStarted() bool
 	SetDefaults()
 	GetName() string
 	IsEnabled() bool
@@ -247,14 +299,19 @@ type IBotExchange interface {
 	UpdateOrderbook(currency pair.CurrencyPair, assetType string) (orderbook.Base, error)
 	GetEnabledCurrencies() []pair.CurrencyPair
 	GetAvailableCurrencies() []pair.CurrencyPair
+	GetEnabledPairs(assetType string) []pair.CurrencyPair
 	GetAssetTypes() []string
 	GetAccountInfo() (AccountInfo, error)
 	GetAuthenticatedAPISupport() bool
 	SetCurrencies(pairs []pair.CurrencyPair, enabledPairs bool) error
 	GetExchangeHistory(pair.CurrencyPair, string) ([]TradeHistory, error)
+	GetHistoricCandles(p pair.CurrencyPair, assetType string, interval kline.Interval, start, end time.Time) ([]kline.Candle, error)
 	SupportsAutoPairUpdates() bool
 	GetLastPairsUpdateTime() int64
 	SupportsRESTTickerBatchUpdates() bool
+	GetCapabilities() Features
+	SupportsBrokerTag() bool
+	MarketOrderInQuote(side OrderSide) bool
 
 	GetWithdrawPermissions() uint32
 	FormatWithdrawPermissions() string
@@ -266,6 +323,8 @@ type IBotExchange interface {
 	CancelOrder(order OrderCancellation) error
 	CancelAllOrders(orders OrderCancellation) (CancelAllOrdersResponse, error)
 	GetOrderInfo(orderID int64) (OrderDetail, error)
+	GetActiveOrders(req GetOrdersRequest) ([]OrderDetail, error)
+	GetOrderHistory(req GetOrdersRequest) ([]OrderDetail, error)
 	GetDepositAddress(cryptocurrency pair.CurrencyItem) (string, error)
 
 	WithdrawCryptocurrencyFunds(address string, cryptocurrency pair.CurrencyItem, amount float64) (string, error)
@@ -274,6 +333,201 @@ type IBotExchange interface {
 	GetWebsocket() (*Websocket, error)
 }
 
+// ExchangeGetter returns an exchange by name or nil if it is not loaded
+type ExchangeGetter func(name string) IBotExchange
+
+// Orderer submits an order on behalf of a strategy and returns its local
+// order ID, such as the engine's order manager
+type Orderer interface {
+	SubmitStrategyOrder(strategy string, exch IBotExchange, o OrderSubmission) (int, error)
+}
+
+// IcebergOrderSubmitter is implemented by exchanges which support native
+// iceberg limit orders, only visibleAmount of the order is shown on the book
+type IcebergOrderSubmitter interface {
+	SubmitIcebergOrder(p pair.CurrencyPair, side OrderSide, amount, visibleAmount, price float64, clientID string) (SubmitOrderResponse, error)
+}
+
+// TrailingStopParams holds the trail of a trailing stop order. The stop
+// follows the best price since activation and triggers a market order when
+// the price retraces by CallbackRate, a fraction of the best price, or by the
+// absolute TrailValue. Exactly one of the two is set. ActivationPrice is
+// optional, trailing starts immediately when it is zero.
+type TrailingStopParams struct {
+	CallbackRate    float64
+	TrailValue      float64
+	ActivationPrice float64
+}
+
+// Validate checks exactly one trail is set and the values are not negative
+func (t *TrailingStopParams) Validate() error {
+	if t.CallbackRate < 0 || t.TrailValue < 0 || t.ActivationPrice < 0 {
+		return errors.New("trailing stop parameters cannot be negative")
+	}
+	if (t.CallbackRate > 0) == (t.TrailValue > 0) {
+		return errors.New("trailing stop requires either a callback rate or trail value")
+	}
+	if t.CallbackRate >= 1 {
+		return errors.New("trailing stop callback rate must be less than one")
+	}
+	return nil
+}
+
+// TrailingStopOrderSubmitter is implemented by exchanges which support native
+// trailing stop orders. SupportsTrailingStop reports whether the exchange can
+// place a trailing stop with the given parameters, for example an exchange
+// may only support a callback rate within a range.
+type TrailingStopOrderSubmitter interface {
+	SupportsTrailingStop(params TrailingStopParams) bool
+	SubmitTrailingStopOrder(p pair.CurrencyPair, side OrderSide, amount float64, params TrailingStopParams, clientID string) (SubmitOrderResponse, error)
+	CancelTrailingStopOrder(order OrderCancellation) error
+}
+
+// FuturesContract is a dated futures contract on an underlying currency pair
+type FuturesContract struct {
+	InstrumentID string
+	Underlying   pair.CurrencyPair
+	Expiry       time.Time
+}
+
+// FuturesExchange is implemented by exchanges which list dated futures
+// contracts and accept orders for a contract by its instrument ID. Reduce only
+// orders close an existing position and never open a new one.
+type FuturesExchange interface {
+	GetFuturesContracts() ([]FuturesContract, error)
+	SubmitFuturesOrder(instrumentID string, side OrderSide, orderType OrderType, amount, price float64, reduceOnly bool, clientID string) (SubmitOrderResponse, error)
+}
+
+// FundingRate is a perpetual swap's current funding rate, the fraction of a
+// position's notional paid by longs to shorts every Interval when positive and
+// by shorts to longs when negative
+type FundingRate struct {
+	InstrumentID string
+	Rate         float64
+	Interval     time.Duration
+	NextFunding  time.Time
+}
+
+// FundingRateExchange is implemented by exchanges which report the funding
+// rate of their perpetual swaps by instrument ID
+type FundingRateExchange interface {
+	GetFundingRate(instrumentID string) (FundingRate, error)
+}
+
+// MarkPrice is a derivative's mark price, which exchanges value positions and
+// trigger liquidations at, and the index price of its underlying. IndexPrice
+// is zero when unknown.
+type MarkPrice struct {
+	InstrumentID string
+	Pair         pair.CurrencyPair
+	AssetType    string
+	MarkPrice    float64
+	IndexPrice   float64
+	Time         time.Time
+}
+
+// MarkPriceExchange is implemented by exchanges which report the mark and
+// index price of their derivatives by instrument ID
+type MarkPriceExchange interface {
+	GetMarkPrice(instrumentID string) (MarkPrice, error)
+}
+
+// FuturesPosition is an open derivatives position, Amount is negative when
+// short. LiquidationPrice is zero when the position cannot be liquidated, such
+// as a cross margin position with sufficient collateral. MarginRatio is the
+// maintenance margin as a fraction of the position's margin, the position is
+// liquidated once it reaches one, and is zero when the exchange does not
+// report it.
+type FuturesPosition struct {
+	Exchange         string
+	InstrumentID     string
+	Pair             pair.CurrencyPair
+	AssetType        string
+	Amount           float64
+	EntryPrice       float64
+	MarkPrice        float64
+	LiquidationPrice float64
+	Leverage         float64
+	MarginRatio      float64
+	UnrealisedPNL    float64
+	UpdateTime       time.Time
+}
+
+// FuturesPositionExchange is implemented by exchanges which return the open
+// derivatives positions of the account
+type FuturesPositionExchange interface {
+	GetFuturesPositions() ([]FuturesPosition, error)
+}
+
+// MarginLevel is the margin ratio of an isolated margin account trading a
+// currency pair, with the same meaning as a futures position's: the account
+// is liquidated once it reaches one
+type MarginLevel struct {
+	Pair        pair.CurrencyPair
+	AssetType   string
+	MarginRatio float64
+	UpdateTime  time.Time
+}
+
+// MarginLevelExchange is implemented by exchanges which report the margin
+// ratio of their isolated margin accounts with borrowed funds
+type MarginLevelExchange interface {
+	GetMarginLevels() ([]MarginLevel, error)
+}
+
+// CollateralTransferer is implemented by exchanges which transfer funds from
+// the spot account into the margin backing the positions or isolated margin
+// account of a currency pair and asset type
+type CollateralTransferer interface {
+	TransferCollateral(p pair.CurrencyPair, assetType string, currency pair.CurrencyItem, amount float64) error
+}
+
+// Earn product types
+const (
+	EarnFlexible = "Flexible"
+	EarnLocked   = "Locked"
+	EarnStaking  = "Staking"
+)
+
+// EarnBalance is the balance of an exchange savings or staking product.
+// AccruedInterest is the cumulative interest reported by the exchange and APR
+// is the product's current annual rate as a fraction.
+type EarnBalance struct {
+	ProductID       string
+	ProductType     string
+	Currency        string
+	Amount          float64
+	AccruedInterest float64
+	APR             float64
+}
+
+// EarnBalanceGetter is implemented by exchanges which return balances held in
+// savings and staking products, which are not included in GetAccountInfo
+type EarnBalanceGetter interface {
+	GetEarnBalances() ([]EarnBalance, error)
+}
+
+// StakingPosition is a staked balance of an exchange staking product. Unlock
+// is zero for positions which can be unstaked at any time.
+type StakingPosition struct {
+	PositionID string
+	ProductID  string
+	Currency   string
+	Amount     float64
+	APR        float64
+	Start      time.Time
+	Unlock     time.Time
+}
+
+// StakingExchange is implemented by exchanges which allow staking through
+// their API. Stake returns the ID of the new position and Unstake redeems a
+// position in full.
+type StakingExchange interface {
+	Stake(productID, currency string, amount float64) (string, error)
+	Unstake(positionID string) error
+	GetStakingPositions() ([]StakingPosition, error)
+}
+
 // SupportsRESTTickerBatchUpdates returns whether or not the
 // exhange supports REST batch ticker fetching
 func (e *Base) SupportsRESTTickerBatchUpdates() bool {
@@ -331,6 +585,31 @@ func (e *Base) GetHTTPClientUserAgent() string {
 	return e.HTTPUserAgent
 }
 
+// SetHTTPClientHeaders sets custom headers sent with every HTTP request of the
+// exchange, such as broker IDs or API version headers
+func (e *Base) SetHTTPClientHeaders(headers map[string]string) error {
+	for k, v := range headers {
+		if k == "" || strings.ContainsAny(k, " :\r\n\t") || strings.ContainsAny(v, "\r\n") {
+			return fmt.Errorf("%s invalid HTTP header %q", e.Name, k)
+		}
+	}
+
+	if e.Requester == nil {
+		e.Requester = request.New(e.Name,
+			request.NewRateLimit(time.Second, 0),
+			request.NewRateLimit(time.Second, 0),
+			new(http.Client))
+	}
+	e.Requester.Headers = headers
+	e.HTTPHeaders = headers
+	return nil
+}
+
+// GetHTTPClientHeaders gets the exchanges custom HTTP headers
+func (e *Base) GetHTTPClientHeaders() map[string]string {
+	return e.HTTPHeaders
+}
+
 // SetClientProxyAddress sets a proxy address for REST and websocket requests
 func (e *Base) SetClientProxyAddress(addr string) error {
 	if addr != "" {
@@ -356,6 +635,78 @@ func (e *Base) SetClientProxyAddress(addr string) error {
 	return nil
 }
 
+// SetTLSPins pins the public keys accepted for REST and websocket TLS
+// connections. Calling it again replaces the pins, so pins can be rotated by
+// reloading the exchange config, and an empty list disables pinning.
+func (e *Base) SetTLSPins(pins []string) error {
+	if e.tlsPins != nil {
+		err := e.tlsPins.Update(pins)
+		if err != nil {
+			return fmt.Errorf("%s setting TLS pins error %s", e.Name, err)
+		}
+		return nil
+	}
+
+	if len(pins) == 0 {
+		return nil
+	}
+
+	pinSet, err := request.NewPinSet(pins)
+	if err != nil {
+		return fmt.Errorf("%s setting TLS pins error %s", e.Name, err)
+	}
+	e.Requester.SetTLSPins(pinSet)
+	e.tlsPins = pinSet
+	return nil
+}
+
+// GetTLSConfig returns the TLS config websocket dialers should use, nil if
+// TLS pinning is not enabled
+func (e *Base) GetTLSConfig() *tls.Config {
+	if e.tlsPins == nil {
+		return nil
+	}
+	return e.tlsPins.TLSConfig()
+}
+
+// SetLocalAddress binds outbound REST and websocket connections to a local IP
+// address or the first address of a network interface, for API keys which are
+// restricted to a whitelisted IP on servers with multiple addresses
+func (e *Base) SetLocalAddress(addr string) error {
+	if addr == "" {
+		return nil
+	}
+
+	ip, err := request.ResolveLocalAddress(addr)
+	if err != nil {
+		return fmt.Errorf("%s setting local address error %s", e.Name, err)
+	}
+	e.Requester.SetLocalAddress(ip)
+	e.localAddress = ip
+	return nil
+}
+
+// GetLocalAddress returns the local IP address outbound connections are bound
+// to, nil if not set
+func (e *Base) GetLocalAddress() net.IP {
+	return e.localAddress
+}
+
+// GetWebsocketNetDial returns the dial function websocket dialers should use,
+// nil if outbound connections are not bound to a local address
+func (e *Base) GetWebsocketNetDial() func(network, addr string) (net.Conn, error) {
+	if e.localAddress == nil {
+		return nil
+	}
+	return request.NewLocalDialer(e.localAddress).Dial
+}
+
+// GetEgressIP returns the public IP address the exchange sees REST requests
+// originate from
+func (e *Base) GetEgressIP() (string, error) {
+	return request.GetEgressIP(e.GetHTTPClient())
+}
+
 // SetAutoPairDefaults sets the default values for whether or not the exchange
 // supports auto pair updating or not
 func (e *Base) SetAutoPairDefaults() error {
@@ -399,7 +750,8 @@ func (e *Base) GetLastPairsUpdateTime() int64 {
 }
 
 // SetAssetTypes checks the exchange asset types (whether it supports SPOT,
-// Binary or Futures) and sets it to a default setting if it doesn't exist
+// Margin or Futures) and sets it to a default setting if it doesn't exist. The
+// currency pairs of each asset type are loaded from the config.
 func (e *Base) SetAssetTypes() error {
 	cfg := config.GetConfig()
 	exch, err := cfg.GetExchangeConfig(e.Name)
@@ -416,6 +768,10 @@ func (e *Base) SetAssetTypes() error {
 		update = true
 	}
 
+	if e.loadAssetPairs(&exch) {
+		update = true
+	}
+
 	if update {
 		return cfg.UpdateExchangeConfig(exch)
 	}
@@ -617,6 +973,12 @@ func (e *Base) IsEnabled() bool {
 	return e.Enabled
 }
 
+// SetAuthenticatedAPISupport is a method that sets if the exchange sends
+// authenticated requests
+func (e *Base) SetAuthenticatedAPISupport(enabled bool) {
+	e.AuthenticatedAPISupport = enabled
+}
+
 // SetAPIKeys is a method that sets the current API keys for the exchange
 func (e *Base) SetAPIKeys(APIKey, APISecret, ClientID string, b64Decode bool) {
 	if !e.AuthenticatedAPISupport {
@@ -668,13 +1030,8 @@ func (e *Base) SetCurrencies(pairs []pair.CurrencyPair, enabledPairs bool) error
 	return cfg.UpdateExchangeConfig(exchCfg)
 }
 
-// UpdateCurrencies updates the exchange currency pairs for either enabledPairs or
-// availablePairs
-func (e *Base) UpdateCurrencies(exchangeProducts []string, enabled, force bool) error {
-	if len(exchangeProducts) == 0 {
-		return fmt.Errorf("%s UpdateCurrencies error - exchangeProducts is empty", e.Name)
-	}
-
+// normaliseProducts upper cases exchange products and drops empty products
+func normaliseProducts(exchangeProducts []string) []string {
 	exchangeProducts = common.SplitStrings(common.StringToUpper(common.JoinStrings(exchangeProducts, ",")), ",")
 	var products []string
 
@@ -684,7 +1041,17 @@ func (e *Base) UpdateCurrencies(exchangeProducts []string, enabled, force bool)
 		}
 		products = append(products, exchangeProducts[x])
 	}
+	return products
+}
+
+// UpdateCurrencies updates the exchange currency pairs for either enabledPairs or
+// availablePairs
+func (e *Base) UpdateCurrencies(exchangeProducts []string, enabled, force bool) error {
+	if len(exchangeProducts) == 0 {
+		return fmt.Errorf("%s UpdateCurrencies error - exchangeProducts is empty", e.Name)
+	}
 
+	products := normaliseProducts(exchangeProducts)
 	var newPairs, removedPairs []string
 	var updateType string
 
@@ -792,6 +1159,40 @@ func (o OrderSide) ToString() string {
 	return fmt.Sprintf("%v", o)
 }
 
+// Liquidity is whether a fill added liquidity to the orderbook as the maker
+// or removed it as the taker
+type Liquidity string
+
+// Liquidity types, UnknownLiquidity is used when it cannot be determined
+const (
+	UnknownLiquidity Liquidity = ""
+	Maker            Liquidity = "Maker"
+	Taker            Liquidity = "Taker"
+)
+
+// InferLiquidity classifies a fill when the exchange does not report it.
+// Market orders always take. A limit order filled at a better price than its
+// limit crossed the book on placement and took, otherwise it is assumed to
+// have rested on the book and been filled as the maker.
+func InferLiquidity(orderType OrderType, side OrderSide, limitPrice, fillPrice float64) Liquidity {
+	if orderType == Market || limitPrice <= 0 {
+		return Taker
+	}
+	switch side {
+	case Buy:
+		if fillPrice < limitPrice {
+			return Taker
+		}
+	case Sell:
+		if fillPrice > limitPrice {
+			return Taker
+		}
+	default:
+		return UnknownLiquidity
+	}
+	return Maker
+}
+
 // SetAPIURL sets configuration API URL for an exchange
 func (e *Base) SetAPIURL(ec config.ExchangeConfig) error {
 	if ec.APIURL == "" || ec.APIURLSecondary == "" {
@@ -894,3 +1295,9 @@ func (e *Base) FormatWithdrawPermissions() string {
 
 	return NoAPIWithdrawalMethodsText
 }
+
+// GetHistoricCandles returns the candles of a currency pair opening between
+// start and end, exchanges which support candle retrieval override it
+func (e *Base) GetHistoricCandles(p pair.CurrencyPair, assetType string, interval kline.Interval, start, end time.Time) ([]kline.Candle, error) {
+	return nil, common.ErrFunctionNotSupported
+}
@@ -0,0 +1,52 @@
+package exchange
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrAPIKeyExpiryUnsupported is returned when querying the API key metadata
+// of an exchange which does not expose it
+var ErrAPIKeyExpiryUnsupported = errors.New("exchange does not report its API key expiry")
+
+// APIKeyInfo holds the creation and expiry times of the API key in use, a
+// zero Expiry is a key which does not expire
+type APIKeyInfo struct {
+	Created time.Time
+	Expiry  time.Time
+}
+
+// APIKeyInfoGetter is implemented by exchanges which expose the metadata of
+// the API key used to authenticate requests
+type APIKeyInfoGetter interface {
+	GetAPIKeyInfo() (APIKeyInfo, error)
+}
+
+// authenticatedAPISupportSetter is implemented by every exchange embedding
+// Base
+type authenticatedAPISupportSetter interface {
+	SetAuthenticatedAPISupport(enabled bool)
+}
+
+// GetAPIKeyInfo returns the metadata of an exchange's API key, returning
+// ErrAPIKeyExpiryUnsupported when the exchange does not expose it
+func GetAPIKeyInfo(exch IBotExchange) (APIKeyInfo, error) {
+	getter, ok := exch.(APIKeyInfoGetter)
+	if !ok {
+		return APIKeyInfo{}, ErrAPIKeyExpiryUnsupported
+	}
+	return getter.GetAPIKeyInfo()
+}
+
+// DisableAuthenticatedAPISupport disables the authenticated API support of an
+// exchange, such as once its API key expires, so authenticated requests are
+// rejected locally rather than failing with the exchange's auth errors.
+// Returns whether it was enabled.
+func DisableAuthenticatedAPISupport(exch IBotExchange) bool {
+	setter, ok := exch.(authenticatedAPISupportSetter)
+	if !ok || !exch.GetAuthenticatedAPISupport() {
+		return false
+	}
+	setter.SetAuthenticatedAPISupport(false)
+	return true
+}
@@ -0,0 +1,218 @@
+package exchange
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	"github.com/thrasher-/gocryptotrader/exchanges/assets"
+)
+
+// AssetPairs holds the currency pairs of a first class asset type other than
+// spot, whose pairs are held by Base's AvailablePairs and EnabledPairs. Nil
+// pair formats use the spot pair formats. Exchanges set the default pair
+// formats of their asset types in SetDefaults.
+type AssetPairs struct {
+	AvailablePairs            []string
+	EnabledPairs              []string
+	RequestCurrencyPairFormat *config.CurrencyPairFormatConfig
+	ConfigCurrencyPairFormat  *config.CurrencyPairFormatConfig
+}
+
+// SupportsAsset returns whether the exchange trades an asset type
+func (e *Base) SupportsAsset(assetType string) bool {
+	return common.StringDataCompare(e.AssetTypes, assetType)
+}
+
+// assetPairs returns the pair store of an asset type, nil for spot and asset
+// types without their own pairs
+func (e *Base) assetPairs(assetType string) *AssetPairs {
+	if assetType == assets.Spot || !e.SupportsAsset(assetType) {
+		return nil
+	}
+	return e.AssetPairs[assetType]
+}
+
+// GetConfigPairFormat returns the config currency pair format of an asset type
+func (e *Base) GetConfigPairFormat(assetType string) config.CurrencyPairFormatConfig {
+	if p := e.assetPairs(assetType); p != nil && p.ConfigCurrencyPairFormat != nil {
+		return *p.ConfigCurrencyPairFormat
+	}
+	return e.ConfigCurrencyPairFormat
+}
+
+// GetRequestPairFormat returns the request currency pair format of an asset
+// type
+func (e *Base) GetRequestPairFormat(assetType string) config.CurrencyPairFormatConfig {
+	if p := e.assetPairs(assetType); p != nil && p.RequestCurrencyPairFormat != nil {
+		return *p.RequestCurrencyPairFormat
+	}
+	return e.RequestCurrencyPairFormat
+}
+
+// GetEnabledPairs returns the enabled currency pairs of an asset type. Asset
+// types without their own pairs use the spot pairs.
+func (e *Base) GetEnabledPairs(assetType string) []pair.CurrencyPair {
+	p := e.assetPairs(assetType)
+	if p == nil {
+		return e.GetEnabledCurrencies()
+	}
+	format := e.GetConfigPairFormat(assetType)
+	return pair.FormatPairs(p.EnabledPairs, format.Delimiter, format.Index)
+}
+
+// GetAvailablePairs returns the available currency pairs of an asset type.
+// Asset types without their own pairs use the spot pairs.
+func (e *Base) GetAvailablePairs(assetType string) []pair.CurrencyPair {
+	p := e.assetPairs(assetType)
+	if p == nil {
+		return e.GetAvailableCurrencies()
+	}
+	format := e.GetConfigPairFormat(assetType)
+	return pair.FormatPairs(p.AvailablePairs, format.Delimiter, format.Index)
+}
+
+// UpdateAssetCurrencies updates the enabled or available currency pairs of an
+// asset type, spot pairs are updated by UpdateCurrencies
+func (e *Base) UpdateAssetCurrencies(assetType string, exchangeProducts []string, enabled, force bool) error {
+	if assetType == assets.Spot {
+		return e.UpdateCurrencies(exchangeProducts, enabled, force)
+	}
+	p := e.assetPairs(assetType)
+	if p == nil {
+		return fmt.Errorf("%s UpdateAssetCurrencies error - asset type %s has no currency pairs",
+			e.Name, assetType)
+	}
+	if len(exchangeProducts) == 0 {
+		return fmt.Errorf("%s UpdateAssetCurrencies error - exchangeProducts is empty", e.Name)
+	}
+
+	products := normaliseProducts(exchangeProducts)
+	var newPairs, removedPairs []string
+	updateType := "available"
+	if enabled {
+		newPairs, removedPairs = pair.FindPairDifferences(p.EnabledPairs, products)
+		updateType = "enabled"
+	} else {
+		newPairs, removedPairs = pair.FindPairDifferences(p.AvailablePairs, products)
+	}
+	if !force && len(newPairs) == 0 && len(removedPairs) == 0 {
+		return nil
+	}
+
+	cfg := config.GetConfig()
+	exch, err := cfg.GetExchangeConfig(e.Name)
+	if err != nil {
+		return err
+	}
+
+	if force {
+		log.Printf("%s forced update of %s %s pairs.", e.Name, assetType, updateType)
+	} else {
+		if len(newPairs) > 0 {
+			log.Printf("%s Updating %s pairs - New: %s.\n", e.Name, assetType, newPairs)
+		}
+		if len(removedPairs) > 0 {
+			log.Printf("%s Updating %s pairs - Removed: %s.\n", e.Name, assetType, removedPairs)
+		}
+	}
+
+	if enabled {
+		p.EnabledPairs = products
+	} else {
+		p.AvailablePairs = products
+	}
+	if exch.AssetPairs == nil {
+		exch.AssetPairs = make(map[string]*config.AssetPairsConfig)
+	}
+	exch.AssetPairs[assetType] = assetPairsConfig(p)
+	return cfg.UpdateExchangeConfig(exch)
+}
+
+// loadAssetPairs loads the currency pairs of each first class asset type the
+// exchange trades from its config, and returns whether the config was
+// updated. Asset types missing from configs written before they had their own
+// pairs start with the spot pairs, which they previously used.
+func (e *Base) loadAssetPairs(exch *config.ExchangeConfig) bool {
+	var update bool
+	for _, a := range e.AssetTypes {
+		if a == assets.Spot || !assets.IsSupported(a) {
+			continue
+		}
+		if e.AssetPairs == nil {
+			e.AssetPairs = make(map[string]*AssetPairs)
+		}
+		p, ok := e.AssetPairs[a]
+		if !ok {
+			p = &AssetPairs{}
+			e.AssetPairs[a] = p
+		}
+		if exch.AssetPairs == nil {
+			exch.AssetPairs = make(map[string]*config.AssetPairsConfig)
+		}
+
+		c, ok := exch.AssetPairs[a]
+		if !ok {
+			if len(p.AvailablePairs) == 0 {
+				p.AvailablePairs = append([]string(nil), e.AvailablePairs...)
+				p.EnabledPairs = append([]string(nil), e.EnabledPairs...)
+			}
+			exch.AssetPairs[a] = assetPairsConfig(p)
+			update = true
+			continue
+		}
+
+		if c.AvailablePairs != "" {
+			p.AvailablePairs = common.SplitStrings(c.AvailablePairs, ",")
+		}
+		if c.EnabledPairs != "" {
+			p.EnabledPairs = common.SplitStrings(c.EnabledPairs, ",")
+		}
+		if loadPairFormat(&p.ConfigCurrencyPairFormat, &c.ConfigCurrencyPairFormat) {
+			update = true
+		}
+		if loadPairFormat(&p.RequestCurrencyPairFormat, &c.RequestCurrencyPairFormat) {
+			update = true
+		}
+	}
+	return update
+}
+
+// loadPairFormat sets a configured pair format the same way as
+// SetCurrencyPairFormat. Configs without the format take the exchange's
+// default and differing configs are reset to it, configured formats are used
+// by asset types without a default. Returns whether the config was updated.
+func loadPairFormat(exchFormat, cfgFormat **config.CurrencyPairFormatConfig) bool {
+	switch {
+	case *exchFormat == nil:
+		if *cfgFormat != nil {
+			f := **cfgFormat
+			*exchFormat = &f
+		}
+		return false
+	case *cfgFormat == nil || !CompareCurrencyPairFormats(**exchFormat, *cfgFormat):
+		f := **exchFormat
+		*cfgFormat = &f
+		return true
+	}
+	return false
+}
+
+// assetPairsConfig returns the config of an asset type's currency pairs
+func assetPairsConfig(p *AssetPairs) *config.AssetPairsConfig {
+	c := &config.AssetPairsConfig{
+		AvailablePairs: common.JoinStrings(p.AvailablePairs, ","),
+		EnabledPairs:   common.JoinStrings(p.EnabledPairs, ","),
+	}
+	if p.ConfigCurrencyPairFormat != nil {
+		f := *p.ConfigCurrencyPairFormat
+		c.ConfigCurrencyPairFormat = &f
+	}
+	if p.RequestCurrencyPairFormat != nil {
+		f := *p.RequestCurrencyPairFormat
+		c.RequestCurrencyPairFormat = &f
+	}
+	return c
+}
@@ -0,0 +1,75 @@
+package exchange
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BrokerTagFormat describes the broker or affiliate tags an exchange sends.
+// Exchanges which leave it unset do not send a broker tag.
+type BrokerTagFormat struct {
+	// Prefix is required at the start of the tag, such as Binance's x-
+	Prefix string
+	// MaxLength is the maximum tag length including the prefix, tagged client
+	// order IDs must leave room for a generated ID
+	MaxLength int
+}
+
+// SupportsBrokerTag returns whether the exchange sends a broker tag
+func (e *Base) SupportsBrokerTag() bool {
+	return e.BrokerTagFormat.MaxLength > 0
+}
+
+// SetBrokerTag sets the broker or affiliate tag attributed to submitted orders.
+// Exchanges with a dedicated broker field send the tag in it, others prefix
+// client order IDs with it. The tag is validated against the exchange's
+// BrokerTagFormat and an empty tag clears it.
+func (e *Base) SetBrokerTag(tag string) error {
+	if tag == "" {
+		e.BrokerTag = ""
+		return nil
+	}
+	if !e.SupportsBrokerTag() {
+		return fmt.Errorf("%s does not support broker tags", e.Name)
+	}
+	format := e.BrokerTagFormat
+	if len(tag) > format.MaxLength {
+		return fmt.Errorf("%s broker tag %s exceeds %d characters",
+			e.Name, tag, format.MaxLength)
+	}
+	if !strings.HasPrefix(tag, format.Prefix) {
+		return fmt.Errorf("%s broker tag %s must start with %s",
+			e.Name, tag, format.Prefix)
+	}
+	id := strings.TrimPrefix(tag, format.Prefix)
+	if id == "" {
+		return fmt.Errorf("%s broker tag %s has no ID after its prefix", e.Name, tag)
+	}
+	for _, r := range id {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return fmt.Errorf("%s broker tag %s must be alphanumeric", e.Name, tag)
+		}
+	}
+	e.BrokerTag = tag
+	return nil
+}
+
+// GetBrokerTag returns the broker or affiliate tag of the exchange
+func (e *Base) GetBrokerTag() string {
+	return e.BrokerTag
+}
+
+// BrokerClientOrderID prefixes a client order ID with the broker tag, an ID is
+// generated when none is supplied. Without a broker tag the client order ID is
+// returned unchanged.
+func (e *Base) BrokerClientOrderID(clientID string) string {
+	if e.BrokerTag == "" || strings.HasPrefix(clientID, e.BrokerTag) {
+		return clientID
+	}
+	if clientID == "" {
+		clientID = strconv.FormatInt(time.Now().UnixNano(), 10)
+	}
+	return e.BrokerTag + clientID
+}
@@ -0,0 +1,118 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+// ErrNotCancellable is returned when placing or cancelling an order with a
+// cancellable context on an exchange whose requests cannot be cancelled once
+// sent
+var ErrNotCancellable = errors.New("exchange requests are not cancellable")
+
+// ContextExchange is implemented by exchanges whose wrapper requests are
+// cancelled with the context, through the Requester's SendPayloadContext.
+// Binance, Bybit, Coinbase, KuCoin, MEXC and OKX implement it, the legacy
+// exchange wrappers do not.
+type ContextExchange interface {
+	UpdateTickerContext(ctx context.Context, p pair.CurrencyPair, assetType string) (ticker.Price, error)
+	UpdateOrderbookContext(ctx context.Context, p pair.CurrencyPair, assetType string) (orderbook.Base, error)
+	GetAccountInfoContext(ctx context.Context) (AccountInfo, error)
+}
+
+// OrderContextExchange is implemented by exchanges whose order placement and
+// cancellation requests are cancelled with the context
+type OrderContextExchange interface {
+	SubmitOrderContext(ctx context.Context, p pair.CurrencyPair, side OrderSide, orderType OrderType, amount, price float64, clientID string) (SubmitOrderResponse, error)
+	CancelOrderContext(ctx context.Context, order OrderCancellation) error
+}
+
+// Cancellable returns whether the order requests of an exchange are cancelled
+// with their context
+func Cancellable(exch IBotExchange) bool {
+	_, ok := exch.(OrderContextExchange)
+	return ok
+}
+
+// UpdateTickerContext updates the ticker of an exchange, cancelled with the
+// context. Exchanges which do not implement ContextExchange are only checked
+// before the request is sent, which then runs to completion.
+func UpdateTickerContext(ctx context.Context, exch IBotExchange, p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	if c, ok := exch.(ContextExchange); ok {
+		return c.UpdateTickerContext(ctx, p, assetType)
+	}
+	if err := ctx.Err(); err != nil {
+		return ticker.Price{}, err
+	}
+	return exch.UpdateTicker(p, assetType)
+}
+
+// UpdateOrderbookContext updates the orderbook of an exchange, cancelled with
+// the context. Exchanges which do not implement ContextExchange are only
+// checked before the request is sent, which then runs to completion.
+func UpdateOrderbookContext(ctx context.Context, exch IBotExchange, p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	if c, ok := exch.(ContextExchange); ok {
+		return c.UpdateOrderbookContext(ctx, p, assetType)
+	}
+	if err := ctx.Err(); err != nil {
+		return orderbook.Base{}, err
+	}
+	return exch.UpdateOrderbook(p, assetType)
+}
+
+// GetAccountInfoContext returns the account balances of an exchange, cancelled
+// with the context. Exchanges which do not implement ContextExchange are only
+// checked before the request is sent, which then runs to completion.
+func GetAccountInfoContext(ctx context.Context, exch IBotExchange) (AccountInfo, error) {
+	if c, ok := exch.(ContextExchange); ok {
+		return c.GetAccountInfoContext(ctx)
+	}
+	if err := ctx.Err(); err != nil {
+		return AccountInfo{}, err
+	}
+	return exch.GetAccountInfo()
+}
+
+// SubmitOrderContext submits an order, cancelled with the context. Exchanges
+// which do not implement OrderContextExchange return ErrNotCancellable for a
+// cancellable context, callers which accept the submission running to
+// completion pass context.Background(). The order's latency is measured from
+// submission.
+func SubmitOrderContext(ctx context.Context, exch IBotExchange, p pair.CurrencyPair, side OrderSide, orderType OrderType, amount, price float64, clientID string) (SubmitOrderResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return SubmitOrderResponse{}, err
+	}
+	c, ok := exch.(OrderContextExchange)
+	if !ok && ctx.Done() != nil {
+		return SubmitOrderResponse{}, ErrNotCancellable
+	}
+	if err := CheckOrderSubmission(exch.GetName()); err != nil {
+		return SubmitOrderResponse{}, err
+	}
+	return measureSubmission(exch, clientID, func() (SubmitOrderResponse, error) {
+		if ok {
+			return c.SubmitOrderContext(ctx, p, side, orderType, amount, price, clientID)
+		}
+		return exch.SubmitOrder(p, side, orderType, amount, price, clientID)
+	})
+}
+
+// CancelOrderContext cancels an order, cancelled with the context. Exchanges
+// which do not implement OrderContextExchange return ErrNotCancellable for a
+// cancellable context.
+func CancelOrderContext(ctx context.Context, exch IBotExchange, order OrderCancellation) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if c, ok := exch.(OrderContextExchange); ok {
+		return c.CancelOrderContext(ctx, order)
+	}
+	if ctx.Done() != nil {
+		return ErrNotCancellable
+	}
+	return exch.CancelOrder(order)
+}
@@ -0,0 +1,40 @@
+package exchange
+
+import "strings"
+
+// DustConversion is the outcome of converting dust balances to a single
+// currency, Amount of Target was received after Fee
+type DustConversion struct {
+	Target    string
+	Converted []string
+	Amount    float64
+	Fee       float64
+}
+
+// DustConverter is implemented by exchanges with an endpoint converting small
+// balances, which are below the minimum order amounts, to a single currency
+type DustConverter interface {
+	ConvertDust(currencies []string) (DustConversion, error)
+}
+
+// DustThreshold returns the smallest balance of a currency which can be sold
+// on one of the enabled pairs it is the base currency of. A pair's smallest
+// order is the larger of its minimum order amount and step size, zero is
+// returned when neither is known for any pair.
+func (e *Base) DustThreshold(currency string) float64 {
+	var threshold float64
+	for _, p := range e.GetEnabledCurrencies() {
+		if !strings.EqualFold(p.FirstCurrency.String(), currency) {
+			continue
+		}
+		r := e.GetPairRounding(p)
+		minimum := r.MinAmount
+		if r.StepSize > minimum {
+			minimum = r.StepSize
+		}
+		if minimum > 0 && (threshold == 0 || minimum < threshold) {
+			threshold = minimum
+		}
+	}
+	return threshold
+}
@@ -0,0 +1,210 @@
+package exchange
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/thrasher-/gocryptotrader/exchanges/assets"
+)
+
+// Capability names a wrapper method or websocket stream of the capability
+// matrix, it is the Features field name
+type Capability string
+
+// Features is an exchange's capability matrix. Each flag reports whether the
+// exchange implements a wrapper method or websocket stream, so callers can
+// skip unsupported endpoints instead of handling ErrNotYetImplemented or
+// ErrFunctionNotSupported at runtime. Orders declares the order types, time
+// in force options and trigger types accepted per asset type, so submissions
+// can be rejected with a precise error before reaching the exchange.
+// IdempotentClientID reports that the exchange rejects or returns the
+// existing order for a submission repeating the client ID of a placed order,
+// so an order with a client ID can be resubmitted when the outcome of a
+// submission is unknown. Exchanges set their features in SetDefaults.
+type Features struct {
+	CanGetTicker          bool `json:"canGetTicker"`
+	CanGetOrderbook       bool `json:"canGetOrderbook"`
+	CanGetTradeHistory    bool `json:"canGetTradeHistory"`
+	CanGetHistoricCandles bool `json:"canGetHistoricCandles"`
+	CanGetAccountInfo     bool `json:"canGetAccountInfo"`
+	CanGetFundingHistory  bool `json:"canGetFundingHistory"`
+	CanSubmitOrder        bool `json:"canSubmitOrder"`
+	CanModifyOrder        bool `json:"canModifyOrder"`
+	CanCancelOrder        bool `json:"canCancelOrder"`
+	CanCancelAllOrders    bool `json:"canCancelAllOrders"`
+	CanGetOrderInfo       bool `json:"canGetOrderInfo"`
+	CanGetActiveOrders    bool `json:"canGetActiveOrders"`
+	CanGetOrderHistory    bool `json:"canGetOrderHistory"`
+	CanGetDepositAddress  bool `json:"canGetDepositAddress"`
+	CanWithdrawCrypto     bool `json:"canWithdrawCrypto"`
+	CanWithdrawFiat       bool `json:"canWithdrawFiat"`
+	CanStreamTicker       bool `json:"canStreamTicker"`
+	CanStreamOrderbook    bool `json:"canStreamOrderbook"`
+	CanStreamTrades       bool `json:"canStreamTrades"`
+	IdempotentClientID    bool `json:"idempotentClientID"`
+
+	Orders map[string]OrderCapabilities `json:"orders,omitempty"`
+}
+
+// TimeInForce is how long an order rests on the orderbook
+type TimeInForce string
+
+// Time in force options, orders without one are good till cancelled
+const (
+	GTC      TimeInForce = "GTC"
+	IOC      TimeInForce = "IOC"
+	FOK      TimeInForce = "FOK"
+	PostOnly TimeInForce = "POST_ONLY"
+)
+
+// TriggerType is the condition on which a conditional order is placed
+type TriggerType string
+
+// Trigger types
+const (
+	StopLoss     TriggerType = "STOP_LOSS"
+	TakeProfit   TriggerType = "TAKE_PROFIT"
+	TrailingStop TriggerType = "TRAILING_STOP"
+)
+
+// OrderCapabilities are the orders an exchange accepts for an asset type
+type OrderCapabilities struct {
+	OrderTypes   []OrderType   `json:"orderTypes"`
+	TimeInForce  []TimeInForce `json:"timeInForce,omitempty"`
+	TriggerTypes []TriggerType `json:"triggerTypes,omitempty"`
+}
+
+// OrderCapabilityError is returned when an order uses an asset type, order
+// type, time in force or trigger type the exchange does not support
+type OrderCapabilityError struct {
+	Exchange  string
+	AssetType string
+	Field     string
+	Value     string
+	Supported []string
+}
+
+// Error implements the error interface
+func (e *OrderCapabilityError) Error() string {
+	if e.Field == "asset type" {
+		return fmt.Sprintf("%s does not support %s orders", e.Exchange, e.AssetType)
+	}
+	supported := "none"
+	if len(e.Supported) > 0 {
+		supported = strings.Join(e.Supported, ", ")
+	}
+	return fmt.Sprintf("%s does not support %s %s for %s orders, supported: %s",
+		e.Exchange, e.Field, e.Value, e.AssetType, supported)
+}
+
+// simulatedOrders are the orders the paper trading engine accepts
+var simulatedOrders = map[string]OrderCapabilities{
+	assets.Spot: {
+		OrderTypes:  []OrderType{Limit, Market, ImmediateOrCancel},
+		TimeInForce: []TimeInForce{GTC, IOC},
+	},
+}
+
+// Supports returns whether a capability is supported, unknown capabilities
+// are unsupported
+func (f Features) Supports(c Capability) bool {
+	v := reflect.ValueOf(f).FieldByName(string(c))
+	return v.IsValid() && v.Kind() == reflect.Bool && v.Bool()
+}
+
+// Capabilities returns the names of the supported capabilities in the order of
+// the matrix
+func (f Features) Capabilities() []Capability {
+	var caps []Capability
+	v := reflect.ValueOf(f)
+	for i := 0; i < v.NumField(); i++ {
+		if v.Field(i).Kind() == reflect.Bool && v.Field(i).Bool() {
+			caps = append(caps, Capability(v.Type().Field(i).Name))
+		}
+	}
+	return caps
+}
+
+// GetCapabilities returns the exchange's capability matrix. Simulated
+// exchanges place, amend and cancel orders on the paper trading engine, so
+// order management is supported whatever the live exchange implements.
+func (e *Base) GetCapabilities() Features {
+	f := e.Features
+	if e.IsSimulated() {
+		f.CanSubmitOrder = true
+		f.CanModifyOrder = true
+		f.CanCancelOrder = true
+		f.CanCancelAllOrders = true
+		f.Orders = simulatedOrders
+	}
+	return f
+}
+
+// ValidateOrder checks an order against the declared order capabilities of
+// its asset type, returning an OrderCapabilityError naming the unsupported
+// field. Exchanges which declare no order capabilities support no orders.
+func (f Features) ValidateOrder(exchName, assetType string, o OrderSubmission) error {
+	c, ok := f.Orders[assetType]
+	if !ok {
+		return &OrderCapabilityError{Exchange: exchName, AssetType: assetType, Field: "asset type"}
+	}
+
+	if !supportsOrderType(c.OrderTypes, o.Type) {
+		var supported []string
+		for _, t := range c.OrderTypes {
+			supported = append(supported, string(t))
+		}
+		return &OrderCapabilityError{Exchange: exchName, AssetType: assetType,
+			Field: "order type", Value: string(o.Type), Supported: supported}
+	}
+
+	if o.TimeInForce != "" && !supportsTimeInForce(c.TimeInForce, o.TimeInForce) {
+		var supported []string
+		for _, t := range c.TimeInForce {
+			supported = append(supported, string(t))
+		}
+		return &OrderCapabilityError{Exchange: exchName, AssetType: assetType,
+			Field: "time in force", Value: string(o.TimeInForce), Supported: supported}
+	}
+
+	if o.Trigger != "" && !supportsTrigger(c.TriggerTypes, o.Trigger) {
+		var supported []string
+		for _, t := range c.TriggerTypes {
+			supported = append(supported, string(t))
+		}
+		return &OrderCapabilityError{Exchange: exchName, AssetType: assetType,
+			Field: "trigger type", Value: string(o.Trigger), Supported: supported}
+	}
+	return nil
+}
+
+// supportsOrderType returns whether an order type is in a declaration
+func supportsOrderType(declared []OrderType, t OrderType) bool {
+	for i := range declared {
+		if declared[i] == t {
+			return true
+		}
+	}
+	return false
+}
+
+// supportsTimeInForce returns whether a time in force is in a declaration
+func supportsTimeInForce(declared []TimeInForce, t TimeInForce) bool {
+	for i := range declared {
+		if declared[i] == t {
+			return true
+		}
+	}
+	return false
+}
+
+// supportsTrigger returns whether a trigger type is in a declaration
+func supportsTrigger(declared []TriggerType, t TriggerType) bool {
+	for i := range declared {
+		if declared[i] == t {
+			return true
+		}
+	}
+	return false
+}
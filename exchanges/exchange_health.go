@@ -0,0 +1,70 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrOrderSubmissionPaused is returned when submitting an order to an exchange
+// whose order submission is paused, such as while it is degraded
+var ErrOrderSubmissionPaused = errors.New("order submission paused")
+
+// SystemStatusChecker is implemented by exchanges with a system status or ping
+// endpoint. CheckSystemStatus returns an error when the endpoint cannot be
+// reached or reports the exchange is not operational, such as during
+// maintenance.
+type SystemStatusChecker interface {
+	CheckSystemStatus() error
+}
+
+// CheckSystemStatusContext checks the system status of an exchange unless the
+// context is already done. The request runs to completion once sent, callers
+// compare its latency against their deadline.
+func CheckSystemStatusContext(ctx context.Context, s SystemStatusChecker) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return s.CheckSystemStatus()
+}
+
+var (
+	pausedSubmissions  = make(map[string]string)
+	pausedSubmissionsM sync.RWMutex
+)
+
+// PauseOrderSubmission rejects the orders submitted to an exchange through
+// SubmitOrderRequest, SubmitOrderContext and the orders package until
+// ResumeOrderSubmission is called, reason is included in the error returned
+func PauseOrderSubmission(exchName, reason string) {
+	pausedSubmissionsM.Lock()
+	pausedSubmissions[exchName] = reason
+	pausedSubmissionsM.Unlock()
+}
+
+// ResumeOrderSubmission resumes accepting the orders submitted to an exchange
+func ResumeOrderSubmission(exchName string) {
+	pausedSubmissionsM.Lock()
+	delete(pausedSubmissions, exchName)
+	pausedSubmissionsM.Unlock()
+}
+
+// OrderSubmissionPaused returns whether order submission to an exchange is
+// paused and the reason it was paused
+func OrderSubmissionPaused(exchName string) (string, bool) {
+	pausedSubmissionsM.RLock()
+	defer pausedSubmissionsM.RUnlock()
+	reason, ok := pausedSubmissions[exchName]
+	return reason, ok
+}
+
+// CheckOrderSubmission returns an error wrapping ErrOrderSubmissionPaused when
+// order submission to an exchange is paused
+func CheckOrderSubmission(exchName string) error {
+	reason, ok := OrderSubmissionPaused(exchName)
+	if !ok {
+		return nil
+	}
+	return fmt.Errorf("%s %s: %s", exchName, ErrOrderSubmissionPaused, reason)
+}
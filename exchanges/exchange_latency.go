@@ -0,0 +1,33 @@
+package exchange
+
+import (
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/exchanges/latency"
+)
+
+// submissionTimer is implemented by exchanges through Base and its embedded
+// request.Requester
+type submissionTimer interface {
+	LastAuthRequestSent() time.Time
+	UseWebsocketOrders() bool
+}
+
+// measureSubmission runs an order submission and records its latency in the
+// latency package's Default tracker. The sent stage is taken from the
+// exchange's most recent authenticated request within the submission, which
+// is not measured for websocket submissions.
+func measureSubmission(exch IBotExchange, clientID string, submit func() (SubmitOrderResponse, error)) (SubmitOrderResponse, error) {
+	m := latency.Start(exch.GetName(), clientID)
+	resp, err := submit()
+	if err != nil || !resp.IsOrderPlaced {
+		return resp, err
+	}
+
+	if t, ok := exch.(submissionTimer); ok && !t.UseWebsocketOrders() {
+		m.MarkSent(t.LastAuthRequestSent())
+	}
+	m.MarkAcked(resp.OrderID)
+	latency.Default.Acknowledged(m)
+	return resp, nil
+}
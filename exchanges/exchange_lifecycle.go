@@ -0,0 +1,122 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Wrapper lifecycle errors
+var (
+	ErrWrapperAlreadyStarted = errors.New("exchange wrapper already started")
+	ErrWrapperNotStarted     = errors.New("exchange wrapper not started")
+)
+
+// lifecycle tracks whether an exchange wrapper has started and its startup in
+// progress, if any
+type lifecycle struct {
+	started bool
+	cancel  context.CancelFunc
+	done    chan struct{}
+	m       sync.Mutex
+}
+
+// StartWrapper implements a wrapper's Start. run is the wrapper's startup,
+// which refreshes its currency pairs from the exchange and is cancelled with
+// its context. Start fails when the wrapper is already started, when run
+// fails, when ctx is done before the startup finishes or when the exchange is
+// left without enabled currency pairs, the wrapper is left stopped so it can
+// be started again. A startup abandoned when ctx is done is cancelled and
+// waited for before the next startup runs, the lock is not held while waiting
+// so Stop and IsStarted do not block on a startup.
+func (e *Base) StartWrapper(ctx context.Context, run func(context.Context) error) error {
+	e.lifecycle.m.Lock()
+	for e.lifecycle.done != nil {
+		previous := e.lifecycle.done
+		e.lifecycle.m.Unlock()
+		select {
+		case <-previous:
+		case <-ctx.Done():
+			return fmt.Errorf("%s startup: previous startup still running: %s", e.Name, ctx.Err())
+		}
+		e.lifecycle.m.Lock()
+	}
+	if e.lifecycle.started {
+		e.lifecycle.m.Unlock()
+		return ErrWrapperAlreadyStarted
+	}
+
+	attempt, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	e.lifecycle.cancel = cancel
+	e.lifecycle.done = done
+	e.lifecycle.m.Unlock()
+
+	var err error
+	go func() {
+		defer close(done)
+		runErr := run(attempt)
+
+		e.lifecycle.m.Lock()
+		defer e.lifecycle.m.Unlock()
+		e.lifecycle.cancel = nil
+		e.lifecycle.done = nil
+		switch {
+		case attempt.Err() != nil:
+			err = attempt.Err()
+		case runErr != nil:
+			err = runErr
+		case len(e.EnabledPairs) == 0:
+			err = errors.New("no enabled currency pairs")
+		default:
+			e.lifecycle.started = true
+		}
+		cancel()
+	}()
+
+	select {
+	case <-done:
+		if err != nil {
+			return fmt.Errorf("%s startup: %s", e.Name, err)
+		}
+		return nil
+	case <-ctx.Done():
+		e.lifecycle.m.Lock()
+		defer e.lifecycle.m.Unlock()
+		if e.lifecycle.started {
+			return nil
+		}
+		cancel()
+		return fmt.Errorf("%s startup: %s", e.Name, ctx.Err())
+	}
+}
+
+// Stop stops an exchange wrapper, shutting down its websocket when connected or
+// reconnecting. A startup in progress is cancelled.
+func (e *Base) Stop() error {
+	e.lifecycle.m.Lock()
+	defer e.lifecycle.m.Unlock()
+	if e.lifecycle.cancel != nil {
+		e.lifecycle.cancel()
+	}
+	if !e.lifecycle.started {
+		return ErrWrapperNotStarted
+	}
+	e.lifecycle.started = false
+
+	if e.Websocket == nil {
+		return nil
+	}
+	if err := e.Websocket.Shutdown(); err != nil && err != ErrWebsocketNotConnected {
+		return err
+	}
+	return nil
+}
+
+// IsStarted returns whether an exchange wrapper has started
+func (e *Base) IsStarted() bool {
+	e.lifecycle.m.Lock()
+	defer e.lifecycle.m.Unlock()
+	return e.lifecycle.started
+}
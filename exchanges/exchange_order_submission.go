@@ -0,0 +1,206 @@
+package exchange
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	"github.com/thrasher-/gocryptotrader/exchanges/assets"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+// Errors returned when submitting an OrderSubmission
+var (
+	ErrOrderAmountRequired = errors.New("order requires exactly one of a base or quote amount greater than zero")
+	ErrOrderPriceRequired  = errors.New("order requires a price greater than zero")
+	ErrNoMarketPrice       = errors.New("no live price to convert the order amount")
+	ErrOrderTriggerPrice   = errors.New("conditional order requires a trigger price greater than zero")
+	ErrOrderNotCarried     = errors.New("wrapper cannot carry the order's asset type, time in force or trigger")
+	ErrOrderContractAmount = errors.New("derivatives orders are sized in contracts by their base amount")
+)
+
+// OrderSubmission is an order sized explicitly in either the base currency,
+// BaseAmount, or the quote currency notional to spend or receive,
+// QuoteAmount. Exactly one of the two is set. Price is ignored for market
+// orders. AssetType defaults to spot, orders for other asset types are sized
+// in contracts by BaseAmount. TimeInForce defaults to good till cancelled, and
+// conditional orders set a Trigger and its TriggerPrice.
+type OrderSubmission struct {
+	AssetType    string
+	Pair         pair.CurrencyPair
+	Side         OrderSide
+	Type         OrderType
+	BaseAmount   float64
+	QuoteAmount  float64
+	Price        float64
+	ClientID     string
+	TimeInForce  TimeInForce
+	Trigger      TriggerType
+	TriggerPrice float64
+}
+
+// Validate checks the order has exactly one amount, a price when it is not a
+// market order and a trigger price when it is conditional
+func (o *OrderSubmission) Validate() error {
+	if o.BaseAmount < 0 || o.QuoteAmount < 0 || (o.BaseAmount > 0) == (o.QuoteAmount > 0) {
+		return ErrOrderAmountRequired
+	}
+	if o.Type != Market && o.Price <= 0 {
+		return ErrOrderPriceRequired
+	}
+	if o.Trigger != "" && o.TriggerPrice <= 0 {
+		return ErrOrderTriggerPrice
+	}
+	return nil
+}
+
+// Asset returns the asset type of the order, spot when it is not set
+func (o *OrderSubmission) Asset() string {
+	if o.AssetType == "" {
+		return assets.Spot
+	}
+	return o.AssetType
+}
+
+// carriedBySubmitOrder returns whether SubmitOrder can carry the order, which
+// holds for good till cancelled spot orders without a trigger and immediate or
+// cancel limit orders when the exchange declares the ImmediateOrCancel order
+// type
+func (o *OrderSubmission) carriedBySubmitOrder(f Features) bool {
+	if o.Asset() != assets.Spot || o.Trigger != "" {
+		return false
+	}
+	switch o.TimeInForce {
+	case "", GTC:
+		return true
+	case IOC:
+		return o.Type == Limit && supportsOrderType(f.Orders[assets.Spot].OrderTypes, ImmediateOrCancel)
+	}
+	return false
+}
+
+// OrderSubmissionSubmitter is implemented by exchanges whose wrappers carry
+// the asset type, time in force and trigger of an order. The order is sized
+// in the base currency, BaseAmount, when it is submitted.
+type OrderSubmissionSubmitter interface {
+	SubmitOrderSubmission(o OrderSubmission) (SubmitOrderResponse, error)
+}
+
+// QuoteAmountOrderSubmitter is implemented by exchanges which can size a
+// market order by its quote currency notional natively
+type QuoteAmountOrderSubmitter interface {
+	SubmitQuoteAmountOrder(p pair.CurrencyPair, side OrderSide, quoteAmount float64, clientID string) (SubmitOrderResponse, error)
+}
+
+// MarketOrderInQuote returns whether SubmitOrder takes the amount of a market
+// order on the side in the quote currency rather than the base currency
+func (e *Base) MarketOrderInQuote(side OrderSide) bool {
+	return e.MarketBuyInQuote && side == Buy
+}
+
+// SubmitOrderRequest submits an order converting its amount to the form the
+// exchange requires. Market orders are converted with the live ticker price,
+// the ask for buys and the bid for sells, while other orders are converted
+// with their own price. Orders are checked against the exchange's declared
+// order capabilities, and orders SubmitOrder cannot carry are submitted
+// through OrderSubmissionSubmitter or rejected with ErrOrderNotCarried. The
+// order's latency is measured from submission.
+func SubmitOrderRequest(exch IBotExchange, o OrderSubmission) (SubmitOrderResponse, error) {
+	if err := o.Validate(); err != nil {
+		return SubmitOrderResponse{}, err
+	}
+	if err := exch.GetCapabilities().ValidateOrder(exch.GetName(), o.Asset(), o); err != nil {
+		return SubmitOrderResponse{}, err
+	}
+	if err := CheckOrderSubmission(exch.GetName()); err != nil {
+		return SubmitOrderResponse{}, err
+	}
+	return measureSubmission(exch, o.ClientID, func() (SubmitOrderResponse, error) {
+		return submitOrderRequest(exch, o)
+	})
+}
+
+// submitOrderRequest submits a validated order, immediate or cancel limit
+// orders are submitted as the ImmediateOrCancel order type
+func submitOrderRequest(exch IBotExchange, o OrderSubmission) (SubmitOrderResponse, error) {
+	if !o.carriedBySubmitOrder(exch.GetCapabilities()) {
+		return submitOrderSubmission(exch, o)
+	}
+	if o.Type == Limit && o.TimeInForce == IOC {
+		o.Type = ImmediateOrCancel
+	}
+	if o.Type != Market {
+		amount := o.BaseAmount
+		if o.QuoteAmount > 0 {
+			amount = o.QuoteAmount / o.Price
+		}
+		return exch.SubmitOrder(o.Pair, o.Side, o.Type, amount, o.Price, o.ClientID)
+	}
+
+	inQuote := exch.MarketOrderInQuote(o.Side)
+	if o.QuoteAmount > 0 {
+		if inQuote {
+			return exch.SubmitOrder(o.Pair, o.Side, Market, o.QuoteAmount, 0, o.ClientID)
+		}
+		if q, ok := exch.(QuoteAmountOrderSubmitter); ok && !isSimulated(exch) {
+			return q.SubmitQuoteAmountOrder(o.Pair, o.Side, o.QuoteAmount, o.ClientID)
+		}
+	} else if !inQuote {
+		return exch.SubmitOrder(o.Pair, o.Side, Market, o.BaseAmount, 0, o.ClientID)
+	}
+
+	price, err := MarketPrice(exch, o.Pair, o.Side)
+	if err != nil {
+		return SubmitOrderResponse{}, err
+	}
+	if o.QuoteAmount > 0 {
+		return exch.SubmitOrder(o.Pair, o.Side, Market, o.QuoteAmount/price, 0, o.ClientID)
+	}
+	return exch.SubmitOrder(o.Pair, o.Side, Market, o.BaseAmount*price, 0, o.ClientID)
+}
+
+// submitOrderSubmission submits an order SubmitOrder cannot carry through the
+// exchange's OrderSubmissionSubmitter, sized in the base currency
+func submitOrderSubmission(exch IBotExchange, o OrderSubmission) (SubmitOrderResponse, error) {
+	s, ok := exch.(OrderSubmissionSubmitter)
+	if !ok || isSimulated(exch) {
+		return SubmitOrderResponse{}, fmt.Errorf("%s %s %s", exch.GetName(), o.Asset(), ErrOrderNotCarried)
+	}
+	if o.QuoteAmount > 0 {
+		if o.Asset() != assets.Spot {
+			return SubmitOrderResponse{}, fmt.Errorf("%s %s", exch.GetName(), ErrOrderContractAmount)
+		}
+		price := o.Price
+		if o.Type == Market {
+			var err error
+			price, err = MarketPrice(exch, o.Pair, o.Side)
+			if err != nil {
+				return SubmitOrderResponse{}, err
+			}
+		}
+		o.BaseAmount = o.QuoteAmount / price
+		o.QuoteAmount = 0
+	}
+	return s.SubmitOrderSubmission(o)
+}
+
+// MarketPrice returns the live price a market order on the side is expected
+// to fill at, falling back to the last traded price when the book side is
+// not available
+func MarketPrice(exch IBotExchange, p pair.CurrencyPair, side OrderSide) (float64, error) {
+	t, err := exch.GetTickerPrice(p, ticker.Spot)
+	if err != nil {
+		return 0, err
+	}
+	price := t.Bid
+	if side == Buy {
+		price = t.Ask
+	}
+	if price <= 0 {
+		price = t.Last
+	}
+	if price <= 0 {
+		return 0, fmt.Errorf("%s %s %s", exch.GetName(), p.Pair(), ErrNoMarketPrice)
+	}
+	return price, nil
+}
@@ -0,0 +1,125 @@
+package exchange
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+)
+
+// Order transports which can be selected via the exchange config
+const (
+	OrderTransportREST      = "REST"
+	OrderTransportWebsocket = "WEBSOCKET"
+)
+
+// Errors returned by the order transport layer
+var (
+	// ErrWebsocketOrdersNotSupported is returned when websocket order
+	// placement is requested for an exchange which does not support it
+	ErrWebsocketOrdersNotSupported = errors.New("websocket order placement not supported")
+	// ErrWebsocketOrderNotSent should be returned by websocket order handlers
+	// when the request was not written to the connection, it is the only
+	// error which falls back to REST as any other failure may have reached the
+	// exchange and resubmitting could duplicate the order
+	ErrWebsocketOrderNotSent = errors.New("websocket order request not sent")
+)
+
+// SubmitOrderFunc submits an order over a single transport
+type SubmitOrderFunc func(p pair.CurrencyPair, side OrderSide, orderType OrderType, amount, price float64, clientID string) (SubmitOrderResponse, error)
+
+// CancelOrderFunc cancels an order over a single transport
+type CancelOrderFunc func(order OrderCancellation) error
+
+// orderTransport holds the selected transport and websocket order handlers
+type orderTransport struct {
+	transport string
+	wsSubmit  SubmitOrderFunc
+	wsCancel  CancelOrderFunc
+}
+
+// SetWebsocketOrderHandlers registers the exchange websocket order functions,
+// exchanges call this in SetDefaults when websocket order placement is
+// supported
+func (e *Base) SetWebsocketOrderHandlers(submit SubmitOrderFunc, cancel CancelOrderFunc) {
+	e.orderTransport.wsSubmit = submit
+	e.orderTransport.wsCancel = cancel
+}
+
+// SupportsWebsocketOrders returns whether the exchange can place orders via
+// websocket
+func (e *Base) SupportsWebsocketOrders() bool {
+	return e.orderTransport.wsSubmit != nil
+}
+
+// SetOrderTransport sets the configured order transport for an exchange,
+// REST is used when none is set
+func (e *Base) SetOrderTransport(ec config.ExchangeConfig) error {
+	transport := strings.ToUpper(ec.OrderTransport)
+	switch transport {
+	case "", OrderTransportREST:
+		e.orderTransport.transport = OrderTransportREST
+	case OrderTransportWebsocket:
+		if !e.SupportsWebsocketOrders() {
+			e.orderTransport.transport = OrderTransportREST
+			return fmt.Errorf("%s %s, defaulting to REST",
+				e.Name,
+				ErrWebsocketOrdersNotSupported)
+		}
+		e.orderTransport.transport = OrderTransportWebsocket
+	default:
+		e.orderTransport.transport = OrderTransportREST
+		return fmt.Errorf("%s invalid order transport %s, defaulting to REST",
+			e.Name,
+			ec.OrderTransport)
+	}
+	return nil
+}
+
+// GetOrderTransport returns the configured order transport
+func (e *Base) GetOrderTransport() string {
+	if e.orderTransport.transport == "" {
+		return OrderTransportREST
+	}
+	return e.orderTransport.transport
+}
+
+// UseWebsocketOrders returns whether orders should currently be routed via
+// websocket, this requires the transport to be configured and the websocket
+// to be enabled and connected
+func (e *Base) UseWebsocketOrders() bool {
+	return e.GetOrderTransport() == OrderTransportWebsocket &&
+		e.SupportsWebsocketOrders() &&
+		e.Websocket != nil &&
+		e.Websocket.IsEnabled() &&
+		e.Websocket.IsConnected()
+}
+
+// SubmitOrderViaTransport submits an order over websocket when selected and
+// available, falling back to the supplied REST function otherwise
+func (e *Base) SubmitOrderViaTransport(rest SubmitOrderFunc, p pair.CurrencyPair, side OrderSide, orderType OrderType, amount, price float64, clientID string) (SubmitOrderResponse, error) {
+	if e.UseWebsocketOrders() {
+		resp, err := e.orderTransport.wsSubmit(p, side, orderType, amount, price, clientID)
+		if err != ErrWebsocketOrderNotSent {
+			return resp, err
+		}
+		log.Printf("%s websocket order submission unavailable, falling back to REST", e.Name)
+	}
+	return rest(p, side, orderType, amount, price, clientID)
+}
+
+// CancelOrderViaTransport cancels an order over websocket when selected and
+// available, falling back to the supplied REST function otherwise
+func (e *Base) CancelOrderViaTransport(rest CancelOrderFunc, order OrderCancellation) error {
+	if e.UseWebsocketOrders() && e.orderTransport.wsCancel != nil {
+		err := e.orderTransport.wsCancel(order)
+		if err != ErrWebsocketOrderNotSent {
+			return err
+		}
+		log.Printf("%s websocket order cancellation unavailable, falling back to REST", e.Name)
+	}
+	return rest(order)
+}
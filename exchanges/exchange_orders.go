@@ -0,0 +1,75 @@
+package exchange
+
+import (
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+)
+
+// GetOrdersRequest filters the orders returned by GetActiveOrders and
+// GetOrderHistory. No currencies returns the orders of every enabled pair, an
+// empty OrderSide returns both sides and a zero StartTime or EndTime leaves
+// that end of the time range open.
+type GetOrdersRequest struct {
+	Currencies []pair.CurrencyPair
+	OrderSide  OrderSide
+	StartTime  time.Time
+	EndTime    time.Time
+}
+
+// GetActiveOrders returns the open orders matching the request, exchanges
+// which support order queries override it
+func (e *Base) GetActiveOrders(req GetOrdersRequest) ([]OrderDetail, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
+// GetOrderHistory returns the closed orders matching the request, exchanges
+// which support order queries override it
+func (e *Base) GetOrderHistory(req GetOrdersRequest) ([]OrderDetail, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
+// GetOrdersRequestPairs returns the request's currencies, or the enabled pairs
+// when none are requested
+func (e *Base) GetOrdersRequestPairs(req GetOrdersRequest) []pair.CurrencyPair {
+	if len(req.Currencies) > 0 {
+		return req.Currencies
+	}
+	return e.GetEnabledCurrencies()
+}
+
+// FilterOrders returns the orders matching the request's currencies, side and
+// creation time range, exchanges use it to filter what their API cannot
+func FilterOrders(orders []OrderDetail, req GetOrdersRequest) []OrderDetail {
+	var filtered []OrderDetail
+	for i := range orders {
+		if orderMatches(&orders[i], req) {
+			filtered = append(filtered, orders[i])
+		}
+	}
+	return filtered
+}
+
+// orderMatches returns whether an order matches the request
+func orderMatches(o *OrderDetail, req GetOrdersRequest) bool {
+	if req.OrderSide != "" && common.StringToUpper(o.OrderSide) != common.StringToUpper(req.OrderSide.ToString()) {
+		return false
+	}
+	if !req.StartTime.IsZero() && o.CreationTime.Before(req.StartTime) {
+		return false
+	}
+	if !req.EndTime.IsZero() && o.CreationTime.After(req.EndTime) {
+		return false
+	}
+	if len(req.Currencies) == 0 {
+		return true
+	}
+	for _, p := range req.Currencies {
+		if p.FirstCurrency.Upper().String() == common.StringToUpper(o.BaseCurrency) &&
+			p.SecondCurrency.Upper().String() == common.StringToUpper(o.QuoteCurrency) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,145 @@
+package exchange
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/config"
+)
+
+// AccountTierDetector is implemented by exchanges which report the fee or VIP
+// tier of the account, used to apply the rate limits of the tier
+type AccountTierDetector interface {
+	DetectAccountTier() (string, error)
+}
+
+// accountTierSetter is implemented by every exchange embedding Base
+type accountTierSetter interface {
+	SetAccountTier(tier string) bool
+}
+
+// rateLimitTiers holds the configured rate limits of each account tier and the
+// exchange's default limits, which apply to tiers without configured limits
+type rateLimitTiers struct {
+	tiers          map[string]config.RateLimitConfig
+	tier           string
+	authInterval   time.Duration
+	authRate       int
+	authBurst      int
+	unauthInterval time.Duration
+	unauthRate     int
+	unauthBurst    int
+	m              sync.Mutex
+}
+
+// getRateLimitTiers returns the rate limit tiers, recording the requester's
+// limits as the defaults when created
+func (e *Base) getRateLimitTiers() *rateLimitTiers {
+	if e.rateLimits == nil {
+		e.rateLimits = &rateLimitTiers{tiers: make(map[string]config.RateLimitConfig)}
+		if e.Requester != nil {
+			auth := e.Requester.GetRateLimit(true)
+			unauth := e.Requester.GetRateLimit(false)
+			e.rateLimits.authInterval = auth.GetDuration()
+			e.rateLimits.authRate = auth.GetRate()
+			e.rateLimits.authBurst = auth.GetBurst()
+			e.rateLimits.unauthInterval = unauth.GetDuration()
+			e.rateLimits.unauthRate = unauth.GetRate()
+			e.rateLimits.unauthBurst = unauth.GetBurst()
+		}
+	}
+	return e.rateLimits
+}
+
+// SetRateLimitTiers sets the rate limits of each account tier from the
+// exchange config and applies the limits of the configured account tier
+func (e *Base) SetRateLimitTiers(tiers map[string]config.RateLimitConfig, tier string) error {
+	for name, cfg := range tiers {
+		if cfg.Interval < 0 || cfg.AuthRate < 0 || cfg.UnauthRate < 0 || cfg.Burst < 0 {
+			return fmt.Errorf("%s rate limit tier %s has negative limits", e.Name, name)
+		}
+	}
+
+	t := e.getRateLimitTiers()
+	t.m.Lock()
+	defer t.m.Unlock()
+	t.tiers = make(map[string]config.RateLimitConfig, len(tiers))
+	for name, cfg := range tiers {
+		t.tiers[strings.ToLower(name)] = cfg
+	}
+	e.applyAccountTier(t, tier)
+	return nil
+}
+
+// SetAccountTier applies the rate limits of an account tier, tiers without
+// configured limits use the exchange's defaults. Returns whether the tier
+// changed.
+func (e *Base) SetAccountTier(tier string) bool {
+	t := e.getRateLimitTiers()
+	t.m.Lock()
+	defer t.m.Unlock()
+
+	if strings.EqualFold(t.tier, tier) {
+		return false
+	}
+	e.applyAccountTier(t, tier)
+	return true
+}
+
+// applyAccountTier sets the requester's rate limits to those of the tier. The
+// mutex must be held by the caller.
+func (e *Base) applyAccountTier(t *rateLimitTiers, tier string) {
+	t.tier = tier
+	authInterval, authRate, authBurst := t.authInterval, t.authRate, t.authBurst
+	unauthInterval, unauthRate, unauthBurst := t.unauthInterval, t.unauthRate, t.unauthBurst
+	if cfg, ok := t.tiers[strings.ToLower(tier)]; ok {
+		if cfg.Interval > 0 {
+			authInterval, unauthInterval = cfg.Interval, cfg.Interval
+		}
+		if cfg.AuthRate > 0 {
+			authRate = cfg.AuthRate
+		}
+		if cfg.UnauthRate > 0 {
+			unauthRate = cfg.UnauthRate
+		}
+		if cfg.Burst > 0 {
+			authBurst, unauthBurst = cfg.Burst, cfg.Burst
+		}
+	}
+
+	if e.Requester != nil {
+		e.Requester.SetRateLimit(true, authInterval, authRate)
+		e.Requester.GetRateLimit(true).SetBurst(authBurst)
+		e.Requester.SetRateLimit(false, unauthInterval, unauthRate)
+		e.Requester.GetRateLimit(false).SetBurst(unauthBurst)
+	}
+}
+
+// GetAccountTier returns the account tier whose rate limits are applied
+func (e *Base) GetAccountTier() string {
+	t := e.getRateLimitTiers()
+	t.m.Lock()
+	defer t.m.Unlock()
+	return t.tier
+}
+
+// UpdateAccountTier detects the account tier of an exchange and applies its
+// rate limits, returning the tier and whether it changed
+func UpdateAccountTier(exch IBotExchange) (string, bool, error) {
+	detector, ok := exch.(AccountTierDetector)
+	if !ok {
+		return "", false, fmt.Errorf("%s does not report its account tier", exch.GetName())
+	}
+	setter, ok := exch.(accountTierSetter)
+	if !ok {
+		return "", false, fmt.Errorf("%s does not support rate limit tiers", exch.GetName())
+	}
+
+	tier, err := detector.DetectAccountTier()
+	if err != nil {
+		return "", false, err
+	}
+	return tier, setter.SetAccountTier(tier), nil
+}
@@ -0,0 +1,171 @@
+package exchange
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+)
+
+// DefaultRoundingKey is the rounding modes config key applied to currency
+// pairs without their own entry
+const DefaultRoundingKey = "default"
+
+// Default rounding modes, prices round away from the market so orders never
+// cross the spread by rounding and amounts round down so orders never exceed
+// the available balance
+const (
+	DefaultPriceRounding  = common.RoundBySide
+	DefaultAmountRounding = common.RoundDown
+)
+
+// PairRounding holds a currency pair's price tick size, amount step size,
+// minimum order amount and the rounding modes used to snap order prices and
+// amounts to them
+type PairRounding struct {
+	TickSize   float64
+	StepSize   float64
+	MinAmount  float64
+	PriceMode  common.RoundingMode
+	AmountMode common.RoundingMode
+}
+
+// pairRounding holds the rounding of each currency pair
+type pairRounding struct {
+	pairs    map[string]PairRounding
+	defaults PairRounding
+	m        sync.RWMutex
+}
+
+// roundingKey returns the currency pair without a delimiter in upper case
+func roundingKey(p string) string {
+	return strings.NewReplacer("-", "", "_", "", "/", "").Replace(common.StringToUpper(p))
+}
+
+// getRounding returns the pair rounding store, creating it if required
+func (e *Base) getRounding() *pairRounding {
+	if e.rounding == nil {
+		e.rounding = &pairRounding{
+			pairs: make(map[string]PairRounding),
+			defaults: PairRounding{
+				PriceMode:  DefaultPriceRounding,
+				AmountMode: DefaultAmountRounding,
+			},
+		}
+	}
+	return e.rounding
+}
+
+// SetRoundingModes sets the rounding modes from the exchange config. Modes
+// are keyed by currency pair, the "default" key applies to every other pair.
+func (e *Base) SetRoundingModes(modes map[string]config.RoundingConfig) error {
+	r := e.getRounding()
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	for key, cfg := range modes {
+		var target PairRounding
+		if strings.EqualFold(key, DefaultRoundingKey) {
+			target = r.defaults
+		} else {
+			target = r.pairs[roundingKey(key)]
+		}
+
+		if cfg.Price != "" {
+			mode, err := common.ParseRoundingMode(cfg.Price)
+			if err != nil {
+				return fmt.Errorf("%s %s price %s", e.Name, key, err)
+			}
+			target.PriceMode = mode
+		}
+		if cfg.Amount != "" {
+			mode, err := common.ParseRoundingMode(cfg.Amount)
+			if err != nil {
+				return fmt.Errorf("%s %s amount %s", e.Name, key, err)
+			}
+			target.AmountMode = mode
+		}
+
+		if strings.EqualFold(key, DefaultRoundingKey) {
+			r.defaults = target
+			continue
+		}
+		r.pairs[roundingKey(key)] = target
+	}
+	return nil
+}
+
+// SetPairIncrements sets a currency pair's price tick size and amount step
+// size, typically from the exchange's instrument or symbol info
+func (e *Base) SetPairIncrements(p pair.CurrencyPair, tickSize, stepSize float64) {
+	r := e.getRounding()
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	key := roundingKey(p.FirstCurrency.String() + p.SecondCurrency.String())
+	target := r.pairs[key]
+	target.TickSize = tickSize
+	target.StepSize = stepSize
+	r.pairs[key] = target
+}
+
+// SetPairMinimumAmount sets the smallest base currency amount of an order of
+// a currency pair, typically from the exchange's instrument or symbol info
+func (e *Base) SetPairMinimumAmount(p pair.CurrencyPair, minAmount float64) {
+	r := e.getRounding()
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	key := roundingKey(p.FirstCurrency.String() + p.SecondCurrency.String())
+	target := r.pairs[key]
+	target.MinAmount = minAmount
+	r.pairs[key] = target
+}
+
+// GetPairRounding returns a currency pair's increments and rounding modes
+func (e *Base) GetPairRounding(p pair.CurrencyPair) PairRounding {
+	r := e.getRounding()
+	r.m.RLock()
+	defer r.m.RUnlock()
+	return r.pair(roundingKey(p.FirstCurrency.String() + p.SecondCurrency.String()))
+}
+
+// RoundOrder snaps an order price and amount to the currency pair's tick and
+// step sizes with its rounding modes. Values are returned unchanged when the
+// increments are not known.
+func (e *Base) RoundOrder(p pair.CurrencyPair, side OrderSide, price, amount float64) (float64, float64) {
+	rounding := e.GetPairRounding(p)
+	return common.RoundToIncrement(price, rounding.TickSize, sideRounding(rounding.PriceMode, side)),
+		common.RoundToIncrement(amount, rounding.StepSize, sideRounding(rounding.AmountMode, side))
+}
+
+// pair returns the rounding of a currency pair falling back to the default
+// modes. The mutex must be held by the caller.
+func (r *pairRounding) pair(key string) PairRounding {
+	rounding, ok := r.pairs[key]
+	if !ok {
+		return r.defaults
+	}
+	if rounding.PriceMode == "" {
+		rounding.PriceMode = r.defaults.PriceMode
+	}
+	if rounding.AmountMode == "" {
+		rounding.AmountMode = r.defaults.AmountMode
+	}
+	return rounding
+}
+
+// sideRounding resolves RoundBySide to rounding down for buys and up for
+// sells
+func sideRounding(mode common.RoundingMode, side OrderSide) common.RoundingMode {
+	if mode != common.RoundBySide {
+		return mode
+	}
+	if side == Buy {
+		return common.RoundDown
+	}
+	return common.RoundUp
+}
@@ -0,0 +1,190 @@
+package exchange
+
+import (
+	"log"
+	"strconv"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	"github.com/thrasher-/gocryptotrader/exchanges/paper"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+// SetSimulation enables paper trading for the exchange, its orders are then
+// routed to an in-memory matching engine funded with balances instead of the
+// exchange. Exchanges call this in Setup from the exchange config.
+func (e *Base) SetSimulation(enabled bool, balances map[string]float64) {
+	if !enabled {
+		e.simulator = nil
+		return
+	}
+	e.simulator = paper.NewEngine(e.Name, balances)
+	e.simulator.MakerFee = e.MakerFee
+	e.simulator.TakerFee = e.TakerFee
+	log.Printf("%s paper trading enabled, orders will be simulated", e.Name)
+}
+
+// simulatedExchange is implemented by exchanges through Base
+type simulatedExchange interface {
+	IsSimulated() bool
+}
+
+// isSimulated returns whether an exchange's orders are paper traded
+func isSimulated(exch IBotExchange) bool {
+	s, ok := exch.(simulatedExchange)
+	return ok && s.IsSimulated()
+}
+
+// IsSimulated returns whether the exchange's orders are paper traded
+func (e *Base) IsSimulated() bool {
+	return e.simulator != nil
+}
+
+// GetSimulator returns the exchange's paper trading engine, nil when the
+// exchange is not simulated
+func (e *Base) GetSimulator() *paper.Engine {
+	return e.simulator
+}
+
+// MatchSimulatedOrders fills resting paper orders against the latest stored
+// market data, the bot calls this after each ticker and orderbook update
+func (e *Base) MatchSimulatedOrders() {
+	if e.simulator != nil {
+		e.simulator.Match()
+	}
+}
+
+// SimulateSubmitOrder places a paper order, exchanges call this from
+// SubmitOrder when IsSimulated is true. Immediate or cancel orders have their
+// unfilled amount cancelled.
+func (e *Base) SimulateSubmitOrder(p pair.CurrencyPair, side OrderSide, orderType OrderType, amount, price float64, clientID string) (SubmitOrderResponse, error) {
+	var submitOrderResponse SubmitOrderResponse
+	buy := side == Buy
+	if orderType == Market && e.MarketOrderInQuote(side) {
+		// The amount of the order is the quote currency to spend
+		best, err := e.simulator.Price(p, ticker.Spot, buy)
+		if err != nil {
+			return submitOrderResponse, err
+		}
+		amount /= best
+	}
+
+	o, err := e.simulator.Submit(paper.Order{
+		ClientID:  clientID,
+		Pair:      p,
+		AssetType: ticker.Spot,
+		Buy:       buy,
+		Market:    orderType == Market,
+		Amount:    amount,
+		Price:     price,
+	})
+	if err != nil {
+		return submitOrderResponse, err
+	}
+	if orderType == ImmediateOrCancel && o.Status != paper.StatusFilled {
+		err = e.simulator.Cancel(o.ID)
+		if err != nil {
+			return submitOrderResponse, err
+		}
+	}
+
+	submitOrderResponse.OrderID = o.ID
+	submitOrderResponse.IsOrderPlaced = true
+	return submitOrderResponse, nil
+}
+
+// SimulateSubmitQuoteAmountOrder places a paper market order spending or
+// receiving a quote currency notional, converted at the best price.
+// Exchanges implementing QuoteAmountOrderSubmitter call this when IsSimulated
+// is true.
+func (e *Base) SimulateSubmitQuoteAmountOrder(p pair.CurrencyPair, side OrderSide, quoteAmount float64, clientID string) (SubmitOrderResponse, error) {
+	if e.MarketOrderInQuote(side) {
+		return e.SimulateSubmitOrder(p, side, Market, quoteAmount, 0, clientID)
+	}
+	best, err := e.simulator.Price(p, ticker.Spot, side == Buy)
+	if err != nil {
+		return SubmitOrderResponse{}, err
+	}
+	return e.SimulateSubmitOrder(p, side, Market, quoteAmount/best, 0, clientID)
+}
+
+// SimulateCancelOrder cancels a paper order, exchanges call this from
+// CancelOrder when IsSimulated is true
+func (e *Base) SimulateCancelOrder(order OrderCancellation) error {
+	return e.simulator.Cancel(order.OrderID)
+}
+
+// SimulateCancelAllOrders cancels the open paper orders of a currency pair,
+// or every open paper order when the pair is empty. Exchanges call this from
+// CancelAllOrders when IsSimulated is true.
+func (e *Base) SimulateCancelAllOrders(orders OrderCancellation) (CancelAllOrdersResponse, error) {
+	resp := CancelAllOrdersResponse{OrderStatus: make(map[string]string)}
+	for _, id := range e.simulator.CancelAll(orders.CurrencyPair) {
+		resp.OrderStatus[id] = paper.StatusCancelled
+	}
+	return resp, nil
+}
+
+// SimulateModifyOrder replaces a paper order with a new price and amount and
+// returns the new order ID, exchanges call this from ModifyOrder when
+// IsSimulated is true
+func (e *Base) SimulateModifyOrder(action ModifyOrder) (string, error) {
+	o, err := e.simulator.Modify(action.OrderID, action.Price, action.Amount)
+	if err != nil {
+		return "", err
+	}
+	return o.ID, nil
+}
+
+// SimulateGetOrderInfo returns a paper order's details, exchanges call this
+// from GetOrderInfo when IsSimulated is true
+func (e *Base) SimulateGetOrderInfo(orderID int64) (OrderDetail, error) {
+	o, err := e.simulator.Order(strconv.FormatInt(orderID, 10))
+	if err != nil {
+		return OrderDetail{}, err
+	}
+
+	return e.simulatedOrderDetail(&o), nil
+}
+
+// SimulateGetOrders returns the paper orders matching the request, open
+// orders when active is true otherwise closed orders. Exchanges call this from
+// GetActiveOrders and GetOrderHistory when IsSimulated is true.
+func (e *Base) SimulateGetOrders(req GetOrdersRequest, active bool) ([]OrderDetail, error) {
+	var orders []OrderDetail
+	for _, o := range e.simulator.Orders() {
+		isOpen := o.Status == paper.StatusOpen || o.Status == paper.StatusPartiallyFilled
+		if isOpen != active {
+			continue
+		}
+		orders = append(orders, e.simulatedOrderDetail(&o))
+	}
+	return FilterOrders(orders, req), nil
+}
+
+// simulatedOrderDetail converts a paper order to its order details
+func (e *Base) simulatedOrderDetail(o *paper.Order) OrderDetail {
+	detail := OrderDetail{
+		Exchange:             e.Name,
+		ID:                   o.ID,
+		BaseCurrency:         o.Pair.FirstCurrency.String(),
+		QuoteCurrency:        o.Pair.SecondCurrency.String(),
+		OrderSide:            string(Sell),
+		OrderType:            string(Limit),
+		CreationTime:         o.Created,
+		Status:               o.Status,
+		Price:                o.Price,
+		Amount:               o.Amount,
+		ExecutedAmount:       o.FilledAmount,
+		AverageExecutedPrice: o.AverageFillPrice,
+	}
+	if o.Buy {
+		detail.OrderSide = string(Buy)
+	}
+	if o.Market {
+		detail.OrderType = string(Market)
+	}
+	if o.Status == paper.StatusOpen || o.Status == paper.StatusPartiallyFilled {
+		detail.OpenVolume = o.Amount - o.FilledAmount
+	}
+	return detail
+}
@@ -1,13 +1,20 @@
 package exchange
 
 import (
+	"context"
+	"errors"
 	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/config"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
+	"github.com/thrasher-/gocryptotrader/exchanges/assets"
+	"github.com/thrasher-/gocryptotrader/exchanges/paper"
 	"github.com/thrasher-/gocryptotrader/exchanges/request"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
@@ -246,6 +253,71 @@ func TestSetAssetTypes(t *testing.T) {
 	}
 }
 
+func TestAssetPairs(t *testing.T) {
+	cfg := config.GetConfig()
+	err := cfg.LoadConfig(config.ConfigTestFile)
+	if err != nil {
+		t.Fatal("Test failed. TestAssetPairs failed to load config", err)
+	}
+
+	b := Base{
+		Name:           "ANX",
+		AvailablePairs: []string{"BTCUSD", "LTCUSD"},
+		EnabledPairs:   []string{"BTCUSD"},
+		AssetTypes:     []string{assets.Spot, assets.Margin, assets.PerpetualSwap, "OPTIONS"},
+		AssetPairs: map[string]*AssetPairs{
+			assets.PerpetualSwap: {
+				ConfigCurrencyPairFormat: &config.CurrencyPairFormatConfig{Delimiter: "-", Uppercase: true},
+			},
+		},
+	}
+	err = b.SetAssetTypes()
+	if err != nil {
+		t.Fatal("Test failed. SetAssetTypes error", err)
+	}
+
+	exch, err := cfg.GetExchangeConfig(b.Name)
+	if err != nil {
+		t.Fatal("Test failed. GetExchangeConfig error", err)
+	}
+	if len(exch.AssetPairs) != 2 || exch.AssetPairs[assets.Margin].EnabledPairs != "BTCUSD" ||
+		exch.AssetPairs[assets.PerpetualSwap].ConfigCurrencyPairFormat.Delimiter != "-" {
+		t.Error("Test failed. SetAssetTypes asset pairs not written to config", exch.AssetPairs)
+	}
+	if p := b.GetEnabledPairs(assets.Margin); len(p) != 1 || p[0].Pair() != "BTCUSD" {
+		t.Error("Test failed. GetEnabledPairs margin pairs not seeded from spot", p)
+	}
+	if p := b.GetAvailablePairs("OPTIONS"); len(p) != 2 {
+		t.Error("Test failed. GetAvailablePairs expected spot pairs for options", p)
+	}
+
+	err = b.UpdateAssetCurrencies(assets.PerpetualSwap, []string{"btc-usd", "eth-usd", ""}, true, false)
+	if err != nil {
+		t.Fatal("Test failed. UpdateAssetCurrencies error", err)
+	}
+	if p := b.GetEnabledPairs(assets.PerpetualSwap); len(p) != 2 || p[1].Pair() != "ETH-USD" {
+		t.Error("Test failed. GetEnabledPairs unexpected swap pairs", p)
+	}
+	if p := b.GetEnabledPairs(assets.Spot); len(p) != 1 {
+		t.Error("Test failed. UpdateAssetCurrencies changed spot pairs", p)
+	}
+	if err = b.UpdateAssetCurrencies(assets.Index, []string{"BTC-USD"}, true, false); err == nil {
+		t.Error("Test failed. UpdateAssetCurrencies expected error for an asset type not traded")
+	}
+
+	reloaded := Base{Name: "ANX", AssetTypes: b.AssetTypes}
+	err = reloaded.SetAssetTypes()
+	if err != nil {
+		t.Fatal("Test failed. SetAssetTypes error", err)
+	}
+	if p := reloaded.GetEnabledPairs(assets.PerpetualSwap); len(p) != 2 || p[0].Pair() != "BTC-USD" {
+		t.Error("Test failed. SetAssetTypes swap pairs not loaded from config", p)
+	}
+	if !reloaded.SupportsAsset(assets.Margin) || reloaded.SupportsAsset(assets.Index) {
+		t.Error("Test failed. SupportsAsset incorrect result")
+	}
+}
+
 func TestGetAssetTypes(t *testing.T) {
 	testExchange := Base{
 		AssetTypes: []string{"SPOT", "Binary", "Futures"},
@@ -649,6 +721,18 @@ func TestSetEnabled(t *testing.T) {
 	}
 }
 
+func TestSetAuthenticatedAPISupport(t *testing.T) {
+	SetAuthenticatedAPISupport := Base{
+		Name:                    "TESTNAME",
+		AuthenticatedAPISupport: true,
+	}
+
+	SetAuthenticatedAPISupport.SetAuthenticatedAPISupport(false)
+	if SetAuthenticatedAPISupport.GetAuthenticatedAPISupport() {
+		t.Error("Test Failed - Exchange SetAuthenticatedAPISupport(false) did not set boolean")
+	}
+}
+
 func TestIsEnabled(t *testing.T) {
 	IsEnabled := Base{
 		Name:    "TESTNAME",
@@ -928,3 +1012,782 @@ func TestOrderTypes(t *testing.T) {
 		t.Errorf("test failed - unexpected string %s", os.ToString())
 	}
 }
+
+func TestInferLiquidity(t *testing.T) {
+	tester := []struct {
+		OrderType  OrderType
+		Side       OrderSide
+		LimitPrice float64
+		FillPrice  float64
+		Expected   Liquidity
+	}{
+		{Market, Buy, 0, 100, Taker},
+		{Limit, Buy, 100, 100, Maker},
+		{Limit, Buy, 100, 99, Taker},
+		{Limit, Sell, 100, 100, Maker},
+		{Limit, Sell, 100, 101, Taker},
+		{Limit, "Short", 100, 100, UnknownLiquidity},
+	}
+
+	for i := range tester {
+		l := InferLiquidity(tester[i].OrderType, tester[i].Side,
+			tester[i].LimitPrice, tester[i].FillPrice)
+		if l != tester[i].Expected {
+			t.Errorf("Test failed. InferLiquidity() %d expected %q received %q",
+				i, tester[i].Expected, l)
+		}
+	}
+}
+
+func TestOrderTransport(t *testing.T) {
+	b := Base{Name: "RAWR"}
+	b.WebsocketInit()
+
+	var restCalls, wsCalls int
+	rest := func(p pair.CurrencyPair, side OrderSide, orderType OrderType, amount, price float64, clientID string) (SubmitOrderResponse, error) {
+		restCalls++
+		return SubmitOrderResponse{IsOrderPlaced: true, OrderID: "rest"}, nil
+	}
+	restCancel := func(order OrderCancellation) error {
+		restCalls++
+		return nil
+	}
+
+	err := b.SetOrderTransport(config.ExchangeConfig{OrderTransport: OrderTransportWebsocket})
+	if err == nil {
+		t.Error("Test failed - SetOrderTransport() expected error when websocket orders unsupported")
+	}
+	if b.GetOrderTransport() != OrderTransportREST {
+		t.Error("Test failed - SetOrderTransport() should default to REST")
+	}
+
+	var wsErr error
+	b.SetWebsocketOrderHandlers(
+		func(p pair.CurrencyPair, side OrderSide, orderType OrderType, amount, price float64, clientID string) (SubmitOrderResponse, error) {
+			wsCalls++
+			return SubmitOrderResponse{IsOrderPlaced: wsErr == nil, OrderID: "ws"}, wsErr
+		},
+		func(order OrderCancellation) error {
+			wsCalls++
+			return wsErr
+		})
+
+	err = b.SetOrderTransport(config.ExchangeConfig{OrderTransport: "websocket"})
+	if err != nil {
+		t.Fatal("Test failed - SetOrderTransport() error", err)
+	}
+
+	// websocket disconnected so REST is used
+	resp, _ := b.SubmitOrderViaTransport(rest, pair.NewCurrencyPair("BTC", "USD"), Buy, Limit, 1, 1, "")
+	if resp.OrderID != "rest" || wsCalls != 0 {
+		t.Error("Test failed - SubmitOrderViaTransport() should use REST when disconnected")
+	}
+
+	b.Websocket.enabled = true
+	b.Websocket.connected = true
+	resp, _ = b.SubmitOrderViaTransport(rest, pair.NewCurrencyPair("BTC", "USD"), Buy, Limit, 1, 1, "")
+	if resp.OrderID != "ws" || wsCalls != 1 {
+		t.Error("Test failed - SubmitOrderViaTransport() should use websocket when connected")
+	}
+
+	wsErr = ErrWebsocketOrderNotSent
+	resp, _ = b.SubmitOrderViaTransport(rest, pair.NewCurrencyPair("BTC", "USD"), Buy, Limit, 1, 1, "")
+	if resp.OrderID != "rest" || restCalls != 2 {
+		t.Error("Test failed - SubmitOrderViaTransport() should fall back to REST when not sent")
+	}
+
+	wsErr = errors.New("timeout awaiting response")
+	_, err = b.SubmitOrderViaTransport(rest, pair.NewCurrencyPair("BTC", "USD"), Buy, Limit, 1, 1, "")
+	if err != wsErr || restCalls != 2 {
+		t.Error("Test failed - SubmitOrderViaTransport() should not fall back after a sent request fails")
+	}
+
+	err = b.CancelOrderViaTransport(restCancel, OrderCancellation{OrderID: "1"})
+	if err != wsErr {
+		t.Error("Test failed - CancelOrderViaTransport() expected websocket error", err)
+	}
+
+	err = b.SetOrderTransport(config.ExchangeConfig{OrderTransport: "carrierpigeon"})
+	if err == nil || b.GetOrderTransport() != OrderTransportREST {
+		t.Error("Test failed - SetOrderTransport() expected error on invalid transport")
+	}
+}
+
+func TestSetTLSPins(t *testing.T) {
+	b := Base{Name: "RAWR", Requester: request.New("RAWR",
+		request.NewRateLimit(time.Second, 1),
+		request.NewRateLimit(time.Second, 1),
+		new(http.Client))}
+
+	if err := b.SetTLSPins(nil); err != nil || b.GetTLSConfig() != nil {
+		t.Error("Test Failed - SetTLSPins() expected pinning to be disabled", err)
+	}
+	if err := b.SetTLSPins([]string{"sha256/invalid"}); err == nil {
+		t.Error("Test Failed - SetTLSPins() expected invalid pin error")
+	}
+
+	err := b.SetTLSPins([]string{"sha256/AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA="})
+	if err != nil {
+		t.Fatal("Test Failed - SetTLSPins() error", err)
+	}
+	if b.GetTLSConfig() == nil || b.GetTLSConfig().VerifyPeerCertificate == nil {
+		t.Error("Test Failed - GetTLSConfig() expected pinned TLS config")
+	}
+	transport, ok := b.GetHTTPClient().Transport.(*http.Transport)
+	if !ok || transport.TLSClientConfig.VerifyPeerCertificate == nil {
+		t.Error("Test Failed - SetTLSPins() HTTP client transport not pinned")
+	}
+}
+
+func TestSetLocalAddress(t *testing.T) {
+	b := Base{Name: "RAWR", Requester: request.New("RAWR",
+		request.NewRateLimit(time.Second, 1),
+		request.NewRateLimit(time.Second, 1),
+		new(http.Client))}
+
+	if err := b.SetLocalAddress(""); err != nil || b.GetWebsocketNetDial() != nil {
+		t.Error("Test Failed - SetLocalAddress() expected no local address", err)
+	}
+	if err := b.SetLocalAddress("notaninterface0"); err == nil {
+		t.Error("Test Failed - SetLocalAddress() expected invalid address error")
+	}
+	if err := b.SetLocalAddress("127.0.0.1"); err != nil {
+		t.Fatal("Test Failed - SetLocalAddress() error", err)
+	}
+	if b.GetLocalAddress().String() != "127.0.0.1" || b.GetWebsocketNetDial() == nil {
+		t.Error("Test Failed - SetLocalAddress() local address not set")
+	}
+}
+
+func TestSetHTTPClientHeaders(t *testing.T) {
+	b := Base{Name: "RAWR"}
+	if err := b.SetHTTPClientHeaders(map[string]string{"Bad Header": "1"}); err == nil {
+		t.Error("Test Failed - SetHTTPClientHeaders() expected invalid header error")
+	}
+	if err := b.SetHTTPClientHeaders(map[string]string{"X-Api-Version": "1\r\nX-Evil: 1"}); err == nil {
+		t.Error("Test Failed - SetHTTPClientHeaders() expected invalid value error")
+	}
+
+	headers := map[string]string{"X-Broker-Id": "gct"}
+	if err := b.SetHTTPClientHeaders(headers); err != nil {
+		t.Fatal("Test Failed - SetHTTPClientHeaders() error", err)
+	}
+	if b.Requester.Headers["X-Broker-Id"] != "gct" || b.GetHTTPClientHeaders()["X-Broker-Id"] != "gct" {
+		t.Error("Test Failed - SetHTTPClientHeaders() headers not set")
+	}
+}
+
+func TestBrokerTag(t *testing.T) {
+	b := Base{Name: "RAWR"}
+	if b.BrokerClientOrderID("abc") != "abc" || b.BrokerClientOrderID("") != "" {
+		t.Error("Test Failed - BrokerClientOrderID() expected unchanged ID without tag")
+	}
+	if err := b.SetBrokerTag("gct"); err == nil || b.SupportsBrokerTag() {
+		t.Error("Test Failed - SetBrokerTag() expected unsupported error")
+	}
+	if err := b.SetBrokerTag(""); err != nil {
+		t.Error("Test Failed - SetBrokerTag() expected empty tag to be accepted", err)
+	}
+	b.BrokerTagFormat = BrokerTagFormat{MaxLength: 12}
+	if err := b.SetBrokerTag("gct-1"); err == nil {
+		t.Error("Test Failed - SetBrokerTag() expected non alphanumeric error")
+	}
+	if err := b.SetBrokerTag("gocryptotrader"); err == nil {
+		t.Error("Test Failed - SetBrokerTag() expected length error")
+	}
+	if err := b.SetBrokerTag("gct"); err != nil || b.GetBrokerTag() != "gct" {
+		t.Fatal("Test Failed - SetBrokerTag() error", err)
+	}
+	if id := b.BrokerClientOrderID("abc"); id != "gctabc" {
+		t.Error("Test Failed - BrokerClientOrderID() incorrect ID", id)
+	}
+	if id := b.BrokerClientOrderID("gctabc"); id != "gctabc" {
+		t.Error("Test Failed - BrokerClientOrderID() expected tagged ID unchanged", id)
+	}
+	if id := b.BrokerClientOrderID(""); len(id) <= 3 || id[:3] != "gct" {
+		t.Error("Test Failed - BrokerClientOrderID() expected generated ID", id)
+	}
+
+	b.BrokerTagFormat = BrokerTagFormat{Prefix: "x-", MaxLength: 16}
+	if err := b.SetBrokerTag("ABC123"); err == nil {
+		t.Error("Test Failed - SetBrokerTag() expected prefix error")
+	}
+	if err := b.SetBrokerTag("x-"); err == nil {
+		t.Error("Test Failed - SetBrokerTag() expected missing ID error")
+	}
+	if err := b.SetBrokerTag("x-ABC-123"); err == nil {
+		t.Error("Test Failed - SetBrokerTag() expected non alphanumeric error")
+	}
+	if err := b.SetBrokerTag("x-ABC123"); err != nil || b.GetBrokerTag() != "x-ABC123" {
+		t.Error("Test Failed - SetBrokerTag() error", err)
+	}
+}
+
+func TestRateLimitTiers(t *testing.T) {
+	b := Base{Name: "RAWR", Requester: request.New("RAWR",
+		request.NewRateLimit(time.Second, 1),
+		request.NewRateLimit(time.Second, 2),
+		new(http.Client))}
+
+	err := b.SetRateLimitTiers(map[string]config.RateLimitConfig{
+		"VIP1": {AuthRate: -1},
+	}, "")
+	if err == nil {
+		t.Error("Test Failed - SetRateLimitTiers() expected negative limit error")
+	}
+
+	err = b.SetRateLimitTiers(map[string]config.RateLimitConfig{
+		"VIP1": {Interval: time.Millisecond * 500, AuthRate: 10},
+		"VIP2": {AuthRate: 20, UnauthRate: 5, Burst: 40},
+	}, "vip1")
+	if err != nil {
+		t.Fatal("Test Failed - SetRateLimitTiers() error", err)
+	}
+	auth := b.Requester.GetRateLimit(true)
+	if auth.GetDuration() != time.Millisecond*500 || auth.GetRate() != 10 {
+		t.Error("Test Failed - SetRateLimitTiers() configured tier not applied", auth)
+	}
+
+	if !b.SetAccountTier("VIP2") || b.GetAccountTier() != "VIP2" {
+		t.Fatal("Test Failed - SetAccountTier() expected tier change")
+	}
+	auth = b.Requester.GetRateLimit(true)
+	unauth := b.Requester.GetRateLimit(false)
+	if auth.GetDuration() != time.Second || auth.GetRate() != 20 || unauth.GetRate() != 5 ||
+		auth.GetBurst() != 40 {
+		t.Error("Test Failed - SetAccountTier() incorrect limits", auth, unauth)
+	}
+	if b.SetAccountTier("vip2") {
+		t.Error("Test Failed - SetAccountTier() expected unchanged tier")
+	}
+
+	// Tiers without configured limits revert to the exchange defaults
+	b.SetAccountTier("VIP3")
+	auth = b.Requester.GetRateLimit(true)
+	unauth = b.Requester.GetRateLimit(false)
+	if auth.GetRate() != 1 || unauth.GetRate() != 2 || unauth.GetBurst() != 2 {
+		t.Error("Test Failed - SetAccountTier() expected default limits", auth, unauth)
+	}
+}
+
+func TestRoundOrder(t *testing.T) {
+	b := Base{Name: "RAWR"}
+	p := pair.NewCurrencyPair("BTC", "USD")
+
+	price, amount := b.RoundOrder(p, Buy, 100.123, 0.12345)
+	if price != 100.123 || amount != 0.12345 {
+		t.Error("Test Failed - RoundOrder() expected unchanged values without increments")
+	}
+
+	b.SetPairIncrements(p, 0.1, 0.01)
+	price, amount = b.RoundOrder(p, Buy, 100.16, 0.129)
+	if price != 100.1 || amount != 0.12 {
+		t.Errorf("Test Failed - RoundOrder() buy expected 100.1 0.12 got %v %v", price, amount)
+	}
+	price, amount = b.RoundOrder(p, Sell, 100.11, 0.129)
+	if price != 100.2 || amount != 0.12 {
+		t.Errorf("Test Failed - RoundOrder() sell expected 100.2 0.12 got %v %v", price, amount)
+	}
+
+	err := b.SetRoundingModes(map[string]config.RoundingConfig{
+		"btc_usd":          {Price: "halfEven"},
+		DefaultRoundingKey: {Amount: "up"},
+	})
+	if err != nil {
+		t.Fatal("Test Failed - SetRoundingModes() error", err)
+	}
+	r := b.GetPairRounding(p)
+	if r.TickSize != 0.1 || r.PriceMode != common.RoundHalfEven || r.AmountMode != common.RoundUp {
+		t.Errorf("Test Failed - GetPairRounding() unexpected rounding %+v", r)
+	}
+	price, amount = b.RoundOrder(p, Buy, 100.25, 0.121)
+	if price != 100.2 || amount != 0.13 {
+		t.Errorf("Test Failed - RoundOrder() override expected 100.2 0.13 got %v %v", price, amount)
+	}
+
+	r = b.GetPairRounding(pair.NewCurrencyPair("LTC", "USD"))
+	if r.PriceMode != DefaultPriceRounding || r.AmountMode != common.RoundUp {
+		t.Errorf("Test Failed - GetPairRounding() unexpected default rounding %+v", r)
+	}
+
+	err = b.SetRoundingModes(map[string]config.RoundingConfig{
+		"BTC-USD": {Price: "sideways"},
+	})
+	if err == nil {
+		t.Error("Test Failed - SetRoundingModes() expected invalid mode error")
+	}
+}
+
+func TestDustThreshold(t *testing.T) {
+	b := Base{Name: "RAWR"}
+	b.ConfigCurrencyPairFormat.Delimiter = "-"
+	b.EnabledPairs = []string{"BTC-USD", "BTC-EUR", "LTC-BTC", "ETH-USD"}
+	if threshold := b.DustThreshold("BTC"); threshold != 0 {
+		t.Error("Test Failed - DustThreshold() expected unknown threshold", threshold)
+	}
+
+	b.SetPairIncrements(pair.NewCurrencyPair("BTC", "USD"), 0.1, 0.001)
+	b.SetPairMinimumAmount(pair.NewCurrencyPair("BTC", "USD"), 0.01)
+	b.SetPairIncrements(pair.NewCurrencyPair("BTC", "EUR"), 0.1, 0.005)
+	b.SetPairMinimumAmount(pair.NewCurrencyPair("LTC", "BTC"), 0.0001)
+	if threshold := b.DustThreshold("btc"); threshold != 0.005 {
+		t.Error("Test Failed - DustThreshold() expected smallest sellable amount", threshold)
+	}
+	if r := b.GetPairRounding(pair.NewCurrencyPair("BTC", "USD")); r.MinAmount != 0.01 || r.StepSize != 0.001 {
+		t.Errorf("Test Failed - SetPairMinimumAmount() expected increments kept %+v", r)
+	}
+	if threshold := b.DustThreshold("ETH"); threshold != 0 {
+		t.Error("Test Failed - DustThreshold() expected unknown threshold", threshold)
+	}
+}
+
+// testOrderExchange records the amounts of submitted orders
+type testOrderExchange struct {
+	IBotExchange
+	inQuote bool
+	price   ticker.Price
+	amounts []float64
+	types   []OrderType
+}
+
+func (e *testOrderExchange) GetName() string {
+	return "RAWR"
+}
+
+func (e *testOrderExchange) GetCapabilities() Features {
+	return Features{
+		CanSubmitOrder: true,
+		Orders: map[string]OrderCapabilities{
+			assets.Spot: {
+				OrderTypes:   []OrderType{Limit, Market, ImmediateOrCancel},
+				TimeInForce:  []TimeInForce{GTC, IOC, FOK},
+				TriggerTypes: []TriggerType{StopLoss},
+			},
+			assets.Futures: {
+				OrderTypes:  []OrderType{Limit, Market},
+				TimeInForce: []TimeInForce{GTC},
+			},
+		},
+	}
+}
+
+func (e *testOrderExchange) MarketOrderInQuote(side OrderSide) bool {
+	return e.inQuote && side == Buy
+}
+
+func (e *testOrderExchange) GetTickerPrice(p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	return e.price, nil
+}
+
+func (e *testOrderExchange) SubmitOrder(p pair.CurrencyPair, side OrderSide, orderType OrderType, amount, price float64, clientID string) (SubmitOrderResponse, error) {
+	e.amounts = append(e.amounts, amount)
+	e.types = append(e.types, orderType)
+	return SubmitOrderResponse{IsOrderPlaced: true}, nil
+}
+
+// testQuoteOrderExchange supports market orders sized by quote amount
+type testQuoteOrderExchange struct {
+	testOrderExchange
+	quoteAmounts []float64
+}
+
+func (e *testQuoteOrderExchange) SubmitQuoteAmountOrder(p pair.CurrencyPair, side OrderSide, quoteAmount float64, clientID string) (SubmitOrderResponse, error) {
+	e.quoteAmounts = append(e.quoteAmounts, quoteAmount)
+	return SubmitOrderResponse{IsOrderPlaced: true}, nil
+}
+
+// testSubmissionExchange carries the asset type, time in force and trigger of
+// an order
+type testSubmissionExchange struct {
+	testOrderExchange
+	submissions []OrderSubmission
+}
+
+func (e *testSubmissionExchange) SubmitOrderSubmission(o OrderSubmission) (SubmitOrderResponse, error) {
+	e.submissions = append(e.submissions, o)
+	return SubmitOrderResponse{IsOrderPlaced: true}, nil
+}
+
+func TestSubmitOrderRequest(t *testing.T) {
+	p := pair.NewCurrencyPair("BTC", "USD")
+	invalid := []OrderSubmission{
+		{Pair: p, Side: Buy, Type: Market},
+		{Pair: p, Side: Buy, Type: Market, BaseAmount: 1, QuoteAmount: 100},
+		{Pair: p, Side: Buy, Type: Limit, BaseAmount: 1},
+		{Pair: p, Side: Buy, Type: Limit, BaseAmount: 1, Price: 1, Trigger: StopLoss},
+	}
+	for i := range invalid {
+		if _, err := SubmitOrderRequest(&testOrderExchange{}, invalid[i]); err == nil {
+			t.Errorf("Test Failed - SubmitOrderRequest() expected error for order %d", i)
+		}
+	}
+
+	exch := &testOrderExchange{price: ticker.Price{Bid: 99, Ask: 100, Last: 98}}
+	orders := []OrderSubmission{
+		{Pair: p, Side: Buy, Type: Limit, QuoteAmount: 500, Price: 250},
+		{Pair: p, Side: Buy, Type: Market, BaseAmount: 2},
+		{Pair: p, Side: Buy, Type: Market, QuoteAmount: 500},
+		{Pair: p, Side: Sell, Type: Market, QuoteAmount: 198},
+		{Pair: p, Side: Sell, Type: Limit, BaseAmount: 2, Price: 99, TimeInForce: IOC},
+	}
+	for i := range orders {
+		if _, err := SubmitOrderRequest(exch, orders[i]); err != nil {
+			t.Fatal("Test Failed - SubmitOrderRequest() error", err)
+		}
+	}
+	expected := []float64{2, 2, 5, 2, 2}
+	for i := range expected {
+		if exch.amounts[i] != expected[i] {
+			t.Errorf("Test Failed - SubmitOrderRequest() base exchange order %d expected %v got %v",
+				i, expected[i], exch.amounts[i])
+		}
+	}
+	if exch.types[0] != Limit || exch.types[4] != ImmediateOrCancel {
+		t.Error("Test Failed - SubmitOrderRequest() expected immediate or cancel limit order", exch.types)
+	}
+
+	exch = &testOrderExchange{inQuote: true, price: ticker.Price{Last: 100}}
+	orders = []OrderSubmission{
+		{Pair: p, Side: Buy, Type: Market, BaseAmount: 2},
+		{Pair: p, Side: Buy, Type: Market, QuoteAmount: 500},
+		{Pair: p, Side: Sell, Type: Market, BaseAmount: 3},
+	}
+	for i := range orders {
+		if _, err := SubmitOrderRequest(exch, orders[i]); err != nil {
+			t.Fatal("Test Failed - SubmitOrderRequest() error", err)
+		}
+	}
+	expected = []float64{200, 500, 3}
+	for i := range expected {
+		if exch.amounts[i] != expected[i] {
+			t.Errorf("Test Failed - SubmitOrderRequest() quote exchange order %d expected %v got %v",
+				i, expected[i], exch.amounts[i])
+		}
+	}
+
+	native := &testQuoteOrderExchange{}
+	_, err := SubmitOrderRequest(native, OrderSubmission{Pair: p, Side: Buy, Type: Market, QuoteAmount: 500})
+	if err != nil || len(native.quoteAmounts) != 1 || len(native.amounts) != 0 {
+		t.Error("Test Failed - SubmitOrderRequest() expected native quote amount order", err)
+	}
+
+	_, err = SubmitOrderRequest(&testOrderExchange{inQuote: true}, OrderSubmission{Pair: p, Side: Buy, Type: Market, BaseAmount: 1})
+	if err == nil {
+		t.Error("Test Failed - SubmitOrderRequest() expected no market price error")
+	}
+
+	// Orders SubmitOrder cannot carry are rejected unless the exchange carries
+	// them through OrderSubmissionSubmitter
+	uncarried := []OrderSubmission{
+		{AssetType: assets.Futures, Pair: p, Side: Buy, Type: Limit, BaseAmount: 1, Price: 100},
+		{Pair: p, Side: Buy, Type: Limit, BaseAmount: 1, Price: 100, TimeInForce: FOK},
+		{Pair: p, Side: Sell, Type: Market, BaseAmount: 1, Trigger: StopLoss, TriggerPrice: 90},
+	}
+	exch = &testOrderExchange{price: ticker.Price{Bid: 99, Ask: 100}}
+	for i := range uncarried {
+		_, err = SubmitOrderRequest(exch, uncarried[i])
+		if err == nil || !strings.Contains(err.Error(), ErrOrderNotCarried.Error()) {
+			t.Errorf("Test Failed - SubmitOrderRequest() order %d expected not carried error got %v", i, err)
+		}
+	}
+	if len(exch.amounts) != 0 {
+		t.Error("Test Failed - SubmitOrderRequest() submitted an order SubmitOrder cannot carry")
+	}
+
+	carrier := &testSubmissionExchange{testOrderExchange: testOrderExchange{price: ticker.Price{Bid: 99, Ask: 100}}}
+	for i := range uncarried {
+		if _, err = SubmitOrderRequest(carrier, uncarried[i]); err != nil {
+			t.Fatal("Test Failed - SubmitOrderRequest() error", err)
+		}
+	}
+	_, err = SubmitOrderRequest(carrier, OrderSubmission{Pair: p, Side: Buy, Type: Limit, QuoteAmount: 500, Price: 100, TimeInForce: FOK})
+	if err != nil || len(carrier.submissions) != 4 || len(carrier.amounts) != 0 {
+		t.Fatal("Test Failed - SubmitOrderRequest() expected orders carried by SubmitOrderSubmission", err)
+	}
+	if carrier.submissions[0].Asset() != assets.Futures || carrier.submissions[1].TimeInForce != FOK ||
+		carrier.submissions[2].Trigger != StopLoss || carrier.submissions[3].BaseAmount != 5 {
+		t.Errorf("Test Failed - SubmitOrderRequest() unexpected carried orders %+v", carrier.submissions)
+	}
+
+	_, err = SubmitOrderRequest(carrier, OrderSubmission{AssetType: assets.Futures, Pair: p, Side: Buy, Type: Market, QuoteAmount: 500})
+	if err == nil || !strings.Contains(err.Error(), ErrOrderContractAmount.Error()) {
+		t.Error("Test Failed - SubmitOrderRequest() expected futures quote amount rejected", err)
+	}
+
+	_, err = SubmitOrderRequest(carrier, OrderSubmission{Pair: p, Side: Buy, Type: Limit, BaseAmount: 1, Price: 100, TimeInForce: PostOnly})
+	if _, ok := err.(*OrderCapabilityError); !ok {
+		t.Error("Test Failed - SubmitOrderRequest() expected undeclared time in force rejected", err)
+	}
+}
+
+// testStatusExchange reports a system status error
+type testStatusExchange struct {
+	err error
+}
+
+func (e testStatusExchange) CheckSystemStatus() error {
+	return e.err
+}
+
+func TestCheckSystemStatusContext(t *testing.T) {
+	maintenance := errors.New("maintenance")
+	if err := CheckSystemStatusContext(context.Background(), testStatusExchange{maintenance}); err != maintenance {
+		t.Error("Test Failed - CheckSystemStatusContext() expected status error", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := CheckSystemStatusContext(ctx, testStatusExchange{}); err != nil && err != context.Canceled {
+		t.Error("Test Failed - CheckSystemStatusContext() unexpected error", err)
+	}
+}
+
+func TestSubmitOrderContext(t *testing.T) {
+	p := pair.NewCurrencyPair("BTC", "USD")
+	exch := &testOrderExchange{}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if Cancellable(exch) {
+		t.Error("Test Failed - Cancellable() expected uncancellable exchange")
+	}
+	_, err := SubmitOrderContext(ctx, exch, p, Buy, Limit, 1, 100, "")
+	if err != ErrNotCancellable || len(exch.amounts) != 0 {
+		t.Error("Test Failed - SubmitOrderContext() expected uncancellable submission rejected", err)
+	}
+	if err = CancelOrderContext(ctx, exch, OrderCancellation{OrderID: "1"}); err != ErrNotCancellable {
+		t.Error("Test Failed - CancelOrderContext() expected uncancellable cancellation rejected", err)
+	}
+	if _, err = SubmitOrderContext(context.Background(), exch, p, Buy, Limit, 1, 100, ""); err != nil || len(exch.amounts) != 1 {
+		t.Error("Test Failed - SubmitOrderContext() expected submission without a deadline", err)
+	}
+	cancel()
+	if _, err = SubmitOrderContext(ctx, exch, p, Buy, Limit, 1, 100, ""); err != context.Canceled {
+		t.Error("Test Failed - SubmitOrderContext() expected cancelled context", err)
+	}
+}
+
+func TestPauseOrderSubmission(t *testing.T) {
+	p := pair.NewCurrencyPair("BTC", "USD")
+	exch := &testOrderExchange{}
+	order := OrderSubmission{Pair: p, Side: Buy, Type: Limit, BaseAmount: 1, Price: 100}
+
+	PauseOrderSubmission(exch.GetName(), "3 failed health checks")
+	if reason, ok := OrderSubmissionPaused(exch.GetName()); !ok || reason != "3 failed health checks" {
+		t.Error("Test Failed - OrderSubmissionPaused() expected paused", reason, ok)
+	}
+	if _, err := SubmitOrderRequest(exch, order); err == nil || len(exch.amounts) != 0 {
+		t.Error("Test Failed - SubmitOrderRequest() expected paused submission rejected", err)
+	}
+	_, err := SubmitOrderContext(context.Background(), exch, p, Buy, Limit, 1, 100, "")
+	if err == nil || len(exch.amounts) != 0 {
+		t.Error("Test Failed - SubmitOrderContext() expected paused submission rejected", err)
+	}
+
+	ResumeOrderSubmission(exch.GetName())
+	if _, err = SubmitOrderRequest(exch, order); err != nil || len(exch.amounts) != 1 {
+		t.Error("Test Failed - SubmitOrderRequest() expected resumed submission", err)
+	}
+}
+
+func TestFilterOrders(t *testing.T) {
+	start := time.Date(2018, 10, 1, 0, 0, 0, 0, time.UTC)
+	orders := []OrderDetail{
+		{ID: "1", BaseCurrency: "BTC", QuoteCurrency: "USD", OrderSide: "buy", CreationTime: start.Add(-time.Hour)},
+		{ID: "2", BaseCurrency: "BTC", QuoteCurrency: "USD", OrderSide: "SELL", CreationTime: start},
+		{ID: "3", BaseCurrency: "eth", QuoteCurrency: "usd", OrderSide: "Buy", CreationTime: start.Add(time.Hour)},
+		{ID: "4", BaseCurrency: "LTC", QuoteCurrency: "USD", OrderSide: "Buy", CreationTime: start.Add(2 * time.Hour)},
+	}
+
+	if filtered := FilterOrders(orders, GetOrdersRequest{}); len(filtered) != 4 {
+		t.Error("Test Failed - FilterOrders() expected every order with an empty request", filtered)
+	}
+	filtered := FilterOrders(orders, GetOrdersRequest{OrderSide: Buy})
+	if len(filtered) != 3 || filtered[0].ID != "1" {
+		t.Error("Test Failed - FilterOrders() unexpected buy orders", filtered)
+	}
+	filtered = FilterOrders(orders, GetOrdersRequest{StartTime: start, EndTime: start.Add(time.Hour)})
+	if len(filtered) != 2 || filtered[0].ID != "2" || filtered[1].ID != "3" {
+		t.Error("Test Failed - FilterOrders() unexpected time range orders", filtered)
+	}
+	filtered = FilterOrders(orders, GetOrdersRequest{
+		Currencies: []pair.CurrencyPair{pair.NewCurrencyPair("ETH", "USD"), pair.NewCurrencyPair("LTC", "USD")},
+		OrderSide:  Buy,
+		EndTime:    start.Add(time.Hour),
+	})
+	if len(filtered) != 1 || filtered[0].ID != "3" {
+		t.Error("Test Failed - FilterOrders() unexpected currency orders", filtered)
+	}
+}
+
+func TestSimulation(t *testing.T) {
+	b := Base{Name: "SimulationTest", TakerFee: 0.1}
+	if b.IsSimulated() {
+		t.Error("Test Failed - IsSimulated() simulated by default")
+	}
+	b.SetSimulation(true, map[string]float64{"USD": 1000})
+	if !b.IsSimulated() {
+		t.Fatal("Test Failed - SetSimulation() did not enable simulation")
+	}
+
+	p := pair.NewCurrencyPair("BTC", "USD")
+	ticker.ProcessTicker(b.Name, p, ticker.Price{Pair: p, Last: 100, Bid: 99, Ask: 100}, ticker.Spot)
+	resp, err := b.SimulateSubmitOrder(p, Buy, Market, 1, 0, "")
+	if err != nil || !resp.IsOrderPlaced {
+		t.Fatal("Test Failed - SimulateSubmitOrder() error", err)
+	}
+	id, _ := strconv.ParseInt(resp.OrderID, 10, 64)
+	detail, err := b.SimulateGetOrderInfo(id)
+	if err != nil || detail.ExecutedAmount != 1 || detail.OrderSide != string(Buy) {
+		t.Errorf("Test Failed - SimulateGetOrderInfo() unexpected detail %+v %v", detail, err)
+	}
+
+	resp, err = b.SimulateSubmitOrder(p, Buy, ImmediateOrCancel, 1, 90, "")
+	if err != nil {
+		t.Fatal("Test Failed - SimulateSubmitOrder() error", err)
+	}
+	id, _ = strconv.ParseInt(resp.OrderID, 10, 64)
+	detail, _ = b.SimulateGetOrderInfo(id)
+	if detail.Status != paper.StatusCancelled || detail.OpenVolume != 0 {
+		t.Errorf("Test Failed - SimulateSubmitOrder() immediate or cancel order left open %+v", detail)
+	}
+
+	resp, err = b.SimulateSubmitOrder(p, Buy, Limit, 1, 90, "")
+	if err != nil {
+		t.Fatal("Test Failed - SimulateSubmitOrder() error", err)
+	}
+	active, err := b.SimulateGetOrders(GetOrdersRequest{Currencies: []pair.CurrencyPair{p}}, true)
+	if err != nil || len(active) != 1 || active[0].ID != resp.OrderID || active[0].OpenVolume != 1 {
+		t.Errorf("Test Failed - SimulateGetOrders() unexpected active orders %+v %v", active, err)
+	}
+	history, err := b.SimulateGetOrders(GetOrdersRequest{OrderSide: Buy}, false)
+	if err != nil || len(history) != 2 {
+		t.Errorf("Test Failed - SimulateGetOrders() unexpected order history %+v %v", history, err)
+	}
+
+	b.SetSimulation(false, nil)
+	if b.IsSimulated() {
+		t.Error("Test Failed - SetSimulation() did not disable simulation")
+	}
+}
+
+func TestGetCapabilities(t *testing.T) {
+	b := Base{Name: "CapabilityTest"}
+	b.Features = Features{CanGetTicker: true, CanGetOrderInfo: true, CanStreamOrderbook: true}
+
+	f := b.GetCapabilities()
+	if !f.Supports("CanGetTicker") || f.Supports("CanSubmitOrder") || f.Supports("CanFly") {
+		t.Errorf("Test Failed - Supports() unexpected capabilities %+v", f)
+	}
+	caps := f.Capabilities()
+	if len(caps) != 3 || caps[0] != "CanGetTicker" || caps[2] != "CanStreamOrderbook" {
+		t.Error("Test Failed - Capabilities() unexpected capabilities", caps)
+	}
+
+	// Simulated exchanges manage orders on the paper trading engine
+	b.SetSimulation(true, nil)
+	if f = b.GetCapabilities(); !f.CanSubmitOrder || !f.CanCancelAllOrders || f.CanWithdrawCrypto {
+		t.Errorf("Test Failed - GetCapabilities() unexpected simulated capabilities %+v", f)
+	}
+	if c := f.Orders[assets.Spot]; len(c.OrderTypes) != 3 || len(c.TimeInForce) != 2 {
+		t.Errorf("Test Failed - GetCapabilities() unexpected simulated order capabilities %+v", c)
+	}
+	if b.Features.CanSubmitOrder {
+		t.Error("Test Failed - GetCapabilities() modified the exchange features")
+	}
+}
+
+func TestValidateOrder(t *testing.T) {
+	f := Features{CanSubmitOrder: true}
+	o := OrderSubmission{Type: Limit}
+	if _, ok := f.ValidateOrder("OrderTest", assets.Spot, o).(*OrderCapabilityError); !ok {
+		t.Error("Test Failed - ValidateOrder() expected undeclared capabilities rejected")
+	}
+
+	f.Orders = map[string]OrderCapabilities{
+		assets.Spot: {
+			OrderTypes:  []OrderType{Limit, Market},
+			TimeInForce: []TimeInForce{GTC, IOC},
+		},
+	}
+	for _, test := range []struct {
+		assetType string
+		order     OrderSubmission
+		field     string
+	}{
+		{assets.Spot, OrderSubmission{Type: Limit}, ""},
+		{assets.Spot, OrderSubmission{Type: Market}, ""},
+		{assets.Spot, OrderSubmission{Type: Limit, TimeInForce: IOC}, ""},
+		{assets.Futures, OrderSubmission{Type: Limit}, "asset type"},
+		{assets.Spot, OrderSubmission{Type: ImmediateOrCancel}, "order type"},
+		{assets.Spot, OrderSubmission{Type: Limit, TimeInForce: PostOnly}, "time in force"},
+		{assets.Spot, OrderSubmission{Type: Limit, Trigger: StopLoss}, "trigger type"},
+	} {
+		err := f.ValidateOrder("OrderTest", test.assetType, test.order)
+		if test.field == "" {
+			if err != nil {
+				t.Errorf("Test Failed - ValidateOrder() %+v error %s", test.order, err)
+			}
+			continue
+		}
+		capErr, ok := err.(*OrderCapabilityError)
+		if !ok || capErr.Field != test.field {
+			t.Errorf("Test Failed - ValidateOrder() %+v expected unsupported %s got %v", test.order, test.field, err)
+		}
+	}
+
+	err := f.ValidateOrder("OrderTest", assets.Spot, OrderSubmission{Type: Limit, TimeInForce: FOK})
+	if err == nil || err.Error() != "OrderTest does not support time in force FOK for SPOT orders, supported: GTC, IOC" {
+		t.Error("Test Failed - ValidateOrder() unexpected error", err)
+	}
+}
+
+func TestStartWrapper(t *testing.T) {
+	b := Base{Name: "LifecycleTest"}
+	ok := func(context.Context) error { return nil }
+
+	// An abandoned startup is cancelled and the next startup waits for it
+	// rather than running alongside it
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	release := make(chan struct{})
+	var running int32
+	slow := func(ctx context.Context) error {
+		atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		<-ctx.Done()
+		<-release
+		return ctx.Err()
+	}
+	if err := b.StartWrapper(ctx, slow); err == nil || b.IsStarted() {
+		t.Error("Test Failed - StartWrapper() expected context error", err)
+	}
+	waiting, stop := context.WithTimeout(context.Background(), time.Millisecond*10)
+	defer stop()
+	if err := b.StartWrapper(waiting, ok); err == nil || !strings.Contains(err.Error(), "previous startup") {
+		t.Error("Test Failed - StartWrapper() expected to wait for the previous startup", err)
+	}
+	close(release)
+	if err := b.StartWrapper(context.Background(), ok); err == nil || b.IsStarted() ||
+		atomic.LoadInt32(&running) != 0 {
+		t.Error("Test Failed - StartWrapper() expected no enabled pairs error", err)
+	}
+	runErr := errors.New("startup failed")
+	if err := b.StartWrapper(context.Background(), func(context.Context) error { return runErr }); err == nil ||
+		!strings.Contains(err.Error(), runErr.Error()) {
+		t.Error("Test Failed - StartWrapper() expected run error", err)
+	}
+
+	b.EnabledPairs = []string{"BTCUSD"}
+	if err := b.StartWrapper(context.Background(), ok); err != nil || !b.IsStarted() {
+		t.Fatal("Test Failed - StartWrapper() error", err)
+	}
+	if err := b.StartWrapper(context.Background(), ok); err != ErrWrapperAlreadyStarted {
+		t.Error("Test Failed - StartWrapper() expected already started error", err)
+	}
+	if err := b.Stop(); err != nil || b.IsStarted() {
+		t.Error("Test Failed - Stop() error", err)
+	}
+	if err := b.Stop(); err != ErrWrapperNotStarted {
+		t.Error("Test Failed - Stop() expected not started error", err)
+	}
+}
@@ -24,6 +24,10 @@ const (
 	websocketRestablishConnection = 1 * time.Second
 )
 
+// ErrWebsocketNotConnected is returned when shutting down a websocket which
+// is not connected
+var ErrWebsocketNotConnected = errors.New("exchange_websocket.go error - System not connected to shut down")
+
 // WebsocketInit initialises the websocket struct
 func (e *Base) WebsocketInit() {
 	e.Websocket = &Websocket{
@@ -42,11 +46,19 @@ func (e *Base) WebsocketSetup(connector func() error,
 	defaultURL,
 	runningURL string) error {
 
+	// Exchanges are set up again when reloaded or restarted, which must not
+	// toggle the connection of an already configured websocket
+	e.Websocket.init = true
+
 	e.Websocket.DataHandler = make(chan interface{}, 1)
 	e.Websocket.Connected = make(chan struct{}, 1)
 	e.Websocket.Disconnected = make(chan struct{}, 1)
 	e.Websocket.Intercomm = make(chan WebsocketResponse, 1)
 	e.Websocket.TrafficAlert = make(chan struct{}, 1)
+	e.Websocket.StateChange = make(chan WebsocketStateChange, websocketStateChangeBuffer)
+	if e.Websocket.GetReconnectPolicy() == (ReconnectPolicy{}) {
+		e.Websocket.SetReconnectPolicy(DefaultReconnectPolicy)
+	}
 
 	err := e.Websocket.SetEnabled(wsEnabled)
 	if err != nil {
@@ -73,9 +85,23 @@ type Websocket struct {
 	enabled      bool
 	init         bool
 	connected    bool
+	running      bool
 	connector    func() error
 	m            sync.Mutex
 
+	state           WebsocketState
+	reconnecting    bool
+	reconnectStop   chan struct{}
+	reconnectPolicy ReconnectPolicy
+	rm              sync.Mutex
+
+	subscriptions        []ChannelSubscription
+	subscriptionsInit    bool
+	defaultSubscriptions func() []ChannelSubscription
+	subscriber           func([]ChannelSubscription) error
+	unsubscriber         func([]ChannelSubscription) error
+	sm                   sync.Mutex
+
 	// Connected denotes a channel switch for diversion of request flow
 	Connected chan struct{}
 
@@ -101,6 +127,10 @@ type Websocket struct {
 
 	// TrafficAlert monitors if there is a halt in traffic throughput
 	TrafficAlert chan struct{}
+
+	// StateChange surfaces connection state transitions for the engine to
+	// log and alert on
+	StateChange chan WebsocketStateChange
 }
 
 // trafficMonitor monitors traffic and switches connection modes for websocket
@@ -144,8 +174,12 @@ func (w *Websocket) trafficMonitor(wg *sync.WaitGroup) {
 			case <-w.ShutdownC: // Returns on shutdown channel close
 				return
 
-			case <-newtimer.C: // If secondary timer runs state timeout is sent to the data handler
-				w.DataHandler <- WebsocketStateTimeout
+			case <-newtimer.C: // If secondary timer runs the connection is dropped and reconnected
+				w.Reconnect(ErrWebsocketTrafficHalted)
+				select {
+				case w.DataHandler <- WebsocketStateTimeout:
+				case <-w.ShutdownC:
+				}
 				return
 
 			case <-w.TrafficAlert: // If in this time response traffic comes through
@@ -164,14 +198,24 @@ func (w *Websocket) trafficMonitor(wg *sync.WaitGroup) {
 // function
 func (w *Websocket) Connect() error {
 	w.m.Lock()
-	defer w.m.Unlock()
+	err := w.connect()
+	w.m.Unlock()
+	if err != nil {
+		return err
+	}
+	w.setState(WebsocketConnected, 0, nil)
+	return nil
+}
 
+// connect starts the websocket routines, runs the connector and subscribes to
+// the channel subscriptions, the routines are shut down again on failure
+func (w *Websocket) connect() error {
 	if !w.IsEnabled() {
 		return fmt.Errorf("exchange_websocket.go %s error - websocket disabled",
 			w.GetName())
 	}
 
-	if w.connected {
+	if w.running {
 		return errors.New("exchange_websocket.go error - already connected, cannot connect again")
 	}
 
@@ -181,13 +225,23 @@ func (w *Websocket) Connect() error {
 	anotherWG.Add(1)
 	go w.trafficMonitor(&anotherWG)
 	anotherWG.Wait()
+	w.running = true
 
 	err := w.connector()
 	if err != nil {
+		w.shutdown()
 		return fmt.Errorf("exchange_websocket.go connection error %s",
 			err)
 	}
 
+	err = w.resubscribe()
+	if err != nil {
+		w.shutdown()
+		return fmt.Errorf("exchange_websocket.go %s could not subscribe to websocket channels %s",
+			w.GetName(),
+			err)
+	}
+
 	// Divert for incoming websocket traffic
 	w.Connected <- struct{}{}
 	w.connected = true
@@ -196,17 +250,27 @@ func (w *Websocket) Connect() error {
 }
 
 // Shutdown attempts to shut down a websocket connection and associated routines
-// by using a package defined shutdown function
+// by using a package defined shutdown function, stopping any reconnect
 func (w *Websocket) Shutdown() error {
+	w.stopReconnect()
+
 	w.m.Lock()
+	err := w.shutdown()
+	w.m.Unlock()
+	if err != nil {
+		return err
+	}
+	w.setState(WebsocketDisconnected, 0, nil)
+	return nil
+}
 
-	defer func() {
-		w.Orderbook.FlushCache()
-		w.m.Unlock()
-	}()
+// shutdown closes the shutdown channel and waits for the websocket routines
+// to return
+func (w *Websocket) shutdown() error {
+	defer w.Orderbook.FlushCache()
 
-	if !w.connected {
-		return errors.New("exchange_websocket.go error - System not connected to shut down")
+	if !w.running {
+		return ErrWebsocketNotConnected
 	}
 
 	timer := time.NewTimer(5 * time.Second)
@@ -221,6 +285,7 @@ func (w *Websocket) Shutdown() error {
 	select {
 	case <-c:
 		w.connected = false
+		w.running = false
 		return nil
 	case <-timer.C:
 		return fmt.Errorf("%s - Websocket routines failed to shutdown",
@@ -275,6 +340,11 @@ func (w *Websocket) IsEnabled() bool {
 	return w.enabled
 }
 
+// IsConnected returns whether the websocket is currently receiving traffic
+func (w *Websocket) IsConnected() bool {
+	return w.connected
+}
+
 // SetProxyAddress sets websocket proxy address
 func (w *Websocket) SetProxyAddress(URL string) error {
 	if w.proxyAddr == URL {
@@ -593,6 +663,18 @@ type TickerData struct {
 	LowPrice   float64
 }
 
+// MarkPriceData defines a derivative's mark and index price feed, IndexPrice
+// is zero when the exchange does not stream it
+type MarkPriceData struct {
+	Timestamp    time.Time
+	Pair         pair.CurrencyPair
+	AssetType    string
+	Exchange     string
+	InstrumentID string
+	MarkPrice    float64
+	IndexPrice   float64
+}
+
 // KlineData defines kline feed
 type KlineData struct {
 	Timestamp  time.Time
@@ -0,0 +1,179 @@
+package exchange
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+)
+
+// Websocket subscription errors
+var (
+	ErrSubscriptionsNotSupported = errors.New("exchange websocket does not support managing subscriptions")
+	ErrSubscriptionNotFound      = errors.New("websocket channel not subscribed")
+)
+
+// ChannelSubscription is a websocket channel subscription. Channel is the
+// exchange's name for the channel, Currency and AssetType are empty for
+// channels not tied to a currency pair and Params holds any exchange specific
+// arguments.
+type ChannelSubscription struct {
+	Channel   string
+	Currency  pair.CurrencyPair
+	AssetType string
+	Params    map[string]string
+}
+
+// Equal returns whether two subscriptions are to the same channel
+func (c *ChannelSubscription) Equal(s *ChannelSubscription) bool {
+	if c.Channel != s.Channel || c.AssetType != s.AssetType ||
+		!c.Currency.Equal(s.Currency, true) || len(c.Params) != len(s.Params) {
+		return false
+	}
+	for k, v := range c.Params {
+		if p, ok := s.Params[k]; !ok || p != v {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the channel and currency pair of a subscription
+func (c *ChannelSubscription) String() string {
+	if c.Currency.Empty() {
+		return c.Channel
+	}
+	return fmt.Sprintf("%s %s", c.Channel, c.Currency.Pair())
+}
+
+// SetSubscriber sets the functions generating an exchange's default
+// subscriptions and sending its subscribe and unsubscribe requests. The
+// default subscriptions are generated when first needed, typically from the
+// enabled currency pairs on connecting, and every subscription is replayed
+// each time the websocket connects. unsubscribe is nil for exchanges which
+// cannot unsubscribe from channels. Setting the subscriber, as exchanges do on
+// setup, discards subscriptions added at runtime.
+func (w *Websocket) SetSubscriber(defaults func() []ChannelSubscription, subscribe, unsubscribe func([]ChannelSubscription) error) {
+	w.sm.Lock()
+	w.defaultSubscriptions = defaults
+	w.subscriber = subscribe
+	w.unsubscriber = unsubscribe
+	w.subscriptions = nil
+	w.subscriptionsInit = false
+	w.sm.Unlock()
+}
+
+// Subscribe adds channel subscriptions, subscribing to them straight away
+// when connected. Channels already subscribed to are ignored.
+func (w *Websocket) Subscribe(subs ...ChannelSubscription) error {
+	w.m.Lock()
+	defer w.m.Unlock()
+	w.sm.Lock()
+	defer w.sm.Unlock()
+
+	if w.subscriber == nil {
+		return ErrSubscriptionsNotSupported
+	}
+	w.initSubscriptions()
+
+	var add []ChannelSubscription
+	for i := range subs {
+		if indexSubscription(w.subscriptions, &subs[i]) == -1 &&
+			indexSubscription(add, &subs[i]) == -1 {
+			add = append(add, subs[i])
+		}
+	}
+	if len(add) == 0 {
+		return nil
+	}
+
+	if w.running {
+		if err := w.subscriber(add); err != nil {
+			return err
+		}
+	}
+	w.subscriptions = append(w.subscriptions, add...)
+	return nil
+}
+
+// Unsubscribe removes channel subscriptions, unsubscribing from them straight
+// away when connected
+func (w *Websocket) Unsubscribe(subs ...ChannelSubscription) error {
+	w.m.Lock()
+	defer w.m.Unlock()
+	w.sm.Lock()
+	defer w.sm.Unlock()
+
+	if w.subscriber == nil || w.unsubscriber == nil {
+		return ErrSubscriptionsNotSupported
+	}
+	w.initSubscriptions()
+	if len(subs) == 0 {
+		return nil
+	}
+
+	for i := range subs {
+		if indexSubscription(w.subscriptions, &subs[i]) == -1 {
+			return fmt.Errorf("%s %s: %s", w.exchangeName, subs[i].String(), ErrSubscriptionNotFound)
+		}
+	}
+
+	if w.running {
+		if err := w.unsubscriber(subs); err != nil {
+			return err
+		}
+	}
+	for i := range subs {
+		if x := indexSubscription(w.subscriptions, &subs[i]); x != -1 {
+			w.subscriptions = append(w.subscriptions[:x], w.subscriptions[x+1:]...)
+		}
+	}
+	return nil
+}
+
+// GetSubscriptions returns the websocket's channel subscriptions
+func (w *Websocket) GetSubscriptions() []ChannelSubscription {
+	w.sm.Lock()
+	defer w.sm.Unlock()
+	w.initSubscriptions()
+	subs := make([]ChannelSubscription, len(w.subscriptions))
+	copy(subs, w.subscriptions)
+	return subs
+}
+
+// resubscribe subscribes to every channel subscription after connecting, the
+// caller holds w.m
+func (w *Websocket) resubscribe() error {
+	w.sm.Lock()
+	defer w.sm.Unlock()
+	if w.subscriber == nil {
+		return nil
+	}
+	w.initSubscriptions()
+	if len(w.subscriptions) == 0 {
+		return nil
+	}
+	return w.subscriber(w.subscriptions)
+}
+
+// initSubscriptions generates the default subscriptions when not yet
+// generated, the caller holds w.sm
+func (w *Websocket) initSubscriptions() {
+	if w.subscriptionsInit {
+		return
+	}
+	w.subscriptionsInit = true
+	if w.defaultSubscriptions != nil {
+		w.subscriptions = w.defaultSubscriptions()
+	}
+}
+
+// indexSubscription returns the index of a subscription, -1 when not found
+func indexSubscription(subs []ChannelSubscription, s *ChannelSubscription) int {
+	for i := range subs {
+		if subs[i].Equal(s) {
+			return i
+		}
+	}
+	return -1
+}
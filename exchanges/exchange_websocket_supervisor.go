@@ -0,0 +1,240 @@
+package exchange
+
+import (
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// WebsocketState is the state of a websocket connection
+type WebsocketState int
+
+// Websocket connection states
+const (
+	WebsocketDisconnected WebsocketState = iota
+	WebsocketConnecting
+	WebsocketConnected
+	WebsocketReconnecting
+)
+
+// websocketStateChangeBuffer is the number of state transitions held for the
+// engine before further transitions are dropped
+const websocketStateChangeBuffer = 32
+
+// Websocket supervisor errors
+var (
+	ErrWebsocketTrafficHalted   = errors.New("websocket traffic halted")
+	ErrWebsocketReconnectFailed = errors.New("websocket reconnect attempts exhausted")
+)
+
+// DefaultReconnectPolicy is the reconnect policy of websockets which have not
+// set their own
+var DefaultReconnectPolicy = ReconnectPolicy{
+	InitialDelay: websocketRestablishConnection,
+	MaxDelay:     2 * time.Minute,
+	Jitter:       0.2,
+}
+
+// String returns the name of a websocket state
+func (s WebsocketState) String() string {
+	switch s {
+	case WebsocketDisconnected:
+		return "DISCONNECTED"
+	case WebsocketConnecting:
+		return "CONNECTING"
+	case WebsocketConnected:
+		return "CONNECTED"
+	case WebsocketReconnecting:
+		return "RECONNECTING"
+	}
+	return "UNKNOWN"
+}
+
+// WebsocketStateChange is a websocket connection state transition. Attempt is
+// the reconnect attempt which caused it, zero outside of reconnecting, and Err
+// the dropped connection or failed attempt.
+type WebsocketStateChange struct {
+	Exchange string
+	From     WebsocketState
+	To       WebsocketState
+	Attempt  int
+	Err      error
+	Time     time.Time
+}
+
+// ReconnectPolicy is how a dropped websocket connection is reconnected. The
+// delay before each attempt doubles from InitialDelay up to MaxDelay and is
+// randomised by up to the Jitter fraction either way, so exchanges dropping
+// many clients at once are not reconnected to in lockstep. MaxAttempts of zero
+// retries until the websocket is shut down.
+type ReconnectPolicy struct {
+	InitialDelay time.Duration
+	MaxDelay     time.Duration
+	Jitter       float64
+	MaxAttempts  int
+}
+
+// Delay returns the jittered delay before a reconnect attempt, starting at 1
+func (p ReconnectPolicy) Delay(attempt int) time.Duration {
+	d := p.InitialDelay
+	for i := 1; i < attempt && (p.MaxDelay <= 0 || d < p.MaxDelay); i++ {
+		d *= 2
+	}
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	if p.Jitter > 0 {
+		d += time.Duration((rand.Float64()*2 - 1) * p.Jitter * float64(d))
+	}
+	return d
+}
+
+// IsConnectionDropped returns whether a websocket read error means the
+// connection to the exchange was lost
+func IsConnectionDropped(err error) bool {
+	if err == nil {
+		return false
+	}
+	if _, ok := err.(*websocket.CloseError); ok {
+		return true
+	}
+	if _, ok := err.(net.Error); ok {
+		return true
+	}
+	return err == io.EOF ||
+		err == io.ErrUnexpectedEOF ||
+		err == ErrWebsocketTrafficHalted ||
+		strings.Contains(err.Error(), "use of closed network connection") ||
+		strings.Contains(err.Error(), "connection reset by peer")
+}
+
+// SetReconnectPolicy sets how the websocket is reconnected when dropped
+func (w *Websocket) SetReconnectPolicy(p ReconnectPolicy) {
+	w.rm.Lock()
+	w.reconnectPolicy = p
+	w.rm.Unlock()
+}
+
+// GetReconnectPolicy returns how the websocket is reconnected when dropped
+func (w *Websocket) GetReconnectPolicy() ReconnectPolicy {
+	w.rm.Lock()
+	defer w.rm.Unlock()
+	return w.reconnectPolicy
+}
+
+// GetState returns the websocket's connection state
+func (w *Websocket) GetState() WebsocketState {
+	w.rm.Lock()
+	defer w.rm.Unlock()
+	return w.state
+}
+
+// IsReconnecting returns whether the connection supervisor is reconnecting
+// the websocket
+func (w *Websocket) IsReconnecting() bool {
+	w.rm.Lock()
+	defer w.rm.Unlock()
+	return w.reconnecting
+}
+
+// Reconnect hands a dropped connection to the connection supervisor, which
+// shuts down the exchange's websocket routines and reconnects with the
+// reconnect policy's backoff. Reconnecting runs the exchange's connector and
+// replays its channel subscriptions. Drops reported while already
+// reconnecting are ignored.
+func (w *Websocket) Reconnect(reason error) {
+	w.rm.Lock()
+	if w.reconnecting {
+		w.rm.Unlock()
+		return
+	}
+	w.reconnecting = true
+	stop := make(chan struct{})
+	w.reconnectStop = stop
+	w.rm.Unlock()
+
+	go w.supervise(reason, stop)
+}
+
+// supervise reconnects the websocket until connected, shut down or out of
+// attempts
+func (w *Websocket) supervise(reason error, stop chan struct{}) {
+	defer func() {
+		w.rm.Lock()
+		w.reconnecting = false
+		w.reconnectStop = nil
+		w.rm.Unlock()
+	}()
+
+	w.setState(WebsocketReconnecting, 0, reason)
+	w.m.Lock()
+	if w.running {
+		if err := w.shutdown(); err != nil {
+			w.m.Unlock()
+			w.setState(WebsocketReconnecting, 0, err)
+			w.m.Lock()
+		}
+	}
+	w.m.Unlock()
+
+	p := w.GetReconnectPolicy()
+	for attempt := 1; p.MaxAttempts == 0 || attempt <= p.MaxAttempts; attempt++ {
+		timer := time.NewTimer(p.Delay(attempt))
+		select {
+		case <-stop:
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		w.setState(WebsocketConnecting, attempt, nil)
+		w.m.Lock()
+		err := w.connect()
+		w.m.Unlock()
+		if err == nil {
+			w.setState(WebsocketConnected, attempt, nil)
+			return
+		}
+		w.setState(WebsocketReconnecting, attempt, err)
+	}
+	w.setState(WebsocketDisconnected, p.MaxAttempts, ErrWebsocketReconnectFailed)
+}
+
+// stopReconnect stops the connection supervisor when reconnecting
+func (w *Websocket) stopReconnect() {
+	w.rm.Lock()
+	if w.reconnectStop != nil {
+		close(w.reconnectStop)
+		w.reconnectStop = nil
+	}
+	w.rm.Unlock()
+}
+
+// setState transitions the websocket's connection state and surfaces the
+// transition on StateChange, dropping it when the engine is not keeping up
+func (w *Websocket) setState(s WebsocketState, attempt int, err error) {
+	w.rm.Lock()
+	change := WebsocketStateChange{
+		Exchange: w.exchangeName,
+		From:     w.state,
+		To:       s,
+		Attempt:  attempt,
+		Err:      err,
+		Time:     time.Now(),
+	}
+	w.state = s
+	w.rm.Unlock()
+
+	if w.StateChange == nil {
+		return
+	}
+	select {
+	case w.StateChange <- change:
+	default:
+	}
+}
@@ -1,9 +1,13 @@
 package exchange
 
 import (
+	"errors"
+	"io"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/gorilla/websocket"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 )
@@ -54,6 +58,16 @@ func TestWebsocket(t *testing.T) {
 		t.Error("test failed - WebsocketSetup")
 	}
 
+	// Setting up again, as when an exchange is reloaded, leaves it unchanged
+	err := wsTest.WebsocketSetup(func() error { return nil },
+		"testName",
+		true,
+		"testDefaultURL",
+		"testRunningURL")
+	if err != nil || !wsTest.Websocket.IsEnabled() {
+		t.Error("test failed - WebsocketSetup repeated setup error", err)
+	}
+
 	// Test websocket connect and shutdown functions
 	comms := make(chan struct{}, 1)
 	go func() {
@@ -73,7 +87,7 @@ func TestWebsocket(t *testing.T) {
 	}()
 
 	// -- Not connected shutdown
-	err := wsTest.Websocket.Shutdown()
+	err = wsTest.Websocket.Shutdown()
 	if err == nil {
 		t.Fatal("test failed - should not be connected to able to shut down")
 	}
@@ -309,3 +323,196 @@ func TestUpdate(t *testing.T) {
 		t.Error("test failed - OrderbookUpdate error", err)
 	}
 }
+
+func TestReconnectPolicyDelay(t *testing.T) {
+	p := ReconnectPolicy{InitialDelay: time.Second, MaxDelay: 5 * time.Second}
+	for attempt, expected := range map[int]time.Duration{
+		1: time.Second,
+		2: 2 * time.Second,
+		3: 4 * time.Second,
+		4: 5 * time.Second,
+		9: 5 * time.Second,
+	} {
+		if d := p.Delay(attempt); d != expected {
+			t.Errorf("test failed - Delay(%d) expected %s got %s", attempt, expected, d)
+		}
+	}
+
+	p.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		if d := p.Delay(2); d < time.Second || d > 3*time.Second {
+			t.Fatal("test failed - Delay() jitter out of range", d)
+		}
+	}
+}
+
+func TestIsConnectionDropped(t *testing.T) {
+	if IsConnectionDropped(nil) || IsConnectionDropped(errors.New("invalid order")) {
+		t.Error("test failed - IsConnectionDropped() unexpected dropped connection")
+	}
+	if !IsConnectionDropped(&websocket.CloseError{Code: 1006}) ||
+		!IsConnectionDropped(io.EOF) ||
+		!IsConnectionDropped(ErrWebsocketTrafficHalted) {
+		t.Error("test failed - IsConnectionDropped() expected dropped connection")
+	}
+}
+
+func TestWebsocketReconnect(t *testing.T) {
+	var b Base
+	b.WebsocketInit()
+	var connects int
+	b.WebsocketSetup(func() error {
+		connects++
+		if connects == 2 || connects == 3 {
+			return errors.New("connection refused")
+		}
+		return nil
+	}, "reconnectTest", true, "", "")
+	b.Websocket.SetReconnectPolicy(ReconnectPolicy{InitialDelay: time.Millisecond})
+	go func() {
+		for {
+			select {
+			case <-b.Websocket.Connected:
+			case <-b.Websocket.Disconnected:
+			}
+		}
+	}()
+
+	if err := b.Websocket.Connect(); err != nil {
+		t.Fatal("test failed - Connect() error", err)
+	}
+	b.Websocket.Reconnect(&websocket.CloseError{Code: 1006})
+
+	expected := []WebsocketState{
+		WebsocketConnected,
+		WebsocketReconnecting,
+		WebsocketConnecting, WebsocketReconnecting,
+		WebsocketConnecting, WebsocketReconnecting,
+		WebsocketConnecting, WebsocketConnected,
+	}
+	for i, s := range expected {
+		select {
+		case c := <-b.Websocket.StateChange:
+			if c.To != s || c.Exchange != "reconnectTest" {
+				t.Fatalf("test failed - Reconnect() transition %d expected %s got %+v", i, s, c)
+			}
+			if s == WebsocketReconnecting && i > 1 && c.Err == nil {
+				t.Error("test failed - Reconnect() expected failed attempt error")
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("test failed - Reconnect() timed out awaiting", s)
+		}
+	}
+	if connects != 4 || !b.Websocket.IsConnected() {
+		t.Error("test failed - Reconnect() expected connection restored", connects)
+	}
+
+	// Shutting down stops a reconnect in progress
+	b.Websocket.SetReconnectPolicy(ReconnectPolicy{InitialDelay: time.Hour})
+	b.Websocket.Reconnect(ErrWebsocketTrafficHalted)
+	for b.Websocket.GetState() != WebsocketReconnecting {
+		time.Sleep(time.Millisecond)
+	}
+	if err := b.Websocket.Shutdown(); err != nil && err != ErrWebsocketNotConnected {
+		t.Error("test failed - Shutdown() error", err)
+	}
+	for b.Websocket.IsReconnecting() {
+		time.Sleep(time.Millisecond)
+	}
+	if connects != 4 {
+		t.Error("test failed - Shutdown() expected reconnect stopped", connects)
+	}
+}
+
+func TestWebsocketSubscriptions(t *testing.T) {
+	var b Base
+	b.WebsocketInit()
+	b.WebsocketSetup(func() error { return nil }, "subscriptionTest", true, "", "")
+	go func() {
+		for {
+			select {
+			case <-b.Websocket.Connected:
+			case <-b.Websocket.Disconnected:
+			}
+		}
+	}()
+
+	ticker := ChannelSubscription{Channel: "ticker", Currency: pair.NewCurrencyPair("BTC", "USD")}
+	trades := ChannelSubscription{Channel: "trades", Currency: pair.NewCurrencyPair("BTC", "USD")}
+	if err := b.Websocket.Subscribe(ticker); err != ErrSubscriptionsNotSupported {
+		t.Error("test failed - Subscribe() expected ErrSubscriptionsNotSupported", err)
+	}
+
+	var defaults int
+	var sent, removed []ChannelSubscription
+	b.Websocket.SetSubscriber(func() []ChannelSubscription {
+		defaults++
+		return []ChannelSubscription{ticker}
+	}, func(subs []ChannelSubscription) error {
+		sent = append(sent, subs...)
+		return nil
+	}, func(subs []ChannelSubscription) error {
+		removed = append(removed, subs...)
+		return nil
+	})
+
+	// Subscribing while disconnected only stores the subscription
+	if err := b.Websocket.Subscribe(trades, trades, ticker); err != nil {
+		t.Fatal("test failed - Subscribe() error", err)
+	}
+	if defaults != 1 || len(sent) != 0 || len(b.Websocket.GetSubscriptions()) != 2 {
+		t.Error("test failed - Subscribe() expected stored subscriptions",
+			defaults, sent, b.Websocket.GetSubscriptions())
+	}
+
+	// Connecting subscribes to every channel
+	if err := b.Websocket.Connect(); err != nil {
+		t.Fatal("test failed - Connect() error", err)
+	}
+	if len(sent) != 2 || !sent[0].Equal(&ticker) || !sent[1].Equal(&trades) {
+		t.Error("test failed - Connect() expected subscriptions sent", sent)
+	}
+
+	// Subscribing while connected sends new channels only
+	depth := ChannelSubscription{Channel: "depth", Params: map[string]string{"levels": "20"}}
+	sent = nil
+	if err := b.Websocket.Subscribe(ticker, depth); err != nil {
+		t.Fatal("test failed - Subscribe() error", err)
+	}
+	if len(sent) != 1 || !sent[0].Equal(&depth) {
+		t.Error("test failed - Subscribe() expected depth subscription sent", sent)
+	}
+
+	err := b.Websocket.Unsubscribe(ChannelSubscription{Channel: "depth"})
+	if err == nil || !strings.Contains(err.Error(), ErrSubscriptionNotFound.Error()) {
+		t.Error("test failed - Unsubscribe() expected ErrSubscriptionNotFound", err)
+	}
+	if err = b.Websocket.Unsubscribe(trades); err != nil {
+		t.Fatal("test failed - Unsubscribe() error", err)
+	}
+	if len(removed) != 1 || !removed[0].Equal(&trades) ||
+		len(b.Websocket.GetSubscriptions()) != 2 {
+		t.Error("test failed - Unsubscribe() expected trades removed",
+			removed, b.Websocket.GetSubscriptions())
+	}
+
+	// Reconnecting replays the remaining subscriptions
+	sent = nil
+	b.Websocket.SetReconnectPolicy(ReconnectPolicy{InitialDelay: time.Millisecond})
+	b.Websocket.Reconnect(ErrWebsocketTrafficHalted)
+	for reconnected := false; !reconnected; {
+		select {
+		case c := <-b.Websocket.StateChange:
+			reconnected = c.To == WebsocketConnected && c.Attempt > 0
+		case <-time.After(5 * time.Second):
+			t.Fatal("test failed - Reconnect() timed out")
+		}
+	}
+	if len(sent) != 2 || !sent[0].Equal(&ticker) || !sent[1].Equal(&depth) {
+		t.Error("test failed - Reconnect() expected subscriptions replayed", sent)
+	}
+
+	if err = b.Websocket.Shutdown(); err != nil {
+		t.Error("test failed - Shutdown() error", err)
+	}
+}
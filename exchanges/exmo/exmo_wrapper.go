@@ -1,11 +1,11 @@
 package exmo
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
 	"strconv"
-	"sync"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
@@ -14,17 +14,13 @@ import (
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
 
-// Start starts the EXMO go routine
-func (e *EXMO) Start(wg *sync.WaitGroup) {
-	wg.Add(1)
-	go func() {
-		e.Run()
-		wg.Done()
-	}()
+// Start starts the EXMO wrapper, refreshing its currency pairs
+func (e *EXMO) Start(ctx context.Context) error {
+	return e.StartWrapper(ctx, e.Run)
 }
 
 // Run implements the EXMO wrapper
-func (e *EXMO) Run() {
+func (e *EXMO) Run(ctx context.Context) error {
 	if e.Verbose {
 		log.Printf("%s polling delay: %ds.\n", e.GetName(), e.RESTPollingDelay)
 		log.Printf("%s %d currencies enabled: %s.\n", e.GetName(), len(e.EnabledPairs), e.EnabledPairs)
@@ -43,6 +39,7 @@ func (e *EXMO) Run() {
 			log.Printf("%s Failed to update available currencies.\n", e.GetName())
 		}
 	}
+	return nil
 }
 
 // UpdateTicker updates and returns the ticker for a currency pair
@@ -178,6 +175,10 @@ func (e *EXMO) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]exch
 
 // SubmitOrder submits a new order
 func (e *EXMO) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	if e.IsSimulated() {
+		return e.SimulateSubmitOrder(p, side, orderType, amount, price, clientID)
+	}
+
 	var submitOrderResponse exchange.SubmitOrderResponse
 	var oT string
 	if orderType == exchange.Limit {
@@ -208,11 +209,19 @@ func (e *EXMO) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderTy
 // ModifyOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (e *EXMO) ModifyOrder(action exchange.ModifyOrder) (string, error) {
+	if e.IsSimulated() {
+		return e.SimulateModifyOrder(action)
+	}
+
 	return "", common.ErrFunctionNotSupported
 }
 
 // CancelOrder cancels an order by its corresponding ID number
 func (e *EXMO) CancelOrder(order exchange.OrderCancellation) error {
+	if e.IsSimulated() {
+		return e.SimulateCancelOrder(order)
+	}
+
 	orderIDInt, err := strconv.ParseInt(order.OrderID, 10, 64)
 
 	if err != nil {
@@ -224,6 +233,10 @@ func (e *EXMO) CancelOrder(order exchange.OrderCancellation) error {
 
 // CancelAllOrders cancels all orders associated with a currency pair
 func (e *EXMO) CancelAllOrders(orderCancellation exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
+	if e.IsSimulated() {
+		return e.SimulateCancelAllOrders(orderCancellation)
+	}
+
 	cancelAllOrdersResponse := exchange.CancelAllOrdersResponse{
 		OrderStatus: make(map[string]string),
 	}
@@ -244,6 +257,10 @@ func (e *EXMO) CancelAllOrders(orderCancellation exchange.OrderCancellation) (ex
 
 // GetOrderInfo returns information on a current open order
 func (e *EXMO) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
+	if e.IsSimulated() {
+		return e.SimulateGetOrderInfo(orderID)
+	}
+
 	var orderDetail exchange.OrderDetail
 	return orderDetail, common.ErrNotYetImplemented
 }
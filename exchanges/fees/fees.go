@@ -0,0 +1,238 @@
+// Package fees models exchange fee schedules, maker and taker trading rates
+// tiered by 30 day trading volume, discounts for paying fees in an exchange's
+// token such as HT on Huobi and withdrawal fee tables, and holds the schedules
+// of the exchanges which define them.
+package fees
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+)
+
+// VolumePeriod is the trading volume period fee tiers are assessed over
+const VolumePeriod = 30 * 24 * time.Hour
+
+// Errors returned by fee schedules
+var (
+	ErrNoSchedule         = errors.New("exchange has no fee schedule")
+	ErrNoTiers            = errors.New("fee schedule has no tiers")
+	ErrTiersUnordered     = errors.New("fee tiers must start at zero volume in ascending volume order")
+	ErrInvalidRate        = errors.New("fee rates must be between zero and one")
+	ErrNoWithdrawalFee    = errors.New("withdrawal fee not published")
+	ErrNoDiscountCurrency = errors.New("fee schedule has no discount currency")
+)
+
+// Tier is the maker and taker trading fee rates charged on accounts with at
+// least MinVolume traded over the volume period
+type Tier struct {
+	MinVolume float64 `json:"minVolume"`
+	Maker     float64 `json:"maker"`
+	Taker     float64 `json:"taker"`
+}
+
+// Discount is the fraction Rate taken off trading fees paid in Currency, such
+// as BNB on Binance or HT on Huobi
+type Discount struct {
+	Currency string  `json:"currency"`
+	Rate     float64 `json:"rate"`
+}
+
+// Schedule is an exchange's fee schedule. Tiers are in ascending order of
+// trading volume, measured in VolumeCurrency, starting at zero. Withdrawal
+// holds the flat withdrawal fee of each currency in that currency.
+type Schedule struct {
+	VolumeCurrency string             `json:"volumeCurrency"`
+	Tiers          []Tier             `json:"tiers"`
+	Discount       Discount           `json:"discount"`
+	Withdrawal     map[string]float64 `json:"withdrawal"`
+}
+
+// Validate checks the schedule's tiers and discount
+func (s *Schedule) Validate() error {
+	if len(s.Tiers) == 0 {
+		return ErrNoTiers
+	}
+	if s.Tiers[0].MinVolume != 0 {
+		return ErrTiersUnordered
+	}
+	for i := range s.Tiers {
+		if i > 0 && s.Tiers[i].MinVolume <= s.Tiers[i-1].MinVolume {
+			return ErrTiersUnordered
+		}
+		if !validRate(s.Tiers[i].Maker) || !validRate(s.Tiers[i].Taker) {
+			return ErrInvalidRate
+		}
+	}
+	if !validRate(s.Discount.Rate) {
+		return ErrInvalidRate
+	}
+	if s.Discount.Rate > 0 && s.Discount.Currency == "" {
+		return ErrNoDiscountCurrency
+	}
+	return nil
+}
+
+// validRate returns whether a rate is a fraction, maker rebates are not
+// modelled
+func validRate(rate float64) bool {
+	return rate >= 0 && rate <= 1
+}
+
+// Tier returns the tier of an account which traded volume over the volume
+// period
+func (s *Schedule) Tier(volume float64) Tier {
+	i := sort.Search(len(s.Tiers), func(i int) bool {
+		return s.Tiers[i].MinVolume > volume
+	})
+	if i == 0 {
+		return s.Tiers[0]
+	}
+	return s.Tiers[i-1]
+}
+
+// Rate returns the maker or taker rate of an account which traded volume over
+// the volume period, discounted when its fees are paid in the discount
+// currency
+func (s *Schedule) Rate(volume float64, maker, discounted bool) float64 {
+	t := s.Tier(volume)
+	rate := t.Taker
+	if maker {
+		rate = t.Maker
+	}
+	if discounted {
+		rate *= 1 - s.Discount.Rate
+	}
+	return rate
+}
+
+// WithdrawalFee returns the published withdrawal fee of a currency
+func (s *Schedule) WithdrawalFee(currency string) (float64, error) {
+	fee, ok := s.Withdrawal[common.StringToUpper(currency)]
+	if !ok {
+		return 0, ErrNoWithdrawalFee
+	}
+	return fee, nil
+}
+
+// VolumeSource returns an exchange's trading volume in a currency over the
+// volume period ending now
+type VolumeSource func(exchName, currency string) (float64, error)
+
+// registration is a registered schedule and whether the account pays its
+// fees in the discount currency
+type registration struct {
+	schedule   Schedule
+	discounted bool
+}
+
+var (
+	schedules = make(map[string]*registration)
+	volume    VolumeSource
+	m         sync.RWMutex
+)
+
+// Register sets an exchange's fee schedule, keeping whether it is discounted
+func Register(exchName string, s Schedule) error {
+	if err := s.Validate(); err != nil {
+		return fmt.Errorf("%s fee schedule: %s", exchName, err)
+	}
+	m.Lock()
+	defer m.Unlock()
+	key := common.StringToLower(exchName)
+	if r, ok := schedules[key]; ok {
+		r.schedule = s
+		return nil
+	}
+	schedules[key] = &registration{schedule: s}
+	return nil
+}
+
+// Get returns an exchange's fee schedule
+func Get(exchName string) (Schedule, error) {
+	m.RLock()
+	defer m.RUnlock()
+	r, ok := schedules[common.StringToLower(exchName)]
+	if !ok {
+		return Schedule{}, ErrNoSchedule
+	}
+	return r.schedule, nil
+}
+
+// Exchanges returns the names of the exchanges with fee schedules in
+// lowercase
+func Exchanges() []string {
+	m.RLock()
+	defer m.RUnlock()
+	var names []string
+	for name := range schedules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SetDiscount sets whether an exchange account pays its trading fees in the
+// schedule's discount currency
+func SetDiscount(exchName string, discounted bool) error {
+	m.Lock()
+	defer m.Unlock()
+	r, ok := schedules[common.StringToLower(exchName)]
+	if !ok {
+		return ErrNoSchedule
+	}
+	if discounted && r.schedule.Discount.Currency == "" {
+		return ErrNoDiscountCurrency
+	}
+	r.discounted = discounted
+	return nil
+}
+
+// SetVolumeSource sets the source of the exchanges' trading volumes, accounts
+// are charged the first tier's rates without one
+func SetVolumeSource(v VolumeSource) {
+	m.Lock()
+	volume = v
+	m.Unlock()
+}
+
+// TradeRate returns an exchange account's maker or taker trading fee rate
+// from its schedule, tiered by its trading volume and discounted when its
+// fees are paid in the discount currency. The first tier is charged when the
+// volume cannot be retrieved.
+func TradeRate(exchName string, maker bool) (float64, error) {
+	m.RLock()
+	r, ok := schedules[common.StringToLower(exchName)]
+	var s Schedule
+	var discounted bool
+	if ok {
+		s, discounted = r.schedule, r.discounted
+	}
+	v := volume
+	m.RUnlock()
+	if !ok {
+		return 0, ErrNoSchedule
+	}
+
+	var traded float64
+	if v != nil {
+		if amount, err := v(exchName, s.VolumeCurrency); err == nil {
+			traded = amount
+		}
+	}
+	return s.Rate(traded, maker, discounted), nil
+}
+
+// WithdrawalFee returns the published withdrawal fee of a currency on an
+// exchange
+func WithdrawalFee(exchName, currency string) (float64, error) {
+	s, err := Get(exchName)
+	if err != nil {
+		return 0, err
+	}
+	return s.WithdrawalFee(currency)
+}
@@ -0,0 +1,144 @@
+package fees
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+var testSchedule = Schedule{
+	VolumeCurrency: "USDT",
+	Tiers: []Tier{
+		{MinVolume: 0, Maker: 0.002, Taker: 0.002},
+		{MinVolume: 1000, Maker: 0.0015, Taker: 0.0018},
+		{MinVolume: 5000, Maker: 0.001, Taker: 0.0012},
+	},
+	Discount:   Discount{Currency: "HT", Rate: 0.25},
+	Withdrawal: map[string]float64{"BTC": 0.0005},
+}
+
+func TestValidate(t *testing.T) {
+	s := testSchedule
+	if err := s.Validate(); err != nil {
+		t.Error("Test Failed - Validate() error", err)
+	}
+
+	s.Tiers = []Tier{testSchedule.Tiers[1], testSchedule.Tiers[0]}
+	if err := s.Validate(); err != ErrTiersUnordered {
+		t.Error("Test Failed - Validate() expected unordered tiers error", err)
+	}
+	s.Tiers = []Tier{{Taker: 1.5}}
+	if err := s.Validate(); err != ErrInvalidRate {
+		t.Error("Test Failed - Validate() expected invalid rate error", err)
+	}
+	s.Tiers = nil
+	if err := s.Validate(); err != ErrNoTiers {
+		t.Error("Test Failed - Validate() expected no tiers error", err)
+	}
+	s = testSchedule
+	s.Discount.Currency = ""
+	if err := s.Validate(); err != ErrNoDiscountCurrency {
+		t.Error("Test Failed - Validate() expected no discount currency error", err)
+	}
+}
+
+func TestRate(t *testing.T) {
+	s := testSchedule
+	for _, test := range []struct {
+		volume     float64
+		maker      bool
+		discounted bool
+		expected   float64
+	}{
+		{0, false, false, 0.002},
+		{999, true, false, 0.002},
+		{1000, true, false, 0.0015},
+		{4000, false, false, 0.0018},
+		{1e9, false, false, 0.0012},
+		{1e9, true, true, 0.00075},
+		{-1, false, true, 0.0015},
+	} {
+		if rate := s.Rate(test.volume, test.maker, test.discounted); math.Abs(rate-test.expected) > 1e-12 {
+			t.Errorf("Test Failed - Rate(%v, %v, %v) expected %v, received %v",
+				test.volume, test.maker, test.discounted, test.expected, rate)
+		}
+	}
+
+	if fee, err := s.WithdrawalFee("btc"); err != nil || fee != 0.0005 {
+		t.Error("Test Failed - WithdrawalFee() unexpected fee", fee, err)
+	}
+	if _, err := s.WithdrawalFee("XYZ"); err != ErrNoWithdrawalFee {
+		t.Error("Test Failed - WithdrawalFee() expected no withdrawal fee error", err)
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	if err := Register("FeesTest", Schedule{}); err == nil {
+		t.Error("Test Failed - Register() expected invalid schedule error")
+	}
+	if err := Register("FeesTest", testSchedule); err != nil {
+		t.Fatal("Test Failed - Register() error", err)
+	}
+	if _, err := Get("feestest"); err != nil {
+		t.Error("Test Failed - Get() error", err)
+	}
+	if _, err := Get("FeesMissing"); err != ErrNoSchedule {
+		t.Error("Test Failed - Get() expected no schedule error", err)
+	}
+	found := false
+	for _, name := range Exchanges() {
+		found = found || name == "feestest"
+	}
+	if !found {
+		t.Error("Test Failed - Exchanges() expected feestest", Exchanges())
+	}
+
+	volumes := map[string]float64{"FeesTest": 2000}
+	SetVolumeSource(func(exchName, currency string) (float64, error) {
+		if currency != "USDT" {
+			return 0, errors.New("unexpected volume currency")
+		}
+		v, ok := volumes[exchName]
+		if !ok {
+			return 0, errors.New("no volume")
+		}
+		return v, nil
+	})
+	defer SetVolumeSource(nil)
+
+	if rate, err := TradeRate("FeesTest", false); err != nil || rate != 0.0018 {
+		t.Error("Test Failed - TradeRate() unexpected rate", rate, err)
+	}
+	if err := SetDiscount("FeesTest", true); err != nil {
+		t.Fatal("Test Failed - SetDiscount() error", err)
+	}
+	// Reregistering keeps the discount
+	if err := Register("FeesTest", testSchedule); err != nil {
+		t.Fatal("Test Failed - Register() error", err)
+	}
+	if rate, err := TradeRate("FeesTest", true); err != nil || math.Abs(rate-0.001125) > 1e-12 {
+		t.Error("Test Failed - TradeRate() unexpected discounted rate", rate, err)
+	}
+	volumes = map[string]float64{}
+	if rate, err := TradeRate("FeesTest", false); err != nil || math.Abs(rate-0.0015) > 1e-12 {
+		t.Error("Test Failed - TradeRate() expected first tier without volume", rate, err)
+	}
+	if _, err := TradeRate("FeesMissing", false); err != ErrNoSchedule {
+		t.Error("Test Failed - TradeRate() expected no schedule error", err)
+	}
+	if fee, err := WithdrawalFee("FeesTest", "BTC"); err != nil || fee != 0.0005 {
+		t.Error("Test Failed - WithdrawalFee() unexpected fee", fee, err)
+	}
+
+	noDiscount := testSchedule
+	noDiscount.Discount = Discount{}
+	if err := Register("FeesNoDiscount", noDiscount); err != nil {
+		t.Fatal("Test Failed - Register() error", err)
+	}
+	if err := SetDiscount("FeesNoDiscount", true); err != ErrNoDiscountCurrency {
+		t.Error("Test Failed - SetDiscount() expected no discount currency error", err)
+	}
+	if err := SetDiscount("FeesMissing", false); err != ErrNoSchedule {
+		t.Error("Test Failed - SetDiscount() expected no schedule error", err)
+	}
+}
@@ -1,45 +1,71 @@
 package gateio
 
 import (
-	"encoding/json"
+	"bytes"
 	"errors"
 	"fmt"
 	"log"
+	"net/url"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/gorilla/websocket"
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/config"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/assets"
 	"github.com/thrasher-/gocryptotrader/exchanges/request"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
 
 const (
-	gateioTradeURL   = "https://api.gateio.io"
-	gateioMarketURL  = "https://data.gateio.io"
-	gateioAPIVersion = "api2/1"
-
-	gateioSymbol          = "pairs"
-	gateioMarketInfo      = "marketinfo"
-	gateioKline           = "candlestick2"
-	gateioOrder           = "private"
-	gateioBalances        = "private/balances"
-	gateioCancelOrder     = "private/cancelOrder"
-	gateioCancelAllOrders = "private/cancelAllOrders"
-	gateioOpenOrders      = "private/openOrders"
-	gateioTicker          = "ticker"
-	gateioTickers         = "tickers"
-	gateioOrderbook       = "orderBook"
-
-	gateioAuthRate   = 100
-	gateioUnauthRate = 100
+	gateioAPIURL     = "https://api.gateio.ws"
+	gateioAPIVersion = "/api/v4/"
+
+	// Public endpoints
+	gateioCurrencyPairs       = "spot/currency_pairs"
+	gateioMarginCurrencyPairs = "margin/currency_pairs"
+	gateioTickers             = "spot/tickers"
+	gateioOrderbook           = "spot/order_book"
+	gateioTrades              = "spot/trades"
+	gateioCandlesticks        = "spot/candlesticks"
+
+	// Authenticated endpoints
+	gateioSpotAccounts     = "spot/accounts"
+	gateioMarginAccounts   = "margin/accounts"
+	gateioSpotFee          = "spot/fee"
+	gateioSpotOrders       = "spot/orders"
+	gateioSpotOpenOrders   = "spot/open_orders"
+	gateioDepositAddress   = "wallet/deposit_address"
+	gateioDeposits         = "wallet/deposits"
+	gateioWithdrawalRecord = "wallet/withdrawals"
+	gateioWithdraw         = "withdrawals"
+	gateioWalletTransfers  = "wallet/transfers"
+
+	// Gate.io allows 200 public requests per 10 seconds per endpoint and 10
+	// order requests per second
+	gateioAuthRate   = 10
+	gateioUnauthRate = 200
+
+	gateioOrdersLimit = 100
+	gateioCandleLimit = 1000
+
+	// gateioClientIDPrefix must prefix client order IDs
+	gateioClientIDPrefix = "t-"
+
+	// gateioMarginLiquidationRisk is the risk rate, an isolated margin
+	// account's assets as a multiple of its liabilities, at which it is
+	// liquidated
+	gateioMarginLiquidationRisk = 1.1
 )
 
 // Gateio is the overarching type across this package
 type Gateio struct {
 	exchange.Base
+	WebsocketConn *websocket.Conn
+	wsWriteLock   sync.Mutex
 }
 
 // SetDefaults sets default values for the exchange
@@ -49,21 +75,52 @@ func (g *Gateio) SetDefaults() {
 	g.Verbose = false
 	g.RESTPollingDelay = 10
 	g.APIWithdrawPermissions = exchange.AutoWithdrawCrypto
+	g.BrokerTagFormat = exchange.BrokerTagFormat{MaxLength: 32}
+	g.Features = exchange.Features{
+		CanGetTicker:          true,
+		CanGetOrderbook:       true,
+		CanGetTradeHistory:    true,
+		CanGetHistoricCandles: true,
+		CanGetAccountInfo:     true,
+		CanGetFundingHistory:  true,
+		CanSubmitOrder:        true,
+		CanCancelOrder:        true,
+		CanCancelAllOrders:    true,
+		CanGetOrderInfo:       true,
+		CanGetActiveOrders:    true,
+		CanGetOrderHistory:    true,
+		CanGetDepositAddress:  true,
+		CanWithdrawCrypto:     true,
+		CanStreamTicker:       true,
+		CanStreamOrderbook:    true,
+		CanStreamTrades:       true,
+	}
+	g.Features.Orders = map[string]exchange.OrderCapabilities{
+		assets.Spot: {
+			OrderTypes:  []exchange.OrderType{exchange.Limit, exchange.Market, exchange.ImmediateOrCancel},
+			TimeInForce: []exchange.TimeInForce{exchange.GTC, exchange.IOC},
+		},
+	}
 	g.RequestCurrencyPairFormat.Delimiter = "_"
-	g.RequestCurrencyPairFormat.Uppercase = false
+	g.RequestCurrencyPairFormat.Uppercase = true
 	g.ConfigCurrencyPairFormat.Delimiter = "_"
 	g.ConfigCurrencyPairFormat.Uppercase = true
-	g.AssetTypes = []string{ticker.Spot}
+	g.AssetTypes = []string{ticker.Spot, assets.Margin}
+	g.AssetPairs = map[string]*exchange.AssetPairs{
+		assets.Margin: {
+			AvailablePairs: []string{"BTC_USDT", "ETH_USDT"},
+			EnabledPairs:   []string{"BTC_USDT"},
+		},
+	}
+	g.MarketBuyInQuote = true
 	g.SupportsAutoPairUpdating = true
 	g.SupportsRESTTickerBatching = true
 	g.Requester = request.New(g.Name,
-		request.NewRateLimit(time.Second*10, gateioAuthRate),
+		request.NewRateLimit(time.Second, gateioAuthRate),
 		request.NewRateLimit(time.Second*10, gateioUnauthRate),
 		common.NewHTTPClientWithTimeout(exchange.DefaultHTTPTimeout))
-	g.APIUrlDefault = gateioTradeURL
+	g.APIUrlDefault = gateioAPIURL
 	g.APIUrl = g.APIUrlDefault
-	g.APIUrlSecondaryDefault = gateioMarketURL
-	g.APIUrlSecondary = g.APIUrlSecondaryDefault
 	g.WebsocketInit()
 }
 
@@ -103,393 +160,430 @@ func (g *Gateio) Setup(exch config.ExchangeConfig) {
 		if err != nil {
 			log.Fatal(err)
 		}
+		err = g.SetTLSPins(exch.TLSPins)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = g.SetLocalAddress(exch.LocalAddress)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = g.SetRoundingModes(exch.RoundingModes)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = g.SetRateLimitTiers(exch.RateLimitTiers, exch.AccountTier)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = g.SetHTTPClientHeaders(exch.HTTPHeaders)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = g.SetBrokerTag(exch.BrokerTag)
+		if err != nil {
+			log.Fatal(err)
+		}
+		g.SetSimulation(exch.Simulate, exch.SimulationBalances)
+		err = g.WebsocketSetup(g.WsConnect,
+			exch.Name,
+			exch.Websocket,
+			gateioWebsocketURL,
+			exch.WebsocketURL)
+		if err != nil {
+			log.Fatal(err)
+		}
+		g.Websocket.SetSubscriber(g.wsDefaultSubscriptions, g.WsSubscribe, g.WsUnsubscribe)
 	}
 }
 
-// GetSymbols returns all supported symbols
-func (g *Gateio) GetSymbols() ([]string, error) {
-	var result []string
-
-	url := fmt.Sprintf("%s/%s/%s", g.APIUrlSecondary, gateioAPIVersion, gateioSymbol)
-
-	err := g.SendHTTPRequest(url, &result)
-	if err != nil {
-		return nil, nil
-	}
-	return result, err
+// GetCurrencyPairs returns the trading rules of all spot currency pairs
+func (g *Gateio) GetCurrencyPairs() ([]CurrencyPair, error) {
+	var resp []CurrencyPair
+	return resp, g.SendHTTPRequest(g.APIUrl+gateioAPIVersion+gateioCurrencyPairs, &resp)
 }
 
-// GetMarketInfo returns information about all trading pairs, including
-// transaction fee, minimum order quantity, price accuracy and so on
-func (g *Gateio) GetMarketInfo() (MarketInfoResponse, error) {
-	type response struct {
-		Result string        `json:"result"`
-		Pairs  []interface{} `json:"pairs"`
-	}
-
-	url := fmt.Sprintf("%s/%s/%s", g.APIUrlSecondary, gateioAPIVersion, gateioMarketInfo)
-
-	var res response
-	var result MarketInfoResponse
-	err := g.SendHTTPRequest(url, &res)
-	if err != nil {
-		return result, err
-	}
-
-	result.Result = res.Result
-	for _, v := range res.Pairs {
-		item := v.(map[string]interface{})
-		for itemk, itemv := range item {
-			pairv := itemv.(map[string]interface{})
-			result.Pairs = append(result.Pairs, MarketInfoPairsResponse{
-				Symbol:        itemk,
-				DecimalPlaces: pairv["decimal_places"].(float64),
-				MinAmount:     pairv["min_amount"].(float64),
-				Fee:           pairv["fee"].(float64),
-			})
-		}
-	}
-	return result, nil
+// GetCurrencyPair returns the trading rules of a spot currency pair
+func (g *Gateio) GetCurrencyPair(symbol string) (CurrencyPair, error) {
+	var resp CurrencyPair
+	return resp, g.SendHTTPRequest(g.APIUrl+gateioAPIVersion+gateioCurrencyPairs+"/"+symbol, &resp)
 }
 
-// GetLatestSpotPrice returns latest spot price of symbol
-// updated every 10 seconds
-//
-// symbol: string of currency pair
-func (g *Gateio) GetLatestSpotPrice(symbol string) (float64, error) {
-	res, err := g.GetTicker(symbol)
-	if err != nil {
-		return 0, err
-	}
-
-	return res.Last, nil
+// GetMarginCurrencyPairs returns the currency pairs which may be traded on
+// margin
+func (g *Gateio) GetMarginCurrencyPairs() ([]MarginCurrencyPair, error) {
+	var resp []MarginCurrencyPair
+	return resp, g.SendHTTPRequest(g.APIUrl+gateioAPIVersion+gateioMarginCurrencyPairs, &resp)
 }
 
-// GetTicker returns a ticker for the supplied symbol
-// updated every 10 seconds
-func (g *Gateio) GetTicker(symbol string) (TickerResponse, error) {
-	url := fmt.Sprintf("%s/%s/%s/%s", g.APIUrlSecondary, gateioAPIVersion, gateioTicker, symbol)
-
-	var res TickerResponse
-	err := g.SendHTTPRequest(url, &res)
-	if err != nil {
-		return res, err
+// GetTickers returns the tickers of all currency pairs, or of a single pair
+// when symbol is set
+func (g *Gateio) GetTickers(symbol string) ([]Ticker, error) {
+	var resp []Ticker
+	params := url.Values{}
+	if symbol != "" {
+		params.Set("currency_pair", symbol)
 	}
-	return res, nil
-}
-
-// GetTickers returns tickers for all symbols
-func (g *Gateio) GetTickers() (map[string]TickerResponse, error) {
-	url := fmt.Sprintf("%s/%s/%s", g.APIUrlSecondary, gateioAPIVersion, gateioTickers)
 
-	resp := make(map[string]TickerResponse)
-	err := g.SendHTTPRequest(url, &resp)
-	if err != nil {
-		return nil, err
-	}
-	return resp, nil
+	path := common.EncodeURLValues(g.APIUrl+gateioAPIVersion+gateioTickers, params)
+	return resp, g.SendHTTPRequest(path, &resp)
 }
 
-// GetOrderbook returns the orderbook data for a suppled symbol
-func (g *Gateio) GetOrderbook(symbol string) (Orderbook, error) {
-	url := fmt.Sprintf("%s/%s/%s/%s", g.APIUrlSecondary, gateioAPIVersion, gateioOrderbook, symbol)
-
+// GetOrderbook returns the orderbook of a currency pair, depth is capped at
+// 100 levels per side
+func (g *Gateio) GetOrderbook(symbol string, depth int64) (Orderbook, error) {
 	var resp OrderbookResponse
-	err := g.SendHTTPRequest(url, &resp)
-	if err != nil {
-		return Orderbook{}, err
+	params := url.Values{}
+	params.Set("currency_pair", symbol)
+	params.Set("with_id", "true")
+	if depth > 0 {
+		params.Set("limit", strconv.FormatInt(depth, 10))
 	}
 
-	if resp.Result != "true" {
-		return Orderbook{}, errors.New("result was not true")
+	path := common.EncodeURLValues(g.APIUrl+gateioAPIVersion+gateioOrderbook, params)
+	err := g.SendHTTPRequest(path, &resp)
+	if err != nil {
+		return Orderbook{}, err
 	}
 
-	var ob Orderbook
-
-	if len(resp.Asks) == 0 {
-		return ob, errors.New("asks are empty")
+	ob := Orderbook{ID: resp.ID, Timestamp: resp.Current.Time()}
+	ob.Bids, err = parseOrderbookLevels(resp.Bids)
+	if err != nil {
+		return ob, err
 	}
+	ob.Asks, err = parseOrderbookLevels(resp.Asks)
+	return ob, err
+}
 
-	// Asks are in reverse order
-	for x := len(resp.Asks) - 1; x != 0; x-- {
-		data := resp.Asks[x]
-
-		price, err := strconv.ParseFloat(data[0], 64)
+// parseOrderbookLevels converts [price, amount] levels
+func parseOrderbookLevels(levels [][2]string) ([]OrderbookItem, error) {
+	items := make([]OrderbookItem, len(levels))
+	for i := range levels {
+		price, err := strconv.ParseFloat(levels[i][0], 64)
 		if err != nil {
-			continue
+			return nil, err
 		}
-
-		amount, err := strconv.ParseFloat(data[1], 64)
+		amount, err := strconv.ParseFloat(levels[i][1], 64)
 		if err != nil {
-			continue
+			return nil, err
 		}
-
-		ob.Asks = append(ob.Asks, OrderbookItem{Price: price, Amount: amount})
+		items[i] = OrderbookItem{Price: price, Amount: amount}
 	}
+	return items, nil
+}
 
-	if len(resp.Bids) == 0 {
-		return ob, errors.New("bids are empty")
+// GetTrades returns up to 1000 recent public trades of a currency pair
+func (g *Gateio) GetTrades(symbol string, limit int64) ([]Trade, error) {
+	var resp []Trade
+	params := url.Values{}
+	params.Set("currency_pair", symbol)
+	if limit > 0 {
+		params.Set("limit", strconv.FormatInt(limit, 10))
 	}
 
-	for x := range resp.Bids {
-		data := resp.Bids[x]
+	path := common.EncodeURLValues(g.APIUrl+gateioAPIVersion+gateioTrades, params)
+	return resp, g.SendHTTPRequest(path, &resp)
+}
 
-		price, err := strconv.ParseFloat(data[0], 64)
-		if err != nil {
-			continue
+// GetCandlesticks returns up to 1000 candles of a currency pair, oldest
+// first. Interval is one of 10s 1m 5m 15m 30m 1h 4h 8h 1d 7d 30d. The limit
+// is ignored when start and end are set.
+func (g *Gateio) GetCandlesticks(symbol, interval string, start, end time.Time, limit int64) ([]Candle, error) {
+	var resp [][]string
+	params := url.Values{}
+	params.Set("currency_pair", symbol)
+	params.Set("interval", interval)
+	if !start.IsZero() && !end.IsZero() {
+		params.Set("from", strconv.FormatInt(start.Unix(), 10))
+		params.Set("to", strconv.FormatInt(end.Unix(), 10))
+	} else if limit > 0 {
+		params.Set("limit", strconv.FormatInt(limit, 10))
+	}
+
+	path := common.EncodeURLValues(g.APIUrl+gateioAPIVersion+gateioCandlesticks, params)
+	err := g.SendHTTPRequest(path, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return parseCandlesticks(resp)
+}
+
+// parseCandlesticks converts [time, quote volume, close, high, low, open,
+// base volume] candles, older responses without the base volume fall back
+// to the quote volume
+func parseCandlesticks(raw [][]string) ([]Candle, error) {
+	candles := make([]Candle, 0, len(raw))
+	for i := range raw {
+		if len(raw[i]) < 6 {
+			return nil, fmt.Errorf("unexpected candle length %d", len(raw[i]))
 		}
 
-		amount, err := strconv.ParseFloat(data[1], 64)
-		if err != nil {
-			continue
+		var values [7]float64
+		for j := 0; j < len(raw[i]) && j < len(values); j++ {
+			v, err := strconv.ParseFloat(raw[i][j], 64)
+			if err != nil {
+				return nil, err
+			}
+			values[j] = v
 		}
 
-		ob.Bids = append(ob.Bids, OrderbookItem{Price: price, Amount: amount})
+		volume := values[1]
+		if len(raw[i]) > 6 {
+			volume = values[6]
+		}
+		candles = append(candles, Candle{
+			Timestamp: time.Unix(int64(values[0]), 0).UTC(),
+			Open:      values[5],
+			High:      values[3],
+			Low:       values[4],
+			Close:     values[2],
+			Volume:    volume,
+		})
 	}
-
-	ob.Result = resp.Result
-	ob.Elapsed = resp.Elapsed
-	return ob, nil
+	return candles, nil
 }
 
-// GetSpotKline returns kline data for the most recent time period
-func (g *Gateio) GetSpotKline(arg KlinesRequestParams) ([]*KLineResponse, error) {
-	url := fmt.Sprintf("%s/%s/%s/%s?group_sec=%d&range_hour=%d",
-		g.APIUrlSecondary,
-		gateioAPIVersion,
-		gateioKline,
-		arg.Symbol,
-		arg.GroupSec,
-		arg.HourSize)
-
-	var rawKlines map[string]interface{}
-	err := g.SendHTTPRequest(url, &rawKlines)
-	if err != nil {
-		return nil, err
+// GetSpotAccounts returns the spot account balances, currency is optional
+func (g *Gateio) GetSpotAccounts(currency string) ([]SpotAccount, error) {
+	var resp []SpotAccount
+	params := url.Values{}
+	if currency != "" {
+		params.Set("currency", currency)
 	}
+	return resp, g.SendAuthenticatedHTTPRequest("GET", gateioSpotAccounts, params, nil, &resp)
+}
 
-	var result []*KLineResponse
-	if rawKlines == nil || rawKlines["data"] == nil {
-		return nil, fmt.Errorf("rawKlines is nil. Err: %s", err)
+// GetMarginAccounts returns the isolated margin accounts, symbol is optional
+func (g *Gateio) GetMarginAccounts(symbol string) ([]MarginAccount, error) {
+	var resp []MarginAccount
+	params := url.Values{}
+	if symbol != "" {
+		params.Set("currency_pair", symbol)
 	}
+	return resp, g.SendAuthenticatedHTTPRequest("GET", gateioMarginAccounts, params, nil, &resp)
+}
 
-	rawKlineDatasString, _ := json.Marshal(rawKlines["data"].([]interface{}))
-	rawKlineDatas := [][]interface{}{}
-	if err := json.Unmarshal(rawKlineDatasString, &rawKlineDatas); err != nil {
-		return nil, fmt.Errorf("rawKlines unmarshal failed. Err: %s", err)
+// GetSpotFee returns the account's trading fee rates, symbol is optional
+func (g *Gateio) GetSpotFee(symbol string) (SpotFee, error) {
+	var resp SpotFee
+	params := url.Values{}
+	if symbol != "" {
+		params.Set("currency_pair", symbol)
 	}
+	return resp, g.SendAuthenticatedHTTPRequest("GET", gateioSpotFee, params, nil, &resp)
+}
 
-	for _, k := range rawKlineDatas {
-		otString, _ := strconv.ParseFloat(k[0].(string), 64)
-		ot, err := common.TimeFromUnixTimestampFloat(otString)
-		if err != nil {
-			return nil, fmt.Errorf("cannot parse Kline.OpenTime. Err: %s", err)
-		}
-		_vol, err := common.FloatFromString(k[1])
-		if err != nil {
-			return nil, fmt.Errorf("cannot parse Kline.Volume. Err: %s", err)
-		}
-		_id, err := common.FloatFromString(k[0])
-		if err != nil {
-			return nil, fmt.Errorf("cannot parse Kline.Id. Err: %s", err)
-		}
-		_close, err := common.FloatFromString(k[2])
+// PlaceOrder places a new spot or margin order
+func (g *Gateio) PlaceOrder(arg PlaceOrderRequest) (Order, error) {
+	var resp Order
+	return resp, g.SendAuthenticatedHTTPRequest("POST", gateioSpotOrders, nil, arg, &resp)
+}
+
+// GetOrder returns an order of a currency pair
+func (g *Gateio) GetOrder(orderID, symbol string) (Order, error) {
+	var resp Order
+	params := url.Values{}
+	params.Set("currency_pair", symbol)
+	return resp, g.SendAuthenticatedHTTPRequest("GET", gateioSpotOrders+"/"+orderID, params, nil, &resp)
+}
+
+// GetOrders returns the open or finished orders of a currency pair, paging
+// through the orders endpoint
+func (g *Gateio) GetOrders(symbol, status string) ([]Order, error) {
+	var orders []Order
+	for page := 1; ; page++ {
+		var resp []Order
+		params := url.Values{}
+		params.Set("currency_pair", symbol)
+		params.Set("status", status)
+		params.Set("page", strconv.Itoa(page))
+		params.Set("limit", strconv.Itoa(gateioOrdersLimit))
+
+		err := g.SendAuthenticatedHTTPRequest("GET", gateioSpotOrders, params, nil, &resp)
 		if err != nil {
-			return nil, fmt.Errorf("cannot parse Kline.Close. Err: %s", err)
+			return nil, err
 		}
-		_high, err := common.FloatFromString(k[3])
-		if err != nil {
-			return nil, fmt.Errorf("cannot parse Kline.High. Err: %s", err)
+		orders = append(orders, resp...)
+		if len(resp) < gateioOrdersLimit {
+			return orders, nil
 		}
-		_low, err := common.FloatFromString(k[4])
+	}
+}
+
+// GetOpenOrders returns the open orders of all currency pairs, paging through
+// the open orders endpoint
+func (g *Gateio) GetOpenOrders() ([]OpenOrders, error) {
+	var orders []OpenOrders
+	for page := 1; ; page++ {
+		var resp []OpenOrders
+		params := url.Values{}
+		params.Set("page", strconv.Itoa(page))
+		params.Set("limit", strconv.Itoa(gateioOrdersLimit))
+
+		err := g.SendAuthenticatedHTTPRequest("GET", gateioSpotOpenOrders, params, nil, &resp)
 		if err != nil {
-			return nil, fmt.Errorf("cannot parse Kline.Low. Err: %s", err)
+			return nil, err
 		}
-		_open, err := common.FloatFromString(k[5])
-		if err != nil {
-			return nil, fmt.Errorf("cannot parse Kline.Open. Err: %s", err)
+		orders = append(orders, resp...)
+		if len(resp) < gateioOrdersLimit {
+			return orders, nil
 		}
-		result = append(result, &KLineResponse{
-			ID:        _id,
-			KlineTime: ot,
-			Volume:    _vol,
-			Close:     _close,
-			High:      _high,
-			Low:       _low,
-			Open:      _open,
-		})
 	}
-	return result, nil
 }
 
-// GetBalances obtains the users account balance
-func (g *Gateio) GetBalances() (BalancesResponse, error) {
-	var result BalancesResponse
-
-	return result,
-		g.SendAuthenticatedHTTPRequest("POST", gateioBalances, "", &result)
+// CancelExistingOrder cancels an order of a currency pair and returns it
+func (g *Gateio) CancelExistingOrder(orderID, symbol string) (Order, error) {
+	var resp Order
+	params := url.Values{}
+	params.Set("currency_pair", symbol)
+	return resp, g.SendAuthenticatedHTTPRequest("DELETE", gateioSpotOrders+"/"+orderID, params, nil, &resp)
 }
 
-// SpotNewOrder places a new order
-func (g *Gateio) SpotNewOrder(arg SpotNewOrderRequestParams) (SpotNewOrderResponse, error) {
-	var result SpotNewOrderResponse
-
-	// Be sure to use the correct price precision before calling this
-	params := fmt.Sprintf("currencyPair=%s&rate=%s&amount=%s",
-		arg.Symbol,
-		strconv.FormatFloat(arg.Price, 'f', -1, 64),
-		strconv.FormatFloat(arg.Amount, 'f', -1, 64),
-	)
-
-	strRequestURL := fmt.Sprintf("%s/%s", gateioOrder, arg.Type)
-
-	err := g.SendAuthenticatedHTTPRequest("POST", strRequestURL, params, &result)
-	if err != nil {
-		return result, err
+// CancelAllExistingOrders cancels the open orders of a currency pair and
+// returns them, side is optional
+func (g *Gateio) CancelAllExistingOrders(symbol, side string) ([]Order, error) {
+	var resp []Order
+	params := url.Values{}
+	params.Set("currency_pair", symbol)
+	if side != "" {
+		params.Set("side", side)
 	}
-
-	return result, nil
+	return resp, g.SendAuthenticatedHTTPRequest("DELETE", gateioSpotOrders, params, nil, &resp)
 }
 
-// CancelExistingOrder cancels an order given the supplied orderID and symbol
-// orderID order ID number
-// symbol trade pair (ltc_btc)
-func (g *Gateio) CancelExistingOrder(orderID int64, symbol string) (bool, error) {
-	type response struct {
-		Result  bool   `json:"result"`
-		Code    int    `json:"code"`
-		Message string `json:"message"`
-	}
-
-	var result response
-	// Be sure to use the correct price precision before calling this
-	params := fmt.Sprintf("orderNumber=%d&currencyPair=%s",
-		orderID,
-		symbol,
-	)
-	err := g.SendAuthenticatedHTTPRequest("POST", gateioCancelOrder, params, &result)
-	if err != nil {
-		return false, err
-	}
-	if !result.Result {
-		return false, fmt.Errorf("code:%d message:%s", result.Code, result.Message)
-	}
-
-	return true, nil
+// GetDepositAddresses returns the deposit addresses of a currency on all
+// chains
+func (g *Gateio) GetDepositAddresses(currency string) (DepositAddress, error) {
+	var resp DepositAddress
+	params := url.Values{}
+	params.Set("currency", currency)
+	return resp, g.SendAuthenticatedHTTPRequest("GET", gateioDepositAddress, params, nil, &resp)
 }
 
-// SendHTTPRequest sends an unauthenticated HTTP request
-func (g *Gateio) SendHTTPRequest(path string, result interface{}) error {
-	return g.SendPayload("GET", path, nil, nil, result, false, g.Verbose)
+// Withdraw submits an on chain withdrawal and returns its ID
+func (g *Gateio) Withdraw(arg WithdrawalRequest) (string, error) {
+	var resp Transfer
+	return resp.ID, g.SendAuthenticatedHTTPRequest("POST", gateioWithdraw, nil, arg, &resp)
 }
 
-// CancelAllExistingOrders all orders for a given symbol and side
-// orderType (0: sell,1: buy,-1: unlimited)
-func (g *Gateio) CancelAllExistingOrders(orderType int64, symbol string) error {
-	type response struct {
-		Result  bool   `json:"result"`
-		Code    int    `json:"code"`
-		Message string `json:"message"`
-	}
-
-	var result response
-	params := fmt.Sprintf("type=%d&currencyPair=%s",
-		orderType,
-		symbol,
-	)
-	err := g.SendAuthenticatedHTTPRequest("POST", gateioCancelAllOrders, params, &result)
-	if err != nil {
-		return err
-	}
-
-	if !result.Result {
-		return fmt.Errorf("code:%d message:%s", result.Code, result.Message)
-	}
-
-	return nil
+// TransferBetweenAccounts transfers funds between the account types, the
+// currency pair must be set for transfers to and from isolated margin
+// accounts
+func (g *Gateio) TransferBetweenAccounts(arg AccountTransferRequest) error {
+	return g.SendAuthenticatedHTTPRequest("POST", gateioWalletTransfers, nil, arg, nil)
 }
 
-//
-// GetOpenOrders retrieves all orders with an optional symbol filter
-func (g *Gateio) GetOpenOrders(symbol string) (OpenOrdersResponse, error) {
-	var params string
-	var result OpenOrdersResponse
-
-	if symbol != "" {
-		params = fmt.Sprintf("currencyPair=%s", symbol)
-	}
+// GetDeposits returns deposits from the last 30 days, currency is optional
+func (g *Gateio) GetDeposits(currency string) ([]Transfer, error) {
+	return g.getTransfers(gateioDeposits, currency)
+}
 
-	err := g.SendAuthenticatedHTTPRequest("POST", gateioOpenOrders, params, &result)
-	if err != nil {
-		return result, err
-	}
+// GetWithdrawals returns withdrawals from the last 30 days, currency is
+// optional
+func (g *Gateio) GetWithdrawals(currency string) ([]Transfer, error) {
+	return g.getTransfers(gateioWithdrawalRecord, currency)
+}
 
-	if result.Code > 0 {
-		return result, fmt.Errorf("code:%d message:%s", result.Code, result.Message)
+// getTransfers queries a deposit or withdrawal record endpoint
+func (g *Gateio) getTransfers(endpoint, currency string) ([]Transfer, error) {
+	var resp []Transfer
+	params := url.Values{}
+	if currency != "" {
+		params.Set("currency", currency)
 	}
+	return resp, g.SendAuthenticatedHTTPRequest("GET", endpoint, params, nil, &resp)
+}
 
-	return result, nil
+// SendHTTPRequest sends an unauthenticated HTTP request
+func (g *Gateio) SendHTTPRequest(path string, result interface{}) error {
+	return g.SendPayload("GET", path, nil, nil, result, false, g.Verbose)
 }
 
-// SendAuthenticatedHTTPRequest sends authenticated requests to the Gateio API
-// To use this you must setup an APIKey and APISecret from the exchange
-func (g *Gateio) SendAuthenticatedHTTPRequest(method, endpoint, param string, result interface{}) error {
+// SendAuthenticatedHTTPRequest sends an authenticated APIv4 request, params
+// are encoded in the query string and data is sent as the JSON body
+func (g *Gateio) SendAuthenticatedHTTPRequest(method, endpoint string, params url.Values, data, result interface{}) error {
 	if !g.AuthenticatedAPISupport {
 		return fmt.Errorf(exchange.WarningAuthenticatedRequestWithoutCredentialsSet, g.Name)
 	}
 
-	headers := make(map[string]string)
-	headers["Content-Type"] = "application/x-www-form-urlencoded"
-	headers["key"] = g.APIKey
-
-	hmac := common.GetHMAC(common.HashSHA512, []byte(param), []byte(g.APISecret))
-	headers["sign"] = common.HexEncodeToString(hmac)
-
-	url := fmt.Sprintf("%s/%s/%s", g.APIUrl, gateioAPIVersion, endpoint)
-
-	var intermidiary json.RawMessage
+	path := gateioAPIVersion + endpoint
+	query := params.Encode()
 
-	err := g.SendPayload(method, url, headers, strings.NewReader(param), &intermidiary, true, g.Verbose)
-	if err != nil {
-		return err
-	}
+	var payload []byte
+	if data != nil {
+		var err error
+		payload, err = common.JSONEncode(data)
+		if err != nil {
+			return errors.New("SendAuthenticatedHTTPRequest: Unable to JSON request")
+		}
 
-	errCap := struct {
-		Result  bool   `json:"result,string"`
-		Code    int    `json:"code"`
-		Message string `json:"message"`
-	}{}
-
-	if err := common.JSONDecode(intermidiary, &errCap); err == nil {
-		if !errCap.Result {
-			return fmt.Errorf("GateIO auth request error, code: %d message: %s",
-				errCap.Code,
-				errCap.Message)
+		if g.Verbose {
+			log.Printf("Request JSON: %s\n", payload)
 		}
 	}
 
-	return common.JSONDecode(intermidiary, result)
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	headers := make(map[string]string)
+	headers["KEY"] = g.APIKey
+	headers["Timestamp"] = timestamp
+	headers["SIGN"] = g.sign(method, path, query, payload, timestamp)
+	headers["Content-Type"] = "application/json"
+	headers["Accept"] = "application/json"
+	if g.BrokerTag != "" {
+		headers["X-Gate-Channel-Id"] = g.BrokerTag
+	}
+
+	return g.SendPayload(method,
+		common.EncodeURLValues(g.APIUrl+path, params),
+		headers,
+		bytes.NewBuffer(payload),
+		result,
+		true,
+		g.Verbose)
+}
+
+// sign returns the hex encoded HMAC-SHA512 APIv4 signature of the method,
+// path, query string, hex encoded SHA512 hash of the body and timestamp,
+// separated by new lines
+func (g *Gateio) sign(method, path, query string, body []byte, timestamp string) string {
+	payload := strings.Join([]string{
+		method,
+		path,
+		query,
+		common.HexEncodeToString(common.GetSHA512(body)),
+		timestamp,
+	}, "\n")
+	hmac := common.GetHMAC(common.HashSHA512, []byte(payload), []byte(g.APISecret))
+	return common.HexEncodeToString(hmac)
 }
 
 // GetFee returns an estimate of fee based on type of transaction
 func (g *Gateio) GetFee(feeBuilder exchange.FeeBuilder) (fee float64, err error) {
 	switch feeBuilder.FeeType {
 	case exchange.CryptocurrencyTradeFee:
-		feePairs, err := g.GetMarketInfo()
-		if err != nil {
-			return 0, err
-		}
-		currencyPair := feeBuilder.FirstCurrency + feeBuilder.Delimiter + feeBuilder.SecondCurrency
-		var feeForPair float64
-		for _, i := range feePairs.Pairs {
-			if strings.EqualFold(currencyPair, i.Symbol) {
-				feeForPair = i.Fee
+		symbol := common.StringToUpper(feeBuilder.FirstCurrency + "_" + feeBuilder.SecondCurrency)
+		var rate float64
+		if g.AuthenticatedAPISupport {
+			rates, err := g.GetSpotFee(symbol)
+			if err != nil {
+				return 0, err
 			}
+			rate = rates.TakerFee.Float64()
+			if feeBuilder.IsMaker {
+				rate = rates.MakerFee.Float64()
+			}
+		} else {
+			// The currency pair's fee is a percentage
+			p, err := g.GetCurrencyPair(symbol)
+			if err != nil {
+				return 0, err
+			}
+			if p.Fee == 0 {
+				return 0, fmt.Errorf("Currency: '%s' failed to find fee data", symbol)
+			}
+			rate = p.Fee.Float64() / 100
 		}
-		if feeForPair == 0 {
-			return 0, fmt.Errorf("Currency: '%s' failed to find fee data", currencyPair)
-		}
-		fee = calculateTradingFee(feeForPair, feeBuilder.PurchasePrice, feeBuilder.Amount)
+		fee = rate * feeBuilder.PurchasePrice * feeBuilder.Amount
 	case exchange.CryptocurrencyWithdrawalFee:
 		fee = getCryptocurrencyWithdrawalFee(feeBuilder.FirstCurrency)
 	}
@@ -501,10 +595,6 @@ func (g *Gateio) GetFee(feeBuilder exchange.FeeBuilder) (fee float64, err error)
 	return fee, nil
 }
 
-func calculateTradingFee(feeForPair, purchasePrice, amount float64) float64 {
-	return (feeForPair / 100) * purchasePrice * amount
-}
-
 func getCryptocurrencyWithdrawalFee(currency string) float64 {
 	return WithdrawalFees[currency]
 }
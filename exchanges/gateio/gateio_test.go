@@ -1,12 +1,19 @@
 package gateio
 
 import (
+	"encoding/json"
+	"math"
 	"testing"
+	"time"
 
 	"github.com/thrasher-/gocryptotrader/config"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
 	"github.com/thrasher-/gocryptotrader/currency/symbol"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/assets"
+	"github.com/thrasher-/gocryptotrader/exchanges/conformance"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
 
 // Please supply your own APIKEYS here for due diligence testing
@@ -38,109 +45,162 @@ func TestSetup(t *testing.T) {
 	g.Setup(gateioConfig)
 }
 
-func TestGetSymbols(t *testing.T) {
-	t.Parallel()
-	_, err := g.GetSymbols()
+func TestConformance(t *testing.T) {
+	cfg := config.GetConfig()
+	cfg.LoadConfig("../../testdata/configtest.json")
+	gateioConfig, err := cfg.GetExchangeConfig("GateIO")
 	if err != nil {
-		t.Errorf("Test failed - Gateio TestGetSymbols: %s", err)
+		t.Fatal("Test Failed - GateIO conformance init error", err)
 	}
+
+	conformance.Run(t, func() exchange.IBotExchange { return new(Gateio) }, gateioConfig)
 }
 
-func TestGetMarketInfo(t *testing.T) {
+func TestGetCurrencyPairs(t *testing.T) {
 	t.Parallel()
-	_, err := g.GetMarketInfo()
+	_, err := g.GetCurrencyPairs()
 	if err != nil {
-		t.Errorf("Test failed - Gateio GetMarketInfo: %s", err)
+		t.Error("Test Failed - Gateio GetCurrencyPairs() error", err)
 	}
 }
 
-func TestSpotNewOrder(t *testing.T) {
+func TestGetMarginCurrencyPairs(t *testing.T) {
 	t.Parallel()
-
-	if apiKey == "" || apiSecret == "" {
-		t.Skip()
-	}
-
-	_, err := g.SpotNewOrder(SpotNewOrderRequestParams{
-		Symbol: "btc_usdt",
-		Amount: 1.1,
-		Price:  10.1,
-		Type:   SpotNewOrderRequestParamsTypeSell,
-	})
+	_, err := g.GetMarginCurrencyPairs()
 	if err != nil {
-		t.Errorf("Test failed - Gateio SpotNewOrder: %s", err)
+		t.Error("Test Failed - Gateio GetMarginCurrencyPairs() error", err)
 	}
 }
 
-func TestCancelExistingOrder(t *testing.T) {
+func TestGetTickers(t *testing.T) {
 	t.Parallel()
-
-	if apiKey == "" || apiSecret == "" {
-		t.Skip()
-	}
-
-	_, err := g.CancelExistingOrder(917591554, "btc_usdt")
+	_, err := g.GetTickers("BTC_USDT")
 	if err != nil {
-		t.Errorf("Test failed - Gateio CancelExistingOrder: %s", err)
+		t.Error("Test Failed - Gateio GetTickers() error", err)
 	}
 }
 
-func TestGetBalances(t *testing.T) {
+func TestGetOrderbook(t *testing.T) {
 	t.Parallel()
-
-	if apiKey == "" || apiSecret == "" {
-		t.Skip()
-	}
-
-	_, err := g.GetBalances()
+	_, err := g.GetOrderbook("BTC_USDT", 10)
 	if err != nil {
-		t.Errorf("Test failed - Gateio GetBalances: %s", err)
+		t.Error("Test Failed - Gateio GetOrderbook() error", err)
 	}
 }
 
-func TestGetLatestSpotPrice(t *testing.T) {
+func TestGetTrades(t *testing.T) {
 	t.Parallel()
-	_, err := g.GetLatestSpotPrice("btc_usdt")
+	_, err := g.GetTrades("BTC_USDT", 10)
 	if err != nil {
-		t.Errorf("Test failed - Gateio GetLatestSpotPrice: %s", err)
+		t.Error("Test Failed - Gateio GetTrades() error", err)
 	}
 }
 
-func TestGetTicker(t *testing.T) {
+func TestGetCandlesticks(t *testing.T) {
 	t.Parallel()
-	_, err := g.GetTicker("btc_usdt")
+	_, err := g.GetCandlesticks("BTC_USDT", "5m", time.Time{}, time.Time{}, 10)
 	if err != nil {
-		t.Errorf("Test failed - Gateio GetTicker: %s", err)
+		t.Error("Test Failed - Gateio GetCandlesticks() error", err)
 	}
 }
 
-func TestGetTickers(t *testing.T) {
+func TestParseCandlesticks(t *testing.T) {
 	t.Parallel()
-	_, err := g.GetTickers()
+	candles, err := parseCandlesticks([][]string{
+		{"1539852480", "971519.67", "6523.51", "6524.43", "6521.05", "6522.18", "148.94"},
+		{"1539852540", "104.6", "6523", "6523", "6523", "6523"},
+	})
 	if err != nil {
-		t.Errorf("Test failed - Gateio GetTicker: %s", err)
+		t.Fatal("Test Failed - parseCandlesticks() error", err)
+	}
+	if len(candles) != 2 || candles[0].Open != 6522.18 || candles[0].Close != 6523.51 ||
+		candles[0].Volume != 148.94 || candles[0].Timestamp.Unix() != 1539852480 {
+		t.Error("Test Failed - parseCandlesticks() incorrect values", candles)
+	}
+	if candles[1].Volume != 104.6 {
+		t.Error("Test Failed - parseCandlesticks() expected quote volume fallback", candles[1])
+	}
+
+	_, err = parseCandlesticks([][]string{{"1539852480", "1"}})
+	if err == nil {
+		t.Error("Test Failed - parseCandlesticks() expected error on short candle")
 	}
 }
 
-func TestGetOrderbook(t *testing.T) {
+func TestNumberUnmarshal(t *testing.T) {
 	t.Parallel()
-	_, err := g.GetOrderbook("btc_usdt")
+	var resp struct {
+		A Number `json:"a"`
+		B Number `json:"b"`
+		C Time   `json:"c"`
+	}
+	err := json.Unmarshal([]byte(`{"a":"1.5","b":"","c":1539852480123.456}`), &resp)
 	if err != nil {
-		t.Errorf("Test failed - Gateio GetTicker: %s", err)
+		t.Fatal("Test Failed - Number UnmarshalJSON() error", err)
+	}
+	if resp.A.Float64() != 1.5 || resp.B.Float64() != 0 {
+		t.Error("Test Failed - Number UnmarshalJSON() incorrect values")
+	}
+	if resp.C.Time().UnixNano()/int64(time.Millisecond) != 1539852480123 {
+		t.Error("Test Failed - Time UnmarshalJSON() incorrect value", resp.C.Time())
 	}
 }
 
-func TestGetSpotKline(t *testing.T) {
-	t.Parallel()
+func TestBuildSpotOrder(t *testing.T) {
+	g.SetDefaults()
+	p := pair.NewCurrencyPairDelimiter("BTC_USDT", "_")
 
-	_, err := g.GetSpotKline(KlinesRequestParams{
-		Symbol:   "btc_usdt",
-		GroupSec: TimeIntervalFiveMinutes, // 5 minutes or less
-		HourSize: 1,                       // 1 hour data
-	})
+	req, err := g.buildSpotOrder(p, exchange.Buy, exchange.Limit, 0.5, 10000, "abc")
+	if err != nil {
+		t.Fatal("Test Failed - buildSpotOrder() error", err)
+	}
+	if req.CurrencyPair != "BTC_USDT" || req.Side != OrderSideBuy || req.Type != OrderTypeLimit ||
+		req.Price != "10000" || req.Amount != "0.5" || req.TimeInForce != TimeInForceGTC ||
+		req.Text != "t-abc" || req.Account != AccountSpot {
+		t.Error("Test Failed - buildSpotOrder() incorrect limit order", req)
+	}
 
+	req, err = g.buildSpotOrder(p, exchange.Buy, exchange.Market, 100, 0, "t-abc")
 	if err != nil {
-		t.Errorf("Test failed - Gateio GetSpotKline: %s", err)
+		t.Fatal("Test Failed - buildSpotOrder() error", err)
+	}
+	if req.Price != "" || req.Amount != "100" || req.TimeInForce != TimeInForceIOC || req.Text != "t-abc" {
+		t.Error("Test Failed - buildSpotOrder() incorrect market order", req)
+	}
+
+	req, err = g.buildSpotOrder(p, exchange.Sell, exchange.ImmediateOrCancel, 1, 1, "")
+	if err != nil || req.Type != OrderTypeLimit || req.TimeInForce != TimeInForceIOC || req.Text != "" {
+		t.Error("Test Failed - buildSpotOrder() incorrect IOC order", req, err)
+	}
+
+	_, err = g.buildSpotOrder(p, exchange.Buy, exchange.OrderType("STOP"), 1, 1, "")
+	if err == nil {
+		t.Error("Test Failed - buildSpotOrder() expected unsupported order type error")
+	}
+}
+
+func TestSign(t *testing.T) {
+	g.SetDefaults()
+	g.APIKey = "key"
+	g.APISecret = "secret"
+	defer func() {
+		g.APIKey = apiKey
+		g.APISecret = apiSecret
+	}()
+
+	sig := g.sign("GET", "/api/v4/spot/accounts", "currency=BTC", nil, "1539852480")
+	if len(sig) != 128 {
+		t.Error("Test Failed - sign() expected hex encoded SHA512", sig)
+	}
+	if sig == g.sign("GET", "/api/v4/spot/accounts", "currency=ETH", nil, "1539852480") {
+		t.Error("Test Failed - sign() query not included in signature")
+	}
+	if sig == g.sign("GET", "/api/v4/spot/accounts", "currency=BTC", []byte("{}"), "1539852480") {
+		t.Error("Test Failed - sign() body not included in signature")
+	}
+	if g.wsAuthSign(gateioWsOrders, gateioWsSubscribe, 1539852480) ==
+		g.wsAuthSign(gateioWsBalances, gateioWsSubscribe, 1539852480) {
+		t.Error("Test Failed - wsAuthSign() channel not included in signature")
 	}
 }
 
@@ -352,6 +412,134 @@ func TestGetAccountInfo(t *testing.T) {
 	}
 }
 
+func TestAccountBalances(t *testing.T) {
+	var spot []SpotAccount
+	err := json.Unmarshal([]byte(`[{"currency":"btc","available":"1","locked":"0.5"}]`), &spot)
+	if err != nil {
+		t.Fatal("Test Failed - accountBalances() spot decode error", err)
+	}
+	var margin []MarginAccount
+	err = json.Unmarshal([]byte(`[
+		{"currency_pair":"BTC_USDT","risk":"1.5",
+			"base":{"currency":"BTC","available":"0.5","locked":"0","borrowed":"0","interest":"0"},
+			"quote":{"currency":"USDT","available":"100","locked":"50","borrowed":"1000","interest":"1"}},
+		{"currency_pair":"ETH_USDT","risk":"3",
+			"base":{"currency":"ETH","available":"2","locked":"0","borrowed":"0","interest":"0"},
+			"quote":{"currency":"USDT","available":"200","locked":"0","borrowed":"0","interest":"0"}}]`), &margin)
+	if err != nil {
+		t.Fatal("Test Failed - accountBalances() margin decode error", err)
+	}
+
+	currencies := accountBalances(spot, margin)
+	if len(currencies) != 4 {
+		t.Fatalf("Test Failed - accountBalances() expected 4 currencies received %v", currencies)
+	}
+	if c := currencies[0]; c.CurrencyName != "BTC" || c.TotalValue != 1.5 || c.Hold != 0.5 ||
+		c.AssetType != assets.Spot {
+		t.Error("Test Failed - accountBalances() incorrect spot balance", c)
+	}
+	if c := currencies[1]; c.CurrencyName != "BTC" || c.TotalValue != 0.5 || c.AssetType != assets.Margin {
+		t.Error("Test Failed - accountBalances() incorrect margin balance", c)
+	}
+	if c := currencies[2]; c.CurrencyName != "USDT" || c.TotalValue != -651 || c.Hold != 50 ||
+		c.AssetType != assets.Margin {
+		t.Error("Test Failed - accountBalances() expected merged borrowed USDT", c)
+	}
+}
+
+func TestMarginLevels(t *testing.T) {
+	var margin []MarginAccount
+	err := json.Unmarshal([]byte(`[
+		{"currency_pair":"BTC_USDT","risk":"2.2",
+			"base":{"currency":"BTC","available":"0.5","borrowed":"0"},
+			"quote":{"currency":"USDT","available":"100","borrowed":"1000"}},
+		{"currency_pair":"ETH_USDT","risk":"1000",
+			"base":{"currency":"ETH","available":"2","borrowed":"0"},
+			"quote":{"currency":"USDT","available":"200","borrowed":"0"}}]`), &margin)
+	if err != nil {
+		t.Fatal("Test Failed - marginLevels() decode error", err)
+	}
+
+	levels := marginLevels(margin, time.Now())
+	if len(levels) != 1 {
+		t.Fatal("Test Failed - marginLevels() expected only accounts with borrowed funds", levels)
+	}
+	if levels[0].Pair.Pair().String() != "BTC_USDT" || levels[0].AssetType != assets.Margin ||
+		math.Abs(levels[0].MarginRatio-0.5) > 1e-9 {
+		t.Error("Test Failed - marginLevels() incorrect margin level", levels[0])
+	}
+}
+
+func TestTransferCollateral(t *testing.T) {
+	g.SetDefaults()
+	p := pair.NewCurrencyPairDelimiter("BTC_USDT", "_")
+	if err := g.TransferCollateral(p, assets.PerpetualSwap, "USDT", 1); err == nil {
+		t.Error("Test Failed - TransferCollateral() expected unsupported asset type error")
+	}
+	if err := g.TransferCollateral(p, assets.Margin, "ETH", 1); err == nil {
+		t.Error("Test Failed - TransferCollateral() expected currency outside of pair error")
+	}
+	if err := g.TransferCollateral(p, assets.Margin, "usdt", 0); err == nil {
+		t.Error("Test Failed - TransferCollateral() expected amount error")
+	}
+}
+
+func TestWsHandleTicker(t *testing.T) {
+	g.SetDefaults()
+	g.Websocket.DataHandler = make(chan interface{}, 1)
+
+	err := g.wsHandleMessage([]byte(`{"time":1606292218,"channel":"spot.tickers","event":"update",
+		"result":{"currency_pair":"BTC_USDT","last":"19106.55","lowest_ask":"19108.71","highest_bid":"19106.55",
+		"base_volume":"7630.91","high_24h":"19630.72","low_24h":"18602.08"}}`))
+	if err != nil {
+		t.Fatal("Test Failed - wsHandleMessage() ticker error", err)
+	}
+	tick, ok := (<-g.Websocket.DataHandler).(exchange.TickerData)
+	if !ok {
+		t.Fatal("Test Failed - wsHandleMessage() expected ticker data")
+	}
+	if tick.ClosePrice != 19106.55 || tick.HighPrice != 19630.72 || tick.Quantity != 7630.91 ||
+		tick.Pair.Pair().String() != "BTC_USDT" || tick.Timestamp.Unix() != 1606292218 {
+		t.Error("Test Failed - wsHandleMessage() incorrect ticker", tick)
+	}
+
+	err = g.wsHandleMessage([]byte(`{"time":1606292218,"channel":"spot.tickers","event":"subscribe",
+		"result":{"status":"success"}}`))
+	if err != nil || len(g.Websocket.DataHandler) != 0 {
+		t.Error("Test Failed - wsHandleMessage() expected acknowledgement to be dropped", err)
+	}
+
+	err = g.wsHandleMessage([]byte(`{"time":1606292218,"channel":"spot.orders","event":"subscribe",
+		"error":{"code":2,"message":"invalid argument"}}`))
+	if err == nil {
+		t.Error("Test Failed - wsHandleMessage() expected subscription error")
+	}
+}
+
+func TestWsHandleOrderbook(t *testing.T) {
+	g.SetDefaults()
+	g.Websocket.DataHandler = make(chan interface{}, 1)
+
+	err := g.wsHandleMessage([]byte(`{"time":1606295412,"channel":"spot.order_book","event":"update",
+		"result":{"t":1606295412123,"lastUpdateId":48791820,"s":"ETH_USDT",
+		"bids":[["1000.5","1.5"],["1000","2"]],"asks":[["1001","0.5"]]}}`))
+	if err != nil {
+		t.Fatal("Test Failed - wsHandleMessage() orderbook error", err)
+	}
+	update, ok := (<-g.Websocket.DataHandler).(exchange.WebsocketOrderbookUpdate)
+	if !ok || update.Pair.Pair().String() != "ETH_USDT" {
+		t.Fatal("Test Failed - wsHandleMessage() expected orderbook update", update)
+	}
+
+	ob, err := orderbook.GetOrderbook(g.Name, update.Pair, ticker.Spot)
+	if err != nil {
+		t.Fatal("Test Failed - wsHandleMessage() orderbook not stored", err)
+	}
+	if len(ob.Bids) != 2 || ob.Bids[0].Price != 1000.5 || len(ob.Asks) != 1 || ob.Asks[0].Amount != 0.5 {
+		t.Error("Test Failed - wsHandleMessage() incorrect orderbook", ob)
+	}
+}
+
 func TestModifyOrder(t *testing.T) {
 	_, err := g.ModifyOrder(exchange.ModifyOrder{})
 	if err == nil {
@@ -1,102 +1,138 @@
 package gateio
 
 import (
+	"encoding/json"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/thrasher-/gocryptotrader/currency/symbol"
 )
 
-// SpotNewOrderRequestParamsType order type (buy or sell)
-type SpotNewOrderRequestParamsType string
+// Order types, sides and time in force
+const (
+	OrderTypeLimit  = "limit"
+	OrderTypeMarket = "market"
 
-var (
-	// SpotNewOrderRequestParamsTypeBuy buy order
-	SpotNewOrderRequestParamsTypeBuy = SpotNewOrderRequestParamsType("buy")
+	OrderSideBuy  = "buy"
+	OrderSideSell = "sell"
 
-	// SpotNewOrderRequestParamsTypeSell sell order
-	SpotNewOrderRequestParamsTypeSell = SpotNewOrderRequestParamsType("sell")
+	TimeInForceGTC = "gtc"
+	TimeInForceIOC = "ioc"
 )
 
-// TimeInterval Interval represents interval enum.
-type TimeInterval int
-
-// TimeInterval vars
-var (
-	TimeIntervalMinute         = TimeInterval(60)
-	TimeIntervalThreeMinutes   = TimeInterval(60 * 3)
-	TimeIntervalFiveMinutes    = TimeInterval(60 * 5)
-	TimeIntervalFifteenMinutes = TimeInterval(60 * 15)
-	TimeIntervalThirtyMinutes  = TimeInterval(60 * 30)
-	TimeIntervalHour           = TimeInterval(60 * 60)
-	TimeIntervalTwoHours       = TimeInterval(2 * 60 * 60)
-	TimeIntervalFourHours      = TimeInterval(4 * 60 * 60)
-	TimeIntervalSixHours       = TimeInterval(6 * 60 * 60)
-	TimeIntervalDay            = TimeInterval(60 * 60 * 24)
+// Order statuses used when listing orders
+const (
+	OrderStatusOpen     = "open"
+	OrderStatusFinished = "finished"
 )
 
-// MarketInfoResponse holds the market info data
-type MarketInfoResponse struct {
-	Result string                    `json:"result"`
-	Pairs  []MarketInfoPairsResponse `json:"pairs"`
+// Accounts an order may be placed in
+const (
+	AccountSpot   = "spot"
+	AccountMargin = "margin"
+)
+
+// TradeStatusTradable is the trade status of currency pairs which may be
+// traded
+const TradeStatusTradable = "tradable"
+
+// Number is a Gate.io string encoded number, empty strings decode to zero
+type Number float64
+
+// UnmarshalJSON implements the json.Unmarshaler interface
+func (n *Number) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "" || s == "null" {
+		*n = 0
+		return nil
+	}
+
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return err
+	}
+	*n = Number(f)
+	return nil
 }
 
-// MarketInfoPairsResponse holds the market info response data
-type MarketInfoPairsResponse struct {
-	Symbol string
-	// DecimalPlaces symbol price accuracy
-	DecimalPlaces float64
-	// MinAmount minimum order amount
-	MinAmount float64
-	// Fee transaction fee
-	Fee float64
+// Float64 returns the number as a float64
+func (n Number) Float64() float64 {
+	return float64(n)
 }
 
-// BalancesResponse holds the user balances
-type BalancesResponse struct {
-	Result    string              `json:"result"`
-	Available []map[string]string `json:"available"`
-	Locked    []map[string]string `json:"locked"`
+// Time is a Gate.io millisecond timestamp, encoded as either a string or a
+// number which may have a fractional part
+type Time time.Time
+
+// UnmarshalJSON implements the json.Unmarshaler interface
+func (t *Time) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "" || s == "null" || s == "0" {
+		*t = Time(time.Time{})
+		return nil
+	}
+
+	ms, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return err
+	}
+	*t = Time(time.Unix(0, int64(ms*float64(time.Millisecond))).UTC())
+	return nil
 }
 
-// KlinesRequestParams represents Klines request data.
-type KlinesRequestParams struct {
-	Symbol   string // Required field; example LTCBTC,BTCUSDT
-	HourSize int    // How many hours of data
-	GroupSec TimeInterval
+// Time returns the timestamp as a time.Time
+func (t Time) Time() time.Time {
+	return time.Time(t)
 }
 
-// KLineResponse holds the kline response data
-type KLineResponse struct {
-	ID        float64
-	KlineTime time.Time
-	Open      float64
-	Time      float64
-	High      float64
-	Low       float64
-	Close     float64
-	Volume    float64
-	Amount    float64 `db:"amount"`
+// CurrencyPair holds the trading rules of a spot currency pair, Precision is
+// the number of decimal places of its price and AmountPrecision of its amount
+type CurrencyPair struct {
+	ID              string `json:"id"`
+	Base            string `json:"base"`
+	Quote           string `json:"quote"`
+	Fee             Number `json:"fee"`
+	MinBaseAmount   Number `json:"min_base_amount"`
+	MinQuoteAmount  Number `json:"min_quote_amount"`
+	AmountPrecision int64  `json:"amount_precision"`
+	Precision       int64  `json:"precision"`
+	TradeStatus     string `json:"trade_status"`
+}
+
+// MarginCurrencyPair holds a currency pair which may be traded on margin,
+// Status is one for enabled pairs
+type MarginCurrencyPair struct {
+	ID             string `json:"id"`
+	Base           string `json:"base"`
+	Quote          string `json:"quote"`
+	Leverage       int64  `json:"leverage"`
+	MinBaseAmount  Number `json:"min_base_amount"`
+	MinQuoteAmount Number `json:"min_quote_amount"`
+	Status         int64  `json:"status"`
 }
 
-// TickerResponse  holds the ticker response data
-type TickerResponse struct {
-	Result        string  `json:"result"`
-	Volume        float64 `json:"baseVolume,string"`    // Trading volume
-	High          float64 `json:"high24hr,string"`      // 24 hour high price
-	Open          float64 `json:"highestBid,string"`    // Openening price
-	Last          float64 `json:"last,string"`          // Last price
-	Low           float64 `json:"low24hr,string"`       // 24 hour low price
-	Close         float64 `json:"lowestAsk,string"`     // Closing price
-	PercentChange float64 `json:"percentChange,string"` // Percentage change
-	QuoteVolume   float64 `json:"quoteVolume,string"`   // Quote currency volume
+// Ticker holds the 24 hour ticker of a currency pair
+type Ticker struct {
+	CurrencyPair     string `json:"currency_pair"`
+	Last             Number `json:"last"`
+	LowestAsk        Number `json:"lowest_ask"`
+	HighestBid       Number `json:"highest_bid"`
+	ChangePercentage Number `json:"change_percentage"`
+	BaseVolume       Number `json:"base_volume"`
+	QuoteVolume      Number `json:"quote_volume"`
+	High24h          Number `json:"high_24h"`
+	Low24h           Number `json:"low_24h"`
 }
 
-// OrderbookResponse stores the orderbook data
+// OrderbookResponse is the raw orderbook of a currency pair, Current is when
+// the response was generated and Update when the orderbook last changed
 type OrderbookResponse struct {
-	Result  string `json:"result"`
-	Elapsed string `json:"elapsed"`
-	Asks    [][]string
-	Bids    [][]string
+	ID      int64       `json:"id"`
+	Current Time        `json:"current"`
+	Update  Time        `json:"update"`
+	Asks    [][2]string `json:"asks"`
+	Bids    [][2]string `json:"bids"`
 }
 
 // OrderbookItem stores an orderbook item
@@ -105,54 +141,214 @@ type OrderbookItem struct {
 	Amount float64
 }
 
-// Orderbook stores the orderbook data
+// Orderbook stores the orderbook data, ID is the orderbook's update ID
 type Orderbook struct {
-	Result  string
-	Elapsed string
-	Bids    []OrderbookItem
-	Asks    []OrderbookItem
+	ID        int64
+	Timestamp time.Time
+	Bids      []OrderbookItem
+	Asks      []OrderbookItem
+}
+
+// Trade holds a public trade
+type Trade struct {
+	ID           string `json:"id"`
+	CreateTimeMs Time   `json:"create_time_ms"`
+	CurrencyPair string `json:"currency_pair"`
+	Side         string `json:"side"`
+	Amount       Number `json:"amount"`
+	Price        Number `json:"price"`
+}
+
+// Candle holds a single candle, Volume is in the base currency
+type Candle struct {
+	Timestamp time.Time
+	Open      float64
+	High      float64
+	Low       float64
+	Close     float64
+	Volume    float64
+}
+
+// SpotAccount holds the balance of a currency in the spot account
+type SpotAccount struct {
+	Currency  string `json:"currency"`
+	Available Number `json:"available"`
+	Locked    Number `json:"locked"`
+}
+
+// MarginBalance holds the balance of one currency of an isolated margin
+// account, Borrowed and Interest are owed to the exchange
+type MarginBalance struct {
+	Currency  string `json:"currency"`
+	Available Number `json:"available"`
+	Locked    Number `json:"locked"`
+	Borrowed  Number `json:"borrowed"`
+	Interest  Number `json:"interest"`
+}
+
+// MarginAccount holds the isolated margin account of a currency pair, Risk is
+// the account's margin level
+type MarginAccount struct {
+	CurrencyPair string        `json:"currency_pair"`
+	Locked       bool          `json:"locked"`
+	Risk         Number        `json:"risk"`
+	Base         MarginBalance `json:"base"`
+	Quote        MarginBalance `json:"quote"`
+}
+
+// SpotFee holds the account's trading fee rates
+type SpotFee struct {
+	UserID     int64  `json:"user_id"`
+	TakerFee   Number `json:"taker_fee"`
+	MakerFee   Number `json:"maker_fee"`
+	GTDiscount bool   `json:"gt_discount"`
+}
+
+// PlaceOrderRequest holds the parameters of a new order. Text is a client
+// order ID which must be prefixed with t-. Market buys are sized in the
+// quote currency.
+type PlaceOrderRequest struct {
+	Text         string `json:"text,omitempty"`
+	CurrencyPair string `json:"currency_pair"`
+	Type         string `json:"type"`
+	Account      string `json:"account"`
+	Side         string `json:"side"`
+	Amount       string `json:"amount"`
+	Price        string `json:"price,omitempty"`
+	TimeInForce  string `json:"time_in_force,omitempty"`
+}
+
+// Order holds a spot or margin order, Left is the amount still to be filled
+// and FilledTotal the quote currency amount filled
+type Order struct {
+	ID           string `json:"id"`
+	Text         string `json:"text"`
+	CreateTimeMs Time   `json:"create_time_ms"`
+	UpdateTimeMs Time   `json:"update_time_ms"`
+	Status       string `json:"status"`
+	CurrencyPair string `json:"currency_pair"`
+	Type         string `json:"type"`
+	Account      string `json:"account"`
+	Side         string `json:"side"`
+	Amount       Number `json:"amount"`
+	Price        Number `json:"price"`
+	TimeInForce  string `json:"time_in_force"`
+	Left         Number `json:"left"`
+	FilledTotal  Number `json:"filled_total"`
+	AvgDealPrice Number `json:"avg_deal_price"`
+	Fee          Number `json:"fee"`
+	FeeCurrency  string `json:"fee_currency"`
+	FinishAs     string `json:"finish_as"`
+	Event        string `json:"event,omitempty"`
+}
+
+// OpenOrders holds the open orders of a currency pair
+type OpenOrders struct {
+	CurrencyPair string  `json:"currency_pair"`
+	Total        int64   `json:"total"`
+	Orders       []Order `json:"orders"`
+}
+
+// ChainAddress holds a deposit address on a chain
+type ChainAddress struct {
+	Chain        string `json:"chain"`
+	Address      string `json:"address"`
+	PaymentID    string `json:"payment_id"`
+	PaymentName  string `json:"payment_name"`
+	ObtainFailed int64  `json:"obtain_failed"`
+}
+
+// DepositAddress holds the deposit addresses of a currency
+type DepositAddress struct {
+	Currency            string         `json:"currency"`
+	Address             string         `json:"address"`
+	MultichainAddresses []ChainAddress `json:"multichain_addresses"`
+}
+
+// WithdrawalRequest holds the parameters of an on chain withdrawal
+type WithdrawalRequest struct {
+	Currency string `json:"currency"`
+	Address  string `json:"address"`
+	Amount   string `json:"amount"`
+	Memo     string `json:"memo,omitempty"`
+	Chain    string `json:"chain,omitempty"`
+}
+
+// AccountTransferRequest holds the parameters of a transfer between account
+// types, CurrencyPair identifies the isolated margin account
+type AccountTransferRequest struct {
+	Currency     string `json:"currency"`
+	From         string `json:"from"`
+	To           string `json:"to"`
+	Amount       string `json:"amount"`
+	CurrencyPair string `json:"currency_pair,omitempty"`
+}
+
+// Transfer holds a deposit or withdrawal record, Timestamp is in seconds
+type Transfer struct {
+	ID        string `json:"id"`
+	TxID      string `json:"txid"`
+	Timestamp Number `json:"timestamp"`
+	Amount    Number `json:"amount"`
+	Fee       Number `json:"fee"`
+	Currency  string `json:"currency"`
+	Address   string `json:"address"`
+	Memo      string `json:"memo"`
+	Status    string `json:"status"`
+	Chain     string `json:"chain"`
+}
+
+// WsRequest is a websocket channel request, Auth is set for private channels
+type WsRequest struct {
+	Time    int64    `json:"time"`
+	ID      int64    `json:"id,omitempty"`
+	Channel string   `json:"channel"`
+	Event   string   `json:"event,omitempty"`
+	Payload []string `json:"payload,omitempty"`
+	Auth    *WsAuth  `json:"auth,omitempty"`
+}
+
+// WsAuth authenticates a private channel request
+type WsAuth struct {
+	Method string `json:"method"`
+	Key    string `json:"KEY"`
+	Sign   string `json:"SIGN"`
 }
 
-// SpotNewOrderRequestParams Order params
-type SpotNewOrderRequestParams struct {
-	Amount float64                       `json:"amount"` // Order quantity
-	Price  float64                       `json:"price"`  // Order price
-	Symbol string                        `json:"symbol"` // Trading pair; btc_usdt, eth_btc......
-	Type   SpotNewOrderRequestParamsType `json:"type"`   // Order type (buy or sell),
+// WsError is returned when a websocket request fails
+type WsError struct {
+	Code    int64  `json:"code"`
+	Message string `json:"message"`
 }
 
-// SpotNewOrderResponse Order response
-type SpotNewOrderResponse struct {
-	OrderNumber  int64   `json:"orderNumber"`         // OrderID number
-	Price        float64 `json:"rate,string"`         // Order price
-	LeftAmount   float64 `json:"leftAmount,string"`   // The remaining amount to fill
-	FilledAmount float64 `json:"filledAmount,string"` // The filled amount
-	Filledrate   float64 `json:"filledRate,string"`   // FilledPrice
+// WsResponse is a websocket message, Event is update for channel data and
+// the request's event for acknowledgements
+type WsResponse struct {
+	Time    int64           `json:"time"`
+	ID      int64           `json:"id"`
+	Channel string          `json:"channel"`
+	Event   string          `json:"event"`
+	Error   *WsError        `json:"error"`
+	Result  json.RawMessage `json:"result"`
 }
 
-// OpenOrdersResponse the main response from GetOpenOrders
-type OpenOrdersResponse struct {
-	Code    int         `json:"code"`
-	Elapsed string      `json:"elapsed"`
-	Message string      `json:"message"`
-	Orders  []OpenOrder `json:"orders"`
-	Result  string      `json:"result"`
+// WsOrderbook is a limited level orderbook snapshot
+type WsOrderbook struct {
+	Timestamp    Time        `json:"t"`
+	LastUpdateID int64       `json:"lastUpdateId"`
+	CurrencyPair string      `json:"s"`
+	Bids         [][2]string `json:"bids"`
+	Asks         [][2]string `json:"asks"`
 }
 
-// OpenOrder details each open order
-type OpenOrder struct {
-	Amount        string  `json:"amount"`
-	CurrencyPair  string  `json:"currencyPair"`
-	FilledAmount  int     `json:"filledAmount"`
-	FilledRate    int     `json:"filledRate"`
-	InitialAmount string  `json:"initialAmount"`
-	InitialRate   float64 `json:"initialRate"`
-	OrderNumber   string  `json:"orderNumber"`
-	Rate          float64 `json:"rate"`
-	Status        string  `json:"status"`
-	Timestamp     string  `json:"timestamp"`
-	Total         string  `json:"total"`
-	Type          string  `json:"type"`
+// WsBalance is a spot balance change
+type WsBalance struct {
+	TimestampMs Time   `json:"timestamp_ms"`
+	User        string `json:"user"`
+	Currency    string `json:"currency"`
+	Change      Number `json:"change"`
+	Total       Number `json:"total"`
+	Available   Number `json:"available"`
 }
 
 // WithdrawalFees the large list of predefined withdrawal fees
@@ -0,0 +1,377 @@
+package gateio
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/assets"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+const (
+	gateioWebsocketURL = "wss://api.gateio.ws/ws/v4/"
+
+	// Public channels
+	gateioWsTickers   = "spot.tickers"
+	gateioWsTrades    = "spot.trades"
+	gateioWsOrderbook = "spot.order_book"
+	gateioWsPing      = "spot.ping"
+
+	// Private channels
+	gateioWsOrders   = "spot.orders"
+	gateioWsBalances = "spot.balances"
+
+	// Events
+	gateioWsSubscribe   = "subscribe"
+	gateioWsUnsubscribe = "unsubscribe"
+	gateioWsUpdate      = "update"
+
+	// Orderbook snapshots of the top 20 levels are pushed every 100ms
+	gateioWsOrderbookLevels   = "20"
+	gateioWsOrderbookInterval = "100ms"
+
+	// gateioWsAllPairs subscribes private channels to every currency pair
+	gateioWsAllPairs = "!all"
+
+	gateioWsPingInterval = time.Second * 15
+)
+
+// WsConnect initiates a websocket connection and, when authenticated API
+// support is enabled, subscribes to order and balance updates
+func (g *Gateio) WsConnect() error {
+	if !g.Websocket.IsEnabled() || !g.IsEnabled() {
+		return errors.New(exchange.WebsocketNotEnabled)
+	}
+
+	var dialer websocket.Dialer
+	dialer.TLSClientConfig = g.GetTLSConfig()
+	dialer.NetDial = g.GetWebsocketNetDial()
+	if g.Websocket.GetProxyAddress() != "" {
+		proxy, err := url.Parse(g.Websocket.GetProxyAddress())
+		if err != nil {
+			return err
+		}
+		dialer.Proxy = http.ProxyURL(proxy)
+	}
+
+	var err error
+	g.WebsocketConn, _, err = dialer.Dial(g.Websocket.GetWebsocketURL(), http.Header{})
+	if err != nil {
+		return fmt.Errorf("%s unable to connect to websocket %s. Error: %s",
+			g.Name,
+			g.Websocket.GetWebsocketURL(),
+			err)
+	}
+
+	go g.WsReadData()
+	go g.wsPingHandler()
+	go g.WsHandleData()
+
+	if !g.AuthenticatedAPISupport {
+		return nil
+	}
+	return g.WsSubscribePrivate()
+}
+
+// wsWrite sends a JSON message over the websocket connection
+func (g *Gateio) wsWrite(data interface{}) error {
+	if g.WebsocketConn == nil {
+		return errors.New("websocket connection not established")
+	}
+
+	payload, err := common.JSONEncode(data)
+	if err != nil {
+		return err
+	}
+
+	g.wsWriteLock.Lock()
+	defer g.wsWriteLock.Unlock()
+	return g.WebsocketConn.WriteMessage(websocket.TextMessage, payload)
+}
+
+// wsAuthSign returns the hex encoded HMAC-SHA512 signature authenticating a
+// private channel request
+func (g *Gateio) wsAuthSign(channel, event string, timestamp int64) string {
+	hmac := common.GetHMAC(common.HashSHA512,
+		[]byte(fmt.Sprintf("channel=%s&event=%s&time=%d", channel, event, timestamp)),
+		[]byte(g.APISecret))
+	return common.HexEncodeToString(hmac)
+}
+
+// wsRequest returns a channel request, authenticated when private
+func (g *Gateio) wsRequest(channel, event string, payload []string, private bool) WsRequest {
+	req := WsRequest{
+		Time:    time.Now().Unix(),
+		Channel: channel,
+		Event:   event,
+		Payload: payload,
+	}
+	if private {
+		req.Auth = &WsAuth{
+			Method: "api_key",
+			Key:    g.APIKey,
+			Sign:   g.wsAuthSign(channel, event, req.Time),
+		}
+	}
+	return req
+}
+
+// wsDefaultSubscriptions returns the ticker, trade and orderbook channels of
+// all enabled spot pairs
+func (g *Gateio) wsDefaultSubscriptions() []exchange.ChannelSubscription {
+	var subs []exchange.ChannelSubscription
+	for _, p := range g.GetEnabledCurrencies() {
+		for _, channel := range []string{gateioWsTickers, gateioWsTrades, gateioWsOrderbook} {
+			subs = append(subs, exchange.ChannelSubscription{
+				Channel:   channel,
+				Currency:  p,
+				AssetType: assets.Spot,
+			})
+		}
+	}
+	return subs
+}
+
+// WsSubscribe subscribes to public channels
+func (g *Gateio) WsSubscribe(subs []exchange.ChannelSubscription) error {
+	return g.wsWriteSubscriptions(gateioWsSubscribe, subs)
+}
+
+// WsUnsubscribe unsubscribes from public channels
+func (g *Gateio) WsUnsubscribe(subs []exchange.ChannelSubscription) error {
+	return g.wsWriteSubscriptions(gateioWsUnsubscribe, subs)
+}
+
+// wsWriteSubscriptions sends a subscribe or unsubscribe request for each
+// public channel, orderbook channels also carry their depth and interval
+func (g *Gateio) wsWriteSubscriptions(event string, subs []exchange.ChannelSubscription) error {
+	for i := range subs {
+		payload := []string{exchange.FormatExchangeCurrency(g.Name, subs[i].Currency).String()}
+		if subs[i].Channel == gateioWsOrderbook {
+			payload = append(payload, gateioWsOrderbookLevels, gateioWsOrderbookInterval)
+		}
+		err := g.wsWrite(g.wsRequest(subs[i].Channel, event, payload, false))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WsSubscribePrivate subscribes to order updates of all currency pairs and
+// spot balance changes
+func (g *Gateio) WsSubscribePrivate() error {
+	err := g.wsWrite(g.wsRequest(gateioWsOrders, gateioWsSubscribe, []string{gateioWsAllPairs}, true))
+	if err != nil {
+		return err
+	}
+	return g.wsWrite(g.wsRequest(gateioWsBalances, gateioWsSubscribe, nil, true))
+}
+
+// WsReadData reads data from the websocket connection
+func (g *Gateio) WsReadData() {
+	g.Websocket.Wg.Add(1)
+
+	defer func() {
+		err := g.WebsocketConn.Close()
+		if err != nil {
+			g.Websocket.DataHandler <- fmt.Errorf("gateio_websocket.go - Unable to to close Websocket connection. Error: %s",
+				err)
+		}
+		g.Websocket.Wg.Done()
+	}()
+
+	for {
+		select {
+		case <-g.Websocket.ShutdownC:
+			return
+
+		default:
+			_, resp, err := g.WebsocketConn.ReadMessage()
+			if err != nil {
+				g.Websocket.DataHandler <- err
+				return
+			}
+
+			g.Websocket.TrafficAlert <- struct{}{}
+			g.Websocket.Intercomm <- exchange.WebsocketResponse{Raw: resp}
+		}
+	}
+}
+
+// wsPingHandler keeps the connection alive with application level pings
+func (g *Gateio) wsPingHandler() {
+	g.Websocket.Wg.Add(1)
+	defer g.Websocket.Wg.Done()
+
+	t := time.NewTicker(gateioWsPingInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-g.Websocket.ShutdownC:
+			return
+
+		case <-t.C:
+			err := g.wsWrite(WsRequest{Time: time.Now().Unix(), Channel: gateioWsPing})
+			if err != nil {
+				g.Websocket.DataHandler <- err
+				return
+			}
+		}
+	}
+}
+
+// WsHandleData handles the read data from the websocket connection
+func (g *Gateio) WsHandleData() {
+	g.Websocket.Wg.Add(1)
+	defer g.Websocket.Wg.Done()
+
+	for {
+		select {
+		case <-g.Websocket.ShutdownC:
+			return
+
+		case resp := <-g.Websocket.Intercomm:
+			err := g.wsHandleMessage(resp.Raw)
+			if err != nil {
+				g.Websocket.DataHandler <- fmt.Sprintf("%s websocket handling error: %s",
+					g.Name,
+					err)
+			}
+		}
+	}
+}
+
+// wsHandleMessage routes a single websocket message, acknowledgements and
+// pongs are dropped
+func (g *Gateio) wsHandleMessage(raw []byte) error {
+	var resp WsResponse
+	err := common.JSONDecode(raw, &resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Error != nil {
+		return fmt.Errorf("%s %s code %d: %s",
+			resp.Channel,
+			resp.Event,
+			resp.Error.Code,
+			resp.Error.Message)
+	}
+	if resp.Event != gateioWsUpdate {
+		return nil
+	}
+
+	switch resp.Channel {
+	case gateioWsTickers:
+		var t Ticker
+		err = common.JSONDecode(resp.Result, &t)
+		if err != nil {
+			return err
+		}
+		g.Websocket.DataHandler <- exchange.TickerData{
+			Timestamp:  time.Unix(resp.Time, 0).UTC(),
+			Pair:       symbolToPair(t.CurrencyPair),
+			AssetType:  ticker.Spot,
+			Exchange:   g.Name,
+			ClosePrice: t.Last.Float64(),
+			Quantity:   t.BaseVolume.Float64(),
+			HighPrice:  t.High24h.Float64(),
+			LowPrice:   t.Low24h.Float64(),
+		}
+	case gateioWsTrades:
+		var t Trade
+		err = common.JSONDecode(resp.Result, &t)
+		if err != nil {
+			return err
+		}
+		g.Websocket.DataHandler <- exchange.TradeData{
+			Timestamp:    t.CreateTimeMs.Time(),
+			CurrencyPair: symbolToPair(t.CurrencyPair),
+			AssetType:    ticker.Spot,
+			Exchange:     g.Name,
+			Price:        t.Price.Float64(),
+			Amount:       t.Amount.Float64(),
+			Side:         t.Side,
+		}
+	case gateioWsOrderbook:
+		return g.wsProcessOrderbook(resp.Result)
+	case gateioWsOrders:
+		var orders []Order
+		err = common.JSONDecode(resp.Result, &orders)
+		if err != nil {
+			return err
+		}
+		for i := range orders {
+			g.Websocket.DataHandler <- orders[i]
+		}
+	case gateioWsBalances:
+		var balances []WsBalance
+		err = common.JSONDecode(resp.Result, &balances)
+		if err != nil {
+			return err
+		}
+		for i := range balances {
+			g.Websocket.DataHandler <- balances[i]
+		}
+	}
+	return nil
+}
+
+// wsProcessOrderbook processes a limited level orderbook snapshot, each
+// snapshot replaces the pair's orderbook
+func (g *Gateio) wsProcessOrderbook(result []byte) error {
+	var book WsOrderbook
+	err := common.JSONDecode(result, &book)
+	if err != nil {
+		return err
+	}
+
+	bids, err := parseOrderbookLevels(book.Bids)
+	if err != nil {
+		return err
+	}
+	asks, err := parseOrderbookLevels(book.Asks)
+	if err != nil {
+		return err
+	}
+
+	var newOrderbook orderbook.Base
+	for i := range bids {
+		newOrderbook.Bids = append(newOrderbook.Bids,
+			orderbook.Item{Price: bids[i].Price, Amount: bids[i].Amount})
+	}
+	for i := range asks {
+		newOrderbook.Asks = append(newOrderbook.Asks,
+			orderbook.Item{Price: asks[i].Price, Amount: asks[i].Amount})
+	}
+
+	p := symbolToPair(book.CurrencyPair)
+	newOrderbook.Pair = p
+	newOrderbook.CurrencyPair = book.CurrencyPair
+	newOrderbook.AssetType = ticker.Spot
+	newOrderbook.LastUpdated = book.Timestamp.Time()
+
+	orderbook.ProcessOrderbook(g.Name, p, newOrderbook, ticker.Spot)
+
+	g.Websocket.DataHandler <- exchange.WebsocketOrderbookUpdate{
+		Pair:     p,
+		Asset:    ticker.Spot,
+		Exchange: g.Name,
+	}
+	return nil
+}
+
+// symbolToPair converts a Gate.io currency pair such as BTC_USDT
+func symbolToPair(symbol string) pair.CurrencyPair {
+	return pair.NewCurrencyPairDelimiter(symbol, "_")
+}
@@ -1,64 +1,121 @@
 package gateio
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
+	"math"
 	"strconv"
-	"sync"
+	"strings"
+	"time"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
-	"github.com/thrasher-/gocryptotrader/exchanges"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/assets"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
 
-// Start starts the GateIO go routine
-func (g *Gateio) Start(wg *sync.WaitGroup) {
-	wg.Add(1)
-	go func() {
-		g.Run()
-		wg.Done()
-	}()
+// candleIntervals maps candle intervals to Gate.io candlestick intervals
+var candleIntervals = map[kline.Interval]string{
+	kline.OneMin:     "1m",
+	kline.FiveMin:    "5m",
+	kline.FifteenMin: "15m",
+	kline.ThirtyMin:  "30m",
+	kline.OneHour:    "1h",
+	kline.FourHour:   "4h",
+	kline.OneDay:     "1d",
+	kline.OneWeek:    "7d",
+}
+
+// Start starts the Gateio wrapper, refreshing its currency pairs
+func (g *Gateio) Start(ctx context.Context) error {
+	return g.StartWrapper(ctx, g.Run)
 }
 
 // Run implements the GateIO wrapper
-func (g *Gateio) Run() {
+func (g *Gateio) Run(ctx context.Context) error {
 	if g.Verbose {
-		log.Printf("%s Websocket: %s. (url: %s).\n", g.GetName(), common.IsEnabled(g.Websocket.IsEnabled()), g.WebsocketURL)
+		log.Printf("%s Websocket: %s. (url: %s).\n", g.GetName(), common.IsEnabled(g.Websocket.IsEnabled()), g.Websocket.GetWebsocketURL())
 		log.Printf("%s polling delay: %ds.\n", g.GetName(), g.RESTPollingDelay)
 		log.Printf("%s %d currencies enabled: %s.\n", g.GetName(), len(g.EnabledPairs), g.EnabledPairs)
 	}
 
-	symbols, err := g.GetSymbols()
+	currencyPairs, err := g.GetCurrencyPairs()
 	if err != nil {
-		log.Printf("%s Unable to fetch symbols.\n", g.GetName())
-	} else {
-		err = g.UpdateCurrencies(symbols, false, false)
-		if err != nil {
-			log.Printf("%s Failed to update available currencies.\n", g.GetName())
+		log.Printf("%s failed to obtain available currency pairs. Err: %s", g.Name, err)
+		return nil
+	}
+
+	var pairs []string
+	for i := range currencyPairs {
+		if currencyPairs[i].TradeStatus != TradeStatusTradable {
+			continue
+		}
+		pairs = append(pairs, currencyPairs[i].ID)
+		p := symbolToPair(currencyPairs[i].ID)
+		g.SetPairIncrements(p,
+			math.Pow10(-int(currencyPairs[i].Precision)),
+			math.Pow10(-int(currencyPairs[i].AmountPrecision)))
+		g.SetPairMinimumAmount(p, currencyPairs[i].MinBaseAmount.Float64())
+	}
+
+	err = g.UpdateCurrencies(pairs, false, false)
+	if err != nil {
+		log.Printf("%s failed to update available currencies. Err: %s", g.Name, err)
+	}
+
+	marginPairs, err := g.GetMarginCurrencyPairs()
+	if err != nil {
+		log.Printf("%s failed to obtain available margin currency pairs. Err: %s", g.Name, err)
+		return nil
+	}
+
+	var margin []string
+	for i := range marginPairs {
+		if marginPairs[i].Status == 1 {
+			margin = append(margin, marginPairs[i].ID)
 		}
 	}
+	err = g.UpdateAssetCurrencies(assets.Margin, margin, false, false)
+	if err != nil {
+		log.Printf("%s failed to update available margin currencies. Err: %s", g.Name, err)
+	}
+	return nil
 }
 
-// UpdateTicker updates and returns the ticker for a currency pair
+// UpdateTicker updates and returns the ticker for a currency pair, tickers
+// of all currency pairs are fetched and processed for every enabled pair
 func (g *Gateio) UpdateTicker(p pair.CurrencyPair, assetType string) (ticker.Price, error) {
 	var tickerPrice ticker.Price
-	result, err := g.GetTickers()
+	tickers, err := g.GetTickers("")
 	if err != nil {
 		return tickerPrice, err
 	}
 
-	for _, x := range g.GetEnabledCurrencies() {
-		currency := exchange.FormatExchangeCurrency(g.Name, x).String()
-		var tp ticker.Price
-		tp.Pair = x
-		tp.High = result[currency].High
-		tp.Last = result[currency].Last
-		tp.Last = result[currency].Last
-		tp.Low = result[currency].Low
-		tp.Volume = result[currency].Volume
-		ticker.ProcessTicker(g.Name, x, tp, assetType)
+	tickerMap := make(map[string]*Ticker, len(tickers))
+	for i := range tickers {
+		tickerMap[tickers[i].CurrencyPair] = &tickers[i]
+	}
+
+	for _, x := range g.GetEnabledPairs(assetType) {
+		t, ok := tickerMap[exchange.FormatExchangeCurrency(g.Name, x).String()]
+		if !ok {
+			continue
+		}
+		ticker.ProcessTicker(g.GetName(), x, ticker.Price{
+			Pair:        x,
+			Last:        t.Last.Float64(),
+			High:        t.High24h.Float64(),
+			Low:         t.Low24h.Float64(),
+			Bid:         t.HighestBid.Float64(),
+			Ask:         t.LowestAsk.Float64(),
+			Volume:      t.BaseVolume.Float64(),
+			LastUpdated: time.Now(),
+		}, assetType)
 	}
 
 	return ticker.GetTicker(g.Name, p, assetType)
@@ -85,9 +142,7 @@ func (g *Gateio) GetOrderbookEx(currency pair.CurrencyPair, assetType string) (o
 // UpdateOrderbook updates and returns the orderbook for a currency pair
 func (g *Gateio) UpdateOrderbook(p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
 	var orderBook orderbook.Base
-	currency := exchange.FormatExchangeCurrency(g.Name, p).String()
-
-	orderbookNew, err := g.GetOrderbook(currency)
+	orderbookNew, err := g.GetOrderbook(exchange.FormatExchangeCurrency(g.Name, p).String(), 100)
 	if err != nil {
 		return orderBook, err
 	}
@@ -106,187 +161,498 @@ func (g *Gateio) UpdateOrderbook(p pair.CurrencyPair, assetType string) (orderbo
 	return orderbook.GetOrderbook(g.Name, p, assetType)
 }
 
-// GetAccountInfo retrieves balances for all enabled currencies for the
-// ZB exchange
+// GetAccountInfo retrieves the spot account balances and the isolated margin
+// account balances, which are reported under the margin asset type
 func (g *Gateio) GetAccountInfo() (exchange.AccountInfo, error) {
 	var info exchange.AccountInfo
-
-	balance, err := g.GetBalances()
+	spot, err := g.GetSpotAccounts("")
 	if err != nil {
 		return info, err
 	}
 
-	if len(balance.Available) == 0 && len(balance.Locked) == 0 {
-		return info, nil
+	margin, err := g.GetMarginAccounts("")
+	if err != nil {
+		return info, err
 	}
 
-	var balances []exchange.AccountCurrencyInfo
-
-	for _, data := range balance.Locked {
-		for key, amountStr := range data {
-			lockedF, err := strconv.ParseFloat(amountStr, 64)
-			if err != nil {
-				return info, err
-			}
+	info.ExchangeName = g.GetName()
+	info.Currencies = accountBalances(spot, margin)
+	return info, nil
+}
 
-			balances = append(balances, exchange.AccountCurrencyInfo{
-				CurrencyName: key,
-				Hold:         lockedF,
-			})
-		}
+// accountBalances converts the spot balances and merges the margin balances
+// of each currency across the isolated margin accounts. Margin balances are
+// net of the amount borrowed and its interest, so are negative when more is
+// owed than held.
+func accountBalances(spot []SpotAccount, margin []MarginAccount) []exchange.AccountCurrencyInfo {
+	currencies := make([]exchange.AccountCurrencyInfo, 0, len(spot))
+	for i := range spot {
+		currencies = append(currencies, exchange.AccountCurrencyInfo{
+			CurrencyName: common.StringToUpper(spot[i].Currency),
+			TotalValue:   spot[i].Available.Float64() + spot[i].Locked.Float64(),
+			Hold:         spot[i].Locked.Float64(),
+			AssetType:    assets.Spot,
+		})
 	}
 
-	for _, data := range balance.Available {
-		for key, amountStr := range data {
-			availAmount, err := strconv.ParseFloat(amountStr, 64)
-			if err != nil {
-				return info, err
-			}
-
-			var updated bool
-			for i := range balances {
-				if balances[i].CurrencyName == key {
-					balances[i].TotalValue = balances[i].Hold + availAmount
-					updated = true
-					break
-				}
+	marginIndex := make(map[string]int)
+	for i := range margin {
+		for _, b := range []MarginBalance{margin[i].Base, margin[i].Quote} {
+			if b.Currency == "" {
+				continue
 			}
-
-			if !updated {
-				balances = append(balances, exchange.AccountCurrencyInfo{
-					CurrencyName: key,
-					TotalValue:   availAmount,
+			name := common.StringToUpper(b.Currency)
+			idx, ok := marginIndex[name]
+			if !ok {
+				idx = len(currencies)
+				marginIndex[name] = idx
+				currencies = append(currencies, exchange.AccountCurrencyInfo{
+					CurrencyName: name,
+					AssetType:    assets.Margin,
 				})
 			}
+			currencies[idx].TotalValue += b.Available.Float64() + b.Locked.Float64() -
+				b.Borrowed.Float64() - b.Interest.Float64()
+			currencies[idx].Hold += b.Locked.Float64()
 		}
 	}
+	return currencies
+}
 
-	info.Currencies = balances
-	info.ExchangeName = g.GetName()
+// GetMarginLevels returns the margin ratio of each isolated margin account
+// with borrowed funds, the liquidation risk rate as a fraction of the
+// account's risk rate
+func (g *Gateio) GetMarginLevels() ([]exchange.MarginLevel, error) {
+	accounts, err := g.GetMarginAccounts("")
+	if err != nil {
+		return nil, err
+	}
+	return marginLevels(accounts, time.Now()), nil
+}
 
-	return info, nil
+// marginLevels converts the risk rates of isolated margin accounts with
+// borrowed funds, accounts without borrowings cannot be liquidated
+func marginLevels(accounts []MarginAccount, now time.Time) []exchange.MarginLevel {
+	var levels []exchange.MarginLevel
+	for i := range accounts {
+		borrowed := accounts[i].Base.Borrowed + accounts[i].Quote.Borrowed
+		if borrowed <= 0 || accounts[i].Risk <= 0 {
+			continue
+		}
+		levels = append(levels, exchange.MarginLevel{
+			Pair:        symbolToPair(accounts[i].CurrencyPair),
+			AssetType:   assets.Margin,
+			MarginRatio: gateioMarginLiquidationRisk / accounts[i].Risk.Float64(),
+			UpdateTime:  now,
+		})
+	}
+	return levels
+}
+
+// TransferCollateral transfers funds from the spot account into the isolated
+// margin account of a currency pair, the currency must be one of the pair's
+func (g *Gateio) TransferCollateral(p pair.CurrencyPair, assetType string, currency pair.CurrencyItem, amount float64) error {
+	if assetType != assets.Margin {
+		return fmt.Errorf("%s collateral transfers to %s are not supported", g.Name, assetType)
+	}
+	if currency.Upper() != p.FirstCurrency.Upper() && currency.Upper() != p.SecondCurrency.Upper() {
+		return fmt.Errorf("%s is not a currency of %s", currency, p.Pair())
+	}
+	if amount <= 0 {
+		return errors.New("collateral transfer amount must be positive")
+	}
+
+	return g.TransferBetweenAccounts(AccountTransferRequest{
+		Currency:     currency.Upper().String(),
+		From:         AccountSpot,
+		To:           AccountMargin,
+		Amount:       strconv.FormatFloat(amount, 'f', -1, 64),
+		CurrencyPair: exchange.FormatExchangeCurrency(g.Name, p).String(),
+	})
 }
 
 // GetFundingHistory returns funding history, deposits and
 // withdrawals
 func (g *Gateio) GetFundingHistory() ([]exchange.FundHistory, error) {
-	var fundHistory []exchange.FundHistory
-	return fundHistory, common.ErrFunctionNotSupported
+	deposits, err := g.GetDeposits("")
+	if err != nil {
+		return nil, err
+	}
+
+	withdrawals, err := g.GetWithdrawals("")
+	if err != nil {
+		return nil, err
+	}
+
+	fundHistory := make([]exchange.FundHistory, 0, len(deposits)+len(withdrawals))
+	for i := range deposits {
+		fundHistory = append(fundHistory, transferHistory(g.Name, "deposit", &deposits[i]))
+	}
+	for i := range withdrawals {
+		fundHistory = append(fundHistory, transferHistory(g.Name, "withdrawal", &withdrawals[i]))
+	}
+	return fundHistory, nil
 }
 
-// GetExchangeHistory returns historic trade data since exchange opening.
+// transferHistory converts a deposit or withdrawal record, whose IDs are
+// prefixed with d or w
+func transferHistory(exchName, transferType string, t *Transfer) exchange.FundHistory {
+	id, _ := strconv.ParseInt(strings.TrimLeft(t.ID, "dw"), 10, 64)
+	return exchange.FundHistory{
+		ExchangeName:    exchName,
+		Status:          t.Status,
+		TransferID:      id,
+		Timestamp:       time.Unix(int64(t.Timestamp), 0).UTC(),
+		Currency:        t.Currency,
+		Amount:          t.Amount.Float64(),
+		Fee:             t.Fee.Float64(),
+		TransferType:    transferType,
+		Chain:           t.Chain,
+		CryptoToAddress: t.Address,
+		CryptoTxID:      t.TxID,
+	}
+}
+
+// GetExchangeHistory returns the most recent public trades
 func (g *Gateio) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]exchange.TradeHistory, error) {
-	var resp []exchange.TradeHistory
+	trades, err := g.GetTrades(exchange.FormatExchangeCurrency(g.Name, p).String(), 1000)
+	if err != nil {
+		return nil, err
+	}
 
-	return resp, common.ErrNotYetImplemented
+	resp := make([]exchange.TradeHistory, len(trades))
+	for i := range trades {
+		tid, _ := strconv.ParseInt(trades[i].ID, 10, 64)
+		resp[i] = exchange.TradeHistory{
+			Timestamp: trades[i].CreateTimeMs.Time(),
+			TID:       tid,
+			Price:     trades[i].Price.Float64(),
+			Amount:    trades[i].Amount.Float64(),
+			Exchange:  g.Name,
+			Type:      trades[i].Side,
+		}
+	}
+	return resp, nil
 }
 
-// SubmitOrder submits a new order
-func (g *Gateio) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
-	var submitOrderResponse exchange.SubmitOrderResponse
-	var orderTypeFormat SpotNewOrderRequestParamsType
+// GetHistoricCandles returns the candles of a currency pair opening between
+// start and end, paging through the candlesticks endpoint
+func (g *Gateio) GetHistoricCandles(p pair.CurrencyPair, assetType string, interval kline.Interval, start, end time.Time) ([]kline.Candle, error) {
+	candleInterval, ok := candleIntervals[interval]
+	if !ok {
+		return nil, kline.ErrUnsupportedInterval
+	}
+	if !start.Before(end) {
+		return nil, kline.ErrInvalidTimeRange
+	}
+
+	symbol := exchange.FormatExchangeCurrency(g.Name, p).String()
+	var candles []kline.Candle
+	for _, r := range kline.CalculateRanges(interval, start, end, gateioCandleLimit) {
+		resp, err := g.GetCandlesticks(symbol, candleInterval, r.Start, r.End, 0)
+		if err != nil {
+			return nil, err
+		}
+		for x := range resp {
+			candles = append(candles, kline.Candle{
+				Time:   resp[x].Timestamp,
+				Open:   resp[x].Open,
+				High:   resp[x].High,
+				Low:    resp[x].Low,
+				Close:  resp[x].Close,
+				Volume: resp[x].Volume,
+			})
+		}
+	}
+	return kline.FilterCandles(candles, start, end), nil
+}
+
+// clientOrderText returns a client order ID with the prefix Gate.io requires
+func clientOrderText(clientID string) string {
+	if clientID == "" || strings.HasPrefix(clientID, gateioClientIDPrefix) {
+		return clientID
+	}
+	return gateioClientIDPrefix + clientID
+}
+
+// buildSpotOrder converts order parameters into a spot order request rounded
+// to the pair's increments. Market buys are sized in the quote currency so
+// their amount is not rounded to the base currency increment.
+func (g *Gateio) buildSpotOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (PlaceOrderRequest, error) {
+	if orderType != exchange.Market || side != exchange.Buy {
+		price, amount = g.RoundOrder(p, side, price, amount)
+	}
+
+	req := PlaceOrderRequest{
+		Text:         clientOrderText(clientID),
+		CurrencyPair: exchange.FormatExchangeCurrency(g.Name, p).String(),
+		Account:      AccountSpot,
+		Amount:       strconv.FormatFloat(amount, 'f', -1, 64),
+	}
 
-	if side == exchange.Buy {
-		orderTypeFormat = SpotNewOrderRequestParamsTypeBuy
-	} else {
-		orderTypeFormat = SpotNewOrderRequestParamsTypeSell
+	switch side {
+	case exchange.Buy:
+		req.Side = OrderSideBuy
+	case exchange.Sell:
+		req.Side = OrderSideSell
+	default:
+		return req, fmt.Errorf("unsupported order side %s", side)
 	}
 
-	var spotNewOrderRequestParams = SpotNewOrderRequestParams{
-		Amount: amount,
-		Price:  price,
-		Symbol: p.Pair().String(),
-		Type:   orderTypeFormat,
+	switch orderType {
+	case exchange.Limit:
+		req.Type = OrderTypeLimit
+		req.Price = strconv.FormatFloat(price, 'f', -1, 64)
+		req.TimeInForce = TimeInForceGTC
+	case exchange.Market:
+		req.Type = OrderTypeMarket
+		req.TimeInForce = TimeInForceIOC
+	case exchange.ImmediateOrCancel:
+		req.Type = OrderTypeLimit
+		req.Price = strconv.FormatFloat(price, 'f', -1, 64)
+		req.TimeInForce = TimeInForceIOC
+	default:
+		return req, fmt.Errorf("unsupported order type %s", orderType)
 	}
+	return req, nil
+}
 
-	response, err := g.SpotNewOrder(spotNewOrderRequestParams)
+// SubmitOrder submits a new spot order, the amount of market buys is in the
+// quote currency
+func (g *Gateio) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	if g.IsSimulated() {
+		return g.SimulateSubmitOrder(p, side, orderType, amount, price, clientID)
+	}
 
-	if response.OrderNumber > 0 {
-		submitOrderResponse.OrderID = fmt.Sprintf("%v", response)
+	var submitOrderResponse exchange.SubmitOrderResponse
+	req, err := g.buildSpotOrder(p, side, orderType, amount, price, clientID)
+	if err != nil {
+		return submitOrderResponse, err
 	}
 
-	if err == nil {
-		submitOrderResponse.IsOrderPlaced = true
+	resp, err := g.PlaceOrder(req)
+	if err != nil {
+		return submitOrderResponse, err
 	}
 
-	return submitOrderResponse, err
+	submitOrderResponse.OrderID = resp.ID
+	submitOrderResponse.IsOrderPlaced = true
+	return submitOrderResponse, nil
 }
 
 // ModifyOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (g *Gateio) ModifyOrder(action exchange.ModifyOrder) (string, error) {
+	if g.IsSimulated() {
+		return g.SimulateModifyOrder(action)
+	}
+
 	return "", common.ErrFunctionNotSupported
 }
 
 // CancelOrder cancels an order by its corresponding ID number
 func (g *Gateio) CancelOrder(order exchange.OrderCancellation) error {
-	orderIDInt, err := strconv.ParseInt(order.OrderID, 10, 64)
-
-	if err != nil {
-		return err
+	if g.IsSimulated() {
+		return g.SimulateCancelOrder(order)
 	}
-	_, err = g.CancelExistingOrder(orderIDInt, exchange.FormatExchangeCurrency(g.Name, order.CurrencyPair).String())
 
+	_, err := g.CancelExistingOrder(order.OrderID,
+		exchange.FormatExchangeCurrency(g.Name, order.CurrencyPair).String())
 	return err
 }
 
-// CancelAllOrders cancels all orders associated with a currency pair
+// CancelAllOrders cancels all spot orders of the currency pairs with open
+// orders
 func (g *Gateio) CancelAllOrders(orderCancellation exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
+	if g.IsSimulated() {
+		return g.SimulateCancelAllOrders(orderCancellation)
+	}
+
 	cancelAllOrdersResponse := exchange.CancelAllOrdersResponse{
 		OrderStatus: make(map[string]string),
 	}
-	openOrders, err := g.GetOpenOrders("")
+	openOrders, err := g.GetOpenOrders()
 	if err != nil {
 		return cancelAllOrdersResponse, err
 	}
 
-	var uniqueSymbols map[string]string
-	for _, openOrder := range openOrders.Orders {
-		uniqueSymbols[openOrder.CurrencyPair] = openOrder.CurrencyPair
-	}
-
-	for _, uniqueSymbol := range uniqueSymbols {
-		err = g.CancelAllExistingOrders(-1, uniqueSymbol)
+	for i := range openOrders {
+		_, err = g.CancelAllExistingOrders(openOrders[i].CurrencyPair, "")
 		if err != nil {
-			return cancelAllOrdersResponse, err
+			cancelAllOrdersResponse.OrderStatus[openOrders[i].CurrencyPair] = err.Error()
 		}
 	}
 
 	return cancelAllOrdersResponse, nil
 }
 
-// GetOrderInfo returns information on a current open order
+// GetOrderInfo returns information on a spot order. Orders are queried by
+// currency pair so open orders are searched before the orders of each enabled
+// pair.
 func (g *Gateio) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
+	if g.IsSimulated() {
+		return g.SimulateGetOrderInfo(orderID)
+	}
+
 	var orderDetail exchange.OrderDetail
-	return orderDetail, common.ErrNotYetImplemented
+	id := strconv.FormatInt(orderID, 10)
+
+	openOrders, err := g.GetOpenOrders()
+	if err != nil {
+		return orderDetail, err
+	}
+	for i := range openOrders {
+		for j := range openOrders[i].Orders {
+			if openOrders[i].Orders[j].ID == id {
+				return g.orderDetail(&openOrders[i].Orders[j]), nil
+			}
+		}
+	}
+
+	for _, p := range g.GetEnabledCurrencies() {
+		o, err := g.GetOrder(id, exchange.FormatExchangeCurrency(g.Name, p).String())
+		if err == nil && o.ID == id {
+			return g.orderDetail(&o), nil
+		}
+	}
+	return orderDetail, fmt.Errorf("order %d not found", orderID)
 }
 
-// GetDepositAddress returns a deposit address for a specified currency
+// GetActiveOrders retrieves the open spot orders matching the request
+func (g *Gateio) GetActiveOrders(req exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	if g.IsSimulated() {
+		return g.SimulateGetOrders(req, true)
+	}
+
+	openOrders, err := g.GetOpenOrders()
+	if err != nil {
+		return nil, err
+	}
+
+	var orders []Order
+	for i := range openOrders {
+		orders = append(orders, openOrders[i].Orders...)
+	}
+	return g.filterOrders(orders, req), nil
+}
+
+// GetOrderHistory retrieves the finished spot orders of the requested
+// currency pairs matching the request
+func (g *Gateio) GetOrderHistory(req exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	if g.IsSimulated() {
+		return g.SimulateGetOrders(req, false)
+	}
+
+	var orders []Order
+	for _, p := range g.GetOrdersRequestPairs(req) {
+		resp, err := g.GetOrders(exchange.FormatExchangeCurrency(g.Name, p).String(), OrderStatusFinished)
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, resp...)
+	}
+	return g.filterOrders(orders, req), nil
+}
+
+// filterOrders converts spot orders and returns those matching the request
+func (g *Gateio) filterOrders(orders []Order, req exchange.GetOrdersRequest) []exchange.OrderDetail {
+	req.Currencies = g.GetOrdersRequestPairs(req)
+	details := make([]exchange.OrderDetail, len(orders))
+	for i := range orders {
+		details[i] = g.orderDetail(&orders[i])
+	}
+	return exchange.FilterOrders(details, req)
+}
+
+// orderDetail converts a Gate.io order to its order details, the amount of
+// market buys is in the quote currency
+func (g *Gateio) orderDetail(o *Order) exchange.OrderDetail {
+	p := symbolToPair(o.CurrencyPair)
+
+	side := exchange.Sell
+	if o.Side == OrderSideBuy {
+		side = exchange.Buy
+	}
+	orderType := exchange.Limit
+	switch {
+	case o.Type == OrderTypeMarket:
+		orderType = exchange.Market
+	case o.TimeInForce == TimeInForceIOC:
+		orderType = exchange.ImmediateOrCancel
+	}
+
+	var executed float64
+	if o.AvgDealPrice > 0 {
+		executed = o.FilledTotal.Float64() / o.AvgDealPrice.Float64()
+	}
+
+	return exchange.OrderDetail{
+		Exchange:             g.Name,
+		ID:                   o.ID,
+		BaseCurrency:         p.FirstCurrency.Upper().String(),
+		QuoteCurrency:        p.SecondCurrency.Upper().String(),
+		OrderSide:            string(side),
+		OrderType:            string(orderType),
+		CreationTime:         o.CreateTimeMs.Time(),
+		Status:               o.Status,
+		Price:                o.Price.Float64(),
+		Amount:               o.Amount.Float64(),
+		OpenVolume:           o.Left.Float64(),
+		ExecutedAmount:       executed,
+		AverageExecutedPrice: o.AvgDealPrice.Float64(),
+	}
+}
+
+// GetDepositAddress returns a deposit address for a specified currency,
+// preferring the chain named after it
 func (g *Gateio) GetDepositAddress(cryptocurrency pair.CurrencyItem) (string, error) {
-	return "", common.ErrNotYetImplemented
+	addresses, err := g.GetDepositAddresses(cryptocurrency.Upper().String())
+	if err != nil {
+		return "", err
+	}
+
+	for i := range addresses.MultichainAddresses {
+		if strings.EqualFold(addresses.MultichainAddresses[i].Chain, cryptocurrency.String()) &&
+			addresses.MultichainAddresses[i].ObtainFailed == 0 {
+			return addresses.MultichainAddresses[i].Address, nil
+		}
+	}
+	if addresses.Address == "" {
+		return "", fmt.Errorf("no deposit address found for %s", cryptocurrency)
+	}
+	return addresses.Address, nil
 }
 
 // WithdrawCryptocurrencyFunds returns a withdrawal ID when a withdrawal is
-// submitted
+// submitted on the chain named after the currency
 func (g *Gateio) WithdrawCryptocurrencyFunds(address string, cryptocurrency pair.CurrencyItem, amount float64) (string, error) {
-	return "", common.ErrNotYetImplemented
+	if address == "" {
+		return "", errors.New("withdrawal address must be set")
+	}
+
+	return g.Withdraw(WithdrawalRequest{
+		Currency: cryptocurrency.Upper().String(),
+		Address:  address,
+		Amount:   strconv.FormatFloat(amount, 'f', -1, 64),
+		Chain:    cryptocurrency.Upper().String(),
+	})
 }
 
 // WithdrawFiatFunds returns a withdrawal ID when a
 // withdrawal is submitted
 func (g *Gateio) WithdrawFiatFunds(currency pair.CurrencyItem, amount float64) (string, error) {
-	return "", common.ErrNotYetImplemented
+	return "", common.ErrFunctionNotSupported
 }
 
 // WithdrawFiatFundsToInternationalBank returns a withdrawal ID when a
 // withdrawal is submitted
 func (g *Gateio) WithdrawFiatFundsToInternationalBank(currency pair.CurrencyItem, amount float64) (string, error) {
-	return "", common.ErrNotYetImplemented
+	return "", common.ErrFunctionNotSupported
 }
 
 // GetWebsocket returns a pointer to the exchange websocket
 func (g *Gateio) GetWebsocket() (*exchange.Websocket, error) {
-	return nil, common.ErrNotYetImplemented
+	return g.Websocket, nil
 }
 
 // GetFeeByType returns an estimate of fee based on type of transaction
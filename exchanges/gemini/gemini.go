@@ -12,6 +12,7 @@ import (
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/config"
 	"github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/assets"
 	"github.com/thrasher-/gocryptotrader/exchanges/request"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
@@ -39,6 +40,9 @@ const (
 	geminiDeposit            = "deposit"
 	geminiNewAddress         = "newAddress"
 	geminiWithdraw           = "withdraw/"
+	geminiTransfers          = "transfers"
+	geminiRoles              = "roles"
+	geminiPaymentMethods     = "payments/methods"
 	geminiHeartbeat          = "heartbeat"
 	geminiVolume             = "notionalvolume"
 
@@ -52,6 +56,10 @@ const (
 	// Assigned API key roles on creation
 	geminiRoleTrader      = "trader"
 	geminiRoleFundManager = "fundmanager"
+
+	// Transfer types
+	geminiTransferDeposit    = "Deposit"
+	geminiTransferWithdrawal = "Withdrawal"
 )
 
 var (
@@ -103,6 +111,20 @@ func (g *Gemini) SetDefaults() {
 	g.Verbose = false
 	g.RESTPollingDelay = 10
 	g.APIWithdrawPermissions = exchange.AutoWithdrawCryptoWithAPIPermission | exchange.AutoWithdrawCryptoWithSetup | exchange.WithdrawFiatViaWebsiteOnly
+	g.Features = exchange.Features{
+		CanGetTicker:       true,
+		CanGetOrderbook:    true,
+		CanGetAccountInfo:  true,
+		CanSubmitOrder:     true,
+		CanCancelOrder:     true,
+		CanCancelAllOrders: true,
+	}
+	g.Features.Orders = map[string]exchange.OrderCapabilities{
+		assets.Spot: {
+			OrderTypes:  []exchange.OrderType{exchange.Limit},
+			TimeInForce: []exchange.TimeInForce{exchange.GTC},
+		},
+	}
 	g.RequestCurrencyPairFormat.Delimiter = ""
 	g.RequestCurrencyPairFormat.Uppercase = true
 	g.ConfigCurrencyPairFormat.Delimiter = ""
@@ -158,6 +180,27 @@ func (g *Gemini) Setup(exch config.ExchangeConfig) {
 		if err != nil {
 			log.Fatal(err)
 		}
+		err = g.SetTLSPins(exch.TLSPins)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = g.SetLocalAddress(exch.LocalAddress)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = g.SetRoundingModes(exch.RoundingModes)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = g.SetRateLimitTiers(exch.RateLimitTiers, exch.AccountTier)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = g.SetHTTPClientHeaders(exch.HTTPHeaders)
+		if err != nil {
+			log.Fatal(err)
+		}
+		g.SetSimulation(exch.Simulate, exch.SimulationBalances)
 	}
 }
 
@@ -446,6 +489,54 @@ func (g *Gemini) WithdrawCrypto(address, currency string, amount float64) (Withd
 	return response, nil
 }
 
+// GetTransfers returns fiat and crypto deposits and withdrawals, fiat
+// transfers carry the bank method such as ACH or Wire
+//
+// timestamp - [optional] Only return transfers on or after this timestamp.
+// limit - [optional] The maximum number of transfers to return, max 50.
+func (g *Gemini) GetTransfers(timestamp int64, limit int) ([]Transfer, error) {
+	response := []Transfer{}
+	request := make(map[string]interface{})
+	if timestamp != 0 {
+		request["timestamp"] = timestamp
+	}
+	if limit != 0 {
+		request["limit_transfers"] = limit
+	}
+
+	return response,
+		g.SendAuthenticatedHTTPRequest("POST", geminiTransfers, request, &response)
+}
+
+// GetRoles returns the roles assigned to the API key
+func (g *Gemini) GetRoles() (Roles, error) {
+	response := Roles{}
+
+	return response,
+		g.SendAuthenticatedHTTPRequest("POST", geminiRoles, nil, &response)
+}
+
+// GetPaymentMethods returns the bank accounts linked to the account for fiat
+// deposits and withdrawals
+func (g *Gemini) GetPaymentMethods() (PaymentMethods, error) {
+	response := PaymentMethods{}
+
+	return response,
+		g.SendAuthenticatedHTTPRequest("POST", geminiPaymentMethods, nil, &response)
+}
+
+// withdrawPermissions maps API key roles into withdrawal permissions, crypto
+// withdrawals require the fund manager role and a whitelisted address while
+// fiat withdrawals are only available via the website
+func withdrawPermissions(roles Roles) uint32 {
+	if roles.IsFundManager {
+		return exchange.AutoWithdrawCryptoWithAPIPermission |
+			exchange.AutoWithdrawCryptoWithSetup |
+			exchange.WithdrawFiatViaWebsiteOnly
+	}
+	return exchange.WithdrawCryptoViaWebsiteOnly | exchange.WithdrawFiatViaWebsiteOnly
+}
+
 // PostHeartbeat sends a maintenance heartbeat to the exchange for all heartbeat
 // maintaned sessions
 func (g *Gemini) PostHeartbeat() (string, error) {
@@ -4,6 +4,7 @@ import (
 	"net/url"
 	"testing"
 
+	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/config"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
 	"github.com/thrasher-/gocryptotrader/currency/symbol"
@@ -213,6 +214,55 @@ func TestWithdrawCrypto(t *testing.T) {
 	}
 }
 
+func TestGetTransfers(t *testing.T) {
+	t.Parallel()
+	_, err := Session[1].GetTransfers(0, 10)
+	if err == nil {
+		t.Error("Test Failed - GetTransfers() error", err)
+	}
+}
+
+func TestWithdrawPermissions(t *testing.T) {
+	t.Parallel()
+	expected := exchange.AutoWithdrawCryptoWithAPIPermission |
+		exchange.AutoWithdrawCryptoWithSetup | exchange.WithdrawFiatViaWebsiteOnly
+	if p := withdrawPermissions(Roles{IsTrader: true, IsFundManager: true}); p != expected {
+		t.Error("Test Failed - withdrawPermissions() incorrect fund manager permissions", p)
+	}
+	expected = exchange.WithdrawCryptoViaWebsiteOnly | exchange.WithdrawFiatViaWebsiteOnly
+	if p := withdrawPermissions(Roles{IsTrader: true}); p != expected {
+		t.Error("Test Failed - withdrawPermissions() incorrect trader permissions", p)
+	}
+}
+
+func TestFundingHistory(t *testing.T) {
+	t.Parallel()
+	g := Gemini{}
+	g.Name = "Gemini"
+	history := g.fundingHistory([]Transfer{
+		{Type: "Deposit", Status: "Advanced", Timestampms: 1507913541275, EID: 320013281,
+			Currency: "USD", Amount: 36, Method: "ACH"},
+		{Type: "Withdrawal", Status: "Complete", Timestampms: 1507913541275, EID: 320013282,
+			Currency: "USD", Amount: 20, Method: "Wire"},
+		{Type: "Deposit", Status: "Complete", Timestampms: 1507913541275, EID: 320013283,
+			Currency: "BTC", Amount: 1, TXHash: "abc", Destination: "1Addr"},
+	})
+	if len(history) != 3 {
+		t.Fatal("Test Failed - fundingHistory() expected three transfers", history)
+	}
+	if history[0].BankFrom != "ACH" || history[0].TransferType != "deposit" ||
+		history[0].Timestamp.Unix() != 1507913541 {
+		t.Error("Test Failed - fundingHistory() incorrect fiat deposit", history[0])
+	}
+	if history[1].BankTo != "Wire" || history[1].TransferType != "withdrawal" {
+		t.Error("Test Failed - fundingHistory() incorrect fiat withdrawal", history[1])
+	}
+	if history[2].CryptoTxID != "abc" || history[2].CryptoToAddress != "1Addr" ||
+		history[2].BankFrom != "" {
+		t.Error("Test Failed - fundingHistory() incorrect crypto deposit", history[2])
+	}
+}
+
 func TestPostHeartbeat(t *testing.T) {
 	t.Parallel()
 	_, err := Session[2].PostHeartbeat()
@@ -418,3 +468,10 @@ func TestModifyOrder(t *testing.T) {
 		t.Error("Test failed - ModifyOrder() error")
 	}
 }
+
+func TestWithdrawFiat(t *testing.T) {
+	_, err := Session[1].WithdrawFiatFunds("USD", 1)
+	if err != common.ErrFunctionNotSupported {
+		t.Error("Test failed - WithdrawFiatFunds() expected function not supported", err)
+	}
+}
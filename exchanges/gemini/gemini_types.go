@@ -181,6 +181,37 @@ type WithdrawalAddress struct {
 	Message string  `json:"message"`
 }
 
+// Transfer holds a fiat or crypto deposit or withdrawal
+type Transfer struct {
+	Type        string  `json:"type"`
+	Status      string  `json:"status"`
+	Timestampms int64   `json:"timestampms"`
+	EID         int64   `json:"eid"`
+	AdvanceEID  int64   `json:"advanceEid"`
+	Currency    string  `json:"currency"`
+	Amount      float64 `json:"amount,string"`
+	Method      string  `json:"method"`
+	TXHash      string  `json:"txHash"`
+	Destination string  `json:"destination"`
+	Purpose     string  `json:"purpose"`
+}
+
+// Roles holds the roles assigned to the API key
+type Roles struct {
+	IsAuditor      bool   `json:"isAuditor"`
+	IsFundManager  bool   `json:"isFundManager"`
+	IsTrader       bool   `json:"isTrader"`
+	CounterpartyID string `json:"counterparty_id"`
+}
+
+// PaymentMethods holds the bank accounts linked to the account
+type PaymentMethods struct {
+	Banks []struct {
+		Bank   string `json:"bank"`
+		BankID string `json:"bankId"`
+	} `json:"banks"`
+}
+
 // ErrorCapture is a generlized error response from the server
 type ErrorCapture struct {
 	Result  string `json:"result"`
@@ -1,11 +1,13 @@
 package gemini
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/url"
 	"strconv"
-	"sync"
+	"strings"
+	"time"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
@@ -14,17 +16,13 @@ import (
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
 
-// Start starts the Gemini go routine
-func (g *Gemini) Start(wg *sync.WaitGroup) {
-	wg.Add(1)
-	go func() {
-		g.Run()
-		wg.Done()
-	}()
+// Start starts the Gemini wrapper, refreshing its currency pairs
+func (g *Gemini) Start(ctx context.Context) error {
+	return g.StartWrapper(ctx, g.Run)
 }
 
 // Run implements the Gemini wrapper
-func (g *Gemini) Run() {
+func (g *Gemini) Run(ctx context.Context) error {
 	if g.Verbose {
 		log.Printf("%s polling delay: %ds.\n", g.GetName(), g.RESTPollingDelay)
 		log.Printf("%s %d currencies enabled: %s.\n", g.GetName(), len(g.EnabledPairs), g.EnabledPairs)
@@ -39,6 +37,16 @@ func (g *Gemini) Run() {
 			log.Printf("%s Failed to update available currencies.\n", g.GetName())
 		}
 	}
+
+	if g.AuthenticatedAPISupport {
+		roles, err := g.GetRoles()
+		if err != nil {
+			log.Printf("%s Failed to get API key roles. Err: %s\n", g.GetName(), err)
+		} else {
+			g.APIWithdrawPermissions = withdrawPermissions(roles)
+		}
+	}
+	return nil
 }
 
 // GetAccountInfo Retrieves balances for all enabled currencies for the
@@ -117,8 +125,40 @@ func (g *Gemini) UpdateOrderbook(p pair.CurrencyPair, assetType string) (orderbo
 // GetFundingHistory returns funding history, deposits and
 // withdrawals
 func (g *Gemini) GetFundingHistory() ([]exchange.FundHistory, error) {
+	transfers, err := g.GetTransfers(0, 50)
+	if err != nil {
+		return nil, err
+	}
+	return g.fundingHistory(transfers), nil
+}
+
+// fundingHistory converts transfers, bank transfers are identified by their
+// method and crypto transfers by their transaction hash
+func (g *Gemini) fundingHistory(transfers []Transfer) []exchange.FundHistory {
 	var fundHistory []exchange.FundHistory
-	return fundHistory, common.ErrFunctionNotSupported
+	for i := range transfers {
+		history := exchange.FundHistory{
+			ExchangeName: g.Name,
+			Status:       transfers[i].Status,
+			TransferID:   transfers[i].EID,
+			Description:  transfers[i].Purpose,
+			Timestamp:    time.Unix(0, transfers[i].Timestampms*int64(time.Millisecond)).UTC(),
+			Currency:     transfers[i].Currency,
+			Amount:       transfers[i].Amount,
+			TransferType: strings.ToLower(transfers[i].Type),
+			CryptoTxID:   transfers[i].TXHash,
+		}
+		switch {
+		case transfers[i].Method == "":
+			history.CryptoToAddress = transfers[i].Destination
+		case transfers[i].Type == geminiTransferDeposit:
+			history.BankFrom = transfers[i].Method
+		case transfers[i].Type == geminiTransferWithdrawal:
+			history.BankTo = transfers[i].Method
+		}
+		fundHistory = append(fundHistory, history)
+	}
+	return fundHistory
 }
 
 // GetExchangeHistory returns historic trade data since exchange opening.
@@ -130,6 +170,10 @@ func (g *Gemini) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]ex
 
 // SubmitOrder submits a new order
 func (g *Gemini) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	if g.IsSimulated() {
+		return g.SimulateSubmitOrder(p, side, orderType, amount, price, clientID)
+	}
+
 	var submitOrderResponse exchange.SubmitOrderResponse
 	response, err := g.NewOrder(p.Pair().String(), amount, price, side.ToString(), orderType.ToString())
 
@@ -147,11 +191,19 @@ func (g *Gemini) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, order
 // ModifyOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (g *Gemini) ModifyOrder(action exchange.ModifyOrder) (string, error) {
+	if g.IsSimulated() {
+		return g.SimulateModifyOrder(action)
+	}
+
 	return "", common.ErrFunctionNotSupported
 }
 
 // CancelOrder cancels an order by its corresponding ID number
 func (g *Gemini) CancelOrder(order exchange.OrderCancellation) error {
+	if g.IsSimulated() {
+		return g.SimulateCancelOrder(order)
+	}
+
 	orderIDInt, err := strconv.ParseInt(order.OrderID, 10, 64)
 	if err != nil {
 		return err
@@ -163,6 +215,10 @@ func (g *Gemini) CancelOrder(order exchange.OrderCancellation) error {
 
 // CancelAllOrders cancels all orders associated with a currency pair
 func (g *Gemini) CancelAllOrders(orderCancellation exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
+	if g.IsSimulated() {
+		return g.SimulateCancelAllOrders(orderCancellation)
+	}
+
 	cancelAllOrdersResponse := exchange.CancelAllOrdersResponse{
 		OrderStatus: make(map[string]string),
 	}
@@ -180,6 +236,10 @@ func (g *Gemini) CancelAllOrders(orderCancellation exchange.OrderCancellation) (
 
 // GetOrderInfo returns information on a current open order
 func (g *Gemini) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
+	if g.IsSimulated() {
+		return g.SimulateGetOrderInfo(orderID)
+	}
+
 	var orderDetail exchange.OrderDetail
 	return orderDetail, common.ErrNotYetImplemented
 }
@@ -196,15 +256,17 @@ func (g *Gemini) WithdrawCryptocurrencyFunds(address string, cryptocurrency pair
 }
 
 // WithdrawFiatFunds returns a withdrawal ID when a
-// withdrawal is submitted
+// withdrawal is submitted, Gemini only supports fiat withdrawals to linked bank
+// accounts via the website
 func (g *Gemini) WithdrawFiatFunds(currency pair.CurrencyItem, amount float64) (string, error) {
-	return "", common.ErrNotYetImplemented
+	return "", common.ErrFunctionNotSupported
 }
 
 // WithdrawFiatFundsToInternationalBank returns a withdrawal ID when a
-// withdrawal is submitted
+// withdrawal is submitted, Gemini only supports fiat withdrawals to linked bank
+// accounts via the website
 func (g *Gemini) WithdrawFiatFundsToInternationalBank(currency pair.CurrencyItem, amount float64) (string, error) {
-	return "", common.ErrNotYetImplemented
+	return "", common.ErrFunctionNotSupported
 }
 
 // GetWebsocket returns a pointer to the exchange websocket
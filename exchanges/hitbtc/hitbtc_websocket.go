@@ -12,6 +12,7 @@ import (
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
 	"github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/assets"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 )
 
@@ -27,6 +28,8 @@ func (h *HitBTC) WsConnect() error {
 	}
 
 	var dialer websocket.Dialer
+	dialer.TLSClientConfig = h.GetTLSConfig()
+	dialer.NetDial = h.GetWebsocketNetDial()
 
 	if h.Websocket.GetProxyAddress() != "" {
 		proxy, err := url.Parse(h.Websocket.GetProxyAddress())
@@ -46,60 +49,53 @@ func (h *HitBTC) WsConnect() error {
 	go h.WsReadData()
 	go h.WsHandleData()
 
-	err = h.WsSubscribe()
-	if err != nil {
-		return err
-	}
-
 	return nil
 }
 
-// WsSubscribe subscribes to the relevant channels
-func (h *HitBTC) WsSubscribe() error {
-	enabledPairs := h.GetEnabledCurrencies()
-	for _, p := range enabledPairs {
-		pF := exchange.FormatExchangeCurrency(h.GetName(), p)
-
-		tickerSubReq, err := common.JSONEncode(WsNotification{
-			JSONRPCVersion: rpcVersion,
-			Method:         "subscribeTicker",
-			Params:         params{Symbol: pF.String()},
-		})
-		if err != nil {
-			return err
+// wsDefaultSubscriptions returns the ticker, orderbook and trade channels of
+// all enabled pairs
+func (h *HitBTC) wsDefaultSubscriptions() []exchange.ChannelSubscription {
+	var subs []exchange.ChannelSubscription
+	for _, p := range h.GetEnabledCurrencies() {
+		for _, channel := range []string{"Ticker", "Orderbook", "Trades"} {
+			subs = append(subs, exchange.ChannelSubscription{
+				Channel:   channel,
+				Currency:  p,
+				AssetType: assets.Spot,
+			})
 		}
+	}
+	return subs
+}
 
-		err = h.WebsocketConn.WriteMessage(websocket.TextMessage, tickerSubReq)
-		if err != nil {
-			return nil
-		}
+// WsSubscribe subscribes to the relevant channels
+func (h *HitBTC) WsSubscribe(subs []exchange.ChannelSubscription) error {
+	return h.wsWriteSubscriptions("subscribe", subs)
+}
 
-		orderbookSubReq, err := common.JSONEncode(WsNotification{
-			JSONRPCVersion: rpcVersion,
-			Method:         "subscribeOrderbook",
-			Params:         params{Symbol: pF.String()},
-		})
-		if err != nil {
-			return err
-		}
+// WsUnsubscribe unsubscribes from channels
+func (h *HitBTC) WsUnsubscribe(subs []exchange.ChannelSubscription) error {
+	return h.wsWriteSubscriptions("unsubscribe", subs)
+}
 
-		err = h.WebsocketConn.WriteMessage(websocket.TextMessage, orderbookSubReq)
-		if err != nil {
-			return nil
-		}
+// wsWriteSubscriptions sends a subscribe or unsubscribe notification for each
+// subscription
+func (h *HitBTC) wsWriteSubscriptions(method string, subs []exchange.ChannelSubscription) error {
+	for i := range subs {
+		pF := exchange.FormatExchangeCurrency(h.GetName(), subs[i].Currency)
 
-		tradeSubReq, err := common.JSONEncode(WsNotification{
+		req, err := common.JSONEncode(WsNotification{
 			JSONRPCVersion: rpcVersion,
-			Method:         "subscribeTrades",
+			Method:         method + subs[i].Channel,
 			Params:         params{Symbol: pF.String()},
 		})
 		if err != nil {
 			return err
 		}
 
-		err = h.WebsocketConn.WriteMessage(websocket.TextMessage, tradeSubReq)
+		err = h.WebsocketConn.WriteMessage(websocket.TextMessage, req)
 		if err != nil {
-			return nil
+			return err
 		}
 	}
 	return nil
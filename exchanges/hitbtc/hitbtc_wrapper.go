@@ -1,10 +1,10 @@
 package hitbtc
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
-	"sync"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
@@ -13,17 +13,13 @@ import (
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
 
-// Start starts the HitBTC go routine
-func (h *HitBTC) Start(wg *sync.WaitGroup) {
-	wg.Add(1)
-	go func() {
-		h.Run()
-		wg.Done()
-	}()
+// Start starts the HitBTC wrapper, refreshing its currency pairs
+func (h *HitBTC) Start(ctx context.Context) error {
+	return h.StartWrapper(ctx, h.Run)
 }
 
 // Run implements the HitBTC wrapper
-func (h *HitBTC) Run() {
+func (h *HitBTC) Run(ctx context.Context) error {
 	if h.Verbose {
 		log.Printf("%s Websocket: %s (url: %s).\n", h.GetName(), common.IsEnabled(h.Websocket.IsEnabled()), hitbtcWebsocketAddress)
 		log.Printf("%s polling delay: %ds.\n", h.GetName(), h.RESTPollingDelay)
@@ -57,6 +53,7 @@ func (h *HitBTC) Run() {
 			log.Printf("%s Failed to update available currencies.\n", h.GetName())
 		}
 	}
+	return nil
 }
 
 // UpdateTicker updates and returns the ticker for a currency pair
@@ -157,6 +154,10 @@ func (h *HitBTC) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]ex
 
 // SubmitOrder submits a new order
 func (h *HitBTC) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	if h.IsSimulated() {
+		return h.SimulateSubmitOrder(p, side, orderType, amount, price, clientID)
+	}
+
 	var submitOrderResponse exchange.SubmitOrderResponse
 	response, err := h.PlaceOrder(p.Pair().String(), price, amount, common.StringToLower(orderType.ToString()), common.StringToLower(side.ToString()))
 
@@ -174,11 +175,19 @@ func (h *HitBTC) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, order
 // ModifyOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (h *HitBTC) ModifyOrder(action exchange.ModifyOrder) (string, error) {
+	if h.IsSimulated() {
+		return h.SimulateModifyOrder(action)
+	}
+
 	return "", common.ErrFunctionNotSupported
 }
 
 // CancelOrder cancels an order by its corresponding ID number
 func (h *HitBTC) CancelOrder(order exchange.OrderCancellation) error {
+	if h.IsSimulated() {
+		return h.SimulateCancelOrder(order)
+	}
+
 	orderIDInt, err := strconv.ParseInt(order.OrderID, 10, 64)
 
 	if err != nil {
@@ -192,6 +201,10 @@ func (h *HitBTC) CancelOrder(order exchange.OrderCancellation) error {
 
 // CancelAllOrders cancels all orders associated with a currency pair
 func (h *HitBTC) CancelAllOrders(orderCancellation exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
+	if h.IsSimulated() {
+		return h.SimulateCancelAllOrders(orderCancellation)
+	}
+
 	cancelAllOrdersResponse := exchange.CancelAllOrdersResponse{
 		OrderStatus: make(map[string]string),
 	}
@@ -209,6 +222,10 @@ func (h *HitBTC) CancelAllOrders(orderCancellation exchange.OrderCancellation) (
 
 // GetOrderInfo returns information on a current open order
 func (h *HitBTC) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
+	if h.IsSimulated() {
+		return h.SimulateGetOrderInfo(orderID)
+	}
+
 	var orderDetail exchange.OrderDetail
 	return orderDetail, common.ErrNotYetImplemented
 }
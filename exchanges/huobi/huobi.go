@@ -15,12 +15,16 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/config"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/assets"
+	"github.com/thrasher-/gocryptotrader/exchanges/fees"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/request"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
@@ -30,6 +34,7 @@ const (
 	huobiAPIVersion = "1"
 
 	huobiMarketHistoryKline    = "market/history/kline"
+	huobiKlineMaxSize          = 2000
 	huobiMarketDetail          = "market/detail"
 	huobiMarketDetailMerged    = "market/detail/merged"
 	huobiMarketDepth           = "market/depth"
@@ -47,7 +52,7 @@ const (
 	huobiGetOrder              = "order/orders/%s"
 	huobiGetOrderMatch         = "order/orders/%s/matchresults"
 	huobiGetOrders             = "order/orders"
-	huobiGetOpenOrders         = "order/order/openOrders"
+	huobiGetOpenOrders         = "order/openOrders"
 	huobiGetOrdersMatch        = "orders/matchresults"
 	huobiMarginTransferIn      = "dw/transfer-in/margin"
 	huobiMarginTransferOut     = "dw/transfer-out/margin"
@@ -57,16 +62,30 @@ const (
 	huobiMarginAccountBalance  = "margin/accounts/balance"
 	huobiWithdrawCreate        = "dw/withdraw/api/create"
 	huobiWithdrawCancel        = "dw/withdraw-virtual/%s/cancel"
+	huobiDepositWithdraw       = "query/deposit-withdraw"
+	huobiFeeRate               = "fee/fee-rate/get"
+
+	// huobiTradeFee is the published base trading fee rate
+	huobiTradeFee = 0.002
 
 	huobiAuthRate   = 100
 	huobiUnauthRate = 100
 )
 
+// contractPairFormat is the pair format of futures and swap pairs, futures
+// pairs are their underlying coin against USD
+var contractPairFormat = config.CurrencyPairFormatConfig{Delimiter: "-", Uppercase: true}
+
 // HUOBI is the overarching type across this package
 type HUOBI struct {
 	exchange.Base
-	AccountID     string
-	WebsocketConn *websocket.Conn
+	AccountID            string
+	WebsocketConn        *websocket.Conn
+	WebsocketPrivateConn *websocket.Conn
+	wsBuffers            map[string]*orderbook.Buffer
+	wsPrivateWriteLock   sync.Mutex
+	leverage             map[string]int
+	leverageLock         sync.Mutex
 }
 
 // SetDefaults sets default values for the exchange
@@ -77,20 +96,69 @@ func (h *HUOBI) SetDefaults() {
 	h.Verbose = false
 	h.RESTPollingDelay = 10
 	h.APIWithdrawPermissions = exchange.AutoWithdrawCryptoWithSetup
+	h.Features = exchange.Features{
+		CanGetTicker:          true,
+		CanGetOrderbook:       true,
+		CanGetHistoricCandles: true,
+		CanGetAccountInfo:     true,
+		CanGetFundingHistory:  true,
+		CanSubmitOrder:        true,
+		CanCancelOrder:        true,
+		CanCancelAllOrders:    true,
+		CanGetActiveOrders:    true,
+		CanGetOrderHistory:    true,
+		CanStreamOrderbook:    true,
+		CanStreamTrades:       true,
+	}
+	h.Features.Orders = map[string]exchange.OrderCapabilities{
+		assets.Spot: {
+			OrderTypes:  []exchange.OrderType{exchange.Limit, exchange.Market},
+			TimeInForce: []exchange.TimeInForce{exchange.GTC},
+		},
+		assets.Futures: {
+			OrderTypes:  []exchange.OrderType{exchange.Limit, exchange.Market},
+			TimeInForce: []exchange.TimeInForce{exchange.GTC},
+		},
+		assets.PerpetualSwap: {
+			OrderTypes:  []exchange.OrderType{exchange.Limit, exchange.Market},
+			TimeInForce: []exchange.TimeInForce{exchange.GTC},
+		},
+	}
 	h.RequestCurrencyPairFormat.Delimiter = ""
 	h.RequestCurrencyPairFormat.Uppercase = false
 	h.ConfigCurrencyPairFormat.Delimiter = "-"
 	h.ConfigCurrencyPairFormat.Uppercase = true
-	h.AssetTypes = []string{ticker.Spot}
+	h.AssetTypes = []string{ticker.Spot, assets.Futures, assets.PerpetualSwap}
+	h.AssetPairs = map[string]*exchange.AssetPairs{
+		assets.Futures: {
+			AvailablePairs:            []string{"BTC-USD", "ETH-USD"},
+			EnabledPairs:              []string{"BTC-USD"},
+			RequestCurrencyPairFormat: &contractPairFormat,
+			ConfigCurrencyPairFormat:  &contractPairFormat,
+		},
+		assets.PerpetualSwap: {
+			AvailablePairs:            []string{"BTC-USDT", "ETH-USDT"},
+			EnabledPairs:              []string{"BTC-USDT"},
+			RequestCurrencyPairFormat: &contractPairFormat,
+			ConfigCurrencyPairFormat:  &contractPairFormat,
+		},
+	}
 	h.SupportsAutoPairUpdating = true
 	h.SupportsRESTTickerBatching = false
+	h.MarketBuyInQuote = true
 	h.Requester = request.New(h.Name,
 		request.NewRateLimit(time.Second*10, huobiAuthRate),
 		request.NewRateLimit(time.Second*10, huobiUnauthRate),
 		common.NewHTTPClientWithTimeout(exchange.DefaultHTTPTimeout))
 	h.APIUrlDefault = huobiAPIURL
 	h.APIUrl = h.APIUrlDefault
+	h.APIUrlSecondaryDefault = huobiFuturesAPIURL
+	h.APIUrlSecondary = h.APIUrlSecondaryDefault
 	h.WebsocketInit()
+	err := fees.Register(h.Name, FeeSchedule)
+	if err != nil {
+		log.Fatal(err)
+	}
 }
 
 // Setup sets user configuration
@@ -131,6 +199,31 @@ func (h *HUOBI) Setup(exch config.ExchangeConfig) {
 		if err != nil {
 			log.Fatal(err)
 		}
+		err = h.SetTLSPins(exch.TLSPins)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = h.SetLocalAddress(exch.LocalAddress)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = h.SetRoundingModes(exch.RoundingModes)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = h.SetRateLimitTiers(exch.RateLimitTiers, exch.AccountTier)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = h.SetHTTPClientHeaders(exch.HTTPHeaders)
+		if err != nil {
+			log.Fatal(err)
+		}
+		h.SetSimulation(exch.Simulate, exch.SimulationBalances)
+		err = fees.SetDiscount(h.Name, exch.FeeDiscount)
+		if err != nil {
+			log.Fatal(err)
+		}
 
 		err = h.WebsocketSetup(h.WsConnect,
 			exch.Name,
@@ -305,8 +398,9 @@ func (h *HUOBI) GetSymbols() ([]Symbol, error) {
 	var result response
 	url := fmt.Sprintf("%s/v%s/%s", h.APIUrl, huobiAPIVersion, huobiSymbols)
 
-	err := h.SendHTTPRequest(url, &result)
+	err := h.SendCachedHTTPRequest(url, &result)
 	if result.ErrorMessage != "" {
+		h.UncachePayload(url)
 		return nil, errors.New(result.ErrorMessage)
 	}
 	return result.Symbols, err
@@ -322,8 +416,9 @@ func (h *HUOBI) GetCurrencies() ([]string, error) {
 	var result response
 	url := fmt.Sprintf("%s/v%s/%s", h.APIUrl, huobiAPIVersion, huobiCurrencies)
 
-	err := h.SendHTTPRequest(url, &result)
+	err := h.SendCachedHTTPRequest(url, &result)
 	if result.ErrorMessage != "" {
+		h.UncachePayload(url)
 		return nil, errors.New(result.ErrorMessage)
 	}
 	return result.Currencies, err
@@ -565,10 +660,13 @@ func (h *HUOBI) GetOpenOrders(accountID, symbol, side string, size int) ([]Order
 
 	vals := url.Values{}
 	vals.Set("symbol", symbol)
-	vals.Set("accountID", accountID)
-	vals.Set("side", side)
+	vals.Set("account-id", accountID)
 	vals.Set("size", fmt.Sprintf("%v", size))
 
+	if side != "" {
+		vals.Set("side", side)
+	}
+
 	var result response
 	err := h.SendAuthenticatedHTTPRequest("GET", huobiGetOpenOrders, vals, nil, &result)
 
@@ -823,11 +921,54 @@ func (h *HUOBI) CancelWithdraw(withdrawID int64) (int64, error) {
 	return result.WithdrawID, err
 }
 
+// GetDepositWithdrawals returns the deposit or withdrawal records of a
+// currency, transferType is deposit or withdraw. Records are returned from
+// the ID from in the direct direction, prev or next.
+func (h *HUOBI) GetDepositWithdrawals(currency, transferType, from, direct string, size int) ([]DepositWithdrawal, error) {
+	type response struct {
+		Response
+		Records []DepositWithdrawal `json:"data"`
+	}
+
+	vals := url.Values{}
+	vals.Set("type", transferType)
+
+	if currency != "" {
+		vals.Set("currency", common.StringToLower(currency))
+	}
+
+	if from != "" {
+		vals.Set("from", from)
+	}
+
+	if direct != "" {
+		vals.Set("direct", direct)
+	}
+
+	if size != 0 {
+		vals.Set("size", strconv.Itoa(size))
+	}
+
+	var result response
+	err := h.SendAuthenticatedHTTPRequest("GET", huobiDepositWithdraw, vals, nil, &result)
+
+	if result.ErrorMessage != "" {
+		return nil, errors.New(result.ErrorMessage)
+	}
+	return result.Records, err
+}
+
 // SendHTTPRequest sends an unauthenticated HTTP request
 func (h *HUOBI) SendHTTPRequest(path string, result interface{}) error {
 	return h.SendPayload("GET", path, nil, nil, result, false, h.Verbose)
 }
 
+// SendCachedHTTPRequest sends an unauthenticated HTTP request for static data
+// through the metadata cache
+func (h *HUOBI) SendCachedHTTPRequest(path string, result interface{}) error {
+	return h.SendCachedPayload(path, nil, result, h.Verbose)
+}
+
 // SendAuthenticatedHTTPRequest sends authenticated requests to the HUOBI API
 func (h *HUOBI) SendAuthenticatedHTTPRequest(method, endpoint string, values url.Values, data interface{}, result interface{}) error {
 	if !h.AuthenticatedAPISupport {
@@ -904,12 +1045,42 @@ func (h *HUOBI) SendAuthenticatedHTTPRequest(method, endpoint string, values url
 	return h.SendPayload(method, url, headers, bytes.NewReader(body), result, true, h.Verbose)
 }
 
-// GetFee returns an estimate of fee based on type of transaction
+// GetFeeRates returns the account maker and taker fee rates of symbols
+func (h *HUOBI) GetFeeRates(symbols ...string) ([]FeeRate, error) {
+	type response struct {
+		Response
+		FeeRates []FeeRate `json:"data"`
+	}
+
+	vals := url.Values{}
+	vals.Set("symbols", common.JoinStrings(symbols, ","))
+
+	var result response
+	err := h.SendAuthenticatedHTTPRequest("GET", huobiFeeRate, vals, nil, &result)
+
+	if result.ErrorMessage != "" {
+		return nil, errors.New(result.ErrorMessage)
+	}
+	return result.FeeRates, err
+}
+
+// GetFee returns an estimate of fee based on type of transaction. Trading fees
+// use the account fee rate when credentials are set, otherwise the published
+// base rate.
 func (h *HUOBI) GetFee(feeBuilder exchange.FeeBuilder) (float64, error) {
 	var fee float64
 	switch feeBuilder.FeeType {
 	case exchange.CryptocurrencyTradeFee:
-		fee = calculateTradingFee(feeBuilder.PurchasePrice, feeBuilder.Amount)
+		fee = calculateTradingFee(h.getTradeFeeRate(feeBuilder),
+			feeBuilder.PurchasePrice, feeBuilder.Amount)
+	case exchange.CryptocurrencyWithdrawalFee:
+		fee = getWithdrawalFee(feeBuilder.FirstCurrency)
+	case exchange.CyptocurrencyDepositFee:
+		// Huobi does not charge for cryptocurrency deposits
+		fee = 0
+	case exchange.InternationalBankDepositFee, exchange.InternationalBankWithdrawalFee:
+		// Huobi has no fiat bank transfers, fiat is traded over the counter
+		fee = 0
 	}
 	if fee < 0 {
 		fee = 0
@@ -918,7 +1089,37 @@ func (h *HUOBI) GetFee(feeBuilder exchange.FeeBuilder) (float64, error) {
 	return fee, nil
 }
 
-func calculateTradingFee(purchasePrice, amount float64) float64 {
-	feePercent := 0.002
-	return feePercent * purchasePrice * amount
+// getTradeFeeRate returns the account fee rate of the pair, falling back to
+// the published base rate when it cannot be retrieved
+func (h *HUOBI) getTradeFeeRate(feeBuilder exchange.FeeBuilder) float64 {
+	if !h.AuthenticatedAPISupport || h.APIKey == "" {
+		return h.scheduleTradeFeeRate(feeBuilder.IsMaker)
+	}
+	rates, err := h.GetFeeRates(common.StringToLower(feeBuilder.FirstCurrency + feeBuilder.SecondCurrency))
+	if err != nil || len(rates) == 0 {
+		return h.scheduleTradeFeeRate(feeBuilder.IsMaker)
+	}
+	if feeBuilder.IsMaker {
+		return rates[0].MakerFee
+	}
+	return rates[0].TakerFee
+}
+
+// scheduleTradeFeeRate returns the fee schedule's rate for the account's
+// trading volume, the published base rate when no schedule is registered
+func (h *HUOBI) scheduleTradeFeeRate(isMaker bool) float64 {
+	rate, err := fees.TradeRate(h.Name, isMaker)
+	if err != nil {
+		return huobiTradeFee
+	}
+	return rate
+}
+
+// getWithdrawalFee returns the published withdrawal fee of a cryptocurrency
+func getWithdrawalFee(currency string) float64 {
+	return WithdrawalFees[common.StringToUpper(currency)]
+}
+
+func calculateTradingFee(feeRate, purchasePrice, amount float64) float64 {
+	return feeRate * purchasePrice * amount
 }
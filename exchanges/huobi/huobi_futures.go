@@ -0,0 +1,361 @@
+package huobi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+)
+
+// Huobi contract API endpoints. Coin margined futures are the Huobi DM
+// delivery contracts, USDT margined contracts are linear perpetual swaps.
+const (
+	huobiFuturesAPIURL = "https://api.hbdm.com"
+
+	huobiContractInfo             = "api/v1/contract_contract_info"
+	huobiContractMarketDetail     = "market/detail/merged"
+	huobiContractMarketDepth      = "market/depth"
+	huobiContractAccountInfo      = "api/v1/contract_account_info"
+	huobiContractPositionInfo     = "api/v1/contract_position_info"
+	huobiContractSwitchLeverRate  = "api/v1/contract_switch_lever_rate"
+	huobiContractOrder            = "api/v1/contract_order"
+	huobiContractCancel           = "api/v1/contract_cancel"
+	huobiContractOrderInfo        = "api/v1/contract_order_info"
+	huobiLinearSwapInfo           = "linear-swap-api/v1/swap_contract_info"
+	huobiLinearSwapMarketDetail   = "linear-swap-ex/market/detail/merged"
+	huobiLinearSwapMarketDepth    = "linear-swap-ex/market/depth"
+	huobiLinearSwapAccountInfo    = "linear-swap-api/v1/swap_account_info"
+	huobiLinearSwapPositionInfo   = "linear-swap-api/v1/swap_position_info"
+	huobiLinearSwapSwitchLeverage = "linear-swap-api/v1/swap_switch_lever_rate"
+	huobiLinearSwapOrder          = "linear-swap-api/v1/swap_order"
+	huobiLinearSwapCancel         = "linear-swap-api/v1/swap_cancel"
+	huobiLinearSwapOrderInfo      = "linear-swap-api/v1/swap_order_info"
+
+	// huobiDefaultLeverage is the lever rate of contract orders until set
+	huobiDefaultLeverage = 1
+)
+
+// GetContractInfo returns the coin margined futures contracts of a symbol,
+// such as BTC, or every symbol when empty
+func (h *HUOBI) GetContractInfo(symbol string) ([]ContractInfo, error) {
+	vals := url.Values{}
+	if symbol != "" {
+		vals.Set("symbol", symbol)
+	}
+
+	type response struct {
+		FuturesResponse
+		Data []ContractInfo `json:"data"`
+	}
+
+	var result response
+	path := fmt.Sprintf("%s/%s", h.APIUrlSecondary, huobiContractInfo)
+	err := h.SendHTTPRequest(common.EncodeURLValues(path, vals), &result)
+	if result.ErrorMessage != "" {
+		return nil, errors.New(result.ErrorMessage)
+	}
+	return result.Data, err
+}
+
+// GetLinearSwapContractInfo returns the USDT margined swap of a contract code,
+// such as BTC-USDT, or every swap when empty
+func (h *HUOBI) GetLinearSwapContractInfo(contractCode string) ([]ContractInfo, error) {
+	vals := url.Values{}
+	if contractCode != "" {
+		vals.Set("contract_code", contractCode)
+	}
+
+	type response struct {
+		FuturesResponse
+		Data []ContractInfo `json:"data"`
+	}
+
+	var result response
+	path := fmt.Sprintf("%s/%s", h.APIUrlSecondary, huobiLinearSwapInfo)
+	err := h.SendHTTPRequest(common.EncodeURLValues(path, vals), &result)
+	if result.ErrorMessage != "" {
+		return nil, errors.New(result.ErrorMessage)
+	}
+	return result.Data, err
+}
+
+// GetContractMarketDetailMerged returns the ticker of a coin margined futures
+// contract by its symbol and contract type, such as BTC_CQ, or contract code
+func (h *HUOBI) GetContractMarketDetailMerged(symbol string) (ContractDetailMerged, error) {
+	vals := url.Values{}
+	vals.Set("symbol", symbol)
+	return h.getContractDetailMerged(huobiContractMarketDetail, vals)
+}
+
+// GetLinearSwapMarketDetailMerged returns the ticker of a USDT margined swap
+func (h *HUOBI) GetLinearSwapMarketDetailMerged(contractCode string) (ContractDetailMerged, error) {
+	vals := url.Values{}
+	vals.Set("contract_code", contractCode)
+	return h.getContractDetailMerged(huobiLinearSwapMarketDetail, vals)
+}
+
+func (h *HUOBI) getContractDetailMerged(endpoint string, vals url.Values) (ContractDetailMerged, error) {
+	type response struct {
+		FuturesResponse
+		Tick ContractDetailMerged `json:"tick"`
+	}
+
+	var result response
+	path := fmt.Sprintf("%s/%s", h.APIUrlSecondary, endpoint)
+	err := h.SendHTTPRequest(common.EncodeURLValues(path, vals), &result)
+	if result.ErrorMessage != "" {
+		return result.Tick, errors.New(result.ErrorMessage)
+	}
+	return result.Tick, err
+}
+
+// GetContractDepth returns the depth of a coin margined futures contract by
+// its symbol and contract type, such as BTC_CQ, or contract code
+func (h *HUOBI) GetContractDepth(symbol string) (Orderbook, error) {
+	vals := url.Values{}
+	vals.Set("symbol", symbol)
+	vals.Set("type", string(OrderBookDataRequestParamsTypeStep0))
+	return h.getContractDepth(huobiContractMarketDepth, vals)
+}
+
+// GetLinearSwapDepth returns the depth of a USDT margined swap
+func (h *HUOBI) GetLinearSwapDepth(contractCode string) (Orderbook, error) {
+	vals := url.Values{}
+	vals.Set("contract_code", contractCode)
+	vals.Set("type", string(OrderBookDataRequestParamsTypeStep0))
+	return h.getContractDepth(huobiLinearSwapMarketDepth, vals)
+}
+
+func (h *HUOBI) getContractDepth(endpoint string, vals url.Values) (Orderbook, error) {
+	type response struct {
+		FuturesResponse
+		Depth Orderbook `json:"tick"`
+	}
+
+	var result response
+	path := fmt.Sprintf("%s/%s", h.APIUrlSecondary, endpoint)
+	err := h.SendHTTPRequest(common.EncodeURLValues(path, vals), &result)
+	if result.ErrorMessage != "" {
+		return result.Depth, errors.New(result.ErrorMessage)
+	}
+	return result.Depth, err
+}
+
+// GetContractAccountInfo returns the coin margined futures accounts of a
+// symbol, or every symbol when empty
+func (h *HUOBI) GetContractAccountInfo(symbol string) ([]ContractAccount, error) {
+	var result []ContractAccount
+	err := h.SendAuthenticatedFuturesHTTPRequest(huobiContractAccountInfo,
+		symbolParams("symbol", symbol), &result)
+	return result, err
+}
+
+// GetLinearSwapAccountInfo returns the USDT margined swap accounts of a
+// contract code, or every contract when empty
+func (h *HUOBI) GetLinearSwapAccountInfo(contractCode string) ([]ContractAccount, error) {
+	var result []ContractAccount
+	err := h.SendAuthenticatedFuturesHTTPRequest(huobiLinearSwapAccountInfo,
+		symbolParams("contract_code", contractCode), &result)
+	return result, err
+}
+
+// GetContractPositions returns the coin margined futures positions of a
+// symbol, or every symbol when empty
+func (h *HUOBI) GetContractPositions(symbol string) ([]ContractPosition, error) {
+	var result []ContractPosition
+	err := h.SendAuthenticatedFuturesHTTPRequest(huobiContractPositionInfo,
+		symbolParams("symbol", symbol), &result)
+	return result, err
+}
+
+// GetLinearSwapPositions returns the USDT margined swap positions of a
+// contract code, or every contract when empty
+func (h *HUOBI) GetLinearSwapPositions(contractCode string) ([]ContractPosition, error) {
+	var result []ContractPosition
+	err := h.SendAuthenticatedFuturesHTTPRequest(huobiLinearSwapPositionInfo,
+		symbolParams("contract_code", contractCode), &result)
+	return result, err
+}
+
+// SetContractLeverage switches the lever rate of a coin margined futures
+// symbol, contract orders are placed at this lever rate
+func (h *HUOBI) SetContractLeverage(symbol string, leverage int) error {
+	err := h.SendAuthenticatedFuturesHTTPRequest(huobiContractSwitchLeverRate,
+		map[string]interface{}{"symbol": symbol, "lever_rate": leverage}, nil)
+	if err != nil {
+		return err
+	}
+	h.setLeverage(symbol, leverage)
+	return nil
+}
+
+// SetLinearSwapLeverage switches the lever rate of a USDT margined swap,
+// contract orders are placed at this lever rate
+func (h *HUOBI) SetLinearSwapLeverage(contractCode string, leverage int) error {
+	err := h.SendAuthenticatedFuturesHTTPRequest(huobiLinearSwapSwitchLeverage,
+		map[string]interface{}{"contract_code": contractCode, "lever_rate": leverage}, nil)
+	if err != nil {
+		return err
+	}
+	h.setLeverage(contractCode, leverage)
+	return nil
+}
+
+// PlaceContractOrder places a coin margined futures order and returns its
+// order ID
+func (h *HUOBI) PlaceContractOrder(arg ContractOrderRequest) (int64, error) {
+	return h.placeContractOrder(huobiContractOrder, arg)
+}
+
+// PlaceLinearSwapOrder places a USDT margined swap order and returns its
+// order ID
+func (h *HUOBI) PlaceLinearSwapOrder(arg ContractOrderRequest) (int64, error) {
+	return h.placeContractOrder(huobiLinearSwapOrder, arg)
+}
+
+func (h *HUOBI) placeContractOrder(endpoint string, arg ContractOrderRequest) (int64, error) {
+	if arg.Volume <= 0 {
+		return 0, errors.New("contract order volume must be greater than zero")
+	}
+	var result struct {
+		OrderID int64 `json:"order_id"`
+	}
+	err := h.SendAuthenticatedFuturesHTTPRequest(endpoint, arg, &result)
+	return result.OrderID, err
+}
+
+// CancelContractOrder cancels a coin margined futures order of a symbol
+func (h *HUOBI) CancelContractOrder(symbol string, orderID int64) error {
+	return h.cancelContractOrder(huobiContractCancel, "symbol", symbol, orderID)
+}
+
+// CancelLinearSwapOrder cancels a USDT margined swap order
+func (h *HUOBI) CancelLinearSwapOrder(contractCode string, orderID int64) error {
+	return h.cancelContractOrder(huobiLinearSwapCancel, "contract_code", contractCode, orderID)
+}
+
+func (h *HUOBI) cancelContractOrder(endpoint, symbolKey, symbol string, orderID int64) error {
+	var result ContractCancelResponse
+	err := h.SendAuthenticatedFuturesHTTPRequest(endpoint, map[string]interface{}{
+		symbolKey:  symbol,
+		"order_id": strconv.FormatInt(orderID, 10),
+	}, &result)
+	if err != nil {
+		return err
+	}
+	if len(result.Errors) > 0 {
+		return errors.New(result.Errors[0].ErrorMessage)
+	}
+	return nil
+}
+
+// GetContractOrderInfo returns a coin margined futures order of a symbol
+func (h *HUOBI) GetContractOrderInfo(symbol string, orderID int64) (ContractOrderInfo, error) {
+	return h.getContractOrderInfo(huobiContractOrderInfo, "symbol", symbol, orderID)
+}
+
+// GetLinearSwapOrderInfo returns a USDT margined swap order
+func (h *HUOBI) GetLinearSwapOrderInfo(contractCode string, orderID int64) (ContractOrderInfo, error) {
+	return h.getContractOrderInfo(huobiLinearSwapOrderInfo, "contract_code", contractCode, orderID)
+}
+
+func (h *HUOBI) getContractOrderInfo(endpoint, symbolKey, symbol string, orderID int64) (ContractOrderInfo, error) {
+	var result []ContractOrderInfo
+	err := h.SendAuthenticatedFuturesHTTPRequest(endpoint, map[string]interface{}{
+		symbolKey:  symbol,
+		"order_id": strconv.FormatInt(orderID, 10),
+	}, &result)
+	if err != nil {
+		return ContractOrderInfo{}, err
+	}
+	if len(result) == 0 {
+		return ContractOrderInfo{}, fmt.Errorf("%s contract order %d not found", h.Name, orderID)
+	}
+	return result[0], nil
+}
+
+// SendAuthenticatedFuturesHTTPRequest sends an authenticated request to the
+// Huobi contract API and decodes the data of its response into result
+func (h *HUOBI) SendAuthenticatedFuturesHTTPRequest(endpoint string, data, result interface{}) error {
+	if !h.AuthenticatedAPISupport {
+		return fmt.Errorf(exchange.WarningAuthenticatedRequestWithoutCredentialsSet, h.Name)
+	}
+
+	host, err := url.Parse(h.APIUrlSecondary)
+	if err != nil {
+		return err
+	}
+
+	values := url.Values{}
+	values.Set("AccessKeyId", h.APIKey)
+	values.Set("SignatureMethod", "HmacSHA256")
+	values.Set("SignatureVersion", "2")
+	values.Set("Timestamp", time.Now().UTC().Format("2006-01-02T15:04:05"))
+
+	endpoint = "/" + endpoint
+	payload := fmt.Sprintf("%s\n%s\n%s\n%s",
+		http.MethodPost, host.Host, endpoint, values.Encode())
+	hmac := common.GetHMAC(common.HashSHA256, []byte(payload), []byte(h.APISecret))
+	values.Set("Signature", common.Base64Encode(hmac))
+
+	body, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("Huobi unable to marshal data: %s", err)
+	}
+
+	var resp struct {
+		FuturesResponse
+		Data json.RawMessage `json:"data"`
+	}
+	path := common.EncodeURLValues(h.APIUrlSecondary+endpoint, values)
+	headers := map[string]string{"Content-Type": "application/json"}
+	err = h.SendPayload(http.MethodPost, path, headers, bytes.NewReader(body), &resp, true, h.Verbose)
+	if err != nil {
+		return err
+	}
+	if resp.Status != "ok" {
+		return fmt.Errorf("%s contract API error %d: %s", h.Name, resp.ErrorCode, resp.ErrorMessage)
+	}
+	if result == nil || len(resp.Data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(resp.Data, result)
+}
+
+// symbolParams returns the request parameters filtering by a symbol or
+// contract code, empty when the symbol is empty
+func symbolParams(key, symbol string) map[string]interface{} {
+	params := make(map[string]interface{})
+	if symbol != "" {
+		params[key] = symbol
+	}
+	return params
+}
+
+// getLeverage returns the lever rate orders for a contract symbol or code are
+// placed at
+func (h *HUOBI) getLeverage(symbol string) int {
+	h.leverageLock.Lock()
+	defer h.leverageLock.Unlock()
+	if l, ok := h.leverage[symbol]; ok {
+		return l
+	}
+	return huobiDefaultLeverage
+}
+
+// setLeverage records the lever rate set for a contract symbol or code
+func (h *HUOBI) setLeverage(symbol string, leverage int) {
+	h.leverageLock.Lock()
+	defer h.leverageLock.Unlock()
+	if h.leverage == nil {
+		h.leverage = make(map[string]int)
+	}
+	h.leverage[symbol] = leverage
+}
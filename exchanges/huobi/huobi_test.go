@@ -9,12 +9,17 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/config"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
 	"github.com/thrasher-/gocryptotrader/currency/symbol"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/assets"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/trade"
 )
 
 // Please supply you own test keys here for due diligence testing.
@@ -86,6 +91,24 @@ func TestGetSpotKline(t *testing.T) {
 	}
 }
 
+func TestGetHistoricCandles(t *testing.T) {
+	t.Parallel()
+	p := pair.NewCurrencyPair("BTC", "USDT")
+	end := time.Now()
+	_, err := h.GetHistoricCandles(p, "SPOT", kline.ThreeMin, end.Add(-time.Hour), end)
+	if err != kline.ErrUnsupportedInterval {
+		t.Errorf("Test failed - Huobi GetHistoricCandles() expected unsupported interval: %s", err)
+	}
+	_, err = h.GetHistoricCandles(p, "SPOT", kline.OneHour, end, end.Add(-time.Hour))
+	if err != kline.ErrInvalidTimeRange {
+		t.Errorf("Test failed - Huobi GetHistoricCandles() expected invalid range: %s", err)
+	}
+	_, err = h.GetHistoricCandles(p, "SPOT", kline.OneHour, end.Add(-time.Hour*24), end)
+	if err != nil {
+		t.Errorf("Test failed - Huobi GetHistoricCandles() error: %s", err)
+	}
+}
+
 func TestGetMarketDetailMerged(t *testing.T) {
 	t.Parallel()
 	_, err := h.GetMarketDetailMerged("btcusdt")
@@ -268,6 +291,19 @@ func TestCancelWithdraw(t *testing.T) {
 	}
 }
 
+func TestGetDepositWithdrawals(t *testing.T) {
+	t.Parallel()
+
+	if h.APIKey == "" || h.APISecret == "" || h.APIAuthPEMKey == "" {
+		t.Skip()
+	}
+
+	_, err := h.GetDepositWithdrawals("btc", "deposit", "", "", 10)
+	if err != nil {
+		t.Errorf("Test failed - Huobi TestGetDepositWithdrawals: %s", err)
+	}
+}
+
 func TestPEMLoadAndSign(t *testing.T) {
 	t.Parallel()
 
@@ -344,8 +380,8 @@ func TestGetFee(t *testing.T) {
 	// CryptocurrencyWithdrawalFee Basic
 	feeBuilder = setFeeBuilder()
 	feeBuilder.FeeType = exchange.CryptocurrencyWithdrawalFee
-	if resp, err := h.GetFee(feeBuilder); resp != float64(0) || err != nil {
-		t.Errorf("Test Failed - GetFee() error. Expected: %f, Received: %f", float64(0), resp)
+	if resp, err := h.GetFee(feeBuilder); resp != float64(0.001) || err != nil {
+		t.Errorf("Test Failed - GetFee() error. Expected: %f, Received: %f", float64(0.001), resp)
 		t.Error(err)
 	}
 
@@ -382,6 +418,28 @@ func TestGetFee(t *testing.T) {
 		t.Errorf("Test Failed - GetFee() error. Expected: %f, Received: %f", float64(0), resp)
 		t.Error(err)
 	}
+
+	// CryptocurrencyWithdrawalFee lower case currency
+	feeBuilder = setFeeBuilder()
+	feeBuilder.FirstCurrency = "eth"
+	feeBuilder.FeeType = exchange.CryptocurrencyWithdrawalFee
+	if resp, err := h.GetFee(feeBuilder); resp != float64(0.01) || err != nil {
+		t.Errorf("Test Failed - GetFee() error. Expected: %f, Received: %f", float64(0.01), resp)
+		t.Error(err)
+	}
+}
+
+func TestFeeSchedule(t *testing.T) {
+	t.Parallel()
+	if err := FeeSchedule.Validate(); err != nil {
+		t.Error("Test Failed - FeeSchedule Validate() error", err)
+	}
+	if tier := FeeSchedule.Tier(25000000); tier.Maker != 0.0016 || tier.Taker != 0.0018 {
+		t.Error("Test Failed - FeeSchedule Tier() unexpected tier", tier)
+	}
+	if fee, err := FeeSchedule.WithdrawalFee("btc"); err != nil || fee != 0.001 {
+		t.Error("Test Failed - FeeSchedule WithdrawalFee() unexpected fee", fee, err)
+	}
 }
 
 func TestFormatWithdrawPermissions(t *testing.T) {
@@ -396,6 +454,86 @@ func TestFormatWithdrawPermissions(t *testing.T) {
 	}
 }
 
+func TestFormatContractSymbol(t *testing.T) {
+	h.SetDefaults()
+	p := pair.NewCurrencyPairDelimiter("btc-usd", "-")
+	if s, err := h.FormatContractSymbol(p, assets.Futures); s != "BTC_CQ" || err != nil {
+		t.Errorf("Test Failed - FormatContractSymbol() error. Expected: BTC_CQ, Received: %s %v", s, err)
+	}
+	p = pair.NewCurrencyPairDelimiter("eth-usdt", "-")
+	if s, err := h.FormatContractSymbol(p, assets.PerpetualSwap); s != "ETH-USDT" || err != nil {
+		t.Errorf("Test Failed - FormatContractSymbol() error. Expected: ETH-USDT, Received: %s %v", s, err)
+	}
+	if _, err := h.FormatContractSymbol(p, assets.Spot); err == nil {
+		t.Error("Test Failed - FormatContractSymbol() expected error for spot")
+	}
+}
+
+func TestOrderDetail(t *testing.T) {
+	h.SetDefaults()
+	p := pair.NewCurrencyPair("btc", "usdt")
+	o := OrderInfo{ID: 42, Type: "sell-limit", Price: "4000", Amount: "2", FieldAmount: "0.5",
+		FieldCashAmount: "2010", CreatedAt: 1538352000000, State: "partial-filled"}
+	d := h.orderDetail(p, &o)
+	if d.ID != "42" || d.BaseCurrency != "BTC" || d.QuoteCurrency != "USDT" ||
+		d.OrderSide != string(exchange.Sell) || d.OrderType != string(exchange.Limit) {
+		t.Errorf("Test Failed - orderDetail() unexpected order %+v", d)
+	}
+	if d.OpenVolume != 1.5 || d.AverageExecutedPrice != 4020 ||
+		!d.CreationTime.Equal(time.Date(2018, 10, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Test Failed - orderDetail() unexpected amounts %+v", d)
+	}
+
+	o.State = "filled"
+	o.Type = "buy-ioc"
+	if d = h.orderDetail(p, &o); d.OpenVolume != 0 || d.OrderSide != string(exchange.Buy) ||
+		d.OrderType != string(exchange.ImmediateOrCancel) {
+		t.Errorf("Test Failed - orderDetail() unexpected filled order %+v", d)
+	}
+	if side, orderType := orderSideType("buy-market"); side != exchange.Buy || orderType != exchange.Market {
+		t.Error("Test Failed - orderSideType() unexpected market order", side, orderType)
+	}
+}
+
+func TestContractOrderRequest(t *testing.T) {
+	h.SetDefaults()
+	h.setLeverage("BTC", 20)
+	req, err := h.contractOrderRequest("BTC190927", exchange.Sell, exchange.Limit, 3, 4000, true, "42")
+	if err != nil {
+		t.Fatal("Test Failed - contractOrderRequest() error", err)
+	}
+	if req.Volume != 3 || req.Direction != ContractDirectionSell ||
+		req.Offset != ContractOffsetClose || req.OrderPriceType != ContractPriceLimit ||
+		req.Price != 4000 || req.LeverRate != 20 || req.ClientOrderID != 42 {
+		t.Errorf("Test Failed - contractOrderRequest() unexpected request %+v", req)
+	}
+
+	req, err = h.contractOrderRequest("ETH-USDT", exchange.Buy, exchange.Market, 1, 0, false, "")
+	if err != nil {
+		t.Fatal("Test Failed - contractOrderRequest() error", err)
+	}
+	if req.Offset != ContractOffsetOpen || req.OrderPriceType != ContractPriceOptimal20 ||
+		req.LeverRate != huobiDefaultLeverage {
+		t.Errorf("Test Failed - contractOrderRequest() unexpected request %+v", req)
+	}
+
+	if _, err = h.contractOrderRequest("BTC190927", exchange.Buy, exchange.Limit, 1.5, 4000, false, ""); err == nil {
+		t.Error("Test Failed - contractOrderRequest() expected error for fractional contracts")
+	}
+	if _, err = h.contractOrderRequest("BTC190927", exchange.Buy, exchange.Limit, 1, 4000, false, "abc"); err == nil {
+		t.Error("Test Failed - contractOrderRequest() expected error for non numeric client ID")
+	}
+}
+
+func TestContractCodeSymbol(t *testing.T) {
+	if s := contractCodeSymbol("BTC190927"); s != "BTC" {
+		t.Errorf("Test Failed - contractCodeSymbol() error. Expected: BTC, Received: %s", s)
+	}
+	if isLinearSwapCode("BTC190927") || !isLinearSwapCode("BTC-USDT") {
+		t.Error("Test Failed - isLinearSwapCode() error")
+	}
+}
+
 // Any tests below this line have the ability to impact your orders on the exchange. Enable canManipulateRealOrders to run them
 // ----------------------------------------------------------------------------------------------------------------------------
 func isRealOrderTestEnabled() bool {
@@ -489,6 +627,21 @@ func TestCancelAllExchangeOrders(t *testing.T) {
 	}
 }
 
+func TestSubmitOrderSubmission(t *testing.T) {
+	var _ exchange.OrderSubmissionSubmitter = &h
+	p := pair.NewCurrencyPair(symbol.BTC, symbol.USD)
+	for _, s := range []exchange.OrderSubmission{
+		{AssetType: assets.Margin, Pair: p, Side: exchange.Buy, Type: exchange.Limit, BaseAmount: 1, Price: 1},
+		{Pair: p, Side: exchange.Buy, Type: exchange.Limit, BaseAmount: 1, Price: 1, Trigger: exchange.StopLoss, TriggerPrice: 1},
+		{AssetType: assets.Futures, Pair: p, Side: exchange.Buy, Type: exchange.Limit, BaseAmount: 1, Price: 1, TimeInForce: exchange.IOC},
+		{AssetType: assets.Futures, Pair: p, Side: exchange.Buy, Type: exchange.Limit, BaseAmount: 1.5, Price: 1},
+	} {
+		if _, err := h.SubmitOrderSubmission(s); err == nil {
+			t.Errorf("Test Failed - SubmitOrderSubmission() expected %+v rejected", s)
+		}
+	}
+}
+
 func TestGetAccountInfo(t *testing.T) {
 	if apiKey == "" || apiSecret == "" {
 		_, err := h.GetAccountInfo()
@@ -509,3 +662,154 @@ func TestModifyOrder(t *testing.T) {
 		t.Error("Test failed - ModifyOrder() error")
 	}
 }
+
+func TestWsProcessOrderbook(t *testing.T) {
+	var hb HUOBI
+	hb.Name = "HuobiWsOrderbookTest"
+	hb.Websocket = &exchange.Websocket{DataHandler: make(chan interface{}, 10)}
+	p := pair.NewCurrencyPair("BTC", "USDT")
+	hb.wsBuffers = map[string]*orderbook.Buffer{
+		"btcusdt": orderbook.NewBuffer(hb.Name, p, "SPOT"),
+	}
+
+	var update WsMarketByPrice
+	err := common.JSONDecode([]byte(`{"ch":"market.btcusdt.mbp.150","ts":1573199608679,"tick":{"seqNum":101,"prevSeqNum":100,"bids":[[9000,0],[8990,2]],"asks":[[9010,1.5]]}}`), &update)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = hb.WsProcessOrderbook(update, "btcusdt")
+	if err != nil {
+		t.Error("Test Failed - WsProcessOrderbook() error", err)
+	}
+
+	var snapshot WsMarketByPriceSnapshot
+	err = common.JSONDecode([]byte(`{"rep":"market.btcusdt.mbp.150","status":"ok","ts":1573199608600,"data":{"seqNum":100,"bids":[[9000,1],[8995,1]],"asks":[[9010,1],[9020,1]]}}`), &snapshot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = hb.WsProcessOrderbookSnapshot(snapshot, "btcusdt")
+	if err != nil {
+		t.Error("Test Failed - WsProcessOrderbookSnapshot() error", err)
+	}
+
+	ob := hb.wsBuffers["btcusdt"].Orderbook()
+	if len(ob.Bids) != 2 || ob.Bids[0].Price != 8995 || ob.Bids[1].Amount != 2 ||
+		len(ob.Asks) != 2 || ob.Asks[0].Amount != 1.5 {
+		t.Errorf("Test Failed - WsProcessOrderbook() unexpected orderbook %+v", ob)
+	}
+
+	err = hb.WsProcessOrderbook(update, "ethusdt")
+	if err == nil {
+		t.Error("Test Failed - WsProcessOrderbook() expected error for unsubscribed symbol")
+	}
+}
+
+func TestWsProcessTrades(t *testing.T) {
+	var hb HUOBI
+	hb.Name = "HuobiWsTradeTest"
+	hb.Websocket = &exchange.Websocket{DataHandler: make(chan interface{}, 10)}
+	p := pair.NewCurrencyPair("BTC", "USDT")
+	hb.wsBuffers = map[string]*orderbook.Buffer{
+		"btcusdt": orderbook.NewBuffer(hb.Name, p, "SPOT"),
+	}
+
+	var update WsTrade
+	err := common.JSONDecode([]byte(`{"ch":"market.btcusdt.trade.detail","ts":1583853365600,"tick":{"id":14650745135,"ts":1583853365586,"data":[{"id":104307223066529641185126,"ts":1583853365586,"tradeId":102043494568,"amount":0.2,"price":9001.5,"direction":"sell"},{"id":104307223066529641185127,"ts":1583853365580,"tradeId":102043494567,"amount":1.5,"price":9002,"direction":"buy"}]}}`), &update)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = hb.WsProcessTrades(update, "btcusdt")
+	if err != nil {
+		t.Fatal("Test Failed - WsProcessTrades() error", err)
+	}
+
+	trades, err := trade.GetTrades(hb.Name, p, "SPOT")
+	if err != nil || len(trades) != 2 {
+		t.Fatal("Test Failed - WsProcessTrades() expected trades stored", trades, err)
+	}
+	if trades[0].TradeID != "102043494567" || trades[0].Side != trade.Buy ||
+		trades[1].Price != 9001.5 || trades[1].Side != trade.Sell {
+		t.Errorf("Test Failed - WsProcessTrades() unexpected trades %+v", trades)
+	}
+	data, ok := (<-hb.Websocket.DataHandler).(exchange.TradeData)
+	if !ok || data.Price != 9001.5 || data.Side != trade.Sell || !data.CurrencyPair.Equal(p, true) {
+		t.Errorf("Test Failed - WsProcessTrades() unexpected trade data %+v", data)
+	}
+}
+
+func TestWsAuthRequest(t *testing.T) {
+	var hb HUOBI
+	hb.APIKey = "key"
+	hb.APISecret = "secret"
+	req, err := hb.wsAuthRequest(time.Date(2019, 9, 1, 18, 16, 16, 0, time.UTC))
+	if err != nil {
+		t.Fatal("Test Failed - wsAuthRequest() error", err)
+	}
+
+	payload := "GET\napi.huobi.pro\n/ws/v2\naccessKey=key&signatureMethod=HmacSHA256&signatureVersion=2.1&timestamp=2019-09-01T18%3A16%3A16"
+	expected := common.Base64Encode(common.GetHMAC(common.HashSHA256, []byte(payload), []byte("secret")))
+	auth, ok := req.Params.(*WsAuthParams)
+	if !ok || req.Action != wsActionRequest || req.Channel != wsActionAuth ||
+		auth.Timestamp != "2019-09-01T18:16:16" || auth.Signature != expected {
+		t.Errorf("Test Failed - wsAuthRequest() unexpected request %+v %+v", req, auth)
+	}
+}
+
+func TestWsHandlePrivateData(t *testing.T) {
+	var hb HUOBI
+	hb.Name = "HuobiWsPrivateTest"
+	hb.Websocket = &exchange.Websocket{DataHandler: make(chan interface{}, 10)}
+
+	err := hb.wsHandlePrivateData([]byte(`{"action":"push","ch":"orders#btcusdt","data":{"eventType":"trade","symbol":"btcusdt","orderId":27163533,"clientOrderId":"","orderStatus":"partial-filled","orderPrice":"9000","orderSize":"1","type":"buy-limit","tradePrice":"9000","tradeVolume":"0.4","tradeId":301,"tradeTime":1583853365586,"aggressor":true,"remainAmt":"0.6","execAmt":"0.4"}}`))
+	if err != nil {
+		t.Fatal("Test Failed - wsHandlePrivateData() error", err)
+	}
+	order, ok := (<-hb.Websocket.DataHandler).(WsOrderUpdate)
+	if !ok || order.OrderID != 27163533 || order.TradeVolume != 0.4 || order.RemainAmount != 0.6 {
+		t.Errorf("Test Failed - wsHandlePrivateData() unexpected order update %+v", order)
+	}
+
+	err = hb.wsHandlePrivateData([]byte(`{"action":"push","ch":"accounts.update#1","data":{"currency":"usdt","accountId":123456,"balance":"1000.5","available":"640.5","changeType":"order.place","accountType":"trade","changeTime":1583853365586}}`))
+	if err != nil {
+		t.Fatal("Test Failed - wsHandlePrivateData() error", err)
+	}
+	balance, ok := (<-hb.Websocket.DataHandler).(WsAccountUpdate)
+	if !ok || balance.Currency != "usdt" || balance.Balance != 1000.5 || balance.Available != 640.5 {
+		t.Errorf("Test Failed - wsHandlePrivateData() unexpected balance update %+v", balance)
+	}
+
+	err = hb.wsHandlePrivateData([]byte(`{"action":"sub","code":2002,"ch":"orders#btcusdt","message":"invalid.auth.state"}`))
+	if err == nil {
+		t.Error("Test Failed - wsHandlePrivateData() expected subscription error")
+	}
+
+	// Pongs require a connection
+	err = hb.wsHandlePrivateData([]byte(`{"action":"ping","data":{"ts":1583853365586}}`))
+	if err == nil {
+		t.Error("Test Failed - wsHandlePrivateData() expected pong error without a connection")
+	}
+}
+
+func TestFundHistory(t *testing.T) {
+	h.SetDefaults()
+	r := DepositWithdrawal{ID: 42, Type: "withdraw", Currency: "usdt", Chain: "trc20usdt",
+		TxHash: "0xabc", Amount: 100, Address: "TXYZ", Fee: 1, State: "wallet-reject",
+		CreatedAt: 1538352000000}
+	f := h.fundHistory(&r)
+	if f.TransferID != 42 || f.TransferType != "withdrawal" || f.Currency != "USDT" ||
+		f.Chain != "trc20usdt" || f.CryptoTxID != "0xabc" || f.NetworkFee != 1 ||
+		f.Status != "REJECTED_BY_WALLET" || !f.Timestamp.Equal(time.Date(2018, 10, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Test Failed - fundHistory() unexpected withdrawal %+v", f)
+	}
+
+	r.Type = "deposit"
+	r.State = "confirming"
+	if f = h.fundHistory(&r); f.TransferType != "deposit" || f.NetworkFee != 0 ||
+		f.Status != "PENDING_CONFIRMATION" {
+		t.Errorf("Test Failed - fundHistory() unexpected deposit %+v", f)
+	}
+	r.State = "new-state"
+	if f = h.fundHistory(&r); f.Status != "NEW-STATE" {
+		t.Error("Test Failed - fundHistory() expected unknown state passed through", f.Status)
+	}
+}
@@ -1,5 +1,10 @@
 package huobi
 
+import (
+	"github.com/thrasher-/gocryptotrader/currency/symbol"
+	"github.com/thrasher-/gocryptotrader/exchanges/fees"
+)
+
 // Response stores the Huobi response information
 type Response struct {
 	Status       string `json:"status"`
@@ -250,8 +255,252 @@ var (
 	TimeIntervalFifteenMinutes = TimeInterval("15min")
 	TimeIntervalThirtyMinutes  = TimeInterval("30min")
 	TimeIntervalHour           = TimeInterval("60min")
+	TimeIntervalFourHours      = TimeInterval("4hour")
 	TimeIntervalDay            = TimeInterval("1day")
 	TimeIntervalWeek           = TimeInterval("1week")
 	TimeIntervalMohth          = TimeInterval("1mon")
 	TimeIntervalYear           = TimeInterval("1year")
 )
+
+// FeeRate holds the account trading fee rates of a symbol
+type FeeRate struct {
+	Symbol   string  `json:"symbol"`
+	MakerFee float64 `json:"maker-fee,string"`
+	TakerFee float64 `json:"taker-fee,string"`
+}
+
+// DepositWithdrawal holds a deposit or withdrawal record, Type is deposit or
+// withdraw and CreatedAt and UpdatedAt are in milliseconds
+type DepositWithdrawal struct {
+	ID         int64   `json:"id"`
+	Type       string  `json:"type"`
+	Currency   string  `json:"currency"`
+	Chain      string  `json:"chain"`
+	TxHash     string  `json:"tx-hash"`
+	Amount     float64 `json:"amount"`
+	Address    string  `json:"address"`
+	AddressTag string  `json:"address-tag"`
+	Fee        float64 `json:"fee"`
+	State      string  `json:"state"`
+	CreatedAt  int64   `json:"created-at"`
+	UpdatedAt  int64   `json:"updated-at"`
+}
+
+// Deposit and withdrawal record states
+var (
+	depositStates = map[string]string{
+		"unknown":    "PENDING",
+		"confirming": "PENDING_CONFIRMATION",
+		"confirmed":  "CONFIRMED",
+		"safe":       "SAFE",
+		"orphan":     "FAILED_ORPHANED",
+	}
+	withdrawalStates = map[string]string{
+		"submitted":       "PENDING",
+		"reexamine":       "PENDING_REVIEW",
+		"canceled":        "CANCELED",
+		"pass":            "APPROVED",
+		"reject":          "REJECTED",
+		"pre-transfer":    "PROCESSING",
+		"wallet-transfer": "SENT",
+		"wallet-reject":   "REJECTED_BY_WALLET",
+		"confirmed":       "CONFIRMED",
+		"confirm-error":   "FAILED",
+		"repealed":        "CANCELED_REPEALED",
+	}
+)
+
+// FeeSchedule is the published fee schedule, VIP tiers by 30 day USDT
+// trading volume with a discount on fees paid in HT
+// Prone to change
+var FeeSchedule = fees.Schedule{
+	VolumeCurrency: symbol.USDT,
+	Tiers: []fees.Tier{
+		{MinVolume: 0, Maker: 0.002, Taker: 0.002},
+		{MinVolume: 5000000, Maker: 0.0018, Taker: 0.0019},
+		{MinVolume: 20000000, Maker: 0.0016, Taker: 0.0018},
+		{MinVolume: 50000000, Maker: 0.0014, Taker: 0.0016},
+		{MinVolume: 100000000, Maker: 0.0012, Taker: 0.0014},
+	},
+	Discount:   fees.Discount{Currency: symbol.HT, Rate: 0.2},
+	Withdrawal: WithdrawalFees,
+}
+
+// WithdrawalFees the published cryptocurrency withdrawal fees
+// Prone to change
+var WithdrawalFees = map[string]float64{
+	symbol.BTC:   0.001,
+	symbol.ETH:   0.01,
+	symbol.LTC:   0.001,
+	symbol.BCH:   0.0001,
+	symbol.ETC:   0.01,
+	symbol.XRP:   0.1,
+	symbol.USDT:  20,
+	symbol.DASH:  0.002,
+	symbol.ZEC:   0.001,
+	symbol.EOS:   0.5,
+	symbol.HT:    1,
+	symbol.OMG:   0.1,
+	symbol.TRX:   1,
+	symbol.QTUM:  0.01,
+	symbol.NEO:   0,
+	symbol.IOTA:  0.5,
+	symbol.ADA:   1,
+	symbol.XMR:   0.01,
+	symbol.XLM:   0.01,
+	symbol.ONT:   0.02,
+	symbol.ZIL:   100,
+	symbol.HSR:   0.01,
+	symbol.BTG:   0.001,
+	symbol.XEM:   4,
+	symbol.WAVES: 0.002,
+	symbol.KNC:   1,
+	symbol.ZRX:   5,
+	symbol.LINK:  1,
+	symbol.ICX:   0.02,
+	symbol.VET:   100,
+	symbol.GNT:   5,
+	symbol.BAT:   5,
+}
+
+// FuturesResponse stores the Huobi contract API response information
+type FuturesResponse struct {
+	Status       string `json:"status"`
+	Timestamp    int64  `json:"ts"`
+	ErrorCode    int64  `json:"err_code"`
+	ErrorMessage string `json:"err_msg"`
+}
+
+// Contract statuses
+const (
+	ContractStatusListing = 1
+	ContractStatusSettled = 5
+)
+
+// ContractInfo stores the details of a coin margined futures contract or
+// USDT margined swap. DeliveryDate is empty for swaps.
+type ContractInfo struct {
+	Symbol         string  `json:"symbol"`
+	ContractCode   string  `json:"contract_code"`
+	ContractType   string  `json:"contract_type"`
+	ContractSize   float64 `json:"contract_size"`
+	PriceTick      float64 `json:"price_tick"`
+	DeliveryDate   string  `json:"delivery_date"`
+	CreateDate     string  `json:"create_date"`
+	ContractStatus int     `json:"contract_status"`
+}
+
+// ContractDetailMerged stores the ticker of a contract, the contract API
+// quotes its prices and volumes as strings
+type ContractDetailMerged struct {
+	ID     int64     `json:"id"`
+	Amount float64   `json:"amount,string"`
+	Open   float64   `json:"open,string"`
+	Close  float64   `json:"close,string"`
+	High   float64   `json:"high,string"`
+	Low    float64   `json:"low,string"`
+	Volume float64   `json:"vol,string"`
+	Count  int64     `json:"count"`
+	Ask    []float64 `json:"ask"`
+	Bid    []float64 `json:"bid"`
+}
+
+// ContractAccount stores the margin account of a contract symbol, USDT
+// margined accounts are per contract code
+type ContractAccount struct {
+	Symbol            string  `json:"symbol"`
+	ContractCode      string  `json:"contract_code"`
+	MarginAsset       string  `json:"margin_asset"`
+	MarginBalance     float64 `json:"margin_balance"`
+	MarginPosition    float64 `json:"margin_position"`
+	MarginFrozen      float64 `json:"margin_frozen"`
+	MarginAvailable   float64 `json:"margin_available"`
+	ProfitReal        float64 `json:"profit_real"`
+	ProfitUnreal      float64 `json:"profit_unreal"`
+	RiskRate          float64 `json:"risk_rate"`
+	WithdrawAvailable float64 `json:"withdraw_available"`
+	LeverRate         float64 `json:"lever_rate"`
+}
+
+// ContractPosition stores an open contract position, Direction is buy for
+// long positions and sell for short positions
+type ContractPosition struct {
+	Symbol         string  `json:"symbol"`
+	ContractCode   string  `json:"contract_code"`
+	ContractType   string  `json:"contract_type"`
+	Volume         float64 `json:"volume"`
+	Available      float64 `json:"available"`
+	Frozen         float64 `json:"frozen"`
+	CostOpen       float64 `json:"cost_open"`
+	CostHold       float64 `json:"cost_hold"`
+	ProfitUnreal   float64 `json:"profit_unreal"`
+	ProfitRate     float64 `json:"profit_rate"`
+	Profit         float64 `json:"profit"`
+	PositionMargin float64 `json:"position_margin"`
+	LeverRate      float64 `json:"lever_rate"`
+	Direction      string  `json:"direction"`
+}
+
+// Contract order directions, offsets and price types
+const (
+	ContractDirectionBuy   = "buy"
+	ContractDirectionSell  = "sell"
+	ContractOffsetOpen     = "open"
+	ContractOffsetClose    = "close"
+	ContractPriceLimit     = "limit"
+	ContractPriceOptimal20 = "optimal_20"
+)
+
+// ContractOrderRequest stores a new contract order, Volume is a number of
+// contracts and ClientOrderID must be numeric
+type ContractOrderRequest struct {
+	ContractCode   string  `json:"contract_code"`
+	ClientOrderID  int64   `json:"client_order_id,omitempty"`
+	Price          float64 `json:"price,omitempty"`
+	Volume         int64   `json:"volume"`
+	Direction      string  `json:"direction"`
+	Offset         string  `json:"offset"`
+	LeverRate      int     `json:"lever_rate"`
+	OrderPriceType string  `json:"order_price_type"`
+}
+
+// Contract order statuses
+const (
+	ContractOrderSubmitted          = 3
+	ContractOrderPartiallyFilled    = 4
+	ContractOrderPartiallyCancelled = 5
+	ContractOrderFilled             = 6
+	ContractOrderCancelled          = 7
+)
+
+// ContractOrderInfo stores the details of a contract order, CreatedAt is in
+// milliseconds
+type ContractOrderInfo struct {
+	Symbol         string  `json:"symbol"`
+	ContractCode   string  `json:"contract_code"`
+	Volume         float64 `json:"volume"`
+	Price          float64 `json:"price"`
+	OrderPriceType string  `json:"order_price_type"`
+	Direction      string  `json:"direction"`
+	Offset         string  `json:"offset"`
+	LeverRate      int     `json:"lever_rate"`
+	OrderID        int64   `json:"order_id"`
+	ClientOrderID  int64   `json:"client_order_id"`
+	CreatedAt      int64   `json:"created_at"`
+	TradeVolume    float64 `json:"trade_volume"`
+	TradeTurnover  float64 `json:"trade_turnover"`
+	TradeAvgPrice  float64 `json:"trade_avg_price"`
+	Fee            float64 `json:"fee"`
+	Status         int     `json:"status"`
+}
+
+// ContractCancelResponse stores the result of a contract order cancellation,
+// Successes is a comma separated list of cancelled order IDs
+type ContractCancelResponse struct {
+	Errors []struct {
+		OrderID      string `json:"order_id"`
+		ErrorCode    int64  `json:"err_code"`
+		ErrorMessage string `json:"err_msg"`
+	} `json:"errors"`
+	Successes string `json:"successes"`
+}
@@ -3,6 +3,7 @@ package huobi
 import (
 	"bytes"
 	"compress/gzip"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
@@ -10,6 +11,7 @@ import (
 	"math/big"
 	"net/http"
 	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -17,13 +19,28 @@ import (
 	"github.com/thrasher-/gocryptotrader/currency/pair"
 	"github.com/thrasher-/gocryptotrader/exchanges"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/trade"
 )
 
 const (
 	huobiSocketIOAddress = "wss://api.huobi.pro/ws"
 	wsMarketKline        = "market.%s.kline.1min"
-	wsMarketDepth        = "market.%s.depth.step0"
+	wsMarketByPrice      = "market.%s.mbp.150"
+	wsMarketByPriceDepth = 150
 	wsMarketTrade        = "market.%s.trade.detail"
+
+	// Authenticated v2 websocket, whose messages are not compressed
+	huobiWsPrivateAddress = "wss://api.huobi.pro/ws/v2"
+	wsPrivateOrders       = "orders#%s"
+	wsPrivateAccounts     = "accounts.update#1"
+	wsActionAuth          = "auth"
+	wsActionRequest       = "req"
+	wsActionSubscribe     = "sub"
+	wsActionPush          = "push"
+	wsActionPing          = "ping"
+	wsActionPong          = "pong"
+	wsSuccessCode         = 200
+	wsAuthTimeout         = time.Second * 10
 )
 
 // WsConnect initiates a new websocket connection
@@ -33,6 +50,8 @@ func (h *HUOBI) WsConnect() error {
 	}
 
 	var dialer websocket.Dialer
+	dialer.TLSClientConfig = h.GetTLSConfig()
+	dialer.NetDial = h.GetWebsocketNetDial()
 
 	if h.Websocket.GetProxyAddress() != "" {
 		proxy, err := url.Parse(h.Websocket.GetProxyAddress())
@@ -43,6 +62,18 @@ func (h *HUOBI) WsConnect() error {
 		dialer.Proxy = http.ProxyURL(proxy)
 	}
 
+	h.wsBuffers = make(map[string]*orderbook.Buffer)
+	for _, p := range h.GetEnabledCurrencies() {
+		fPair := exchange.FormatExchangeCurrency(h.GetName(), p)
+		buffer := orderbook.NewBuffer(h.GetName(), p, "SPOT")
+		buffer.MaxDepth = wsMarketByPriceDepth
+		symbol := fPair.String()
+		buffer.Resubscribe = func() error {
+			return h.wsRequestOrderbook(symbol)
+		}
+		h.wsBuffers[symbol] = buffer
+	}
+
 	var err error
 	h.WebsocketConn, _, err = dialer.Dial(h.Websocket.GetWebsocketURL(), http.Header{})
 	if err != nil {
@@ -57,7 +88,27 @@ func (h *HUOBI) WsConnect() error {
 		return err
 	}
 
-	return nil
+	if !h.AuthenticatedAPISupport {
+		return nil
+	}
+
+	// Private channels are subscribed on every connection, so they are
+	// resubscribed when a dropped connection is reconnected
+	h.WebsocketPrivateConn, _, err = dialer.Dial(huobiWsPrivateAddress, http.Header{})
+	if err != nil {
+		return err
+	}
+
+	err = h.wsAuthenticate()
+	if err != nil {
+		h.WebsocketPrivateConn.Close()
+		h.WebsocketPrivateConn = nil
+		return err
+	}
+
+	go h.wsReadPrivateData(h.WebsocketPrivateConn)
+
+	return h.WsSubscribePrivate()
 }
 
 // WsReadData reads data from the websocket connection
@@ -129,8 +180,26 @@ func (h *HUOBI) WsHandleData() {
 				continue
 			}
 
+			if common.StringContains(init.Reply, "mbp") {
+				var snapshot WsMarketByPriceSnapshot
+				err := common.JSONDecode(resp.Raw, &snapshot)
+				if err != nil {
+					log.Fatal(err)
+				}
+
+				data := common.SplitStrings(snapshot.Reply, ".")
+
+				// Resyncs are recoverable so are logged rather than sent to
+				// the data handler
+				err = h.WsProcessOrderbookSnapshot(snapshot, data[1])
+				if err != nil {
+					log.Printf("%s websocket orderbook error: %s", h.Name, err)
+				}
+				continue
+			}
+
 			if init.Ping != 0 {
-				err = h.WebsocketConn.WriteJSON(`{"pong":1337}`)
+				err = h.WebsocketConn.WriteJSON(WsPong{Pong: init.Ping})
 				if err != nil {
 					log.Fatal(err)
 				}
@@ -138,16 +207,19 @@ func (h *HUOBI) WsHandleData() {
 			}
 
 			switch {
-			case common.StringContains(init.Channel, "depth"):
-				var depth WsDepth
-				err := common.JSONDecode(resp.Raw, &depth)
+			case common.StringContains(init.Channel, "mbp"):
+				var update WsMarketByPrice
+				err := common.JSONDecode(resp.Raw, &update)
 				if err != nil {
 					log.Fatal(err)
 				}
 
-				data := common.SplitStrings(depth.Channel, ".")
+				data := common.SplitStrings(update.Channel, ".")
 
-				h.WsProcessOrderbook(depth, data[1])
+				err = h.WsProcessOrderbook(update, data[1])
+				if err != nil {
+					log.Printf("%s websocket orderbook error: %s", h.Name, err)
+				}
 
 			case common.StringContains(init.Channel, "kline"):
 				var kline WsKline
@@ -159,7 +231,7 @@ func (h *HUOBI) WsHandleData() {
 				data := common.SplitStrings(kline.Channel, ".")
 
 				h.Websocket.DataHandler <- exchange.KlineData{
-					Timestamp:  time.Unix(0, kline.Timestamp),
+					Timestamp:  common.UnixTimestampToUTC(kline.Timestamp),
 					Exchange:   h.GetName(),
 					AssetType:  "SPOT",
 					Pair:       pair.NewCurrencyPairFromString(data[1]),
@@ -171,64 +243,144 @@ func (h *HUOBI) WsHandleData() {
 				}
 
 			case common.StringContains(init.Channel, "trade"):
-				var trade WsTrade
-				err := common.JSONDecode(resp.Raw, &trade)
+				var update WsTrade
+				err := common.JSONDecode(resp.Raw, &update)
 				if err != nil {
 					log.Fatal(err)
 				}
 
-				data := common.SplitStrings(trade.Channel, ".")
+				data := common.SplitStrings(update.Channel, ".")
 
-				h.Websocket.DataHandler <- exchange.TradeData{
-					Exchange:     h.GetName(),
-					AssetType:    "SPOT",
-					CurrencyPair: pair.NewCurrencyPairFromString(data[1]),
-					Timestamp:    time.Unix(0, trade.Tick.Timestamp),
+				err = h.WsProcessTrades(update, data[1])
+				if err != nil {
+					h.Websocket.DataHandler <- err
 				}
 			}
 		}
 	}
 }
 
-// WsProcessOrderbook processes new orderbook data
-func (h *HUOBI) WsProcessOrderbook(ob WsDepth, symbol string) error {
-	var bids []orderbook.Item
-	for _, data := range ob.Tick.Bids {
-		bidLevel := data.([]interface{})
-		bids = append(bids, orderbook.Item{Price: bidLevel[0].(float64),
-			Amount: bidLevel[0].(float64)})
+// WsProcessTrades normalises a trade detail message into the trade store and
+// sends each valid trade to the data handler
+func (h *HUOBI) WsProcessTrades(update WsTrade, symbol string) error {
+	p := pair.NewCurrencyPairFromString(symbol)
+	if buffer, ok := h.wsBuffers[symbol]; ok {
+		p = buffer.Pair
 	}
 
-	var asks []orderbook.Item
-	for _, data := range ob.Tick.Asks {
-		askLevel := data.([]interface{})
-		asks = append(asks, orderbook.Item{Price: askLevel[0].(float64),
-			Amount: askLevel[0].(float64)})
+	trades := make([]trade.Trade, len(update.Tick.Data))
+	for i := range update.Tick.Data {
+		// tradeId replaces the deprecated id, which is kept as a fallback
+		tradeID := update.Tick.Data[i].ID.String()
+		if update.Tick.Data[i].TradeID != 0 {
+			tradeID = strconv.FormatInt(update.Tick.Data[i].TradeID, 10)
+		}
+		trades[i] = trade.Trade{
+			TradeID:   tradeID,
+			Price:     update.Tick.Data[i].Price,
+			Amount:    update.Tick.Data[i].Amount,
+			Side:      update.Tick.Data[i].Direction,
+			Timestamp: common.UnixTimestampToUTC(update.Tick.Data[i].Timestamp),
+		}
 	}
 
-	p := pair.NewCurrencyPairFromString(symbol)
+	err := trade.ProcessTrades(h.GetName(), p, "SPOT", trades)
+	for i := range trades {
+		if trades[i].Price <= 0 || trades[i].Amount <= 0 {
+			continue
+		}
+		h.Websocket.DataHandler <- exchange.TradeData{
+			Exchange:     h.GetName(),
+			AssetType:    "SPOT",
+			CurrencyPair: p,
+			Timestamp:    trades[i].Timestamp,
+			Price:        trades[i].Price,
+			Amount:       trades[i].Amount,
+			Side:         trade.Side(trades[i].Side),
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("huobi_websocket.go - %s trades: %s", symbol, err)
+	}
+	return nil
+}
 
-	var newOrderbook orderbook.Base
-	newOrderbook.Asks = asks
-	newOrderbook.Bids = bids
-	newOrderbook.CurrencyPair = symbol
-	newOrderbook.LastUpdated = time.Now()
-	newOrderbook.Pair = p
+// WsProcessOrderbook applies an incremental market by price update to the
+// local orderbook, the buffer requests a new snapshot when a sequence gap is
+// detected
+func (h *HUOBI) WsProcessOrderbook(update WsMarketByPrice, symbol string) error {
+	buffer, ok := h.wsBuffers[symbol]
+	if !ok {
+		return fmt.Errorf("huobi_websocket.go - orderbook for %s not subscribed", symbol)
+	}
 
-	err := h.Websocket.Orderbook.LoadSnapshot(newOrderbook, h.GetName())
+	err := buffer.Apply(orderbook.Update{
+		Bids:         wsOrderbookItems(update.Tick.Bids),
+		Asks:         wsOrderbookItems(update.Tick.Asks),
+		UpdateID:     update.Tick.SeqNum,
+		PrevUpdateID: update.Tick.PrevSeqNum,
+		UpdateTime:   common.UnixTimestampToUTC(update.Timestamp),
+	})
 	if err != nil {
-		return err
+		return fmt.Errorf("huobi_websocket.go - %s orderbook resyncing: %s", symbol, err)
+	}
+
+	if buffer.Synced() {
+		h.Websocket.DataHandler <- exchange.WebsocketOrderbookUpdate{
+			Pair:     buffer.Pair,
+			Exchange: h.GetName(),
+			Asset:    buffer.AssetType,
+		}
+	}
+	return nil
+}
+
+// WsProcessOrderbookSnapshot loads a requested market by price snapshot and
+// replays the buffered updates which follow it
+func (h *HUOBI) WsProcessOrderbookSnapshot(snapshot WsMarketByPriceSnapshot, symbol string) error {
+	buffer, ok := h.wsBuffers[symbol]
+	if !ok {
+		return fmt.Errorf("huobi_websocket.go - orderbook for %s not subscribed", symbol)
+	}
+
+	err := buffer.LoadSnapshot(orderbook.Base{
+		Bids:        wsOrderbookItems(snapshot.Data.Bids),
+		Asks:        wsOrderbookItems(snapshot.Data.Asks),
+		LastUpdated: common.UnixTimestampToUTC(snapshot.Timestamp),
+	}, snapshot.Data.SeqNum)
+	if err != nil {
+		return fmt.Errorf("huobi_websocket.go - %s orderbook snapshot rejected: %s", symbol, err)
 	}
 
 	h.Websocket.DataHandler <- exchange.WebsocketOrderbookUpdate{
-		Pair:     p,
+		Pair:     buffer.Pair,
 		Exchange: h.GetName(),
-		Asset:    "SPOT",
+		Asset:    buffer.AssetType,
 	}
-
 	return nil
 }
 
+// wsRequestOrderbook requests a market by price snapshot for a symbol
+func (h *HUOBI) wsRequestOrderbook(symbol string) error {
+	reqJSON, err := common.JSONEncode(WsRequest{Topic: fmt.Sprintf(wsMarketByPrice, symbol)})
+	if err != nil {
+		return err
+	}
+	return h.WebsocketConn.WriteMessage(websocket.TextMessage, reqJSON)
+}
+
+// wsOrderbookItems converts price and amount levels to orderbook items
+func wsOrderbookItems(levels [][]float64) []orderbook.Item {
+	items := make([]orderbook.Item, 0, len(levels))
+	for i := range levels {
+		if len(levels[i]) < 2 {
+			continue
+		}
+		items = append(items, orderbook.Item{Price: levels[i][0], Amount: levels[i][1]})
+	}
+	return items
+}
+
 // WsSubscribe susbcribes to the current websocket streams based on the enabled
 // pair
 func (h *HUOBI) WsSubscribe() error {
@@ -237,7 +389,7 @@ func (h *HUOBI) WsSubscribe() error {
 	for _, p := range pairs {
 		fPair := exchange.FormatExchangeCurrency(h.GetName(), p)
 
-		depthTopic := fmt.Sprintf(wsMarketDepth, fPair.String())
+		depthTopic := fmt.Sprintf(wsMarketByPrice, fPair.String())
 		depthJSON, err := common.JSONEncode(WsRequest{Subscribe: depthTopic})
 		if err != nil {
 			return err
@@ -248,6 +400,12 @@ func (h *HUOBI) WsSubscribe() error {
 			return err
 		}
 
+		// Updates are buffered until the snapshot arrives
+		err = h.wsRequestOrderbook(fPair.String())
+		if err != nil {
+			return err
+		}
+
 		klineTopic := fmt.Sprintf(wsMarketKline, fPair.String())
 		KlineJSON, err := common.JSONEncode(WsRequest{Subscribe: klineTopic})
 		if err != nil {
@@ -273,6 +431,205 @@ func (h *HUOBI) WsSubscribe() error {
 	return nil
 }
 
+// wsAuthRequest returns the v2 websocket authentication request signed at a
+// time
+func (h *HUOBI) wsAuthRequest(t time.Time) (WsV2Request, error) {
+	address, err := url.Parse(huobiWsPrivateAddress)
+	if err != nil {
+		return WsV2Request{}, err
+	}
+
+	auth := WsAuthParams{
+		AuthType:         "api",
+		AccessKey:        h.APIKey,
+		SignatureMethod:  "HmacSHA256",
+		SignatureVersion: "2.1",
+		Timestamp:        t.UTC().Format("2006-01-02T15:04:05"),
+	}
+	values := url.Values{}
+	values.Set("accessKey", auth.AccessKey)
+	values.Set("signatureMethod", auth.SignatureMethod)
+	values.Set("signatureVersion", auth.SignatureVersion)
+	values.Set("timestamp", auth.Timestamp)
+
+	payload := fmt.Sprintf("GET\n%s\n%s\n%s", address.Host, address.Path, values.Encode())
+	hmac := common.GetHMAC(common.HashSHA256, []byte(payload), []byte(h.APISecret))
+	auth.Signature = common.Base64Encode(hmac)
+
+	return WsV2Request{Action: wsActionRequest, Channel: wsActionAuth, Params: &auth}, nil
+}
+
+// wsAuthenticate authenticates the private connection and waits for the
+// result
+func (h *HUOBI) wsAuthenticate() error {
+	req, err := h.wsAuthRequest(time.Now())
+	if err != nil {
+		return err
+	}
+	err = h.wsPrivateWrite(req)
+	if err != nil {
+		return err
+	}
+
+	err = h.WebsocketPrivateConn.SetReadDeadline(time.Now().Add(wsAuthTimeout))
+	if err != nil {
+		return err
+	}
+	defer h.WebsocketPrivateConn.SetReadDeadline(time.Time{})
+
+	for {
+		_, raw, err := h.WebsocketPrivateConn.ReadMessage()
+		if err != nil {
+			return fmt.Errorf("%s websocket authentication error: %s", h.Name, err)
+		}
+
+		var resp WsV2Response
+		if common.JSONDecode(raw, &resp) != nil {
+			continue
+		}
+
+		switch {
+		case resp.Action == wsActionPing:
+			err = h.wsPrivatePong(resp.Data)
+			if err != nil {
+				return err
+			}
+		case resp.Action == wsActionRequest && resp.Channel == wsActionAuth:
+			if resp.Code != wsSuccessCode {
+				return fmt.Errorf("%s websocket authentication failed code %d: %s",
+					h.Name,
+					resp.Code,
+					resp.Message)
+			}
+			return nil
+		}
+	}
+}
+
+// WsSubscribePrivate subscribes to the order updates of the enabled pairs and
+// to account balance changes
+func (h *HUOBI) WsSubscribePrivate() error {
+	for _, channel := range h.wsPrivateChannels() {
+		err := h.wsPrivateWrite(WsV2Request{Action: wsActionSubscribe, Channel: channel})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// wsPrivateChannels returns the private channels subscribed to
+func (h *HUOBI) wsPrivateChannels() []string {
+	var channels []string
+	for _, p := range h.GetEnabledCurrencies() {
+		fPair := exchange.FormatExchangeCurrency(h.GetName(), p)
+		channels = append(channels, fmt.Sprintf(wsPrivateOrders, fPair.String()))
+	}
+	return append(channels, wsPrivateAccounts)
+}
+
+// wsPrivateWrite sends a JSON message over the private connection
+func (h *HUOBI) wsPrivateWrite(data interface{}) error {
+	h.wsPrivateWriteLock.Lock()
+	defer h.wsPrivateWriteLock.Unlock()
+
+	if h.WebsocketPrivateConn == nil {
+		return errors.New("huobi_websocket.go - private websocket connection not established")
+	}
+	return h.WebsocketPrivateConn.WriteJSON(data)
+}
+
+// wsPrivatePong answers a ping of the private connection
+func (h *HUOBI) wsPrivatePong(data []byte) error {
+	var ping WsV2Ping
+	err := common.JSONDecode(data, &ping)
+	if err != nil {
+		return err
+	}
+	return h.wsPrivateWrite(WsV2Request{Action: wsActionPong, Data: &ping})
+}
+
+// wsReadPrivateData reads and handles data from the private connection. Read
+// errors are sent to the data handler, which reconnects dropped connections.
+func (h *HUOBI) wsReadPrivateData(conn *websocket.Conn) {
+	h.Websocket.Wg.Add(1)
+
+	defer func() {
+		err := conn.Close()
+		if err != nil {
+			h.Websocket.DataHandler <- fmt.Errorf("huobi_websocket.go - Unable to to close private Websocket connection. Error: %s",
+				err)
+		}
+		h.Websocket.Wg.Done()
+	}()
+
+	for {
+		select {
+		case <-h.Websocket.ShutdownC:
+			return
+
+		default:
+			_, resp, err := conn.ReadMessage()
+			if err != nil {
+				h.Websocket.DataHandler <- err
+				return
+			}
+
+			h.Websocket.TrafficAlert <- struct{}{}
+
+			err = h.wsHandlePrivateData(resp)
+			if err != nil {
+				h.Websocket.DataHandler <- fmt.Sprintf("%s private websocket handling error: %s",
+					h.Name,
+					err)
+			}
+		}
+	}
+}
+
+// wsHandlePrivateData answers pings and sends order updates and balance
+// changes to the data handler
+func (h *HUOBI) wsHandlePrivateData(raw []byte) error {
+	var resp WsV2Response
+	err := common.JSONDecode(raw, &resp)
+	if err != nil {
+		return err
+	}
+
+	switch resp.Action {
+	case wsActionPing:
+		return h.wsPrivatePong(resp.Data)
+
+	case wsActionSubscribe:
+		if resp.Code != wsSuccessCode {
+			return fmt.Errorf("subscription to %s failed code %d: %s",
+				resp.Channel,
+				resp.Code,
+				resp.Message)
+		}
+
+	case wsActionPush:
+		switch {
+		case common.StringContains(resp.Channel, "orders#"):
+			var order WsOrderUpdate
+			err = common.JSONDecode(resp.Data, &order)
+			if err != nil {
+				return err
+			}
+			h.Websocket.DataHandler <- order
+
+		case common.StringContains(resp.Channel, "accounts.update#"):
+			var balance WsAccountUpdate
+			err = common.JSONDecode(resp.Data, &balance)
+			if err != nil {
+				return err
+			}
+			h.Websocket.DataHandler <- balance
+		}
+	}
+	return nil
+}
+
 // WsRequest defines a request data structure
 type WsRequest struct {
 	Topic             string `json:"req,omitempty"`
@@ -290,6 +647,7 @@ type WsResponse struct {
 	Ping         int64  `json:"ping"`
 	Channel      string `json:"ch"`
 	Subscribed   string `json:"subbed"`
+	Reply        string `json:"rep"`
 }
 
 // WsHeartBeat defines a heartbeat request
@@ -297,18 +655,103 @@ type WsHeartBeat struct {
 	ClientNonce int64 `json:"ping"`
 }
 
-// WsDepth defines market depth websocket response
-type WsDepth struct {
+// WsPong defines a heartbeat response
+type WsPong struct {
+	Pong int64 `json:"pong"`
+}
+
+// WsV2Request defines a request to the authenticated v2 websocket
+type WsV2Request struct {
+	Action  string      `json:"action"`
+	Channel string      `json:"ch,omitempty"`
+	Params  interface{} `json:"params,omitempty"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+// WsV2Response defines a response or push from the authenticated v2
+// websocket
+type WsV2Response struct {
+	Action  string          `json:"action"`
+	Code    int64           `json:"code"`
+	Channel string          `json:"ch"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// WsAuthParams defines the v2 websocket authentication parameters
+type WsAuthParams struct {
+	AuthType         string `json:"authType"`
+	AccessKey        string `json:"accessKey"`
+	SignatureMethod  string `json:"signatureMethod"`
+	SignatureVersion string `json:"signatureVersion"`
+	Timestamp        string `json:"timestamp"`
+	Signature        string `json:"signature"`
+}
+
+// WsV2Ping defines a v2 websocket heartbeat
+type WsV2Ping struct {
+	Timestamp int64 `json:"ts"`
+}
+
+// WsOrderUpdate defines an order update pushed by the v2 websocket. Trade
+// fields are only set for trade events.
+type WsOrderUpdate struct {
+	EventType       string  `json:"eventType"`
+	Symbol          string  `json:"symbol"`
+	AccountID       int64   `json:"accountId"`
+	OrderID         int64   `json:"orderId"`
+	ClientOrderID   string  `json:"clientOrderId"`
+	Type            string  `json:"type"`
+	OrderStatus     string  `json:"orderStatus"`
+	OrderPrice      float64 `json:"orderPrice,string"`
+	OrderSize       float64 `json:"orderSize,string"`
+	OrderCreateTime int64   `json:"orderCreateTime"`
+	TradePrice      float64 `json:"tradePrice,string"`
+	TradeVolume     float64 `json:"tradeVolume,string"`
+	TradeID         int64   `json:"tradeId"`
+	TradeTime       int64   `json:"tradeTime"`
+	Aggressor       bool    `json:"aggressor"`
+	RemainAmount    float64 `json:"remainAmt,string"`
+	ExecutedAmount  float64 `json:"execAmt,string"`
+	LastActTime     int64   `json:"lastActTime"`
+}
+
+// WsAccountUpdate defines an account balance change pushed by the v2
+// websocket
+type WsAccountUpdate struct {
+	Currency    string  `json:"currency"`
+	AccountID   int64   `json:"accountId"`
+	Balance     float64 `json:"balance,string"`
+	Available   float64 `json:"available,string"`
+	ChangeType  string  `json:"changeType"`
+	AccountType string  `json:"accountType"`
+	ChangeTime  int64   `json:"changeTime"`
+}
+
+// WsMarketByPrice defines an incremental market by price websocket update
+type WsMarketByPrice struct {
 	Channel   string `json:"ch"`
 	Timestamp int64  `json:"ts"`
 	Tick      struct {
-		Bids      []interface{} `json:"bids"`
-		Asks      []interface{} `json:"asks"`
-		Timestamp int64         `json:"ts"`
-		Version   int64         `json:"version"`
+		SeqNum     int64       `json:"seqNum"`
+		PrevSeqNum int64       `json:"prevSeqNum"`
+		Bids       [][]float64 `json:"bids"`
+		Asks       [][]float64 `json:"asks"`
 	} `json:"tick"`
 }
 
+// WsMarketByPriceSnapshot defines a requested market by price snapshot
+type WsMarketByPriceSnapshot struct {
+	Reply     string `json:"rep"`
+	Status    string `json:"status"`
+	Timestamp int64  `json:"ts"`
+	Data      struct {
+		SeqNum int64       `json:"seqNum"`
+		Bids   [][]float64 `json:"bids"`
+		Asks   [][]float64 `json:"asks"`
+	} `json:"data"`
+}
+
 // WsKline defines market kline websocket response
 type WsKline struct {
 	Channel   string `json:"ch"`
@@ -336,6 +779,7 @@ type WsTrade struct {
 			Amount    float64 `json:"amount"`
 			Timestamp int64   `json:"ts"`
 			ID        big.Int `json:"id,number"`
+			TradeID   int64   `json:"tradeId"`
 			Price     float64 `json:"price"`
 			Direction string  `json:"direction"`
 		} `json:"data"`
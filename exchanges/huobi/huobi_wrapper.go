@@ -1,31 +1,31 @@
 package huobi
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/config"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
 	"github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/assets"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
 
-// Start starts the HUOBI go routine
-func (h *HUOBI) Start(wg *sync.WaitGroup) {
-	wg.Add(1)
-	go func() {
-		h.Run()
-		wg.Done()
-	}()
+// Start starts the HUOBI wrapper, refreshing its currency pairs
+func (h *HUOBI) Start(ctx context.Context) error {
+	return h.StartWrapper(ctx, h.Run)
 }
 
 // Run implements the HUOBI wrapper
-func (h *HUOBI) Run() {
+func (h *HUOBI) Run(ctx context.Context) error {
 	if h.Verbose {
 		log.Printf("%s Websocket: %s (url: %s).\n", h.GetName(), common.IsEnabled(h.Websocket.IsEnabled()), huobiSocketIOAddress)
 		log.Printf("%s polling delay: %ds.\n", h.GetName(), h.RESTPollingDelay)
@@ -46,7 +46,7 @@ func (h *HUOBI) Run() {
 			exchCfg, errCNY := cfg.GetExchangeConfig(h.Name)
 			if err != nil {
 				log.Printf("%s failed to get exchange config. %s\n", h.Name, errCNY)
-				return
+				return nil
 			}
 			exchCfg.BaseCurrencies = "USD"
 			h.BaseCurrencies = []string{"USD"}
@@ -54,7 +54,7 @@ func (h *HUOBI) Run() {
 			errCNY = cfg.UpdateExchangeConfig(exchCfg)
 			if errCNY != nil {
 				log.Printf("%s failed to update config. %s\n", h.Name, errCNY)
-				return
+				return nil
 			}
 		}
 
@@ -78,10 +78,74 @@ func (h *HUOBI) Run() {
 			log.Printf("%s Failed to update available currencies.\n", h.GetName())
 		}
 	}
+
+	h.updateContractPairs()
+	return nil
+}
+
+// updateContractPairs updates the available futures pairs from the listed
+// coin margined futures and the available swap pairs from the USDT margined
+// swaps
+func (h *HUOBI) updateContractPairs() {
+	contracts, err := h.GetContractInfo("")
+	if err != nil {
+		log.Printf("%s Failed to get futures contracts. Err: %s\n", h.GetName(), err)
+	} else {
+		var futures []string
+		for x := range contracts {
+			underlying := contracts[x].Symbol + "-USD"
+			if contracts[x].ContractStatus == ContractStatusListing &&
+				!common.StringDataCompare(futures, underlying) {
+				futures = append(futures, underlying)
+			}
+		}
+		err = h.UpdateAssetCurrencies(assets.Futures, futures, false, false)
+		if err != nil {
+			log.Printf("%s Failed to update available futures currencies. Err: %s\n", h.GetName(), err)
+		}
+	}
+
+	swaps, err := h.GetLinearSwapContractInfo("")
+	if err != nil {
+		log.Printf("%s Failed to get swap contracts. Err: %s\n", h.GetName(), err)
+		return
+	}
+	var codes []string
+	for x := range swaps {
+		if swaps[x].ContractStatus == ContractStatusListing {
+			codes = append(codes, swaps[x].ContractCode)
+		}
+	}
+	err = h.UpdateAssetCurrencies(assets.PerpetualSwap, codes, false, false)
+	if err != nil {
+		log.Printf("%s Failed to update available swap currencies. Err: %s\n", h.GetName(), err)
+	}
+}
+
+// FormatContractSymbol returns the contract API symbol of a futures or swap
+// pair. Futures pairs resolve to their underlying coin's current quarter
+// contract, such as BTC_CQ, and swap pairs to their contract code.
+func (h *HUOBI) FormatContractSymbol(p pair.CurrencyPair, assetType string) (string, error) {
+	switch assetType {
+	case assets.Futures:
+		return p.FirstCurrency.Upper().String() + "_CQ", nil
+	case assets.PerpetualSwap:
+		return p.FirstCurrency.Upper().String() + "-" + p.SecondCurrency.Upper().String(), nil
+	}
+	return "", fmt.Errorf("%s asset type %s is not a contract", h.Name, assetType)
+}
+
+// isContractAsset returns whether an asset type trades on the contract API
+func isContractAsset(assetType string) bool {
+	return assetType == assets.Futures || assetType == assets.PerpetualSwap
 }
 
 // UpdateTicker updates and returns the ticker for a currency pair
 func (h *HUOBI) UpdateTicker(p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	if isContractAsset(assetType) {
+		return h.updateContractTicker(p, assetType)
+	}
+
 	var tickerPrice ticker.Price
 	tick, err := h.GetMarketDetailMerged(exchange.FormatExchangeCurrency(h.Name, p).String())
 	if err != nil {
@@ -106,6 +170,41 @@ func (h *HUOBI) UpdateTicker(p pair.CurrencyPair, assetType string) (ticker.Pric
 	return ticker.GetTicker(h.Name, p, assetType)
 }
 
+// updateContractTicker updates and returns the ticker for a futures or swap
+// pair
+func (h *HUOBI) updateContractTicker(p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	var tickerPrice ticker.Price
+	symbol, err := h.FormatContractSymbol(p, assetType)
+	if err != nil {
+		return tickerPrice, err
+	}
+
+	var tick ContractDetailMerged
+	if assetType == assets.Futures {
+		tick, err = h.GetContractMarketDetailMerged(symbol)
+	} else {
+		tick, err = h.GetLinearSwapMarketDetailMerged(symbol)
+	}
+	if err != nil {
+		return tickerPrice, err
+	}
+
+	tickerPrice.Pair = p
+	tickerPrice.Low = tick.Low
+	tickerPrice.Last = tick.Close
+	tickerPrice.Volume = tick.Amount
+	tickerPrice.High = tick.High
+	if len(tick.Ask) > 0 {
+		tickerPrice.Ask = tick.Ask[0]
+	}
+	if len(tick.Bid) > 0 {
+		tickerPrice.Bid = tick.Bid[0]
+	}
+
+	ticker.ProcessTicker(h.GetName(), p, tickerPrice, assetType)
+	return ticker.GetTicker(h.Name, p, assetType)
+}
+
 // GetTickerPrice returns the ticker for a currency pair
 func (h *HUOBI) GetTickerPrice(p pair.CurrencyPair, assetType string) (ticker.Price, error) {
 	tickerNew, err := ticker.GetTicker(h.GetName(), p, assetType)
@@ -127,10 +226,25 @@ func (h *HUOBI) GetOrderbookEx(p pair.CurrencyPair, assetType string) (orderbook
 // UpdateOrderbook updates and returns the orderbook for a currency pair
 func (h *HUOBI) UpdateOrderbook(p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
 	var orderBook orderbook.Base
-	orderbookNew, err := h.GetDepth(OrderBookDataRequestParams{
-		Symbol: exchange.FormatExchangeCurrency(h.Name, p).String(),
-		Type:   OrderBookDataRequestParamsTypeStep1,
-	})
+	var orderbookNew Orderbook
+	var err error
+	if isContractAsset(assetType) {
+		var symbol string
+		symbol, err = h.FormatContractSymbol(p, assetType)
+		if err != nil {
+			return orderBook, err
+		}
+		if assetType == assets.Futures {
+			orderbookNew, err = h.GetContractDepth(symbol)
+		} else {
+			orderbookNew, err = h.GetLinearSwapDepth(symbol)
+		}
+	} else {
+		orderbookNew, err = h.GetDepth(OrderBookDataRequestParams{
+			Symbol: exchange.FormatExchangeCurrency(h.Name, p).String(),
+			Type:   OrderBookDataRequestParamsTypeStep1,
+		})
+	}
 	if err != nil {
 		return orderBook, err
 	}
@@ -173,8 +287,9 @@ func (h *HUOBI) GetAccountID() (string, error) {
 	return h.AccountID, nil
 }
 
-//GetAccountInfo retrieves balances for all enabled currencies for the
-// HUOBI exchange - to-do
+// GetAccountInfo retrieves balances for all enabled currencies for the
+// HUOBI exchange, including the margin balances of the contract accounts of
+// enabled futures and swap asset types
 func (h *HUOBI) GetAccountInfo() (exchange.AccountInfo, error) {
 	var info exchange.AccountInfo
 	info.ExchangeName = h.GetName()
@@ -219,14 +334,241 @@ func (h *HUOBI) GetAccountInfo() (exchange.AccountInfo, error) {
 	}
 
 	info.Currencies = balances
+
+	for _, assetType := range h.GetAssetTypes() {
+		if !isContractAsset(assetType) || len(h.GetEnabledPairs(assetType)) == 0 {
+			continue
+		}
+		contracts, err := h.GetFuturesAccountInfo(assetType)
+		if err != nil {
+			return info, err
+		}
+		info.Currencies = append(info.Currencies, contracts.Currencies...)
+	}
+	return info, nil
+}
+
+// GetFuturesAccountInfo retrieves the margin balances of the coin margined
+// futures or USDT margined swap accounts, by their asset type. Hold is the
+// margin held by positions and open orders.
+func (h *HUOBI) GetFuturesAccountInfo(assetType string) (exchange.AccountInfo, error) {
+	info := exchange.AccountInfo{ExchangeName: h.GetName()}
+	var accounts []ContractAccount
+	var err error
+	switch assetType {
+	case assets.Futures:
+		accounts, err = h.GetContractAccountInfo("")
+	case assets.PerpetualSwap:
+		accounts, err = h.GetLinearSwapAccountInfo("")
+	default:
+		return info, fmt.Errorf("%s asset type %s is not a contract", h.Name, assetType)
+	}
+	if err != nil {
+		return info, err
+	}
+
+	balances := make(map[string]*exchange.AccountCurrencyInfo)
+	var names []string
+	for x := range accounts {
+		name := accounts[x].Symbol
+		if accounts[x].MarginAsset != "" {
+			name = accounts[x].MarginAsset
+		}
+		b, ok := balances[name]
+		if !ok {
+			b = &exchange.AccountCurrencyInfo{CurrencyName: name, AssetType: assetType}
+			balances[name] = b
+			names = append(names, name)
+		}
+		b.TotalValue += accounts[x].MarginBalance
+		b.Hold += accounts[x].MarginPosition + accounts[x].MarginFrozen
+	}
+	for _, name := range names {
+		info.Currencies = append(info.Currencies, *balances[name])
+	}
 	return info, nil
 }
 
+// GetFuturesContracts returns the listed coin margined futures contracts,
+// which deliver at 08:00 UTC on their delivery date
+func (h *HUOBI) GetFuturesContracts() ([]exchange.FuturesContract, error) {
+	contracts, err := h.GetContractInfo("")
+	if err != nil {
+		return nil, err
+	}
+
+	var result []exchange.FuturesContract
+	for x := range contracts {
+		if contracts[x].ContractStatus != ContractStatusListing {
+			continue
+		}
+		delivery, err := time.Parse("20060102", contracts[x].DeliveryDate)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, exchange.FuturesContract{
+			InstrumentID: contracts[x].ContractCode,
+			Underlying:   pair.NewCurrencyPairDelimiter(contracts[x].Symbol+"-USD", "-"),
+			Expiry:       delivery.Add(8 * time.Hour),
+		})
+	}
+	return result, nil
+}
+
+// SubmitFuturesOrder submits an order for a number of contracts of a coin
+// margined futures contract, or a USDT margined swap when the instrument ID
+// is a swap contract code such as BTC-USDT. Orders are placed at the lever
+// rate last set for the contract's symbol, market orders take the best 20
+// price levels and client IDs must be numeric.
+func (h *HUOBI) SubmitFuturesOrder(instrumentID string, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, reduceOnly bool, clientID string) (exchange.SubmitOrderResponse, error) {
+	var submitOrderResponse exchange.SubmitOrderResponse
+	req, err := h.contractOrderRequest(instrumentID, side, orderType, amount, price, reduceOnly, clientID)
+	if err != nil {
+		return submitOrderResponse, err
+	}
+
+	var orderID int64
+	if isLinearSwapCode(instrumentID) {
+		orderID, err = h.PlaceLinearSwapOrder(req)
+	} else {
+		orderID, err = h.PlaceContractOrder(req)
+	}
+	if err != nil {
+		return submitOrderResponse, err
+	}
+
+	submitOrderResponse.OrderID = strconv.FormatInt(orderID, 10)
+	submitOrderResponse.IsOrderPlaced = true
+	return submitOrderResponse, nil
+}
+
+// CancelFuturesOrder cancels a coin margined futures or USDT margined swap
+// order by its contract code
+func (h *HUOBI) CancelFuturesOrder(instrumentID, orderID string) error {
+	id, err := strconv.ParseInt(orderID, 10, 64)
+	if err != nil {
+		return err
+	}
+	if isLinearSwapCode(instrumentID) {
+		return h.CancelLinearSwapOrder(instrumentID, id)
+	}
+	return h.CancelContractOrder(contractCodeSymbol(instrumentID), id)
+}
+
+// contractOrderRequest returns the contract order request of a futures
+// order, contracts can only be traded in whole numbers
+func (h *HUOBI) contractOrderRequest(instrumentID string, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, reduceOnly bool, clientID string) (ContractOrderRequest, error) {
+	req := ContractOrderRequest{
+		ContractCode: instrumentID,
+		Volume:       int64(amount),
+		Offset:       ContractOffsetOpen,
+	}
+	if float64(req.Volume) != amount || req.Volume <= 0 {
+		return req, fmt.Errorf("%s contract order amount %v must be a whole number of contracts",
+			h.Name, amount)
+	}
+	if reduceOnly {
+		req.Offset = ContractOffsetClose
+	}
+
+	switch side {
+	case exchange.Buy:
+		req.Direction = ContractDirectionBuy
+	case exchange.Sell:
+		req.Direction = ContractDirectionSell
+	default:
+		return req, fmt.Errorf("unsupported order side %s", side)
+	}
+
+	switch orderType {
+	case exchange.Limit:
+		req.OrderPriceType = ContractPriceLimit
+		req.Price = price
+	case exchange.Market:
+		req.OrderPriceType = ContractPriceOptimal20
+	default:
+		return req, fmt.Errorf("unsupported order type %s", orderType)
+	}
+
+	if clientID != "" {
+		id, err := strconv.ParseInt(clientID, 10, 64)
+		if err != nil {
+			return req, fmt.Errorf("%s client order ID must be numeric", h.Name)
+		}
+		req.ClientOrderID = id
+	}
+
+	if isLinearSwapCode(instrumentID) {
+		req.LeverRate = h.getLeverage(instrumentID)
+	} else {
+		req.LeverRate = h.getLeverage(contractCodeSymbol(instrumentID))
+	}
+	return req, nil
+}
+
+// isLinearSwapCode returns whether a contract code is a USDT margined swap,
+// whose codes are delimited pairs unlike coin margined futures such as
+// BTC190927
+func isLinearSwapCode(contractCode string) bool {
+	return common.StringContains(contractCode, "-")
+}
+
+// contractCodeSymbol returns the symbol of a coin margined futures contract
+// code, such as BTC for BTC190927
+func contractCodeSymbol(contractCode string) string {
+	for i := range contractCode {
+		if contractCode[i] >= '0' && contractCode[i] <= '9' {
+			return contractCode[:i]
+		}
+	}
+	return contractCode
+}
+
 // GetFundingHistory returns funding history, deposits and
 // withdrawals
 func (h *HUOBI) GetFundingHistory() ([]exchange.FundHistory, error) {
 	var fundHistory []exchange.FundHistory
-	return fundHistory, common.ErrFunctionNotSupported
+	for _, transferType := range []string{"deposit", "withdraw"} {
+		records, err := h.GetDepositWithdrawals("", transferType, "", "", huobiFundingHistoryLimit)
+		if err != nil {
+			return nil, err
+		}
+		for i := range records {
+			fundHistory = append(fundHistory, h.fundHistory(&records[i]))
+		}
+	}
+	return fundHistory, nil
+}
+
+// huobiFundingHistoryLimit is the number of deposits and withdrawals queried
+const huobiFundingHistoryLimit = 100
+
+// fundHistory converts a Huobi deposit or withdrawal record to its funding
+// history, Huobi's withdrawal fee is the network fee of the chain
+func (h *HUOBI) fundHistory(r *DepositWithdrawal) exchange.FundHistory {
+	f := exchange.FundHistory{
+		ExchangeName:    h.Name,
+		TransferID:      r.ID,
+		Description:     r.AddressTag,
+		Timestamp:       time.Unix(0, r.CreatedAt*int64(time.Millisecond)).UTC(),
+		Currency:        common.StringToUpper(r.Currency),
+		Amount:          r.Amount,
+		Chain:           r.Chain,
+		CryptoToAddress: r.Address,
+		CryptoTxID:      r.TxHash,
+	}
+	states := depositStates
+	f.TransferType = "deposit"
+	if r.Type != "deposit" {
+		states = withdrawalStates
+		f.TransferType = "withdrawal"
+		f.NetworkFee = r.Fee
+	}
+	f.Status = states[r.State]
+	if f.Status == "" {
+		f.Status = common.StringToUpper(r.State)
+	}
+	return f
 }
 
 // GetExchangeHistory returns historic trade data since exchange opening.
@@ -236,8 +578,63 @@ func (h *HUOBI) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]exc
 	return resp, common.ErrNotYetImplemented
 }
 
-// SubmitOrder submits a new order
+// klineIntervals maps the candle intervals supported by Huobi to its periods
+var klineIntervals = map[kline.Interval]TimeInterval{
+	kline.OneMin:     TimeIntervalMinute,
+	kline.FiveMin:    TimeIntervalFiveMinutes,
+	kline.FifteenMin: TimeIntervalFifteenMinutes,
+	kline.ThirtyMin:  TimeIntervalThirtyMinutes,
+	kline.OneHour:    TimeIntervalHour,
+	kline.FourHour:   TimeIntervalFourHours,
+	kline.OneDay:     TimeIntervalDay,
+	kline.OneWeek:    TimeIntervalWeek,
+}
+
+// GetHistoricCandles returns the candles of a currency pair opening between
+// start and end. Huobi only returns the latest 2000 candles of an interval.
+func (h *HUOBI) GetHistoricCandles(p pair.CurrencyPair, assetType string, interval kline.Interval, start, end time.Time) ([]kline.Candle, error) {
+	period, ok := klineIntervals[interval]
+	if !ok {
+		return nil, kline.ErrUnsupportedInterval
+	}
+	if !start.Before(end) {
+		return nil, kline.ErrInvalidTimeRange
+	}
+
+	size := kline.TotalCandles(interval, start, time.Now()) + 1
+	if size > huobiKlineMaxSize {
+		size = huobiKlineMaxSize
+	}
+	klines, err := h.GetSpotKline(KlinesRequestParams{
+		Symbol: exchange.FormatExchangeCurrency(h.Name, p).String(),
+		Period: period,
+		Size:   size,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	candles := make([]kline.Candle, len(klines))
+	for x := range klines {
+		candles[x] = kline.Candle{
+			Time:   time.Unix(klines[x].ID, 0),
+			Open:   klines[x].Open,
+			High:   klines[x].High,
+			Low:    klines[x].Low,
+			Close:  klines[x].Close,
+			Volume: klines[x].Amount,
+		}
+	}
+	return kline.FilterCandles(candles, start, end), nil
+}
+
+// SubmitOrder submits a new order, market buy amounts are the quote currency
+// amount to spend
 func (h *HUOBI) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	if h.IsSimulated() {
+		return h.SimulateSubmitOrder(p, side, orderType, amount, price, clientID)
+	}
+
 	var submitOrderResponse exchange.SubmitOrderResponse
 	accountID, err := strconv.ParseInt(clientID, 10, 64)
 	if err != nil {
@@ -281,14 +678,49 @@ func (h *HUOBI) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderT
 	return submitOrderResponse, err
 }
 
+// SubmitOrderSubmission submits an order by its asset type, spot orders are
+// submitted with SubmitOrder and futures and swap orders for a number of
+// contracts of the pair's contract with SubmitFuturesOrder. Triggers and time
+// in force other than GTC are not supported.
+func (h *HUOBI) SubmitOrderSubmission(s exchange.OrderSubmission) (exchange.SubmitOrderResponse, error) {
+	var submitOrderResponse exchange.SubmitOrderResponse
+	if s.Trigger != "" {
+		return submitOrderResponse, fmt.Errorf("%s %s %s", h.Name, s.Asset(), exchange.ErrOrderNotCarried)
+	}
+	if s.TimeInForce != "" && s.TimeInForce != exchange.GTC {
+		return submitOrderResponse, fmt.Errorf("%s unsupported time in force %s", h.Name, s.TimeInForce)
+	}
+
+	switch assetType := s.Asset(); {
+	case assetType == ticker.Spot:
+		return h.SubmitOrder(s.Pair, s.Side, s.Type, s.BaseAmount, s.Price, s.ClientID)
+	case isContractAsset(assetType):
+		instrumentID, err := h.FormatContractSymbol(s.Pair, assetType)
+		if err != nil {
+			return submitOrderResponse, err
+		}
+		return h.SubmitFuturesOrder(instrumentID, s.Side, s.Type, s.BaseAmount, s.Price, false, s.ClientID)
+	default:
+		return submitOrderResponse, fmt.Errorf("%s %s %s", h.Name, assetType, exchange.ErrOrderNotCarried)
+	}
+}
+
 // ModifyOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (h *HUOBI) ModifyOrder(action exchange.ModifyOrder) (string, error) {
+	if h.IsSimulated() {
+		return h.SimulateModifyOrder(action)
+	}
+
 	return "", common.ErrFunctionNotSupported
 }
 
 // CancelOrder cancels an order by its corresponding ID number
 func (h *HUOBI) CancelOrder(order exchange.OrderCancellation) error {
+	if h.IsSimulated() {
+		return h.SimulateCancelOrder(order)
+	}
+
 	orderIDInt, err := strconv.ParseInt(order.OrderID, 10, 64)
 
 	if err != nil {
@@ -302,6 +734,10 @@ func (h *HUOBI) CancelOrder(order exchange.OrderCancellation) error {
 
 // CancelAllOrders cancels all orders associated with a currency pair
 func (h *HUOBI) CancelAllOrders(orderCancellation exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
+	if h.IsSimulated() {
+		return h.SimulateCancelAllOrders(orderCancellation)
+	}
+
 	cancelAllOrdersResponse := exchange.CancelAllOrdersResponse{
 		OrderStatus: make(map[string]string),
 	}
@@ -321,10 +757,135 @@ func (h *HUOBI) CancelAllOrders(orderCancellation exchange.OrderCancellation) (e
 
 // GetOrderInfo returns information on a current open order
 func (h *HUOBI) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
+	if h.IsSimulated() {
+		return h.SimulateGetOrderInfo(orderID)
+	}
+
 	var orderDetail exchange.OrderDetail
 	return orderDetail, common.ErrNotYetImplemented
 }
 
+// Huobi order query limits and states
+const (
+	huobiOrderQueryLimit   = 500
+	huobiOrderHistoryLimit = "100"
+	huobiOrderHistoryState = "filled,partial-canceled,canceled"
+)
+
+// GetActiveOrders returns the open orders matching the request, Huobi
+// requires a symbol so each pair is queried separately
+func (h *HUOBI) GetActiveOrders(req exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	if h.IsSimulated() {
+		return h.SimulateGetOrders(req, true)
+	}
+
+	accountID, err := h.GetAccountID()
+	if err != nil {
+		return nil, err
+	}
+
+	var side string
+	if req.OrderSide != "" {
+		side = common.StringToLower(req.OrderSide.ToString())
+	}
+
+	var orders []exchange.OrderDetail
+	for _, p := range h.GetOrdersRequestPairs(req) {
+		resp, err := h.GetOpenOrders(accountID,
+			exchange.FormatExchangeCurrency(h.Name, p).String(),
+			side,
+			huobiOrderQueryLimit)
+		if err != nil {
+			return nil, err
+		}
+		for i := range resp {
+			orders = append(orders, h.orderDetail(p, &resp[i]))
+		}
+	}
+	return exchange.FilterOrders(orders, req), nil
+}
+
+// GetOrderHistory returns the filled and cancelled orders matching the
+// request, Huobi requires a symbol so each pair is queried separately
+func (h *HUOBI) GetOrderHistory(req exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	if h.IsSimulated() {
+		return h.SimulateGetOrders(req, false)
+	}
+
+	var start, end string
+	if !req.StartTime.IsZero() {
+		start = req.StartTime.UTC().Format("2006-01-02")
+	}
+	if !req.EndTime.IsZero() {
+		end = req.EndTime.UTC().Format("2006-01-02")
+	}
+
+	var orders []exchange.OrderDetail
+	for _, p := range h.GetOrdersRequestPairs(req) {
+		resp, err := h.GetOrders(exchange.FormatExchangeCurrency(h.Name, p).String(),
+			"",
+			start,
+			end,
+			huobiOrderHistoryState,
+			"",
+			"",
+			huobiOrderHistoryLimit)
+		if err != nil {
+			return nil, err
+		}
+		for i := range resp {
+			orders = append(orders, h.orderDetail(p, &resp[i]))
+		}
+	}
+	return exchange.FilterOrders(orders, req), nil
+}
+
+// orderDetail converts a Huobi order of a currency pair to its order details
+func (h *HUOBI) orderDetail(p pair.CurrencyPair, o *OrderInfo) exchange.OrderDetail {
+	price, _ := strconv.ParseFloat(o.Price, 64)
+	amount, _ := strconv.ParseFloat(o.Amount, 64)
+	filled, _ := strconv.ParseFloat(o.FieldAmount, 64)
+	filledCash, _ := strconv.ParseFloat(o.FieldCashAmount, 64)
+	side, orderType := orderSideType(o.Type)
+
+	detail := exchange.OrderDetail{
+		Exchange:       h.Name,
+		ID:             strconv.Itoa(o.ID),
+		BaseCurrency:   p.FirstCurrency.Upper().String(),
+		QuoteCurrency:  p.SecondCurrency.Upper().String(),
+		OrderSide:      string(side),
+		OrderType:      string(orderType),
+		CreationTime:   time.Unix(0, o.CreatedAt*int64(time.Millisecond)).UTC(),
+		Status:         o.State,
+		Price:          price,
+		Amount:         amount,
+		ExecutedAmount: filled,
+	}
+	if filled > 0 {
+		detail.AverageExecutedPrice = filledCash / filled
+	}
+	if o.State == "submitted" || o.State == "partial-filled" {
+		detail.OpenVolume = amount - filled
+	}
+	return detail
+}
+
+// orderSideType returns the side and type of a Huobi order type such as
+// buy-limit or sell-ioc
+func orderSideType(t string) (exchange.OrderSide, exchange.OrderType) {
+	side := exchange.Sell
+	if common.StringContains(t, "buy") {
+		side = exchange.Buy
+	}
+	switch {
+	case common.StringContains(t, "market"):
+		return side, exchange.Market
+	case common.StringContains(t, "ioc"):
+		return side, exchange.ImmediateOrCancel
+	}
+	return side, exchange.Limit
+}
+
 // GetDepositAddress returns a deposit address for a specified currency
 func (h *HUOBI) GetDepositAddress(cryptocurrency pair.CurrencyItem) (string, error) {
 	return "", common.ErrNotYetImplemented
@@ -1,6 +1,7 @@
 package huobihadax
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
@@ -14,17 +15,13 @@ import (
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
 
-// Start starts the OKEX go routine
-func (h *HUOBIHADAX) Start(wg *sync.WaitGroup) {
-	wg.Add(1)
-	go func() {
-		h.Run()
-		wg.Done()
-	}()
+// Start starts the HUOBIHADAX wrapper, refreshing its currency pairs
+func (h *HUOBIHADAX) Start(ctx context.Context) error {
+	return h.StartWrapper(ctx, h.Run)
 }
 
 // Run implements the OKEX wrapper
-func (h *HUOBIHADAX) Run() {
+func (h *HUOBIHADAX) Run(ctx context.Context) error {
 	if h.Verbose {
 		log.Printf("%s Websocket: %s. (url: %s).\n", h.GetName(), common.IsEnabled(h.Websocket.IsEnabled()), h.WebsocketURL)
 		log.Printf("%s polling delay: %ds.\n", h.GetName(), h.RESTPollingDelay)
@@ -46,6 +43,7 @@ func (h *HUOBIHADAX) Run() {
 			log.Printf("%s Failed to update available currencies.\n", h.GetName())
 		}
 	}
+	return nil
 }
 
 // UpdateTicker updates and returns the ticker for a currency pair
@@ -201,8 +199,13 @@ func (h *HUOBIHADAX) GetExchangeHistory(p pair.CurrencyPair, assetType string) (
 	return resp, common.ErrNotYetImplemented
 }
 
-// SubmitOrder submits a new order
+// SubmitOrder submits a new order, market buy amounts are the quote currency
+// amount to spend
 func (h *HUOBIHADAX) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	if h.IsSimulated() {
+		return h.SimulateSubmitOrder(p, side, orderType, amount, price, clientID)
+	}
+
 	var submitOrderResponse exchange.SubmitOrderResponse
 	accountID, err := strconv.ParseInt(clientID, 0, 64)
 	if err != nil {
@@ -249,11 +252,19 @@ func (h *HUOBIHADAX) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, o
 // ModifyOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (h *HUOBIHADAX) ModifyOrder(action exchange.ModifyOrder) (string, error) {
+	if h.IsSimulated() {
+		return h.SimulateModifyOrder(action)
+	}
+
 	return "", common.ErrFunctionNotSupported
 }
 
 // CancelOrder cancels an order by its corresponding ID number
 func (h *HUOBIHADAX) CancelOrder(order exchange.OrderCancellation) error {
+	if h.IsSimulated() {
+		return h.SimulateCancelOrder(order)
+	}
+
 	orderIDInt, err := strconv.ParseInt(order.OrderID, 10, 64)
 
 	if err != nil {
@@ -267,6 +278,10 @@ func (h *HUOBIHADAX) CancelOrder(order exchange.OrderCancellation) error {
 
 // CancelAllOrders cancels all orders associated with a currency pair
 func (h *HUOBIHADAX) CancelAllOrders(orderCancellation exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
+	if h.IsSimulated() {
+		return h.SimulateCancelAllOrders(orderCancellation)
+	}
+
 	cancelAllOrdersResponse := exchange.CancelAllOrdersResponse{
 		OrderStatus: make(map[string]string),
 	}
@@ -286,6 +301,10 @@ func (h *HUOBIHADAX) CancelAllOrders(orderCancellation exchange.OrderCancellatio
 
 // GetOrderInfo returns information on a current open order
 func (h *HUOBIHADAX) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
+	if h.IsSimulated() {
+		return h.SimulateGetOrderInfo(orderID)
+	}
+
 	var orderDetail exchange.OrderDetail
 	return orderDetail, common.ErrNotYetImplemented
 }
@@ -1,11 +1,11 @@
 package itbit
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/url"
 	"strconv"
-	"sync"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
@@ -14,21 +14,18 @@ import (
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
 
-// Start starts the ItBit go routine
-func (i *ItBit) Start(wg *sync.WaitGroup) {
-	wg.Add(1)
-	go func() {
-		i.Run()
-		wg.Done()
-	}()
+// Start starts the ItBit wrapper, refreshing its currency pairs
+func (i *ItBit) Start(ctx context.Context) error {
+	return i.StartWrapper(ctx, i.Run)
 }
 
 // Run implements the ItBit wrapper
-func (i *ItBit) Run() {
+func (i *ItBit) Run(ctx context.Context) error {
 	if i.Verbose {
 		log.Printf("%s polling delay: %ds.\n", i.GetName(), i.RESTPollingDelay)
 		log.Printf("%s %d currencies enabled: %s.\n", i.GetName(), len(i.EnabledPairs), i.EnabledPairs)
 	}
+	return nil
 }
 
 // UpdateTicker updates and returns the ticker for a currency pair
@@ -165,6 +162,10 @@ func (i *ItBit) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]exc
 
 // SubmitOrder submits a new order
 func (i *ItBit) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	if i.IsSimulated() {
+		return i.SimulateSubmitOrder(p, side, orderType, amount, price, clientID)
+	}
+
 	var submitOrderResponse exchange.SubmitOrderResponse
 	var wallet string
 
@@ -202,16 +203,28 @@ func (i *ItBit) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderT
 // ModifyOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (i *ItBit) ModifyOrder(action exchange.ModifyOrder) (string, error) {
+	if i.IsSimulated() {
+		return i.SimulateModifyOrder(action)
+	}
+
 	return "", common.ErrFunctionNotSupported
 }
 
 // CancelOrder cancels an order by its corresponding ID number
 func (i *ItBit) CancelOrder(order exchange.OrderCancellation) error {
+	if i.IsSimulated() {
+		return i.SimulateCancelOrder(order)
+	}
+
 	return i.CancelExistingOrder(order.WalletAddress, order.OrderID)
 }
 
 // CancelAllOrders cancels all orders associated with a currency pair
 func (i *ItBit) CancelAllOrders(orderCancellation exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
+	if i.IsSimulated() {
+		return i.SimulateCancelAllOrders(orderCancellation)
+	}
+
 	cancelAllOrdersResponse := exchange.CancelAllOrdersResponse{
 		OrderStatus: make(map[string]string),
 	}
@@ -232,6 +245,10 @@ func (i *ItBit) CancelAllOrders(orderCancellation exchange.OrderCancellation) (e
 
 // GetOrderInfo returns information on a current open order
 func (i *ItBit) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
+	if i.IsSimulated() {
+		return i.SimulateGetOrderInfo(orderID)
+	}
+
 	var orderDetail exchange.OrderDetail
 	return orderDetail, common.ErrNotYetImplemented
 }
@@ -0,0 +1,143 @@
+// Package kline provides the candle type returned by exchanges' historic
+// candle requests and helpers shared by their implementations
+package kline
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Interval is the duration of a candle
+type Interval time.Duration
+
+// Supported candle intervals, exchanges support a subset of these
+const (
+	OneMin     = Interval(time.Minute)
+	ThreeMin   = 3 * OneMin
+	FiveMin    = 5 * OneMin
+	TenMin     = 10 * OneMin
+	FifteenMin = 15 * OneMin
+	ThirtyMin  = 30 * OneMin
+	OneHour    = Interval(time.Hour)
+	FourHour   = 4 * OneHour
+	SixHour    = 6 * OneHour
+	TwelveHour = 12 * OneHour
+	OneDay     = 24 * OneHour
+	OneWeek    = 7 * OneDay
+)
+
+// Errors returned when validating candle requests
+var (
+	ErrUnsupportedInterval = errors.New("unsupported candle interval")
+	ErrInvalidTimeRange    = errors.New("start time must be before end time")
+)
+
+// Candle is a single OHLCV candle, Time is the candle's open time
+type Candle struct {
+	Time   time.Time
+	Open   float64
+	High   float64
+	Low    float64
+	Close  float64
+	Volume float64
+}
+
+// Range is a window of candles requested in a single page
+type Range struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Duration returns the interval as a time.Duration
+func (i Interval) Duration() time.Duration {
+	return time.Duration(i)
+}
+
+// Short returns the interval in the short form used by most exchanges, such
+// as 1m, 4h, 1d or 1w
+func (i Interval) Short() string {
+	d := i.Duration()
+	switch {
+	case d >= time.Hour*24*7 && d%(time.Hour*24*7) == 0:
+		return strconv.FormatInt(int64(d/(time.Hour*24*7)), 10) + "w"
+	case d >= time.Hour*24 && d%(time.Hour*24) == 0:
+		return strconv.FormatInt(int64(d/(time.Hour*24)), 10) + "d"
+	case d >= time.Hour && d%time.Hour == 0:
+		return strconv.FormatInt(int64(d/time.Hour), 10) + "h"
+	default:
+		return strconv.FormatInt(int64(d/time.Minute), 10) + "m"
+	}
+}
+
+// ValidateRequest checks an interval is supported and the time range is valid
+func ValidateRequest(interval Interval, start, end time.Time, supported []Interval) error {
+	var ok bool
+	for x := range supported {
+		if supported[x] == interval {
+			ok = true
+			break
+		}
+	}
+	if !ok {
+		return ErrUnsupportedInterval
+	}
+	if !start.Before(end) {
+		return ErrInvalidTimeRange
+	}
+	return nil
+}
+
+// TotalCandles returns the number of candles of an interval between start and
+// end, including a partial candle at the end
+func TotalCandles(interval Interval, start, end time.Time) int {
+	if interval <= 0 || !start.Before(end) {
+		return 0
+	}
+	d := interval.Duration()
+	return int((end.Sub(start) + d - 1) / d)
+}
+
+// CalculateRanges splits start to end into ranges of at most limit candles,
+// for exchanges which cap the candles returned per request
+func CalculateRanges(interval Interval, start, end time.Time, limit int) []Range {
+	if interval <= 0 || limit <= 0 || !start.Before(end) {
+		return nil
+	}
+	step := interval.Duration() * time.Duration(limit)
+	var ranges []Range
+	for s := start; s.Before(end); s = s.Add(step) {
+		e := s.Add(step)
+		if e.After(end) {
+			e = end
+		}
+		ranges = append(ranges, Range{Start: s, End: e})
+	}
+	return ranges
+}
+
+// FilterCandles returns the candles which open between start inclusive and
+// end exclusive, sorted by time with duplicates removed
+func FilterCandles(candles []Candle, start, end time.Time) []Candle {
+	filtered := make([]Candle, 0, len(candles))
+	for x := range candles {
+		if candles[x].Time.Before(start) || !candles[x].Time.Before(end) {
+			continue
+		}
+		filtered = append(filtered, candles[x])
+	}
+	sort.SliceStable(filtered, func(i, j int) bool {
+		return filtered[i].Time.Before(filtered[j].Time)
+	})
+
+	result := filtered[:0]
+	for x := range filtered {
+		if len(result) > 0 && result[len(result)-1].Time.Equal(filtered[x].Time) {
+			result[len(result)-1] = filtered[x]
+			continue
+		}
+		result = append(result, filtered[x])
+	}
+	return result
+}
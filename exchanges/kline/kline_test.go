@@ -0,0 +1,70 @@
+package kline
+
+import (
+	"testing"
+	"time"
+)
+
+func TestShort(t *testing.T) {
+	tester := map[Interval]string{
+		OneMin:     "1m",
+		FifteenMin: "15m",
+		OneHour:    "1h",
+		TwelveHour: "12h",
+		OneDay:     "1d",
+		OneWeek:    "1w",
+	}
+	for interval, expected := range tester {
+		if interval.Short() != expected {
+			t.Errorf("Test Failed - Short() expected %s received %s", expected, interval.Short())
+		}
+	}
+}
+
+func TestValidateRequest(t *testing.T) {
+	start := time.Date(2018, 9, 1, 0, 0, 0, 0, time.UTC)
+	supported := []Interval{OneMin, OneHour}
+	if err := ValidateRequest(OneHour, start, start.Add(time.Hour), supported); err != nil {
+		t.Error("Test Failed - ValidateRequest() error", err)
+	}
+	if err := ValidateRequest(OneDay, start, start.Add(time.Hour), supported); err != ErrUnsupportedInterval {
+		t.Error("Test Failed - ValidateRequest() expected unsupported interval", err)
+	}
+	if err := ValidateRequest(OneHour, start, start, supported); err != ErrInvalidTimeRange {
+		t.Error("Test Failed - ValidateRequest() expected invalid time range", err)
+	}
+}
+
+func TestCalculateRanges(t *testing.T) {
+	start := time.Date(2018, 9, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour * 25)
+	if n := TotalCandles(OneHour, start, end.Add(time.Minute)); n != 26 {
+		t.Error("Test Failed - TotalCandles() expected 26", n)
+	}
+
+	ranges := CalculateRanges(OneHour, start, end, 10)
+	if len(ranges) != 3 || !ranges[1].Start.Equal(start.Add(time.Hour*10)) ||
+		!ranges[2].End.Equal(end) {
+		t.Error("Test Failed - CalculateRanges() incorrect ranges", ranges)
+	}
+	if CalculateRanges(OneHour, end, start, 10) != nil {
+		t.Error("Test Failed - CalculateRanges() expected no ranges")
+	}
+}
+
+func TestFilterCandles(t *testing.T) {
+	start := time.Date(2018, 9, 1, 0, 0, 0, 0, time.UTC)
+	candles := []Candle{
+		{Time: start.Add(time.Hour * 2), Close: 3},
+		{Time: start.Add(-time.Hour), Close: 0},
+		{Time: start, Close: 1},
+		{Time: start.Add(time.Hour), Close: 2},
+		{Time: start.Add(time.Hour), Close: 2.5},
+		{Time: start.Add(time.Hour * 3), Close: 4},
+	}
+	filtered := FilterCandles(candles, start, start.Add(time.Hour*3))
+	if len(filtered) != 3 || filtered[0].Close != 1 || filtered[1].Close != 2.5 ||
+		filtered[2].Close != 3 {
+		t.Error("Test Failed - FilterCandles() incorrect candles", filtered)
+	}
+}
@@ -1,16 +1,21 @@
 package kraken
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/gorilla/websocket"
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
 	"github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/assets"
 	"github.com/thrasher-/gocryptotrader/exchanges/request"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
@@ -19,6 +24,7 @@ const (
 	krakenAPIURL         = "https://api.kraken.com"
 	krakenAPIVersion     = "0"
 	krakenServerTime     = "Time"
+	krakenSystemStatus   = "SystemStatus"
 	krakenAssets         = "Assets"
 	krakenAssetPairs     = "AssetPairs"
 	krakenTicker         = "Ticker"
@@ -41,15 +47,59 @@ const (
 	krakenOrderPlace     = "AddOrder"
 	krakenWithdrawInfo   = "WithdrawInfo"
 	krakenDepositMethods = "DepositMethods"
+	krakenWsToken        = "GetWebSocketsToken"
 
 	krakenAuthRate   = 0
 	krakenUnauthRate = 0
 )
 
+// ErrUnknownSymbol is returned when a Kraken pair name cannot be converted to
+// a currency pair
+var ErrUnknownSymbol = errors.New("unknown Kraken pair name")
+
+// legacyAssets maps the X and Z prefixed codes of Kraken's original
+// cryptocurrency and fiat assets to their common codes
+var legacyAssets = map[string]string{
+	"XXBT": "BTC",
+	"XXDG": "DOGE",
+	"XETH": "ETH",
+	"XETC": "ETC",
+	"XLTC": "LTC",
+	"XMLN": "MLN",
+	"XREP": "REP",
+	"XXLM": "XLM",
+	"XXMR": "XMR",
+	"XXRP": "XRP",
+	"XZEC": "ZEC",
+	"ZAUD": "AUD",
+	"ZCAD": "CAD",
+	"ZEUR": "EUR",
+	"ZGBP": "GBP",
+	"ZJPY": "JPY",
+	"ZUSD": "USD",
+}
+
+// assetAliases maps Kraken's asset codes which differ from the common codes
+var assetAliases = map[string]string{
+	"XBT": "BTC",
+	"XDG": "DOGE",
+}
+
 // Kraken is the overarching type across the alphapoint package
 type Kraken struct {
 	exchange.Base
 	CryptoFee, FiatFee float64
+
+	WebsocketConn        *websocket.Conn
+	WebsocketPrivateConn *websocket.Conn
+	wsWriteLock          sync.Mutex
+	wsPrivateWriteLock   sync.Mutex
+	wsRequestID          int64
+
+	// symbols maps Kraken's pair names, altnames and websocket names to
+	// currency pairs
+	symbols     map[string]pair.CurrencyPair
+	symbolsLock sync.RWMutex
 }
 
 // SetDefaults sets current default settings
@@ -61,6 +111,25 @@ func (k *Kraken) SetDefaults() {
 	k.Verbose = false
 	k.RESTPollingDelay = 10
 	k.APIWithdrawPermissions = exchange.AutoWithdrawCryptoWithSetup | exchange.WithdrawCryptoWith2FA | exchange.AutoWithdrawFiatWithSetup | exchange.WithdrawFiatWith2FA
+	k.Features = exchange.Features{
+		CanGetTicker:       true,
+		CanGetOrderbook:    true,
+		CanGetAccountInfo:  true,
+		CanSubmitOrder:     true,
+		CanCancelOrder:     true,
+		CanCancelAllOrders: true,
+		CanGetActiveOrders: true,
+		CanGetOrderHistory: true,
+		CanStreamTicker:    true,
+		CanStreamOrderbook: true,
+		CanStreamTrades:    true,
+	}
+	k.Features.Orders = map[string]exchange.OrderCapabilities{
+		assets.Spot: {
+			OrderTypes:  []exchange.OrderType{exchange.Limit, exchange.Market},
+			TimeInForce: []exchange.TimeInForce{exchange.GTC},
+		},
+	}
 	k.RequestCurrencyPairFormat.Delimiter = ""
 	k.RequestCurrencyPairFormat.Uppercase = true
 	k.RequestCurrencyPairFormat.Separator = ","
@@ -75,6 +144,7 @@ func (k *Kraken) SetDefaults() {
 		common.NewHTTPClientWithTimeout(exchange.DefaultHTTPTimeout))
 	k.APIUrlDefault = krakenAPIURL
 	k.APIUrl = k.APIUrlDefault
+	k.symbols = make(map[string]pair.CurrencyPair)
 	k.WebsocketInit()
 }
 
@@ -113,9 +183,127 @@ func (k *Kraken) Setup(exch config.ExchangeConfig) {
 		if err != nil {
 			log.Fatal(err)
 		}
+		err = k.SetTLSPins(exch.TLSPins)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = k.SetLocalAddress(exch.LocalAddress)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = k.SetRoundingModes(exch.RoundingModes)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = k.SetRateLimitTiers(exch.RateLimitTiers, exch.AccountTier)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = k.SetHTTPClientHeaders(exch.HTTPHeaders)
+		if err != nil {
+			log.Fatal(err)
+		}
+		k.SetSimulation(exch.Simulate, exch.SimulationBalances)
+		err = k.WebsocketSetup(k.WsConnect,
+			exch.Name,
+			exch.Websocket,
+			krakenWebsocketURL,
+			exch.WebsocketURL)
+		if err != nil {
+			log.Fatal(err)
+		}
+		k.Websocket.SetSubscriber(k.wsDefaultSubscriptions, k.WsSubscribe, k.WsUnsubscribe)
 	}
 }
 
+// NormaliseAsset converts a Kraken asset code, such as XXBT, ZUSD or XDG, to
+// the code used across the bot
+func NormaliseAsset(asset string) string {
+	asset = common.StringToUpper(asset)
+	if c, ok := legacyAssets[asset]; ok {
+		return c
+	}
+	if c, ok := assetAliases[asset]; ok {
+		return c
+	}
+	return asset
+}
+
+// krakenAsset converts a currency code to Kraken's asset code
+func krakenAsset(c string) string {
+	c = common.StringToUpper(c)
+	for k, v := range assetAliases {
+		if v == c {
+			return k
+		}
+	}
+	return c
+}
+
+// krakenSymbol returns the Kraken altname of a currency pair, such as XBTUSD
+// for BTC-USD
+func krakenSymbol(p pair.CurrencyPair) string {
+	return krakenAsset(p.FirstCurrency.String()) + krakenAsset(p.SecondCurrency.String())
+}
+
+// setAssetPairs records the names of Kraken's asset pairs and returns their
+// currency pairs in config format. Dark pool pairs are skipped.
+func (k *Kraken) setAssetPairs(assetPairs map[string]AssetPairs) []string {
+	k.symbolsLock.Lock()
+	defer k.symbolsLock.Unlock()
+	var products []string
+	for name, v := range assetPairs {
+		if common.StringContains(v.Altname, ".d") {
+			continue
+		}
+		p := k.newPair(NormaliseAsset(v.Base), NormaliseAsset(v.Quote))
+		k.symbols[name] = p
+		k.symbols[v.Altname] = p
+		if v.Wsname != "" {
+			k.symbols[v.Wsname] = p
+		}
+		products = append(products, p.Pair().String())
+	}
+	return products
+}
+
+// symbolToPair converts a Kraken pair name, altname or websocket name to a
+// currency pair. Names not yet loaded from the asset pairs are split on a
+// slash, matched with the altnames of the enabled pairs or split into legacy
+// asset codes.
+func (k *Kraken) symbolToPair(name string) (pair.CurrencyPair, error) {
+	k.symbolsLock.RLock()
+	p, ok := k.symbols[name]
+	k.symbolsLock.RUnlock()
+	if ok {
+		return p, nil
+	}
+
+	if parts := strings.Split(name, "/"); len(parts) == 2 {
+		return k.newPair(NormaliseAsset(parts[0]), NormaliseAsset(parts[1])), nil
+	}
+	for _, p := range k.GetEnabledCurrencies() {
+		if krakenSymbol(p) == name {
+			return k.newPair(p.FirstCurrency.Upper().String(), p.SecondCurrency.Upper().String()), nil
+		}
+	}
+	if len(name) == 8 {
+		base, baseOK := legacyAssets[name[:4]]
+		quote, quoteOK := legacyAssets[name[4:]]
+		if baseOK && quoteOK {
+			return k.newPair(base, quote), nil
+		}
+	}
+	return pair.CurrencyPair{}, ErrUnknownSymbol
+}
+
+// newPair returns a currency pair in config format
+func (k *Kraken) newPair(base, quote string) pair.CurrencyPair {
+	p := pair.NewCurrencyPair(base, quote)
+	p.Delimiter = k.ConfigCurrencyPairFormat.Delimiter
+	return p
+}
+
 // GetServerTime returns current server time
 func (k *Kraken) GetServerTime() (TimeResponse, error) {
 	path := fmt.Sprintf("%s/%s/public/%s", k.APIUrl, krakenAPIVersion, krakenServerTime)
@@ -132,6 +320,22 @@ func (k *Kraken) GetServerTime() (TimeResponse, error) {
 	return response.Result, GetError(response.Error)
 }
 
+// GetSystemStatus returns the trading status of the exchange
+func (k *Kraken) GetSystemStatus() (SystemStatus, error) {
+	path := fmt.Sprintf("%s/%s/public/%s", k.APIUrl, krakenAPIVersion, krakenSystemStatus)
+
+	var response struct {
+		Error  []string     `json:"error"`
+		Result SystemStatus `json:"result"`
+	}
+
+	if err := k.SendHTTPRequest(path, &response); err != nil {
+		return response.Result, err
+	}
+
+	return response.Result, GetError(response.Error)
+}
+
 // GetAssets returns a full asset list
 func (k *Kraken) GetAssets() (map[string]Asset, error) {
 	path := fmt.Sprintf("%s/%s/public/%s", k.APIUrl, krakenAPIVersion, krakenAssets)
@@ -141,11 +345,15 @@ func (k *Kraken) GetAssets() (map[string]Asset, error) {
 		Result map[string]Asset `json:"result"`
 	}
 
-	if err := k.SendHTTPRequest(path, &response); err != nil {
+	if err := k.SendCachedHTTPRequest(path, &response); err != nil {
 		return response.Result, err
 	}
 
-	return response.Result, GetError(response.Error)
+	if err := GetError(response.Error); err != nil {
+		k.UncachePayload(path)
+		return response.Result, err
+	}
+	return response.Result, nil
 }
 
 // GetAssetPairs returns a full asset pair list
@@ -157,11 +365,15 @@ func (k *Kraken) GetAssetPairs() (map[string]AssetPairs, error) {
 		Result map[string]AssetPairs `json:"result"`
 	}
 
-	if err := k.SendHTTPRequest(path, &response); err != nil {
+	if err := k.SendCachedHTTPRequest(path, &response); err != nil {
 		return response.Result, err
 	}
 
-	return response.Result, GetError(response.Error)
+	if err := GetError(response.Error); err != nil {
+		k.UncachePayload(path)
+		return response.Result, err
+	}
+	return response.Result, nil
 }
 
 // GetTicker returns ticker information from kraken
@@ -863,6 +1075,22 @@ func (k *Kraken) CancelExistingOrder(txid string) (CancelOrderResponse, error) {
 	return response.Result, GetError(response.Error)
 }
 
+// GetWebsocketToken returns a token authenticating private websocket
+// subscriptions, it must be used within 15 minutes and remains valid while
+// the connection is open
+func (k *Kraken) GetWebsocketToken() (WsToken, error) {
+	var response struct {
+		Error  []string `json:"error"`
+		Result WsToken  `json:"result"`
+	}
+
+	if err := k.SendAuthenticatedHTTPRequest(krakenWsToken, url.Values{}, &response); err != nil {
+		return response.Result, err
+	}
+
+	return response.Result, GetError(response.Error)
+}
+
 // GetError parse Exchange errors in response and return the first one
 // Error format from API doc:
 //   error = array of error messages in the format of:
@@ -887,6 +1115,12 @@ func (k *Kraken) SendHTTPRequest(path string, result interface{}) error {
 	return k.SendPayload("GET", path, nil, nil, result, false, k.Verbose)
 }
 
+// SendCachedHTTPRequest sends an unauthenticated HTTP request for static data
+// through the metadata cache
+func (k *Kraken) SendCachedHTTPRequest(path string, result interface{}) error {
+	return k.SendCachedPayload(path, nil, result, k.Verbose)
+}
+
 // SendAuthenticatedHTTPRequest sends an authenticated HTTP request
 func (k *Kraken) SendAuthenticatedHTTPRequest(method string, params url.Values, result interface{}) (err error) {
 	if !k.AuthenticatedAPISupport {
@@ -2,11 +2,16 @@ package kraken
 
 import (
 	"testing"
+	"time"
 
 	"github.com/thrasher-/gocryptotrader/config"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
 	"github.com/thrasher-/gocryptotrader/currency/symbol"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/assets"
+	"github.com/thrasher-/gocryptotrader/exchanges/conformance"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/trade"
 )
 
 var k Kraken
@@ -39,6 +44,17 @@ func TestSetup(t *testing.T) {
 	k.Setup(krakenConfig)
 }
 
+func TestConformance(t *testing.T) {
+	cfg := config.GetConfig()
+	cfg.LoadConfig("../../testdata/configtest.json")
+	krakenConfig, err := cfg.GetExchangeConfig("Kraken")
+	if err != nil {
+		t.Fatal("Test Failed - Kraken conformance init error", err)
+	}
+
+	conformance.Run(t, func() exchange.IBotExchange { return new(Kraken) }, krakenConfig)
+}
+
 func TestGetServerTime(t *testing.T) {
 	t.Parallel()
 	_, err := k.GetServerTime()
@@ -47,6 +63,14 @@ func TestGetServerTime(t *testing.T) {
 	}
 }
 
+func TestCheckSystemStatus(t *testing.T) {
+	t.Parallel()
+	err := k.CheckSystemStatus()
+	if err != nil {
+		t.Error("Test Failed - CheckSystemStatus() error", err)
+	}
+}
+
 func TestGetAssets(t *testing.T) {
 	t.Parallel()
 	_, err := k.GetAssets()
@@ -438,3 +462,157 @@ func TestModifyOrder(t *testing.T) {
 		t.Error("Test failed - ModifyOrder() error")
 	}
 }
+
+func TestNormaliseAsset(t *testing.T) {
+	for asset, expected := range map[string]string{
+		"XXBT": "BTC",
+		"XBT":  "BTC",
+		"XXDG": "DOGE",
+		"ZUSD": "USD",
+		"XTZ":  "XTZ",
+		"dot":  "DOT",
+	} {
+		if c := NormaliseAsset(asset); c != expected {
+			t.Errorf("Test Failed - NormaliseAsset(%s) expected %s, received %s", asset, expected, c)
+		}
+	}
+
+	if s := krakenSymbol(pair.NewCurrencyPair("BTC", "USD")); s != "XBTUSD" {
+		t.Error("Test Failed - krakenSymbol() unexpected symbol", s)
+	}
+
+	pairs, ok := normaliseConfigPairs([]string{"XBT-USD", "ETH-XBT", "XDG-EUR"})
+	if !ok || pairs[0] != "BTC-USD" || pairs[1] != "ETH-BTC" || pairs[2] != "DOGE-EUR" {
+		t.Error("Test Failed - normaliseConfigPairs() unexpected pairs", pairs)
+	}
+	if _, ok = normaliseConfigPairs([]string{"BTC-USD"}); ok {
+		t.Error("Test Failed - normaliseConfigPairs() expected normalised pairs unchanged")
+	}
+}
+
+func TestSymbolToPair(t *testing.T) {
+	var kr Kraken
+	kr.SetDefaults()
+
+	products := kr.setAssetPairs(map[string]AssetPairs{
+		"XXBTZUSD":   {Altname: "XBTUSD", Wsname: "XBT/USD", Base: "XXBT", Quote: "ZUSD"},
+		"DOTUSD":     {Altname: "DOTUSD", Wsname: "DOT/USD", Base: "DOT", Quote: "ZUSD"},
+		"XXBTZUSD.d": {Altname: "XBTUSD.d", Base: "XXBT", Quote: "ZUSD"},
+	})
+	if len(products) != 2 {
+		t.Error("Test Failed - setAssetPairs() unexpected products", products)
+	}
+
+	for _, name := range []string{"XXBTZUSD", "XBTUSD", "XBT/USD", "BTC/USD", "XXBTZEUR"} {
+		p, err := kr.symbolToPair(name)
+		if err != nil || p.FirstCurrency != "BTC" || p.Delimiter != "-" {
+			t.Errorf("Test Failed - symbolToPair(%s) unexpected pair %v %v", name, p, err)
+		}
+	}
+	if p, err := kr.symbolToPair("DOTUSD"); err != nil || p.Pair().String() != "DOT-USD" {
+		t.Error("Test Failed - symbolToPair() unexpected pair", p, err)
+	}
+	if _, err := kr.symbolToPair("ADAUSD"); err != ErrUnknownSymbol {
+		t.Error("Test Failed - symbolToPair() expected unknown symbol error", err)
+	}
+}
+
+func TestOrderDetail(t *testing.T) {
+	var kr Kraken
+	kr.SetDefaults()
+	kr.EnabledPairs = []string{"BTC-USD"}
+
+	var o OrderInfo
+	o.Status = "open"
+	o.OpenTm = 1540000000.5
+	o.Descr.Pair = "XBTUSD"
+	o.Descr.Type = "buy"
+	o.Descr.OrderType = "limit"
+	o.Descr.Price = 6500
+	o.Vol = 2
+	o.VolExec = 0.5
+	o.Price = 6499
+	detail, err := kr.orderDetail("OABCDE-12345-FGHIJK", o)
+	if err != nil {
+		t.Fatal("Test Failed - orderDetail() error", err)
+	}
+	if detail.ID != "OABCDE-12345-FGHIJK" || detail.BaseCurrency != "BTC" || detail.QuoteCurrency != "USD" ||
+		detail.OrderSide != string(exchange.Buy) || detail.OrderType != string(exchange.Limit) ||
+		detail.OpenVolume != 1.5 || detail.AverageExecutedPrice != 6499 ||
+		!detail.CreationTime.Equal(time.Unix(1540000000, int64(time.Second/2))) {
+		t.Errorf("Test Failed - orderDetail() unexpected order detail %+v", detail)
+	}
+}
+
+func TestWsHandleMessage(t *testing.T) {
+	var kr Kraken
+	kr.SetDefaults()
+	kr.Websocket = &exchange.Websocket{DataHandler: make(chan interface{}, 10)}
+
+	for _, msg := range []string{
+		`{"method":"subscribe","result":{"channel":"ticker","symbol":"BTC/USD"},"success":true,"req_id":1}`,
+		`{"channel":"heartbeat"}`,
+		`{"channel":"status","type":"update","data":[{"api_version":"v2","system":"online"}]}`,
+	} {
+		if err := kr.wsHandleMessage([]byte(msg)); err != nil {
+			t.Error("Test Failed - wsHandleMessage() error", err)
+		}
+	}
+	if err := kr.wsHandleMessage([]byte(`{"method":"subscribe","error":"Currency pair not supported ABC/USD","success":false,"req_id":2}`)); err == nil {
+		t.Error("Test Failed - wsHandleMessage() expected subscription error")
+	}
+
+	err := kr.wsHandleMessage([]byte(`{"channel":"ticker","type":"snapshot","data":[{"symbol":"BTC/USD","bid":6499.9,"bid_qty":1.5,"ask":6500.1,"ask_qty":2,"last":6500,"volume":1200.5,"vwap":6480,"low":6400,"high":6600,"change":100,"change_pct":1.56}]}`))
+	if err != nil {
+		t.Fatal("Test Failed - wsHandleMessage() ticker error", err)
+	}
+	tick, ok := (<-kr.Websocket.DataHandler).(exchange.TickerData)
+	if !ok || tick.Pair.Pair().String() != "BTC-USD" || tick.ClosePrice != 6500 || tick.OpenPrice != 6400 {
+		t.Errorf("Test Failed - wsHandleMessage() unexpected ticker %+v", tick)
+	}
+
+	err = kr.wsHandleMessage([]byte(`{"channel":"trade","type":"update","data":[{"symbol":"XDG/USD","side":"sell","price":0.25,"qty":100,"ord_type":"market","trade_id":4665906,"timestamp":"2018-10-20T07:49:37.708706Z"}]}`))
+	if err != nil {
+		t.Fatal("Test Failed - wsHandleMessage() trade error", err)
+	}
+	td, ok := (<-kr.Websocket.DataHandler).(exchange.TradeData)
+	if !ok || td.CurrencyPair.FirstCurrency != "DOGE" || td.Side != trade.Sell || td.Amount != 100 {
+		t.Errorf("Test Failed - wsHandleMessage() unexpected trade %+v", td)
+	}
+	if last, err := trade.LastTrade(kr.Name, td.CurrencyPair, assets.Spot); err != nil || last.TradeID != "4665906" {
+		t.Error("Test Failed - wsHandleMessage() expected the trade stored", last, err)
+	}
+
+	err = kr.wsHandleMessage([]byte(`{"channel":"book","type":"snapshot","data":[{"symbol":"BTC/USD","bids":[{"price":6499.9,"qty":1.5},{"price":6499,"qty":3}],"asks":[{"price":6500.1,"qty":2}],"checksum":2439117997}]}`))
+	if err != nil {
+		t.Fatal("Test Failed - wsHandleMessage() book snapshot error", err)
+	}
+	<-kr.Websocket.DataHandler
+	err = kr.wsHandleMessage([]byte(`{"channel":"book","type":"update","data":[{"symbol":"BTC/USD","bids":[],"asks":[{"price":6500.1,"qty":0.5}],"checksum":2308193312,"timestamp":"2018-10-20T07:49:38.000000Z"}]}`))
+	if err != nil {
+		t.Fatal("Test Failed - wsHandleMessage() book update error", err)
+	}
+	<-kr.Websocket.DataHandler
+	ob, err := orderbook.GetOrderbook(kr.Name, tick.Pair, assets.Spot)
+	if err != nil || len(ob.Bids) != 2 || len(ob.Asks) != 1 || ob.Asks[0].Amount != 0.5 {
+		t.Errorf("Test Failed - wsHandleMessage() unexpected orderbook %+v %v", ob, err)
+	}
+
+	err = kr.wsHandleMessage([]byte(`{"channel":"executions","type":"update","data":[{"order_id":"OABCDE-12345-FGHIJK","exec_type":"trade","order_status":"partially_filled","symbol":"BTC/USD","side":"buy","order_qty":2,"limit_price":6500,"cum_qty":0.5,"avg_price":6499,"last_qty":0.5,"last_price":6499,"timestamp":"2018-10-20T07:49:39.000000Z"}]}`))
+	if err != nil {
+		t.Fatal("Test Failed - wsHandleMessage() executions error", err)
+	}
+	execution, ok := (<-kr.Websocket.DataHandler).(WsExecution)
+	if !ok || execution.OrderID != "OABCDE-12345-FGHIJK" || execution.CumQty != 0.5 || execution.ExecType != "trade" {
+		t.Errorf("Test Failed - wsHandleMessage() unexpected execution %+v", execution)
+	}
+
+	err = kr.wsHandleMessage([]byte(`{"channel":"balances","type":"snapshot","data":[{"asset":"XBT","asset_class":"currency","balance":1.25}]}`))
+	if err != nil {
+		t.Fatal("Test Failed - wsHandleMessage() balances error", err)
+	}
+	balance, ok := (<-kr.Websocket.DataHandler).(WsBalance)
+	if !ok || balance.Asset != "BTC" || balance.Balance != 1.25 {
+		t.Errorf("Test Failed - wsHandleMessage() unexpected balance %+v", balance)
+	}
+}
@@ -1,6 +1,11 @@
 package kraken
 
-import "github.com/thrasher-/gocryptotrader/currency/symbol"
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency/symbol"
+)
 
 // TimeResponse type
 type TimeResponse struct {
@@ -8,6 +13,13 @@ type TimeResponse struct {
 	Rfc1123  string `json:"rfc1123"`
 }
 
+// SystemStatus holds the trading status of the exchange, one of online,
+// maintenance, cancel_only or post_only
+type SystemStatus struct {
+	Status    string `json:"status"`
+	Timestamp string `json:"timestamp"`
+}
+
 // Asset holds asset information
 type Asset struct {
 	Altname         string `json:"altname"`
@@ -23,6 +35,7 @@ type AssetPairs struct {
 	Base              string      `json:"base"`
 	AclassQuote       string      `json:"aclass_quote"`
 	Quote             string      `json:"quote"`
+	Wsname            string      `json:"wsname"`
 	Lot               string      `json:"lot"`
 	PairDecimals      int         `json:"pair_decimals"`
 	LotDecimals       int         `json:"lot_decimals"`
@@ -329,6 +342,119 @@ type CancelOrderResponse struct {
 	Pending interface{} `json:"pending"`
 }
 
+// WsToken is a token authenticating private websocket subscriptions, Expires
+// is the number of seconds it must be used within
+type WsToken struct {
+	Token   string `json:"token"`
+	Expires int64  `json:"expires"`
+}
+
+// WsRequest is a websocket v2 method request
+type WsRequest struct {
+	Method    string      `json:"method"`
+	Params    interface{} `json:"params,omitempty"`
+	RequestID int64       `json:"req_id,omitempty"`
+}
+
+// WsSubscription is the params of a websocket v2 subscribe or unsubscribe
+// request, Token is required for private channels
+type WsSubscription struct {
+	Channel    string   `json:"channel"`
+	Symbol     []string `json:"symbol,omitempty"`
+	Depth      int      `json:"depth,omitempty"`
+	Token      string   `json:"token,omitempty"`
+	SnapOrders bool     `json:"snap_orders,omitempty"`
+}
+
+// WsResponse is a websocket v2 method response or channel message
+type WsResponse struct {
+	Method    string          `json:"method"`
+	Success   bool            `json:"success"`
+	Error     string          `json:"error"`
+	RequestID int64           `json:"req_id"`
+	Channel   string          `json:"channel"`
+	Type      string          `json:"type"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// WsTicker is a websocket v2 ticker update
+type WsTicker struct {
+	Symbol    string  `json:"symbol"`
+	Bid       float64 `json:"bid"`
+	BidQty    float64 `json:"bid_qty"`
+	Ask       float64 `json:"ask"`
+	AskQty    float64 `json:"ask_qty"`
+	Last      float64 `json:"last"`
+	Volume    float64 `json:"volume"`
+	VWAP      float64 `json:"vwap"`
+	Low       float64 `json:"low"`
+	High      float64 `json:"high"`
+	Change    float64 `json:"change"`
+	ChangePct float64 `json:"change_pct"`
+}
+
+// WsTrade is a websocket v2 executed trade
+type WsTrade struct {
+	Symbol    string    `json:"symbol"`
+	Side      string    `json:"side"`
+	Price     float64   `json:"price"`
+	Qty       float64   `json:"qty"`
+	OrderType string    `json:"ord_type"`
+	TradeID   int64     `json:"trade_id"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// WsBookLevel is a price level of a websocket v2 book, a zero quantity
+// removes the level
+type WsBookLevel struct {
+	Price float64 `json:"price"`
+	Qty   float64 `json:"qty"`
+}
+
+// WsBook is a websocket v2 book snapshot or update, Timestamp is only set on
+// updates
+type WsBook struct {
+	Symbol    string        `json:"symbol"`
+	Bids      []WsBookLevel `json:"bids"`
+	Asks      []WsBookLevel `json:"asks"`
+	Checksum  uint32        `json:"checksum"`
+	Timestamp time.Time     `json:"timestamp"`
+}
+
+// WsExecution is a websocket v2 order status or fill event of the account
+type WsExecution struct {
+	OrderID     string    `json:"order_id"`
+	ClientID    string    `json:"cl_ord_id"`
+	ExecID      string    `json:"exec_id"`
+	ExecType    string    `json:"exec_type"`
+	OrderStatus string    `json:"order_status"`
+	OrderType   string    `json:"order_type"`
+	Symbol      string    `json:"symbol"`
+	Side        string    `json:"side"`
+	OrderQty    float64   `json:"order_qty"`
+	LimitPrice  float64   `json:"limit_price"`
+	CumQty      float64   `json:"cum_qty"`
+	CumCost     float64   `json:"cum_cost"`
+	AvgPrice    float64   `json:"avg_price"`
+	LastQty     float64   `json:"last_qty"`
+	LastPrice   float64   `json:"last_price"`
+	FeeUSDEquiv float64   `json:"fee_usd_equiv"`
+	TimeInForce string    `json:"time_in_force"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+// WsBalance is a websocket v2 asset balance of the account, updates set the
+// ledger entry which changed it
+type WsBalance struct {
+	Asset     string    `json:"asset"`
+	Balance   float64   `json:"balance"`
+	Amount    float64   `json:"amount"`
+	Fee       float64   `json:"fee"`
+	Type      string    `json:"type"`
+	LedgerID  string    `json:"ledger_id"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
 // DepositFees the large list of predefined deposit fees
 // Prone to change
 var DepositFees = map[string]float64{
@@ -0,0 +1,466 @@
+package kraken
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/assets"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/trade"
+)
+
+const (
+	krakenWebsocketURL        = "wss://ws.kraken.com/v2"
+	krakenWebsocketPrivateURL = "wss://ws-auth.kraken.com/v2"
+
+	// Public channels
+	krakenWsTicker = "ticker"
+	krakenWsTrade  = "trade"
+	krakenWsBook   = "book"
+
+	// Private channels
+	krakenWsExecutions = "executions"
+	krakenWsBalances   = "balances"
+
+	// Channels sent without a subscription
+	krakenWsHeartbeat = "heartbeat"
+	krakenWsStatus    = "status"
+
+	// Methods
+	krakenWsSubscribe   = "subscribe"
+	krakenWsUnsubscribe = "unsubscribe"
+	krakenWsPing        = "ping"
+
+	krakenWsSnapshot     = "snapshot"
+	krakenWsBookDepth    = 10
+	krakenWsPingInterval = time.Second * 30
+)
+
+// WsConnect initiates the public websocket connection and, when
+// authenticated API support is enabled, the private connection with a token
+// from the REST API
+func (k *Kraken) WsConnect() error {
+	if !k.Websocket.IsEnabled() || !k.IsEnabled() {
+		return errors.New(exchange.WebsocketNotEnabled)
+	}
+
+	k.Websocket.Orderbook.FlushCache()
+
+	var err error
+	k.WebsocketConn, err = k.wsDial(k.Websocket.GetWebsocketURL())
+	if err != nil {
+		return err
+	}
+
+	go k.WsReadData(k.WebsocketConn)
+	go k.wsPingHandler(k.WebsocketConn, &k.wsWriteLock)
+	go k.WsHandleData()
+
+	if !k.AuthenticatedAPISupport {
+		return nil
+	}
+
+	token, err := k.GetWebsocketToken()
+	if err != nil {
+		return err
+	}
+
+	k.WebsocketPrivateConn, err = k.wsDial(krakenWebsocketPrivateURL)
+	if err != nil {
+		return err
+	}
+
+	go k.WsReadData(k.WebsocketPrivateConn)
+	go k.wsPingHandler(k.WebsocketPrivateConn, &k.wsPrivateWriteLock)
+
+	return k.WsSubscribePrivate(token.Token)
+}
+
+// wsDial dials a websocket URL using the configured proxy
+func (k *Kraken) wsDial(address string) (*websocket.Conn, error) {
+	var dialer websocket.Dialer
+	dialer.TLSClientConfig = k.GetTLSConfig()
+	dialer.NetDial = k.GetWebsocketNetDial()
+	if k.Websocket.GetProxyAddress() != "" {
+		proxy, err := url.Parse(k.Websocket.GetProxyAddress())
+		if err != nil {
+			return nil, err
+		}
+		dialer.Proxy = http.ProxyURL(proxy)
+	}
+
+	conn, _, err := dialer.Dial(address, http.Header{})
+	if err != nil {
+		return nil, fmt.Errorf("%s unable to connect to websocket %s. Error: %s",
+			k.Name,
+			address,
+			err)
+	}
+	return conn, nil
+}
+
+// wsWrite sends a request over the supplied connection
+func (k *Kraken) wsWrite(conn *websocket.Conn, private bool, method string, params interface{}) error {
+	if conn == nil {
+		return errors.New("websocket connection not established")
+	}
+
+	if private {
+		k.wsPrivateWriteLock.Lock()
+		defer k.wsPrivateWriteLock.Unlock()
+	} else {
+		k.wsWriteLock.Lock()
+		defer k.wsWriteLock.Unlock()
+	}
+
+	payload, err := common.JSONEncode(WsRequest{
+		Method:    method,
+		Params:    params,
+		RequestID: atomic.AddInt64(&k.wsRequestID, 1),
+	})
+	if err != nil {
+		return err
+	}
+	return conn.WriteMessage(websocket.TextMessage, payload)
+}
+
+// wsSymbol returns the websocket v2 symbol of a currency pair, such as
+// BTC/USD, which uses the common asset codes rather than Kraken's
+func wsSymbol(p pair.CurrencyPair) string {
+	return p.FirstCurrency.Upper().String() + "/" + p.SecondCurrency.Upper().String()
+}
+
+// wsDefaultSubscriptions returns the ticker, trade and book channels of all
+// enabled pairs
+func (k *Kraken) wsDefaultSubscriptions() []exchange.ChannelSubscription {
+	var subs []exchange.ChannelSubscription
+	for _, p := range k.GetEnabledCurrencies() {
+		for _, channel := range []string{krakenWsTicker, krakenWsTrade, krakenWsBook} {
+			subs = append(subs, exchange.ChannelSubscription{
+				Channel:   channel,
+				Currency:  p,
+				AssetType: assets.Spot,
+			})
+		}
+	}
+	return subs
+}
+
+// WsSubscribe subscribes to public channels
+func (k *Kraken) WsSubscribe(subs []exchange.ChannelSubscription) error {
+	return k.wsWriteSubscriptions(krakenWsSubscribe, subs)
+}
+
+// WsUnsubscribe unsubscribes from public channels
+func (k *Kraken) WsUnsubscribe(subs []exchange.ChannelSubscription) error {
+	return k.wsWriteSubscriptions(krakenWsUnsubscribe, subs)
+}
+
+// wsWriteSubscriptions sends a subscribe or unsubscribe request per channel
+// for the symbols of the subscriptions on the public connection
+func (k *Kraken) wsWriteSubscriptions(method string, subs []exchange.ChannelSubscription) error {
+	var channels []string
+	symbols := make(map[string][]string)
+	for i := range subs {
+		if _, ok := symbols[subs[i].Channel]; !ok {
+			channels = append(channels, subs[i].Channel)
+		}
+		symbols[subs[i].Channel] = append(symbols[subs[i].Channel], wsSymbol(subs[i].Currency))
+	}
+
+	for _, channel := range channels {
+		params := WsSubscription{Channel: channel, Symbol: symbols[channel]}
+		if channel == krakenWsBook {
+			params.Depth = krakenWsBookDepth
+		}
+		err := k.wsWrite(k.WebsocketConn, false, method, params)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WsSubscribePrivate subscribes to the account's executions, with a snapshot
+// of its open orders, and balances on the private connection
+func (k *Kraken) WsSubscribePrivate(token string) error {
+	err := k.wsWrite(k.WebsocketPrivateConn, true, krakenWsSubscribe, WsSubscription{
+		Channel:    krakenWsExecutions,
+		Token:      token,
+		SnapOrders: true,
+	})
+	if err != nil {
+		return err
+	}
+	return k.wsWrite(k.WebsocketPrivateConn, true, krakenWsSubscribe, WsSubscription{
+		Channel: krakenWsBalances,
+		Token:   token,
+	})
+}
+
+// WsReadData reads data from a websocket connection
+func (k *Kraken) WsReadData(conn *websocket.Conn) {
+	k.Websocket.Wg.Add(1)
+
+	defer func() {
+		err := conn.Close()
+		if err != nil {
+			k.Websocket.DataHandler <- fmt.Errorf("kraken_websocket.go - Unable to to close Websocket connection. Error: %s",
+				err)
+		}
+		k.Websocket.Wg.Done()
+	}()
+
+	for {
+		select {
+		case <-k.Websocket.ShutdownC:
+			return
+
+		default:
+			_, resp, err := conn.ReadMessage()
+			if err != nil {
+				k.Websocket.DataHandler <- err
+				return
+			}
+
+			k.Websocket.TrafficAlert <- struct{}{}
+			k.Websocket.Intercomm <- exchange.WebsocketResponse{Raw: resp}
+		}
+	}
+}
+
+// wsPingHandler pings a connection, Kraken closes connections which are idle
+// for a minute
+func (k *Kraken) wsPingHandler(conn *websocket.Conn, lock *sync.Mutex) {
+	k.Websocket.Wg.Add(1)
+	defer k.Websocket.Wg.Done()
+
+	t := time.NewTicker(krakenWsPingInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-k.Websocket.ShutdownC:
+			return
+
+		case <-t.C:
+			lock.Lock()
+			err := conn.WriteMessage(websocket.TextMessage,
+				[]byte(`{"method":"`+krakenWsPing+`"}`))
+			lock.Unlock()
+			if err != nil {
+				k.Websocket.DataHandler <- err
+				return
+			}
+		}
+	}
+}
+
+// WsHandleData handles the read data from the websocket connections
+func (k *Kraken) WsHandleData() {
+	k.Websocket.Wg.Add(1)
+	defer k.Websocket.Wg.Done()
+
+	for {
+		select {
+		case <-k.Websocket.ShutdownC:
+			return
+
+		case resp := <-k.Websocket.Intercomm:
+			err := k.wsHandleMessage(resp.Raw)
+			if err != nil {
+				k.Websocket.DataHandler <- fmt.Sprintf("%s websocket handling error: %s",
+					k.Name,
+					err)
+			}
+		}
+	}
+}
+
+// wsHandleMessage routes a single websocket message
+func (k *Kraken) wsHandleMessage(raw []byte) error {
+	var resp WsResponse
+	err := common.JSONDecode(raw, &resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Method != "" {
+		if !resp.Success && resp.Error != "" {
+			return fmt.Errorf("%s request %d: %s", resp.Method, resp.RequestID, resp.Error)
+		}
+		return nil
+	}
+
+	switch resp.Channel {
+	case krakenWsHeartbeat, krakenWsStatus:
+		return nil
+	case krakenWsTicker:
+		return k.wsProcessTickers(&resp)
+	case krakenWsTrade:
+		return k.wsProcessTrades(&resp)
+	case krakenWsBook:
+		return k.wsProcessBooks(&resp)
+	case krakenWsExecutions:
+		var executions []WsExecution
+		err = common.JSONDecode(resp.Data, &executions)
+		if err != nil {
+			return err
+		}
+		for i := range executions {
+			k.Websocket.DataHandler <- executions[i]
+		}
+	case krakenWsBalances:
+		var balances []WsBalance
+		err = common.JSONDecode(resp.Data, &balances)
+		if err != nil {
+			return err
+		}
+		for i := range balances {
+			balances[i].Asset = NormaliseAsset(balances[i].Asset)
+			k.Websocket.DataHandler <- balances[i]
+		}
+	}
+	return nil
+}
+
+// wsProcessTickers sends ticker updates to the data handler
+func (k *Kraken) wsProcessTickers(resp *WsResponse) error {
+	var tickers []WsTicker
+	err := common.JSONDecode(resp.Data, &tickers)
+	if err != nil {
+		return err
+	}
+
+	for i := range tickers {
+		p, err := k.symbolToPair(tickers[i].Symbol)
+		if err != nil {
+			return fmt.Errorf("%s: %s", tickers[i].Symbol, err)
+		}
+		k.Websocket.DataHandler <- exchange.TickerData{
+			Timestamp:  time.Now(),
+			Pair:       p,
+			AssetType:  assets.Spot,
+			Exchange:   k.Name,
+			ClosePrice: tickers[i].Last,
+			Quantity:   tickers[i].Volume,
+			OpenPrice:  tickers[i].Last - tickers[i].Change,
+			HighPrice:  tickers[i].High,
+			LowPrice:   tickers[i].Low,
+		}
+	}
+	return nil
+}
+
+// wsProcessTrades normalises executed trades into the trade store and sends
+// each to the data handler
+func (k *Kraken) wsProcessTrades(resp *WsResponse) error {
+	var trades []WsTrade
+	err := common.JSONDecode(resp.Data, &trades)
+	if err != nil {
+		return err
+	}
+
+	bySymbol := make(map[string][]trade.Trade)
+	var symbols []string
+	for i := range trades {
+		if _, ok := bySymbol[trades[i].Symbol]; !ok {
+			symbols = append(symbols, trades[i].Symbol)
+		}
+		bySymbol[trades[i].Symbol] = append(bySymbol[trades[i].Symbol], trade.Trade{
+			TradeID:   strconv.FormatInt(trades[i].TradeID, 10),
+			Price:     trades[i].Price,
+			Amount:    trades[i].Qty,
+			Side:      trades[i].Side,
+			Timestamp: trades[i].Timestamp,
+		})
+	}
+
+	for _, s := range symbols {
+		p, err := k.symbolToPair(s)
+		if err != nil {
+			return fmt.Errorf("%s: %s", s, err)
+		}
+		normalised := bySymbol[s]
+		err = trade.ProcessTrades(k.Name, p, assets.Spot, normalised)
+		for i := range normalised {
+			if normalised[i].Price <= 0 || normalised[i].Amount <= 0 {
+				continue
+			}
+			k.Websocket.DataHandler <- exchange.TradeData{
+				Timestamp:    normalised[i].Timestamp,
+				CurrencyPair: p,
+				AssetType:    assets.Spot,
+				Exchange:     k.Name,
+				Price:        normalised[i].Price,
+				Amount:       normalised[i].Amount,
+				Side:         trade.Side(normalised[i].Side),
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("%s trades: %s", s, err)
+		}
+	}
+	return nil
+}
+
+// wsProcessBooks loads book snapshots and applies updates to the local
+// orderbook cache
+func (k *Kraken) wsProcessBooks(resp *WsResponse) error {
+	var books []WsBook
+	err := common.JSONDecode(resp.Data, &books)
+	if err != nil {
+		return err
+	}
+
+	for i := range books {
+		p, err := k.symbolToPair(books[i].Symbol)
+		if err != nil {
+			return fmt.Errorf("%s: %s", books[i].Symbol, err)
+		}
+
+		bids := bookItems(books[i].Bids)
+		asks := bookItems(books[i].Asks)
+		if resp.Type == krakenWsSnapshot {
+			err = k.Websocket.Orderbook.LoadSnapshot(orderbook.Base{
+				Pair:         p,
+				CurrencyPair: p.Pair().String(),
+				Bids:         bids,
+				Asks:         asks,
+				AssetType:    assets.Spot,
+				LastUpdated:  books[i].Timestamp,
+			}, k.Name)
+		} else {
+			err = k.Websocket.Orderbook.Update(bids, asks, p, books[i].Timestamp, k.Name, assets.Spot)
+		}
+		if err != nil {
+			return err
+		}
+
+		k.Websocket.DataHandler <- exchange.WebsocketOrderbookUpdate{
+			Pair:     p,
+			Asset:    assets.Spot,
+			Exchange: k.Name,
+		}
+	}
+	return nil
+}
+
+// bookItems converts websocket book levels to orderbook items
+func bookItems(levels []WsBookLevel) []orderbook.Item {
+	items := make([]orderbook.Item, len(levels))
+	for i := range levels {
+		items[i] = orderbook.Item{Price: levels[i].Price, Amount: levels[i].Qty}
+	}
+	return items
+}
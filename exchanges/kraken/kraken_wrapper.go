@@ -1,9 +1,13 @@
 package kraken
 
 import (
+	"context"
+	"fmt"
 	"log"
+	"math"
+	"strconv"
 	"strings"
-	"sync"
+	"time"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
@@ -12,17 +16,13 @@ import (
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
 
-// Start starts the Kraken go routine
-func (k *Kraken) Start(wg *sync.WaitGroup) {
-	wg.Add(1)
-	go func() {
-		k.Run()
-		wg.Done()
-	}()
+// Start starts the Kraken wrapper, refreshing its currency pairs
+func (k *Kraken) Start(ctx context.Context) error {
+	return k.StartWrapper(ctx, k.Run)
 }
 
 // Run implements the Kraken wrapper
-func (k *Kraken) Run() {
+func (k *Kraken) Run(ctx context.Context) error {
 	if k.Verbose {
 		log.Printf("%s polling delay: %ds.\n", k.GetName(), k.RESTPollingDelay)
 		log.Printf("%s %d currencies enabled: %s.\n", k.GetName(), len(k.EnabledPairs), k.EnabledPairs)
@@ -37,26 +37,19 @@ func (k *Kraken) Run() {
 			forceUpgrade = true
 		}
 
-		var exchangeProducts []string
-		for _, v := range assetPairs {
-			if common.StringContains(v.Altname, ".d") {
-				continue
-			}
-			if v.Base[0] == 'X' {
-				if len(v.Base) > 3 {
-					v.Base = v.Base[1:]
-				}
-			}
-			if v.Quote[0] == 'Z' || v.Quote[0] == 'X' {
-				v.Quote = v.Quote[1:]
-			}
-			exchangeProducts = append(exchangeProducts, v.Base+"-"+v.Quote)
-		}
+		exchangeProducts := k.setAssetPairs(assetPairs)
 
 		if forceUpgrade {
-			enabledPairs := []string{"XBT-USD"}
+			enabledPairs := []string{"BTC-USD"}
 			log.Println("WARNING: Available pairs for Kraken reset due to config upgrade, please enable the ones you would like again")
 
+			err = k.UpdateCurrencies(enabledPairs, true, true)
+			if err != nil {
+				log.Printf("%s Failed to get config.\n", k.GetName())
+			}
+		} else if enabledPairs, ok := normaliseConfigPairs(k.EnabledPairs); ok {
+			log.Printf("%s enabled pairs upgraded from Kraken's XBT and XDG asset codes to BTC and DOGE", k.GetName())
+
 			err = k.UpdateCurrencies(enabledPairs, true, true)
 			if err != nil {
 				log.Printf("%s Failed to get config.\n", k.GetName())
@@ -67,34 +60,59 @@ func (k *Kraken) Run() {
 			log.Printf("%s Failed to get config.\n", k.GetName())
 		}
 	}
+	return nil
+}
+
+// normaliseConfigPairs converts config pairs using Kraken's asset codes, such
+// as XBT-USD, to the common codes and returns whether any were converted
+func normaliseConfigPairs(pairs []string) ([]string, bool) {
+	converted := false
+	normalised := make([]string, len(pairs))
+	for i := range pairs {
+		parts := common.SplitStrings(pairs[i], "-")
+		for j := range parts {
+			if c := NormaliseAsset(parts[j]); c != parts[j] {
+				parts[j] = c
+				converted = true
+			}
+		}
+		normalised[i] = common.JoinStrings(parts, "-")
+	}
+	return normalised, converted
 }
 
 // UpdateTicker updates and returns the ticker for a currency pair
 func (k *Kraken) UpdateTicker(p pair.CurrencyPair, assetType string) (ticker.Price, error) {
 	var tickerPrice ticker.Price
 	pairs := k.GetEnabledCurrencies()
-	pairsCollated, err := exchange.GetAndFormatExchangeCurrencies(k.Name, pairs)
-	if err != nil {
-		return tickerPrice, err
+	symbols := make([]string, len(pairs))
+	for i := range pairs {
+		symbols[i] = krakenSymbol(pairs[i])
 	}
-	tickers, err := k.GetTickers(pairsCollated.String())
+	tickers, err := k.GetTickers(common.JoinStrings(symbols, k.RequestCurrencyPairFormat.Separator))
 	if err != nil {
 		return tickerPrice, err
 	}
 
-	for _, x := range pairs {
-		for y, z := range tickers {
-			if common.StringContains(y, x.FirstCurrency.Upper().String()) && common.StringContains(y, x.SecondCurrency.Upper().String()) {
-				var tp ticker.Price
-				tp.Pair = x
-				tp.Last = z.Last
-				tp.Ask = z.Ask
-				tp.Bid = z.Bid
-				tp.High = z.High
-				tp.Low = z.Low
-				tp.Volume = z.Volume
-				ticker.ProcessTicker(k.GetName(), x, tp, assetType)
+	for name, z := range tickers {
+		tp, err := k.symbolToPair(name)
+		if err != nil {
+			log.Printf("%s ticker %s: %s", k.Name, name, err)
+			continue
+		}
+		for _, x := range pairs {
+			if !x.Equal(tp, true) {
+				continue
 			}
+			ticker.ProcessTicker(k.GetName(), x, ticker.Price{
+				Pair:   x,
+				Last:   z.Last,
+				Ask:    z.Ask,
+				Bid:    z.Bid,
+				High:   z.High,
+				Low:    z.Low,
+				Volume: z.Volume,
+			}, assetType)
 		}
 	}
 	return ticker.GetTicker(k.GetName(), p, assetType)
@@ -121,7 +139,7 @@ func (k *Kraken) GetOrderbookEx(p pair.CurrencyPair, assetType string) (orderboo
 // UpdateOrderbook updates and returns the orderbook for a currency pair
 func (k *Kraken) UpdateOrderbook(p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
 	var orderBook orderbook.Base
-	orderbookNew, err := k.GetDepth(exchange.FormatExchangeCurrency(k.GetName(), p).String())
+	orderbookNew, err := k.GetDepth(krakenSymbol(p))
 	if err != nil {
 		return orderBook, err
 	}
@@ -152,7 +170,7 @@ func (k *Kraken) GetAccountInfo() (exchange.AccountInfo, error) {
 	var balances []exchange.AccountCurrencyInfo
 	for key, data := range bal {
 		balances = append(balances, exchange.AccountCurrencyInfo{
-			CurrencyName: key,
+			CurrencyName: NormaliseAsset(key),
 			TotalValue:   data,
 		})
 	}
@@ -177,10 +195,14 @@ func (k *Kraken) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]ex
 
 // SubmitOrder submits a new order
 func (k *Kraken) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	if k.IsSimulated() {
+		return k.SimulateSubmitOrder(p, side, orderType, amount, price, clientID)
+	}
+
 	var submitOrderResponse exchange.SubmitOrderResponse
 	var args = AddOrderOptions{}
 
-	response, err := k.AddOrder(p.Pair().String(), side.ToString(), orderType.ToString(), amount, price, 0, 0, args)
+	response, err := k.AddOrder(krakenSymbol(p), side.ToString(), orderType.ToString(), amount, price, 0, 0, args)
 
 	if len(response.TransactionIds) > 0 {
 		submitOrderResponse.OrderID = strings.Join(response.TransactionIds, ", ")
@@ -196,11 +218,19 @@ func (k *Kraken) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, order
 // ModifyOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (k *Kraken) ModifyOrder(action exchange.ModifyOrder) (string, error) {
+	if k.IsSimulated() {
+		return k.SimulateModifyOrder(action)
+	}
+
 	return "", common.ErrFunctionNotSupported
 }
 
 // CancelOrder cancels an order by its corresponding ID number
 func (k *Kraken) CancelOrder(order exchange.OrderCancellation) error {
+	if k.IsSimulated() {
+		return k.SimulateCancelOrder(order)
+	}
+
 	_, err := k.CancelExistingOrder(order.OrderID)
 
 	return err
@@ -208,6 +238,10 @@ func (k *Kraken) CancelOrder(order exchange.OrderCancellation) error {
 
 // CancelAllOrders cancels all orders associated with a currency pair
 func (k *Kraken) CancelAllOrders(orderCancellation exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
+	if k.IsSimulated() {
+		return k.SimulateCancelAllOrders(orderCancellation)
+	}
+
 	cancelAllOrdersResponse := exchange.CancelAllOrdersResponse{
 		OrderStatus: make(map[string]string),
 	}
@@ -231,10 +265,101 @@ func (k *Kraken) CancelAllOrders(orderCancellation exchange.OrderCancellation) (
 
 // GetOrderInfo returns information on a current open order
 func (k *Kraken) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
+	if k.IsSimulated() {
+		return k.SimulateGetOrderInfo(orderID)
+	}
+
 	var orderDetail exchange.OrderDetail
 	return orderDetail, common.ErrNotYetImplemented
 }
 
+// GetActiveOrders retrieves the open orders matching the request, Kraken
+// returns the open orders of every pair in one request
+func (k *Kraken) GetActiveOrders(req exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	if k.IsSimulated() {
+		return k.SimulateGetOrders(req, true)
+	}
+
+	resp, err := k.GetOpenOrders(OrderInfoOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var orders []exchange.OrderDetail
+	for id, o := range resp.Open {
+		detail, err := k.orderDetail(id, o)
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, detail)
+	}
+	return exchange.FilterOrders(orders, req), nil
+}
+
+// GetOrderHistory retrieves the closed and cancelled orders matching the
+// request, opened within its time range
+func (k *Kraken) GetOrderHistory(req exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	if k.IsSimulated() {
+		return k.SimulateGetOrders(req, false)
+	}
+
+	var args GetClosedOrdersOptions
+	if !req.StartTime.IsZero() {
+		args.Start = strconv.FormatInt(req.StartTime.Unix(), 10)
+	}
+	if !req.EndTime.IsZero() {
+		args.End = strconv.FormatInt(req.EndTime.Unix(), 10)
+	}
+	resp, err := k.GetClosedOrders(args)
+	if err != nil {
+		return nil, err
+	}
+
+	var orders []exchange.OrderDetail
+	for id, o := range resp.Closed {
+		detail, err := k.orderDetail(id, o)
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, detail)
+	}
+	return exchange.FilterOrders(orders, req), nil
+}
+
+// orderDetail converts a Kraken order to its order details
+func (k *Kraken) orderDetail(id string, o OrderInfo) (exchange.OrderDetail, error) {
+	p, err := k.symbolToPair(o.Descr.Pair)
+	if err != nil {
+		return exchange.OrderDetail{}, fmt.Errorf("order %s pair %s: %s", id, o.Descr.Pair, err)
+	}
+
+	side := exchange.Sell
+	if o.Descr.Type == "buy" {
+		side = exchange.Buy
+	}
+	orderType := exchange.Limit
+	if o.Descr.OrderType == "market" {
+		orderType = exchange.Market
+	}
+	sec, frac := math.Modf(o.OpenTm)
+
+	return exchange.OrderDetail{
+		Exchange:             k.Name,
+		ID:                   id,
+		BaseCurrency:         p.FirstCurrency.Upper().String(),
+		QuoteCurrency:        p.SecondCurrency.Upper().String(),
+		OrderSide:            string(side),
+		OrderType:            string(orderType),
+		CreationTime:         time.Unix(int64(sec), int64(frac*float64(time.Second))).UTC(),
+		Status:               o.Status,
+		Price:                o.Descr.Price,
+		Amount:               o.Vol,
+		OpenVolume:           o.Vol - o.VolExec,
+		ExecutedAmount:       o.VolExec,
+		AverageExecutedPrice: o.Price,
+	}, nil
+}
+
 // GetDepositAddress returns a deposit address for a specified currency
 func (k *Kraken) GetDepositAddress(cryptocurrency pair.CurrencyItem) (string, error) {
 	return "", common.ErrNotYetImplemented
@@ -260,7 +385,7 @@ func (k *Kraken) WithdrawFiatFundsToInternationalBank(currency pair.CurrencyItem
 
 // GetWebsocket returns a pointer to the exchange websocket
 func (k *Kraken) GetWebsocket() (*exchange.Websocket, error) {
-	return nil, common.ErrNotYetImplemented
+	return k.Websocket, nil
 }
 
 // GetFeeByType returns an estimate of fee based on type of transaction
@@ -272,3 +397,15 @@ func (k *Kraken) GetFeeByType(feeBuilder exchange.FeeBuilder) (float64, error) {
 func (k *Kraken) GetWithdrawCapabilities() uint32 {
 	return k.GetWithdrawPermissions()
 }
+
+// CheckSystemStatus returns an error unless the exchange is online
+func (k *Kraken) CheckSystemStatus() error {
+	status, err := k.GetSystemStatus()
+	if err != nil {
+		return err
+	}
+	if status.Status != "online" {
+		return fmt.Errorf("system status %s", status.Status)
+	}
+	return nil
+}
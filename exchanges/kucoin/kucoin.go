@@ -0,0 +1,747 @@
+package kucoin
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/config"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/assets"
+	"github.com/thrasher-/gocryptotrader/exchanges/request"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+const (
+	kucoinAPIURL        = "https://api.kucoin.com"
+	kucoinSandboxAPIURL = "https://openapi-sandbox.kucoin.com"
+
+	// Public endpoints
+	kucoinSymbols      = "/api/v2/symbols"
+	kucoinAllTickers   = "/api/v1/market/allTickers"
+	kucoinStats        = "/api/v1/market/stats"
+	kucoinOrderbook    = "/api/v1/market/orderbook/level2_100"
+	kucoinTrades       = "/api/v1/market/histories"
+	kucoinCandles      = "/api/v1/market/candles"
+	kucoinServerTime   = "/api/v1/timestamp"
+	kucoinStatus       = "/api/v1/status"
+	kucoinBulletPublic = "/api/v1/bullet-public"
+
+	// Authenticated endpoints
+	kucoinBulletPrivate    = "/api/v1/bullet-private"
+	kucoinAccounts         = "/api/v1/accounts"
+	kucoinOrders           = "/api/v1/orders"
+	kucoinBaseFee          = "/api/v1/base-fee"
+	kucoinDepositAddresses = "/api/v1/deposit-addresses"
+	kucoinDeposits         = "/api/v1/deposits"
+	kucoinWithdrawals      = "/api/v1/withdrawals"
+	kucoinWithdrawalQuotas = "/api/v1/withdrawals/quotas"
+	kucoinLend             = "/api/v1/margin/lend"
+	kucoinLendActive       = "/api/v1/margin/lend/active"
+	kucoinLendDone         = "/api/v1/margin/lend/done"
+	kucoinLendUnsettled    = "/api/v1/margin/lend/trade/unsettled"
+	kucoinLendSettled      = "/api/v1/margin/lend/trade/settled"
+	kucoinLendAssets       = "/api/v1/margin/lend/assets"
+	kucoinLendMarket       = "/api/v1/margin/market"
+	kucoinToggleAutoLend   = "/api/v1/margin/toggle-auto-lend"
+	kucoinInnerTransfer    = "/api/v2/accounts/inner-transfer"
+
+	// Account types
+	AccountTypeMain   = "main"
+	AccountTypeTrade  = "trade"
+	AccountTypeMargin = "margin"
+
+	// KuCoin allows roughly 30 requests per 3 seconds per endpoint group
+	kucoinAuthRate   = 30
+	kucoinUnauthRate = 30
+
+	kucoinSuccessCode = "200000"
+	kucoinKeyVersion  = "2"
+	kucoinPageSize    = 500
+)
+
+// KuCoin is the overarching type across the KuCoin package
+type KuCoin struct {
+	exchange.Base
+	WebsocketConn *websocket.Conn
+	wsWriteLock   sync.Mutex
+	wsRequestID   int64
+}
+
+// SetDefaults sets the basic defaults for KuCoin
+func (k *KuCoin) SetDefaults() {
+	k.Name = "KuCoin"
+	k.Enabled = false
+	k.Verbose = false
+	k.RESTPollingDelay = 10
+	k.APIWithdrawPermissions = exchange.AutoWithdrawCryptoWithAPIPermission
+	k.BrokerTagFormat = exchange.BrokerTagFormat{MaxLength: 12}
+	k.Features = exchange.Features{
+		CanGetTicker:         true,
+		CanGetOrderbook:      true,
+		CanGetTradeHistory:   true,
+		CanGetAccountInfo:    true,
+		CanGetFundingHistory: true,
+		CanSubmitOrder:       true,
+		IdempotentClientID:   true,
+		CanCancelOrder:       true,
+		CanCancelAllOrders:   true,
+		CanGetDepositAddress: true,
+		CanWithdrawCrypto:    true,
+		CanStreamTicker:      true,
+		CanStreamOrderbook:   true,
+		CanStreamTrades:      true,
+	}
+	k.Features.Orders = map[string]exchange.OrderCapabilities{
+		assets.Spot: {
+			OrderTypes:  []exchange.OrderType{exchange.Limit, exchange.Market, exchange.ImmediateOrCancel},
+			TimeInForce: []exchange.TimeInForce{exchange.GTC, exchange.IOC},
+		},
+	}
+	k.RequestCurrencyPairFormat.Delimiter = "-"
+	k.RequestCurrencyPairFormat.Uppercase = true
+	k.ConfigCurrencyPairFormat.Delimiter = "-"
+	k.ConfigCurrencyPairFormat.Uppercase = true
+	k.AssetTypes = []string{ticker.Spot}
+	k.SupportsAutoPairUpdating = true
+	k.SupportsRESTTickerBatching = true
+	k.Requester = request.New(k.Name,
+		request.NewRateLimit(time.Second*3, kucoinAuthRate),
+		request.NewRateLimit(time.Second*3, kucoinUnauthRate),
+		common.NewHTTPClientWithTimeout(exchange.DefaultHTTPTimeout))
+	k.APIUrlDefault = kucoinAPIURL
+	k.APIUrl = k.APIUrlDefault
+	k.WebsocketInit()
+}
+
+// Setup takes in the supplied exchange configuration details and sets params
+func (k *KuCoin) Setup(exch config.ExchangeConfig) {
+	if !exch.Enabled {
+		k.SetEnabled(false)
+	} else {
+		k.Enabled = true
+		k.AuthenticatedAPISupport = exch.AuthenticatedAPISupport
+		k.SetAPIKeys(exch.APIKey, exch.APISecret, exch.ClientID, false)
+		k.SetHTTPClientTimeout(exch.HTTPTimeout)
+		k.SetHTTPClientUserAgent(exch.HTTPUserAgent)
+		k.RESTPollingDelay = exch.RESTPollingDelay
+		k.Verbose = exch.Verbose
+		k.BaseCurrencies = common.SplitStrings(exch.BaseCurrencies, ",")
+		k.AvailablePairs = common.SplitStrings(exch.AvailablePairs, ",")
+		k.EnabledPairs = common.SplitStrings(exch.EnabledPairs, ",")
+		if exch.UseSandbox {
+			k.APIUrlDefault = kucoinSandboxAPIURL
+			k.APIUrl = k.APIUrlDefault
+		}
+		err := k.SetCurrencyPairFormat()
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = k.SetAssetTypes()
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = k.SetAutoPairDefaults()
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = k.SetAPIURL(exch)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = k.SetClientProxyAddress(exch.ProxyAddress)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = k.SetTLSPins(exch.TLSPins)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = k.SetLocalAddress(exch.LocalAddress)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = k.SetRoundingModes(exch.RoundingModes)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = k.SetRateLimitTiers(exch.RateLimitTiers, exch.AccountTier)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = k.SetHTTPClientHeaders(exch.HTTPHeaders)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = k.SetBrokerTag(exch.BrokerTag)
+		if err != nil {
+			log.Fatal(err)
+		}
+		k.SetSimulation(exch.Simulate, exch.SimulationBalances)
+		err = k.WebsocketSetup(k.WsConnect,
+			exch.Name,
+			exch.Websocket,
+			kucoinWebsocketURL,
+			exch.WebsocketURL)
+		if err != nil {
+			log.Fatal(err)
+		}
+		k.Websocket.SetSubscriber(k.wsDefaultSubscriptions, k.WsSubscribe, k.WsUnsubscribe)
+	}
+}
+
+// GetSymbols returns all spot symbols and their trading rules, market is
+// optional e.g. USDS or BTC
+func (k *KuCoin) GetSymbols(market string) ([]Symbol, error) {
+	var resp []Symbol
+	params := url.Values{}
+	if market != "" {
+		params.Set("market", market)
+	}
+
+	path := common.EncodeURLValues(k.APIUrl+kucoinSymbols, params)
+	return resp, k.SendHTTPRequest(path, &resp)
+}
+
+// GetTickers returns 24 hour statistics for all symbols
+func (k *KuCoin) GetTickers() (Tickers, error) {
+	return k.GetTickersContext(context.Background())
+}
+
+// GetTickersContext returns 24 hour statistics for all symbols, cancelled with
+// the context
+func (k *KuCoin) GetTickersContext(ctx context.Context) (Tickers, error) {
+	var resp Tickers
+	return resp, k.SendHTTPRequestContext(ctx, k.APIUrl+kucoinAllTickers, &resp)
+}
+
+// GetTicker returns 24 hour statistics for a symbol
+func (k *KuCoin) GetTicker(symbol string) (Ticker, error) {
+	var resp Ticker
+	params := url.Values{}
+	params.Set("symbol", symbol)
+
+	path := common.EncodeURLValues(k.APIUrl+kucoinStats, params)
+	return resp, k.SendHTTPRequest(path, &resp)
+}
+
+// GetOrderbook returns the top 100 bids and asks for a symbol
+func (k *KuCoin) GetOrderbook(symbol string) (Orderbook, error) {
+	return k.GetOrderbookContext(context.Background(), symbol)
+}
+
+// GetOrderbookContext returns the top 100 bids and asks for a symbol,
+// cancelled with the context
+func (k *KuCoin) GetOrderbookContext(ctx context.Context, symbol string) (Orderbook, error) {
+	var resp orderbookResponse
+	params := url.Values{}
+	params.Set("symbol", symbol)
+
+	path := common.EncodeURLValues(k.APIUrl+kucoinOrderbook, params)
+	err := k.SendHTTPRequestContext(ctx, path, &resp)
+	if err != nil {
+		return Orderbook{}, err
+	}
+
+	ob := Orderbook{Time: resp.Time}
+	ob.Sequence, _ = strconv.ParseInt(resp.Sequence, 10, 64)
+	ob.Bids, err = parseOrderbookLevels(resp.Bids)
+	if err != nil {
+		return ob, err
+	}
+	ob.Asks, err = parseOrderbookLevels(resp.Asks)
+	return ob, err
+}
+
+// parseOrderbookLevels converts [price, size] levels
+func parseOrderbookLevels(levels [][2]string) ([]OrderbookItem, error) {
+	items := make([]OrderbookItem, len(levels))
+	for i := range levels {
+		price, err := strconv.ParseFloat(levels[i][0], 64)
+		if err != nil {
+			return nil, err
+		}
+		amount, err := strconv.ParseFloat(levels[i][1], 64)
+		if err != nil {
+			return nil, err
+		}
+		items[i] = OrderbookItem{Price: price, Amount: amount}
+	}
+	return items, nil
+}
+
+// GetTrades returns the 100 most recent trades for a symbol
+func (k *KuCoin) GetTrades(symbol string) ([]Trade, error) {
+	var resp []Trade
+	params := url.Values{}
+	params.Set("symbol", symbol)
+
+	path := common.EncodeURLValues(k.APIUrl+kucoinTrades, params)
+	return resp, k.SendHTTPRequest(path, &resp)
+}
+
+// GetCandles returns klines for a symbol. Interval is one of 1min, 3min,
+// 5min, 15min, 30min, 1hour, 2hour, 4hour, 6hour, 8hour, 12hour, 1day, 1week
+func (k *KuCoin) GetCandles(symbol, interval string, start, end time.Time) ([]Candle, error) {
+	var resp [][]string
+	params := url.Values{}
+	params.Set("symbol", symbol)
+	params.Set("type", interval)
+	if !start.IsZero() {
+		params.Set("startAt", strconv.FormatInt(start.Unix(), 10))
+	}
+	if !end.IsZero() {
+		params.Set("endAt", strconv.FormatInt(end.Unix(), 10))
+	}
+
+	path := common.EncodeURLValues(k.APIUrl+kucoinCandles, params)
+	err := k.SendHTTPRequest(path, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return parseCandles(resp)
+}
+
+// parseCandles converts [time, open, close, high, low, volume, turnover]
+// candles
+func parseCandles(raw [][]string) ([]Candle, error) {
+	candles := make([]Candle, 0, len(raw))
+	for i := range raw {
+		if len(raw[i]) < 7 {
+			return nil, fmt.Errorf("unexpected candle length %d", len(raw[i]))
+		}
+
+		var values [7]float64
+		for j := 0; j < 7; j++ {
+			v, err := strconv.ParseFloat(raw[i][j], 64)
+			if err != nil {
+				return nil, err
+			}
+			values[j] = v
+		}
+
+		candles = append(candles, Candle{
+			Time:     int64(values[0]),
+			Open:     values[1],
+			Close:    values[2],
+			High:     values[3],
+			Low:      values[4],
+			Volume:   values[5],
+			Turnover: values[6],
+		})
+	}
+	return candles, nil
+}
+
+// GetServerTime returns the KuCoin server time
+func (k *KuCoin) GetServerTime() (time.Time, error) {
+	var resp int64
+	err := k.SendHTTPRequest(k.APIUrl+kucoinServerTime, &resp)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return common.UnixTimestampToUTC(resp), nil
+}
+
+// GetServiceStatus returns the service status of the exchange
+func (k *KuCoin) GetServiceStatus() (ServiceStatus, error) {
+	var resp ServiceStatus
+	return resp, k.SendHTTPRequest(k.APIUrl+kucoinStatus, &resp)
+}
+
+// GetAccounts returns account balances, both parameters are optional. Type is
+// one of main, trade or margin.
+func (k *KuCoin) GetAccounts(currency, accountType string) ([]Account, error) {
+	return k.GetAccountsContext(context.Background(), currency, accountType)
+}
+
+// GetAccountsContext returns account balances, cancelled with the context
+func (k *KuCoin) GetAccountsContext(ctx context.Context, currency, accountType string) ([]Account, error) {
+	var resp []Account
+	params := url.Values{}
+	if currency != "" {
+		params.Set("currency", currency)
+	}
+	if accountType != "" {
+		params.Set("type", accountType)
+	}
+	return resp, k.SendAuthenticatedHTTPRequestContext(ctx, "GET", kucoinAccounts, params, nil, &resp)
+}
+
+// InnerTransfer moves funds between the main, trade and margin accounts
+func (k *KuCoin) InnerTransfer(currency, from, to string, amount float64) (string, error) {
+	var resp struct {
+		OrderID string `json:"orderId"`
+	}
+	req := map[string]string{
+		"clientOid": strconv.FormatInt(time.Now().UnixNano(), 10),
+		"currency":  currency,
+		"from":      from,
+		"to":        to,
+		"amount":    strconv.FormatFloat(amount, 'f', -1, 64),
+	}
+	return resp.OrderID, k.SendAuthenticatedHTTPRequest("POST", kucoinInnerTransfer, nil, req, &resp)
+}
+
+// PlaceOrder places a new spot or margin order and returns its order ID
+func (k *KuCoin) PlaceOrder(arg OrderRequest) (string, error) {
+	return k.PlaceOrderContext(context.Background(), arg)
+}
+
+// PlaceOrderContext places a new spot or margin order, cancelled with the
+// context
+func (k *KuCoin) PlaceOrderContext(ctx context.Context, arg OrderRequest) (string, error) {
+	if arg.ClientOrderID == "" {
+		return "", errors.New("client order ID must be set")
+	}
+
+	var resp struct {
+		OrderID string `json:"orderId"`
+	}
+	return resp.OrderID, k.SendAuthenticatedHTTPRequestContext(ctx, "POST", kucoinOrders, nil, arg, &resp)
+}
+
+// CancelExistingOrder cancels an order by its order ID
+func (k *KuCoin) CancelExistingOrder(orderID string) ([]string, error) {
+	return k.CancelExistingOrderContext(context.Background(), orderID)
+}
+
+// CancelExistingOrderContext cancels an order by its order ID, cancelled with
+// the context
+func (k *KuCoin) CancelExistingOrderContext(ctx context.Context, orderID string) ([]string, error) {
+	var resp struct {
+		CancelledOrderIDs []string `json:"cancelledOrderIds"`
+	}
+	return resp.CancelledOrderIDs, k.SendAuthenticatedHTTPRequestContext(ctx, "DELETE", kucoinOrders+"/"+orderID, nil, nil, &resp)
+}
+
+// CancelOrders cancels all open orders, symbol and trade type are optional.
+// Trade type is one of TRADE or MARGIN_TRADE.
+func (k *KuCoin) CancelOrders(symbol, tradeType string) ([]string, error) {
+	var resp struct {
+		CancelledOrderIDs []string `json:"cancelledOrderIds"`
+	}
+	params := url.Values{}
+	if symbol != "" {
+		params.Set("symbol", symbol)
+	}
+	if tradeType != "" {
+		params.Set("tradeType", tradeType)
+	}
+	return resp.CancelledOrderIDs, k.SendAuthenticatedHTTPRequest("DELETE", kucoinOrders, params, nil, &resp)
+}
+
+// GetOrders returns a page of orders. Status is active or done, symbol is
+// optional and pages start at 1.
+func (k *KuCoin) GetOrders(status, symbol string, page int64) (OrdersPage, error) {
+	var resp OrdersPage
+	params := url.Values{}
+	if status != "" {
+		params.Set("status", status)
+	}
+	if symbol != "" {
+		params.Set("symbol", symbol)
+	}
+	setPage(params, page)
+	return resp, k.SendAuthenticatedHTTPRequest("GET", kucoinOrders, params, nil, &resp)
+}
+
+// GetOrder returns an order by its order ID
+func (k *KuCoin) GetOrder(orderID string) (Order, error) {
+	var resp Order
+	return resp, k.SendAuthenticatedHTTPRequest("GET", kucoinOrders+"/"+orderID, nil, nil, &resp)
+}
+
+// GetBaseFee returns the account's base spot fee rates
+func (k *KuCoin) GetBaseFee() (Fee, error) {
+	var resp Fee
+	return resp, k.SendAuthenticatedHTTPRequest("GET", kucoinBaseFee, nil, nil, &resp)
+}
+
+// GetCurrencyDepositAddress returns a deposit address for a currency, chain
+// is optional
+func (k *KuCoin) GetCurrencyDepositAddress(currency, chain string) (DepositAddress, error) {
+	var resp DepositAddress
+	params := url.Values{}
+	params.Set("currency", currency)
+	if chain != "" {
+		params.Set("chain", chain)
+	}
+	return resp, k.SendAuthenticatedHTTPRequest("GET", kucoinDepositAddresses, params, nil, &resp)
+}
+
+// GetDeposits returns a page of deposit records, currency is optional
+func (k *KuCoin) GetDeposits(currency string, page int64) (TransfersPage, error) {
+	var resp TransfersPage
+	params := url.Values{}
+	if currency != "" {
+		params.Set("currency", currency)
+	}
+	setPage(params, page)
+	return resp, k.SendAuthenticatedHTTPRequest("GET", kucoinDeposits, params, nil, &resp)
+}
+
+// GetWithdrawals returns a page of withdrawal records, currency is optional
+func (k *KuCoin) GetWithdrawals(currency string, page int64) (TransfersPage, error) {
+	var resp TransfersPage
+	params := url.Values{}
+	if currency != "" {
+		params.Set("currency", currency)
+	}
+	setPage(params, page)
+	return resp, k.SendAuthenticatedHTTPRequest("GET", kucoinWithdrawals, params, nil, &resp)
+}
+
+// GetWithdrawalQuota returns withdrawal limits and fees for a currency
+func (k *KuCoin) GetWithdrawalQuota(currency string) (WithdrawalQuota, error) {
+	var resp WithdrawalQuota
+	params := url.Values{}
+	params.Set("currency", currency)
+	return resp, k.SendAuthenticatedHTTPRequest("GET", kucoinWithdrawalQuotas, params, nil, &resp)
+}
+
+// Withdraw submits a withdrawal from the main account and returns its ID
+func (k *KuCoin) Withdraw(arg WithdrawalRequest) (string, error) {
+	var resp struct {
+		WithdrawalID string `json:"withdrawalId"`
+	}
+	return resp.WithdrawalID, k.SendAuthenticatedHTTPRequest("POST", kucoinWithdrawals, nil, arg, &resp)
+}
+
+// CreateLendOrder lends funds from the main account and returns the order ID
+func (k *KuCoin) CreateLendOrder(arg LendOrderRequest) (string, error) {
+	switch arg.Term {
+	case 7, 14, 28:
+	default:
+		return "", errors.New("lending term must be 7, 14 or 28 days")
+	}
+
+	var resp struct {
+		OrderID string `json:"orderId"`
+	}
+	return resp.OrderID, k.SendAuthenticatedHTTPRequest("POST", kucoinLend, nil, arg, &resp)
+}
+
+// CancelLendOrder cancels the unfilled portion of a lending order
+func (k *KuCoin) CancelLendOrder(orderID string) error {
+	return k.SendAuthenticatedHTTPRequest("DELETE", kucoinLend+"/"+orderID, nil, nil, nil)
+}
+
+// SetAutoLend enables or disables automatic relending of a currency
+func (k *KuCoin) SetAutoLend(arg AutoLendRequest) error {
+	return k.SendAuthenticatedHTTPRequest("POST", kucoinToggleAutoLend, nil, arg, nil)
+}
+
+// GetActiveLendOrders returns a page of open lending orders, currency is
+// optional
+func (k *KuCoin) GetActiveLendOrders(currency string, page int64) (LendOrdersPage, error) {
+	return k.getLendOrders(kucoinLendActive, currency, page)
+}
+
+// GetLendOrderHistory returns a page of completed or cancelled lending
+// orders, currency is optional
+func (k *KuCoin) GetLendOrderHistory(currency string, page int64) (LendOrdersPage, error) {
+	return k.getLendOrders(kucoinLendDone, currency, page)
+}
+
+// getLendOrders returns a page of lending orders from an endpoint
+func (k *KuCoin) getLendOrders(endpoint, currency string, page int64) (LendOrdersPage, error) {
+	var resp LendOrdersPage
+	params := url.Values{}
+	if currency != "" {
+		params.Set("currency", currency)
+	}
+	setPage(params, page)
+	return resp, k.SendAuthenticatedHTTPRequest("GET", endpoint, params, nil, &resp)
+}
+
+// GetUnsettledLendTrades returns a page of filled lending orders that are
+// still accruing interest, currency is optional
+func (k *KuCoin) GetUnsettledLendTrades(currency string, page int64) (LendTradesPage, error) {
+	return k.getLendTrades(kucoinLendUnsettled, currency, page)
+}
+
+// GetSettledLendTrades returns a page of repaid lending orders, currency is
+// optional
+func (k *KuCoin) GetSettledLendTrades(currency string, page int64) (LendTradesPage, error) {
+	return k.getLendTrades(kucoinLendSettled, currency, page)
+}
+
+// getLendTrades returns a page of lending trades from an endpoint
+func (k *KuCoin) getLendTrades(endpoint, currency string, page int64) (LendTradesPage, error) {
+	var resp LendTradesPage
+	params := url.Values{}
+	if currency != "" {
+		params.Set("currency", currency)
+	}
+	setPage(params, page)
+	return resp, k.SendAuthenticatedHTTPRequest("GET", endpoint, params, nil, &resp)
+}
+
+// GetLendAssets returns lending account records, currency is optional
+func (k *KuCoin) GetLendAssets(currency string) ([]LendAsset, error) {
+	var resp []LendAsset
+	params := url.Values{}
+	if currency != "" {
+		params.Set("currency", currency)
+	}
+	return resp, k.SendAuthenticatedHTTPRequest("GET", kucoinLendAssets, params, nil, &resp)
+}
+
+// GetLendMarket returns aggregated lending demand for a currency, term is
+// optional
+func (k *KuCoin) GetLendMarket(currency string, term int64) ([]LendMarket, error) {
+	var resp []LendMarket
+	params := url.Values{}
+	params.Set("currency", currency)
+	if term > 0 {
+		params.Set("term", strconv.FormatInt(term, 10))
+	}
+	return resp, k.SendAuthenticatedHTTPRequest("GET", kucoinLendMarket, params, nil, &resp)
+}
+
+// setPage sets the paging parameters for list endpoints
+func setPage(params url.Values, page int64) {
+	if page > 0 {
+		params.Set("currentPage", strconv.FormatInt(page, 10))
+	}
+	params.Set("pageSize", strconv.FormatInt(kucoinPageSize, 10))
+}
+
+// SendHTTPRequest sends an unauthenticated HTTP request
+func (k *KuCoin) SendHTTPRequest(path string, result interface{}) error {
+	return k.SendHTTPRequestContext(context.Background(), path, result)
+}
+
+// SendHTTPRequestContext sends an unauthenticated HTTP request, cancelled with
+// the context
+func (k *KuCoin) SendHTTPRequestContext(ctx context.Context, path string, result interface{}) error {
+	return k.sendPayload(ctx, "GET", path, nil, nil, false, result)
+}
+
+// SendAuthenticatedHTTPRequest sends an authenticated HTTP request, params
+// are encoded in the query string and data is sent as the JSON body
+func (k *KuCoin) SendAuthenticatedHTTPRequest(method, endpoint string, params url.Values, data, result interface{}) error {
+	return k.SendAuthenticatedHTTPRequestContext(context.Background(), method, endpoint, params, data, result)
+}
+
+// SendAuthenticatedHTTPRequestContext sends an authenticated HTTP request,
+// cancelled with the context
+func (k *KuCoin) SendAuthenticatedHTTPRequestContext(ctx context.Context, method, endpoint string, params url.Values, data, result interface{}) error {
+	if !k.AuthenticatedAPISupport {
+		return fmt.Errorf(exchange.WarningAuthenticatedRequestWithoutCredentialsSet, k.Name)
+	}
+
+	requestPath := endpoint
+	if len(params) > 0 {
+		requestPath = common.EncodeURLValues(requestPath, params)
+	}
+
+	var payload []byte
+	if data != nil {
+		var err error
+		payload, err = common.JSONEncode(data)
+		if err != nil {
+			return errors.New("SendAuthenticatedHTTPRequest: Unable to JSON request")
+		}
+
+		if k.Verbose {
+			log.Printf("Request JSON: %s\n", payload)
+		}
+	}
+
+	timestamp := strconv.FormatInt(common.UnixMillis(time.Now()), 10)
+	headers := make(map[string]string)
+	headers["KC-API-KEY"] = k.APIKey
+	headers["KC-API-SIGN"] = k.sign(timestamp, method, requestPath, payload)
+	headers["KC-API-TIMESTAMP"] = timestamp
+	headers["KC-API-PASSPHRASE"] = k.signPassphrase()
+	headers["KC-API-KEY-VERSION"] = kucoinKeyVersion
+	headers["Content-Type"] = "application/json"
+
+	return k.sendPayload(ctx, method, k.APIUrl+requestPath, headers, payload, true, result)
+}
+
+// sendPayload sends a request and decodes the response envelope
+func (k *KuCoin) sendPayload(ctx context.Context, method, path string, headers map[string]string, payload []byte, authenticated bool, result interface{}) error {
+	var resp Response
+	err := k.SendPayloadContext(ctx,
+		method,
+		path,
+		headers,
+		bytes.NewBuffer(payload),
+		&resp,
+		authenticated,
+		k.Verbose)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != kucoinSuccessCode {
+		return fmt.Errorf("%s error code %s: %s", k.Name, resp.Code, resp.Msg)
+	}
+
+	if result == nil || len(resp.Data) == 0 {
+		return nil
+	}
+	return common.JSONDecode(resp.Data, result)
+}
+
+// sign returns the base64 encoded HMAC-SHA256 request signature
+func (k *KuCoin) sign(timestamp, method, requestPath string, body []byte) string {
+	hmac := common.GetHMAC(common.HashSHA256,
+		[]byte(timestamp+method+requestPath+string(body)),
+		[]byte(k.APISecret))
+	return common.Base64Encode(hmac)
+}
+
+// signPassphrase returns the passphrase signed with the API secret as
+// required by version 2 API keys
+func (k *KuCoin) signPassphrase() string {
+	hmac := common.GetHMAC(common.HashSHA256,
+		[]byte(k.ClientID),
+		[]byte(k.APISecret))
+	return common.Base64Encode(hmac)
+}
+
+// GetFee returns an estimate of fee based on type of transaction
+func (k *KuCoin) GetFee(feeBuilder exchange.FeeBuilder) (float64, error) {
+	var fee float64
+	switch feeBuilder.FeeType {
+	case exchange.CryptocurrencyTradeFee:
+		rate := kucoinDefaultFee
+		if k.AuthenticatedAPISupport {
+			baseFee, err := k.GetBaseFee()
+			if err != nil {
+				return 0, err
+			}
+			rate = baseFee.TakerFeeRate
+			if feeBuilder.IsMaker {
+				rate = baseFee.MakerFeeRate
+			}
+		}
+		fee = rate * feeBuilder.PurchasePrice * feeBuilder.Amount
+	case exchange.CryptocurrencyWithdrawalFee:
+		quota, err := k.GetWithdrawalQuota(feeBuilder.FirstCurrency)
+		if err != nil {
+			return 0, err
+		}
+		fee = quota.WithdrawMinFee
+	}
+
+	if fee < 0 {
+		fee = 0
+	}
+	return fee, nil
+}
+
+// kucoinDefaultFee is the spot maker and taker rate for the lowest tier
+const kucoinDefaultFee = 0.001
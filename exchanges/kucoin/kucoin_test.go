@@ -0,0 +1,390 @@
+package kucoin
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	"github.com/thrasher-/gocryptotrader/currency/symbol"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/conformance"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+var k KuCoin
+
+// Please supply you own test keys here for due diligence testing.
+const (
+	apiKey                  = ""
+	apiSecret               = ""
+	passphrase              = ""
+	canManipulateRealOrders = false
+)
+
+func TestSetDefaults(t *testing.T) {
+	k.SetDefaults()
+	if k.GetName() != "KuCoin" {
+		t.Error("Test Failed - KuCoin - SetDefaults() error")
+	}
+}
+
+func TestSetup(t *testing.T) {
+	cfg := config.GetConfig()
+	cfg.LoadConfig("../../testdata/configtest.json")
+	kucoinConfig, err := cfg.GetExchangeConfig("KuCoin")
+	if err != nil {
+		t.Error("Test Failed - KuCoin Setup() init error")
+	}
+
+	kucoinConfig.AuthenticatedAPISupport = true
+	kucoinConfig.APIKey = apiKey
+	kucoinConfig.APISecret = apiSecret
+	kucoinConfig.ClientID = passphrase
+
+	k.Setup(kucoinConfig)
+}
+
+func TestConformance(t *testing.T) {
+	cfg := config.GetConfig()
+	cfg.LoadConfig("../../testdata/configtest.json")
+	kucoinConfig, err := cfg.GetExchangeConfig("KuCoin")
+	if err != nil {
+		t.Fatal("Test Failed - KuCoin conformance init error", err)
+	}
+
+	conformance.Run(t, func() exchange.IBotExchange { return new(KuCoin) }, kucoinConfig)
+}
+
+func TestCheckSystemStatus(t *testing.T) {
+	t.Parallel()
+	err := k.CheckSystemStatus()
+	if err != nil {
+		t.Error("Test Failed - KuCoin CheckSystemStatus() error", err)
+	}
+}
+
+func TestGetSymbols(t *testing.T) {
+	t.Parallel()
+	_, err := k.GetSymbols("")
+	if err != nil {
+		t.Error("Test Failed - KuCoin GetSymbols() error", err)
+	}
+}
+
+func TestGetTickers(t *testing.T) {
+	t.Parallel()
+	_, err := k.GetTickers()
+	if err != nil {
+		t.Error("Test Failed - KuCoin GetTickers() error", err)
+	}
+}
+
+func TestGetOrderbook(t *testing.T) {
+	t.Parallel()
+	_, err := k.GetOrderbook("BTC-USDT")
+	if err != nil {
+		t.Error("Test Failed - KuCoin GetOrderbook() error", err)
+	}
+}
+
+func TestGetTrades(t *testing.T) {
+	t.Parallel()
+	_, err := k.GetTrades("BTC-USDT")
+	if err != nil {
+		t.Error("Test Failed - KuCoin GetTrades() error", err)
+	}
+}
+
+func TestGetCandles(t *testing.T) {
+	t.Parallel()
+	_, err := k.GetCandles("BTC-USDT", "1hour", time.Now().Add(-time.Hour*24), time.Now())
+	if err != nil {
+		t.Error("Test Failed - KuCoin GetCandles() error", err)
+	}
+}
+
+func TestGetWsToken(t *testing.T) {
+	t.Parallel()
+	token, err := k.GetWsToken(false)
+	if err != nil {
+		t.Error("Test Failed - KuCoin GetWsToken() error", err)
+	}
+	if err == nil && (token.Token == "" || len(token.InstanceServers) == 0) {
+		t.Error("Test Failed - KuCoin GetWsToken() returned no token or servers")
+	}
+}
+
+func TestParseCandles(t *testing.T) {
+	t.Parallel()
+	candles, err := parseCandles([][]string{
+		{"1545904980", "0.058", "0.049", "0.058", "0.049", "0.018", "0.000945"},
+	})
+	if err != nil {
+		t.Fatal("Test Failed - parseCandles() error", err)
+	}
+	if len(candles) != 1 || candles[0].Time != 1545904980 ||
+		candles[0].Open != 0.058 || candles[0].Close != 0.049 {
+		t.Error("Test Failed - parseCandles() incorrect values", candles)
+	}
+
+	_, err = parseCandles([][]string{{"1545904980", "0.058"}})
+	if err == nil {
+		t.Error("Test Failed - parseCandles() expected error on short candle")
+	}
+}
+
+func TestSign(t *testing.T) {
+	k.SetDefaults()
+	k.APISecret = "secret"
+	k.ClientID = "passphrase"
+	defer func() {
+		k.APISecret = apiSecret
+		k.ClientID = passphrase
+	}()
+
+	expected := common.Base64Encode(common.GetHMAC(common.HashSHA256,
+		[]byte("1547015186532GET/api/v1/accounts?currency=BTC"),
+		[]byte("secret")))
+	if sig := k.sign("1547015186532", "GET", "/api/v1/accounts?currency=BTC", nil); sig != expected {
+		t.Errorf("Test Failed - sign() expected %s, received %s", expected, sig)
+	}
+
+	if k.signPassphrase() == k.ClientID {
+		t.Error("Test Failed - signPassphrase() passphrase not signed")
+	}
+}
+
+func TestBuildOrder(t *testing.T) {
+	k.SetDefaults()
+	p := pair.NewCurrencyPairDelimiter("BTC-USDT", "-")
+
+	req, err := k.buildOrder(p, exchange.Buy, exchange.Limit, 0.5, 10000, "")
+	if err != nil {
+		t.Fatal("Test Failed - buildOrder() error", err)
+	}
+	if req.Symbol != "BTC-USDT" || req.Side != "buy" || req.Type != "limit" ||
+		req.Price != "10000" || req.Size != "0.5" || req.ClientOrderID == "" {
+		t.Error("Test Failed - buildOrder() incorrect limit order", req)
+	}
+
+	req, err = k.buildOrder(p, exchange.Sell, exchange.ImmediateOrCancel, 1, 2, "abc")
+	if err != nil {
+		t.Fatal("Test Failed - buildOrder() error", err)
+	}
+	if req.TimeInForce != "IOC" || req.ClientOrderID != "abc" {
+		t.Error("Test Failed - buildOrder() incorrect IOC order", req)
+	}
+
+	_, err = k.buildOrder(p, exchange.Buy, exchange.OrderType("STOP"), 1, 1, "")
+	if err == nil {
+		t.Error("Test Failed - buildOrder() expected unsupported order type error")
+	}
+}
+
+func TestWsHandleMessage(t *testing.T) {
+	k.SetDefaults()
+	k.Websocket.DataHandler = make(chan interface{}, 10)
+
+	err := k.wsHandleMessage([]byte(`{"type":"message","topic":"/market/match:BTC-USDT","subject":"trade.l3match","data":{"sequence":"1545896669145","symbol":"BTC-USDT","side":"buy","price":"0.08200000000000000000","size":"0.01022222000000000000","tradeId":"5c24c5da03aa673885cd67aa","takerOrderId":"5c24c5d903aa6772d55b371e","makerOrderId":"5c2187d003aa677bd09d5c93","time":"1545913818099033203"}}`))
+	if err != nil {
+		t.Fatal("Test Failed - wsHandleMessage() error", err)
+	}
+
+	trade, ok := (<-k.Websocket.DataHandler).(exchange.TradeData)
+	if !ok || trade.Price != 0.082 || trade.CurrencyPair.Pair().String() != "BTC-USDT" {
+		t.Error("Test Failed - wsHandleMessage() incorrect trade", trade)
+	}
+
+	err = k.wsHandleMessage([]byte(`{"id":"1","type":"error","code":401,"data":"token is expired"}`))
+	if err == nil {
+		t.Error("Test Failed - wsHandleMessage() expected error")
+	}
+}
+
+func TestCreateLendOrder(t *testing.T) {
+	_, err := k.CreateLendOrder(LendOrderRequest{Currency: "USDT", Size: "10", DailyIntRate: "0.0002", Term: 3})
+	if err == nil {
+		t.Error("Test Failed - CreateLendOrder() expected invalid term error")
+	}
+}
+
+func setFeeBuilder() exchange.FeeBuilder {
+	return exchange.FeeBuilder{
+		Amount:         1,
+		Delimiter:      "-",
+		FeeType:        exchange.CryptocurrencyTradeFee,
+		FirstCurrency:  symbol.BTC,
+		SecondCurrency: symbol.USDT,
+		IsMaker:        false,
+		PurchasePrice:  1,
+	}
+}
+
+func TestGetFee(t *testing.T) {
+	k.SetDefaults()
+	if apiKey != "" || apiSecret != "" {
+		t.Skip()
+	}
+	k.AuthenticatedAPISupport = false
+
+	var feeBuilder = setFeeBuilder()
+	// CryptocurrencyTradeFee Basic
+	if resp, err := k.GetFee(feeBuilder); resp != float64(0.001) || err != nil {
+		t.Errorf("Test Failed - GetFee() error. Expected: %f, Received: %f", float64(0.001), resp)
+		t.Error(err)
+	}
+
+	// CryptocurrencyTradeFee High quantity
+	feeBuilder = setFeeBuilder()
+	feeBuilder.Amount = 1000
+	feeBuilder.PurchasePrice = 1000
+	if resp, err := k.GetFee(feeBuilder); resp != float64(1000) || err != nil {
+		t.Errorf("Test Failed - GetFee() error. Expected: %f, Received: %f", float64(1000), resp)
+		t.Error(err)
+	}
+
+	// CryptocurrencyTradeFee Negative purchase price
+	feeBuilder = setFeeBuilder()
+	feeBuilder.PurchasePrice = -1000
+	if resp, err := k.GetFee(feeBuilder); resp != float64(0) || err != nil {
+		t.Errorf("Test Failed - GetFee() error. Expected: %f, Received: %f", float64(0), resp)
+		t.Error(err)
+	}
+
+	// InternationalBankDepositFee Basic
+	feeBuilder = setFeeBuilder()
+	feeBuilder.FeeType = exchange.InternationalBankDepositFee
+	if resp, err := k.GetFee(feeBuilder); resp != float64(0) || err != nil {
+		t.Errorf("Test Failed - GetFee() error. Expected: %f, Received: %f", float64(0), resp)
+		t.Error(err)
+	}
+}
+
+func TestFormatWithdrawPermissions(t *testing.T) {
+	k.SetDefaults()
+	expectedResult := exchange.AutoWithdrawCryptoWithAPIPermissionText
+	withdrawPermissions := k.FormatWithdrawPermissions()
+	if withdrawPermissions != expectedResult {
+		t.Errorf("Expected: %s, Received: %s", expectedResult, withdrawPermissions)
+	}
+}
+
+func TestGetAccountInfo(t *testing.T) {
+	if apiKey != "" || apiSecret != "" {
+		_, err := k.GetAccountInfo()
+		if err != nil {
+			t.Error("Test Failed - GetAccountInfo() error", err)
+		}
+	} else {
+		_, err := k.GetAccountInfo()
+		if err == nil {
+			t.Error("Test Failed - GetAccountInfo() error")
+		}
+	}
+}
+
+func TestAccountBalances(t *testing.T) {
+	currencies := accountBalances([]Account{
+		{Currency: "BTC", Type: AccountTypeMain, Balance: 1, Holds: 0},
+		{Currency: "USDT", Type: AccountTypeTrade, Balance: 100, Holds: 25},
+		{Currency: "BTC", Type: AccountTypeTrade, Balance: 0.5, Holds: 0.25},
+		{Currency: "BTC", Type: AccountTypeMargin, Balance: 3, Holds: 0},
+	})
+	if len(currencies) != 2 {
+		t.Fatalf("Test Failed - accountBalances() expected 2 currencies received %v", currencies)
+	}
+	if c := currencies[0]; c.CurrencyName != "BTC" || c.TotalValue != 1.5 || c.Hold != 0.25 {
+		t.Error("Test Failed - accountBalances() expected combined BTC balances", c)
+	}
+	if c := currencies[1]; c.CurrencyName != "USDT" || c.TotalValue != 100 || c.Hold != 25 {
+		t.Error("Test Failed - accountBalances() expected trade USDT balance", c)
+	}
+}
+
+func TestModifyOrder(t *testing.T) {
+	_, err := k.ModifyOrder(exchange.ModifyOrder{})
+	if err == nil {
+		t.Error("Test failed - ModifyOrder() error")
+	}
+}
+
+// Any tests below this line have the ability to impact your orders on the exchange. Enable canManipulateRealOrders to run them
+// ----------------------------------------------------------------------------------------------------------------------------
+func isRealOrderTestEnabled() bool {
+	if k.APIKey == "" || k.APISecret == "" ||
+		k.APIKey == "Key" || k.APISecret == "Secret" ||
+		!canManipulateRealOrders {
+		return false
+	}
+	return true
+}
+
+func TestSubmitOrder(t *testing.T) {
+	k.SetDefaults()
+	TestSetup(t)
+
+	if !isRealOrderTestEnabled() {
+		t.Skip()
+	}
+
+	var p = pair.CurrencyPair{
+		Delimiter:      "-",
+		FirstCurrency:  symbol.BTC,
+		SecondCurrency: symbol.USDT,
+	}
+	response, err := k.SubmitOrder(p, exchange.Buy, exchange.Limit, 0.001, 10, "")
+	if err != nil || !response.IsOrderPlaced {
+		t.Errorf("Order failed to be placed: %v", err)
+	}
+}
+
+func TestCancelExchangeOrder(t *testing.T) {
+	k.SetDefaults()
+	TestSetup(t)
+
+	if !isRealOrderTestEnabled() {
+		t.Skip()
+	}
+
+	var orderCancellation = exchange.OrderCancellation{
+		OrderID:      "1",
+		CurrencyPair: pair.NewCurrencyPairDelimiter("BTC-USDT", "-"),
+	}
+
+	err := k.CancelOrder(orderCancellation)
+	if err != nil {
+		t.Errorf("Could not cancel order: %s", err)
+	}
+}
+
+func TestCancelAllExchangeOrders(t *testing.T) {
+	k.SetDefaults()
+	TestSetup(t)
+
+	if !isRealOrderTestEnabled() {
+		t.Skip()
+	}
+
+	resp, err := k.CancelAllOrders(exchange.OrderCancellation{})
+	if err != nil {
+		t.Errorf("Could not cancel order: %s", err)
+	}
+
+	if len(resp.OrderStatus) > 0 {
+		t.Errorf("%v orders failed to cancel", len(resp.OrderStatus))
+	}
+}
+
+func TestUpdateTickerContext(t *testing.T) {
+	var _ exchange.ContextExchange = &k
+	var _ exchange.OrderContextExchange = &k
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := exchange.UpdateTickerContext(ctx, &k, pair.NewCurrencyPair("BTC", "USDT"), ticker.Spot)
+	if err != context.Canceled {
+		t.Error("Test Failed - UpdateTickerContext() expected cancelled request", err)
+	}
+}
@@ -0,0 +1,405 @@
+package kucoin
+
+import "encoding/json"
+
+// Response is the envelope returned by all KuCoin REST endpoints
+type Response struct {
+	Code string          `json:"code"`
+	Msg  string          `json:"msg"`
+	Data json.RawMessage `json:"data"`
+}
+
+// Symbol holds spot symbol trading rules
+type Symbol struct {
+	Symbol          string  `json:"symbol"`
+	Name            string  `json:"name"`
+	BaseCurrency    string  `json:"baseCurrency"`
+	QuoteCurrency   string  `json:"quoteCurrency"`
+	FeeCurrency     string  `json:"feeCurrency"`
+	Market          string  `json:"market"`
+	BaseMinSize     float64 `json:"baseMinSize,string"`
+	QuoteMinSize    float64 `json:"quoteMinSize,string"`
+	BaseMaxSize     float64 `json:"baseMaxSize,string"`
+	QuoteMaxSize    float64 `json:"quoteMaxSize,string"`
+	BaseIncrement   float64 `json:"baseIncrement,string"`
+	QuoteIncrement  float64 `json:"quoteIncrement,string"`
+	PriceIncrement  float64 `json:"priceIncrement,string"`
+	PriceLimitRate  float64 `json:"priceLimitRate,string"`
+	IsMarginEnabled bool    `json:"isMarginEnabled"`
+	EnableTrading   bool    `json:"enableTrading"`
+}
+
+// Ticker holds 24 hour statistics for a symbol
+type Ticker struct {
+	Symbol      string  `json:"symbol"`
+	SymbolName  string  `json:"symbolName"`
+	Buy         float64 `json:"buy,string"`
+	Sell        float64 `json:"sell,string"`
+	ChangeRate  float64 `json:"changeRate,string"`
+	ChangePrice float64 `json:"changePrice,string"`
+	High        float64 `json:"high,string"`
+	Low         float64 `json:"low,string"`
+	Volume      float64 `json:"vol,string"`
+	VolumeValue float64 `json:"volValue,string"`
+	Last        float64 `json:"last,string"`
+	Time        int64   `json:"time"`
+}
+
+// Tickers holds tickers for all symbols
+type Tickers struct {
+	Time    int64    `json:"time"`
+	Tickers []Ticker `json:"ticker"`
+}
+
+// orderbookResponse holds raw orderbook levels as [price, size]
+type orderbookResponse struct {
+	Sequence string      `json:"sequence"`
+	Time     int64       `json:"time"`
+	Bids     [][2]string `json:"bids"`
+	Asks     [][2]string `json:"asks"`
+}
+
+// OrderbookItem stores an individual orderbook level
+type OrderbookItem struct {
+	Price  float64
+	Amount float64
+}
+
+// Orderbook stores the orderbook data
+type Orderbook struct {
+	Sequence int64
+	Time     int64
+	Bids     []OrderbookItem
+	Asks     []OrderbookItem
+}
+
+// Trade holds a public trade, time is in nanoseconds
+type Trade struct {
+	Sequence string  `json:"sequence"`
+	Price    float64 `json:"price,string"`
+	Size     float64 `json:"size,string"`
+	Side     string  `json:"side"`
+	Time     int64   `json:"time"`
+}
+
+// Candle holds kline data
+type Candle struct {
+	Time     int64
+	Open     float64
+	Close    float64
+	High     float64
+	Low      float64
+	Volume   float64
+	Turnover float64
+}
+
+// Account holds a sub account balance, Type is main, trade or margin
+type Account struct {
+	ID        string  `json:"id"`
+	Currency  string  `json:"currency"`
+	Type      string  `json:"type"`
+	Balance   float64 `json:"balance,string"`
+	Available float64 `json:"available,string"`
+	Holds     float64 `json:"holds,string"`
+}
+
+// OrderRequest holds the parameters for placing a spot order. Size is in the
+// base currency, Funds is in the quote currency and is only valid for market
+// orders.
+type OrderRequest struct {
+	ClientOrderID string `json:"clientOid"`
+	Side          string `json:"side"`
+	Symbol        string `json:"symbol"`
+	Type          string `json:"type,omitempty"`
+	Remark        string `json:"remark,omitempty"`
+	TradeType     string `json:"tradeType,omitempty"`
+	Price         string `json:"price,omitempty"`
+	Size          string `json:"size,omitempty"`
+	Funds         string `json:"funds,omitempty"`
+	TimeInForce   string `json:"timeInForce,omitempty"`
+	PostOnly      bool   `json:"postOnly,omitempty"`
+	Hidden        bool   `json:"hidden,omitempty"`
+	Iceberg       bool   `json:"iceberg,omitempty"`
+	VisibleSize   string `json:"visibleSize,omitempty"`
+}
+
+// Order holds order details
+type Order struct {
+	ID            string  `json:"id"`
+	Symbol        string  `json:"symbol"`
+	OperationType string  `json:"opType"`
+	Type          string  `json:"type"`
+	Side          string  `json:"side"`
+	Price         float64 `json:"price,string"`
+	Size          float64 `json:"size,string"`
+	Funds         float64 `json:"funds,string"`
+	DealFunds     float64 `json:"dealFunds,string"`
+	DealSize      float64 `json:"dealSize,string"`
+	Fee           float64 `json:"fee,string"`
+	FeeCurrency   string  `json:"feeCurrency"`
+	STP           string  `json:"stp"`
+	TimeInForce   string  `json:"timeInForce"`
+	PostOnly      bool    `json:"postOnly"`
+	Hidden        bool    `json:"hidden"`
+	Iceberg       bool    `json:"iceberg"`
+	ClientOrderID string  `json:"clientOid"`
+	Remark        string  `json:"remark"`
+	IsActive      bool    `json:"isActive"`
+	CancelExist   bool    `json:"cancelExist"`
+	CreatedAt     int64   `json:"createdAt"`
+	TradeType     string  `json:"tradeType"`
+}
+
+// pagination holds the paging fields common to list endpoints
+type pagination struct {
+	CurrentPage int64 `json:"currentPage"`
+	PageSize    int64 `json:"pageSize"`
+	TotalNum    int64 `json:"totalNum"`
+	TotalPage   int64 `json:"totalPage"`
+}
+
+// OrdersPage holds a page of orders
+type OrdersPage struct {
+	pagination
+	Items []Order `json:"items"`
+}
+
+// Fee holds maker and taker fee rates
+type Fee struct {
+	Symbol       string  `json:"symbol"`
+	TakerFeeRate float64 `json:"takerFeeRate,string"`
+	MakerFeeRate float64 `json:"makerFeeRate,string"`
+}
+
+// DepositAddress holds a deposit address
+type DepositAddress struct {
+	Address string `json:"address"`
+	Memo    string `json:"memo"`
+	Chain   string `json:"chain"`
+}
+
+// WithdrawalRequest holds the parameters for a withdrawal
+type WithdrawalRequest struct {
+	Currency string `json:"currency"`
+	Address  string `json:"address"`
+	Amount   string `json:"amount"`
+	Memo     string `json:"memo,omitempty"`
+	IsInner  bool   `json:"isInner"`
+	Remark   string `json:"remark,omitempty"`
+	Chain    string `json:"chain,omitempty"`
+}
+
+// WithdrawalQuota holds withdrawal limits and fees for a currency
+type WithdrawalQuota struct {
+	Currency            string  `json:"currency"`
+	AvailableAmount     float64 `json:"availableAmount,string"`
+	RemainAmount        float64 `json:"remainAmount,string"`
+	WithdrawMinSize     float64 `json:"withdrawMinSize,string"`
+	LimitBTCAmount      float64 `json:"limitBTCAmount,string"`
+	InnerWithdrawMinFee float64 `json:"innerWithdrawMinFee,string"`
+	WithdrawMinFee      float64 `json:"withdrawMinFee,string"`
+	IsWithdrawEnabled   bool    `json:"isWithdrawEnabled"`
+	Precision           int64   `json:"precision"`
+	Chain               string  `json:"chain"`
+}
+
+// Transfer holds a deposit or withdrawal record
+type Transfer struct {
+	ID         string  `json:"id"`
+	Address    string  `json:"address"`
+	Memo       string  `json:"memo"`
+	Currency   string  `json:"currency"`
+	Amount     float64 `json:"amount,string"`
+	Fee        float64 `json:"fee,string"`
+	WalletTxID string  `json:"walletTxId"`
+	IsInner    bool    `json:"isInner"`
+	Status     string  `json:"status"`
+	Remark     string  `json:"remark"`
+	CreatedAt  int64   `json:"createdAt"`
+	UpdatedAt  int64   `json:"updatedAt"`
+}
+
+// TransfersPage holds a page of deposits or withdrawals
+type TransfersPage struct {
+	pagination
+	Items []Transfer `json:"items"`
+}
+
+// LendOrderRequest holds the parameters for a lending order. Term is in days
+// and must be one of 7, 14 or 28.
+type LendOrderRequest struct {
+	Currency     string `json:"currency"`
+	Size         string `json:"size"`
+	DailyIntRate string `json:"dailyIntRate"`
+	Term         int64  `json:"term"`
+}
+
+// AutoLendRequest holds the parameters for toggling auto lending
+type AutoLendRequest struct {
+	Currency     string `json:"currency"`
+	IsEnable     bool   `json:"isEnable"`
+	RetainSize   string `json:"retainSize,omitempty"`
+	DailyIntRate string `json:"dailyIntRate,omitempty"`
+	Term         int64  `json:"term,omitempty"`
+}
+
+// LendOrder holds an active or historical lending order
+type LendOrder struct {
+	OrderID      string  `json:"orderId"`
+	Currency     string  `json:"currency"`
+	Size         float64 `json:"size,string"`
+	FilledSize   float64 `json:"filledSize,string"`
+	DailyIntRate float64 `json:"dailyIntRate,string"`
+	Term         int64   `json:"term"`
+	CreatedAt    int64   `json:"createdAt"`
+	Status       string  `json:"status"`
+}
+
+// LendOrdersPage holds a page of lending orders
+type LendOrdersPage struct {
+	pagination
+	Items []LendOrder `json:"items"`
+}
+
+// LendTrade holds a filled lending order that is either accruing interest or
+// has been settled
+type LendTrade struct {
+	TradeID         string  `json:"tradeId"`
+	Currency        string  `json:"currency"`
+	Size            float64 `json:"size,string"`
+	Interest        float64 `json:"interest,string"`
+	Repaid          float64 `json:"repaid,string"`
+	DailyIntRate    float64 `json:"dailyIntRate,string"`
+	Term            int64   `json:"term"`
+	MaturityTime    int64   `json:"maturityTime"`
+	SettledAt       int64   `json:"settledAt"`
+	Note            string  `json:"note"`
+	AccruedInterest float64 `json:"accruedInterest,string"`
+}
+
+// LendTradesPage holds a page of lending trades
+type LendTradesPage struct {
+	pagination
+	Items []LendTrade `json:"items"`
+}
+
+// LendAsset holds the lending account record for a currency
+type LendAsset struct {
+	Currency           string  `json:"currency"`
+	Outstanding        float64 `json:"outstanding,string"`
+	FilledSize         float64 `json:"filledSize,string"`
+	AccountedUnsettled float64 `json:"accountedUnsettled,string"`
+	Settled            float64 `json:"settled,string"`
+	IsAutoLend         bool    `json:"isAutoLend"`
+}
+
+// LendMarket holds aggregated lending demand for a rate and term
+type LendMarket struct {
+	DailyIntRate float64 `json:"dailyIntRate,string"`
+	Term         int64   `json:"term"`
+	Size         float64 `json:"size,string"`
+}
+
+// InstanceServer holds a websocket server returned by the bullet endpoints,
+// ping values are in milliseconds
+type InstanceServer struct {
+	Endpoint     string `json:"endpoint"`
+	Encrypt      bool   `json:"encrypt"`
+	Protocol     string `json:"protocol"`
+	PingInterval int64  `json:"pingInterval"`
+	PingTimeout  int64  `json:"pingTimeout"`
+}
+
+// WsToken holds a websocket connection token
+type WsToken struct {
+	Token           string           `json:"token"`
+	InstanceServers []InstanceServer `json:"instanceServers"`
+}
+
+// WsRequest is a websocket subscribe or ping message
+type WsRequest struct {
+	ID             string `json:"id"`
+	Type           string `json:"type"`
+	Topic          string `json:"topic,omitempty"`
+	PrivateChannel bool   `json:"privateChannel,omitempty"`
+	Response       bool   `json:"response,omitempty"`
+}
+
+// WsResponse is a websocket message
+type WsResponse struct {
+	ID      string          `json:"id"`
+	Type    string          `json:"type"`
+	Topic   string          `json:"topic"`
+	Subject string          `json:"subject"`
+	Code    json.Number     `json:"code"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// WsTicker holds a websocket ticker update
+type WsTicker struct {
+	Sequence    string  `json:"sequence"`
+	Price       float64 `json:"price,string"`
+	Size        float64 `json:"size,string"`
+	BestAsk     float64 `json:"bestAsk,string"`
+	BestAskSize float64 `json:"bestAskSize,string"`
+	BestBid     float64 `json:"bestBid,string"`
+	BestBidSize float64 `json:"bestBidSize,string"`
+	Time        int64   `json:"time"`
+}
+
+// WsMatch holds a websocket trade, time is in nanoseconds
+type WsMatch struct {
+	Sequence     string  `json:"sequence"`
+	Symbol       string  `json:"symbol"`
+	Side         string  `json:"side"`
+	Price        float64 `json:"price,string"`
+	Size         float64 `json:"size,string"`
+	TradeID      string  `json:"tradeId"`
+	TakerOrderID string  `json:"takerOrderId"`
+	MakerOrderID string  `json:"makerOrderId"`
+	Time         int64   `json:"time,string"`
+}
+
+// WsDepth holds a top of book orderbook snapshot
+type WsDepth struct {
+	Asks      [][2]string `json:"asks"`
+	Bids      [][2]string `json:"bids"`
+	Timestamp int64       `json:"timestamp"`
+}
+
+// WsOrderChange holds a private order update, Type is one of open, match,
+// filled, canceled or update
+type WsOrderChange struct {
+	Symbol        string  `json:"symbol"`
+	OrderType     string  `json:"orderType"`
+	Side          string  `json:"side"`
+	OrderID       string  `json:"orderId"`
+	Type          string  `json:"type"`
+	OrderTime     int64   `json:"orderTime"`
+	Size          float64 `json:"size,string"`
+	FilledSize    float64 `json:"filledSize,string"`
+	Price         float64 `json:"price,string"`
+	ClientOrderID string  `json:"clientOid"`
+	RemainSize    float64 `json:"remainSize,string"`
+	Status        string  `json:"status"`
+	Timestamp     int64   `json:"ts"`
+}
+
+// WsBalance holds a private balance update
+type WsBalance struct {
+	Total           float64 `json:"total,string"`
+	Available       float64 `json:"available,string"`
+	AvailableChange float64 `json:"availableChange,string"`
+	Currency        string  `json:"currency"`
+	Hold            float64 `json:"hold,string"`
+	HoldChange      float64 `json:"holdChange,string"`
+	RelationEvent   string  `json:"relationEvent"`
+	Time            int64   `json:"time,string"`
+}
+
+// ServiceStatus holds the service status of the exchange, one of open, close
+// or cancelonly
+type ServiceStatus struct {
+	Status  string `json:"status"`
+	Message string `json:"msg"`
+}
@@ -0,0 +1,480 @@
+package kucoin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/assets"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+const (
+	// kucoinWebsocketURL is a placeholder, the endpoint to connect to is
+	// assigned by the bullet token request
+	kucoinWebsocketURL = "wss://ws-api-spot.kucoin.com/"
+
+	// Public topics
+	kucoinWsTicker = "/market/ticker"
+	kucoinWsMatch  = "/market/match"
+	kucoinWsDepth  = "/spotMarket/level2Depth50"
+
+	// Private topics
+	kucoinWsTradeOrders = "/spotMarket/tradeOrders"
+	kucoinWsBalance     = "/account/balance"
+
+	// Message types
+	kucoinWsWelcome     = "welcome"
+	kucoinWsSubscribe   = "subscribe"
+	kucoinWsUnsubscribe = "unsubscribe"
+	kucoinWsPing        = "ping"
+	kucoinWsPong        = "pong"
+	kucoinWsAck         = "ack"
+	kucoinWsMessage     = "message"
+	kucoinWsError       = "error"
+
+	kucoinWsWelcomeTimeout = time.Second * 10
+	kucoinWsDefaultPing    = time.Second * 18
+	// KuCoin limits a single subscription to 100 topics
+	kucoinWsMaxTopics = 100
+)
+
+// GetWsToken requests a websocket token and server list. A private token is
+// returned when authenticated, this allows subscribing to both public and
+// private topics on the same connection.
+func (k *KuCoin) GetWsToken(private bool) (WsToken, error) {
+	var resp WsToken
+	if private {
+		return resp, k.SendAuthenticatedHTTPRequest("POST", kucoinBulletPrivate, nil, nil, &resp)
+	}
+	return resp, k.sendPayload(context.Background(), "POST", k.APIUrl+kucoinBulletPublic, nil, nil, false, &resp)
+}
+
+// WsConnect requests a connection token then initiates a websocket connection
+func (k *KuCoin) WsConnect() error {
+	if !k.Websocket.IsEnabled() || !k.IsEnabled() {
+		return errors.New(exchange.WebsocketNotEnabled)
+	}
+
+	token, err := k.GetWsToken(k.AuthenticatedAPISupport)
+	if err != nil {
+		return fmt.Errorf("%s unable to obtain websocket token. Error: %s",
+			k.Name,
+			err)
+	}
+	if len(token.InstanceServers) == 0 {
+		return fmt.Errorf("%s no websocket servers returned", k.Name)
+	}
+	server := token.InstanceServers[0]
+
+	var dialer websocket.Dialer
+	dialer.TLSClientConfig = k.GetTLSConfig()
+	dialer.NetDial = k.GetWebsocketNetDial()
+	if k.Websocket.GetProxyAddress() != "" {
+		proxy, err := url.Parse(k.Websocket.GetProxyAddress())
+		if err != nil {
+			return err
+		}
+		dialer.Proxy = http.ProxyURL(proxy)
+	}
+
+	params := url.Values{}
+	params.Set("token", token.Token)
+	params.Set("connectId", strconv.FormatInt(time.Now().UnixNano(), 10))
+	k.WebsocketConn, _, err = dialer.Dial(common.EncodeURLValues(server.Endpoint, params),
+		http.Header{})
+	if err != nil {
+		return fmt.Errorf("%s unable to connect to websocket. Error: %s",
+			k.Name,
+			err)
+	}
+
+	err = k.wsAwaitWelcome()
+	if err != nil {
+		k.WebsocketConn.Close()
+		return err
+	}
+
+	pingInterval := kucoinWsDefaultPing
+	if server.PingInterval > 0 {
+		pingInterval = time.Duration(server.PingInterval) * time.Millisecond
+	}
+
+	go k.WsReadData()
+	go k.wsPingHandler(pingInterval)
+	go k.WsHandleData()
+
+	err = k.wsSubscribePrivate()
+	if err != nil {
+		return fmt.Errorf("%s could not subscribe to websocket topics. Error: %s",
+			k.Name,
+			err)
+	}
+	return nil
+}
+
+// wsAwaitWelcome waits for the welcome message which confirms the token was
+// accepted
+func (k *KuCoin) wsAwaitWelcome() error {
+	err := k.WebsocketConn.SetReadDeadline(time.Now().Add(kucoinWsWelcomeTimeout))
+	if err != nil {
+		return err
+	}
+	defer k.WebsocketConn.SetReadDeadline(time.Time{})
+
+	_, raw, err := k.WebsocketConn.ReadMessage()
+	if err != nil {
+		return fmt.Errorf("%s websocket welcome error: %s", k.Name, err)
+	}
+
+	var resp WsResponse
+	err = common.JSONDecode(raw, &resp)
+	if err != nil {
+		return err
+	}
+	if resp.Type != kucoinWsWelcome {
+		return fmt.Errorf("%s websocket unexpected first message type %s",
+			k.Name,
+			resp.Type)
+	}
+	return nil
+}
+
+// wsNextID returns a unique request ID
+func (k *KuCoin) wsNextID() string {
+	return strconv.FormatInt(atomic.AddInt64(&k.wsRequestID, 1), 10)
+}
+
+// wsWrite sends a JSON message over the websocket connection
+func (k *KuCoin) wsWrite(data interface{}) error {
+	if k.WebsocketConn == nil {
+		return errors.New("websocket connection not established")
+	}
+
+	payload, err := common.JSONEncode(data)
+	if err != nil {
+		return err
+	}
+
+	k.wsWriteLock.Lock()
+	defer k.wsWriteLock.Unlock()
+	return k.WebsocketConn.WriteMessage(websocket.TextMessage, payload)
+}
+
+// wsDefaultSubscriptions returns the ticker, match and depth topics of all
+// enabled pairs
+func (k *KuCoin) wsDefaultSubscriptions() []exchange.ChannelSubscription {
+	var subs []exchange.ChannelSubscription
+	for _, topic := range []string{kucoinWsTicker, kucoinWsMatch, kucoinWsDepth} {
+		for _, p := range k.GetEnabledCurrencies() {
+			subs = append(subs, exchange.ChannelSubscription{
+				Channel:   topic,
+				Currency:  p,
+				AssetType: assets.Spot,
+			})
+		}
+	}
+	return subs
+}
+
+// WsSubscribe subscribes to public topics
+func (k *KuCoin) WsSubscribe(subs []exchange.ChannelSubscription) error {
+	return k.wsWriteSubscriptions(kucoinWsSubscribe, subs)
+}
+
+// WsUnsubscribe unsubscribes from public topics
+func (k *KuCoin) WsUnsubscribe(subs []exchange.ChannelSubscription) error {
+	return k.wsWriteSubscriptions(kucoinWsUnsubscribe, subs)
+}
+
+// wsWriteSubscriptions sends subscribe or unsubscribe requests for public
+// topics, joining the symbols of each topic up to the per request limit
+func (k *KuCoin) wsWriteSubscriptions(operation string, subs []exchange.ChannelSubscription) error {
+	var topics []string
+	symbols := make(map[string][]string)
+	for i := range subs {
+		if _, ok := symbols[subs[i].Channel]; !ok {
+			topics = append(topics, subs[i].Channel)
+			symbols[subs[i].Channel] = nil
+		}
+		if !subs[i].Currency.Empty() {
+			symbols[subs[i].Channel] = append(symbols[subs[i].Channel],
+				exchange.FormatExchangeCurrency(k.Name, subs[i].Currency).String())
+		}
+	}
+
+	for _, topic := range topics {
+		s := symbols[topic]
+		if len(s) == 0 {
+			err := k.wsWrite(WsRequest{
+				ID:       k.wsNextID(),
+				Type:     operation,
+				Topic:    topic,
+				Response: true,
+			})
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		for i := 0; i < len(s); i += kucoinWsMaxTopics {
+			end := i + kucoinWsMaxTopics
+			if end > len(s) {
+				end = len(s)
+			}
+
+			err := k.wsWrite(WsRequest{
+				ID:       k.wsNextID(),
+				Type:     operation,
+				Topic:    topic + ":" + strings.Join(s[i:end], ","),
+				Response: true,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// wsSubscribePrivate subscribes to private order and balance topics when
+// authenticated
+func (k *KuCoin) wsSubscribePrivate() error {
+	if !k.AuthenticatedAPISupport {
+		return nil
+	}
+
+	for _, topic := range []string{kucoinWsTradeOrders, kucoinWsBalance} {
+		err := k.wsWrite(WsRequest{
+			ID:             k.wsNextID(),
+			Type:           kucoinWsSubscribe,
+			Topic:          topic,
+			PrivateChannel: true,
+			Response:       true,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WsReadData reads data from the websocket connection
+func (k *KuCoin) WsReadData() {
+	k.Websocket.Wg.Add(1)
+
+	defer func() {
+		err := k.WebsocketConn.Close()
+		if err != nil {
+			k.Websocket.DataHandler <- fmt.Errorf("kucoin_websocket.go - Unable to to close Websocket connection. Error: %s",
+				err)
+		}
+		k.Websocket.Wg.Done()
+	}()
+
+	for {
+		select {
+		case <-k.Websocket.ShutdownC:
+			return
+
+		default:
+			_, resp, err := k.WebsocketConn.ReadMessage()
+			if err != nil {
+				k.Websocket.DataHandler <- err
+				return
+			}
+
+			k.Websocket.TrafficAlert <- struct{}{}
+			k.Websocket.Intercomm <- exchange.WebsocketResponse{Raw: resp}
+		}
+	}
+}
+
+// wsPingHandler sends pings at the interval requested by the server
+func (k *KuCoin) wsPingHandler(interval time.Duration) {
+	k.Websocket.Wg.Add(1)
+	defer k.Websocket.Wg.Done()
+
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-k.Websocket.ShutdownC:
+			return
+
+		case <-t.C:
+			err := k.wsWrite(WsRequest{ID: k.wsNextID(), Type: kucoinWsPing})
+			if err != nil {
+				k.Websocket.DataHandler <- err
+				return
+			}
+		}
+	}
+}
+
+// WsHandleData handles the read data from the websocket connection
+func (k *KuCoin) WsHandleData() {
+	k.Websocket.Wg.Add(1)
+	defer k.Websocket.Wg.Done()
+
+	for {
+		select {
+		case <-k.Websocket.ShutdownC:
+			return
+
+		case resp := <-k.Websocket.Intercomm:
+			err := k.wsHandleMessage(resp.Raw)
+			if err != nil {
+				k.Websocket.DataHandler <- fmt.Sprintf("%s websocket handling error: %s",
+					k.Name,
+					err)
+			}
+		}
+	}
+}
+
+// wsHandleMessage routes a single websocket message
+func (k *KuCoin) wsHandleMessage(raw []byte) error {
+	var resp WsResponse
+	err := common.JSONDecode(raw, &resp)
+	if err != nil {
+		return err
+	}
+
+	switch resp.Type {
+	case kucoinWsPong, kucoinWsAck, kucoinWsWelcome:
+		return nil
+	case kucoinWsError:
+		return fmt.Errorf("code %s: %s", resp.Code, resp.Data)
+	case kucoinWsMessage:
+	default:
+		return nil
+	}
+
+	topic := resp.Topic
+	var symbol string
+	if i := strings.Index(topic, ":"); i != -1 {
+		topic, symbol = resp.Topic[:i], resp.Topic[i+1:]
+	}
+
+	switch topic {
+	case kucoinWsTicker:
+		return k.wsProcessTicker(symbol, resp.Data)
+	case kucoinWsMatch:
+		return k.wsProcessMatch(resp.Data)
+	case kucoinWsDepth:
+		return k.wsProcessDepth(symbol, resp.Data)
+	case kucoinWsTradeOrders:
+		var order WsOrderChange
+		err = common.JSONDecode(resp.Data, &order)
+		if err != nil {
+			return err
+		}
+		k.Websocket.DataHandler <- order
+	case kucoinWsBalance:
+		var balance WsBalance
+		err = common.JSONDecode(resp.Data, &balance)
+		if err != nil {
+			return err
+		}
+		k.Websocket.DataHandler <- balance
+	}
+	return nil
+}
+
+// wsProcessTicker sends a best bid and ask update to the data handler
+func (k *KuCoin) wsProcessTicker(symbol string, data []byte) error {
+	var t WsTicker
+	err := common.JSONDecode(data, &t)
+	if err != nil {
+		return err
+	}
+
+	k.Websocket.DataHandler <- exchange.TickerData{
+		Timestamp:  common.UnixTimestampToUTC(t.Time),
+		Pair:       pair.NewCurrencyPairDelimiter(symbol, "-"),
+		AssetType:  ticker.Spot,
+		Exchange:   k.Name,
+		ClosePrice: t.Price,
+		Quantity:   t.Size,
+	}
+	return nil
+}
+
+// wsProcessMatch sends a trade to the data handler
+func (k *KuCoin) wsProcessMatch(data []byte) error {
+	var m WsMatch
+	err := common.JSONDecode(data, &m)
+	if err != nil {
+		return err
+	}
+
+	k.Websocket.DataHandler <- exchange.TradeData{
+		Timestamp:    common.UnixTimestampToUTC(m.Time),
+		CurrencyPair: pair.NewCurrencyPairDelimiter(m.Symbol, "-"),
+		AssetType:    ticker.Spot,
+		Exchange:     k.Name,
+		Price:        m.Price,
+		Amount:       m.Size,
+		Side:         m.Side,
+	}
+	return nil
+}
+
+// wsProcessDepth processes a top 50 orderbook snapshot. KuCoin pushes the
+// full snapshot each time so the local websocket orderbook cache, which is
+// built for incremental updates, is bypassed.
+func (k *KuCoin) wsProcessDepth(symbol string, data []byte) error {
+	var depth WsDepth
+	err := common.JSONDecode(data, &depth)
+	if err != nil {
+		return err
+	}
+
+	bids, err := parseOrderbookLevels(depth.Bids)
+	if err != nil {
+		return err
+	}
+	asks, err := parseOrderbookLevels(depth.Asks)
+	if err != nil {
+		return err
+	}
+
+	var newOrderbook orderbook.Base
+	for i := range bids {
+		newOrderbook.Bids = append(newOrderbook.Bids,
+			orderbook.Item{Price: bids[i].Price, Amount: bids[i].Amount})
+	}
+	for i := range asks {
+		newOrderbook.Asks = append(newOrderbook.Asks,
+			orderbook.Item{Price: asks[i].Price, Amount: asks[i].Amount})
+	}
+
+	p := pair.NewCurrencyPairDelimiter(symbol, "-")
+	newOrderbook.Pair = p
+	newOrderbook.CurrencyPair = symbol
+	newOrderbook.AssetType = ticker.Spot
+	newOrderbook.LastUpdated = common.UnixTimestampToUTC(depth.Timestamp)
+
+	orderbook.ProcessOrderbook(k.Name, p, newOrderbook, ticker.Spot)
+
+	k.Websocket.DataHandler <- exchange.WebsocketOrderbookUpdate{
+		Pair:     p,
+		Asset:    ticker.Spot,
+		Exchange: k.Name,
+	}
+	return nil
+}
@@ -0,0 +1,494 @@
+package kucoin
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+// Start starts the KuCoin wrapper, refreshing its currency pairs
+func (k *KuCoin) Start(ctx context.Context) error {
+	return k.StartWrapper(ctx, k.Run)
+}
+
+// Run implements the KuCoin wrapper
+func (k *KuCoin) Run(ctx context.Context) error {
+	if k.Verbose {
+		log.Printf("%s Websocket: %s. (url: %s).\n", k.GetName(), common.IsEnabled(k.Websocket.IsEnabled()), k.Websocket.GetWebsocketURL())
+		log.Printf("%s polling delay: %ds.\n", k.GetName(), k.RESTPollingDelay)
+		log.Printf("%s %d currencies enabled: %s.\n", k.GetName(), len(k.EnabledPairs), k.EnabledPairs)
+	}
+
+	symbols, err := k.GetSymbols("")
+	if err != nil {
+		log.Printf("%s failed to obtain available symbols. Err: %s", k.Name, err)
+		return nil
+	}
+
+	var pairs []string
+	for i := range symbols {
+		if !symbols[i].EnableTrading {
+			continue
+		}
+		pairs = append(pairs, symbols[i].Symbol)
+		k.SetPairIncrements(pair.NewCurrencyPair(symbols[i].BaseCurrency, symbols[i].QuoteCurrency),
+			symbols[i].PriceIncrement, symbols[i].BaseIncrement)
+	}
+
+	err = k.UpdateCurrencies(pairs, false, false)
+	if err != nil {
+		log.Printf("%s failed to update available currencies. Err: %s", k.Name, err)
+	}
+	return nil
+}
+
+// UpdateTicker updates and returns the ticker for a currency pair
+func (k *KuCoin) UpdateTicker(p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	return k.UpdateTickerContext(context.Background(), p, assetType)
+}
+
+// UpdateTickerContext updates and returns the ticker for a currency pair,
+// cancelled with the context
+func (k *KuCoin) UpdateTickerContext(ctx context.Context, p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	var tickerPrice ticker.Price
+	tickers, err := k.GetTickersContext(ctx)
+	if err != nil {
+		return tickerPrice, err
+	}
+
+	tickerMap := make(map[string]*Ticker, len(tickers.Tickers))
+	for i := range tickers.Tickers {
+		tickerMap[tickers.Tickers[i].Symbol] = &tickers.Tickers[i]
+	}
+
+	for _, x := range k.GetEnabledCurrencies() {
+		t, ok := tickerMap[exchange.FormatExchangeCurrency(k.Name, x).String()]
+		if !ok {
+			continue
+		}
+		ticker.ProcessTicker(k.GetName(), x, ticker.Price{
+			Pair:        x,
+			Last:        t.Last,
+			High:        t.High,
+			Low:         t.Low,
+			Bid:         t.Buy,
+			Ask:         t.Sell,
+			Volume:      t.Volume,
+			LastUpdated: common.UnixTimestampToUTC(tickers.Time),
+		}, assetType)
+	}
+	return ticker.GetTicker(k.Name, p, assetType)
+}
+
+// GetTickerPrice returns the ticker for a currency pair
+func (k *KuCoin) GetTickerPrice(p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	tickerNew, err := ticker.GetTicker(k.GetName(), p, assetType)
+	if err != nil {
+		return k.UpdateTicker(p, assetType)
+	}
+	return tickerNew, nil
+}
+
+// GetOrderbookEx returns orderbook base on the currency pair
+func (k *KuCoin) GetOrderbookEx(p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	ob, err := orderbook.GetOrderbook(k.GetName(), p, assetType)
+	if err != nil {
+		return k.UpdateOrderbook(p, assetType)
+	}
+	return ob, nil
+}
+
+// UpdateOrderbook updates and returns the orderbook for a currency pair
+func (k *KuCoin) UpdateOrderbook(p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	return k.UpdateOrderbookContext(context.Background(), p, assetType)
+}
+
+// UpdateOrderbookContext updates and returns the orderbook for a currency
+// pair, cancelled with the context
+func (k *KuCoin) UpdateOrderbookContext(ctx context.Context, p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	var orderBook orderbook.Base
+	orderbookNew, err := k.GetOrderbookContext(ctx, exchange.FormatExchangeCurrency(k.Name, p).String())
+	if err != nil {
+		return orderBook, err
+	}
+
+	for x := range orderbookNew.Bids {
+		orderBook.Bids = append(orderBook.Bids, orderbook.Item{
+			Amount: orderbookNew.Bids[x].Amount,
+			Price:  orderbookNew.Bids[x].Price,
+		})
+	}
+
+	for x := range orderbookNew.Asks {
+		orderBook.Asks = append(orderBook.Asks, orderbook.Item{
+			Amount: orderbookNew.Asks[x].Amount,
+			Price:  orderbookNew.Asks[x].Price,
+		})
+	}
+
+	orderbook.ProcessOrderbook(k.GetName(), p, orderBook, assetType)
+	return orderbook.GetOrderbook(k.Name, p, assetType)
+}
+
+// GetAccountInfo retrieves balances for all currencies, balances held in the
+// main and trade accounts are combined
+func (k *KuCoin) GetAccountInfo() (exchange.AccountInfo, error) {
+	return k.GetAccountInfoContext(context.Background())
+}
+
+// GetAccountInfoContext retrieves balances for all currencies, cancelled with
+// the context
+func (k *KuCoin) GetAccountInfoContext(ctx context.Context) (exchange.AccountInfo, error) {
+	var info exchange.AccountInfo
+	accounts, err := k.GetAccountsContext(ctx, "", "")
+	if err != nil {
+		return info, err
+	}
+
+	info.ExchangeName = k.GetName()
+	info.Currencies = accountBalances(accounts)
+	return info, nil
+}
+
+// accountBalances combines the main and trade account balances by currency.
+// Margin accounts are excluded as their balances include borrowed funds.
+func accountBalances(accounts []Account) []exchange.AccountCurrencyInfo {
+	var currencies []exchange.AccountCurrencyInfo
+	index := make(map[string]int)
+	for i := range accounts {
+		if accounts[i].Type != AccountTypeMain && accounts[i].Type != AccountTypeTrade {
+			continue
+		}
+		currency := common.StringToUpper(accounts[i].Currency)
+		x, ok := index[currency]
+		if !ok {
+			x = len(currencies)
+			index[currency] = x
+			currencies = append(currencies, exchange.AccountCurrencyInfo{CurrencyName: currency})
+		}
+		currencies[x].TotalValue += accounts[i].Balance
+		currencies[x].Hold += accounts[i].Holds
+	}
+	return currencies
+}
+
+// GetFundingHistory returns funding history, deposits and
+// withdrawals
+func (k *KuCoin) GetFundingHistory() ([]exchange.FundHistory, error) {
+	var fundHistory []exchange.FundHistory
+	deposits, err := k.GetDeposits("", 1)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range deposits.Items {
+		fundHistory = append(fundHistory, exchange.FundHistory{
+			ExchangeName:    k.Name,
+			Status:          deposits.Items[i].Status,
+			Timestamp:       common.UnixTimestampToUTC(deposits.Items[i].CreatedAt),
+			Currency:        deposits.Items[i].Currency,
+			Amount:          deposits.Items[i].Amount,
+			Fee:             deposits.Items[i].Fee,
+			TransferType:    "deposit",
+			CryptoToAddress: deposits.Items[i].Address,
+			CryptoTxID:      deposits.Items[i].WalletTxID,
+		})
+	}
+
+	withdrawals, err := k.GetWithdrawals("", 1)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range withdrawals.Items {
+		fundHistory = append(fundHistory, exchange.FundHistory{
+			ExchangeName:    k.Name,
+			Status:          withdrawals.Items[i].Status,
+			Timestamp:       common.UnixTimestampToUTC(withdrawals.Items[i].CreatedAt),
+			Currency:        withdrawals.Items[i].Currency,
+			Amount:          withdrawals.Items[i].Amount,
+			Fee:             withdrawals.Items[i].Fee,
+			TransferType:    "withdrawal",
+			CryptoToAddress: withdrawals.Items[i].Address,
+			CryptoTxID:      withdrawals.Items[i].WalletTxID,
+		})
+	}
+	return fundHistory, nil
+}
+
+// GetExchangeHistory returns the most recent public trades
+func (k *KuCoin) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]exchange.TradeHistory, error) {
+	trades, err := k.GetTrades(exchange.FormatExchangeCurrency(k.Name, p).String())
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]exchange.TradeHistory, len(trades))
+	for i := range trades {
+		tid, _ := strconv.ParseInt(trades[i].Sequence, 10, 64)
+		resp[i] = exchange.TradeHistory{
+			Timestamp: common.UnixTimestampToUTC(trades[i].Time),
+			TID:       tid,
+			Price:     trades[i].Price,
+			Amount:    trades[i].Size,
+			Exchange:  k.Name,
+			Type:      trades[i].Side,
+		}
+	}
+	return resp, nil
+}
+
+// buildOrder converts order parameters into a spot order request, a client
+// order ID is generated when one is not supplied
+func (k *KuCoin) buildOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (OrderRequest, error) {
+	clientID = k.BrokerClientOrderID(clientID)
+	if clientID == "" {
+		clientID = strconv.FormatInt(time.Now().UnixNano(), 10)
+	}
+
+	price, amount = k.RoundOrder(p, side, price, amount)
+	req := OrderRequest{
+		ClientOrderID: clientID,
+		Symbol:        exchange.FormatExchangeCurrency(k.Name, p).String(),
+		TradeType:     "TRADE",
+		Size:          strconv.FormatFloat(amount, 'f', -1, 64),
+	}
+
+	switch side {
+	case exchange.Buy:
+		req.Side = "buy"
+	case exchange.Sell:
+		req.Side = "sell"
+	default:
+		return req, fmt.Errorf("unsupported order side %s", side)
+	}
+
+	switch orderType {
+	case exchange.Limit:
+		req.Type = "limit"
+		req.Price = strconv.FormatFloat(price, 'f', -1, 64)
+	case exchange.Market:
+		req.Type = "market"
+	case exchange.ImmediateOrCancel:
+		req.Type = "limit"
+		req.Price = strconv.FormatFloat(price, 'f', -1, 64)
+		req.TimeInForce = "IOC"
+	default:
+		return req, fmt.Errorf("unsupported order type %s", orderType)
+	}
+	return req, nil
+}
+
+// SubmitOrder submits a new spot order
+func (k *KuCoin) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	return k.SubmitOrderContext(context.Background(), p, side, orderType, amount, price, clientID)
+}
+
+// SubmitOrderContext submits a new spot order, cancelled with the context
+func (k *KuCoin) SubmitOrderContext(ctx context.Context, p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	if k.IsSimulated() {
+		return k.SimulateSubmitOrder(p, side, orderType, amount, price, clientID)
+	}
+
+	var submitOrderResponse exchange.SubmitOrderResponse
+	req, err := k.buildOrder(p, side, orderType, amount, price, clientID)
+	if err != nil {
+		return submitOrderResponse, err
+	}
+
+	orderID, err := k.PlaceOrderContext(ctx, req)
+	if err != nil {
+		return submitOrderResponse, err
+	}
+
+	submitOrderResponse.OrderID = orderID
+	submitOrderResponse.IsOrderPlaced = true
+	return submitOrderResponse, nil
+}
+
+// SubmitQuoteAmountOrder submits a market order sized by the quote currency
+// funds to spend or receive
+func (k *KuCoin) SubmitQuoteAmountOrder(p pair.CurrencyPair, side exchange.OrderSide, quoteAmount float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	if k.IsSimulated() {
+		return k.SimulateSubmitQuoteAmountOrder(p, side, quoteAmount, clientID)
+	}
+
+	var submitOrderResponse exchange.SubmitOrderResponse
+	req, err := k.buildOrder(p, side, exchange.Market, 0, 0, clientID)
+	if err != nil {
+		return submitOrderResponse, err
+	}
+	req.Size = ""
+	req.Funds = strconv.FormatFloat(quoteAmount, 'f', -1, 64)
+
+	orderID, err := k.PlaceOrder(req)
+	if err != nil {
+		return submitOrderResponse, err
+	}
+
+	submitOrderResponse.OrderID = orderID
+	submitOrderResponse.IsOrderPlaced = true
+	return submitOrderResponse, nil
+}
+
+// SubmitIcebergOrder submits a limit order showing only visibleAmount on the
+// book
+func (k *KuCoin) SubmitIcebergOrder(p pair.CurrencyPair, side exchange.OrderSide, amount, visibleAmount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	if k.IsSimulated() {
+		return k.SimulateSubmitOrder(p, side, exchange.Limit, amount, price, clientID)
+	}
+
+	var submitOrderResponse exchange.SubmitOrderResponse
+	req, err := k.buildOrder(p, side, exchange.Limit, amount, price, clientID)
+	if err != nil {
+		return submitOrderResponse, err
+	}
+	req.Iceberg = true
+	req.VisibleSize = strconv.FormatFloat(visibleAmount, 'f', -1, 64)
+
+	orderID, err := k.PlaceOrder(req)
+	if err != nil {
+		return submitOrderResponse, err
+	}
+
+	submitOrderResponse.OrderID = orderID
+	submitOrderResponse.IsOrderPlaced = true
+	return submitOrderResponse, nil
+}
+
+// ModifyOrder will allow of changing orderbook placement and limit to
+// market conversion
+func (k *KuCoin) ModifyOrder(action exchange.ModifyOrder) (string, error) {
+	if k.IsSimulated() {
+		return k.SimulateModifyOrder(action)
+	}
+
+	return "", common.ErrFunctionNotSupported
+}
+
+// CancelOrder cancels an order by its corresponding ID number
+func (k *KuCoin) CancelOrder(order exchange.OrderCancellation) error {
+	return k.CancelOrderContext(context.Background(), order)
+}
+
+// CancelOrderContext cancels an order by its corresponding ID number,
+// cancelled with the context
+func (k *KuCoin) CancelOrderContext(ctx context.Context, order exchange.OrderCancellation) error {
+	if k.IsSimulated() {
+		return k.SimulateCancelOrder(order)
+	}
+
+	_, err := k.CancelExistingOrderContext(ctx, order.OrderID)
+	return err
+}
+
+// CancelAllOrders cancels all spot orders for all enabled currencies, or for
+// the currency pair of the order cancellation when it is set
+func (k *KuCoin) CancelAllOrders(orderCancellation exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
+	if k.IsSimulated() {
+		return k.SimulateCancelAllOrders(orderCancellation)
+	}
+
+	cancelAllOrdersResponse := exchange.CancelAllOrdersResponse{
+		OrderStatus: make(map[string]string),
+	}
+
+	if !orderCancellation.CurrencyPair.Empty() {
+		symbol := exchange.FormatExchangeCurrency(k.Name, orderCancellation.CurrencyPair).String()
+		_, err := k.CancelOrders(symbol, "TRADE")
+		return cancelAllOrdersResponse, err
+	}
+
+	for _, p := range k.GetEnabledCurrencies() {
+		symbol := exchange.FormatExchangeCurrency(k.Name, p).String()
+		_, err := k.CancelOrders(symbol, "TRADE")
+		if err != nil {
+			cancelAllOrdersResponse.OrderStatus[symbol] = err.Error()
+		}
+	}
+
+	return cancelAllOrdersResponse, nil
+}
+
+// SupportsCancelAllScope returns whether CancelAllOrders enforces the scope,
+// KuCoin open orders can only be cancelled by currency pair
+func (k *KuCoin) SupportsCancelAllScope(scope exchange.CancelAllScope) bool {
+	return scope.Side == "" && scope.OrderType == "" && scope.MinAge == 0
+}
+
+// GetOrderInfo returns information on a current open order. KuCoin order IDs
+// are not numeric, use GetOrder to look up an order by its ID.
+func (k *KuCoin) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
+	if k.IsSimulated() {
+		return k.SimulateGetOrderInfo(orderID)
+	}
+
+	var orderDetail exchange.OrderDetail
+	return orderDetail, common.ErrFunctionNotSupported
+}
+
+// GetDepositAddress returns a deposit address for a specified currency
+func (k *KuCoin) GetDepositAddress(cryptocurrency pair.CurrencyItem) (string, error) {
+	address, err := k.GetCurrencyDepositAddress(cryptocurrency.String(), "")
+	if err != nil {
+		return "", err
+	}
+	return address.Address, nil
+}
+
+// WithdrawCryptocurrencyFunds returns a withdrawal ID when a withdrawal is
+// submitted. Funds are withdrawn from the main account.
+func (k *KuCoin) WithdrawCryptocurrencyFunds(address string, cryptocurrency pair.CurrencyItem, amount float64) (string, error) {
+	return k.Withdraw(WithdrawalRequest{
+		Currency: cryptocurrency.Upper().String(),
+		Address:  address,
+		Amount:   strconv.FormatFloat(amount, 'f', -1, 64),
+	})
+}
+
+// WithdrawFiatFunds returns a withdrawal ID when a
+// withdrawal is submitted
+func (k *KuCoin) WithdrawFiatFunds(currency pair.CurrencyItem, amount float64) (string, error) {
+	return "", common.ErrFunctionNotSupported
+}
+
+// WithdrawFiatFundsToInternationalBank returns a withdrawal ID when a
+// withdrawal is submitted
+func (k *KuCoin) WithdrawFiatFundsToInternationalBank(currency pair.CurrencyItem, amount float64) (string, error) {
+	return "", common.ErrFunctionNotSupported
+}
+
+// GetWebsocket returns a pointer to the exchange websocket
+func (k *KuCoin) GetWebsocket() (*exchange.Websocket, error) {
+	return k.Websocket, nil
+}
+
+// GetFeeByType returns an estimate of fee based on type of transaction
+func (k *KuCoin) GetFeeByType(feeBuilder exchange.FeeBuilder) (float64, error) {
+	return k.GetFee(feeBuilder)
+}
+
+// GetWithdrawCapabilities returns the types of withdrawal methods permitted by the exchange
+func (k *KuCoin) GetWithdrawCapabilities() uint32 {
+	return k.GetWithdrawPermissions()
+}
+
+// CheckSystemStatus returns an error unless the exchange's service is open
+func (k *KuCoin) CheckSystemStatus() error {
+	status, err := k.GetServiceStatus()
+	if err != nil {
+		return err
+	}
+	if status.Status != "open" {
+		return fmt.Errorf("service status %s %s", status.Status, status.Message)
+	}
+	return nil
+}
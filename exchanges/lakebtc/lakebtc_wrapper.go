@@ -1,10 +1,10 @@
 package lakebtc
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
-	"sync"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
@@ -13,17 +13,13 @@ import (
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
 
-// Start starts the LakeBTC go routine
-func (l *LakeBTC) Start(wg *sync.WaitGroup) {
-	wg.Add(1)
-	go func() {
-		l.Run()
-		wg.Done()
-	}()
+// Start starts the LakeBTC wrapper, refreshing its currency pairs
+func (l *LakeBTC) Start(ctx context.Context) error {
+	return l.StartWrapper(ctx, l.Run)
 }
 
 // Run implements the LakeBTC wrapper
-func (l *LakeBTC) Run() {
+func (l *LakeBTC) Run(ctx context.Context) error {
 	if l.Verbose {
 		log.Printf("%s polling delay: %ds.\n", l.GetName(), l.RESTPollingDelay)
 		log.Printf("%s %d currencies enabled: %s.\n", l.GetName(), len(l.EnabledPairs), l.EnabledPairs)
@@ -38,6 +34,7 @@ func (l *LakeBTC) Run() {
 			log.Printf("%s Failed to update available currencies.\n", l.GetName())
 		}
 	}
+	return nil
 }
 
 // UpdateTicker updates and returns the ticker for a currency pair
@@ -140,6 +137,10 @@ func (l *LakeBTC) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]e
 
 // SubmitOrder submits a new order
 func (l *LakeBTC) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	if l.IsSimulated() {
+		return l.SimulateSubmitOrder(p, side, orderType, amount, price, clientID)
+	}
+
 	var submitOrderResponse exchange.SubmitOrderResponse
 	isBuyOrder := side == exchange.Buy
 	response, err := l.Trade(isBuyOrder, amount, price, common.StringToLower(p.Pair().String()))
@@ -158,11 +159,19 @@ func (l *LakeBTC) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orde
 // ModifyOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (l *LakeBTC) ModifyOrder(action exchange.ModifyOrder) (string, error) {
+	if l.IsSimulated() {
+		return l.SimulateModifyOrder(action)
+	}
+
 	return "", common.ErrFunctionNotSupported
 }
 
 // CancelOrder cancels an order by its corresponding ID number
 func (l *LakeBTC) CancelOrder(order exchange.OrderCancellation) error {
+	if l.IsSimulated() {
+		return l.SimulateCancelOrder(order)
+	}
+
 	orderIDInt, err := strconv.ParseInt(order.OrderID, 10, 64)
 
 	if err != nil {
@@ -174,6 +183,10 @@ func (l *LakeBTC) CancelOrder(order exchange.OrderCancellation) error {
 
 // CancelAllOrders cancels all orders associated with a currency pair
 func (l *LakeBTC) CancelAllOrders(orderCancellation exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
+	if l.IsSimulated() {
+		return l.SimulateCancelAllOrders(orderCancellation)
+	}
+
 	cancelAllOrdersResponse := exchange.CancelAllOrdersResponse{
 		OrderStatus: make(map[string]string),
 	}
@@ -194,6 +207,10 @@ func (l *LakeBTC) CancelAllOrders(orderCancellation exchange.OrderCancellation)
 
 // GetOrderInfo returns information on a current open order
 func (l *LakeBTC) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
+	if l.IsSimulated() {
+		return l.SimulateGetOrderInfo(orderID)
+	}
+
 	var orderDetail exchange.OrderDetail
 	return orderDetail, common.ErrNotYetImplemented
 }
@@ -0,0 +1,332 @@
+// Package latency measures the latency of each stage of an order's lifecycle,
+// from engine submission to the first fill, and keeps per exchange latency
+// distributions for tuning execution strategies
+package latency
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Default tracker values
+const (
+	DefaultMaxSamples      = 1000
+	DefaultMaxAwaitingFill = 10000
+)
+
+// Segment is the interval between two stages of an order's lifecycle
+type Segment string
+
+// Measured segments. Submit is when the engine accepts the order, Sent when
+// the request is handed to the HTTP client after rate limiting, Ack when the
+// exchange responds with the order ID and Fill when the first fill is
+// received.
+const (
+	SubmitToSent Segment = "submit_to_sent"
+	SentToAck    Segment = "sent_to_ack"
+	SubmitToAck  Segment = "submit_to_ack"
+	AckToFill    Segment = "ack_to_fill"
+	SubmitToFill Segment = "submit_to_fill"
+)
+
+// Segments lists every measured segment in lifecycle order
+var Segments = []Segment{SubmitToSent, SentToAck, SubmitToAck, AckToFill, SubmitToFill}
+
+// Order holds the stage timestamps of a single order, zero times are stages
+// which have not been reached or could not be measured
+type Order struct {
+	Exchange  string
+	ClientID  string
+	OrderID   string
+	Submitted time.Time
+	Sent      time.Time
+	Acked     time.Time
+	Filled    time.Time
+}
+
+// Distribution summarises the latency samples of a segment
+type Distribution struct {
+	Count int           `json:"count"`
+	Min   time.Duration `json:"min"`
+	Max   time.Duration `json:"max"`
+	Mean  time.Duration `json:"mean"`
+	P50   time.Duration `json:"p50"`
+	P90   time.Duration `json:"p90"`
+	P99   time.Duration `json:"p99"`
+}
+
+// Stats holds the latency distributions of an exchange, Orders is the total
+// number of acknowledged orders measured
+type Stats struct {
+	Exchange string                   `json:"exchange"`
+	Orders   int64                    `json:"orders"`
+	Segments map[Segment]Distribution `json:"segments"`
+}
+
+// samples is a ring of the most recent latency samples of a segment
+type samples struct {
+	values []time.Duration
+	next   int
+}
+
+// Tracker records order lifecycle timestamps and keeps the most recent
+// MaxSamples latencies of each segment per exchange. Acknowledged orders wait
+// for their first fill, the oldest are dropped once MaxAwaitingFill is
+// reached.
+type Tracker struct {
+	MaxSamples      int
+	MaxAwaitingFill int
+
+	samples  map[string]map[Segment]*samples
+	orders   map[string]int64
+	awaiting map[string]*Order
+	queue    []string
+	m        sync.Mutex
+}
+
+// Default is the tracker used by the bot engine
+var Default = NewTracker()
+
+// NewTracker returns a new latency tracker
+func NewTracker() *Tracker {
+	return &Tracker{
+		MaxSamples:      DefaultMaxSamples,
+		MaxAwaitingFill: DefaultMaxAwaitingFill,
+		samples:         make(map[string]map[Segment]*samples),
+		orders:          make(map[string]int64),
+		awaiting:        make(map[string]*Order),
+	}
+}
+
+// Start returns a new order measurement submitted now
+func Start(exchangeName, clientID string) *Order {
+	return &Order{
+		Exchange:  exchangeName,
+		ClientID:  clientID,
+		Submitted: time.Now(),
+	}
+}
+
+// MarkSent records when the order request was sent, times outside the
+// submission window are ignored as they belong to another request
+func (o *Order) MarkSent(sent time.Time) {
+	if sent.Before(o.Submitted) || (!o.Acked.IsZero() && sent.After(o.Acked)) {
+		return
+	}
+	o.Sent = sent
+}
+
+// MarkAcked records the exchange acknowledgement of the order now
+func (o *Order) MarkAcked(orderID string) {
+	o.OrderID = orderID
+	o.Acked = time.Now()
+	if o.Sent.After(o.Acked) {
+		o.Sent = time.Time{}
+	}
+}
+
+// Acknowledged records the submission latencies of an acknowledged order and
+// holds it until its first fill is received. Orders without an order ID
+// cannot be matched to fills.
+func (t *Tracker) Acknowledged(o *Order) {
+	if o == nil || o.Acked.IsZero() {
+		return
+	}
+
+	t.m.Lock()
+	defer t.m.Unlock()
+	t.orders[o.Exchange]++
+	if !o.Sent.IsZero() {
+		t.add(o.Exchange, SubmitToSent, o.Sent.Sub(o.Submitted))
+		t.add(o.Exchange, SentToAck, o.Acked.Sub(o.Sent))
+	}
+	t.add(o.Exchange, SubmitToAck, o.Acked.Sub(o.Submitted))
+
+	if o.OrderID == "" {
+		return
+	}
+	k := key(o.Exchange, o.OrderID)
+	if _, ok := t.awaiting[k]; !ok {
+		t.queue = append(t.queue, k)
+	}
+	t.awaiting[k] = o
+	for t.MaxAwaitingFill > 0 && len(t.awaiting) > t.MaxAwaitingFill {
+		delete(t.awaiting, t.queue[0])
+		t.queue = t.queue[1:]
+	}
+}
+
+// Fill records the first fill of an acknowledged order, later fills and
+// fills of unmeasured orders are ignored. It returns whether the fill was
+// measured.
+func (t *Tracker) Fill(exchangeName, orderID string, received time.Time) bool {
+	t.m.Lock()
+	defer t.m.Unlock()
+	k := key(exchangeName, orderID)
+	o, ok := t.awaiting[k]
+	if !ok {
+		return false
+	}
+	delete(t.awaiting, k)
+	for i := range t.queue {
+		if t.queue[i] == k {
+			t.queue = append(t.queue[:i], t.queue[i+1:]...)
+			break
+		}
+	}
+
+	// A fill can be received over websocket before the REST acknowledgement
+	if received.Before(o.Acked) {
+		received = o.Acked
+	}
+	o.Filled = received
+	t.add(o.Exchange, AckToFill, o.Filled.Sub(o.Acked))
+	t.add(o.Exchange, SubmitToFill, o.Filled.Sub(o.Submitted))
+	return true
+}
+
+// AwaitingFill returns the number of acknowledged orders waiting for a fill
+func (t *Tracker) AwaitingFill() int {
+	t.m.Lock()
+	defer t.m.Unlock()
+	return len(t.awaiting)
+}
+
+// Stats returns the latency distributions of every measured exchange
+func (t *Tracker) Stats() []Stats {
+	t.m.Lock()
+	defer t.m.Unlock()
+	var names []string
+	for name := range t.samples {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := make([]Stats, 0, len(names))
+	for _, name := range names {
+		s := Stats{
+			Exchange: name,
+			Orders:   t.orders[name],
+			Segments: make(map[Segment]Distribution),
+		}
+		for segment, samples := range t.samples[name] {
+			s.Segments[segment] = distribution(samples.values)
+		}
+		result = append(result, s)
+	}
+	return result
+}
+
+// ExchangeStats returns the latency distributions of an exchange
+func (t *Tracker) ExchangeStats(exchangeName string) (Stats, bool) {
+	for _, s := range t.Stats() {
+		if strings.EqualFold(s.Exchange, exchangeName) {
+			return s, true
+		}
+	}
+	return Stats{}, false
+}
+
+// WriteMetrics writes the latency distributions as Prometheus summaries in
+// the text exposition format, in seconds
+func (t *Tracker) WriteMetrics(w io.Writer) error {
+	const name = "gocryptotrader_order_latency_seconds"
+	_, err := fmt.Fprintf(w, "# HELP %s Order lifecycle latency by segment\n# TYPE %s summary\n",
+		name, name)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range t.Stats() {
+		for _, segment := range Segments {
+			d, ok := s.Segments[segment]
+			if !ok {
+				continue
+			}
+			labels := fmt.Sprintf("exchange=%q,segment=%q", s.Exchange, segment)
+			quantiles := []struct {
+				quantile string
+				value    time.Duration
+			}{{"0.5", d.P50}, {"0.9", d.P90}, {"0.99", d.P99}}
+			for _, q := range quantiles {
+				_, err = fmt.Fprintf(w, "%s{%s,quantile=%q} %g\n",
+					name, labels, q.quantile, q.value.Seconds())
+				if err != nil {
+					return err
+				}
+			}
+			_, err = fmt.Fprintf(w, "%s_sum{%s} %g\n%s_count{%s} %d\n",
+				name, labels, (d.Mean * time.Duration(d.Count)).Seconds(),
+				name, labels, d.Count)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// add records a latency sample, negative samples from clock adjustments are
+// clamped to zero
+func (t *Tracker) add(exchangeName string, segment Segment, d time.Duration) {
+	if d < 0 {
+		d = 0
+	}
+	segments, ok := t.samples[exchangeName]
+	if !ok {
+		segments = make(map[Segment]*samples)
+		t.samples[exchangeName] = segments
+	}
+	s, ok := segments[segment]
+	if !ok {
+		s = &samples{}
+		segments[segment] = s
+	}
+
+	if t.MaxSamples <= 0 || len(s.values) < t.MaxSamples {
+		s.values = append(s.values, d)
+		return
+	}
+	s.values[s.next] = d
+	s.next = (s.next + 1) % len(s.values)
+}
+
+// distribution summarises latency samples using nearest rank percentiles
+func distribution(values []time.Duration) Distribution {
+	if len(values) == 0 {
+		return Distribution{}
+	}
+	sorted := append([]time.Duration(nil), values...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var total time.Duration
+	for i := range sorted {
+		total += sorted[i]
+	}
+	percentile := func(p float64) time.Duration {
+		rank := int(math.Ceil(p/100*float64(len(sorted)))) - 1
+		if rank < 0 {
+			rank = 0
+		}
+		return sorted[rank]
+	}
+	return Distribution{
+		Count: len(sorted),
+		Min:   sorted[0],
+		Max:   sorted[len(sorted)-1],
+		Mean:  total / time.Duration(len(sorted)),
+		P50:   percentile(50),
+		P90:   percentile(90),
+		P99:   percentile(99),
+	}
+}
+
+// key returns the awaiting fill key of an exchange order
+func key(exchangeName, orderID string) string {
+	return strings.ToLower(exchangeName) + ":" + orderID
+}
@@ -0,0 +1,97 @@
+package latency
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTracker(t *testing.T) {
+	tr := NewTracker()
+	now := time.Now()
+	o := &Order{
+		Exchange:  "Bitstamp",
+		Submitted: now,
+	}
+	o.MarkSent(now.Add(-time.Second))
+	if !o.Sent.IsZero() {
+		t.Error("Test Failed - MarkSent() accepted a time before submission")
+	}
+	o.OrderID = "1337"
+	o.Acked = now.Add(50 * time.Millisecond)
+	o.MarkSent(now.Add(time.Second))
+	if !o.Sent.IsZero() {
+		t.Error("Test Failed - MarkSent() accepted a time after acknowledgement")
+	}
+	o.MarkSent(now.Add(10 * time.Millisecond))
+	tr.Acknowledged(o)
+
+	if tr.AwaitingFill() != 1 {
+		t.Error("Test Failed - Acknowledged() order not awaiting fill")
+	}
+	if tr.Fill("Bitstamp", "1", now) {
+		t.Error("Test Failed - Fill() measured an unknown order")
+	}
+	if !tr.Fill("BITSTAMP", "1337", now.Add(150*time.Millisecond)) {
+		t.Error("Test Failed - Fill() did not measure the first fill")
+	}
+	if tr.Fill("Bitstamp", "1337", now.Add(time.Second)) {
+		t.Error("Test Failed - Fill() measured a later fill")
+	}
+
+	s, ok := tr.ExchangeStats("bitstamp")
+	if !ok || s.Orders != 1 {
+		t.Fatal("Test Failed - ExchangeStats() missing exchange")
+	}
+	expected := map[Segment]time.Duration{
+		SubmitToSent: 10 * time.Millisecond,
+		SentToAck:    40 * time.Millisecond,
+		SubmitToAck:  50 * time.Millisecond,
+		AckToFill:    100 * time.Millisecond,
+		SubmitToFill: 150 * time.Millisecond,
+	}
+	for segment, d := range expected {
+		if s.Segments[segment].P50 != d || s.Segments[segment].Count != 1 {
+			t.Errorf("Test Failed - Stats() %s expected %v received %+v",
+				segment, d, s.Segments[segment])
+		}
+	}
+}
+
+func TestTrackerLimits(t *testing.T) {
+	tr := NewTracker()
+	tr.MaxSamples = 10
+	tr.MaxAwaitingFill = 5
+	now := time.Now()
+	for i := 1; i <= 20; i++ {
+		o := &Order{Exchange: "Kraken", OrderID: string(rune('a' + i)), Submitted: now}
+		o.Acked = now.Add(time.Duration(i) * time.Millisecond)
+		tr.Acknowledged(o)
+	}
+
+	if tr.AwaitingFill() != 5 {
+		t.Error("Test Failed - Acknowledged() awaiting fill not limited", tr.AwaitingFill())
+	}
+	s, _ := tr.ExchangeStats("Kraken")
+	d := s.Segments[SubmitToAck]
+	if d.Count != 10 || d.Min != 11*time.Millisecond || d.Max != 20*time.Millisecond {
+		t.Errorf("Test Failed - Stats() samples not limited %+v", d)
+	}
+	if d.P50 != 15*time.Millisecond || d.P90 != 19*time.Millisecond || d.P99 != 20*time.Millisecond {
+		t.Errorf("Test Failed - Stats() unexpected percentiles %+v", d)
+	}
+	if _, ok := s.Segments[SentToAck]; ok {
+		t.Error("Test Failed - Stats() measured an unsent segment")
+	}
+
+	var buf bytes.Buffer
+	err := tr.WriteMetrics(&buf)
+	if err != nil {
+		t.Fatal("Test Failed - WriteMetrics() error", err)
+	}
+	if !strings.Contains(buf.String(),
+		`gocryptotrader_order_latency_seconds_count{exchange="Kraken",segment="submit_to_ack"} 10`) {
+		t.Error("Test Failed - WriteMetrics() unexpected output", buf.String())
+	}
+}
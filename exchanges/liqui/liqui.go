@@ -11,6 +11,7 @@ import (
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/config"
 	"github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/assets"
 	"github.com/thrasher-/gocryptotrader/exchanges/request"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
@@ -52,6 +53,20 @@ func (l *Liqui) SetDefaults() {
 	l.RESTPollingDelay = 10
 	l.Ticker = make(map[string]Ticker)
 	l.APIWithdrawPermissions = exchange.NoAPIWithdrawalMethods
+	l.Features = exchange.Features{
+		CanGetTicker:       true,
+		CanGetOrderbook:    true,
+		CanGetAccountInfo:  true,
+		CanSubmitOrder:     true,
+		CanCancelOrder:     true,
+		CanCancelAllOrders: true,
+	}
+	l.Features.Orders = map[string]exchange.OrderCapabilities{
+		assets.Spot: {
+			OrderTypes:  []exchange.OrderType{exchange.Limit},
+			TimeInForce: []exchange.TimeInForce{exchange.GTC},
+		},
+	}
 	l.RequestCurrencyPairFormat.Delimiter = "_"
 	l.RequestCurrencyPairFormat.Uppercase = false
 	l.RequestCurrencyPairFormat.Separator = "-"
@@ -106,6 +121,27 @@ func (l *Liqui) Setup(exch config.ExchangeConfig) {
 		if err != nil {
 			log.Fatal(err)
 		}
+		err = l.SetTLSPins(exch.TLSPins)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = l.SetLocalAddress(exch.LocalAddress)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = l.SetRoundingModes(exch.RoundingModes)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = l.SetRateLimitTiers(exch.RateLimitTiers, exch.AccountTier)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = l.SetHTTPClientHeaders(exch.HTTPHeaders)
+		if err != nil {
+			log.Fatal(err)
+		}
+		l.SetSimulation(exch.Simulate, exch.SimulationBalances)
 	}
 }
 
@@ -207,8 +243,8 @@ func (l *Liqui) Trade(pair, orderType string, amount, price float64) (float64, e
 	return result.OrderID, l.SendAuthenticatedHTTPRequest(liquiTrade, req, &result)
 }
 
-// GetActiveOrders returns the list of your active orders.
-func (l *Liqui) GetActiveOrders(pair string) (map[string]ActiveOrders, error) {
+// GetOpenOrders returns the list of your active orders.
+func (l *Liqui) GetOpenOrders(pair string) (map[string]ActiveOrders, error) {
 	result := make(map[string]ActiveOrders)
 
 	req := url.Values{}
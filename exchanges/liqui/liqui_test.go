@@ -89,9 +89,9 @@ func TestAuthRequests(t *testing.T) {
 			t.Error("Test Failed - liqui Trade() error", err)
 		}
 
-		_, err = l.GetActiveOrders("eth_btc")
+		_, err = l.GetOpenOrders("eth_btc")
 		if err == nil {
-			t.Error("Test Failed - liqui GetActiveOrders() error", err)
+			t.Error("Test Failed - liqui GetOpenOrders() error", err)
 		}
 
 		_, err = l.GetOrderInfo(1337)
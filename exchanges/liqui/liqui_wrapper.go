@@ -1,10 +1,10 @@
 package liqui
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
-	"sync"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
@@ -13,17 +13,13 @@ import (
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
 
-// Start starts the Liqui go routine
-func (l *Liqui) Start(wg *sync.WaitGroup) {
-	wg.Add(1)
-	go func() {
-		l.Run()
-		wg.Done()
-	}()
+// Start starts the Liqui wrapper, refreshing its currency pairs
+func (l *Liqui) Start(ctx context.Context) error {
+	return l.StartWrapper(ctx, l.Run)
 }
 
 // Run implements the Liqui wrapper
-func (l *Liqui) Run() {
+func (l *Liqui) Run(ctx context.Context) error {
 	if l.Verbose {
 		log.Printf("%s polling delay: %ds.\n", l.GetName(), l.RESTPollingDelay)
 		log.Printf("%s %d currencies enabled: %s.\n", l.GetName(), len(l.EnabledPairs), l.EnabledPairs)
@@ -40,6 +36,7 @@ func (l *Liqui) Run() {
 			log.Printf("%s Failed to get config.\n", l.GetName())
 		}
 	}
+	return nil
 }
 
 // UpdateTicker updates and returns the ticker for a currency pair
@@ -150,6 +147,10 @@ func (l *Liqui) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]exc
 
 // SubmitOrder submits a new order
 func (l *Liqui) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	if l.IsSimulated() {
+		return l.SimulateSubmitOrder(p, side, orderType, amount, price, clientID)
+	}
+
 	var submitOrderResponse exchange.SubmitOrderResponse
 	response, err := l.Trade(p.Pair().String(), fmt.Sprintf("%s", orderType), amount, price)
 
@@ -167,11 +168,19 @@ func (l *Liqui) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderT
 // ModifyOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (l *Liqui) ModifyOrder(action exchange.ModifyOrder) (string, error) {
+	if l.IsSimulated() {
+		return l.SimulateModifyOrder(action)
+	}
+
 	return "", common.ErrFunctionNotSupported
 }
 
 // CancelOrder cancels an order by its corresponding ID number
 func (l *Liqui) CancelOrder(order exchange.OrderCancellation) error {
+	if l.IsSimulated() {
+		return l.SimulateCancelOrder(order)
+	}
+
 	orderIDInt, err := strconv.ParseInt(order.OrderID, 10, 64)
 
 	if err != nil {
@@ -184,10 +193,14 @@ func (l *Liqui) CancelOrder(order exchange.OrderCancellation) error {
 
 // CancelAllOrders cancels all orders associated with a currency pair
 func (l *Liqui) CancelAllOrders(orderCancellation exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
+	if l.IsSimulated() {
+		return l.SimulateCancelAllOrders(orderCancellation)
+	}
+
 	cancelAllOrdersResponse := exchange.CancelAllOrdersResponse{
 		OrderStatus: make(map[string]string),
 	}
-	activeOrders, err := l.GetActiveOrders("")
+	activeOrders, err := l.GetOpenOrders("")
 	if err != nil {
 		return cancelAllOrdersResponse, err
 	}
@@ -209,6 +222,10 @@ func (l *Liqui) CancelAllOrders(orderCancellation exchange.OrderCancellation) (e
 
 // GetOrderInfo returns information on a current open order
 func (l *Liqui) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
+	if l.IsSimulated() {
+		return l.SimulateGetOrderInfo(orderID)
+	}
+
 	var orderDetail exchange.OrderDetail
 	return orderDetail, common.ErrNotYetImplemented
 }
@@ -1,12 +1,12 @@
 package localbitcoins
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
 	"math"
 	"strconv"
-	"sync"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
@@ -15,17 +15,13 @@ import (
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
 
-// Start starts the LocalBitcoins go routine
-func (l *LocalBitcoins) Start(wg *sync.WaitGroup) {
-	wg.Add(1)
-	go func() {
-		l.Run()
-		wg.Done()
-	}()
+// Start starts the LocalBitcoins wrapper, refreshing its currency pairs
+func (l *LocalBitcoins) Start(ctx context.Context) error {
+	return l.StartWrapper(ctx, l.Run)
 }
 
 // Run implements the LocalBitcoins wrapper
-func (l *LocalBitcoins) Run() {
+func (l *LocalBitcoins) Run(ctx context.Context) error {
 	if l.Verbose {
 		log.Printf("%s polling delay: %ds.\n", l.GetName(), l.RESTPollingDelay)
 		log.Printf("%s %d currencies enabled: %s.\n", l.GetName(), len(l.EnabledPairs), l.EnabledPairs)
@@ -34,7 +30,7 @@ func (l *LocalBitcoins) Run() {
 	currencies, err := l.GetTradableCurrencies()
 	if err != nil {
 		log.Printf("%s failed to obtain available tradable currencies. Err: %s", l.Name, err)
-		return
+		return nil
 	}
 
 	var pairs []string
@@ -47,6 +43,7 @@ func (l *LocalBitcoins) Run() {
 		log.Printf("%s failed to update available currencies. Err %s", l.Name, err)
 	}
 
+	return nil
 }
 
 // UpdateTicker updates and returns the ticker for a currency pair
@@ -142,6 +139,10 @@ func (l *LocalBitcoins) GetExchangeHistory(p pair.CurrencyPair, assetType string
 
 // SubmitOrder submits a new order
 func (l *LocalBitcoins) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	if l.IsSimulated() {
+		return l.SimulateSubmitOrder(p, side, orderType, amount, price, clientID)
+	}
+
 	var submitOrderResponse exchange.SubmitOrderResponse
 	// These are placeholder details
 	// TODO store a user's localbitcoin details to use here
@@ -210,16 +211,28 @@ func (l *LocalBitcoins) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide
 // ModifyOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (l *LocalBitcoins) ModifyOrder(action exchange.ModifyOrder) (string, error) {
+	if l.IsSimulated() {
+		return l.SimulateModifyOrder(action)
+	}
+
 	return "", common.ErrFunctionNotSupported
 }
 
 // CancelOrder cancels an order by its corresponding ID number
 func (l *LocalBitcoins) CancelOrder(order exchange.OrderCancellation) error {
+	if l.IsSimulated() {
+		return l.SimulateCancelOrder(order)
+	}
+
 	return l.DeleteAd(order.OrderID)
 }
 
 // CancelAllOrders cancels all orders associated with a currency pair
 func (l *LocalBitcoins) CancelAllOrders(orderCancellation exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
+	if l.IsSimulated() {
+		return l.SimulateCancelAllOrders(orderCancellation)
+	}
+
 	cancelAllOrdersResponse := exchange.CancelAllOrdersResponse{
 		OrderStatus: make(map[string]string),
 	}
@@ -241,6 +254,10 @@ func (l *LocalBitcoins) CancelAllOrders(orderCancellation exchange.OrderCancella
 
 // GetOrderInfo returns information on a current open order
 func (l *LocalBitcoins) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
+	if l.IsSimulated() {
+		return l.SimulateGetOrderInfo(orderID)
+	}
+
 	var orderDetail exchange.OrderDetail
 	return orderDetail, common.ErrNotYetImplemented
 }
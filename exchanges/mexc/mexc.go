@@ -0,0 +1,646 @@
+package mexc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/config"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/assets"
+	"github.com/thrasher-/gocryptotrader/exchanges/request"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+const (
+	mexcAPIURL     = "https://api.mexc.com"
+	mexcAPIVersion = "/api/v3/"
+
+	// Public endpoints
+	mexcExchangeInfo = "exchangeInfo"
+	mexcDepth        = "depth"
+	mexcTrades       = "trades"
+	mexcKlines       = "klines"
+	mexcTicker24hr   = "ticker/24hr"
+	mexcServerTime   = "time"
+	mexcPing         = "ping"
+
+	// Authenticated endpoints
+	mexcOrder           = "order"
+	mexcOpenOrders      = "openOrders"
+	mexcAllOrders       = "allOrders"
+	mexcAccount         = "account"
+	mexcCurrencyConfig  = "capital/config/getall"
+	mexcWithdraw        = "capital/withdraw"
+	mexcDepositAddress  = "capital/deposit/address"
+	mexcDepositHistory  = "capital/deposit/hisrec"
+	mexcWithdrawHistory = "capital/withdraw/history"
+	mexcUserDataStream  = "userDataStream"
+
+	// MEXC allows 500 requests per 10 seconds per endpoint
+	mexcAuthRate   = 500
+	mexcUnauthRate = 500
+
+	mexcRecvWindow = 5 * time.Second
+	mexcDefaultFee = 0.001
+)
+
+// MEXC is the overarching type across the MEXC package
+type MEXC struct {
+	exchange.Base
+	WebsocketConn *websocket.Conn
+	wsWriteLock   sync.Mutex
+	listenKey     string
+}
+
+// SetDefaults sets the basic defaults for MEXC
+func (m *MEXC) SetDefaults() {
+	m.Name = "MEXC"
+	m.Enabled = false
+	m.Verbose = false
+	m.RESTPollingDelay = 10
+	m.APIWithdrawPermissions = exchange.AutoWithdrawCryptoWithAPIPermission
+	m.BrokerTagFormat = exchange.BrokerTagFormat{MaxLength: 12}
+	m.Features = exchange.Features{
+		CanGetTicker:         true,
+		CanGetOrderbook:      true,
+		CanGetTradeHistory:   true,
+		CanGetAccountInfo:    true,
+		CanGetFundingHistory: true,
+		CanSubmitOrder:       true,
+		CanCancelOrder:       true,
+		CanCancelAllOrders:   true,
+		CanGetDepositAddress: true,
+		CanWithdrawCrypto:    true,
+		CanStreamOrderbook:   true,
+		CanStreamTrades:      true,
+	}
+	m.Features.Orders = map[string]exchange.OrderCapabilities{
+		assets.Spot: {
+			OrderTypes:  []exchange.OrderType{exchange.Limit, exchange.Market, exchange.ImmediateOrCancel},
+			TimeInForce: []exchange.TimeInForce{exchange.GTC, exchange.IOC},
+		},
+	}
+	m.RequestCurrencyPairFormat.Delimiter = ""
+	m.RequestCurrencyPairFormat.Uppercase = true
+	m.ConfigCurrencyPairFormat.Delimiter = "-"
+	m.ConfigCurrencyPairFormat.Uppercase = true
+	m.AssetTypes = []string{ticker.Spot}
+	m.SupportsAutoPairUpdating = true
+	m.SupportsRESTTickerBatching = true
+	m.Requester = request.New(m.Name,
+		request.NewRateLimit(time.Second*10, mexcAuthRate),
+		request.NewRateLimit(time.Second*10, mexcUnauthRate),
+		common.NewHTTPClientWithTimeout(exchange.DefaultHTTPTimeout))
+	m.APIUrlDefault = mexcAPIURL
+	m.APIUrl = m.APIUrlDefault
+	m.WebsocketInit()
+}
+
+// Setup takes in the supplied exchange configuration details and sets params
+func (m *MEXC) Setup(exch config.ExchangeConfig) {
+	if !exch.Enabled {
+		m.SetEnabled(false)
+	} else {
+		m.Enabled = true
+		m.AuthenticatedAPISupport = exch.AuthenticatedAPISupport
+		m.SetAPIKeys(exch.APIKey, exch.APISecret, "", false)
+		m.SetHTTPClientTimeout(exch.HTTPTimeout)
+		m.SetHTTPClientUserAgent(exch.HTTPUserAgent)
+		m.RESTPollingDelay = exch.RESTPollingDelay
+		m.Verbose = exch.Verbose
+		m.BaseCurrencies = common.SplitStrings(exch.BaseCurrencies, ",")
+		m.AvailablePairs = common.SplitStrings(exch.AvailablePairs, ",")
+		m.EnabledPairs = common.SplitStrings(exch.EnabledPairs, ",")
+		err := m.SetCurrencyPairFormat()
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = m.SetAssetTypes()
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = m.SetAutoPairDefaults()
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = m.SetAPIURL(exch)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = m.SetClientProxyAddress(exch.ProxyAddress)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = m.SetTLSPins(exch.TLSPins)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = m.SetLocalAddress(exch.LocalAddress)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = m.SetRoundingModes(exch.RoundingModes)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = m.SetRateLimitTiers(exch.RateLimitTiers, exch.AccountTier)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = m.SetHTTPClientHeaders(exch.HTTPHeaders)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = m.SetBrokerTag(exch.BrokerTag)
+		if err != nil {
+			log.Fatal(err)
+		}
+		m.SetSimulation(exch.Simulate, exch.SimulationBalances)
+		err = m.WebsocketSetup(m.WsConnect,
+			exch.Name,
+			exch.Websocket,
+			mexcWebsocketURL,
+			exch.WebsocketURL)
+		if err != nil {
+			log.Fatal(err)
+		}
+		m.Websocket.SetSubscriber(m.wsDefaultSubscriptions, m.WsSubscribe, m.WsUnsubscribe)
+	}
+}
+
+// GetExchangeInfo returns trading rules for all symbols, or a single symbol
+// when supplied
+func (m *MEXC) GetExchangeInfo(symbol string) (ExchangeInfo, error) {
+	var resp ExchangeInfo
+	params := url.Values{}
+	if symbol != "" {
+		params.Set("symbol", symbol)
+	}
+
+	path := common.EncodeURLValues(m.APIUrl+mexcAPIVersion+mexcExchangeInfo, params)
+	return resp, m.SendHTTPRequest(path, &resp)
+}
+
+// GetOrderbook returns bids and asks for a symbol, limit is optional and
+// defaults to 100 with a maximum of 5000
+func (m *MEXC) GetOrderbook(symbol string, limit int64) (Orderbook, error) {
+	return m.GetOrderbookContext(context.Background(), symbol, limit)
+}
+
+// GetOrderbookContext returns bids and asks for a symbol, cancelled with the
+// context
+func (m *MEXC) GetOrderbookContext(ctx context.Context, symbol string, limit int64) (Orderbook, error) {
+	var resp orderbookResponse
+	params := url.Values{}
+	params.Set("symbol", symbol)
+	if limit > 0 {
+		params.Set("limit", strconv.FormatInt(limit, 10))
+	}
+
+	path := common.EncodeURLValues(m.APIUrl+mexcAPIVersion+mexcDepth, params)
+	err := m.SendHTTPRequestContext(ctx, path, &resp)
+	if err != nil {
+		return Orderbook{}, err
+	}
+
+	ob := Orderbook{LastUpdateID: resp.LastUpdateID}
+	ob.Bids, err = parseOrderbookLevels(resp.Bids)
+	if err != nil {
+		return ob, err
+	}
+	ob.Asks, err = parseOrderbookLevels(resp.Asks)
+	return ob, err
+}
+
+// parseOrderbookLevels converts [price, quantity] levels
+func parseOrderbookLevels(levels [][2]string) ([]OrderbookItem, error) {
+	items := make([]OrderbookItem, len(levels))
+	for i := range levels {
+		price, err := strconv.ParseFloat(levels[i][0], 64)
+		if err != nil {
+			return nil, err
+		}
+		quantity, err := strconv.ParseFloat(levels[i][1], 64)
+		if err != nil {
+			return nil, err
+		}
+		items[i] = OrderbookItem{Price: price, Quantity: quantity}
+	}
+	return items, nil
+}
+
+// GetTrades returns recent trades for a symbol, limit is optional and
+// defaults to 500 with a maximum of 1000
+func (m *MEXC) GetTrades(symbol string, limit int64) ([]Trade, error) {
+	var resp []Trade
+	params := url.Values{}
+	params.Set("symbol", symbol)
+	if limit > 0 {
+		params.Set("limit", strconv.FormatInt(limit, 10))
+	}
+
+	path := common.EncodeURLValues(m.APIUrl+mexcAPIVersion+mexcTrades, params)
+	return resp, m.SendHTTPRequest(path, &resp)
+}
+
+// GetCandles returns klines for a symbol. Interval is one of 1m, 5m, 15m,
+// 30m, 60m, 4h, 1d, 1W or 1M. Start and end are optional.
+func (m *MEXC) GetCandles(symbol, interval string, start, end time.Time, limit int64) ([]Candle, error) {
+	var resp [][]interface{}
+	params := url.Values{}
+	params.Set("symbol", symbol)
+	params.Set("interval", interval)
+	if !start.IsZero() {
+		params.Set("startTime", strconv.FormatInt(common.UnixMillis(start), 10))
+	}
+	if !end.IsZero() {
+		params.Set("endTime", strconv.FormatInt(common.UnixMillis(end), 10))
+	}
+	if limit > 0 {
+		params.Set("limit", strconv.FormatInt(limit, 10))
+	}
+
+	path := common.EncodeURLValues(m.APIUrl+mexcAPIVersion+mexcKlines, params)
+	err := m.SendHTTPRequest(path, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return parseCandles(resp)
+}
+
+// parseCandles converts [openTime, open, high, low, close, volume,
+// closeTime, quoteVolume] candles, times are numbers and prices are strings
+func parseCandles(raw [][]interface{}) ([]Candle, error) {
+	candles := make([]Candle, 0, len(raw))
+	for i := range raw {
+		if len(raw[i]) < 8 {
+			return nil, fmt.Errorf("unexpected candle length %d", len(raw[i]))
+		}
+
+		var values [8]float64
+		for j := 0; j < 8; j++ {
+			switch v := raw[i][j].(type) {
+			case float64:
+				values[j] = v
+			case string:
+				f, err := strconv.ParseFloat(v, 64)
+				if err != nil {
+					return nil, err
+				}
+				values[j] = f
+			default:
+				return nil, fmt.Errorf("unexpected candle value type %T", v)
+			}
+		}
+
+		candles = append(candles, Candle{
+			OpenTime:    int64(values[0]),
+			Open:        values[1],
+			High:        values[2],
+			Low:         values[3],
+			Close:       values[4],
+			Volume:      values[5],
+			CloseTime:   int64(values[6]),
+			QuoteVolume: values[7],
+		})
+	}
+	return candles, nil
+}
+
+// GetTickers returns 24 hour statistics for all symbols
+func (m *MEXC) GetTickers() ([]Ticker, error) {
+	return m.GetTickersContext(context.Background())
+}
+
+// GetTickersContext returns 24 hour statistics for all symbols, cancelled with
+// the context
+func (m *MEXC) GetTickersContext(ctx context.Context) ([]Ticker, error) {
+	var resp []Ticker
+	return resp, m.SendHTTPRequestContext(ctx, m.APIUrl+mexcAPIVersion+mexcTicker24hr, &resp)
+}
+
+// GetTicker returns 24 hour statistics for a symbol
+func (m *MEXC) GetTicker(symbol string) (Ticker, error) {
+	var resp Ticker
+	params := url.Values{}
+	params.Set("symbol", symbol)
+
+	path := common.EncodeURLValues(m.APIUrl+mexcAPIVersion+mexcTicker24hr, params)
+	return resp, m.SendHTTPRequest(path, &resp)
+}
+
+// Ping tests connectivity to the REST API
+func (m *MEXC) Ping() error {
+	var resp struct{}
+	return m.SendHTTPRequest(m.APIUrl+mexcAPIVersion+mexcPing, &resp)
+}
+
+// GetServerTime returns the MEXC server time
+func (m *MEXC) GetServerTime() (time.Time, error) {
+	var resp struct {
+		ServerTime int64 `json:"serverTime"`
+	}
+	err := m.SendHTTPRequest(m.APIUrl+mexcAPIVersion+mexcServerTime, &resp)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return common.UnixTimestampToUTC(resp.ServerTime), nil
+}
+
+// GetAccount returns account permissions and balances
+func (m *MEXC) GetAccount() (Account, error) {
+	return m.GetAccountContext(context.Background())
+}
+
+// GetAccountContext returns account permissions and balances, cancelled with
+// the context
+func (m *MEXC) GetAccountContext(ctx context.Context) (Account, error) {
+	var resp Account
+	return resp, m.SendAuthHTTPRequestContext(ctx, "GET", mexcAccount, nil, &resp)
+}
+
+// NewOrder places a new spot order
+func (m *MEXC) NewOrder(arg NewOrderRequest) (NewOrderResponse, error) {
+	return m.NewOrderContext(context.Background(), arg)
+}
+
+// NewOrderContext places a new spot order, cancelled with the context
+func (m *MEXC) NewOrderContext(ctx context.Context, arg NewOrderRequest) (NewOrderResponse, error) {
+	var resp NewOrderResponse
+	if arg.Quantity <= 0 && arg.QuoteOrderQty <= 0 {
+		return resp, errors.New("quantity or quote order quantity must be set")
+	}
+
+	params := url.Values{}
+	params.Set("symbol", arg.Symbol)
+	params.Set("side", arg.Side)
+	params.Set("type", arg.Type)
+	if arg.Quantity > 0 {
+		params.Set("quantity", strconv.FormatFloat(arg.Quantity, 'f', -1, 64))
+	}
+	if arg.QuoteOrderQty > 0 {
+		params.Set("quoteOrderQty", strconv.FormatFloat(arg.QuoteOrderQty, 'f', -1, 64))
+	}
+	if arg.Price > 0 {
+		params.Set("price", strconv.FormatFloat(arg.Price, 'f', -1, 64))
+	}
+	if arg.ClientOrderID != "" {
+		params.Set("newClientOrderId", arg.ClientOrderID)
+	}
+	return resp, m.SendAuthHTTPRequestContext(ctx, "POST", mexcOrder, params, &resp)
+}
+
+// CancelExistingOrder cancels an order by its order ID
+func (m *MEXC) CancelExistingOrder(symbol, orderID string) (CancelOrderResponse, error) {
+	return m.CancelExistingOrderContext(context.Background(), symbol, orderID)
+}
+
+// CancelExistingOrderContext cancels an order by its order ID, cancelled with
+// the context
+func (m *MEXC) CancelExistingOrderContext(ctx context.Context, symbol, orderID string) (CancelOrderResponse, error) {
+	var resp CancelOrderResponse
+	params := url.Values{}
+	params.Set("symbol", symbol)
+	params.Set("orderId", orderID)
+	return resp, m.SendAuthHTTPRequestContext(ctx, "DELETE", mexcOrder, params, &resp)
+}
+
+// CancelOpenOrders cancels all open orders for up to 5 comma separated
+// symbols
+func (m *MEXC) CancelOpenOrders(symbols string) ([]CancelOrderResponse, error) {
+	var resp []CancelOrderResponse
+	params := url.Values{}
+	params.Set("symbol", symbols)
+	return resp, m.SendAuthHTTPRequest("DELETE", mexcOpenOrders, params, &resp)
+}
+
+// QueryOrder returns an order by its order ID
+func (m *MEXC) QueryOrder(symbol, orderID string) (Order, error) {
+	var resp Order
+	params := url.Values{}
+	params.Set("symbol", symbol)
+	params.Set("orderId", orderID)
+	return resp, m.SendAuthHTTPRequest("GET", mexcOrder, params, &resp)
+}
+
+// GetOpenOrders returns all open orders for a symbol
+func (m *MEXC) GetOpenOrders(symbol string) ([]Order, error) {
+	var resp []Order
+	params := url.Values{}
+	params.Set("symbol", symbol)
+	return resp, m.SendAuthHTTPRequest("GET", mexcOpenOrders, params, &resp)
+}
+
+// GetAllOrders returns orders for a symbol from the last 7 days unless a
+// start time is supplied, the maximum query window is 24 hours
+func (m *MEXC) GetAllOrders(symbol string, start, end time.Time) ([]Order, error) {
+	var resp []Order
+	params := url.Values{}
+	params.Set("symbol", symbol)
+	if !start.IsZero() {
+		params.Set("startTime", strconv.FormatInt(common.UnixMillis(start), 10))
+	}
+	if !end.IsZero() {
+		params.Set("endTime", strconv.FormatInt(common.UnixMillis(end), 10))
+	}
+	return resp, m.SendAuthHTTPRequest("GET", mexcAllOrders, params, &resp)
+}
+
+// GetCurrencies returns deposit and withdrawal details for all currencies
+func (m *MEXC) GetCurrencies() ([]Currency, error) {
+	var resp []Currency
+	return resp, m.SendAuthHTTPRequest("GET", mexcCurrencyConfig, nil, &resp)
+}
+
+// GetCurrencyDepositAddresses returns the deposit addresses for a currency,
+// network is optional
+func (m *MEXC) GetCurrencyDepositAddresses(coin, network string) ([]DepositAddress, error) {
+	var resp []DepositAddress
+	params := url.Values{}
+	params.Set("coin", coin)
+	if network != "" {
+		params.Set("network", network)
+	}
+	return resp, m.SendAuthHTTPRequest("GET", mexcDepositAddress, params, &resp)
+}
+
+// Withdraw submits a withdrawal and returns its ID
+func (m *MEXC) Withdraw(arg WithdrawalRequest) (string, error) {
+	var resp struct {
+		ID string `json:"id"`
+	}
+	params := url.Values{}
+	params.Set("coin", arg.Coin)
+	params.Set("address", arg.Address)
+	params.Set("amount", strconv.FormatFloat(arg.Amount, 'f', -1, 64))
+	if arg.Network != "" {
+		params.Set("netWork", arg.Network)
+	}
+	if arg.Memo != "" {
+		params.Set("memo", arg.Memo)
+	}
+	if arg.WithdrawOrderID != "" {
+		params.Set("withdrawOrderId", arg.WithdrawOrderID)
+	}
+	return resp.ID, m.SendAuthHTTPRequest("POST", mexcWithdraw, params, &resp)
+}
+
+// GetDeposits returns deposit records from the last 7 days, coin is optional
+func (m *MEXC) GetDeposits(coin string) ([]Deposit, error) {
+	var resp []Deposit
+	params := url.Values{}
+	if coin != "" {
+		params.Set("coin", coin)
+	}
+	return resp, m.SendAuthHTTPRequest("GET", mexcDepositHistory, params, &resp)
+}
+
+// GetWithdrawals returns withdrawal records from the last 7 days, coin is
+// optional
+func (m *MEXC) GetWithdrawals(coin string) ([]Withdrawal, error) {
+	var resp []Withdrawal
+	params := url.Values{}
+	if coin != "" {
+		params.Set("coin", coin)
+	}
+	return resp, m.SendAuthHTTPRequest("GET", mexcWithdrawHistory, params, &resp)
+}
+
+// CreateListenKey creates a listen key for the private websocket streams,
+// keys expire after 60 minutes unless kept alive
+func (m *MEXC) CreateListenKey() (string, error) {
+	var resp struct {
+		ListenKey string `json:"listenKey"`
+	}
+	return resp.ListenKey, m.SendAuthHTTPRequest("POST", mexcUserDataStream, nil, &resp)
+}
+
+// KeepAliveListenKey extends a listen key's validity by 60 minutes
+func (m *MEXC) KeepAliveListenKey(listenKey string) error {
+	params := url.Values{}
+	params.Set("listenKey", listenKey)
+	return m.SendAuthHTTPRequest("PUT", mexcUserDataStream, params, nil)
+}
+
+// CloseListenKey closes a listen key
+func (m *MEXC) CloseListenKey(listenKey string) error {
+	params := url.Values{}
+	params.Set("listenKey", listenKey)
+	return m.SendAuthHTTPRequest("DELETE", mexcUserDataStream, params, nil)
+}
+
+// SendHTTPRequest sends an unauthenticated HTTP request
+func (m *MEXC) SendHTTPRequest(path string, result interface{}) error {
+	return m.SendHTTPRequestContext(context.Background(), path, result)
+}
+
+// SendHTTPRequestContext sends an unauthenticated HTTP request, cancelled with
+// the context
+func (m *MEXC) SendHTTPRequestContext(ctx context.Context, path string, result interface{}) error {
+	return m.SendPayloadContext(ctx, "GET", path, nil, nil, result, false, m.Verbose)
+}
+
+// SendAuthHTTPRequest sends an authenticated HTTP request, all parameters are
+// sent in the signed query string
+func (m *MEXC) SendAuthHTTPRequest(method, endpoint string, params url.Values, result interface{}) error {
+	return m.SendAuthHTTPRequestContext(context.Background(), method, endpoint, params, result)
+}
+
+// SendAuthHTTPRequestContext sends an authenticated HTTP request, cancelled
+// with the context
+func (m *MEXC) SendAuthHTTPRequestContext(ctx context.Context, method, endpoint string, params url.Values, result interface{}) error {
+	if !m.AuthenticatedAPISupport {
+		return fmt.Errorf(exchange.WarningAuthenticatedRequestWithoutCredentialsSet, m.Name)
+	}
+
+	if params == nil {
+		params = url.Values{}
+	}
+	params.Set("recvWindow", strconv.FormatInt(common.RecvWindow(mexcRecvWindow), 10))
+	params.Set("timestamp", strconv.FormatInt(common.UnixMillis(time.Now()), 10))
+	params.Set("signature", m.sign(params.Encode()))
+
+	headers := make(map[string]string)
+	headers["X-MEXC-APIKEY"] = m.APIKey
+	headers["Content-Type"] = "application/json"
+
+	path := common.EncodeURLValues(m.APIUrl+mexcAPIVersion+endpoint, params)
+	if m.Verbose {
+		log.Printf("%s sending authenticated request to %s", m.Name, endpoint)
+	}
+	return m.SendPayloadContext(ctx, method, path, headers, bytes.NewBufferString(""), result, true, m.Verbose)
+}
+
+// sign returns the hex encoded HMAC-SHA256 signature of the query string
+func (m *MEXC) sign(query string) string {
+	hmac := common.GetHMAC(common.HashSHA256, []byte(query), []byte(m.APISecret))
+	return common.HexEncodeToString(hmac)
+}
+
+// GetFee returns an estimate of fee based on type of transaction
+func (m *MEXC) GetFee(feeBuilder exchange.FeeBuilder) (float64, error) {
+	var fee float64
+	switch feeBuilder.FeeType {
+	case exchange.CryptocurrencyTradeFee:
+		rate := mexcDefaultFee
+		if m.AuthenticatedAPISupport {
+			account, err := m.GetAccount()
+			if err != nil {
+				return 0, err
+			}
+			// Commission rates are returned in basis points
+			rate = account.TakerCommission / 10000
+			if feeBuilder.IsMaker {
+				rate = account.MakerCommission / 10000
+			}
+		}
+		fee = rate * feeBuilder.PurchasePrice * feeBuilder.Amount
+	case exchange.CryptocurrencyWithdrawalFee:
+		currencies, err := m.GetCurrencies()
+		if err != nil {
+			return 0, err
+		}
+		fee = defaultWithdrawalFee(currencies, feeBuilder.FirstCurrency)
+	}
+
+	if fee < 0 {
+		fee = 0
+	}
+	return fee, nil
+}
+
+// defaultWithdrawalFee returns the withdrawal fee of a currency's default
+// network, falling back to the first withdrawable network
+func defaultWithdrawalFee(currencies []Currency, coin string) float64 {
+	for i := range currencies {
+		if currencies[i].Coin != coin {
+			continue
+		}
+
+		var fee float64
+		var found bool
+		for _, n := range currencies[i].NetworkList {
+			if !n.WithdrawEnable {
+				continue
+			}
+			if n.IsDefault {
+				return n.WithdrawFee
+			}
+			if !found {
+				fee = n.WithdrawFee
+				found = true
+			}
+		}
+		return fee
+	}
+	return 0
+}
@@ -0,0 +1,419 @@
+package mexc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	"github.com/thrasher-/gocryptotrader/currency/symbol"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/conformance"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+var m MEXC
+
+// Please supply you own test keys here for due diligence testing.
+const (
+	apiKey                  = ""
+	apiSecret               = ""
+	canManipulateRealOrders = false
+)
+
+func TestSetDefaults(t *testing.T) {
+	m.SetDefaults()
+	if m.GetName() != "MEXC" {
+		t.Error("Test Failed - MEXC - SetDefaults() error")
+	}
+}
+
+func TestSetup(t *testing.T) {
+	cfg := config.GetConfig()
+	cfg.LoadConfig("../../testdata/configtest.json")
+	mexcConfig, err := cfg.GetExchangeConfig("MEXC")
+	if err != nil {
+		t.Error("Test Failed - MEXC Setup() init error")
+	}
+
+	mexcConfig.AuthenticatedAPISupport = true
+	mexcConfig.APIKey = apiKey
+	mexcConfig.APISecret = apiSecret
+
+	m.Setup(mexcConfig)
+}
+
+func TestConformance(t *testing.T) {
+	cfg := config.GetConfig()
+	cfg.LoadConfig("../../testdata/configtest.json")
+	mexcConfig, err := cfg.GetExchangeConfig("MEXC")
+	if err != nil {
+		t.Fatal("Test Failed - MEXC conformance init error", err)
+	}
+
+	conformance.Run(t, func() exchange.IBotExchange { return new(MEXC) }, mexcConfig)
+}
+
+func TestCheckSystemStatus(t *testing.T) {
+	t.Parallel()
+	err := m.CheckSystemStatus()
+	if err != nil {
+		t.Error("Test Failed - MEXC CheckSystemStatus() error", err)
+	}
+}
+
+func TestGetExchangeInfo(t *testing.T) {
+	t.Parallel()
+	_, err := m.GetExchangeInfo("")
+	if err != nil {
+		t.Error("Test Failed - MEXC GetExchangeInfo() error", err)
+	}
+}
+
+func TestGetTickers(t *testing.T) {
+	t.Parallel()
+	_, err := m.GetTickers()
+	if err != nil {
+		t.Error("Test Failed - MEXC GetTickers() error", err)
+	}
+}
+
+func TestGetOrderbook(t *testing.T) {
+	t.Parallel()
+	_, err := m.GetOrderbook("BTCUSDT", 10)
+	if err != nil {
+		t.Error("Test Failed - MEXC GetOrderbook() error", err)
+	}
+}
+
+func TestGetTrades(t *testing.T) {
+	t.Parallel()
+	_, err := m.GetTrades("BTCUSDT", 10)
+	if err != nil {
+		t.Error("Test Failed - MEXC GetTrades() error", err)
+	}
+}
+
+func TestGetCandles(t *testing.T) {
+	t.Parallel()
+	_, err := m.GetCandles("BTCUSDT", "60m", time.Time{}, time.Time{}, 10)
+	if err != nil {
+		t.Error("Test Failed - MEXC GetCandles() error", err)
+	}
+}
+
+func TestParseCandles(t *testing.T) {
+	t.Parallel()
+	candles, err := parseCandles([][]interface{}{
+		{float64(1640804880000), "47482.36", "47482.36", "47416.57", "47436.1", "3.550717", float64(1640804940000), "168387.3"},
+	})
+	if err != nil {
+		t.Fatal("Test Failed - parseCandles() error", err)
+	}
+	if len(candles) != 1 || candles[0].OpenTime != 1640804880000 ||
+		candles[0].Open != 47482.36 || candles[0].Close != 47436.1 {
+		t.Error("Test Failed - parseCandles() incorrect values", candles)
+	}
+
+	_, err = parseCandles([][]interface{}{{float64(1640804880000), "47482.36"}})
+	if err == nil {
+		t.Error("Test Failed - parseCandles() expected error on short candle")
+	}
+}
+
+func TestSign(t *testing.T) {
+	m.SetDefaults()
+	m.APISecret = "secret"
+	defer func() {
+		m.APISecret = apiSecret
+	}()
+
+	expected := common.HexEncodeToString(common.GetHMAC(common.HashSHA256,
+		[]byte("symbol=BTCUSDT&timestamp=1644489390087"),
+		[]byte("secret")))
+	if sig := m.sign("symbol=BTCUSDT&timestamp=1644489390087"); sig != expected {
+		t.Errorf("Test Failed - sign() expected %s, received %s", expected, sig)
+	}
+}
+
+func TestNewOrder(t *testing.T) {
+	_, err := m.NewOrder(NewOrderRequest{Symbol: "BTCUSDT", Side: "BUY", Type: "MARKET"})
+	if err == nil {
+		t.Error("Test Failed - NewOrder() expected missing quantity error")
+	}
+}
+
+func TestBuildOrder(t *testing.T) {
+	m.SetDefaults()
+	p := pair.NewCurrencyPairDelimiter("BTC-USDT", "-")
+
+	req, err := m.buildOrder(p, exchange.Buy, exchange.Limit, 0.5, 10000, "")
+	if err != nil {
+		t.Fatal("Test Failed - buildOrder() error", err)
+	}
+	if req.Symbol != "BTCUSDT" || req.Side != "BUY" || req.Type != "LIMIT" ||
+		req.Price != 10000 || req.Quantity != 0.5 {
+		t.Error("Test Failed - buildOrder() incorrect limit order", req)
+	}
+
+	req, err = m.buildOrder(p, exchange.Sell, exchange.ImmediateOrCancel, 1, 2, "abc")
+	if err != nil {
+		t.Fatal("Test Failed - buildOrder() error", err)
+	}
+	if req.Type != "IMMEDIATE_OR_CANCEL" || req.ClientOrderID != "abc" {
+		t.Error("Test Failed - buildOrder() incorrect IOC order", req)
+	}
+
+	_, err = m.buildOrder(p, exchange.Buy, exchange.OrderType("STOP"), 1, 1, "")
+	if err == nil {
+		t.Error("Test Failed - buildOrder() expected unsupported order type error")
+	}
+}
+
+func TestDefaultWithdrawalFee(t *testing.T) {
+	t.Parallel()
+	currencies := []Currency{
+		{Coin: "USDT", NetworkList: []Network{
+			{Network: "ERC20", WithdrawEnable: true, WithdrawFee: 5},
+			{Network: "TRC20", WithdrawEnable: true, WithdrawFee: 1, IsDefault: true},
+		}},
+		{Coin: "BTC", NetworkList: []Network{
+			{Network: "BTC", WithdrawEnable: false, WithdrawFee: 0.001, IsDefault: true},
+			{Network: "BEP20", WithdrawEnable: true, WithdrawFee: 0.0001},
+		}},
+	}
+
+	if fee := defaultWithdrawalFee(currencies, "USDT"); fee != 1 {
+		t.Errorf("Test Failed - defaultWithdrawalFee() expected 1, received %f", fee)
+	}
+	if fee := defaultWithdrawalFee(currencies, "BTC"); fee != 0.0001 {
+		t.Errorf("Test Failed - defaultWithdrawalFee() expected 0.0001, received %f", fee)
+	}
+	if fee := defaultWithdrawalFee(currencies, "ETH"); fee != 0 {
+		t.Errorf("Test Failed - defaultWithdrawalFee() expected 0, received %f", fee)
+	}
+}
+
+func TestWsChannels(t *testing.T) {
+	m.SetDefaults()
+	m.EnabledPairs = []string{"BTC-USDT"}
+	m.listenKey = "key"
+	defer func() {
+		m.listenKey = ""
+	}()
+
+	channels := append(m.wsPrivateChannels(), m.wsChannels(m.wsDefaultSubscriptions())...)
+	expected := []string{
+		"spot@private.orders.v3.api",
+		"spot@private.account.v3.api",
+		"spot@public.deals.v3.api@BTCUSDT",
+		"spot@public.bookTicker.v3.api@BTCUSDT",
+		"spot@public.limit.depth.v3.api@BTCUSDT@20",
+	}
+	if len(channels) != len(expected) {
+		t.Fatalf("Test Failed - wsChannels() expected %d channels, received %d",
+			len(expected),
+			len(channels))
+	}
+	for i := range expected {
+		if channels[i] != expected[i] {
+			t.Errorf("Test Failed - wsChannels() expected %s, received %s",
+				expected[i],
+				channels[i])
+		}
+	}
+}
+
+func TestWsHandleMessage(t *testing.T) {
+	m.SetDefaults()
+	m.EnabledPairs = []string{"BTC-USDT"}
+	m.Websocket.DataHandler = make(chan interface{}, 10)
+
+	err := m.wsHandleMessage([]byte(`{"id":0,"code":0,"msg":"spot@public.deals.v3.api@BTCUSDT"}`))
+	if err != nil {
+		t.Error("Test Failed - wsHandleMessage() subscription response error", err)
+	}
+
+	err = m.wsHandleMessage([]byte(`{"c":"spot@public.deals.v3.api@BTCUSDT","d":{"deals":[{"S":2,"p":"20233.84","t":1678789654800,"v":"0.001028"}],"e":"spot@public.deals.v3.api"},"s":"BTCUSDT","t":1678789654800}`))
+	if err != nil {
+		t.Fatal("Test Failed - wsHandleMessage() error", err)
+	}
+
+	trade, ok := (<-m.Websocket.DataHandler).(exchange.TradeData)
+	if !ok || trade.Price != 20233.84 || trade.Side != "sell" ||
+		trade.CurrencyPair.Pair().String() != "BTC-USDT" {
+		t.Error("Test Failed - wsHandleMessage() incorrect trade", trade)
+	}
+
+	err = m.wsHandleMessage([]byte(`{"c":"spot@public.deals.v3.api@ETHUSDT","d":{"deals":[]},"s":"ETHUSDT","t":1678789654800}`))
+	if err == nil {
+		t.Error("Test Failed - wsHandleMessage() expected error on pair that is not enabled")
+	}
+
+	err = m.wsHandleMessage([]byte(`{"id":0,"code":1,"msg":"Blocked"}`))
+	if err == nil {
+		t.Error("Test Failed - wsHandleMessage() expected error")
+	}
+}
+
+func setFeeBuilder() exchange.FeeBuilder {
+	return exchange.FeeBuilder{
+		Amount:         1,
+		Delimiter:      "",
+		FeeType:        exchange.CryptocurrencyTradeFee,
+		FirstCurrency:  symbol.BTC,
+		SecondCurrency: symbol.USDT,
+		IsMaker:        false,
+		PurchasePrice:  1,
+	}
+}
+
+func TestGetFee(t *testing.T) {
+	m.SetDefaults()
+	if apiKey != "" || apiSecret != "" {
+		t.Skip()
+	}
+	m.AuthenticatedAPISupport = false
+
+	var feeBuilder = setFeeBuilder()
+	// CryptocurrencyTradeFee Basic
+	if resp, err := m.GetFee(feeBuilder); resp != float64(0.001) || err != nil {
+		t.Errorf("Test Failed - GetFee() error. Expected: %f, Received: %f", float64(0.001), resp)
+		t.Error(err)
+	}
+
+	// CryptocurrencyTradeFee High quantity
+	feeBuilder = setFeeBuilder()
+	feeBuilder.Amount = 1000
+	feeBuilder.PurchasePrice = 1000
+	if resp, err := m.GetFee(feeBuilder); resp != float64(1000) || err != nil {
+		t.Errorf("Test Failed - GetFee() error. Expected: %f, Received: %f", float64(1000), resp)
+		t.Error(err)
+	}
+
+	// CryptocurrencyTradeFee Negative purchase price
+	feeBuilder = setFeeBuilder()
+	feeBuilder.PurchasePrice = -1000
+	if resp, err := m.GetFee(feeBuilder); resp != float64(0) || err != nil {
+		t.Errorf("Test Failed - GetFee() error. Expected: %f, Received: %f", float64(0), resp)
+		t.Error(err)
+	}
+
+	// InternationalBankDepositFee Basic
+	feeBuilder = setFeeBuilder()
+	feeBuilder.FeeType = exchange.InternationalBankDepositFee
+	if resp, err := m.GetFee(feeBuilder); resp != float64(0) || err != nil {
+		t.Errorf("Test Failed - GetFee() error. Expected: %f, Received: %f", float64(0), resp)
+		t.Error(err)
+	}
+}
+
+func TestFormatWithdrawPermissions(t *testing.T) {
+	m.SetDefaults()
+	expectedResult := exchange.AutoWithdrawCryptoWithAPIPermissionText
+	withdrawPermissions := m.FormatWithdrawPermissions()
+	if withdrawPermissions != expectedResult {
+		t.Errorf("Expected: %s, Received: %s", expectedResult, withdrawPermissions)
+	}
+}
+
+func TestGetAccountInfo(t *testing.T) {
+	if apiKey != "" || apiSecret != "" {
+		_, err := m.GetAccountInfo()
+		if err != nil {
+			t.Error("Test Failed - GetAccountInfo() error", err)
+		}
+	} else {
+		_, err := m.GetAccountInfo()
+		if err == nil {
+			t.Error("Test Failed - GetAccountInfo() error")
+		}
+	}
+}
+
+func TestModifyOrder(t *testing.T) {
+	_, err := m.ModifyOrder(exchange.ModifyOrder{})
+	if err == nil {
+		t.Error("Test failed - ModifyOrder() error")
+	}
+}
+
+// Any tests below this line have the ability to impact your orders on the exchange. Enable canManipulateRealOrders to run them
+// ----------------------------------------------------------------------------------------------------------------------------
+func isRealOrderTestEnabled() bool {
+	if m.APIKey == "" || m.APISecret == "" ||
+		m.APIKey == "Key" || m.APISecret == "Secret" ||
+		!canManipulateRealOrders {
+		return false
+	}
+	return true
+}
+
+func TestSubmitOrder(t *testing.T) {
+	m.SetDefaults()
+	TestSetup(t)
+
+	if !isRealOrderTestEnabled() {
+		t.Skip()
+	}
+
+	var p = pair.CurrencyPair{
+		Delimiter:      "-",
+		FirstCurrency:  symbol.BTC,
+		SecondCurrency: symbol.USDT,
+	}
+	response, err := m.SubmitOrder(p, exchange.Buy, exchange.Limit, 0.001, 10, "")
+	if err != nil || !response.IsOrderPlaced {
+		t.Errorf("Order failed to be placed: %v", err)
+	}
+}
+
+func TestCancelExchangeOrder(t *testing.T) {
+	m.SetDefaults()
+	TestSetup(t)
+
+	if !isRealOrderTestEnabled() {
+		t.Skip()
+	}
+
+	var orderCancellation = exchange.OrderCancellation{
+		OrderID:      "1",
+		CurrencyPair: pair.NewCurrencyPairDelimiter("BTC-USDT", "-"),
+	}
+
+	err := m.CancelOrder(orderCancellation)
+	if err != nil {
+		t.Errorf("Could not cancel order: %s", err)
+	}
+}
+
+func TestCancelAllExchangeOrders(t *testing.T) {
+	m.SetDefaults()
+	TestSetup(t)
+
+	if !isRealOrderTestEnabled() {
+		t.Skip()
+	}
+
+	resp, err := m.CancelAllOrders(exchange.OrderCancellation{})
+	if err != nil {
+		t.Errorf("Could not cancel order: %s", err)
+	}
+
+	if len(resp.OrderStatus) > 0 {
+		t.Errorf("%v orders failed to cancel", len(resp.OrderStatus))
+	}
+}
+
+func TestUpdateTickerContext(t *testing.T) {
+	var _ exchange.ContextExchange = &m
+	var _ exchange.OrderContextExchange = &m
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := exchange.UpdateTickerContext(ctx, &m, pair.NewCurrencyPair("BTC", "USDT"), ticker.Spot)
+	if err != context.Canceled {
+		t.Error("Test Failed - UpdateTickerContext() expected cancelled request", err)
+	}
+}
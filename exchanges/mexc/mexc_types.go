@@ -0,0 +1,362 @@
+package mexc
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+)
+
+// Symbol holds spot symbol trading rules
+type Symbol struct {
+	Symbol               string   `json:"symbol"`
+	Status               string   `json:"status"`
+	BaseAsset            string   `json:"baseAsset"`
+	BaseAssetPrecision   int64    `json:"baseAssetPrecision"`
+	QuoteAsset           string   `json:"quoteAsset"`
+	QuotePrecision       int64    `json:"quotePrecision"`
+	QuoteAssetPrecision  int64    `json:"quoteAssetPrecision"`
+	BaseSizePrecision    float64  `json:"baseSizePrecision,string"`
+	QuoteAmountPrecision float64  `json:"quoteAmountPrecision,string"`
+	MaxQuoteAmount       float64  `json:"maxQuoteAmount,string"`
+	MakerCommission      float64  `json:"makerCommission,string"`
+	TakerCommission      float64  `json:"takerCommission,string"`
+	OrderTypes           []string `json:"orderTypes"`
+	IsSpotTradingAllowed bool     `json:"isSpotTradingAllowed"`
+	Permissions          []string `json:"permissions"`
+}
+
+// ExchangeInfo holds the exchange trading rules
+type ExchangeInfo struct {
+	Timezone   string   `json:"timezone"`
+	ServerTime int64    `json:"serverTime"`
+	Symbols    []Symbol `json:"symbols"`
+}
+
+// Ticker holds 24 hour price change statistics
+type Ticker struct {
+	Symbol             string  `json:"symbol"`
+	PriceChange        float64 `json:"priceChange,string"`
+	PriceChangePercent float64 `json:"priceChangePercent,string"`
+	PrevClosePrice     float64 `json:"prevClosePrice,string"`
+	LastPrice          float64 `json:"lastPrice,string"`
+	BidPrice           float64 `json:"bidPrice,string"`
+	BidQty             float64 `json:"bidQty,string"`
+	AskPrice           float64 `json:"askPrice,string"`
+	AskQty             float64 `json:"askQty,string"`
+	OpenPrice          float64 `json:"openPrice,string"`
+	HighPrice          float64 `json:"highPrice,string"`
+	LowPrice           float64 `json:"lowPrice,string"`
+	Volume             float64 `json:"volume,string"`
+	QuoteVolume        float64 `json:"quoteVolume,string"`
+	OpenTime           int64   `json:"openTime"`
+	CloseTime          int64   `json:"closeTime"`
+}
+
+// orderbookResponse holds raw orderbook levels as [price, quantity]
+type orderbookResponse struct {
+	LastUpdateID int64       `json:"lastUpdateId"`
+	Bids         [][2]string `json:"bids"`
+	Asks         [][2]string `json:"asks"`
+}
+
+// OrderbookItem stores an individual orderbook level
+type OrderbookItem struct {
+	Price    float64
+	Quantity float64
+}
+
+// Orderbook stores the orderbook data
+type Orderbook struct {
+	LastUpdateID int64
+	Bids         []OrderbookItem
+	Asks         []OrderbookItem
+}
+
+// Trade holds a public trade
+type Trade struct {
+	Price        float64 `json:"price,string"`
+	Quantity     float64 `json:"qty,string"`
+	QuoteQty     float64 `json:"quoteQty,string"`
+	Time         int64   `json:"time"`
+	IsBuyerMaker bool    `json:"isBuyerMaker"`
+	IsBestMatch  bool    `json:"isBestMatch"`
+}
+
+// Candle holds kline data
+type Candle struct {
+	OpenTime    int64
+	Open        float64
+	High        float64
+	Low         float64
+	Close       float64
+	Volume      float64
+	CloseTime   int64
+	QuoteVolume float64
+}
+
+// Balance holds an asset balance
+type Balance struct {
+	Asset  string  `json:"asset"`
+	Free   float64 `json:"free,string"`
+	Locked float64 `json:"locked,string"`
+}
+
+// Account holds account information and balances
+type Account struct {
+	MakerCommission float64   `json:"makerCommission"`
+	TakerCommission float64   `json:"takerCommission"`
+	CanTrade        bool      `json:"canTrade"`
+	CanWithdraw     bool      `json:"canWithdraw"`
+	CanDeposit      bool      `json:"canDeposit"`
+	AccountType     string    `json:"accountType"`
+	Balances        []Balance `json:"balances"`
+}
+
+// NewOrderRequest holds the parameters for placing an order. Quantity is in
+// the base asset, QuoteOrderQty is in the quote asset and is only valid for
+// market orders.
+type NewOrderRequest struct {
+	Symbol        string
+	Side          string
+	Type          string
+	Quantity      float64
+	QuoteOrderQty float64
+	Price         float64
+	ClientOrderID string
+}
+
+// NewOrderResponse holds the response from placing an order
+type NewOrderResponse struct {
+	Symbol       string  `json:"symbol"`
+	OrderID      string  `json:"orderId"`
+	OrderListID  int64   `json:"orderListId"`
+	Price        float64 `json:"price,string"`
+	OrigQty      float64 `json:"origQty,string"`
+	Type         string  `json:"type"`
+	Side         string  `json:"side"`
+	TransactTime int64   `json:"transactTime"`
+}
+
+// Order holds order details
+type Order struct {
+	Symbol              string  `json:"symbol"`
+	OrderID             string  `json:"orderId"`
+	OrderListID         int64   `json:"orderListId"`
+	ClientOrderID       string  `json:"clientOrderId"`
+	Price               float64 `json:"price,string"`
+	OrigQty             float64 `json:"origQty,string"`
+	ExecutedQty         float64 `json:"executedQty,string"`
+	CummulativeQuoteQty float64 `json:"cummulativeQuoteQty,string"`
+	Status              string  `json:"status"`
+	TimeInForce         string  `json:"timeInForce"`
+	Type                string  `json:"type"`
+	Side                string  `json:"side"`
+	StopPrice           float64 `json:"stopPrice,string"`
+	Time                int64   `json:"time"`
+	UpdateTime          int64   `json:"updateTime"`
+	IsWorking           bool    `json:"isWorking"`
+	OrigQuoteOrderQty   float64 `json:"origQuoteOrderQty,string"`
+}
+
+// CancelOrderResponse holds the response from cancelling an order
+type CancelOrderResponse struct {
+	Symbol            string  `json:"symbol"`
+	OrigClientOrderID string  `json:"origClientOrderId"`
+	OrderID           string  `json:"orderId"`
+	ClientOrderID     string  `json:"clientOrderId"`
+	Price             float64 `json:"price,string"`
+	OrigQty           float64 `json:"origQty,string"`
+	ExecutedQty       float64 `json:"executedQty,string"`
+	Status            string  `json:"status"`
+	Type              string  `json:"type"`
+	Side              string  `json:"side"`
+}
+
+// Network holds the deposit and withdrawal details for a currency network
+type Network struct {
+	Coin           string  `json:"coin"`
+	Network        string  `json:"network"`
+	NetworkID      string  `json:"netWork"`
+	Name           string  `json:"name"`
+	DepositEnable  bool    `json:"depositEnable"`
+	WithdrawEnable bool    `json:"withdrawEnable"`
+	WithdrawFee    float64 `json:"withdrawFee,string"`
+	WithdrawMin    float64 `json:"withdrawMin,string"`
+	WithdrawMax    float64 `json:"withdrawMax,string"`
+	MinConfirm     int64   `json:"minConfirm"`
+	IsDefault      bool    `json:"isDefault"`
+}
+
+// Currency holds currency details and its supported networks
+type Currency struct {
+	Coin        string    `json:"coin"`
+	Name        string    `json:"name"`
+	NetworkList []Network `json:"networkList"`
+}
+
+// DepositAddress holds a deposit address
+type DepositAddress struct {
+	Coin    string `json:"coin"`
+	Network string `json:"network"`
+	Address string `json:"address"`
+	Memo    string `json:"memo"`
+}
+
+// WithdrawalRequest holds the parameters for a withdrawal
+type WithdrawalRequest struct {
+	Coin            string
+	Address         string
+	Amount          float64
+	Network         string
+	Memo            string
+	WithdrawOrderID string
+}
+
+// Deposit holds a deposit record, status 5 is success
+type Deposit struct {
+	Amount        float64 `json:"amount,string"`
+	Coin          string  `json:"coin"`
+	Network       string  `json:"network"`
+	Status        int64   `json:"status"`
+	Address       string  `json:"address"`
+	Memo          string  `json:"memo"`
+	TransactionID string  `json:"txId"`
+	InsertTime    int64   `json:"insertTime"`
+}
+
+// Withdrawal holds a withdrawal record, status 7 is success
+type Withdrawal struct {
+	ID             string  `json:"id"`
+	TransactionID  string  `json:"txId"`
+	Coin           string  `json:"coin"`
+	Network        string  `json:"network"`
+	Address        string  `json:"address"`
+	Amount         float64 `json:"amount,string"`
+	Status         int64   `json:"status"`
+	TransactionFee float64 `json:"transactionFee,string"`
+	ApplyTime      int64   `json:"applyTime"`
+	Memo           string  `json:"memo"`
+}
+
+// depositStatus maps deposit status codes
+var depositStatus = map[int64]string{
+	1: "SMALL",
+	2: "TIME_DELAY",
+	3: "LARGE_DELAY",
+	4: "PENDING",
+	5: "SUCCESS",
+	6: "AUDITING",
+	7: "REJECTED",
+}
+
+// withdrawalStatus maps withdrawal status codes
+var withdrawalStatus = map[int64]string{
+	1:  "APPLY",
+	2:  "AUDITING",
+	3:  "WAIT",
+	4:  "PROCESSING",
+	5:  "WAIT_PACKAGING",
+	6:  "WAIT_CONFIRM",
+	7:  "SUCCESS",
+	8:  "FAILED",
+	9:  "CANCEL",
+	10: "MANUAL",
+}
+
+// WsRequest is a websocket subscription or ping message
+type WsRequest struct {
+	Method string   `json:"method"`
+	Params []string `json:"params,omitempty"`
+}
+
+// WsResponse is a websocket message. Channel, Data and Symbol are set for
+// pushed data, Code and Msg are set for request responses.
+type WsResponse struct {
+	ID      int64           `json:"id"`
+	Code    int64           `json:"code"`
+	Msg     string          `json:"msg"`
+	Channel string          `json:"c"`
+	Symbol  string          `json:"s"`
+	Time    int64           `json:"t"`
+	Data    json.RawMessage `json:"d"`
+}
+
+// WsDeal holds a public trade, side 1 is buy and 2 is sell
+type WsDeal struct {
+	Side     int64   `json:"S"`
+	Price    float64 `json:"p,string"`
+	Quantity float64 `json:"v,string"`
+	Time     int64   `json:"t"`
+}
+
+// WsDeals holds public trades
+type WsDeals struct {
+	Deals []WsDeal `json:"deals"`
+	Event string   `json:"e"`
+}
+
+// WsBookTicker holds the best bid and ask
+type WsBookTicker struct {
+	Pair      pair.CurrencyPair `json:"-"`
+	Timestamp time.Time         `json:"-"`
+	AskQty    float64           `json:"A,string"`
+	BidQty    float64           `json:"B,string"`
+	AskPrice  float64           `json:"a,string"`
+	BidPrice  float64           `json:"b,string"`
+}
+
+// WsDepthLevel holds an orderbook level
+type WsDepthLevel struct {
+	Price    float64 `json:"p,string"`
+	Quantity float64 `json:"v,string"`
+}
+
+// WsDepth holds a partial orderbook snapshot
+type WsDepth struct {
+	Asks    []WsDepthLevel `json:"asks"`
+	Bids    []WsDepthLevel `json:"bids"`
+	Event   string         `json:"e"`
+	Version string         `json:"r"`
+}
+
+// WsOrder holds a private order update. Side 1 is buy and 2 is sell, status
+// 1 is new, 2 filled, 3 partially filled, 4 cancelled and 5 partially
+// cancelled.
+type WsOrder struct {
+	RemainAmount     float64 `json:"A"`
+	CreateTime       int64   `json:"O"`
+	Side             int64   `json:"S"`
+	RemainQuantity   float64 `json:"V"`
+	Amount           float64 `json:"a"`
+	ClientOrderID    string  `json:"c"`
+	OrderID          string  `json:"i"`
+	IsMaker          int64   `json:"m"`
+	OrderType        int64   `json:"o"`
+	Price            float64 `json:"p"`
+	Status           int64   `json:"s"`
+	Quantity         float64 `json:"v"`
+	AveragePrice     float64 `json:"ap"`
+	CumulativeQty    float64 `json:"cv"`
+	CumulativeAmount float64 `json:"ca"`
+}
+
+// Liquidity returns whether the order update was a fill as the maker or taker
+func (w *WsOrder) Liquidity() exchange.Liquidity {
+	if w.IsMaker == 1 {
+		return exchange.Maker
+	}
+	return exchange.Taker
+}
+
+// WsAccount holds a private balance update
+type WsAccount struct {
+	Asset        string  `json:"a"`
+	ChangeTime   int64   `json:"c"`
+	Free         float64 `json:"f,string"`
+	FreeChange   float64 `json:"fd,string"`
+	Locked       float64 `json:"l,string"`
+	LockedChange float64 `json:"ld,string"`
+	ChangeType   string  `json:"o"`
+}
@@ -0,0 +1,449 @@
+package mexc
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/assets"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+const (
+	mexcWebsocketURL = "wss://wbs.mexc.com/ws"
+
+	// Public channels, the symbol is appended after an @
+	mexcWsDeals      = "public.deals.v3.api"
+	mexcWsBookTicker = "public.bookTicker.v3.api"
+	mexcWsDepth      = "public.limit.depth.v3.api"
+
+	// Private channels, these require a listen key on the connection
+	mexcWsOrders  = "private.orders.v3.api"
+	mexcWsAccount = "private.account.v3.api"
+
+	mexcWsChannelPrefix = "spot@"
+	mexcWsDepthLevels   = "20"
+
+	mexcWsSubscribe   = "SUBSCRIPTION"
+	mexcWsUnsubscribe = "UNSUBSCRIPTION"
+	mexcWsPing        = "PING"
+
+	mexcWsPingInterval = time.Second * 30
+	// Listen keys expire after 60 minutes
+	mexcWsListenKeyKeepAlive = time.Minute * 30
+	// MEXC limits a connection to 30 subscriptions
+	mexcWsMaxSubscriptions = 30
+)
+
+// WsConnect initiates a websocket connection, when authenticated a listen
+// key is created so private channels can be subscribed to on the same
+// connection
+func (m *MEXC) WsConnect() error {
+	if !m.Websocket.IsEnabled() || !m.IsEnabled() {
+		return errors.New(exchange.WebsocketNotEnabled)
+	}
+
+	var dialer websocket.Dialer
+	dialer.TLSClientConfig = m.GetTLSConfig()
+	dialer.NetDial = m.GetWebsocketNetDial()
+	if m.Websocket.GetProxyAddress() != "" {
+		proxy, err := url.Parse(m.Websocket.GetProxyAddress())
+		if err != nil {
+			return err
+		}
+		dialer.Proxy = http.ProxyURL(proxy)
+	}
+
+	endpoint := m.Websocket.GetWebsocketURL()
+	m.listenKey = ""
+	if m.AuthenticatedAPISupport {
+		listenKey, err := m.CreateListenKey()
+		if err != nil {
+			return fmt.Errorf("%s unable to create listen key. Error: %s",
+				m.Name,
+				err)
+		}
+		m.listenKey = listenKey
+
+		params := url.Values{}
+		params.Set("listenKey", listenKey)
+		endpoint = common.EncodeURLValues(endpoint, params)
+	}
+
+	var err error
+	m.WebsocketConn, _, err = dialer.Dial(endpoint, http.Header{})
+	if err != nil {
+		return fmt.Errorf("%s unable to connect to websocket. Error: %s",
+			m.Name,
+			err)
+	}
+
+	go m.WsReadData()
+	go m.wsPingHandler()
+	go m.WsHandleData()
+	if m.listenKey != "" {
+		go m.wsListenKeyHandler()
+	}
+
+	err = m.wsSubscribePrivate()
+	if err != nil {
+		return fmt.Errorf("%s could not subscribe to websocket channels. Error: %s",
+			m.Name,
+			err)
+	}
+	return nil
+}
+
+// wsWrite sends a JSON message over the websocket connection
+func (m *MEXC) wsWrite(data interface{}) error {
+	if m.WebsocketConn == nil {
+		return errors.New("websocket connection not established")
+	}
+
+	payload, err := common.JSONEncode(data)
+	if err != nil {
+		return err
+	}
+
+	m.wsWriteLock.Lock()
+	defer m.wsWriteLock.Unlock()
+	return m.WebsocketConn.WriteMessage(websocket.TextMessage, payload)
+}
+
+// wsDefaultSubscriptions returns the deals, book ticker and depth channels of
+// all enabled pairs
+func (m *MEXC) wsDefaultSubscriptions() []exchange.ChannelSubscription {
+	var subs []exchange.ChannelSubscription
+	for _, p := range m.GetEnabledCurrencies() {
+		for _, channel := range []string{mexcWsDeals, mexcWsBookTicker, mexcWsDepth} {
+			subs = append(subs, exchange.ChannelSubscription{
+				Channel:   channel,
+				Currency:  p,
+				AssetType: assets.Spot,
+			})
+		}
+	}
+	return subs
+}
+
+// wsChannels returns the channel names of subscriptions
+func (m *MEXC) wsChannels(subs []exchange.ChannelSubscription) []string {
+	var channels []string
+	for i := range subs {
+		channel := mexcWsChannelPrefix + subs[i].Channel
+		if !subs[i].Currency.Empty() {
+			channel += "@" + exchange.FormatExchangeCurrency(m.Name, subs[i].Currency).String()
+		}
+		if subs[i].Channel == mexcWsDepth {
+			channel += "@" + mexcWsDepthLevels
+		}
+		channels = append(channels, channel)
+	}
+	return channels
+}
+
+// wsPrivateChannels returns the private order and account channels when
+// authenticated
+func (m *MEXC) wsPrivateChannels() []string {
+	if m.listenKey == "" {
+		return nil
+	}
+	return []string{
+		mexcWsChannelPrefix + mexcWsOrders,
+		mexcWsChannelPrefix + mexcWsAccount,
+	}
+}
+
+// wsSubscribePrivate subscribes to the private channels when authenticated
+func (m *MEXC) wsSubscribePrivate() error {
+	channels := m.wsPrivateChannels()
+	if len(channels) == 0 {
+		return nil
+	}
+	return m.wsWrite(WsRequest{Method: mexcWsSubscribe, Params: channels})
+}
+
+// WsSubscribe subscribes to public channels. Channels beyond the per
+// connection limit, which includes the private channels, are not subscribed.
+func (m *MEXC) WsSubscribe(subs []exchange.ChannelSubscription) error {
+	channels := m.wsChannels(subs)
+	limit := mexcWsMaxSubscriptions - len(m.wsPrivateChannels())
+	if len(channels) > limit {
+		m.Websocket.DataHandler <- fmt.Sprintf("%s websocket subscription limit of %d reached, %d channels not subscribed",
+			m.Name,
+			mexcWsMaxSubscriptions,
+			len(channels)-limit)
+		channels = channels[:limit]
+	}
+
+	if len(channels) == 0 {
+		return nil
+	}
+	return m.wsWrite(WsRequest{Method: mexcWsSubscribe, Params: channels})
+}
+
+// WsUnsubscribe unsubscribes from public channels
+func (m *MEXC) WsUnsubscribe(subs []exchange.ChannelSubscription) error {
+	channels := m.wsChannels(subs)
+	if len(channels) == 0 {
+		return nil
+	}
+	return m.wsWrite(WsRequest{Method: mexcWsUnsubscribe, Params: channels})
+}
+
+// WsReadData reads data from the websocket connection
+func (m *MEXC) WsReadData() {
+	m.Websocket.Wg.Add(1)
+
+	defer func() {
+		err := m.WebsocketConn.Close()
+		if err != nil {
+			m.Websocket.DataHandler <- fmt.Errorf("mexc_websocket.go - Unable to to close Websocket connection. Error: %s",
+				err)
+		}
+		m.Websocket.Wg.Done()
+	}()
+
+	for {
+		select {
+		case <-m.Websocket.ShutdownC:
+			return
+
+		default:
+			_, resp, err := m.WebsocketConn.ReadMessage()
+			if err != nil {
+				m.Websocket.DataHandler <- err
+				return
+			}
+
+			m.Websocket.TrafficAlert <- struct{}{}
+			m.Websocket.Intercomm <- exchange.WebsocketResponse{Raw: resp}
+		}
+	}
+}
+
+// wsPingHandler keeps the connection alive, MEXC closes connections which
+// are idle for 60 seconds
+func (m *MEXC) wsPingHandler() {
+	m.Websocket.Wg.Add(1)
+	defer m.Websocket.Wg.Done()
+
+	t := time.NewTicker(mexcWsPingInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-m.Websocket.ShutdownC:
+			return
+
+		case <-t.C:
+			err := m.wsWrite(WsRequest{Method: mexcWsPing})
+			if err != nil {
+				m.Websocket.DataHandler <- err
+				return
+			}
+		}
+	}
+}
+
+// wsListenKeyHandler extends the listen key before it expires
+func (m *MEXC) wsListenKeyHandler() {
+	m.Websocket.Wg.Add(1)
+	defer m.Websocket.Wg.Done()
+
+	t := time.NewTicker(mexcWsListenKeyKeepAlive)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-m.Websocket.ShutdownC:
+			return
+
+		case <-t.C:
+			err := m.KeepAliveListenKey(m.listenKey)
+			if err != nil {
+				m.Websocket.DataHandler <- fmt.Sprintf("%s unable to keep listen key alive: %s",
+					m.Name,
+					err)
+			}
+		}
+	}
+}
+
+// WsHandleData handles the read data from the websocket connection
+func (m *MEXC) WsHandleData() {
+	m.Websocket.Wg.Add(1)
+	defer m.Websocket.Wg.Done()
+
+	for {
+		select {
+		case <-m.Websocket.ShutdownC:
+			return
+
+		case resp := <-m.Websocket.Intercomm:
+			err := m.wsHandleMessage(resp.Raw)
+			if err != nil {
+				m.Websocket.DataHandler <- fmt.Sprintf("%s websocket handling error: %s",
+					m.Name,
+					err)
+			}
+		}
+	}
+}
+
+// wsHandleMessage routes a single websocket message
+func (m *MEXC) wsHandleMessage(raw []byte) error {
+	var resp WsResponse
+	err := common.JSONDecode(raw, &resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Channel == "" {
+		// Subscription and ping responses
+		if resp.Code != 0 {
+			return fmt.Errorf("code %d: %s", resp.Code, resp.Msg)
+		}
+		return nil
+	}
+
+	parts := strings.Split(resp.Channel, "@")
+	if len(parts) < 2 {
+		return fmt.Errorf("unhandled channel %s", resp.Channel)
+	}
+
+	switch parts[1] {
+	case mexcWsDeals:
+		return m.wsProcessDeals(resp)
+	case mexcWsBookTicker:
+		return m.wsProcessBookTicker(resp)
+	case mexcWsDepth:
+		return m.wsProcessDepth(resp)
+	case mexcWsOrders:
+		var order WsOrder
+		err = common.JSONDecode(resp.Data, &order)
+		if err != nil {
+			return err
+		}
+		m.Websocket.DataHandler <- order
+	case mexcWsAccount:
+		var account WsAccount
+		err = common.JSONDecode(resp.Data, &account)
+		if err != nil {
+			return err
+		}
+		m.Websocket.DataHandler <- account
+	}
+	return nil
+}
+
+// pairFromSymbol returns the enabled pair matching an exchange symbol, MEXC
+// symbols have no delimiter so they cannot be split reliably
+func (m *MEXC) pairFromSymbol(symbol string) (pair.CurrencyPair, error) {
+	for _, p := range m.GetEnabledCurrencies() {
+		if exchange.FormatExchangeCurrency(m.Name, p).String() == symbol {
+			return p, nil
+		}
+	}
+	return pair.CurrencyPair{}, fmt.Errorf("symbol %s is not enabled", symbol)
+}
+
+// wsProcessDeals sends trades to the data handler
+func (m *MEXC) wsProcessDeals(resp WsResponse) error {
+	var deals WsDeals
+	err := common.JSONDecode(resp.Data, &deals)
+	if err != nil {
+		return err
+	}
+
+	p, err := m.pairFromSymbol(resp.Symbol)
+	if err != nil {
+		return err
+	}
+
+	for i := range deals.Deals {
+		side := "buy"
+		if deals.Deals[i].Side == 2 {
+			side = "sell"
+		}
+
+		m.Websocket.DataHandler <- exchange.TradeData{
+			Timestamp:    common.UnixTimestampToUTC(deals.Deals[i].Time),
+			CurrencyPair: p,
+			AssetType:    ticker.Spot,
+			Exchange:     m.Name,
+			Price:        deals.Deals[i].Price,
+			Amount:       deals.Deals[i].Quantity,
+			Side:         side,
+		}
+	}
+	return nil
+}
+
+// wsProcessBookTicker sends a best bid and ask update to the data handler,
+// TickerData has no bid and ask fields so the update is sent as is
+func (m *MEXC) wsProcessBookTicker(resp WsResponse) error {
+	var t WsBookTicker
+	err := common.JSONDecode(resp.Data, &t)
+	if err != nil {
+		return err
+	}
+
+	p, err := m.pairFromSymbol(resp.Symbol)
+	if err != nil {
+		return err
+	}
+
+	t.Pair = p
+	t.Timestamp = common.UnixTimestampToUTC(resp.Time)
+	m.Websocket.DataHandler <- t
+	return nil
+}
+
+// wsProcessDepth processes a partial orderbook snapshot. MEXC pushes the
+// full snapshot each time so the local websocket orderbook cache, which is
+// built for incremental updates, is bypassed.
+func (m *MEXC) wsProcessDepth(resp WsResponse) error {
+	var depth WsDepth
+	err := common.JSONDecode(resp.Data, &depth)
+	if err != nil {
+		return err
+	}
+
+	p, err := m.pairFromSymbol(resp.Symbol)
+	if err != nil {
+		return err
+	}
+
+	var newOrderbook orderbook.Base
+	for i := range depth.Bids {
+		newOrderbook.Bids = append(newOrderbook.Bids,
+			orderbook.Item{Price: depth.Bids[i].Price, Amount: depth.Bids[i].Quantity})
+	}
+	for i := range depth.Asks {
+		newOrderbook.Asks = append(newOrderbook.Asks,
+			orderbook.Item{Price: depth.Asks[i].Price, Amount: depth.Asks[i].Quantity})
+	}
+
+	newOrderbook.Pair = p
+	newOrderbook.CurrencyPair = resp.Symbol
+	newOrderbook.AssetType = ticker.Spot
+	newOrderbook.LastUpdated = common.UnixTimestampToUTC(resp.Time)
+
+	orderbook.ProcessOrderbook(m.Name, p, newOrderbook, ticker.Spot)
+
+	m.Websocket.DataHandler <- exchange.WebsocketOrderbookUpdate{
+		Pair:     p,
+		Asset:    ticker.Spot,
+		Exchange: m.Name,
+	}
+	return nil
+}
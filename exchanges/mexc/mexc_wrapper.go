@@ -0,0 +1,417 @@
+package mexc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+// mexcCancelSymbolLimit is the maximum number of symbols per cancel open
+// orders request
+const mexcCancelSymbolLimit = 5
+
+// Start starts the MEXC wrapper, refreshing its currency pairs
+func (m *MEXC) Start(ctx context.Context) error {
+	return m.StartWrapper(ctx, m.Run)
+}
+
+// Run implements the MEXC wrapper
+func (m *MEXC) Run(ctx context.Context) error {
+	if m.Verbose {
+		log.Printf("%s Websocket: %s. (url: %s).\n", m.GetName(), common.IsEnabled(m.Websocket.IsEnabled()), m.Websocket.GetWebsocketURL())
+		log.Printf("%s polling delay: %ds.\n", m.GetName(), m.RESTPollingDelay)
+		log.Printf("%s %d currencies enabled: %s.\n", m.GetName(), len(m.EnabledPairs), m.EnabledPairs)
+	}
+
+	info, err := m.GetExchangeInfo("")
+	if err != nil {
+		log.Printf("%s failed to obtain available symbols. Err: %s", m.Name, err)
+		return nil
+	}
+
+	var pairs []string
+	for i := range info.Symbols {
+		if !info.Symbols[i].IsSpotTradingAllowed {
+			continue
+		}
+		pairs = append(pairs, info.Symbols[i].BaseAsset+"-"+info.Symbols[i].QuoteAsset)
+	}
+
+	err = m.UpdateCurrencies(pairs, false, false)
+	if err != nil {
+		log.Printf("%s failed to update available currencies. Err: %s", m.Name, err)
+	}
+	return nil
+}
+
+// UpdateTicker updates and returns the ticker for a currency pair
+func (m *MEXC) UpdateTicker(p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	return m.UpdateTickerContext(context.Background(), p, assetType)
+}
+
+// UpdateTickerContext updates and returns the ticker for a currency pair,
+// cancelled with the context
+func (m *MEXC) UpdateTickerContext(ctx context.Context, p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	var tickerPrice ticker.Price
+	tickers, err := m.GetTickersContext(ctx)
+	if err != nil {
+		return tickerPrice, err
+	}
+
+	tickerMap := make(map[string]*Ticker, len(tickers))
+	for i := range tickers {
+		tickerMap[tickers[i].Symbol] = &tickers[i]
+	}
+
+	for _, x := range m.GetEnabledCurrencies() {
+		t, ok := tickerMap[exchange.FormatExchangeCurrency(m.Name, x).String()]
+		if !ok {
+			continue
+		}
+		ticker.ProcessTicker(m.GetName(), x, ticker.Price{
+			Pair:        x,
+			Last:        t.LastPrice,
+			High:        t.HighPrice,
+			Low:         t.LowPrice,
+			Bid:         t.BidPrice,
+			Ask:         t.AskPrice,
+			Volume:      t.Volume,
+			LastUpdated: common.UnixTimestampToUTC(t.CloseTime),
+		}, assetType)
+	}
+	return ticker.GetTicker(m.Name, p, assetType)
+}
+
+// GetTickerPrice returns the ticker for a currency pair
+func (m *MEXC) GetTickerPrice(p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	tickerNew, err := ticker.GetTicker(m.GetName(), p, assetType)
+	if err != nil {
+		return m.UpdateTicker(p, assetType)
+	}
+	return tickerNew, nil
+}
+
+// GetOrderbookEx returns orderbook base on the currency pair
+func (m *MEXC) GetOrderbookEx(p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	ob, err := orderbook.GetOrderbook(m.GetName(), p, assetType)
+	if err != nil {
+		return m.UpdateOrderbook(p, assetType)
+	}
+	return ob, nil
+}
+
+// UpdateOrderbook updates and returns the orderbook for a currency pair
+func (m *MEXC) UpdateOrderbook(p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	return m.UpdateOrderbookContext(context.Background(), p, assetType)
+}
+
+// UpdateOrderbookContext updates and returns the orderbook for a currency
+// pair, cancelled with the context
+func (m *MEXC) UpdateOrderbookContext(ctx context.Context, p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	var orderBook orderbook.Base
+	orderbookNew, err := m.GetOrderbookContext(ctx, exchange.FormatExchangeCurrency(m.Name, p).String(), 1000)
+	if err != nil {
+		return orderBook, err
+	}
+
+	for x := range orderbookNew.Bids {
+		orderBook.Bids = append(orderBook.Bids, orderbook.Item{
+			Amount: orderbookNew.Bids[x].Quantity,
+			Price:  orderbookNew.Bids[x].Price,
+		})
+	}
+
+	for x := range orderbookNew.Asks {
+		orderBook.Asks = append(orderBook.Asks, orderbook.Item{
+			Amount: orderbookNew.Asks[x].Quantity,
+			Price:  orderbookNew.Asks[x].Price,
+		})
+	}
+
+	orderbook.ProcessOrderbook(m.GetName(), p, orderBook, assetType)
+	return orderbook.GetOrderbook(m.Name, p, assetType)
+}
+
+// GetAccountInfo retrieves balances for all currencies
+func (m *MEXC) GetAccountInfo() (exchange.AccountInfo, error) {
+	return m.GetAccountInfoContext(context.Background())
+}
+
+// GetAccountInfoContext retrieves balances for all currencies, cancelled with
+// the context
+func (m *MEXC) GetAccountInfoContext(ctx context.Context) (exchange.AccountInfo, error) {
+	var info exchange.AccountInfo
+	account, err := m.GetAccountContext(ctx)
+	if err != nil {
+		return info, err
+	}
+
+	for i := range account.Balances {
+		info.Currencies = append(info.Currencies, exchange.AccountCurrencyInfo{
+			CurrencyName: account.Balances[i].Asset,
+			TotalValue:   account.Balances[i].Free + account.Balances[i].Locked,
+			Hold:         account.Balances[i].Locked,
+		})
+	}
+
+	info.ExchangeName = m.GetName()
+	return info, nil
+}
+
+// GetFundingHistory returns funding history, deposits and
+// withdrawals
+func (m *MEXC) GetFundingHistory() ([]exchange.FundHistory, error) {
+	var fundHistory []exchange.FundHistory
+	deposits, err := m.GetDeposits("")
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range deposits {
+		fundHistory = append(fundHistory, exchange.FundHistory{
+			ExchangeName:    m.Name,
+			Status:          depositStatus[deposits[i].Status],
+			Timestamp:       common.UnixTimestampToUTC(deposits[i].InsertTime),
+			Currency:        deposits[i].Coin,
+			Amount:          deposits[i].Amount,
+			TransferType:    "deposit",
+			CryptoToAddress: deposits[i].Address,
+			CryptoTxID:      deposits[i].TransactionID,
+		})
+	}
+
+	withdrawals, err := m.GetWithdrawals("")
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range withdrawals {
+		fundHistory = append(fundHistory, exchange.FundHistory{
+			ExchangeName:    m.Name,
+			Status:          withdrawalStatus[withdrawals[i].Status],
+			Timestamp:       common.UnixTimestampToUTC(withdrawals[i].ApplyTime),
+			Currency:        withdrawals[i].Coin,
+			Amount:          withdrawals[i].Amount,
+			Fee:             withdrawals[i].TransactionFee,
+			TransferType:    "withdrawal",
+			CryptoToAddress: withdrawals[i].Address,
+			CryptoTxID:      withdrawals[i].TransactionID,
+		})
+	}
+	return fundHistory, nil
+}
+
+// GetExchangeHistory returns the most recent public trades
+func (m *MEXC) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]exchange.TradeHistory, error) {
+	trades, err := m.GetTrades(exchange.FormatExchangeCurrency(m.Name, p).String(), 0)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]exchange.TradeHistory, len(trades))
+	for i := range trades {
+		side := "buy"
+		if trades[i].IsBuyerMaker {
+			side = "sell"
+		}
+		resp[i] = exchange.TradeHistory{
+			Timestamp: common.UnixTimestampToUTC(trades[i].Time),
+			Price:     trades[i].Price,
+			Amount:    trades[i].Quantity,
+			Exchange:  m.Name,
+			Type:      side,
+		}
+	}
+	return resp, nil
+}
+
+// buildOrder converts order parameters into a spot order request, amount is
+// always in the base currency
+func (m *MEXC) buildOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (NewOrderRequest, error) {
+	req := NewOrderRequest{
+		Symbol:        exchange.FormatExchangeCurrency(m.Name, p).String(),
+		Quantity:      amount,
+		ClientOrderID: m.BrokerClientOrderID(clientID),
+	}
+
+	switch side {
+	case exchange.Buy:
+		req.Side = "BUY"
+	case exchange.Sell:
+		req.Side = "SELL"
+	default:
+		return req, fmt.Errorf("unsupported order side %s", side)
+	}
+
+	switch orderType {
+	case exchange.Limit:
+		req.Type = "LIMIT"
+		req.Price = price
+	case exchange.Market:
+		req.Type = "MARKET"
+	case exchange.ImmediateOrCancel:
+		req.Type = "IMMEDIATE_OR_CANCEL"
+		req.Price = price
+	default:
+		return req, fmt.Errorf("unsupported order type %s", orderType)
+	}
+	return req, nil
+}
+
+// SubmitOrder submits a new spot order
+func (m *MEXC) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	return m.SubmitOrderContext(context.Background(), p, side, orderType, amount, price, clientID)
+}
+
+// SubmitOrderContext submits a new spot order, cancelled with the context
+func (m *MEXC) SubmitOrderContext(ctx context.Context, p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	if m.IsSimulated() {
+		return m.SimulateSubmitOrder(p, side, orderType, amount, price, clientID)
+	}
+
+	var submitOrderResponse exchange.SubmitOrderResponse
+	req, err := m.buildOrder(p, side, orderType, amount, price, clientID)
+	if err != nil {
+		return submitOrderResponse, err
+	}
+
+	resp, err := m.NewOrderContext(ctx, req)
+	if err != nil {
+		return submitOrderResponse, err
+	}
+
+	submitOrderResponse.OrderID = resp.OrderID
+	submitOrderResponse.IsOrderPlaced = true
+	return submitOrderResponse, nil
+}
+
+// ModifyOrder will allow of changing orderbook placement and limit to
+// market conversion
+func (m *MEXC) ModifyOrder(action exchange.ModifyOrder) (string, error) {
+	if m.IsSimulated() {
+		return m.SimulateModifyOrder(action)
+	}
+
+	return "", common.ErrFunctionNotSupported
+}
+
+// CancelOrder cancels an order by its corresponding ID number
+func (m *MEXC) CancelOrder(order exchange.OrderCancellation) error {
+	return m.CancelOrderContext(context.Background(), order)
+}
+
+// CancelOrderContext cancels an order by its corresponding ID number,
+// cancelled with the context
+func (m *MEXC) CancelOrderContext(ctx context.Context, order exchange.OrderCancellation) error {
+	if m.IsSimulated() {
+		return m.SimulateCancelOrder(order)
+	}
+
+	_, err := m.CancelExistingOrderContext(ctx, exchange.FormatExchangeCurrency(m.Name, order.CurrencyPair).String(),
+		order.OrderID)
+	return err
+}
+
+// CancelAllOrders cancels all spot orders for all enabled currencies
+func (m *MEXC) CancelAllOrders(orderCancellation exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
+	if m.IsSimulated() {
+		return m.SimulateCancelAllOrders(orderCancellation)
+	}
+
+	cancelAllOrdersResponse := exchange.CancelAllOrdersResponse{
+		OrderStatus: make(map[string]string),
+	}
+
+	var symbols []string
+	for _, p := range m.GetEnabledCurrencies() {
+		symbols = append(symbols, exchange.FormatExchangeCurrency(m.Name, p).String())
+	}
+
+	for i := 0; i < len(symbols); i += mexcCancelSymbolLimit {
+		end := i + mexcCancelSymbolLimit
+		if end > len(symbols) {
+			end = len(symbols)
+		}
+
+		batch := strings.Join(symbols[i:end], ",")
+		_, err := m.CancelOpenOrders(batch)
+		if err != nil {
+			cancelAllOrdersResponse.OrderStatus[batch] = err.Error()
+		}
+	}
+
+	return cancelAllOrdersResponse, nil
+}
+
+// GetOrderInfo returns information on a current open order. MEXC order IDs
+// are not numeric, use QueryOrder to look up an order by its ID.
+func (m *MEXC) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
+	if m.IsSimulated() {
+		return m.SimulateGetOrderInfo(orderID)
+	}
+
+	var orderDetail exchange.OrderDetail
+	return orderDetail, common.ErrFunctionNotSupported
+}
+
+// GetDepositAddress returns a deposit address for a specified currency
+func (m *MEXC) GetDepositAddress(cryptocurrency pair.CurrencyItem) (string, error) {
+	addresses, err := m.GetCurrencyDepositAddresses(cryptocurrency.Upper().String(), "")
+	if err != nil {
+		return "", err
+	}
+	if len(addresses) == 0 {
+		return "", errors.New("no deposit address found, one must be created on the exchange")
+	}
+	return addresses[0].Address, nil
+}
+
+// WithdrawCryptocurrencyFunds returns a withdrawal ID when a withdrawal is
+// submitted, the currency's default network is used
+func (m *MEXC) WithdrawCryptocurrencyFunds(address string, cryptocurrency pair.CurrencyItem, amount float64) (string, error) {
+	return m.Withdraw(WithdrawalRequest{
+		Coin:    cryptocurrency.Upper().String(),
+		Address: address,
+		Amount:  amount,
+	})
+}
+
+// WithdrawFiatFunds returns a withdrawal ID when a
+// withdrawal is submitted
+func (m *MEXC) WithdrawFiatFunds(currency pair.CurrencyItem, amount float64) (string, error) {
+	return "", common.ErrFunctionNotSupported
+}
+
+// WithdrawFiatFundsToInternationalBank returns a withdrawal ID when a
+// withdrawal is submitted
+func (m *MEXC) WithdrawFiatFundsToInternationalBank(currency pair.CurrencyItem, amount float64) (string, error) {
+	return "", common.ErrFunctionNotSupported
+}
+
+// GetWebsocket returns a pointer to the exchange websocket
+func (m *MEXC) GetWebsocket() (*exchange.Websocket, error) {
+	return m.Websocket, nil
+}
+
+// GetFeeByType returns an estimate of fee based on type of transaction
+func (m *MEXC) GetFeeByType(feeBuilder exchange.FeeBuilder) (float64, error) {
+	return m.GetFee(feeBuilder)
+}
+
+// GetWithdrawCapabilities returns the types of withdrawal methods permitted by the exchange
+func (m *MEXC) GetWithdrawCapabilities() uint32 {
+	return m.GetWithdrawPermissions()
+}
+
+// CheckSystemStatus pings the REST API
+func (m *MEXC) CheckSystemStatus() error {
+	return m.Ping()
+}
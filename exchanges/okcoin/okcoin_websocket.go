@@ -52,6 +52,8 @@ func (o *OKCoin) WsConnect() error {
 		"2hour", "4hour", "6hour", "12hour", "day", "3day", "week"}
 
 	var dialer websocket.Dialer
+	dialer.TLSClientConfig = o.GetTLSConfig()
+	dialer.NetDial = o.GetWebsocketNetDial()
 
 	if o.Websocket.GetProxyAddress() != "" {
 		proxy, err := url.Parse(o.Websocket.GetProxyAddress())
@@ -171,7 +173,7 @@ func (o *OKCoin) WsHandleData() {
 				}
 
 				o.Websocket.DataHandler <- exchange.TickerData{
-					Timestamp:  time.Unix(0, ticker.Timestamp),
+					Timestamp:  common.UnixTimestampToUTC(ticker.Timestamp),
 					Pair:       pair.NewCurrencyPairFromString(currencyPair),
 					AssetType:  assetType,
 					Exchange:   o.GetName(),
@@ -220,7 +222,7 @@ func (o *OKCoin) WsHandleData() {
 
 				for _, data := range klines {
 					o.Websocket.DataHandler <- exchange.KlineData{
-						Timestamp:  time.Unix(0, data.Timestamp),
+						Timestamp:  common.UnixTimestampToUTC(data.Timestamp),
 						Pair:       pair.NewCurrencyPairFromString(currencyPair),
 						AssetType:  assetType,
 						Exchange:   o.GetName(),
@@ -1,11 +1,11 @@
 package okcoin
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
 	"strconv"
-	"sync"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
@@ -14,17 +14,13 @@ import (
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
 
-// Start starts the OKCoin go routine
-func (o *OKCoin) Start(wg *sync.WaitGroup) {
-	wg.Add(1)
-	go func() {
-		o.Run()
-		wg.Done()
-	}()
+// Start starts the OKCoin wrapper, refreshing its currency pairs
+func (o *OKCoin) Start(ctx context.Context) error {
+	return o.StartWrapper(ctx, o.Run)
 }
 
 // Run implements the OKCoin wrapper
-func (o *OKCoin) Run() {
+func (o *OKCoin) Run(ctx context.Context) error {
 	if o.Verbose {
 		log.Printf("%s Websocket: %s. (url: %s).\n", o.GetName(), common.IsEnabled(o.Websocket.IsEnabled()), o.WebsocketURL)
 		log.Printf("%s polling delay: %ds.\n", o.GetName(), o.RESTPollingDelay)
@@ -63,6 +59,7 @@ func (o *OKCoin) Run() {
 			}
 		}
 	}
+	return nil
 }
 
 // UpdateTicker updates and returns the ticker for a currency pair
@@ -194,6 +191,10 @@ func (o *OKCoin) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]ex
 
 // SubmitOrder submits a new order
 func (o *OKCoin) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	if o.IsSimulated() {
+		return o.SimulateSubmitOrder(p, side, orderType, amount, price, clientID)
+	}
+
 	var submitOrderResponse exchange.SubmitOrderResponse
 	var oT string
 	if orderType == exchange.Limit {
@@ -228,11 +229,19 @@ func (o *OKCoin) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, order
 // ModifyOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (o *OKCoin) ModifyOrder(action exchange.ModifyOrder) (string, error) {
+	if o.IsSimulated() {
+		return o.SimulateModifyOrder(action)
+	}
+
 	return "", common.ErrFunctionNotSupported
 }
 
 // CancelOrder cancels an order by its corresponding ID number
 func (o *OKCoin) CancelOrder(order exchange.OrderCancellation) error {
+	if o.IsSimulated() {
+		return o.SimulateCancelOrder(order)
+	}
+
 	orderIDInt, err := strconv.ParseInt(order.OrderID, 10, 64)
 	orders := []int64{orderIDInt}
 
@@ -247,6 +256,10 @@ func (o *OKCoin) CancelOrder(order exchange.OrderCancellation) error {
 
 // CancelAllOrders cancels all orders associated with a currency pair
 func (o *OKCoin) CancelAllOrders(orderCancellation exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
+	if o.IsSimulated() {
+		return o.SimulateCancelAllOrders(orderCancellation)
+	}
+
 	cancelAllOrdersResponse := exchange.CancelAllOrdersResponse{
 		OrderStatus: make(map[string]string),
 	}
@@ -278,6 +291,10 @@ func (o *OKCoin) CancelAllOrders(orderCancellation exchange.OrderCancellation) (
 
 // GetOrderInfo returns information on a current open order
 func (o *OKCoin) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
+	if o.IsSimulated() {
+		return o.SimulateGetOrderInfo(orderID)
+	}
+
 	var orderDetail exchange.OrderDetail
 	return orderDetail, common.ErrNotYetImplemented
 }
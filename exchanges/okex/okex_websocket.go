@@ -37,6 +37,8 @@ func (o *OKEX) WsConnect() error {
 	}
 
 	var dialer websocket.Dialer
+	dialer.TLSClientConfig = o.GetTLSConfig()
+	dialer.NetDial = o.GetWebsocketNetDial()
 
 	if o.Websocket.GetProxyAddress() != "" {
 		proxy, err := url.Parse(o.Websocket.GetProxyAddress())
@@ -237,7 +239,7 @@ func (o *OKEX) WsHandleData() {
 					}
 
 					o.Websocket.DataHandler <- exchange.TickerData{
-						Timestamp: time.Unix(0, int64(ticker.Timestamp)),
+						Timestamp: common.UnixTimestampToUTC(int64(ticker.Timestamp)),
 						Exchange:  o.GetName(),
 						AssetType: assetType,
 					}
@@ -283,7 +285,7 @@ func (o *OKEX) WsHandleData() {
 						volume, _ := strconv.ParseFloat(kline[5], 64)
 
 						o.Websocket.DataHandler <- exchange.KlineData{
-							Timestamp:  time.Unix(ntime, 0),
+							Timestamp:  common.UnixTimestampToUTC(ntime),
 							Pair:       pair.NewCurrencyPairFromString(newPair),
 							AssetType:  assetType,
 							Exchange:   o.GetName(),
@@ -1,11 +1,11 @@
 package okex
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
 	"strconv"
-	"sync"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
@@ -14,17 +14,13 @@ import (
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
 
-// Start starts the OKEX go routine
-func (o *OKEX) Start(wg *sync.WaitGroup) {
-	wg.Add(1)
-	go func() {
-		o.Run()
-		wg.Done()
-	}()
+// Start starts the OKEX wrapper, refreshing its currency pairs
+func (o *OKEX) Start(ctx context.Context) error {
+	return o.StartWrapper(ctx, o.Run)
 }
 
 // Run implements the OKEX wrapper
-func (o *OKEX) Run() {
+func (o *OKEX) Run(ctx context.Context) error {
 	if o.Verbose {
 		log.Printf("%s Websocket: %s. (url: %s).\n", o.GetName(), common.IsEnabled(o.Websocket.IsEnabled()), o.WebsocketURL)
 		log.Printf("%s polling delay: %ds.\n", o.GetName(), o.RESTPollingDelay)
@@ -34,7 +30,7 @@ func (o *OKEX) Run() {
 	prods, err := o.GetSpotInstruments()
 	if err != nil {
 		log.Printf("OKEX failed to obtain available spot instruments. Err: %d", err)
-		return
+		return nil
 	}
 
 	var pairs []string
@@ -46,6 +42,7 @@ func (o *OKEX) Run() {
 	if err != nil {
 		log.Printf("OKEX failed to update available currencies. Err: %s", err)
 	}
+	return nil
 }
 
 // UpdateTicker updates and returns the ticker for a currency pair
@@ -187,6 +184,10 @@ func (o *OKEX) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]exch
 
 // SubmitOrder submits a new order
 func (o *OKEX) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	if o.IsSimulated() {
+		return o.SimulateSubmitOrder(p, side, orderType, amount, price, clientID)
+	}
+
 	var submitOrderResponse exchange.SubmitOrderResponse
 	var oT SpotNewOrderRequestType
 
@@ -229,11 +230,19 @@ func (o *OKEX) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderTy
 // ModifyOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (o *OKEX) ModifyOrder(action exchange.ModifyOrder) (string, error) {
+	if o.IsSimulated() {
+		return o.SimulateModifyOrder(action)
+	}
+
 	return "", common.ErrFunctionNotSupported
 }
 
 // CancelOrder cancels an order by its corresponding ID number
 func (o *OKEX) CancelOrder(order exchange.OrderCancellation) error {
+	if o.IsSimulated() {
+		return o.SimulateCancelOrder(order)
+	}
+
 	orderIDInt, err := strconv.ParseInt(order.OrderID, 10, 64)
 	if err != nil {
 		return err
@@ -245,6 +254,10 @@ func (o *OKEX) CancelOrder(order exchange.OrderCancellation) error {
 
 // CancelAllOrders cancels all orders for all enabled currencies
 func (o *OKEX) CancelAllOrders(orderCancellation exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
+	if o.IsSimulated() {
+		return o.SimulateCancelAllOrders(orderCancellation)
+	}
+
 	cancelAllOrdersResponse := exchange.CancelAllOrdersResponse{
 		OrderStatus: make(map[string]string),
 	}
@@ -277,6 +290,10 @@ func (o *OKEX) CancelAllOrders(orderCancellation exchange.OrderCancellation) (ex
 
 // GetOrderInfo returns information on a current open order
 func (o *OKEX) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
+	if o.IsSimulated() {
+		return o.SimulateGetOrderInfo(orderID)
+	}
+
 	var orderDetail exchange.OrderDetail
 	return orderDetail, common.ErrNotYetImplemented
 }
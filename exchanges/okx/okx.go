@@ -0,0 +1,973 @@
+package okx
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/config"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/assets"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/request"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+const (
+	okxAPIURL     = "https://www.okx.com"
+	okxAPIVersion = "/api/v5/"
+
+	// Public endpoints
+	okxInstruments  = "public/instruments"
+	okxServerTime   = "public/time"
+	okxSystemStatus = "system/status"
+	okxMarkPrice    = "public/mark-price"
+	okxTickers      = "market/tickers"
+	okxTicker       = "market/ticker"
+	okxIndexTickers = "market/index-tickers"
+	okxOrderbook    = "market/books"
+	okxTrades       = "market/trades"
+	okxCandles      = "market/candles"
+
+	// Authenticated endpoints
+	okxAccountBalance    = "account/balance"
+	okxAccountConfig     = "account/config"
+	okxAccountPositions  = "account/positions"
+	okxAccountTradeFee   = "account/trade-fee"
+	okxPlaceOrder        = "trade/order"
+	okxCancelOrder       = "trade/cancel-order"
+	okxCancelBatchOrders = "trade/cancel-batch-orders"
+	okxAmendOrder        = "trade/amend-order"
+	okxPlaceAlgoOrder    = "trade/order-algo"
+	okxCancelAlgoOrders  = "trade/cancel-algos"
+	okxOrderDetail       = "trade/order"
+	okxPendingOrders     = "trade/orders-pending"
+	okxOrderHistory      = "trade/orders-history"
+	okxCurrencies        = "asset/currencies"
+	okxFundingBalances   = "asset/balances"
+	okxDepositAddress    = "asset/deposit-address"
+	okxWithdrawal        = "asset/withdrawal"
+	okxDepositHistory    = "asset/deposit-history"
+	okxWithdrawalHistory = "asset/withdrawal-history"
+	okxSavingsBalance    = "finance/savings/balance"
+	okxStakingOrders     = "finance/staking-defi/orders-active"
+	okxStakingPurchase   = "finance/staking-defi/purchase"
+	okxStakingRedeem     = "finance/staking-defi/redeem"
+
+	// OKX allows 20 requests per 2 seconds on most endpoints
+	okxAuthRate   = 20
+	okxUnauthRate = 20
+
+	okxMaxBatchCancel = 20
+	okxSuccessCode    = "0"
+	okxTimeLayout     = "2006-01-02T15:04:05.000Z"
+)
+
+// OKX is the overarching type across the OKX package
+type OKX struct {
+	exchange.Base
+	WebsocketConn        *websocket.Conn
+	WebsocketPrivateConn *websocket.Conn
+	wsWriteLock          sync.Mutex
+	wsPrivateWriteLock   sync.Mutex
+	wsRequests           map[string]chan wsOrderResponse
+	wsRequestsLock       sync.Mutex
+	wsRequestID          int64
+	wsIndexPrices        map[string]float64
+	wsIndexLock          sync.Mutex
+	wsBuffers            map[string]*orderbook.Buffer
+	wsBuffersLock        sync.Mutex
+
+	// Simulated routes requests to the demo trading environment
+	Simulated bool
+
+	instruments     map[string][]Instrument
+	instrumentsLock sync.Mutex
+}
+
+// SetDefaults sets the basic defaults for OKX
+func (o *OKX) SetDefaults() {
+	o.Name = "OKX"
+	o.Enabled = false
+	o.Verbose = false
+	o.RESTPollingDelay = 10
+	o.APIWithdrawPermissions = exchange.AutoWithdrawCryptoWithAPIPermission
+	o.BrokerTagFormat = exchange.BrokerTagFormat{MaxLength: 16}
+	o.Features = exchange.Features{
+		CanGetTicker:         true,
+		CanGetOrderbook:      true,
+		CanGetTradeHistory:   true,
+		CanGetAccountInfo:    true,
+		CanGetFundingHistory: true,
+		CanSubmitOrder:       true,
+		IdempotentClientID:   true,
+		CanModifyOrder:       true,
+		CanCancelOrder:       true,
+		CanCancelAllOrders:   true,
+		CanGetOrderInfo:      true,
+		CanGetDepositAddress: true,
+		CanWithdrawCrypto:    true,
+		CanStreamTicker:      true,
+		CanStreamOrderbook:   true,
+		CanStreamTrades:      true,
+	}
+	o.Features.Orders = map[string]exchange.OrderCapabilities{
+		assets.Spot: {
+			OrderTypes:  []exchange.OrderType{exchange.Limit, exchange.Market, exchange.ImmediateOrCancel},
+			TimeInForce: []exchange.TimeInForce{exchange.GTC, exchange.IOC, exchange.FOK, exchange.PostOnly},
+		},
+		assets.Futures: {
+			OrderTypes:  []exchange.OrderType{exchange.Limit, exchange.Market},
+			TimeInForce: []exchange.TimeInForce{exchange.GTC, exchange.IOC, exchange.FOK, exchange.PostOnly},
+		},
+		assets.PerpetualSwap: {
+			OrderTypes:  []exchange.OrderType{exchange.Limit, exchange.Market},
+			TimeInForce: []exchange.TimeInForce{exchange.GTC, exchange.IOC, exchange.FOK, exchange.PostOnly},
+		},
+	}
+	o.RequestCurrencyPairFormat.Delimiter = "-"
+	o.RequestCurrencyPairFormat.Uppercase = true
+	o.ConfigCurrencyPairFormat.Delimiter = "-"
+	o.ConfigCurrencyPairFormat.Uppercase = true
+	o.SupportsAutoPairUpdating = true
+	o.SupportsRESTTickerBatching = true
+	o.Requester = request.New(o.Name,
+		request.NewRateLimit(time.Second*2, okxAuthRate),
+		request.NewRateLimit(time.Second*2, okxUnauthRate),
+		common.NewHTTPClientWithTimeout(exchange.DefaultHTTPTimeout))
+	o.APIUrlDefault = okxAPIURL
+	o.APIUrl = o.APIUrlDefault
+	o.AssetTypes = []string{ticker.Spot,
+		AssetMargin,
+		AssetPerpetualSwap,
+		AssetFutures,
+		AssetOptions}
+	o.wsRequests = make(map[string]chan wsOrderResponse)
+	o.instruments = make(map[string][]Instrument)
+	o.WebsocketInit()
+	o.SetWebsocketOrderHandlers(o.WsSubmitOrder, o.WsCancelOrder)
+}
+
+// Setup takes in the supplied exchange configuration details and sets params
+func (o *OKX) Setup(exch config.ExchangeConfig) {
+	if !exch.Enabled {
+		o.SetEnabled(false)
+	} else {
+		o.Enabled = true
+		o.AuthenticatedAPISupport = exch.AuthenticatedAPISupport
+		o.SetAPIKeys(exch.APIKey, exch.APISecret, exch.ClientID, false)
+		o.SetHTTPClientTimeout(exch.HTTPTimeout)
+		o.SetHTTPClientUserAgent(exch.HTTPUserAgent)
+		o.RESTPollingDelay = exch.RESTPollingDelay
+		o.Verbose = exch.Verbose
+		o.Simulated = exch.UseSandbox
+		o.BaseCurrencies = common.SplitStrings(exch.BaseCurrencies, ",")
+		o.AvailablePairs = common.SplitStrings(exch.AvailablePairs, ",")
+		o.EnabledPairs = common.SplitStrings(exch.EnabledPairs, ",")
+		err := o.SetCurrencyPairFormat()
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = o.SetAssetTypes()
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = o.SetAutoPairDefaults()
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = o.SetAPIURL(exch)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = o.SetClientProxyAddress(exch.ProxyAddress)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = o.SetTLSPins(exch.TLSPins)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = o.SetLocalAddress(exch.LocalAddress)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = o.SetRoundingModes(exch.RoundingModes)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = o.SetRateLimitTiers(exch.RateLimitTiers, exch.AccountTier)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = o.SetHTTPClientHeaders(exch.HTTPHeaders)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = o.SetBrokerTag(exch.BrokerTag)
+		if err != nil {
+			log.Fatal(err)
+		}
+		o.SetSimulation(exch.Simulate, exch.SimulationBalances)
+		wsDefaultURL := okxWebsocketPublicURL
+		if o.Simulated {
+			wsDefaultURL = okxWsSimulatedPublicURL
+		}
+		err = o.WebsocketSetup(o.WsConnect,
+			exch.Name,
+			exch.Websocket,
+			wsDefaultURL,
+			exch.WebsocketURL)
+		if err != nil {
+			log.Fatal(err)
+		}
+		o.Websocket.SetSubscriber(o.wsDefaultSubscriptions, o.WsSubscribe, o.WsUnsubscribe)
+		err = o.SetOrderTransport(exch)
+		if err != nil {
+			log.Println(err)
+		}
+	}
+}
+
+// GetInstruments returns instruments for an instrument type, underlying is
+// required for options and optional for swaps and futures
+func (o *OKX) GetInstruments(instrumentType, underlying string) ([]Instrument, error) {
+	var resp []Instrument
+	params := url.Values{}
+	params.Set("instType", instrumentType)
+	if underlying != "" {
+		params.Set("uly", underlying)
+	}
+
+	path := common.EncodeURLValues(o.APIUrl+okxAPIVersion+okxInstruments, params)
+	return resp, o.SendHTTPRequest(path, &resp)
+}
+
+// GetServerTime returns the OKX server time
+func (o *OKX) GetServerTime() (time.Time, error) {
+	var resp []struct {
+		Timestamp Time `json:"ts"`
+	}
+
+	err := o.SendHTTPRequest(o.APIUrl+okxAPIVersion+okxServerTime, &resp)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if len(resp) == 0 {
+		return time.Time{}, errors.New("no server time returned")
+	}
+	return resp[0].Timestamp.Time(), nil
+}
+
+// GetSystemStatus returns the scheduled and ongoing system maintenance
+func (o *OKX) GetSystemStatus() ([]SystemStatus, error) {
+	var resp []SystemStatus
+	return resp, o.SendHTTPRequest(o.APIUrl+okxAPIVersion+okxSystemStatus, &resp)
+}
+
+// GetTickers returns the tickers for all instruments of an instrument type
+func (o *OKX) GetTickers(instrumentType string) ([]Ticker, error) {
+	return o.GetTickersContext(context.Background(), instrumentType)
+}
+
+// GetTickersContext returns the tickers for all instruments of a type,
+// cancelled with the context
+func (o *OKX) GetTickersContext(ctx context.Context, instrumentType string) ([]Ticker, error) {
+	var resp []Ticker
+	params := url.Values{}
+	params.Set("instType", instrumentType)
+
+	path := common.EncodeURLValues(o.APIUrl+okxAPIVersion+okxTickers, params)
+	return resp, o.SendHTTPRequestContext(ctx, path, &resp)
+}
+
+// GetTicker returns the ticker for an instrument
+func (o *OKX) GetTicker(instrumentID string) (Ticker, error) {
+	return o.GetTickerContext(context.Background(), instrumentID)
+}
+
+// GetTickerContext returns the ticker for an instrument, cancelled with the
+// context
+func (o *OKX) GetTickerContext(ctx context.Context, instrumentID string) (Ticker, error) {
+	var resp []Ticker
+	params := url.Values{}
+	params.Set("instId", instrumentID)
+
+	path := common.EncodeURLValues(o.APIUrl+okxAPIVersion+okxTicker, params)
+	err := o.SendHTTPRequestContext(ctx, path, &resp)
+	if err != nil {
+		return Ticker{}, err
+	}
+	if len(resp) == 0 {
+		return Ticker{}, fmt.Errorf("no ticker returned for %s", instrumentID)
+	}
+	return resp[0], nil
+}
+
+// GetInstrumentMarkPrice returns the mark price of a swap, futures or option
+// instrument
+func (o *OKX) GetInstrumentMarkPrice(instrumentType, instrumentID string) (MarkPrice, error) {
+	var resp []MarkPrice
+	params := url.Values{}
+	params.Set("instType", instrumentType)
+	params.Set("instId", instrumentID)
+
+	path := common.EncodeURLValues(o.APIUrl+okxAPIVersion+okxMarkPrice, params)
+	err := o.SendHTTPRequest(path, &resp)
+	if err != nil {
+		return MarkPrice{}, err
+	}
+	if len(resp) == 0 {
+		return MarkPrice{}, fmt.Errorf("no mark price returned for %s", instrumentID)
+	}
+	return resp[0], nil
+}
+
+// GetIndexTicker returns the index price of an underlying such as BTC-USDT
+func (o *OKX) GetIndexTicker(underlying string) (IndexTicker, error) {
+	var resp []IndexTicker
+	params := url.Values{}
+	params.Set("instId", underlying)
+
+	path := common.EncodeURLValues(o.APIUrl+okxAPIVersion+okxIndexTickers, params)
+	err := o.SendHTTPRequest(path, &resp)
+	if err != nil {
+		return IndexTicker{}, err
+	}
+	if len(resp) == 0 {
+		return IndexTicker{}, fmt.Errorf("no index ticker returned for %s", underlying)
+	}
+	return resp[0], nil
+}
+
+// GetOrderbook returns the orderbook for an instrument, depth is capped at
+// 400 levels per side
+func (o *OKX) GetOrderbook(instrumentID string, depth int64) (Orderbook, error) {
+	return o.GetOrderbookContext(context.Background(), instrumentID, depth)
+}
+
+// GetOrderbookContext returns the orderbook for an instrument, cancelled with
+// the context
+func (o *OKX) GetOrderbookContext(ctx context.Context, instrumentID string, depth int64) (Orderbook, error) {
+	var resp []OrderbookResponse
+	params := url.Values{}
+	params.Set("instId", instrumentID)
+	if depth > 0 {
+		params.Set("sz", strconv.FormatInt(depth, 10))
+	}
+
+	path := common.EncodeURLValues(o.APIUrl+okxAPIVersion+okxOrderbook, params)
+	err := o.SendHTTPRequestContext(ctx, path, &resp)
+	if err != nil {
+		return Orderbook{}, err
+	}
+	if len(resp) == 0 {
+		return Orderbook{}, fmt.Errorf("no orderbook returned for %s", instrumentID)
+	}
+	return parseOrderbook(&resp[0])
+}
+
+// parseOrderbook converts raw orderbook levels
+func parseOrderbook(raw *OrderbookResponse) (Orderbook, error) {
+	ob := Orderbook{Timestamp: raw.Timestamp.Time()}
+	var err error
+	ob.Asks, err = parseOrderbookLevels(raw.Asks)
+	if err != nil {
+		return ob, err
+	}
+	ob.Bids, err = parseOrderbookLevels(raw.Bids)
+	return ob, err
+}
+
+// parseOrderbookLevels converts [price, size, deprecated, orders] levels
+func parseOrderbookLevels(levels [][4]string) ([]OrderbookItem, error) {
+	items := make([]OrderbookItem, len(levels))
+	for i := range levels {
+		price, err := strconv.ParseFloat(levels[i][0], 64)
+		if err != nil {
+			return nil, err
+		}
+		amount, err := strconv.ParseFloat(levels[i][1], 64)
+		if err != nil {
+			return nil, err
+		}
+		numOrders, _ := strconv.ParseInt(levels[i][3], 10, 64)
+		items[i] = OrderbookItem{Price: price, Amount: amount, NumOrders: numOrders}
+	}
+	return items, nil
+}
+
+// GetTrades returns recent public trades for an instrument, limit is capped
+// at 500
+func (o *OKX) GetTrades(instrumentID string, limit int64) ([]Trade, error) {
+	var resp []Trade
+	params := url.Values{}
+	params.Set("instId", instrumentID)
+	if limit > 0 {
+		params.Set("limit", strconv.FormatInt(limit, 10))
+	}
+
+	path := common.EncodeURLValues(o.APIUrl+okxAPIVersion+okxTrades, params)
+	return resp, o.SendHTTPRequest(path, &resp)
+}
+
+// GetCandles returns candlesticks for an instrument. Bar is the interval
+// e.g. 1m 5m 1H 1D, after returns candles older than the supplied time and
+// before returns candles newer than the supplied time
+func (o *OKX) GetCandles(instrumentID, bar string, after, before time.Time, limit int64) ([]Candle, error) {
+	var resp [][]string
+	params := url.Values{}
+	params.Set("instId", instrumentID)
+	if bar != "" {
+		params.Set("bar", bar)
+	}
+	if !after.IsZero() {
+		params.Set("after", strconv.FormatInt(common.UnixMillis(after), 10))
+	}
+	if !before.IsZero() {
+		params.Set("before", strconv.FormatInt(common.UnixMillis(before), 10))
+	}
+	if limit > 0 {
+		params.Set("limit", strconv.FormatInt(limit, 10))
+	}
+
+	path := common.EncodeURLValues(o.APIUrl+okxAPIVersion+okxCandles, params)
+	err := o.SendHTTPRequest(path, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return parseCandles(resp)
+}
+
+// parseCandles converts [ts, open, high, low, close, volume, ...] candles
+func parseCandles(raw [][]string) ([]Candle, error) {
+	candles := make([]Candle, 0, len(raw))
+	for i := range raw {
+		if len(raw[i]) < 6 {
+			return nil, fmt.Errorf("unexpected candle length %d", len(raw[i]))
+		}
+
+		var values [6]float64
+		for j := 0; j < 6; j++ {
+			v, err := strconv.ParseFloat(raw[i][j], 64)
+			if err != nil {
+				return nil, err
+			}
+			values[j] = v
+		}
+
+		candles = append(candles, Candle{
+			Timestamp: common.UnixTimestampToUTC(int64(values[0])),
+			Open:      values[1],
+			High:      values[2],
+			Low:       values[3],
+			Close:     values[4],
+			Volume:    values[5],
+		})
+	}
+	return candles, nil
+}
+
+// GetAccountBalance returns the unified trading account balance, currency is
+// optional and may be a comma separated list
+func (o *OKX) GetAccountBalance(currency string) ([]AccountBalance, error) {
+	return o.GetAccountBalanceContext(context.Background(), currency)
+}
+
+// GetAccountBalanceContext returns the trading account balances, cancelled
+// with the context
+func (o *OKX) GetAccountBalanceContext(ctx context.Context, currency string) ([]AccountBalance, error) {
+	var resp []AccountBalance
+	params := url.Values{}
+	if currency != "" {
+		params.Set("ccy", currency)
+	}
+	return resp, o.SendAuthenticatedHTTPRequestContext(ctx, "GET", okxAccountBalance, params, nil, &resp)
+}
+
+// GetFundingBalances returns the funding account balances, which hold
+// deposits and withdrawals apart from the trading account, currency is
+// optional and may be a comma separated list
+func (o *OKX) GetFundingBalances(currency string) ([]FundingBalance, error) {
+	return o.GetFundingBalancesContext(context.Background(), currency)
+}
+
+// GetFundingBalancesContext returns the funding account balances, cancelled
+// with the context
+func (o *OKX) GetFundingBalancesContext(ctx context.Context, currency string) ([]FundingBalance, error) {
+	var resp []FundingBalance
+	params := url.Values{}
+	if currency != "" {
+		params.Set("ccy", currency)
+	}
+	return resp, o.SendAuthenticatedHTTPRequestContext(ctx, "GET", okxFundingBalances, params, nil, &resp)
+}
+
+// GetAccountConfig returns the account configuration including the account
+// level and position mode
+func (o *OKX) GetAccountConfig() (AccountConfig, error) {
+	var resp []AccountConfig
+	err := o.SendAuthenticatedHTTPRequest("GET", okxAccountConfig, nil, nil, &resp)
+	if err != nil {
+		return AccountConfig{}, err
+	}
+	if len(resp) == 0 {
+		return AccountConfig{}, errors.New("no account config returned")
+	}
+	return resp[0], nil
+}
+
+// GetPositions returns open positions, both parameters are optional
+func (o *OKX) GetPositions(instrumentType, instrumentID string) ([]Position, error) {
+	var resp []Position
+	params := url.Values{}
+	if instrumentType != "" {
+		params.Set("instType", instrumentType)
+	}
+	if instrumentID != "" {
+		params.Set("instId", instrumentID)
+	}
+	return resp, o.SendAuthenticatedHTTPRequest("GET", okxAccountPositions, params, nil, &resp)
+}
+
+// GetTradeFee returns the account fee rates for an instrument type
+func (o *OKX) GetTradeFee(instrumentType, instrumentID string) (TradeFee, error) {
+	var resp []TradeFee
+	params := url.Values{}
+	params.Set("instType", instrumentType)
+	if instrumentID != "" {
+		params.Set("instId", instrumentID)
+	}
+
+	err := o.SendAuthenticatedHTTPRequest("GET", okxAccountTradeFee, params, nil, &resp)
+	if err != nil {
+		return TradeFee{}, err
+	}
+	if len(resp) == 0 {
+		return TradeFee{}, errors.New("no trade fee returned")
+	}
+	return resp[0], nil
+}
+
+// PlaceOrder places a new order
+func (o *OKX) PlaceOrder(arg PlaceOrderRequest) (OrderResponse, error) {
+	return o.PlaceOrderContext(context.Background(), arg)
+}
+
+// PlaceOrderContext places a new order, cancelled with the context
+func (o *OKX) PlaceOrderContext(ctx context.Context, arg PlaceOrderRequest) (OrderResponse, error) {
+	var resp []OrderResponse
+	err := o.SendAuthenticatedHTTPRequestContext(ctx, "POST", okxPlaceOrder, nil, arg, &resp)
+	return firstOrderResponse(resp, err)
+}
+
+// CancelExistingOrder cancels an order by order ID or client order ID
+func (o *OKX) CancelExistingOrder(arg CancelOrderRequest) (OrderResponse, error) {
+	return o.CancelExistingOrderContext(context.Background(), arg)
+}
+
+// CancelExistingOrderContext cancels an order by order ID or client order ID,
+// cancelled with the context
+func (o *OKX) CancelExistingOrderContext(ctx context.Context, arg CancelOrderRequest) (OrderResponse, error) {
+	var resp []OrderResponse
+	err := o.SendAuthenticatedHTTPRequestContext(ctx, "POST", okxCancelOrder, nil, arg, &resp)
+	return firstOrderResponse(resp, err)
+}
+
+// CancelBatchOrders cancels up to 20 orders in a single request
+func (o *OKX) CancelBatchOrders(args []CancelOrderRequest) ([]OrderResponse, error) {
+	if len(args) == 0 {
+		return nil, errors.New("no orders to cancel")
+	}
+	if len(args) > okxMaxBatchCancel {
+		return nil, fmt.Errorf("cannot cancel more than %d orders per batch", okxMaxBatchCancel)
+	}
+
+	var resp []OrderResponse
+	err := o.SendAuthenticatedHTTPRequest("POST", okxCancelBatchOrders, nil, args, &resp)
+	return resp, err
+}
+
+// AmendOrder amends the size and/or price of an open order
+func (o *OKX) AmendOrder(arg AmendOrderRequest) (OrderResponse, error) {
+	var resp []OrderResponse
+	err := o.SendAuthenticatedHTTPRequest("POST", okxAmendOrder, nil, arg, &resp)
+	return firstOrderResponse(resp, err)
+}
+
+// firstOrderResponse returns the first order response, checking its status
+func firstOrderResponse(resp []OrderResponse, err error) (OrderResponse, error) {
+	if len(resp) > 0 && resp[0].StatusCode != "" && resp[0].StatusCode != okxSuccessCode {
+		return resp[0], fmt.Errorf("OKX error code %s: %s",
+			resp[0].StatusCode,
+			resp[0].StatusMessage)
+	}
+	if err != nil {
+		return OrderResponse{}, err
+	}
+	if len(resp) == 0 {
+		return OrderResponse{}, errors.New("no order response returned")
+	}
+	return resp[0], nil
+}
+
+// PlaceAlgoOrder places an algo order such as a trailing stop
+func (o *OKX) PlaceAlgoOrder(arg AlgoOrderRequest) (AlgoOrderResponse, error) {
+	var resp []AlgoOrderResponse
+	err := o.SendAuthenticatedHTTPRequest("POST", okxPlaceAlgoOrder, nil, arg, &resp)
+	return firstAlgoOrderResponse(resp, err)
+}
+
+// CancelAlgoOrder cancels an algo order by algo ID
+func (o *OKX) CancelAlgoOrder(arg CancelAlgoOrderRequest) (AlgoOrderResponse, error) {
+	var resp []AlgoOrderResponse
+	err := o.SendAuthenticatedHTTPRequest("POST", okxCancelAlgoOrders, nil,
+		[]CancelAlgoOrderRequest{arg}, &resp)
+	return firstAlgoOrderResponse(resp, err)
+}
+
+// firstAlgoOrderResponse returns the first algo order result, surfacing the
+// per order error code
+func firstAlgoOrderResponse(resp []AlgoOrderResponse, err error) (AlgoOrderResponse, error) {
+	if len(resp) > 0 && resp[0].StatusCode != "" && resp[0].StatusCode != okxSuccessCode {
+		return resp[0], fmt.Errorf("OKX error code %s: %s",
+			resp[0].StatusCode,
+			resp[0].StatusMessage)
+	}
+	if err != nil {
+		return AlgoOrderResponse{}, err
+	}
+	if len(resp) == 0 {
+		return AlgoOrderResponse{}, errors.New("no algo order response returned")
+	}
+	return resp[0], nil
+}
+
+// GetOrderDetail returns an order by instrument and order ID
+func (o *OKX) GetOrderDetail(instrumentID, orderID string) (Order, error) {
+	var resp []Order
+	params := url.Values{}
+	params.Set("instId", instrumentID)
+	params.Set("ordId", orderID)
+
+	err := o.SendAuthenticatedHTTPRequest("GET", okxOrderDetail, params, nil, &resp)
+	if err != nil {
+		return Order{}, err
+	}
+	if len(resp) == 0 {
+		return Order{}, fmt.Errorf("order %s not found", orderID)
+	}
+	return resp[0], nil
+}
+
+// GetPendingOrders returns open orders, both parameters are optional
+func (o *OKX) GetPendingOrders(instrumentType, instrumentID string) ([]Order, error) {
+	var resp []Order
+	params := url.Values{}
+	if instrumentType != "" {
+		params.Set("instType", instrumentType)
+	}
+	if instrumentID != "" {
+		params.Set("instId", instrumentID)
+	}
+	return resp, o.SendAuthenticatedHTTPRequest("GET", okxPendingOrders, params, nil, &resp)
+}
+
+// GetInstrumentOrderHistory returns completed orders from the last 7 days for an
+// instrument type
+func (o *OKX) GetInstrumentOrderHistory(instrumentType, instrumentID string) ([]Order, error) {
+	var resp []Order
+	params := url.Values{}
+	params.Set("instType", instrumentType)
+	if instrumentID != "" {
+		params.Set("instId", instrumentID)
+	}
+	return resp, o.SendAuthenticatedHTTPRequest("GET", okxOrderHistory, params, nil, &resp)
+}
+
+// GetCurrencies returns funding currency details including withdrawal fees
+// for each chain
+func (o *OKX) GetCurrencies(currency string) ([]Currency, error) {
+	var resp []Currency
+	params := url.Values{}
+	if currency != "" {
+		params.Set("ccy", currency)
+	}
+	return resp, o.SendAuthenticatedHTTPRequest("GET", okxCurrencies, params, nil, &resp)
+}
+
+// GetDepositAddresses returns deposit addresses for a currency on all chains
+func (o *OKX) GetDepositAddresses(currency string) ([]DepositAddress, error) {
+	var resp []DepositAddress
+	params := url.Values{}
+	params.Set("ccy", currency)
+	return resp, o.SendAuthenticatedHTTPRequest("GET", okxDepositAddress, params, nil, &resp)
+}
+
+// Withdraw submits an on chain withdrawal from the funding account
+func (o *OKX) Withdraw(arg WithdrawalRequest) (WithdrawalResponse, error) {
+	var resp []WithdrawalResponse
+	err := o.SendAuthenticatedHTTPRequest("POST", okxWithdrawal, nil, arg, &resp)
+	if err != nil {
+		return WithdrawalResponse{}, err
+	}
+	if len(resp) == 0 {
+		return WithdrawalResponse{}, errors.New("no withdrawal response returned")
+	}
+	return resp[0], nil
+}
+
+// GetDepositHistory returns deposit records, currency is optional
+func (o *OKX) GetDepositHistory(currency string) ([]DepositHistory, error) {
+	var resp []DepositHistory
+	params := url.Values{}
+	if currency != "" {
+		params.Set("ccy", currency)
+	}
+	return resp, o.SendAuthenticatedHTTPRequest("GET", okxDepositHistory, params, nil, &resp)
+}
+
+// GetWithdrawalHistory returns withdrawal records, currency is optional
+func (o *OKX) GetWithdrawalHistory(currency string) ([]WithdrawalHistory, error) {
+	var resp []WithdrawalHistory
+	params := url.Values{}
+	if currency != "" {
+		params.Set("ccy", currency)
+	}
+	return resp, o.SendAuthenticatedHTTPRequest("GET", okxWithdrawalHistory, params, nil, &resp)
+}
+
+// GetSavingsBalance returns simple earn savings balances, currency is
+// optional
+func (o *OKX) GetSavingsBalance(currency string) ([]SavingsBalance, error) {
+	var resp []SavingsBalance
+	params := url.Values{}
+	if currency != "" {
+		params.Set("ccy", currency)
+	}
+	return resp, o.SendAuthenticatedHTTPRequest("GET", okxSavingsBalance, params, nil, &resp)
+}
+
+// GetActiveStakingOrders returns active on-chain earn orders, currency is
+// optional
+func (o *OKX) GetActiveStakingOrders(currency string) ([]StakingOrder, error) {
+	var resp []StakingOrder
+	params := url.Values{}
+	if currency != "" {
+		params.Set("ccy", currency)
+	}
+	return resp, o.SendAuthenticatedHTTPRequest("GET", okxStakingOrders, params, nil, &resp)
+}
+
+// PurchaseStaking invests an amount of currency in an on-chain earn product
+// and returns the order ID, term is required for fixed term products
+func (o *OKX) PurchaseStaking(productID, currency string, amount float64, term string) (string, error) {
+	req := StakingPurchaseRequest{
+		ProductID: productID,
+		InvestData: []StakingInvestment{{
+			Currency: currency,
+			Amount:   strconv.FormatFloat(amount, 'f', -1, 64),
+		}},
+		Term: term,
+	}
+	var resp []OrderResponse
+	err := o.SendAuthenticatedHTTPRequest("POST", okxStakingPurchase, nil, req, &resp)
+	order, err := firstOrderResponse(resp, err)
+	return order.OrderID, err
+}
+
+// RedeemStaking redeems an on-chain earn order, allowEarlyRedeem is required
+// to redeem fixed term orders before the end of their term
+func (o *OKX) RedeemStaking(orderID, protocolType string, allowEarlyRedeem bool) error {
+	req := StakingRedeemRequest{
+		OrderID:          orderID,
+		ProtocolType:     protocolType,
+		AllowEarlyRedeem: allowEarlyRedeem,
+	}
+	var resp []OrderResponse
+	_, err := firstOrderResponse(resp, o.SendAuthenticatedHTTPRequest("POST", okxStakingRedeem, nil, req, &resp))
+	return err
+}
+
+// SendHTTPRequest sends an unauthenticated HTTP request
+func (o *OKX) SendHTTPRequest(path string, result interface{}) error {
+	return o.SendHTTPRequestContext(context.Background(), path, result)
+}
+
+// SendHTTPRequestContext sends an unauthenticated HTTP request, cancelled with
+// the context
+func (o *OKX) SendHTTPRequestContext(ctx context.Context, path string, result interface{}) error {
+	var resp Response
+	err := o.SendPayloadContext(ctx, "GET", path, o.requestHeaders(), nil, &resp, false, o.Verbose)
+	if err != nil {
+		return err
+	}
+	return o.decodeResponse(&resp, result)
+}
+
+// SendAuthenticatedHTTPRequest sends an authenticated HTTP request, params
+// are encoded in the query string and data is sent as the JSON body
+func (o *OKX) SendAuthenticatedHTTPRequest(method, endpoint string, params url.Values, data, result interface{}) error {
+	return o.SendAuthenticatedHTTPRequestContext(context.Background(), method, endpoint, params, data, result)
+}
+
+// SendAuthenticatedHTTPRequestContext sends an authenticated HTTP request,
+// cancelled with the context
+func (o *OKX) SendAuthenticatedHTTPRequestContext(ctx context.Context, method, endpoint string, params url.Values, data, result interface{}) error {
+	if !o.AuthenticatedAPISupport {
+		return fmt.Errorf(exchange.WarningAuthenticatedRequestWithoutCredentialsSet, o.Name)
+	}
+
+	requestPath := okxAPIVersion + endpoint
+	if len(params) > 0 {
+		requestPath = common.EncodeURLValues(requestPath, params)
+	}
+
+	var payload []byte
+	if data != nil {
+		var err error
+		payload, err = common.JSONEncode(data)
+		if err != nil {
+			return errors.New("SendAuthenticatedHTTPRequest: Unable to JSON request")
+		}
+
+		if o.Verbose {
+			log.Printf("Request JSON: %s\n", payload)
+		}
+	}
+
+	timestamp := time.Now().UTC().Format(okxTimeLayout)
+	headers := o.requestHeaders()
+	headers["OK-ACCESS-KEY"] = o.APIKey
+	headers["OK-ACCESS-SIGN"] = o.sign(timestamp, method, requestPath, payload)
+	headers["OK-ACCESS-TIMESTAMP"] = timestamp
+	headers["OK-ACCESS-PASSPHRASE"] = o.ClientID
+	headers["Content-Type"] = "application/json"
+
+	var resp Response
+	err := o.SendPayloadContext(ctx,
+		method,
+		o.APIUrl+requestPath,
+		headers,
+		bytes.NewBuffer(payload),
+		&resp,
+		true,
+		o.Verbose)
+	if err != nil {
+		return err
+	}
+	return o.decodeResponse(&resp, result)
+}
+
+// requestHeaders returns the headers sent with every request
+func (o *OKX) requestHeaders() map[string]string {
+	headers := make(map[string]string)
+	if o.Simulated {
+		headers["x-simulated-trading"] = "1"
+	}
+	return headers
+}
+
+// sign returns the base64 encoded HMAC-SHA256 request signature
+func (o *OKX) sign(timestamp, method, requestPath string, body []byte) string {
+	hmac := common.GetHMAC(common.HashSHA256,
+		[]byte(timestamp+method+requestPath+string(body)),
+		[]byte(o.APISecret))
+	return common.Base64Encode(hmac)
+}
+
+// decodeResponse checks the response code and decodes the response data. On
+// failure the data is still decoded so order status codes are available.
+func (o *OKX) decodeResponse(resp *Response, result interface{}) error {
+	if result != nil && len(resp.Data) > 0 {
+		err := json.Unmarshal(resp.Data, result)
+		if err != nil && resp.Code == okxSuccessCode {
+			return err
+		}
+	}
+
+	if resp.Code != okxSuccessCode {
+		return fmt.Errorf("%s error code %s: %s", o.Name, resp.Code, resp.Msg)
+	}
+	return nil
+}
+
+// GetFee returns an estimate of fee based on type of transaction
+func (o *OKX) GetFee(feeBuilder exchange.FeeBuilder) (float64, error) {
+	var fee float64
+	switch feeBuilder.FeeType {
+	case exchange.CryptocurrencyTradeFee:
+		rate := okxDefaultTakerFee
+		if feeBuilder.IsMaker {
+			rate = okxDefaultMakerFee
+		}
+
+		if o.AuthenticatedAPISupport {
+			tradeFee, err := o.GetTradeFee(InstrumentTypeSpot,
+				feeBuilder.FirstCurrency+"-"+feeBuilder.SecondCurrency)
+			if err != nil {
+				return 0, err
+			}
+			// The fee level is the account tier, apply its rate limits when
+			// it has changed
+			if tradeFee.Level != "" {
+				o.SetAccountTier(tradeFee.Level)
+			}
+			// OKX returns charged fees as negative rates
+			rate = -tradeFee.Taker.Float64()
+			if feeBuilder.IsMaker {
+				rate = -tradeFee.Maker.Float64()
+			}
+		}
+		fee = rate * feeBuilder.PurchasePrice * feeBuilder.Amount
+	case exchange.CryptocurrencyWithdrawalFee:
+		if !o.AuthenticatedAPISupport {
+			return 0, fmt.Errorf(exchange.WarningAuthenticatedRequestWithoutCredentialsSet, o.Name)
+		}
+		currencies, err := o.GetCurrencies(feeBuilder.FirstCurrency)
+		if err != nil {
+			return 0, err
+		}
+		c, err := selectChain(currencies, feeBuilder.FirstCurrency)
+		if err != nil {
+			return 0, err
+		}
+		fee = c.MinFee.Float64()
+	}
+
+	if fee < 0 {
+		fee = 0
+	}
+	return fee, nil
+}
+
+// Default spot fee rates for the lowest regular user tier
+const (
+	okxDefaultMakerFee = 0.0008
+	okxDefaultTakerFee = 0.001
+)
+
+// selectChain returns the main chain entry for a currency, which OKX names
+// CCY-CCY, falling back to the first chain listed
+func selectChain(currencies []Currency, currency string) (Currency, error) {
+	if len(currencies) == 0 {
+		return Currency{}, fmt.Errorf("no chains found for currency %s", currency)
+	}
+
+	mainChain := common.StringToUpper(currency) + "-" + common.StringToUpper(currency)
+	for i := range currencies {
+		if currencies[i].Chain == mainChain {
+			return currencies[i], nil
+		}
+	}
+	return currencies[0], nil
+}
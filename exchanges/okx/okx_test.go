@@ -0,0 +1,646 @@
+package okx
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	"github.com/thrasher-/gocryptotrader/currency/symbol"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/conformance"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+var o OKX
+
+// Please supply you own test keys here for due diligence testing.
+const (
+	apiKey                  = ""
+	apiSecret               = ""
+	passphrase              = ""
+	canManipulateRealOrders = false
+)
+
+func TestSetDefaults(t *testing.T) {
+	o.SetDefaults()
+	if o.GetName() != "OKX" {
+		t.Error("Test Failed - OKX - SetDefaults() error")
+	}
+}
+
+func TestSetup(t *testing.T) {
+	cfg := config.GetConfig()
+	cfg.LoadConfig("../../testdata/configtest.json")
+	okxConfig, err := cfg.GetExchangeConfig("OKX")
+	if err != nil {
+		t.Error("Test Failed - OKX Setup() init error")
+	}
+
+	okxConfig.AuthenticatedAPISupport = true
+	okxConfig.APIKey = apiKey
+	okxConfig.APISecret = apiSecret
+	okxConfig.ClientID = passphrase
+
+	o.Setup(okxConfig)
+}
+
+func TestConformance(t *testing.T) {
+	cfg := config.GetConfig()
+	cfg.LoadConfig("../../testdata/configtest.json")
+	okxConfig, err := cfg.GetExchangeConfig("OKX")
+	if err != nil {
+		t.Fatal("Test Failed - OKX conformance init error", err)
+	}
+
+	conformance.Run(t, func() exchange.IBotExchange { return new(OKX) }, okxConfig)
+}
+
+func TestGetInstruments(t *testing.T) {
+	t.Parallel()
+	_, err := o.GetInstruments(InstrumentTypeSpot, "")
+	if err != nil {
+		t.Error("Test Failed - OKX GetInstruments() error", err)
+	}
+	_, err = o.GetInstruments(InstrumentTypeFutures, "BTC-USD")
+	if err != nil {
+		t.Error("Test Failed - OKX GetInstruments() error", err)
+	}
+}
+
+func TestGetServerTime(t *testing.T) {
+	t.Parallel()
+	_, err := o.GetServerTime()
+	if err != nil {
+		t.Error("Test Failed - OKX GetServerTime() error", err)
+	}
+}
+
+func TestCheckSystemStatus(t *testing.T) {
+	t.Parallel()
+	err := o.CheckSystemStatus()
+	if err != nil {
+		t.Error("Test Failed - OKX CheckSystemStatus() error", err)
+	}
+}
+
+func TestGetTicker(t *testing.T) {
+	t.Parallel()
+	_, err := o.GetTicker("BTC-USDT-SWAP")
+	if err != nil {
+		t.Error("Test Failed - OKX GetTicker() error", err)
+	}
+}
+
+func TestGetOrderbook(t *testing.T) {
+	t.Parallel()
+	_, err := o.GetOrderbook("BTC-USDT", 20)
+	if err != nil {
+		t.Error("Test Failed - OKX GetOrderbook() error", err)
+	}
+}
+
+func TestGetTrades(t *testing.T) {
+	t.Parallel()
+	_, err := o.GetTrades("BTC-USDT", 10)
+	if err != nil {
+		t.Error("Test Failed - OKX GetTrades() error", err)
+	}
+}
+
+func TestGetCandles(t *testing.T) {
+	t.Parallel()
+	_, err := o.GetCandles("BTC-USDT", "1H", time.Time{}, time.Time{}, 10)
+	if err != nil {
+		t.Error("Test Failed - OKX GetCandles() error", err)
+	}
+}
+
+func TestParseCandles(t *testing.T) {
+	t.Parallel()
+	candles, err := parseCandles([][]string{
+		{"1597026383085", "3.721", "3.743", "3.677", "3.708", "8422410", "22698348.04828491", "12698348.04828491", "0"},
+	})
+	if err != nil {
+		t.Fatal("Test Failed - parseCandles() error", err)
+	}
+	if len(candles) != 1 || candles[0].Close != 3.708 ||
+		candles[0].Timestamp.UnixNano()/int64(time.Millisecond) != 1597026383085 {
+		t.Error("Test Failed - parseCandles() incorrect values", candles)
+	}
+
+	_, err = parseCandles([][]string{{"1597026383085", "3.721"}})
+	if err == nil {
+		t.Error("Test Failed - parseCandles() expected error on short candle")
+	}
+}
+
+func TestNumberUnmarshal(t *testing.T) {
+	t.Parallel()
+	var resp struct {
+		A Number `json:"a"`
+		B Number `json:"b"`
+		C Time   `json:"c"`
+	}
+	err := json.Unmarshal([]byte(`{"a":"1.5","b":"","c":"1597026383085"}`), &resp)
+	if err != nil {
+		t.Fatal("Test Failed - Number UnmarshalJSON() error", err)
+	}
+	if resp.A.Float64() != 1.5 || resp.B.Float64() != 0 {
+		t.Error("Test Failed - Number UnmarshalJSON() incorrect values")
+	}
+	if resp.C.Time().UnixNano()/int64(time.Millisecond) != 1597026383085 {
+		t.Error("Test Failed - Time UnmarshalJSON() incorrect value")
+	}
+}
+
+func TestInstrumentIDToPair(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		instrumentID string
+		assetType    string
+	}{
+		{"BTC-USDT", ticker.Spot},
+		{"BTC-USDT-SWAP", AssetPerpetualSwap},
+		{"BTC-USD-241227", AssetFutures},
+		{"BTC-USD-241227-50000-C", AssetOptions},
+	}
+
+	for _, test := range tests {
+		p, assetType, err := InstrumentIDToPair(test.instrumentID)
+		if err != nil {
+			t.Error("Test Failed - InstrumentIDToPair() error", err)
+			continue
+		}
+		if assetType != test.assetType {
+			t.Errorf("Test Failed - InstrumentIDToPair() %s expected %s, received %s",
+				test.instrumentID, test.assetType, assetType)
+		}
+		if p.FirstCurrency != symbol.BTC {
+			t.Errorf("Test Failed - InstrumentIDToPair() %s incorrect base %s",
+				test.instrumentID, p.FirstCurrency)
+		}
+	}
+
+	_, _, err := InstrumentIDToPair("BTCUSDT")
+	if err == nil {
+		t.Error("Test Failed - InstrumentIDToPair() expected error")
+	}
+}
+
+func TestFormatInstrumentID(t *testing.T) {
+	o.SetDefaults()
+	p := pair.NewCurrencyPairDelimiter("BTC-USDT", "-")
+
+	id, err := o.FormatInstrumentID(p, AssetMargin)
+	if err != nil || id != "BTC-USDT" {
+		t.Error("Test Failed - FormatInstrumentID() margin error", id, err)
+	}
+
+	id, err = o.FormatInstrumentID(p, AssetPerpetualSwap)
+	if err != nil || id != "BTC-USDT-SWAP" {
+		t.Error("Test Failed - FormatInstrumentID() swap error", id, err)
+	}
+
+	_, err = o.FormatInstrumentID(p, AssetOptions)
+	if err == nil {
+		t.Error("Test Failed - FormatInstrumentID() expected options error")
+	}
+}
+
+func TestNearestFuture(t *testing.T) {
+	t.Parallel()
+	now := time.Unix(1700000000, 0)
+	expiry := func(d time.Duration) Time {
+		return Time(now.Add(d))
+	}
+	instruments := []Instrument{
+		{InstrumentID: "BTC-USD-EXPIRED", Underlying: "BTC-USD", State: "live", ExpiryTime: expiry(-time.Hour)},
+		{InstrumentID: "BTC-USD-QUARTER", Underlying: "BTC-USD", State: "live", ExpiryTime: expiry(90 * 24 * time.Hour)},
+		{InstrumentID: "BTC-USD-WEEK", Underlying: "BTC-USD", State: "live", ExpiryTime: expiry(7 * 24 * time.Hour)},
+		{InstrumentID: "ETH-USD-WEEK", Underlying: "ETH-USD", State: "live", ExpiryTime: expiry(time.Hour)},
+	}
+
+	id, err := nearestFuture(instruments, "BTC-USD", now)
+	if err != nil || id != "BTC-USD-WEEK" {
+		t.Error("Test Failed - nearestFuture() error", id, err)
+	}
+
+	_, err = nearestFuture(instruments, "LTC-USD", now)
+	if err == nil {
+		t.Error("Test Failed - nearestFuture() expected error")
+	}
+}
+
+func TestBuildSpotOrder(t *testing.T) {
+	o.SetDefaults()
+	p := pair.NewCurrencyPairDelimiter("BTC-USDT", "-")
+
+	req, err := o.buildSpotOrder(p, exchange.Buy, exchange.Limit, 0.5, 10000, "abc")
+	if err != nil {
+		t.Fatal("Test Failed - buildSpotOrder() error", err)
+	}
+	if req.InstrumentID != "BTC-USDT" || req.Side != "buy" ||
+		req.OrderType != OrderTypeLimit || req.Price != "10000" ||
+		req.Size != "0.5" || req.TradeMode != TradeModeCash {
+		t.Error("Test Failed - buildSpotOrder() incorrect limit order", req)
+	}
+
+	req, err = o.buildSpotOrder(p, exchange.Sell, exchange.Market, 1, 0, "")
+	if err != nil {
+		t.Fatal("Test Failed - buildSpotOrder() error", err)
+	}
+	if req.Price != "" || req.TargetCcy != "base_ccy" || req.Side != "sell" {
+		t.Error("Test Failed - buildSpotOrder() incorrect market order", req)
+	}
+
+	_, err = o.buildSpotOrder(p, exchange.Buy, exchange.OrderType("STOP"), 1, 1, "")
+	if err == nil {
+		t.Error("Test Failed - buildSpotOrder() expected unsupported order type error")
+	}
+}
+
+func TestSign(t *testing.T) {
+	o.SetDefaults()
+	o.APISecret = "secret"
+	defer func() { o.APISecret = apiSecret }()
+
+	sig := o.sign("2020-12-08T09:08:57.715Z", "GET", "/api/v5/account/balance", nil)
+	if sig != o.sign("2020-12-08T09:08:57.715Z", "GET", "/api/v5/account/balance", []byte{}) {
+		t.Error("Test Failed - sign() empty body mismatch")
+	}
+	if sig == o.sign("2020-12-08T09:08:57.715Z", "POST", "/api/v5/account/balance", nil) {
+		t.Error("Test Failed - sign() method not included in signature")
+	}
+}
+
+func TestSelectChain(t *testing.T) {
+	t.Parallel()
+	currencies := []Currency{
+		{Currency: "USDT", Chain: "USDT-TRC20"},
+		{Currency: "USDT", Chain: "USDT-USDT"},
+	}
+	c, err := selectChain(currencies, "usdt")
+	if err != nil || c.Chain != "USDT-USDT" {
+		t.Error("Test Failed - selectChain() error", c.Chain, err)
+	}
+
+	_, err = selectChain(nil, "usdt")
+	if err == nil {
+		t.Error("Test Failed - selectChain() expected error")
+	}
+}
+
+func setFeeBuilder() exchange.FeeBuilder {
+	return exchange.FeeBuilder{
+		Amount:         1,
+		Delimiter:      "-",
+		FeeType:        exchange.CryptocurrencyTradeFee,
+		FirstCurrency:  symbol.BTC,
+		SecondCurrency: symbol.USDT,
+		IsMaker:        false,
+		PurchasePrice:  1,
+	}
+}
+
+func TestGetFee(t *testing.T) {
+	o.SetDefaults()
+	if apiKey != "" || apiSecret != "" {
+		t.Skip()
+	}
+	o.AuthenticatedAPISupport = false
+
+	var feeBuilder = setFeeBuilder()
+	// CryptocurrencyTradeFee Basic
+	if resp, err := o.GetFee(feeBuilder); resp != float64(0.001) || err != nil {
+		t.Errorf("Test Failed - GetFee() error. Expected: %f, Received: %f", float64(0.001), resp)
+		t.Error(err)
+	}
+
+	// CryptocurrencyTradeFee IsMaker
+	feeBuilder = setFeeBuilder()
+	feeBuilder.IsMaker = true
+	if resp, err := o.GetFee(feeBuilder); resp != float64(0.0008) || err != nil {
+		t.Errorf("Test Failed - GetFee() error. Expected: %f, Received: %f", float64(0.0008), resp)
+		t.Error(err)
+	}
+
+	// CryptocurrencyTradeFee Negative purchase price
+	feeBuilder = setFeeBuilder()
+	feeBuilder.PurchasePrice = -1000
+	if resp, err := o.GetFee(feeBuilder); resp != float64(0) || err != nil {
+		t.Errorf("Test Failed - GetFee() error. Expected: %f, Received: %f", float64(0), resp)
+		t.Error(err)
+	}
+
+	// CryptocurrencyWithdrawalFee requires credentials
+	feeBuilder = setFeeBuilder()
+	feeBuilder.FeeType = exchange.CryptocurrencyWithdrawalFee
+	if _, err := o.GetFee(feeBuilder); err == nil {
+		t.Error("Test Failed - GetFee() expected error without credentials")
+	}
+
+	// InternationalBankDepositFee Basic
+	feeBuilder = setFeeBuilder()
+	feeBuilder.FeeType = exchange.InternationalBankDepositFee
+	if resp, err := o.GetFee(feeBuilder); resp != float64(0) || err != nil {
+		t.Errorf("Test Failed - GetFee() error. Expected: %f, Received: %f", float64(0), resp)
+		t.Error(err)
+	}
+}
+
+func TestFormatWithdrawPermissions(t *testing.T) {
+	o.SetDefaults()
+	expectedResult := exchange.AutoWithdrawCryptoWithAPIPermissionText
+	withdrawPermissions := o.FormatWithdrawPermissions()
+	if withdrawPermissions != expectedResult {
+		t.Errorf("Expected: %s, Received: %s", expectedResult, withdrawPermissions)
+	}
+}
+
+func TestOrderTransportFallback(t *testing.T) {
+	o.SetDefaults()
+	if !o.SupportsWebsocketOrders() {
+		t.Error("Test Failed - SupportsWebsocketOrders() expected true")
+	}
+
+	err := o.SetOrderTransport(config.ExchangeConfig{OrderTransport: exchange.OrderTransportWebsocket})
+	if err != nil {
+		t.Fatal("Test Failed - SetOrderTransport() error", err)
+	}
+
+	// websocket is not connected so orders must be routed via REST
+	if o.UseWebsocketOrders() {
+		t.Error("Test Failed - UseWebsocketOrders() expected false while disconnected")
+	}
+
+	err = o.WsCancelOrder(exchange.OrderCancellation{OrderID: "1"})
+	if err != exchange.ErrWebsocketOrderNotSent {
+		t.Error("Test Failed - WsCancelOrder() expected ErrWebsocketOrderNotSent", err)
+	}
+}
+
+func TestUpdateTickerContext(t *testing.T) {
+	var _ exchange.ContextExchange = &o
+	var _ exchange.OrderContextExchange = &o
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := exchange.UpdateTickerContext(ctx, &o, pair.NewCurrencyPair("BTC", "USDT"), ticker.Spot)
+	if err != context.Canceled {
+		t.Error("Test Failed - UpdateTickerContext() expected cancelled request", err)
+	}
+}
+
+func TestGetAccountInfo(t *testing.T) {
+	if apiKey != "" || apiSecret != "" {
+		_, err := o.GetAccountInfo()
+		if err != nil {
+			t.Error("Test Failed - GetAccountInfo() error", err)
+		}
+	} else {
+		_, err := o.GetAccountInfo()
+		if err == nil {
+			t.Error("Test Failed - GetAccountInfo() error")
+		}
+	}
+}
+
+func TestUnifiedAccountBalances(t *testing.T) {
+	var trading []AccountBalance
+	err := json.Unmarshal([]byte(`[{"totalEq":"41000","details":[
+		{"ccy":"BTC","eq":"1.1","cashBal":"1","frozenBal":"0.25","upl":"0.1"},
+		{"ccy":"USDT","eq":"-500","cashBal":"-500","frozenBal":"0","liab":"500"}]}]`), &trading)
+	if err != nil {
+		t.Fatal("Test Failed - unifiedAccountBalances() decode error", err)
+	}
+	var funding []FundingBalance
+	err = json.Unmarshal([]byte(`[{"ccy":"btc","bal":"0.5","frozenBal":"0.125","availBal":"0.375"},
+		{"ccy":"ETH","bal":"2","frozenBal":"0","availBal":"2"}]`), &funding)
+	if err != nil {
+		t.Fatal("Test Failed - unifiedAccountBalances() decode error", err)
+	}
+
+	currencies := unifiedAccountBalances(trading, funding)
+	if len(currencies) != 3 {
+		t.Fatalf("Test Failed - unifiedAccountBalances() expected 3 currencies received %v", currencies)
+	}
+	if c := currencies[0]; c.CurrencyName != "BTC" || c.TotalValue != 1.5 || c.Hold != 0.375 {
+		t.Error("Test Failed - unifiedAccountBalances() expected merged BTC cash balances", c)
+	}
+	if c := currencies[1]; c.CurrencyName != "USDT" || c.TotalValue != -500 {
+		t.Error("Test Failed - unifiedAccountBalances() expected borrowed USDT", c)
+	}
+	if c := currencies[2]; c.CurrencyName != "ETH" || c.TotalValue != 2 {
+		t.Error("Test Failed - unifiedAccountBalances() expected funding ETH", c)
+	}
+}
+
+func TestWsProcessMarkPrices(t *testing.T) {
+	o.SetDefaults()
+	o.Websocket.DataHandler = make(chan interface{}, 1)
+
+	err := o.wsHandleMessage([]byte(`{"arg":{"channel":"index-tickers","instId":"BTC-USDT"},
+		"data":[{"instId":"BTC-USDT","idxPx":"43350","ts":"1597026383085"}]}`))
+	if err != nil {
+		t.Fatal("Test Failed - wsHandleMessage() index ticker error", err)
+	}
+	err = o.wsHandleMessage([]byte(`{"arg":{"channel":"mark-price","instId":"BTC-USDT-SWAP"},
+		"data":[{"instType":"SWAP","instId":"BTC-USDT-SWAP","markPx":"43360.5","ts":"1597026383085"}]}`))
+	if err != nil {
+		t.Fatal("Test Failed - wsHandleMessage() mark price error", err)
+	}
+	mark, ok := (<-o.Websocket.DataHandler).(exchange.MarkPriceData)
+	if !ok {
+		t.Fatal("Test Failed - wsHandleMessage() expected mark price data")
+	}
+	if mark.MarkPrice != 43360.5 || mark.IndexPrice != 43350 || mark.InstrumentID != "BTC-USDT-SWAP" ||
+		mark.AssetType != AssetPerpetualSwap {
+		t.Error("Test Failed - wsHandleMessage() incorrect mark price", mark)
+	}
+}
+
+func TestWsProcessOrderbook(t *testing.T) {
+	var exch OKX
+	exch.SetDefaults()
+	exch.Name = "OKXBooksTest"
+	exch.Websocket.DataHandler = make(chan interface{}, 3)
+
+	err := exch.wsHandleMessage([]byte(`{"arg":{"channel":"books","instId":"BTC-USDT"},"action":"snapshot",
+		"data":[{"asks":[["8476.98","415","0","13"]],"bids":[["8476.97","256","0","12"]],"ts":"1597026383085","seqId":100,"prevSeqId":-1}]}`))
+	if err != nil {
+		t.Fatal("Test Failed - wsHandleMessage() snapshot error", err)
+	}
+	err = exch.wsHandleMessage([]byte(`{"arg":{"channel":"books","instId":"BTC-USDT"},"action":"update",
+		"data":[{"asks":[["8476.98","0","0","0"],["8477","10","0","1"]],"bids":[],"ts":"1597026383185","seqId":102,"prevSeqId":100}]}`))
+	if err != nil {
+		t.Fatal("Test Failed - wsHandleMessage() update error", err)
+	}
+	ob := exch.wsBuffers["BTC-USDT"].Orderbook()
+	if len(ob.Asks) != 1 || ob.Asks[0].Price != 8477 || ob.Bids[0].Amount != 256 {
+		t.Errorf("Test Failed - wsHandleMessage() unexpected orderbook %+v", ob)
+	}
+
+	// A gap resubscribes, which fails without a connection
+	err = exch.wsHandleMessage([]byte(`{"arg":{"channel":"books","instId":"BTC-USDT"},"action":"update",
+		"data":[{"asks":[],"bids":[["8476.5","1","0","1"]],"ts":"1597026383285","seqId":106,"prevSeqId":105}]}`))
+	if err == nil || exch.wsBuffers["BTC-USDT"].Synced() {
+		t.Error("Test Failed - wsHandleMessage() expected sequence gap", err)
+	}
+	if c := orderbook.Sequences.Counter(exch.Name); c.Gaps != 1 || c.Updates != 2 {
+		t.Errorf("Test Failed - wsHandleMessage() unexpected sequence counters %+v", c)
+	}
+}
+
+func TestGetEarnBalances(t *testing.T) {
+	_, err := o.GetEarnBalances()
+	if apiKey != "" || apiSecret != "" {
+		if err != nil {
+			t.Error("Test Failed - GetEarnBalances() error", err)
+		}
+	} else if err == nil {
+		t.Error("Test Failed - GetEarnBalances() expected error without credentials")
+	}
+}
+
+func TestStakingOrderUnlock(t *testing.T) {
+	purchased := time.Date(2018, 9, 1, 0, 0, 0, 0, time.UTC)
+	s := StakingOrder{Term: "30", PurchasedAt: Time(purchased)}
+	if !s.Unlock().Equal(purchased.AddDate(0, 0, 30)) {
+		t.Error("Test Failed - Unlock() incorrect unlock time", s.Unlock())
+	}
+	s.Term = "0"
+	if !s.Unlock().IsZero() {
+		t.Error("Test Failed - Unlock() expected flexible order to have no unlock time")
+	}
+}
+
+func TestGetStakingPositions(t *testing.T) {
+	_, err := o.GetStakingPositions()
+	if apiKey != "" || apiSecret != "" {
+		if err != nil {
+			t.Error("Test Failed - GetStakingPositions() error", err)
+		}
+	} else if err == nil {
+		t.Error("Test Failed - GetStakingPositions() expected error without credentials")
+	}
+}
+
+func TestDetectAccountTier(t *testing.T) {
+	_, err := o.DetectAccountTier()
+	if apiKey != "" || apiSecret != "" {
+		if err != nil {
+			t.Error("Test Failed - DetectAccountTier() error", err)
+		}
+	} else if err == nil {
+		t.Error("Test Failed - DetectAccountTier() expected error without credentials")
+	}
+}
+
+func TestModifyOrder(t *testing.T) {
+	_, err := o.ModifyOrder(exchange.ModifyOrder{})
+	if err == nil {
+		t.Error("Test failed - ModifyOrder() error")
+	}
+}
+
+// Any tests below this line have the ability to impact your orders on the exchange. Enable canManipulateRealOrders to run them
+// ----------------------------------------------------------------------------------------------------------------------------
+func isRealOrderTestEnabled() bool {
+	if o.APIKey == "" || o.APISecret == "" ||
+		o.APIKey == "Key" || o.APISecret == "Secret" ||
+		!canManipulateRealOrders {
+		return false
+	}
+	return true
+}
+
+func TestSubmitOrder(t *testing.T) {
+	o.SetDefaults()
+	TestSetup(t)
+
+	if !isRealOrderTestEnabled() {
+		t.Skip()
+	}
+
+	var p = pair.CurrencyPair{
+		Delimiter:      "-",
+		FirstCurrency:  symbol.BTC,
+		SecondCurrency: symbol.USDT,
+	}
+	response, err := o.SubmitOrder(p, exchange.Buy, exchange.Limit, 0.001, 10, "")
+	if err != nil || !response.IsOrderPlaced {
+		t.Errorf("Order failed to be placed: %v", err)
+	}
+}
+
+func TestCancelExchangeOrder(t *testing.T) {
+	o.SetDefaults()
+	TestSetup(t)
+
+	if !isRealOrderTestEnabled() {
+		t.Skip()
+	}
+
+	currencyPair := pair.NewCurrencyPairDelimiter("BTC-USDT", "-")
+
+	var orderCancellation = exchange.OrderCancellation{
+		OrderID:      "1",
+		CurrencyPair: currencyPair,
+	}
+
+	err := o.CancelOrder(orderCancellation)
+	if err != nil {
+		t.Errorf("Could not cancel order: %s", err)
+	}
+}
+
+func TestCancelAllExchangeOrders(t *testing.T) {
+	o.SetDefaults()
+	TestSetup(t)
+
+	if !isRealOrderTestEnabled() {
+		t.Skip()
+	}
+
+	resp, err := o.CancelAllOrders(exchange.OrderCancellation{})
+	if err != nil {
+		t.Errorf("Could not cancel order: %s", err)
+	}
+
+	if len(resp.OrderStatus) > 0 {
+		t.Errorf("%v orders failed to cancel", len(resp.OrderStatus))
+	}
+}
+
+func TestOrderLiquidity(t *testing.T) {
+	t.Parallel()
+	tester := map[string]exchange.Liquidity{
+		"M": exchange.Maker,
+		"T": exchange.Taker,
+		"":  exchange.UnknownLiquidity,
+	}
+	for execType, expected := range tester {
+		o := Order{ExecutionType: execType}
+		if l := o.Liquidity(); l != expected {
+			t.Errorf("Test failed. Liquidity() %q expected %q received %q",
+				execType, expected, l)
+		}
+	}
+}
+
+func TestSubmitOrderSubmission(t *testing.T) {
+	var _ exchange.OrderSubmissionSubmitter = &o
+	p := pair.NewCurrencyPair(symbol.BTC, symbol.USDT)
+	for _, s := range []exchange.OrderSubmission{
+		{AssetType: AssetMargin, Pair: p, Side: exchange.Buy, Type: exchange.Limit, BaseAmount: 1, Price: 1},
+		{Pair: p, Side: exchange.Buy, Type: exchange.Limit, BaseAmount: 1, Price: 1, Trigger: exchange.StopLoss, TriggerPrice: 1},
+		{Pair: p, Side: exchange.Buy, Type: exchange.Market, BaseAmount: 1, TimeInForce: exchange.FOK},
+	} {
+		if _, err := o.SubmitOrderSubmission(s); err == nil {
+			t.Errorf("Test Failed - SubmitOrderSubmission() expected %+v rejected", s)
+		}
+	}
+}
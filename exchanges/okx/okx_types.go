@@ -0,0 +1,614 @@
+package okx
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/assets"
+)
+
+// Asset types supported by OKX, spot uses ticker.Spot. Options have no first
+// class asset type and use the spot pairs.
+const (
+	AssetMargin        = assets.Margin
+	AssetPerpetualSwap = assets.PerpetualSwap
+	AssetFutures       = assets.Futures
+	AssetOptions       = "OPTIONS"
+)
+
+// OKX instrument types
+const (
+	InstrumentTypeSpot    = "SPOT"
+	InstrumentTypeMargin  = "MARGIN"
+	InstrumentTypeSwap    = "SWAP"
+	InstrumentTypeFutures = "FUTURES"
+	InstrumentTypeOption  = "OPTION"
+	InstrumentTypeAny     = "ANY"
+)
+
+// Trade modes used when placing orders on the unified account
+const (
+	TradeModeCash     = "cash"
+	TradeModeCross    = "cross"
+	TradeModeIsolated = "isolated"
+)
+
+// Order types
+const (
+	OrderTypeMarket          = "market"
+	OrderTypeLimit           = "limit"
+	OrderTypePostOnly        = "post_only"
+	OrderTypeFillOrKill      = "fok"
+	OrderTypeImmediateCancel = "ioc"
+	OrderTypeTrailingStop    = "move_order_stop"
+)
+
+// Number is an OKX string encoded number, empty strings decode to zero
+type Number float64
+
+// UnmarshalJSON implements the json.Unmarshaler interface
+func (n *Number) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "" || s == "null" {
+		*n = 0
+		return nil
+	}
+
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return err
+	}
+	*n = Number(f)
+	return nil
+}
+
+// Float64 returns the number as a float64
+func (n Number) Float64() float64 {
+	return float64(n)
+}
+
+// Time is an OKX string encoded millisecond timestamp
+type Time time.Time
+
+// UnmarshalJSON implements the json.Unmarshaler interface
+func (t *Time) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "" || s == "null" || s == "0" {
+		*t = Time(time.Time{})
+		return nil
+	}
+
+	ms, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return err
+	}
+	*t = Time(common.UnixTimestampToUTC(ms))
+	return nil
+}
+
+// Time returns the timestamp as a time.Time
+func (t Time) Time() time.Time {
+	return time.Time(t)
+}
+
+// Response is the response envelope for all REST requests
+type Response struct {
+	Code string          `json:"code"`
+	Msg  string          `json:"msg"`
+	Data json.RawMessage `json:"data"`
+}
+
+// Instrument holds instrument details
+type Instrument struct {
+	InstrumentType   string `json:"instType"`
+	InstrumentID     string `json:"instId"`
+	Underlying       string `json:"uly"`
+	InstrumentFamily string `json:"instFamily"`
+	BaseCurrency     string `json:"baseCcy"`
+	QuoteCurrency    string `json:"quoteCcy"`
+	SettleCurrency   string `json:"settleCcy"`
+	ContractValue    Number `json:"ctVal"`
+	ContractMultiple Number `json:"ctMult"`
+	ContractValueCcy string `json:"ctValCcy"`
+	OptionType       string `json:"optType"`
+	Strike           Number `json:"stk"`
+	ListTime         Time   `json:"listTime"`
+	ExpiryTime       Time   `json:"expTime"`
+	Leverage         Number `json:"lever"`
+	TickSize         Number `json:"tickSz"`
+	LotSize          Number `json:"lotSz"`
+	MinSize          Number `json:"minSz"`
+	ContractType     string `json:"ctType"`
+	Alias            string `json:"alias"`
+	State            string `json:"state"`
+}
+
+// Ticker holds ticker data
+type Ticker struct {
+	InstrumentType string `json:"instType"`
+	InstrumentID   string `json:"instId"`
+	Last           Number `json:"last"`
+	LastSize       Number `json:"lastSz"`
+	AskPrice       Number `json:"askPx"`
+	AskSize        Number `json:"askSz"`
+	BidPrice       Number `json:"bidPx"`
+	BidSize        Number `json:"bidSz"`
+	Open24H        Number `json:"open24h"`
+	High24H        Number `json:"high24h"`
+	Low24H         Number `json:"low24h"`
+	VolCcy24H      Number `json:"volCcy24h"`
+	Vol24H         Number `json:"vol24h"`
+	Timestamp      Time   `json:"ts"`
+}
+
+// MarkPrice holds the mark price of a derivatives instrument
+type MarkPrice struct {
+	InstrumentType string `json:"instType"`
+	InstrumentID   string `json:"instId"`
+	MarkPrice      Number `json:"markPx"`
+	Timestamp      Time   `json:"ts"`
+}
+
+// IndexTicker holds the index price of an underlying
+type IndexTicker struct {
+	InstrumentID string `json:"instId"`
+	IndexPrice   Number `json:"idxPx"`
+	Timestamp    Time   `json:"ts"`
+}
+
+// OrderbookResponse holds the raw orderbook response, each level is
+// [price, size, deprecated, number of orders]
+type OrderbookResponse struct {
+	Asks      [][4]string `json:"asks"`
+	Bids      [][4]string `json:"bids"`
+	Timestamp Time        `json:"ts"`
+	Checksum  int64       `json:"checksum"`
+	SeqID     int64       `json:"seqId"`
+	PrevSeqID int64       `json:"prevSeqId"`
+}
+
+// OrderbookItem holds a single orderbook level
+type OrderbookItem struct {
+	Price     float64
+	Amount    float64
+	NumOrders int64
+}
+
+// Orderbook holds parsed orderbook data
+type Orderbook struct {
+	Asks      []OrderbookItem
+	Bids      []OrderbookItem
+	Timestamp time.Time
+}
+
+// Trade holds a public trade
+type Trade struct {
+	InstrumentID string `json:"instId"`
+	TradeID      string `json:"tradeId"`
+	Price        Number `json:"px"`
+	Size         Number `json:"sz"`
+	Side         string `json:"side"`
+	Timestamp    Time   `json:"ts"`
+}
+
+// Candle holds candlestick data
+type Candle struct {
+	Timestamp time.Time
+	Open      float64
+	High      float64
+	Low       float64
+	Close     float64
+	Volume    float64
+}
+
+// AccountBalance holds the unified account balance
+type AccountBalance struct {
+	TotalEquity    Number                 `json:"totalEq"`
+	IsolatedEquity Number                 `json:"isoEq"`
+	AdjustedEquity Number                 `json:"adjEq"`
+	MarginRatio    Number                 `json:"mgnRatio"`
+	UpdateTime     Time                   `json:"uTime"`
+	Details        []AccountBalanceDetail `json:"details"`
+}
+
+// AccountBalanceDetail holds the balance of a single currency
+type AccountBalanceDetail struct {
+	Currency        string `json:"ccy"`
+	Equity          Number `json:"eq"`
+	CashBalance     Number `json:"cashBal"`
+	AvailableBal    Number `json:"availBal"`
+	FrozenBalance   Number `json:"frozenBal"`
+	OrderFrozen     Number `json:"ordFrozen"`
+	AvailableEquity Number `json:"availEq"`
+	Liabilities     Number `json:"liab"`
+	UnrealisedPL    Number `json:"upl"`
+	EquityUSD       Number `json:"eqUsd"`
+	UpdateTime      Time   `json:"uTime"`
+}
+
+// FundingBalance holds the funding account balance of a single currency
+type FundingBalance struct {
+	Currency         string `json:"ccy"`
+	Balance          Number `json:"bal"`
+	FrozenBalance    Number `json:"frozenBal"`
+	AvailableBalance Number `json:"availBal"`
+}
+
+// AccountConfig holds the account configuration
+type AccountConfig struct {
+	UID          string `json:"uid"`
+	AccountLevel string `json:"acctLv"`
+	PositionMode string `json:"posMode"`
+	AutoLoan     bool   `json:"autoLoan"`
+}
+
+// Position holds an open derivatives or margin position
+type Position struct {
+	InstrumentType   string `json:"instType"`
+	InstrumentID     string `json:"instId"`
+	MarginMode       string `json:"mgnMode"`
+	PositionID       string `json:"posId"`
+	PositionSide     string `json:"posSide"`
+	Position         Number `json:"pos"`
+	AveragePrice     Number `json:"avgPx"`
+	UnrealisedPL     Number `json:"upl"`
+	Leverage         Number `json:"lever"`
+	LiquidationPrice Number `json:"liqPx"`
+	MarkPrice        Number `json:"markPx"`
+	Margin           Number `json:"margin"`
+	MarginRatio      Number `json:"mgnRatio"`
+	Currency         string `json:"ccy"`
+	CreationTime     Time   `json:"cTime"`
+	UpdateTime       Time   `json:"uTime"`
+}
+
+// PlaceOrderRequest holds the parameters to place an order
+type PlaceOrderRequest struct {
+	InstrumentID  string `json:"instId"`
+	TradeMode     string `json:"tdMode"`
+	Currency      string `json:"ccy,omitempty"`
+	ClientOrderID string `json:"clOrdId,omitempty"`
+	Tag           string `json:"tag,omitempty"`
+	Side          string `json:"side"`
+	PositionSide  string `json:"posSide,omitempty"`
+	OrderType     string `json:"ordType"`
+	Size          string `json:"sz"`
+	Price         string `json:"px,omitempty"`
+	ReduceOnly    bool   `json:"reduceOnly,omitempty"`
+	TargetCcy     string `json:"tgtCcy,omitempty"`
+}
+
+// CancelOrderRequest holds the parameters to cancel an order
+type CancelOrderRequest struct {
+	InstrumentID  string `json:"instId"`
+	OrderID       string `json:"ordId,omitempty"`
+	ClientOrderID string `json:"clOrdId,omitempty"`
+}
+
+// AmendOrderRequest holds the parameters to amend an order
+type AmendOrderRequest struct {
+	InstrumentID  string `json:"instId"`
+	OrderID       string `json:"ordId,omitempty"`
+	ClientOrderID string `json:"clOrdId,omitempty"`
+	NewSize       string `json:"newSz,omitempty"`
+	NewPrice      string `json:"newPx,omitempty"`
+}
+
+// AlgoOrderRequest holds the parameters to place an algo order, trailing
+// stops set either CallbackRatio or CallbackSpread
+type AlgoOrderRequest struct {
+	InstrumentID   string `json:"instId"`
+	TradeMode      string `json:"tdMode"`
+	ClientOrderID  string `json:"algoClOrdId,omitempty"`
+	Tag            string `json:"tag,omitempty"`
+	Side           string `json:"side"`
+	OrderType      string `json:"ordType"`
+	Size           string `json:"sz"`
+	TargetCcy      string `json:"tgtCcy,omitempty"`
+	CallbackRatio  string `json:"callbackRatio,omitempty"`
+	CallbackSpread string `json:"callbackSpread,omitempty"`
+	ActivePrice    string `json:"activePx,omitempty"`
+}
+
+// CancelAlgoOrderRequest holds the parameters to cancel an algo order
+type CancelAlgoOrderRequest struct {
+	AlgoID       string `json:"algoId"`
+	InstrumentID string `json:"instId"`
+}
+
+// AlgoOrderResponse holds the result of an algo order placement or
+// cancellation
+type AlgoOrderResponse struct {
+	AlgoID        string `json:"algoId"`
+	ClientOrderID string `json:"algoClOrdId"`
+	StatusCode    string `json:"sCode"`
+	StatusMessage string `json:"sMsg"`
+}
+
+// OrderResponse holds the result of an order placement, amendment or
+// cancellation
+type OrderResponse struct {
+	OrderID       string `json:"ordId"`
+	ClientOrderID string `json:"clOrdId"`
+	Tag           string `json:"tag"`
+	StatusCode    string `json:"sCode"`
+	StatusMessage string `json:"sMsg"`
+}
+
+// Order holds order details
+type Order struct {
+	InstrumentType  string `json:"instType"`
+	InstrumentID    string `json:"instId"`
+	Currency        string `json:"ccy"`
+	OrderID         string `json:"ordId"`
+	ClientOrderID   string `json:"clOrdId"`
+	Tag             string `json:"tag"`
+	Price           Number `json:"px"`
+	Size            Number `json:"sz"`
+	OrderType       string `json:"ordType"`
+	Side            string `json:"side"`
+	PositionSide    string `json:"posSide"`
+	TradeMode       string `json:"tdMode"`
+	AccFillSize     Number `json:"accFillSz"`
+	FillPrice       Number `json:"fillPx"`
+	TradeID         string `json:"tradeId"`
+	FillSize        Number `json:"fillSz"`
+	FillTime        Time   `json:"fillTime"`
+	AveragePrice    Number `json:"avgPx"`
+	State           string `json:"state"`
+	Leverage        Number `json:"lever"`
+	FeeCurrency     string `json:"feeCcy"`
+	Fee             Number `json:"fee"`
+	Category        string `json:"category"`
+	UpdateTime      Time   `json:"uTime"`
+	CreationTime    Time   `json:"cTime"`
+	ReduceOnly      string `json:"reduceOnly"`
+	TargetCurrency  string `json:"tgtCcy"`
+	ExecutionType   string `json:"execType"`
+	CancelSource    string `json:"cancelSource"`
+	AmendResult     string `json:"amendResult"`
+	RequestID       string `json:"reqId"`
+	MarginCurrency  string `json:"marginCcy"`
+	QuickMarginType string `json:"quickMgnType"`
+}
+
+// Liquidity returns whether the last fill of the order was as the maker or
+// taker
+func (o *Order) Liquidity() exchange.Liquidity {
+	switch o.ExecutionType {
+	case "M":
+		return exchange.Maker
+	case "T":
+		return exchange.Taker
+	}
+	return exchange.UnknownLiquidity
+}
+
+// TradeFee holds the account trading fee rates, negative values are charged
+// and positive values are rebates
+type TradeFee struct {
+	InstrumentType string `json:"instType"`
+	Level          string `json:"level"`
+	Maker          Number `json:"maker"`
+	Taker          Number `json:"taker"`
+	MakerUSDT      Number `json:"makerU"`
+	TakerUSDT      Number `json:"takerU"`
+	Timestamp      Time   `json:"ts"`
+}
+
+// Currency holds funding account currency details
+type Currency struct {
+	Currency       string `json:"ccy"`
+	Name           string `json:"name"`
+	Chain          string `json:"chain"`
+	CanDeposit     bool   `json:"canDep"`
+	CanWithdraw    bool   `json:"canWd"`
+	CanInternal    bool   `json:"canInternal"`
+	MinDeposit     Number `json:"minDep"`
+	MinWithdrawal  Number `json:"minWd"`
+	MaxWithdrawal  Number `json:"maxWd"`
+	MinFee         Number `json:"minFee"`
+	MaxFee         Number `json:"maxFee"`
+	MainNet        bool   `json:"mainNet"`
+	WithdrawTickSz Number `json:"wdTickSz"`
+}
+
+// DepositAddress holds a deposit address
+type DepositAddress struct {
+	Currency  string `json:"ccy"`
+	Chain     string `json:"chain"`
+	Address   string `json:"addr"`
+	Tag       string `json:"tag"`
+	Memo      string `json:"memo"`
+	PaymentID string `json:"pmtId"`
+	To        string `json:"to"`
+	Selected  bool   `json:"selected"`
+}
+
+// WithdrawalRequest holds the parameters for an on chain withdrawal
+type WithdrawalRequest struct {
+	Currency    string `json:"ccy"`
+	Amount      string `json:"amt"`
+	Destination string `json:"dest"`
+	ToAddress   string `json:"toAddr"`
+	Fee         string `json:"fee"`
+	Chain       string `json:"chain,omitempty"`
+}
+
+// WithdrawalResponse holds a submitted withdrawal
+type WithdrawalResponse struct {
+	WithdrawalID string `json:"wdId"`
+	Currency     string `json:"ccy"`
+	Amount       Number `json:"amt"`
+	Chain        string `json:"chain"`
+	ClientID     string `json:"clientId"`
+}
+
+// DepositHistory holds a deposit record
+type DepositHistory struct {
+	Currency      string `json:"ccy"`
+	Chain         string `json:"chain"`
+	Amount        Number `json:"amt"`
+	From          string `json:"from"`
+	To            string `json:"to"`
+	TransactionID string `json:"txId"`
+	Timestamp     Time   `json:"ts"`
+	State         string `json:"state"`
+	DepositID     string `json:"depId"`
+}
+
+// WithdrawalHistory holds a withdrawal record
+type WithdrawalHistory struct {
+	Currency      string `json:"ccy"`
+	Chain         string `json:"chain"`
+	Amount        Number `json:"amt"`
+	From          string `json:"from"`
+	To            string `json:"to"`
+	Tag           string `json:"tag"`
+	TransactionID string `json:"txId"`
+	Fee           Number `json:"fee"`
+	Timestamp     Time   `json:"ts"`
+	State         string `json:"state"`
+	WithdrawalID  string `json:"wdId"`
+}
+
+// SavingsBalance holds the balance of a simple earn savings currency. Rate is
+// the annual lending rate and Earnings the cumulative interest earned.
+type SavingsBalance struct {
+	Currency         string `json:"ccy"`
+	Amount           Number `json:"amt"`
+	Earnings         Number `json:"earnings"`
+	Rate             Number `json:"rate"`
+	LoanAmount       Number `json:"loanAmt"`
+	PendingAmount    Number `json:"pendingAmt"`
+	RedemptionAmount Number `json:"redemptAmt"`
+}
+
+// StakingOrder holds an active on-chain earn order
+type StakingOrder struct {
+	OrderID      string        `json:"ordId"`
+	Currency     string        `json:"ccy"`
+	ProductID    string        `json:"productId"`
+	State        string        `json:"state"`
+	Protocol     string        `json:"protocol"`
+	ProtocolType string        `json:"protocolType"`
+	Term         string        `json:"term"`
+	APY          Number        `json:"apy"`
+	InvestData   []StakingData `json:"investData"`
+	EarningData  []StakingData `json:"earningData"`
+	PurchasedAt  Time          `json:"purchasedTime"`
+}
+
+// Unlock returns when a fixed term order's term ends, zero for flexible
+// orders
+func (s *StakingOrder) Unlock() time.Time {
+	days, err := strconv.Atoi(s.Term)
+	if err != nil || days <= 0 || s.PurchasedAt.Time().IsZero() {
+		return time.Time{}
+	}
+	return s.PurchasedAt.Time().AddDate(0, 0, days)
+}
+
+// StakingPurchaseRequest invests in an on-chain earn product
+type StakingPurchaseRequest struct {
+	ProductID  string              `json:"productId"`
+	InvestData []StakingInvestment `json:"investData"`
+	Term       string              `json:"term,omitempty"`
+}
+
+// StakingInvestment is an amount of currency to invest
+type StakingInvestment struct {
+	Currency string `json:"ccy"`
+	Amount   string `json:"amt"`
+}
+
+// StakingRedeemRequest redeems an on-chain earn order
+type StakingRedeemRequest struct {
+	OrderID          string `json:"ordId"`
+	ProtocolType     string `json:"protocolType"`
+	AllowEarlyRedeem bool   `json:"allowEarlyRedeem"`
+}
+
+// StakingData holds an invested or earned amount of a staking order
+type StakingData struct {
+	Currency string `json:"ccy"`
+	Amount   Number `json:"amt"`
+	Earnings Number `json:"earnings"`
+}
+
+// Deposit and withdrawal states
+var (
+	depositStates = map[string]string{
+		"0": "WAITING_FOR_CONFIRMATION",
+		"1": "CREDITED",
+		"2": "SUCCESSFUL",
+		"8": "PENDING",
+	}
+	withdrawalStates = map[string]string{
+		"-3": "CANCELING",
+		"-2": "CANCELED",
+		"-1": "FAILED",
+		"0":  "PENDING",
+		"1":  "SENDING",
+		"2":  "SENT",
+		"3":  "AWAITING_EMAIL_VERIFICATION",
+		"4":  "AWAITING_MANUAL_VERIFICATION",
+		"5":  "AWAITING_IDENTITY_VERIFICATION",
+	}
+)
+
+// WsRequest is a websocket operation request
+type WsRequest struct {
+	ID        string        `json:"id,omitempty"`
+	Operation string        `json:"op"`
+	Arguments []interface{} `json:"args"`
+}
+
+// WsChannel defines a websocket channel subscription argument
+type WsChannel struct {
+	Channel        string `json:"channel"`
+	InstrumentType string `json:"instType,omitempty"`
+	InstrumentID   string `json:"instId,omitempty"`
+	Currency       string `json:"ccy,omitempty"`
+}
+
+// WsLogin holds websocket login arguments
+type WsLogin struct {
+	APIKey     string `json:"apiKey"`
+	Passphrase string `json:"passphrase"`
+	Timestamp  string `json:"timestamp"`
+	Sign       string `json:"sign"`
+}
+
+// WsResponse is the generic websocket response used to route messages
+type WsResponse struct {
+	ID        string          `json:"id"`
+	Event     string          `json:"event"`
+	Operation string          `json:"op"`
+	Code      string          `json:"code"`
+	Msg       string          `json:"msg"`
+	Action    string          `json:"action"`
+	Argument  WsChannel       `json:"arg"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// wsOrderResponse holds the result of a websocket order operation
+type wsOrderResponse struct {
+	Code string
+	Msg  string
+	Data []OrderResponse
+}
+
+// SystemStatus holds a system maintenance event, State is one of scheduled,
+// ongoing, pre_open, completed or canceled
+type SystemStatus struct {
+	Title       string `json:"title"`
+	State       string `json:"state"`
+	Begin       Time   `json:"begin"`
+	End         Time   `json:"end"`
+	ServiceType string `json:"serviceType"`
+}
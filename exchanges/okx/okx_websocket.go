@@ -0,0 +1,741 @@
+package okx
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/assets"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+const (
+	okxWebsocketPublicURL  = "wss://ws.okx.com:8443/ws/v5/public"
+	okxWebsocketPrivateURL = "wss://ws.okx.com:8443/ws/v5/private"
+
+	okxWsSimulatedPublicURL  = "wss://wspap.okx.com:8443/ws/v5/public?brokerId=9999"
+	okxWsSimulatedPrivateURL = "wss://wspap.okx.com:8443/ws/v5/private?brokerId=9999"
+
+	// Public channels
+	okxWsTickers      = "tickers"
+	okxWsTrades       = "trades"
+	okxWsBooks        = "books"
+	okxWsMarkPrice    = "mark-price"
+	okxWsIndexTickers = "index-tickers"
+
+	// Private channels
+	okxWsAccount   = "account"
+	okxWsOrders    = "orders"
+	okxWsPositions = "positions"
+
+	// Operations
+	okxWsOpSubscribe   = "subscribe"
+	okxWsOpUnsubscribe = "unsubscribe"
+	okxWsOpLogin       = "login"
+	okxWsOpOrder       = "order"
+	okxWsOpCancelOrder = "cancel-order"
+	okxWsOpAmendOrder  = "amend-order"
+
+	okxWsPingInterval   = time.Second * 25
+	okxWsLoginTimeout   = time.Second * 10
+	okxWsRequestTimeout = time.Second * 10
+)
+
+// WsConnect initiates the public websocket connection and, when
+// authenticated API support is enabled, the private connection
+func (o *OKX) WsConnect() error {
+	if !o.Websocket.IsEnabled() || !o.IsEnabled() {
+		return errors.New(exchange.WebsocketNotEnabled)
+	}
+
+	o.Websocket.Orderbook.FlushCache()
+
+	var err error
+	o.WebsocketConn, err = o.wsDial(o.Websocket.GetWebsocketURL())
+	if err != nil {
+		return err
+	}
+
+	go o.WsReadData(o.WebsocketConn)
+	go o.wsPingHandler(o.WebsocketConn, &o.wsWriteLock)
+	go o.WsHandleData()
+
+	if !o.AuthenticatedAPISupport {
+		return nil
+	}
+
+	privateURL := okxWebsocketPrivateURL
+	if o.Simulated {
+		privateURL = okxWsSimulatedPrivateURL
+	}
+
+	o.WebsocketPrivateConn, err = o.wsDial(privateURL)
+	if err != nil {
+		return err
+	}
+
+	err = o.wsLogin()
+	if err != nil {
+		o.WebsocketPrivateConn.Close()
+		o.WebsocketPrivateConn = nil
+		return err
+	}
+
+	go o.WsReadData(o.WebsocketPrivateConn)
+	go o.wsPingHandler(o.WebsocketPrivateConn, &o.wsPrivateWriteLock)
+
+	return o.WsSubscribePrivate()
+}
+
+// wsDial dials a websocket URL using the configured proxy
+func (o *OKX) wsDial(address string) (*websocket.Conn, error) {
+	var dialer websocket.Dialer
+	dialer.TLSClientConfig = o.GetTLSConfig()
+	dialer.NetDial = o.GetWebsocketNetDial()
+	if o.Websocket.GetProxyAddress() != "" {
+		proxy, err := url.Parse(o.Websocket.GetProxyAddress())
+		if err != nil {
+			return nil, err
+		}
+		dialer.Proxy = http.ProxyURL(proxy)
+	}
+
+	conn, _, err := dialer.Dial(address, http.Header{})
+	if err != nil {
+		return nil, fmt.Errorf("%s unable to connect to websocket %s. Error: %s",
+			o.Name,
+			address,
+			err)
+	}
+	return conn, nil
+}
+
+// wsWrite sends a JSON message over the supplied connection
+func (o *OKX) wsWrite(conn *websocket.Conn, private bool, data interface{}) error {
+	if conn == nil {
+		return errors.New("websocket connection not established")
+	}
+
+	if private {
+		o.wsPrivateWriteLock.Lock()
+		defer o.wsPrivateWriteLock.Unlock()
+	} else {
+		o.wsWriteLock.Lock()
+		defer o.wsWriteLock.Unlock()
+	}
+
+	payload, err := common.JSONEncode(data)
+	if err != nil {
+		return err
+	}
+	return conn.WriteMessage(websocket.TextMessage, payload)
+}
+
+// wsLoginSign returns the websocket login signature
+func (o *OKX) wsLoginSign(timestamp string) string {
+	return o.sign(timestamp, "GET", "/users/self/verify", nil)
+}
+
+// wsLogin authenticates the private connection and waits for the result
+func (o *OKX) wsLogin() error {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	err := o.wsWrite(o.WebsocketPrivateConn, true, WsRequest{
+		Operation: okxWsOpLogin,
+		Arguments: []interface{}{WsLogin{
+			APIKey:     o.APIKey,
+			Passphrase: o.ClientID,
+			Timestamp:  timestamp,
+			Sign:       o.wsLoginSign(timestamp),
+		}},
+	})
+	if err != nil {
+		return err
+	}
+
+	err = o.WebsocketPrivateConn.SetReadDeadline(time.Now().Add(okxWsLoginTimeout))
+	if err != nil {
+		return err
+	}
+	defer o.WebsocketPrivateConn.SetReadDeadline(time.Time{})
+
+	for {
+		_, raw, err := o.WebsocketPrivateConn.ReadMessage()
+		if err != nil {
+			return fmt.Errorf("%s websocket login error: %s", o.Name, err)
+		}
+
+		var resp WsResponse
+		if common.JSONDecode(raw, &resp) != nil {
+			continue
+		}
+
+		switch resp.Event {
+		case okxWsOpLogin:
+			if resp.Code != okxSuccessCode {
+				return fmt.Errorf("%s websocket login failed code %s: %s",
+					o.Name,
+					resp.Code,
+					resp.Msg)
+			}
+			return nil
+		case "error":
+			return fmt.Errorf("%s websocket login failed code %s: %s",
+				o.Name,
+				resp.Code,
+				resp.Msg)
+		}
+	}
+}
+
+// wsDefaultSubscriptions returns the public ticker, trade and orderbook
+// channels of all enabled spot pairs
+func (o *OKX) wsDefaultSubscriptions() []exchange.ChannelSubscription {
+	var subs []exchange.ChannelSubscription
+	for _, p := range o.GetEnabledCurrencies() {
+		for _, channel := range []string{okxWsTickers, okxWsTrades, okxWsBooks} {
+			subs = append(subs, exchange.ChannelSubscription{
+				Channel:   channel,
+				Currency:  p,
+				AssetType: assets.Spot,
+			})
+		}
+	}
+	return subs
+}
+
+// WsSubscribe subscribes to public channels
+func (o *OKX) WsSubscribe(subs []exchange.ChannelSubscription) error {
+	return o.wsWriteSubscriptions(okxWsOpSubscribe, subs)
+}
+
+// WsUnsubscribe unsubscribes from public channels
+func (o *OKX) WsUnsubscribe(subs []exchange.ChannelSubscription) error {
+	return o.wsWriteSubscriptions(okxWsOpUnsubscribe, subs)
+}
+
+// wsWriteSubscriptions sends a subscribe or unsubscribe operation for public
+// channels on the public connection. Index tickers are subscribed by their
+// underlying and swap and futures channels by instrument ID.
+func (o *OKX) wsWriteSubscriptions(operation string, subs []exchange.ChannelSubscription) error {
+	var channels []interface{}
+	for i := range subs {
+		channel := WsChannel{Channel: subs[i].Channel}
+		if !subs[i].Currency.Empty() {
+			channel.InstrumentID = exchange.FormatExchangeCurrency(o.Name, subs[i].Currency).String()
+			if subs[i].Channel != okxWsIndexTickers &&
+				(subs[i].AssetType == AssetPerpetualSwap || subs[i].AssetType == AssetFutures) {
+				instrumentID, err := o.FormatInstrumentID(subs[i].Currency, subs[i].AssetType)
+				if err != nil {
+					return err
+				}
+				channel.InstrumentID = instrumentID
+			}
+		}
+		channels = append(channels, channel)
+	}
+
+	if len(channels) == 0 {
+		return nil
+	}
+
+	return o.wsWrite(o.WebsocketConn, false, WsRequest{
+		Operation: operation,
+		Arguments: channels,
+	})
+}
+
+// WsSubscribePrivate subscribes to account, order and position updates
+func (o *OKX) WsSubscribePrivate() error {
+	return o.wsWrite(o.WebsocketPrivateConn, true, WsRequest{
+		Operation: okxWsOpSubscribe,
+		Arguments: []interface{}{
+			WsChannel{Channel: okxWsAccount},
+			WsChannel{Channel: okxWsOrders, InstrumentType: InstrumentTypeAny},
+			WsChannel{Channel: okxWsPositions, InstrumentType: InstrumentTypeAny},
+		},
+	})
+}
+
+// WsReadData reads data from a websocket connection
+func (o *OKX) WsReadData(conn *websocket.Conn) {
+	o.Websocket.Wg.Add(1)
+
+	defer func() {
+		err := conn.Close()
+		if err != nil {
+			o.Websocket.DataHandler <- fmt.Errorf("okx_websocket.go - Unable to to close Websocket connection. Error: %s",
+				err)
+		}
+		o.Websocket.Wg.Done()
+	}()
+
+	for {
+		select {
+		case <-o.Websocket.ShutdownC:
+			return
+
+		default:
+			_, resp, err := conn.ReadMessage()
+			if err != nil {
+				o.Websocket.DataHandler <- err
+				return
+			}
+
+			o.Websocket.TrafficAlert <- struct{}{}
+			o.Websocket.Intercomm <- exchange.WebsocketResponse{Raw: resp}
+		}
+	}
+}
+
+// wsPingHandler keeps a connection alive, OKX closes connections after 30
+// seconds without traffic
+func (o *OKX) wsPingHandler(conn *websocket.Conn, lock *sync.Mutex) {
+	o.Websocket.Wg.Add(1)
+	defer o.Websocket.Wg.Done()
+
+	t := time.NewTicker(okxWsPingInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-o.Websocket.ShutdownC:
+			return
+
+		case <-t.C:
+			lock.Lock()
+			err := conn.WriteMessage(websocket.TextMessage, []byte("ping"))
+			lock.Unlock()
+			if err != nil {
+				o.Websocket.DataHandler <- err
+				return
+			}
+		}
+	}
+}
+
+// WsHandleData handles the read data from the websocket connections
+func (o *OKX) WsHandleData() {
+	o.Websocket.Wg.Add(1)
+	defer o.Websocket.Wg.Done()
+
+	for {
+		select {
+		case <-o.Websocket.ShutdownC:
+			return
+
+		case resp := <-o.Websocket.Intercomm:
+			if string(resp.Raw) == "pong" {
+				continue
+			}
+
+			err := o.wsHandleMessage(resp.Raw)
+			if err != nil {
+				o.Websocket.DataHandler <- fmt.Sprintf("%s websocket handling error: %s",
+					o.Name,
+					err)
+			}
+		}
+	}
+}
+
+// wsHandleMessage routes a single websocket message
+func (o *OKX) wsHandleMessage(raw []byte) error {
+	var resp WsResponse
+	err := common.JSONDecode(raw, &resp)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case resp.ID != "":
+		return o.wsRouteOrderResponse(&resp)
+	case resp.Event == "error":
+		return fmt.Errorf("code %s: %s", resp.Code, resp.Msg)
+	case resp.Event != "":
+		return nil
+	}
+
+	switch resp.Argument.Channel {
+	case okxWsTickers:
+		return o.wsProcessTickers(&resp)
+	case okxWsTrades:
+		return o.wsProcessTrades(&resp)
+	case okxWsBooks:
+		return o.wsProcessOrderbook(&resp)
+	case okxWsMarkPrice:
+		return o.wsProcessMarkPrices(&resp)
+	case okxWsIndexTickers:
+		var tickers []IndexTicker
+		err = common.JSONDecode(resp.Data, &tickers)
+		if err != nil {
+			return err
+		}
+		o.wsIndexLock.Lock()
+		if o.wsIndexPrices == nil {
+			o.wsIndexPrices = make(map[string]float64)
+		}
+		for i := range tickers {
+			o.wsIndexPrices[tickers[i].InstrumentID] = tickers[i].IndexPrice.Float64()
+		}
+		o.wsIndexLock.Unlock()
+	case okxWsOrders:
+		var orders []Order
+		err = common.JSONDecode(resp.Data, &orders)
+		if err != nil {
+			return err
+		}
+		for i := range orders {
+			o.Websocket.DataHandler <- orders[i]
+		}
+	case okxWsAccount:
+		var balances []AccountBalance
+		err = common.JSONDecode(resp.Data, &balances)
+		if err != nil {
+			return err
+		}
+		for i := range balances {
+			o.Websocket.DataHandler <- balances[i]
+		}
+	case okxWsPositions:
+		var positions []Position
+		err = common.JSONDecode(resp.Data, &positions)
+		if err != nil {
+			return err
+		}
+		for i := range positions {
+			p, assetType, err := InstrumentIDToPair(positions[i].InstrumentID)
+			if err != nil {
+				return err
+			}
+			o.Websocket.DataHandler <- exchange.WebsocketPositionUpdated{
+				Timestamp: positions[i].UpdateTime.Time(),
+				Pair:      p,
+				AssetType: assetType,
+				Exchange:  o.Name,
+			}
+		}
+	}
+	return nil
+}
+
+// wsProcessTickers sends ticker updates to the data handler
+func (o *OKX) wsProcessTickers(resp *WsResponse) error {
+	var tickers []Ticker
+	err := common.JSONDecode(resp.Data, &tickers)
+	if err != nil {
+		return err
+	}
+
+	for i := range tickers {
+		p, assetType, err := InstrumentIDToPair(tickers[i].InstrumentID)
+		if err != nil {
+			return err
+		}
+		o.Websocket.DataHandler <- exchange.TickerData{
+			Timestamp:  tickers[i].Timestamp.Time(),
+			Pair:       p,
+			AssetType:  assetType,
+			Exchange:   o.Name,
+			ClosePrice: tickers[i].Last.Float64(),
+			Quantity:   tickers[i].Vol24H.Float64(),
+			OpenPrice:  tickers[i].Open24H.Float64(),
+			HighPrice:  tickers[i].High24H.Float64(),
+			LowPrice:   tickers[i].Low24H.Float64(),
+		}
+	}
+	return nil
+}
+
+// wsProcessMarkPrices sends swap and futures mark prices to the data handler,
+// with the last index price of their underlying when index tickers are
+// subscribed
+func (o *OKX) wsProcessMarkPrices(resp *WsResponse) error {
+	var marks []MarkPrice
+	err := common.JSONDecode(resp.Data, &marks)
+	if err != nil {
+		return err
+	}
+
+	for i := range marks {
+		p, assetType, err := InstrumentIDToPair(marks[i].InstrumentID)
+		if err != nil {
+			return err
+		}
+		o.wsIndexLock.Lock()
+		index := o.wsIndexPrices[p.Pair().String()]
+		o.wsIndexLock.Unlock()
+		o.Websocket.DataHandler <- exchange.MarkPriceData{
+			Timestamp:    marks[i].Timestamp.Time(),
+			Pair:         p,
+			AssetType:    assetType,
+			Exchange:     o.Name,
+			InstrumentID: marks[i].InstrumentID,
+			MarkPrice:    marks[i].MarkPrice.Float64(),
+			IndexPrice:   index,
+		}
+	}
+	return nil
+}
+
+// wsProcessTrades sends trade updates to the data handler
+func (o *OKX) wsProcessTrades(resp *WsResponse) error {
+	var trades []Trade
+	err := common.JSONDecode(resp.Data, &trades)
+	if err != nil {
+		return err
+	}
+
+	for i := range trades {
+		p, assetType, err := InstrumentIDToPair(trades[i].InstrumentID)
+		if err != nil {
+			return err
+		}
+		o.Websocket.DataHandler <- exchange.TradeData{
+			Timestamp:    trades[i].Timestamp.Time(),
+			CurrencyPair: p,
+			AssetType:    assetType,
+			Exchange:     o.Name,
+			Price:        trades[i].Price.Float64(),
+			Amount:       trades[i].Size.Float64(),
+			Side:         trades[i].Side,
+		}
+	}
+	return nil
+}
+
+// wsProcessOrderbook loads orderbook snapshots and applies incremental
+// updates to the instrument's orderbook buffer. Each update's prevSeqId must
+// match the previous seqId, the books channel is resubscribed for a new
+// snapshot when a gap is detected.
+func (o *OKX) wsProcessOrderbook(resp *WsResponse) error {
+	var books []OrderbookResponse
+	err := common.JSONDecode(resp.Data, &books)
+	if err != nil {
+		return err
+	}
+
+	p, assetType, err := InstrumentIDToPair(resp.Argument.InstrumentID)
+	if err != nil {
+		return err
+	}
+	buffer := o.wsBuffer(resp.Argument.InstrumentID, p, assetType)
+
+	for i := range books {
+		ob, err := parseOrderbook(&books[i])
+		if err != nil {
+			return err
+		}
+
+		asks := make([]orderbook.Item, len(ob.Asks))
+		for x := range ob.Asks {
+			asks[x] = orderbook.Item{Price: ob.Asks[x].Price, Amount: ob.Asks[x].Amount}
+		}
+		bids := make([]orderbook.Item, len(ob.Bids))
+		for x := range ob.Bids {
+			bids[x] = orderbook.Item{Price: ob.Bids[x].Price, Amount: ob.Bids[x].Amount}
+		}
+
+		if resp.Action == "snapshot" {
+			err = buffer.LoadSnapshot(orderbook.Base{
+				CurrencyPair: p.Pair().String(),
+				Asks:         asks,
+				Bids:         bids,
+				LastUpdated:  ob.Timestamp,
+			}, books[i].SeqID)
+		} else {
+			err = buffer.Apply(orderbook.Update{
+				Bids:         bids,
+				Asks:         asks,
+				UpdateID:     books[i].SeqID,
+				PrevUpdateID: books[i].PrevSeqID,
+				UpdateTime:   ob.Timestamp,
+			})
+		}
+		if err != nil {
+			return fmt.Errorf("%s orderbook resyncing: %s", resp.Argument.InstrumentID, err)
+		}
+
+		if buffer.Synced() {
+			o.Websocket.DataHandler <- exchange.WebsocketOrderbookUpdate{
+				Pair:     p,
+				Asset:    assetType,
+				Exchange: o.Name,
+			}
+		}
+	}
+	return nil
+}
+
+// wsBuffer returns the orderbook buffer of an instrument, creating it on its
+// first snapshot
+func (o *OKX) wsBuffer(instrumentID string, p pair.CurrencyPair, assetType string) *orderbook.Buffer {
+	o.wsBuffersLock.Lock()
+	defer o.wsBuffersLock.Unlock()
+	if o.wsBuffers == nil {
+		o.wsBuffers = make(map[string]*orderbook.Buffer)
+	}
+	buffer, ok := o.wsBuffers[instrumentID]
+	if !ok {
+		buffer = orderbook.NewBuffer(o.Name, p, assetType)
+		buffer.Resubscribe = func() error {
+			return o.wsResubscribeBook(instrumentID)
+		}
+		o.wsBuffers[instrumentID] = buffer
+	}
+	return buffer
+}
+
+// wsResubscribeBook resubscribes to an instrument's books channel, which
+// starts with a fresh snapshot
+func (o *OKX) wsResubscribeBook(instrumentID string) error {
+	channel := WsChannel{Channel: okxWsBooks, InstrumentID: instrumentID}
+	for _, operation := range []string{okxWsOpUnsubscribe, okxWsOpSubscribe} {
+		err := o.wsWrite(o.WebsocketConn, false, WsRequest{
+			Operation: operation,
+			Arguments: []interface{}{channel},
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// wsRouteOrderResponse delivers an order operation response to the waiting
+// request
+func (o *OKX) wsRouteOrderResponse(resp *WsResponse) error {
+	o.wsRequestsLock.Lock()
+	ch, ok := o.wsRequests[resp.ID]
+	delete(o.wsRequests, resp.ID)
+	o.wsRequestsLock.Unlock()
+	if !ok {
+		return fmt.Errorf("unexpected response for request ID %s", resp.ID)
+	}
+
+	result := wsOrderResponse{Code: resp.Code, Msg: resp.Msg}
+	if len(resp.Data) > 0 {
+		err := common.JSONDecode(resp.Data, &result.Data)
+		if err != nil {
+			result.Code = "-1"
+			result.Msg = err.Error()
+		}
+	}
+	ch <- result
+	return nil
+}
+
+// wsSendOrderOperation sends an order operation on the private connection
+// and waits for its response. exchange.ErrWebsocketOrderNotSent is returned
+// when the request could not be written so callers can fall back to REST.
+func (o *OKX) wsSendOrderOperation(operation string, arg interface{}) (OrderResponse, error) {
+	if o.WebsocketPrivateConn == nil {
+		return OrderResponse{}, exchange.ErrWebsocketOrderNotSent
+	}
+
+	id := strconv.FormatInt(atomic.AddInt64(&o.wsRequestID, 1), 10)
+	ch := make(chan wsOrderResponse, 1)
+	o.wsRequestsLock.Lock()
+	o.wsRequests[id] = ch
+	o.wsRequestsLock.Unlock()
+
+	removeRequest := func() {
+		o.wsRequestsLock.Lock()
+		delete(o.wsRequests, id)
+		o.wsRequestsLock.Unlock()
+	}
+
+	err := o.wsWrite(o.WebsocketPrivateConn, true, WsRequest{
+		ID:        id,
+		Operation: operation,
+		Arguments: []interface{}{arg},
+	})
+	if err != nil {
+		removeRequest()
+		return OrderResponse{}, exchange.ErrWebsocketOrderNotSent
+	}
+
+	timer := time.NewTimer(okxWsRequestTimeout)
+	defer timer.Stop()
+
+	select {
+	case result := <-ch:
+		if len(result.Data) == 0 && result.Code != okxSuccessCode {
+			return OrderResponse{}, fmt.Errorf("%s error code %s: %s",
+				o.Name,
+				result.Code,
+				result.Msg)
+		}
+		return firstOrderResponse(result.Data, nil)
+	case <-timer.C:
+		removeRequest()
+		return OrderResponse{}, fmt.Errorf("%s websocket %s request %s timed out awaiting response",
+			o.Name,
+			operation,
+			id)
+	}
+}
+
+// WsSubmitOrder submits a spot order over the private websocket connection
+func (o *OKX) WsSubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	var submitOrderResponse exchange.SubmitOrderResponse
+	arg, err := o.buildSpotOrder(p, side, orderType, amount, price, clientID)
+	if err != nil {
+		return submitOrderResponse, err
+	}
+
+	resp, err := o.wsSendOrderOperation(okxWsOpOrder, arg)
+	if err != nil {
+		return submitOrderResponse, err
+	}
+
+	submitOrderResponse.OrderID = resp.OrderID
+	submitOrderResponse.IsOrderPlaced = true
+	return submitOrderResponse, nil
+}
+
+// WsCancelOrder cancels an order over the private websocket connection
+func (o *OKX) WsCancelOrder(order exchange.OrderCancellation) error {
+	_, err := o.wsSendOrderOperation(okxWsOpCancelOrder, CancelOrderRequest{
+		InstrumentID: exchange.FormatExchangeCurrency(o.Name, order.CurrencyPair).String(),
+		OrderID:      order.OrderID,
+	})
+	return err
+}
+
+// WsAmendOrder amends an order over the private websocket connection
+func (o *OKX) WsAmendOrder(arg AmendOrderRequest) (OrderResponse, error) {
+	return o.wsSendOrderOperation(okxWsOpAmendOrder, arg)
+}
+
+// InstrumentIDToPair converts an OKX instrument ID into a currency pair and
+// asset type. Margin instruments share spot IDs and are returned as spot.
+func InstrumentIDToPair(instrumentID string) (pair.CurrencyPair, string, error) {
+	parts := strings.Split(instrumentID, "-")
+	if len(parts) < 2 {
+		return pair.CurrencyPair{}, "", fmt.Errorf("invalid instrument ID %s", instrumentID)
+	}
+
+	p := pair.NewCurrencyPairDelimiter(parts[0]+"-"+parts[1], "-")
+	switch {
+	case len(parts) == 2:
+		return p, ticker.Spot, nil
+	case len(parts) == 3 && parts[2] == InstrumentTypeSwap:
+		return p, AssetPerpetualSwap, nil
+	case len(parts) == 3:
+		return p, AssetFutures, nil
+	case len(parts) == 5:
+		return p, AssetOptions, nil
+	}
+	return pair.CurrencyPair{}, "", fmt.Errorf("invalid instrument ID %s", instrumentID)
+}
@@ -0,0 +1,1115 @@
+package okx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+// Start starts the OKX wrapper, refreshing its currency pairs
+func (o *OKX) Start(ctx context.Context) error {
+	return o.StartWrapper(ctx, o.Run)
+}
+
+// Run implements the OKX wrapper
+func (o *OKX) Run(ctx context.Context) error {
+	if o.Verbose {
+		log.Printf("%s Websocket: %s. (url: %s).\n", o.GetName(), common.IsEnabled(o.Websocket.IsEnabled()), o.Websocket.GetWebsocketURL())
+		log.Printf("%s polling delay: %ds.\n", o.GetName(), o.RESTPollingDelay)
+		log.Printf("%s %d currencies enabled: %s.\n", o.GetName(), len(o.EnabledPairs), o.EnabledPairs)
+		log.Printf("%s order transport: %s.\n", o.GetName(), o.GetOrderTransport())
+	}
+
+	instruments, err := o.loadInstruments(InstrumentTypeSpot)
+	if err != nil {
+		log.Printf("%s failed to obtain available spot instruments. Err: %s", o.Name, err)
+		return nil
+	}
+
+	var pairs []string
+	for i := range instruments {
+		if instruments[i].State != "live" {
+			continue
+		}
+		pairs = append(pairs, instruments[i].BaseCurrency+"-"+instruments[i].QuoteCurrency)
+		o.SetPairIncrements(pair.NewCurrencyPair(instruments[i].BaseCurrency, instruments[i].QuoteCurrency),
+			float64(instruments[i].TickSize), float64(instruments[i].LotSize))
+	}
+
+	err = o.UpdateCurrencies(pairs, false, false)
+	if err != nil {
+		log.Printf("%s failed to update available currencies. Err: %s", o.Name, err)
+	}
+	return nil
+}
+
+// loadInstruments fetches and caches instruments for an instrument type
+func (o *OKX) loadInstruments(instrumentType string) ([]Instrument, error) {
+	instruments, err := o.GetInstruments(instrumentType, "")
+	if err != nil {
+		return nil, err
+	}
+
+	o.instrumentsLock.Lock()
+	o.instruments[instrumentType] = instruments
+	o.instrumentsLock.Unlock()
+	return instruments, nil
+}
+
+// assetTypeToInstrumentType converts an asset type to an OKX instrument type
+func assetTypeToInstrumentType(assetType string) (string, error) {
+	switch assetType {
+	case ticker.Spot:
+		return InstrumentTypeSpot, nil
+	case AssetMargin:
+		return InstrumentTypeMargin, nil
+	case AssetPerpetualSwap:
+		return InstrumentTypeSwap, nil
+	case AssetFutures:
+		return InstrumentTypeFutures, nil
+	case AssetOptions:
+		return InstrumentTypeOption, nil
+	}
+	return "", fmt.Errorf("asset type %s not supported", assetType)
+}
+
+// FormatInstrumentID returns the OKX instrument ID for a pair and asset type.
+// Spot and margin share the pair ID, swaps append -SWAP and futures resolve to
+// the nearest expiring contract. Options require a strike and expiry so must
+// be addressed by instrument ID directly.
+func (o *OKX) FormatInstrumentID(p pair.CurrencyPair, assetType string) (string, error) {
+	base := exchange.FormatExchangeCurrency(o.Name, p).String()
+	switch assetType {
+	case ticker.Spot, AssetMargin:
+		return base, nil
+	case AssetPerpetualSwap:
+		return base + "-" + InstrumentTypeSwap, nil
+	case AssetFutures:
+		o.instrumentsLock.Lock()
+		instruments, ok := o.instruments[InstrumentTypeFutures]
+		o.instrumentsLock.Unlock()
+		if !ok {
+			var err error
+			instruments, err = o.loadInstruments(InstrumentTypeFutures)
+			if err != nil {
+				return "", err
+			}
+		}
+		return nearestFuture(instruments, base, time.Now())
+	case AssetOptions:
+		return "", errors.New("options must be addressed by instrument ID")
+	}
+	return "", fmt.Errorf("asset type %s not supported", assetType)
+}
+
+// nearestFuture returns the live futures contract for an underlying with the
+// closest expiry after the supplied time
+func nearestFuture(instruments []Instrument, underlying string, now time.Time) (string, error) {
+	var candidates []Instrument
+	for i := range instruments {
+		if instruments[i].Underlying == underlying &&
+			instruments[i].State == "live" &&
+			instruments[i].ExpiryTime.Time().After(now) {
+			candidates = append(candidates, instruments[i])
+		}
+	}
+
+	if len(candidates) == 0 {
+		return "", fmt.Errorf("no live futures contract found for %s", underlying)
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].ExpiryTime.Time().Before(candidates[j].ExpiryTime.Time())
+	})
+	return candidates[0].InstrumentID, nil
+}
+
+// GetFuturesContracts returns the live dated futures contracts
+func (o *OKX) GetFuturesContracts() ([]exchange.FuturesContract, error) {
+	instruments, err := o.loadInstruments(InstrumentTypeFutures)
+	if err != nil {
+		return nil, err
+	}
+
+	var contracts []exchange.FuturesContract
+	for i := range instruments {
+		if instruments[i].State != "live" {
+			continue
+		}
+		contracts = append(contracts, exchange.FuturesContract{
+			InstrumentID: instruments[i].InstrumentID,
+			Underlying:   pair.NewCurrencyPairDelimiter(instruments[i].Underlying, "-"),
+			Expiry:       instruments[i].ExpiryTime.Time(),
+		})
+	}
+	return contracts, nil
+}
+
+// buildContractOrder converts order parameters into a cross margin order
+// request for a futures or swap contract sized in contracts
+func (o *OKX) buildContractOrder(instrumentID string, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (PlaceOrderRequest, error) {
+	req := PlaceOrderRequest{
+		InstrumentID:  instrumentID,
+		TradeMode:     TradeModeCross,
+		ClientOrderID: clientID,
+		Tag:           o.BrokerTag,
+		Size:          strconv.FormatFloat(amount, 'f', -1, 64),
+	}
+
+	switch side {
+	case exchange.Buy:
+		req.Side = "buy"
+	case exchange.Sell:
+		req.Side = "sell"
+	default:
+		return req, fmt.Errorf("unsupported order side %s", side)
+	}
+
+	switch orderType {
+	case exchange.Limit:
+		req.OrderType = OrderTypeLimit
+		req.Price = strconv.FormatFloat(price, 'f', -1, 64)
+	case exchange.Market:
+		req.OrderType = OrderTypeMarket
+	default:
+		return req, fmt.Errorf("unsupported order type %s", orderType)
+	}
+	return req, nil
+}
+
+// SubmitFuturesOrder submits an order for a futures contract in cross margin
+// mode
+func (o *OKX) SubmitFuturesOrder(instrumentID string, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, reduceOnly bool, clientID string) (exchange.SubmitOrderResponse, error) {
+	var submitOrderResponse exchange.SubmitOrderResponse
+	req, err := o.buildContractOrder(instrumentID, side, orderType, amount, price, clientID)
+	if err != nil {
+		return submitOrderResponse, err
+	}
+	req.ReduceOnly = reduceOnly
+
+	resp, err := o.PlaceOrder(req)
+	if err != nil {
+		return submitOrderResponse, err
+	}
+
+	submitOrderResponse.OrderID = resp.OrderID
+	submitOrderResponse.IsOrderPlaced = true
+	return submitOrderResponse, nil
+}
+
+// GetMarkPrice returns the mark price of a swap or futures contract and the
+// index price of its underlying
+func (o *OKX) GetMarkPrice(instrumentID string) (exchange.MarkPrice, error) {
+	p, assetType, err := InstrumentIDToPair(instrumentID)
+	if err != nil {
+		return exchange.MarkPrice{}, err
+	}
+	instrumentType, err := assetTypeToInstrumentType(assetType)
+	if err != nil {
+		return exchange.MarkPrice{}, err
+	}
+	mark, err := o.GetInstrumentMarkPrice(instrumentType, instrumentID)
+	if err != nil {
+		return exchange.MarkPrice{}, err
+	}
+	index, err := o.GetIndexTicker(p.Pair().String())
+	if err != nil {
+		return exchange.MarkPrice{}, err
+	}
+	return exchange.MarkPrice{
+		InstrumentID: instrumentID,
+		Pair:         p,
+		AssetType:    assetType,
+		MarkPrice:    mark.MarkPrice.Float64(),
+		IndexPrice:   index.IndexPrice.Float64(),
+		Time:         mark.Timestamp.Time(),
+	}, nil
+}
+
+// GetFuturesPositions returns the open swap and futures positions. OKX
+// reports the margin ratio as equity over maintenance margin, liquidating at
+// one, so it is inverted.
+func (o *OKX) GetFuturesPositions() ([]exchange.FuturesPosition, error) {
+	resp, err := o.GetPositions("", "")
+	if err != nil {
+		return nil, err
+	}
+
+	var positions []exchange.FuturesPosition
+	for i := range resp {
+		if resp[i].InstrumentType != InstrumentTypeSwap &&
+			resp[i].InstrumentType != InstrumentTypeFutures {
+			continue
+		}
+		amount := resp[i].Position.Float64()
+		if amount == 0 {
+			continue
+		}
+		if resp[i].PositionSide == "short" {
+			amount = -amount
+		}
+		p, assetType, err := InstrumentIDToPair(resp[i].InstrumentID)
+		if err != nil {
+			return nil, err
+		}
+		position := exchange.FuturesPosition{
+			Exchange:         o.Name,
+			InstrumentID:     resp[i].InstrumentID,
+			Pair:             p,
+			AssetType:        assetType,
+			Amount:           amount,
+			EntryPrice:       resp[i].AveragePrice.Float64(),
+			MarkPrice:        resp[i].MarkPrice.Float64(),
+			LiquidationPrice: resp[i].LiquidationPrice.Float64(),
+			Leverage:         resp[i].Leverage.Float64(),
+			UnrealisedPNL:    resp[i].UnrealisedPL.Float64(),
+			UpdateTime:       resp[i].UpdateTime.Time(),
+		}
+		if ratio := resp[i].MarginRatio.Float64(); ratio > 0 {
+			position.MarginRatio = 1 / ratio
+		}
+		positions = append(positions, position)
+	}
+	return positions, nil
+}
+
+// UpdateTicker updates and returns the ticker for a currency pair
+func (o *OKX) UpdateTicker(p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	return o.UpdateTickerContext(context.Background(), p, assetType)
+}
+
+// UpdateTickerContext updates and returns the ticker for a currency pair,
+// cancelled with the context
+func (o *OKX) UpdateTickerContext(ctx context.Context, p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	var tickerPrice ticker.Price
+
+	if assetType == ticker.Spot {
+		tickers, err := o.GetTickersContext(ctx, InstrumentTypeSpot)
+		if err != nil {
+			return tickerPrice, err
+		}
+
+		tickerMap := make(map[string]*Ticker, len(tickers))
+		for i := range tickers {
+			tickerMap[tickers[i].InstrumentID] = &tickers[i]
+		}
+
+		for _, x := range o.GetEnabledCurrencies() {
+			t, ok := tickerMap[exchange.FormatExchangeCurrency(o.Name, x).String()]
+			if !ok {
+				continue
+			}
+			ticker.ProcessTicker(o.GetName(), x, tickerToPrice(x, t), assetType)
+		}
+		return ticker.GetTicker(o.Name, p, assetType)
+	}
+
+	instrumentID, err := o.FormatInstrumentID(p, assetType)
+	if err != nil {
+		return tickerPrice, err
+	}
+
+	t, err := o.GetTickerContext(ctx, instrumentID)
+	if err != nil {
+		return tickerPrice, err
+	}
+
+	ticker.ProcessTicker(o.GetName(), p, tickerToPrice(p, &t), assetType)
+	return ticker.GetTicker(o.Name, p, assetType)
+}
+
+// tickerToPrice converts an OKX ticker
+func tickerToPrice(p pair.CurrencyPair, t *Ticker) ticker.Price {
+	return ticker.Price{
+		Pair:        p,
+		Last:        t.Last.Float64(),
+		High:        t.High24H.Float64(),
+		Low:         t.Low24H.Float64(),
+		Bid:         t.BidPrice.Float64(),
+		Ask:         t.AskPrice.Float64(),
+		Volume:      t.Vol24H.Float64(),
+		LastUpdated: t.Timestamp.Time(),
+	}
+}
+
+// GetTickerPrice returns the ticker for a currency pair
+func (o *OKX) GetTickerPrice(p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	tickerNew, err := ticker.GetTicker(o.GetName(), p, assetType)
+	if err != nil {
+		return o.UpdateTicker(p, assetType)
+	}
+	return tickerNew, nil
+}
+
+// GetOrderbookEx returns orderbook base on the currency pair
+func (o *OKX) GetOrderbookEx(p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	ob, err := orderbook.GetOrderbook(o.GetName(), p, assetType)
+	if err != nil {
+		return o.UpdateOrderbook(p, assetType)
+	}
+	return ob, nil
+}
+
+// UpdateOrderbook updates and returns the orderbook for a currency pair
+func (o *OKX) UpdateOrderbook(p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	return o.UpdateOrderbookContext(context.Background(), p, assetType)
+}
+
+// UpdateOrderbookContext updates and returns the orderbook for a currency
+// pair, cancelled with the context
+func (o *OKX) UpdateOrderbookContext(ctx context.Context, p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	var orderBook orderbook.Base
+	instrumentID, err := o.FormatInstrumentID(p, assetType)
+	if err != nil {
+		return orderBook, err
+	}
+
+	orderbookNew, err := o.GetOrderbookContext(ctx, instrumentID, 400)
+	if err != nil {
+		return orderBook, err
+	}
+
+	for x := range orderbookNew.Bids {
+		orderBook.Bids = append(orderBook.Bids, orderbook.Item{
+			Amount: orderbookNew.Bids[x].Amount,
+			Price:  orderbookNew.Bids[x].Price,
+		})
+	}
+
+	for x := range orderbookNew.Asks {
+		orderBook.Asks = append(orderBook.Asks, orderbook.Item{
+			Amount: orderbookNew.Asks[x].Amount,
+			Price:  orderbookNew.Asks[x].Price,
+		})
+	}
+
+	orderbook.ProcessOrderbook(o.GetName(), p, orderBook, assetType)
+	return orderbook.GetOrderbook(o.Name, p, assetType)
+}
+
+// GetAccountInfo retrieves balances for all currencies in the unified trading
+// account and the funding account. Hold includes funds frozen by open orders,
+// margin and pending withdrawals.
+func (o *OKX) GetAccountInfo() (exchange.AccountInfo, error) {
+	return o.GetAccountInfoContext(context.Background())
+}
+
+// GetAccountInfoContext retrieves balances for all currencies in the unified
+// trading account and the funding account, cancelled with the context
+func (o *OKX) GetAccountInfoContext(ctx context.Context) (exchange.AccountInfo, error) {
+	var info exchange.AccountInfo
+	trading, err := o.GetAccountBalanceContext(ctx, "")
+	if err != nil {
+		return info, err
+	}
+	funding, err := o.GetFundingBalancesContext(ctx, "")
+	if err != nil {
+		return info, err
+	}
+
+	info.ExchangeName = o.GetName()
+	info.Currencies = unifiedAccountBalances(trading, funding)
+	return info, nil
+}
+
+// unifiedAccountBalances merges the unified trading account and funding
+// account balances by currency. The trading account's cash balance is used
+// rather than its equity, which includes the unrealised profit and loss of
+// positions, and is negative for currencies borrowed in the margin modes.
+func unifiedAccountBalances(trading []AccountBalance, funding []FundingBalance) []exchange.AccountCurrencyInfo {
+	var currencies []exchange.AccountCurrencyInfo
+	index := make(map[string]int)
+	add := func(currency string, total, hold float64) {
+		currency = common.StringToUpper(currency)
+		x, ok := index[currency]
+		if !ok {
+			x = len(currencies)
+			index[currency] = x
+			currencies = append(currencies, exchange.AccountCurrencyInfo{CurrencyName: currency})
+		}
+		currencies[x].TotalValue += total
+		currencies[x].Hold += hold
+	}
+
+	for i := range trading {
+		for j := range trading[i].Details {
+			d := &trading[i].Details[j]
+			add(d.Currency, d.CashBalance.Float64(), d.FrozenBalance.Float64())
+		}
+	}
+	for i := range funding {
+		add(funding[i].Currency, funding[i].Balance.Float64(), funding[i].FrozenBalance.Float64())
+	}
+	return currencies
+}
+
+// GetFundingHistory returns funding history, deposits and
+// withdrawals
+func (o *OKX) GetFundingHistory() ([]exchange.FundHistory, error) {
+	var fundHistory []exchange.FundHistory
+	deposits, err := o.GetDepositHistory("")
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range deposits {
+		id, _ := strconv.ParseInt(deposits[i].DepositID, 10, 64)
+		fundHistory = append(fundHistory, exchange.FundHistory{
+			ExchangeName:      o.Name,
+			Status:            depositStates[deposits[i].State],
+			TransferID:        id,
+			Timestamp:         deposits[i].Timestamp.Time(),
+			Currency:          deposits[i].Currency,
+			Amount:            deposits[i].Amount.Float64(),
+			TransferType:      "deposit",
+			Chain:             deposits[i].Chain,
+			CryptoToAddress:   deposits[i].To,
+			CryptoFromAddress: deposits[i].From,
+			CryptoTxID:        deposits[i].TransactionID,
+		})
+	}
+
+	withdrawals, err := o.GetWithdrawalHistory("")
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range withdrawals {
+		id, _ := strconv.ParseInt(withdrawals[i].WithdrawalID, 10, 64)
+		fundHistory = append(fundHistory, exchange.FundHistory{
+			ExchangeName:      o.Name,
+			Status:            withdrawalStates[withdrawals[i].State],
+			TransferID:        id,
+			Timestamp:         withdrawals[i].Timestamp.Time(),
+			Currency:          withdrawals[i].Currency,
+			Amount:            withdrawals[i].Amount.Float64(),
+			NetworkFee:        withdrawals[i].Fee.Float64(),
+			TransferType:      "withdrawal",
+			Chain:             withdrawals[i].Chain,
+			CryptoToAddress:   withdrawals[i].To,
+			CryptoFromAddress: withdrawals[i].From,
+			CryptoTxID:        withdrawals[i].TransactionID,
+		})
+	}
+	return fundHistory, nil
+}
+
+// GetExchangeHistory returns the most recent public trades
+func (o *OKX) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]exchange.TradeHistory, error) {
+	instrumentID, err := o.FormatInstrumentID(p, assetType)
+	if err != nil {
+		return nil, err
+	}
+
+	trades, err := o.GetTrades(instrumentID, 500)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]exchange.TradeHistory, len(trades))
+	for i := range trades {
+		tid, _ := strconv.ParseInt(trades[i].TradeID, 10, 64)
+		resp[i] = exchange.TradeHistory{
+			Timestamp: trades[i].Timestamp.Time(),
+			TID:       tid,
+			Price:     trades[i].Price.Float64(),
+			Amount:    trades[i].Size.Float64(),
+			Exchange:  o.Name,
+			Type:      trades[i].Side,
+		}
+	}
+	return resp, nil
+}
+
+// buildSpotOrder converts order parameters into a spot order request. Market
+// buys are sized in the base currency to match limit orders.
+func (o *OKX) buildSpotOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (PlaceOrderRequest, error) {
+	price, amount = o.RoundOrder(p, side, price, amount)
+	req := PlaceOrderRequest{
+		InstrumentID:  exchange.FormatExchangeCurrency(o.Name, p).String(),
+		TradeMode:     TradeModeCash,
+		ClientOrderID: clientID,
+		Tag:           o.BrokerTag,
+		Size:          strconv.FormatFloat(amount, 'f', -1, 64),
+	}
+
+	switch side {
+	case exchange.Buy:
+		req.Side = "buy"
+	case exchange.Sell:
+		req.Side = "sell"
+	default:
+		return req, fmt.Errorf("unsupported order side %s", side)
+	}
+
+	switch orderType {
+	case exchange.Limit:
+		req.OrderType = OrderTypeLimit
+		req.Price = strconv.FormatFloat(price, 'f', -1, 64)
+	case exchange.Market:
+		req.OrderType = OrderTypeMarket
+		req.TargetCcy = "base_ccy"
+	case exchange.ImmediateOrCancel:
+		req.OrderType = OrderTypeImmediateCancel
+		req.Price = strconv.FormatFloat(price, 'f', -1, 64)
+	default:
+		return req, fmt.Errorf("unsupported order type %s", orderType)
+	}
+	return req, nil
+}
+
+// SubmitOrder submits a new spot order, via websocket when configured and
+// connected otherwise via REST
+func (o *OKX) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	if o.IsSimulated() {
+		return o.SimulateSubmitOrder(p, side, orderType, amount, price, clientID)
+	}
+
+	return o.SubmitOrderViaTransport(o.restSubmitOrder, p, side, orderType, amount, price, clientID)
+}
+
+// SubmitOrderContext submits a new spot order via REST, cancelled with the
+// context
+func (o *OKX) SubmitOrderContext(ctx context.Context, p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	if o.IsSimulated() {
+		return o.SimulateSubmitOrder(p, side, orderType, amount, price, clientID)
+	}
+
+	return o.submitOrderContext(ctx, p, side, orderType, amount, price, clientID)
+}
+
+// restSubmitOrder submits a new spot order via REST
+func (o *OKX) restSubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	return o.submitOrderContext(context.Background(), p, side, orderType, amount, price, clientID)
+}
+
+// submitOrderContext submits a new spot order via REST, cancelled with the
+// context
+func (o *OKX) submitOrderContext(ctx context.Context, p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	var submitOrderResponse exchange.SubmitOrderResponse
+	req, err := o.buildSpotOrder(p, side, orderType, amount, price, clientID)
+	if err != nil {
+		return submitOrderResponse, err
+	}
+
+	resp, err := o.PlaceOrderContext(ctx, req)
+	if err != nil {
+		return submitOrderResponse, err
+	}
+
+	submitOrderResponse.OrderID = resp.OrderID
+	submitOrderResponse.IsOrderPlaced = true
+	return submitOrderResponse, nil
+}
+
+// SubmitOrderSubmission submits a spot, futures or perpetual swap order
+// carrying its time in force via REST, limit orders are placed as the post
+// only, fill or kill and immediate or cancel order types. Futures and swap
+// orders are placed in cross margin mode and sized in contracts.
+func (o *OKX) SubmitOrderSubmission(s exchange.OrderSubmission) (exchange.SubmitOrderResponse, error) {
+	var submitOrderResponse exchange.SubmitOrderResponse
+	if s.Trigger != "" {
+		return submitOrderResponse, fmt.Errorf("%s %s %s", o.Name, s.Asset(), exchange.ErrOrderNotCarried)
+	}
+
+	var req PlaceOrderRequest
+	var err error
+	switch s.Asset() {
+	case ticker.Spot:
+		req, err = o.buildSpotOrder(s.Pair, s.Side, s.Type, s.BaseAmount, s.Price, s.ClientID)
+	case AssetFutures, AssetPerpetualSwap:
+		var instrumentID string
+		instrumentID, err = o.FormatInstrumentID(s.Pair, s.Asset())
+		if err != nil {
+			return submitOrderResponse, err
+		}
+		req, err = o.buildContractOrder(instrumentID, s.Side, s.Type, s.BaseAmount, s.Price, s.ClientID)
+	default:
+		return submitOrderResponse, fmt.Errorf("%s %s %s", o.Name, s.Asset(), exchange.ErrOrderNotCarried)
+	}
+	if err != nil {
+		return submitOrderResponse, err
+	}
+
+	switch s.TimeInForce {
+	case "", exchange.GTC:
+	case exchange.IOC:
+		req.OrderType = OrderTypeImmediateCancel
+	case exchange.FOK:
+		req.OrderType = OrderTypeFillOrKill
+	case exchange.PostOnly:
+		req.OrderType = OrderTypePostOnly
+	default:
+		return submitOrderResponse, fmt.Errorf("%s unsupported time in force %s", o.Name, s.TimeInForce)
+	}
+	if s.Type == exchange.Market && req.OrderType != OrderTypeMarket {
+		return submitOrderResponse, fmt.Errorf("%s market orders do not support time in force %s",
+			o.Name, s.TimeInForce)
+	}
+
+	resp, err := o.PlaceOrder(req)
+	if err != nil {
+		return submitOrderResponse, err
+	}
+
+	submitOrderResponse.OrderID = resp.OrderID
+	submitOrderResponse.IsOrderPlaced = true
+	return submitOrderResponse, nil
+}
+
+// SubmitQuoteAmountOrder submits a market spot order sized by the quote
+// currency notional via REST
+func (o *OKX) SubmitQuoteAmountOrder(p pair.CurrencyPair, side exchange.OrderSide, quoteAmount float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	if o.IsSimulated() {
+		return o.SimulateSubmitQuoteAmountOrder(p, side, quoteAmount, clientID)
+	}
+
+	var submitOrderResponse exchange.SubmitOrderResponse
+	req, err := o.buildSpotOrder(p, side, exchange.Market, 0, 0, clientID)
+	if err != nil {
+		return submitOrderResponse, err
+	}
+	req.Size = strconv.FormatFloat(quoteAmount, 'f', -1, 64)
+	req.TargetCcy = "quote_ccy"
+
+	resp, err := o.PlaceOrder(req)
+	if err != nil {
+		return submitOrderResponse, err
+	}
+
+	submitOrderResponse.OrderID = resp.OrderID
+	submitOrderResponse.IsOrderPlaced = true
+	return submitOrderResponse, nil
+}
+
+// SupportsTrailingStop returns whether a native trailing stop can be placed,
+// OKX supports both callback rates and trail values with an optional
+// activation price
+func (o *OKX) SupportsTrailingStop(params exchange.TrailingStopParams) bool {
+	if o.IsSimulated() {
+		return false
+	}
+
+	return params.Validate() == nil
+}
+
+// SubmitTrailingStopOrder submits a native trailing stop as a move order stop
+// algo order
+func (o *OKX) SubmitTrailingStopOrder(p pair.CurrencyPair, side exchange.OrderSide, amount float64, params exchange.TrailingStopParams, clientID string) (exchange.SubmitOrderResponse, error) {
+	if o.IsSimulated() {
+		return exchange.SubmitOrderResponse{}, common.ErrFunctionNotSupported
+	}
+
+	var submitOrderResponse exchange.SubmitOrderResponse
+	if err := params.Validate(); err != nil {
+		return submitOrderResponse, err
+	}
+
+	req := AlgoOrderRequest{
+		InstrumentID:  exchange.FormatExchangeCurrency(o.Name, p).String(),
+		TradeMode:     TradeModeCash,
+		ClientOrderID: clientID,
+		Tag:           o.BrokerTag,
+		OrderType:     OrderTypeTrailingStop,
+		Size:          strconv.FormatFloat(amount, 'f', -1, 64),
+		TargetCcy:     "base_ccy",
+	}
+	switch side {
+	case exchange.Buy:
+		req.Side = "buy"
+	case exchange.Sell:
+		req.Side = "sell"
+	default:
+		return submitOrderResponse, fmt.Errorf("unsupported order side %s", side)
+	}
+	if params.CallbackRate > 0 {
+		req.CallbackRatio = strconv.FormatFloat(params.CallbackRate, 'f', -1, 64)
+	} else {
+		req.CallbackSpread = strconv.FormatFloat(params.TrailValue, 'f', -1, 64)
+	}
+	if params.ActivationPrice > 0 {
+		req.ActivePrice = strconv.FormatFloat(params.ActivationPrice, 'f', -1, 64)
+	}
+
+	resp, err := o.PlaceAlgoOrder(req)
+	if err != nil {
+		return submitOrderResponse, err
+	}
+
+	submitOrderResponse.OrderID = resp.AlgoID
+	submitOrderResponse.IsOrderPlaced = true
+	return submitOrderResponse, nil
+}
+
+// CancelTrailingStopOrder cancels a native trailing stop by its algo ID
+func (o *OKX) CancelTrailingStopOrder(order exchange.OrderCancellation) error {
+	_, err := o.CancelAlgoOrder(CancelAlgoOrderRequest{
+		AlgoID:       order.OrderID,
+		InstrumentID: exchange.FormatExchangeCurrency(o.Name, order.CurrencyPair).String(),
+	})
+	return err
+}
+
+// ModifyOrder will allow of changing orderbook placement and limit to
+// market conversion
+func (o *OKX) ModifyOrder(action exchange.ModifyOrder) (string, error) {
+	if o.IsSimulated() {
+		return o.SimulateModifyOrder(action)
+	}
+
+	if action.OrderID == "" {
+		return "", errors.New("order ID must be set")
+	}
+
+	req := AmendOrderRequest{
+		InstrumentID: exchange.FormatExchangeCurrency(o.Name, action.Currency).String(),
+		OrderID:      action.OrderID,
+	}
+	if action.Amount > 0 {
+		req.NewSize = strconv.FormatFloat(action.Amount, 'f', -1, 64)
+	}
+	if action.Price > 0 {
+		req.NewPrice = strconv.FormatFloat(action.Price, 'f', -1, 64)
+	}
+
+	resp, err := o.AmendOrder(req)
+	if err != nil {
+		return "", err
+	}
+	return resp.OrderID, nil
+}
+
+// CancelOrder cancels an order by its corresponding ID number, via websocket
+// when configured and connected otherwise via REST
+func (o *OKX) CancelOrder(order exchange.OrderCancellation) error {
+	if o.IsSimulated() {
+		return o.SimulateCancelOrder(order)
+	}
+
+	return o.CancelOrderViaTransport(o.restCancelOrder, order)
+}
+
+// CancelOrderContext cancels an order via REST, cancelled with the context
+func (o *OKX) CancelOrderContext(ctx context.Context, order exchange.OrderCancellation) error {
+	if o.IsSimulated() {
+		return o.SimulateCancelOrder(order)
+	}
+
+	return o.cancelOrderContext(ctx, order)
+}
+
+// restCancelOrder cancels an order via REST
+func (o *OKX) restCancelOrder(order exchange.OrderCancellation) error {
+	return o.cancelOrderContext(context.Background(), order)
+}
+
+// cancelOrderContext cancels an order via REST, cancelled with the context
+func (o *OKX) cancelOrderContext(ctx context.Context, order exchange.OrderCancellation) error {
+	_, err := o.CancelExistingOrderContext(ctx, CancelOrderRequest{
+		InstrumentID: exchange.FormatExchangeCurrency(o.Name, order.CurrencyPair).String(),
+		OrderID:      order.OrderID,
+	})
+	return err
+}
+
+// CancelAllOrders cancels all spot orders for all enabled currencies
+func (o *OKX) CancelAllOrders(orderCancellation exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
+	if o.IsSimulated() {
+		return o.SimulateCancelAllOrders(orderCancellation)
+	}
+
+	cancelAllOrdersResponse := exchange.CancelAllOrdersResponse{
+		OrderStatus: make(map[string]string),
+	}
+
+	openOrders, err := o.GetPendingOrders(InstrumentTypeSpot, "")
+	if err != nil {
+		return cancelAllOrdersResponse, err
+	}
+
+	enabled := make(map[string]bool)
+	for _, p := range o.GetEnabledCurrencies() {
+		enabled[exchange.FormatExchangeCurrency(o.Name, p).String()] = true
+	}
+
+	var requests []CancelOrderRequest
+	for i := range openOrders {
+		if !enabled[openOrders[i].InstrumentID] {
+			continue
+		}
+		requests = append(requests, CancelOrderRequest{
+			InstrumentID: openOrders[i].InstrumentID,
+			OrderID:      openOrders[i].OrderID,
+		})
+	}
+
+	for len(requests) > 0 {
+		n := len(requests)
+		if n > okxMaxBatchCancel {
+			n = okxMaxBatchCancel
+		}
+
+		resp, err := o.CancelBatchOrders(requests[:n])
+		if err != nil && len(resp) == 0 {
+			for i := range requests[:n] {
+				cancelAllOrdersResponse.OrderStatus[requests[i].OrderID] = err.Error()
+			}
+		}
+		for i := range resp {
+			if resp[i].StatusCode != okxSuccessCode {
+				cancelAllOrdersResponse.OrderStatus[resp[i].OrderID] = resp[i].StatusMessage
+			}
+		}
+		requests = requests[n:]
+	}
+
+	return cancelAllOrdersResponse, nil
+}
+
+// GetOrderInfo returns information on a spot order, open orders are searched
+// before the last 7 days of order history
+func (o *OKX) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
+	if o.IsSimulated() {
+		return o.SimulateGetOrderInfo(orderID)
+	}
+
+	var orderDetail exchange.OrderDetail
+	id := strconv.FormatInt(orderID, 10)
+
+	orders, err := o.GetPendingOrders(InstrumentTypeSpot, "")
+	if err != nil {
+		return orderDetail, err
+	}
+
+	history, err := o.GetInstrumentOrderHistory(InstrumentTypeSpot, "")
+	if err != nil {
+		return orderDetail, err
+	}
+	orders = append(orders, history...)
+
+	for i := range orders {
+		if orders[i].OrderID != id {
+			continue
+		}
+
+		p, _, err := InstrumentIDToPair(orders[i].InstrumentID)
+		if err != nil {
+			return orderDetail, err
+		}
+
+		return exchange.OrderDetail{
+			Exchange:             o.Name,
+			ID:                   orders[i].OrderID,
+			BaseCurrency:         p.FirstCurrency.String(),
+			QuoteCurrency:        p.SecondCurrency.String(),
+			OrderSide:            orders[i].Side,
+			OrderType:            orders[i].OrderType,
+			CreationTime:         orders[i].CreationTime.Time(),
+			Status:               orders[i].State,
+			Price:                orders[i].Price.Float64(),
+			Amount:               orders[i].Size.Float64(),
+			OpenVolume:           orders[i].Size.Float64() - orders[i].AccFillSize.Float64(),
+			ExecutedAmount:       orders[i].AccFillSize.Float64(),
+			AverageExecutedPrice: orders[i].AveragePrice.Float64(),
+		}, nil
+	}
+	return orderDetail, fmt.Errorf("order %d not found", orderID)
+}
+
+// GetDepositAddress returns a deposit address for a specified currency
+func (o *OKX) GetDepositAddress(cryptocurrency pair.CurrencyItem) (string, error) {
+	addresses, err := o.GetDepositAddresses(cryptocurrency.String())
+	if err != nil {
+		return "", err
+	}
+	if len(addresses) == 0 {
+		return "", fmt.Errorf("no deposit address found for %s", cryptocurrency)
+	}
+
+	for i := range addresses {
+		if addresses[i].Selected {
+			return addresses[i].Address, nil
+		}
+	}
+	return addresses[0].Address, nil
+}
+
+// WithdrawCryptocurrencyFunds returns a withdrawal ID when a withdrawal is
+// submitted. The minimum fee for the currency's main chain is used.
+func (o *OKX) WithdrawCryptocurrencyFunds(address string, cryptocurrency pair.CurrencyItem, amount float64) (string, error) {
+	currencies, err := o.GetCurrencies(cryptocurrency.String())
+	if err != nil {
+		return "", err
+	}
+
+	chain, err := selectChain(currencies, cryptocurrency.String())
+	if err != nil {
+		return "", err
+	}
+	if !chain.CanWithdraw {
+		return "", fmt.Errorf("withdrawals of %s are currently disabled", cryptocurrency)
+	}
+
+	resp, err := o.Withdraw(WithdrawalRequest{
+		Currency:    cryptocurrency.Upper().String(),
+		Amount:      strconv.FormatFloat(amount, 'f', -1, 64),
+		Destination: "4", // on chain withdrawal
+		ToAddress:   address,
+		Fee:         strconv.FormatFloat(chain.MinFee.Float64(), 'f', -1, 64),
+		Chain:       chain.Chain,
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.WithdrawalID, nil
+}
+
+// WithdrawFiatFunds returns a withdrawal ID when a
+// withdrawal is submitted
+func (o *OKX) WithdrawFiatFunds(currency pair.CurrencyItem, amount float64) (string, error) {
+	return "", common.ErrFunctionNotSupported
+}
+
+// WithdrawFiatFundsToInternationalBank returns a withdrawal ID when a
+// withdrawal is submitted
+func (o *OKX) WithdrawFiatFundsToInternationalBank(currency pair.CurrencyItem, amount float64) (string, error) {
+	return "", common.ErrFunctionNotSupported
+}
+
+// GetWebsocket returns a pointer to the exchange websocket
+func (o *OKX) GetWebsocket() (*exchange.Websocket, error) {
+	return o.Websocket, nil
+}
+
+// GetFeeByType returns an estimate of fee based on type of transaction
+func (o *OKX) GetFeeByType(feeBuilder exchange.FeeBuilder) (float64, error) {
+	return o.GetFee(feeBuilder)
+}
+
+// GetWithdrawCapabilities returns the types of withdrawal methods permitted by the exchange
+func (o *OKX) GetWithdrawCapabilities() uint32 {
+	return o.GetWithdrawPermissions()
+}
+
+// GetEarnBalances returns the simple earn savings and on-chain earn balances
+func (o *OKX) GetEarnBalances() ([]exchange.EarnBalance, error) {
+	savings, err := o.GetSavingsBalance("")
+	if err != nil {
+		return nil, err
+	}
+	staking, err := o.GetActiveStakingOrders("")
+	if err != nil {
+		return nil, err
+	}
+
+	var balances []exchange.EarnBalance
+	for i := range savings {
+		balances = append(balances, exchange.EarnBalance{
+			ProductID:       "savings-" + savings[i].Currency,
+			ProductType:     exchange.EarnFlexible,
+			Currency:        savings[i].Currency,
+			Amount:          savings[i].Amount.Float64(),
+			AccruedInterest: savings[i].Earnings.Float64(),
+			APR:             savings[i].Rate.Float64(),
+		})
+	}
+	for i := range staking {
+		b := exchange.EarnBalance{
+			ProductID:   staking[i].OrderID,
+			ProductType: exchange.EarnStaking,
+			Currency:    staking[i].Currency,
+			APR:         staking[i].APY.Float64(),
+		}
+		for _, invested := range staking[i].InvestData {
+			if invested.Currency == b.Currency {
+				b.Amount += invested.Amount.Float64()
+			}
+		}
+		for _, earned := range staking[i].EarningData {
+			if earned.Currency == b.Currency {
+				b.AccruedInterest += earned.Earnings.Float64()
+			}
+		}
+		balances = append(balances, b)
+	}
+	return balances, nil
+}
+
+// Stake invests in an on-chain earn product and returns the order ID
+func (o *OKX) Stake(productID, currency string, amount float64) (string, error) {
+	return o.PurchaseStaking(productID, currency, amount, "")
+}
+
+// Unstake redeems an active on-chain earn order, fixed term orders are
+// redeemed early
+func (o *OKX) Unstake(positionID string) error {
+	active, err := o.GetActiveStakingOrders("")
+	if err != nil {
+		return err
+	}
+	for i := range active {
+		if active[i].OrderID == positionID {
+			return o.RedeemStaking(positionID, active[i].ProtocolType, true)
+		}
+	}
+	return fmt.Errorf("staking order %s not found", positionID)
+}
+
+// GetStakingPositions returns the active on-chain earn orders
+func (o *OKX) GetStakingPositions() ([]exchange.StakingPosition, error) {
+	active, err := o.GetActiveStakingOrders("")
+	if err != nil {
+		return nil, err
+	}
+	positions := make([]exchange.StakingPosition, 0, len(active))
+	for i := range active {
+		p := exchange.StakingPosition{
+			PositionID: active[i].OrderID,
+			ProductID:  active[i].ProductID,
+			Currency:   active[i].Currency,
+			APR:        active[i].APY.Float64(),
+			Start:      active[i].PurchasedAt.Time(),
+			Unlock:     active[i].Unlock(),
+		}
+		for _, invested := range active[i].InvestData {
+			if invested.Currency == p.Currency {
+				p.Amount += invested.Amount.Float64()
+			}
+		}
+		positions = append(positions, p)
+	}
+	return positions, nil
+}
+
+// DetectAccountTier returns the spot fee level of the account, e.g. Lv1 or VIP1
+func (o *OKX) DetectAccountTier() (string, error) {
+	fee, err := o.GetTradeFee(InstrumentTypeSpot, "")
+	if err != nil {
+		return "", err
+	}
+	return fee.Level, nil
+}
+
+// CheckSystemStatus returns an error during ongoing system maintenance
+func (o *OKX) CheckSystemStatus() error {
+	events, err := o.GetSystemStatus()
+	if err != nil {
+		return err
+	}
+	for i := range events {
+		if events[i].State == "ongoing" {
+			return fmt.Errorf("ongoing maintenance %s", events[i].Title)
+		}
+	}
+	return nil
+}
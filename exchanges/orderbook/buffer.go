@@ -0,0 +1,402 @@
+package orderbook
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+)
+
+// Default buffer values
+const (
+	DefaultMaxPendingUpdates = 1000
+	DefaultMaxClockSkew      = 10 * time.Second
+	maxResyncAttempts        = 3
+)
+
+// Errors returned by the orderbook buffer
+var (
+	ErrSequenceGap       = errors.New("orderbook update sequence gap detected")
+	ErrChecksumMismatch  = errors.New("orderbook checksum mismatch")
+	ErrSnapshotOutdated  = errors.New("orderbook snapshot is older than buffered updates")
+	ErrSnapshotIncorrect = errors.New("orderbook snapshot bids and asks are empty")
+)
+
+// Update is an incremental change to an orderbook, each level replaces the
+// amount at its price and a zero amount removes the level. UpdateID is the ID
+// of the last change in the update. When set, PrevUpdateID must match the
+// previous update's UpdateID, otherwise FirstUpdateID, or UpdateID when that
+// is also unset, must follow on from it. A zero UpdateID disables sequence
+// checks for the update.
+type Update struct {
+	Bids          []Item
+	Asks          []Item
+	UpdateID      int64
+	FirstUpdateID int64
+	PrevUpdateID  int64
+	Checksum      uint32
+	UpdateTime    time.Time
+}
+
+// SnapshotFetcher returns a full orderbook and the update ID it is current to,
+// normally from the exchange's REST API
+type SnapshotFetcher func() (Base, int64, error)
+
+// ChecksumFunc returns an exchange's checksum of the orderbook, used to
+// validate it after each update
+type ChecksumFunc func(b *Base) uint32
+
+// Buffer maintains a live orderbook from a snapshot and incremental websocket
+// updates. Updates received before the snapshot or after a sequence gap or
+// checksum mismatch are buffered, then replayed on top of the next snapshot.
+// When Fetch is set the buffer fetches snapshots itself. Otherwise Resubscribe
+// is called for exchanges which send a fresh snapshot on subscribing, or the
+// caller requests one when Apply returns an error, and passes it to
+// LoadSnapshot. Update timestamps are validated against the previous update
+// and the local clock, allowing for MaxClockSkew, and the results are counted
+// by Tracker. Each valid orderbook is processed into the orderbook package.
+type Buffer struct {
+	ExchangeName string
+	Pair         pair.CurrencyPair
+	AssetType    string
+	Fetch        SnapshotFetcher
+	Resubscribe  func() error
+	Checksum     ChecksumFunc
+	MaxDepth     int
+	MaxPending   int
+	MaxClockSkew time.Duration
+	Tracker      *SequenceTracker
+
+	book        Base
+	updateID    int64
+	updateTime  time.Time
+	synced      bool
+	invalidated bool
+	pending     []Update
+	m           sync.Mutex
+}
+
+// NewBuffer returns a new orderbook buffer for an exchange currency pair
+func NewBuffer(exchangeName string, p pair.CurrencyPair, assetType string) *Buffer {
+	return &Buffer{
+		ExchangeName: exchangeName,
+		Pair:         p,
+		AssetType:    assetType,
+		MaxPending:   DefaultMaxPendingUpdates,
+		MaxClockSkew: DefaultMaxClockSkew,
+		Tracker:      Sequences,
+	}
+}
+
+// Apply applies an incremental update. When the buffer is out of sync the
+// update is buffered, and a snapshot is fetched if Fetch is set. An error is
+// returned when the update leaves the buffer needing a snapshot which could
+// not be fetched.
+func (b *Buffer) Apply(u Update) error {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	b.checkClock(&u)
+	if !b.synced {
+		b.queue(u)
+		if b.Fetch == nil {
+			return nil
+		}
+		return b.resync()
+	}
+
+	stale, err := b.apply(&u, false)
+	if stale {
+		return nil
+	}
+	if err == nil {
+		b.publish()
+		return nil
+	}
+
+	// The book can no longer be trusted, keep the update for replay on top
+	// of a fresh snapshot
+	b.record(func(c *SequenceCounter) {
+		if err == ErrChecksumMismatch {
+			c.ChecksumMismatches++
+		} else {
+			c.Gaps++
+		}
+	})
+	b.synced = false
+	b.invalidated = true
+	b.pending = b.pending[:0]
+	b.queue(u)
+	if b.Fetch != nil {
+		return b.resync()
+	}
+	if b.Resubscribe != nil {
+		if subErr := b.Resubscribe(); subErr != nil {
+			return subErr
+		}
+	}
+	return err
+}
+
+// LoadSnapshot replaces the orderbook with a snapshot current to updateID and
+// replays the buffered updates which follow it. ErrSnapshotOutdated is
+// returned when the snapshot precedes the buffered updates, in which case
+// Resubscribe is called when set.
+func (b *Buffer) LoadSnapshot(ob Base, updateID int64) error {
+	b.m.Lock()
+	defer b.m.Unlock()
+	err := b.load(ob, updateID)
+	if err != nil && b.Resubscribe != nil {
+		if subErr := b.Resubscribe(); subErr != nil {
+			return subErr
+		}
+	}
+	return err
+}
+
+// Orderbook returns a copy of the current orderbook
+func (b *Buffer) Orderbook() Base {
+	b.m.Lock()
+	defer b.m.Unlock()
+	return b.copyBook()
+}
+
+// Synced returns whether the orderbook is current
+func (b *Buffer) Synced() bool {
+	b.m.Lock()
+	defer b.m.Unlock()
+	return b.synced
+}
+
+// UpdateID returns the ID of the last applied update
+func (b *Buffer) UpdateID() int64 {
+	b.m.Lock()
+	defer b.m.Unlock()
+	return b.updateID
+}
+
+// Reset discards the orderbook and buffered updates, for example when the
+// websocket connection is re-established
+func (b *Buffer) Reset() {
+	b.m.Lock()
+	b.book = Base{}
+	b.updateID = 0
+	b.updateTime = time.Time{}
+	b.synced = false
+	b.invalidated = false
+	b.pending = nil
+	b.m.Unlock()
+}
+
+// queue buffers an update, dropping the oldest once MaxPending is reached
+func (b *Buffer) queue(u Update) {
+	b.pending = append(b.pending, u)
+	if b.MaxPending > 0 && len(b.pending) > b.MaxPending {
+		b.pending = b.pending[len(b.pending)-b.MaxPending:]
+	}
+}
+
+// resync fetches snapshots until one is recent enough for the buffered
+// updates
+func (b *Buffer) resync() error {
+	var err error
+	for i := 0; i < maxResyncAttempts; i++ {
+		var ob Base
+		var updateID int64
+		ob, updateID, err = b.Fetch()
+		if err != nil {
+			return err
+		}
+		err = b.load(ob, updateID)
+		if err != ErrSnapshotOutdated {
+			return err
+		}
+	}
+	return err
+}
+
+// load replaces the orderbook with a snapshot and replays buffered updates
+func (b *Buffer) load(ob Base, updateID int64) error {
+	if len(ob.Bids) == 0 && len(ob.Asks) == 0 {
+		return ErrSnapshotIncorrect
+	}
+
+	pending := b.pending
+	if updateID != 0 {
+		for len(pending) > 0 && pending[0].UpdateID != 0 && pending[0].UpdateID <= updateID {
+			pending = pending[1:]
+		}
+		if len(pending) > 0 && pending[0].UpdateID != 0 &&
+			!connects(&pending[0], updateID, true) {
+			b.pending = pending
+			return ErrSnapshotOutdated
+		}
+	}
+
+	b.book = ob
+	b.book.Bids = append([]Item(nil), ob.Bids...)
+	b.book.Asks = append([]Item(nil), ob.Asks...)
+	sort.Slice(b.book.Bids, func(i, j int) bool { return b.book.Bids[i].Price > b.book.Bids[j].Price })
+	sort.Slice(b.book.Asks, func(i, j int) bool { return b.book.Asks[i].Price < b.book.Asks[j].Price })
+	b.trim()
+	if b.book.LastUpdated.IsZero() {
+		b.book.LastUpdated = time.Now()
+	}
+	b.updateID = updateID
+
+	for i := range pending {
+		_, err := b.apply(&pending[i], i == 0)
+		if err != nil {
+			b.synced = false
+			b.pending = pending[i:]
+			return err
+		}
+	}
+
+	b.synced = true
+	b.pending = b.pending[:0]
+	if b.invalidated {
+		b.invalidated = false
+		b.record(func(c *SequenceCounter) { c.Resyncs++ })
+	}
+	b.publish()
+	return nil
+}
+
+// apply validates the sequence of an update and applies it to the orderbook.
+// Updates which precede the orderbook are reported as stale and not applied.
+func (b *Buffer) apply(u *Update, first bool) (stale bool, err error) {
+	if u.UpdateID != 0 && b.updateID != 0 {
+		if u.UpdateID <= b.updateID {
+			return true, nil
+		}
+		if !connects(u, b.updateID, first) {
+			return false, ErrSequenceGap
+		}
+	}
+
+	b.book.Bids = applyLevels(b.book.Bids, u.Bids, true)
+	b.book.Asks = applyLevels(b.book.Asks, u.Asks, false)
+	b.trim()
+	if b.Checksum != nil && b.Checksum(&b.book) != u.Checksum {
+		return false, ErrChecksumMismatch
+	}
+
+	if u.UpdateID != 0 {
+		b.updateID = u.UpdateID
+	}
+	b.book.LastUpdated = u.UpdateTime
+	if b.book.LastUpdated.IsZero() {
+		b.book.LastUpdated = time.Now()
+	}
+	return false, nil
+}
+
+// checkClock counts an update and validates its timestamp against the previous
+// update and the local clock
+func (b *Buffer) checkClock(u *Update) {
+	outOfOrder, skewed := false, false
+	if !u.UpdateTime.IsZero() {
+		outOfOrder = u.UpdateTime.Before(b.updateTime)
+		if !outOfOrder {
+			b.updateTime = u.UpdateTime
+		}
+		if b.MaxClockSkew > 0 {
+			skew := time.Since(u.UpdateTime)
+			skewed = skew > b.MaxClockSkew || skew < -b.MaxClockSkew
+		}
+	}
+	b.record(func(c *SequenceCounter) {
+		c.Updates++
+		if outOfOrder {
+			c.OutOfOrder++
+		}
+		if skewed {
+			c.ClockSkews++
+		}
+	})
+}
+
+// record updates the buffer's sequence counters when tracked
+func (b *Buffer) record(update func(c *SequenceCounter)) {
+	if b.Tracker != nil {
+		b.Tracker.record(b.ExchangeName, update)
+	}
+}
+
+// connects returns whether an update follows on from lastID, the first update
+// after a snapshot only needs to span it
+func connects(u *Update, lastID int64, first bool) bool {
+	switch {
+	case u.PrevUpdateID != 0:
+		if first {
+			return u.PrevUpdateID <= lastID
+		}
+		return u.PrevUpdateID == lastID
+	case u.FirstUpdateID != 0:
+		if first {
+			return u.FirstUpdateID <= lastID+1
+		}
+		return u.FirstUpdateID == lastID+1
+	default:
+		return u.UpdateID == lastID+1
+	}
+}
+
+// applyLevels applies changed levels to a side of the book kept sorted by
+// price, descending for bids and ascending for asks
+func applyLevels(side, changes []Item, descending bool) []Item {
+	for x := range changes {
+		price := changes[x].Price
+		i := sort.Search(len(side), func(i int) bool {
+			if descending {
+				return side[i].Price <= price
+			}
+			return side[i].Price >= price
+		})
+		found := i < len(side) && side[i].Price == price
+		switch {
+		case changes[x].Amount == 0:
+			if found {
+				side = append(side[:i], side[i+1:]...)
+			}
+		case found:
+			side[i].Amount = changes[x].Amount
+		default:
+			side = append(side, Item{})
+			copy(side[i+1:], side[i:])
+			side[i] = Item{Price: price, Amount: changes[x].Amount, ID: changes[x].ID}
+		}
+	}
+	return side
+}
+
+// trim limits each side of the book to MaxDepth levels
+func (b *Buffer) trim() {
+	if b.MaxDepth <= 0 {
+		return
+	}
+	if len(b.book.Bids) > b.MaxDepth {
+		b.book.Bids = b.book.Bids[:b.MaxDepth]
+	}
+	if len(b.book.Asks) > b.MaxDepth {
+		b.book.Asks = b.book.Asks[:b.MaxDepth]
+	}
+}
+
+// copyBook returns a copy of the orderbook which is safe to retain
+func (b *Buffer) copyBook() Base {
+	ob := b.book
+	ob.Bids = append([]Item(nil), b.book.Bids...)
+	ob.Asks = append([]Item(nil), b.book.Asks...)
+	ob.Pair = b.Pair
+	ob.AssetType = b.AssetType
+	return ob
+}
+
+// publish processes the current orderbook into the orderbook package
+func (b *Buffer) publish() {
+	ProcessOrderbook(b.ExchangeName, b.Pair, b.copyBook(), b.AssetType)
+}
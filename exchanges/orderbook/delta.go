@@ -0,0 +1,389 @@
+package orderbook
+
+import (
+	"bufio"
+	"compress/flate"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"sort"
+	"time"
+)
+
+// Frame types written by the delta encoder
+const (
+	keyframe   byte = 1
+	deltaFrame byte = 2
+)
+
+// Default delta encoding values, prices and amounts are stored to 8 decimal
+// places and a full keyframe is written every 100 frames
+const (
+	DefaultDeltaScale       = 1e8
+	DefaultKeyframeInterval = 100
+)
+
+// Errors returned when decoding orderbook deltas
+var (
+	ErrDeltaBeforeKeyframe = errors.New("orderbook delta received before keyframe")
+	ErrInvalidFrame        = errors.New("invalid orderbook frame")
+)
+
+// DeltaEncoder encodes successive snapshots of a single orderbook into
+// compact frames. A keyframe holds every level, subsequent delta frames only
+// hold the levels which changed since the previous frame with a zero amount
+// marking a removed level. Prices and amounts are stored as varint encoded
+// integers scaled by PriceScale and AmountScale, prices are delta encoded
+// against the previous level. A keyframe is written every KeyframeInterval
+// frames so a reader never has to replay an unbounded number of deltas.
+// Level IDs are not encoded.
+type DeltaEncoder struct {
+	PriceScale       float64
+	AmountScale      float64
+	KeyframeInterval int
+
+	bids      map[int64]int64
+	asks      map[int64]int64
+	timestamp int64
+	frames    int
+	buf       []byte
+}
+
+// NewDeltaEncoder returns a new delta encoder, zero values use the defaults
+func NewDeltaEncoder(priceScale, amountScale float64, keyframeInterval int) *DeltaEncoder {
+	if priceScale <= 0 {
+		priceScale = DefaultDeltaScale
+	}
+	if amountScale <= 0 {
+		amountScale = DefaultDeltaScale
+	}
+	if keyframeInterval <= 0 {
+		keyframeInterval = DefaultKeyframeInterval
+	}
+	return &DeltaEncoder{
+		PriceScale:       priceScale,
+		AmountScale:      amountScale,
+		KeyframeInterval: keyframeInterval,
+	}
+}
+
+// ForceKeyframe causes the next encoded frame to be a keyframe, for example
+// when starting a new storage segment or a new subscriber joins a stream
+func (e *DeltaEncoder) ForceKeyframe() {
+	e.frames = 0
+	e.bids = nil
+}
+
+// Encode returns the frame for the orderbook snapshot. The returned slice is
+// only valid until the next call to Encode.
+func (e *DeltaEncoder) Encode(b Base) []byte {
+	bids := e.quantise(b.Bids)
+	asks := e.quantise(b.Asks)
+	ts := b.LastUpdated.UnixNano()
+
+	e.buf = e.buf[:0]
+	if e.bids == nil || e.frames%e.KeyframeInterval == 0 {
+		e.buf = append(e.buf, keyframe)
+		e.buf = binary.AppendVarint(e.buf, ts)
+		e.buf = binary.BigEndian.AppendUint64(e.buf, math.Float64bits(e.PriceScale))
+		e.buf = binary.BigEndian.AppendUint64(e.buf, math.Float64bits(e.AmountScale))
+		e.buf = appendLevels(e.buf, bids)
+		e.buf = appendLevels(e.buf, asks)
+		e.frames = 0
+	} else {
+		e.buf = append(e.buf, deltaFrame)
+		e.buf = binary.AppendVarint(e.buf, ts-e.timestamp)
+		e.buf = appendLevels(e.buf, levelChanges(e.bids, bids))
+		e.buf = appendLevels(e.buf, levelChanges(e.asks, asks))
+	}
+
+	e.bids = bids
+	e.asks = asks
+	e.timestamp = ts
+	e.frames++
+	return e.buf
+}
+
+// quantise converts the levels to scaled integers keyed by price, levels
+// which round to a zero amount are dropped
+func (e *DeltaEncoder) quantise(items []Item) map[int64]int64 {
+	levels := make(map[int64]int64, len(items))
+	for i := range items {
+		amount := int64(math.Round(items[i].Amount * e.AmountScale))
+		if amount <= 0 {
+			continue
+		}
+		levels[int64(math.Round(items[i].Price*e.PriceScale))] = amount
+	}
+	return levels
+}
+
+// level is a scaled price level
+type level struct {
+	price  int64
+	amount int64
+}
+
+// levelChanges returns the levels which differ between two sides of the book,
+// removed levels are returned with a zero amount
+func levelChanges(prev, next map[int64]int64) map[int64]int64 {
+	changes := make(map[int64]int64)
+	for price, amount := range next {
+		if prev[price] != amount {
+			changes[price] = amount
+		}
+	}
+	for price := range prev {
+		if _, ok := next[price]; !ok {
+			changes[price] = 0
+		}
+	}
+	return changes
+}
+
+// appendLevels appends the level count followed by each level in ascending
+// price order, prices are encoded as the difference from the previous level
+func appendLevels(buf []byte, levels map[int64]int64) []byte {
+	sorted := make([]level, 0, len(levels))
+	for price, amount := range levels {
+		sorted = append(sorted, level{price, amount})
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].price < sorted[j].price })
+
+	buf = binary.AppendUvarint(buf, uint64(len(sorted)))
+	var last int64
+	for i := range sorted {
+		buf = binary.AppendVarint(buf, sorted[i].price-last)
+		buf = binary.AppendUvarint(buf, uint64(sorted[i].amount))
+		last = sorted[i].price
+	}
+	return buf
+}
+
+// DeltaDecoder rebuilds orderbook snapshots from frames written by a
+// DeltaEncoder
+type DeltaDecoder struct {
+	priceScale  float64
+	amountScale float64
+	bids        map[int64]int64
+	asks        map[int64]int64
+	timestamp   int64
+}
+
+// NewDeltaDecoder returns a new delta decoder
+func NewDeltaDecoder() *DeltaDecoder {
+	return &DeltaDecoder{}
+}
+
+// Decode applies a frame and returns the full orderbook, bids are sorted
+// descending and asks ascending by price. Deltas received before the first
+// keyframe return ErrDeltaBeforeKeyframe and can be skipped until one
+// arrives.
+func (d *DeltaDecoder) Decode(frame []byte) (Base, error) {
+	if len(frame) == 0 {
+		return Base{}, ErrInvalidFrame
+	}
+
+	r := frameReader{buf: frame[1:]}
+	switch frame[0] {
+	case keyframe:
+		ts := r.varint()
+		priceScale := math.Float64frombits(r.uint64())
+		amountScale := math.Float64frombits(r.uint64())
+		bids := make(map[int64]int64)
+		asks := make(map[int64]int64)
+		r.levels(bids)
+		r.levels(asks)
+		if r.err != nil || priceScale <= 0 || amountScale <= 0 {
+			return Base{}, ErrInvalidFrame
+		}
+		d.priceScale, d.amountScale = priceScale, amountScale
+		d.bids, d.asks, d.timestamp = bids, asks, ts
+	case deltaFrame:
+		if d.bids == nil {
+			return Base{}, ErrDeltaBeforeKeyframe
+		}
+		ts := d.timestamp + r.varint()
+		bids := copyLevels(d.bids)
+		asks := copyLevels(d.asks)
+		r.levels(bids)
+		r.levels(asks)
+		if r.err != nil {
+			return Base{}, ErrInvalidFrame
+		}
+		d.bids, d.asks, d.timestamp = bids, asks, ts
+	default:
+		return Base{}, ErrInvalidFrame
+	}
+	if len(r.buf) != 0 {
+		return Base{}, ErrInvalidFrame
+	}
+
+	return Base{
+		Bids:        d.items(d.bids, true),
+		Asks:        d.items(d.asks, false),
+		LastUpdated: time.Unix(0, d.timestamp).UTC(),
+	}, nil
+}
+
+// items converts scaled levels back to orderbook items
+func (d *DeltaDecoder) items(levels map[int64]int64, descending bool) []Item {
+	items := make([]Item, 0, len(levels))
+	for price, amount := range levels {
+		items = append(items, Item{
+			Price:  float64(price) / d.priceScale,
+			Amount: float64(amount) / d.amountScale,
+		})
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if descending {
+			return items[i].Price > items[j].Price
+		}
+		return items[i].Price < items[j].Price
+	})
+	return items
+}
+
+// copyLevels returns a copy of a side of the book
+func copyLevels(levels map[int64]int64) map[int64]int64 {
+	c := make(map[int64]int64, len(levels))
+	for price, amount := range levels {
+		c[price] = amount
+	}
+	return c
+}
+
+// frameReader reads the varint encoded fields of a frame, recording the first
+// error encountered
+type frameReader struct {
+	buf []byte
+	err error
+}
+
+func (r *frameReader) varint() int64 {
+	v, n := binary.Varint(r.buf)
+	if n <= 0 {
+		r.err = ErrInvalidFrame
+		return 0
+	}
+	r.buf = r.buf[n:]
+	return v
+}
+
+func (r *frameReader) uvarint() uint64 {
+	v, n := binary.Uvarint(r.buf)
+	if n <= 0 {
+		r.err = ErrInvalidFrame
+		return 0
+	}
+	r.buf = r.buf[n:]
+	return v
+}
+
+func (r *frameReader) uint64() uint64 {
+	if len(r.buf) < 8 {
+		r.err = ErrInvalidFrame
+		return 0
+	}
+	v := binary.BigEndian.Uint64(r.buf)
+	r.buf = r.buf[8:]
+	return v
+}
+
+// levels applies the encoded levels to a side of the book, a zero amount
+// removes the level
+func (r *frameReader) levels(side map[int64]int64) {
+	count := r.uvarint()
+	var price int64
+	for i := uint64(0); i < count && r.err == nil; i++ {
+		price += r.varint()
+		amount := r.uvarint()
+		if r.err != nil {
+			return
+		}
+		if amount == 0 {
+			delete(side, price)
+			continue
+		}
+		side[price] = int64(amount)
+	}
+}
+
+// DeltaWriter writes delta encoded orderbook frames to a compressed stream.
+// Each frame is length prefixed and flushed so a streaming reader receives
+// it immediately, the compression window is kept across frames.
+type DeltaWriter struct {
+	encoder *DeltaEncoder
+	w       *flate.Writer
+	header  []byte
+}
+
+// NewDeltaWriter returns a new writer compressing frames from the encoder to w
+func NewDeltaWriter(w io.Writer, e *DeltaEncoder) (*DeltaWriter, error) {
+	fw, err := flate.NewWriter(w, flate.BestCompression)
+	if err != nil {
+		return nil, err
+	}
+	return &DeltaWriter{encoder: e, w: fw}, nil
+}
+
+// WriteOrderbook encodes and writes an orderbook snapshot
+func (dw *DeltaWriter) WriteOrderbook(b Base) error {
+	frame := dw.encoder.Encode(b)
+	dw.header = binary.AppendUvarint(dw.header[:0], uint64(len(frame)))
+	if _, err := dw.w.Write(dw.header); err != nil {
+		return err
+	}
+	if _, err := dw.w.Write(frame); err != nil {
+		return err
+	}
+	return dw.w.Flush()
+}
+
+// Close flushes and terminates the compressed stream, it does not close the
+// underlying writer
+func (dw *DeltaWriter) Close() error {
+	return dw.w.Close()
+}
+
+// DeltaReader reads orderbook snapshots from a stream written by a
+// DeltaWriter
+type DeltaReader struct {
+	decoder *DeltaDecoder
+	r       *bufio.Reader
+	fr      io.ReadCloser
+	frame   []byte
+}
+
+// NewDeltaReader returns a new reader decompressing frames from r
+func NewDeltaReader(r io.Reader) *DeltaReader {
+	fr := flate.NewReader(r)
+	return &DeltaReader{
+		decoder: NewDeltaDecoder(),
+		r:       bufio.NewReader(fr),
+		fr:      fr,
+	}
+}
+
+// ReadOrderbook returns the next orderbook snapshot, io.EOF is returned at the
+// end of the stream
+func (dr *DeltaReader) ReadOrderbook() (Base, error) {
+	length, err := binary.ReadUvarint(dr.r)
+	if err != nil {
+		return Base{}, err
+	}
+	if cap(dr.frame) < int(length) {
+		dr.frame = make([]byte, length)
+	}
+	dr.frame = dr.frame[:length]
+	if _, err = io.ReadFull(dr.r, dr.frame); err != nil {
+		return Base{}, err
+	}
+	return dr.decoder.Decode(dr.frame)
+}
+
+// Close releases the decompressor, it does not close the underlying reader
+func (dr *DeltaReader) Close() error {
+	return dr.fr.Close()
+}
@@ -0,0 +1,342 @@
+package orderbook
+
+import (
+	"bufio"
+	"compress/flate"
+	"encoding/binary"
+	"errors"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+)
+
+// Errors returned by the orderbook history
+var (
+	ErrNoHistory         = errors.New("no orderbook history at or before requested time")
+	ErrHistoryOutOfOrder = errors.New("orderbook snapshot is older than the last recorded snapshot")
+)
+
+// History records the snapshots of a single orderbook as delta encoded frames
+// so the book can be reconstructed at any point in time. Frames are grouped
+// into segments which each start with a keyframe, a query only replays the
+// deltas of the segment containing the requested time.
+type History struct {
+	encoder  *DeltaEncoder
+	segments []*historySegment
+	m        sync.RWMutex
+}
+
+// historySegment holds a keyframe followed by its deltas and the timestamp of
+// each frame in unix nanoseconds
+type historySegment struct {
+	times  []int64
+	frames [][]byte
+}
+
+// NewHistory returns a new orderbook history, zero values use the delta
+// encoding defaults
+func NewHistory(priceScale, amountScale float64, keyframeInterval int) *History {
+	return &History{
+		encoder: NewDeltaEncoder(priceScale, amountScale, keyframeInterval),
+	}
+}
+
+// Record adds an orderbook snapshot timestamped by its LastUpdated time
+func (h *History) Record(b Base) error {
+	h.m.Lock()
+	defer h.m.Unlock()
+
+	ts := b.LastUpdated.UnixNano()
+	if last := h.last(); last != nil && ts < last.times[len(last.times)-1] {
+		return ErrHistoryOutOfOrder
+	}
+	h.add(ts, h.encoder.Encode(b))
+	return nil
+}
+
+// At returns the orderbook as it was at the time t
+func (h *History) At(t time.Time) (Base, error) {
+	h.m.RLock()
+	defer h.m.RUnlock()
+
+	ts := t.UnixNano()
+	s := h.segment(ts)
+	if s < 0 {
+		return Base{}, ErrNoHistory
+	}
+
+	seg := h.segments[s]
+	d := NewDeltaDecoder()
+	var b Base
+	var err error
+	for i := range seg.frames {
+		if seg.times[i] > ts {
+			break
+		}
+		if b, err = d.Decode(seg.frames[i]); err != nil {
+			return Base{}, err
+		}
+	}
+	return b, nil
+}
+
+// Range calls fn with the book as it was at the time from, followed by each
+// snapshot recorded after from until the time to inclusive. Iteration stops
+// at the first error returned by fn.
+func (h *History) Range(from, to time.Time, fn func(Base) error) error {
+	h.m.RLock()
+	defer h.m.RUnlock()
+
+	start, end := from.UnixNano(), to.UnixNano()
+	s := h.segment(start)
+	if s < 0 {
+		// Begin at the first snapshot if recording started within the range
+		if len(h.segments) == 0 || h.segments[0].times[0] > end {
+			return ErrNoHistory
+		}
+		s = 0
+	}
+
+	var current Base
+	var pending bool
+replay:
+	for ; s < len(h.segments); s++ {
+		seg := h.segments[s]
+		d := NewDeltaDecoder()
+		for i := range seg.frames {
+			if seg.times[i] > end {
+				break replay
+			}
+			b, err := d.Decode(seg.frames[i])
+			if err != nil {
+				return err
+			}
+			if seg.times[i] <= start {
+				current, pending = b, true
+				continue
+			}
+			if pending {
+				pending = false
+				if err = fn(current); err != nil {
+					return err
+				}
+			}
+			if err = fn(b); err != nil {
+				return err
+			}
+		}
+	}
+	if pending {
+		return fn(current)
+	}
+	return nil
+}
+
+// Trim removes whole segments which are not required to reconstruct the book
+// at or after the time before
+func (h *History) Trim(before time.Time) {
+	h.m.Lock()
+	defer h.m.Unlock()
+
+	s := h.segment(before.UnixNano())
+	if s > 0 {
+		h.segments = append(h.segments[:0:0], h.segments[s:]...)
+	}
+}
+
+// Start returns the time of the oldest recorded snapshot
+func (h *History) Start() time.Time {
+	h.m.RLock()
+	defer h.m.RUnlock()
+	if len(h.segments) == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, h.segments[0].times[0]).UTC()
+}
+
+// End returns the time of the most recently recorded snapshot
+func (h *History) End() time.Time {
+	h.m.RLock()
+	defer h.m.RUnlock()
+	last := h.last()
+	if last == nil {
+		return time.Time{}
+	}
+	return time.Unix(0, last.times[len(last.times)-1]).UTC()
+}
+
+// Save writes the recorded frames to w in the compressed stream format used
+// by DeltaWriter, so a saved history can also be replayed by a DeltaReader
+func (h *History) Save(w io.Writer) error {
+	h.m.RLock()
+	defer h.m.RUnlock()
+
+	fw, err := flate.NewWriter(w, flate.BestCompression)
+	if err != nil {
+		return err
+	}
+	var header []byte
+	for _, seg := range h.segments {
+		for i := range seg.frames {
+			header = binary.AppendUvarint(header[:0], uint64(len(seg.frames[i])))
+			if _, err = fw.Write(header); err != nil {
+				return err
+			}
+			if _, err = fw.Write(seg.frames[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return fw.Close()
+}
+
+// LoadHistory reads a history written by Save or a DeltaWriter. Further
+// snapshots recorded to the returned history start a new segment.
+func LoadHistory(r io.Reader) (*History, error) {
+	fr := flate.NewReader(r)
+	defer fr.Close()
+	br := bufio.NewReader(fr)
+
+	h := NewHistory(0, 0, 0)
+	d := NewDeltaDecoder()
+	for {
+		length, err := binary.ReadUvarint(br)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		frame := make([]byte, length)
+		if _, err = io.ReadFull(br, frame); err != nil {
+			return nil, err
+		}
+		b, err := d.Decode(frame)
+		if err == ErrDeltaBeforeKeyframe {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		h.add(b.LastUpdated.UnixNano(), frame)
+	}
+	return h, nil
+}
+
+// add appends a frame, starting a new segment on a keyframe. The mutex must
+// be held by the caller.
+func (h *History) add(ts int64, frame []byte) {
+	f := make([]byte, len(frame))
+	copy(f, frame)
+	if f[0] == keyframe || len(h.segments) == 0 {
+		h.segments = append(h.segments, &historySegment{})
+	}
+	last := h.last()
+	last.times = append(last.times, ts)
+	last.frames = append(last.frames, f)
+}
+
+// last returns the most recent segment. The mutex must be held by the caller.
+func (h *History) last() *historySegment {
+	if len(h.segments) == 0 {
+		return nil
+	}
+	return h.segments[len(h.segments)-1]
+}
+
+// segment returns the index of the segment containing the timestamp, or -1
+// if it is before the first recorded snapshot. The mutex must be held by the
+// caller.
+func (h *History) segment(ts int64) int {
+	return sort.Search(len(h.segments), func(i int) bool {
+		return h.segments[i].times[0] > ts
+	}) - 1
+}
+
+// Vars for the orderbook history store
+var (
+	histories       = make(map[string]*History)
+	historyEnabled  bool
+	historyInterval int
+	historyMtx      sync.Mutex
+)
+
+// EnableHistory starts recording every orderbook passed to ProcessOrderbook,
+// with a keyframe every keyframeInterval snapshots
+func EnableHistory(keyframeInterval int) {
+	historyMtx.Lock()
+	historyEnabled = true
+	historyInterval = keyframeInterval
+	historyMtx.Unlock()
+}
+
+// DisableHistory stops recording orderbooks, recorded history is kept
+func DisableHistory() {
+	historyMtx.Lock()
+	historyEnabled = false
+	historyMtx.Unlock()
+}
+
+// historyKey returns the store key for an exchange orderbook
+func historyKey(exchName string, p pair.CurrencyPair, orderbookType string) string {
+	return strings.ToUpper(exchName + "_" + p.Pair().String() + "_" + orderbookType)
+}
+
+// RecordHistory adds an orderbook snapshot to the history store
+func RecordHistory(exchName string, p pair.CurrencyPair, orderbookType string, b Base) error {
+	historyMtx.Lock()
+	key := historyKey(exchName, p, orderbookType)
+	h, ok := histories[key]
+	if !ok {
+		h = NewHistory(0, 0, historyInterval)
+		histories[key] = h
+	}
+	historyMtx.Unlock()
+	return h.Record(b)
+}
+
+// GetHistory returns the recorded history of an exchange orderbook or nil if
+// none has been recorded
+func GetHistory(exchName string, p pair.CurrencyPair, orderbookType string) *History {
+	historyMtx.Lock()
+	defer historyMtx.Unlock()
+	return histories[historyKey(exchName, p, orderbookType)]
+}
+
+// SetHistory replaces the recorded history of an exchange orderbook, for
+// example with one restored by LoadHistory for a backtest
+func SetHistory(exchName string, p pair.CurrencyPair, orderbookType string, h *History) {
+	historyMtx.Lock()
+	histories[historyKey(exchName, p, orderbookType)] = h
+	historyMtx.Unlock()
+}
+
+// GetOrderbookAt returns an exchange orderbook as it was at the time t
+func GetOrderbookAt(exchName string, p pair.CurrencyPair, orderbookType string, t time.Time) (Base, error) {
+	h := GetHistory(exchName, p, orderbookType)
+	if h == nil {
+		return Base{}, ErrNoHistory
+	}
+	b, err := h.At(t)
+	if err != nil {
+		return Base{}, err
+	}
+	b.Pair = p
+	b.CurrencyPair = p.Pair().String()
+	b.AssetType = orderbookType
+	return b, nil
+}
+
+// recordProcessed records a processed orderbook if history is enabled
+func recordProcessed(exchName string, p pair.CurrencyPair, b Base, orderbookType string) {
+	historyMtx.Lock()
+	enabled := historyEnabled
+	historyMtx.Unlock()
+	if enabled {
+		RecordHistory(exchName, p, orderbookType, b)
+	}
+}
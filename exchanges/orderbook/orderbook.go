@@ -165,6 +165,7 @@ func ProcessOrderbook(exchangeName string, p pair.CurrencyPair, orderbookNew Bas
 	}
 	orderbookNew.CurrencyPair = p.Pair().String()
 	orderbookNew.LastUpdated = time.Now()
+	recordProcessed(exchangeName, p, orderbookNew, orderbookType)
 
 	orderbook, err := GetOrderbookByExchange(exchangeName)
 	if err != nil {
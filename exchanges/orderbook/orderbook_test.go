@@ -1,8 +1,12 @@
 package orderbook
 
 import (
+	"bytes"
+	"io"
 	"math/rand"
+	"reflect"
 	"strconv"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -328,3 +332,438 @@ func TestProcessOrderbook(t *testing.T) {
 
 	wg.Wait()
 }
+
+func deltaTestBooks() []Base {
+	start := time.Date(2018, 7, 1, 0, 0, 0, 0, time.UTC)
+	return []Base{
+		{
+			Bids:        []Item{{Price: 6400.5, Amount: 1.5}, {Price: 6400, Amount: 2}},
+			Asks:        []Item{{Price: 6401, Amount: 0.25}, {Price: 6402.25, Amount: 3}},
+			LastUpdated: start,
+		},
+		{
+			Bids:        []Item{{Price: 6400.5, Amount: 1}, {Price: 6400, Amount: 2}},
+			Asks:        []Item{{Price: 6401, Amount: 0.25}, {Price: 6402.25, Amount: 3}},
+			LastUpdated: start.Add(time.Millisecond),
+		},
+		{
+			Bids:        []Item{{Price: 6400, Amount: 2}, {Price: 6399, Amount: 0.00000001}},
+			Asks:        []Item{{Price: 6401, Amount: 0.25}},
+			LastUpdated: start.Add(2 * time.Millisecond),
+		},
+		{
+			Bids:        []Item{{Price: 6400, Amount: 2}, {Price: 6399, Amount: 0.00000001}},
+			Asks:        []Item{{Price: 6401, Amount: 0.25}},
+			LastUpdated: start.Add(3 * time.Millisecond),
+		},
+	}
+}
+
+func TestDeltaEncoding(t *testing.T) {
+	t.Parallel()
+	e := NewDeltaEncoder(0, 0, 3)
+	d := NewDeltaDecoder()
+	books := deltaTestBooks()
+
+	var sizes []int
+	for i := range books {
+		frame := e.Encode(books[i])
+		sizes = append(sizes, len(frame))
+		if (i%3 == 0) != (frame[0] == keyframe) {
+			t.Errorf("Test failed. Encode() frame %d unexpected frame type %d", i, frame[0])
+		}
+
+		result, err := d.Decode(frame)
+		if err != nil {
+			t.Fatal("Test failed. Decode() error", err)
+		}
+		if !reflect.DeepEqual(result, books[i]) {
+			t.Errorf("Test failed. Decode() frame %d expected %+v received %+v",
+				i, books[i], result)
+		}
+	}
+
+	if sizes[1] >= sizes[0] || sizes[2] >= sizes[0] {
+		t.Errorf("Test failed. Encode() expected deltas smaller than keyframe %v", sizes)
+	}
+
+	_, err := NewDeltaDecoder().Decode(e.Encode(books[0]))
+	if err != ErrDeltaBeforeKeyframe {
+		t.Error("Test failed. Decode() expected ErrDeltaBeforeKeyframe received", err)
+	}
+
+	e.ForceKeyframe()
+	if frame := e.Encode(books[0]); frame[0] != keyframe {
+		t.Error("Test failed. ForceKeyframe() did not produce a keyframe")
+	}
+
+	for _, frame := range [][]byte{nil, {9}, {keyframe, 1}, append(e.Encode(books[1]), 0)} {
+		if _, err = d.Decode(frame); err != ErrInvalidFrame {
+			t.Errorf("Test failed. Decode(%v) expected ErrInvalidFrame received %v", frame, err)
+		}
+	}
+}
+
+func TestDeltaWriterReader(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	w, err := NewDeltaWriter(&buf, NewDeltaEncoder(0, 0, 0))
+	if err != nil {
+		t.Fatal("Test failed. NewDeltaWriter() error", err)
+	}
+
+	books := deltaTestBooks()
+	for i := range books {
+		if err = w.WriteOrderbook(books[i]); err != nil {
+			t.Fatal("Test failed. WriteOrderbook() error", err)
+		}
+	}
+	if err = w.Close(); err != nil {
+		t.Fatal("Test failed. Close() error", err)
+	}
+
+	r := NewDeltaReader(&buf)
+	for i := range books {
+		result, err := r.ReadOrderbook()
+		if err != nil {
+			t.Fatal("Test failed. ReadOrderbook() error", err)
+		}
+		if !reflect.DeepEqual(result, books[i]) {
+			t.Errorf("Test failed. ReadOrderbook() frame %d expected %+v received %+v",
+				i, books[i], result)
+		}
+	}
+	if _, err = r.ReadOrderbook(); err != io.EOF {
+		t.Error("Test failed. ReadOrderbook() expected EOF received", err)
+	}
+	if err = r.Close(); err != nil {
+		t.Error("Test failed. Close() error", err)
+	}
+}
+
+func TestHistory(t *testing.T) {
+	t.Parallel()
+	h := NewHistory(0, 0, 2)
+	books := deltaTestBooks()
+	start := books[0].LastUpdated
+
+	if _, err := h.At(start); err != ErrNoHistory {
+		t.Error("Test failed. At() expected ErrNoHistory received", err)
+	}
+
+	for i := range books {
+		if err := h.Record(books[i]); err != nil {
+			t.Fatal("Test failed. Record() error", err)
+		}
+	}
+	if err := h.Record(books[0]); err != ErrHistoryOutOfOrder {
+		t.Error("Test failed. Record() expected ErrHistoryOutOfOrder received", err)
+	}
+	if len(h.segments) != 2 {
+		t.Errorf("Test failed. Record() expected 2 segments received %d", len(h.segments))
+	}
+	if !h.Start().Equal(start) || !h.End().Equal(books[3].LastUpdated) {
+		t.Errorf("Test failed. Start() End() unexpected %s %s", h.Start(), h.End())
+	}
+
+	for i := range books {
+		// Query between snapshots returns the earlier snapshot
+		result, err := h.At(books[i].LastUpdated.Add(time.Microsecond))
+		if err != nil {
+			t.Fatal("Test failed. At() error", err)
+		}
+		expected := books[i]
+		expected.LastUpdated = result.LastUpdated
+		if !reflect.DeepEqual(result, expected) ||
+			!result.LastUpdated.Equal(books[i].LastUpdated) {
+			t.Errorf("Test failed. At() snapshot %d expected %+v received %+v",
+				i, books[i], result)
+		}
+	}
+
+	var ranged []Base
+	err := h.Range(start.Add(time.Microsecond), books[2].LastUpdated, func(b Base) error {
+		ranged = append(ranged, b)
+		return nil
+	})
+	if err != nil {
+		t.Fatal("Test failed. Range() error", err)
+	}
+	if !reflect.DeepEqual(ranged, books[:3]) {
+		t.Errorf("Test failed. Range() expected %+v received %+v", books[:3], ranged)
+	}
+
+	var buf bytes.Buffer
+	if err = h.Save(&buf); err != nil {
+		t.Fatal("Test failed. Save() error", err)
+	}
+	loaded, err := LoadHistory(&buf)
+	if err != nil {
+		t.Fatal("Test failed. LoadHistory() error", err)
+	}
+	result, err := loaded.At(books[1].LastUpdated)
+	if err != nil || !reflect.DeepEqual(result, books[1]) {
+		t.Errorf("Test failed. LoadHistory() expected %+v received %+v %v",
+			books[1], result, err)
+	}
+
+	h.Trim(books[3].LastUpdated)
+	if _, err = h.At(books[1].LastUpdated); err != ErrNoHistory {
+		t.Error("Test failed. Trim() expected ErrNoHistory received", err)
+	}
+	if _, err = h.At(books[3].LastUpdated); err != nil {
+		t.Error("Test failed. Trim() removed required segment", err)
+	}
+}
+
+func TestGetOrderbookAt(t *testing.T) {
+	p := pair.NewCurrencyPair("HIST", "USD")
+	if _, err := GetOrderbookAt("HistoryExchange", p, Spot, time.Now()); err != ErrNoHistory {
+		t.Error("Test failed. GetOrderbookAt() expected ErrNoHistory received", err)
+	}
+
+	EnableHistory(10)
+	before := time.Now()
+	ProcessOrderbook("HistoryExchange", p, Base{
+		Bids: []Item{{Price: 10, Amount: 1}},
+		Asks: []Item{{Price: 11, Amount: 2}},
+	}, Spot)
+	DisableHistory()
+	ProcessOrderbook("HistoryExchange", p, Base{
+		Bids: []Item{{Price: 9, Amount: 1}},
+	}, Spot)
+
+	if _, err := GetOrderbookAt("HistoryExchange", p, Spot, before.Add(-time.Second)); err != ErrNoHistory {
+		t.Error("Test failed. GetOrderbookAt() expected ErrNoHistory received", err)
+	}
+	result, err := GetOrderbookAt("historyexchange", p, Spot, time.Now())
+	if err != nil {
+		t.Fatal("Test failed. GetOrderbookAt() error", err)
+	}
+	if len(result.Bids) != 1 || result.Bids[0].Price != 10 || result.Asks[0].Amount != 2 ||
+		result.Pair.Pair() != p.Pair() || result.AssetType != Spot {
+		t.Errorf("Test failed. GetOrderbookAt() unexpected orderbook %+v", result)
+	}
+}
+
+func TestBuffer(t *testing.T) {
+	t.Parallel()
+	p := pair.NewCurrencyPair("BTC", "USD")
+	b := NewBuffer("BufferTest", p, Spot)
+
+	// Updates before the snapshot are buffered and replayed
+	err := b.Apply(Update{UpdateID: 9, FirstUpdateID: 8, Bids: []Item{{Price: 99, Amount: 5}}})
+	if err != nil {
+		t.Error("Test failed. Apply() error", err)
+	}
+	err = b.Apply(Update{UpdateID: 12, FirstUpdateID: 10, Asks: []Item{{Price: 101, Amount: 0}, {Price: 102, Amount: 3}}})
+	if err != nil {
+		t.Error("Test failed. Apply() error", err)
+	}
+	if b.Synced() {
+		t.Error("Test failed. Synced() expected false before snapshot")
+	}
+
+	snapshot := Base{
+		Bids: []Item{{Price: 98, Amount: 1}, {Price: 99, Amount: 2}},
+		Asks: []Item{{Price: 101, Amount: 1}},
+	}
+	err = b.LoadSnapshot(snapshot, 6)
+	if err != ErrSnapshotOutdated {
+		t.Error("Test failed. LoadSnapshot() expected ErrSnapshotOutdated received", err)
+	}
+	err = b.LoadSnapshot(snapshot, 10)
+	if err != nil {
+		t.Fatal("Test failed. LoadSnapshot() error", err)
+	}
+
+	ob := b.Orderbook()
+	expectedBids := []Item{{Price: 99, Amount: 2}, {Price: 98, Amount: 1}}
+	expectedAsks := []Item{{Price: 102, Amount: 3}}
+	if !reflect.DeepEqual(ob.Bids, expectedBids) || !reflect.DeepEqual(ob.Asks, expectedAsks) {
+		t.Errorf("Test failed. Orderbook() unexpected book %+v %+v", ob.Bids, ob.Asks)
+	}
+	if !b.Synced() || b.UpdateID() != 12 {
+		t.Error("Test failed. LoadSnapshot() expected synced book at update 12")
+	}
+
+	stored, err := GetOrderbook("BufferTest", p, Spot)
+	if err != nil {
+		t.Fatal("Test failed. GetOrderbook() error", err)
+	}
+	if !reflect.DeepEqual(stored.Asks, expectedAsks) {
+		t.Error("Test failed. Buffer did not process orderbook", stored.Asks)
+	}
+
+	// Stale updates are ignored and gaps require a new snapshot
+	err = b.Apply(Update{UpdateID: 11, FirstUpdateID: 11, Bids: []Item{{Price: 97, Amount: 1}}})
+	if err != nil || len(b.Orderbook().Bids) != 2 {
+		t.Error("Test failed. Apply() did not ignore stale update", err)
+	}
+	err = b.Apply(Update{UpdateID: 15, FirstUpdateID: 14, Bids: []Item{{Price: 97, Amount: 1}}})
+	if err != ErrSequenceGap {
+		t.Error("Test failed. Apply() expected ErrSequenceGap received", err)
+	}
+
+	// Fetch resyncs automatically, replaying the update which caused the gap
+	b.Fetch = func() (Base, int64, error) {
+		return Base{Bids: []Item{{Price: 98, Amount: 4}}, Asks: []Item{{Price: 100, Amount: 1}}}, 13, nil
+	}
+	err = b.Apply(Update{UpdateID: 16, FirstUpdateID: 16, Asks: []Item{{Price: 100.5, Amount: 2}}})
+	if err != nil {
+		t.Fatal("Test failed. Apply() error", err)
+	}
+	ob = b.Orderbook()
+	expectedBids = []Item{{Price: 98, Amount: 4}, {Price: 97, Amount: 1}}
+	expectedAsks = []Item{{Price: 100, Amount: 1}, {Price: 100.5, Amount: 2}}
+	if !reflect.DeepEqual(ob.Bids, expectedBids) || !reflect.DeepEqual(ob.Asks, expectedAsks) {
+		t.Errorf("Test failed. Apply() unexpected book after resync %+v %+v", ob.Bids, ob.Asks)
+	}
+
+	// Checksum mismatches resync
+	checksums := 0
+	b.Checksum = func(ob *Base) uint32 {
+		checksums++
+		return uint32(len(ob.Bids) + len(ob.Asks))
+	}
+	b.Fetch = nil
+	err = b.Apply(Update{UpdateID: 17, FirstUpdateID: 17, Bids: []Item{{Price: 96, Amount: 1}}, Checksum: 5})
+	if err != nil {
+		t.Error("Test failed. Apply() error", err)
+	}
+	err = b.Apply(Update{UpdateID: 18, FirstUpdateID: 18, Bids: []Item{{Price: 95, Amount: 1}}, Checksum: 1})
+	if err != ErrChecksumMismatch || b.Synced() || checksums != 2 {
+		t.Error("Test failed. Apply() expected ErrChecksumMismatch received", err)
+	}
+
+	b.Reset()
+	if b.Synced() || b.UpdateID() != 0 || len(b.Orderbook().Bids) != 0 {
+		t.Error("Test failed. Reset() did not clear buffer")
+	}
+}
+
+func TestBufferPrevUpdateID(t *testing.T) {
+	t.Parallel()
+	b := NewBuffer("BufferPrevTest", pair.NewCurrencyPair("BTC", "USD"), Spot)
+	b.MaxDepth = 2
+	err := b.LoadSnapshot(Base{
+		Bids: []Item{{Price: 3, Amount: 1}, {Price: 2, Amount: 1}},
+		Asks: []Item{{Price: 4, Amount: 1}},
+	}, 100)
+	if err != nil {
+		t.Fatal("Test failed. LoadSnapshot() error", err)
+	}
+
+	err = b.Apply(Update{UpdateID: 110, PrevUpdateID: 100, Bids: []Item{{Price: 1, Amount: 1}, {Price: 3.5, Amount: 1}}})
+	if err != nil {
+		t.Error("Test failed. Apply() error", err)
+	}
+	if bids := b.Orderbook().Bids; len(bids) != 2 || bids[0].Price != 3.5 || bids[1].Price != 3 {
+		t.Error("Test failed. Apply() unexpected bids with MaxDepth", bids)
+	}
+
+	err = b.Apply(Update{UpdateID: 130, PrevUpdateID: 120, Asks: []Item{{Price: 5, Amount: 1}}})
+	if err != ErrSequenceGap {
+		t.Error("Test failed. Apply() expected ErrSequenceGap received", err)
+	}
+	if err = b.LoadSnapshot(Base{}, 140); err != ErrSnapshotIncorrect {
+		t.Error("Test failed. LoadSnapshot() expected ErrSnapshotIncorrect received", err)
+	}
+}
+
+func TestBufferSequenceTracking(t *testing.T) {
+	t.Parallel()
+	tracker := NewSequenceTracker()
+	b := NewBuffer("SequenceTest", pair.NewCurrencyPair("BTC", "USD"), Spot)
+	b.Tracker = tracker
+	resubscribes := 0
+	b.Resubscribe = func() error {
+		resubscribes++
+		return nil
+	}
+
+	now := time.Now()
+	err := b.LoadSnapshot(Base{Bids: []Item{{Price: 1, Amount: 1}}, Asks: []Item{{Price: 2, Amount: 1}}}, 1)
+	if err != nil {
+		t.Fatal("Test failed. LoadSnapshot() error", err)
+	}
+	err = b.Apply(Update{UpdateID: 2, Bids: []Item{{Price: 1, Amount: 2}}, UpdateTime: now})
+	if err != nil {
+		t.Error("Test failed. Apply() error", err)
+	}
+	// Timestamps before the previous update or outside the clock skew are
+	// counted but still applied
+	err = b.Apply(Update{UpdateID: 3, Bids: []Item{{Price: 1, Amount: 3}}, UpdateTime: now.Add(-time.Second)})
+	if err != nil {
+		t.Error("Test failed. Apply() error", err)
+	}
+	err = b.Apply(Update{UpdateID: 4, Bids: []Item{{Price: 1, Amount: 4}}, UpdateTime: now.Add(time.Minute)})
+	if err != nil || b.Orderbook().Bids[0].Amount != 4 {
+		t.Error("Test failed. Apply() error", err)
+	}
+
+	// Gaps resubscribe for a fresh snapshot
+	err = b.Apply(Update{UpdateID: 6, Bids: []Item{{Price: 1, Amount: 6}}})
+	if err != ErrSequenceGap || resubscribes != 1 {
+		t.Error("Test failed. Apply() expected gap and resubscribe", err, resubscribes)
+	}
+	err = b.LoadSnapshot(Base{Bids: []Item{{Price: 1, Amount: 5}}, Asks: []Item{{Price: 2, Amount: 1}}}, 5)
+	if err != nil || !b.Synced() || b.Orderbook().Bids[0].Amount != 6 {
+		t.Error("Test failed. LoadSnapshot() expected resynced book", err)
+	}
+
+	c := tracker.Counter("SequenceTest")
+	expected := SequenceCounter{Exchange: "SequenceTest", Updates: 4, Gaps: 1, Resyncs: 1, OutOfOrder: 1, ClockSkews: 1}
+	if c != expected {
+		t.Errorf("Test failed. Counter() expected %+v received %+v", expected, c)
+	}
+
+	var buf bytes.Buffer
+	err = tracker.WriteMetrics(&buf)
+	if err != nil {
+		t.Fatal("Test failed. WriteMetrics() error", err)
+	}
+	if !strings.Contains(buf.String(), `gocryptotrader_orderbook_sequence_gaps_total{exchange="SequenceTest"} 1`) {
+		t.Error("Test failed. WriteMetrics() missing gap counter", buf.String())
+	}
+}
+
+func testDepthBook() Base {
+	return Base{
+		Bids: []Item{{Price: 99, Amount: 1}, {Price: 98, Amount: 2}, {Price: 90, Amount: 10}},
+		Asks: []Item{{Price: 101, Amount: 1}, {Price: 102, Amount: 2}, {Price: 110, Amount: 10}},
+	}
+}
+
+func TestEstimateSlippage(t *testing.T) {
+	t.Parallel()
+	b := testDepthBook()
+	s, err := b.EstimateSlippage(true, 2)
+	if err != nil || s.Price != 101.5 || s.WorstPrice != 102 || s.MidPrice != 100 || s.SlippageBps != 150 {
+		t.Error("Test Failed - EstimateSlippage() incorrect buy fill", s, err)
+	}
+	s, err = b.EstimateSlippage(false, 3)
+	if err != nil || s.WorstPrice != 98 || s.Amount != 3 {
+		t.Error("Test Failed - EstimateSlippage() incorrect sell fill", s, err)
+	}
+	if _, err = b.EstimateSlippage(true, 20); err != ErrInsufficientLiquidity {
+		t.Error("Test Failed - EstimateSlippage() expected insufficient liquidity error", err)
+	}
+	if _, err = (&Base{Bids: b.Bids}).EstimateSlippage(false, 1); err != ErrNoMidPrice {
+		t.Error("Test Failed - EstimateSlippage() expected no mid price error", err)
+	}
+}
+
+func TestLiquidityWithin(t *testing.T) {
+	t.Parallel()
+	b := testDepthBook()
+	if amount, err := b.LiquidityWithin(true, 200); err != nil || amount != 3 {
+		t.Error("Test Failed - LiquidityWithin() incorrect ask liquidity", amount, err)
+	}
+	if amount, _ := b.LiquidityWithin(false, 100); amount != 1 {
+		t.Error("Test Failed - LiquidityWithin() incorrect bid liquidity", amount)
+	}
+	if amount, _ := b.LiquidityWithin(false, 0); amount != 13 {
+		t.Error("Test Failed - LiquidityWithin() incorrect total liquidity", amount)
+	}
+}
@@ -0,0 +1,113 @@
+package orderbook
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+)
+
+// SequenceCounter holds the websocket orderbook validation counters of an
+// exchange. Updates counts every update received, Gaps and ChecksumMismatches
+// are updates which invalidated a book and Resyncs are books restored from a
+// snapshot afterwards. OutOfOrder updates are timestamped before the previous
+// update and ClockSkews are updates whose exchange timestamp differs from the
+// local clock by more than the buffer's MaxClockSkew.
+type SequenceCounter struct {
+	Exchange           string `json:"exchange"`
+	Updates            int64  `json:"updates"`
+	Gaps               int64  `json:"gaps"`
+	ChecksumMismatches int64  `json:"checksum_mismatches"`
+	Resyncs            int64  `json:"resyncs"`
+	OutOfOrder         int64  `json:"out_of_order"`
+	ClockSkews         int64  `json:"clock_skews"`
+}
+
+// SequenceTracker counts the sequence and clock validation results of
+// orderbook buffers per exchange
+type SequenceTracker struct {
+	counters map[string]*SequenceCounter
+	m        sync.Mutex
+}
+
+// Sequences is the shared sequence tracker used by orderbook buffers
+var Sequences = NewSequenceTracker()
+
+// NewSequenceTracker returns a new sequence tracker
+func NewSequenceTracker() *SequenceTracker {
+	return &SequenceTracker{counters: make(map[string]*SequenceCounter)}
+}
+
+// Counters returns the counters of every exchange ordered by exchange name
+func (t *SequenceTracker) Counters() []SequenceCounter {
+	t.m.Lock()
+	defer t.m.Unlock()
+	counters := make([]SequenceCounter, 0, len(t.counters))
+	for _, c := range t.counters {
+		counters = append(counters, *c)
+	}
+	sort.Slice(counters, func(i, j int) bool {
+		return counters[i].Exchange < counters[j].Exchange
+	})
+	return counters
+}
+
+// Counter returns the counters of an exchange
+func (t *SequenceTracker) Counter(exchName string) SequenceCounter {
+	t.m.Lock()
+	defer t.m.Unlock()
+	if c, ok := t.counters[exchName]; ok {
+		return *c
+	}
+	return SequenceCounter{Exchange: exchName}
+}
+
+// WriteMetrics writes the counters in the Prometheus text exposition format
+func (t *SequenceTracker) WriteMetrics(w io.Writer) error {
+	counters := t.Counters()
+	metrics := []struct {
+		name, help string
+		value      func(c *SequenceCounter) int64
+	}{
+		{"gocryptotrader_orderbook_updates_total", "Websocket orderbook updates received",
+			func(c *SequenceCounter) int64 { return c.Updates }},
+		{"gocryptotrader_orderbook_sequence_gaps_total", "Websocket orderbook sequence gaps detected",
+			func(c *SequenceCounter) int64 { return c.Gaps }},
+		{"gocryptotrader_orderbook_checksum_mismatches_total", "Websocket orderbook checksum mismatches",
+			func(c *SequenceCounter) int64 { return c.ChecksumMismatches }},
+		{"gocryptotrader_orderbook_resyncs_total", "Websocket orderbooks resynced from a snapshot",
+			func(c *SequenceCounter) int64 { return c.Resyncs }},
+		{"gocryptotrader_orderbook_out_of_order_total", "Websocket orderbook updates timestamped before the previous update",
+			func(c *SequenceCounter) int64 { return c.OutOfOrder }},
+		{"gocryptotrader_orderbook_clock_skews_total", "Websocket orderbook updates outside the allowed clock skew",
+			func(c *SequenceCounter) int64 { return c.ClockSkews }},
+	}
+
+	for _, metric := range metrics {
+		_, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s counter\n",
+			metric.name, metric.help, metric.name)
+		if err != nil {
+			return err
+		}
+		for i := range counters {
+			_, err = fmt.Fprintf(w, "%s{exchange=%q} %d\n",
+				metric.name, counters[i].Exchange, metric.value(&counters[i]))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// record updates the counters of an exchange
+func (t *SequenceTracker) record(exchName string, update func(c *SequenceCounter)) {
+	t.m.Lock()
+	c, ok := t.counters[exchName]
+	if !ok {
+		c = &SequenceCounter{Exchange: exchName}
+		t.counters[exchName] = c
+	}
+	update(c)
+	t.m.Unlock()
+}
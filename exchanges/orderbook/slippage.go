@@ -0,0 +1,99 @@
+package orderbook
+
+import (
+	"errors"
+	"math"
+)
+
+// Errors returned when estimating slippage
+var (
+	ErrNoMidPrice            = errors.New("orderbook has no bids or asks for a mid price")
+	ErrInsufficientLiquidity = errors.New("orderbook has insufficient liquidity for the amount")
+)
+
+// Slippage is the expected fill of a market order walking the book. Price is
+// the volume weighted fill price, WorstPrice the last level taken and
+// SlippageBps the distance of the fill price from the mid price in basis
+// points.
+type Slippage struct {
+	Amount      float64
+	Price       float64
+	WorstPrice  float64
+	MidPrice    float64
+	SlippageBps float64
+}
+
+// MidPrice returns the price halfway between the best bid and best ask
+func (o *Base) MidPrice() (float64, error) {
+	if len(o.Bids) == 0 || len(o.Asks) == 0 || o.Bids[0].Price <= 0 || o.Asks[0].Price <= 0 {
+		return 0, ErrNoMidPrice
+	}
+	return (o.Bids[0].Price + o.Asks[0].Price) / 2, nil
+}
+
+// takeSide returns the levels a buy or sell takes liquidity from
+func (o *Base) takeSide(buy bool) []Item {
+	if buy {
+		return o.Asks
+	}
+	return o.Bids
+}
+
+// EstimateSlippage walks the asks for a buy, or the bids for a sell, of
+// amount in the base currency and returns the expected fill
+func (o *Base) EstimateSlippage(buy bool, amount float64) (Slippage, error) {
+	mid, err := o.MidPrice()
+	if err != nil {
+		return Slippage{}, err
+	}
+
+	s := Slippage{MidPrice: mid}
+	var quote float64
+	remaining := amount
+	for _, level := range o.takeSide(buy) {
+		if remaining <= 0 {
+			break
+		}
+		if level.Price <= 0 || level.Amount <= 0 {
+			continue
+		}
+		taken := math.Min(level.Amount, remaining)
+		remaining -= taken
+		s.Amount += taken
+		quote += taken * level.Price
+		s.WorstPrice = level.Price
+	}
+	// Allow for floating point error when the book is exactly consumed
+	if remaining > amount*1e-9 {
+		return Slippage{}, ErrInsufficientLiquidity
+	}
+	s.Price = quote / s.Amount
+	s.SlippageBps = math.Abs(s.Price-mid) / mid * 10000
+	return s, nil
+}
+
+// LiquidityWithin returns the amount in the base currency a buy, or sell, can
+// take from the book at prices within bps basis points of the mid price. A
+// zero bps returns the liquidity of the whole side.
+func (o *Base) LiquidityWithin(buy bool, bps float64) (float64, error) {
+	mid, err := o.MidPrice()
+	if err != nil {
+		return 0, err
+	}
+
+	limit := math.Inf(1)
+	if bps > 0 {
+		limit = mid * bps / 10000
+	}
+	var amount float64
+	for _, level := range o.takeSide(buy) {
+		if level.Price <= 0 || level.Amount <= 0 {
+			continue
+		}
+		if math.Abs(level.Price-mid) > limit {
+			break
+		}
+		amount += level.Amount
+	}
+	return amount, nil
+}
@@ -0,0 +1,122 @@
+package orders
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+)
+
+// ErrExchangeNotFound is returned when a cancel all targets an exchange which
+// is not loaded
+var ErrExchangeNotFound = errors.New("exchange not found")
+
+// CancelAllResult holds the open orders matched by a scoped cancel all, on a
+// dry run the orders which would be cancelled. Native lists the exchanges
+// which enforced the scope themselves and Errors holds failures keyed by
+// exchange name or exchange order ID.
+type CancelAllResult struct {
+	DryRun bool
+	Orders []*Order
+	Native []string
+	Errors map[string]error
+}
+
+// CancelAll cancels the open tracked orders matching the scope on an
+// exchange, or on every exchange with matching orders when exchName is empty.
+// Exchanges which enforce the scope natively are cancelled through
+// CancelAllOrders, which also cancels matching orders placed outside the
+// order manager, otherwise each matching order is cancelled individually. A
+// dry run returns the matching orders without cancelling them.
+func CancelAll(getExchange exchange.ExchangeGetter, exchName string, scope exchange.CancelAllScope, dryRun bool) CancelAllResult {
+	result := CancelAllResult{
+		DryRun: dryRun,
+		Errors: make(map[string]error),
+	}
+
+	var names []string
+	byExchange := make(map[string][]*Order)
+	if exchName != "" {
+		names = append(names, exchName)
+	}
+	now := time.Now()
+	for _, o := range Orders.All() {
+		if exchName != "" && !strings.EqualFold(o.Exchange, exchName) {
+			continue
+		}
+		if !o.matchesScope(scope, now) {
+			continue
+		}
+		result.Orders = append(result.Orders, o)
+		key := strings.ToLower(o.Exchange)
+		if _, ok := byExchange[key]; !ok && exchName == "" {
+			names = append(names, o.Exchange)
+		}
+		byExchange[key] = append(byExchange[key], o)
+	}
+	if dryRun {
+		return result
+	}
+
+	for _, name := range names {
+		exch := getExchange(name)
+		if exch == nil {
+			result.Errors[name] = fmt.Errorf("%s %s", name, ErrExchangeNotFound)
+			continue
+		}
+		matched := byExchange[strings.ToLower(name)]
+
+		if s, ok := exch.(exchange.ScopedCancelAller); ok && s.SupportsCancelAllScope(scope) {
+			resp, err := exch.CancelAllOrders(exchange.OrderCancellation{
+				CurrencyPair: scope.Pair,
+				Side:         scope.Side,
+			})
+			if err != nil {
+				result.Errors[name] = err
+				continue
+			}
+			result.Native = append(result.Native, name)
+			for _, o := range matched {
+				if msg := resp.OrderStatus[o.ExchangeOrderID]; msg != "" {
+					result.Errors[o.ExchangeOrderID] = errors.New(msg)
+					continue
+				}
+				o.m.Lock()
+				o.cancelled()
+				o.m.Unlock()
+			}
+			continue
+		}
+
+		for _, o := range matched {
+			if err := o.CancelRemainder(exch.CancelOrder); err != nil {
+				result.Errors[o.ExchangeOrderID] = err
+			}
+		}
+	}
+	return result
+}
+
+// matchesScope returns whether the order is open on the exchange and matches
+// the cancel all scope at now
+func (o *Order) matchesScope(scope exchange.CancelAllScope, now time.Time) bool {
+	o.m.Lock()
+	defer o.m.Unlock()
+
+	if o.ExchangeOrderID == "" || o.remaining() == 0 ||
+		(o.Status != StatusNew && o.Status != StatusPartiallyFilled) {
+		return false
+	}
+	if !scope.Pair.Empty() && !o.Pair.Equal(scope.Pair, true) {
+		return false
+	}
+	if scope.Side != "" && o.Side != scope.Side {
+		return false
+	}
+	if scope.OrderType != "" && o.orderType() != scope.OrderType {
+		return false
+	}
+	return scope.MinAge == 0 || now.Sub(o.Created) >= scope.MinAge
+}
@@ -0,0 +1,395 @@
+package orders
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/latency"
+)
+
+// Errors returned when tracking fills
+var (
+	ErrOrderNotFound     = errors.New("order not found")
+	ErrDuplicateFill     = errors.New("fill has already been applied to order")
+	ErrOverfill          = errors.New("fill amount exceeds order remaining amount")
+	ErrNothingRemaining  = errors.New("order has no remaining amount")
+	ErrNoExchangeOrderID = errors.New("order has no exchange order ID")
+)
+
+// Order statuses maintained from fills and cancellations
+const (
+	StatusNew                = "NEW"
+	StatusPartiallyFilled    = "PARTIALLY_FILLED"
+	StatusFilled             = "FILLED"
+	StatusCancelled          = "CANCELLED"
+	StatusPartiallyCancelled = "PARTIALLY_CANCELLED"
+)
+
+// fillTolerance absorbs floating point error when comparing filled and order
+// amounts
+const fillTolerance = 1e-9
+
+// Fill is an individual execution against an order. Fee is the fee paid in
+// the quote currency, a negative fee is a rebate received. Liquidity is set
+// from exchange data when available and otherwise inferred when the fill is
+// applied.
+type Fill struct {
+	TradeID   string
+	Amount    float64
+	Price     float64
+	Fee       float64
+	Liquidity exchange.Liquidity
+	Timestamp time.Time
+}
+
+// FeeBreakdown holds an order's fills and fees split by liquidity. Fees are
+// the fees paid and Rebates the rebates received, both positive.
+type FeeBreakdown struct {
+	MakerAmount float64
+	TakerAmount float64
+	MakerFees   float64
+	TakerFees   float64
+	Rebates     float64
+}
+
+// NetFee returns the fees paid less the rebates received
+func (f *FeeBreakdown) NetFee() float64 {
+	return f.MakerFees + f.TakerFees - f.Rebates
+}
+
+// TrackOrder adds an order placed on an exchange to the order manager so its
+// fills can be tracked and returns the local order ID
+func TrackOrder(exchName, exchangeOrderID string, p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64) int {
+	return TrackStrategyOrder("", exchName, exchangeOrderID, p, side, orderType, amount, price)
+}
+
+// TrackStrategyOrder adds an order placed on an exchange by a strategy to the
+// order manager and returns the local order ID
+func TrackStrategyOrder(strategy, exchName, exchangeOrderID string, p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64) int {
+	o := &Order{
+		Exchange:        exchName,
+		ExchangeOrderID: exchangeOrderID,
+		Pair:            p,
+		Side:            side,
+		Amount:          amount,
+		Price:           price,
+		Status:          StatusNew,
+		Created:         time.Now(),
+		Strategy:        strategy,
+	}
+	if orderType == exchange.Market {
+		o.Type = marketOrder
+	}
+	return Orders.Add(o)
+}
+
+// GetOrderByExchangeOrderID returns order pointer by the exchange's order ID,
+// used to route websocket fills and order updates
+func GetOrderByExchangeOrderID(exchName, exchangeOrderID string) *Order {
+	return Orders.ByExchangeOrderID(exchName, exchangeOrderID)
+}
+
+// ApplyFill applies a fill to an order by its local order ID
+func ApplyFill(orderID int, f Fill) error {
+	o := GetOrderByOrderID(orderID)
+	if o == nil {
+		return ErrOrderNotFound
+	}
+	return o.AddFill(f)
+}
+
+// AddFill applies an individual execution to the order, updating the
+// cumulative filled amount and average fill price. Fills carrying a trade ID
+// which has already been applied are rejected so replayed websocket messages
+// are not double counted.
+func (o *Order) AddFill(f Fill) error {
+	if f.Amount <= 0 || f.Price <= 0 {
+		return fmt.Errorf("invalid fill amount %f or price %f", f.Amount, f.Price)
+	}
+
+	o.m.Lock()
+	defer o.m.Unlock()
+
+	if f.TradeID != "" {
+		for i := range o.fills {
+			if o.fills[i].TradeID == f.TradeID {
+				return ErrDuplicateFill
+			}
+		}
+	}
+
+	if o.FilledAmount+f.Amount > o.Amount+fillTolerance {
+		return ErrOverfill
+	}
+
+	if f.Liquidity == exchange.UnknownLiquidity {
+		f.Liquidity = exchange.InferLiquidity(o.orderType(), o.Side, o.Price, f.Price)
+	}
+
+	if o.FilledAmount == 0 {
+		o.firstFill()
+	}
+	filled := o.FilledAmount + f.Amount
+	o.AverageFillPrice = (o.AverageFillPrice*o.FilledAmount + f.Price*f.Amount) / filled
+	o.FilledAmount = filled
+	o.fills = append(o.fills, f)
+	o.fillReservation(f.Amount, f.Amount*f.Price)
+	o.updateStatus()
+	return nil
+}
+
+// ApplyOrderUpdate applies an exchange order update which reports cumulative
+// executed amounts rather than individual fills. Updates reporting less than
+// the amount already filled are treated as stale and ignored. If the
+// exchange only reports the open volume the filled amount is derived from it.
+func (o *Order) ApplyOrderUpdate(d exchange.OrderDetail) error {
+	o.m.Lock()
+	defer o.m.Unlock()
+
+	if d.Amount > 0 {
+		o.Amount = d.Amount
+	}
+
+	executed := d.ExecutedAmount
+	if executed == 0 && d.OpenVolume > 0 {
+		executed = o.Amount - d.OpenVolume
+	}
+	if executed > o.Amount+fillTolerance {
+		return ErrOverfill
+	}
+	if executed < o.FilledAmount {
+		return nil
+	}
+
+	notional := o.AverageFillPrice * o.FilledAmount
+	if d.AverageExecutedPrice > 0 {
+		o.AverageFillPrice = d.AverageExecutedPrice
+	} else if executed > o.FilledAmount && d.Price > 0 {
+		// Without an average price from the exchange assume the new volume
+		// executed at the order's limit price
+		o.AverageFillPrice = (o.AverageFillPrice*o.FilledAmount +
+			d.Price*(executed-o.FilledAmount)) / executed
+	}
+	if o.FilledAmount == 0 && executed > 0 {
+		o.firstFill()
+	}
+	o.fillReservation(executed-o.FilledAmount,
+		o.AverageFillPrice*executed-notional)
+	o.FilledAmount = executed
+	o.updateStatus()
+	return nil
+}
+
+// firstFill records the receipt of the order's first fill for latency
+// measurement
+func (o *Order) firstFill() {
+	if o.ExchangeOrderID != "" {
+		latency.Default.Fill(o.Exchange, o.ExchangeOrderID, time.Now())
+	}
+}
+
+// Remaining returns the amount of the order which has not been filled
+func (o *Order) Remaining() float64 {
+	o.m.Lock()
+	defer o.m.Unlock()
+	return o.remaining()
+}
+
+// Fills returns a copy of the fills applied to the order
+func (o *Order) Fills() []Fill {
+	o.m.Lock()
+	defer o.m.Unlock()
+	fills := make([]Fill, len(o.fills))
+	copy(fills, o.fills)
+	return fills
+}
+
+// FeeBreakdown returns the order's fills and fees split by maker and taker
+// liquidity, rebates are accounted separately from fees paid
+func (o *Order) FeeBreakdown() FeeBreakdown {
+	o.m.Lock()
+	defer o.m.Unlock()
+
+	var b FeeBreakdown
+	for i := range o.fills {
+		fee := o.fills[i].Fee
+		if fee < 0 {
+			b.Rebates -= fee
+			fee = 0
+		}
+		if o.fills[i].Liquidity == exchange.Maker {
+			b.MakerAmount += o.fills[i].Amount
+			b.MakerFees += fee
+		} else {
+			b.TakerAmount += o.fills[i].Amount
+			b.TakerFees += fee
+		}
+	}
+	return b
+}
+
+// FillFeeBuilder returns the fee builder to estimate the trading fee of a
+// fill through an exchange's GetFee, for exchanges which do not report fees
+// on fills
+func (o *Order) FillFeeBuilder(f Fill) exchange.FeeBuilder {
+	liquidity := f.Liquidity
+	if liquidity == exchange.UnknownLiquidity {
+		liquidity = exchange.InferLiquidity(o.orderType(), o.Side, o.Price, f.Price)
+	}
+	return exchange.FeeBuilder{
+		FeeType:        exchange.CryptocurrencyTradeFee,
+		FirstCurrency:  o.Pair.FirstCurrency.String(),
+		SecondCurrency: o.Pair.SecondCurrency.String(),
+		Delimiter:      o.Pair.Delimiter,
+		IsMaker:        liquidity == exchange.Maker,
+		PurchasePrice:  f.Price,
+		Amount:         f.Amount,
+	}
+}
+
+// Detail returns the order as an exchange order detail
+func (o *Order) Detail() exchange.OrderDetail {
+	o.m.Lock()
+	defer o.m.Unlock()
+
+	return exchange.OrderDetail{
+		Exchange:             o.Exchange,
+		ID:                   o.ExchangeOrderID,
+		BaseCurrency:         o.Pair.FirstCurrency.String(),
+		QuoteCurrency:        o.Pair.SecondCurrency.String(),
+		OrderSide:            string(o.Side),
+		OrderType:            string(o.orderType()),
+		Status:               o.Status,
+		Price:                o.Price,
+		Amount:               o.Amount,
+		OpenVolume:           o.remaining(),
+		ExecutedAmount:       o.FilledAmount,
+		AverageExecutedPrice: o.AverageFillPrice,
+	}
+}
+
+// CancelRemainder cancels the unfilled remainder of the order on the exchange
+// and reduces the order amount to the filled amount so nothing remains open
+func (o *Order) CancelRemainder(cancel exchange.CancelOrderFunc) error {
+	o.m.Lock()
+	defer o.m.Unlock()
+
+	if o.remaining() <= fillTolerance {
+		return ErrNothingRemaining
+	}
+	if o.ExchangeOrderID == "" {
+		return ErrNoExchangeOrderID
+	}
+
+	err := cancel(exchange.OrderCancellation{
+		OrderID:      o.ExchangeOrderID,
+		CurrencyPair: o.Pair,
+		Side:         o.Side,
+	})
+	if err != nil {
+		return err
+	}
+	o.cancelled()
+	return nil
+}
+
+// MarkCancelled records that the exchange cancelled the unfilled remainder of
+// the order, used when a cancellation is observed rather than requested
+func (o *Order) MarkCancelled() {
+	o.m.Lock()
+	o.cancelled()
+	o.m.Unlock()
+}
+
+// IsOpen returns whether the order is still working on the exchange
+func (o *Order) IsOpen() bool {
+	o.m.Lock()
+	defer o.m.Unlock()
+	return o.Status == StatusNew || o.Status == StatusPartiallyFilled
+}
+
+// TopUpRemainder submits an additional order at the same price and side so
+// the combined open amount of the order and its previous top ups is restored
+// to target. The top up is tracked as a new order and its local order ID is
+// returned.
+func (o *Order) TopUpRemainder(submit exchange.SubmitOrderFunc, target float64) (int, error) {
+	if target <= 0 {
+		return 0, ErrInvalidAmount
+	}
+
+	open := o.Remaining()
+	o.m.Lock()
+	topUps := make([]int, len(o.TopUpOrderIDs))
+	copy(topUps, o.TopUpOrderIDs)
+	o.m.Unlock()
+	for i := range topUps {
+		if t := GetOrderByOrderID(topUps[i]); t != nil {
+			open += t.Remaining()
+		}
+	}
+
+	amount := target - open
+	if amount <= fillTolerance {
+		return 0, fmt.Errorf("open amount %f already meets target %f", open, target)
+	}
+
+	orderType := o.orderType()
+	resp, err := submit(o.Pair, o.Side, orderType, amount, o.Price, "")
+	if err != nil {
+		return 0, err
+	}
+	if !resp.IsOrderPlaced {
+		return 0, fmt.Errorf("%s top up order was not placed", o.Exchange)
+	}
+
+	id := TrackOrder(o.Exchange, resp.OrderID, o.Pair, o.Side, orderType, amount, o.Price)
+	o.m.Lock()
+	o.TopUpOrderIDs = append(o.TopUpOrderIDs, id)
+	o.m.Unlock()
+	return id, nil
+}
+
+// orderType returns the exchange order type of the order
+func (o *Order) orderType() exchange.OrderType {
+	if o.Type == marketOrder {
+		return exchange.Market
+	}
+	return exchange.Limit
+}
+
+// remaining returns the unfilled amount. The mutex must be held by the caller.
+func (o *Order) remaining() float64 {
+	r := o.Amount - o.FilledAmount
+	if r < fillTolerance {
+		return 0
+	}
+	return r
+}
+
+// cancelled reduces the order amount to the filled amount, sets the cancelled
+// status and releases the order's reservation. The mutex must be held by the
+// caller.
+func (o *Order) cancelled() {
+	o.Amount = o.FilledAmount
+	if o.FilledAmount > 0 {
+		o.Status = StatusPartiallyCancelled
+	} else {
+		o.Status = StatusCancelled
+	}
+	o.settleReservation()
+}
+
+// updateStatus sets the status from the filled amount, releasing the order's
+// reservation once filled. The mutex must be held by the caller.
+func (o *Order) updateStatus() {
+	switch {
+	case o.remaining() == 0:
+		o.Status = StatusFilled
+	case o.FilledAmount > 0:
+		o.Status = StatusPartiallyFilled
+	}
+	o.settleReservation()
+}
@@ -0,0 +1,246 @@
+package orders
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+)
+
+// Errors returned by iceberg orders
+var (
+	ErrInvalidVisibleAmount = errors.New("visible amount must be greater than zero and less than the total amount")
+	ErrIcebergNotStarted    = errors.New("iceberg order has not been started")
+	ErrIcebergStarted       = errors.New("iceberg order has already been started")
+	ErrIcebergDone          = errors.New("iceberg order is filled or cancelled")
+)
+
+// Iceberg shows only a visible amount of a larger limit order on the book.
+// When the exchange supports native iceberg orders the full order is placed
+// with the visible amount as a parameter, otherwise the engine keeps a single
+// child limit order of at most the visible amount open and replenishes it as
+// each child fills.
+type Iceberg struct {
+	Exchange string
+	Pair     pair.CurrencyPair
+	Side     exchange.OrderSide
+	Price    float64
+	Total    float64
+	Visible  float64
+	Native   bool
+	Status   string
+
+	exch     exchange.IBotExchange
+	children []int
+	m        sync.Mutex
+}
+
+// NewIceberg returns a new iceberg order. Native is set when the exchange
+// supports native iceberg orders and can be cleared before Start to force
+// engine side emulation.
+func NewIceberg(exch exchange.IBotExchange, p pair.CurrencyPair, side exchange.OrderSide, total, visible, price float64) (*Iceberg, error) {
+	if total <= 0 || price <= 0 {
+		return nil, ErrInvalidAmount
+	}
+	if visible <= 0 || visible >= total {
+		return nil, ErrInvalidVisibleAmount
+	}
+
+	_, native := exch.(exchange.IcebergOrderSubmitter)
+	return &Iceberg{
+		Exchange: exch.GetName(),
+		Pair:     p,
+		Side:     side,
+		Price:    price,
+		Total:    total,
+		Visible:  visible,
+		Native:   native,
+		Status:   StatusNew,
+		exch:     exch,
+	}, nil
+}
+
+// Start places the native iceberg order or the first visible child order
+func (i *Iceberg) Start() error {
+	i.m.Lock()
+	defer i.m.Unlock()
+
+	if len(i.children) > 0 {
+		return ErrIcebergStarted
+	}
+
+	if i.Native {
+		resp, err := i.exch.(exchange.IcebergOrderSubmitter).SubmitIcebergOrder(i.Pair,
+			i.Side, i.Total, i.Visible, i.Price, "")
+		if err != nil {
+			return err
+		}
+		return i.track(resp, i.Total)
+	}
+	return i.submitChild(i.Visible)
+}
+
+// AddFill applies a fill to the active child order, or to the native order,
+// and replenishes the visible amount once the child is filled
+func (i *Iceberg) AddFill(f Fill) error {
+	i.m.Lock()
+	defer i.m.Unlock()
+
+	active := i.active()
+	if active == nil {
+		return ErrIcebergNotStarted
+	}
+	if err := active.AddFill(f); err != nil {
+		return err
+	}
+	return i.replenish()
+}
+
+// Replenish places the next visible child order if the active child has been
+// filled, for use when fills are applied to the child order directly through
+// the order manager
+func (i *Iceberg) Replenish() error {
+	i.m.Lock()
+	defer i.m.Unlock()
+
+	if i.active() == nil {
+		return ErrIcebergNotStarted
+	}
+	return i.replenish()
+}
+
+// Cancel cancels the unfilled remainder of the active order, no further
+// child orders are placed
+func (i *Iceberg) Cancel() error {
+	i.m.Lock()
+	defer i.m.Unlock()
+
+	active := i.active()
+	if active == nil {
+		return ErrIcebergNotStarted
+	}
+	if i.Status == StatusFilled || i.Status == StatusCancelled ||
+		i.Status == StatusPartiallyCancelled {
+		return ErrIcebergDone
+	}
+
+	if err := active.CancelRemainder(i.exch.CancelOrder); err != nil &&
+		err != ErrNothingRemaining {
+		return err
+	}
+
+	if filled, _ := i.filled(); filled > 0 {
+		i.Status = StatusPartiallyCancelled
+	} else {
+		i.Status = StatusCancelled
+	}
+	return nil
+}
+
+// Filled returns the total filled amount and the volume weighted average fill
+// price across all child orders
+func (i *Iceberg) Filled() (amount, averagePrice float64) {
+	i.m.Lock()
+	defer i.m.Unlock()
+	return i.filled()
+}
+
+// Remaining returns the amount of the iceberg which has not been filled
+func (i *Iceberg) Remaining() float64 {
+	filled, _ := i.Filled()
+	r := i.Total - filled
+	if r < fillTolerance {
+		return 0
+	}
+	return r
+}
+
+// Children returns the local order IDs of the orders placed for the iceberg
+func (i *Iceberg) Children() []int {
+	i.m.Lock()
+	defer i.m.Unlock()
+	children := make([]int, len(i.children))
+	copy(children, i.children)
+	return children
+}
+
+// replenish places the next child order when the active child is filled.
+// The mutex must be held by the caller.
+func (i *Iceberg) replenish() error {
+	if i.Status == StatusCancelled || i.Status == StatusPartiallyCancelled {
+		return nil
+	}
+
+	filled, _ := i.filled()
+	remaining := i.Total - filled
+	if remaining <= fillTolerance {
+		i.Status = StatusFilled
+		return nil
+	}
+	i.Status = StatusPartiallyFilled
+
+	if i.Native || i.active().Remaining() > 0 {
+		return nil
+	}
+
+	next := i.Visible
+	if remaining < next {
+		next = remaining
+	}
+	return i.submitChild(next)
+}
+
+// submitChild submits and tracks a visible child limit order. The mutex must
+// be held by the caller.
+func (i *Iceberg) submitChild(amount float64) error {
+	err := exchange.CheckOrderSubmission(i.exch.GetName())
+	if err != nil {
+		return err
+	}
+	resp, err := i.exch.SubmitOrder(i.Pair, i.Side, exchange.Limit, amount, i.Price, "")
+	if err != nil {
+		return err
+	}
+	return i.track(resp, amount)
+}
+
+// track adds a placed order to the order manager. The mutex must be held by
+// the caller.
+func (i *Iceberg) track(resp exchange.SubmitOrderResponse, amount float64) error {
+	if !resp.IsOrderPlaced {
+		return fmt.Errorf("%s iceberg order was not placed", i.Exchange)
+	}
+	i.children = append(i.children, TrackOrder(i.Exchange, resp.OrderID, i.Pair,
+		i.Side, exchange.Limit, amount, i.Price))
+	return nil
+}
+
+// active returns the most recently placed order. The mutex must be held by
+// the caller.
+func (i *Iceberg) active() *Order {
+	if len(i.children) == 0 {
+		return nil
+	}
+	return GetOrderByOrderID(i.children[len(i.children)-1])
+}
+
+// filled sums the fills across all child orders. The mutex must be held by
+// the caller.
+func (i *Iceberg) filled() (amount, averagePrice float64) {
+	var notional float64
+	for x := range i.children {
+		o := GetOrderByOrderID(i.children[x])
+		if o == nil {
+			continue
+		}
+		d := o.Detail()
+		amount += d.ExecutedAmount
+		notional += d.ExecutedAmount * d.AverageExecutedPrice
+	}
+	if amount > 0 {
+		averagePrice = notional / amount
+	}
+	return amount, averagePrice
+}
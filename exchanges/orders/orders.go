@@ -1,69 +1,153 @@
 package orders
 
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+)
+
 const (
 	limitOrder = iota
 	marketOrder
 )
 
-// Orders variable holds an array of pointers to order structs
-var Orders []*Order
+// Orders is the shared order store used by the bot
+var Orders = &Store{}
+
+// Store holds the orders tracked by the order manager in local order ID order
+// and is safe for concurrent use
+type Store struct {
+	orders []*Order
+	m      sync.RWMutex
+}
 
-// Order struct holds order values
+// Order struct holds order values. FilledAmount and AverageFillPrice are
+// maintained from fills and exchange order updates, see fills.go. Created is
+// when the order was added to the order manager. Strategy is the strategy which
+// placed the order, see SubmitStrategyOrder. The funds reserved for the order
+// are consumed as it fills and released once it closes, see HoldReservation.
 type Order struct {
-	OrderID  int
-	Exchange string
-	Type     int
-	Amount   float64
-	Price    float64
+	OrderID          int
+	Exchange         string
+	ExchangeOrderID  string
+	Pair             pair.CurrencyPair
+	Side             exchange.OrderSide
+	Type             int
+	Amount           float64
+	Price            float64
+	Status           string
+	FilledAmount     float64
+	AverageFillPrice float64
+	Created          time.Time
+	TopUpOrderIDs    []int
+	Strategy         string
+	fills            []Fill
+	reserved         string
+	m                sync.Mutex
 }
 
-// NewOrder creates a new order and returns a an orderID
-func NewOrder(Exchange string, amount, price float64) int {
-	order := &Order{}
-	if len(Orders) == 0 {
-		order.OrderID = 0
+// Add adds an order to the store, assigning it the next local order ID, and
+// returns the ID. The order must not be modified by the caller once added
+// except through its methods.
+func (s *Store) Add(o *Order) int {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if len(s.orders) == 0 {
+		o.OrderID = 0
 	} else {
-		order.OrderID = len(Orders)
+		// Orders are appended in ID order, continuing from the last ID
+		// prevents reusing an ID after an order is deleted
+		o.OrderID = s.orders[len(s.orders)-1].OrderID + 1
 	}
-
-	order.Exchange = Exchange
-	order.Amount = amount
-	order.Price = price
-	Orders = append(Orders, order)
-	return order.OrderID
+	s.orders = append(s.orders, o)
+	return o.OrderID
 }
 
-// DeleteOrder deletes orders by ID and returns state
-func DeleteOrder(orderID int) bool {
-	for i := range Orders {
-		if Orders[i].OrderID == orderID {
-			Orders = append(Orders[:i], Orders[i+1:]...)
+// Delete deletes an order by ID and returns whether it was found
+func (s *Store) Delete(orderID int) bool {
+	s.m.Lock()
+	defer s.m.Unlock()
+	for i := range s.orders {
+		if s.orders[i].OrderID == orderID {
+			s.orders = append(s.orders[:i], s.orders[i+1:]...)
 			return true
 		}
 	}
 	return false
 }
 
-// GetOrdersByExchange returns order pointer grouped by exchange
-func GetOrdersByExchange(exchange string) []*Order {
-	orders := []*Order{}
-	for i := range Orders {
-		if Orders[i].Exchange == exchange {
-			orders = append(orders, Orders[i])
+// All returns the tracked orders
+func (s *Store) All() []*Order {
+	s.m.RLock()
+	defer s.m.RUnlock()
+	orders := make([]*Order, len(s.orders))
+	copy(orders, s.orders)
+	return orders
+}
+
+// ByOrderID returns an order by its local order ID
+func (s *Store) ByOrderID(orderID int) *Order {
+	s.m.RLock()
+	defer s.m.RUnlock()
+	for i := range s.orders {
+		if s.orders[i].OrderID == orderID {
+			return s.orders[i]
 		}
 	}
-	if len(orders) > 0 {
-		return orders
-	}
 	return nil
 }
 
-// GetOrderByOrderID returns order pointer by ID
-func GetOrderByOrderID(orderID int) *Order {
-	for i := range Orders {
-		if Orders[i].OrderID == orderID {
-			return Orders[i]
+// ByExchange returns the orders of an exchange
+func (s *Store) ByExchange(exchName string) []*Order {
+	s.m.RLock()
+	defer s.m.RUnlock()
+	var orders []*Order
+	for i := range s.orders {
+		if s.orders[i].Exchange == exchName {
+			orders = append(orders, s.orders[i])
+		}
+	}
+	return orders
+}
+
+// ByExchangeOrderID returns an order by its exchange and the exchange's order
+// ID
+func (s *Store) ByExchangeOrderID(exchName, exchangeOrderID string) *Order {
+	s.m.RLock()
+	defer s.m.RUnlock()
+	for i := range s.orders {
+		if strings.EqualFold(s.orders[i].Exchange, exchName) &&
+			s.orders[i].ExchangeOrderID == exchangeOrderID {
+			return s.orders[i]
 		}
 	}
 	return nil
 }
+
+// NewOrder creates a new order and returns a an orderID
+func NewOrder(Exchange string, amount, price float64) int {
+	return Orders.Add(&Order{
+		Exchange: Exchange,
+		Amount:   amount,
+		Price:    price,
+		Created:  time.Now(),
+	})
+}
+
+// DeleteOrder deletes orders by ID and returns state
+func DeleteOrder(orderID int) bool {
+	return Orders.Delete(orderID)
+}
+
+// GetOrdersByExchange returns order pointer grouped by exchange
+func GetOrdersByExchange(exchange string) []*Order {
+	return Orders.ByExchange(exchange)
+}
+
+// GetOrderByOrderID returns order pointer by ID
+func GetOrderByOrderID(orderID int) *Order {
+	return Orders.ByOrderID(orderID)
+}
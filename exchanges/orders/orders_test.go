@@ -1,7 +1,17 @@
 package orders
 
 import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/assets"
 )
 
 func TestNewOrder(t *testing.T) {
@@ -35,3 +45,707 @@ func TestGetOrderByOrderID(t *testing.T) {
 		t.Error("Test Failed - Orders_test.go GetOrdersByExchange() - Error")
 	}
 }
+
+func TestOrdersConcurrentAccess(t *testing.T) {
+	p := pair.NewCurrencyPair("BTC", "USD")
+	var wg sync.WaitGroup
+	ids := make(chan int, 100)
+	for i := 0; i < 100; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			ids <- TrackOrder("ConcurrentTest", strconv.Itoa(i), p, exchange.Buy, exchange.Limit, 1, 100)
+		}(i)
+		go func(i int) {
+			defer wg.Done()
+			if o := GetOrderByExchangeOrderID("ConcurrentTest", strconv.Itoa(i)); o != nil && o.Pair != p {
+				t.Error("Test Failed - GetOrderByExchangeOrderID() returned a partially tracked order", o)
+			}
+			GetOrdersByExchange("ConcurrentTest")
+		}(i)
+	}
+	wg.Wait()
+	close(ids)
+
+	seen := make(map[int]bool)
+	for id := range ids {
+		if seen[id] {
+			t.Error("Test Failed - TrackOrder() assigned a duplicate order ID", id)
+		}
+		seen[id] = true
+		defer DeleteOrder(id)
+	}
+	if tracked := GetOrdersByExchange("ConcurrentTest"); len(tracked) != 100 {
+		t.Error("Test Failed - GetOrdersByExchange() expected every order tracked", len(tracked))
+	}
+}
+
+func TestNormaliseRejection(t *testing.T) {
+	if r := NormaliseRejection("Huobi", nil); r != nil {
+		t.Error("Test Failed - NormaliseRejection() expected nil")
+	}
+
+	r := NormaliseRejection("Huobi", errors.New("order-value-min-error"))
+	if r.Reason != RejectBelowMinimumNotional || !r.Retryable || r.Advice != RetryWithAdjustedAmount {
+		t.Error("Test Failed - NormaliseRejection() incorrect huobi mapping", r)
+	}
+
+	r = NormaliseRejection("Binance", errors.New("{\"code\":-1013,\"msg\":\"Filter failure: MIN_NOTIONAL\"}"))
+	if r.Reason != RejectBelowMinimumNotional {
+		t.Error("Test Failed - NormaliseRejection() incorrect binance mapping", r)
+	}
+
+	r = NormaliseRejection("Bithumb", errors.New("unsuccessful HTTP status code: 429"))
+	if r.Reason != RejectRateLimited || r.Advice != RetryAfterBackoff {
+		t.Error("Test Failed - NormaliseRejection() incorrect common mapping", r)
+	}
+
+	r = NormaliseRejection("Bithumb", errors.New("SendAuthenticatedHTTPRequest error Code:5600 Message:insufficient funds"))
+	if r.Reason != RejectInsufficientBalance || r.Retryable {
+		t.Error("Test Failed - NormaliseRejection() incorrect generic code mapping", r)
+	}
+
+	r = NormaliseRejection("Bithumb", errors.New("SendAuthenticatedHTTPRequest error Code:5600 Message:order 5100 not found"))
+	if r.Reason != RejectUnknown {
+		t.Error("Test Failed - NormaliseRejection() matched a code outside the code field", r)
+	}
+
+	r = NormaliseRejection("OKX", errors.New("OKX error code 51008: Order failed. Insufficient balance"))
+	if r.Reason != RejectInsufficientBalance {
+		t.Error("Test Failed - NormaliseRejection() incorrect okx code mapping", r)
+	}
+
+	if NormaliseRejection("Bithumb", r) != r {
+		t.Error("Test Failed - NormaliseRejection() should return existing rejection")
+	}
+
+	if IsRetryable("ANX", errors.New("something odd")) {
+		t.Error("Test Failed - IsRetryable() unknown errors should not be retryable")
+	}
+}
+
+func TestReservations(t *testing.T) {
+	r := NewReservations(time.Minute)
+	err := r.Reserve("Huobi", "USDT", "1", 10)
+	if err != ErrBalanceNotFound {
+		t.Error("Test Failed - Reserve() error", err)
+	}
+
+	r.UpdateBalance("Huobi", "usdt", 100)
+	if err = r.Reserve("huobi", "USDT", "1", 60); err != nil {
+		t.Fatal("Test Failed - Reserve() error", err)
+	}
+	if err = r.Reserve("huobi", "USDT", "1", 1); err != ErrReservationExists {
+		t.Error("Test Failed - Reserve() error", err)
+	}
+	if err = r.Reserve("huobi", "USDT", "2", 50); err == nil {
+		t.Error("Test Failed - Reserve() expected insufficient funds error")
+	}
+
+	avail, err := r.Available("huobi", "USDT")
+	if err != nil || avail != 40 {
+		t.Errorf("Test Failed - Available() expected 40 received %v %v", avail, err)
+	}
+
+	if err = r.Fill("huobi", "USDT", "1", 20); err != nil {
+		t.Error("Test Failed - Fill() error", err)
+	}
+	if r.GetReservation("huobi", "USDT", "1") != 40 {
+		t.Error("Test Failed - Fill() reservation not reduced")
+	}
+	if avail, _ = r.Available("huobi", "USDT"); avail != 40 {
+		t.Errorf("Test Failed - Fill() available expected 40 received %v", avail)
+	}
+
+	if err = r.Rename("huobi", "USDT", "1", "abc"); err != nil {
+		t.Error("Test Failed - Rename() error", err)
+	}
+	if err = r.Release("huobi", "USDT", "abc"); err != nil {
+		t.Error("Test Failed - Release() error", err)
+	}
+	if err = r.Release("huobi", "USDT", "abc"); err != ErrReservationMissing {
+		t.Error("Test Failed - Release() error", err)
+	}
+	if avail, _ = r.Available("huobi", "USDT"); avail != 80 {
+		t.Errorf("Test Failed - Release() available expected 80 received %v", avail)
+	}
+
+	r.TTL = time.Nanosecond
+	time.Sleep(time.Millisecond)
+	if !r.IsStale("huobi", "USDT") {
+		t.Error("Test Failed - IsStale() expected stale balance")
+	}
+	if err = r.Reserve("huobi", "USDT", "3", 1); err != ErrBalanceStale {
+		t.Error("Test Failed - Reserve() error", err)
+	}
+}
+
+func TestRequiredFunds(t *testing.T) {
+	c, amount := RequiredFunds(true, "BTC", "USDT", 2, 100)
+	if c != "USDT" || amount != 200 {
+		t.Error("Test Failed - RequiredFunds() buy incorrect")
+	}
+	c, amount = RequiredFunds(false, "BTC", "USDT", 2, 100)
+	if c != "BTC" || amount != 2 {
+		t.Error("Test Failed - RequiredFunds() sell incorrect")
+	}
+}
+
+func TestAddFill(t *testing.T) {
+	id := TrackOrder("Kraken", "OABC", pair.NewCurrencyPair("BTC", "USD"),
+		exchange.Buy, exchange.Limit, 2, 100)
+	o := GetOrderByOrderID(id)
+
+	if err := o.AddFill(Fill{TradeID: "1", Amount: 0.5, Price: 100}); err != nil {
+		t.Fatal("Test Failed - AddFill() error", err)
+	}
+	if err := o.AddFill(Fill{TradeID: "1", Amount: 0.5, Price: 100}); err != ErrDuplicateFill {
+		t.Error("Test Failed - AddFill() expected duplicate fill error", err)
+	}
+	if err := ApplyFill(id, Fill{TradeID: "2", Amount: 1, Price: 97}); err != nil {
+		t.Fatal("Test Failed - ApplyFill() error", err)
+	}
+	if o.FilledAmount != 1.5 || o.AverageFillPrice != 98 || o.Remaining() != 0.5 ||
+		o.Status != StatusPartiallyFilled {
+		t.Error("Test Failed - AddFill() incorrect fill state", o.Detail())
+	}
+	if err := o.AddFill(Fill{TradeID: "3", Amount: 1, Price: 97}); err != ErrOverfill {
+		t.Error("Test Failed - AddFill() expected overfill error", err)
+	}
+	if err := o.AddFill(Fill{TradeID: "4", Amount: 0.5, Price: 98}); err != nil {
+		t.Fatal("Test Failed - AddFill() error", err)
+	}
+	if o.Status != StatusFilled || len(o.Fills()) != 3 {
+		t.Error("Test Failed - AddFill() expected filled order", o.Detail())
+	}
+	if ApplyFill(-1, Fill{Amount: 1, Price: 1}) != ErrOrderNotFound {
+		t.Error("Test Failed - ApplyFill() expected order not found error")
+	}
+}
+
+func TestFeeBreakdown(t *testing.T) {
+	id := TrackOrder("Kraken", "OFEE", pair.NewCurrencyPair("BTC", "USD"),
+		exchange.Sell, exchange.Limit, 3, 100)
+	o := GetOrderByOrderID(id)
+
+	fills := []Fill{
+		{TradeID: "1", Amount: 1, Price: 100, Fee: -0.025},
+		{TradeID: "2", Amount: 1, Price: 101, Fee: 0.2},
+		{TradeID: "3", Amount: 1, Price: 100, Fee: 0.25, Liquidity: exchange.Taker},
+	}
+	for i := range fills {
+		if err := o.AddFill(fills[i]); err != nil {
+			t.Fatal("Test Failed - AddFill() error", err)
+		}
+	}
+
+	applied := o.Fills()
+	if applied[0].Liquidity != exchange.Maker || applied[1].Liquidity != exchange.Taker ||
+		applied[2].Liquidity != exchange.Taker {
+		t.Error("Test Failed - AddFill() incorrect liquidity", applied)
+	}
+
+	b := o.FeeBreakdown()
+	if b.MakerAmount != 1 || b.TakerAmount != 2 || b.MakerFees != 0 ||
+		b.TakerFees != 0.45 || b.Rebates != 0.025 || b.NetFee() != 0.425 {
+		t.Errorf("Test Failed - FeeBreakdown() unexpected breakdown %+v", b)
+	}
+
+	fb := o.FillFeeBuilder(Fill{Amount: 1, Price: 100})
+	if !fb.IsMaker || fb.FeeType != exchange.CryptocurrencyTradeFee ||
+		fb.FirstCurrency != "BTC" || fb.PurchasePrice != 100 {
+		t.Errorf("Test Failed - FillFeeBuilder() unexpected builder %+v", fb)
+	}
+}
+
+func TestApplyOrderUpdate(t *testing.T) {
+	id := TrackOrder("OKX", "123", pair.NewCurrencyPair("ETH", "USDT"),
+		exchange.Sell, exchange.Limit, 10, 2000)
+	o := GetOrderByExchangeOrderID("okx", "123")
+	if o == nil || o.OrderID != id {
+		t.Fatal("Test Failed - GetOrderByExchangeOrderID() order not found")
+	}
+
+	err := o.ApplyOrderUpdate(exchange.OrderDetail{ExecutedAmount: 4, AverageExecutedPrice: 2001})
+	if err != nil {
+		t.Fatal("Test Failed - ApplyOrderUpdate() error", err)
+	}
+	// A stale update is ignored
+	err = o.ApplyOrderUpdate(exchange.OrderDetail{ExecutedAmount: 2, AverageExecutedPrice: 2005})
+	if err != nil {
+		t.Fatal("Test Failed - ApplyOrderUpdate() error", err)
+	}
+	if o.FilledAmount != 4 || o.AverageFillPrice != 2001 || o.Remaining() != 6 {
+		t.Error("Test Failed - ApplyOrderUpdate() incorrect fill state", o.Detail())
+	}
+
+	// Open volume only updates derive the filled amount at the limit price
+	err = o.ApplyOrderUpdate(exchange.OrderDetail{Price: 2000, OpenVolume: 5})
+	if err != nil {
+		t.Fatal("Test Failed - ApplyOrderUpdate() error", err)
+	}
+	if o.FilledAmount != 5 || o.AverageFillPrice != 2000.8 {
+		t.Error("Test Failed - ApplyOrderUpdate() incorrect derived fill state", o.Detail())
+	}
+
+	if o.ApplyOrderUpdate(exchange.OrderDetail{ExecutedAmount: 11}) != ErrOverfill {
+		t.Error("Test Failed - ApplyOrderUpdate() expected overfill error")
+	}
+}
+
+func TestCancelRemainder(t *testing.T) {
+	id := TrackOrder("Bitstamp", "55", pair.NewCurrencyPair("BTC", "EUR"),
+		exchange.Buy, exchange.Limit, 1, 50)
+	o := GetOrderByOrderID(id)
+	if err := o.AddFill(Fill{Amount: 0.25, Price: 50}); err != nil {
+		t.Fatal("Test Failed - AddFill() error", err)
+	}
+
+	var cancelled exchange.OrderCancellation
+	cancel := func(c exchange.OrderCancellation) error {
+		cancelled = c
+		return nil
+	}
+	if err := o.CancelRemainder(cancel); err != nil {
+		t.Fatal("Test Failed - CancelRemainder() error", err)
+	}
+	if cancelled.OrderID != "55" || cancelled.Side != exchange.Buy {
+		t.Error("Test Failed - CancelRemainder() incorrect cancellation", cancelled)
+	}
+	if o.Remaining() != 0 || o.Amount != 0.25 || o.Status != StatusPartiallyCancelled {
+		t.Error("Test Failed - CancelRemainder() incorrect order state", o.Detail())
+	}
+	if o.CancelRemainder(cancel) != ErrNothingRemaining {
+		t.Error("Test Failed - CancelRemainder() expected nothing remaining error")
+	}
+}
+
+func TestTopUpRemainder(t *testing.T) {
+	id := TrackOrder("Gemini", "77", pair.NewCurrencyPair("ETH", "USD"),
+		exchange.Sell, exchange.Limit, 3, 150)
+	o := GetOrderByOrderID(id)
+	if err := o.AddFill(Fill{Amount: 2, Price: 150}); err != nil {
+		t.Fatal("Test Failed - AddFill() error", err)
+	}
+
+	var submitted float64
+	submit := func(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+		submitted = amount
+		return exchange.SubmitOrderResponse{IsOrderPlaced: true, OrderID: "78"}, nil
+	}
+
+	topUpID, err := o.TopUpRemainder(submit, 3)
+	if err != nil {
+		t.Fatal("Test Failed - TopUpRemainder() error", err)
+	}
+	if submitted != 2 {
+		t.Errorf("Test Failed - TopUpRemainder() expected amount 2 received %v", submitted)
+	}
+	topUp := GetOrderByOrderID(topUpID)
+	if topUp == nil || topUp.ExchangeOrderID != "78" || topUp.Price != 150 ||
+		topUp.Side != exchange.Sell {
+		t.Fatal("Test Failed - TopUpRemainder() top up order not tracked")
+	}
+
+	if _, err = o.TopUpRemainder(submit, 3); err == nil {
+		t.Error("Test Failed - TopUpRemainder() expected target already met error")
+	}
+	if _, err = o.TopUpRemainder(submit, 0); err != ErrInvalidAmount {
+		t.Error("Test Failed - TopUpRemainder() expected invalid amount error", err)
+	}
+}
+
+// testExchange records orders submitted and cancelled through the
+// IBotExchange interface
+type testExchange struct {
+	exchange.IBotExchange
+	submitted []float64
+	cancelled []string
+}
+
+func (e *testExchange) GetName() string {
+	return "TestExchange"
+}
+
+func (e *testExchange) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	e.submitted = append(e.submitted, amount)
+	return exchange.SubmitOrderResponse{
+		IsOrderPlaced: true,
+		OrderID:       fmt.Sprintf("child-%d", len(e.submitted)),
+	}, nil
+}
+
+func (e *testExchange) GetCapabilities() exchange.Features {
+	return exchange.Features{
+		CanSubmitOrder: true,
+		Orders: map[string]exchange.OrderCapabilities{
+			assets.Spot: {OrderTypes: []exchange.OrderType{exchange.Limit, exchange.Market}},
+		},
+	}
+}
+
+func (e *testExchange) MarketOrderInQuote(side exchange.OrderSide) bool {
+	return false
+}
+
+func (e *testExchange) CancelOrder(order exchange.OrderCancellation) error {
+	e.cancelled = append(e.cancelled, order.OrderID)
+	return nil
+}
+
+// testIcebergExchange supports native iceberg orders
+type testIcebergExchange struct {
+	testExchange
+	visible float64
+}
+
+func (e *testIcebergExchange) SubmitIcebergOrder(p pair.CurrencyPair, side exchange.OrderSide, amount, visibleAmount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	e.submitted = append(e.submitted, amount)
+	e.visible = visibleAmount
+	return exchange.SubmitOrderResponse{IsOrderPlaced: true, OrderID: "native"}, nil
+}
+
+func TestIcebergEmulated(t *testing.T) {
+	exch := &testExchange{}
+	p := pair.NewCurrencyPair("BTC", "USDT")
+
+	if _, err := NewIceberg(exch, p, exchange.Buy, 1, 1, 100); err != ErrInvalidVisibleAmount {
+		t.Error("Test Failed - NewIceberg() expected invalid visible amount error", err)
+	}
+
+	i, err := NewIceberg(exch, p, exchange.Buy, 2.5, 1, 100)
+	if err != nil {
+		t.Fatal("Test Failed - NewIceberg() error", err)
+	}
+	if i.Native {
+		t.Error("Test Failed - NewIceberg() expected emulated iceberg")
+	}
+	if err = i.AddFill(Fill{Amount: 1, Price: 100}); err != ErrIcebergNotStarted {
+		t.Error("Test Failed - AddFill() expected not started error", err)
+	}
+	if err = i.Start(); err != nil {
+		t.Fatal("Test Failed - Start() error", err)
+	}
+	if err = i.Start(); err != ErrIcebergStarted {
+		t.Error("Test Failed - Start() expected already started error", err)
+	}
+
+	if err = i.AddFill(Fill{Amount: 0.4, Price: 100}); err != nil {
+		t.Fatal("Test Failed - AddFill() error", err)
+	}
+	if len(exch.submitted) != 1 {
+		t.Error("Test Failed - AddFill() replenished a partially filled child")
+	}
+	if err = i.AddFill(Fill{Amount: 0.6, Price: 99}); err != nil {
+		t.Fatal("Test Failed - AddFill() error", err)
+	}
+	if err = i.AddFill(Fill{Amount: 1, Price: 98}); err != nil {
+		t.Fatal("Test Failed - AddFill() error", err)
+	}
+	if len(exch.submitted) != 3 || exch.submitted[1] != 1 || exch.submitted[2] != 0.5 {
+		t.Error("Test Failed - AddFill() incorrect child orders", exch.submitted)
+	}
+
+	filled, avg := i.Filled()
+	if filled != 2 || avg != 98.7 || i.Remaining() != 0.5 || i.Status != StatusPartiallyFilled {
+		t.Errorf("Test Failed - Filled() incorrect iceberg state %v %v %v %s",
+			filled, avg, i.Remaining(), i.Status)
+	}
+
+	if err = i.Cancel(); err != nil {
+		t.Fatal("Test Failed - Cancel() error", err)
+	}
+	if len(exch.cancelled) != 1 || exch.cancelled[0] != "child-3" ||
+		i.Status != StatusPartiallyCancelled {
+		t.Error("Test Failed - Cancel() incorrect cancellation", exch.cancelled, i.Status)
+	}
+	if err = i.Cancel(); err != ErrIcebergDone {
+		t.Error("Test Failed - Cancel() expected done error", err)
+	}
+}
+
+func TestIcebergNative(t *testing.T) {
+	exch := &testIcebergExchange{}
+	i, err := NewIceberg(exch, pair.NewCurrencyPair("ETH", "BTC"), exchange.Sell, 10, 2, 0.05)
+	if err != nil {
+		t.Fatal("Test Failed - NewIceberg() error", err)
+	}
+	if !i.Native {
+		t.Fatal("Test Failed - NewIceberg() expected native iceberg")
+	}
+	if err = i.Start(); err != nil {
+		t.Fatal("Test Failed - Start() error", err)
+	}
+	if len(exch.submitted) != 1 || exch.submitted[0] != 10 || exch.visible != 2 {
+		t.Error("Test Failed - Start() incorrect native order", exch.submitted, exch.visible)
+	}
+
+	if err = i.AddFill(Fill{Amount: 4, Price: 0.05}); err != nil {
+		t.Fatal("Test Failed - AddFill() error", err)
+	}
+	if err = i.AddFill(Fill{Amount: 6, Price: 0.05}); err != nil {
+		t.Fatal("Test Failed - AddFill() error", err)
+	}
+	if len(exch.submitted) != 1 || i.Status != StatusFilled || i.Remaining() != 0 {
+		t.Error("Test Failed - AddFill() incorrect native iceberg state", i.Status)
+	}
+}
+
+// testTrailingStopExchange supports native trailing stops by callback rate
+type testTrailingStopExchange struct {
+	testExchange
+	params exchange.TrailingStopParams
+}
+
+func (e *testTrailingStopExchange) SupportsTrailingStop(params exchange.TrailingStopParams) bool {
+	return params.CallbackRate > 0
+}
+
+func (e *testTrailingStopExchange) SubmitTrailingStopOrder(p pair.CurrencyPair, side exchange.OrderSide, amount float64, params exchange.TrailingStopParams, clientID string) (exchange.SubmitOrderResponse, error) {
+	e.submitted = append(e.submitted, amount)
+	e.params = params
+	return exchange.SubmitOrderResponse{IsOrderPlaced: true, OrderID: "trailing"}, nil
+}
+
+func (e *testTrailingStopExchange) CancelTrailingStopOrder(order exchange.OrderCancellation) error {
+	e.cancelled = append(e.cancelled, order.OrderID)
+	return nil
+}
+
+func TestTrailingStopEmulated(t *testing.T) {
+	exch := &testTrailingStopExchange{}
+	p := pair.NewCurrencyPair("BTC", "USDT")
+
+	if _, err := NewTrailingStop(exch, p, exchange.Sell, 1,
+		exchange.TrailingStopParams{CallbackRate: 0.01, TrailValue: 50}); err == nil {
+		t.Error("Test Failed - NewTrailingStop() expected invalid params error")
+	}
+
+	// Trail values are not supported natively by the test exchange
+	ts, err := NewTrailingStop(exch, p, exchange.Sell, 2,
+		exchange.TrailingStopParams{TrailValue: 50, ActivationPrice: 1000})
+	if err != nil {
+		t.Fatal("Test Failed - NewTrailingStop() error", err)
+	}
+	if ts.Native {
+		t.Fatal("Test Failed - NewTrailingStop() expected emulated trailing stop")
+	}
+	if _, err = ts.OnPrice(1000); err != ErrTrailingStopNotStarted {
+		t.Error("Test Failed - OnPrice() expected not started error", err)
+	}
+	if err = ts.Start(); err != nil {
+		t.Fatal("Test Failed - Start() error", err)
+	}
+
+	for _, price := range []float64{900, 1000, 1100, 1060} {
+		triggered, err := ts.OnPrice(price)
+		if err != nil || triggered {
+			t.Fatalf("Test Failed - OnPrice(%v) unexpected trigger %v %v", price, triggered, err)
+		}
+	}
+	if !ts.Activated || ts.BestPrice != 1100 || ts.StopPrice() != 1050 {
+		t.Errorf("Test Failed - OnPrice() unexpected state %v %v %v",
+			ts.Activated, ts.BestPrice, ts.StopPrice())
+	}
+
+	triggered, err := ts.OnPrice(1050)
+	if err != nil || !triggered {
+		t.Fatal("Test Failed - OnPrice() expected trigger", err)
+	}
+	o := ts.Order()
+	if o == nil || o.Type != marketOrder || o.Amount != 2 || ts.Status != StatusTriggered ||
+		len(exch.submitted) != 1 {
+		t.Error("Test Failed - OnPrice() expected market order", o)
+	}
+	if err = ts.Cancel(); err != ErrTrailingStopDone {
+		t.Error("Test Failed - Cancel() expected done error", err)
+	}
+
+	buy, err := NewTrailingStop(&testExchange{}, p, exchange.Buy, 1,
+		exchange.TrailingStopParams{CallbackRate: 0.1})
+	if err != nil {
+		t.Fatal("Test Failed - NewTrailingStop() error", err)
+	}
+	if err = buy.Start(); err != nil {
+		t.Fatal("Test Failed - Start() error", err)
+	}
+	if triggered, _ = buy.OnPrice(100); triggered || buy.StopPrice() != 110 {
+		t.Error("Test Failed - OnPrice() unexpected buy stop price", buy.StopPrice())
+	}
+	if err = buy.Cancel(); err != nil || buy.Status != StatusCancelled {
+		t.Error("Test Failed - Cancel() error", err)
+	}
+	if triggered, _ = buy.OnPrice(200); triggered {
+		t.Error("Test Failed - OnPrice() cancelled stop should not trigger")
+	}
+}
+
+func TestTrailingStopNative(t *testing.T) {
+	exch := &testTrailingStopExchange{}
+	params := exchange.TrailingStopParams{CallbackRate: 0.02}
+	ts, err := NewTrailingStop(exch, pair.NewCurrencyPair("ETH", "USDT"), exchange.Sell, 3, params)
+	if err != nil {
+		t.Fatal("Test Failed - NewTrailingStop() error", err)
+	}
+	if !ts.Native {
+		t.Fatal("Test Failed - NewTrailingStop() expected native trailing stop")
+	}
+	if err = ts.Start(); err != nil {
+		t.Fatal("Test Failed - Start() error", err)
+	}
+	if err = ts.Start(); err != ErrTrailingStopStarted {
+		t.Error("Test Failed - Start() expected started error", err)
+	}
+	if len(exch.submitted) != 1 || exch.params != params || ts.Order().ExchangeOrderID != "trailing" {
+		t.Error("Test Failed - Start() incorrect native order", exch.submitted, exch.params)
+	}
+
+	if triggered, _ := ts.OnPrice(1); triggered {
+		t.Error("Test Failed - OnPrice() native trailing stop should not trigger")
+	}
+	if err = ts.Cancel(); err != nil {
+		t.Fatal("Test Failed - Cancel() error", err)
+	}
+	if len(exch.cancelled) != 1 || exch.cancelled[0] != "trailing" ||
+		ts.Order().Status != StatusCancelled {
+		t.Error("Test Failed - Cancel() incorrect cancellation", exch.cancelled)
+	}
+}
+
+// testScopedExchange cancels all orders of a currency pair natively
+type testScopedExchange struct {
+	testExchange
+	cancelAll []exchange.OrderCancellation
+}
+
+func (e *testScopedExchange) SupportsCancelAllScope(scope exchange.CancelAllScope) bool {
+	return scope.Side == "" && scope.OrderType == "" && scope.MinAge == 0
+}
+
+func (e *testScopedExchange) CancelAllOrders(order exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
+	e.cancelAll = append(e.cancelAll, order)
+	return exchange.CancelAllOrdersResponse{
+		OrderStatus: map[string]string{"S2": "order already closed"},
+	}, nil
+}
+
+func TestCancelAll(t *testing.T) {
+	btc := pair.NewCurrencyPair("BTC", "USD")
+	eth := pair.NewCurrencyPair("ETH", "USD")
+	buy := GetOrderByOrderID(TrackOrder("EngineCancel", "E1", btc, exchange.Buy, exchange.Limit, 1, 100))
+	sell := GetOrderByOrderID(TrackOrder("EngineCancel", "E2", btc, exchange.Sell, exchange.Limit, 1, 200))
+	market := GetOrderByOrderID(TrackOrder("EngineCancel", "E3", btc, exchange.Buy, exchange.Market, 1, 0))
+	other := GetOrderByOrderID(TrackOrder("EngineCancel", "E4", eth, exchange.Buy, exchange.Limit, 1, 10))
+	sell.Created = time.Now().Add(-time.Hour)
+
+	engine := &testExchange{}
+	scoped := &testScopedExchange{}
+	getExchange := func(name string) exchange.IBotExchange {
+		switch name {
+		case "EngineCancel":
+			return engine
+		case "ScopedCancel":
+			return scoped
+		}
+		return nil
+	}
+
+	scope := exchange.CancelAllScope{Pair: btc, Side: exchange.Buy, OrderType: exchange.Limit}
+	r := CancelAll(getExchange, "EngineCancel", scope, true)
+	if !r.DryRun || len(r.Orders) != 1 || r.Orders[0] != buy || len(engine.cancelled) != 0 {
+		t.Fatal("Test Failed - CancelAll() dry run incorrect orders", r.Orders)
+	}
+
+	r = CancelAll(getExchange, "EngineCancel", scope, false)
+	if len(r.Errors) != 0 || len(engine.cancelled) != 1 || engine.cancelled[0] != "E1" ||
+		buy.Status != StatusCancelled || market.Status != StatusNew {
+		t.Error("Test Failed - CancelAll() engine side scope incorrect", engine.cancelled, r.Errors)
+	}
+
+	r = CancelAll(getExchange, "EngineCancel", exchange.CancelAllScope{MinAge: time.Minute}, false)
+	if len(r.Orders) != 1 || r.Orders[0] != sell || sell.Status != StatusCancelled {
+		t.Error("Test Failed - CancelAll() age scope incorrect", r.Orders)
+	}
+
+	s1 := GetOrderByOrderID(TrackOrder("ScopedCancel", "S1", btc, exchange.Buy, exchange.Limit, 1, 100))
+	s2 := GetOrderByOrderID(TrackOrder("ScopedCancel", "S2", btc, exchange.Sell, exchange.Limit, 1, 200))
+	r = CancelAll(getExchange, "", exchange.CancelAllScope{Pair: btc}, false)
+	if len(r.Native) != 1 || r.Native[0] != "ScopedCancel" || len(scoped.cancelAll) != 1 ||
+		!scoped.cancelAll[0].CurrencyPair.Equal(btc, true) {
+		t.Fatal("Test Failed - CancelAll() expected native scoped cancel", r.Native)
+	}
+	if s1.Status != StatusCancelled || s2.Status != StatusNew || r.Errors["S2"] == nil {
+		t.Error("Test Failed - CancelAll() native cancel statuses incorrect", s1.Status, s2.Status)
+	}
+	if market.Status != StatusCancelled || other.Status != StatusNew {
+		t.Error("Test Failed - CancelAll() global scope incorrect", market.Status, other.Status)
+	}
+
+	r = CancelAll(getExchange, "Missing", exchange.CancelAllScope{}, false)
+	if r.Errors["Missing"] == nil {
+		t.Error("Test Failed - CancelAll() expected exchange not found error")
+	}
+}
+
+func TestThrottle(t *testing.T) {
+	exch := &testExchange{}
+	btc := pair.NewCurrencyPair("BTC", "USDT")
+	eth := pair.NewCurrencyPair("ETH", "USDT")
+	ltc := pair.NewCurrencyPair("LTC", "USDT")
+
+	if err := SetThrottle("test", ThrottleLimits{MaxOpenOrders: -1}); err != ErrInvalidThrottleLimits {
+		t.Error("Test Failed - SetThrottle() expected invalid limits error", err)
+	}
+	if _, err := SubmitStrategyOrder("unthrottled", exch, btc, exchange.Buy, exchange.Limit, 1, 100, ""); err != nil {
+		t.Error("Test Failed - SubmitStrategyOrder() unthrottled strategy error", err)
+	}
+
+	err := SetThrottle("test", ThrottleLimits{
+		MaxOrdersPerMinute: 3,
+		MaxOpenOrders:      2,
+		MinPairInterval:    time.Hour,
+	})
+	if err != nil {
+		t.Fatal("Test Failed - SetThrottle() error", err)
+	}
+	defer RemoveThrottle("test")
+	if l, ok := GetThrottle("test"); !ok || l.MaxOpenOrders != 2 {
+		t.Error("Test Failed - GetThrottle() unexpected limits", l)
+	}
+
+	id, err := SubmitStrategyOrder("test", exch, btc, exchange.Buy, exchange.Limit, 1, 100, "")
+	if err != nil {
+		t.Fatal("Test Failed - SubmitStrategyOrder() error", err)
+	}
+	if o := GetOrderByOrderID(id); o == nil || o.Strategy != "test" || o.ExchangeOrderID != "child-2" {
+		t.Error("Test Failed - SubmitStrategyOrder() order not tracked for the strategy")
+	}
+	_, err = SubmitStrategyOrder("test", exch, btc, exchange.Sell, exchange.Limit, 1, 110, "")
+	if err == nil || !strings.Contains(err.Error(), ErrPairIntervalNotElapsed.Error()) {
+		t.Error("Test Failed - SubmitStrategyOrder() expected pair interval error", err)
+	}
+	if len(exch.submitted) != 2 {
+		t.Error("Test Failed - SubmitStrategyOrder() submitted a throttled order")
+	}
+
+	if _, err = SubmitStrategyOrder("test", exch, eth, exchange.Buy, exchange.Limit, 1, 100, ""); err != nil {
+		t.Fatal("Test Failed - SubmitStrategyOrder() error", err)
+	}
+	_, err = SubmitStrategyOrder("test", exch, ltc, exchange.Buy, exchange.Limit, 1, 100, "")
+	if err == nil || !strings.Contains(err.Error(), ErrMaxOpenOrders.Error()) {
+		t.Error("Test Failed - SubmitStrategyOrder() expected max open orders error", err)
+	}
+
+	// Filling an order frees an open order slot, the per minute rate then
+	// applies
+	if err = ApplyFill(id, Fill{Amount: 1, Price: 100}); err != nil {
+		t.Fatal("Test Failed - ApplyFill() error", err)
+	}
+	if _, err = SubmitStrategyOrder("test", exch, ltc, exchange.Buy, exchange.Limit, 1, 100, ""); err != nil {
+		t.Fatal("Test Failed - SubmitStrategyOrder() error", err)
+	}
+	err = AllowOrder("test", exch.GetName(), pair.NewCurrencyPair("XRP", "USDT"))
+	if err == nil || !strings.Contains(err.Error(), ErrOrderRateExceeded.Error()) {
+		t.Error("Test Failed - AllowOrder() expected order rate error", err)
+	}
+}
@@ -0,0 +1,247 @@
+package orders
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// RejectionReason is a normalised reason for an exchange rejecting an order
+type RejectionReason string
+
+// Normalised rejection reasons
+const (
+	RejectUnknown              RejectionReason = "UNKNOWN"
+	RejectBelowMinimumNotional RejectionReason = "BELOW_MINIMUM_NOTIONAL"
+	RejectBelowMinimumAmount   RejectionReason = "BELOW_MINIMUM_AMOUNT"
+	RejectInvalidPrecision     RejectionReason = "INVALID_PRECISION"
+	RejectInsufficientBalance  RejectionReason = "INSUFFICIENT_BALANCE"
+	RejectPriceOutOfBand       RejectionReason = "PRICE_OUT_OF_BAND"
+	RejectRateLimited          RejectionReason = "RATE_LIMITED"
+	RejectInvalidPair          RejectionReason = "INVALID_PAIR"
+	RejectMarketClosed         RejectionReason = "MARKET_CLOSED"
+	RejectAuthentication       RejectionReason = "AUTHENTICATION"
+	RejectExchangeUnavailable  RejectionReason = "EXCHANGE_UNAVAILABLE"
+)
+
+// RetryAdvice tells the caller how a rejected order could be resubmitted
+type RetryAdvice string
+
+// Retry advice values
+const (
+	// DoNotRetry means resubmitting the same order will fail again
+	DoNotRetry RetryAdvice = "DO_NOT_RETRY"
+	// RetryAfterBackoff means the same order can be resubmitted after waiting
+	RetryAfterBackoff RetryAdvice = "RETRY_AFTER_BACKOFF"
+	// RetryWithAdjustedAmount means the amount must be rounded or increased
+	RetryWithAdjustedAmount RetryAdvice = "RETRY_WITH_ADJUSTED_AMOUNT"
+	// RetryWithAdjustedPrice means the price must be moved within the
+	// exchange price band or rounded to the tick size
+	RetryWithAdjustedPrice RetryAdvice = "RETRY_WITH_ADJUSTED_PRICE"
+)
+
+// reasonAdvice maps each rejection reason to its retry advice
+var reasonAdvice = map[RejectionReason]RetryAdvice{
+	RejectUnknown:              DoNotRetry,
+	RejectBelowMinimumNotional: RetryWithAdjustedAmount,
+	RejectBelowMinimumAmount:   RetryWithAdjustedAmount,
+	RejectInvalidPrecision:     RetryWithAdjustedAmount,
+	RejectInsufficientBalance:  DoNotRetry,
+	RejectPriceOutOfBand:       RetryWithAdjustedPrice,
+	RejectRateLimited:          RetryAfterBackoff,
+	RejectInvalidPair:          DoNotRetry,
+	RejectMarketClosed:         DoNotRetry,
+	RejectAuthentication:       DoNotRetry,
+	RejectExchangeUnavailable:  RetryAfterBackoff,
+}
+
+// Rejection is a normalised order rejection, it satisfies the error interface
+// so wrappers can return it in place of the raw exchange error
+type Rejection struct {
+	Exchange  string
+	Reason    RejectionReason
+	Advice    RetryAdvice
+	Retryable bool
+	Raw       string
+}
+
+// Error implements the error interface
+func (r *Rejection) Error() string {
+	return fmt.Sprintf("%s order rejected: %s (%s) - %s",
+		r.Exchange,
+		r.Reason,
+		r.Advice,
+		r.Raw)
+}
+
+// rejectionMatch maps an exchange error message fragment to a reason
+type rejectionMatch struct {
+	match  string
+	reason RejectionReason
+}
+
+// commonRejections are checked for all exchanges after any exchange specific
+// matches
+var commonRejections = []rejectionMatch{
+	{"unsuccessful http status code: 429", RejectRateLimited},
+	{"unsuccessful http status code: 418", RejectRateLimited},
+	{"unsuccessful http status code: 502", RejectExchangeUnavailable},
+	{"unsuccessful http status code: 503", RejectExchangeUnavailable},
+	{"unsuccessful http status code: 504", RejectExchangeUnavailable},
+	{"rate limit", RejectRateLimited},
+	{"too many requests", RejectRateLimited},
+	{"insufficient", RejectInsufficientBalance},
+	{"not enough", RejectInsufficientBalance},
+	{"min_notional", RejectBelowMinimumNotional},
+	{"minimum notional", RejectBelowMinimumNotional},
+	{"min notional", RejectBelowMinimumNotional},
+	{"minimum amount", RejectBelowMinimumAmount},
+	{"minimum order", RejectBelowMinimumAmount},
+	{"precision", RejectInvalidPrecision},
+	{"invalid symbol", RejectInvalidPair},
+	{"invalid signature", RejectAuthentication},
+	{"invalid api", RejectAuthentication},
+	{"timed-out", RejectExchangeUnavailable},
+}
+
+// exchangeRejection holds an exchange's error codes, matched exactly against
+// the code parsed from an error, and error message fragments
+type exchangeRejection struct {
+	codes    map[string]RejectionReason
+	messages []rejectionMatch
+}
+
+// exchangeRejections holds exchange specific error codes and messages, keyed
+// by lower case exchange name
+var exchangeRejections = map[string]exchangeRejection{
+	"binance": {
+		codes: map[string]RejectionReason{
+			"-1003": RejectRateLimited,
+			"-1015": RejectRateLimited,
+			"-1121": RejectInvalidPair,
+			"-1022": RejectAuthentication,
+			"-2014": RejectAuthentication,
+			"-2015": RejectAuthentication,
+			"-2010": RejectInsufficientBalance,
+		},
+		messages: []rejectionMatch{
+			{"filter failure: min_notional", RejectBelowMinimumNotional},
+			{"filter failure: lot_size", RejectInvalidPrecision},
+			{"filter failure: price_filter", RejectPriceOutOfBand},
+			{"filter failure: percent_price", RejectPriceOutOfBand},
+			{"market is closed", RejectMarketClosed},
+		},
+	},
+	"huobi": {
+		messages: []rejectionMatch{
+			{"order-value-min-error", RejectBelowMinimumNotional},
+			{"order-orderamount-precision-error", RejectInvalidPrecision},
+			{"order-orderprice-precision-error", RejectInvalidPrecision},
+			{"order-limitorder-amount-min-error", RejectBelowMinimumAmount},
+			{"order-marketorder-amount-min-error", RejectBelowMinimumAmount},
+			{"order-limitorder-price-min-error", RejectPriceOutOfBand},
+			{"order-limitorder-price-max-error", RejectPriceOutOfBand},
+			{"account-frozen-balance-insufficient-error", RejectInsufficientBalance},
+			{"account-balance-insufficient-error", RejectInsufficientBalance},
+			{"insufficient-balance", RejectInsufficientBalance},
+			{"base-symbol-error", RejectInvalidPair},
+			{"base-symbol-trade-disabled", RejectMarketClosed},
+			{"api-signature-not-valid", RejectAuthentication},
+			{"login-required", RejectAuthentication},
+		},
+	},
+	"bithumb": {
+		codes: map[string]RejectionReason{
+			"5100": RejectAuthentication,
+			"5300": RejectAuthentication,
+			"5600": RejectUnknown,
+			"5900": RejectExchangeUnavailable,
+		},
+	},
+	"okx": {
+		codes: map[string]RejectionReason{
+			"50011": RejectRateLimited,
+			"50013": RejectExchangeUnavailable,
+			"50111": RejectAuthentication,
+			"50113": RejectAuthentication,
+			"51001": RejectInvalidPair,
+			"51006": RejectPriceOutOfBand,
+			"51008": RejectInsufficientBalance,
+			"51020": RejectBelowMinimumAmount,
+			"51121": RejectInvalidPrecision,
+		},
+	},
+}
+
+// errorCode matches the exchange error code field wrappers include in their
+// errors, such as "error code 51008: ..." or "error Code:5100 Message:...",
+// or the code of a raw JSON error response
+var errorCode = regexp.MustCompile(`(?:error code|"code")\s*:?\s*"?(-?\d+)\b`)
+
+// ErrorCode returns the exchange error code carried by an error message, or
+// an empty string when it has none
+func ErrorCode(msg string) string {
+	m := errorCode.FindStringSubmatch(strings.ToLower(msg))
+	if m == nil {
+		return ""
+	}
+	return m[1]
+}
+
+// RejectionReasonFromError returns the normalised reason for a raw exchange
+// error, RejectUnknown is returned when no mapping matches. Exchange error
+// codes are matched on the code field of the error, so a code appearing in
+// an amount or order ID is not mistaken for it.
+func RejectionReasonFromError(exchName string, err error) RejectionReason {
+	if err == nil {
+		return ""
+	}
+
+	msg := strings.ToLower(err.Error())
+	rejections := exchangeRejections[strings.ToLower(exchName)]
+	if reason, ok := rejections.codes[ErrorCode(msg)]; ok && reason != RejectUnknown {
+		return reason
+	}
+	// known codes with a generic meaning fall through to the message based
+	// matching
+	for _, m := range rejections.messages {
+		if strings.Contains(msg, m.match) {
+			return m.reason
+		}
+	}
+
+	for _, m := range commonRejections {
+		if strings.Contains(msg, m.match) {
+			return m.reason
+		}
+	}
+	return RejectUnknown
+}
+
+// NormaliseRejection converts a raw order submission error into a Rejection.
+// Errors that are already a Rejection are returned as is.
+func NormaliseRejection(exchName string, err error) *Rejection {
+	if err == nil {
+		return nil
+	}
+
+	if r, ok := err.(*Rejection); ok {
+		return r
+	}
+
+	reason := RejectionReasonFromError(exchName, err)
+	advice := reasonAdvice[reason]
+	return &Rejection{
+		Exchange:  exchName,
+		Reason:    reason,
+		Advice:    advice,
+		Retryable: advice != DoNotRetry,
+		Raw:       err.Error(),
+	}
+}
+
+// IsRetryable returns whether a raw order error is considered retryable
+func IsRetryable(exchName string, err error) bool {
+	r := NormaliseRejection(exchName, err)
+	return r != nil && r.Retryable
+}
@@ -0,0 +1,336 @@
+package orders
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+)
+
+// Errors returned by the reservation layer
+var (
+	ErrInsufficientFunds  = errors.New("insufficient unreserved funds")
+	ErrBalanceStale       = errors.New("cached balance is stale and requires a refresh")
+	ErrBalanceNotFound    = errors.New("no cached balance found")
+	ErrReservationExists  = errors.New("reservation already exists for order")
+	ErrReservationMissing = errors.New("reservation not found for order")
+	ErrInvalidAmount      = errors.New("amount must be greater than zero")
+)
+
+// DefaultBalanceTTL is the default period a cached balance is considered
+// valid before a fresh GetAccountInfo call is required
+const DefaultBalanceTTL = time.Minute
+
+// balance holds a cached exchange balance and outstanding reservations
+type balance struct {
+	available    float64
+	lastUpdated  time.Time
+	reservations map[string]float64
+}
+
+// reserved returns the total amount reserved across all orders
+func (b *balance) reserved() float64 {
+	var total float64
+	for _, v := range b.reservations {
+		total += v
+	}
+	return total
+}
+
+// Reservations tracks locally reserved funds per exchange and currency so
+// concurrent strategies cannot oversubscribe the same balance between account
+// info refreshes
+type Reservations struct {
+	TTL      time.Duration
+	balances map[string]map[string]*balance
+	m        sync.Mutex
+}
+
+// Reserved is the shared reservation store used by the bot
+var Reserved = NewReservations(DefaultBalanceTTL)
+
+// NewReservations returns a new reservation store with the supplied balance
+// time to live
+func NewReservations(ttl time.Duration) *Reservations {
+	if ttl <= 0 {
+		ttl = DefaultBalanceTTL
+	}
+	return &Reservations{
+		TTL:      ttl,
+		balances: make(map[string]map[string]*balance),
+	}
+}
+
+// getBalance returns the balance entry, creating it if required. The mutex
+// must be held by the caller.
+func (r *Reservations) getBalance(exchName, currency string, create bool) *balance {
+	exchName = strings.ToLower(exchName)
+	currency = strings.ToUpper(currency)
+
+	c, ok := r.balances[exchName]
+	if !ok {
+		if !create {
+			return nil
+		}
+		c = make(map[string]*balance)
+		r.balances[exchName] = c
+	}
+
+	b, ok := c[currency]
+	if !ok {
+		if !create {
+			return nil
+		}
+		b = &balance{reservations: make(map[string]float64)}
+		c[currency] = b
+	}
+	return b
+}
+
+// UpdateBalance sets the cached available balance for an exchange currency,
+// usually from a fresh GetAccountInfo call. Existing reservations are kept
+// as the exchange balance does not yet reflect unsent orders.
+func (r *Reservations) UpdateBalance(exchName, currency string, available float64) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	b := r.getBalance(exchName, currency, true)
+	b.available = available
+	b.lastUpdated = time.Now()
+}
+
+// IsStale returns whether the cached balance has expired or is missing
+func (r *Reservations) IsStale(exchName, currency string) bool {
+	r.m.Lock()
+	defer r.m.Unlock()
+	b := r.getBalance(exchName, currency, false)
+	return b == nil || time.Since(b.lastUpdated) > r.TTL
+}
+
+// Available returns the cached balance minus outstanding reservations
+func (r *Reservations) Available(exchName, currency string) (float64, error) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	b := r.getBalance(exchName, currency, false)
+	if b == nil {
+		return 0, ErrBalanceNotFound
+	}
+	if time.Since(b.lastUpdated) > r.TTL {
+		return b.available - b.reserved(), ErrBalanceStale
+	}
+	return b.available - b.reserved(), nil
+}
+
+// Reserve performs a pre-flight balance check and reserves the required
+// amount against the supplied order ID
+func (r *Reservations) Reserve(exchName, currency, orderID string, amount float64) error {
+	if amount <= 0 {
+		return ErrInvalidAmount
+	}
+
+	r.m.Lock()
+	defer r.m.Unlock()
+	b := r.getBalance(exchName, currency, false)
+	if b == nil {
+		return ErrBalanceNotFound
+	}
+	if time.Since(b.lastUpdated) > r.TTL {
+		return ErrBalanceStale
+	}
+	if _, ok := b.reservations[orderID]; ok {
+		return ErrReservationExists
+	}
+	if free := b.available - b.reserved(); free < amount {
+		return fmt.Errorf("%s %s: %s requested %f available %f",
+			exchName,
+			currency,
+			ErrInsufficientFunds,
+			amount,
+			free)
+	}
+	b.reservations[orderID] = amount
+	return nil
+}
+
+// Rename moves a reservation to a new order ID, used when a reservation is
+// taken against a client ID before the exchange order ID is known
+func (r *Reservations) Rename(exchName, currency, oldID, newID string) error {
+	r.m.Lock()
+	defer r.m.Unlock()
+	b := r.getBalance(exchName, currency, false)
+	if b == nil {
+		return ErrReservationMissing
+	}
+	amount, ok := b.reservations[oldID]
+	if !ok {
+		return ErrReservationMissing
+	}
+	delete(b.reservations, oldID)
+	b.reservations[newID] = amount
+	return nil
+}
+
+// Release removes the remaining reservation for an order, used when an order
+// is cancelled or rejected
+func (r *Reservations) Release(exchName, currency, orderID string) error {
+	r.m.Lock()
+	defer r.m.Unlock()
+	b := r.getBalance(exchName, currency, false)
+	if b == nil {
+		return ErrReservationMissing
+	}
+	if _, ok := b.reservations[orderID]; !ok {
+		return ErrReservationMissing
+	}
+	delete(b.reservations, orderID)
+	return nil
+}
+
+// Fill consumes part of an order reservation on a fill. The cached balance is
+// reduced by the same amount so it stays accurate until the next refresh.
+// The reservation is removed once fully consumed.
+func (r *Reservations) Fill(exchName, currency, orderID string, amount float64) error {
+	if amount <= 0 {
+		return ErrInvalidAmount
+	}
+
+	r.m.Lock()
+	defer r.m.Unlock()
+	b := r.getBalance(exchName, currency, false)
+	if b == nil {
+		return ErrReservationMissing
+	}
+	reserved, ok := b.reservations[orderID]
+	if !ok {
+		return ErrReservationMissing
+	}
+	if amount > reserved {
+		amount = reserved
+	}
+
+	b.available -= amount
+	if reserved-amount <= 0 {
+		delete(b.reservations, orderID)
+		return nil
+	}
+	b.reservations[orderID] = reserved - amount
+	return nil
+}
+
+// GetReservation returns the outstanding reserved amount for an order
+func (r *Reservations) GetReservation(exchName, currency, orderID string) float64 {
+	r.m.Lock()
+	defer r.m.Unlock()
+	b := r.getBalance(exchName, currency, false)
+	if b == nil {
+		return 0
+	}
+	return b.reservations[orderID]
+}
+
+// RequiredFunds returns the currency and amount that must be available to
+// place an order. Buys require the quote currency, sells the base currency.
+func RequiredFunds(buy bool, baseCurrency, quoteCurrency string, amount, price float64) (string, float64) {
+	if buy {
+		return quoteCurrency, amount * price
+	}
+	return baseCurrency, amount
+}
+
+// pendingReservations numbers the reservations taken before an order is sent
+var pendingReservations int64
+
+// SubmissionFunds returns the currency and amount that must be available to
+// place an order submission. Orders sized by an amount which cannot be
+// converted without a price, such as market sells sized by quote amount,
+// return a zero amount.
+func SubmissionFunds(o exchange.OrderSubmission) (string, float64) {
+	buy := o.Side == exchange.Buy
+	base, quote := o.Pair.FirstCurrency.String(), o.Pair.SecondCurrency.String()
+	if buy && o.QuoteAmount > 0 {
+		return quote, o.QuoteAmount
+	}
+	amount := o.BaseAmount
+	if amount == 0 && o.Price > 0 {
+		amount = o.QuoteAmount / o.Price
+	}
+	return RequiredFunds(buy, base, quote, amount, o.Price)
+}
+
+// ReserveSubmission reserves the funds required by an order submission before
+// it is sent against a pending ID, which is renamed once the order is placed
+// with HoldReservation or released if it is rejected. Orders whose required
+// funds are unknown are not reserved and return an empty currency.
+func (r *Reservations) ReserveSubmission(exchName string, o exchange.OrderSubmission) (string, string, error) {
+	currency, amount := SubmissionFunds(o)
+	if amount <= 0 {
+		return "", "", nil
+	}
+	pendingID := "pending-" + strconv.FormatInt(atomic.AddInt64(&pendingReservations, 1), 10)
+	return currency, pendingID, r.Reserve(exchName, currency, pendingID, amount)
+}
+
+// HoldReservation moves the reservation taken for the order before it was
+// sent to the order, keyed by its exchange order ID. The reservation is then
+// consumed by the order's fills and released once it closes.
+func (o *Order) HoldReservation(currency, pendingID string) error {
+	o.m.Lock()
+	defer o.m.Unlock()
+	err := Reserved.Rename(o.Exchange, currency, pendingID, o.reservationID())
+	if err != nil {
+		return err
+	}
+	o.reserved = currency
+	return nil
+}
+
+// reservationID returns the ID the order's reservation is held under. The
+// mutex must be held by the caller.
+func (o *Order) reservationID() string {
+	if o.ExchangeOrderID != "" {
+		return o.ExchangeOrderID
+	}
+	return "order-" + strconv.Itoa(o.OrderID)
+}
+
+// fillReservation consumes the order's reservation by a fill of amount with
+// notional value in the quote currency. The mutex must be held by the caller.
+func (o *Order) fillReservation(amount, notional float64) {
+	if o.reserved == "" {
+		return
+	}
+	if o.Side == exchange.Buy {
+		amount = notional
+	}
+	if amount > 0 {
+		Reserved.Fill(o.Exchange, o.reserved, o.reservationID(), amount)
+	}
+}
+
+// settleReservation releases the remainder of the order's reservation once it
+// is no longer open. The mutex must be held by the caller.
+func (o *Order) settleReservation() {
+	if o.reserved == "" || o.Status == StatusNew || o.Status == StatusPartiallyFilled {
+		return
+	}
+	Reserved.Release(o.Exchange, o.reserved, o.reservationID())
+	o.reserved = ""
+}
+
+// ReleaseReservation releases the remainder of the order's reservation while
+// it is still open, for orders whose fills can no longer be tracked. It
+// returns whether the order held a reservation.
+func (o *Order) ReleaseReservation() bool {
+	o.m.Lock()
+	defer o.m.Unlock()
+	if o.reserved == "" {
+		return false
+	}
+	Reserved.Release(o.Exchange, o.reserved, o.reservationID())
+	o.reserved = ""
+	return true
+}
@@ -0,0 +1,162 @@
+package orders
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+)
+
+// Errors returned when a strategy order is throttled
+var (
+	ErrOrderRateExceeded      = errors.New("strategy order rate limit exceeded")
+	ErrMaxOpenOrders          = errors.New("strategy maximum open orders reached")
+	ErrPairIntervalNotElapsed = errors.New("strategy minimum interval between orders on the pair has not elapsed")
+	ErrInvalidThrottleLimits  = errors.New("order throttle limits cannot be negative")
+)
+
+// throttleWindow is the window MaxOrdersPerMinute is measured over
+const throttleWindow = time.Minute
+
+// ThrottleLimits restricts the orders a strategy places independently of the
+// exchange rate limits. Zero values are not enforced.
+type ThrottleLimits struct {
+	MaxOrdersPerMinute int
+	MaxOpenOrders      int
+	MinPairInterval    time.Duration
+}
+
+// Validate checks the throttle limits
+func (l *ThrottleLimits) Validate() error {
+	if l.MaxOrdersPerMinute < 0 || l.MaxOpenOrders < 0 || l.MinPairInterval < 0 {
+		return ErrInvalidThrottleLimits
+	}
+	return nil
+}
+
+// throttle holds a strategy's limits and recent submissions
+type throttle struct {
+	limits    ThrottleLimits
+	submitted []time.Time
+	lastPair  map[string]time.Time
+}
+
+var (
+	throttles  = make(map[string]*throttle)
+	throttlesM sync.Mutex
+)
+
+// SetThrottle sets the order throttle limits of a strategy, replacing any
+// previous limits
+func SetThrottle(strategy string, limits ThrottleLimits) error {
+	if err := limits.Validate(); err != nil {
+		return err
+	}
+	throttlesM.Lock()
+	defer throttlesM.Unlock()
+	t, ok := throttles[strategy]
+	if !ok {
+		t = &throttle{lastPair: make(map[string]time.Time)}
+		throttles[strategy] = t
+	}
+	t.limits = limits
+	return nil
+}
+
+// RemoveThrottle removes the order throttle limits of a strategy
+func RemoveThrottle(strategy string) {
+	throttlesM.Lock()
+	delete(throttles, strategy)
+	throttlesM.Unlock()
+}
+
+// GetThrottle returns the order throttle limits of a strategy
+func GetThrottle(strategy string) (ThrottleLimits, bool) {
+	throttlesM.Lock()
+	defer throttlesM.Unlock()
+	t, ok := throttles[strategy]
+	if !ok {
+		return ThrottleLimits{}, false
+	}
+	return t.limits, true
+}
+
+// AllowOrder checks a strategy may place an order on an exchange currency
+// pair and records the order against its limits when it may. Strategies
+// without limits are always allowed.
+func AllowOrder(strategy, exchName string, p pair.CurrencyPair) error {
+	throttlesM.Lock()
+	defer throttlesM.Unlock()
+	t, ok := throttles[strategy]
+	if !ok {
+		return nil
+	}
+
+	now := time.Now()
+	i := 0
+	for i < len(t.submitted) && now.Sub(t.submitted[i]) >= throttleWindow {
+		i++
+	}
+	t.submitted = t.submitted[i:]
+
+	if t.limits.MaxOrdersPerMinute > 0 && len(t.submitted) >= t.limits.MaxOrdersPerMinute {
+		return fmt.Errorf("%s %s: %d per minute", strategy, ErrOrderRateExceeded,
+			t.limits.MaxOrdersPerMinute)
+	}
+	if t.limits.MaxOpenOrders > 0 {
+		open := openStrategyOrders(strategy)
+		if open >= t.limits.MaxOpenOrders {
+			return fmt.Errorf("%s %s: %d open", strategy, ErrMaxOpenOrders, open)
+		}
+	}
+	key := strings.ToLower(exchName) + " " + p.Pair().Upper().String()
+	if last, ok := t.lastPair[key]; ok && t.limits.MinPairInterval > 0 &&
+		now.Sub(last) < t.limits.MinPairInterval {
+		return fmt.Errorf("%s %s: %s %s", strategy, ErrPairIntervalNotElapsed,
+			exchName, p.Pair())
+	}
+
+	t.submitted = append(t.submitted, now)
+	t.lastPair[key] = now
+	return nil
+}
+
+// SubmitStrategyOrder submits an order on behalf of a strategy once its
+// throttle limits allow it and tracks the placed order in the order manager,
+// returning the local order ID
+func SubmitStrategyOrder(strategy string, exch exchange.IBotExchange, p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (int, error) {
+	err := exchange.CheckOrderSubmission(exch.GetName())
+	if err != nil {
+		return 0, err
+	}
+	err = AllowOrder(strategy, exch.GetName(), p)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := exch.SubmitOrder(p, side, orderType, amount, price, clientID)
+	if err != nil {
+		return 0, err
+	}
+	if !resp.IsOrderPlaced {
+		return 0, fmt.Errorf("%s %s order was not placed", exch.GetName(), side)
+	}
+
+	return TrackStrategyOrder(strategy, exch.GetName(), resp.OrderID, p, side, orderType, amount, price), nil
+}
+
+// openStrategyOrders returns the number of a strategy's tracked orders which
+// are not yet filled or cancelled
+func openStrategyOrders(strategy string) int {
+	var open int
+	for _, o := range Orders.All() {
+		if o.Strategy == strategy && o.IsOpen() {
+			open++
+		}
+	}
+	return open
+}
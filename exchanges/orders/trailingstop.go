@@ -0,0 +1,222 @@
+package orders
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+)
+
+// StatusTriggered is the status of an emulated trailing stop which has
+// placed its market order
+const StatusTriggered = "TRIGGERED"
+
+// Errors returned by trailing stop orders
+var (
+	ErrTrailingStopNotStarted = errors.New("trailing stop has not been started")
+	ErrTrailingStopStarted    = errors.New("trailing stop has already been started")
+	ErrTrailingStopDone       = errors.New("trailing stop is triggered or cancelled")
+)
+
+// TrailingStop places a market order once the price retraces from its best
+// level since activation. A sell stop trails below the highest price and a
+// buy stop trails above the lowest price. When the exchange supports a native
+// trailing stop with the parameters it is placed on the exchange, otherwise
+// the engine tracks the price through OnPrice and places the market order
+// itself.
+type TrailingStop struct {
+	Exchange  string
+	Pair      pair.CurrencyPair
+	Side      exchange.OrderSide
+	Amount    float64
+	Params    exchange.TrailingStopParams
+	Native    bool
+	Status    string
+	Activated bool
+	BestPrice float64
+
+	exch    exchange.IBotExchange
+	started bool
+	order   *Order
+	m       sync.Mutex
+}
+
+// NewTrailingStop returns a new trailing stop. Native is set when the
+// exchange supports a native trailing stop with the parameters and can be
+// cleared before Start to force engine side emulation.
+func NewTrailingStop(exch exchange.IBotExchange, p pair.CurrencyPair, side exchange.OrderSide, amount float64, params exchange.TrailingStopParams) (*TrailingStop, error) {
+	if amount <= 0 {
+		return nil, ErrInvalidAmount
+	}
+	if side != exchange.Buy && side != exchange.Sell {
+		return nil, fmt.Errorf("unsupported order side %s", side)
+	}
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	native := false
+	if t, ok := exch.(exchange.TrailingStopOrderSubmitter); ok {
+		native = t.SupportsTrailingStop(params)
+	}
+	return &TrailingStop{
+		Exchange:  exch.GetName(),
+		Pair:      p,
+		Side:      side,
+		Amount:    amount,
+		Params:    params,
+		Native:    native,
+		Status:    StatusNew,
+		Activated: params.ActivationPrice == 0,
+		exch:      exch,
+	}, nil
+}
+
+// Start places the native trailing stop or begins emulating it
+func (t *TrailingStop) Start() error {
+	t.m.Lock()
+	defer t.m.Unlock()
+
+	if t.started {
+		return ErrTrailingStopStarted
+	}
+	if t.Native {
+		resp, err := t.exch.(exchange.TrailingStopOrderSubmitter).SubmitTrailingStopOrder(t.Pair,
+			t.Side, t.Amount, t.Params, "")
+		if err != nil {
+			return err
+		}
+		if err = t.track(resp); err != nil {
+			return err
+		}
+	}
+	t.started = true
+	return nil
+}
+
+// OnPrice updates an emulated trailing stop with the latest price and places
+// the market order if the stop is hit, returning whether it triggered. Native
+// trailing stops are tracked by the exchange and ignore price updates.
+func (t *TrailingStop) OnPrice(price float64) (bool, error) {
+	t.m.Lock()
+	defer t.m.Unlock()
+
+	if !t.started {
+		return false, ErrTrailingStopNotStarted
+	}
+	if t.Native || t.Status != StatusNew || price <= 0 {
+		return false, nil
+	}
+
+	if !t.Activated {
+		if (t.Side == exchange.Sell && price < t.Params.ActivationPrice) ||
+			(t.Side == exchange.Buy && price > t.Params.ActivationPrice) {
+			return false, nil
+		}
+		t.Activated = true
+	}
+
+	if t.BestPrice == 0 ||
+		(t.Side == exchange.Sell && price > t.BestPrice) ||
+		(t.Side == exchange.Buy && price < t.BestPrice) {
+		t.BestPrice = price
+	}
+
+	stop := t.stopPrice()
+	if (t.Side == exchange.Sell && price > stop) ||
+		(t.Side == exchange.Buy && price < stop) {
+		return false, nil
+	}
+
+	resp, err := exchange.SubmitOrderRequest(t.exch, exchange.OrderSubmission{
+		Pair:       t.Pair,
+		Side:       t.Side,
+		Type:       exchange.Market,
+		BaseAmount: t.Amount,
+	})
+	if err != nil {
+		return false, err
+	}
+	if err = t.track(resp); err != nil {
+		return false, err
+	}
+	t.Status = StatusTriggered
+	return true, nil
+}
+
+// StopPrice returns the price at which an emulated trailing stop triggers,
+// zero until it has been activated and received a price
+func (t *TrailingStop) StopPrice() float64 {
+	t.m.Lock()
+	defer t.m.Unlock()
+	if t.BestPrice == 0 {
+		return 0
+	}
+	return t.stopPrice()
+}
+
+// Order returns the order placed for the trailing stop, the native order or
+// the market order placed when an emulated stop triggered, or nil
+func (t *TrailingStop) Order() *Order {
+	t.m.Lock()
+	defer t.m.Unlock()
+	return t.order
+}
+
+// Cancel cancels the native trailing stop on the exchange or stops emulating
+// it
+func (t *TrailingStop) Cancel() error {
+	t.m.Lock()
+	defer t.m.Unlock()
+
+	if !t.started {
+		return ErrTrailingStopNotStarted
+	}
+	if t.Status != StatusNew {
+		return ErrTrailingStopDone
+	}
+
+	if t.Native {
+		o := t.order
+		err := t.exch.(exchange.TrailingStopOrderSubmitter).CancelTrailingStopOrder(exchange.OrderCancellation{
+			OrderID:      o.ExchangeOrderID,
+			CurrencyPair: t.Pair,
+			Side:         t.Side,
+		})
+		if err != nil {
+			return err
+		}
+		o.m.Lock()
+		o.Amount = o.FilledAmount
+		o.Status = StatusCancelled
+		o.m.Unlock()
+	}
+	t.Status = StatusCancelled
+	return nil
+}
+
+// stopPrice returns the trigger price from the best price. The mutex must be
+// held by the caller.
+func (t *TrailingStop) stopPrice() float64 {
+	trail := t.Params.TrailValue
+	if t.Params.CallbackRate > 0 {
+		trail = t.BestPrice * t.Params.CallbackRate
+	}
+	if t.Side == exchange.Sell {
+		return t.BestPrice - trail
+	}
+	return t.BestPrice + trail
+}
+
+// track adds a placed order to the order manager. The mutex must be held by
+// the caller.
+func (t *TrailingStop) track(resp exchange.SubmitOrderResponse) error {
+	if !resp.IsOrderPlaced {
+		return fmt.Errorf("%s trailing stop order was not placed", t.Exchange)
+	}
+	t.order = GetOrderByOrderID(TrackOrder(t.Exchange, resp.OrderID, t.Pair,
+		t.Side, exchange.Market, t.Amount, 0))
+	return nil
+}
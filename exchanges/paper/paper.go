@@ -0,0 +1,496 @@
+// Package paper is an in-memory matching engine for paper trading. Orders
+// are matched against the live orderbook and ticker data stored for the
+// exchange, filling against simulated balances so strategies can be dry-run
+// on real market data without risking funds.
+package paper
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/quality"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+// Order statuses
+const (
+	StatusOpen            = "OPEN"
+	StatusPartiallyFilled = "PARTIALLY_FILLED"
+	StatusFilled          = "FILLED"
+	StatusCancelled       = "CANCELLED"
+)
+
+// Errors returned by the matching engine
+var (
+	ErrOrderNotFound       = errors.New("paper order not found")
+	ErrOrderClosed         = errors.New("paper order is no longer open")
+	ErrInvalidOrder        = errors.New("paper order requires an amount and a limit price greater than zero")
+	ErrInsufficientBalance = errors.New("insufficient paper balance")
+	ErrNoMarketData        = errors.New("no market data to match paper order")
+)
+
+// Order is a paper order. Amount is in the base currency and Price is the
+// limit price, ignored for market orders. Fee is the total fee paid in the
+// quote currency.
+type Order struct {
+	ID               string
+	ClientID         string
+	Pair             pair.CurrencyPair
+	AssetType        string
+	Buy              bool
+	Market           bool
+	Amount           float64
+	Price            float64
+	FilledAmount     float64
+	AverageFillPrice float64
+	Fee              float64
+	Status           string
+	Created          time.Time
+	Fills            []Fill
+}
+
+// Fill is an execution of a paper order
+type Fill struct {
+	Amount float64
+	Price  float64
+	Fee    float64
+	Maker  bool
+	Time   time.Time
+}
+
+// Balance is a paper currency balance, Hold is reserved by open orders
+type Balance struct {
+	Currency string
+	Total    float64
+	Hold     float64
+}
+
+// MarketData returns the orderbook to match orders against, the default
+// reads the orderbook and ticker stores populated by the bot
+type MarketData func(p pair.CurrencyPair, assetType string) (orderbook.Base, error)
+
+// Engine matches paper orders for a single exchange. MakerFee and TakerFee
+// are percentages, as stored on exchange.Base.
+type Engine struct {
+	ExchangeName string
+	MakerFee     float64
+	TakerFee     float64
+	Data         MarketData
+
+	balances map[string]*Balance
+	orders   map[string]*Order
+	nextID   int64
+	m        sync.Mutex
+}
+
+// NewEngine returns a matching engine for an exchange funded with balances
+// keyed by currency code
+func NewEngine(exchangeName string, balances map[string]float64) *Engine {
+	e := &Engine{
+		ExchangeName: exchangeName,
+		balances:     make(map[string]*Balance),
+		orders:       make(map[string]*Order),
+	}
+	e.Data = e.storedMarketData
+	for currency, amount := range balances {
+		currency = strings.ToUpper(currency)
+		e.balances[currency] = &Balance{Currency: currency, Total: amount}
+	}
+	return e
+}
+
+// Submit places a paper order. Market orders and marketable limit orders
+// fill against the book as the taker, the rest of a limit order rests until
+// Match fills it.
+func (e *Engine) Submit(o Order) (Order, error) {
+	if o.Amount <= 0 || (!o.Market && o.Price <= 0) {
+		return Order{}, ErrInvalidOrder
+	}
+	book, err := e.Data(o.Pair, o.AssetType)
+	if err != nil {
+		return Order{}, err
+	}
+
+	e.m.Lock()
+	defer e.m.Unlock()
+
+	// Market orders are reserved at the worst price they can fill at
+	reservePrice := o.Price
+	if o.Market {
+		reservePrice = worstPrice(&book, o.Buy, o.Amount)
+		if reservePrice == 0 {
+			return Order{}, ErrNoMarketData
+		}
+	}
+	err = e.reserve(&o, o.Amount, reservePrice)
+	if err != nil {
+		return Order{}, err
+	}
+
+	e.nextID++
+	o.ID = strconv.FormatInt(e.nextID, 10)
+	o.Status = StatusOpen
+	o.Created = time.Now()
+	o.FilledAmount, o.AverageFillPrice, o.Fee, o.Fills = 0, 0, 0, nil
+	order := &o
+	e.orders[o.ID] = order
+
+	e.take(order, &book, reservePrice)
+	if order.Market && order.Status != StatusFilled {
+		// Unfilled market volume is cancelled rather than left resting
+		e.release(order, order.Amount-order.FilledAmount, reservePrice)
+		order.Status = StatusCancelled
+	}
+	return copyOrder(order), nil
+}
+
+// Cancel cancels an open paper order, releasing its reserved balance
+func (e *Engine) Cancel(id string) error {
+	e.m.Lock()
+	defer e.m.Unlock()
+	o, ok := e.orders[id]
+	if !ok {
+		return ErrOrderNotFound
+	}
+	if !open(o) {
+		return ErrOrderClosed
+	}
+	e.release(o, o.Amount-o.FilledAmount, o.Price)
+	o.Status = StatusCancelled
+	return nil
+}
+
+// CancelAll cancels every open paper order of a currency pair, or all open
+// orders when the pair is empty, and returns the cancelled order IDs
+func (e *Engine) CancelAll(p pair.CurrencyPair) []string {
+	var ids []string
+	for _, o := range e.OpenOrders() {
+		if !p.Empty() && !o.Pair.Equal(p, false) {
+			continue
+		}
+		if e.Cancel(o.ID) == nil {
+			ids = append(ids, o.ID)
+		}
+	}
+	return ids
+}
+
+// Modify changes the price and amount of an open limit order, zero values
+// keep the current value. The order is cancelled and replaced with a new
+// order ID, as exchanges do when an order's price changes. The original
+// order stays cancelled when the replacement is rejected.
+func (e *Engine) Modify(id string, price, amount float64) (Order, error) {
+	e.m.Lock()
+	o, ok := e.orders[id]
+	if !ok {
+		e.m.Unlock()
+		return Order{}, ErrOrderNotFound
+	}
+	if !open(o) || o.Market {
+		e.m.Unlock()
+		return Order{}, ErrOrderClosed
+	}
+	replacement := Order{
+		ClientID:  o.ClientID,
+		Pair:      o.Pair,
+		AssetType: o.AssetType,
+		Buy:       o.Buy,
+		Amount:    o.Amount - o.FilledAmount,
+		Price:     o.Price,
+	}
+	e.m.Unlock()
+
+	if price > 0 {
+		replacement.Price = price
+	}
+	if amount > 0 {
+		replacement.Amount = amount
+	}
+	err := e.Cancel(id)
+	if err != nil {
+		return Order{}, err
+	}
+	return e.Submit(replacement)
+}
+
+// Match fills resting limit orders against the latest market data, it is
+// called whenever the exchange's ticker or orderbook is updated
+func (e *Engine) Match() {
+	for _, o := range e.OpenOrders() {
+		book, err := e.Data(o.Pair, o.AssetType)
+		if err != nil {
+			continue
+		}
+		e.m.Lock()
+		order := e.orders[o.ID]
+		if open(order) {
+			e.make(order, &book)
+		}
+		e.m.Unlock()
+	}
+}
+
+// Price returns the best opposite side price an order on the side would
+// fill at
+func (e *Engine) Price(p pair.CurrencyPair, assetType string, buy bool) (float64, error) {
+	book, err := e.Data(p, assetType)
+	if err != nil {
+		return 0, err
+	}
+	levels := book.Asks
+	if !buy {
+		levels = book.Bids
+	}
+	if len(levels) == 0 || levels[0].Price <= 0 {
+		return 0, fmt.Errorf("%s %s %s", e.ExchangeName, p.Pair(), ErrNoMarketData)
+	}
+	return levels[0].Price, nil
+}
+
+// Order returns a paper order by ID
+func (e *Engine) Order(id string) (Order, error) {
+	e.m.Lock()
+	defer e.m.Unlock()
+	o, ok := e.orders[id]
+	if !ok {
+		return Order{}, ErrOrderNotFound
+	}
+	return copyOrder(o), nil
+}
+
+// OpenOrders returns the open paper orders, oldest first
+func (e *Engine) OpenOrders() []Order {
+	return e.list(open)
+}
+
+// Orders returns every paper order including filled and cancelled orders,
+// oldest first
+func (e *Engine) Orders() []Order {
+	return e.list(func(*Order) bool { return true })
+}
+
+// list returns the paper orders matching include, oldest first
+func (e *Engine) list(include func(o *Order) bool) []Order {
+	e.m.Lock()
+	defer e.m.Unlock()
+	var orders []Order
+	for _, o := range e.orders {
+		if include(o) {
+			orders = append(orders, copyOrder(o))
+		}
+	}
+	sort.Slice(orders, func(i, j int) bool {
+		a, _ := strconv.ParseInt(orders[i].ID, 10, 64)
+		b, _ := strconv.ParseInt(orders[j].ID, 10, 64)
+		return a < b
+	})
+	return orders
+}
+
+// Balances returns the paper balances sorted by currency
+func (e *Engine) Balances() []Balance {
+	e.m.Lock()
+	defer e.m.Unlock()
+	balances := make([]Balance, 0, len(e.balances))
+	for _, b := range e.balances {
+		balances = append(balances, *b)
+	}
+	sort.Slice(balances, func(i, j int) bool { return balances[i].Currency < balances[j].Currency })
+	return balances
+}
+
+// storedMarketData returns the stored orderbook, falling back to a single
+// level book at the stored ticker's bid and ask. Feeds quarantined by the
+// data quality monitor are not matched against.
+func (e *Engine) storedMarketData(p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	if quality.Default.IsQuarantined(e.ExchangeName, p, assetType) {
+		return orderbook.Base{}, quality.ErrFeedQuarantined
+	}
+	book, err := orderbook.GetOrderbook(e.ExchangeName, p, assetType)
+	if err == nil && (len(book.Bids) > 0 || len(book.Asks) > 0) {
+		return book, nil
+	}
+	t, err := ticker.GetTicker(e.ExchangeName, p, assetType)
+	if err != nil || (t.Bid <= 0 && t.Ask <= 0) {
+		return orderbook.Base{}, fmt.Errorf("%s %s %s", e.ExchangeName, p.Pair(), ErrNoMarketData)
+	}
+	// Ticker volume is not depth, the top of book is treated as unlimited
+	book = orderbook.Base{Pair: p, AssetType: assetType}
+	if t.Bid > 0 {
+		book.Bids = []orderbook.Item{{Price: t.Bid, Amount: -1}}
+	}
+	if t.Ask > 0 {
+		book.Asks = []orderbook.Item{{Price: t.Ask, Amount: -1}}
+	}
+	return book, nil
+}
+
+// take fills an incoming order against the opposite side of the book at each
+// level's price, up to its limit price
+func (e *Engine) take(o *Order, book *orderbook.Base, reservePrice float64) {
+	levels := book.Asks
+	if !o.Buy {
+		levels = book.Bids
+	}
+	for i := range levels {
+		remaining := o.Amount - o.FilledAmount
+		if remaining <= 0 {
+			break
+		}
+		if !o.Market && !crosses(o, levels[i].Price) {
+			break
+		}
+		amount := remaining
+		if levels[i].Amount >= 0 && levels[i].Amount < amount {
+			amount = levels[i].Amount
+		}
+		if amount <= 0 {
+			continue
+		}
+		e.fill(o, amount, levels[i].Price, reservePrice, false)
+	}
+}
+
+// make fills a resting order at its limit price once the opposite side of
+// the book trades through it
+func (e *Engine) make(o *Order, book *orderbook.Base) {
+	levels := book.Asks
+	if !o.Buy {
+		levels = book.Bids
+	}
+	for i := range levels {
+		remaining := o.Amount - o.FilledAmount
+		if remaining <= 0 || !crosses(o, levels[i].Price) {
+			break
+		}
+		amount := remaining
+		if levels[i].Amount >= 0 && levels[i].Amount < amount {
+			amount = levels[i].Amount
+		}
+		if amount <= 0 {
+			continue
+		}
+		e.fill(o, amount, o.Price, o.Price, true)
+	}
+}
+
+// fill applies an execution to an order and the balances, the reserved
+// quote of a buy is released at the reserve price and the fee is paid in
+// the quote currency
+func (e *Engine) fill(o *Order, amount, price, reservePrice float64, maker bool) {
+	feeRate := e.TakerFee
+	if maker {
+		feeRate = e.MakerFee
+	}
+	notional := amount * price
+	fee := notional * feeRate / 100
+
+	base := e.balance(o.Pair.FirstCurrency.Upper().String())
+	quote := e.balance(o.Pair.SecondCurrency.Upper().String())
+	if o.Buy {
+		quote.Hold -= amount * reservePrice
+		quote.Total -= notional + fee
+		base.Total += amount
+	} else {
+		base.Hold -= amount
+		base.Total -= amount
+		quote.Total += notional - fee
+	}
+
+	filled := o.FilledAmount + amount
+	o.AverageFillPrice = (o.AverageFillPrice*o.FilledAmount + price*amount) / filled
+	o.FilledAmount = filled
+	o.Fee += fee
+	o.Fills = append(o.Fills, Fill{Amount: amount, Price: price, Fee: fee, Maker: maker, Time: time.Now()})
+	o.Status = StatusPartiallyFilled
+	if o.Amount-o.FilledAmount <= 1e-12 {
+		o.Status = StatusFilled
+	}
+}
+
+// reserve holds the balance an order can spend, including the taker fee on
+// buys
+func (e *Engine) reserve(o *Order, amount, price float64) error {
+	if o.Buy {
+		quote := e.balance(o.Pair.SecondCurrency.Upper().String())
+		required := amount * price * (1 + e.TakerFee/100)
+		if quote.Total-quote.Hold < required {
+			return fmt.Errorf("%s %s", ErrInsufficientBalance, quote.Currency)
+		}
+		quote.Hold += amount * price
+		return nil
+	}
+	base := e.balance(o.Pair.FirstCurrency.Upper().String())
+	if base.Total-base.Hold < amount {
+		return fmt.Errorf("%s %s", ErrInsufficientBalance, base.Currency)
+	}
+	base.Hold += amount
+	return nil
+}
+
+// release returns the reserved balance of an order's unfilled amount
+func (e *Engine) release(o *Order, amount, price float64) {
+	if o.Buy {
+		e.balance(o.Pair.SecondCurrency.Upper().String()).Hold -= amount * price
+		return
+	}
+	e.balance(o.Pair.FirstCurrency.Upper().String()).Hold -= amount
+}
+
+// balance returns a currency balance, creating an empty one
+func (e *Engine) balance(currency string) *Balance {
+	b, ok := e.balances[currency]
+	if !ok {
+		b = &Balance{Currency: currency}
+		e.balances[currency] = b
+	}
+	return b
+}
+
+// worstPrice returns the price of the deepest level a market order would
+// fill at, zero when the book side is empty
+func worstPrice(book *orderbook.Base, buy bool, amount float64) float64 {
+	levels := book.Asks
+	if !buy {
+		levels = book.Bids
+	}
+	var price float64
+	for i := range levels {
+		price = levels[i].Price
+		if levels[i].Amount < 0 {
+			break
+		}
+		amount -= levels[i].Amount
+		if amount <= 0 {
+			break
+		}
+	}
+	return price
+}
+
+// crosses returns whether an opposite side price is within an order's limit
+func crosses(o *Order, price float64) bool {
+	if o.Buy {
+		return price <= o.Price
+	}
+	return price >= o.Price
+}
+
+// open returns whether an order can still fill
+func open(o *Order) bool {
+	return o.Status == StatusOpen || o.Status == StatusPartiallyFilled
+}
+
+// copyOrder returns a copy of an order which is safe to retain
+func copyOrder(o *Order) Order {
+	c := *o
+	c.Fills = append([]Fill(nil), o.Fills...)
+	return c
+}
@@ -0,0 +1,125 @@
+package paper
+
+import (
+	"math"
+	"testing"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+)
+
+func newTestEngine(book *orderbook.Base) *Engine {
+	e := NewEngine("Bitstamp", map[string]float64{"usd": 10000, "BTC": 1})
+	e.TakerFee = 0.1
+	e.MakerFee = 0
+	e.Data = func(p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+		return *book, nil
+	}
+	return e
+}
+
+func balances(e *Engine) map[string]Balance {
+	result := make(map[string]Balance)
+	for _, b := range e.Balances() {
+		result[b.Currency] = b
+	}
+	return result
+}
+
+func TestSubmitMarket(t *testing.T) {
+	book := &orderbook.Base{
+		Bids: []orderbook.Item{{Price: 99, Amount: 1}},
+		Asks: []orderbook.Item{{Price: 100, Amount: 1}, {Price: 101, Amount: 2}},
+	}
+	e := newTestEngine(book)
+	p := pair.NewCurrencyPair("BTC", "USD")
+
+	o, err := e.Submit(Order{Pair: p, Buy: true, Market: true, Amount: 2})
+	if err != nil {
+		t.Fatal("Test Failed - Submit() error", err)
+	}
+	if o.Status != StatusFilled || o.FilledAmount != 2 || o.AverageFillPrice != 100.5 {
+		t.Errorf("Test Failed - Submit() unexpected market fill %+v", o)
+	}
+	b := balances(e)
+	if b["BTC"].Total != 3 || math.Abs(b["USD"].Total-(10000-201-0.201)) > 1e-9 || b["USD"].Hold != 0 {
+		t.Errorf("Test Failed - Submit() unexpected balances %+v", b)
+	}
+
+	_, err = e.Submit(Order{Pair: p, Market: true, Amount: 10})
+	if err == nil {
+		t.Error("Test Failed - Submit() sold more than the balance")
+	}
+}
+
+func TestSubmitLimit(t *testing.T) {
+	book := &orderbook.Base{
+		Bids: []orderbook.Item{{Price: 99, Amount: 1}},
+		Asks: []orderbook.Item{{Price: 100, Amount: 0.5}, {Price: 102, Amount: 2}},
+	}
+	e := newTestEngine(book)
+	p := pair.NewCurrencyPair("BTC", "USD")
+
+	o, err := e.Submit(Order{Pair: p, Buy: true, Amount: 1, Price: 101})
+	if err != nil {
+		t.Fatal("Test Failed - Submit() error", err)
+	}
+	if o.Status != StatusPartiallyFilled || o.FilledAmount != 0.5 {
+		t.Errorf("Test Failed - Submit() unexpected limit fill %+v", o)
+	}
+	if b := balances(e); b["USD"].Hold != 50.5 {
+		t.Errorf("Test Failed - Submit() unexpected hold %+v", b["USD"])
+	}
+
+	// The resting remainder fills at its limit price once the market trades
+	// through it
+	book.Asks = []orderbook.Item{{Price: 100.5, Amount: 5}}
+	e.Match()
+	o, err = e.Order(o.ID)
+	if err != nil {
+		t.Fatal("Test Failed - Order() error", err)
+	}
+	if o.Status != StatusFilled || o.AverageFillPrice != 100.5 || !o.Fills[1].Maker {
+		t.Errorf("Test Failed - Match() unexpected fill %+v", o)
+	}
+	if b := balances(e); b["USD"].Hold != 0 || b["BTC"].Total != 2 {
+		t.Errorf("Test Failed - Match() unexpected balances %+v", b)
+	}
+}
+
+func TestCancelModify(t *testing.T) {
+	book := &orderbook.Base{
+		Bids: []orderbook.Item{{Price: 99, Amount: 1}},
+		Asks: []orderbook.Item{{Price: 100, Amount: 1}},
+	}
+	e := newTestEngine(book)
+	p := pair.NewCurrencyPair("BTC", "USD")
+
+	o, err := e.Submit(Order{Pair: p, Amount: 0.5, Price: 110})
+	if err != nil {
+		t.Fatal("Test Failed - Submit() error", err)
+	}
+	modified, err := e.Modify(o.ID, 120, 0)
+	if err != nil {
+		t.Fatal("Test Failed - Modify() error", err)
+	}
+	if modified.ID == o.ID || modified.Price != 120 || modified.Amount != 0.5 {
+		t.Errorf("Test Failed - Modify() unexpected order %+v", modified)
+	}
+	if err = e.Cancel(o.ID); err != ErrOrderClosed {
+		t.Error("Test Failed - Cancel() replaced order error", err)
+	}
+	if b := balances(e); b["BTC"].Hold != 0.5 {
+		t.Errorf("Test Failed - Modify() unexpected hold %+v", b["BTC"])
+	}
+
+	if ids := e.CancelAll(pair.CurrencyPair{}); len(ids) != 1 || ids[0] != modified.ID {
+		t.Error("Test Failed - CancelAll() unexpected IDs", ids)
+	}
+	if b := balances(e); b["BTC"].Hold != 0 || len(e.OpenOrders()) != 0 {
+		t.Errorf("Test Failed - CancelAll() unexpected balances %+v", b["BTC"])
+	}
+	if err = e.Cancel("1337"); err != ErrOrderNotFound {
+		t.Error("Test Failed - Cancel() unknown order error", err)
+	}
+}
@@ -31,6 +31,8 @@ func (p *Poloniex) WsConnect() error {
 	}
 
 	var dialer websocket.Dialer
+	dialer.TLSClientConfig = p.GetTLSConfig()
+	dialer.NetDial = p.GetWebsocketNetDial()
 	if p.Websocket.GetProxyAddress() != "" {
 		proxy, err := url.Parse(p.Websocket.GetProxyAddress())
 		if err != nil {
@@ -256,7 +258,7 @@ func (p *Poloniex) WsHandleData() {
 							trade.Timestamp, _ = data[0].(int64)
 
 							p.Websocket.DataHandler <- exchange.TradeData{
-								Timestamp: time.Unix(trade.Timestamp, 0),
+								Timestamp: common.UnixTimestampToUTC(trade.Timestamp),
 								// CurrencyPair: pair.NewCurrencyPairFromString(trade.Symbol),
 								Side:   trade.Side,
 								Amount: trade.Volume,
@@ -1,10 +1,10 @@
 package poloniex
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
-	"sync"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
@@ -13,17 +13,13 @@ import (
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
 
-// Start starts the Poloniex go routine
-func (p *Poloniex) Start(wg *sync.WaitGroup) {
-	wg.Add(1)
-	go func() {
-		p.Run()
-		wg.Done()
-	}()
+// Start starts the Poloniex wrapper, refreshing its currency pairs
+func (p *Poloniex) Start(ctx context.Context) error {
+	return p.StartWrapper(ctx, p.Run)
 }
 
 // Run implements the Poloniex wrapper
-func (p *Poloniex) Run() {
+func (p *Poloniex) Run(ctx context.Context) error {
 	if p.Verbose {
 		log.Printf("%s Websocket: %s (url: %s).\n", p.GetName(), common.IsEnabled(p.Websocket.IsEnabled()), poloniexWebsocketAddress)
 		log.Printf("%s polling delay: %ds.\n", p.GetName(), p.RESTPollingDelay)
@@ -45,6 +41,7 @@ func (p *Poloniex) Run() {
 			log.Printf("%s Failed to update available currencies %s.\n", p.GetName(), err)
 		}
 	}
+	return nil
 }
 
 // UpdateTicker updates and returns the ticker for a currency pair
@@ -157,6 +154,10 @@ func (p *Poloniex) GetExchangeHistory(currencyPair pair.CurrencyPair, assetType
 
 // SubmitOrder submits a new order
 func (p *Poloniex) SubmitOrder(currencyPair pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	if p.IsSimulated() {
+		return p.SimulateSubmitOrder(currencyPair, side, orderType, amount, price, clientID)
+	}
+
 	var submitOrderResponse exchange.SubmitOrderResponse
 	fillOrKill := orderType == exchange.Market
 	isBuyOrder := side == exchange.Buy
@@ -176,6 +177,10 @@ func (p *Poloniex) SubmitOrder(currencyPair pair.CurrencyPair, side exchange.Ord
 // ModifyOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (p *Poloniex) ModifyOrder(action exchange.ModifyOrder) (string, error) {
+	if p.IsSimulated() {
+		return p.SimulateModifyOrder(action)
+	}
+
 	oID, err := strconv.ParseInt(action.OrderID, 10, 64)
 	if err != nil {
 		return "", err
@@ -195,6 +200,10 @@ func (p *Poloniex) ModifyOrder(action exchange.ModifyOrder) (string, error) {
 
 // CancelOrder cancels an order by its corresponding ID number
 func (p *Poloniex) CancelOrder(order exchange.OrderCancellation) error {
+	if p.IsSimulated() {
+		return p.SimulateCancelOrder(order)
+	}
+
 	orderIDInt, err := strconv.ParseInt(order.OrderID, 10, 64)
 
 	if err != nil {
@@ -208,6 +217,10 @@ func (p *Poloniex) CancelOrder(order exchange.OrderCancellation) error {
 
 // CancelAllOrders cancels all orders associated with a currency pair
 func (p *Poloniex) CancelAllOrders(orderCancellation exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
+	if p.IsSimulated() {
+		return p.SimulateCancelAllOrders(orderCancellation)
+	}
+
 	cancelAllOrdersResponse := exchange.CancelAllOrdersResponse{
 		OrderStatus: make(map[string]string),
 	}
@@ -230,6 +243,10 @@ func (p *Poloniex) CancelAllOrders(orderCancellation exchange.OrderCancellation)
 
 // GetOrderInfo returns information on a current open order
 func (p *Poloniex) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
+	if p.IsSimulated() {
+		return p.SimulateGetOrderInfo(orderID)
+	}
+
 	var orderDetail exchange.OrderDetail
 	return orderDetail, common.ErrNotYetImplemented
 }
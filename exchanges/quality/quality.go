@@ -0,0 +1,417 @@
+// Package quality monitors exchange market data feeds for crossed and locked
+// orderbooks, zero and negative spreads and impossible prints. Feeds which
+// repeatedly fail the checks are quarantined until they recover.
+package quality
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+// Issue is a data quality problem detected in a feed
+type Issue string
+
+// Issues detected by the monitor
+const (
+	CrossedBook     Issue = "crossed_book"
+	LockedBook      Issue = "locked_book"
+	NegativeSpread  Issue = "negative_spread"
+	ZeroSpread      Issue = "zero_spread"
+	ImpossiblePrint Issue = "impossible_print"
+)
+
+// Default monitor values
+const (
+	DefaultQuarantineAfter   = 3
+	DefaultReleaseAfter      = 5
+	DefaultMaxPriceDeviation = 0.5
+)
+
+// ErrFeedQuarantined is returned for clean data from a feed which is still
+// quarantined
+var ErrFeedQuarantined = errors.New("feed is quarantined")
+
+// Alert is raised when a feed is quarantined or released
+type Alert struct {
+	Exchange    string
+	Pair        pair.CurrencyPair
+	AssetType   string
+	Issues      []Issue
+	Quarantined bool
+	Time        time.Time
+}
+
+// String returns a human readable alert
+func (a Alert) String() string {
+	if !a.Quarantined {
+		return fmt.Sprintf("%s %s %s feed released from quarantine",
+			a.Exchange, a.Pair.Pair(), a.AssetType)
+	}
+	return fmt.Sprintf("%s %s %s feed quarantined: %s",
+		a.Exchange, a.Pair.Pair(), a.AssetType, joinIssues(a.Issues))
+}
+
+// Counter holds the data quality counters of an exchange
+type Counter struct {
+	Exchange    string          `json:"exchange"`
+	Checks      int64           `json:"checks"`
+	Issues      map[Issue]int64 `json:"issues"`
+	Quarantines int64           `json:"quarantines"`
+	Quarantined int             `json:"quarantined"`
+}
+
+// FeedStatus is the data quality state of a single feed
+type FeedStatus struct {
+	Exchange    string            `json:"exchange"`
+	Pair        pair.CurrencyPair `json:"pair"`
+	AssetType   string            `json:"asset_type"`
+	Quarantined bool              `json:"quarantined"`
+	Since       time.Time         `json:"since"`
+	LastIssues  []Issue           `json:"last_issues"`
+}
+
+// feed tracks the consecutive results of a feed's checks
+type feed struct {
+	FeedStatus
+	bad       int
+	clean     int
+	lastPrice float64
+}
+
+// Monitor checks exchange feeds, quarantining a feed after QuarantineAfter
+// consecutive failed checks and releasing it after ReleaseAfter consecutive
+// clean checks. A print moving more than MaxPriceDeviation, as a fraction of
+// the previous print, is considered impossible. Alert is called when a feed is
+// quarantined or released.
+type Monitor struct {
+	QuarantineAfter   int
+	ReleaseAfter      int
+	MaxPriceDeviation float64
+	Alert             func(Alert)
+
+	feeds    map[string]*feed
+	counters map[string]*Counter
+	m        sync.Mutex
+}
+
+// Default is the shared data quality monitor used by the bot
+var Default = NewMonitor()
+
+// NewMonitor returns a new data quality monitor with the default thresholds
+func NewMonitor() *Monitor {
+	return &Monitor{
+		QuarantineAfter:   DefaultQuarantineAfter,
+		ReleaseAfter:      DefaultReleaseAfter,
+		MaxPriceDeviation: DefaultMaxPriceDeviation,
+		feeds:             make(map[string]*feed),
+		counters:          make(map[string]*Counter),
+	}
+}
+
+// CheckTicker checks a ticker update. An error is returned when the ticker
+// fails the checks or the feed is quarantined, in which case it should not be
+// used.
+func (m *Monitor) CheckTicker(exchName string, p pair.CurrencyPair, assetType string, price ticker.Price) error {
+	var issues []Issue
+	if !validPrice(price.Last) || !validPrice(price.Bid) || !validPrice(price.Ask) ||
+		!validPrice(price.High) || !validPrice(price.Low) ||
+		(price.High > 0 && price.Low > 0 && price.High < price.Low) {
+		issues = append(issues, ImpossiblePrint)
+	}
+	if price.Bid > 0 && price.Ask > 0 {
+		switch {
+		case price.Bid > price.Ask:
+			issues = append(issues, NegativeSpread)
+		case price.Bid == price.Ask:
+			issues = append(issues, ZeroSpread)
+		}
+	}
+	return m.record(exchName, p, assetType, price.Last, issues)
+}
+
+// CheckOrderbook checks an orderbook update. An error is returned when the
+// orderbook fails the checks or the feed is quarantined, in which case it
+// should not be used.
+func (m *Monitor) CheckOrderbook(exchName string, p pair.CurrencyPair, assetType string, ob orderbook.Base) error {
+	var issues []Issue
+	bestBid, bestAsk := 0.0, 0.0
+	for _, side := range [][]orderbook.Item{ob.Bids, ob.Asks} {
+		for i := range side {
+			if !validPrice(side[i].Price) || side[i].Price == 0 || !validPrice(side[i].Amount) {
+				issues = append(issues, ImpossiblePrint)
+				break
+			}
+		}
+	}
+	for i := range ob.Bids {
+		if ob.Bids[i].Price > bestBid {
+			bestBid = ob.Bids[i].Price
+		}
+	}
+	for i := range ob.Asks {
+		if ob.Asks[i].Price > 0 && (bestAsk == 0 || ob.Asks[i].Price < bestAsk) {
+			bestAsk = ob.Asks[i].Price
+		}
+	}
+	if bestBid > 0 && bestAsk > 0 {
+		switch {
+		case bestBid > bestAsk:
+			issues = append(issues, CrossedBook)
+		case bestBid == bestAsk:
+			issues = append(issues, LockedBook)
+		}
+	}
+
+	var mid float64
+	if bestBid > 0 && bestAsk > 0 {
+		mid = (bestBid + bestAsk) / 2
+	}
+	return m.record(exchName, p, assetType, mid, uniqueIssues(issues))
+}
+
+// IsQuarantined returns whether a feed is quarantined
+func (m *Monitor) IsQuarantined(exchName string, p pair.CurrencyPair, assetType string) bool {
+	m.m.Lock()
+	defer m.m.Unlock()
+	f, ok := m.feeds[feedKey(exchName, p, assetType)]
+	return ok && f.Quarantined
+}
+
+// Release manually releases a feed from quarantine
+func (m *Monitor) Release(exchName string, p pair.CurrencyPair, assetType string) {
+	m.m.Lock()
+	f, ok := m.feeds[feedKey(exchName, p, assetType)]
+	if !ok || !f.Quarantined {
+		m.m.Unlock()
+		return
+	}
+	alert := m.release(f)
+	m.m.Unlock()
+	m.alert(alert)
+}
+
+// Feeds returns the status of every quarantined feed
+func (m *Monitor) Feeds() []FeedStatus {
+	m.m.Lock()
+	defer m.m.Unlock()
+	var feeds []FeedStatus
+	for _, f := range m.feeds {
+		if f.Quarantined {
+			feeds = append(feeds, f.FeedStatus)
+		}
+	}
+	sort.Slice(feeds, func(i, j int) bool {
+		return feedKey(feeds[i].Exchange, feeds[i].Pair, feeds[i].AssetType) <
+			feedKey(feeds[j].Exchange, feeds[j].Pair, feeds[j].AssetType)
+	})
+	return feeds
+}
+
+// Counters returns the data quality counters of each exchange
+func (m *Monitor) Counters() []Counter {
+	m.m.Lock()
+	defer m.m.Unlock()
+	counters := make([]Counter, 0, len(m.counters))
+	for _, c := range m.counters {
+		counter := *c
+		counter.Issues = make(map[Issue]int64, len(c.Issues))
+		for issue, count := range c.Issues {
+			counter.Issues[issue] = count
+		}
+		counters = append(counters, counter)
+	}
+	sort.Slice(counters, func(i, j int) bool {
+		return counters[i].Exchange < counters[j].Exchange
+	})
+	return counters
+}
+
+// WriteMetrics writes the counters in the Prometheus text exposition format
+func (m *Monitor) WriteMetrics(w io.Writer) error {
+	counters := m.Counters()
+	metrics := []struct {
+		name, help, kind string
+		value            func(c *Counter) []string
+	}{
+		{"gocryptotrader_data_quality_checks_total", "Market data updates checked", "counter",
+			func(c *Counter) []string {
+				return []string{fmt.Sprintf("{exchange=%q} %d", c.Exchange, c.Checks)}
+			}},
+		{"gocryptotrader_data_quality_issues_total", "Market data quality issues detected", "counter",
+			func(c *Counter) []string {
+				var lines []string
+				for _, issue := range []Issue{CrossedBook, LockedBook, NegativeSpread, ZeroSpread, ImpossiblePrint} {
+					lines = append(lines, fmt.Sprintf("{exchange=%q,issue=%q} %d",
+						c.Exchange, issue, c.Issues[issue]))
+				}
+				return lines
+			}},
+		{"gocryptotrader_data_quality_quarantines_total", "Feeds quarantined", "counter",
+			func(c *Counter) []string {
+				return []string{fmt.Sprintf("{exchange=%q} %d", c.Exchange, c.Quarantines)}
+			}},
+		{"gocryptotrader_data_quality_quarantined_feeds", "Feeds currently quarantined", "gauge",
+			func(c *Counter) []string {
+				return []string{fmt.Sprintf("{exchange=%q} %d", c.Exchange, c.Quarantined)}
+			}},
+	}
+
+	for _, metric := range metrics {
+		_, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n",
+			metric.name, metric.help, metric.name, metric.kind)
+		if err != nil {
+			return err
+		}
+		for i := range counters {
+			for _, line := range metric.value(&counters[i]) {
+				_, err = fmt.Fprintf(w, "%s%s\n", metric.name, line)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// record updates a feed with the result of a check, quarantining or
+// releasing it as required
+func (m *Monitor) record(exchName string, p pair.CurrencyPair, assetType string, price float64, issues []Issue) error {
+	m.m.Lock()
+	key := feedKey(exchName, p, assetType)
+	f, ok := m.feeds[key]
+	if !ok {
+		f = &feed{FeedStatus: FeedStatus{Exchange: exchName, Pair: p, AssetType: assetType}}
+		m.feeds[key] = f
+	}
+	c, ok := m.counters[exchName]
+	if !ok {
+		c = &Counter{Exchange: exchName, Issues: make(map[Issue]int64)}
+		m.counters[exchName] = c
+	}
+
+	// A print is compared to the previous print whether or not it was clean,
+	// so a genuine level shift is only flagged once
+	if price > 0 && validPrice(price) {
+		if f.lastPrice > 0 && m.MaxPriceDeviation > 0 &&
+			math.Abs(price-f.lastPrice)/f.lastPrice > m.MaxPriceDeviation {
+			issues = uniqueIssues(append(issues, ImpossiblePrint))
+		}
+		f.lastPrice = price
+	}
+
+	c.Checks++
+	for _, issue := range issues {
+		c.Issues[issue]++
+	}
+
+	var alert *Alert
+	if len(issues) > 0 {
+		f.LastIssues = issues
+		f.clean = 0
+		f.bad++
+		if !f.Quarantined && f.bad >= m.QuarantineAfter {
+			f.Quarantined = true
+			f.Since = time.Now()
+			c.Quarantines++
+			c.Quarantined++
+			alert = &Alert{
+				Exchange:    exchName,
+				Pair:        p,
+				AssetType:   assetType,
+				Issues:      issues,
+				Quarantined: true,
+				Time:        f.Since,
+			}
+		}
+	} else {
+		f.bad = 0
+		f.clean++
+		if f.Quarantined && f.clean >= m.ReleaseAfter {
+			alert = m.release(f)
+		}
+	}
+	quarantined := f.Quarantined
+	m.m.Unlock()
+
+	m.alert(alert)
+	if len(issues) > 0 {
+		return fmt.Errorf("%s %s %s failed data quality checks: %s",
+			exchName, p.Pair(), assetType, joinIssues(issues))
+	}
+	if quarantined {
+		return ErrFeedQuarantined
+	}
+	return nil
+}
+
+// release releases a feed from quarantine, the mutex must be held by the
+// caller
+func (m *Monitor) release(f *feed) *Alert {
+	f.Quarantined = false
+	f.bad = 0
+	f.clean = 0
+	if c, ok := m.counters[f.Exchange]; ok {
+		c.Quarantined--
+	}
+	return &Alert{
+		Exchange:  f.Exchange,
+		Pair:      f.Pair,
+		AssetType: f.AssetType,
+		Time:      time.Now(),
+	}
+}
+
+// alert raises an alert if one is set
+func (m *Monitor) alert(a *Alert) {
+	if a != nil && m.Alert != nil {
+		m.Alert(*a)
+	}
+}
+
+// validPrice returns whether a value is finite and not negative
+func validPrice(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0) && v >= 0
+}
+
+// uniqueIssues removes duplicate issues
+func uniqueIssues(issues []Issue) []Issue {
+	var unique []Issue
+	for i := range issues {
+		found := false
+		for j := range unique {
+			if unique[j] == issues[i] {
+				found = true
+				break
+			}
+		}
+		if !found {
+			unique = append(unique, issues[i])
+		}
+	}
+	return unique
+}
+
+// joinIssues returns the issues as a comma separated list
+func joinIssues(issues []Issue) string {
+	s := make([]string, len(issues))
+	for i := range issues {
+		s[i] = string(issues[i])
+	}
+	return strings.Join(s, ", ")
+}
+
+// feedKey returns the map key of a feed
+func feedKey(exchName string, p pair.CurrencyPair, assetType string) string {
+	return exchName + " " + assetType + " " + p.Pair().String()
+}
@@ -0,0 +1,139 @@
+package quality
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+func TestCheckTicker(t *testing.T) {
+	m := NewMonitor()
+	p := pair.NewCurrencyPair("BTC", "USD")
+
+	err := m.CheckTicker("Bitstamp", p, ticker.Spot, ticker.Price{Last: 100, Bid: 99, Ask: 101, High: 105, Low: 95})
+	if err != nil {
+		t.Error("Test Failed - CheckTicker() error", err)
+	}
+
+	tests := []struct {
+		price ticker.Price
+		issue Issue
+	}{
+		{ticker.Price{Last: 100, Bid: 102, Ask: 101}, NegativeSpread},
+		{ticker.Price{Last: 100, Bid: 101, Ask: 101}, ZeroSpread},
+		{ticker.Price{Last: math.NaN(), Bid: 99, Ask: 101}, ImpossiblePrint},
+		{ticker.Price{Last: 100, High: 90, Low: 95}, ImpossiblePrint},
+		{ticker.Price{Last: 1000, Bid: 999, Ask: 1001}, ImpossiblePrint},
+	}
+	for i := range tests {
+		m = NewMonitor()
+		m.CheckTicker("Bitstamp", p, ticker.Spot, ticker.Price{Last: 100})
+		err = m.CheckTicker("Bitstamp", p, ticker.Spot, tests[i].price)
+		if err == nil || !strings.Contains(err.Error(), string(tests[i].issue)) {
+			t.Errorf("Test Failed - CheckTicker() %d expected %s received %v",
+				i, tests[i].issue, err)
+		}
+	}
+}
+
+func TestCheckOrderbook(t *testing.T) {
+	m := NewMonitor()
+	p := pair.NewCurrencyPair("BTC", "USD")
+
+	tests := []struct {
+		ob    orderbook.Base
+		issue Issue
+	}{
+		{orderbook.Base{
+			Bids: []orderbook.Item{{Price: 99, Amount: 1}},
+			Asks: []orderbook.Item{{Price: 101, Amount: 1}},
+		}, ""},
+		{orderbook.Base{
+			Bids: []orderbook.Item{{Price: 102, Amount: 1}},
+			Asks: []orderbook.Item{{Price: 101, Amount: 1}},
+		}, CrossedBook},
+		{orderbook.Base{
+			Bids: []orderbook.Item{{Price: 101, Amount: 1}},
+			Asks: []orderbook.Item{{Price: 101, Amount: 1}},
+		}, LockedBook},
+		{orderbook.Base{
+			Bids: []orderbook.Item{{Price: 99, Amount: -1}},
+			Asks: []orderbook.Item{{Price: 101, Amount: 1}},
+		}, ImpossiblePrint},
+	}
+	for i := range tests {
+		err := m.CheckOrderbook("Kraken", p, orderbook.Spot, tests[i].ob)
+		if tests[i].issue == "" {
+			if err != nil {
+				t.Errorf("Test Failed - CheckOrderbook() %d error %v", i, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), string(tests[i].issue)) {
+			t.Errorf("Test Failed - CheckOrderbook() %d expected %s received %v",
+				i, tests[i].issue, err)
+		}
+	}
+}
+
+func TestQuarantine(t *testing.T) {
+	m := NewMonitor()
+	m.QuarantineAfter = 2
+	m.ReleaseAfter = 2
+	var alerts []Alert
+	m.Alert = func(a Alert) { alerts = append(alerts, a) }
+
+	p := pair.NewCurrencyPair("BTC", "USD")
+	crossed := ticker.Price{Last: 100, Bid: 102, Ask: 101}
+	clean := ticker.Price{Last: 100, Bid: 99, Ask: 101}
+
+	m.CheckTicker("Bitstamp", p, ticker.Spot, crossed)
+	if m.IsQuarantined("Bitstamp", p, ticker.Spot) {
+		t.Error("Test Failed - IsQuarantined() quarantined before threshold")
+	}
+	m.CheckTicker("Bitstamp", p, ticker.Spot, crossed)
+	if !m.IsQuarantined("Bitstamp", p, ticker.Spot) || len(alerts) != 1 || !alerts[0].Quarantined {
+		t.Fatal("Test Failed - CheckTicker() did not quarantine feed")
+	}
+	if feeds := m.Feeds(); len(feeds) != 1 || feeds[0].LastIssues[0] != NegativeSpread {
+		t.Error("Test Failed - Feeds() unexpected feeds", feeds)
+	}
+
+	err := m.CheckTicker("Bitstamp", p, ticker.Spot, clean)
+	if err != ErrFeedQuarantined {
+		t.Error("Test Failed - CheckTicker() expected ErrFeedQuarantined received", err)
+	}
+	err = m.CheckTicker("Bitstamp", p, ticker.Spot, clean)
+	if err != nil || m.IsQuarantined("Bitstamp", p, ticker.Spot) || len(alerts) != 2 {
+		t.Error("Test Failed - CheckTicker() did not release feed", err)
+	}
+
+	m.CheckTicker("Bitstamp", p, ticker.Spot, crossed)
+	m.CheckTicker("Bitstamp", p, ticker.Spot, crossed)
+	m.Release("Bitstamp", p, ticker.Spot)
+	if m.IsQuarantined("Bitstamp", p, ticker.Spot) || len(alerts) != 4 {
+		t.Error("Test Failed - Release() did not release feed")
+	}
+
+	counters := m.Counters()
+	if len(counters) != 1 || counters[0].Checks != 6 ||
+		counters[0].Issues[NegativeSpread] != 4 || counters[0].Quarantines != 2 ||
+		counters[0].Quarantined != 0 {
+		t.Errorf("Test Failed - Counters() unexpected counters %+v", counters)
+	}
+
+	var buf bytes.Buffer
+	err = m.WriteMetrics(&buf)
+	if err != nil {
+		t.Fatal("Test Failed - WriteMetrics() error", err)
+	}
+	if !strings.Contains(buf.String(),
+		`gocryptotrader_data_quality_issues_total{exchange="Bitstamp",issue="negative_spread"} 4`) {
+		t.Error("Test Failed - WriteMetrics() missing issue counter", buf.String())
+	}
+}
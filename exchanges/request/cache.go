@@ -0,0 +1,228 @@
+package request
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+)
+
+// cacheIndexFile holds the cached responses' metadata in the cache directory
+const cacheIndexFile = "index.json"
+
+var (
+	metadataCache   *DiskCache
+	metadataCacheMu sync.RWMutex
+)
+
+// CacheEntry holds the metadata of a cached response, the body is stored in
+// its own file in the cache directory
+type CacheEntry struct {
+	URL          string    `json:"url"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"lastModified,omitempty"`
+	Fetched      time.Time `json:"fetched"`
+	LastUsed     time.Time `json:"lastUsed"`
+	Size         int64     `json:"size"`
+}
+
+// DiskCache is a least recently used cache of large static exchange responses
+// such as symbol lists and currency chains, kept on disk so they survive
+// restarts. Responses younger than MaxAge are served without a request, older
+// responses are revalidated with the ETag or Last-Modified validators the
+// exchange returned, and the least recently used responses are evicted once
+// the cache exceeds MaxBytes.
+type DiskCache struct {
+	Dir      string
+	MaxBytes int64
+	MaxAge   time.Duration
+
+	entries map[string]*CacheEntry
+	size    int64
+	m       sync.Mutex
+}
+
+// SetMetadataCache sets the disk cache used by SendCachedPayload for every
+// exchange, nil disables caching
+func SetMetadataCache(c *DiskCache) {
+	metadataCacheMu.Lock()
+	metadataCache = c
+	metadataCacheMu.Unlock()
+}
+
+// GetMetadataCache returns the disk cache used by SendCachedPayload, nil when
+// caching is disabled
+func GetMetadataCache() *DiskCache {
+	metadataCacheMu.RLock()
+	defer metadataCacheMu.RUnlock()
+	return metadataCache
+}
+
+// NewDiskCache returns a disk cache in dir, creating the directory and loading
+// the responses cached by an earlier run
+func NewDiskCache(dir string, maxBytes int64, maxAge time.Duration) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0770); err != nil {
+		return nil, err
+	}
+	c := &DiskCache{
+		Dir:      dir,
+		MaxBytes: maxBytes,
+		MaxAge:   maxAge,
+		entries:  make(map[string]*CacheEntry),
+	}
+
+	data, err := common.ReadFile(filepath.Join(dir, cacheIndexFile))
+	if os.IsNotExist(err) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = common.JSONDecode(data, &c.entries); err != nil {
+		return nil, err
+	}
+	for key, e := range c.entries {
+		if _, err = os.Stat(c.bodyFile(key)); err != nil {
+			delete(c.entries, key)
+			continue
+		}
+		c.size += e.Size
+	}
+	return c, nil
+}
+
+// Get returns the cached response of a URL and its metadata
+func (c *DiskCache) Get(url string) (CacheEntry, []byte, bool) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	key := cacheKey(url)
+	e, ok := c.entries[key]
+	if !ok {
+		return CacheEntry{}, nil, false
+	}
+	body, err := common.ReadFile(c.bodyFile(key))
+	if err != nil {
+		c.remove(key)
+		c.saveIndex()
+		return CacheEntry{}, nil, false
+	}
+	e.LastUsed = time.Now()
+	c.saveIndex()
+	return *e, body, true
+}
+
+// Fresh returns whether a cached response can be served without revalidating
+// it with the exchange
+func (c *DiskCache) Fresh(e CacheEntry) bool {
+	return time.Since(e.Fetched) < c.MaxAge
+}
+
+// Put caches the response of a URL with its validators, evicting the least
+// recently used responses when the cache is full
+func (c *DiskCache) Put(url string, body []byte, etag, lastModified string) error {
+	c.m.Lock()
+	defer c.m.Unlock()
+	if c.MaxBytes > 0 && int64(len(body)) > c.MaxBytes {
+		return nil
+	}
+
+	key := cacheKey(url)
+	if err := common.WriteFile(c.bodyFile(key), body); err != nil {
+		return err
+	}
+	if e, ok := c.entries[key]; ok {
+		c.size -= e.Size
+	}
+	now := time.Now()
+	c.entries[key] = &CacheEntry{
+		URL:          url,
+		ETag:         etag,
+		LastModified: lastModified,
+		Fetched:      now,
+		LastUsed:     now,
+		Size:         int64(len(body)),
+	}
+	c.size += int64(len(body))
+	c.evict()
+	return c.saveIndex()
+}
+
+// Revalidated marks a cached response as fresh after the exchange reported it
+// unchanged
+func (c *DiskCache) Revalidated(url string) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	if e, ok := c.entries[cacheKey(url)]; ok {
+		e.Fetched = time.Now()
+		c.saveIndex()
+	}
+}
+
+// Remove removes the cached response of a URL, used when an exchange returns
+// an error in an otherwise successful response
+func (c *DiskCache) Remove(url string) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	c.remove(cacheKey(url))
+	c.saveIndex()
+}
+
+// Size returns the total size in bytes of the cached responses
+func (c *DiskCache) Size() int64 {
+	c.m.Lock()
+	defer c.m.Unlock()
+	return c.size
+}
+
+// evict removes the least recently used responses until the cache fits
+func (c *DiskCache) evict() {
+	if c.MaxBytes <= 0 || c.size <= c.MaxBytes {
+		return
+	}
+	keys := make([]string, 0, len(c.entries))
+	for key := range c.entries {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return c.entries[keys[i]].LastUsed.Before(c.entries[keys[j]].LastUsed)
+	})
+	for _, key := range keys {
+		if c.size <= c.MaxBytes {
+			return
+		}
+		c.remove(key)
+	}
+}
+
+// remove deletes a cached response and its body file
+func (c *DiskCache) remove(key string) {
+	e, ok := c.entries[key]
+	if !ok {
+		return
+	}
+	c.size -= e.Size
+	delete(c.entries, key)
+	os.Remove(c.bodyFile(key))
+}
+
+// saveIndex writes the cached responses' metadata to the index file
+func (c *DiskCache) saveIndex() error {
+	data, err := common.JSONEncode(c.entries)
+	if err != nil {
+		return err
+	}
+	return common.WriteFile(filepath.Join(c.Dir, cacheIndexFile), data)
+}
+
+// bodyFile returns the file a cached response's body is stored in
+func (c *DiskCache) bodyFile(key string) string {
+	return filepath.Join(c.Dir, key+".body")
+}
+
+// cacheKey returns the file name safe key of a URL
+func cacheKey(url string) string {
+	return common.HexEncodeToString(common.GetSHA256([]byte(url)))
+}
@@ -0,0 +1,113 @@
+package request
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestDiskCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "diskcache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	c, err := NewDiskCache(dir, 10, time.Hour)
+	if err != nil {
+		t.Fatal("Test Failed - NewDiskCache() error", err)
+	}
+	if err = c.Put("a", []byte("aaaa"), `"1"`, ""); err != nil {
+		t.Fatal("Test Failed - Put() error", err)
+	}
+	if err = c.Put("b", []byte("bbbb"), "", ""); err != nil {
+		t.Fatal("Test Failed - Put() error", err)
+	}
+	c.entries[cacheKey("a")].LastUsed = time.Now().Add(-time.Minute)
+	c.entries[cacheKey("b")].LastUsed = time.Now().Add(-2 * time.Minute)
+	if _, body, ok := c.Get("a"); !ok || string(body) != "aaaa" {
+		t.Error("Test Failed - Get() unexpected body", string(body), ok)
+	}
+
+	// The least recently used response is evicted once the cache is full
+	if err = c.Put("c", []byte("cccc"), "", ""); err != nil {
+		t.Fatal("Test Failed - Put() error", err)
+	}
+	if _, _, ok := c.Get("b"); ok || c.Size() != 8 {
+		t.Error("Test Failed - Put() expected least recently used response evicted", c.Size())
+	}
+
+	// Cached responses are loaded by a new cache in the directory
+	c, err = NewDiskCache(dir, 10, time.Hour)
+	if err != nil {
+		t.Fatal("Test Failed - NewDiskCache() error", err)
+	}
+	e, _, ok := c.Get("a")
+	if !ok || e.ETag != `"1"` || !c.Fresh(e) || c.Size() != 8 {
+		t.Errorf("Test Failed - NewDiskCache() unexpected entry %+v %v", e, ok)
+	}
+	c.Remove("a")
+	if _, _, ok = c.Get("a"); ok {
+		t.Error("Test Failed - Remove() response still cached")
+	}
+}
+
+func TestSendCachedPayload(t *testing.T) {
+	var requests, notModified int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`{"symbols":["BTCUSD","ETHUSD"]}`))
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "sendcachedpayload")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	r := New("cachetest", NewRateLimit(time.Second, 0), NewRateLimit(time.Second, 0), new(http.Client))
+	var result struct {
+		Symbols []string `json:"symbols"`
+	}
+	if err = r.SendCachedPayload(server.URL, nil, &result, false); err != nil || len(result.Symbols) != 2 {
+		t.Fatal("Test Failed - SendCachedPayload() without cache error", err)
+	}
+
+	c, err := NewDiskCache(dir, 1<<20, time.Hour)
+	if err != nil {
+		t.Fatal("Test Failed - NewDiskCache() error", err)
+	}
+	SetMetadataCache(c)
+	defer SetMetadataCache(nil)
+
+	requests = 0
+	for i := 0; i < 2; i++ {
+		result.Symbols = nil
+		if err = r.SendCachedPayload(server.URL, nil, &result, false); err != nil || len(result.Symbols) != 2 {
+			t.Fatal("Test Failed - SendCachedPayload() error", err)
+		}
+	}
+	if requests != 1 {
+		t.Error("Test Failed - SendCachedPayload() expected fresh response served from cache", requests)
+	}
+
+	// Stale responses are revalidated with the exchange
+	c.MaxAge = 0
+	result.Symbols = nil
+	if err = r.SendCachedPayload(server.URL, nil, &result, false); err != nil || len(result.Symbols) != 2 {
+		t.Fatal("Test Failed - SendCachedPayload() error", err)
+	}
+	if requests != 2 || notModified != 1 {
+		t.Error("Test Failed - SendCachedPayload() expected stale response revalidated", requests, notModified)
+	}
+}
@@ -0,0 +1,268 @@
+package request
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/thrasher-/gocryptotrader/common"
+)
+
+// CassetteMode is whether a cassette records or replays requests
+type CassetteMode int
+
+// Cassette modes
+const (
+	CassetteReplay CassetteMode = iota
+	CassetteRecord
+)
+
+// CassetteRecordEnv is the environment variable which switches cassettes
+// opened by OpenCassette to recording live API interactions
+const CassetteRecordEnv = "GCT_RECORD_CASSETTES"
+
+// cassetteRedacted replaces the values of sensitive fields in cassettes
+const cassetteRedacted = "REDACTED"
+
+// DefaultCassetteRedactions are the query parameters and body fields whose
+// values are never written to a cassette, such as credentials, signatures and
+// nonces which change on every request
+var DefaultCassetteRedactions = []string{
+	"accesskeyid",
+	"api_key",
+	"apikey",
+	"key",
+	"nonce",
+	"passphrase",
+	"recvwindow",
+	"secret",
+	"sign",
+	"signature",
+	"signaturemethod",
+	"signatureversion",
+	"timestamp",
+	"tonce",
+}
+
+// Errors returned by cassettes
+var (
+	ErrCassetteInteractionNotFound = errors.New("cassette has no recorded interaction for request")
+)
+
+// Interaction is a recorded request and its response. Request headers are
+// never recorded as they carry credentials.
+type Interaction struct {
+	Method     string              `json:"method"`
+	URL        string              `json:"url"`
+	Body       string              `json:"body,omitempty"`
+	StatusCode int                 `json:"statusCode"`
+	Headers    map[string][]string `json:"headers,omitempty"`
+	Response   string              `json:"response"`
+}
+
+// Cassette records a Requester's live API interactions to a fixture file and
+// replays them in tests, so wrapper endpoints can be regression tested without
+// hand written responses. Values of the Redact query parameters and body
+// fields are replaced in recorded requests, and requests are matched on their
+// redacted method, URL and body. Replayed interactions are served in recorded
+// order, the last match is repeated once they are used up.
+type Cassette struct {
+	File         string
+	Mode         CassetteMode
+	Redact       []string
+	Interactions []Interaction
+
+	played map[int]bool
+	m      sync.Mutex
+}
+
+// NewCassette returns an empty cassette which records to file
+func NewCassette(file string) *Cassette {
+	return &Cassette{
+		File:   file,
+		Mode:   CassetteRecord,
+		Redact: DefaultCassetteRedactions,
+		played: make(map[int]bool),
+	}
+}
+
+// LoadCassette returns a cassette replaying the interactions recorded in file
+func LoadCassette(file string) (*Cassette, error) {
+	data, err := common.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	c := NewCassette(file)
+	c.Mode = CassetteReplay
+	if err = common.JSONDecode(data, &c.Interactions); err != nil {
+		return nil, fmt.Errorf("cassette %s: %s", file, err)
+	}
+	return c, nil
+}
+
+// OpenCassette returns a recording cassette when CassetteRecordEnv is set,
+// otherwise a cassette replaying the interactions recorded in file
+func OpenCassette(file string) (*Cassette, error) {
+	if os.Getenv(CassetteRecordEnv) != "" {
+		return NewCassette(file), nil
+	}
+	return LoadCassette(file)
+}
+
+// Save writes the recorded interactions to the cassette file
+func (c *Cassette) Save() error {
+	c.m.Lock()
+	defer c.m.Unlock()
+	data, err := json.MarshalIndent(c.Interactions, "", " ")
+	if err != nil {
+		return err
+	}
+	return common.WriteFile(c.File, data)
+}
+
+// record appends a live interaction to the cassette
+func (c *Cassette) record(req *http.Request, body []byte, resp *http.Response, contents []byte) {
+	headers := make(map[string][]string)
+	for k, v := range resp.Header {
+		if k == "Set-Cookie" {
+			continue
+		}
+		headers[k] = v
+	}
+
+	c.m.Lock()
+	defer c.m.Unlock()
+	c.Interactions = append(c.Interactions, Interaction{
+		Method:     req.Method,
+		URL:        c.redactURL(req.URL),
+		Body:       string(c.redactBody(body)),
+		StatusCode: resp.StatusCode,
+		Headers:    headers,
+		Response:   string(contents),
+	})
+}
+
+// replay returns the recorded response of a request
+func (c *Cassette) replay(req *http.Request, body []byte) (*http.Response, []byte, error) {
+	u := c.redactURL(req.URL)
+	b := string(c.redactBody(body))
+
+	c.m.Lock()
+	defer c.m.Unlock()
+	match := -1
+	for i := range c.Interactions {
+		in := &c.Interactions[i]
+		if in.Method != req.Method || in.URL != u || in.Body != b {
+			continue
+		}
+		match = i
+		if !c.played[i] {
+			break
+		}
+	}
+	if match == -1 {
+		return nil, nil, fmt.Errorf("%s %s %s", ErrCassetteInteractionNotFound, req.Method, u)
+	}
+	c.played[match] = true
+
+	in := &c.Interactions[match]
+	contents := []byte(in.Response)
+	resp := &http.Response{
+		Status:        http.StatusText(in.StatusCode),
+		StatusCode:    in.StatusCode,
+		Header:        http.Header(in.Headers),
+		Body:          ioutil.NopCloser(bytes.NewReader(contents)),
+		ContentLength: int64(len(contents)),
+		Request:       req,
+	}
+	if resp.Header == nil {
+		resp.Header = make(http.Header)
+	}
+	return resp, contents, nil
+}
+
+// redacted returns whether a field's value is redacted
+func (c *Cassette) redacted(field string) bool {
+	for _, r := range c.Redact {
+		if strings.EqualFold(r, field) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactURL returns a URL with its redacted query parameters replaced and the
+// parameters sorted
+func (c *Cassette) redactURL(u *url.URL) string {
+	redacted := *u
+	redacted.RawQuery = c.redactValues(u.Query()).Encode()
+	return redacted.String()
+}
+
+// redactValues replaces the values of redacted parameters
+func (c *Cassette) redactValues(vals url.Values) url.Values {
+	for k := range vals {
+		if c.redacted(k) {
+			vals[k] = []string{cassetteRedacted}
+		}
+	}
+	return vals
+}
+
+// redactBody replaces the redacted fields of a JSON or form encoded body
+func (c *Cassette) redactBody(body []byte) []byte {
+	if len(body) == 0 {
+		return body
+	}
+
+	var v interface{}
+	if json.Unmarshal(body, &v) == nil {
+		if !c.redactJSON(v) {
+			return body
+		}
+		redacted, err := json.Marshal(v)
+		if err != nil {
+			return body
+		}
+		return redacted
+	}
+
+	vals, err := url.ParseQuery(string(body))
+	if err != nil || !strings.Contains(string(body), "=") {
+		return body
+	}
+	return []byte(c.redactValues(vals).Encode())
+}
+
+// redactJSON replaces the values of redacted fields in decoded JSON and
+// returns whether any were replaced
+func (c *Cassette) redactJSON(v interface{}) bool {
+	var replaced bool
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, field := range t {
+			if c.redacted(k) {
+				t[k] = cassetteRedacted
+				replaced = true
+				continue
+			}
+			if c.redactJSON(field) {
+				replaced = true
+			}
+		}
+	case []interface{}:
+		for i := range t {
+			if c.redactJSON(t[i]) {
+				replaced = true
+			}
+		}
+	}
+	return replaced
+}
@@ -0,0 +1,75 @@
+package request
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCassette(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Set-Cookie", "session=secret")
+		w.Write([]byte(`{"path":"` + r.URL.Path + `","amount":"` + r.URL.Query().Get("amount") + `"}`))
+	}))
+
+	dir, err := ioutil.TempDir("", "cassette")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "cassette.json")
+
+	r := New("cassettetest", NewRateLimit(time.Second, 0), NewRateLimit(time.Second, 0), new(http.Client))
+	r.SetCassette(NewCassette(file))
+	var result struct {
+		Path   string `json:"path"`
+		Amount string `json:"amount"`
+	}
+	err = r.SendPayload("POST", server.URL+"/order?amount=1&apikey=abc&nonce=1", nil,
+		strings.NewReader(`{"amount":"1","signature":"deadbeef"}`), &result, true, false)
+	if err != nil || result.Path != "/order" {
+		t.Fatal("Test Failed - SendPayload() recording error", err)
+	}
+	if err = r.GetCassette().Save(); err != nil {
+		t.Fatal("Test Failed - Save() error", err)
+	}
+	server.Close()
+
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{"abc", "deadbeef", "session"} {
+		if strings.Contains(string(data), s) {
+			t.Errorf("Test Failed - Save() cassette contains sensitive value %s", s)
+		}
+	}
+
+	// Requests differing only in redacted values are replayed without the
+	// server or rate limiter
+	c, err := LoadCassette(file)
+	if err != nil {
+		t.Fatal("Test Failed - LoadCassette() error", err)
+	}
+	r = New("cassettetest", NewRateLimit(time.Hour, 1), NewRateLimit(time.Hour, 1), new(http.Client))
+	r.SetCassette(c)
+	for i := 0; i < 2; i++ {
+		result.Path, result.Amount = "", ""
+		err = r.SendPayload("POST", server.URL+"/order?nonce=2&apikey=xyz&amount=1", nil,
+			strings.NewReader(`{"signature":"cafebabe","amount":"1"}`), &result, true, false)
+		if err != nil || result.Path != "/order" || result.Amount != "1" {
+			t.Fatal("Test Failed - SendPayload() replay error", err)
+		}
+	}
+
+	err = r.SendPayload("POST", server.URL+"/order", nil,
+		strings.NewReader(`{"amount":"2"}`), &result, true, false)
+	if err == nil || !strings.Contains(err.Error(), ErrCassetteInteractionNotFound.Error()) {
+		t.Error("Test Failed - SendPayload() expected unrecorded request error", err)
+	}
+}
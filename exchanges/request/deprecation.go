@@ -0,0 +1,215 @@
+package request
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Deprecation is an exchange's notice that an endpoint is deprecated or
+// scheduled to be shut down, reported through the Deprecation, Sunset,
+// Warning and Link response headers or an exchange's announcement fields.
+// Sunset is zero when no shutdown date was given.
+type Deprecation struct {
+	Exchange   string
+	Endpoint   string
+	Deprecated time.Time
+	Sunset     time.Time
+	Message    string
+	Link       string
+	LastSeen   time.Time
+}
+
+// Key returns the exchange and endpoint the notice applies to
+func (d Deprecation) Key() string {
+	return d.Exchange + " " + d.Endpoint
+}
+
+// String returns a one line description of the notice
+func (d Deprecation) String() string {
+	s := d.Exchange + " endpoint " + d.Endpoint + " is deprecated"
+	if !d.Sunset.IsZero() {
+		s += " and will be shut down at " + d.Sunset.UTC().Format(time.RFC1123)
+	}
+	if d.Message != "" {
+		s += ": " + d.Message
+	}
+	if d.Link != "" {
+		s += " (" + d.Link + ")"
+	}
+	return s
+}
+
+// warnCodeMiscPersistent is the Warning header code exchanges send
+// deprecation notices with
+const warnCodeMiscPersistent = "299"
+
+var (
+	deprecations = make(map[string]Deprecation)
+	deprecationM sync.RWMutex
+)
+
+// ReportDeprecation records a deprecation notice for an endpoint of the
+// exchange, for exchanges announcing shutdowns in response fields rather
+// than headers. A later notice for the same endpoint replaces it.
+func (r *Requester) ReportDeprecation(endpoint string, sunset time.Time, message string) {
+	storeDeprecation(Deprecation{
+		Exchange: r.Name,
+		Endpoint: endpoint,
+		Sunset:   sunset,
+		Message:  message,
+		LastSeen: time.Now(),
+	})
+}
+
+// checkDeprecation records the deprecation notice of a response to a request,
+// if it has one
+func (r *Requester) checkDeprecation(req *http.Request, resp *http.Response) {
+	d, ok := parseDeprecation(resp.Header)
+	if !ok {
+		return
+	}
+	d.Exchange = r.Name
+	d.Endpoint = req.Method + " " + req.URL.Path
+	d.LastSeen = time.Now()
+	storeDeprecation(d)
+}
+
+// parseDeprecation returns the deprecation notice of response headers and
+// whether they hold one
+func parseDeprecation(h http.Header) (Deprecation, bool) {
+	var d Deprecation
+	found := false
+
+	if v := strings.TrimSpace(h.Get("Deprecation")); v != "" {
+		found = true
+		d.Deprecated = parseHeaderTime(v)
+	}
+	if v := strings.TrimSpace(h.Get("Sunset")); v != "" {
+		if t := parseHeaderTime(v); !t.IsZero() {
+			found = true
+			d.Sunset = t
+		}
+	}
+	for _, w := range h["Warning"] {
+		if !strings.HasPrefix(strings.TrimSpace(w), warnCodeMiscPersistent+" ") {
+			continue
+		}
+		found = true
+		d.Message = warningText(w)
+		break
+	}
+	if !found {
+		return d, false
+	}
+	for _, l := range h["Link"] {
+		if link := relLink(l, "deprecation", "sunset"); link != "" {
+			d.Link = link
+			break
+		}
+	}
+	return d, true
+}
+
+// parseHeaderTime parses an HTTP date or a structured field date of the form
+// @<unix seconds>, returning zero for any other value such as "true"
+func parseHeaderTime(v string) time.Time {
+	if strings.HasPrefix(v, "@") {
+		s, err := strconv.ParseInt(v[1:], 10, 64)
+		if err != nil {
+			return time.Time{}
+		}
+		return time.Unix(s, 0).UTC()
+	}
+	t, err := http.ParseTime(v)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// warningText returns the quoted text of a Warning header value in the format
+// <code> <agent> "<text>" ["<date>"]
+func warningText(w string) string {
+	start := strings.Index(w, `"`)
+	if start < 0 {
+		return ""
+	}
+	end := strings.Index(w[start+1:], `"`)
+	if end < 0 {
+		return w[start+1:]
+	}
+	return w[start+1 : start+1+end]
+}
+
+// relLink returns the URL of a Link header value with any of the relation
+// types, or an empty string
+func relLink(l string, rels ...string) string {
+	for _, part := range strings.Split(l, ",") {
+		fields := strings.Split(part, ";")
+		target := strings.TrimSpace(fields[0])
+		if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+			continue
+		}
+		for _, param := range fields[1:] {
+			kv := strings.SplitN(strings.TrimSpace(param), "=", 2)
+			if len(kv) != 2 || !strings.EqualFold(kv[0], "rel") {
+				continue
+			}
+			for _, rel := range rels {
+				if strings.EqualFold(strings.Trim(kv[1], `"`), rel) {
+					return target[1 : len(target)-1]
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// storeDeprecation records a notice, keeping the details of an earlier notice
+// for the endpoint which the new one omits
+func storeDeprecation(d Deprecation) {
+	deprecationM.Lock()
+	defer deprecationM.Unlock()
+	if old, ok := deprecations[d.Key()]; ok {
+		if d.Deprecated.IsZero() {
+			d.Deprecated = old.Deprecated
+		}
+		if d.Sunset.IsZero() {
+			d.Sunset = old.Sunset
+		}
+		if d.Message == "" {
+			d.Message = old.Message
+		}
+		if d.Link == "" {
+			d.Link = old.Link
+		}
+	}
+	deprecations[d.Key()] = d
+}
+
+// GetDeprecations returns the deprecation notices received from every
+// exchange, ordered by sunset date with notices without one last
+func GetDeprecations() []Deprecation {
+	deprecationM.RLock()
+	list := make([]Deprecation, 0, len(deprecations))
+	for _, d := range deprecations {
+		list = append(list, d)
+	}
+	deprecationM.RUnlock()
+
+	sort.Slice(list, func(i, j int) bool {
+		a, b := list[i].Sunset, list[j].Sunset
+		if a.IsZero() != b.IsZero() {
+			return b.IsZero()
+		}
+		if !a.Equal(b) {
+			return a.Before(b)
+		}
+		return list[i].Key() < list[j].Key()
+	})
+	return list
+}
@@ -0,0 +1,72 @@
+package request
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestParseDeprecation(t *testing.T) {
+	if _, ok := parseDeprecation(http.Header{"Warning": {`110 - "Response is Stale"`}}); ok {
+		t.Error("Test Failed - parseDeprecation() expected no notice for a non persistent warning")
+	}
+
+	h := http.Header{}
+	h.Set("Deprecation", "@1688169599")
+	h.Set("Sunset", "Sat, 31 Dec 2033 23:59:59 GMT")
+	h.Add("Warning", `299 api.exchange.com "v1 orders are deprecated, use v2" "Sat, 01 Jul 2023 00:00:00 GMT"`)
+	h.Add("Link", `<https://exchange.com/docs>; rel="help", <https://exchange.com/changelog>; rel="deprecation"`)
+	d, ok := parseDeprecation(h)
+	if !ok {
+		t.Fatal("Test Failed - parseDeprecation() expected a notice")
+	}
+	if d.Deprecated.Unix() != 1688169599 ||
+		!d.Sunset.Equal(time.Date(2033, 12, 31, 23, 59, 59, 0, time.UTC)) ||
+		d.Message != "v1 orders are deprecated, use v2" ||
+		d.Link != "https://exchange.com/changelog" {
+		t.Errorf("Test Failed - parseDeprecation() unexpected notice %+v", d)
+	}
+
+	d, ok = parseDeprecation(http.Header{"Deprecation": {"true"}})
+	if !ok || !d.Deprecated.IsZero() {
+		t.Error("Test Failed - parseDeprecation() expected an undated notice", d, ok)
+	}
+}
+
+func TestCheckDeprecation(t *testing.T) {
+	sunset := time.Date(2033, 6, 30, 0, 0, 0, 0, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/ticker" {
+			w.Header().Set("Sunset", sunset.Format(http.TimeFormat))
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	r := New("deprecationtest", NewRateLimit(time.Second, 0), NewRateLimit(time.Second, 0), new(http.Client))
+	for _, path := range []string{"/v1/ticker", "/v2/ticker"} {
+		if err := r.SendPayload("GET", server.URL+path, nil, nil, nil, false, false); err != nil {
+			t.Fatal("Test Failed - SendPayload() error", err)
+		}
+	}
+	r.ReportDeprecation("account", time.Time{}, "account endpoint replaced by unified account")
+	r.ReportDeprecation("GET /v1/ticker", time.Time{}, "use v2")
+
+	var notices []Deprecation
+	for _, d := range GetDeprecations() {
+		if d.Exchange == "deprecationtest" {
+			notices = append(notices, d)
+		}
+	}
+	if len(notices) != 2 {
+		t.Fatal("Test Failed - GetDeprecations() unexpected notices", notices)
+	}
+	if notices[0].Endpoint != "GET /v1/ticker" || !notices[0].Sunset.Equal(sunset) ||
+		notices[0].Message != "use v2" {
+		t.Errorf("Test Failed - checkDeprecation() unexpected notice %+v", notices[0])
+	}
+	if notices[1].Endpoint != "account" || !notices[1].Sunset.IsZero() {
+		t.Errorf("Test Failed - ReportDeprecation() unexpected notice %+v", notices[1])
+	}
+}
@@ -0,0 +1,93 @@
+package request
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// EgressIPURL is the service queried for the public IP address outbound
+// requests originate from
+var EgressIPURL = "https://api.ipify.org"
+
+// ResolveLocalAddress returns the IP address for addr, which is either an IP
+// address or the name of a network interface in which case its first IPv4
+// address is used, falling back to its first IPv6 address
+func ResolveLocalAddress(addr string) (net.IP, error) {
+	if ip := net.ParseIP(addr); ip != nil {
+		return ip, nil
+	}
+
+	iface, err := net.InterfaceByName(addr)
+	if err != nil {
+		return nil, fmt.Errorf("local address %s is not an IP address or network interface", addr)
+	}
+	addrs, err := iface.Addrs()
+	if err != nil {
+		return nil, err
+	}
+
+	var ipv6 net.IP
+	for i := range addrs {
+		ipNet, ok := addrs[i].(*net.IPNet)
+		if !ok {
+			continue
+		}
+		if ipNet.IP.To4() != nil {
+			return ipNet.IP, nil
+		}
+		if ipv6 == nil {
+			ipv6 = ipNet.IP
+		}
+	}
+	if ipv6 != nil {
+		return ipv6, nil
+	}
+	return nil, fmt.Errorf("network interface %s has no IP addresses", addr)
+}
+
+// NewLocalDialer returns a dialer which binds outbound connections to the
+// local IP address
+func NewLocalDialer(ip net.IP) *net.Dialer {
+	return &net.Dialer{
+		LocalAddr: &net.TCPAddr{IP: ip},
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}
+}
+
+// SetLocalAddress binds all outbound connections made by the requester to the
+// local IP address, used when API keys are restricted to a whitelisted IP on
+// a server with multiple addresses
+func (r *Requester) SetLocalAddress(ip net.IP) {
+	r.transport().DialContext = NewLocalDialer(ip).DialContext
+}
+
+// GetEgressIP returns the public IP address requests made by the client are
+// seen to originate from
+func GetEgressIP(client *http.Client) (string, error) {
+	resp, err := client.Get(EgressIPURL)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("egress IP request returned status code %d", resp.StatusCode)
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	ip := strings.TrimSpace(string(body))
+	if net.ParseIP(ip) == nil {
+		return "", errors.New("egress IP response is not an IP address")
+	}
+	return ip, nil
+}
@@ -0,0 +1,59 @@
+package request
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestResolveLocalAddress(t *testing.T) {
+	ip, err := ResolveLocalAddress("127.0.0.1")
+	if err != nil || !ip.Equal(net.ParseIP("127.0.0.1")) {
+		t.Error("Test Failed - ResolveLocalAddress() expected 127.0.0.1", ip, err)
+	}
+
+	if _, err = ResolveLocalAddress("notaninterface0"); err == nil {
+		t.Error("Test Failed - ResolveLocalAddress() expected unknown interface error")
+	}
+
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		t.Skip("unable to list network interfaces", err)
+	}
+	for i := range ifaces {
+		if ifaces[i].Flags&net.FlagLoopback == 0 {
+			continue
+		}
+		ip, err = ResolveLocalAddress(ifaces[i].Name)
+		if err != nil || !ip.IsLoopback() {
+			t.Error("Test Failed - ResolveLocalAddress() expected loopback address", ip, err)
+		}
+		break
+	}
+}
+
+func TestSetLocalAddressAndGetEgressIP(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host, _, _ := net.SplitHostPort(r.RemoteAddr)
+		w.Write([]byte(host + "\n"))
+	}))
+	defer server.Close()
+
+	defaultURL := EgressIPURL
+	EgressIPURL = server.URL
+	defer func() { EgressIPURL = defaultURL }()
+
+	r := New("egress", NewRateLimit(time.Second, 0), NewRateLimit(time.Second, 0),
+		new(http.Client))
+	r.SetLocalAddress(net.ParseIP("127.0.0.1"))
+
+	ip, err := GetEgressIP(r.HTTPClient)
+	if err != nil {
+		t.Fatal("Test Failed - GetEgressIP() error", err)
+	}
+	if ip != "127.0.0.1" {
+		t.Errorf("Test Failed - GetEgressIP() expected 127.0.0.1 received %s", ip)
+	}
+}
@@ -0,0 +1,130 @@
+package request
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// pinPrefix is the prefix of a pin in the format used by HPKP, the base64
+// encoded SHA256 hash of a certificate's DER encoded SubjectPublicKeyInfo
+const pinPrefix = "sha256/"
+
+// ErrCertificateNotPinned is returned when no certificate presented by the
+// server matches a pin
+var ErrCertificateNotPinned = errors.New("server certificate public key does not match any pinned key")
+
+// PinSet holds the pinned public key hashes for an exchange. A connection is
+// accepted if any certificate in the verified chain matches any pin, so pins
+// can be rotated by adding the new key before the exchange changes
+// certificate and removing the old key afterwards. An empty set disables
+// pinning.
+type PinSet struct {
+	pins map[string]struct{}
+	m    sync.RWMutex
+}
+
+// NewPinSet returns a new pin set from pins in the format sha256/<base64>
+func NewPinSet(pins []string) (*PinSet, error) {
+	p := &PinSet{}
+	return p, p.Update(pins)
+}
+
+// Update replaces the pins, taking effect on the next TLS handshake
+func (p *PinSet) Update(pins []string) error {
+	parsed := make(map[string]struct{}, len(pins))
+	for _, pin := range pins {
+		hash := strings.TrimPrefix(strings.TrimSpace(pin), pinPrefix)
+		b, err := base64.StdEncoding.DecodeString(hash)
+		if err != nil || len(b) != sha256.Size {
+			return fmt.Errorf("invalid TLS pin %q, expected %s<base64 SHA256 hash>",
+				pin, pinPrefix)
+		}
+		parsed[hash] = struct{}{}
+	}
+
+	p.m.Lock()
+	p.pins = parsed
+	p.m.Unlock()
+	return nil
+}
+
+// Pins returns the current pins in the format sha256/<base64>
+func (p *PinSet) Pins() []string {
+	p.m.RLock()
+	defer p.m.RUnlock()
+	var pins []string
+	for pin := range p.pins {
+		pins = append(pins, pinPrefix+pin)
+	}
+	return pins
+}
+
+// VerifyPeerCertificate satisfies tls.Config.VerifyPeerCertificate and is
+// called after the standard certificate chain verification
+func (p *PinSet) VerifyPeerCertificate(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error {
+	p.m.RLock()
+	defer p.m.RUnlock()
+	if len(p.pins) == 0 {
+		return nil
+	}
+
+	for i := range verifiedChains {
+		for j := range verifiedChains[i] {
+			if _, ok := p.pins[spkiHash(verifiedChains[i][j])]; ok {
+				return nil
+			}
+		}
+	}
+
+	// Chains are not verified when InsecureSkipVerify is set, in which case
+	// the presented certificates are checked directly
+	if len(verifiedChains) == 0 {
+		for i := range rawCerts {
+			cert, err := x509.ParseCertificate(rawCerts[i])
+			if err != nil {
+				return err
+			}
+			if _, ok := p.pins[spkiHash(cert)]; ok {
+				return nil
+			}
+		}
+	}
+	return ErrCertificateNotPinned
+}
+
+// TLSConfig returns a TLS config which verifies connections against the pins,
+// for use by websocket dialers
+func (p *PinSet) TLSConfig() *tls.Config {
+	return &tls.Config{VerifyPeerCertificate: p.VerifyPeerCertificate}
+}
+
+// PinFromCertificate returns the pin for a certificate in the format
+// sha256/<base64>
+func PinFromCertificate(cert *x509.Certificate) string {
+	return pinPrefix + spkiHash(cert)
+}
+
+// spkiHash returns the base64 encoded SHA256 hash of the certificate's
+// SubjectPublicKeyInfo
+func spkiHash(cert *x509.Certificate) string {
+	hash := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	return base64.StdEncoding.EncodeToString(hash[:])
+}
+
+// SetTLSPins verifies all HTTPS connections made by the requester against the
+// pin set, keeping any existing transport settings such as a proxy
+func (r *Requester) SetTLSPins(p *PinSet) {
+	t := r.transport()
+	if t.TLSClientConfig == nil {
+		t.TLSClientConfig = &tls.Config{}
+	} else {
+		t.TLSClientConfig = t.TLSClientConfig.Clone()
+	}
+	t.TLSClientConfig.VerifyPeerCertificate = p.VerifyPeerCertificate
+}
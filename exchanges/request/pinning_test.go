@@ -0,0 +1,61 @@
+package request
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testUnknownPin = "sha256/AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA="
+
+func TestNewPinSet(t *testing.T) {
+	if _, err := NewPinSet([]string{"sha256/notbase64!"}); err == nil {
+		t.Error("Test Failed - NewPinSet() expected invalid pin error")
+	}
+	if _, err := NewPinSet([]string{"sha256/AAAA"}); err == nil {
+		t.Error("Test Failed - NewPinSet() expected invalid hash length error")
+	}
+
+	p, err := NewPinSet([]string{testUnknownPin})
+	if err != nil {
+		t.Fatal("Test Failed - NewPinSet() error", err)
+	}
+	if pins := p.Pins(); len(pins) != 1 || pins[0] != testUnknownPin {
+		t.Error("Test Failed - Pins() incorrect pins", pins)
+	}
+}
+
+func TestSetTLSPins(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+
+	r := New("pinning", NewRateLimit(time.Second, 0), NewRateLimit(time.Second, 0),
+		server.Client())
+
+	p, err := NewPinSet([]string{testUnknownPin})
+	if err != nil {
+		t.Fatal("Test Failed - NewPinSet() error", err)
+	}
+	r.SetTLSPins(p)
+
+	_, err = r.HTTPClient.Get(server.URL)
+	if err == nil || !strings.Contains(err.Error(), ErrCertificateNotPinned.Error()) {
+		t.Error("Test Failed - SetTLSPins() expected unpinned certificate error", err)
+	}
+
+	// Rotating in the server's pin alongside the old pin allows the
+	// connection
+	err = p.Update([]string{testUnknownPin, PinFromCertificate(server.Certificate())})
+	if err != nil {
+		t.Fatal("Test Failed - Update() error", err)
+	}
+	resp, err := r.HTTPClient.Get(server.URL)
+	if err != nil {
+		t.Fatal("Test Failed - SetTLSPins() pinned request error", err)
+	}
+	resp.Body.Close()
+}
@@ -0,0 +1,295 @@
+package request
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimit is a token bucket refilled with Rate tokens every Duration, up to
+// Burst tokens. A request takes its endpoint's weight in tokens and waits for
+// the bucket to refill when too few remain. Burst defaults to Rate.
+type RateLimit struct {
+	Duration time.Duration
+	Rate     int
+	Burst    int
+
+	tokens       float64
+	last         time.Time
+	blockedUntil time.Time
+	m            sync.Mutex
+}
+
+// RateLimitHeaders names the response headers an exchange reports its rate
+// limit usage in, used to correct the limiter's tokens to the exchange's
+// count. Used is the weight used in the current window, Remaining the weight
+// left and Reset the seconds until the window resets. Empty names are ignored.
+type RateLimitHeaders struct {
+	Used      string
+	Remaining string
+	Reset     string
+}
+
+// NewRateLimit creates a new RateLimit allowing rate requests per duration
+// with a full bucket
+func NewRateLimit(d time.Duration, rate int) *RateLimit {
+	return NewBurstRateLimit(d, rate, rate)
+}
+
+// NewBurstRateLimit creates a new RateLimit refilled with rate tokens per
+// duration which allows bursts of up to burst tokens
+func NewBurstRateLimit(d time.Duration, rate, burst int) *RateLimit {
+	return &RateLimit{
+		Duration: d,
+		Rate:     rate,
+		Burst:    burst,
+		tokens:   float64(burst),
+		last:     time.Now(),
+	}
+}
+
+// ToString returns the rate limiter in string notation
+func (r *RateLimit) ToString() string {
+	r.m.Lock()
+	defer r.m.Unlock()
+	return fmt.Sprintf("Rate limiter set to %d requests per %v with a burst of %d",
+		r.Rate, r.Duration, r.capacity())
+}
+
+// GetRate returns the ratelimit rate
+func (r *RateLimit) GetRate() int {
+	r.m.Lock()
+	defer r.m.Unlock()
+	return r.Rate
+}
+
+// SetRate sets the ratelimit rate, a burst equal to the previous rate follows
+// the new rate
+func (r *RateLimit) SetRate(rate int) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	r.refill(time.Now())
+	if r.Burst == r.Rate {
+		r.Burst = rate
+	}
+	r.Rate = rate
+	r.clamp()
+}
+
+// GetBurst returns the maximum tokens the bucket holds
+func (r *RateLimit) GetBurst() int {
+	r.m.Lock()
+	defer r.m.Unlock()
+	return r.capacity()
+}
+
+// SetBurst sets the maximum tokens the bucket holds
+func (r *RateLimit) SetBurst(burst int) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	r.refill(time.Now())
+	r.Burst = burst
+	r.clamp()
+}
+
+// SetDuration sets the duration for the ratelimit
+func (r *RateLimit) SetDuration(d time.Duration) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	r.refill(time.Now())
+	r.Duration = d
+}
+
+// GetDuration gets the duration for the ratelimit
+func (r *RateLimit) GetDuration() time.Duration {
+	r.m.Lock()
+	defer r.m.Unlock()
+	return r.Duration
+}
+
+// Tokens returns the tokens currently available, negative when requests are
+// waiting for the bucket to refill
+func (r *RateLimit) Tokens() float64 {
+	r.m.Lock()
+	defer r.m.Unlock()
+	r.refill(time.Now())
+	return r.tokens
+}
+
+// Reserve takes weight tokens from the bucket and returns how long to wait
+// before sending the request. Tokens taken beyond those available are repaid
+// by the refill, so concurrent requests queue in the order they reserved.
+func (r *RateLimit) Reserve(weight int) time.Duration {
+	r.m.Lock()
+	defer r.m.Unlock()
+	now := time.Now()
+	r.refill(now)
+	r.tokens -= float64(weight)
+
+	var wait time.Duration
+	if r.tokens < 0 && r.Rate > 0 {
+		wait = time.Duration(-r.tokens / float64(r.Rate) * float64(r.Duration))
+	}
+	if blocked := r.blockedUntil.Sub(now); blocked > wait {
+		wait = blocked
+	}
+	return wait
+}
+
+// Refund returns the tokens of a reserved request which was not sent
+func (r *RateLimit) Refund(weight int) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	r.refill(time.Now())
+	r.tokens += float64(weight)
+	r.clamp()
+}
+
+// Wait reserves weight tokens and blocks until the request can be sent. The
+// tokens are refunded if the context is done first.
+func (r *RateLimit) Wait(ctx context.Context, weight int) error {
+	wait := r.Reserve(weight)
+	if wait <= 0 {
+		return nil
+	}
+	t := time.NewTimer(wait)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		r.Refund(weight)
+		return ctx.Err()
+	}
+}
+
+// SetRemaining sets the tokens available to the remaining weight reported by
+// the exchange, less the weight of requests already waiting
+func (r *RateLimit) SetRemaining(remaining float64) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	r.refill(time.Now())
+	var waiting float64
+	if r.tokens < 0 {
+		waiting = r.tokens
+	}
+	r.tokens = remaining + waiting
+	r.clamp()
+}
+
+// BlockUntil stops requests being sent before t, used when the exchange
+// rejects requests for exceeding its limits
+func (r *RateLimit) BlockUntil(t time.Time) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	if t.After(r.blockedUntil) {
+		r.blockedUntil = t
+	}
+}
+
+// capacity returns the maximum tokens held. The mutex must be held by the
+// caller.
+func (r *RateLimit) capacity() int {
+	if r.Burst > 0 {
+		return r.Burst
+	}
+	return r.Rate
+}
+
+// refill adds the tokens accrued since the last refill. The mutex must be held
+// by the caller.
+func (r *RateLimit) refill(now time.Time) {
+	if r.last.IsZero() {
+		r.tokens = float64(r.capacity())
+		r.last = now
+		return
+	}
+	if r.Duration > 0 && now.After(r.last) {
+		r.tokens += float64(r.Rate) * float64(now.Sub(r.last)) / float64(r.Duration)
+		r.clamp()
+	}
+	r.last = now
+}
+
+// clamp caps the tokens at the bucket's capacity. The mutex must be held by
+// the caller.
+func (r *RateLimit) clamp() {
+	if c := float64(r.capacity()); r.tokens > c {
+		r.tokens = c
+	}
+}
+
+// SetEndpointWeight sets the tokens a request to an endpoint takes. The
+// endpoint is a URL path, optionally prefixed by the method and a space for
+// endpoints whose weight differs by method, such as "GET /api/v3/order".
+// Endpoints without a weight take one token.
+func (r *Requester) SetEndpointWeight(endpoint string, weight int) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	if r.weights == nil {
+		r.weights = make(map[string]int)
+	}
+	r.weights[endpoint] = weight
+}
+
+// EndpointWeight returns the tokens a request to a URL path takes
+func (r *Requester) EndpointWeight(method, path string) int {
+	r.m.Lock()
+	defer r.m.Unlock()
+	if w, ok := r.weights[strings.ToUpper(method)+" "+path]; ok {
+		return w
+	}
+	if w, ok := r.weights[path]; ok {
+		return w
+	}
+	return 1
+}
+
+// SetRateLimitHeaders sets the response headers the limiter's tokens are
+// corrected from
+func (r *Requester) SetRateLimitHeaders(h RateLimitHeaders) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	r.limitHeaders = h
+}
+
+// adjustRateLimit corrects a rate limit from the usage reported by a
+// response. Requests rejected for exceeding the limit block the limiter for
+// the Retry-After period, or a full duration when none is given.
+func (r *Requester) adjustRateLimit(limit *RateLimit, resp *http.Response) {
+	r.m.Lock()
+	h := r.limitHeaders
+	r.m.Unlock()
+
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == 418 {
+		wait := limit.GetDuration()
+		if s, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+			wait = time.Duration(s) * time.Second
+		}
+		limit.BlockUntil(time.Now().Add(wait))
+		return
+	}
+
+	var remaining float64
+	if v, err := strconv.ParseFloat(resp.Header.Get(h.Remaining), 64); h.Remaining != "" && err == nil {
+		remaining = v
+	} else if v, err := strconv.ParseFloat(resp.Header.Get(h.Used), 64); h.Used != "" && err == nil {
+		remaining = float64(limit.GetBurst()) - v
+	} else {
+		return
+	}
+	if remaining < 0 {
+		remaining = 0
+	}
+	limit.SetRemaining(remaining)
+	if remaining > 0 || h.Reset == "" {
+		return
+	}
+	if s, err := strconv.ParseFloat(resp.Header.Get(h.Reset), 64); err == nil {
+		limit.BlockUntil(time.Now().Add(time.Duration(s * float64(time.Second))))
+	}
+}
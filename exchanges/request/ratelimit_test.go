@@ -0,0 +1,109 @@
+package request
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRateLimitToString(t *testing.T) {
+	r := NewBurstRateLimit(time.Second*10, 5, 20)
+	if r.ToString() != "Rate limiter set to 5 requests per 10s with a burst of 20" {
+		t.Error("unexpected values", r.ToString())
+	}
+}
+
+func TestReserve(t *testing.T) {
+	r := NewBurstRateLimit(time.Second, 10, 20)
+	if wait := r.Reserve(20); wait != 0 {
+		t.Error("burst should not wait", wait)
+	}
+	// 5 tokens short at 10 tokens per second
+	if wait := r.Reserve(5); wait < time.Millisecond*450 || wait > time.Millisecond*500 {
+		t.Error("unexpected wait", wait)
+	}
+	r.Refund(5)
+	if tokens := r.Tokens(); tokens < 0 || tokens > 1 {
+		t.Error("unexpected tokens after refund", tokens)
+	}
+
+	r.SetRate(20)
+	if r.GetBurst() != 20 {
+		t.Error("explicit burst should not follow rate", r.GetBurst())
+	}
+	r = NewRateLimit(time.Second, 10)
+	r.SetRate(50)
+	if r.GetBurst() != 50 {
+		t.Error("default burst should follow rate", r.GetBurst())
+	}
+
+	r.BlockUntil(time.Now().Add(time.Minute))
+	if wait := r.Reserve(1); wait < time.Second*59 {
+		t.Error("blocked limit should wait", wait)
+	}
+}
+
+func TestWait(t *testing.T) {
+	r := NewRateLimit(time.Minute, 1)
+	if err := r.Wait(context.Background(), 1); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*20)
+	defer cancel()
+	if err := r.Wait(ctx, 1); err != context.DeadlineExceeded {
+		t.Error("expected expired wait", err)
+	}
+	if tokens := r.Tokens(); tokens < 0 {
+		t.Error("tokens of abandoned wait were not refunded", tokens)
+	}
+}
+
+func TestEndpointWeight(t *testing.T) {
+	r := New("test", NewRateLimit(time.Second, 10), NewRateLimit(time.Second, 10), new(http.Client))
+	r.SetEndpointWeight("/api/v3/order", 1)
+	r.SetEndpointWeight("GET /api/v3/order", 2)
+	if w := r.EndpointWeight("get", "/api/v3/order"); w != 2 {
+		t.Error("unexpected method weight", w)
+	}
+	if w := r.EndpointWeight("POST", "/api/v3/order"); w != 1 {
+		t.Error("unexpected path weight", w)
+	}
+	if w := r.EndpointWeight("GET", "/api/v3/time"); w != 1 {
+		t.Error("unexpected default weight", w)
+	}
+}
+
+func TestAdjustRateLimit(t *testing.T) {
+	var used, status string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("X-Used-Weight", used)
+		if status == "429" {
+			w.Header().Set("Retry-After", "30")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	}))
+	defer srv.Close()
+
+	limit := NewRateLimit(time.Minute, 100)
+	r := New("test", limit, limit, new(http.Client))
+	r.SetRateLimitHeaders(RateLimitHeaders{Used: "X-Used-Weight"})
+	r.SetEndpointWeight("/heavy", 10)
+
+	used = "60"
+	if err := r.SendPayload("GET", srv.URL+"/heavy", nil, nil, nil, false, false); err != nil {
+		t.Fatal(err)
+	}
+	if tokens := limit.Tokens(); tokens < 40 || tokens > 41 {
+		t.Error("tokens not corrected from used weight header", tokens)
+	}
+
+	status = "429"
+	if err := r.SendPayload("GET", srv.URL, nil, nil, nil, false, false); err == nil {
+		t.Fatal("expected rejected request")
+	}
+	if wait := limit.Reserve(1); wait < time.Second*29 {
+		t.Error("rejected request did not block the limiter", wait)
+	}
+}
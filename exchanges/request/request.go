@@ -1,6 +1,7 @@
 package request
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -18,7 +19,6 @@ import (
 var supportedMethods = []string{"GET", "POST", "HEAD", "PUT", "DELETE", "OPTIONS", "CONNECT"}
 
 const (
-	maxRequestJobs              = 50
 	proxyTLSTimeout             = 15 * time.Second
 	defaultTimeoutRetryAttempts = 3
 )
@@ -30,111 +30,13 @@ type Requester struct {
 	AuthLimit            *RateLimit
 	Name                 string
 	UserAgent            string
-	Cycle                time.Time
+	Headers              map[string]string
 	timeoutRetryAttempts int
+	weights              map[string]int
+	limitHeaders         RateLimitHeaders
 	m                    sync.Mutex
-	Jobs                 chan Job
-	WorkerStarted        bool
-}
-
-// RateLimit struct
-type RateLimit struct {
-	Duration time.Duration
-	Rate     int
-	Requests int
-	Mutex    sync.Mutex
-}
-
-// JobResult holds a request job result
-type JobResult struct {
-	Error  error
-	Result interface{}
-}
-
-// Job holds a request job
-type Job struct {
-	Request     *http.Request
-	Method      string
-	Path        string
-	Headers     map[string]string
-	Body        io.Reader
-	Result      interface{}
-	JobResult   chan *JobResult
-	AuthRequest bool
-	Verbose     bool
-}
-
-// NewRateLimit creates a new RateLimit
-func NewRateLimit(d time.Duration, rate int) *RateLimit {
-	return &RateLimit{Duration: d, Rate: rate}
-}
-
-// ToString returns the rate limiter in string notation
-func (r *RateLimit) ToString() string {
-	return fmt.Sprintf("Rate limiter set to %d requests per %v", r.Rate, r.Duration)
-}
-
-// GetRate returns the ratelimit rate
-func (r *RateLimit) GetRate() int {
-	r.Mutex.Lock()
-	defer r.Mutex.Unlock()
-	return r.Rate
-}
-
-// SetRate sets the ratelimit rate
-func (r *RateLimit) SetRate(rate int) {
-	r.Mutex.Lock()
-	defer r.Mutex.Unlock()
-	r.Rate = rate
-}
-
-// GetRequests returns the number of requests for the ratelimit
-func (r *RateLimit) GetRequests() int {
-	r.Mutex.Lock()
-	defer r.Mutex.Unlock()
-	return r.Requests
-}
-
-// SetRequests sets requests counter for the rateliit
-func (r *RateLimit) SetRequests(l int) {
-	r.Mutex.Lock()
-	defer r.Mutex.Unlock()
-	r.Requests = l
-}
-
-// SetDuration sets the duration for the ratelimit
-func (r *RateLimit) SetDuration(d time.Duration) {
-	r.Mutex.Lock()
-	defer r.Mutex.Unlock()
-	r.Duration = d
-}
-
-// GetDuration gets the duration for the ratelimit
-func (r *RateLimit) GetDuration() time.Duration {
-	r.Mutex.Lock()
-	defer r.Mutex.Unlock()
-	return r.Duration
-}
-
-// StartCycle restarts the cycle time and requests counters
-func (r *Requester) StartCycle() {
-	r.Cycle = time.Now()
-	r.AuthLimit.SetRequests(0)
-	r.UnauthLimit.SetRequests(0)
-}
-
-// IsRateLimited returns whether or not the request Requester is rate limited
-func (r *Requester) IsRateLimited(auth bool) bool {
-	if auth {
-		if r.AuthLimit.GetRequests() >= r.AuthLimit.GetRate() && r.IsValidCycle(auth) {
-			return true
-		}
-	} else {
-		if r.UnauthLimit.GetRequests() >= r.UnauthLimit.GetRate() && r.IsValidCycle(auth) {
-			return true
-		}
-	}
-	return false
+	lastAuthSent         time.Time
+	cassette             *Cassette
 }
 
 // RequiresRateLimiter returns whether or not the request Requester requires a rate limiter
@@ -145,36 +47,6 @@ func (r *Requester) RequiresRateLimiter() bool {
 	return false
 }
 
-// IncrementRequests increments the ratelimiter request counter for either auth or unauth
-// requests
-func (r *Requester) IncrementRequests(auth bool) {
-	if auth {
-		reqs := r.AuthLimit.GetRequests()
-		reqs++
-		r.AuthLimit.SetRequests(reqs)
-		return
-	}
-
-	reqs := r.UnauthLimit.GetRequests()
-	reqs++
-	r.UnauthLimit.SetRequests(reqs)
-}
-
-// DecrementRequests decrements the ratelimiter request counter for either auth or unauth
-// requests
-func (r *Requester) DecrementRequests(auth bool) {
-	if auth {
-		reqs := r.AuthLimit.GetRequests()
-		reqs--
-		r.AuthLimit.SetRequests(reqs)
-		return
-	}
-
-	reqs := r.AuthLimit.GetRequests()
-	reqs--
-	r.UnauthLimit.SetRequests(reqs)
-}
-
 // SetRateLimit sets the request Requester ratelimiter
 func (r *Requester) SetRateLimit(auth bool, duration time.Duration, rate int) {
 	if auth {
@@ -211,7 +83,6 @@ func New(name string, authLimit, unauthLimit *RateLimit, httpRequester *http.Cli
 		UnauthLimit:          unauthLimit,
 		AuthLimit:            authLimit,
 		Name:                 name,
-		Jobs:                 make(chan Job, maxRequestJobs),
 		timeoutRetryAttempts: defaultTimeoutRetryAttempts,
 	}
 }
@@ -221,22 +92,6 @@ func IsValidMethod(method string) bool {
 	return common.StringDataCompareUpper(supportedMethods, method)
 }
 
-// IsValidCycle checks to see whether the current request cycle is valid or not
-func (r *Requester) IsValidCycle(auth bool) bool {
-	if auth {
-		if time.Since(r.Cycle) < r.AuthLimit.GetDuration() {
-			return true
-		}
-	} else {
-		if time.Since(r.Cycle) < r.UnauthLimit.GetDuration() {
-			return true
-		}
-	}
-
-	r.StartCycle()
-	return false
-}
-
 func (r *Requester) checkRequest(method, path string, body io.Reader, headers map[string]string) (*http.Request, error) {
 	req, err := http.NewRequest(method, path, body)
 	if err != nil {
@@ -251,11 +106,29 @@ func (r *Requester) checkRequest(method, path string, body io.Reader, headers ma
 		req.Header.Add("User-Agent", r.UserAgent)
 	}
 
+	// Custom headers never replace the headers of a request, such as
+	// authentication signatures
+	for k, v := range r.Headers {
+		if req.Header.Get(k) == "" {
+			req.Header.Add(k, v)
+		}
+	}
+
 	return req, nil
 }
 
 // DoRequest performs a HTTP/HTTPS request with the supplied params
 func (r *Requester) DoRequest(req *http.Request, method, path string, headers map[string]string, body io.Reader, result interface{}, authRequest, verbose bool) error {
+	resp, contents, err := r.doRequest(req, path, headers, body, authRequest, verbose)
+	if err != nil {
+		return err
+	}
+	return r.decodeResponse(resp, contents, result, verbose)
+}
+
+// doRequest sends the request, retrying it when it times out, and returns the
+// response with its body read
+func (r *Requester) doRequest(req *http.Request, path string, headers map[string]string, body io.Reader, authRequest, verbose bool) (*http.Response, []byte, error) {
 	if verbose {
 		log.Printf("%s exchange request path: %s requires rate limiter: %v", r.Name, path, r.RequiresRateLimiter())
 		for k, d := range headers {
@@ -264,10 +137,36 @@ func (r *Requester) DoRequest(req *http.Request, method, path string, headers ma
 		log.Println(body)
 	}
 
+	cassette := r.GetCassette()
+	var reqBody []byte
+	if cassette != nil && req.GetBody != nil {
+		b, err := req.GetBody()
+		if err != nil {
+			return nil, nil, err
+		}
+		reqBody, err = ioutil.ReadAll(b)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	if cassette != nil && cassette.Mode == CassetteReplay {
+		return cassette.replay(req, reqBody)
+	}
+
 	var timeoutError error
 	for i := 0; i < r.timeoutRetryAttempts+1; i++ {
+		if authRequest {
+			r.m.Lock()
+			r.lastAuthSent = time.Now()
+			r.m.Unlock()
+		}
 		resp, err := r.HTTPClient.Do(req)
 		if err != nil {
+			// A cancelled or expired request context is never retried
+			if ctxErr := req.Context().Err(); ctxErr != nil {
+				return nil, nil, ctxErr
+			}
+
 			if timeoutErr, ok := err.(net.Error); ok && timeoutErr.Timeout() {
 				if verbose {
 					log.Printf("%s request has timed-out retrying request, count %d",
@@ -278,153 +177,200 @@ func (r *Requester) DoRequest(req *http.Request, method, path string, headers ma
 				continue
 			}
 
-			if r.RequiresRateLimiter() {
-				r.DecrementRequests(authRequest)
-			}
-			return err
+			return nil, nil, err
 		}
 		if resp == nil {
-			if r.RequiresRateLimiter() {
-				r.DecrementRequests(authRequest)
-			}
-			return errors.New("resp is nil")
+			return nil, nil, errors.New("resp is nil")
+		}
+
+		if r.RequiresRateLimiter() {
+			r.adjustRateLimit(r.GetRateLimit(authRequest), resp)
 		}
+		r.checkDeprecation(req, resp)
 
 		contents, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
 		if err != nil {
-			return err
+			return nil, nil, err
 		}
-
-		if resp.StatusCode != 200 && resp.StatusCode != 201 && resp.StatusCode != 202 {
-			err = fmt.Errorf("unsuccessful HTTP status code: %d", resp.StatusCode)
-
-			if verbose {
-				err = fmt.Errorf("%s\n%s", err.Error(),
-					fmt.Sprintf("%s exchange raw response: %s", r.Name, string(contents)))
-			}
-
-			return err
+		if cassette != nil {
+			cassette.record(req, reqBody, resp, contents)
 		}
+		return resp, contents, nil
+	}
+	return nil, nil, fmt.Errorf("request.go error - failed to retry request %s",
+		timeoutError)
+}
+
+// decodeResponse checks the response status code and decodes its body into
+// result
+func (r *Requester) decodeResponse(resp *http.Response, contents []byte, result interface{}, verbose bool) error {
+	if resp.StatusCode != 200 && resp.StatusCode != 201 && resp.StatusCode != 202 {
+		err := fmt.Errorf("unsuccessful HTTP status code: %d", resp.StatusCode)
 
-		resp.Body.Close()
 		if verbose {
-			log.Printf("HTTP status: %s, Code: %v", resp.Status, resp.StatusCode)
-			log.Printf("%s exchange raw response: %s", r.Name, string(contents))
+			err = fmt.Errorf("%s\n%s", err.Error(),
+				fmt.Sprintf("%s exchange raw response: %s", r.Name, string(contents)))
 		}
 
-		if result != nil {
-			return common.JSONDecode(contents, result)
-		}
+		return err
+	}
 
-		return nil
+	if verbose {
+		log.Printf("HTTP status: %s, Code: %v", resp.Status, resp.StatusCode)
+		log.Printf("%s exchange raw response: %s", r.Name, string(contents))
 	}
-	return fmt.Errorf("request.go error - failed to retry request %s",
-		timeoutError)
-}
 
-func (r *Requester) worker() {
-	for {
-		for x := range r.Jobs {
-			if !r.IsRateLimited(x.AuthRequest) {
-				r.IncrementRequests(x.AuthRequest)
+	if result != nil {
+		return common.JSONDecode(contents, result)
+	}
 
-				err := r.DoRequest(x.Request, x.Method, x.Path, x.Headers, x.Body, x.Result, x.AuthRequest, x.Verbose)
-				x.JobResult <- &JobResult{
-					Error:  err,
-					Result: x.Result,
-				}
-			} else {
-				limit := r.GetRateLimit(x.AuthRequest)
-				diff := limit.GetDuration() - time.Since(r.Cycle)
-				if x.Verbose {
-					log.Printf("%s request. Rate limited! Sleeping for %v", r.Name, diff)
-				}
-				time.Sleep(diff)
-
-				for {
-					if !r.IsRateLimited(x.AuthRequest) {
-						r.IncrementRequests(x.AuthRequest)
-
-						if x.Verbose {
-							log.Printf("%s request. No longer rate limited! Doing request", r.Name)
-						}
-
-						err := r.DoRequest(x.Request, x.Method, x.Path, x.Headers, x.Body, x.Result, x.AuthRequest, x.Verbose)
-						x.JobResult <- &JobResult{
-							Error:  err,
-							Result: x.Result,
-						}
-						break
-					}
-				}
-			}
-		}
+	return nil
+}
+
+// LastAuthRequestSent returns when the most recent authenticated request was
+// handed to the HTTP client, used to measure order submission latency
+func (r *Requester) LastAuthRequestSent() time.Time {
+	if r == nil {
+		return time.Time{}
 	}
+	r.m.Lock()
+	defer r.m.Unlock()
+	return r.lastAuthSent
 }
 
 // SendPayload handles sending HTTP/HTTPS requests
 func (r *Requester) SendPayload(method, path string, headers map[string]string, body io.Reader, result interface{}, authRequest, verbose bool) error {
+	return r.SendPayloadContext(context.Background(), method, path, headers, body, result, authRequest, verbose)
+}
+
+// SendPayloadContext handles sending HTTP/HTTPS requests which are abandoned
+// when the context is cancelled or expires, whether waiting on the rate limiter
+// or in flight
+func (r *Requester) SendPayloadContext(ctx context.Context, method, path string, headers map[string]string, body io.Reader, result interface{}, authRequest, verbose bool) error {
+	resp, contents, err := r.sendPayload(ctx, method, path, headers, body, authRequest, verbose)
+	if err != nil {
+		return err
+	}
+	return r.decodeResponse(resp, contents, result, verbose)
+}
+
+// sendPayload checks and rate limits a request, then sends it and returns the
+// response with its body read
+func (r *Requester) sendPayload(ctx context.Context, method, path string, headers map[string]string, body io.Reader, authRequest, verbose bool) (*http.Response, []byte, error) {
 	if r == nil || r.Name == "" {
-		return errors.New("not initiliased, SetDefaults() called before making request?")
+		return nil, nil, errors.New("not initiliased, SetDefaults() called before making request?")
 	}
 
 	if !IsValidMethod(method) {
-		return fmt.Errorf("incorrect method supplied %s: supported %s", method, supportedMethods)
+		return nil, nil, fmt.Errorf("incorrect method supplied %s: supported %s", method, supportedMethods)
 	}
 
 	if path == "" {
-		return errors.New("invalid path")
+		return nil, nil, errors.New("invalid path")
 	}
 
 	req, err := r.checkRequest(method, path, body, headers)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
+	req = req.WithContext(ctx)
 
-	if !r.RequiresRateLimiter() {
-		return r.DoRequest(req, method, path, headers, body, result, authRequest, verbose)
+	if !r.RequiresRateLimiter() || r.replaying() {
+		return r.doRequest(req, path, headers, body, authRequest, verbose)
 	}
 
-	if len(r.Jobs) == maxRequestJobs {
-		return errors.New("max request jobs reached")
+	limit := r.GetRateLimit(authRequest)
+	weight := r.EndpointWeight(method, req.URL.Path)
+	if verbose {
+		log.Printf("%s request. Waiting for %d rate limit tokens, %.2f available.",
+			r.Name, weight, limit.Tokens())
 	}
-
-	r.m.Lock()
-	if !r.WorkerStarted {
-		r.StartCycle()
-		r.WorkerStarted = true
-		go r.worker()
+	if err = limit.Wait(ctx, weight); err != nil {
+		return nil, nil, err
 	}
-	r.m.Unlock()
+	return r.doRequest(req, path, headers, body, authRequest, verbose)
+}
 
-	jobResult := make(chan *JobResult)
+// SendCachedPayload sends a GET request for large static data such as symbol
+// lists or currency chains through the metadata disk cache. A fresh cached
+// response is decoded without a request, a stale one is revalidated with
+// If-None-Match and If-Modified-Since where the exchange returned an ETag or
+// Last-Modified header. Without a metadata cache the request is sent as
+// normal.
+func (r *Requester) SendCachedPayload(path string, headers map[string]string, result interface{}, verbose bool) error {
+	cache := GetMetadataCache()
+	if cache == nil {
+		return r.SendPayload("GET", path, headers, nil, result, false, verbose)
+	}
 
-	newJob := Job{
-		Request:     req,
-		Method:      method,
-		Path:        path,
-		Headers:     headers,
-		Body:        body,
-		Result:      result,
-		JobResult:   jobResult,
-		AuthRequest: authRequest,
-		Verbose:     verbose,
+	entry, cached, ok := cache.Get(path)
+	if ok && cache.Fresh(entry) {
+		if verbose {
+			log.Printf("%s exchange request path: %s served from cache", r.Name, path)
+		}
+		return common.JSONDecode(cached, result)
 	}
 
-	if verbose {
-		log.Printf("%s request. Attaching new job.", r.Name)
+	conditional := make(map[string]string, len(headers)+2)
+	for k, v := range headers {
+		conditional[k] = v
+	}
+	if ok && entry.ETag != "" {
+		conditional["If-None-Match"] = entry.ETag
+	}
+	if ok && entry.LastModified != "" {
+		conditional["If-Modified-Since"] = entry.LastModified
 	}
-	r.Jobs <- newJob
 
-	if verbose {
-		log.Printf("%s request. Waiting for job to complete.", r.Name)
+	resp, contents, err := r.sendPayload(context.Background(), "GET", path, conditional, nil, false, verbose)
+	if err != nil {
+		return err
+	}
+	if ok && resp.StatusCode == http.StatusNotModified {
+		if verbose {
+			log.Printf("%s exchange request path: %s not modified, served from cache", r.Name, path)
+		}
+		cache.Revalidated(path)
+		return common.JSONDecode(cached, result)
+	}
+	if err = r.decodeResponse(resp, contents, result, verbose); err != nil {
+		return err
 	}
-	resp := <-newJob.JobResult
+	if err = cache.Put(path, contents, resp.Header.Get("ETag"), resp.Header.Get("Last-Modified")); err != nil {
+		log.Printf("%s failed to cache response of %s. Err: %s", r.Name, path, err)
+	}
+	return nil
+}
 
-	if verbose {
-		log.Printf("%s request. Job complete.", r.Name)
+// UncachePayload removes a cached response, exchanges call this when a cached
+// response holds an exchange error
+func (r *Requester) UncachePayload(path string) {
+	if cache := GetMetadataCache(); cache != nil {
+		cache.Remove(path)
 	}
-	return resp.Error
+}
+
+// SetCassette records the requester's requests to, or replays them from, a
+// cassette, nil sends requests as normal
+func (r *Requester) SetCassette(c *Cassette) {
+	r.m.Lock()
+	r.cassette = c
+	r.m.Unlock()
+}
+
+// GetCassette returns the requester's cassette, nil when not set
+func (r *Requester) GetCassette() *Cassette {
+	r.m.Lock()
+	defer r.m.Unlock()
+	return r.cassette
+}
+
+// replaying returns whether requests are replayed from a cassette, which
+// skips rate limiting
+func (r *Requester) replaying() bool {
+	c := r.GetCassette()
+	return c != nil && c.Mode == CassetteReplay
 }
 
 // SetProxy sets a proxy address to the client transport
@@ -433,9 +379,30 @@ func (r *Requester) SetProxy(p *url.URL) error {
 		return errors.New("No proxy URL supplied")
 	}
 
-	r.HTTPClient.Transport = &http.Transport{
+	transport := &http.Transport{
 		Proxy:               http.ProxyURL(p),
 		TLSHandshakeTimeout: proxyTLSTimeout,
 	}
+	// Keep any TLS pinning or local address binding already applied to the
+	// transport
+	if t, ok := r.HTTPClient.Transport.(*http.Transport); ok && t != nil {
+		transport.TLSClientConfig = t.TLSClientConfig
+		transport.DialContext = t.DialContext
+	}
+	r.HTTPClient.Transport = transport
 	return nil
 }
+
+// transport returns the client's HTTP transport, replacing a nil or custom
+// round tripper with a new transport
+func (r *Requester) transport() *http.Transport {
+	t, ok := r.HTTPClient.Transport.(*http.Transport)
+	if !ok || t == nil {
+		t = &http.Transport{
+			Proxy:               http.ProxyFromEnvironment,
+			TLSHandshakeTimeout: proxyTLSTimeout,
+		}
+		r.HTTPClient.Transport = t
+	}
+	return t
+}
@@ -0,0 +1,307 @@
+// Package simulator is an offline exchange for local development. It quotes
+// a synthetic market whose mid prices follow a random walk and matches orders
+// with the paper trading engine, applying configurable latency, book depth
+// and fee tiers, so the bot can be run end to end without network access.
+package simulator
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/assets"
+	"github.com/thrasher-/gocryptotrader/exchanges/fees"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+// Errors returned when setting up the simulated market
+var (
+	ErrInvalidSettings = errors.New("simulator latency, spread, volatility, depth levels and level amount cannot be negative")
+	ErrInvalidPrice    = errors.New("simulator prices must be greater than zero")
+)
+
+// Simulator is the overarching type across the simulator package
+type Simulator struct {
+	exchange.Base
+	latency     time.Duration
+	spread      float64
+	volatility  float64
+	depthLevels int
+	levelAmount float64
+	prices      map[string]float64
+	markets     map[string]*market
+	rand        *rand.Rand
+	m           sync.Mutex
+}
+
+// SetDefaults sets the basic defaults for the Simulator
+func (s *Simulator) SetDefaults() {
+	s.Name = "Simulator"
+	s.Enabled = false
+	s.Verbose = false
+	s.RESTPollingDelay = 10
+	s.APIWithdrawPermissions = exchange.NoAPIWithdrawalMethods
+	s.Features = exchange.Features{
+		CanGetTicker:       true,
+		CanGetOrderbook:    true,
+		CanGetAccountInfo:  true,
+		CanSubmitOrder:     true,
+		CanModifyOrder:     true,
+		CanCancelOrder:     true,
+		CanCancelAllOrders: true,
+		CanGetOrderInfo:    true,
+		CanGetActiveOrders: true,
+		CanGetOrderHistory: true,
+	}
+	s.Features.Orders = map[string]exchange.OrderCapabilities{
+		assets.Spot: {
+			OrderTypes:  []exchange.OrderType{exchange.Limit, exchange.Market, exchange.ImmediateOrCancel},
+			TimeInForce: []exchange.TimeInForce{exchange.GTC, exchange.IOC},
+		},
+	}
+	s.RequestCurrencyPairFormat.Delimiter = "-"
+	s.RequestCurrencyPairFormat.Uppercase = true
+	s.ConfigCurrencyPairFormat.Delimiter = "-"
+	s.ConfigCurrencyPairFormat.Uppercase = true
+	s.AssetTypes = []string{ticker.Spot}
+	s.SupportsAutoPairUpdating = true
+	s.SupportsRESTTickerBatching = false
+	err := s.setMarket(nil)
+	if err != nil {
+		log.Fatal(err)
+	}
+	s.WebsocketInit()
+}
+
+// Setup takes in the supplied exchange configuration details and sets params.
+// Orders are always simulated, funded with the config's simulation balances.
+func (s *Simulator) Setup(exch config.ExchangeConfig) {
+	if !exch.Enabled {
+		s.SetEnabled(false)
+	} else {
+		s.Enabled = true
+		s.AuthenticatedAPISupport = exch.AuthenticatedAPISupport
+		s.SetAPIKeys(exch.APIKey, exch.APISecret, "", false)
+		s.RESTPollingDelay = exch.RESTPollingDelay
+		s.Verbose = exch.Verbose
+		s.BaseCurrencies = common.SplitStrings(exch.BaseCurrencies, ",")
+		s.AvailablePairs = common.SplitStrings(exch.AvailablePairs, ",")
+		s.EnabledPairs = common.SplitStrings(exch.EnabledPairs, ",")
+		err := s.SetCurrencyPairFormat()
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = s.SetAssetTypes()
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = s.SetAutoPairDefaults()
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = s.SetRoundingModes(exch.RoundingModes)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = s.setMarket(exch.Simulator)
+		if err != nil {
+			log.Fatal(err)
+		}
+		s.SetSimulation(true, exch.SimulationBalances)
+		s.GetSimulator().Data = s.book
+		s.updateFees()
+	}
+}
+
+// setMarket applies the simulator config, zero values and a nil config use
+// the defaults. Fee tiers are registered as the exchange's fee schedule.
+func (s *Simulator) setMarket(cfg *config.SimulatorConfig) error {
+	if cfg == nil {
+		cfg = new(config.SimulatorConfig)
+	}
+	if cfg.Latency < 0 || cfg.Spread < 0 || cfg.Volatility < 0 ||
+		cfg.DepthLevels < 0 || cfg.LevelAmount < 0 {
+		return ErrInvalidSettings
+	}
+
+	prices := defaultPrices
+	if len(cfg.Prices) > 0 {
+		prices = make(map[string]float64, len(cfg.Prices))
+		for k, v := range cfg.Prices {
+			if v <= 0 {
+				return ErrInvalidPrice
+			}
+			p := pair.NewCurrencyPairDelimiter(k, s.ConfigCurrencyPairFormat.Delimiter)
+			prices[marketKey(p)] = v
+		}
+	}
+
+	schedule := DefaultFeeSchedule
+	if len(cfg.FeeTiers) > 0 {
+		schedule = fees.Schedule{
+			VolumeCurrency: DefaultFeeSchedule.VolumeCurrency,
+			Tiers:          cfg.FeeTiers,
+		}
+	}
+	err := fees.Register(s.Name, schedule)
+	if err != nil {
+		return err
+	}
+
+	seed := cfg.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.latency = cfg.Latency
+	s.spread = valueOrDefault(cfg.Spread, defaultSpread)
+	s.volatility = valueOrDefault(cfg.Volatility, defaultVolatility)
+	s.depthLevels = cfg.DepthLevels
+	if s.depthLevels == 0 {
+		s.depthLevels = defaultDepthLevels
+	}
+	s.levelAmount = valueOrDefault(cfg.LevelAmount, defaultLevelAmount)
+	s.prices = prices
+	s.markets = make(map[string]*market)
+	s.rand = rand.New(rand.NewSource(seed))
+	return nil
+}
+
+// valueOrDefault returns v, or def when v is zero
+func valueOrDefault(v, def float64) float64 {
+	if v == 0 {
+		return def
+	}
+	return v
+}
+
+// marketKey returns the key of a currency pair's market
+func marketKey(p pair.CurrencyPair) string {
+	return p.Display("-", true).String()
+}
+
+// pricedPairs returns the currency pairs with configured prices in config
+// format
+func (s *Simulator) pricedPairs() []string {
+	s.m.Lock()
+	defer s.m.Unlock()
+	pairs := make([]string, 0, len(s.prices))
+	for k := range s.prices {
+		pairs = append(pairs, k)
+	}
+	sort.Strings(pairs)
+	return pairs
+}
+
+// market returns a currency pair's market, opening it at its configured price
+// on first use. s.m must be held.
+func (s *Simulator) market(p pair.CurrencyPair) *market {
+	key := marketKey(p)
+	mkt, ok := s.markets[key]
+	if !ok {
+		price, ok := s.prices[key]
+		if !ok {
+			price = defaultPrice
+		}
+		mkt = &market{Mid: price, High: price, Low: price}
+		s.markets[key] = mkt
+	}
+	return mkt
+}
+
+// step moves a market's mid price by a normally distributed return with a
+// standard deviation of volatility percent. s.m must be held.
+func (s *Simulator) step(mkt *market) {
+	mkt.Mid *= math.Exp(s.volatility / 100 * s.rand.NormFloat64())
+	mkt.High = math.Max(mkt.High, mkt.Mid)
+	mkt.Low = math.Min(mkt.Low, mkt.Mid)
+}
+
+// orderbook returns the book quoted around a mid price. The best bid and ask
+// are half the spread from the mid, each further level another spread away,
+// with levelAmount at every level. s.m must be held.
+func (s *Simulator) orderbook(mid float64, p pair.CurrencyPair, assetType string) orderbook.Base {
+	book := orderbook.Base{Pair: p, AssetType: assetType}
+	width := mid * s.spread / 100
+	for i := 0; i < s.depthLevels; i++ {
+		offset := width/2 + float64(i)*width
+		if mid-offset > 0 {
+			book.Bids = append(book.Bids, orderbook.Item{Price: mid - offset, Amount: s.levelAmount})
+		}
+		book.Asks = append(book.Asks, orderbook.Item{Price: mid + offset, Amount: s.levelAmount})
+	}
+	return book
+}
+
+// book returns the current book of a currency pair, the paper trading engine
+// matches orders against it
+func (s *Simulator) book(p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.orderbook(s.market(p).Mid, p, assetType), nil
+}
+
+// wait delays an order request by the configured latency
+func (s *Simulator) wait() {
+	s.m.Lock()
+	latency := s.latency
+	s.m.Unlock()
+	if latency > 0 {
+		time.Sleep(latency)
+	}
+}
+
+// updateFees sets the maker and taker fees charged on fills from the fee
+// schedule's tier for the exchange's trading volume
+func (s *Simulator) updateFees() {
+	maker, err := fees.TradeRate(s.Name, true)
+	if err != nil {
+		log.Printf("%s unable to get maker fee rate: %s", s.Name, err)
+		return
+	}
+	taker, err := fees.TradeRate(s.Name, false)
+	if err != nil {
+		log.Printf("%s unable to get taker fee rate: %s", s.Name, err)
+		return
+	}
+	s.MakerFee = maker * 100
+	s.TakerFee = taker * 100
+	if e := s.GetSimulator(); e != nil {
+		e.MakerFee = s.MakerFee
+		e.TakerFee = s.TakerFee
+	}
+}
+
+// checkAuth returns an error when authenticated API support is disabled,
+// simulated account and order requests are gated on it like any exchange's
+func (s *Simulator) checkAuth() error {
+	if !s.AuthenticatedAPISupport || !s.IsSimulated() {
+		return fmt.Errorf(exchange.WarningAuthenticatedRequestWithoutCredentialsSet, s.Name)
+	}
+	return nil
+}
+
+// GetFee returns an estimate of fee based on type of transaction
+func (s *Simulator) GetFee(feeBuilder exchange.FeeBuilder) (float64, error) {
+	var fee float64
+	if feeBuilder.FeeType == exchange.CryptocurrencyTradeFee {
+		rate, err := fees.TradeRate(s.Name, feeBuilder.IsMaker)
+		if err != nil {
+			return 0, err
+		}
+		fee = rate * feeBuilder.PurchasePrice * feeBuilder.Amount
+	}
+	return fee, nil
+}
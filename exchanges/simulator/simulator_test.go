@@ -0,0 +1,191 @@
+package simulator
+
+import (
+	"math"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/conformance"
+	"github.com/thrasher-/gocryptotrader/exchanges/fees"
+	"github.com/thrasher-/gocryptotrader/exchanges/paper"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+var (
+	s      Simulator
+	btcusd = pair.NewCurrencyPairDelimiter("BTC-USD", "-")
+)
+
+// setMid moves a market's mid price
+func setMid(p pair.CurrencyPair, mid float64) {
+	s.m.Lock()
+	s.market(p).Mid = mid
+	s.m.Unlock()
+}
+
+func TestSetDefaults(t *testing.T) {
+	s.SetDefaults()
+	if s.GetName() != "Simulator" {
+		t.Error("Test Failed - Simulator - SetDefaults() error")
+	}
+}
+
+func TestSetup(t *testing.T) {
+	cfg := config.GetConfig()
+	cfg.LoadConfig("../../testdata/configtest.json")
+	simulatorConfig, err := cfg.GetExchangeConfig("Simulator")
+	if err != nil {
+		t.Fatal("Test Failed - Simulator Setup() init error", err)
+	}
+
+	market := *simulatorConfig.Simulator
+	market.Latency = 0
+	market.Seed = 1
+	simulatorConfig.Simulator = &market
+	s.Setup(simulatorConfig)
+
+	if !s.IsSimulated() || s.MakerFee != 0.1 || s.TakerFee != 0.2 {
+		t.Error("Test Failed - Setup() unexpected simulation settings", s.IsSimulated(), s.MakerFee, s.TakerFee)
+	}
+	schedule, err := fees.Get(s.Name)
+	if err != nil || len(schedule.Tiers) != 2 {
+		t.Error("Test Failed - Setup() expected config fee tiers", schedule, err)
+	}
+}
+
+func TestConformance(t *testing.T) {
+	cfg := config.GetConfig()
+	cfg.LoadConfig("../../testdata/configtest.json")
+	simulatorConfig, err := cfg.GetExchangeConfig("Simulator")
+	if err != nil {
+		t.Fatal("Test Failed - Simulator conformance init error", err)
+	}
+
+	conformance.Run(t, func() exchange.IBotExchange { return new(Simulator) }, simulatorConfig)
+}
+
+func TestSetMarket(t *testing.T) {
+	var sim Simulator
+	sim.SetDefaults()
+	if err := sim.setMarket(&config.SimulatorConfig{Spread: -1}); err != ErrInvalidSettings {
+		t.Error("Test Failed - setMarket() expected invalid settings error", err)
+	}
+	if err := sim.setMarket(&config.SimulatorConfig{Prices: map[string]float64{"BTC-USD": 0}}); err != ErrInvalidPrice {
+		t.Error("Test Failed - setMarket() expected invalid price error", err)
+	}
+	tiers := []fees.Tier{{MinVolume: 1000, Maker: 0.001, Taker: 0.001}}
+	if err := sim.setMarket(&config.SimulatorConfig{FeeTiers: tiers}); err == nil {
+		t.Error("Test Failed - setMarket() expected invalid fee tiers error")
+	}
+}
+
+func TestUpdateTicker(t *testing.T) {
+	first, err := s.UpdateTicker(btcusd, ticker.Spot)
+	if err != nil {
+		t.Fatal("Test Failed - UpdateTicker() error", err)
+	}
+	if first.Bid >= first.Last || first.Ask <= first.Last || first.High < first.Low {
+		t.Errorf("Test Failed - UpdateTicker() unexpected ticker %+v", first)
+	}
+
+	second, err := s.UpdateTicker(btcusd, ticker.Spot)
+	if err != nil {
+		t.Fatal("Test Failed - UpdateTicker() error", err)
+	}
+	if second.Last == first.Last {
+		t.Error("Test Failed - UpdateTicker() expected the mid price to move")
+	}
+
+	book, err := s.UpdateOrderbook(btcusd, ticker.Spot)
+	if err != nil {
+		t.Fatal("Test Failed - UpdateOrderbook() error", err)
+	}
+	if len(book.Bids) != 10 || len(book.Asks) != 10 || book.Bids[0].Price != second.Bid ||
+		book.Asks[0].Price != second.Ask || book.Asks[9].Amount != 1 {
+		t.Errorf("Test Failed - UpdateOrderbook() unexpected book %+v", book)
+	}
+}
+
+func TestSubmitOrder(t *testing.T) {
+	setMid(btcusd, 6500)
+
+	// The book holds 10 BTC of asks, the rest of a market order is cancelled
+	resp, err := s.SubmitOrder(btcusd, exchange.Buy, exchange.Market, 12, 0, "")
+	if err != nil || !resp.IsOrderPlaced {
+		t.Fatal("Test Failed - SubmitOrder() error", err)
+	}
+	id, _ := strconv.ParseInt(resp.OrderID, 10, 64)
+	order, err := s.GetOrderInfo(id)
+	if err != nil {
+		t.Fatal("Test Failed - GetOrderInfo() error", err)
+	}
+	if order.Status != paper.StatusCancelled || order.ExecutedAmount != 10 {
+		t.Errorf("Test Failed - SubmitOrder() expected a partial fill %+v", order)
+	}
+	o, err := s.GetSimulator().Order(resp.OrderID)
+	if err != nil || math.Abs(o.Fee-o.AverageFillPrice*10*0.002) > 1e-6 {
+		t.Error("Test Failed - SubmitOrder() expected the taker fee", o.Fee, err)
+	}
+
+	// Limit orders rest until the market trades through them
+	resp, err = s.SubmitOrder(btcusd, exchange.Sell, exchange.Limit, 0.5, 6600, "")
+	if err != nil {
+		t.Fatal("Test Failed - SubmitOrder() error", err)
+	}
+	active, err := s.GetActiveOrders(exchange.GetOrdersRequest{})
+	if err != nil || len(active) != 1 || active[0].ID != resp.OrderID {
+		t.Fatal("Test Failed - GetActiveOrders() expected the resting order", active, err)
+	}
+	setMid(btcusd, 6700)
+	s.MatchSimulatedOrders()
+	o, err = s.GetSimulator().Order(resp.OrderID)
+	if err != nil || o.Status != paper.StatusFilled || o.AverageFillPrice != 6600 ||
+		math.Abs(o.Fee-6600*0.5*0.001) > 1e-6 {
+		t.Errorf("Test Failed - MatchSimulatedOrders() expected a maker fill %+v", o)
+	}
+
+	history, err := s.GetOrderHistory(exchange.GetOrdersRequest{})
+	if err != nil || len(history) != 2 {
+		t.Error("Test Failed - GetOrderHistory() unexpected orders", history, err)
+	}
+	info, err := s.GetAccountInfo()
+	if err != nil || len(info.Currencies) != 2 {
+		t.Error("Test Failed - GetAccountInfo() unexpected balances", info, err)
+	}
+}
+
+func TestLatency(t *testing.T) {
+	s.m.Lock()
+	s.latency = 20 * time.Millisecond
+	s.m.Unlock()
+	defer func() {
+		s.m.Lock()
+		s.latency = 0
+		s.m.Unlock()
+	}()
+
+	start := time.Now()
+	err := s.CancelOrder(exchange.OrderCancellation{OrderID: "1337", CurrencyPair: btcusd})
+	if err != paper.ErrOrderNotFound {
+		t.Error("Test Failed - CancelOrder() expected order not found error", err)
+	}
+	if time.Since(start) < 20*time.Millisecond {
+		t.Error("Test Failed - CancelOrder() expected the request to be delayed")
+	}
+}
+
+func TestGetFee(t *testing.T) {
+	fee, err := s.GetFee(exchange.FeeBuilder{
+		FeeType:       exchange.CryptocurrencyTradeFee,
+		PurchasePrice: 1000,
+		Amount:        2,
+		IsMaker:       true,
+	})
+	if err != nil || fee != 2 {
+		t.Error("Test Failed - GetFee() unexpected fee", fee, err)
+	}
+}
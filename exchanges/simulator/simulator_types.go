@@ -0,0 +1,42 @@
+package simulator
+
+import (
+	"github.com/thrasher-/gocryptotrader/exchanges/fees"
+)
+
+// Market defaults, used when the exchange config has no simulator settings
+const (
+	defaultPrice       = 100
+	defaultSpread      = 0.1
+	defaultVolatility  = 0.05
+	defaultDepthLevels = 10
+	defaultLevelAmount = 1
+)
+
+// defaultPrices are the starting mid prices of the default currency pairs
+var defaultPrices = map[string]float64{
+	"BTC-USD": 6500,
+	"ETH-USD": 200,
+	"LTC-USD": 50,
+	"ETH-BTC": 0.03,
+	"LTC-BTC": 0.008,
+}
+
+// DefaultFeeSchedule is the Simulator fee schedule charged until the exchange
+// config sets fee tiers, volume is measured in USD
+var DefaultFeeSchedule = fees.Schedule{
+	VolumeCurrency: "USD",
+	Tiers: []fees.Tier{
+		{MinVolume: 0, Maker: 0.001, Taker: 0.002},
+		{MinVolume: 100000, Maker: 0.0008, Taker: 0.0016},
+		{MinVolume: 1000000, Maker: 0.0005, Taker: 0.001},
+	},
+}
+
+// market is the synthetic market of a currency pair, High and Low are the
+// range of the mid price since the simulator was set up
+type market struct {
+	Mid  float64
+	High float64
+	Low  float64
+}
@@ -0,0 +1,229 @@
+package simulator
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+// Start starts the Simulator wrapper
+func (s *Simulator) Start(ctx context.Context) error {
+	return s.StartWrapper(ctx, s.Run)
+}
+
+// Run implements the Simulator wrapper, its available currency pairs are the
+// markets with configured prices
+func (s *Simulator) Run(ctx context.Context) error {
+	if s.Verbose {
+		log.Printf("%s polling delay: %ds.\n", s.GetName(), s.RESTPollingDelay)
+		log.Printf("%s %d currencies enabled: %s.\n", s.GetName(), len(s.EnabledPairs), s.EnabledPairs)
+	}
+
+	err := s.UpdateCurrencies(s.pricedPairs(), false, false)
+	if err != nil {
+		log.Printf("%s failed to update available currencies. Err: %s", s.Name, err)
+	}
+	return nil
+}
+
+// UpdateTicker moves the currency pair's mid price a step along its random
+// walk and returns its ticker
+func (s *Simulator) UpdateTicker(p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	s.m.Lock()
+	mkt := s.market(p)
+	s.step(mkt)
+	book := s.orderbook(mkt.Mid, p, assetType)
+	tickerPrice := ticker.Price{
+		Pair:        p,
+		Last:        mkt.Mid,
+		High:        mkt.High,
+		Low:         mkt.Low,
+		LastUpdated: time.Now(),
+	}
+	s.m.Unlock()
+
+	if len(book.Bids) > 0 {
+		tickerPrice.Bid = book.Bids[0].Price
+	}
+	if len(book.Asks) > 0 {
+		tickerPrice.Ask = book.Asks[0].Price
+	}
+	ticker.ProcessTicker(s.GetName(), p, tickerPrice, assetType)
+	return ticker.GetTicker(s.Name, p, assetType)
+}
+
+// GetTickerPrice returns the ticker for a currency pair
+func (s *Simulator) GetTickerPrice(p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	tickerNew, err := ticker.GetTicker(s.GetName(), p, assetType)
+	if err != nil {
+		return s.UpdateTicker(p, assetType)
+	}
+	return tickerNew, nil
+}
+
+// GetOrderbookEx returns orderbook base on the currency pair
+func (s *Simulator) GetOrderbookEx(p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	ob, err := orderbook.GetOrderbook(s.GetName(), p, assetType)
+	if err != nil {
+		return s.UpdateOrderbook(p, assetType)
+	}
+	return ob, nil
+}
+
+// UpdateOrderbook updates and returns the orderbook quoted around the
+// currency pair's current mid price
+func (s *Simulator) UpdateOrderbook(p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+	orderBook, err := s.book(p, assetType)
+	if err != nil {
+		return orderBook, err
+	}
+
+	orderbook.ProcessOrderbook(s.GetName(), p, orderBook, assetType)
+	return orderbook.GetOrderbook(s.Name, p, assetType)
+}
+
+// GetAccountInfo retrieves the simulated balances
+func (s *Simulator) GetAccountInfo() (exchange.AccountInfo, error) {
+	var info exchange.AccountInfo
+	if err := s.checkAuth(); err != nil {
+		return info, err
+	}
+
+	for _, b := range s.GetSimulator().Balances() {
+		info.Currencies = append(info.Currencies, exchange.AccountCurrencyInfo{
+			CurrencyName: b.Currency,
+			TotalValue:   b.Total,
+			Hold:         b.Hold,
+		})
+	}
+
+	info.ExchangeName = s.GetName()
+	return info, nil
+}
+
+// GetFundingHistory returns funding history, deposits and
+// withdrawals
+func (s *Simulator) GetFundingHistory() ([]exchange.FundHistory, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
+// GetExchangeHistory returns historic trade data since exchange opening.
+func (s *Simulator) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]exchange.TradeHistory, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
+// SubmitOrder submits a new order to the matching engine, fees are charged
+// at the tier of the exchange's current trading volume
+func (s *Simulator) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	if err := s.checkAuth(); err != nil {
+		return exchange.SubmitOrderResponse{}, err
+	}
+
+	s.wait()
+	s.updateFees()
+	return s.SimulateSubmitOrder(p, side, orderType, amount, price, clientID)
+}
+
+// ModifyOrder will allow of changing orderbook placement and limit to
+// market conversion
+func (s *Simulator) ModifyOrder(action exchange.ModifyOrder) (string, error) {
+	if err := s.checkAuth(); err != nil {
+		return "", err
+	}
+
+	s.wait()
+	return s.SimulateModifyOrder(action)
+}
+
+// CancelOrder cancels an order by its corresponding ID number
+func (s *Simulator) CancelOrder(order exchange.OrderCancellation) error {
+	if err := s.checkAuth(); err != nil {
+		return err
+	}
+
+	s.wait()
+	return s.SimulateCancelOrder(order)
+}
+
+// CancelAllOrders cancels all orders of a currency pair, or every open order
+// when the pair is empty
+func (s *Simulator) CancelAllOrders(orderCancellation exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
+	if err := s.checkAuth(); err != nil {
+		return exchange.CancelAllOrdersResponse{}, err
+	}
+
+	s.wait()
+	return s.SimulateCancelAllOrders(orderCancellation)
+}
+
+// GetOrderInfo returns information on a current open order
+func (s *Simulator) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
+	if err := s.checkAuth(); err != nil {
+		return exchange.OrderDetail{}, err
+	}
+
+	return s.SimulateGetOrderInfo(orderID)
+}
+
+// GetActiveOrders retrieves any orders that are active/open
+func (s *Simulator) GetActiveOrders(getOrdersRequest exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	if err := s.checkAuth(); err != nil {
+		return nil, err
+	}
+
+	return s.SimulateGetOrders(getOrdersRequest, true)
+}
+
+// GetOrderHistory retrieves account order information
+// Can Limit response to specific order status
+func (s *Simulator) GetOrderHistory(getOrdersRequest exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	if err := s.checkAuth(); err != nil {
+		return nil, err
+	}
+
+	return s.SimulateGetOrders(getOrdersRequest, false)
+}
+
+// GetDepositAddress returns a deposit address for a specified currency
+func (s *Simulator) GetDepositAddress(cryptocurrency pair.CurrencyItem) (string, error) {
+	return "", common.ErrFunctionNotSupported
+}
+
+// WithdrawCryptocurrencyFunds returns a withdrawal ID when a withdrawal is
+// submitted
+func (s *Simulator) WithdrawCryptocurrencyFunds(address string, cryptocurrency pair.CurrencyItem, amount float64) (string, error) {
+	return "", common.ErrFunctionNotSupported
+}
+
+// WithdrawFiatFunds returns a withdrawal ID when a
+// withdrawal is submitted
+func (s *Simulator) WithdrawFiatFunds(currency pair.CurrencyItem, amount float64) (string, error) {
+	return "", common.ErrFunctionNotSupported
+}
+
+// WithdrawFiatFundsToInternationalBank returns a withdrawal ID when a
+// withdrawal is submitted
+func (s *Simulator) WithdrawFiatFundsToInternationalBank(currency pair.CurrencyItem, amount float64) (string, error) {
+	return "", common.ErrFunctionNotSupported
+}
+
+// GetWebsocket returns a pointer to the exchange websocket
+func (s *Simulator) GetWebsocket() (*exchange.Websocket, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
+// GetFeeByType returns an estimate of fee based on type of transaction
+func (s *Simulator) GetFeeByType(feeBuilder exchange.FeeBuilder) (float64, error) {
+	return s.GetFee(feeBuilder)
+}
+
+// GetWithdrawCapabilities returns the types of withdrawal methods permitted by the exchange
+func (s *Simulator) GetWithdrawCapabilities() uint32 {
+	return s.GetWithdrawPermissions()
+}
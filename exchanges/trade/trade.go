@@ -0,0 +1,159 @@
+// Package trade normalises the executed trades streamed by exchange
+// websockets and stores the most recent trades of each exchange, currency
+// pair and asset type, so consumers read uniform trade data regardless of the
+// exchange it came from.
+package trade
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+)
+
+// MaxStored is the number of recent trades stored per exchange, currency pair
+// and asset type
+const MaxStored = 1000
+
+// Taker sides of a trade
+const (
+	Buy     = "BUY"
+	Sell    = "SELL"
+	Unknown = "UNKNOWN"
+)
+
+// Errors returned by the trade store
+var (
+	ErrNoTrades     = errors.New("no trades stored for exchange, currency pair and asset type")
+	ErrInvalidTrade = errors.New("trade requires a price, amount and timestamp")
+)
+
+// Trade is an executed trade. Side is the side of the taker, Unknown when the
+// exchange does not report it.
+type Trade struct {
+	Exchange  string            `json:"exchange"`
+	Pair      pair.CurrencyPair `json:"pair"`
+	AssetType string            `json:"assetType"`
+	TradeID   string            `json:"tradeID"`
+	Price     float64           `json:"price"`
+	Amount    float64           `json:"amount"`
+	Side      string            `json:"side"`
+	Timestamp time.Time         `json:"timestamp"`
+}
+
+// Side returns the normalised taker side of an exchange's side or direction
+func Side(side string) string {
+	switch common.StringToUpper(side) {
+	case "BUY", "BID", "B":
+		return Buy
+	case "SELL", "ASK", "S":
+		return Sell
+	}
+	return Unknown
+}
+
+// market holds the recent trades of an exchange's market in time order and
+// the IDs of those with one, to drop redelivered trades
+type market struct {
+	trades []Trade
+	ids    map[string]bool
+}
+
+var (
+	markets = make(map[string]*market)
+	m       sync.RWMutex
+)
+
+// key returns the store key of an exchange's market
+func key(exchangeName string, p pair.CurrencyPair, assetType string) string {
+	return common.StringToLower(exchangeName) + " " +
+		p.Display("-", true).String() + " " + common.StringToUpper(assetType)
+}
+
+// ProcessTrades stores the trades of an exchange's currency pair and asset
+// type, setting them on each trade and normalising its side. Trades already
+// stored with the same trade ID are dropped. Invalid trades are skipped and
+// ErrInvalidTrade returned once the valid trades are stored.
+func ProcessTrades(exchangeName string, p pair.CurrencyPair, assetType string, trades []Trade) error {
+	var err error
+	k := key(exchangeName, p, assetType)
+
+	m.Lock()
+	defer m.Unlock()
+	mkt, ok := markets[k]
+	if !ok {
+		mkt = &market{ids: make(map[string]bool)}
+		markets[k] = mkt
+	}
+
+	for i := range trades {
+		t := trades[i]
+		if t.Price <= 0 || t.Amount <= 0 || t.Timestamp.IsZero() {
+			err = ErrInvalidTrade
+			continue
+		}
+		if t.TradeID != "" {
+			if mkt.ids[t.TradeID] {
+				continue
+			}
+			mkt.ids[t.TradeID] = true
+		}
+		t.Exchange = exchangeName
+		t.Pair = p
+		t.AssetType = assetType
+		t.Side = Side(t.Side)
+		mkt.trades = append(mkt.trades, t)
+	}
+
+	// Exchanges batch trades newest first, the store keeps them oldest first
+	sort.SliceStable(mkt.trades, func(i, j int) bool {
+		return mkt.trades[i].Timestamp.Before(mkt.trades[j].Timestamp)
+	})
+	if excess := len(mkt.trades) - MaxStored; excess > 0 {
+		for i := range mkt.trades[:excess] {
+			delete(mkt.ids, mkt.trades[i].TradeID)
+		}
+		mkt.trades = append([]Trade(nil), mkt.trades[excess:]...)
+	}
+	return err
+}
+
+// GetTrades returns the stored trades of an exchange's currency pair and
+// asset type, oldest first
+func GetTrades(exchangeName string, p pair.CurrencyPair, assetType string) ([]Trade, error) {
+	m.RLock()
+	defer m.RUnlock()
+	mkt, ok := markets[key(exchangeName, p, assetType)]
+	if !ok || len(mkt.trades) == 0 {
+		return nil, ErrNoTrades
+	}
+	return append([]Trade(nil), mkt.trades...), nil
+}
+
+// GetTradesSince returns the stored trades of an exchange's currency pair and
+// asset type executed after since, oldest first
+func GetTradesSince(exchangeName string, p pair.CurrencyPair, assetType string, since time.Time) ([]Trade, error) {
+	trades, err := GetTrades(exchangeName, p, assetType)
+	if err != nil {
+		return nil, err
+	}
+	i := sort.Search(len(trades), func(i int) bool {
+		return trades[i].Timestamp.After(since)
+	})
+	return trades[i:], nil
+}
+
+// LastTrade returns the most recent stored trade of an exchange's currency
+// pair and asset type
+func LastTrade(exchangeName string, p pair.CurrencyPair, assetType string) (Trade, error) {
+	m.RLock()
+	defer m.RUnlock()
+	mkt, ok := markets[key(exchangeName, p, assetType)]
+	if !ok || len(mkt.trades) == 0 {
+		return Trade{}, ErrNoTrades
+	}
+	return mkt.trades[len(mkt.trades)-1], nil
+}
@@ -0,0 +1,87 @@
+package trade
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+)
+
+var (
+	btcusdt = pair.NewCurrencyPair("BTC", "USDT")
+	start   = time.Date(2018, 9, 20, 0, 0, 0, 0, time.UTC)
+)
+
+func TestSide(t *testing.T) {
+	for side, expected := range map[string]string{
+		"buy":  Buy,
+		"BID":  Buy,
+		"sell": Sell,
+		"ask":  Sell,
+		"":     Unknown,
+	} {
+		if s := Side(side); s != expected {
+			t.Errorf("Test Failed - Side(%q) expected %s, received %s", side, expected, s)
+		}
+	}
+}
+
+func TestProcessTrades(t *testing.T) {
+	if _, err := GetTrades("TradeTest", btcusdt, "SPOT"); err != ErrNoTrades {
+		t.Error("Test Failed - GetTrades() expected no trades error", err)
+	}
+
+	err := ProcessTrades("TradeTest", btcusdt, "SPOT", []Trade{
+		{TradeID: "2", Price: 6501, Amount: 0.5, Side: "sell", Timestamp: start.Add(time.Second)},
+		{TradeID: "1", Price: 6500, Amount: 1, Side: "buy", Timestamp: start},
+		{TradeID: "3", Price: 0, Amount: 1, Timestamp: start},
+	})
+	if err != ErrInvalidTrade {
+		t.Error("Test Failed - ProcessTrades() expected invalid trade error", err)
+	}
+	// Redelivered trades are dropped
+	err = ProcessTrades("TradeTest", btcusdt, "SPOT", []Trade{
+		{TradeID: "2", Price: 6501, Amount: 0.5, Side: "sell", Timestamp: start.Add(time.Second)},
+	})
+	if err != nil {
+		t.Error("Test Failed - ProcessTrades() error", err)
+	}
+
+	trades, err := GetTrades("tradetest", btcusdt, "spot")
+	if err != nil {
+		t.Fatal("Test Failed - GetTrades() error", err)
+	}
+	if len(trades) != 2 || trades[0].TradeID != "1" || trades[0].Side != Buy ||
+		trades[1].Side != Sell || trades[1].Exchange != "TradeTest" || trades[1].AssetType != "SPOT" {
+		t.Errorf("Test Failed - GetTrades() unexpected trades %+v", trades)
+	}
+
+	since, err := GetTradesSince("TradeTest", btcusdt, "SPOT", start)
+	if err != nil || len(since) != 1 || since[0].TradeID != "2" {
+		t.Error("Test Failed - GetTradesSince() unexpected trades", since, err)
+	}
+	last, err := LastTrade("TradeTest", btcusdt, "SPOT")
+	if err != nil || last.Price != 6501 {
+		t.Error("Test Failed - LastTrade() unexpected trade", last, err)
+	}
+}
+
+func TestProcessTradesLimit(t *testing.T) {
+	trades := make([]Trade, MaxStored+10)
+	for i := range trades {
+		trades[i] = Trade{
+			TradeID:   strconv.Itoa(i),
+			Price:     6500,
+			Amount:    1,
+			Timestamp: start.Add(time.Duration(i) * time.Second),
+		}
+	}
+	if err := ProcessTrades("TradeLimitTest", btcusdt, "SPOT", trades); err != nil {
+		t.Fatal("Test Failed - ProcessTrades() error", err)
+	}
+	stored, err := GetTrades("TradeLimitTest", btcusdt, "SPOT")
+	if err != nil || len(stored) != MaxStored || stored[0].TradeID != "10" || stored[0].Side != Unknown {
+		t.Error("Test Failed - ProcessTrades() expected oldest trades evicted", len(stored), err)
+	}
+}
@@ -1,10 +1,10 @@
 package wex
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
-	"sync"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
@@ -13,17 +13,13 @@ import (
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
 
-// Start starts the WEX go routine
-func (w *WEX) Start(wg *sync.WaitGroup) {
-	wg.Add(1)
-	go func() {
-		w.Run()
-		wg.Done()
-	}()
+// Start starts the WEX wrapper, refreshing its currency pairs
+func (w *WEX) Start(ctx context.Context) error {
+	return w.StartWrapper(ctx, w.Run)
 }
 
 // Run implements the WEX wrapper
-func (w *WEX) Run() {
+func (w *WEX) Run(ctx context.Context) error {
 	if w.Verbose {
 		log.Printf("%s Websocket: %s.", w.GetName(), common.IsEnabled(w.Websocket.IsEnabled()))
 		log.Printf("%s polling delay: %ds.\n", w.GetName(), w.RESTPollingDelay)
@@ -53,6 +49,7 @@ func (w *WEX) Run() {
 			log.Printf("%s Failed to get config.\n", w.GetName())
 		}
 	}
+	return nil
 }
 
 // UpdateTicker updates and returns the ticker for a currency pair
@@ -160,6 +157,10 @@ func (w *WEX) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]excha
 
 // SubmitOrder submits a new order
 func (w *WEX) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	if w.IsSimulated() {
+		return w.SimulateSubmitOrder(p, side, orderType, amount, price, clientID)
+	}
+
 	var submitOrderResponse exchange.SubmitOrderResponse
 	response, err := w.Trade(common.StringToLower(p.Pair().String()), common.StringToLower(side.ToString()), amount, price)
 
@@ -177,11 +178,19 @@ func (w *WEX) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderTyp
 // ModifyOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (w *WEX) ModifyOrder(action exchange.ModifyOrder) (string, error) {
+	if w.IsSimulated() {
+		return w.SimulateModifyOrder(action)
+	}
+
 	return "", common.ErrNotYetImplemented
 }
 
 // CancelOrder cancels an order by its corresponding ID number
 func (w *WEX) CancelOrder(order exchange.OrderCancellation) error {
+	if w.IsSimulated() {
+		return w.SimulateCancelOrder(order)
+	}
+
 	orderIDInt, err := strconv.ParseInt(order.OrderID, 10, 64)
 	if err != nil {
 		return err
@@ -194,13 +203,17 @@ func (w *WEX) CancelOrder(order exchange.OrderCancellation) error {
 
 // CancelAllOrders cancels all orders associated with a currency pair
 func (w *WEX) CancelAllOrders(orderCancellation exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
+	if w.IsSimulated() {
+		return w.SimulateCancelAllOrders(orderCancellation)
+	}
+
 	cancelAllOrdersResponse := exchange.CancelAllOrdersResponse{
 		OrderStatus: make(map[string]string),
 	}
 	var allActiveOrders map[string]ActiveOrders
 
 	for _, pair := range w.EnabledPairs {
-		activeOrders, err := w.GetActiveOrders(pair)
+		activeOrders, err := w.GetOpenOrders(pair)
 		if err != nil {
 			return cancelAllOrdersResponse, err
 		}
@@ -227,6 +240,10 @@ func (w *WEX) CancelAllOrders(orderCancellation exchange.OrderCancellation) (exc
 
 // GetOrderInfo returns information on a current open order
 func (w *WEX) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
+	if w.IsSimulated() {
+		return w.SimulateGetOrderInfo(orderID)
+	}
+
 	var orderDetail exchange.OrderDetail
 	return orderDetail, common.ErrNotYetImplemented
 }
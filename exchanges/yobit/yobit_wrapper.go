@@ -1,10 +1,10 @@
 package yobit
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
-	"sync"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
@@ -13,22 +13,19 @@ import (
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
 
-// Start starts the WEX go routine
-func (y *Yobit) Start(wg *sync.WaitGroup) {
-	wg.Add(1)
-	go func() {
-		y.Run()
-		wg.Done()
-	}()
+// Start starts the Yobit wrapper, refreshing its currency pairs
+func (y *Yobit) Start(ctx context.Context) error {
+	return y.StartWrapper(ctx, y.Run)
 }
 
 // Run implements the Yobit wrapper
-func (y *Yobit) Run() {
+func (y *Yobit) Run(ctx context.Context) error {
 	if y.Verbose {
 		log.Printf("%s Websocket: %s.", y.GetName(), common.IsEnabled(y.Websocket.IsEnabled()))
 		log.Printf("%s polling delay: %ds.\n", y.GetName(), y.RESTPollingDelay)
 		log.Printf("%s %d currencies enabled: %s.\n", y.GetName(), len(y.EnabledPairs), y.EnabledPairs)
 	}
+	return nil
 }
 
 // UpdateTicker updates and returns the ticker for a currency pair
@@ -142,6 +139,10 @@ func (y *Yobit) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]exc
 
 // SubmitOrder submits a new order
 func (y *Yobit) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	if y.IsSimulated() {
+		return y.SimulateSubmitOrder(p, side, orderType, amount, price, clientID)
+	}
+
 	var submitOrderResponse exchange.SubmitOrderResponse
 	response, err := y.Trade(p.Pair().String(), orderType.ToString(), amount, price)
 
@@ -159,11 +160,19 @@ func (y *Yobit) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderT
 // ModifyOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (y *Yobit) ModifyOrder(action exchange.ModifyOrder) (string, error) {
+	if y.IsSimulated() {
+		return y.SimulateModifyOrder(action)
+	}
+
 	return "", common.ErrFunctionNotSupported
 }
 
 // CancelOrder cancels an order by its corresponding ID number
 func (y *Yobit) CancelOrder(order exchange.OrderCancellation) error {
+	if y.IsSimulated() {
+		return y.SimulateCancelOrder(order)
+	}
+
 	orderIDInt, err := strconv.ParseInt(order.OrderID, 10, 64)
 	if err != nil {
 		return err
@@ -175,13 +184,17 @@ func (y *Yobit) CancelOrder(order exchange.OrderCancellation) error {
 
 // CancelAllOrders cancels all orders associated with a currency pair
 func (y *Yobit) CancelAllOrders(orderCancellation exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
+	if y.IsSimulated() {
+		return y.SimulateCancelAllOrders(orderCancellation)
+	}
+
 	cancelAllOrdersResponse := exchange.CancelAllOrdersResponse{
 		OrderStatus: make(map[string]string),
 	}
 	var allActiveOrders []map[string]ActiveOrders
 
 	for _, pair := range y.EnabledPairs {
-		activeOrdersForPair, err := y.GetActiveOrders(pair)
+		activeOrdersForPair, err := y.GetOpenOrders(pair)
 		if err != nil {
 			return cancelAllOrdersResponse, err
 		}
@@ -208,6 +221,10 @@ func (y *Yobit) CancelAllOrders(orderCancellation exchange.OrderCancellation) (e
 
 // GetOrderInfo returns information on a current open order
 func (y *Yobit) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
+	if y.IsSimulated() {
+		return y.SimulateGetOrderInfo(orderID)
+	}
+
 	var orderDetail exchange.OrderDetail
 	return orderDetail, common.ErrNotYetImplemented
 }
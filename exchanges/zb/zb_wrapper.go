@@ -1,10 +1,10 @@
 package zb
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
-	"sync"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
@@ -13,17 +13,13 @@ import (
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
 
-// Start starts the OKEX go routine
-func (z *ZB) Start(wg *sync.WaitGroup) {
-	wg.Add(1)
-	go func() {
-		z.Run()
-		wg.Done()
-	}()
+// Start starts the ZB wrapper, refreshing its currency pairs
+func (z *ZB) Start(ctx context.Context) error {
+	return z.StartWrapper(ctx, z.Run)
 }
 
 // Run implements the OKEX wrapper
-func (z *ZB) Run() {
+func (z *ZB) Run(ctx context.Context) error {
 	if z.Verbose {
 		log.Printf("%s Websocket: %s. (url: %s).\n", z.GetName(), common.IsEnabled(z.Websocket.IsEnabled()), z.WebsocketURL)
 		log.Printf("%s polling delay: %ds.\n", z.GetName(), z.RESTPollingDelay)
@@ -44,6 +40,7 @@ func (z *ZB) Run() {
 			log.Printf("%s Failed to update available currencies.\n", z.GetName())
 		}
 	}
+	return nil
 }
 
 // UpdateTicker updates and returns the ticker for a currency pair
@@ -164,6 +161,10 @@ func (z *ZB) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]exchan
 
 // SubmitOrder submits a new order
 func (z *ZB) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	if z.IsSimulated() {
+		return z.SimulateSubmitOrder(p, side, orderType, amount, price, clientID)
+	}
+
 	var submitOrderResponse exchange.SubmitOrderResponse
 	var oT SpotNewOrderRequestParamsType
 
@@ -195,11 +196,19 @@ func (z *ZB) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType
 // ModifyOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (z *ZB) ModifyOrder(action exchange.ModifyOrder) (string, error) {
+	if z.IsSimulated() {
+		return z.SimulateModifyOrder(action)
+	}
+
 	return "", common.ErrFunctionNotSupported
 }
 
 // CancelOrder cancels an order by its corresponding ID number
 func (z *ZB) CancelOrder(order exchange.OrderCancellation) error {
+	if z.IsSimulated() {
+		return z.SimulateCancelOrder(order)
+	}
+
 	orderIDInt, err := strconv.ParseInt(order.OrderID, 10, 64)
 
 	if err != nil {
@@ -211,6 +220,10 @@ func (z *ZB) CancelOrder(order exchange.OrderCancellation) error {
 
 // CancelAllOrders cancels all orders associated with a currency pair
 func (z *ZB) CancelAllOrders(orderCancellation exchange.OrderCancellation) (exchange.CancelAllOrdersResponse, error) {
+	if z.IsSimulated() {
+		return z.SimulateCancelAllOrders(orderCancellation)
+	}
+
 	cancelAllOrdersResponse := exchange.CancelAllOrdersResponse{
 		OrderStatus: make(map[string]string),
 	}
@@ -238,6 +251,10 @@ func (z *ZB) CancelAllOrders(orderCancellation exchange.OrderCancellation) (exch
 
 // GetOrderInfo returns information on a current open order
 func (z *ZB) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
+	if z.IsSimulated() {
+		return z.SimulateGetOrderInfo(orderID)
+	}
+
 	var orderDetail exchange.OrderDetail
 	return orderDetail, common.ErrNotYetImplemented
 }
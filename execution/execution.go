@@ -0,0 +1,202 @@
+// Package execution slices a large parent order into smaller child orders
+// placed through the order manager over time, with TWAP, VWAP and iceberg
+// algorithms, randomised slice sizes and cancel-on-deviation safeguards.
+package execution
+
+import (
+	"errors"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
+)
+
+// StrategyName is the strategy child orders are submitted and throttled as
+const StrategyName = "execution"
+
+// Execution algorithms
+const (
+	TWAP    = "TWAP"
+	VWAP    = "VWAP"
+	Iceberg = "ICEBERG"
+)
+
+// Errors returned when validating and running executions
+var (
+	ErrUnknownAlgorithm     = errors.New("unknown execution algorithm, use TWAP, VWAP or ICEBERG")
+	ErrInvalidAmount        = errors.New("amount must be greater than zero")
+	ErrInvalidPrice         = errors.New("price must not be negative")
+	ErrPriceRequired        = errors.New("iceberg executions require a limit price")
+	ErrInvalidDuration      = errors.New("TWAP and VWAP executions require a positive duration")
+	ErrInvalidSlices        = errors.New("TWAP and VWAP executions require at least one slice")
+	ErrInvalidVisibleAmount = errors.New("visible amount must be greater than zero and less than the amount")
+	ErrInvalidRandomization = errors.New("randomization must be between 0 and 1")
+	ErrInvalidDeviation     = errors.New("maximum deviation must be between 0 and 1")
+	ErrInvalidVolumeProfile = errors.New("volume profile must hold a non-negative weight per slice")
+	ErrPriceDeviation       = errors.New("price deviated beyond the maximum deviation")
+	ErrDurationElapsed      = errors.New("duration elapsed before the amount was filled")
+	ErrCancelled            = errors.New("execution cancelled")
+	ErrNotFound             = errors.New("execution not found")
+	ErrFinished             = errors.New("execution already finished")
+)
+
+// Params describes a parent order and how it is sliced. Amount is in the base
+// currency. Child orders are limit orders at Price, or market orders when
+// Price is zero. TWAP and VWAP executions place Slices child orders spread
+// evenly over Duration, VWAP weighting their amounts by VolumeProfile.
+// Iceberg executions keep a single limit child of VisibleAmount open until
+// Amount is filled, or Duration elapses when positive. Randomization varies
+// each slice amount by up to that fraction and MaxDeviation, when positive,
+// cancels the execution once the last price moves more than that fraction
+// from the price when it started.
+type Params struct {
+	Algorithm     string
+	Exchange      string
+	Pair          pair.CurrencyPair
+	Side          exchange.OrderSide
+	Amount        float64
+	Price         float64
+	Duration      time.Duration
+	Slices        int
+	VisibleAmount float64
+	Randomization float64
+	MaxDeviation  float64
+	VolumeProfile []float64
+}
+
+// Validate checks the parameters of an execution and normalises its
+// algorithm's case. An empty VWAP volume profile is allowed and filled in by
+// the manager from historic candles.
+func (p *Params) Validate() error {
+	p.Algorithm = common.StringToUpper(p.Algorithm)
+	switch {
+	case p.Algorithm != TWAP && p.Algorithm != VWAP && p.Algorithm != Iceberg:
+		return ErrUnknownAlgorithm
+	case p.Amount <= 0:
+		return ErrInvalidAmount
+	case p.Price < 0:
+		return ErrInvalidPrice
+	case p.Randomization < 0 || p.Randomization > 1:
+		return ErrInvalidRandomization
+	case p.MaxDeviation < 0 || p.MaxDeviation > 1:
+		return ErrInvalidDeviation
+	}
+
+	if p.Algorithm == Iceberg {
+		switch {
+		case p.Price == 0:
+			return ErrPriceRequired
+		case p.VisibleAmount <= 0 || p.VisibleAmount >= p.Amount:
+			return ErrInvalidVisibleAmount
+		case p.Duration < 0:
+			return ErrInvalidDuration
+		}
+		return nil
+	}
+
+	switch {
+	case p.Duration <= 0:
+		return ErrInvalidDuration
+	case p.Slices <= 0:
+		return ErrInvalidSlices
+	case p.Algorithm == VWAP && len(p.VolumeProfile) > 0 && len(p.VolumeProfile) != p.Slices:
+		return ErrInvalidVolumeProfile
+	}
+	for _, w := range p.VolumeProfile {
+		if w < 0 {
+			return ErrInvalidVolumeProfile
+		}
+	}
+	return nil
+}
+
+// Slice is a child order of a TWAP or VWAP execution placed Offset after the
+// execution starts
+type Slice struct {
+	Offset time.Duration
+	Amount float64
+}
+
+// Schedule returns the child orders of a TWAP or VWAP execution. Slices are
+// placed at even intervals over the duration, TWAP slices are of equal amount
+// and VWAP slices are proportional to the volume profile. When randomization
+// is set each amount is varied by up to that fraction using random, which
+// returns numbers in [0, 1), and the slices are scaled back to the amount.
+func Schedule(p Params, random func() float64) []Slice {
+	weights := make([]float64, p.Slices)
+	var total float64
+	for i := range weights {
+		weights[i] = 1
+		if p.Algorithm == VWAP && len(p.VolumeProfile) == p.Slices {
+			weights[i] = p.VolumeProfile[i]
+		}
+		weights[i] = randomize(weights[i], p.Randomization, random)
+		total += weights[i]
+	}
+
+	slices := make([]Slice, p.Slices)
+	interval := p.Duration / time.Duration(p.Slices)
+	for i := range slices {
+		slices[i].Offset = interval * time.Duration(i)
+		if total > 0 {
+			slices[i].Amount = p.Amount * weights[i] / total
+		} else {
+			slices[i].Amount = p.Amount / float64(p.Slices)
+		}
+	}
+	return slices
+}
+
+// VolumeProfile returns the share of volume traded in each of slices equal
+// windows of duration starting at start, measured over the same time of day
+// in candles covering the previous days. Windows without volume get no
+// weight, a profile of all zeros is returned as nil.
+func VolumeProfile(candles []kline.Candle, start time.Time, duration time.Duration, slices int) []float64 {
+	if slices <= 0 || duration <= 0 {
+		return nil
+	}
+	profile := make([]float64, slices)
+	var total float64
+	for _, c := range candles {
+		if !c.Time.Before(start) {
+			continue
+		}
+		// Offset of the candle from start on the candle's day
+		offset := c.Time.Sub(start) % (24 * time.Hour)
+		if offset < 0 {
+			offset += 24 * time.Hour
+		}
+		if offset >= duration {
+			continue
+		}
+		i := int(offset * time.Duration(slices) / duration)
+		profile[i] += c.Volume
+		total += c.Volume
+	}
+	if total == 0 {
+		return nil
+	}
+	for i := range profile {
+		profile[i] /= total
+	}
+	return profile
+}
+
+// randomize varies amount by up to randomization using random
+func randomize(amount, randomization float64, random func() float64) float64 {
+	if randomization == 0 {
+		return amount
+	}
+	return amount * (1 + randomization*(2*random()-1))
+}
+
+// deviation returns the fraction price has moved from reference
+func deviation(price, reference float64) float64 {
+	d := (price - reference) / reference
+	if d < 0 {
+		return -d
+	}
+	return d
+}
@@ -0,0 +1,356 @@
+package execution
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-/gocryptotrader/exchanges/orders"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+// testExchange records cancelled orders and returns candles
+type testExchange struct {
+	exchange.IBotExchange
+	candles   []kline.Candle
+	cancelled []string
+	m         sync.Mutex
+}
+
+func (e *testExchange) GetName() string {
+	return "ExecutionExchange"
+}
+
+func (e *testExchange) CancelOrder(o exchange.OrderCancellation) error {
+	e.m.Lock()
+	e.cancelled = append(e.cancelled, o.OrderID)
+	e.m.Unlock()
+	return nil
+}
+
+func (e *testExchange) GetHistoricCandles(p pair.CurrencyPair, assetType string, interval kline.Interval, start, end time.Time) ([]kline.Candle, error) {
+	return e.candles, nil
+}
+
+// orderID is the last exchange order ID assigned by testOrderer
+var orderID int
+
+// testOrderer tracks submitted orders in the order store, rejecting them when
+// err is set
+type testOrderer struct {
+	submitted []exchange.OrderSubmission
+	err       error
+	m         sync.Mutex
+}
+
+func (o *testOrderer) SubmitStrategyOrder(strategy string, exch exchange.IBotExchange, s exchange.OrderSubmission) (int, error) {
+	o.m.Lock()
+	defer o.m.Unlock()
+	if o.err != nil {
+		return 0, o.err
+	}
+	o.submitted = append(o.submitted, s)
+	orderID++
+	return orders.TrackOrder(exch.GetName(), strconv.Itoa(orderID), s.Pair,
+		s.Side, s.Type, s.BaseAmount, s.Price), nil
+}
+
+func (o *testOrderer) placed() []exchange.OrderSubmission {
+	o.m.Lock()
+	defer o.m.Unlock()
+	return append([]exchange.OrderSubmission(nil), o.submitted...)
+}
+
+// testTicker returns a settable last price
+type testTicker struct {
+	last float64
+	m    sync.Mutex
+}
+
+func (t *testTicker) get(exchName string, p pair.CurrencyPair, assetType string) (ticker.Price, error) {
+	t.m.Lock()
+	defer t.m.Unlock()
+	return ticker.Price{Pair: p, Last: t.last}, nil
+}
+
+func (t *testTicker) set(last float64) {
+	t.m.Lock()
+	t.last = last
+	t.m.Unlock()
+}
+
+func newTestManager() (*Manager, *testExchange, *testOrderer, *testTicker) {
+	exch := &testExchange{}
+	orderer := &testOrderer{}
+	tick := &testTicker{last: 100}
+	m := NewManager(orderer, func(name string) exchange.IBotExchange {
+		if name == exch.GetName() {
+			return exch
+		}
+		return nil
+	}, tick.get)
+	m.PollInterval = time.Millisecond * 5
+	return m, exch, orderer, tick
+}
+
+// waitFinished waits for an execution to finish and returns its state
+func waitFinished(t *testing.T, m *Manager, id int) Execution {
+	for i := 0; i < 200; i++ {
+		e, err := m.Execution(id)
+		if err != nil {
+			t.Fatal("Test Failed - Execution() error", err)
+		}
+		if e.Status != StatusRunning {
+			return e
+		}
+		time.Sleep(time.Millisecond * 5)
+	}
+	t.Fatal("Test Failed - execution did not finish")
+	return Execution{}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		params Params
+		err    error
+	}{
+		{Params{Algorithm: "twap", Amount: 1, Duration: time.Minute, Slices: 4}, nil},
+		{Params{Algorithm: "POV", Amount: 1}, ErrUnknownAlgorithm},
+		{Params{Algorithm: TWAP, Duration: time.Minute, Slices: 4}, ErrInvalidAmount},
+		{Params{Algorithm: TWAP, Amount: 1, Slices: 4}, ErrInvalidDuration},
+		{Params{Algorithm: TWAP, Amount: 1, Duration: time.Minute}, ErrInvalidSlices},
+		{Params{Algorithm: TWAP, Amount: 1, Duration: time.Minute, Slices: 4, Randomization: 2}, ErrInvalidRandomization},
+		{Params{Algorithm: TWAP, Amount: 1, Duration: time.Minute, Slices: 4, MaxDeviation: -1}, ErrInvalidDeviation},
+		{Params{Algorithm: VWAP, Amount: 1, Duration: time.Minute, Slices: 4, VolumeProfile: []float64{1}}, ErrInvalidVolumeProfile},
+		{Params{Algorithm: Iceberg, Amount: 1, VisibleAmount: 0.1}, ErrPriceRequired},
+		{Params{Algorithm: Iceberg, Amount: 1, Price: 100, VisibleAmount: 1}, ErrInvalidVisibleAmount},
+		{Params{Algorithm: Iceberg, Amount: 1, Price: 100, VisibleAmount: 0.1}, nil},
+	}
+	for i := range tests {
+		if err := tests[i].params.Validate(); err != tests[i].err {
+			t.Errorf("Test Failed - Validate() %d expected %v, received %v", i, tests[i].err, err)
+		}
+	}
+}
+
+func TestSchedule(t *testing.T) {
+	p := Params{Algorithm: TWAP, Amount: 1, Duration: time.Minute, Slices: 4}
+	slices := Schedule(p, nil)
+	if len(slices) != 4 || slices[1].Offset != 15*time.Second || slices[3].Amount != 0.25 {
+		t.Error("Test Failed - Schedule() incorrect TWAP slices", slices)
+	}
+
+	p.Algorithm = VWAP
+	p.VolumeProfile = []float64{0.1, 0.2, 0.3, 0.4}
+	slices = Schedule(p, nil)
+	if math.Abs(slices[0].Amount-0.1) > 1e-9 || math.Abs(slices[3].Amount-0.4) > 1e-9 {
+		t.Error("Test Failed - Schedule() incorrect VWAP slices", slices)
+	}
+
+	// Randomised slices vary but still sum to the amount
+	p.Algorithm = TWAP
+	p.Randomization = 0.5
+	random := []float64{0, 0.25, 0.75, 0.99}
+	slices = Schedule(p, func() float64 {
+		r := random[0]
+		random = random[1:]
+		return r
+	})
+	var total float64
+	for i := range slices {
+		total += slices[i].Amount
+	}
+	if math.Abs(total-1) > 1e-9 || slices[0].Amount >= slices[3].Amount {
+		t.Error("Test Failed - Schedule() incorrect randomised slices", slices, total)
+	}
+}
+
+func TestVolumeProfile(t *testing.T) {
+	start := time.Date(2019, 6, 2, 12, 0, 0, 0, time.UTC)
+	day := start.Add(-24 * time.Hour)
+	candles := []kline.Candle{
+		{Time: day, Volume: 10},
+		{Time: day.Add(10 * time.Minute), Volume: 10},
+		{Time: day.Add(45 * time.Minute), Volume: 20},
+		{Time: day.Add(2 * time.Hour), Volume: 100},
+		{Time: start, Volume: 100},
+	}
+	profile := VolumeProfile(candles, start, time.Hour, 2)
+	if len(profile) != 2 || profile[0] != 0.5 || profile[1] != 0.5 {
+		t.Error("Test Failed - VolumeProfile() incorrect profile", profile)
+	}
+	if profile = VolumeProfile(nil, start, time.Hour, 2); profile != nil {
+		t.Error("Test Failed - VolumeProfile() expected nil profile without volume", profile)
+	}
+}
+
+func TestTWAP(t *testing.T) {
+	m, exch, orderer, _ := newTestManager()
+	var finished []Execution
+	var finishedM sync.Mutex
+	m.OnFinish = func(e Execution) {
+		finishedM.Lock()
+		finished = append(finished, e)
+		finishedM.Unlock()
+	}
+
+	_, err := m.Start(Params{Algorithm: TWAP, Exchange: "Other", Amount: 1, Duration: time.Minute, Slices: 4})
+	if err == nil {
+		t.Error("Test Failed - Start() expected exchange not found error")
+	}
+
+	e, err := m.Start(Params{Algorithm: TWAP, Exchange: exch.GetName(), Pair: pair.NewCurrencyPair("BTC", "USDT"),
+		Side: exchange.Buy, Amount: 1, Price: 100, Duration: time.Millisecond * 40, Slices: 4})
+	if err != nil {
+		t.Fatal("Test Failed - Start() error", err)
+	}
+	e = waitFinished(t, m, e.ID)
+	submitted := orderer.placed()
+	if e.Status != StatusCompleted || len(e.Children) != 4 || len(submitted) != 4 {
+		t.Fatal("Test Failed - TWAP expected four slices placed", e, submitted)
+	}
+
+	// The unfilled slices are cancelled and carried into the next slice
+	if submitted[0].BaseAmount != 0.25 || submitted[1].BaseAmount != 0.5 ||
+		submitted[3].BaseAmount != 1 || submitted[0].Type != exchange.Limit {
+		t.Error("Test Failed - TWAP expected unfilled slices carried forward", submitted)
+	}
+	if len(exch.cancelled) != 4 {
+		t.Error("Test Failed - TWAP expected unfilled slices cancelled", exch.cancelled)
+	}
+	finishedM.Lock()
+	if len(finished) != 1 || finished[0].ID != e.ID {
+		t.Error("Test Failed - OnFinish expected to be called once", finished)
+	}
+	finishedM.Unlock()
+
+	if err = m.Cancel(e.ID); err != ErrFinished {
+		t.Error("Test Failed - Cancel() expected finished error", err)
+	}
+	if err = m.Cancel(100); err != ErrNotFound {
+		t.Error("Test Failed - Cancel() expected not found error", err)
+	}
+}
+
+func TestVWAPHistoricProfile(t *testing.T) {
+	m, exch, orderer, _ := newTestManager()
+	_, err := m.Start(Params{Algorithm: VWAP, Exchange: exch.GetName(), Amount: 1, Duration: time.Millisecond * 20, Slices: 2})
+	if err == nil {
+		t.Error("Test Failed - Start() expected volume profile error")
+	}
+
+	now := time.Now()
+	exch.candles = []kline.Candle{
+		{Time: now.Add(-24*time.Hour + 2*time.Millisecond), Volume: 3},
+		{Time: now.Add(-24*time.Hour + 15*time.Millisecond), Volume: 1},
+	}
+	e, err := m.Start(Params{Algorithm: VWAP, Exchange: exch.GetName(), Pair: pair.NewCurrencyPair("BTC", "USDT"),
+		Side: exchange.Sell, Amount: 1, Duration: time.Millisecond * 20, Slices: 2})
+	if err != nil {
+		t.Fatal("Test Failed - Start() error", err)
+	}
+	e = waitFinished(t, m, e.ID)
+	submitted := orderer.placed()
+	if e.Status != StatusCompleted || len(submitted) != 2 || submitted[0].BaseAmount != 0.75 ||
+		submitted[1].Type != exchange.Market {
+		t.Error("Test Failed - VWAP expected market slices weighted by volume", e, submitted)
+	}
+}
+
+func TestIceberg(t *testing.T) {
+	m, exch, orderer, _ := newTestManager()
+	e, err := m.Start(Params{Algorithm: Iceberg, Exchange: exch.GetName(), Pair: pair.NewCurrencyPair("BTC", "USDT"),
+		Side: exchange.Buy, Amount: 1, Price: 100, VisibleAmount: 0.4})
+	if err != nil {
+		t.Fatal("Test Failed - Start() error", err)
+	}
+
+	// Fill each visible child as it is placed
+	for i := 0; i < 200; i++ {
+		if current, _ := m.Execution(e.ID); current.Status != StatusRunning {
+			break
+		}
+		if active := m.get(e.ID).active(); active != nil && active.IsOpen() {
+			err = active.AddFill(orders.Fill{Amount: active.Remaining(), Price: 100})
+			if err != nil {
+				t.Fatal("Test Failed - AddFill() error", err)
+			}
+		}
+		time.Sleep(time.Millisecond * 2)
+	}
+
+	e = waitFinished(t, m, e.ID)
+	submitted := orderer.placed()
+	if e.Status != StatusCompleted || len(submitted) != 3 || math.Abs(submitted[2].BaseAmount-0.2) > 1e-9 ||
+		math.Abs(e.FilledAmount-1) > 1e-9 || e.AveragePrice != 100 {
+		t.Error("Test Failed - Iceberg expected three children filling the amount", e, submitted)
+	}
+}
+
+func TestIcebergDeadline(t *testing.T) {
+	m, exch, _, _ := newTestManager()
+	e, err := m.Start(Params{Algorithm: Iceberg, Exchange: exch.GetName(), Pair: pair.NewCurrencyPair("BTC", "USDT"),
+		Side: exchange.Sell, Amount: 1, Price: 100, VisibleAmount: 0.4, Duration: time.Millisecond * 20})
+	if err != nil {
+		t.Fatal("Test Failed - Start() error", err)
+	}
+	e = waitFinished(t, m, e.ID)
+	if e.Status != StatusCancelled || e.Err != ErrDurationElapsed || len(exch.cancelled) != 1 {
+		t.Error("Test Failed - Iceberg expected cancellation once the duration elapsed", e, exch.cancelled)
+	}
+}
+
+func TestCancelOnDeviation(t *testing.T) {
+	m, exch, orderer, tick := newTestManager()
+	e, err := m.Start(Params{Algorithm: TWAP, Exchange: exch.GetName(), Pair: pair.NewCurrencyPair("BTC", "USDT"),
+		Side: exchange.Buy, Amount: 1, Price: 100, Duration: time.Second, Slices: 2, MaxDeviation: 0.05})
+	if err != nil {
+		t.Fatal("Test Failed - Start() error", err)
+	}
+	if e.ReferencePrice != 100 {
+		t.Error("Test Failed - Start() expected reference price", e.ReferencePrice)
+	}
+
+	for i := 0; i < 100 && len(orderer.placed()) == 0; i++ {
+		time.Sleep(time.Millisecond)
+	}
+	tick.set(106)
+	e = waitFinished(t, m, e.ID)
+	if e.Status != StatusCancelled || e.Err != ErrPriceDeviation || len(orderer.placed()) != 1 ||
+		len(exch.cancelled) != 1 {
+		t.Error("Test Failed - expected execution cancelled on deviation", e, exch.cancelled)
+	}
+}
+
+func TestCancel(t *testing.T) {
+	m, exch, orderer, _ := newTestManager()
+	orderer.err = errors.New("throttled")
+	e, err := m.Start(Params{Algorithm: TWAP, Exchange: exch.GetName(), Amount: 1, Duration: time.Second, Slices: 2})
+	if err != nil {
+		t.Fatal("Test Failed - Start() error", err)
+	}
+	if e = waitFinished(t, m, e.ID); e.Status != StatusFailed || e.Err != orderer.err {
+		t.Error("Test Failed - expected execution failed on rejected order", e)
+	}
+
+	orderer.err = nil
+	e, err = m.Start(Params{Algorithm: TWAP, Exchange: exch.GetName(), Amount: 1, Duration: time.Second, Slices: 2})
+	if err != nil {
+		t.Fatal("Test Failed - Start() error", err)
+	}
+	if err = m.Cancel(e.ID); err != nil {
+		t.Fatal("Test Failed - Cancel() error", err)
+	}
+	if e = waitFinished(t, m, e.ID); e.Status != StatusCancelled || e.Err != ErrCancelled {
+		t.Error("Test Failed - expected execution cancelled", e)
+	}
+	if executions := m.Executions(); len(executions) != 2 || executions[1].ID != 1 {
+		t.Error("Test Failed - Executions() expected both executions", executions)
+	}
+}
@@ -0,0 +1,439 @@
+package execution
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/assets"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-/gocryptotrader/exchanges/orders"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+// DefaultPollInterval is how often running executions check prices for
+// deviation and iceberg executions check their child order for fills
+const DefaultPollInterval = 5 * time.Second
+
+// Execution statuses
+const (
+	StatusRunning   = "RUNNING"
+	StatusCompleted = "COMPLETED"
+	StatusCancelled = "CANCELLED"
+	StatusFailed    = "FAILED"
+)
+
+// fillTolerance is the remaining amount below which a parent order is filled
+const fillTolerance = 1e-8
+
+// profileIntervals are the candle intervals a VWAP volume profile is built
+// from, the largest not exceeding the slice interval is used
+var profileIntervals = []kline.Interval{kline.OneHour, kline.FifteenMin, kline.FiveMin, kline.OneMin}
+
+// TickerGetter returns the latest ticker of a currency pair, such as
+// ticker.GetTicker
+type TickerGetter func(exchName string, p pair.CurrencyPair, assetType string) (ticker.Price, error)
+
+// Execution is the state of a parent order. Children are the local order
+// manager IDs of its child orders, FilledAmount and AveragePrice are summed
+// from their fills as the order manager observes them. ReferencePrice is the
+// last price when the execution started, set when MaxDeviation is. Err is why
+// a cancelled or failed execution stopped.
+type Execution struct {
+	ID             int
+	Params         Params
+	Status         string
+	Err            error
+	Started        time.Time
+	Finished       time.Time
+	ReferencePrice float64
+	Children       []int
+	FilledAmount   float64
+	AveragePrice   float64
+}
+
+// execution is a running parent order, children holds its child orders so
+// they are not looked up in the order store while it changes
+type execution struct {
+	Execution
+	exch      exchange.IBotExchange
+	children  []*orders.Order
+	cancel    chan struct{}
+	cancelled bool
+	m         sync.Mutex
+}
+
+// Manager runs executions, placing their child orders through Orderer
+type Manager struct {
+	Orderer      exchange.Orderer
+	GetExchange  exchange.ExchangeGetter
+	GetTicker    TickerGetter
+	PollInterval time.Duration
+	// OnFinish is called once an execution completes, is cancelled or fails
+	OnFinish func(Execution)
+
+	random     func() float64
+	executions []*execution
+	m          sync.Mutex
+}
+
+// NewManager returns an execution manager placing child orders through
+// orderer and checking prices with getTicker
+func NewManager(orderer exchange.Orderer, getExchange exchange.ExchangeGetter, getTicker TickerGetter) *Manager {
+	return &Manager{
+		Orderer:      orderer,
+		GetExchange:  getExchange,
+		GetTicker:    getTicker,
+		PollInterval: DefaultPollInterval,
+		random:       rand.Float64,
+	}
+}
+
+// Start validates and starts an execution, returning its initial state. VWAP
+// executions without a volume profile take theirs from the exchange's candles
+// of the same time window the previous day.
+func (m *Manager) Start(p Params) (Execution, error) {
+	err := p.Validate()
+	if err != nil {
+		return Execution{}, err
+	}
+	exch := m.GetExchange(p.Exchange)
+	if exch == nil {
+		return Execution{}, fmt.Errorf("%s exchange not found", p.Exchange)
+	}
+
+	now := time.Now()
+	if p.Algorithm == VWAP && len(p.VolumeProfile) == 0 {
+		p.VolumeProfile, err = historicVolumeProfile(exch, p, now)
+		if err != nil {
+			return Execution{}, fmt.Errorf("%s VWAP volume profile unavailable: %s", exch.GetName(), err)
+		}
+	}
+
+	e := &execution{
+		Execution: Execution{
+			Params:  p,
+			Status:  StatusRunning,
+			Started: now,
+		},
+		exch:   exch,
+		cancel: make(chan struct{}),
+	}
+	if p.MaxDeviation > 0 {
+		t, err := m.GetTicker(exch.GetName(), p.Pair, ticker.Spot)
+		if err != nil {
+			return Execution{}, err
+		}
+		if t.Last <= 0 {
+			return Execution{}, fmt.Errorf("%s %s has no last price to measure deviation from", exch.GetName(), p.Pair.Pair())
+		}
+		e.ReferencePrice = t.Last
+	}
+
+	m.m.Lock()
+	if len(m.executions) > 0 {
+		e.ID = m.executions[len(m.executions)-1].ID + 1
+	}
+	m.executions = append(m.executions, e)
+	m.m.Unlock()
+
+	snapshot := e.snapshot()
+	go m.run(e)
+	return snapshot, nil
+}
+
+// Cancel stops a running execution and cancels the unfilled remainder of its
+// open child order
+func (m *Manager) Cancel(id int) error {
+	e := m.get(id)
+	if e == nil {
+		return ErrNotFound
+	}
+	e.m.Lock()
+	defer e.m.Unlock()
+	if e.Status != StatusRunning || e.cancelled {
+		return ErrFinished
+	}
+	e.cancelled = true
+	close(e.cancel)
+	return nil
+}
+
+// Execution returns the state of an execution
+func (m *Manager) Execution(id int) (Execution, error) {
+	e := m.get(id)
+	if e == nil {
+		return Execution{}, ErrNotFound
+	}
+	return e.snapshot(), nil
+}
+
+// Executions returns the state of every execution ordered by ID
+func (m *Manager) Executions() []Execution {
+	m.m.Lock()
+	executions := append([]*execution(nil), m.executions...)
+	m.m.Unlock()
+
+	result := make([]Execution, len(executions))
+	for i := range executions {
+		result[i] = executions[i].snapshot()
+	}
+	return result
+}
+
+// get returns an execution by ID or nil
+func (m *Manager) get(id int) *execution {
+	m.m.Lock()
+	defer m.m.Unlock()
+	for _, e := range m.executions {
+		if e.ID == id {
+			return e
+		}
+	}
+	return nil
+}
+
+// run places an execution's child orders until it finishes
+func (m *Manager) run(e *execution) {
+	var err error
+	if e.Params.Algorithm == Iceberg {
+		err = m.runIceberg(e)
+	} else {
+		err = m.runSlices(e, Schedule(e.Params, m.random))
+	}
+	if err != nil {
+		m.cancelActive(e)
+	}
+
+	e.m.Lock()
+	switch err {
+	case nil:
+		e.Status = StatusCompleted
+	case ErrCancelled, ErrPriceDeviation, ErrDurationElapsed:
+		e.Status = StatusCancelled
+	default:
+		e.Status = StatusFailed
+	}
+	e.Err = err
+	e.Finished = time.Now()
+	e.m.Unlock()
+
+	if m.OnFinish != nil {
+		m.OnFinish(e.snapshot())
+	}
+}
+
+// runSlices places the slices of a TWAP or VWAP execution as they fall due.
+// The unfilled remainder of a limit slice is cancelled when the next slice is
+// due and carried into it, the last slice is cancelled once the duration
+// elapses.
+func (m *Manager) runSlices(e *execution, slices []Slice) error {
+	for _, s := range slices {
+		if err := m.wait(e, e.Started.Add(s.Offset)); err != nil {
+			return err
+		}
+		if err := m.checkDeviation(e); err != nil {
+			return err
+		}
+		if err := m.place(e, s.Amount+m.cancelActive(e)); err != nil {
+			return err
+		}
+	}
+	if err := m.wait(e, e.Started.Add(e.Params.Duration)); err != nil {
+		return err
+	}
+	m.cancelActive(e)
+	return nil
+}
+
+// runIceberg keeps a single limit child of about the visible amount open,
+// placing the next once the previous has filled, until the amount is filled
+// or the duration elapses
+func (m *Manager) runIceberg(e *execution) error {
+	p := e.Params
+	var deadline time.Time
+	if p.Duration > 0 {
+		deadline = e.Started.Add(p.Duration)
+	}
+	for {
+		filled, _ := e.filled()
+		remaining := p.Amount - filled
+		if remaining <= fillTolerance {
+			return nil
+		}
+		if !deadline.IsZero() && !time.Now().Before(deadline) {
+			return ErrDurationElapsed
+		}
+
+		if active := e.active(); active == nil || !active.IsOpen() {
+			visible := randomize(p.VisibleAmount, p.Randomization, m.random)
+			if visible > remaining {
+				visible = remaining
+			}
+			if err := m.place(e, visible); err != nil {
+				return err
+			}
+		}
+
+		next := time.Now().Add(m.PollInterval)
+		if !deadline.IsZero() && deadline.Before(next) {
+			next = deadline
+		}
+		if err := m.wait(e, next); err != nil {
+			return err
+		}
+	}
+}
+
+// wait waits until a time, checking the price for deviation each poll
+// interval, and returns early when the execution is cancelled or the price
+// deviates
+func (m *Manager) wait(e *execution, until time.Time) error {
+	for {
+		d := time.Until(until)
+		if d <= 0 {
+			return nil
+		}
+		if d > m.PollInterval {
+			d = m.PollInterval
+		}
+		select {
+		case <-e.cancel:
+			return ErrCancelled
+		case <-time.After(d):
+		}
+		if err := m.checkDeviation(e); err != nil {
+			return err
+		}
+	}
+}
+
+// checkDeviation returns ErrPriceDeviation when the last price has moved from
+// the reference price by more than the maximum deviation
+func (m *Manager) checkDeviation(e *execution) error {
+	if e.Params.MaxDeviation == 0 {
+		return nil
+	}
+	t, err := m.GetTicker(e.exch.GetName(), e.Params.Pair, ticker.Spot)
+	if err != nil {
+		return err
+	}
+	if t.Last > 0 && deviation(t.Last, e.ReferencePrice) > e.Params.MaxDeviation {
+		return ErrPriceDeviation
+	}
+	return nil
+}
+
+// place submits a child order, a limit order at the execution's price or a
+// market order when it has none
+func (m *Manager) place(e *execution, amount float64) error {
+	if amount <= fillTolerance {
+		return nil
+	}
+	o := exchange.OrderSubmission{
+		Pair:       e.Params.Pair,
+		Side:       e.Params.Side,
+		Type:       exchange.Market,
+		BaseAmount: amount,
+	}
+	if e.Params.Price > 0 {
+		o.Type = exchange.Limit
+		o.Price = e.Params.Price
+	}
+	id, err := m.Orderer.SubmitStrategyOrder(StrategyName, e.exch, o)
+	if err != nil {
+		return err
+	}
+	child := orders.GetOrderByOrderID(id)
+	e.m.Lock()
+	e.Children = append(e.Children, id)
+	if child != nil {
+		e.children = append(e.children, child)
+	}
+	e.m.Unlock()
+	return nil
+}
+
+// cancelActive cancels the unfilled remainder of the open limit child order,
+// if any, and returns the amount cancelled. Market orders are never
+// cancelled.
+func (m *Manager) cancelActive(e *execution) float64 {
+	if e.Params.Price == 0 {
+		return 0
+	}
+	active := e.active()
+	if active == nil || !active.IsOpen() {
+		return 0
+	}
+	remaining := active.Remaining()
+	if err := active.CancelRemainder(e.exch.CancelOrder); err != nil {
+		return 0
+	}
+	return remaining
+}
+
+// active returns the most recently placed child order
+func (e *execution) active() *orders.Order {
+	e.m.Lock()
+	defer e.m.Unlock()
+	if len(e.children) == 0 {
+		return nil
+	}
+	return e.children[len(e.children)-1]
+}
+
+// filled sums the fills across all child orders
+func (e *execution) filled() (amount, averagePrice float64) {
+	e.m.Lock()
+	children := append([]*orders.Order(nil), e.children...)
+	e.m.Unlock()
+
+	var notional float64
+	for _, o := range children {
+		d := o.Detail()
+		amount += d.ExecutedAmount
+		notional += d.ExecutedAmount * d.AverageExecutedPrice
+	}
+	if amount > 0 {
+		averagePrice = notional / amount
+	}
+	return amount, averagePrice
+}
+
+// snapshot returns a copy of the execution's state
+func (e *execution) snapshot() Execution {
+	filled, average := e.filled()
+	e.m.Lock()
+	defer e.m.Unlock()
+	s := e.Execution
+	s.Children = append([]int(nil), e.Children...)
+	s.FilledAmount, s.AveragePrice = filled, average
+	return s
+}
+
+// historicVolumeProfile builds a VWAP volume profile from the exchange's
+// candles of the execution's time window the previous day
+func historicVolumeProfile(exch exchange.IBotExchange, p Params, now time.Time) ([]float64, error) {
+	sliceInterval := p.Duration / time.Duration(p.Slices)
+	interval := kline.OneMin
+	for _, i := range profileIntervals {
+		if time.Duration(i) <= sliceInterval {
+			interval = i
+			break
+		}
+	}
+
+	start := now.Add(-24 * time.Hour)
+	candles, err := exch.GetHistoricCandles(p.Pair, assets.Spot, interval, start, start.Add(p.Duration))
+	if err != nil {
+		return nil, err
+	}
+	profile := VolumeProfile(candles, now, p.Duration, p.Slices)
+	if profile == nil {
+		return nil, fmt.Errorf("no volume traded between %s and %s", start.Format(time.RFC3339), start.Add(p.Duration).Format(time.RFC3339))
+	}
+	return profile, nil
+}
@@ -0,0 +1,4091 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: rpc.proto
+
+package gctrpc
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type GenericExchangeNameRequest struct {
+	Exchange             string   `protobuf:"bytes,1,opt,name=exchange,proto3" json:"exchange,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GenericExchangeNameRequest) Reset()         { *m = GenericExchangeNameRequest{} }
+func (m *GenericExchangeNameRequest) String() string { return proto.CompactTextString(m) }
+func (*GenericExchangeNameRequest) ProtoMessage()    {}
+func (*GenericExchangeNameRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{0}
+}
+
+func (m *GenericExchangeNameRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GenericExchangeNameRequest.Unmarshal(m, b)
+}
+func (m *GenericExchangeNameRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GenericExchangeNameRequest.Marshal(b, m, deterministic)
+}
+func (m *GenericExchangeNameRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GenericExchangeNameRequest.Merge(m, src)
+}
+func (m *GenericExchangeNameRequest) XXX_Size() int {
+	return xxx_messageInfo_GenericExchangeNameRequest.Size(m)
+}
+func (m *GenericExchangeNameRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GenericExchangeNameRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GenericExchangeNameRequest proto.InternalMessageInfo
+
+func (m *GenericExchangeNameRequest) GetExchange() string {
+	if m != nil {
+		return m.Exchange
+	}
+	return ""
+}
+
+type GenericResponse struct {
+	Status               string   `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GenericResponse) Reset()         { *m = GenericResponse{} }
+func (m *GenericResponse) String() string { return proto.CompactTextString(m) }
+func (*GenericResponse) ProtoMessage()    {}
+func (*GenericResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{1}
+}
+
+func (m *GenericResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GenericResponse.Unmarshal(m, b)
+}
+func (m *GenericResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GenericResponse.Marshal(b, m, deterministic)
+}
+func (m *GenericResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GenericResponse.Merge(m, src)
+}
+func (m *GenericResponse) XXX_Size() int {
+	return xxx_messageInfo_GenericResponse.Size(m)
+}
+func (m *GenericResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GenericResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GenericResponse proto.InternalMessageInfo
+
+func (m *GenericResponse) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+type CurrencyPair struct {
+	Delimiter            string   `protobuf:"bytes,1,opt,name=delimiter,proto3" json:"delimiter,omitempty"`
+	Base                 string   `protobuf:"bytes,2,opt,name=base,proto3" json:"base,omitempty"`
+	Quote                string   `protobuf:"bytes,3,opt,name=quote,proto3" json:"quote,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CurrencyPair) Reset()         { *m = CurrencyPair{} }
+func (m *CurrencyPair) String() string { return proto.CompactTextString(m) }
+func (*CurrencyPair) ProtoMessage()    {}
+func (*CurrencyPair) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{2}
+}
+
+func (m *CurrencyPair) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CurrencyPair.Unmarshal(m, b)
+}
+func (m *CurrencyPair) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CurrencyPair.Marshal(b, m, deterministic)
+}
+func (m *CurrencyPair) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CurrencyPair.Merge(m, src)
+}
+func (m *CurrencyPair) XXX_Size() int {
+	return xxx_messageInfo_CurrencyPair.Size(m)
+}
+func (m *CurrencyPair) XXX_DiscardUnknown() {
+	xxx_messageInfo_CurrencyPair.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CurrencyPair proto.InternalMessageInfo
+
+func (m *CurrencyPair) GetDelimiter() string {
+	if m != nil {
+		return m.Delimiter
+	}
+	return ""
+}
+
+func (m *CurrencyPair) GetBase() string {
+	if m != nil {
+		return m.Base
+	}
+	return ""
+}
+
+func (m *CurrencyPair) GetQuote() string {
+	if m != nil {
+		return m.Quote
+	}
+	return ""
+}
+
+type GetExchangesRequest struct {
+	// all returns every configured exchange rather than the loaded exchanges
+	All                  bool     `protobuf:"varint,1,opt,name=all,proto3" json:"all,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetExchangesRequest) Reset()         { *m = GetExchangesRequest{} }
+func (m *GetExchangesRequest) String() string { return proto.CompactTextString(m) }
+func (*GetExchangesRequest) ProtoMessage()    {}
+func (*GetExchangesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{3}
+}
+
+func (m *GetExchangesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetExchangesRequest.Unmarshal(m, b)
+}
+func (m *GetExchangesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetExchangesRequest.Marshal(b, m, deterministic)
+}
+func (m *GetExchangesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetExchangesRequest.Merge(m, src)
+}
+func (m *GetExchangesRequest) XXX_Size() int {
+	return xxx_messageInfo_GetExchangesRequest.Size(m)
+}
+func (m *GetExchangesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetExchangesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetExchangesRequest proto.InternalMessageInfo
+
+func (m *GetExchangesRequest) GetAll() bool {
+	if m != nil {
+		return m.All
+	}
+	return false
+}
+
+type GetExchangesResponse struct {
+	Exchanges            []string `protobuf:"bytes,1,rep,name=exchanges,proto3" json:"exchanges,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetExchangesResponse) Reset()         { *m = GetExchangesResponse{} }
+func (m *GetExchangesResponse) String() string { return proto.CompactTextString(m) }
+func (*GetExchangesResponse) ProtoMessage()    {}
+func (*GetExchangesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{4}
+}
+
+func (m *GetExchangesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetExchangesResponse.Unmarshal(m, b)
+}
+func (m *GetExchangesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetExchangesResponse.Marshal(b, m, deterministic)
+}
+func (m *GetExchangesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetExchangesResponse.Merge(m, src)
+}
+func (m *GetExchangesResponse) XXX_Size() int {
+	return xxx_messageInfo_GetExchangesResponse.Size(m)
+}
+func (m *GetExchangesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetExchangesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetExchangesResponse proto.InternalMessageInfo
+
+func (m *GetExchangesResponse) GetExchanges() []string {
+	if m != nil {
+		return m.Exchanges
+	}
+	return nil
+}
+
+type GetExchangeCapabilitiesResponse struct {
+	Exchange             string   `protobuf:"bytes,1,opt,name=exchange,proto3" json:"exchange,omitempty"`
+	Capabilities         []string `protobuf:"bytes,2,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetExchangeCapabilitiesResponse) Reset()         { *m = GetExchangeCapabilitiesResponse{} }
+func (m *GetExchangeCapabilitiesResponse) String() string { return proto.CompactTextString(m) }
+func (*GetExchangeCapabilitiesResponse) ProtoMessage()    {}
+func (*GetExchangeCapabilitiesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{5}
+}
+
+func (m *GetExchangeCapabilitiesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetExchangeCapabilitiesResponse.Unmarshal(m, b)
+}
+func (m *GetExchangeCapabilitiesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetExchangeCapabilitiesResponse.Marshal(b, m, deterministic)
+}
+func (m *GetExchangeCapabilitiesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetExchangeCapabilitiesResponse.Merge(m, src)
+}
+func (m *GetExchangeCapabilitiesResponse) XXX_Size() int {
+	return xxx_messageInfo_GetExchangeCapabilitiesResponse.Size(m)
+}
+func (m *GetExchangeCapabilitiesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetExchangeCapabilitiesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetExchangeCapabilitiesResponse proto.InternalMessageInfo
+
+func (m *GetExchangeCapabilitiesResponse) GetExchange() string {
+	if m != nil {
+		return m.Exchange
+	}
+	return ""
+}
+
+func (m *GetExchangeCapabilitiesResponse) GetCapabilities() []string {
+	if m != nil {
+		return m.Capabilities
+	}
+	return nil
+}
+
+type GetTickerRequest struct {
+	Exchange             string        `protobuf:"bytes,1,opt,name=exchange,proto3" json:"exchange,omitempty"`
+	Pair                 *CurrencyPair `protobuf:"bytes,2,opt,name=pair,proto3" json:"pair,omitempty"`
+	AssetType            string        `protobuf:"bytes,3,opt,name=asset_type,json=assetType,proto3" json:"asset_type,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *GetTickerRequest) Reset()         { *m = GetTickerRequest{} }
+func (m *GetTickerRequest) String() string { return proto.CompactTextString(m) }
+func (*GetTickerRequest) ProtoMessage()    {}
+func (*GetTickerRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{6}
+}
+
+func (m *GetTickerRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTickerRequest.Unmarshal(m, b)
+}
+func (m *GetTickerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTickerRequest.Marshal(b, m, deterministic)
+}
+func (m *GetTickerRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTickerRequest.Merge(m, src)
+}
+func (m *GetTickerRequest) XXX_Size() int {
+	return xxx_messageInfo_GetTickerRequest.Size(m)
+}
+func (m *GetTickerRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTickerRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTickerRequest proto.InternalMessageInfo
+
+func (m *GetTickerRequest) GetExchange() string {
+	if m != nil {
+		return m.Exchange
+	}
+	return ""
+}
+
+func (m *GetTickerRequest) GetPair() *CurrencyPair {
+	if m != nil {
+		return m.Pair
+	}
+	return nil
+}
+
+func (m *GetTickerRequest) GetAssetType() string {
+	if m != nil {
+		return m.AssetType
+	}
+	return ""
+}
+
+type TickerResponse struct {
+	Pair                 *CurrencyPair `protobuf:"bytes,1,opt,name=pair,proto3" json:"pair,omitempty"`
+	LastUpdated          int64         `protobuf:"varint,2,opt,name=last_updated,json=lastUpdated,proto3" json:"last_updated,omitempty"`
+	Last                 float64       `protobuf:"fixed64,3,opt,name=last,proto3" json:"last,omitempty"`
+	High                 float64       `protobuf:"fixed64,4,opt,name=high,proto3" json:"high,omitempty"`
+	Low                  float64       `protobuf:"fixed64,5,opt,name=low,proto3" json:"low,omitempty"`
+	Bid                  float64       `protobuf:"fixed64,6,opt,name=bid,proto3" json:"bid,omitempty"`
+	Ask                  float64       `protobuf:"fixed64,7,opt,name=ask,proto3" json:"ask,omitempty"`
+	Volume               float64       `protobuf:"fixed64,8,opt,name=volume,proto3" json:"volume,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *TickerResponse) Reset()         { *m = TickerResponse{} }
+func (m *TickerResponse) String() string { return proto.CompactTextString(m) }
+func (*TickerResponse) ProtoMessage()    {}
+func (*TickerResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{7}
+}
+
+func (m *TickerResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TickerResponse.Unmarshal(m, b)
+}
+func (m *TickerResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TickerResponse.Marshal(b, m, deterministic)
+}
+func (m *TickerResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TickerResponse.Merge(m, src)
+}
+func (m *TickerResponse) XXX_Size() int {
+	return xxx_messageInfo_TickerResponse.Size(m)
+}
+func (m *TickerResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TickerResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TickerResponse proto.InternalMessageInfo
+
+func (m *TickerResponse) GetPair() *CurrencyPair {
+	if m != nil {
+		return m.Pair
+	}
+	return nil
+}
+
+func (m *TickerResponse) GetLastUpdated() int64 {
+	if m != nil {
+		return m.LastUpdated
+	}
+	return 0
+}
+
+func (m *TickerResponse) GetLast() float64 {
+	if m != nil {
+		return m.Last
+	}
+	return 0
+}
+
+func (m *TickerResponse) GetHigh() float64 {
+	if m != nil {
+		return m.High
+	}
+	return 0
+}
+
+func (m *TickerResponse) GetLow() float64 {
+	if m != nil {
+		return m.Low
+	}
+	return 0
+}
+
+func (m *TickerResponse) GetBid() float64 {
+	if m != nil {
+		return m.Bid
+	}
+	return 0
+}
+
+func (m *TickerResponse) GetAsk() float64 {
+	if m != nil {
+		return m.Ask
+	}
+	return 0
+}
+
+func (m *TickerResponse) GetVolume() float64 {
+	if m != nil {
+		return m.Volume
+	}
+	return 0
+}
+
+type GetOrderbookRequest struct {
+	Exchange             string        `protobuf:"bytes,1,opt,name=exchange,proto3" json:"exchange,omitempty"`
+	Pair                 *CurrencyPair `protobuf:"bytes,2,opt,name=pair,proto3" json:"pair,omitempty"`
+	AssetType            string        `protobuf:"bytes,3,opt,name=asset_type,json=assetType,proto3" json:"asset_type,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *GetOrderbookRequest) Reset()         { *m = GetOrderbookRequest{} }
+func (m *GetOrderbookRequest) String() string { return proto.CompactTextString(m) }
+func (*GetOrderbookRequest) ProtoMessage()    {}
+func (*GetOrderbookRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{8}
+}
+
+func (m *GetOrderbookRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetOrderbookRequest.Unmarshal(m, b)
+}
+func (m *GetOrderbookRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetOrderbookRequest.Marshal(b, m, deterministic)
+}
+func (m *GetOrderbookRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetOrderbookRequest.Merge(m, src)
+}
+func (m *GetOrderbookRequest) XXX_Size() int {
+	return xxx_messageInfo_GetOrderbookRequest.Size(m)
+}
+func (m *GetOrderbookRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetOrderbookRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetOrderbookRequest proto.InternalMessageInfo
+
+func (m *GetOrderbookRequest) GetExchange() string {
+	if m != nil {
+		return m.Exchange
+	}
+	return ""
+}
+
+func (m *GetOrderbookRequest) GetPair() *CurrencyPair {
+	if m != nil {
+		return m.Pair
+	}
+	return nil
+}
+
+func (m *GetOrderbookRequest) GetAssetType() string {
+	if m != nil {
+		return m.AssetType
+	}
+	return ""
+}
+
+type OrderbookItem struct {
+	Amount               float64  `protobuf:"fixed64,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	Price                float64  `protobuf:"fixed64,2,opt,name=price,proto3" json:"price,omitempty"`
+	Id                   int64    `protobuf:"varint,3,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *OrderbookItem) Reset()         { *m = OrderbookItem{} }
+func (m *OrderbookItem) String() string { return proto.CompactTextString(m) }
+func (*OrderbookItem) ProtoMessage()    {}
+func (*OrderbookItem) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{9}
+}
+
+func (m *OrderbookItem) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OrderbookItem.Unmarshal(m, b)
+}
+func (m *OrderbookItem) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OrderbookItem.Marshal(b, m, deterministic)
+}
+func (m *OrderbookItem) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OrderbookItem.Merge(m, src)
+}
+func (m *OrderbookItem) XXX_Size() int {
+	return xxx_messageInfo_OrderbookItem.Size(m)
+}
+func (m *OrderbookItem) XXX_DiscardUnknown() {
+	xxx_messageInfo_OrderbookItem.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OrderbookItem proto.InternalMessageInfo
+
+func (m *OrderbookItem) GetAmount() float64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *OrderbookItem) GetPrice() float64 {
+	if m != nil {
+		return m.Price
+	}
+	return 0
+}
+
+func (m *OrderbookItem) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type OrderbookResponse struct {
+	Pair                 *CurrencyPair    `protobuf:"bytes,1,opt,name=pair,proto3" json:"pair,omitempty"`
+	Bids                 []*OrderbookItem `protobuf:"bytes,2,rep,name=bids,proto3" json:"bids,omitempty"`
+	Asks                 []*OrderbookItem `protobuf:"bytes,3,rep,name=asks,proto3" json:"asks,omitempty"`
+	LastUpdated          int64            `protobuf:"varint,4,opt,name=last_updated,json=lastUpdated,proto3" json:"last_updated,omitempty"`
+	AssetType            string           `protobuf:"bytes,5,opt,name=asset_type,json=assetType,proto3" json:"asset_type,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *OrderbookResponse) Reset()         { *m = OrderbookResponse{} }
+func (m *OrderbookResponse) String() string { return proto.CompactTextString(m) }
+func (*OrderbookResponse) ProtoMessage()    {}
+func (*OrderbookResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{10}
+}
+
+func (m *OrderbookResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OrderbookResponse.Unmarshal(m, b)
+}
+func (m *OrderbookResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OrderbookResponse.Marshal(b, m, deterministic)
+}
+func (m *OrderbookResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OrderbookResponse.Merge(m, src)
+}
+func (m *OrderbookResponse) XXX_Size() int {
+	return xxx_messageInfo_OrderbookResponse.Size(m)
+}
+func (m *OrderbookResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_OrderbookResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OrderbookResponse proto.InternalMessageInfo
+
+func (m *OrderbookResponse) GetPair() *CurrencyPair {
+	if m != nil {
+		return m.Pair
+	}
+	return nil
+}
+
+func (m *OrderbookResponse) GetBids() []*OrderbookItem {
+	if m != nil {
+		return m.Bids
+	}
+	return nil
+}
+
+func (m *OrderbookResponse) GetAsks() []*OrderbookItem {
+	if m != nil {
+		return m.Asks
+	}
+	return nil
+}
+
+func (m *OrderbookResponse) GetLastUpdated() int64 {
+	if m != nil {
+		return m.LastUpdated
+	}
+	return 0
+}
+
+func (m *OrderbookResponse) GetAssetType() string {
+	if m != nil {
+		return m.AssetType
+	}
+	return ""
+}
+
+type AccountCurrencyInfo struct {
+	Currency             string   `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"`
+	TotalValue           float64  `protobuf:"fixed64,2,opt,name=total_value,json=totalValue,proto3" json:"total_value,omitempty"`
+	Hold                 float64  `protobuf:"fixed64,3,opt,name=hold,proto3" json:"hold,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AccountCurrencyInfo) Reset()         { *m = AccountCurrencyInfo{} }
+func (m *AccountCurrencyInfo) String() string { return proto.CompactTextString(m) }
+func (*AccountCurrencyInfo) ProtoMessage()    {}
+func (*AccountCurrencyInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{11}
+}
+
+func (m *AccountCurrencyInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AccountCurrencyInfo.Unmarshal(m, b)
+}
+func (m *AccountCurrencyInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AccountCurrencyInfo.Marshal(b, m, deterministic)
+}
+func (m *AccountCurrencyInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccountCurrencyInfo.Merge(m, src)
+}
+func (m *AccountCurrencyInfo) XXX_Size() int {
+	return xxx_messageInfo_AccountCurrencyInfo.Size(m)
+}
+func (m *AccountCurrencyInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccountCurrencyInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AccountCurrencyInfo proto.InternalMessageInfo
+
+func (m *AccountCurrencyInfo) GetCurrency() string {
+	if m != nil {
+		return m.Currency
+	}
+	return ""
+}
+
+func (m *AccountCurrencyInfo) GetTotalValue() float64 {
+	if m != nil {
+		return m.TotalValue
+	}
+	return 0
+}
+
+func (m *AccountCurrencyInfo) GetHold() float64 {
+	if m != nil {
+		return m.Hold
+	}
+	return 0
+}
+
+type GetAccountInfoResponse struct {
+	Exchange             string                 `protobuf:"bytes,1,opt,name=exchange,proto3" json:"exchange,omitempty"`
+	Currencies           []*AccountCurrencyInfo `protobuf:"bytes,2,rep,name=currencies,proto3" json:"currencies,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *GetAccountInfoResponse) Reset()         { *m = GetAccountInfoResponse{} }
+func (m *GetAccountInfoResponse) String() string { return proto.CompactTextString(m) }
+func (*GetAccountInfoResponse) ProtoMessage()    {}
+func (*GetAccountInfoResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{12}
+}
+
+func (m *GetAccountInfoResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetAccountInfoResponse.Unmarshal(m, b)
+}
+func (m *GetAccountInfoResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetAccountInfoResponse.Marshal(b, m, deterministic)
+}
+func (m *GetAccountInfoResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetAccountInfoResponse.Merge(m, src)
+}
+func (m *GetAccountInfoResponse) XXX_Size() int {
+	return xxx_messageInfo_GetAccountInfoResponse.Size(m)
+}
+func (m *GetAccountInfoResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetAccountInfoResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetAccountInfoResponse proto.InternalMessageInfo
+
+func (m *GetAccountInfoResponse) GetExchange() string {
+	if m != nil {
+		return m.Exchange
+	}
+	return ""
+}
+
+func (m *GetAccountInfoResponse) GetCurrencies() []*AccountCurrencyInfo {
+	if m != nil {
+		return m.Currencies
+	}
+	return nil
+}
+
+type SubmitOrderRequest struct {
+	Exchange  string        `protobuf:"bytes,1,opt,name=exchange,proto3" json:"exchange,omitempty"`
+	Pair      *CurrencyPair `protobuf:"bytes,2,opt,name=pair,proto3" json:"pair,omitempty"`
+	Side      string        `protobuf:"bytes,3,opt,name=side,proto3" json:"side,omitempty"`
+	OrderType string        `protobuf:"bytes,4,opt,name=order_type,json=orderType,proto3" json:"order_type,omitempty"`
+	// Exactly one of base_amount and quote_amount is set
+	BaseAmount           float64  `protobuf:"fixed64,5,opt,name=base_amount,json=baseAmount,proto3" json:"base_amount,omitempty"`
+	QuoteAmount          float64  `protobuf:"fixed64,6,opt,name=quote_amount,json=quoteAmount,proto3" json:"quote_amount,omitempty"`
+	Price                float64  `protobuf:"fixed64,7,opt,name=price,proto3" json:"price,omitempty"`
+	ClientId             string   `protobuf:"bytes,8,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SubmitOrderRequest) Reset()         { *m = SubmitOrderRequest{} }
+func (m *SubmitOrderRequest) String() string { return proto.CompactTextString(m) }
+func (*SubmitOrderRequest) ProtoMessage()    {}
+func (*SubmitOrderRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{13}
+}
+
+func (m *SubmitOrderRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubmitOrderRequest.Unmarshal(m, b)
+}
+func (m *SubmitOrderRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubmitOrderRequest.Marshal(b, m, deterministic)
+}
+func (m *SubmitOrderRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubmitOrderRequest.Merge(m, src)
+}
+func (m *SubmitOrderRequest) XXX_Size() int {
+	return xxx_messageInfo_SubmitOrderRequest.Size(m)
+}
+func (m *SubmitOrderRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubmitOrderRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubmitOrderRequest proto.InternalMessageInfo
+
+func (m *SubmitOrderRequest) GetExchange() string {
+	if m != nil {
+		return m.Exchange
+	}
+	return ""
+}
+
+func (m *SubmitOrderRequest) GetPair() *CurrencyPair {
+	if m != nil {
+		return m.Pair
+	}
+	return nil
+}
+
+func (m *SubmitOrderRequest) GetSide() string {
+	if m != nil {
+		return m.Side
+	}
+	return ""
+}
+
+func (m *SubmitOrderRequest) GetOrderType() string {
+	if m != nil {
+		return m.OrderType
+	}
+	return ""
+}
+
+func (m *SubmitOrderRequest) GetBaseAmount() float64 {
+	if m != nil {
+		return m.BaseAmount
+	}
+	return 0
+}
+
+func (m *SubmitOrderRequest) GetQuoteAmount() float64 {
+	if m != nil {
+		return m.QuoteAmount
+	}
+	return 0
+}
+
+func (m *SubmitOrderRequest) GetPrice() float64 {
+	if m != nil {
+		return m.Price
+	}
+	return 0
+}
+
+func (m *SubmitOrderRequest) GetClientId() string {
+	if m != nil {
+		return m.ClientId
+	}
+	return ""
+}
+
+type SubmitOrderResponse struct {
+	OrderPlaced          bool     `protobuf:"varint,1,opt,name=order_placed,json=orderPlaced,proto3" json:"order_placed,omitempty"`
+	OrderId              string   `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SubmitOrderResponse) Reset()         { *m = SubmitOrderResponse{} }
+func (m *SubmitOrderResponse) String() string { return proto.CompactTextString(m) }
+func (*SubmitOrderResponse) ProtoMessage()    {}
+func (*SubmitOrderResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{14}
+}
+
+func (m *SubmitOrderResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubmitOrderResponse.Unmarshal(m, b)
+}
+func (m *SubmitOrderResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubmitOrderResponse.Marshal(b, m, deterministic)
+}
+func (m *SubmitOrderResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubmitOrderResponse.Merge(m, src)
+}
+func (m *SubmitOrderResponse) XXX_Size() int {
+	return xxx_messageInfo_SubmitOrderResponse.Size(m)
+}
+func (m *SubmitOrderResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubmitOrderResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubmitOrderResponse proto.InternalMessageInfo
+
+func (m *SubmitOrderResponse) GetOrderPlaced() bool {
+	if m != nil {
+		return m.OrderPlaced
+	}
+	return false
+}
+
+func (m *SubmitOrderResponse) GetOrderId() string {
+	if m != nil {
+		return m.OrderId
+	}
+	return ""
+}
+
+type CancelOrderRequest struct {
+	Exchange             string        `protobuf:"bytes,1,opt,name=exchange,proto3" json:"exchange,omitempty"`
+	AccountId            string        `protobuf:"bytes,2,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	OrderId              string        `protobuf:"bytes,3,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Pair                 *CurrencyPair `protobuf:"bytes,4,opt,name=pair,proto3" json:"pair,omitempty"`
+	WalletAddress        string        `protobuf:"bytes,5,opt,name=wallet_address,json=walletAddress,proto3" json:"wallet_address,omitempty"`
+	Side                 string        `protobuf:"bytes,6,opt,name=side,proto3" json:"side,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *CancelOrderRequest) Reset()         { *m = CancelOrderRequest{} }
+func (m *CancelOrderRequest) String() string { return proto.CompactTextString(m) }
+func (*CancelOrderRequest) ProtoMessage()    {}
+func (*CancelOrderRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{15}
+}
+
+func (m *CancelOrderRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CancelOrderRequest.Unmarshal(m, b)
+}
+func (m *CancelOrderRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CancelOrderRequest.Marshal(b, m, deterministic)
+}
+func (m *CancelOrderRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CancelOrderRequest.Merge(m, src)
+}
+func (m *CancelOrderRequest) XXX_Size() int {
+	return xxx_messageInfo_CancelOrderRequest.Size(m)
+}
+func (m *CancelOrderRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CancelOrderRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CancelOrderRequest proto.InternalMessageInfo
+
+func (m *CancelOrderRequest) GetExchange() string {
+	if m != nil {
+		return m.Exchange
+	}
+	return ""
+}
+
+func (m *CancelOrderRequest) GetAccountId() string {
+	if m != nil {
+		return m.AccountId
+	}
+	return ""
+}
+
+func (m *CancelOrderRequest) GetOrderId() string {
+	if m != nil {
+		return m.OrderId
+	}
+	return ""
+}
+
+func (m *CancelOrderRequest) GetPair() *CurrencyPair {
+	if m != nil {
+		return m.Pair
+	}
+	return nil
+}
+
+func (m *CancelOrderRequest) GetWalletAddress() string {
+	if m != nil {
+		return m.WalletAddress
+	}
+	return ""
+}
+
+func (m *CancelOrderRequest) GetSide() string {
+	if m != nil {
+		return m.Side
+	}
+	return ""
+}
+
+type GetArbitrageOpportunitiesRequest struct {
+	// pair and exchange optionally filter the opportunities, exchange matches
+	// either the buy or sell exchange
+	Pair     *CurrencyPair `protobuf:"bytes,1,opt,name=pair,proto3" json:"pair,omitempty"`
+	Exchange string        `protobuf:"bytes,2,opt,name=exchange,proto3" json:"exchange,omitempty"`
+	// limit is the maximum number of opportunities returned, zero for all
+	Limit                int32    `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetArbitrageOpportunitiesRequest) Reset()         { *m = GetArbitrageOpportunitiesRequest{} }
+func (m *GetArbitrageOpportunitiesRequest) String() string { return proto.CompactTextString(m) }
+func (*GetArbitrageOpportunitiesRequest) ProtoMessage()    {}
+func (*GetArbitrageOpportunitiesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{16}
+}
+
+func (m *GetArbitrageOpportunitiesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetArbitrageOpportunitiesRequest.Unmarshal(m, b)
+}
+func (m *GetArbitrageOpportunitiesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetArbitrageOpportunitiesRequest.Marshal(b, m, deterministic)
+}
+func (m *GetArbitrageOpportunitiesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetArbitrageOpportunitiesRequest.Merge(m, src)
+}
+func (m *GetArbitrageOpportunitiesRequest) XXX_Size() int {
+	return xxx_messageInfo_GetArbitrageOpportunitiesRequest.Size(m)
+}
+func (m *GetArbitrageOpportunitiesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetArbitrageOpportunitiesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetArbitrageOpportunitiesRequest proto.InternalMessageInfo
+
+func (m *GetArbitrageOpportunitiesRequest) GetPair() *CurrencyPair {
+	if m != nil {
+		return m.Pair
+	}
+	return nil
+}
+
+func (m *GetArbitrageOpportunitiesRequest) GetExchange() string {
+	if m != nil {
+		return m.Exchange
+	}
+	return ""
+}
+
+func (m *GetArbitrageOpportunitiesRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type ArbitrageOpportunity struct {
+	Pair                 *CurrencyPair `protobuf:"bytes,1,opt,name=pair,proto3" json:"pair,omitempty"`
+	BuyExchange          string        `protobuf:"bytes,2,opt,name=buy_exchange,json=buyExchange,proto3" json:"buy_exchange,omitempty"`
+	BuyPrice             float64       `protobuf:"fixed64,3,opt,name=buy_price,json=buyPrice,proto3" json:"buy_price,omitempty"`
+	SellExchange         string        `protobuf:"bytes,4,opt,name=sell_exchange,json=sellExchange,proto3" json:"sell_exchange,omitempty"`
+	SellPrice            float64       `protobuf:"fixed64,5,opt,name=sell_price,json=sellPrice,proto3" json:"sell_price,omitempty"`
+	Amount               float64       `protobuf:"fixed64,6,opt,name=amount,proto3" json:"amount,omitempty"`
+	SpreadPercent        float64       `protobuf:"fixed64,7,opt,name=spread_percent,json=spreadPercent,proto3" json:"spread_percent,omitempty"`
+	Costs                float64       `protobuf:"fixed64,8,opt,name=costs,proto3" json:"costs,omitempty"`
+	Profit               float64       `protobuf:"fixed64,9,opt,name=profit,proto3" json:"profit,omitempty"`
+	ProfitPercent        float64       `protobuf:"fixed64,10,opt,name=profit_percent,json=profitPercent,proto3" json:"profit_percent,omitempty"`
+	Time                 int64         `protobuf:"varint,11,opt,name=time,proto3" json:"time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *ArbitrageOpportunity) Reset()         { *m = ArbitrageOpportunity{} }
+func (m *ArbitrageOpportunity) String() string { return proto.CompactTextString(m) }
+func (*ArbitrageOpportunity) ProtoMessage()    {}
+func (*ArbitrageOpportunity) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{17}
+}
+
+func (m *ArbitrageOpportunity) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ArbitrageOpportunity.Unmarshal(m, b)
+}
+func (m *ArbitrageOpportunity) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ArbitrageOpportunity.Marshal(b, m, deterministic)
+}
+func (m *ArbitrageOpportunity) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ArbitrageOpportunity.Merge(m, src)
+}
+func (m *ArbitrageOpportunity) XXX_Size() int {
+	return xxx_messageInfo_ArbitrageOpportunity.Size(m)
+}
+func (m *ArbitrageOpportunity) XXX_DiscardUnknown() {
+	xxx_messageInfo_ArbitrageOpportunity.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ArbitrageOpportunity proto.InternalMessageInfo
+
+func (m *ArbitrageOpportunity) GetPair() *CurrencyPair {
+	if m != nil {
+		return m.Pair
+	}
+	return nil
+}
+
+func (m *ArbitrageOpportunity) GetBuyExchange() string {
+	if m != nil {
+		return m.BuyExchange
+	}
+	return ""
+}
+
+func (m *ArbitrageOpportunity) GetBuyPrice() float64 {
+	if m != nil {
+		return m.BuyPrice
+	}
+	return 0
+}
+
+func (m *ArbitrageOpportunity) GetSellExchange() string {
+	if m != nil {
+		return m.SellExchange
+	}
+	return ""
+}
+
+func (m *ArbitrageOpportunity) GetSellPrice() float64 {
+	if m != nil {
+		return m.SellPrice
+	}
+	return 0
+}
+
+func (m *ArbitrageOpportunity) GetAmount() float64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *ArbitrageOpportunity) GetSpreadPercent() float64 {
+	if m != nil {
+		return m.SpreadPercent
+	}
+	return 0
+}
+
+func (m *ArbitrageOpportunity) GetCosts() float64 {
+	if m != nil {
+		return m.Costs
+	}
+	return 0
+}
+
+func (m *ArbitrageOpportunity) GetProfit() float64 {
+	if m != nil {
+		return m.Profit
+	}
+	return 0
+}
+
+func (m *ArbitrageOpportunity) GetProfitPercent() float64 {
+	if m != nil {
+		return m.ProfitPercent
+	}
+	return 0
+}
+
+func (m *ArbitrageOpportunity) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+type GetArbitrageOpportunitiesResponse struct {
+	Opportunities        []*ArbitrageOpportunity `protobuf:"bytes,1,rep,name=opportunities,proto3" json:"opportunities,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *GetArbitrageOpportunitiesResponse) Reset()         { *m = GetArbitrageOpportunitiesResponse{} }
+func (m *GetArbitrageOpportunitiesResponse) String() string { return proto.CompactTextString(m) }
+func (*GetArbitrageOpportunitiesResponse) ProtoMessage()    {}
+func (*GetArbitrageOpportunitiesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{18}
+}
+
+func (m *GetArbitrageOpportunitiesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetArbitrageOpportunitiesResponse.Unmarshal(m, b)
+}
+func (m *GetArbitrageOpportunitiesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetArbitrageOpportunitiesResponse.Marshal(b, m, deterministic)
+}
+func (m *GetArbitrageOpportunitiesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetArbitrageOpportunitiesResponse.Merge(m, src)
+}
+func (m *GetArbitrageOpportunitiesResponse) XXX_Size() int {
+	return xxx_messageInfo_GetArbitrageOpportunitiesResponse.Size(m)
+}
+func (m *GetArbitrageOpportunitiesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetArbitrageOpportunitiesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetArbitrageOpportunitiesResponse proto.InternalMessageInfo
+
+func (m *GetArbitrageOpportunitiesResponse) GetOpportunities() []*ArbitrageOpportunity {
+	if m != nil {
+		return m.Opportunities
+	}
+	return nil
+}
+
+type GetPortfolioValuationRequest struct {
+	// exchange optionally limits the exchange valuations to a single exchange
+	Exchange             string   `protobuf:"bytes,1,opt,name=exchange,proto3" json:"exchange,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetPortfolioValuationRequest) Reset()         { *m = GetPortfolioValuationRequest{} }
+func (m *GetPortfolioValuationRequest) String() string { return proto.CompactTextString(m) }
+func (*GetPortfolioValuationRequest) ProtoMessage()    {}
+func (*GetPortfolioValuationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{19}
+}
+
+func (m *GetPortfolioValuationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetPortfolioValuationRequest.Unmarshal(m, b)
+}
+func (m *GetPortfolioValuationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetPortfolioValuationRequest.Marshal(b, m, deterministic)
+}
+func (m *GetPortfolioValuationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetPortfolioValuationRequest.Merge(m, src)
+}
+func (m *GetPortfolioValuationRequest) XXX_Size() int {
+	return xxx_messageInfo_GetPortfolioValuationRequest.Size(m)
+}
+func (m *GetPortfolioValuationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetPortfolioValuationRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetPortfolioValuationRequest proto.InternalMessageInfo
+
+func (m *GetPortfolioValuationRequest) GetExchange() string {
+	if m != nil {
+		return m.Exchange
+	}
+	return ""
+}
+
+type CoinValuation struct {
+	Coin                 string   `protobuf:"bytes,1,opt,name=coin,proto3" json:"coin,omitempty"`
+	Balance              float64  `protobuf:"fixed64,2,opt,name=balance,proto3" json:"balance,omitempty"`
+	Value                float64  `protobuf:"fixed64,3,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CoinValuation) Reset()         { *m = CoinValuation{} }
+func (m *CoinValuation) String() string { return proto.CompactTextString(m) }
+func (*CoinValuation) ProtoMessage()    {}
+func (*CoinValuation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{20}
+}
+
+func (m *CoinValuation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CoinValuation.Unmarshal(m, b)
+}
+func (m *CoinValuation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CoinValuation.Marshal(b, m, deterministic)
+}
+func (m *CoinValuation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CoinValuation.Merge(m, src)
+}
+func (m *CoinValuation) XXX_Size() int {
+	return xxx_messageInfo_CoinValuation.Size(m)
+}
+func (m *CoinValuation) XXX_DiscardUnknown() {
+	xxx_messageInfo_CoinValuation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CoinValuation proto.InternalMessageInfo
+
+func (m *CoinValuation) GetCoin() string {
+	if m != nil {
+		return m.Coin
+	}
+	return ""
+}
+
+func (m *CoinValuation) GetBalance() float64 {
+	if m != nil {
+		return m.Balance
+	}
+	return 0
+}
+
+func (m *CoinValuation) GetValue() float64 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
+type HoldingsValuation struct {
+	Name                 string           `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value                float64          `protobuf:"fixed64,2,opt,name=value,proto3" json:"value,omitempty"`
+	Coins                []*CoinValuation `protobuf:"bytes,3,rep,name=coins,proto3" json:"coins,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *HoldingsValuation) Reset()         { *m = HoldingsValuation{} }
+func (m *HoldingsValuation) String() string { return proto.CompactTextString(m) }
+func (*HoldingsValuation) ProtoMessage()    {}
+func (*HoldingsValuation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{21}
+}
+
+func (m *HoldingsValuation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HoldingsValuation.Unmarshal(m, b)
+}
+func (m *HoldingsValuation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HoldingsValuation.Marshal(b, m, deterministic)
+}
+func (m *HoldingsValuation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HoldingsValuation.Merge(m, src)
+}
+func (m *HoldingsValuation) XXX_Size() int {
+	return xxx_messageInfo_HoldingsValuation.Size(m)
+}
+func (m *HoldingsValuation) XXX_DiscardUnknown() {
+	xxx_messageInfo_HoldingsValuation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HoldingsValuation proto.InternalMessageInfo
+
+func (m *HoldingsValuation) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *HoldingsValuation) GetValue() float64 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
+func (m *HoldingsValuation) GetCoins() []*CoinValuation {
+	if m != nil {
+		return m.Coins
+	}
+	return nil
+}
+
+type PortfolioValuation struct {
+	Time         int64                `protobuf:"varint,1,opt,name=time,proto3" json:"time,omitempty"`
+	BaseCurrency string               `protobuf:"bytes,2,opt,name=base_currency,json=baseCurrency,proto3" json:"base_currency,omitempty"`
+	Total        float64              `protobuf:"fixed64,3,opt,name=total,proto3" json:"total,omitempty"`
+	Exchanges    []*HoldingsValuation `protobuf:"bytes,4,rep,name=exchanges,proto3" json:"exchanges,omitempty"`
+	OnChain      *HoldingsValuation   `protobuf:"bytes,5,opt,name=on_chain,json=onChain,proto3" json:"on_chain,omitempty"`
+	Earn         *HoldingsValuation   `protobuf:"bytes,6,opt,name=earn,proto3" json:"earn,omitempty"`
+	// unpriced holds the coins which could not be valued
+	Unpriced             []string `protobuf:"bytes,7,rep,name=unpriced,proto3" json:"unpriced,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PortfolioValuation) Reset()         { *m = PortfolioValuation{} }
+func (m *PortfolioValuation) String() string { return proto.CompactTextString(m) }
+func (*PortfolioValuation) ProtoMessage()    {}
+func (*PortfolioValuation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{22}
+}
+
+func (m *PortfolioValuation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PortfolioValuation.Unmarshal(m, b)
+}
+func (m *PortfolioValuation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PortfolioValuation.Marshal(b, m, deterministic)
+}
+func (m *PortfolioValuation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PortfolioValuation.Merge(m, src)
+}
+func (m *PortfolioValuation) XXX_Size() int {
+	return xxx_messageInfo_PortfolioValuation.Size(m)
+}
+func (m *PortfolioValuation) XXX_DiscardUnknown() {
+	xxx_messageInfo_PortfolioValuation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PortfolioValuation proto.InternalMessageInfo
+
+func (m *PortfolioValuation) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *PortfolioValuation) GetBaseCurrency() string {
+	if m != nil {
+		return m.BaseCurrency
+	}
+	return ""
+}
+
+func (m *PortfolioValuation) GetTotal() float64 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+func (m *PortfolioValuation) GetExchanges() []*HoldingsValuation {
+	if m != nil {
+		return m.Exchanges
+	}
+	return nil
+}
+
+func (m *PortfolioValuation) GetOnChain() *HoldingsValuation {
+	if m != nil {
+		return m.OnChain
+	}
+	return nil
+}
+
+func (m *PortfolioValuation) GetEarn() *HoldingsValuation {
+	if m != nil {
+		return m.Earn
+	}
+	return nil
+}
+
+func (m *PortfolioValuation) GetUnpriced() []string {
+	if m != nil {
+		return m.Unpriced
+	}
+	return nil
+}
+
+type GetPortfolioSnapshotsRequest struct {
+	// start and end are unix timestamps, a zero end is now
+	Start int64 `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`
+	End   int64 `protobuf:"varint,2,opt,name=end,proto3" json:"end,omitempty"`
+	// exchange optionally limits the exchange valuations to a single exchange
+	Exchange             string   `protobuf:"bytes,3,opt,name=exchange,proto3" json:"exchange,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetPortfolioSnapshotsRequest) Reset()         { *m = GetPortfolioSnapshotsRequest{} }
+func (m *GetPortfolioSnapshotsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetPortfolioSnapshotsRequest) ProtoMessage()    {}
+func (*GetPortfolioSnapshotsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{23}
+}
+
+func (m *GetPortfolioSnapshotsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetPortfolioSnapshotsRequest.Unmarshal(m, b)
+}
+func (m *GetPortfolioSnapshotsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetPortfolioSnapshotsRequest.Marshal(b, m, deterministic)
+}
+func (m *GetPortfolioSnapshotsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetPortfolioSnapshotsRequest.Merge(m, src)
+}
+func (m *GetPortfolioSnapshotsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetPortfolioSnapshotsRequest.Size(m)
+}
+func (m *GetPortfolioSnapshotsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetPortfolioSnapshotsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetPortfolioSnapshotsRequest proto.InternalMessageInfo
+
+func (m *GetPortfolioSnapshotsRequest) GetStart() int64 {
+	if m != nil {
+		return m.Start
+	}
+	return 0
+}
+
+func (m *GetPortfolioSnapshotsRequest) GetEnd() int64 {
+	if m != nil {
+		return m.End
+	}
+	return 0
+}
+
+func (m *GetPortfolioSnapshotsRequest) GetExchange() string {
+	if m != nil {
+		return m.Exchange
+	}
+	return ""
+}
+
+type GetPortfolioSnapshotsResponse struct {
+	Snapshots            []*PortfolioValuation `protobuf:"bytes,1,rep,name=snapshots,proto3" json:"snapshots,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *GetPortfolioSnapshotsResponse) Reset()         { *m = GetPortfolioSnapshotsResponse{} }
+func (m *GetPortfolioSnapshotsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetPortfolioSnapshotsResponse) ProtoMessage()    {}
+func (*GetPortfolioSnapshotsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{24}
+}
+
+func (m *GetPortfolioSnapshotsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetPortfolioSnapshotsResponse.Unmarshal(m, b)
+}
+func (m *GetPortfolioSnapshotsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetPortfolioSnapshotsResponse.Marshal(b, m, deterministic)
+}
+func (m *GetPortfolioSnapshotsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetPortfolioSnapshotsResponse.Merge(m, src)
+}
+func (m *GetPortfolioSnapshotsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetPortfolioSnapshotsResponse.Size(m)
+}
+func (m *GetPortfolioSnapshotsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetPortfolioSnapshotsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetPortfolioSnapshotsResponse proto.InternalMessageInfo
+
+func (m *GetPortfolioSnapshotsResponse) GetSnapshots() []*PortfolioValuation {
+	if m != nil {
+		return m.Snapshots
+	}
+	return nil
+}
+
+type WithdrawalAddress struct {
+	Id       int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Label    string `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	Exchange string `protobuf:"bytes,3,opt,name=exchange,proto3" json:"exchange,omitempty"`
+	Currency string `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	Chain    string `protobuf:"bytes,5,opt,name=chain,proto3" json:"chain,omitempty"`
+	Address  string `protobuf:"bytes,6,opt,name=address,proto3" json:"address,omitempty"`
+	// daily_limit and monthly_limit are in the currency, zero is unlimited
+	DailyLimit   float64 `protobuf:"fixed64,7,opt,name=daily_limit,json=dailyLimit,proto3" json:"daily_limit,omitempty"`
+	MonthlyLimit float64 `protobuf:"fixed64,8,opt,name=monthly_limit,json=monthlyLimit,proto3" json:"monthly_limit,omitempty"`
+	Locked       bool    `protobuf:"varint,9,opt,name=locked,proto3" json:"locked,omitempty"`
+	Verified     bool    `protobuf:"varint,10,opt,name=verified,proto3" json:"verified,omitempty"`
+	Added        int64   `protobuf:"varint,11,opt,name=added,proto3" json:"added,omitempty"`
+	// daily_used and monthly_used are the amounts withdrawn within the rolling
+	// 24 hour and 30 day windows, they are ignored by requests
+	DailyUsed            float64  `protobuf:"fixed64,12,opt,name=daily_used,json=dailyUsed,proto3" json:"daily_used,omitempty"`
+	MonthlyUsed          float64  `protobuf:"fixed64,13,opt,name=monthly_used,json=monthlyUsed,proto3" json:"monthly_used,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WithdrawalAddress) Reset()         { *m = WithdrawalAddress{} }
+func (m *WithdrawalAddress) String() string { return proto.CompactTextString(m) }
+func (*WithdrawalAddress) ProtoMessage()    {}
+func (*WithdrawalAddress) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{25}
+}
+
+func (m *WithdrawalAddress) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WithdrawalAddress.Unmarshal(m, b)
+}
+func (m *WithdrawalAddress) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WithdrawalAddress.Marshal(b, m, deterministic)
+}
+func (m *WithdrawalAddress) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WithdrawalAddress.Merge(m, src)
+}
+func (m *WithdrawalAddress) XXX_Size() int {
+	return xxx_messageInfo_WithdrawalAddress.Size(m)
+}
+func (m *WithdrawalAddress) XXX_DiscardUnknown() {
+	xxx_messageInfo_WithdrawalAddress.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WithdrawalAddress proto.InternalMessageInfo
+
+func (m *WithdrawalAddress) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *WithdrawalAddress) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
+func (m *WithdrawalAddress) GetExchange() string {
+	if m != nil {
+		return m.Exchange
+	}
+	return ""
+}
+
+func (m *WithdrawalAddress) GetCurrency() string {
+	if m != nil {
+		return m.Currency
+	}
+	return ""
+}
+
+func (m *WithdrawalAddress) GetChain() string {
+	if m != nil {
+		return m.Chain
+	}
+	return ""
+}
+
+func (m *WithdrawalAddress) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *WithdrawalAddress) GetDailyLimit() float64 {
+	if m != nil {
+		return m.DailyLimit
+	}
+	return 0
+}
+
+func (m *WithdrawalAddress) GetMonthlyLimit() float64 {
+	if m != nil {
+		return m.MonthlyLimit
+	}
+	return 0
+}
+
+func (m *WithdrawalAddress) GetLocked() bool {
+	if m != nil {
+		return m.Locked
+	}
+	return false
+}
+
+func (m *WithdrawalAddress) GetVerified() bool {
+	if m != nil {
+		return m.Verified
+	}
+	return false
+}
+
+func (m *WithdrawalAddress) GetAdded() int64 {
+	if m != nil {
+		return m.Added
+	}
+	return 0
+}
+
+func (m *WithdrawalAddress) GetDailyUsed() float64 {
+	if m != nil {
+		return m.DailyUsed
+	}
+	return 0
+}
+
+func (m *WithdrawalAddress) GetMonthlyUsed() float64 {
+	if m != nil {
+		return m.MonthlyUsed
+	}
+	return 0
+}
+
+type GetWithdrawalAddressesRequest struct {
+	// exchange and currency optionally filter the entries
+	Exchange             string   `protobuf:"bytes,1,opt,name=exchange,proto3" json:"exchange,omitempty"`
+	Currency             string   `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetWithdrawalAddressesRequest) Reset()         { *m = GetWithdrawalAddressesRequest{} }
+func (m *GetWithdrawalAddressesRequest) String() string { return proto.CompactTextString(m) }
+func (*GetWithdrawalAddressesRequest) ProtoMessage()    {}
+func (*GetWithdrawalAddressesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{26}
+}
+
+func (m *GetWithdrawalAddressesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetWithdrawalAddressesRequest.Unmarshal(m, b)
+}
+func (m *GetWithdrawalAddressesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetWithdrawalAddressesRequest.Marshal(b, m, deterministic)
+}
+func (m *GetWithdrawalAddressesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetWithdrawalAddressesRequest.Merge(m, src)
+}
+func (m *GetWithdrawalAddressesRequest) XXX_Size() int {
+	return xxx_messageInfo_GetWithdrawalAddressesRequest.Size(m)
+}
+func (m *GetWithdrawalAddressesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetWithdrawalAddressesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetWithdrawalAddressesRequest proto.InternalMessageInfo
+
+func (m *GetWithdrawalAddressesRequest) GetExchange() string {
+	if m != nil {
+		return m.Exchange
+	}
+	return ""
+}
+
+func (m *GetWithdrawalAddressesRequest) GetCurrency() string {
+	if m != nil {
+		return m.Currency
+	}
+	return ""
+}
+
+type GetWithdrawalAddressesResponse struct {
+	Addresses            []*WithdrawalAddress `protobuf:"bytes,1,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *GetWithdrawalAddressesResponse) Reset()         { *m = GetWithdrawalAddressesResponse{} }
+func (m *GetWithdrawalAddressesResponse) String() string { return proto.CompactTextString(m) }
+func (*GetWithdrawalAddressesResponse) ProtoMessage()    {}
+func (*GetWithdrawalAddressesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{27}
+}
+
+func (m *GetWithdrawalAddressesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetWithdrawalAddressesResponse.Unmarshal(m, b)
+}
+func (m *GetWithdrawalAddressesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetWithdrawalAddressesResponse.Marshal(b, m, deterministic)
+}
+func (m *GetWithdrawalAddressesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetWithdrawalAddressesResponse.Merge(m, src)
+}
+func (m *GetWithdrawalAddressesResponse) XXX_Size() int {
+	return xxx_messageInfo_GetWithdrawalAddressesResponse.Size(m)
+}
+func (m *GetWithdrawalAddressesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetWithdrawalAddressesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetWithdrawalAddressesResponse proto.InternalMessageInfo
+
+func (m *GetWithdrawalAddressesResponse) GetAddresses() []*WithdrawalAddress {
+	if m != nil {
+		return m.Addresses
+	}
+	return nil
+}
+
+type WithdrawalAddressRequest struct {
+	Id                   int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WithdrawalAddressRequest) Reset()         { *m = WithdrawalAddressRequest{} }
+func (m *WithdrawalAddressRequest) String() string { return proto.CompactTextString(m) }
+func (*WithdrawalAddressRequest) ProtoMessage()    {}
+func (*WithdrawalAddressRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{28}
+}
+
+func (m *WithdrawalAddressRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WithdrawalAddressRequest.Unmarshal(m, b)
+}
+func (m *WithdrawalAddressRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WithdrawalAddressRequest.Marshal(b, m, deterministic)
+}
+func (m *WithdrawalAddressRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WithdrawalAddressRequest.Merge(m, src)
+}
+func (m *WithdrawalAddressRequest) XXX_Size() int {
+	return xxx_messageInfo_WithdrawalAddressRequest.Size(m)
+}
+func (m *WithdrawalAddressRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WithdrawalAddressRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WithdrawalAddressRequest proto.InternalMessageInfo
+
+func (m *WithdrawalAddressRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type SetWithdrawalAddressFlagRequest struct {
+	Id                   int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Value                bool     `protobuf:"varint,2,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetWithdrawalAddressFlagRequest) Reset()         { *m = SetWithdrawalAddressFlagRequest{} }
+func (m *SetWithdrawalAddressFlagRequest) String() string { return proto.CompactTextString(m) }
+func (*SetWithdrawalAddressFlagRequest) ProtoMessage()    {}
+func (*SetWithdrawalAddressFlagRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{29}
+}
+
+func (m *SetWithdrawalAddressFlagRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetWithdrawalAddressFlagRequest.Unmarshal(m, b)
+}
+func (m *SetWithdrawalAddressFlagRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetWithdrawalAddressFlagRequest.Marshal(b, m, deterministic)
+}
+func (m *SetWithdrawalAddressFlagRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetWithdrawalAddressFlagRequest.Merge(m, src)
+}
+func (m *SetWithdrawalAddressFlagRequest) XXX_Size() int {
+	return xxx_messageInfo_SetWithdrawalAddressFlagRequest.Size(m)
+}
+func (m *SetWithdrawalAddressFlagRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetWithdrawalAddressFlagRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetWithdrawalAddressFlagRequest proto.InternalMessageInfo
+
+func (m *SetWithdrawalAddressFlagRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *SetWithdrawalAddressFlagRequest) GetValue() bool {
+	if m != nil {
+		return m.Value
+	}
+	return false
+}
+
+type ExchangeHealth struct {
+	Exchange string `protobuf:"bytes,1,opt,name=exchange,proto3" json:"exchange,omitempty"`
+	Degraded bool   `protobuf:"varint,2,opt,name=degraded,proto3" json:"degraded,omitempty"`
+	// degraded_since and last_check are unix timestamps
+	DegradedSince       int64 `protobuf:"varint,3,opt,name=degraded_since,json=degradedSince,proto3" json:"degraded_since,omitempty"`
+	ConsecutiveFailures int64 `protobuf:"varint,4,opt,name=consecutive_failures,json=consecutiveFailures,proto3" json:"consecutive_failures,omitempty"`
+	Checks              int64 `protobuf:"varint,5,opt,name=checks,proto3" json:"checks,omitempty"`
+	Failures            int64 `protobuf:"varint,6,opt,name=failures,proto3" json:"failures,omitempty"`
+	LastCheck           int64 `protobuf:"varint,7,opt,name=last_check,json=lastCheck,proto3" json:"last_check,omitempty"`
+	// last_latency_ms is the latency of the last check, average_latency_ms the
+	// mean latency of the successful checks
+	LastLatencyMs         int64    `protobuf:"varint,8,opt,name=last_latency_ms,json=lastLatencyMs,proto3" json:"last_latency_ms,omitempty"`
+	AverageLatencyMs      int64    `protobuf:"varint,9,opt,name=average_latency_ms,json=averageLatencyMs,proto3" json:"average_latency_ms,omitempty"`
+	LastError             string   `protobuf:"bytes,10,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	OrderSubmissionPaused bool     `protobuf:"varint,11,opt,name=order_submission_paused,json=orderSubmissionPaused,proto3" json:"order_submission_paused,omitempty"`
+	PauseReason           string   `protobuf:"bytes,12,opt,name=pause_reason,json=pauseReason,proto3" json:"pause_reason,omitempty"`
+	XXX_NoUnkeyedLiteral  struct{} `json:"-"`
+	XXX_unrecognized      []byte   `json:"-"`
+	XXX_sizecache         int32    `json:"-"`
+}
+
+func (m *ExchangeHealth) Reset()         { *m = ExchangeHealth{} }
+func (m *ExchangeHealth) String() string { return proto.CompactTextString(m) }
+func (*ExchangeHealth) ProtoMessage()    {}
+func (*ExchangeHealth) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{30}
+}
+
+func (m *ExchangeHealth) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExchangeHealth.Unmarshal(m, b)
+}
+func (m *ExchangeHealth) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExchangeHealth.Marshal(b, m, deterministic)
+}
+func (m *ExchangeHealth) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExchangeHealth.Merge(m, src)
+}
+func (m *ExchangeHealth) XXX_Size() int {
+	return xxx_messageInfo_ExchangeHealth.Size(m)
+}
+func (m *ExchangeHealth) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExchangeHealth.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExchangeHealth proto.InternalMessageInfo
+
+func (m *ExchangeHealth) GetExchange() string {
+	if m != nil {
+		return m.Exchange
+	}
+	return ""
+}
+
+func (m *ExchangeHealth) GetDegraded() bool {
+	if m != nil {
+		return m.Degraded
+	}
+	return false
+}
+
+func (m *ExchangeHealth) GetDegradedSince() int64 {
+	if m != nil {
+		return m.DegradedSince
+	}
+	return 0
+}
+
+func (m *ExchangeHealth) GetConsecutiveFailures() int64 {
+	if m != nil {
+		return m.ConsecutiveFailures
+	}
+	return 0
+}
+
+func (m *ExchangeHealth) GetChecks() int64 {
+	if m != nil {
+		return m.Checks
+	}
+	return 0
+}
+
+func (m *ExchangeHealth) GetFailures() int64 {
+	if m != nil {
+		return m.Failures
+	}
+	return 0
+}
+
+func (m *ExchangeHealth) GetLastCheck() int64 {
+	if m != nil {
+		return m.LastCheck
+	}
+	return 0
+}
+
+func (m *ExchangeHealth) GetLastLatencyMs() int64 {
+	if m != nil {
+		return m.LastLatencyMs
+	}
+	return 0
+}
+
+func (m *ExchangeHealth) GetAverageLatencyMs() int64 {
+	if m != nil {
+		return m.AverageLatencyMs
+	}
+	return 0
+}
+
+func (m *ExchangeHealth) GetLastError() string {
+	if m != nil {
+		return m.LastError
+	}
+	return ""
+}
+
+func (m *ExchangeHealth) GetOrderSubmissionPaused() bool {
+	if m != nil {
+		return m.OrderSubmissionPaused
+	}
+	return false
+}
+
+func (m *ExchangeHealth) GetPauseReason() string {
+	if m != nil {
+		return m.PauseReason
+	}
+	return ""
+}
+
+type GetExchangeHealthResponse struct {
+	Exchanges            []*ExchangeHealth `protobuf:"bytes,1,rep,name=exchanges,proto3" json:"exchanges,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *GetExchangeHealthResponse) Reset()         { *m = GetExchangeHealthResponse{} }
+func (m *GetExchangeHealthResponse) String() string { return proto.CompactTextString(m) }
+func (*GetExchangeHealthResponse) ProtoMessage()    {}
+func (*GetExchangeHealthResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{31}
+}
+
+func (m *GetExchangeHealthResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetExchangeHealthResponse.Unmarshal(m, b)
+}
+func (m *GetExchangeHealthResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetExchangeHealthResponse.Marshal(b, m, deterministic)
+}
+func (m *GetExchangeHealthResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetExchangeHealthResponse.Merge(m, src)
+}
+func (m *GetExchangeHealthResponse) XXX_Size() int {
+	return xxx_messageInfo_GetExchangeHealthResponse.Size(m)
+}
+func (m *GetExchangeHealthResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetExchangeHealthResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetExchangeHealthResponse proto.InternalMessageInfo
+
+func (m *GetExchangeHealthResponse) GetExchanges() []*ExchangeHealth {
+	if m != nil {
+		return m.Exchanges
+	}
+	return nil
+}
+
+type StartExecutionRequest struct {
+	// algorithm is TWAP, VWAP or ICEBERG
+	Algorithm string        `protobuf:"bytes,1,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
+	Exchange  string        `protobuf:"bytes,2,opt,name=exchange,proto3" json:"exchange,omitempty"`
+	Pair      *CurrencyPair `protobuf:"bytes,3,opt,name=pair,proto3" json:"pair,omitempty"`
+	Side      string        `protobuf:"bytes,4,opt,name=side,proto3" json:"side,omitempty"`
+	Amount    float64       `protobuf:"fixed64,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	// price is the limit price of the child orders, they are market orders
+	// when it is zero
+	Price float64 `protobuf:"fixed64,6,opt,name=price,proto3" json:"price,omitempty"`
+	// duration is a Go duration string such as 1h30m
+	Duration             string    `protobuf:"bytes,7,opt,name=duration,proto3" json:"duration,omitempty"`
+	Slices               int32     `protobuf:"varint,8,opt,name=slices,proto3" json:"slices,omitempty"`
+	VisibleAmount        float64   `protobuf:"fixed64,9,opt,name=visible_amount,json=visibleAmount,proto3" json:"visible_amount,omitempty"`
+	Randomization        float64   `protobuf:"fixed64,10,opt,name=randomization,proto3" json:"randomization,omitempty"`
+	MaxDeviation         float64   `protobuf:"fixed64,11,opt,name=max_deviation,json=maxDeviation,proto3" json:"max_deviation,omitempty"`
+	VolumeProfile        []float64 `protobuf:"fixed64,12,rep,packed,name=volume_profile,json=volumeProfile,proto3" json:"volume_profile,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *StartExecutionRequest) Reset()         { *m = StartExecutionRequest{} }
+func (m *StartExecutionRequest) String() string { return proto.CompactTextString(m) }
+func (*StartExecutionRequest) ProtoMessage()    {}
+func (*StartExecutionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{32}
+}
+
+func (m *StartExecutionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StartExecutionRequest.Unmarshal(m, b)
+}
+func (m *StartExecutionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StartExecutionRequest.Marshal(b, m, deterministic)
+}
+func (m *StartExecutionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StartExecutionRequest.Merge(m, src)
+}
+func (m *StartExecutionRequest) XXX_Size() int {
+	return xxx_messageInfo_StartExecutionRequest.Size(m)
+}
+func (m *StartExecutionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StartExecutionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StartExecutionRequest proto.InternalMessageInfo
+
+func (m *StartExecutionRequest) GetAlgorithm() string {
+	if m != nil {
+		return m.Algorithm
+	}
+	return ""
+}
+
+func (m *StartExecutionRequest) GetExchange() string {
+	if m != nil {
+		return m.Exchange
+	}
+	return ""
+}
+
+func (m *StartExecutionRequest) GetPair() *CurrencyPair {
+	if m != nil {
+		return m.Pair
+	}
+	return nil
+}
+
+func (m *StartExecutionRequest) GetSide() string {
+	if m != nil {
+		return m.Side
+	}
+	return ""
+}
+
+func (m *StartExecutionRequest) GetAmount() float64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *StartExecutionRequest) GetPrice() float64 {
+	if m != nil {
+		return m.Price
+	}
+	return 0
+}
+
+func (m *StartExecutionRequest) GetDuration() string {
+	if m != nil {
+		return m.Duration
+	}
+	return ""
+}
+
+func (m *StartExecutionRequest) GetSlices() int32 {
+	if m != nil {
+		return m.Slices
+	}
+	return 0
+}
+
+func (m *StartExecutionRequest) GetVisibleAmount() float64 {
+	if m != nil {
+		return m.VisibleAmount
+	}
+	return 0
+}
+
+func (m *StartExecutionRequest) GetRandomization() float64 {
+	if m != nil {
+		return m.Randomization
+	}
+	return 0
+}
+
+func (m *StartExecutionRequest) GetMaxDeviation() float64 {
+	if m != nil {
+		return m.MaxDeviation
+	}
+	return 0
+}
+
+func (m *StartExecutionRequest) GetVolumeProfile() []float64 {
+	if m != nil {
+		return m.VolumeProfile
+	}
+	return nil
+}
+
+type Execution struct {
+	Id        int64         `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Algorithm string        `protobuf:"bytes,2,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
+	Exchange  string        `protobuf:"bytes,3,opt,name=exchange,proto3" json:"exchange,omitempty"`
+	Pair      *CurrencyPair `protobuf:"bytes,4,opt,name=pair,proto3" json:"pair,omitempty"`
+	Side      string        `protobuf:"bytes,5,opt,name=side,proto3" json:"side,omitempty"`
+	Amount    float64       `protobuf:"fixed64,6,opt,name=amount,proto3" json:"amount,omitempty"`
+	Price     float64       `protobuf:"fixed64,7,opt,name=price,proto3" json:"price,omitempty"`
+	Status    string        `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`
+	Error     string        `protobuf:"bytes,9,opt,name=error,proto3" json:"error,omitempty"`
+	// started and finished are unix timestamps
+	Started              int64    `protobuf:"varint,10,opt,name=started,proto3" json:"started,omitempty"`
+	Finished             int64    `protobuf:"varint,11,opt,name=finished,proto3" json:"finished,omitempty"`
+	ReferencePrice       float64  `protobuf:"fixed64,12,opt,name=reference_price,json=referencePrice,proto3" json:"reference_price,omitempty"`
+	ChildOrderIds        []int64  `protobuf:"varint,13,rep,packed,name=child_order_ids,json=childOrderIds,proto3" json:"child_order_ids,omitempty"`
+	FilledAmount         float64  `protobuf:"fixed64,14,opt,name=filled_amount,json=filledAmount,proto3" json:"filled_amount,omitempty"`
+	AveragePrice         float64  `protobuf:"fixed64,15,opt,name=average_price,json=averagePrice,proto3" json:"average_price,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Execution) Reset()         { *m = Execution{} }
+func (m *Execution) String() string { return proto.CompactTextString(m) }
+func (*Execution) ProtoMessage()    {}
+func (*Execution) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{33}
+}
+
+func (m *Execution) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Execution.Unmarshal(m, b)
+}
+func (m *Execution) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Execution.Marshal(b, m, deterministic)
+}
+func (m *Execution) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Execution.Merge(m, src)
+}
+func (m *Execution) XXX_Size() int {
+	return xxx_messageInfo_Execution.Size(m)
+}
+func (m *Execution) XXX_DiscardUnknown() {
+	xxx_messageInfo_Execution.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Execution proto.InternalMessageInfo
+
+func (m *Execution) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *Execution) GetAlgorithm() string {
+	if m != nil {
+		return m.Algorithm
+	}
+	return ""
+}
+
+func (m *Execution) GetExchange() string {
+	if m != nil {
+		return m.Exchange
+	}
+	return ""
+}
+
+func (m *Execution) GetPair() *CurrencyPair {
+	if m != nil {
+		return m.Pair
+	}
+	return nil
+}
+
+func (m *Execution) GetSide() string {
+	if m != nil {
+		return m.Side
+	}
+	return ""
+}
+
+func (m *Execution) GetAmount() float64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *Execution) GetPrice() float64 {
+	if m != nil {
+		return m.Price
+	}
+	return 0
+}
+
+func (m *Execution) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *Execution) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *Execution) GetStarted() int64 {
+	if m != nil {
+		return m.Started
+	}
+	return 0
+}
+
+func (m *Execution) GetFinished() int64 {
+	if m != nil {
+		return m.Finished
+	}
+	return 0
+}
+
+func (m *Execution) GetReferencePrice() float64 {
+	if m != nil {
+		return m.ReferencePrice
+	}
+	return 0
+}
+
+func (m *Execution) GetChildOrderIds() []int64 {
+	if m != nil {
+		return m.ChildOrderIds
+	}
+	return nil
+}
+
+func (m *Execution) GetFilledAmount() float64 {
+	if m != nil {
+		return m.FilledAmount
+	}
+	return 0
+}
+
+func (m *Execution) GetAveragePrice() float64 {
+	if m != nil {
+		return m.AveragePrice
+	}
+	return 0
+}
+
+type GetExecutionsRequest struct {
+	Status               string   `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetExecutionsRequest) Reset()         { *m = GetExecutionsRequest{} }
+func (m *GetExecutionsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetExecutionsRequest) ProtoMessage()    {}
+func (*GetExecutionsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{34}
+}
+
+func (m *GetExecutionsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetExecutionsRequest.Unmarshal(m, b)
+}
+func (m *GetExecutionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetExecutionsRequest.Marshal(b, m, deterministic)
+}
+func (m *GetExecutionsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetExecutionsRequest.Merge(m, src)
+}
+func (m *GetExecutionsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetExecutionsRequest.Size(m)
+}
+func (m *GetExecutionsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetExecutionsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetExecutionsRequest proto.InternalMessageInfo
+
+func (m *GetExecutionsRequest) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+type GetExecutionsResponse struct {
+	Executions           []*Execution `protobuf:"bytes,1,rep,name=executions,proto3" json:"executions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *GetExecutionsResponse) Reset()         { *m = GetExecutionsResponse{} }
+func (m *GetExecutionsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetExecutionsResponse) ProtoMessage()    {}
+func (*GetExecutionsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{35}
+}
+
+func (m *GetExecutionsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetExecutionsResponse.Unmarshal(m, b)
+}
+func (m *GetExecutionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetExecutionsResponse.Marshal(b, m, deterministic)
+}
+func (m *GetExecutionsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetExecutionsResponse.Merge(m, src)
+}
+func (m *GetExecutionsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetExecutionsResponse.Size(m)
+}
+func (m *GetExecutionsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetExecutionsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetExecutionsResponse proto.InternalMessageInfo
+
+func (m *GetExecutionsResponse) GetExecutions() []*Execution {
+	if m != nil {
+		return m.Executions
+	}
+	return nil
+}
+
+type CancelExecutionRequest struct {
+	Id                   int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CancelExecutionRequest) Reset()         { *m = CancelExecutionRequest{} }
+func (m *CancelExecutionRequest) String() string { return proto.CompactTextString(m) }
+func (*CancelExecutionRequest) ProtoMessage()    {}
+func (*CancelExecutionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{36}
+}
+
+func (m *CancelExecutionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CancelExecutionRequest.Unmarshal(m, b)
+}
+func (m *CancelExecutionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CancelExecutionRequest.Marshal(b, m, deterministic)
+}
+func (m *CancelExecutionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CancelExecutionRequest.Merge(m, src)
+}
+func (m *CancelExecutionRequest) XXX_Size() int {
+	return xxx_messageInfo_CancelExecutionRequest.Size(m)
+}
+func (m *CancelExecutionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CancelExecutionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CancelExecutionRequest proto.InternalMessageInfo
+
+func (m *CancelExecutionRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type RequestWithdrawalRequest struct {
+	Exchange             string   `protobuf:"bytes,1,opt,name=exchange,proto3" json:"exchange,omitempty"`
+	Currency             string   `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	Address              string   `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	Amount               float64  `protobuf:"fixed64,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RequestWithdrawalRequest) Reset()         { *m = RequestWithdrawalRequest{} }
+func (m *RequestWithdrawalRequest) String() string { return proto.CompactTextString(m) }
+func (*RequestWithdrawalRequest) ProtoMessage()    {}
+func (*RequestWithdrawalRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{37}
+}
+
+func (m *RequestWithdrawalRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RequestWithdrawalRequest.Unmarshal(m, b)
+}
+func (m *RequestWithdrawalRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RequestWithdrawalRequest.Marshal(b, m, deterministic)
+}
+func (m *RequestWithdrawalRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestWithdrawalRequest.Merge(m, src)
+}
+func (m *RequestWithdrawalRequest) XXX_Size() int {
+	return xxx_messageInfo_RequestWithdrawalRequest.Size(m)
+}
+func (m *RequestWithdrawalRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestWithdrawalRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestWithdrawalRequest proto.InternalMessageInfo
+
+func (m *RequestWithdrawalRequest) GetExchange() string {
+	if m != nil {
+		return m.Exchange
+	}
+	return ""
+}
+
+func (m *RequestWithdrawalRequest) GetCurrency() string {
+	if m != nil {
+		return m.Currency
+	}
+	return ""
+}
+
+func (m *RequestWithdrawalRequest) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *RequestWithdrawalRequest) GetAmount() float64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+type Withdrawal struct {
+	Id                   int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Exchange             string   `protobuf:"bytes,2,opt,name=exchange,proto3" json:"exchange,omitempty"`
+	Currency             string   `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	Address              string   `protobuf:"bytes,4,opt,name=address,proto3" json:"address,omitempty"`
+	Amount               float64  `protobuf:"fixed64,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	Status               string   `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	Reason               string   `protobuf:"bytes,7,opt,name=reason,proto3" json:"reason,omitempty"`
+	TxId                 string   `protobuf:"bytes,8,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	Requested            int64    `protobuf:"varint,9,opt,name=requested,proto3" json:"requested,omitempty"`
+	Updated              int64    `protobuf:"varint,10,opt,name=updated,proto3" json:"updated,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Withdrawal) Reset()         { *m = Withdrawal{} }
+func (m *Withdrawal) String() string { return proto.CompactTextString(m) }
+func (*Withdrawal) ProtoMessage()    {}
+func (*Withdrawal) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{38}
+}
+
+func (m *Withdrawal) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Withdrawal.Unmarshal(m, b)
+}
+func (m *Withdrawal) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Withdrawal.Marshal(b, m, deterministic)
+}
+func (m *Withdrawal) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Withdrawal.Merge(m, src)
+}
+func (m *Withdrawal) XXX_Size() int {
+	return xxx_messageInfo_Withdrawal.Size(m)
+}
+func (m *Withdrawal) XXX_DiscardUnknown() {
+	xxx_messageInfo_Withdrawal.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Withdrawal proto.InternalMessageInfo
+
+func (m *Withdrawal) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *Withdrawal) GetExchange() string {
+	if m != nil {
+		return m.Exchange
+	}
+	return ""
+}
+
+func (m *Withdrawal) GetCurrency() string {
+	if m != nil {
+		return m.Currency
+	}
+	return ""
+}
+
+func (m *Withdrawal) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *Withdrawal) GetAmount() float64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *Withdrawal) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *Withdrawal) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *Withdrawal) GetTxId() string {
+	if m != nil {
+		return m.TxId
+	}
+	return ""
+}
+
+func (m *Withdrawal) GetRequested() int64 {
+	if m != nil {
+		return m.Requested
+	}
+	return 0
+}
+
+func (m *Withdrawal) GetUpdated() int64 {
+	if m != nil {
+		return m.Updated
+	}
+	return 0
+}
+
+type GetWithdrawalsRequest struct {
+	Status               string   `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetWithdrawalsRequest) Reset()         { *m = GetWithdrawalsRequest{} }
+func (m *GetWithdrawalsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetWithdrawalsRequest) ProtoMessage()    {}
+func (*GetWithdrawalsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{39}
+}
+
+func (m *GetWithdrawalsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetWithdrawalsRequest.Unmarshal(m, b)
+}
+func (m *GetWithdrawalsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetWithdrawalsRequest.Marshal(b, m, deterministic)
+}
+func (m *GetWithdrawalsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetWithdrawalsRequest.Merge(m, src)
+}
+func (m *GetWithdrawalsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetWithdrawalsRequest.Size(m)
+}
+func (m *GetWithdrawalsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetWithdrawalsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetWithdrawalsRequest proto.InternalMessageInfo
+
+func (m *GetWithdrawalsRequest) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+type GetWithdrawalsResponse struct {
+	Withdrawals          []*Withdrawal `protobuf:"bytes,1,rep,name=withdrawals,proto3" json:"withdrawals,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *GetWithdrawalsResponse) Reset()         { *m = GetWithdrawalsResponse{} }
+func (m *GetWithdrawalsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetWithdrawalsResponse) ProtoMessage()    {}
+func (*GetWithdrawalsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{40}
+}
+
+func (m *GetWithdrawalsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetWithdrawalsResponse.Unmarshal(m, b)
+}
+func (m *GetWithdrawalsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetWithdrawalsResponse.Marshal(b, m, deterministic)
+}
+func (m *GetWithdrawalsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetWithdrawalsResponse.Merge(m, src)
+}
+func (m *GetWithdrawalsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetWithdrawalsResponse.Size(m)
+}
+func (m *GetWithdrawalsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetWithdrawalsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetWithdrawalsResponse proto.InternalMessageInfo
+
+func (m *GetWithdrawalsResponse) GetWithdrawals() []*Withdrawal {
+	if m != nil {
+		return m.Withdrawals
+	}
+	return nil
+}
+
+type ApproveWithdrawalRequest struct {
+	Id                   int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ApproveWithdrawalRequest) Reset()         { *m = ApproveWithdrawalRequest{} }
+func (m *ApproveWithdrawalRequest) String() string { return proto.CompactTextString(m) }
+func (*ApproveWithdrawalRequest) ProtoMessage()    {}
+func (*ApproveWithdrawalRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{41}
+}
+
+func (m *ApproveWithdrawalRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ApproveWithdrawalRequest.Unmarshal(m, b)
+}
+func (m *ApproveWithdrawalRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ApproveWithdrawalRequest.Marshal(b, m, deterministic)
+}
+func (m *ApproveWithdrawalRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApproveWithdrawalRequest.Merge(m, src)
+}
+func (m *ApproveWithdrawalRequest) XXX_Size() int {
+	return xxx_messageInfo_ApproveWithdrawalRequest.Size(m)
+}
+func (m *ApproveWithdrawalRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApproveWithdrawalRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ApproveWithdrawalRequest proto.InternalMessageInfo
+
+func (m *ApproveWithdrawalRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type RejectWithdrawalRequest struct {
+	Id                   int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Reason               string   `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RejectWithdrawalRequest) Reset()         { *m = RejectWithdrawalRequest{} }
+func (m *RejectWithdrawalRequest) String() string { return proto.CompactTextString(m) }
+func (*RejectWithdrawalRequest) ProtoMessage()    {}
+func (*RejectWithdrawalRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{42}
+}
+
+func (m *RejectWithdrawalRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RejectWithdrawalRequest.Unmarshal(m, b)
+}
+func (m *RejectWithdrawalRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RejectWithdrawalRequest.Marshal(b, m, deterministic)
+}
+func (m *RejectWithdrawalRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RejectWithdrawalRequest.Merge(m, src)
+}
+func (m *RejectWithdrawalRequest) XXX_Size() int {
+	return xxx_messageInfo_RejectWithdrawalRequest.Size(m)
+}
+func (m *RejectWithdrawalRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RejectWithdrawalRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RejectWithdrawalRequest proto.InternalMessageInfo
+
+func (m *RejectWithdrawalRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *RejectWithdrawalRequest) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*GenericExchangeNameRequest)(nil), "gctrpc.GenericExchangeNameRequest")
+	proto.RegisterType((*GenericResponse)(nil), "gctrpc.GenericResponse")
+	proto.RegisterType((*CurrencyPair)(nil), "gctrpc.CurrencyPair")
+	proto.RegisterType((*GetExchangesRequest)(nil), "gctrpc.GetExchangesRequest")
+	proto.RegisterType((*GetExchangesResponse)(nil), "gctrpc.GetExchangesResponse")
+	proto.RegisterType((*GetExchangeCapabilitiesResponse)(nil), "gctrpc.GetExchangeCapabilitiesResponse")
+	proto.RegisterType((*GetTickerRequest)(nil), "gctrpc.GetTickerRequest")
+	proto.RegisterType((*TickerResponse)(nil), "gctrpc.TickerResponse")
+	proto.RegisterType((*GetOrderbookRequest)(nil), "gctrpc.GetOrderbookRequest")
+	proto.RegisterType((*OrderbookItem)(nil), "gctrpc.OrderbookItem")
+	proto.RegisterType((*OrderbookResponse)(nil), "gctrpc.OrderbookResponse")
+	proto.RegisterType((*AccountCurrencyInfo)(nil), "gctrpc.AccountCurrencyInfo")
+	proto.RegisterType((*GetAccountInfoResponse)(nil), "gctrpc.GetAccountInfoResponse")
+	proto.RegisterType((*SubmitOrderRequest)(nil), "gctrpc.SubmitOrderRequest")
+	proto.RegisterType((*SubmitOrderResponse)(nil), "gctrpc.SubmitOrderResponse")
+	proto.RegisterType((*CancelOrderRequest)(nil), "gctrpc.CancelOrderRequest")
+	proto.RegisterType((*GetArbitrageOpportunitiesRequest)(nil), "gctrpc.GetArbitrageOpportunitiesRequest")
+	proto.RegisterType((*ArbitrageOpportunity)(nil), "gctrpc.ArbitrageOpportunity")
+	proto.RegisterType((*GetArbitrageOpportunitiesResponse)(nil), "gctrpc.GetArbitrageOpportunitiesResponse")
+	proto.RegisterType((*GetPortfolioValuationRequest)(nil), "gctrpc.GetPortfolioValuationRequest")
+	proto.RegisterType((*CoinValuation)(nil), "gctrpc.CoinValuation")
+	proto.RegisterType((*HoldingsValuation)(nil), "gctrpc.HoldingsValuation")
+	proto.RegisterType((*PortfolioValuation)(nil), "gctrpc.PortfolioValuation")
+	proto.RegisterType((*GetPortfolioSnapshotsRequest)(nil), "gctrpc.GetPortfolioSnapshotsRequest")
+	proto.RegisterType((*GetPortfolioSnapshotsResponse)(nil), "gctrpc.GetPortfolioSnapshotsResponse")
+	proto.RegisterType((*WithdrawalAddress)(nil), "gctrpc.WithdrawalAddress")
+	proto.RegisterType((*GetWithdrawalAddressesRequest)(nil), "gctrpc.GetWithdrawalAddressesRequest")
+	proto.RegisterType((*GetWithdrawalAddressesResponse)(nil), "gctrpc.GetWithdrawalAddressesResponse")
+	proto.RegisterType((*WithdrawalAddressRequest)(nil), "gctrpc.WithdrawalAddressRequest")
+	proto.RegisterType((*SetWithdrawalAddressFlagRequest)(nil), "gctrpc.SetWithdrawalAddressFlagRequest")
+	proto.RegisterType((*ExchangeHealth)(nil), "gctrpc.ExchangeHealth")
+	proto.RegisterType((*GetExchangeHealthResponse)(nil), "gctrpc.GetExchangeHealthResponse")
+	proto.RegisterType((*StartExecutionRequest)(nil), "gctrpc.StartExecutionRequest")
+	proto.RegisterType((*Execution)(nil), "gctrpc.Execution")
+	proto.RegisterType((*GetExecutionsRequest)(nil), "gctrpc.GetExecutionsRequest")
+	proto.RegisterType((*GetExecutionsResponse)(nil), "gctrpc.GetExecutionsResponse")
+	proto.RegisterType((*CancelExecutionRequest)(nil), "gctrpc.CancelExecutionRequest")
+	proto.RegisterType((*RequestWithdrawalRequest)(nil), "gctrpc.RequestWithdrawalRequest")
+	proto.RegisterType((*Withdrawal)(nil), "gctrpc.Withdrawal")
+	proto.RegisterType((*GetWithdrawalsRequest)(nil), "gctrpc.GetWithdrawalsRequest")
+	proto.RegisterType((*GetWithdrawalsResponse)(nil), "gctrpc.GetWithdrawalsResponse")
+	proto.RegisterType((*ApproveWithdrawalRequest)(nil), "gctrpc.ApproveWithdrawalRequest")
+	proto.RegisterType((*RejectWithdrawalRequest)(nil), "gctrpc.RejectWithdrawalRequest")
+}
+
+func init() { proto.RegisterFile("rpc.proto", fileDescriptor_77a6da22d6a3feb1) }
+
+var fileDescriptor_77a6da22d6a3feb1 = []byte{
+	// 2558 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x5a, 0x4b, 0x73, 0xe3, 0xc6,
+	0xf1, 0x17, 0x09, 0x8a, 0x22, 0x9b, 0x22, 0xb5, 0x1a, 0xbd, 0xb8, 0xb4, 0xf6, 0x05, 0x7b, 0x5f,
+	0xff, 0x7f, 0xb2, 0x29, 0x2b, 0x5b, 0xb1, 0x2b, 0x39, 0xa4, 0x64, 0xad, 0xac, 0x55, 0xbc, 0x0f,
+	0x19, 0x5a, 0xaf, 0xcb, 0x79, 0x14, 0x6b, 0x08, 0x8c, 0xc4, 0xb1, 0x40, 0x00, 0x0b, 0x0c, 0xb5,
+	0x92, 0x0f, 0xb9, 0xc4, 0xa7, 0x7c, 0x87, 0x9c, 0xf3, 0x35, 0x72, 0xc9, 0x39, 0x87, 0x54, 0xbe,
+	0x45, 0x6e, 0xf9, 0x02, 0xa9, 0x79, 0x02, 0x20, 0x40, 0x8a, 0xae, 0x28, 0xb9, 0xa1, 0x7b, 0x7a,
+	0x7a, 0x7a, 0xba, 0x7f, 0xd3, 0xdd, 0x33, 0x24, 0x34, 0xe3, 0xc8, 0x7d, 0x12, 0xc5, 0x21, 0x0b,
+	0x51, 0xfd, 0xd4, 0x65, 0x71, 0xe4, 0xda, 0x9f, 0x42, 0xef, 0x80, 0x04, 0x24, 0xa6, 0xee, 0xfe,
+	0x85, 0x3b, 0xc4, 0xc1, 0x29, 0x79, 0x85, 0x47, 0xc4, 0x21, 0xef, 0xc6, 0x24, 0x61, 0xa8, 0x07,
+	0x0d, 0xa2, 0xd8, 0xdd, 0xca, 0xdd, 0xca, 0xa3, 0xa6, 0x63, 0x68, 0xfb, 0x31, 0xac, 0xa8, 0x99,
+	0x0e, 0x49, 0xa2, 0x30, 0x48, 0x08, 0xda, 0x84, 0x7a, 0xc2, 0x30, 0x1b, 0x27, 0x4a, 0x58, 0x51,
+	0xf6, 0x5b, 0x58, 0xde, 0x1b, 0xc7, 0x31, 0x09, 0xdc, 0xcb, 0x23, 0x4c, 0x63, 0xb4, 0x0d, 0x4d,
+	0x8f, 0xf8, 0x74, 0x44, 0x19, 0x89, 0x95, 0x68, 0xca, 0x40, 0x08, 0x6a, 0x03, 0x9c, 0x90, 0x6e,
+	0x55, 0x0c, 0x88, 0x6f, 0xb4, 0x0e, 0x8b, 0xef, 0xc6, 0x21, 0x23, 0x5d, 0x4b, 0x30, 0x25, 0x61,
+	0x3f, 0x84, 0xb5, 0x03, 0xc2, 0xb4, 0xe1, 0x89, 0xb6, 0xfa, 0x06, 0x58, 0xd8, 0xf7, 0x85, 0xe2,
+	0x86, 0xc3, 0x3f, 0xed, 0xa7, 0xb0, 0x9e, 0x17, 0x54, 0x06, 0x6f, 0x43, 0x53, 0xef, 0x87, 0xdb,
+	0x6c, 0x71, 0x43, 0x0c, 0xc3, 0xc6, 0x70, 0x27, 0x33, 0x6b, 0x0f, 0x47, 0x78, 0x40, 0x7d, 0xca,
+	0x68, 0x46, 0xc1, 0x0c, 0x07, 0x21, 0x1b, 0x96, 0xdd, 0xcc, 0x9c, 0x6e, 0x55, 0xe8, 0xcf, 0xf1,
+	0xec, 0xf7, 0x70, 0xe3, 0x80, 0xb0, 0x37, 0xd4, 0x3d, 0x23, 0xf1, 0x1c, 0x4e, 0x47, 0x8f, 0xa0,
+	0x16, 0x61, 0x1a, 0x0b, 0xdf, 0xb4, 0x76, 0xd6, 0x9f, 0xc8, 0x28, 0x3e, 0xc9, 0x7a, 0xd7, 0x11,
+	0x12, 0xe8, 0x16, 0x00, 0x4e, 0x12, 0xc2, 0xfa, 0xec, 0x32, 0xd2, 0x6e, 0x6b, 0x0a, 0xce, 0x9b,
+	0xcb, 0x88, 0xd8, 0x7f, 0xaf, 0x40, 0x47, 0x2f, 0xab, 0xf6, 0xa2, 0x75, 0x57, 0xae, 0xd4, 0x7d,
+	0x0f, 0x96, 0x7d, 0x9c, 0xb0, 0xfe, 0x38, 0xf2, 0x30, 0x23, 0x9e, 0xb0, 0xc6, 0x72, 0x5a, 0x9c,
+	0xf7, 0x95, 0x64, 0xf1, 0x20, 0x72, 0x52, 0x2c, 0x5c, 0x71, 0xc4, 0x37, 0xe7, 0x0d, 0xe9, 0xe9,
+	0xb0, 0x5b, 0x93, 0x3c, 0xfe, 0xcd, 0x63, 0xe5, 0x87, 0xef, 0xbb, 0x8b, 0x82, 0xc5, 0x3f, 0x39,
+	0x67, 0x40, 0xbd, 0x6e, 0x5d, 0x72, 0x06, 0xd4, 0xe3, 0x1c, 0x9c, 0x9c, 0x75, 0x97, 0x24, 0x07,
+	0x27, 0x67, 0x1c, 0x68, 0xe7, 0xa1, 0x3f, 0x1e, 0x91, 0x6e, 0x43, 0x30, 0x15, 0x65, 0x7f, 0x27,
+	0x00, 0xf1, 0x3a, 0xf6, 0x48, 0x3c, 0x08, 0xc3, 0xb3, 0xff, 0xa9, 0x47, 0x5f, 0x42, 0xdb, 0x2c,
+	0x7c, 0xc8, 0xc8, 0x88, 0x1b, 0x89, 0x47, 0xe1, 0x38, 0x60, 0x62, 0xcd, 0x8a, 0xa3, 0x28, 0x8e,
+	0xe5, 0x28, 0xa6, 0xae, 0x04, 0x78, 0xc5, 0x91, 0x04, 0xea, 0x40, 0x95, 0x7a, 0x42, 0xab, 0xe5,
+	0x54, 0xa9, 0x67, 0xff, 0xa3, 0x02, 0xab, 0x99, 0x8d, 0xfc, 0xe0, 0x18, 0x3d, 0x86, 0xda, 0x80,
+	0x7a, 0x12, 0x75, 0xad, 0x9d, 0x0d, 0x2d, 0x99, 0x33, 0xd1, 0x11, 0x22, 0x5c, 0x14, 0x27, 0x67,
+	0x49, 0xd7, 0x9a, 0x29, 0xca, 0x45, 0x0a, 0x91, 0xaf, 0x15, 0x23, 0x9f, 0x77, 0xd3, 0xe2, 0xa4,
+	0x9b, 0x4e, 0x60, 0x6d, 0xd7, 0x75, 0xb9, 0x23, 0xb4, 0xd1, 0x87, 0xc1, 0x49, 0xc8, 0x43, 0xe4,
+	0x2a, 0x5a, 0x87, 0x48, 0xd3, 0xe8, 0x0e, 0xb4, 0x58, 0xc8, 0xb0, 0xdf, 0x3f, 0xc7, 0xfe, 0x58,
+	0xbb, 0x0d, 0x04, 0xeb, 0x2d, 0xe7, 0x08, 0x60, 0x85, 0xbe, 0xa7, 0xc1, 0xc6, 0xbf, 0xed, 0x77,
+	0xb0, 0x79, 0x40, 0x98, 0x5a, 0x8a, 0x2f, 0x31, 0xd7, 0x99, 0xfd, 0x05, 0x80, 0x5a, 0x56, 0x9f,
+	0xd8, 0xd6, 0xce, 0x07, 0xda, 0x21, 0x25, 0x76, 0x3b, 0x19, 0x71, 0xfb, 0xfb, 0x2a, 0xa0, 0xe3,
+	0xf1, 0x60, 0x44, 0x25, 0x02, 0xaf, 0x17, 0x7d, 0x08, 0x6a, 0x09, 0xf5, 0x34, 0xee, 0xc4, 0x37,
+	0x77, 0x75, 0xc8, 0x57, 0x92, 0xae, 0xae, 0x49, 0x57, 0x0b, 0x0e, 0x77, 0x35, 0xf7, 0x1b, 0x4f,
+	0x9e, 0x7d, 0x85, 0x42, 0x79, 0xc6, 0x80, 0xb3, 0x76, 0x05, 0x87, 0x47, 0x53, 0x24, 0x52, 0x2d,
+	0x21, 0xcf, 0x5c, 0x4b, 0xf0, 0x76, 0x27, 0xc0, 0xba, 0x94, 0x05, 0xeb, 0x07, 0xd0, 0x74, 0x7d,
+	0x4a, 0x02, 0xd6, 0xa7, 0x5e, 0xb7, 0xa1, 0xc2, 0x25, 0x18, 0x87, 0x9e, 0x7d, 0x0c, 0x6b, 0x39,
+	0x2f, 0x28, 0xb7, 0xdf, 0x83, 0x65, 0x69, 0x6c, 0xe4, 0x63, 0x97, 0x78, 0x2a, 0x3d, 0xb7, 0x04,
+	0xef, 0x48, 0xb0, 0xd0, 0x4d, 0x68, 0x48, 0x11, 0xea, 0xa9, 0xec, 0xbf, 0x24, 0xe8, 0x43, 0xcf,
+	0xfe, 0x5b, 0x05, 0xd0, 0x1e, 0x0e, 0x5c, 0xe2, 0xcf, 0xed, 0x5b, 0x0e, 0x44, 0x19, 0xb1, 0x54,
+	0x5f, 0x53, 0x71, 0x0e, 0xf3, 0x8b, 0x59, 0xb9, 0xc5, 0x4c, 0x54, 0x6a, 0x57, 0x46, 0xe5, 0x3e,
+	0x74, 0xde, 0x63, 0xdf, 0x27, 0xac, 0x8f, 0x3d, 0x2f, 0x26, 0x49, 0xa2, 0x00, 0xdf, 0x96, 0xdc,
+	0x5d, 0xc9, 0x34, 0xc1, 0xab, 0xa7, 0xc1, 0xb3, 0x7f, 0x0f, 0x77, 0x39, 0x40, 0xe3, 0x01, 0x65,
+	0x31, 0x3e, 0x25, 0xaf, 0xa3, 0x28, 0x8c, 0xd9, 0x38, 0x50, 0xf5, 0x45, 0x6e, 0x6f, 0xfe, 0xe3,
+	0x9e, 0x75, 0x44, 0x75, 0xc2, 0x11, 0xeb, 0xb0, 0x28, 0x6a, 0xab, 0xd8, 0xe6, 0xa2, 0x23, 0x09,
+	0xfb, 0x9f, 0x55, 0x58, 0x2f, 0x59, 0xfd, 0xf2, 0x87, 0xd5, 0x81, 0xc1, 0xf8, 0xb2, 0x3f, 0xb1,
+	0x70, 0x6b, 0x30, 0xbe, 0xd4, 0x45, 0x93, 0x23, 0x85, 0x8b, 0x48, 0x0c, 0xc9, 0xf3, 0xd9, 0x18,
+	0x8c, 0x2f, 0x8f, 0x38, 0x8d, 0x3e, 0x84, 0x76, 0x42, 0x7c, 0x3f, 0x55, 0x20, 0x21, 0xbc, 0xcc,
+	0x99, 0xfb, 0x99, 0x30, 0x0a, 0x21, 0xa9, 0x42, 0x82, 0xb8, 0xc9, 0x39, 0x52, 0x47, 0x9a, 0x65,
+	0xeb, 0xb9, 0x2c, 0x7b, 0x1f, 0x3a, 0x49, 0x14, 0x13, 0xec, 0xf5, 0x23, 0x12, 0xbb, 0x24, 0x60,
+	0x0a, 0xc1, 0x6d, 0xc9, 0x3d, 0x92, 0x4c, 0xee, 0x1b, 0x37, 0x4c, 0x58, 0xa2, 0x0a, 0x89, 0x24,
+	0xb8, 0xd2, 0x28, 0x0e, 0x4f, 0x28, 0xeb, 0x36, 0xa5, 0x52, 0x49, 0x71, 0xa5, 0xf2, 0xcb, 0x28,
+	0x05, 0xa9, 0x54, 0x72, 0xb5, 0x52, 0x04, 0x35, 0x46, 0x47, 0xa4, 0xdb, 0x12, 0xd9, 0x51, 0x7c,
+	0xdb, 0xa7, 0x70, 0x6f, 0x46, 0xb8, 0xd5, 0x19, 0xf9, 0x0c, 0xda, 0x61, 0x76, 0x40, 0xf4, 0x24,
+	0xad, 0x9d, 0x6d, 0x93, 0x81, 0x4a, 0xe2, 0xe5, 0xe4, 0xa7, 0xd8, 0x3f, 0x87, 0xed, 0x03, 0xc2,
+	0x8e, 0xc2, 0x98, 0x9d, 0x84, 0x3e, 0x0d, 0x79, 0x86, 0xc4, 0x8c, 0x86, 0xc1, 0x3c, 0x3d, 0xdd,
+	0x31, 0xb4, 0xf7, 0x42, 0x1a, 0x98, 0x39, 0x7c, 0x27, 0x6e, 0x48, 0x03, 0x25, 0x28, 0xbe, 0x51,
+	0x17, 0x96, 0x06, 0xd8, 0xe7, 0x67, 0x51, 0xa5, 0x62, 0x4d, 0x72, 0x67, 0xca, 0x14, 0x2d, 0x03,
+	0x2d, 0x09, 0xfb, 0x5b, 0x58, 0x7d, 0x1e, 0xfa, 0x1e, 0x0d, 0x4e, 0x93, 0x9c, 0xe2, 0x00, 0x8f,
+	0xb4, 0x05, 0xe2, 0x3b, 0x9d, 0x5e, 0xcd, 0x4c, 0x47, 0xff, 0xcf, 0x23, 0x44, 0x83, 0x42, 0x79,
+	0xca, 0x19, 0xea, 0x48, 0x19, 0xfb, 0x4f, 0x55, 0x40, 0xc5, 0xad, 0x9b, 0x80, 0x54, 0xd2, 0x80,
+	0x70, 0xf0, 0x89, 0xec, 0x68, 0xca, 0x8e, 0x44, 0xef, 0x32, 0x67, 0x6a, 0xac, 0x73, 0x93, 0x44,
+	0x9d, 0xd1, 0x3b, 0x12, 0x04, 0xfa, 0x24, 0xdb, 0x36, 0xd6, 0x84, 0x59, 0x37, 0xb5, 0x59, 0x85,
+	0xad, 0x66, 0x3a, 0x4a, 0xf4, 0x14, 0x1a, 0x61, 0xd0, 0x77, 0x87, 0x98, 0x06, 0x02, 0xc9, 0x33,
+	0xe7, 0x2d, 0x85, 0xc1, 0x1e, 0x97, 0x44, 0x3f, 0x86, 0x1a, 0xc1, 0x71, 0xd0, 0xad, 0x5f, 0x35,
+	0x43, 0x88, 0xf1, 0x00, 0x8f, 0x03, 0x71, 0x5a, 0xbc, 0xee, 0x92, 0xe8, 0x39, 0x0d, 0x6d, 0x0f,
+	0xf2, 0xe0, 0x38, 0x0e, 0x70, 0x94, 0x0c, 0x43, 0x66, 0x12, 0xce, 0x3a, 0x2c, 0x26, 0x0c, 0xc7,
+	0x4c, 0x79, 0x4a, 0x12, 0xbc, 0x01, 0x23, 0x81, 0x6e, 0xf3, 0xf8, 0x67, 0x0e, 0x44, 0xd6, 0x04,
+	0x88, 0xbe, 0x81, 0x5b, 0x53, 0xd6, 0x50, 0x28, 0xff, 0x14, 0x9a, 0x89, 0x66, 0x2a, 0x84, 0xf7,
+	0xf4, 0xa6, 0x4a, 0x70, 0x9b, 0x0a, 0xdb, 0xff, 0xaa, 0xc2, 0xea, 0xd7, 0x94, 0x0d, 0xbd, 0x18,
+	0xbf, 0xc7, 0xbe, 0xce, 0xae, 0xb2, 0x75, 0xaa, 0xe8, 0xd6, 0x49, 0xe4, 0x3b, 0x3c, 0x20, 0xbe,
+	0x8a, 0xa8, 0x24, 0x66, 0x99, 0x9c, 0xeb, 0x3e, 0x6a, 0x13, 0xdd, 0x07, 0xcf, 0x10, 0x26, 0x60,
+	0x4d, 0x47, 0x12, 0xfc, 0x10, 0xe8, 0x8c, 0x2f, 0x93, 0xba, 0x26, 0x79, 0xd5, 0xf5, 0x30, 0xf5,
+	0x2f, 0xfb, 0x32, 0xe7, 0xca, 0xac, 0x03, 0x82, 0xf5, 0x82, 0x73, 0x38, 0xf0, 0x46, 0x61, 0xc0,
+	0x86, 0x46, 0x44, 0xa6, 0x9e, 0x65, 0xc5, 0x94, 0x42, 0x9b, 0x50, 0xf7, 0x43, 0xf7, 0x8c, 0x78,
+	0x22, 0x03, 0x35, 0x1c, 0x45, 0x71, 0x4b, 0xcf, 0x49, 0x4c, 0x4f, 0x28, 0xf1, 0x44, 0xee, 0x69,
+	0x38, 0x86, 0xe6, 0x96, 0x62, 0xcf, 0x23, 0x9e, 0xca, 0x3b, 0x92, 0xe0, 0xf9, 0x53, 0xda, 0x33,
+	0x4e, 0x88, 0xd7, 0x5d, 0x96, 0xf9, 0x53, 0x70, 0xbe, 0x4a, 0x88, 0xc7, 0x73, 0xb8, 0xb6, 0x46,
+	0x08, 0xb4, 0x65, 0x0f, 0xa0, 0x78, 0x5c, 0xc4, 0xfe, 0x5a, 0x04, 0xb4, 0xe0, 0xf7, 0xb4, 0x4c,
+	0xcd, 0xaa, 0xc2, 0x59, 0xd7, 0x56, 0xf3, 0xae, 0xb5, 0xbf, 0x81, 0xdb, 0xd3, 0x14, 0x2b, 0xa8,
+	0x7c, 0x02, 0x4d, 0xac, 0x99, 0x0a, 0x2a, 0x06, 0xff, 0x85, 0x79, 0x4e, 0x2a, 0x6b, 0xff, 0x1f,
+	0x74, 0x8b, 0xe3, 0xca, 0xdc, 0x09, 0xbc, 0xd8, 0x07, 0x70, 0xe7, 0xb8, 0xc4, 0x8c, 0xcf, 0x7d,
+	0x7c, 0x3a, 0x65, 0x4a, 0x3e, 0x55, 0x35, 0x74, 0xa6, 0xfb, 0xab, 0x05, 0x1d, 0x5d, 0xb7, 0x9e,
+	0x13, 0xec, 0xb3, 0xe1, 0x55, 0xae, 0xf1, 0xc8, 0x69, 0x8c, 0x3d, 0x75, 0x85, 0x6a, 0x38, 0x86,
+	0xe6, 0x95, 0x46, 0x7f, 0xf7, 0x13, 0x1a, 0xa8, 0xe2, 0x69, 0x39, 0x6d, 0xcd, 0x3d, 0xe6, 0x4c,
+	0xf4, 0x31, 0xac, 0xbb, 0xdc, 0x51, 0xee, 0x98, 0xd1, 0x73, 0xd2, 0x3f, 0xc1, 0xd4, 0x1f, 0xc7,
+	0x22, 0x29, 0x71, 0xe1, 0xb5, 0xcc, 0xd8, 0xe7, 0x6a, 0x88, 0x23, 0xcb, 0x1d, 0x12, 0xf7, 0x4c,
+	0xb6, 0x2a, 0x96, 0xa3, 0x28, 0x6e, 0x8d, 0x99, 0x5e, 0x17, 0x23, 0x86, 0xe6, 0x18, 0x12, 0x6d,
+	0xbf, 0x10, 0x15, 0x90, 0xb6, 0x9c, 0x26, 0xe7, 0xec, 0x71, 0x06, 0x7a, 0x00, 0x2b, 0x62, 0xd8,
+	0xc7, 0x8c, 0xc7, 0xb5, 0x3f, 0x92, 0xe5, 0xd4, 0x72, 0xda, 0x9c, 0xfd, 0x42, 0x72, 0x5f, 0x26,
+	0xe8, 0x47, 0x80, 0xf0, 0x39, 0xe1, 0xf5, 0x2b, 0x2b, 0xda, 0x14, 0xa2, 0x37, 0xd4, 0x48, 0x2a,
+	0xad, 0x17, 0x25, 0x71, 0x1c, 0xc6, 0x02, 0xec, 0x4d, 0xb9, 0xe8, 0x3e, 0x67, 0xa0, 0x9f, 0xc1,
+	0x96, 0xec, 0xdf, 0x12, 0xde, 0x6c, 0x26, 0x09, 0x0d, 0x83, 0x7e, 0x84, 0xc7, 0x89, 0xc2, 0x7f,
+	0xc3, 0xd9, 0x10, 0xc3, 0xc7, 0x66, 0xf4, 0x08, 0x8f, 0x15, 0xe0, 0x85, 0x58, 0x3f, 0x26, 0x38,
+	0x09, 0x03, 0x71, 0x22, 0x9a, 0x4e, 0x4b, 0xf0, 0x1c, 0xc1, 0xb2, 0xbf, 0x84, 0x9b, 0x99, 0x8b,
+	0xbf, 0x8c, 0xa4, 0x81, 0xe4, 0xd3, 0xc9, 0x37, 0x83, 0xd6, 0xce, 0xa6, 0x86, 0xe4, 0xc4, 0x94,
+	0x54, 0xd0, 0xfe, 0xa3, 0x05, 0x1b, 0xc7, 0x0c, 0xc7, 0x6c, 0xff, 0x42, 0x04, 0x24, 0xad, 0xc7,
+	0xdb, 0xd0, 0xc4, 0xfe, 0x69, 0x18, 0x53, 0x36, 0x1c, 0xe9, 0xc7, 0x10, 0xc3, 0x98, 0xd9, 0xd7,
+	0xe9, 0x46, 0xcd, 0x9a, 0xfb, 0xf2, 0x50, 0xcb, 0x5c, 0x1e, 0xd2, 0xc6, 0x69, 0xb1, 0xfc, 0x7a,
+	0x5a, 0xcf, 0x76, 0xfc, 0x1c, 0xab, 0xe3, 0x58, 0x24, 0x64, 0x11, 0xff, 0xa6, 0x63, 0x68, 0xae,
+	0x29, 0xf1, 0xa9, 0x4b, 0x64, 0xd4, 0x17, 0x1d, 0x45, 0x71, 0x0c, 0x9f, 0xd3, 0x84, 0x0e, 0x7c,
+	0x73, 0xc1, 0x90, 0xdd, 0x54, 0x5b, 0x71, 0xd5, 0x15, 0xe3, 0x23, 0x68, 0xc7, 0x38, 0xf0, 0xc2,
+	0x11, 0xfd, 0x4e, 0xea, 0x57, 0x3d, 0x55, 0x8e, 0x29, 0xb2, 0x26, 0xbe, 0xe8, 0x7b, 0xe4, 0x9c,
+	0x4a, 0xa9, 0x96, 0xca, 0x9a, 0xf8, 0xe2, 0x99, 0xe6, 0x89, 0x15, 0xc5, 0x4b, 0x40, 0x5f, 0x34,
+	0x64, 0x3e, 0xe9, 0x2e, 0xdf, 0xb5, 0xc4, 0x8a, 0x82, 0x7b, 0x24, 0x99, 0xf6, 0x5f, 0x2c, 0x68,
+	0x9a, 0x38, 0x14, 0xce, 0x76, 0x2e, 0x20, 0xd5, 0x59, 0x01, 0xb1, 0xa6, 0x04, 0xa4, 0x36, 0x77,
+	0x40, 0x16, 0x4b, 0x03, 0x52, 0x2f, 0x0f, 0x48, 0xee, 0x0a, 0x96, 0xbe, 0xb5, 0x35, 0xb2, 0x6f,
+	0x6d, 0x5c, 0x5a, 0x1e, 0x98, 0xa6, 0x2c, 0x57, 0x82, 0xe0, 0xe5, 0x4a, 0x94, 0x72, 0x55, 0x35,
+	0x2c, 0x47, 0x93, 0xe2, 0xd8, 0xd3, 0x80, 0x26, 0x43, 0x53, 0x37, 0x0c, 0x8d, 0x1e, 0xc2, 0x4a,
+	0x4c, 0x4e, 0x08, 0x37, 0x9e, 0xa8, 0xfe, 0x5b, 0xd6, 0x8f, 0x8e, 0x61, 0xcb, 0x26, 0xfc, 0x01,
+	0xac, 0xb8, 0x43, 0xea, 0x7b, 0x7d, 0x7d, 0xa3, 0x4a, 0xba, 0xed, 0xbb, 0x16, 0x4f, 0x00, 0x82,
+	0xfd, 0x5a, 0xde, 0xab, 0x12, 0x1e, 0xc4, 0x13, 0xea, 0xfb, 0xc4, 0xd3, 0x80, 0xe8, 0xc8, 0x20,
+	0x4a, 0xa6, 0xc2, 0xc3, 0x87, 0xd0, 0xd6, 0x59, 0x42, 0xae, 0xb9, 0x22, 0x85, 0x14, 0x53, 0xac,
+	0x68, 0x3f, 0x51, 0x2f, 0x7a, 0x2a, 0x88, 0x26, 0xb7, 0x4f, 0x7b, 0x82, 0xfc, 0x15, 0x6c, 0x4c,
+	0xc8, 0xab, 0xe3, 0xfc, 0x31, 0x00, 0x31, 0x5c, 0x75, 0x9e, 0x57, 0xd3, 0xf3, 0xac, 0x46, 0x9c,
+	0x8c, 0x90, 0xfd, 0x08, 0x36, 0xe5, 0x55, 0xb4, 0x70, 0x96, 0x27, 0x2b, 0xcb, 0xf7, 0x15, 0xe8,
+	0xaa, 0xb1, 0xb4, 0xbc, 0xfc, 0x87, 0x55, 0x33, 0xdb, 0x7a, 0x58, 0xf9, 0xd6, 0x23, 0x45, 0x50,
+	0x2d, 0x8b, 0x20, 0xfb, 0x0f, 0x55, 0x80, 0x74, 0xfd, 0x02, 0xe0, 0x67, 0xe5, 0x98, 0xac, 0x21,
+	0xd6, 0x74, 0x43, 0x6a, 0xd3, 0x0c, 0xc9, 0xe7, 0x96, 0x34, 0x3a, 0xf5, 0x1c, 0x68, 0x37, 0xa1,
+	0xae, 0xb2, 0xb1, 0xcc, 0x2d, 0x8a, 0x42, 0x6b, 0xb0, 0xc8, 0x2e, 0xd2, 0x37, 0x86, 0x1a, 0xbb,
+	0x38, 0x14, 0xe7, 0x33, 0x96, 0x2e, 0x54, 0xdd, 0x91, 0xe5, 0xa4, 0x0c, 0x6e, 0x94, 0x7e, 0x9c,
+	0x52, 0x48, 0x57, 0xa4, 0xfd, 0x13, 0x01, 0x81, 0xd4, 0x0f, 0x57, 0x62, 0xe6, 0x15, 0x6c, 0x4e,
+	0x4e, 0x30, 0x35, 0xa0, 0xf5, 0x3e, 0x65, 0x2b, 0xd4, 0xa0, 0x62, 0x63, 0xe2, 0x64, 0xc5, 0x78,
+	0x4f, 0xb2, 0x1b, 0x45, 0x71, 0x78, 0x4e, 0x8a, 0x60, 0x98, 0x44, 0xce, 0x2e, 0x6c, 0x39, 0xe4,
+	0x5b, 0xe2, 0xb2, 0x2b, 0x45, 0x33, 0xce, 0xab, 0x66, 0x9d, 0xb7, 0xf3, 0xe7, 0x35, 0xe8, 0x1c,
+	0x84, 0x7b, 0xf1, 0x65, 0xc4, 0xc2, 0x37, 0x31, 0xf6, 0x48, 0x8c, 0xbe, 0x80, 0xe5, 0xec, 0x3b,
+	0x38, 0x32, 0x4f, 0x5b, 0x25, 0xcf, 0xe8, 0xbd, 0xed, 0xf2, 0x41, 0xe9, 0x02, 0x7b, 0x01, 0xbd,
+	0x86, 0xce, 0x7e, 0x80, 0x07, 0x3e, 0xd9, 0x37, 0x2f, 0xde, 0xe9, 0x8c, 0x69, 0x3f, 0x29, 0xf4,
+	0xb6, 0x26, 0x64, 0x32, 0x0a, 0x8f, 0x60, 0xe5, 0x19, 0x4d, 0xae, 0x53, 0xe3, 0x2b, 0x68, 0xab,
+	0xa2, 0x7b, 0x3d, 0xfa, 0x5e, 0xc2, 0xf2, 0x31, 0x0b, 0xa3, 0x6b, 0xdc, 0xb0, 0x43, 0x92, 0xeb,
+	0x34, 0x70, 0x08, 0x5b, 0x53, 0x7e, 0xb2, 0x98, 0x4b, 0xf3, 0xc3, 0x92, 0x90, 0x97, 0xfd, 0xee,
+	0x61, 0x2f, 0xa0, 0xdf, 0xc2, 0x6a, 0xa1, 0x47, 0x9a, 0x6b, 0x8d, 0x7b, 0x25, 0x6b, 0xe4, 0x5b,
+	0x2c, 0x7b, 0x01, 0xfd, 0x12, 0x9a, 0xe6, 0x77, 0x11, 0xd4, 0xcd, 0xcc, 0xc8, 0xfd, 0x54, 0xd2,
+	0x33, 0x8d, 0x57, 0xfe, 0xa7, 0x0c, 0x7b, 0x01, 0x3d, 0x17, 0x48, 0x37, 0x4f, 0xd8, 0x39, 0xa4,
+	0x4f, 0xfe, 0x3e, 0xd0, 0xbb, 0x59, 0x78, 0xf2, 0xce, 0x68, 0x7a, 0x0b, 0x9d, 0xfc, 0x43, 0xf2,
+	0x5c, 0xbb, 0xbc, 0x9d, 0x59, 0xaf, 0xe4, 0x11, 0x5a, 0x58, 0xd8, 0xca, 0x3c, 0x93, 0x22, 0x73,
+	0x03, 0x2e, 0xbe, 0x20, 0xf7, 0x3e, 0x28, 0x1d, 0x33, 0x9a, 0x9e, 0x41, 0x2b, 0xf3, 0x34, 0x9a,
+	0x6a, 0x2a, 0xbe, 0x97, 0xce, 0x82, 0x4e, 0x2c, 0x9a, 0xde, 0xf2, 0x07, 0x2a, 0xf4, 0x28, 0xbb,
+	0x9d, 0x59, 0x4f, 0x96, 0xbd, 0xc7, 0x73, 0x48, 0x9a, 0x35, 0x7f, 0x23, 0x52, 0x72, 0xc9, 0x83,
+	0xcd, 0x47, 0x19, 0x2d, 0x53, 0x9f, 0xb2, 0x7a, 0x33, 0x5e, 0x0d, 0xec, 0x05, 0x74, 0x02, 0x1b,
+	0xa5, 0xef, 0x10, 0xe5, 0xca, 0x27, 0x9f, 0x42, 0x7a, 0xf7, 0xaf, 0x90, 0x32, 0x9b, 0xa0, 0x13,
+	0x65, 0xc2, 0xdc, 0x62, 0x51, 0x56, 0xc5, 0xf4, 0xeb, 0x73, 0xef, 0xc1, 0x55, 0x62, 0x99, 0x7c,
+	0xb6, 0xbe, 0xeb, 0x79, 0x05, 0x19, 0x34, 0xfd, 0x4e, 0xdc, 0x9b, 0x3e, 0x64, 0x2f, 0xa0, 0x2f,
+	0x61, 0x4b, 0xfe, 0x6c, 0x73, 0x7d, 0x2a, 0xdf, 0xf2, 0xc2, 0x35, 0xca, 0xd5, 0x38, 0xad, 0xf2,
+	0xee, 0xd4, 0x79, 0x73, 0xc0, 0xf3, 0x77, 0xb0, 0xf1, 0x22, 0x74, 0xcf, 0x8a, 0x5a, 0x4d, 0xce,
+	0xba, 0xe2, 0x0e, 0x3f, 0xdb, 0xec, 0x3e, 0x6c, 0xbd, 0xe5, 0xef, 0x28, 0x97, 0xff, 0xad, 0x05,
+	0x9e, 0x41, 0x27, 0x7f, 0xff, 0x43, 0xb7, 0x8c, 0xde, 0xb2, 0x7b, 0x61, 0xaf, 0xd8, 0x84, 0xca,
+	0x82, 0x96, 0x6b, 0x63, 0x51, 0xbe, 0x48, 0x4f, 0x74, 0xc3, 0xbd, 0x5b, 0x53, 0x46, 0x8d, 0x57,
+	0x5f, 0xc0, 0xca, 0x44, 0x2b, 0x8b, 0x6e, 0xe7, 0xd3, 0x47, 0xc1, 0xae, 0x19, 0x31, 0xfa, 0x02,
+	0x56, 0x0b, 0xdd, 0x6e, 0x1a, 0xf5, 0x69, 0x8d, 0x70, 0xaf, 0xa4, 0x71, 0x12, 0xd8, 0xec, 0xe4,
+	0xbb, 0x2f, 0x74, 0xab, 0xf4, 0x9c, 0x24, 0x65, 0x29, 0xb7, 0xa4, 0x69, 0x93, 0xf6, 0x15, 0x1a,
+	0xb0, 0xd4, 0xbe, 0x69, 0xbd, 0xd9, 0x14, 0xfb, 0x0e, 0xe1, 0xc6, 0x64, 0x87, 0x86, 0xee, 0xa4,
+	0x7b, 0x2d, 0xed, 0xdd, 0xca, 0x55, 0x7d, 0xd6, 0xf8, 0xb5, 0xfa, 0x3b, 0xc6, 0xa0, 0x2e, 0xfe,
+	0x9d, 0xf1, 0xd3, 0x7f, 0x0f, 0x00, 0x8e, 0x25, 0x7c, 0x60, 0xaa, 0x21, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// GoCryptoTraderClient is the client API for GoCryptoTrader service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type GoCryptoTraderClient interface {
+	// GetExchanges returns the names of the loaded exchanges, or of every
+	// configured exchange
+	GetExchanges(ctx context.Context, in *GetExchangesRequest, opts ...grpc.CallOption) (*GetExchangesResponse, error)
+	// EnableExchange loads and starts a configured exchange
+	EnableExchange(ctx context.Context, in *GenericExchangeNameRequest, opts ...grpc.CallOption) (*GenericResponse, error)
+	// DisableExchange stops and unloads an exchange
+	DisableExchange(ctx context.Context, in *GenericExchangeNameRequest, opts ...grpc.CallOption) (*GenericResponse, error)
+	// StartExchange starts a stopped exchange's REST polling, websocket and
+	// order manager participation
+	StartExchange(ctx context.Context, in *GenericExchangeNameRequest, opts ...grpc.CallOption) (*GenericResponse, error)
+	// StopExchange stops an exchange's subsystems while keeping it loaded
+	StopExchange(ctx context.Context, in *GenericExchangeNameRequest, opts ...grpc.CallOption) (*GenericResponse, error)
+	// RestartExchange stops an exchange, reloads its config and starts it again
+	RestartExchange(ctx context.Context, in *GenericExchangeNameRequest, opts ...grpc.CallOption) (*GenericResponse, error)
+	// GetExchangeCapabilities returns the wrapper methods and websocket streams
+	// a loaded exchange supports
+	GetExchangeCapabilities(ctx context.Context, in *GenericExchangeNameRequest, opts ...grpc.CallOption) (*GetExchangeCapabilitiesResponse, error)
+	// GetExchangeHealth returns the health monitor status of an exchange, or of
+	// every checked exchange when the exchange name is empty
+	GetExchangeHealth(ctx context.Context, in *GenericExchangeNameRequest, opts ...grpc.CallOption) (*GetExchangeHealthResponse, error)
+	// GetTicker fetches the latest ticker of a currency pair
+	GetTicker(ctx context.Context, in *GetTickerRequest, opts ...grpc.CallOption) (*TickerResponse, error)
+	// GetOrderbook fetches the latest orderbook of a currency pair
+	GetOrderbook(ctx context.Context, in *GetOrderbookRequest, opts ...grpc.CallOption) (*OrderbookResponse, error)
+	// GetAccountInfo fetches the account balances of an exchange
+	GetAccountInfo(ctx context.Context, in *GenericExchangeNameRequest, opts ...grpc.CallOption) (*GetAccountInfoResponse, error)
+	// SubmitOrder submits an order sized in either the base or quote currency
+	SubmitOrder(ctx context.Context, in *SubmitOrderRequest, opts ...grpc.CallOption) (*SubmitOrderResponse, error)
+	// CancelOrder cancels an open order
+	CancelOrder(ctx context.Context, in *CancelOrderRequest, opts ...grpc.CallOption) (*GenericResponse, error)
+	// GetArbitrageOpportunities returns the ranked opportunities of the
+	// arbitrage scanner's last scan
+	GetArbitrageOpportunities(ctx context.Context, in *GetArbitrageOpportunitiesRequest, opts ...grpc.CallOption) (*GetArbitrageOpportunitiesResponse, error)
+	// GetPortfolioValuation returns the portfolio sync's latest valuation of the
+	// portfolio, in total and by exchange
+	GetPortfolioValuation(ctx context.Context, in *GetPortfolioValuationRequest, opts ...grpc.CallOption) (*PortfolioValuation, error)
+	// GetPortfolioSnapshots returns the recorded valuations of the portfolio
+	GetPortfolioSnapshots(ctx context.Context, in *GetPortfolioSnapshotsRequest, opts ...grpc.CallOption) (*GetPortfolioSnapshotsResponse, error)
+	// GetWithdrawalAddresses returns the withdrawal address book entries with
+	// the amounts withdrawn within their limit windows
+	GetWithdrawalAddresses(ctx context.Context, in *GetWithdrawalAddressesRequest, opts ...grpc.CallOption) (*GetWithdrawalAddressesResponse, error)
+	// AddWithdrawalAddress adds an unverified withdrawal address book entry
+	AddWithdrawalAddress(ctx context.Context, in *WithdrawalAddress, opts ...grpc.CallOption) (*WithdrawalAddress, error)
+	// UpdateWithdrawalAddress replaces the label, destination and limits of a
+	// withdrawal address book entry, a changed destination must be verified
+	// again
+	UpdateWithdrawalAddress(ctx context.Context, in *WithdrawalAddress, opts ...grpc.CallOption) (*WithdrawalAddress, error)
+	// RemoveWithdrawalAddress removes a withdrawal address book entry
+	RemoveWithdrawalAddress(ctx context.Context, in *WithdrawalAddressRequest, opts ...grpc.CallOption) (*GenericResponse, error)
+	// LockWithdrawalAddress locks or unlocks withdrawals to an address
+	LockWithdrawalAddress(ctx context.Context, in *SetWithdrawalAddressFlagRequest, opts ...grpc.CallOption) (*WithdrawalAddress, error)
+	// VerifyWithdrawalAddress marks an address as verified or unverified
+	VerifyWithdrawalAddress(ctx context.Context, in *SetWithdrawalAddressFlagRequest, opts ...grpc.CallOption) (*WithdrawalAddress, error)
+	// StartExecution starts slicing a parent order into child orders with the
+	// TWAP, VWAP or iceberg execution algorithm
+	StartExecution(ctx context.Context, in *StartExecutionRequest, opts ...grpc.CallOption) (*Execution, error)
+	// GetExecutions returns the executions started since the bot started,
+	// optionally filtered by status
+	GetExecutions(ctx context.Context, in *GetExecutionsRequest, opts ...grpc.CallOption) (*GetExecutionsResponse, error)
+	// CancelExecution stops an execution and cancels its open child orders
+	CancelExecution(ctx context.Context, in *CancelExecutionRequest, opts ...grpc.CallOption) (*GenericResponse, error)
+	// RequestWithdrawal requests a withdrawal to a whitelisted or address book
+	// address, it is only sent once approved
+	RequestWithdrawal(ctx context.Context, in *RequestWithdrawalRequest, opts ...grpc.CallOption) (*Withdrawal, error)
+	// GetWithdrawals returns the withdrawals requested since the bot started,
+	// optionally filtered by status
+	GetWithdrawals(ctx context.Context, in *GetWithdrawalsRequest, opts ...grpc.CallOption) (*GetWithdrawalsResponse, error)
+	// ApproveWithdrawal revalidates a pending withdrawal and sends it
+	ApproveWithdrawal(ctx context.Context, in *ApproveWithdrawalRequest, opts ...grpc.CallOption) (*Withdrawal, error)
+	// RejectWithdrawal rejects a pending withdrawal
+	RejectWithdrawal(ctx context.Context, in *RejectWithdrawalRequest, opts ...grpc.CallOption) (*Withdrawal, error)
+}
+
+type goCryptoTraderClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewGoCryptoTraderClient(cc *grpc.ClientConn) GoCryptoTraderClient {
+	return &goCryptoTraderClient{cc}
+}
+
+func (c *goCryptoTraderClient) GetExchanges(ctx context.Context, in *GetExchangesRequest, opts ...grpc.CallOption) (*GetExchangesResponse, error) {
+	out := new(GetExchangesResponse)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTrader/GetExchanges", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderClient) EnableExchange(ctx context.Context, in *GenericExchangeNameRequest, opts ...grpc.CallOption) (*GenericResponse, error) {
+	out := new(GenericResponse)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTrader/EnableExchange", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderClient) DisableExchange(ctx context.Context, in *GenericExchangeNameRequest, opts ...grpc.CallOption) (*GenericResponse, error) {
+	out := new(GenericResponse)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTrader/DisableExchange", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderClient) StartExchange(ctx context.Context, in *GenericExchangeNameRequest, opts ...grpc.CallOption) (*GenericResponse, error) {
+	out := new(GenericResponse)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTrader/StartExchange", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderClient) StopExchange(ctx context.Context, in *GenericExchangeNameRequest, opts ...grpc.CallOption) (*GenericResponse, error) {
+	out := new(GenericResponse)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTrader/StopExchange", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderClient) RestartExchange(ctx context.Context, in *GenericExchangeNameRequest, opts ...grpc.CallOption) (*GenericResponse, error) {
+	out := new(GenericResponse)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTrader/RestartExchange", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderClient) GetExchangeCapabilities(ctx context.Context, in *GenericExchangeNameRequest, opts ...grpc.CallOption) (*GetExchangeCapabilitiesResponse, error) {
+	out := new(GetExchangeCapabilitiesResponse)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTrader/GetExchangeCapabilities", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderClient) GetExchangeHealth(ctx context.Context, in *GenericExchangeNameRequest, opts ...grpc.CallOption) (*GetExchangeHealthResponse, error) {
+	out := new(GetExchangeHealthResponse)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTrader/GetExchangeHealth", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderClient) GetTicker(ctx context.Context, in *GetTickerRequest, opts ...grpc.CallOption) (*TickerResponse, error) {
+	out := new(TickerResponse)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTrader/GetTicker", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderClient) GetOrderbook(ctx context.Context, in *GetOrderbookRequest, opts ...grpc.CallOption) (*OrderbookResponse, error) {
+	out := new(OrderbookResponse)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTrader/GetOrderbook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderClient) GetAccountInfo(ctx context.Context, in *GenericExchangeNameRequest, opts ...grpc.CallOption) (*GetAccountInfoResponse, error) {
+	out := new(GetAccountInfoResponse)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTrader/GetAccountInfo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderClient) SubmitOrder(ctx context.Context, in *SubmitOrderRequest, opts ...grpc.CallOption) (*SubmitOrderResponse, error) {
+	out := new(SubmitOrderResponse)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTrader/SubmitOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderClient) CancelOrder(ctx context.Context, in *CancelOrderRequest, opts ...grpc.CallOption) (*GenericResponse, error) {
+	out := new(GenericResponse)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTrader/CancelOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderClient) GetArbitrageOpportunities(ctx context.Context, in *GetArbitrageOpportunitiesRequest, opts ...grpc.CallOption) (*GetArbitrageOpportunitiesResponse, error) {
+	out := new(GetArbitrageOpportunitiesResponse)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTrader/GetArbitrageOpportunities", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderClient) GetPortfolioValuation(ctx context.Context, in *GetPortfolioValuationRequest, opts ...grpc.CallOption) (*PortfolioValuation, error) {
+	out := new(PortfolioValuation)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTrader/GetPortfolioValuation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderClient) GetPortfolioSnapshots(ctx context.Context, in *GetPortfolioSnapshotsRequest, opts ...grpc.CallOption) (*GetPortfolioSnapshotsResponse, error) {
+	out := new(GetPortfolioSnapshotsResponse)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTrader/GetPortfolioSnapshots", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderClient) GetWithdrawalAddresses(ctx context.Context, in *GetWithdrawalAddressesRequest, opts ...grpc.CallOption) (*GetWithdrawalAddressesResponse, error) {
+	out := new(GetWithdrawalAddressesResponse)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTrader/GetWithdrawalAddresses", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderClient) AddWithdrawalAddress(ctx context.Context, in *WithdrawalAddress, opts ...grpc.CallOption) (*WithdrawalAddress, error) {
+	out := new(WithdrawalAddress)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTrader/AddWithdrawalAddress", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderClient) UpdateWithdrawalAddress(ctx context.Context, in *WithdrawalAddress, opts ...grpc.CallOption) (*WithdrawalAddress, error) {
+	out := new(WithdrawalAddress)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTrader/UpdateWithdrawalAddress", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderClient) RemoveWithdrawalAddress(ctx context.Context, in *WithdrawalAddressRequest, opts ...grpc.CallOption) (*GenericResponse, error) {
+	out := new(GenericResponse)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTrader/RemoveWithdrawalAddress", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderClient) LockWithdrawalAddress(ctx context.Context, in *SetWithdrawalAddressFlagRequest, opts ...grpc.CallOption) (*WithdrawalAddress, error) {
+	out := new(WithdrawalAddress)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTrader/LockWithdrawalAddress", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderClient) VerifyWithdrawalAddress(ctx context.Context, in *SetWithdrawalAddressFlagRequest, opts ...grpc.CallOption) (*WithdrawalAddress, error) {
+	out := new(WithdrawalAddress)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTrader/VerifyWithdrawalAddress", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderClient) StartExecution(ctx context.Context, in *StartExecutionRequest, opts ...grpc.CallOption) (*Execution, error) {
+	out := new(Execution)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTrader/StartExecution", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderClient) GetExecutions(ctx context.Context, in *GetExecutionsRequest, opts ...grpc.CallOption) (*GetExecutionsResponse, error) {
+	out := new(GetExecutionsResponse)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTrader/GetExecutions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderClient) CancelExecution(ctx context.Context, in *CancelExecutionRequest, opts ...grpc.CallOption) (*GenericResponse, error) {
+	out := new(GenericResponse)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTrader/CancelExecution", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderClient) RequestWithdrawal(ctx context.Context, in *RequestWithdrawalRequest, opts ...grpc.CallOption) (*Withdrawal, error) {
+	out := new(Withdrawal)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTrader/RequestWithdrawal", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderClient) GetWithdrawals(ctx context.Context, in *GetWithdrawalsRequest, opts ...grpc.CallOption) (*GetWithdrawalsResponse, error) {
+	out := new(GetWithdrawalsResponse)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTrader/GetWithdrawals", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderClient) ApproveWithdrawal(ctx context.Context, in *ApproveWithdrawalRequest, opts ...grpc.CallOption) (*Withdrawal, error) {
+	out := new(Withdrawal)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTrader/ApproveWithdrawal", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderClient) RejectWithdrawal(ctx context.Context, in *RejectWithdrawalRequest, opts ...grpc.CallOption) (*Withdrawal, error) {
+	out := new(Withdrawal)
+	err := c.cc.Invoke(ctx, "/gctrpc.GoCryptoTrader/RejectWithdrawal", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GoCryptoTraderServer is the server API for GoCryptoTrader service.
+type GoCryptoTraderServer interface {
+	// GetExchanges returns the names of the loaded exchanges, or of every
+	// configured exchange
+	GetExchanges(context.Context, *GetExchangesRequest) (*GetExchangesResponse, error)
+	// EnableExchange loads and starts a configured exchange
+	EnableExchange(context.Context, *GenericExchangeNameRequest) (*GenericResponse, error)
+	// DisableExchange stops and unloads an exchange
+	DisableExchange(context.Context, *GenericExchangeNameRequest) (*GenericResponse, error)
+	// StartExchange starts a stopped exchange's REST polling, websocket and
+	// order manager participation
+	StartExchange(context.Context, *GenericExchangeNameRequest) (*GenericResponse, error)
+	// StopExchange stops an exchange's subsystems while keeping it loaded
+	StopExchange(context.Context, *GenericExchangeNameRequest) (*GenericResponse, error)
+	// RestartExchange stops an exchange, reloads its config and starts it again
+	RestartExchange(context.Context, *GenericExchangeNameRequest) (*GenericResponse, error)
+	// GetExchangeCapabilities returns the wrapper methods and websocket streams
+	// a loaded exchange supports
+	GetExchangeCapabilities(context.Context, *GenericExchangeNameRequest) (*GetExchangeCapabilitiesResponse, error)
+	// GetExchangeHealth returns the health monitor status of an exchange, or of
+	// every checked exchange when the exchange name is empty
+	GetExchangeHealth(context.Context, *GenericExchangeNameRequest) (*GetExchangeHealthResponse, error)
+	// GetTicker fetches the latest ticker of a currency pair
+	GetTicker(context.Context, *GetTickerRequest) (*TickerResponse, error)
+	// GetOrderbook fetches the latest orderbook of a currency pair
+	GetOrderbook(context.Context, *GetOrderbookRequest) (*OrderbookResponse, error)
+	// GetAccountInfo fetches the account balances of an exchange
+	GetAccountInfo(context.Context, *GenericExchangeNameRequest) (*GetAccountInfoResponse, error)
+	// SubmitOrder submits an order sized in either the base or quote currency
+	SubmitOrder(context.Context, *SubmitOrderRequest) (*SubmitOrderResponse, error)
+	// CancelOrder cancels an open order
+	CancelOrder(context.Context, *CancelOrderRequest) (*GenericResponse, error)
+	// GetArbitrageOpportunities returns the ranked opportunities of the
+	// arbitrage scanner's last scan
+	GetArbitrageOpportunities(context.Context, *GetArbitrageOpportunitiesRequest) (*GetArbitrageOpportunitiesResponse, error)
+	// GetPortfolioValuation returns the portfolio sync's latest valuation of the
+	// portfolio, in total and by exchange
+	GetPortfolioValuation(context.Context, *GetPortfolioValuationRequest) (*PortfolioValuation, error)
+	// GetPortfolioSnapshots returns the recorded valuations of the portfolio
+	GetPortfolioSnapshots(context.Context, *GetPortfolioSnapshotsRequest) (*GetPortfolioSnapshotsResponse, error)
+	// GetWithdrawalAddresses returns the withdrawal address book entries with
+	// the amounts withdrawn within their limit windows
+	GetWithdrawalAddresses(context.Context, *GetWithdrawalAddressesRequest) (*GetWithdrawalAddressesResponse, error)
+	// AddWithdrawalAddress adds an unverified withdrawal address book entry
+	AddWithdrawalAddress(context.Context, *WithdrawalAddress) (*WithdrawalAddress, error)
+	// UpdateWithdrawalAddress replaces the label, destination and limits of a
+	// withdrawal address book entry, a changed destination must be verified
+	// again
+	UpdateWithdrawalAddress(context.Context, *WithdrawalAddress) (*WithdrawalAddress, error)
+	// RemoveWithdrawalAddress removes a withdrawal address book entry
+	RemoveWithdrawalAddress(context.Context, *WithdrawalAddressRequest) (*GenericResponse, error)
+	// LockWithdrawalAddress locks or unlocks withdrawals to an address
+	LockWithdrawalAddress(context.Context, *SetWithdrawalAddressFlagRequest) (*WithdrawalAddress, error)
+	// VerifyWithdrawalAddress marks an address as verified or unverified
+	VerifyWithdrawalAddress(context.Context, *SetWithdrawalAddressFlagRequest) (*WithdrawalAddress, error)
+	// StartExecution starts slicing a parent order into child orders with the
+	// TWAP, VWAP or iceberg execution algorithm
+	StartExecution(context.Context, *StartExecutionRequest) (*Execution, error)
+	// GetExecutions returns the executions started since the bot started,
+	// optionally filtered by status
+	GetExecutions(context.Context, *GetExecutionsRequest) (*GetExecutionsResponse, error)
+	// CancelExecution stops an execution and cancels its open child orders
+	CancelExecution(context.Context, *CancelExecutionRequest) (*GenericResponse, error)
+	// RequestWithdrawal requests a withdrawal to a whitelisted or address book
+	// address, it is only sent once approved
+	RequestWithdrawal(context.Context, *RequestWithdrawalRequest) (*Withdrawal, error)
+	// GetWithdrawals returns the withdrawals requested since the bot started,
+	// optionally filtered by status
+	GetWithdrawals(context.Context, *GetWithdrawalsRequest) (*GetWithdrawalsResponse, error)
+	// ApproveWithdrawal revalidates a pending withdrawal and sends it
+	ApproveWithdrawal(context.Context, *ApproveWithdrawalRequest) (*Withdrawal, error)
+	// RejectWithdrawal rejects a pending withdrawal
+	RejectWithdrawal(context.Context, *RejectWithdrawalRequest) (*Withdrawal, error)
+}
+
+// UnimplementedGoCryptoTraderServer can be embedded to have forward compatible implementations.
+type UnimplementedGoCryptoTraderServer struct {
+}
+
+func (*UnimplementedGoCryptoTraderServer) GetExchanges(ctx context.Context, req *GetExchangesRequest) (*GetExchangesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetExchanges not implemented")
+}
+func (*UnimplementedGoCryptoTraderServer) EnableExchange(ctx context.Context, req *GenericExchangeNameRequest) (*GenericResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnableExchange not implemented")
+}
+func (*UnimplementedGoCryptoTraderServer) DisableExchange(ctx context.Context, req *GenericExchangeNameRequest) (*GenericResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisableExchange not implemented")
+}
+func (*UnimplementedGoCryptoTraderServer) StartExchange(ctx context.Context, req *GenericExchangeNameRequest) (*GenericResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartExchange not implemented")
+}
+func (*UnimplementedGoCryptoTraderServer) StopExchange(ctx context.Context, req *GenericExchangeNameRequest) (*GenericResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StopExchange not implemented")
+}
+func (*UnimplementedGoCryptoTraderServer) RestartExchange(ctx context.Context, req *GenericExchangeNameRequest) (*GenericResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestartExchange not implemented")
+}
+func (*UnimplementedGoCryptoTraderServer) GetExchangeCapabilities(ctx context.Context, req *GenericExchangeNameRequest) (*GetExchangeCapabilitiesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetExchangeCapabilities not implemented")
+}
+func (*UnimplementedGoCryptoTraderServer) GetExchangeHealth(ctx context.Context, req *GenericExchangeNameRequest) (*GetExchangeHealthResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetExchangeHealth not implemented")
+}
+func (*UnimplementedGoCryptoTraderServer) GetTicker(ctx context.Context, req *GetTickerRequest) (*TickerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTicker not implemented")
+}
+func (*UnimplementedGoCryptoTraderServer) GetOrderbook(ctx context.Context, req *GetOrderbookRequest) (*OrderbookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrderbook not implemented")
+}
+func (*UnimplementedGoCryptoTraderServer) GetAccountInfo(ctx context.Context, req *GenericExchangeNameRequest) (*GetAccountInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAccountInfo not implemented")
+}
+func (*UnimplementedGoCryptoTraderServer) SubmitOrder(ctx context.Context, req *SubmitOrderRequest) (*SubmitOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitOrder not implemented")
+}
+func (*UnimplementedGoCryptoTraderServer) CancelOrder(ctx context.Context, req *CancelOrderRequest) (*GenericResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelOrder not implemented")
+}
+func (*UnimplementedGoCryptoTraderServer) GetArbitrageOpportunities(ctx context.Context, req *GetArbitrageOpportunitiesRequest) (*GetArbitrageOpportunitiesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetArbitrageOpportunities not implemented")
+}
+func (*UnimplementedGoCryptoTraderServer) GetPortfolioValuation(ctx context.Context, req *GetPortfolioValuationRequest) (*PortfolioValuation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPortfolioValuation not implemented")
+}
+func (*UnimplementedGoCryptoTraderServer) GetPortfolioSnapshots(ctx context.Context, req *GetPortfolioSnapshotsRequest) (*GetPortfolioSnapshotsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPortfolioSnapshots not implemented")
+}
+func (*UnimplementedGoCryptoTraderServer) GetWithdrawalAddresses(ctx context.Context, req *GetWithdrawalAddressesRequest) (*GetWithdrawalAddressesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWithdrawalAddresses not implemented")
+}
+func (*UnimplementedGoCryptoTraderServer) AddWithdrawalAddress(ctx context.Context, req *WithdrawalAddress) (*WithdrawalAddress, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddWithdrawalAddress not implemented")
+}
+func (*UnimplementedGoCryptoTraderServer) UpdateWithdrawalAddress(ctx context.Context, req *WithdrawalAddress) (*WithdrawalAddress, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateWithdrawalAddress not implemented")
+}
+func (*UnimplementedGoCryptoTraderServer) RemoveWithdrawalAddress(ctx context.Context, req *WithdrawalAddressRequest) (*GenericResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveWithdrawalAddress not implemented")
+}
+func (*UnimplementedGoCryptoTraderServer) LockWithdrawalAddress(ctx context.Context, req *SetWithdrawalAddressFlagRequest) (*WithdrawalAddress, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LockWithdrawalAddress not implemented")
+}
+func (*UnimplementedGoCryptoTraderServer) VerifyWithdrawalAddress(ctx context.Context, req *SetWithdrawalAddressFlagRequest) (*WithdrawalAddress, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyWithdrawalAddress not implemented")
+}
+func (*UnimplementedGoCryptoTraderServer) StartExecution(ctx context.Context, req *StartExecutionRequest) (*Execution, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartExecution not implemented")
+}
+func (*UnimplementedGoCryptoTraderServer) GetExecutions(ctx context.Context, req *GetExecutionsRequest) (*GetExecutionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetExecutions not implemented")
+}
+func (*UnimplementedGoCryptoTraderServer) CancelExecution(ctx context.Context, req *CancelExecutionRequest) (*GenericResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelExecution not implemented")
+}
+func (*UnimplementedGoCryptoTraderServer) RequestWithdrawal(ctx context.Context, req *RequestWithdrawalRequest) (*Withdrawal, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestWithdrawal not implemented")
+}
+func (*UnimplementedGoCryptoTraderServer) GetWithdrawals(ctx context.Context, req *GetWithdrawalsRequest) (*GetWithdrawalsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWithdrawals not implemented")
+}
+func (*UnimplementedGoCryptoTraderServer) ApproveWithdrawal(ctx context.Context, req *ApproveWithdrawalRequest) (*Withdrawal, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveWithdrawal not implemented")
+}
+func (*UnimplementedGoCryptoTraderServer) RejectWithdrawal(ctx context.Context, req *RejectWithdrawalRequest) (*Withdrawal, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RejectWithdrawal not implemented")
+}
+
+func RegisterGoCryptoTraderServer(s *grpc.Server, srv GoCryptoTraderServer) {
+	s.RegisterService(&_GoCryptoTrader_serviceDesc, srv)
+}
+
+func _GoCryptoTrader_GetExchanges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetExchangesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServer).GetExchanges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTrader/GetExchanges",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServer).GetExchanges(ctx, req.(*GetExchangesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTrader_EnableExchange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenericExchangeNameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServer).EnableExchange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTrader/EnableExchange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServer).EnableExchange(ctx, req.(*GenericExchangeNameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTrader_DisableExchange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenericExchangeNameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServer).DisableExchange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTrader/DisableExchange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServer).DisableExchange(ctx, req.(*GenericExchangeNameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTrader_StartExchange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenericExchangeNameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServer).StartExchange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTrader/StartExchange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServer).StartExchange(ctx, req.(*GenericExchangeNameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTrader_StopExchange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenericExchangeNameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServer).StopExchange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTrader/StopExchange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServer).StopExchange(ctx, req.(*GenericExchangeNameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTrader_RestartExchange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenericExchangeNameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServer).RestartExchange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTrader/RestartExchange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServer).RestartExchange(ctx, req.(*GenericExchangeNameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTrader_GetExchangeCapabilities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenericExchangeNameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServer).GetExchangeCapabilities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTrader/GetExchangeCapabilities",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServer).GetExchangeCapabilities(ctx, req.(*GenericExchangeNameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTrader_GetExchangeHealth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenericExchangeNameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServer).GetExchangeHealth(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTrader/GetExchangeHealth",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServer).GetExchangeHealth(ctx, req.(*GenericExchangeNameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTrader_GetTicker_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTickerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServer).GetTicker(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTrader/GetTicker",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServer).GetTicker(ctx, req.(*GetTickerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTrader_GetOrderbook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrderbookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServer).GetOrderbook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTrader/GetOrderbook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServer).GetOrderbook(ctx, req.(*GetOrderbookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTrader_GetAccountInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenericExchangeNameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServer).GetAccountInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTrader/GetAccountInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServer).GetAccountInfo(ctx, req.(*GenericExchangeNameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTrader_SubmitOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServer).SubmitOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTrader/SubmitOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServer).SubmitOrder(ctx, req.(*SubmitOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTrader_CancelOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServer).CancelOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTrader/CancelOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServer).CancelOrder(ctx, req.(*CancelOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTrader_GetArbitrageOpportunities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetArbitrageOpportunitiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServer).GetArbitrageOpportunities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTrader/GetArbitrageOpportunities",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServer).GetArbitrageOpportunities(ctx, req.(*GetArbitrageOpportunitiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTrader_GetPortfolioValuation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPortfolioValuationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServer).GetPortfolioValuation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTrader/GetPortfolioValuation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServer).GetPortfolioValuation(ctx, req.(*GetPortfolioValuationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTrader_GetPortfolioSnapshots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPortfolioSnapshotsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServer).GetPortfolioSnapshots(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTrader/GetPortfolioSnapshots",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServer).GetPortfolioSnapshots(ctx, req.(*GetPortfolioSnapshotsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTrader_GetWithdrawalAddresses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWithdrawalAddressesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServer).GetWithdrawalAddresses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTrader/GetWithdrawalAddresses",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServer).GetWithdrawalAddresses(ctx, req.(*GetWithdrawalAddressesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTrader_AddWithdrawalAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WithdrawalAddress)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServer).AddWithdrawalAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTrader/AddWithdrawalAddress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServer).AddWithdrawalAddress(ctx, req.(*WithdrawalAddress))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTrader_UpdateWithdrawalAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WithdrawalAddress)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServer).UpdateWithdrawalAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTrader/UpdateWithdrawalAddress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServer).UpdateWithdrawalAddress(ctx, req.(*WithdrawalAddress))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTrader_RemoveWithdrawalAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WithdrawalAddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServer).RemoveWithdrawalAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTrader/RemoveWithdrawalAddress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServer).RemoveWithdrawalAddress(ctx, req.(*WithdrawalAddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTrader_LockWithdrawalAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetWithdrawalAddressFlagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServer).LockWithdrawalAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTrader/LockWithdrawalAddress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServer).LockWithdrawalAddress(ctx, req.(*SetWithdrawalAddressFlagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTrader_VerifyWithdrawalAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetWithdrawalAddressFlagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServer).VerifyWithdrawalAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTrader/VerifyWithdrawalAddress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServer).VerifyWithdrawalAddress(ctx, req.(*SetWithdrawalAddressFlagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTrader_StartExecution_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartExecutionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServer).StartExecution(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTrader/StartExecution",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServer).StartExecution(ctx, req.(*StartExecutionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTrader_GetExecutions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetExecutionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServer).GetExecutions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTrader/GetExecutions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServer).GetExecutions(ctx, req.(*GetExecutionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTrader_CancelExecution_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelExecutionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServer).CancelExecution(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTrader/CancelExecution",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServer).CancelExecution(ctx, req.(*CancelExecutionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTrader_RequestWithdrawal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestWithdrawalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServer).RequestWithdrawal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTrader/RequestWithdrawal",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServer).RequestWithdrawal(ctx, req.(*RequestWithdrawalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTrader_GetWithdrawals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWithdrawalsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServer).GetWithdrawals(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTrader/GetWithdrawals",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServer).GetWithdrawals(ctx, req.(*GetWithdrawalsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTrader_ApproveWithdrawal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveWithdrawalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServer).ApproveWithdrawal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTrader/ApproveWithdrawal",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServer).ApproveWithdrawal(ctx, req.(*ApproveWithdrawalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTrader_RejectWithdrawal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RejectWithdrawalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServer).RejectWithdrawal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gctrpc.GoCryptoTrader/RejectWithdrawal",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServer).RejectWithdrawal(ctx, req.(*RejectWithdrawalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _GoCryptoTrader_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "gctrpc.GoCryptoTrader",
+	HandlerType: (*GoCryptoTraderServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetExchanges",
+			Handler:    _GoCryptoTrader_GetExchanges_Handler,
+		},
+		{
+			MethodName: "EnableExchange",
+			Handler:    _GoCryptoTrader_EnableExchange_Handler,
+		},
+		{
+			MethodName: "DisableExchange",
+			Handler:    _GoCryptoTrader_DisableExchange_Handler,
+		},
+		{
+			MethodName: "StartExchange",
+			Handler:    _GoCryptoTrader_StartExchange_Handler,
+		},
+		{
+			MethodName: "StopExchange",
+			Handler:    _GoCryptoTrader_StopExchange_Handler,
+		},
+		{
+			MethodName: "RestartExchange",
+			Handler:    _GoCryptoTrader_RestartExchange_Handler,
+		},
+		{
+			MethodName: "GetExchangeCapabilities",
+			Handler:    _GoCryptoTrader_GetExchangeCapabilities_Handler,
+		},
+		{
+			MethodName: "GetExchangeHealth",
+			Handler:    _GoCryptoTrader_GetExchangeHealth_Handler,
+		},
+		{
+			MethodName: "GetTicker",
+			Handler:    _GoCryptoTrader_GetTicker_Handler,
+		},
+		{
+			MethodName: "GetOrderbook",
+			Handler:    _GoCryptoTrader_GetOrderbook_Handler,
+		},
+		{
+			MethodName: "GetAccountInfo",
+			Handler:    _GoCryptoTrader_GetAccountInfo_Handler,
+		},
+		{
+			MethodName: "SubmitOrder",
+			Handler:    _GoCryptoTrader_SubmitOrder_Handler,
+		},
+		{
+			MethodName: "CancelOrder",
+			Handler:    _GoCryptoTrader_CancelOrder_Handler,
+		},
+		{
+			MethodName: "GetArbitrageOpportunities",
+			Handler:    _GoCryptoTrader_GetArbitrageOpportunities_Handler,
+		},
+		{
+			MethodName: "GetPortfolioValuation",
+			Handler:    _GoCryptoTrader_GetPortfolioValuation_Handler,
+		},
+		{
+			MethodName: "GetPortfolioSnapshots",
+			Handler:    _GoCryptoTrader_GetPortfolioSnapshots_Handler,
+		},
+		{
+			MethodName: "GetWithdrawalAddresses",
+			Handler:    _GoCryptoTrader_GetWithdrawalAddresses_Handler,
+		},
+		{
+			MethodName: "AddWithdrawalAddress",
+			Handler:    _GoCryptoTrader_AddWithdrawalAddress_Handler,
+		},
+		{
+			MethodName: "UpdateWithdrawalAddress",
+			Handler:    _GoCryptoTrader_UpdateWithdrawalAddress_Handler,
+		},
+		{
+			MethodName: "RemoveWithdrawalAddress",
+			Handler:    _GoCryptoTrader_RemoveWithdrawalAddress_Handler,
+		},
+		{
+			MethodName: "LockWithdrawalAddress",
+			Handler:    _GoCryptoTrader_LockWithdrawalAddress_Handler,
+		},
+		{
+			MethodName: "VerifyWithdrawalAddress",
+			Handler:    _GoCryptoTrader_VerifyWithdrawalAddress_Handler,
+		},
+		{
+			MethodName: "StartExecution",
+			Handler:    _GoCryptoTrader_StartExecution_Handler,
+		},
+		{
+			MethodName: "GetExecutions",
+			Handler:    _GoCryptoTrader_GetExecutions_Handler,
+		},
+		{
+			MethodName: "CancelExecution",
+			Handler:    _GoCryptoTrader_CancelExecution_Handler,
+		},
+		{
+			MethodName: "RequestWithdrawal",
+			Handler:    _GoCryptoTrader_RequestWithdrawal_Handler,
+		},
+		{
+			MethodName: "GetWithdrawals",
+			Handler:    _GoCryptoTrader_GetWithdrawals_Handler,
+		},
+		{
+			MethodName: "ApproveWithdrawal",
+			Handler:    _GoCryptoTrader_ApproveWithdrawal_Handler,
+		},
+		{
+			MethodName: "RejectWithdrawal",
+			Handler:    _GoCryptoTrader_RejectWithdrawal_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "rpc.proto",
+}
@@ -1,10 +1,14 @@
 module github.com/thrasher-/gocryptotrader
 
 require (
+	github.com/golang/protobuf v1.3.2
 	github.com/gorilla/context v0.0.0-20160226214623-1ea25387ff6f // indirect
 	github.com/gorilla/mux v1.6.1
 	github.com/gorilla/websocket v1.2.0
+	github.com/lib/pq v1.0.0
+	github.com/mattn/go-sqlite3 v1.10.0
 	github.com/toorop/go-pusher v0.0.0-20180107133620-4549deda5702
 	golang.org/x/crypto v0.0.0-20180602220124-df8d4716b347
 	golang.org/x/net v0.0.0-20180201030042-309822c5b9b9 // indirect
+	google.golang.org/grpc v1.18.0
 )
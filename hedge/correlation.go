@@ -0,0 +1,119 @@
+package hedge
+
+import (
+	"math"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
+)
+
+// Stats are the correlation and beta of an asset's returns against a hedging
+// instrument's over the Samples returns ending with the candle opening at
+// Time. Beta is the minimum variance hedge ratio, the notional of the
+// instrument which offsets a unit notional of the asset.
+type Stats struct {
+	Correlation float64   `json:"correlation"`
+	Beta        float64   `json:"beta"`
+	Samples     int       `json:"samples"`
+	Time        time.Time `json:"time"`
+}
+
+// Returns returns the log returns between the closes of candles in time
+// order
+func Returns(candles []kline.Candle) ([]float64, error) {
+	if len(candles) < 2 {
+		return nil, ErrInsufficientCandles
+	}
+	returns := make([]float64, len(candles)-1)
+	for x := 1; x < len(candles); x++ {
+		if candles[x-1].Close <= 0 || candles[x].Close <= 0 {
+			return nil, ErrInvalidCandle
+		}
+		returns[x-1] = math.Log(candles[x].Close / candles[x-1].Close)
+	}
+	return returns, nil
+}
+
+// Align returns the candles of a and b opening at the same times, so returns
+// are measured over the same periods when either market missed a candle
+func Align(a, b []kline.Candle) ([]kline.Candle, []kline.Candle) {
+	times := make(map[int64]int, len(b))
+	for x := range b {
+		times[b[x].Time.UnixNano()] = x
+	}
+	var alignedA, alignedB []kline.Candle
+	for x := range a {
+		if y, ok := times[a[x].Time.UnixNano()]; ok {
+			alignedA = append(alignedA, a[x])
+			alignedB = append(alignedB, b[y])
+		}
+	}
+	return alignedA, alignedB
+}
+
+// Calculate returns the correlation and beta of asset returns against hedge
+// returns of the same periods
+func Calculate(asset, hedge []float64) (Stats, error) {
+	if len(asset) != len(hedge) {
+		return Stats{}, ErrMismatchedReturns
+	}
+	if len(asset) < 2 {
+		return Stats{}, ErrInsufficientCandles
+	}
+
+	var meanA, meanH float64
+	for x := range asset {
+		meanA += asset[x]
+		meanH += hedge[x]
+	}
+	n := float64(len(asset))
+	meanA /= n
+	meanH /= n
+
+	var cov, varA, varH float64
+	for x := range asset {
+		da, dh := asset[x]-meanA, hedge[x]-meanH
+		cov += da * dh
+		varA += da * da
+		varH += dh * dh
+	}
+	if varA == 0 || varH == 0 {
+		return Stats{}, ErrNoVariance
+	}
+	return Stats{
+		Correlation: cov / math.Sqrt(varA*varH),
+		Beta:        cov / varH,
+		Samples:     len(asset),
+	}, nil
+}
+
+// Rolling returns the stats of each window of returns between the aligned
+// candles of asset and hedge, in time order
+func Rolling(asset, hedge []kline.Candle, window int) ([]Stats, error) {
+	if window < 2 {
+		return nil, ErrInvalidWindow
+	}
+	asset, hedge = Align(asset, hedge)
+	assetReturns, err := Returns(asset)
+	if err != nil {
+		return nil, err
+	}
+	hedgeReturns, err := Returns(hedge)
+	if err != nil {
+		return nil, err
+	}
+	if len(assetReturns) < window {
+		return nil, ErrInsufficientCandles
+	}
+
+	var series []Stats
+	for end := window; end <= len(assetReturns); end++ {
+		s, err := Calculate(assetReturns[end-window:end], hedgeReturns[end-window:end])
+		if err != nil {
+			return nil, err
+		}
+		s.Time = asset[end].Time
+		series = append(series, s)
+	}
+	return series, nil
+}
@@ -0,0 +1,465 @@
+// Package hedge computes rolling correlations and betas between held assets
+// and liquid hedging instruments and suggests the hedge orders which bring a
+// holding's net exposure to a target, optionally placing them through the
+// order manager.
+package hedge
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/assets"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-/gocryptotrader/exchanges/orders"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+// StrategyName is the strategy spot hedge orders are submitted and throttled
+// as
+const StrategyName = "hedge"
+
+// Assistant defaults
+const (
+	DefaultInterval       = kline.OneHour
+	DefaultWindow         = 30
+	DefaultMinCorrelation = 0.7
+	DefaultMaxAge         = time.Minute
+	DefaultScanInterval   = time.Hour
+)
+
+// Errors returned when calculating and placing hedges
+var (
+	ErrInsufficientCandles = errors.New("not enough candles for the correlation window")
+	ErrInvalidCandle       = errors.New("candle close prices must be greater than zero")
+	ErrInvalidWindow       = errors.New("correlation window must be at least two returns")
+	ErrMismatchedReturns   = errors.New("asset and hedge returns differ in length")
+	ErrNoVariance          = errors.New("returns have no variance")
+	ErrNoQuote             = errors.New("no current ticker")
+	ErrNoHedge             = errors.New("no hedging instrument meets the minimum correlation")
+	ErrNoHolding           = errors.New("holding has no balance to hedge")
+	ErrFuturesNotSupported = errors.New("exchange does not support futures orders")
+)
+
+// Market is an exchange's market in a currency pair. Futures and perpetual
+// swaps are ordered by InstrumentID, which defaults to the pair.
+type Market struct {
+	Exchange     string            `json:"exchange"`
+	Pair         pair.CurrencyPair `json:"pair"`
+	AssetType    string            `json:"assetType"`
+	InstrumentID string            `json:"instrumentID,omitempty"`
+}
+
+// instrumentID returns the market's instrument ID, the pair when it has none
+func (m Market) instrumentID() string {
+	if m.InstrumentID == "" {
+		return m.Pair.Pair().String()
+	}
+	return m.InstrumentID
+}
+
+func (m Market) String() string {
+	if m.InstrumentID != "" {
+		return m.Exchange + " " + m.InstrumentID
+	}
+	return fmt.Sprintf("%s %s %s", m.Exchange, m.AssetType, m.Pair.Pair())
+}
+
+// Holding is a held amount of the base currency of Asset's pair, priced by
+// Asset, and the instruments it may be hedged with. An Amount of zero holds
+// the exchange account's balance of the currency.
+type Holding struct {
+	Asset  Market   `json:"asset"`
+	Amount float64  `json:"amount"`
+	Hedges []Market `json:"hedges"`
+}
+
+// Suggestion is the hedge order bringing a holding's net exposure to its
+// target. Amounts are in the base currency of the pairs. Exposure and
+// TargetExposure are the notional of the holding's asset left unhedged, the
+// hedge's notional being divided by the beta. Amount is the order size, Side
+// buys or sells it, and Rebalance is set when the order exceeds the
+// tolerance.
+type Suggestion struct {
+	Holding        Market             `json:"holding"`
+	Hedge          Market             `json:"hedge"`
+	Stats          Stats              `json:"stats"`
+	HeldAmount     float64            `json:"heldAmount"`
+	AssetPrice     float64            `json:"assetPrice"`
+	HedgePrice     float64            `json:"hedgePrice"`
+	HedgeAmount    float64            `json:"hedgeAmount"`
+	TargetAmount   float64            `json:"targetAmount"`
+	Exposure       float64            `json:"exposure"`
+	TargetExposure float64            `json:"targetExposure"`
+	Amount         float64            `json:"amount"`
+	Side           exchange.OrderSide `json:"side"`
+	Rebalance      bool               `json:"rebalance"`
+	Time           time.Time          `json:"time"`
+}
+
+// Suggest returns the suggestion hedging heldAmount of an asset at assetPrice
+// with an instrument at hedgePrice, given the current hedgeAmount of the
+// instrument, so that targetExposure, a fraction of the holding's notional,
+// is left unhedged. A zero beta suggests closing the hedge.
+func Suggest(s Stats, heldAmount, assetPrice, hedgeAmount, hedgePrice, targetExposure float64) Suggestion {
+	assetNotional := heldAmount * assetPrice
+	hedgeNotional := hedgeAmount * hedgePrice
+	target := -(1 - targetExposure) * s.Beta * assetNotional / hedgePrice
+
+	suggestion := Suggestion{
+		Stats:          s,
+		HeldAmount:     heldAmount,
+		AssetPrice:     assetPrice,
+		HedgePrice:     hedgePrice,
+		HedgeAmount:    hedgeAmount,
+		TargetAmount:   target,
+		Exposure:       assetNotional,
+		TargetExposure: targetExposure * assetNotional,
+		Amount:         math.Abs(target - hedgeAmount),
+		Side:           exchange.Buy,
+	}
+	if s.Beta != 0 {
+		suggestion.Exposure += hedgeNotional / s.Beta
+	} else {
+		suggestion.TargetExposure = assetNotional
+	}
+	if target < hedgeAmount {
+		suggestion.Side = exchange.Sell
+	}
+	return suggestion
+}
+
+// CandleSource returns the candles of a market opening between start and end
+type CandleSource func(m Market, interval kline.Interval, start, end time.Time) ([]kline.Candle, error)
+
+// PriceSource returns the current price of a market
+type PriceSource func(m Market) (float64, error)
+
+// BalanceSource returns the balance of a currency on an exchange
+type BalanceSource func(exchangeName, currency string) (float64, error)
+
+// ExchangeCandles returns a CandleSource fetching historic candles from the
+// exchanges returned by getExchange
+func ExchangeCandles(getExchange exchange.ExchangeGetter) CandleSource {
+	return func(m Market, interval kline.Interval, start, end time.Time) ([]kline.Candle, error) {
+		exch := getExchange(m.Exchange)
+		if exch == nil {
+			return nil, fmt.Errorf("%s exchange not found", m.Exchange)
+		}
+		return exch.GetHistoricCandles(m.Pair, m.AssetType, interval, start, end)
+	}
+}
+
+// TickerPrices returns a PriceSource reading the last price from the shared
+// ticker store. Tickers older than maxAge are not current.
+func TickerPrices(maxAge time.Duration) PriceSource {
+	if maxAge <= 0 {
+		maxAge = DefaultMaxAge
+	}
+	return func(m Market) (float64, error) {
+		t, err := ticker.GetTicker(m.Exchange, m.Pair, m.AssetType)
+		if err != nil || time.Since(t.LastUpdated) > maxAge || t.Last <= 0 {
+			return 0, ErrNoQuote
+		}
+		return t.Last, nil
+	}
+}
+
+// ExchangeBalances returns a BalanceSource reading the account info of the
+// exchanges returned by getExchange
+func ExchangeBalances(getExchange exchange.ExchangeGetter) BalanceSource {
+	return func(exchangeName, currency string) (float64, error) {
+		exch := getExchange(exchangeName)
+		if exch == nil {
+			return 0, fmt.Errorf("%s exchange not found", exchangeName)
+		}
+		info, err := exch.GetAccountInfo()
+		if err != nil {
+			return 0, err
+		}
+		var balance float64
+		for i := range info.Currencies {
+			if common.StringToUpper(info.Currencies[i].CurrencyName) == common.StringToUpper(currency) {
+				balance += info.Currencies[i].TotalValue
+			}
+		}
+		return balance, nil
+	}
+}
+
+// Result holds the outcome of placing a suggested hedge order. OrderID is the
+// local order manager ID of the order.
+type Result struct {
+	Suggestion Suggestion
+	OrderID    int
+	Err        error
+}
+
+// Assistant hedges Holdings with the instrument whose returns correlate most
+// strongly with the holding's over the last Window candles of Interval, as
+// long as the absolute correlation is at least MinCorrelation. Once a holding
+// is hedged the same instrument is kept, and its hedge closed when the
+// correlation falls below the minimum. Scans report the suggestions whose
+// order exceeds Tolerance, a fraction of the holding's notional, to
+// OnSuggestion and, when AutoExecute is set, place them.
+type Assistant struct {
+	Holdings       []Holding
+	Candles        CandleSource
+	Prices         PriceSource
+	Balances       BalanceSource
+	Interval       kline.Interval
+	Window         int
+	MinCorrelation float64
+	TargetExposure float64
+	Tolerance      float64
+	AutoExecute    bool
+	Orderer        exchange.Orderer
+	GetExchange    exchange.ExchangeGetter
+	OnSuggestion   func(Suggestion)
+	OnExecution    func(Result)
+	OnError        func(h Holding, err error)
+
+	hedges map[string]hedgePosition
+	latest []Suggestion
+	m      sync.Mutex
+}
+
+// hedgePosition is the instrument and amount a holding is hedged with
+type hedgePosition struct {
+	market Market
+	amount float64
+}
+
+// NewAssistant returns an assistant of holdings priced from the ticker store
+// with the default candle window and minimum correlation
+func NewAssistant(holdings []Holding) *Assistant {
+	return &Assistant{
+		Holdings:       holdings,
+		Prices:         TickerPrices(DefaultMaxAge),
+		Interval:       DefaultInterval,
+		Window:         DefaultWindow,
+		MinCorrelation: DefaultMinCorrelation,
+	}
+}
+
+// SetHedge records that a holding is hedged with amount of an instrument,
+// negative when short, such as a hedge placed before the bot started
+func (a *Assistant) SetHedge(holding, hedge Market, amount float64) {
+	a.m.Lock()
+	defer a.m.Unlock()
+	if a.hedges == nil {
+		a.hedges = make(map[string]hedgePosition)
+	}
+	a.hedges[holding.String()] = hedgePosition{market: hedge, amount: amount}
+}
+
+// Hedge returns the instrument and amount a holding is hedged with
+func (a *Assistant) Hedge(holding Market) (Market, float64, bool) {
+	a.m.Lock()
+	defer a.m.Unlock()
+	p, ok := a.hedges[holding.String()]
+	return p.market, p.amount, ok
+}
+
+// Latest returns the suggestions of the last scan
+func (a *Assistant) Latest() []Suggestion {
+	a.m.Lock()
+	defer a.m.Unlock()
+	return append([]Suggestion(nil), a.latest...)
+}
+
+// Scan calculates the hedge of each holding at now and returns their
+// suggestions
+func (a *Assistant) Scan(now time.Time) []Suggestion {
+	var result []Suggestion
+	for i := range a.Holdings {
+		s, err := a.suggest(&a.Holdings[i], now)
+		if err != nil {
+			if a.OnError != nil {
+				a.OnError(a.Holdings[i], err)
+			}
+			continue
+		}
+		result = append(result, s)
+	}
+
+	a.m.Lock()
+	a.latest = result
+	a.m.Unlock()
+
+	for i := range result {
+		if !result[i].Rebalance {
+			continue
+		}
+		if a.OnSuggestion != nil {
+			a.OnSuggestion(result[i])
+		}
+		if a.AutoExecute && a.Orderer != nil {
+			r := a.Execute(result[i])
+			if a.OnExecution != nil {
+				a.OnExecution(r)
+			}
+		}
+	}
+	return result
+}
+
+// suggest returns the suggestion of a holding at now
+func (a *Assistant) suggest(h *Holding, now time.Time) (Suggestion, error) {
+	held := h.Amount
+	if held == 0 && a.Balances != nil {
+		var err error
+		held, err = a.Balances(h.Asset.Exchange, h.Asset.Pair.FirstCurrency.String())
+		if err != nil {
+			return Suggestion{}, err
+		}
+	}
+
+	current, hedgeAmount, hedged := a.Hedge(h.Asset)
+	if held <= 0 && !hedged {
+		return Suggestion{}, ErrNoHolding
+	}
+
+	assetPrice, err := a.Prices(h.Asset)
+	if err != nil {
+		return Suggestion{}, err
+	}
+
+	window := a.Window
+	if window < 2 {
+		window = DefaultWindow
+	}
+	interval := a.Interval
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	start := now.Add(-time.Duration(window+2) * interval.Duration())
+	assetCandles, err := a.Candles(h.Asset, interval, start, now)
+	if err != nil {
+		return Suggestion{}, err
+	}
+
+	candidates := h.Hedges
+	if hedged {
+		candidates = []Market{current}
+	}
+
+	var best Market
+	var bestStats Stats
+	found := false
+	for _, m := range candidates {
+		hedgeCandles, err := a.Candles(m, interval, start, now)
+		if err != nil {
+			continue
+		}
+		series, err := Rolling(assetCandles, hedgeCandles, window)
+		if err != nil {
+			continue
+		}
+		s := series[len(series)-1]
+		if math.Abs(s.Correlation) < a.MinCorrelation {
+			continue
+		}
+		if !found || math.Abs(s.Correlation) > math.Abs(bestStats.Correlation) {
+			best, bestStats, found = m, s, true
+		}
+	}
+	if !found {
+		if !hedged {
+			return Suggestion{}, ErrNoHedge
+		}
+		// The hedge no longer tracks the holding so it is closed
+		best, bestStats = current, Stats{Time: now}
+	}
+
+	hedgePrice, err := a.Prices(best)
+	if err != nil {
+		return Suggestion{}, err
+	}
+
+	s := Suggest(bestStats, held, assetPrice, hedgeAmount, hedgePrice, a.TargetExposure)
+	s.Holding = h.Asset
+	s.Hedge = best
+	s.Time = now
+	s.Rebalance = s.Amount > 0 &&
+		s.Amount*hedgePrice >= a.Tolerance*math.Abs(held*assetPrice)
+	return s, nil
+}
+
+// Execute places a suggestion's hedge order at market and records the
+// holding's new hedge. Spot hedges are placed through Orderer and futures and
+// perpetual swap hedges on exchanges implementing exchange.FuturesExchange,
+// reduce only when they shrink the hedge.
+func (a *Assistant) Execute(s Suggestion) Result {
+	result := Result{Suggestion: s}
+	exch := a.GetExchange(s.Hedge.Exchange)
+	if exch == nil {
+		result.Err = fmt.Errorf("%s exchange not found", s.Hedge.Exchange)
+		return result
+	}
+
+	if s.Hedge.AssetType == assets.Spot {
+		result.OrderID, result.Err = a.Orderer.SubmitStrategyOrder(StrategyName, exch, exchange.OrderSubmission{
+			Pair:       s.Hedge.Pair,
+			Side:       s.Side,
+			Type:       exchange.Market,
+			BaseAmount: s.Amount,
+		})
+	} else {
+		result.OrderID, result.Err = submitFuturesHedge(exch, s)
+	}
+	if result.Err != nil {
+		return result
+	}
+
+	amount := s.HedgeAmount + s.Amount
+	if s.Side == exchange.Sell {
+		amount = s.HedgeAmount - s.Amount
+	}
+	a.SetHedge(s.Holding, s.Hedge, amount)
+	return result
+}
+
+// submitFuturesHedge places a suggestion's futures hedge order and tracks it
+// in the order manager
+func submitFuturesHedge(exch exchange.IBotExchange, s Suggestion) (int, error) {
+	f, ok := exch.(exchange.FuturesExchange)
+	if !ok {
+		return 0, ErrFuturesNotSupported
+	}
+	reduceOnly := s.Amount <= math.Abs(s.HedgeAmount) &&
+		(s.HedgeAmount < 0) == (s.Side == exchange.Buy)
+
+	instrumentID := s.Hedge.instrumentID()
+	resp, err := f.SubmitFuturesOrder(instrumentID, s.Side, exchange.Market, s.Amount, 0, reduceOnly, "")
+	if err == nil && !resp.IsOrderPlaced {
+		err = fmt.Errorf("%s %s order for %s was not placed", s.Hedge.Exchange, s.Side, instrumentID)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return orders.TrackOrder(s.Hedge.Exchange, resp.OrderID, s.Hedge.Pair,
+		s.Side, exchange.Market, s.Amount, 0), nil
+}
+
+// Run scans the holdings every interval until stop is closed
+func (a *Assistant) Run(interval time.Duration, stop <-chan struct{}) {
+	if interval <= 0 {
+		interval = DefaultScanInterval
+	}
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		a.Scan(time.Now())
+		select {
+		case <-stop:
+			return
+		case <-t.C:
+		}
+	}
+}
@@ -0,0 +1,242 @@
+package hedge
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/assets"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
+)
+
+var (
+	start = time.Date(2018, 9, 1, 0, 0, 0, 0, time.UTC)
+
+	held   = Market{Exchange: "HedgeSpot", Pair: pair.NewCurrencyPair("ETH", "USD"), AssetType: assets.Spot}
+	perp   = Market{Exchange: "HedgeFutures", Pair: pair.NewCurrencyPair("BTC", "USD"), AssetType: assets.PerpetualSwap, InstrumentID: "BTC-USD-SWAP"}
+	noise  = Market{Exchange: "HedgeSpot", Pair: pair.NewCurrencyPair("LTC", "USD"), AssetType: assets.Spot}
+	hedgeR = []float64{0.01, -0.02, 0.015, 0.005, -0.01, 0.02, -0.005, 0.01}
+	noiseR = []float64{0.01, 0.01, -0.01, -0.01, 0.01, 0.01, -0.01, -0.01}
+)
+
+// testCandles returns hourly candles from start whose closes have returns
+func testCandles(first float64, returns []float64) []kline.Candle {
+	candles := []kline.Candle{{Time: start, Open: first, High: first, Low: first, Close: first}}
+	for x := range returns {
+		c := candles[x].Close * math.Exp(returns[x])
+		candles = append(candles, kline.Candle{Time: start.Add(time.Duration(x+1) * time.Hour),
+			Open: c, High: c, Low: c, Close: c})
+	}
+	return candles
+}
+
+// scaled returns returns multiplied by beta
+func scaled(returns []float64, beta float64) []float64 {
+	s := make([]float64, len(returns))
+	for x := range returns {
+		s[x] = returns[x] * beta
+	}
+	return s
+}
+
+func TestCalculate(t *testing.T) {
+	s, err := Calculate(scaled(hedgeR, 2), hedgeR)
+	if err != nil || math.Abs(s.Correlation-1) > 1e-9 || math.Abs(s.Beta-2) > 1e-9 || s.Samples != len(hedgeR) {
+		t.Error("Test Failed - Calculate() expected perfect correlation with beta 2", s, err)
+	}
+	s, err = Calculate(scaled(hedgeR, -0.5), hedgeR)
+	if err != nil || math.Abs(s.Correlation+1) > 1e-9 || math.Abs(s.Beta+0.5) > 1e-9 {
+		t.Error("Test Failed - Calculate() expected inverse correlation", s, err)
+	}
+
+	if _, err = Calculate(hedgeR, hedgeR[1:]); err != ErrMismatchedReturns {
+		t.Error("Test Failed - Calculate() expected mismatched returns error", err)
+	}
+	if _, err = Calculate([]float64{0.1, 0.1}, []float64{0.1, 0.2}); err != ErrNoVariance {
+		t.Error("Test Failed - Calculate() expected no variance error", err)
+	}
+}
+
+func TestRolling(t *testing.T) {
+	asset := testCandles(200, scaled(hedgeR, 2))
+	hedge := testCandles(6000, hedgeR)
+	// A missing hedge candle drops the asset candle of the same time
+	hedge = append(hedge[:3], hedge[4:]...)
+
+	series, err := Rolling(asset, hedge, 4)
+	if err != nil {
+		t.Fatal("Test Failed - Rolling() error", err)
+	}
+	if len(series) != 4 || !series[3].Time.Equal(asset[len(asset)-1].Time) {
+		t.Fatalf("Test Failed - Rolling() unexpected series %+v", series)
+	}
+	// Returns spanning the missing candle are still proportional
+	for _, s := range series {
+		if math.Abs(s.Beta-2) > 1e-9 {
+			t.Error("Test Failed - Rolling() expected beta 2", s)
+		}
+	}
+
+	if _, err = Rolling(asset, hedge, 1); err != ErrInvalidWindow {
+		t.Error("Test Failed - Rolling() expected invalid window error", err)
+	}
+	if _, err = Rolling(asset, hedge, 8); err != ErrInsufficientCandles {
+		t.Error("Test Failed - Rolling() expected insufficient candles error", err)
+	}
+	asset[2].Close = 0
+	if _, err = Rolling(asset, hedge, 4); err != ErrInvalidCandle {
+		t.Error("Test Failed - Rolling() expected invalid candle error", err)
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	// 10 ETH at 200 with a beta of 0.5 to BTC at 5000 is hedged by selling
+	// 0.2 BTC, half when half the exposure is kept
+	s := Suggest(Stats{Correlation: 0.9, Beta: 0.5}, 10, 200, 0, 5000, 0)
+	if s.Side != exchange.Sell || math.Abs(s.Amount-0.2) > 1e-9 || s.Exposure != 2000 || s.TargetExposure != 0 {
+		t.Errorf("Test Failed - Suggest() unexpected suggestion %+v", s)
+	}
+	s = Suggest(Stats{Correlation: 0.9, Beta: 0.5}, 10, 200, -0.2, 5000, 0.5)
+	if s.Side != exchange.Buy || math.Abs(s.Amount-0.1) > 1e-9 || math.Abs(s.Exposure) > 1e-9 || s.TargetExposure != 1000 {
+		t.Errorf("Test Failed - Suggest() unexpected reduction %+v", s)
+	}
+	s = Suggest(Stats{}, 10, 200, -0.2, 5000, 0)
+	if s.Side != exchange.Buy || math.Abs(s.Amount-0.2) > 1e-9 || s.TargetAmount != 0 {
+		t.Errorf("Test Failed - Suggest() expected hedge closed %+v", s)
+	}
+}
+
+// testOrderer records spot orders
+type testOrderer struct {
+	sides []exchange.OrderSide
+}
+
+func (o *testOrderer) SubmitStrategyOrder(strategy string, exch exchange.IBotExchange, s exchange.OrderSubmission) (int, error) {
+	o.sides = append(o.sides, s.Side)
+	return len(o.sides), nil
+}
+
+// testFuturesExchange records futures orders
+type testFuturesExchange struct {
+	exchange.IBotExchange
+	amounts    []float64
+	reduceOnly []bool
+	fail       bool
+}
+
+func (e *testFuturesExchange) GetFuturesContracts() ([]exchange.FuturesContract, error) {
+	return nil, nil
+}
+
+func (e *testFuturesExchange) SubmitFuturesOrder(instrumentID string, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, reduceOnly bool, clientID string) (exchange.SubmitOrderResponse, error) {
+	if e.fail {
+		return exchange.SubmitOrderResponse{}, errors.New("insufficient margin")
+	}
+	if side == exchange.Sell {
+		amount = -amount
+	}
+	e.amounts = append(e.amounts, amount)
+	e.reduceOnly = append(e.reduceOnly, reduceOnly)
+	return exchange.SubmitOrderResponse{IsOrderPlaced: true, OrderID: "1"}, nil
+}
+
+func TestAssistantScan(t *testing.T) {
+	candles := map[string][]kline.Candle{
+		held.String():  testCandles(200, scaled(hedgeR, 0.5)),
+		perp.String():  testCandles(5000, hedgeR),
+		noise.String(): testCandles(50, noiseR),
+	}
+	prices := map[string]float64{held.String(): 200, perp.String(): 5000, noise.String(): 50}
+	futures := &testFuturesExchange{}
+
+	a := NewAssistant([]Holding{{Asset: held, Hedges: []Market{noise, perp}}})
+	a.Window = 6
+	a.Tolerance = 0.05
+	a.AutoExecute = true
+	a.Orderer = &testOrderer{}
+	a.Candles = func(m Market, interval kline.Interval, from, to time.Time) ([]kline.Candle, error) {
+		return candles[m.String()], nil
+	}
+	a.Prices = func(m Market) (float64, error) {
+		return prices[m.String()], nil
+	}
+	a.Balances = func(exchangeName, currency string) (float64, error) {
+		return 10, nil
+	}
+	a.GetExchange = func(name string) exchange.IBotExchange {
+		return futures
+	}
+	var suggested []Suggestion
+	a.OnSuggestion = func(s Suggestion) { suggested = append(suggested, s) }
+
+	now := start.Add(10 * time.Hour)
+	result := a.Scan(now)
+	if len(result) != 1 || len(suggested) != 1 || result[0].Hedge != perp ||
+		math.Abs(result[0].Stats.Beta-0.5) > 1e-9 || result[0].HeldAmount != 10 {
+		t.Fatalf("Test Failed - Scan() expected perpetual hedge %+v", result)
+	}
+	if len(futures.amounts) != 1 || math.Abs(futures.amounts[0]+0.2) > 1e-9 || futures.reduceOnly[0] {
+		t.Errorf("Test Failed - Scan() expected 0.2 BTC sold %v", futures.amounts)
+	}
+	if m, amount, ok := a.Hedge(held); !ok || m != perp || math.Abs(amount+0.2) > 1e-9 {
+		t.Error("Test Failed - Hedge() expected recorded hedge", m, amount)
+	}
+
+	// Hedged within tolerance, nothing is placed
+	prices[perp.String()] = 5100
+	a.Scan(now)
+	if len(futures.amounts) != 1 || len(a.Latest()) != 1 || a.Latest()[0].Rebalance {
+		t.Error("Test Failed - Scan() expected no rebalance within tolerance", futures.amounts)
+	}
+
+	// The hedge is kept and closed once it no longer correlates
+	candles[perp.String()] = testCandles(5000, noiseR)
+	a.Scan(now)
+	if len(futures.amounts) != 2 || math.Abs(futures.amounts[1]-0.2) > 1e-9 || !futures.reduceOnly[1] {
+		t.Errorf("Test Failed - Scan() expected reduce only close %v %v", futures.amounts, futures.reduceOnly)
+	}
+
+	// No candidate correlates enough
+	a.hedges = nil
+	var errs []error
+	a.OnError = func(h Holding, err error) { errs = append(errs, err) }
+	a.Scan(now)
+	if len(errs) != 1 || errs[0] != ErrNoHedge {
+		t.Error("Test Failed - Scan() expected no hedge error", errs)
+	}
+}
+
+func TestExecute(t *testing.T) {
+	futures := &testFuturesExchange{fail: true}
+	orderer := &testOrderer{}
+	a := &Assistant{Orderer: orderer, GetExchange: func(name string) exchange.IBotExchange {
+		if name == perp.Exchange {
+			return futures
+		}
+		return nil
+	}}
+
+	s := Suggestion{Holding: held, Hedge: perp, Amount: 1, Side: exchange.Sell}
+	if r := a.Execute(s); r.Err == nil {
+		t.Error("Test Failed - Execute() expected futures order error")
+	}
+	if _, _, ok := a.Hedge(held); ok {
+		t.Error("Test Failed - Execute() recorded a failed hedge")
+	}
+
+	s.Hedge = noise
+	if r := a.Execute(s); r.Err == nil {
+		t.Error("Test Failed - Execute() expected exchange not found error")
+	}
+
+	a.GetExchange = func(name string) exchange.IBotExchange { return futures }
+	if r := a.Execute(s); r.Err != nil || r.OrderID != 1 || len(orderer.sides) != 1 {
+		t.Error("Test Failed - Execute() expected spot hedge through the orderer", r)
+	}
+	if _, amount, _ := a.Hedge(held); amount != -1 {
+		t.Error("Test Failed - Execute() expected short spot hedge", amount)
+	}
+}
@@ -6,13 +6,16 @@ import (
 	"io"
 	"log"
 	"os"
+	"time"
 
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/currency"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
 	"github.com/thrasher-/gocryptotrader/currency/translation"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/abbo"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/orders"
 	"github.com/thrasher-/gocryptotrader/exchanges/stats"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 	"github.com/thrasher-/gocryptotrader/portfolio"
@@ -274,8 +277,15 @@ func GetSpecificOrderbook(currency, exchangeName, assetType string) (orderbook.B
 }
 
 // GetSpecificTicker returns a specific ticker given the currency,
-// exchangeName and assetType
+// exchangeName and assetType. The aggregated best bid and offer ticker is
+// returned when exchangeName is ABBO.
 func GetSpecificTicker(currency, exchangeName, assetType string) (ticker.Price, error) {
+	if exchangeName == abbo.Exchange {
+		return ticker.GetTicker(abbo.Exchange,
+			abbo.CanonicalPair(pair.NewCurrencyPairFromString(currency)),
+			assetType)
+	}
+
 	var specificTicker ticker.Price
 	var err error
 	for x := range bot.exchanges {
@@ -364,6 +374,7 @@ func SeedExchangeAccountInfo(data []exchange.AccountInfo) {
 			onHold := data[i].Currencies[j].Hold
 			avail := data[i].Currencies[j].TotalValue
 			total := onHold + avail
+			orders.Reserved.UpdateBalance(exchangeName, currencyName, avail)
 
 			if !port.ExchangeAddressExists(exchangeName, currencyName) {
 				if total <= 0 {
@@ -396,3 +407,44 @@ func SeedExchangeAccountInfo(data []exchange.AccountInfo) {
 		}
 	}
 }
+
+// SeedExchangeEarnBalances updates the portfolio earn products of each
+// exchange with its savings and staking product balances at t
+func SeedExchangeEarnBalances(data map[string][]exchange.EarnBalance, t time.Time) {
+	port := portfolio.GetPortfolio()
+	for exchangeName, balances := range data {
+		products := make([]portfolio.EarnProduct, 0, len(balances))
+		for i := range balances {
+			products = append(products, portfolio.EarnProduct{
+				ProductID:       balances[i].ProductID,
+				ProductType:     balances[i].ProductType,
+				Coin:            balances[i].Currency,
+				Balance:         balances[i].Amount,
+				AccruedInterest: balances[i].AccruedInterest,
+				APR:             balances[i].APR,
+			})
+		}
+		port.UpdateEarnProducts(exchangeName, products, t)
+	}
+}
+
+// SeedExchangeStakingPositions updates the portfolio staking positions of each
+// exchange
+func SeedExchangeStakingPositions(data map[string][]exchange.StakingPosition) {
+	port := portfolio.GetPortfolio()
+	for exchangeName, positions := range data {
+		tracked := make([]portfolio.StakingPosition, 0, len(positions))
+		for i := range positions {
+			tracked = append(tracked, portfolio.StakingPosition{
+				PositionID: positions[i].PositionID,
+				ProductID:  positions[i].ProductID,
+				Coin:       positions[i].Currency,
+				Amount:     positions[i].Amount,
+				APR:        positions[i].APR,
+				Start:      positions[i].Start,
+				Unlock:     positions[i].Unlock,
+			})
+		}
+		port.UpdateStakingPositions(exchangeName, tracked)
+	}
+}
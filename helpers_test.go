@@ -9,6 +9,7 @@ import (
 	"github.com/thrasher-/gocryptotrader/currency"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/abbo"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-/gocryptotrader/exchanges/stats"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
@@ -273,6 +274,20 @@ func TestGetSpecificTicker(t *testing.T) {
 		t.Fatal("Unexpected result")
 	}
 
+	_, err = abbo.Default.Update("Bitstamp", p, ticker.Spot, 999, 1001)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tick, err = GetSpecificTicker("BTCUSD", abbo.Exchange, ticker.Spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if tick.Bid != 999 || tick.Ask != 1001 {
+		t.Fatal("Unexpected ABBO result")
+	}
+
 	UnloadExchange("Bitstamp")
 }
 
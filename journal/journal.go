@@ -0,0 +1,392 @@
+// Package journal keeps a session based trading journal of the orders and
+// fills placed by the bot. Entries carry strategy tags and free-form
+// annotations and are exported with a performance report for post-trade
+// review.
+package journal
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+)
+
+// JournalFile is the default file name of the journal in the data directory
+const JournalFile = "trade_journal.json"
+
+// sessionIDFormat names sessions started without a name
+const sessionIDFormat = "20060102-150405"
+
+// Entry kinds
+const (
+	KindOrder = "order"
+	KindFill  = "fill"
+)
+
+// Errors returned by the journal
+var (
+	ErrNoSession       = errors.New("no journal session started")
+	ErrSessionExists   = errors.New("journal session already exists")
+	ErrSessionNotFound = errors.New("journal session not found")
+	ErrEntryNotFound   = errors.New("journal entry not found")
+	ErrInvalidKind     = errors.New("journal entry kind must be order or fill")
+	ErrEmptyAnnotation = errors.New("annotation text cannot be empty")
+)
+
+// Session is a period of trading, entries are recorded to the current session.
+// Ended is zero while the session is current.
+type Session struct {
+	ID      string    `json:"id"`
+	Started time.Time `json:"started"`
+	Ended   time.Time `json:"ended,omitempty"`
+}
+
+// Annotation is a free-form note added to an entry
+type Annotation struct {
+	Time time.Time `json:"time"`
+	Text string    `json:"text"`
+}
+
+// Entry is an order placed or a fill received during a session. Fills take
+// the tags of their order's entry when recorded without tags.
+type Entry struct {
+	ID          int64        `json:"id"`
+	Session     string       `json:"session"`
+	Kind        string       `json:"kind"`
+	Time        time.Time    `json:"time"`
+	Exchange    string       `json:"exchange"`
+	OrderID     string       `json:"orderID"`
+	Pair        string       `json:"pair"`
+	Side        string       `json:"side"`
+	Price       float64      `json:"price"`
+	Amount      float64      `json:"amount"`
+	Fee         float64      `json:"fee"`
+	Tags        []string     `json:"tags,omitempty"`
+	Annotations []Annotation `json:"annotations,omitempty"`
+}
+
+// record is a line of the journal file, exactly one field is set
+type record struct {
+	Session    *Session          `json:"session,omitempty"`
+	Entry      *Entry            `json:"entry,omitempty"`
+	Annotation *annotationRecord `json:"annotation,omitempty"`
+	Tags       *tagsRecord       `json:"tags,omitempty"`
+}
+
+type annotationRecord struct {
+	EntryID    int64      `json:"entryID"`
+	Annotation Annotation `json:"annotation"`
+}
+
+type tagsRecord struct {
+	EntryID int64    `json:"entryID"`
+	Tags    []string `json:"tags"`
+}
+
+// Journal persists sessions, entries and their annotations to an append only
+// file of JSON lines. A journal with an empty path is held in memory only.
+type Journal struct {
+	path     string
+	sessions []*Session
+	entries  []*Entry
+	byID     map[int64]*Entry
+	current  *Session
+	m        sync.Mutex
+}
+
+// New returns an in memory journal
+func New() *Journal {
+	return &Journal{byID: make(map[int64]*Entry)}
+}
+
+// Open loads the journal at path, creating it when it does not exist. A
+// session left current when the journal was last written remains current
+// until a new session is started.
+func Open(path string) (*Journal, error) {
+	j := New()
+	j.path = path
+	f, err := os.OpenFile(path, os.O_RDONLY|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1024*1024)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var r record
+		err = common.JSONDecode(scanner.Bytes(), &r)
+		if err != nil {
+			return nil, err
+		}
+		j.apply(&r)
+	}
+	return j, scanner.Err()
+}
+
+// StartSession ends the current session and starts a new one. Sessions
+// started without a name are named by their start time.
+func (j *Journal) StartSession(name string) (Session, error) {
+	j.m.Lock()
+	defer j.m.Unlock()
+
+	now := time.Now()
+	if name == "" {
+		name = now.UTC().Format(sessionIDFormat)
+	}
+	if j.session(name) != nil {
+		return Session{}, ErrSessionExists
+	}
+	if err := j.endSession(now); err != nil {
+		return Session{}, err
+	}
+	s := &Session{ID: name, Started: now}
+	if err := j.write(record{Session: s}); err != nil {
+		return Session{}, err
+	}
+	j.apply(&record{Session: s})
+	return *s, nil
+}
+
+// EndSession ends the current session, entries cannot be recorded until a new
+// session is started
+func (j *Journal) EndSession() error {
+	j.m.Lock()
+	defer j.m.Unlock()
+	if j.current == nil {
+		return ErrNoSession
+	}
+	return j.endSession(time.Now())
+}
+
+// endSession ends the current session if there is one. The mutex must be held
+// by the caller.
+func (j *Journal) endSession(now time.Time) error {
+	if j.current == nil {
+		return nil
+	}
+	ended := *j.current
+	ended.Ended = now
+	if err := j.write(record{Session: &ended}); err != nil {
+		return err
+	}
+	j.apply(&record{Session: &ended})
+	return nil
+}
+
+// CurrentSession returns the current session
+func (j *Journal) CurrentSession() (Session, error) {
+	j.m.Lock()
+	defer j.m.Unlock()
+	if j.current == nil {
+		return Session{}, ErrNoSession
+	}
+	return *j.current, nil
+}
+
+// Sessions returns the sessions in the order they were started
+func (j *Journal) Sessions() []Session {
+	j.m.Lock()
+	defer j.m.Unlock()
+	result := make([]Session, len(j.sessions))
+	for i := range j.sessions {
+		result[i] = *j.sessions[i]
+	}
+	return result
+}
+
+// Record adds an entry to the current session and returns its ID. Entries
+// recorded without a time are timestamped now.
+func (j *Journal) Record(e Entry) (int64, error) {
+	if e.Kind != KindOrder && e.Kind != KindFill {
+		return 0, ErrInvalidKind
+	}
+
+	j.m.Lock()
+	defer j.m.Unlock()
+	if j.current == nil {
+		return 0, ErrNoSession
+	}
+	e.ID = int64(len(j.entries)) + 1
+	e.Session = j.current.ID
+	e.Annotations = nil
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	if e.Kind == KindFill && len(e.Tags) == 0 {
+		if o := j.orderEntry(e.Exchange, e.OrderID); o != nil {
+			e.Tags = append([]string(nil), o.Tags...)
+		}
+	}
+	e.Tags = normaliseTags(e.Tags)
+
+	if err := j.write(record{Entry: &e}); err != nil {
+		return 0, err
+	}
+	j.apply(&record{Entry: &e})
+	return e.ID, nil
+}
+
+// Annotate adds a free-form annotation to an entry
+func (j *Journal) Annotate(id int64, text string) error {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return ErrEmptyAnnotation
+	}
+
+	j.m.Lock()
+	defer j.m.Unlock()
+	if j.byID[id] == nil {
+		return ErrEntryNotFound
+	}
+	r := record{Annotation: &annotationRecord{
+		EntryID:    id,
+		Annotation: Annotation{Time: time.Now(), Text: text},
+	}}
+	if err := j.write(r); err != nil {
+		return err
+	}
+	j.apply(&r)
+	return nil
+}
+
+// Tag adds strategy tags to an entry
+func (j *Journal) Tag(id int64, tags ...string) error {
+	j.m.Lock()
+	defer j.m.Unlock()
+	if j.byID[id] == nil {
+		return ErrEntryNotFound
+	}
+	r := record{Tags: &tagsRecord{EntryID: id, Tags: normaliseTags(tags)}}
+	if err := j.write(r); err != nil {
+		return err
+	}
+	j.apply(&r)
+	return nil
+}
+
+// Entry returns an entry by ID
+func (j *Journal) Entry(id int64) (Entry, error) {
+	j.m.Lock()
+	defer j.m.Unlock()
+	e := j.byID[id]
+	if e == nil {
+		return Entry{}, ErrEntryNotFound
+	}
+	return copyEntry(e), nil
+}
+
+// Entries returns the entries of a session in the order they were recorded
+func (j *Journal) Entries(session string) ([]Entry, error) {
+	j.m.Lock()
+	defer j.m.Unlock()
+	if j.session(session) == nil {
+		return nil, ErrSessionNotFound
+	}
+	var result []Entry
+	for _, e := range j.entries {
+		if e.Session == session {
+			result = append(result, copyEntry(e))
+		}
+	}
+	return result, nil
+}
+
+// write appends a record to the journal file. The mutex must be held by the
+// caller.
+func (j *Journal) write(r record) error {
+	if j.path == "" {
+		return nil
+	}
+	data, err := common.JSONEncode(r)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(j.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.Write(append(data, '\n'))
+	return err
+}
+
+// apply applies a record to the journal state. The mutex must be held by the
+// caller.
+func (j *Journal) apply(r *record) {
+	switch {
+	case r.Session != nil:
+		s := j.session(r.Session.ID)
+		if s == nil {
+			s = &Session{}
+			j.sessions = append(j.sessions, s)
+		}
+		*s = *r.Session
+		if s.Ended.IsZero() {
+			j.current = s
+		} else if j.current == s {
+			j.current = nil
+		}
+	case r.Entry != nil:
+		e := *r.Entry
+		j.entries = append(j.entries, &e)
+		j.byID[e.ID] = &e
+	case r.Annotation != nil:
+		if e := j.byID[r.Annotation.EntryID]; e != nil {
+			e.Annotations = append(e.Annotations, r.Annotation.Annotation)
+		}
+	case r.Tags != nil:
+		if e := j.byID[r.Tags.EntryID]; e != nil {
+			e.Tags = normaliseTags(append(e.Tags, r.Tags.Tags...))
+		}
+	}
+}
+
+// session returns a session by ID. The mutex must be held by the caller.
+func (j *Journal) session(id string) *Session {
+	for _, s := range j.sessions {
+		if s.ID == id {
+			return s
+		}
+	}
+	return nil
+}
+
+// orderEntry returns the latest order entry of an exchange order. The mutex
+// must be held by the caller.
+func (j *Journal) orderEntry(exchName, orderID string) *Entry {
+	for i := len(j.entries) - 1; i >= 0; i-- {
+		e := j.entries[i]
+		if e.Kind == KindOrder && e.Exchange == exchName && e.OrderID == orderID {
+			return e
+		}
+	}
+	return nil
+}
+
+// normaliseTags lower cases and trims tags, dropping empty and duplicate tags
+func normaliseTags(tags []string) []string {
+	var result []string
+	for _, t := range tags {
+		t = common.StringToLower(strings.TrimSpace(t))
+		if t != "" && !common.StringDataCompare(result, t) {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+// copyEntry returns a copy of an entry which does not share its slices
+func copyEntry(e *Entry) Entry {
+	c := *e
+	c.Tags = append([]string(nil), e.Tags...)
+	c.Annotations = append([]Annotation(nil), e.Annotations...)
+	return c
+}
@@ -0,0 +1,174 @@
+package journal
+
+import (
+	"bytes"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestJournal(t *testing.T) {
+	dir, err := ioutil.TempDir("", "journal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, JournalFile)
+
+	j, err := Open(path)
+	if err != nil {
+		t.Fatal("Test Failed - Open() error", err)
+	}
+	if _, err = j.Record(Entry{Kind: KindOrder}); err != ErrNoSession {
+		t.Error("Test Failed - Record() expected no session error", err)
+	}
+	if _, err = j.StartSession("first"); err != nil {
+		t.Fatal("Test Failed - StartSession() error", err)
+	}
+	if _, err = j.StartSession("first"); err != ErrSessionExists {
+		t.Error("Test Failed - StartSession() expected session exists error", err)
+	}
+	if _, err = j.Record(Entry{Kind: "trade"}); err != ErrInvalidKind {
+		t.Error("Test Failed - Record() expected invalid kind error", err)
+	}
+
+	order, err := j.Record(Entry{Kind: KindOrder, Exchange: "Bitstamp", OrderID: "1", Tags: []string{" MarketMaker ", "marketmaker"}})
+	if err != nil {
+		t.Fatal("Test Failed - Record() error", err)
+	}
+	fill, err := j.Record(Entry{Kind: KindFill, Exchange: "Bitstamp", OrderID: "1", Side: "BUY", Price: 100, Amount: 1})
+	if err != nil {
+		t.Fatal("Test Failed - Record() error", err)
+	}
+	if err = j.Annotate(fill, "  filled ahead of news  "); err != nil {
+		t.Fatal("Test Failed - Annotate() error", err)
+	}
+	if err = j.Annotate(fill, " "); err != ErrEmptyAnnotation {
+		t.Error("Test Failed - Annotate() expected empty annotation error", err)
+	}
+	if err = j.Tag(order, "momentum"); err != nil {
+		t.Fatal("Test Failed - Tag() error", err)
+	}
+	if err = j.Tag(1337, "momentum"); err != ErrEntryNotFound {
+		t.Error("Test Failed - Tag() expected entry not found error", err)
+	}
+
+	if _, err = j.StartSession("second"); err != nil {
+		t.Fatal("Test Failed - StartSession() error", err)
+	}
+
+	// Reopening replays the journal file
+	j, err = Open(path)
+	if err != nil {
+		t.Fatal("Test Failed - Open() reopen error", err)
+	}
+	if s, err := j.CurrentSession(); err != nil || s.ID != "second" {
+		t.Error("Test Failed - CurrentSession() unexpected session", s, err)
+	}
+	sessions := j.Sessions()
+	if len(sessions) != 2 || sessions[0].Ended.IsZero() {
+		t.Error("Test Failed - Sessions() unexpected sessions", sessions)
+	}
+	e, err := j.Entry(fill)
+	if err != nil || len(e.Tags) != 1 || e.Tags[0] != "marketmaker" ||
+		len(e.Annotations) != 1 || e.Annotations[0].Text != "filled ahead of news" {
+		t.Error("Test Failed - Entry() unexpected fill entry", e, err)
+	}
+	e, _ = j.Entry(order)
+	if len(e.Tags) != 2 || e.Tags[1] != "momentum" {
+		t.Error("Test Failed - Entry() unexpected order tags", e.Tags)
+	}
+	if entries, err := j.Entries("first"); err != nil || len(entries) != 2 {
+		t.Error("Test Failed - Entries() unexpected entries", entries, err)
+	}
+	if _, err = j.Entries("third"); err != ErrSessionNotFound {
+		t.Error("Test Failed - Entries() expected session not found error", err)
+	}
+
+	if err = j.EndSession(); err != nil {
+		t.Fatal("Test Failed - EndSession() error", err)
+	}
+	if err = j.EndSession(); err != ErrNoSession {
+		t.Error("Test Failed - EndSession() expected no session error", err)
+	}
+}
+
+func TestReport(t *testing.T) {
+	j := New()
+	if _, err := j.StartSession(""); err != nil {
+		t.Fatal("Test Failed - StartSession() error", err)
+	}
+	s, _ := j.CurrentSession()
+
+	entries := []Entry{
+		{Kind: KindOrder, Exchange: "Bitstamp", OrderID: "1", Tags: []string{"mm"}},
+		{Kind: KindFill, Exchange: "Bitstamp", OrderID: "1", Pair: "BTCUSD", Side: "BUY", Price: 100, Amount: 2, Fee: 0.2},
+		{Kind: KindFill, Exchange: "Bitstamp", OrderID: "2", Pair: "BTCUSD", Side: "SELL", Price: 110, Amount: 3, Fee: 0.3, Tags: []string{"mm"}},
+		{Kind: KindFill, Exchange: "Bitstamp", OrderID: "3", Pair: "BTCUSD", Side: "BUY", Price: 105, Amount: 1},
+	}
+	for i := range entries {
+		if _, err := j.Record(entries[i]); err != nil {
+			t.Fatal("Test Failed - Record() error", err)
+		}
+	}
+
+	r, err := j.Report(s.ID)
+	if err != nil {
+		t.Fatal("Test Failed - Report() error", err)
+	}
+	// The sell closes the long of 2 for 20 and opens a short of 1 at 110,
+	// which the final buy closes for 5
+	if r.Total.Orders != 1 || r.Total.Fills != 3 || r.Total.Volume != 635 ||
+		math.Abs(r.Total.RealisedProfit-25) > 1e-9 || math.Abs(r.Total.NetProfit-24.5) > 1e-9 {
+		t.Errorf("Test Failed - Report() unexpected total %+v", r.Total)
+	}
+	if len(r.Strategies) != 2 || r.Strategies[0].Tag != "mm" || r.Strategies[1].Tag != untagged ||
+		math.Abs(r.Strategies[0].RealisedProfit-20) > 1e-9 || r.Strategies[1].RealisedProfit != 0 {
+		t.Errorf("Test Failed - Report() unexpected strategies %+v", r.Strategies)
+	}
+
+	var b bytes.Buffer
+	if err = j.ExportCSV(&b, s.ID); err != nil {
+		t.Fatal("Test Failed - ExportCSV() error", err)
+	}
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	if len(lines) != 10 || !strings.HasPrefix(lines[7], "total,1,3,635") {
+		t.Error("Test Failed - ExportCSV() unexpected output", lines)
+	}
+	b.Reset()
+	if err = j.ExportJSON(&b, s.ID); err != nil || !strings.Contains(b.String(), `"realisedProfit":25`) {
+		t.Error("Test Failed - ExportJSON() unexpected output", b.String(), err)
+	}
+}
+
+func TestPeriod(t *testing.T) {
+	j := New()
+	if _, err := j.StartSession("august"); err != nil {
+		t.Fatal("Test Failed - StartSession() error", err)
+	}
+	start := time.Date(2018, 9, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0)
+	record := func(e Entry) {
+		if _, err := j.Record(e); err != nil {
+			t.Fatal("Test Failed - Record() error", err)
+		}
+	}
+	record(Entry{Kind: KindFill, Time: start.Add(-time.Hour), Exchange: "Bitstamp", Pair: "BTCUSD", Side: "BUY", Price: 100, Amount: 2, Fee: 0.2})
+	if _, err := j.StartSession("september"); err != nil {
+		t.Fatal("Test Failed - StartSession() error", err)
+	}
+	record(Entry{Kind: KindOrder, Time: start.Add(time.Hour), Exchange: "Bitstamp", OrderID: "2"})
+	record(Entry{Kind: KindFill, Time: start.Add(time.Hour), Exchange: "Bitstamp", OrderID: "2", Pair: "BTCUSD", Side: "SELL", Price: 110, Amount: 1, Fee: 0.1})
+	record(Entry{Kind: KindFill, Time: end, Exchange: "Bitstamp", Pair: "BTCUSD", Side: "SELL", Price: 120, Amount: 1})
+
+	// The sell in the period realises 10 against the buy before it
+	s, entries := j.Period(start, end)
+	if len(entries) != 2 || s.Orders != 1 || s.Fills != 1 || s.Volume != 110 ||
+		math.Abs(s.RealisedProfit-10) > 1e-9 || math.Abs(s.NetProfit-9.9) > 1e-9 {
+		t.Errorf("Test Failed - Period() unexpected summary %+v %v", s, entries)
+	}
+}
@@ -0,0 +1,268 @@
+package journal
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// untagged is the report tag of entries without strategy tags
+const untagged = "untagged"
+
+// Summary is the performance of a set of entries. Volume is the notional
+// filled, RealisedProfit is measured against the average cost of each
+// exchange pair's position and NetProfit is net of fees.
+type Summary struct {
+	Tag            string  `json:"tag,omitempty"`
+	Orders         int     `json:"orders"`
+	Fills          int     `json:"fills"`
+	Volume         float64 `json:"volume"`
+	Fees           float64 `json:"fees"`
+	RealisedProfit float64 `json:"realisedProfit"`
+	NetProfit      float64 `json:"netProfit"`
+}
+
+// Report is the performance of a session overall and per strategy tag.
+// Entries with several tags count towards each of them.
+type Report struct {
+	Session    Session   `json:"session"`
+	Total      Summary   `json:"total"`
+	Strategies []Summary `json:"strategies"`
+}
+
+// Export is a session's report and entries for post-trade review
+type Export struct {
+	Report  Report  `json:"report"`
+	Entries []Entry `json:"entries"`
+}
+
+// position is a net position held at an average cost
+type position struct {
+	amount  float64
+	average float64
+}
+
+// fill applies a fill to the position and returns the profit it realised.
+// Amount is positive for buys and negative for sells.
+func (p *position) fill(amount, price float64) float64 {
+	if p.amount == 0 || (p.amount > 0) == (amount > 0) {
+		total := math.Abs(p.amount) + math.Abs(amount)
+		p.average = (p.average*math.Abs(p.amount) + price*math.Abs(amount)) / total
+		p.amount += amount
+		return 0
+	}
+
+	closed := math.Min(math.Abs(amount), math.Abs(p.amount))
+	realised := closed * (price - p.average)
+	if p.amount < 0 {
+		realised = -realised
+	}
+	remaining := p.amount + amount
+	switch {
+	case remaining == 0:
+		p.average = 0
+	case (remaining > 0) != (p.amount > 0):
+		// The fill reversed the position, the remainder opened at the price
+		p.average = price
+	}
+	p.amount = remaining
+	return realised
+}
+
+// summariser accumulates a summary over entries
+type summariser struct {
+	Summary
+	positions map[string]*position
+}
+
+func (s *summariser) add(e *Entry) {
+	if e.Kind == KindOrder {
+		s.Orders++
+		return
+	}
+	s.Fills++
+	s.Volume += e.Price * e.Amount
+	s.Fees += e.Fee
+	s.RealisedProfit += s.fill(e)
+}
+
+// fill applies a fill to its exchange pair's position and returns the profit
+// it realised
+func (s *summariser) fill(e *Entry) float64 {
+	key := e.Exchange + " " + e.Pair
+	p, ok := s.positions[key]
+	if !ok {
+		p = &position{}
+		s.positions[key] = p
+	}
+	amount := e.Amount
+	if side := strings.ToLower(e.Side); side == "sell" || side == "ask" {
+		amount = -amount
+	}
+	return p.fill(amount, e.Price)
+}
+
+func (s *summariser) summary() Summary {
+	result := s.Summary
+	result.NetProfit = result.RealisedProfit - result.Fees
+	return result
+}
+
+func newSummariser(tag string) *summariser {
+	return &summariser{
+		Summary:   Summary{Tag: tag},
+		positions: make(map[string]*position),
+	}
+}
+
+// Report returns the performance report of a session
+func (j *Journal) Report(session string) (Report, error) {
+	entries, err := j.Entries(session)
+	if err != nil {
+		return Report{}, err
+	}
+	j.m.Lock()
+	r := Report{Session: *j.session(session)}
+	j.m.Unlock()
+
+	total := newSummariser("")
+	strategies := make(map[string]*summariser)
+	for i := range entries {
+		total.add(&entries[i])
+		tags := entries[i].Tags
+		if len(tags) == 0 {
+			tags = []string{untagged}
+		}
+		for _, t := range tags {
+			s, ok := strategies[t]
+			if !ok {
+				s = newSummariser(t)
+				strategies[t] = s
+			}
+			s.add(&entries[i])
+		}
+	}
+
+	r.Total = total.summary()
+	for _, s := range strategies {
+		r.Strategies = append(r.Strategies, s.summary())
+	}
+	sort.Slice(r.Strategies, func(a, b int) bool {
+		return r.Strategies[a].Tag < r.Strategies[b].Tag
+	})
+	return r, nil
+}
+
+// Period returns the summary of the entries recorded from start until end
+// across all sessions, and those entries. Positions are built from every
+// earlier fill, so profit realised in the period is measured against the cost
+// of positions opened before it.
+func (j *Journal) Period(start, end time.Time) (Summary, []Entry) {
+	j.m.Lock()
+	var entries []Entry
+	for _, e := range j.entries {
+		if e.Time.Before(end) {
+			entries = append(entries, copyEntry(e))
+		}
+	}
+	j.m.Unlock()
+
+	s := newSummariser("")
+	var result []Entry
+	for i := range entries {
+		if entries[i].Time.Before(start) {
+			if entries[i].Kind == KindFill {
+				s.fill(&entries[i])
+			}
+			continue
+		}
+		s.add(&entries[i])
+		result = append(result, entries[i])
+	}
+	return s.summary(), result
+}
+
+// ExportJSON writes a session's report and entries as JSON
+func (j *Journal) ExportJSON(w io.Writer, session string) error {
+	export, err := j.export(session)
+	if err != nil {
+		return err
+	}
+	return json.NewEncoder(w).Encode(export)
+}
+
+// ExportCSV writes a session's entries followed by its report as CSV. Tags
+// are separated by semicolons and annotations by newlines within their
+// fields.
+func (j *Journal) ExportCSV(w io.Writer, session string) error {
+	export, err := j.export(session)
+	if err != nil {
+		return err
+	}
+
+	c := csv.NewWriter(w)
+	c.Write([]string{"id", "session", "kind", "time", "exchange", "order_id",
+		"pair", "side", "price", "amount", "fee", "tags", "annotations"})
+	for _, e := range export.Entries {
+		annotations := make([]string, len(e.Annotations))
+		for i := range e.Annotations {
+			annotations[i] = e.Annotations[i].Text
+		}
+		c.Write([]string{
+			strconv.FormatInt(e.ID, 10),
+			e.Session,
+			e.Kind,
+			e.Time.UTC().Format(time.RFC3339),
+			e.Exchange,
+			e.OrderID,
+			e.Pair,
+			e.Side,
+			formatFloat(e.Price),
+			formatFloat(e.Amount),
+			formatFloat(e.Fee),
+			strings.Join(e.Tags, ";"),
+			strings.Join(annotations, "\n"),
+		})
+	}
+
+	c.Write(nil)
+	c.Write([]string{"strategy", "orders", "fills", "volume", "fees",
+		"realised_profit", "net_profit"})
+	summaries := append([]Summary{export.Report.Total}, export.Report.Strategies...)
+	summaries[0].Tag = "total"
+	for _, s := range summaries {
+		c.Write([]string{
+			s.Tag,
+			strconv.Itoa(s.Orders),
+			strconv.Itoa(s.Fills),
+			formatFloat(s.Volume),
+			formatFloat(s.Fees),
+			formatFloat(s.RealisedProfit),
+			formatFloat(s.NetProfit),
+		})
+	}
+	c.Flush()
+	return c.Error()
+}
+
+// export returns a session's report and entries
+func (j *Journal) export(session string) (Export, error) {
+	r, err := j.Report(session)
+	if err != nil {
+		return Export{}, err
+	}
+	entries, err := j.Entries(session)
+	if err != nil {
+		return Export{}, err
+	}
+	return Export{Report: r, Entries: entries}, nil
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,342 @@
+// Package liquidation tracks the mark and index prices of derivatives and the
+// liquidation prices of open futures and perpetual swap positions, alerting
+// when a position's margin ratio or its distance to liquidation breaches the
+// configured thresholds.
+package liquidation
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+)
+
+// Tracker defaults
+const (
+	DefaultMaxMarginRatio     = 0.8
+	DefaultMinDistancePercent = 10
+	DefaultPollInterval       = time.Minute
+)
+
+// Alert reasons
+const (
+	ReasonMarginRatio = "margin ratio"
+	ReasonDistance    = "distance to liquidation"
+)
+
+// ErrPositionsUnavailable is returned when an exchange does not report its
+// futures positions
+var ErrPositionsUnavailable = errors.New("exchange does not report futures positions")
+
+// Risk is an open position's exposure to liquidation. DistancePercent is how
+// far the mark price may move against the position before it reaches the
+// liquidation price, as a percentage of the mark price, negative once it has
+// passed it. HasLiquidation is false when the exchange reports no liquidation
+// price, in which case DistancePercent is not calculated.
+type Risk struct {
+	Position        exchange.FuturesPosition `json:"position"`
+	HasLiquidation  bool                     `json:"hasLiquidation"`
+	DistancePercent float64                  `json:"distancePercent"`
+}
+
+func (r Risk) String() string {
+	return fmt.Sprintf("%s %s", r.Position.Exchange, r.Position.InstrumentID)
+}
+
+// Alert is raised once when a position breaches a threshold, Reasons holds
+// each threshold breached
+type Alert struct {
+	Risk    Risk      `json:"risk"`
+	Reasons []string  `json:"reasons"`
+	Time    time.Time `json:"time"`
+}
+
+func (a Alert) String() string {
+	p := a.Risk.Position
+	s := fmt.Sprintf("%s position of %v at mark price %v", a.Risk, p.Amount, p.MarkPrice)
+	if a.Risk.HasLiquidation {
+		s += fmt.Sprintf(" is %.2f%% from liquidation at %v", a.Risk.DistancePercent, p.LiquidationPrice)
+	}
+	if p.MarginRatio > 0 {
+		s += fmt.Sprintf(" with a margin ratio of %.2f", p.MarginRatio)
+	}
+	return s + ", breaching " + common.JoinStrings(a.Reasons, " and ")
+}
+
+// Distance returns how far, as a percentage of the mark price, the mark price
+// may move against a position before reaching its liquidation price. Longs are
+// liquidated as the price falls and shorts as it rises.
+func Distance(p *exchange.FuturesPosition) (float64, bool) {
+	if p.LiquidationPrice <= 0 || p.MarkPrice <= 0 {
+		return 0, false
+	}
+	if p.Amount < 0 {
+		return (p.LiquidationPrice - p.MarkPrice) / p.MarkPrice * 100, true
+	}
+	return (p.MarkPrice - p.LiquidationPrice) / p.MarkPrice * 100, true
+}
+
+// Assess returns the liquidation risk of a position
+func Assess(p *exchange.FuturesPosition) Risk {
+	r := Risk{Position: *p}
+	r.DistancePercent, r.HasLiquidation = Distance(p)
+	return r
+}
+
+// PositionSource returns the open futures positions of an exchange
+type PositionSource func(exchName string) ([]exchange.FuturesPosition, error)
+
+// ExchangePositions returns a PositionSource asking the exchanges returned by
+// getExchange which implement exchange.FuturesPositionExchange
+func ExchangePositions(getExchange exchange.ExchangeGetter) PositionSource {
+	return func(exchName string) ([]exchange.FuturesPosition, error) {
+		exch := getExchange(exchName)
+		if exch == nil {
+			return nil, fmt.Errorf("%s exchange not found", exchName)
+		}
+		f, ok := exch.(exchange.FuturesPositionExchange)
+		if !ok {
+			return nil, ErrPositionsUnavailable
+		}
+		return f.GetFuturesPositions()
+	}
+}
+
+// Tracker holds the latest mark prices streamed by exchanges and the open
+// positions polled from Exchanges, repricing positions as mark prices arrive.
+// A position alerts OnAlert once when its margin ratio reaches MaxMarginRatio
+// or it comes within MinDistancePercent of liquidation, and again only after
+// it has recovered. A zero threshold disables it.
+type Tracker struct {
+	Exchanges          []string
+	Positions          PositionSource
+	MaxMarginRatio     float64
+	MinDistancePercent float64
+	OnAlert            func(Alert)
+	OnError            func(exchName string, err error)
+
+	marks     map[string]exchange.MarkPriceData
+	positions map[string][]exchange.FuturesPosition
+	alerted   map[string]bool
+	m         sync.Mutex
+}
+
+// NewTracker returns a tracker of the positions of exchanges with the default
+// thresholds
+func NewTracker(exchanges []string) *Tracker {
+	return &Tracker{
+		Exchanges:          exchanges,
+		MaxMarginRatio:     DefaultMaxMarginRatio,
+		MinDistancePercent: DefaultMinDistancePercent,
+	}
+}
+
+// instrumentKey identifies an exchange's instrument, by pair and asset type
+// when the instrument ID is unknown
+func instrumentKey(exchName, instrumentID, pairAsset string) string {
+	if instrumentID != "" {
+		return exchName + " " + instrumentID
+	}
+	return exchName + " " + pairAsset
+}
+
+func markKey(m *exchange.MarkPriceData) string {
+	return instrumentKey(m.Exchange, m.InstrumentID, m.AssetType+" "+m.Pair.Pair().String())
+}
+
+func positionKey(p *exchange.FuturesPosition) string {
+	return instrumentKey(p.Exchange, p.InstrumentID, p.AssetType+" "+p.Pair.Pair().String())
+}
+
+// UpdateMarkPrice stores a streamed mark price and reprices the open
+// positions in its instrument
+func (t *Tracker) UpdateMarkPrice(m exchange.MarkPriceData) {
+	if m.MarkPrice <= 0 {
+		return
+	}
+	key := markKey(&m)
+
+	t.m.Lock()
+	if t.marks == nil {
+		t.marks = make(map[string]exchange.MarkPriceData)
+	}
+	t.marks[key] = m
+	positions := t.positions[m.Exchange]
+	var risks []Risk
+	for i := range positions {
+		if positionKey(&positions[i]) != key {
+			continue
+		}
+		positions[i].MarkPrice = m.MarkPrice
+		risks = append(risks, Assess(&positions[i]))
+	}
+	t.m.Unlock()
+
+	t.check(risks, m.Timestamp)
+}
+
+// MarkPrices returns the latest streamed mark prices ordered by exchange and
+// instrument
+func (t *Tracker) MarkPrices() []exchange.MarkPriceData {
+	t.m.Lock()
+	keys := make([]string, 0, len(t.marks))
+	for k := range t.marks {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	result := make([]exchange.MarkPriceData, 0, len(keys))
+	for _, k := range keys {
+		result = append(result, t.marks[k])
+	}
+	t.m.Unlock()
+	return result
+}
+
+// UpdatePositions replaces the open positions of an exchange, repricing them
+// with streamed mark prices newer than the positions, and checks them at now
+func (t *Tracker) UpdatePositions(exchName string, positions []exchange.FuturesPosition, now time.Time) {
+	open := make([]exchange.FuturesPosition, 0, len(positions))
+	for i := range positions {
+		if positions[i].Amount == 0 {
+			continue
+		}
+		p := positions[i]
+		p.Exchange = exchName
+		open = append(open, p)
+	}
+
+	t.m.Lock()
+	if t.positions == nil {
+		t.positions = make(map[string][]exchange.FuturesPosition)
+	}
+	t.positions[exchName] = open
+	risks := make([]Risk, 0, len(open))
+	keys := make(map[string]bool, len(open))
+	for i := range open {
+		key := positionKey(&open[i])
+		keys[key] = true
+		if m, ok := t.marks[key]; ok && m.Timestamp.After(open[i].UpdateTime) {
+			open[i].MarkPrice = m.MarkPrice
+		}
+		risks = append(risks, Assess(&open[i]))
+	}
+	// Closed positions may alert again when reopened
+	for key := range t.alerted {
+		if !keys[key] && strings.HasPrefix(key, exchName+" ") {
+			delete(t.alerted, key)
+		}
+	}
+	t.m.Unlock()
+
+	t.check(risks, now)
+}
+
+// Risks returns the liquidation risk of every open position, closest to
+// liquidation first
+func (t *Tracker) Risks() []Risk {
+	t.m.Lock()
+	var result []Risk
+	for _, positions := range t.positions {
+		for i := range positions {
+			result = append(result, Assess(&positions[i]))
+		}
+	}
+	t.m.Unlock()
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return closeness(result[i]) < closeness(result[j])
+	})
+	return result
+}
+
+// closeness orders risks by distance to liquidation, those without a
+// liquidation price last
+func closeness(r Risk) float64 {
+	if !r.HasLiquidation {
+		return math.Inf(1)
+	}
+	return r.DistancePercent
+}
+
+// Breaches returns the thresholds a position's risk breaches
+func (t *Tracker) Breaches(r Risk) []string {
+	var reasons []string
+	if t.MaxMarginRatio > 0 && r.Position.MarginRatio >= t.MaxMarginRatio {
+		reasons = append(reasons, ReasonMarginRatio)
+	}
+	if t.MinDistancePercent > 0 && r.HasLiquidation && r.DistancePercent <= t.MinDistancePercent {
+		reasons = append(reasons, ReasonDistance)
+	}
+	return reasons
+}
+
+// check alerts the risks which newly breach a threshold and clears those
+// which have recovered
+func (t *Tracker) check(risks []Risk, now time.Time) {
+	var alerts []Alert
+	t.m.Lock()
+	if t.alerted == nil {
+		t.alerted = make(map[string]bool)
+	}
+	for i := range risks {
+		key := positionKey(&risks[i].Position)
+		reasons := t.Breaches(risks[i])
+		if len(reasons) == 0 {
+			delete(t.alerted, key)
+			continue
+		}
+		if t.alerted[key] {
+			continue
+		}
+		t.alerted[key] = true
+		alerts = append(alerts, Alert{Risk: risks[i], Reasons: reasons, Time: now})
+	}
+	t.m.Unlock()
+
+	if t.OnAlert == nil {
+		return
+	}
+	for i := range alerts {
+		t.OnAlert(alerts[i])
+	}
+}
+
+// Poll fetches and checks the open positions of each exchange
+func (t *Tracker) Poll(now time.Time) {
+	if t.Positions == nil {
+		return
+	}
+	for _, exchName := range t.Exchanges {
+		positions, err := t.Positions(exchName)
+		if err != nil {
+			if t.OnError != nil {
+				t.OnError(exchName, err)
+			}
+			continue
+		}
+		t.UpdatePositions(exchName, positions, now)
+	}
+}
+
+// Run polls the positions every interval until stop is closed
+func (t *Tracker) Run(interval time.Duration, stop <-chan struct{}) {
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+	tick := time.NewTicker(interval)
+	defer tick.Stop()
+	for {
+		t.Poll(time.Now())
+		select {
+		case <-stop:
+			return
+		case <-tick.C:
+		}
+	}
+}
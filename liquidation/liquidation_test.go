@@ -0,0 +1,157 @@
+package liquidation
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/assets"
+)
+
+var (
+	btcusdt = pair.NewCurrencyPair("BTC", "USDT")
+	now     = time.Date(2018, 9, 20, 0, 0, 0, 0, time.UTC)
+)
+
+func testPosition(amount, mark, liquidation, marginRatio float64) exchange.FuturesPosition {
+	return exchange.FuturesPosition{
+		Exchange:         "Liquidation",
+		InstrumentID:     "BTCUSDT",
+		Pair:             btcusdt,
+		AssetType:        assets.PerpetualSwap,
+		Amount:           amount,
+		MarkPrice:        mark,
+		LiquidationPrice: liquidation,
+		MarginRatio:      marginRatio,
+		UpdateTime:       now,
+	}
+}
+
+func TestDistance(t *testing.T) {
+	long := testPosition(1, 10000, 9000, 0)
+	d, ok := Distance(&long)
+	if !ok || math.Abs(d-10) > 1e-9 {
+		t.Error("Test Failed - Distance() incorrect long distance", d, ok)
+	}
+
+	short := testPosition(-1, 10000, 10500, 0)
+	d, ok = Distance(&short)
+	if !ok || math.Abs(d-5) > 1e-9 {
+		t.Error("Test Failed - Distance() incorrect short distance", d, ok)
+	}
+
+	short.MarkPrice = 11000
+	d, _ = Distance(&short)
+	if d >= 0 {
+		t.Error("Test Failed - Distance() expected negative distance past liquidation", d)
+	}
+
+	cross := testPosition(1, 10000, 0, 0)
+	if _, ok = Distance(&cross); ok {
+		t.Error("Test Failed - Distance() expected no distance without a liquidation price")
+	}
+}
+
+func TestTrackerAlerts(t *testing.T) {
+	var alerts []Alert
+	tracker := NewTracker([]string{"Liquidation"})
+	tracker.OnAlert = func(a Alert) {
+		alerts = append(alerts, a)
+	}
+
+	// 20% from liquidation with a low margin ratio is safe
+	tracker.UpdatePositions("Liquidation", []exchange.FuturesPosition{
+		testPosition(1, 10000, 8000, 0.2),
+		testPosition(0, 10000, 0, 0),
+	}, now)
+	if len(alerts) != 0 {
+		t.Fatal("Test Failed - UpdatePositions() unexpected alert", alerts)
+	}
+	if risks := tracker.Risks(); len(risks) != 1 || math.Abs(risks[0].DistancePercent-20) > 1e-9 {
+		t.Fatal("Test Failed - Risks() incorrect risks", risks)
+	}
+
+	// The mark falling to 8800 brings the position within 10% of liquidation
+	tracker.UpdateMarkPrice(exchange.MarkPriceData{Exchange: "Liquidation", InstrumentID: "BTCUSDT",
+		Pair: btcusdt, AssetType: assets.PerpetualSwap, MarkPrice: 8800, Timestamp: now.Add(time.Second)})
+	if len(alerts) != 1 || alerts[0].Reasons[0] != ReasonDistance ||
+		alerts[0].Risk.Position.MarkPrice != 8800 {
+		t.Fatal("Test Failed - UpdateMarkPrice() expected distance alert", alerts)
+	}
+
+	// Further breaches do not alert again until the position recovers
+	tracker.UpdateMarkPrice(exchange.MarkPriceData{Exchange: "Liquidation", InstrumentID: "BTCUSDT",
+		MarkPrice: 8500, Timestamp: now.Add(2 * time.Second)})
+	if len(alerts) != 1 {
+		t.Error("Test Failed - UpdateMarkPrice() expected a single alert", alerts)
+	}
+
+	// Polled positions older than the streamed mark keep the streamed mark
+	tracker.UpdatePositions("Liquidation", []exchange.FuturesPosition{
+		testPosition(1, 10000, 8000, 0.2),
+	}, now.Add(3*time.Second))
+	if risks := tracker.Risks(); risks[0].Position.MarkPrice != 8500 {
+		t.Error("Test Failed - UpdatePositions() expected streamed mark price", risks[0].Position)
+	}
+
+	tracker.UpdateMarkPrice(exchange.MarkPriceData{Exchange: "Liquidation", InstrumentID: "BTCUSDT",
+		MarkPrice: 10000, Timestamp: now.Add(4 * time.Second)})
+	tracker.UpdatePositions("Liquidation", []exchange.FuturesPosition{
+		testPosition(1, 10000, 8000, 0.9),
+	}, now.Add(5*time.Second))
+	if len(alerts) != 2 || alerts[1].Reasons[0] != ReasonMarginRatio {
+		t.Error("Test Failed - UpdatePositions() expected margin ratio alert", alerts)
+	}
+
+	if marks := tracker.MarkPrices(); len(marks) != 1 || marks[0].MarkPrice != 10000 {
+		t.Error("Test Failed - MarkPrices() incorrect marks", marks)
+	}
+}
+
+// testPositionsExchange returns fixed futures positions
+type testPositionsExchange struct {
+	exchange.IBotExchange
+	positions []exchange.FuturesPosition
+}
+
+func (e *testPositionsExchange) GetFuturesPositions() ([]exchange.FuturesPosition, error) {
+	return e.positions, nil
+}
+
+func TestPoll(t *testing.T) {
+	exchanges := map[string]exchange.IBotExchange{
+		"Liquidation": &testPositionsExchange{positions: []exchange.FuturesPosition{
+			testPosition(-2, 10000, 10200, 0),
+		}},
+	}
+
+	var alerts []Alert
+	var errs []error
+	tracker := NewTracker([]string{"Liquidation", "Missing"})
+	tracker.Positions = ExchangePositions(func(name string) exchange.IBotExchange {
+		return exchanges[name]
+	})
+	tracker.OnAlert = func(a Alert) {
+		alerts = append(alerts, a)
+	}
+	tracker.OnError = func(exchName string, err error) {
+		errs = append(errs, err)
+	}
+
+	tracker.Poll(now)
+	if len(alerts) != 1 || alerts[0].Risk.Position.Exchange != "Liquidation" {
+		t.Error("Test Failed - Poll() expected short position alert", alerts)
+	}
+	if len(errs) != 1 {
+		t.Error("Test Failed - Poll() expected missing exchange error", errs)
+	}
+
+	_, err := ExchangePositions(func(name string) exchange.IBotExchange {
+		return struct{ exchange.IBotExchange }{}
+	})("Spot")
+	if err != ErrPositionsUnavailable {
+		t.Error("Test Failed - ExchangePositions() expected positions unavailable", err)
+	}
+}
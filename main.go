@@ -10,28 +10,80 @@ import (
 	"runtime"
 	"strconv"
 	"syscall"
+	"time"
 
+	"github.com/thrasher-/gocryptotrader/arbitrage"
+	"github.com/thrasher-/gocryptotrader/availability"
+	"github.com/thrasher-/gocryptotrader/basis"
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/communications"
+	"github.com/thrasher-/gocryptotrader/communications/base"
+	"github.com/thrasher-/gocryptotrader/communications/smtpservice"
 	"github.com/thrasher-/gocryptotrader/config"
 	"github.com/thrasher-/gocryptotrader/currency"
 	"github.com/thrasher-/gocryptotrader/currency/forexprovider"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	"github.com/thrasher-/gocryptotrader/db"
+	"github.com/thrasher-/gocryptotrader/dust"
 	"github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/assets"
+	"github.com/thrasher-/gocryptotrader/exchanges/calendar"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-/gocryptotrader/exchanges/orders"
+	"github.com/thrasher-/gocryptotrader/exchanges/quality"
+	"github.com/thrasher-/gocryptotrader/exchanges/request"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-/gocryptotrader/execution"
+	"github.com/thrasher-/gocryptotrader/hedge"
+	"github.com/thrasher-/gocryptotrader/journal"
+	"github.com/thrasher-/gocryptotrader/liquidation"
+	"github.com/thrasher-/gocryptotrader/margin"
 	"github.com/thrasher-/gocryptotrader/portfolio"
+	"github.com/thrasher-/gocryptotrader/risk"
+	"github.com/thrasher-/gocryptotrader/statement"
+	"github.com/thrasher-/gocryptotrader/strategy"
+	"github.com/thrasher-/gocryptotrader/strategy/bridge"
+	"github.com/thrasher-/gocryptotrader/strategy/marketmaker"
+	"github.com/thrasher-/gocryptotrader/strategy/rsi"
+	"github.com/thrasher-/gocryptotrader/withdraw"
 )
 
 // Bot contains configuration, portfolio, exchange & ticker data and is the
 // overarching type across this code base.
 type Bot struct {
-	config     *config.Config
-	portfolio  *portfolio.Base
-	exchanges  []exchange.IBotExchange
-	comms      *communications.Communications
-	shutdown   chan bool
-	dryRun     bool
-	configFile string
-	dataDir    string
-	logFile    string
+	config        *config.Config
+	portfolio     *portfolio.Base
+	exchanges     []exchange.IBotExchange
+	comms         *communications.Communications
+	availability  *availability.Journal
+	health        *availability.Monitor
+	orderManager  *OrderManager
+	deposits      *DepositMonitor
+	db            db.Storage
+	journal       *journal.Journal
+	arbitrage     *arbitrage.Scanner
+	statements    *statement.Scheduler
+	portfolioSync *PortfolioSync
+	strategies    *strategy.Runner
+	basis         *basis.Monitor
+	hedging       *hedge.Assistant
+	liquidation   *liquidation.Tracker
+	calendar      *calendar.Calendar
+	calendarStop  chan struct{}
+	margin        *margin.Monitor
+	dustSweeper   *dust.Sweeper
+	addressBook   *withdraw.AddressBook
+	withdrawals   *withdraw.Approvals
+	batches       *withdraw.Manager
+	batchesStop   chan struct{}
+	execution     *execution.Manager
+	sync          *SyncManager
+	shutdown      chan bool
+	dryRun        bool
+	verbose       bool
+	configFile    string
+	dataDir       string
+	logFile       string
 }
 
 const banner = `
@@ -59,7 +111,7 @@ func main() {
 	flag.StringVar(&bot.dataDir, "datadir", common.GetDefaultDataDir(runtime.GOOS), "default data directory for GoCryptoTrader files")
 	dryrun := flag.Bool("dryrun", false, "dry runs bot, doesn't save config file")
 	version := flag.Bool("version", false, "retrieves current GoCryptoTrader version")
-	verbosity := flag.Bool("verbose", false, "increases logging verbosity for GoCryptoTrader")
+	flag.BoolVar(&bot.verbose, "verbose", false, "increases logging verbosity for GoCryptoTrader")
 
 	flag.Parse()
 
@@ -107,6 +159,7 @@ func main() {
 	common.HTTPClient = common.NewHTTPClientWithTimeout(bot.config.GlobalHTTPTimeout)
 	log.Printf("Global HTTP request timeout: %v.\n", common.HTTPClient.Timeout)
 
+	SetupMetadataCache()
 	SetupExchanges()
 	if len(bot.exchanges) == 0 {
 		log.Fatalf("No exchanges were able to be loaded. Exiting")
@@ -115,6 +168,14 @@ func main() {
 	log.Println("Starting communication mediums..")
 	bot.comms = communications.NewComm(bot.config.GetCommunicationsConfig())
 	bot.comms.GetEnabledCommunicationMediums()
+	quality.Default.Alert = dataQualityAlert
+	bot.orderManager = NewOrderManager()
+	bot.orderManager.OnEvent = func(e OrderEvent) {
+		orderEventAlert(e)
+		strategyOrderEvent(e)
+	}
+	bot.deposits = NewDepositMonitor()
+	bot.deposits.OnResume = depositMonitorAlert
 
 	log.Printf("Fiat display currency: %s.", bot.config.Currency.FiatDisplayCurrency)
 	currency.BaseCurrency = bot.config.Currency.FiatDisplayCurrency
@@ -131,6 +192,25 @@ func main() {
 		log.Fatalf("Unable to fetch forex data. Error: %s", err)
 	}
 
+	retention, _ := time.ParseDuration(bot.config.HealthMonitor.Retention)
+	bot.availability, err = availability.Open(bot.dataDir+common.GetOSPathSlash()+availability.JournalFile, retention)
+	if err != nil {
+		log.Printf("Failed to open availability journal. Err: %s", err)
+	}
+	SetupJournal()
+
+	SetupOrderThrottles()
+	SetupStrategies()
+	SetupExecution()
+
+	if bot.config.Database.Enabled {
+		SetupDatabase()
+	} else {
+		log.Println("Database support disabled.")
+	}
+	SetupWithdrawalAddressBook()
+	SetupWithdrawals()
+
 	bot.portfolio = &portfolio.Portfolio
 	bot.portfolio.SeedPortfolio(bot.config.Portfolio)
 	SeedExchangeAccountInfo(GetAllEnabledExchangeAccountInfo().Data)
@@ -157,16 +237,87 @@ func main() {
 		log.Println("HTTP RESTful Webserver support disabled.")
 	}
 
+	if bot.config.GRPC.Enabled {
+		StartRPCServer()
+	} else {
+		log.Println("gRPC server support disabled.")
+	}
+
+	go bot.orderManager.Run()
+	go bot.deposits.Run()
+	if bot.db != nil {
+		go WithdrawalHistoryRoutine()
+		go DataRetentionRoutine()
+	}
 	go portfolio.StartPortfolioWatcher()
+	go EarnBalanceUpdaterRoutine()
+	go StakingUpdaterRoutine()
+	go AccountTierUpdaterRoutine()
+	go DeprecationMonitorRoutine()
+	keyExpiryInterval, _ := time.ParseDuration(bot.config.APIKeyExpiry.Interval)
+	go APIKeyExpiryRoutine(keyExpiryInterval, bot.config.APIKeyExpiry.AlertDays)
+	SetupHealthMonitor()
+	go ExchangeWatchdogRoutine(exchangeWatchdogInterval)
+	SetupSyncManager()
+
+	SetupCandleBootstrap()
+	SetupArbitrage()
+	SetupStatements()
+	SetupPortfolioSync()
+	SetupBasis()
+	SetupHedging()
+	SetupLiquidation()
+	SetupCalendar()
+	SetupMarginMonitor()
+	SetupDustSweep()
 
 	go TickerUpdaterRoutine()
 	go OrderbookUpdaterRoutine()
-	go WebsocketRoutine(*verbosity)
+	go WebsocketRoutine(bot.verbose)
 
 	<-bot.shutdown
 	Shutdown()
 }
 
+// SetupOrderThrottles applies the configured strategy order throttle limits
+// to the order manager
+func SetupOrderThrottles() {
+	for name, t := range bot.config.OrderThrottles {
+		err := orders.SetThrottle(name, orders.ThrottleLimits{
+			MaxOrdersPerMinute: t.MaxOrdersPerMinute,
+			MaxOpenOrders:      t.MaxOpenOrders,
+			MinPairInterval:    t.MinPairInterval,
+		})
+		if err != nil {
+			log.Printf("Failed to set order throttle for strategy %s. Err: %s", name, err)
+			continue
+		}
+		log.Printf("Order throttle for strategy %s: %d orders per minute, %d open orders, %v between orders on a pair.\n",
+			name, t.MaxOrdersPerMinute, t.MaxOpenOrders, t.MinPairInterval)
+	}
+}
+
+// metadataCacheDir is the data directory folder of the metadata cache
+const metadataCacheDir = "metadatacache"
+
+// SetupMetadataCache caches large static exchange responses in the data
+// directory when enabled in the config
+func SetupMetadataCache() {
+	cfg := bot.config.MetadataCache
+	if !cfg.Enabled {
+		return
+	}
+	maxAge, _ := time.ParseDuration(cfg.MaxAge)
+	dir := bot.dataDir + common.GetOSPathSlash() + metadataCacheDir
+	cache, err := request.NewDiskCache(dir, int64(cfg.MaxSizeMB)<<20, maxAge)
+	if err != nil {
+		log.Printf("Failed to open metadata cache. Err: %s", err)
+		return
+	}
+	request.SetMetadataCache(cache)
+	log.Printf("Metadata cache: %s, %dMB, revalidated after %v.\n", dir, cfg.MaxSizeMB, maxAge)
+}
+
 // AdjustGoMaxProcs adjusts the maximum processes that the CPU can handle.
 func AdjustGoMaxProcs() {
 	log.Println("Adjusting bot runtime performance..")
@@ -219,6 +370,26 @@ func Shutdown() {
 		}
 	}
 
+	if bot.calendarStop != nil {
+		close(bot.calendarStop)
+	}
+
+	if bot.batchesStop != nil {
+		close(bot.batchesStop)
+	}
+
+	if bot.journal != nil {
+		if err := bot.journal.EndSession(); err != nil {
+			log.Printf("Unable to end trade journal session. Err: %s", err)
+		}
+	}
+
+	if bot.db != nil {
+		if err := bot.db.Close(); err != nil {
+			log.Printf("Unable to close database. Err: %s", err)
+		}
+	}
+
 	log.Println("Exiting.")
 
 	if logFileHandle != nil {
@@ -226,3 +397,671 @@ func Shutdown() {
 	}
 	os.Exit(0)
 }
+
+// SetupArbitrage starts scanning the exchanges for arbitrage opportunities
+// when enabled in the config
+func SetupArbitrage() {
+	cfg := bot.config.Arbitrage
+	if !cfg.Enabled {
+		log.Println("Arbitrage scanner disabled.")
+		return
+	}
+	interval, _ := time.ParseDuration(cfg.ScanInterval)
+	maxAge, _ := time.ParseDuration(cfg.MaxAge)
+	exchanges := func() []exchange.IBotExchange { return bot.exchanges }
+
+	bot.arbitrage = arbitrage.NewScanner(cfg.Amount, cfg.MinProfitPercent, maxAge,
+		arbitrage.ExchangeFees(exchanges, cfg.DefaultTakerFeePercent/100, arbitrage.DefaultFeeCacheDuration))
+	go bot.arbitrage.Run(exchanges, interval, nil)
+	log.Printf("Arbitrage scanner: %v of each pair every %v, minimum profit %v%%.\n",
+		cfg.Amount, interval, cfg.MinProfitPercent)
+}
+
+// SetupStatements publishes the monthly account statements of the enabled
+// exchanges, valued in the fiat display currency
+func SetupStatements() {
+	cfg := bot.config.Statements
+	if !cfg.Enabled {
+		log.Println("Account statements disabled.")
+		return
+	}
+	dir := cfg.Directory
+	if dir == "" {
+		dir = bot.dataDir + common.GetOSPathSlash() + "statements"
+	}
+	exchanges := func() []exchange.IBotExchange { return bot.exchanges }
+	base := bot.config.Currency.FiatDisplayCurrency
+
+	bot.statements = &statement.Scheduler{
+		Generator: &statement.Generator{
+			BaseCurrency: base,
+			Exchanges:    exchanges,
+			Journal:      bot.journal,
+			Value:        statement.TickerValuer(exchanges, base),
+		},
+		Locale:    bot.config.GetDisplayLocale(),
+		Formats:   cfg.Formats,
+		Directory: dir,
+	}
+	if cfg.Email {
+		mailer := new(smtpservice.SMTPservice)
+		mailer.Setup(bot.config.GetCommunicationsConfig())
+		bot.statements.Mailer = mailer
+	}
+	go bot.statements.Run(statement.DefaultCheckInterval, nil)
+	log.Printf("Account statements: %s stored in %s, emailed: %v.\n",
+		common.JoinStrings(cfg.Formats, ", "), dir, cfg.Email)
+}
+
+// SetupWithdrawalAddressBook loads the withdrawal address book from the
+// database when enabled in the config, counting the withdrawals recorded
+// within the monthly window against the limits of the addresses
+func SetupWithdrawalAddressBook() {
+	cfg := bot.config.WithdrawalAddressBook
+	if !cfg.Enabled || bot.db == nil {
+		log.Println("Withdrawal address book disabled.")
+		return
+	}
+	book, err := withdraw.NewAddressBook(bot.db, cfg.EncryptionKey)
+	if err != nil {
+		log.Printf("Failed to load withdrawal address book. Err: %s", err)
+		return
+	}
+
+	now := time.Now()
+	history, err := bot.db.Withdrawals("", now.Add(-withdraw.MonthlyWindow), time.Time{})
+	if err != nil {
+		log.Printf("Failed to load withdrawal history, address limits only count new withdrawals. Err: %s", err)
+	}
+	for i := range history {
+		book.Record(history[i].Exchange, pair.CurrencyItem(history[i].Currency),
+			history[i].Address, history[i].Amount, history[i].Timestamp)
+	}
+	bot.addressBook = book
+	log.Printf("Withdrawal address book: %d addresses loaded.\n", len(book.List()))
+}
+
+// SetupWithdrawals starts the withdrawal approval workflow when enabled in the
+// config, restoring the withdrawals of the attempts recorded in the database.
+// Withdrawals are sent to whitelisted addresses and, when the address book is
+// enabled, to the addresses it authorises.
+func SetupWithdrawals() {
+	cfg := bot.config.Withdrawals
+	if !cfg.Enabled || bot.db == nil {
+		log.Println("Withdrawals disabled.")
+		return
+	}
+	attempts, err := bot.db.WithdrawalAttempts("", time.Time{}, time.Time{})
+	if err != nil {
+		log.Printf("Failed to load withdrawal attempts. Err: %s", err)
+		return
+	}
+
+	approvals := withdraw.ApprovalConfig{DailyLimits: cfg.DailyLimits}
+	for _, entry := range cfg.Whitelist {
+		approvals.Whitelist = append(approvals.Whitelist, withdraw.WhitelistEntry{
+			Exchange: entry.Exchange,
+			Currency: pair.CurrencyItem(entry.Currency),
+			Address:  entry.Address,
+		})
+	}
+	bot.withdrawals = withdraw.NewApprovals(approvals, bot.db, GetExchangeByName)
+	bot.withdrawals.Addresses = bot.addressBook
+	bot.withdrawals.Load(attempts)
+	bot.withdrawals.OnUpdate = func(w withdraw.Withdrawal) {
+		message := fmt.Sprintf("Withdrawal %d of %v %s from %s to %s %s.",
+			w.ID, w.Amount, w.Currency, w.Exchange, w.Address, w.Status)
+		if w.TxID != "" {
+			message += fmt.Sprintf(" TxID: %s", w.TxID)
+		}
+		if w.Reason != "" {
+			message += fmt.Sprintf(" Reason: %s", w.Reason)
+		}
+		log.Println(message)
+		bot.comms.PushEvent(base.Event{Type: "withdrawal", TradeDetails: message})
+	}
+	log.Printf("Withdrawals: %d whitelisted addresses, %d withdrawals loaded.\n",
+		len(approvals.Whitelist), len(bot.withdrawals.Withdrawals()))
+	SetupWithdrawalBatching()
+}
+
+// SetupWithdrawalBatching starts queueing requested withdrawals and
+// consolidating them into batches when enabled in the config. Batches are
+// requested through the withdrawal approval workflow once large enough while
+// the currency's network fee, taken from the chain's fee API, is within its
+// threshold, or once their maximum delay passes.
+func SetupWithdrawalBatching() {
+	cfg := bot.config.Withdrawals.Batching
+	if !cfg.Enabled {
+		log.Println("Withdrawal batching disabled.")
+		return
+	}
+
+	maxDelay, _ := time.ParseDuration(cfg.MaxDelay)
+	manager, err := withdraw.NewManager(withdraw.Config{
+		MinBatchAmount: cfg.MinBatchAmount,
+		MaxDelay:       maxDelay,
+	}, bot.withdrawals)
+	if err != nil {
+		log.Printf("Failed to start withdrawal batching. Err: %s", err)
+		return
+	}
+	fees := withdraw.NewFeeOracle()
+	for currency, threshold := range cfg.FeeThresholds {
+		fees.SetThreshold(pair.CurrencyItem(currency), threshold)
+	}
+	manager.FeeCondition = fees.Condition
+	manager.OnResult = func(r withdraw.Result) {
+		if r.Err != nil {
+			log.Printf("Withdrawal batch of %v %s from %s to %s not requested. Err: %s",
+				r.Batch.Amount, r.Batch.Currency, r.Batch.Exchange, r.Batch.Address, r.Err)
+		}
+	}
+
+	interval, _ := time.ParseDuration(cfg.ProcessInterval)
+	bot.batches = manager
+	bot.batchesStop = make(chan struct{})
+	go bot.batches.Run(interval, bot.batchesStop)
+	log.Printf("Withdrawal batching: every %v, minimum batch %v, maximum delay %v, %d fee thresholds.\n",
+		interval, cfg.MinBatchAmount, maxDelay, len(cfg.FeeThresholds))
+}
+
+// SetupPortfolioSync starts syncing the portfolio with the balances of the
+// enabled exchanges and recording its valuations when enabled in the config
+func SetupPortfolioSync() {
+	cfg := bot.config.PortfolioSync
+	if !cfg.Enabled {
+		log.Println("Portfolio sync disabled.")
+		return
+	}
+	base := cfg.BaseCurrency
+	if base == "" {
+		base = bot.config.Currency.FiatDisplayCurrency
+	}
+	path := bot.dataDir + common.GetOSPathSlash() + portfolio.SnapshotFile
+	snapshots, err := portfolio.OpenSnapshots(path, cfg.MaxSnapshots)
+	if err != nil {
+		log.Printf("Failed to open portfolio snapshots, valuations will not be persisted. Err: %s", err)
+		snapshots = portfolio.NewSnapshots(cfg.MaxSnapshots)
+	}
+	exchanges := func() []exchange.IBotExchange { return bot.exchanges }
+
+	bot.portfolioSync = NewPortfolioSync(bot.portfolio, base,
+		portfolio.PriceFunc(statement.TickerValuer(exchanges, base)), snapshots)
+	bot.portfolioSync.Interval, _ = time.ParseDuration(cfg.Interval)
+	go bot.portfolioSync.Run()
+	log.Printf("Portfolio sync: valued in %s every %v.\n", base, bot.portfolioSync.Interval)
+}
+
+// SetupStrategies starts passing the exchanges' market data and order events
+// to the configured strategies when enabled in the config, serving them to
+// strategies connecting over the strategy bridge when it is enabled
+func SetupStrategies() {
+	cfg := bot.config.Strategies
+	if !cfg.Enabled {
+		log.Println("Strategies disabled.")
+		return
+	}
+	interval, _ := time.ParseDuration(cfg.CandleInterval)
+	bot.strategies = strategy.NewRunner(kline.Interval(interval))
+	bot.strategies.OnError = func(name string, err error) {
+		log.Printf("Strategy %s error: %s", name, err)
+	}
+
+	for _, s := range cfg.RSI {
+		exch := GetExchangeByName(s.Exchange)
+		if exch == nil {
+			log.Printf("Strategy %s not started, exchange %s is not loaded.", s.Name, s.Exchange)
+			continue
+		}
+		r, err := rsi.New(s.Name, exch, pair.NewCurrencyPairFromString(s.Pair), rsi.Config{
+			Period:     s.Period,
+			Oversold:   s.Oversold,
+			Overbought: s.Overbought,
+			OrderSize:  s.OrderSize,
+		}, bot.orderManager)
+		if err == nil {
+			err = bot.strategies.Add(r, exch.GetName())
+		}
+		if err != nil {
+			log.Printf("Strategy %s not started. Err: %s", s.Name, err)
+		}
+	}
+
+	for _, s := range cfg.MarketMaker {
+		exch := GetExchangeByName(s.Exchange)
+		if exch == nil {
+			log.Printf("Strategy %s not started, exchange %s is not loaded.", s.Name, s.Exchange)
+			continue
+		}
+		m, err := marketmaker.NewStrategy(s.Name, exch, pair.NewCurrencyPairFromString(s.Pair), marketmaker.Config{
+			Spread:           s.Spread,
+			OrderSize:        s.OrderSize,
+			MaxInventory:     s.MaxInventory,
+			SkewFactor:       s.SkewFactor,
+			RequoteThreshold: s.RequoteThreshold,
+		}, risk.Limits{
+			MaxOrderAmount:   s.MaxOrderAmount,
+			MaxOrderNotional: s.MaxOrderNotional,
+			MaxPosition:      s.MaxPosition,
+			MaxOpenOrders:    s.MaxOpenOrders,
+		})
+		if err == nil {
+			err = bot.strategies.Add(m, exch.GetName())
+		}
+		if err != nil {
+			log.Printf("Strategy %s not started. Err: %s", s.Name, err)
+		}
+	}
+	log.Printf("Strategies: %s running on %v candles.\n",
+		common.JoinStrings(bot.strategies.Strategies(), ", "), interval)
+
+	if cfg.Bridge.Enabled {
+		server := bridge.NewServer(bot.strategies, bot.orderManager, GetExchangeByName, cfg.Bridge.Token)
+		go func() {
+			err := server.ListenAndServe(cfg.Bridge.ListenAddress)
+			if err != nil {
+				log.Printf("Strategy bridge stopped. Err: %s", err)
+			}
+		}()
+		log.Printf("Strategy bridge listening on ws://%s/\n", cfg.Bridge.ListenAddress)
+	}
+}
+
+// SetupBasis starts monitoring the basis of the configured spot and futures
+// spreads, executing cash-and-carry trades through the order manager when
+// automatic execution is enabled in the config
+func SetupBasis() {
+	cfg := bot.config.Basis
+	if !cfg.Enabled {
+		log.Println("Basis monitor disabled.")
+		return
+	}
+
+	var spreads []basis.Spread
+	for _, s := range cfg.Spreads {
+		p := pair.NewCurrencyPairFromString(s.Pair)
+		spread := basis.Spread{
+			Spot: basis.Market{Exchange: s.SpotExchange, Pair: p, AssetType: assets.Spot},
+			Future: basis.Market{Exchange: s.FuturesExchange, Pair: p, AssetType: s.FuturesAssetType,
+				InstrumentID: s.InstrumentID},
+		}
+		if s.FuturesAssetType == assets.Futures {
+			expiry, err := basisContractExpiry(s.FuturesExchange, s.InstrumentID)
+			if err != nil {
+				log.Printf("Basis spread %s not monitored. Err: %s", spread, err)
+				continue
+			}
+			spread.Future.Expiry = expiry
+		}
+		spreads = append(spreads, spread)
+	}
+
+	bot.basis = basis.NewMonitor(spreads, cfg.MinAnnualisedPercent)
+	bot.basis.Funding = basis.ExchangeFunding(GetExchangeByName)
+	bot.basis.AutoExecute = cfg.AutoExecute
+	bot.basis.Amount = cfg.Amount
+	bot.basis.Executor = &basis.Executor{Orderer: bot.orderManager, GetExchange: GetExchangeByName}
+	bot.basis.OnOpportunity = func(b basis.Basis) {
+		log.Printf("Basis %s: %.4f%% (%.2f%% annualised).", b.Spread, b.BasisPercent, b.AnnualisedPercent)
+	}
+	bot.basis.OnExecution = func(r basis.Result) {
+		if r.Err != nil {
+			log.Printf("Cash and carry of %v %s failed. Spot placed: %v, unwound: %v. Err: %s",
+				r.Amount, r.Basis.Spread, r.SpotPlaced, r.Unwound, r.Err)
+			return
+		}
+		log.Printf("Cash and carry of %v %s executed at %.2f%% annualised.",
+			r.Amount, r.Basis.Spread, r.Basis.AnnualisedPercent)
+	}
+
+	interval, _ := time.ParseDuration(cfg.ScanInterval)
+	go bot.basis.Run(interval, nil)
+	log.Printf("Basis monitor: %d spreads every %v, minimum carry %v%% annualised.\n",
+		len(spreads), interval, cfg.MinAnnualisedPercent)
+}
+
+// SetupHedging starts the hedging assistant on the configured holdings,
+// placing its suggested hedges through the order manager when automatic
+// execution is enabled in the config
+func SetupHedging() {
+	cfg := bot.config.Hedging
+	if !cfg.Enabled {
+		log.Println("Hedging assistant disabled.")
+		return
+	}
+
+	var holdings []hedge.Holding
+	for _, h := range cfg.Holdings {
+		holding := hedge.Holding{
+			Asset: hedge.Market{Exchange: h.Exchange, Pair: pair.NewCurrencyPairFromString(h.Pair),
+				AssetType: assets.Spot},
+			Amount: h.Amount,
+		}
+		for _, i := range h.Hedges {
+			holding.Hedges = append(holding.Hedges, hedge.Market{Exchange: i.Exchange,
+				Pair: pair.NewCurrencyPairFromString(i.Pair), AssetType: i.AssetType,
+				InstrumentID: i.InstrumentID})
+		}
+		holdings = append(holdings, holding)
+	}
+
+	candleInterval, _ := time.ParseDuration(cfg.CandleInterval)
+	bot.hedging = hedge.NewAssistant(holdings)
+	bot.hedging.Candles = hedge.ExchangeCandles(GetExchangeByName)
+	bot.hedging.Balances = hedge.ExchangeBalances(GetExchangeByName)
+	bot.hedging.Interval = kline.Interval(candleInterval)
+	bot.hedging.Window = cfg.Window
+	bot.hedging.MinCorrelation = cfg.MinCorrelation
+	bot.hedging.TargetExposure = cfg.TargetExposure
+	bot.hedging.Tolerance = cfg.Tolerance
+	bot.hedging.AutoExecute = cfg.AutoExecute
+	bot.hedging.Orderer = bot.orderManager
+	bot.hedging.GetExchange = GetExchangeByName
+	bot.hedging.OnSuggestion = func(s hedge.Suggestion) {
+		log.Printf("Hedge %s: %s %v %s (correlation %.2f, beta %.2f), exposure %.2f targeting %.2f.",
+			s.Holding, s.Side, s.Amount, s.Hedge, s.Stats.Correlation, s.Stats.Beta,
+			s.Exposure, s.TargetExposure)
+	}
+	bot.hedging.OnExecution = func(r hedge.Result) {
+		if r.Err != nil {
+			log.Printf("Hedge %s: %s %v %s failed. Err: %s", r.Suggestion.Holding,
+				r.Suggestion.Side, r.Suggestion.Amount, r.Suggestion.Hedge, r.Err)
+			return
+		}
+		log.Printf("Hedge %s: %s %v %s placed.", r.Suggestion.Holding,
+			r.Suggestion.Side, r.Suggestion.Amount, r.Suggestion.Hedge)
+	}
+	bot.hedging.OnError = func(h hedge.Holding, err error) {
+		log.Printf("Hedge %s not suggested. Err: %s", h.Asset, err)
+	}
+
+	interval, _ := time.ParseDuration(cfg.ScanInterval)
+	go bot.hedging.Run(interval, nil)
+	log.Printf("Hedging assistant: %d holdings every %v, minimum correlation %v over %d %v candles.\n",
+		len(holdings), interval, cfg.MinCorrelation, cfg.Window, candleInterval)
+}
+
+// SetupLiquidation starts polling the futures positions of the configured
+// exchanges, or of every enabled exchange with authenticated API support which
+// reports positions, and notifies enabled communication mediums when a
+// position nears liquidation
+func SetupLiquidation() {
+	cfg := bot.config.Liquidation
+	if !cfg.Enabled {
+		log.Println("Liquidation monitor disabled.")
+		return
+	}
+
+	exchanges := cfg.Exchanges
+	if len(exchanges) == 0 {
+		for _, exch := range bot.exchanges {
+			if exch == nil || !exch.IsEnabled() || !exch.GetAuthenticatedAPISupport() {
+				continue
+			}
+			if _, ok := exch.(exchange.FuturesPositionExchange); ok {
+				exchanges = append(exchanges, exch.GetName())
+			}
+		}
+	}
+
+	bot.liquidation = liquidation.NewTracker(exchanges)
+	bot.liquidation.Positions = liquidation.ExchangePositions(GetExchangeByName)
+	bot.liquidation.MaxMarginRatio = cfg.MaxMarginRatio
+	bot.liquidation.MinDistancePercent = cfg.MinDistancePercent
+	bot.liquidation.OnAlert = func(a liquidation.Alert) {
+		message := "Liquidation risk: " + a.String()
+		log.Println(message)
+		bot.comms.PushEvent(base.Event{Type: "liquidation_risk", TradeDetails: message})
+	}
+	bot.liquidation.OnError = func(exchName string, err error) {
+		log.Printf("Liquidation monitor %s positions not updated. Err: %s", exchName, err)
+	}
+
+	interval, _ := time.ParseDuration(cfg.PollInterval)
+	go bot.liquidation.Run(interval, nil)
+	log.Printf("Liquidation monitor: %s every %v, maximum margin ratio %v, minimum distance %v%%.\n",
+		common.JoinStrings(exchanges, ", "), interval, cfg.MaxMarginRatio, cfg.MinDistancePercent)
+}
+
+// SetupCalendar starts tracking the dated futures contracts of the configured
+// exchanges, or of every enabled exchange listing futures contracts, notifying
+// enabled communication mediums of roll reminders and, when automatic rolling
+// is enabled, rolling positions through the risk checked roll path
+func SetupCalendar() {
+	cfg := bot.config.Calendar
+	if !cfg.Enabled {
+		log.Println("Futures calendar disabled.")
+		return
+	}
+
+	exchanges := cfg.Exchanges
+	if len(exchanges) == 0 {
+		for _, exch := range bot.exchanges {
+			if exch == nil || !exch.IsEnabled() {
+				continue
+			}
+			if _, ok := exch.(exchange.FuturesExchange); ok {
+				exchanges = append(exchanges, exch.GetName())
+			}
+		}
+	}
+
+	rollWindow, _ := time.ParseDuration(cfg.RollWindow)
+	bot.calendar = calendar.NewCalendar(rollWindow)
+	bot.calendar.AutoRoll = cfg.AutoRoll
+	bot.calendar.Exchanges = exchanges
+	bot.calendar.GetExchange = GetExchangeByName
+	bot.calendar.Positions = calendar.ExchangePositions(GetExchangeByName)
+	bot.calendar.Limits = &risk.Limits{
+		MaxOrderAmount:   cfg.MaxOrderAmount,
+		MaxOrderNotional: cfg.MaxOrderNotional,
+		MaxPosition:      cfg.MaxPosition,
+	}
+	bot.calendar.OnReminder = func(r calendar.Reminder) {
+		message := fmt.Sprintf("Futures roll reminder: %s %s expires in %v",
+			r.Contract.Exchange, r.Contract.InstrumentID, r.TimeToExpiry.Round(time.Minute))
+		if r.Next.InstrumentID != "" {
+			message += ", next contract " + r.Next.InstrumentID
+		}
+		log.Println(message)
+		bot.comms.PushEvent(base.Event{Type: "futures_roll_reminder", TradeDetails: message})
+	}
+	bot.calendar.OnRoll = func(r calendar.RollResult) {
+		message := fmt.Sprintf("Futures roll: %s %s %v %s to %s",
+			r.Position.Exchange, r.Position.Side, r.Position.Amount, r.From.InstrumentID, r.To.InstrumentID)
+		if r.Err != nil {
+			message = fmt.Sprintf("%s failed, closed %t. Err: %s", message, r.Closed, r.Err)
+		}
+		log.Println(message)
+		bot.comms.PushEvent(base.Event{Type: "futures_roll", TradeDetails: message})
+	}
+	bot.calendar.OnError = func(exchName string, err error) {
+		log.Printf("Futures calendar %s not updated. Err: %s", exchName, err)
+	}
+
+	interval, _ := time.ParseDuration(cfg.PollInterval)
+	bot.calendarStop = make(chan struct{})
+	go bot.calendar.Run(interval, bot.calendarStop)
+	log.Printf("Futures calendar: %s every %v, roll window %v, automatic roll %t.\n",
+		common.JoinStrings(exchanges, ", "), interval, rollWindow, cfg.AutoRoll)
+}
+
+// SetupMarginMonitor starts polling the futures positions and isolated margin
+// accounts of the configured exchanges, or of every enabled exchange with
+// authenticated API support which reports either, executing the configured
+// rules as margin ratios rise and notifying enabled communication mediums of
+// each action taken
+func SetupMarginMonitor() {
+	cfg := bot.config.MarginMonitor
+	if !cfg.Enabled {
+		log.Println("Margin monitor disabled.")
+		return
+	}
+
+	exchanges := cfg.Exchanges
+	if len(exchanges) == 0 {
+		for _, exch := range bot.exchanges {
+			if exch == nil || !exch.IsEnabled() || !exch.GetAuthenticatedAPISupport() {
+				continue
+			}
+			_, hasPositions := exch.(exchange.FuturesPositionExchange)
+			_, hasLevels := exch.(exchange.MarginLevelExchange)
+			if hasPositions || hasLevels {
+				exchanges = append(exchanges, exch.GetName())
+			}
+		}
+	}
+
+	rules := make([]margin.Rule, len(cfg.Rules))
+	for i, r := range cfg.Rules {
+		rules[i] = margin.Rule{
+			MarginRatio:        r.MarginRatio,
+			Action:             r.Action,
+			ReduceFraction:     r.ReduceFraction,
+			CollateralCurrency: r.CollateralCurrency,
+			CollateralAmount:   r.CollateralAmount,
+		}
+	}
+
+	bot.margin = margin.NewMonitor(exchanges, rules, GetExchangeByName)
+	if cfg.Cooldown != "" {
+		bot.margin.Cooldown, _ = time.ParseDuration(cfg.Cooldown)
+	}
+	bot.margin.OnAction = func(e margin.Event) {
+		message := "Margin monitor: " + e.String()
+		log.Println(message)
+		bot.comms.PushEvent(base.Event{Type: "margin_action", TradeDetails: message})
+	}
+	bot.margin.OnError = func(exchName string, err error) {
+		log.Printf("Margin monitor %s accounts not updated. Err: %s", exchName, err)
+	}
+
+	interval, _ := time.ParseDuration(cfg.PollInterval)
+	go bot.margin.Run(interval, nil)
+	log.Printf("Margin monitor: %s every %v with %d rules.\n",
+		common.JoinStrings(exchanges, ", "), interval, len(rules))
+}
+
+// SetupHealthMonitor starts periodically checking the system status of
+// enabled exchanges, pausing the order submission of degraded exchanges until
+// they recover
+func SetupHealthMonitor() {
+	cfg := bot.config.HealthMonitor
+	bot.health = availability.NewMonitor(cfg.FailureThreshold)
+	bot.health.OnChange = exchangeHealthChanged
+
+	interval, _ := time.ParseDuration(cfg.Interval)
+	timeout, _ := time.ParseDuration(cfg.Timeout)
+	go HealthMonitorRoutine(interval, timeout)
+}
+
+// exchangeHealthChanged pauses or resumes the order submission of an exchange
+// as it becomes degraded or recovers and alerts the communication mediums
+func exchangeHealthChanged(s availability.Status) {
+	var message string
+	if s.Degraded {
+		exchange.PauseOrderSubmission(s.Exchange, "exchange degraded")
+		message = fmt.Sprintf("%s degraded after %d failed health checks, order submission paused. Error: %s",
+			s.Exchange, s.ConsecutiveFailures, s.LastError)
+	} else {
+		exchange.ResumeOrderSubmission(s.Exchange)
+		message = fmt.Sprintf("%s recovered, order submission resumed", s.Exchange)
+	}
+	log.Println(message)
+	bot.comms.PushEvent(base.Event{Type: "exchange_health", TradeDetails: message})
+}
+
+// SetupDustSweep starts periodically sweeping the dust balances of enabled
+// exchanges with authenticated API support, topping them up through the order
+// manager on exchanges without dust conversion when enabled in the config
+func SetupDustSweep() {
+	cfg := bot.config.DustSweep
+	if !cfg.Enabled {
+		log.Println("Dust sweep disabled.")
+		return
+	}
+
+	bot.dustSweeper = &dust.Sweeper{
+		Exchanges: func() []exchange.IBotExchange {
+			var exchanges []exchange.IBotExchange
+			for _, exch := range bot.exchanges {
+				if exch != nil && exch.IsEnabled() && exch.GetAuthenticatedAPISupport() {
+					exchanges = append(exchanges, exch)
+				}
+			}
+			return exchanges
+		},
+		Overrides:     exchangeDustThresholds,
+		Orderer:       bot.orderManager,
+		QuoteCurrency: cfg.QuoteCurrency,
+		TopUp:         cfg.TopUp,
+		OnSweep: func(r dust.Result) {
+			switch {
+			case r.Err == dust.ErrNoDust:
+			case r.Conversion != nil:
+				log.Printf("Dust sweep %s: converted %s to %v %s.", r.Exchange,
+					common.JoinStrings(r.Conversion.Converted, ","), r.Conversion.Amount, r.Conversion.Target)
+			case r.Err != nil:
+				log.Printf("Dust sweep %s failed. Skipped: %v. Err: %s", r.Exchange, r.Skipped, r.Err)
+			default:
+				log.Printf("Dust sweep %s: %d balances topped up and sold.", r.Exchange, len(r.Dust))
+			}
+		},
+	}
+	if cfg.Exclude != "" {
+		bot.dustSweeper.Exclude = common.SplitStrings(cfg.Exclude, ",")
+	}
+
+	interval, _ := time.ParseDuration(cfg.Interval)
+	go bot.dustSweeper.Run(interval, nil)
+	log.Printf("Dust sweep: every %v into %s, top up %v.\n", interval, cfg.QuoteCurrency, cfg.TopUp)
+}
+
+// SetupExecution creates the execution manager slicing parent orders started
+// over gRPC into child orders placed through the order manager
+func SetupExecution() {
+	bot.execution = execution.NewManager(bot.orderManager, GetExchangeByName, ticker.GetTicker)
+	bot.execution.OnFinish = func(e execution.Execution) {
+		message := fmt.Sprintf("%s execution %d on %s %s %s %v finished %s. Filled %v at %v.",
+			e.Params.Algorithm, e.ID, e.Params.Exchange, e.Params.Pair.Pair(), e.Params.Side,
+			e.Params.Amount, e.Status, e.FilledAmount, e.AveragePrice)
+		if e.Err != nil {
+			message += fmt.Sprintf(" Err: %s", e.Err)
+		}
+		log.Println(message)
+		bot.comms.PushEvent(base.Event{Type: "execution", TradeDetails: message})
+	}
+}
+
+// exchangeDustThresholds returns the configured dust thresholds of an exchange
+func exchangeDustThresholds(exchName string) map[string]float64 {
+	exchCfg, err := bot.config.GetExchangeConfig(exchName)
+	if err != nil {
+		return nil
+	}
+	return exchCfg.DustThresholds
+}
+
+// basisContractExpiry returns the expiry of a dated futures contract listed on
+// an exchange
+func basisContractExpiry(exchName, instrumentID string) (time.Time, error) {
+	exch := GetExchangeByName(exchName)
+	if exch == nil {
+		return time.Time{}, fmt.Errorf("%s exchange not loaded", exchName)
+	}
+	f, ok := exch.(exchange.FuturesExchange)
+	if !ok {
+		return time.Time{}, basis.ErrFuturesNotSupported
+	}
+	contracts, err := f.GetFuturesContracts()
+	if err != nil {
+		return time.Time{}, err
+	}
+	for i := range contracts {
+		if contracts[i].InstrumentID == instrumentID {
+			return contracts[i].Expiry, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%s futures contract %s not found", exchName, instrumentID)
+}
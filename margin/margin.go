@@ -0,0 +1,504 @@
+// Package margin monitors the margin ratio of futures positions and isolated
+// margin accounts, estimating position ratios from streamed mark prices
+// between polls, and executes configured defensive actions as ratios rise
+// towards liquidation: alerting, reducing positions with reduce only orders
+// or transferring collateral from the spot account.
+package margin
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+)
+
+// Actions a rule may take
+const (
+	ActionAlert         = "alert"
+	ActionReduce        = "reduce"
+	ActionAddCollateral = "addCollateral"
+)
+
+// DefaultPollInterval is how often accounts are polled when no interval is
+// given
+const DefaultPollInterval = time.Minute
+
+// Errors returned when an action cannot be taken
+var (
+	ErrMarginUnavailable     = errors.New("exchange does not report futures positions or margin levels")
+	ErrReduceUnsupported     = errors.New("exchange does not support reduce only futures orders")
+	ErrReduceMarginAccount   = errors.New("isolated margin accounts cannot be reduced")
+	ErrCollateralUnsupported = errors.New("exchange does not support collateral transfers")
+)
+
+// Rule is a defensive action taken when an account's margin ratio reaches
+// MarginRatio. Reduce closes ReduceFraction of a position with a reduce only
+// market order and AddCollateral transfers CollateralAmount of
+// CollateralCurrency, the quote currency of the account's pair when empty,
+// from the spot account into the account's margin.
+type Rule struct {
+	MarginRatio        float64 `json:"marginRatio"`
+	Action             string  `json:"action"`
+	ReduceFraction     float64 `json:"reduceFraction,omitempty"`
+	CollateralCurrency string  `json:"collateralCurrency,omitempty"`
+	CollateralAmount   float64 `json:"collateralAmount,omitempty"`
+}
+
+// Validate checks the rule's margin ratio and action parameters
+func (r *Rule) Validate() error {
+	if r.MarginRatio <= 0 || r.MarginRatio >= 1 {
+		return fmt.Errorf("margin ratio %v must be between 0 and 1", r.MarginRatio)
+	}
+	switch r.Action {
+	case ActionAlert:
+	case ActionReduce:
+		if r.ReduceFraction <= 0 || r.ReduceFraction > 1 {
+			return fmt.Errorf("reduce fraction %v must be above 0 and at most 1", r.ReduceFraction)
+		}
+	case ActionAddCollateral:
+		if r.CollateralAmount <= 0 {
+			return fmt.Errorf("collateral amount %v must be positive", r.CollateralAmount)
+		}
+	default:
+		return fmt.Errorf("unknown action %q", r.Action)
+	}
+	return nil
+}
+
+func (r Rule) String() string {
+	switch r.Action {
+	case ActionReduce:
+		return fmt.Sprintf("reduce by %v%% at margin ratio %v", r.ReduceFraction*100, r.MarginRatio)
+	case ActionAddCollateral:
+		currency := r.CollateralCurrency
+		if currency == "" {
+			currency = "quote currency"
+		}
+		return fmt.Sprintf("add %v %s collateral at margin ratio %v", r.CollateralAmount, currency, r.MarginRatio)
+	}
+	return fmt.Sprintf("%s at margin ratio %v", r.Action, r.MarginRatio)
+}
+
+// Account is a futures position or an isolated margin account, whose Amount,
+// MarkPrice and LiquidationPrice are zero. MarginRatio is the maintenance
+// margin as a fraction of the account's margin, liquidated once it reaches
+// one, and Estimated is set when it has been estimated from a mark price
+// streamed since the account was polled.
+type Account struct {
+	Exchange         string            `json:"exchange"`
+	InstrumentID     string            `json:"instrumentID"`
+	Pair             pair.CurrencyPair `json:"pair"`
+	AssetType        string            `json:"assetType"`
+	Amount           float64           `json:"amount"`
+	MarkPrice        float64           `json:"markPrice"`
+	LiquidationPrice float64           `json:"liquidationPrice"`
+	MarginRatio      float64           `json:"marginRatio"`
+	Estimated        bool              `json:"estimated"`
+	UpdateTime       time.Time         `json:"updateTime"`
+
+	polledMark  float64
+	polledRatio float64
+}
+
+// IsPosition returns whether the account is a futures position
+func (a *Account) IsPosition() bool {
+	return a.Amount != 0
+}
+
+func (a Account) String() string {
+	if a.InstrumentID != "" {
+		return a.Exchange + " " + a.InstrumentID
+	}
+	return fmt.Sprintf("%s %s %s", a.Exchange, a.AssetType, a.Pair.Pair())
+}
+
+// key identifies an account by its instrument, by pair and asset type when
+// the instrument ID is unknown
+func (a *Account) key() string {
+	return instrumentKey(a.Exchange, a.InstrumentID, a.AssetType, a.Pair)
+}
+
+func instrumentKey(exchName, instrumentID, assetType string, p pair.CurrencyPair) string {
+	if instrumentID != "" {
+		return exchName + " " + instrumentID
+	}
+	return exchName + " " + assetType + " " + p.Pair().String()
+}
+
+// EstimateMarginRatio estimates a position's margin ratio at a new mark
+// price from the ratio reported at the polled mark price and its liquidation
+// price, where the ratio reaches one. The inverse of the ratio, the margin as
+// a multiple of the maintenance margin, moves linearly with the price for
+// linear contracts so is interpolated between the two. Ratios are capped at
+// one past the liquidation price. It returns false when the position lacks
+// the prices or ratio needed.
+func EstimateMarginRatio(ratio, mark, liquidation, newMark float64) (float64, bool) {
+	if ratio <= 0 || mark <= 0 || liquidation <= 0 || newMark <= 0 || liquidation == mark {
+		return 0, false
+	}
+	inverse := 1/ratio + (newMark-mark)*(1-1/ratio)/(liquidation-mark)
+	if inverse <= 1 {
+		return 1, true
+	}
+	return 1 / inverse, true
+}
+
+// Event is a rule executed for an account, Err is set when its action failed
+// and OrderID for reduce orders
+type Event struct {
+	Account Account   `json:"account"`
+	Rule    Rule      `json:"rule"`
+	OrderID string    `json:"orderID,omitempty"`
+	Err     error     `json:"-"`
+	Time    time.Time `json:"time"`
+}
+
+func (e Event) String() string {
+	s := fmt.Sprintf("%s margin ratio %.3f", e.Account, e.Account.MarginRatio)
+	if e.Account.Estimated {
+		s += fmt.Sprintf(" (estimated at mark price %v)", e.Account.MarkPrice)
+	}
+	s += " reached " + e.Rule.String()
+	if e.Err != nil {
+		return s + ", action failed: " + e.Err.Error()
+	}
+	if e.OrderID != "" {
+		s += ", order " + e.OrderID
+	}
+	return s
+}
+
+// Monitor holds the futures positions and isolated margin accounts polled
+// from Exchanges, re-estimating position margin ratios as mark prices stream
+// in. Each rule whose margin ratio an account reaches is executed once, in
+// ascending order of ratio, and again only after the account has recovered
+// below it or, when Cooldown is set, every Cooldown while it remains
+// reached. OnAction receives every rule executed.
+type Monitor struct {
+	Exchanges   []string
+	Rules       []Rule
+	Cooldown    time.Duration
+	GetExchange exchange.ExchangeGetter
+	OnAction    func(Event)
+	OnError     func(exchName string, err error)
+
+	accounts map[string][]Account
+	marks    map[string]exchange.MarkPriceData
+	executed map[string]time.Time
+	m        sync.Mutex
+}
+
+// NewMonitor returns a monitor of the accounts of exchanges, ordering the
+// rules by margin ratio
+func NewMonitor(exchanges []string, rules []Rule, getExchange exchange.ExchangeGetter) *Monitor {
+	sorted := append([]Rule(nil), rules...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].MarginRatio < sorted[j].MarginRatio
+	})
+	return &Monitor{
+		Exchanges:   exchanges,
+		Rules:       sorted,
+		GetExchange: getExchange,
+	}
+}
+
+// UpdateMarkPrice stores a streamed mark price and re-estimates the margin
+// ratio of the open positions in its instrument
+func (m *Monitor) UpdateMarkPrice(d exchange.MarkPriceData) {
+	if d.MarkPrice <= 0 {
+		return
+	}
+	key := instrumentKey(d.Exchange, d.InstrumentID, d.AssetType, d.Pair)
+
+	m.m.Lock()
+	if m.marks == nil {
+		m.marks = make(map[string]exchange.MarkPriceData)
+	}
+	m.marks[key] = d
+	accounts := m.accounts[d.Exchange]
+	var updated []Account
+	for i := range accounts {
+		if !accounts[i].IsPosition() || accounts[i].key() != key {
+			continue
+		}
+		if reprice(&accounts[i], d.MarkPrice) {
+			updated = append(updated, accounts[i])
+		}
+	}
+	m.m.Unlock()
+
+	m.check(updated, d.Timestamp)
+}
+
+// reprice re-estimates a position's margin ratio at a mark price
+func reprice(a *Account, mark float64) bool {
+	ratio, ok := EstimateMarginRatio(a.polledRatio, a.polledMark, a.LiquidationPrice, mark)
+	if !ok {
+		return false
+	}
+	a.MarkPrice = mark
+	a.MarginRatio = ratio
+	a.Estimated = mark != a.polledMark
+	return true
+}
+
+// UpdateAccounts replaces the open positions and isolated margin accounts of
+// an exchange, re-estimating position ratios with streamed mark prices newer
+// than the positions, and checks them at now
+func (m *Monitor) UpdateAccounts(exchName string, positions []exchange.FuturesPosition, levels []exchange.MarginLevel, now time.Time) {
+	accounts := make([]Account, 0, len(positions)+len(levels))
+	for i := range positions {
+		if positions[i].Amount == 0 {
+			continue
+		}
+		accounts = append(accounts, Account{
+			Exchange:         exchName,
+			InstrumentID:     positions[i].InstrumentID,
+			Pair:             positions[i].Pair,
+			AssetType:        positions[i].AssetType,
+			Amount:           positions[i].Amount,
+			MarkPrice:        positions[i].MarkPrice,
+			LiquidationPrice: positions[i].LiquidationPrice,
+			MarginRatio:      positions[i].MarginRatio,
+			UpdateTime:       positions[i].UpdateTime,
+			polledMark:       positions[i].MarkPrice,
+			polledRatio:      positions[i].MarginRatio,
+		})
+	}
+	for i := range levels {
+		accounts = append(accounts, Account{
+			Exchange:    exchName,
+			Pair:        levels[i].Pair,
+			AssetType:   levels[i].AssetType,
+			MarginRatio: levels[i].MarginRatio,
+			UpdateTime:  levels[i].UpdateTime,
+		})
+	}
+
+	m.m.Lock()
+	if m.accounts == nil {
+		m.accounts = make(map[string][]Account)
+	}
+	m.accounts[exchName] = accounts
+	keys := make(map[string]bool, len(accounts))
+	for i := range accounts {
+		key := accounts[i].key()
+		keys[key] = true
+		if d, ok := m.marks[key]; ok && accounts[i].IsPosition() && d.Timestamp.After(accounts[i].UpdateTime) {
+			reprice(&accounts[i], d.MarkPrice)
+		}
+	}
+	// Rules execute again for accounts which are closed and reopened
+	for key := range m.executed {
+		if strings.HasPrefix(key, exchName+" ") && !keys[key[:strings.LastIndex(key, "#")]] {
+			delete(m.executed, key)
+		}
+	}
+	checked := append([]Account(nil), accounts...)
+	m.m.Unlock()
+
+	m.check(checked, now)
+}
+
+// Accounts returns the monitored accounts, highest margin ratio first
+func (m *Monitor) Accounts() []Account {
+	m.m.Lock()
+	var result []Account
+	for _, accounts := range m.accounts {
+		result = append(result, accounts...)
+	}
+	m.m.Unlock()
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].MarginRatio > result[j].MarginRatio
+	})
+	return result
+}
+
+// due returns the rules to execute for an account at now, clearing those the
+// account has recovered below
+func (m *Monitor) due(a *Account, now time.Time) []Rule {
+	if m.executed == nil {
+		m.executed = make(map[string]time.Time)
+	}
+	var rules []Rule
+	for i := range m.Rules {
+		key := fmt.Sprintf("%s#%d", a.key(), i)
+		if a.MarginRatio < m.Rules[i].MarginRatio {
+			delete(m.executed, key)
+			continue
+		}
+		last, ok := m.executed[key]
+		if ok && (m.Cooldown <= 0 || now.Sub(last) < m.Cooldown) {
+			continue
+		}
+		m.executed[key] = now
+		rules = append(rules, m.Rules[i])
+	}
+	return rules
+}
+
+// check executes the rules due for the accounts
+func (m *Monitor) check(accounts []Account, now time.Time) {
+	type action struct {
+		account Account
+		rule    Rule
+	}
+	var actions []action
+	m.m.Lock()
+	for i := range accounts {
+		for _, r := range m.due(&accounts[i], now) {
+			actions = append(actions, action{account: accounts[i], rule: r})
+		}
+	}
+	m.m.Unlock()
+
+	for i := range actions {
+		e := m.Execute(actions[i].account, actions[i].rule, now)
+		if m.OnAction != nil {
+			m.OnAction(e)
+		}
+	}
+}
+
+// Execute takes a rule's action for an account
+func (m *Monitor) Execute(a Account, r Rule, now time.Time) Event {
+	e := Event{Account: a, Rule: r, Time: now}
+	if r.Action == ActionAlert {
+		return e
+	}
+
+	var exch exchange.IBotExchange
+	if m.GetExchange != nil {
+		exch = m.GetExchange(a.Exchange)
+	}
+	if exch == nil {
+		e.Err = fmt.Errorf("%s exchange not found", a.Exchange)
+		return e
+	}
+
+	switch r.Action {
+	case ActionReduce:
+		e.OrderID, e.Err = reduce(exch, &a, r.ReduceFraction)
+	case ActionAddCollateral:
+		e.Err = addCollateral(exch, &a, r)
+	default:
+		e.Err = fmt.Errorf("unknown action %q", r.Action)
+	}
+	return e
+}
+
+// reduce closes a fraction of a position with a reduce only market order
+func reduce(exch exchange.IBotExchange, a *Account, fraction float64) (string, error) {
+	if !a.IsPosition() {
+		return "", ErrReduceMarginAccount
+	}
+	f, ok := exch.(exchange.FuturesExchange)
+	if !ok {
+		return "", ErrReduceUnsupported
+	}
+	if a.InstrumentID == "" {
+		return "", errors.New("position has no instrument ID to reduce")
+	}
+
+	side := exchange.Sell
+	if a.Amount < 0 {
+		side = exchange.Buy
+	}
+	resp, err := f.SubmitFuturesOrder(a.InstrumentID, side, exchange.Market,
+		math.Abs(a.Amount)*fraction, 0, true, "")
+	if err != nil {
+		return "", err
+	}
+	if !resp.IsOrderPlaced {
+		return "", errors.New("reduce order not placed")
+	}
+	return resp.OrderID, nil
+}
+
+// addCollateral transfers collateral from the spot account into an account's
+// margin
+func addCollateral(exch exchange.IBotExchange, a *Account, r Rule) error {
+	t, ok := exch.(exchange.CollateralTransferer)
+	if !ok {
+		return ErrCollateralUnsupported
+	}
+	currency := pair.CurrencyItem(r.CollateralCurrency)
+	if currency == "" {
+		currency = a.Pair.SecondCurrency
+	}
+	return t.TransferCollateral(a.Pair, a.AssetType, currency, r.CollateralAmount)
+}
+
+// Poll fetches and checks the futures positions and isolated margin accounts
+// of each exchange
+func (m *Monitor) Poll(now time.Time) {
+	for _, exchName := range m.Exchanges {
+		positions, levels, err := m.fetch(exchName)
+		if err != nil {
+			if m.OnError != nil {
+				m.OnError(exchName, err)
+			}
+			continue
+		}
+		m.UpdateAccounts(exchName, positions, levels, now)
+	}
+}
+
+// fetch returns the futures positions and isolated margin levels an exchange
+// reports
+func (m *Monitor) fetch(exchName string) ([]exchange.FuturesPosition, []exchange.MarginLevel, error) {
+	var exch exchange.IBotExchange
+	if m.GetExchange != nil {
+		exch = m.GetExchange(exchName)
+	}
+	if exch == nil {
+		return nil, nil, fmt.Errorf("%s exchange not found", exchName)
+	}
+
+	f, hasPositions := exch.(exchange.FuturesPositionExchange)
+	l, hasLevels := exch.(exchange.MarginLevelExchange)
+	if !hasPositions && !hasLevels {
+		return nil, nil, ErrMarginUnavailable
+	}
+
+	var positions []exchange.FuturesPosition
+	var levels []exchange.MarginLevel
+	var err error
+	if hasPositions {
+		positions, err = f.GetFuturesPositions()
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	if hasLevels {
+		levels, err = l.GetMarginLevels()
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return positions, levels, nil
+}
+
+// Run polls the accounts every interval until stop is closed
+func (m *Monitor) Run(interval time.Duration, stop <-chan struct{}) {
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+	tick := time.NewTicker(interval)
+	defer tick.Stop()
+	for {
+		m.Poll(time.Now())
+		select {
+		case <-stop:
+			return
+		case <-tick.C:
+		}
+	}
+}
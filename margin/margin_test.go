@@ -0,0 +1,253 @@
+package margin
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/assets"
+)
+
+var (
+	btcusdt = pair.NewCurrencyPair("BTC", "USDT")
+	now     = time.Date(2018, 9, 20, 0, 0, 0, 0, time.UTC)
+)
+
+func testPosition(amount, mark, liquidation, marginRatio float64) exchange.FuturesPosition {
+	return exchange.FuturesPosition{
+		Exchange:         "Margin",
+		InstrumentID:     "BTCUSDT",
+		Pair:             btcusdt,
+		AssetType:        assets.PerpetualSwap,
+		Amount:           amount,
+		MarkPrice:        mark,
+		LiquidationPrice: liquidation,
+		MarginRatio:      marginRatio,
+		UpdateTime:       now,
+	}
+}
+
+func TestRuleValidate(t *testing.T) {
+	valid := []Rule{
+		{MarginRatio: 0.5, Action: ActionAlert},
+		{MarginRatio: 0.8, Action: ActionReduce, ReduceFraction: 0.25},
+		{MarginRatio: 0.7, Action: ActionAddCollateral, CollateralAmount: 100},
+	}
+	for i := range valid {
+		if err := valid[i].Validate(); err != nil {
+			t.Error("Test Failed - Validate() unexpected error", valid[i], err)
+		}
+	}
+
+	invalid := []Rule{
+		{MarginRatio: 1, Action: ActionAlert},
+		{MarginRatio: 0.8, Action: ActionReduce, ReduceFraction: 1.5},
+		{MarginRatio: 0.7, Action: ActionAddCollateral},
+		{MarginRatio: 0.7, Action: "liquidate"},
+	}
+	for i := range invalid {
+		if err := invalid[i].Validate(); err == nil {
+			t.Error("Test Failed - Validate() expected error", invalid[i])
+		}
+	}
+}
+
+func TestEstimateMarginRatio(t *testing.T) {
+	// A long with a margin ratio of 0.5 at 10000 liquidated at 9000
+	r, ok := EstimateMarginRatio(0.5, 10000, 9000, 10000)
+	if !ok || math.Abs(r-0.5) > 1e-9 {
+		t.Error("Test Failed - EstimateMarginRatio() expected polled ratio", r, ok)
+	}
+	r, _ = EstimateMarginRatio(0.5, 10000, 9000, 9500)
+	if math.Abs(r-1/1.5) > 1e-9 {
+		t.Error("Test Failed - EstimateMarginRatio() incorrect ratio halfway to liquidation", r)
+	}
+	r, _ = EstimateMarginRatio(0.5, 10000, 9000, 11000)
+	if math.Abs(r-1/3.0) > 1e-9 {
+		t.Error("Test Failed - EstimateMarginRatio() incorrect ratio as price rises", r)
+	}
+	r, _ = EstimateMarginRatio(0.5, 10000, 9000, 8000)
+	if r != 1 {
+		t.Error("Test Failed - EstimateMarginRatio() expected ratio capped past liquidation", r)
+	}
+
+	// A short liquidated as the price rises
+	r, _ = EstimateMarginRatio(0.5, 10000, 11000, 10500)
+	if math.Abs(r-1/1.5) > 1e-9 {
+		t.Error("Test Failed - EstimateMarginRatio() incorrect short ratio", r)
+	}
+
+	if _, ok = EstimateMarginRatio(0, 10000, 9000, 9500); ok {
+		t.Error("Test Failed - EstimateMarginRatio() expected no estimate without a ratio")
+	}
+}
+
+func TestMonitorRules(t *testing.T) {
+	var events []Event
+	monitor := NewMonitor([]string{"Margin"}, []Rule{
+		{MarginRatio: 0.8, Action: ActionAlert},
+		{MarginRatio: 0.6, Action: ActionAlert},
+	}, nil)
+	monitor.OnAction = func(e Event) {
+		events = append(events, e)
+	}
+	if monitor.Rules[0].MarginRatio != 0.6 {
+		t.Fatal("Test Failed - NewMonitor() expected rules ordered by margin ratio", monitor.Rules)
+	}
+
+	monitor.UpdateAccounts("Margin", []exchange.FuturesPosition{
+		testPosition(1, 10000, 9000, 0.5),
+		testPosition(0, 10000, 0, 0),
+	}, nil, now)
+	if len(events) != 0 {
+		t.Fatal("Test Failed - UpdateAccounts() unexpected action", events)
+	}
+
+	// Halfway to liquidation the ratio is estimated at two thirds
+	monitor.UpdateMarkPrice(exchange.MarkPriceData{Exchange: "Margin", InstrumentID: "BTCUSDT",
+		Pair: btcusdt, AssetType: assets.PerpetualSwap, MarkPrice: 9500, Timestamp: now.Add(time.Second)})
+	if len(events) != 1 || events[0].Rule.MarginRatio != 0.6 || !events[0].Account.Estimated {
+		t.Fatal("Test Failed - UpdateMarkPrice() expected first rule", events)
+	}
+
+	// Rules are not executed again while reached without a cooldown
+	monitor.UpdateMarkPrice(exchange.MarkPriceData{Exchange: "Margin", InstrumentID: "BTCUSDT",
+		MarkPrice: 9200, Timestamp: now.Add(2 * time.Second)})
+	if len(events) != 2 || events[1].Rule.MarginRatio != 0.8 {
+		t.Fatal("Test Failed - UpdateMarkPrice() expected only the second rule", events)
+	}
+
+	// Recovering below a rule re-arms it
+	monitor.UpdateMarkPrice(exchange.MarkPriceData{Exchange: "Margin", InstrumentID: "BTCUSDT",
+		MarkPrice: 10000, Timestamp: now.Add(3 * time.Second)})
+	monitor.UpdateMarkPrice(exchange.MarkPriceData{Exchange: "Margin", InstrumentID: "BTCUSDT",
+		MarkPrice: 9500, Timestamp: now.Add(4 * time.Second)})
+	if len(events) != 3 || events[2].Rule.MarginRatio != 0.6 {
+		t.Fatal("Test Failed - UpdateMarkPrice() expected re-armed rule", events)
+	}
+
+	// Polled positions older than the streamed mark keep the estimate
+	monitor.UpdateAccounts("Margin", []exchange.FuturesPosition{
+		testPosition(1, 10000, 9000, 0.5),
+	}, []exchange.MarginLevel{
+		{Pair: btcusdt, AssetType: assets.Margin, MarginRatio: 0.3, UpdateTime: now},
+	}, now.Add(5*time.Second))
+	accounts := monitor.Accounts()
+	if len(accounts) != 2 || accounts[0].MarkPrice != 9500 || accounts[1].AssetType != assets.Margin {
+		t.Error("Test Failed - Accounts() incorrect accounts", accounts)
+	}
+	if len(events) != 3 {
+		t.Error("Test Failed - UpdateAccounts() unexpected action", events)
+	}
+}
+
+func TestMonitorCooldown(t *testing.T) {
+	var events []Event
+	monitor := NewMonitor([]string{"Margin"}, []Rule{{MarginRatio: 0.5, Action: ActionAlert}}, nil)
+	monitor.Cooldown = time.Minute
+	monitor.OnAction = func(e Event) {
+		events = append(events, e)
+	}
+
+	levels := []exchange.MarginLevel{{Pair: btcusdt, AssetType: assets.Margin, MarginRatio: 0.6}}
+	monitor.UpdateAccounts("Margin", nil, levels, now)
+	monitor.UpdateAccounts("Margin", nil, levels, now.Add(30*time.Second))
+	if len(events) != 1 {
+		t.Fatal("Test Failed - UpdateAccounts() expected a single action within the cooldown", events)
+	}
+	monitor.UpdateAccounts("Margin", nil, levels, now.Add(time.Minute))
+	if len(events) != 2 {
+		t.Error("Test Failed - UpdateAccounts() expected action after the cooldown", events)
+	}
+}
+
+// testMarginExchange records reduce orders and collateral transfers
+type testMarginExchange struct {
+	exchange.IBotExchange
+	positions []exchange.FuturesPosition
+	levels    []exchange.MarginLevel
+	orders    []float64
+	sides     []exchange.OrderSide
+	transfers []pair.CurrencyItem
+}
+
+func (e *testMarginExchange) GetFuturesPositions() ([]exchange.FuturesPosition, error) {
+	return e.positions, nil
+}
+
+func (e *testMarginExchange) GetMarginLevels() ([]exchange.MarginLevel, error) {
+	return e.levels, nil
+}
+
+func (e *testMarginExchange) GetFuturesContracts() ([]exchange.FuturesContract, error) {
+	return nil, nil
+}
+
+func (e *testMarginExchange) SubmitFuturesOrder(instrumentID string, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, reduceOnly bool, clientID string) (exchange.SubmitOrderResponse, error) {
+	if !reduceOnly || orderType != exchange.Market {
+		return exchange.SubmitOrderResponse{}, errors.New("expected reduce only market order")
+	}
+	e.orders = append(e.orders, amount)
+	e.sides = append(e.sides, side)
+	return exchange.SubmitOrderResponse{OrderID: "1", IsOrderPlaced: true}, nil
+}
+
+func (e *testMarginExchange) TransferCollateral(p pair.CurrencyPair, assetType string, currency pair.CurrencyItem, amount float64) error {
+	e.transfers = append(e.transfers, currency)
+	return nil
+}
+
+func TestPollActions(t *testing.T) {
+	exch := &testMarginExchange{
+		positions: []exchange.FuturesPosition{testPosition(-4, 10000, 10500, 0.9)},
+		levels:    []exchange.MarginLevel{{Pair: btcusdt, AssetType: assets.Margin, MarginRatio: 0.95}},
+	}
+	exchanges := map[string]exchange.IBotExchange{
+		"Margin": exch,
+		"Spot":   struct{ exchange.IBotExchange }{},
+	}
+
+	var events []Event
+	var errs []error
+	monitor := NewMonitor([]string{"Margin", "Spot", "Missing"}, []Rule{
+		{MarginRatio: 0.7, Action: ActionAddCollateral, CollateralAmount: 100},
+		{MarginRatio: 0.85, Action: ActionReduce, ReduceFraction: 0.25},
+	}, func(name string) exchange.IBotExchange {
+		return exchanges[name]
+	})
+	monitor.OnAction = func(e Event) {
+		events = append(events, e)
+	}
+	monitor.OnError = func(exchName string, err error) {
+		errs = append(errs, err)
+	}
+
+	monitor.Poll(now)
+	if len(errs) != 2 || errs[0] != ErrMarginUnavailable {
+		t.Error("Test Failed - Poll() expected unavailable and missing exchange errors", errs)
+	}
+	if len(events) != 4 {
+		t.Fatal("Test Failed - Poll() expected four actions", events)
+	}
+	if len(exch.orders) != 1 || exch.orders[0] != 1 || exch.sides[0] != exchange.Buy {
+		t.Error("Test Failed - Poll() expected a quarter of the short bought back", exch.orders, exch.sides)
+	}
+	if len(exch.transfers) != 2 || exch.transfers[0] != "USDT" {
+		t.Error("Test Failed - Poll() expected quote currency collateral transfers", exch.transfers)
+	}
+	for i := range events {
+		if events[i].Rule.Action == ActionReduce && !events[i].Account.IsPosition() &&
+			events[i].Err != ErrReduceMarginAccount {
+			t.Error("Test Failed - Poll() expected margin account reduce error", events[i])
+		}
+	}
+
+	e := monitor.Execute(Account{Exchange: "Spot", Amount: 1, InstrumentID: "BTCUSDT"},
+		Rule{MarginRatio: 0.5, Action: ActionReduce, ReduceFraction: 1}, now)
+	if e.Err != ErrReduceUnsupported {
+		t.Error("Test Failed - Execute() expected reduce unsupported", e.Err)
+	}
+}
@@ -0,0 +1,469 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/communications/base"
+	"github.com/thrasher-/gocryptotrader/currency"
+	"github.com/thrasher-/gocryptotrader/currency/locale"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	"github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/assets"
+	"github.com/thrasher-/gocryptotrader/exchanges/orders"
+)
+
+const orderManagerPollInterval = time.Second * 10
+
+// orderSubmitRetries is the number of times an order rejected for a retryable
+// reason is resubmitted
+const orderSubmitRetries = 2
+
+// orderRetryBackoff is the delay before resubmitting an order rejected while
+// the exchange is rate limiting or unavailable, doubled on each retry
+var orderRetryBackoff = time.Second
+
+// Order event types emitted by the order manager
+const (
+	OrderEventFill        = "order_fill"
+	OrderEventPartialFill = "order_partial_fill"
+	OrderEventCancel      = "order_cancel"
+)
+
+// OrderEvent is a change in the state of an order placed by the bot observed
+// by the order manager. FillAmount and FillPrice are the amount executed since
+// the previous poll and its average price.
+type OrderEvent struct {
+	Type       string
+	Order      ManagedOrder
+	FillAmount float64
+	FillPrice  float64
+}
+
+// String returns a one line description of the event
+func (e OrderEvent) String() string {
+	return e.describe(fmt.Sprint(e.Order.AverageExecutedPrice))
+}
+
+// Format returns a one line description of the event for notifications, with
+// the fill price written in locale l when the order is quoted in fiat
+func (e OrderEvent) Format(l locale.Locale) string {
+	if !currency.IsFiatCurrency(e.Order.QuoteCurrency) {
+		return e.String()
+	}
+	return e.describe(l.FormatPrice(e.Order.AverageExecutedPrice, e.Order.QuoteCurrency))
+}
+
+func (e OrderEvent) describe(price string) string {
+	return fmt.Sprintf("%s %s order %s %s %s%s %s: %v of %v filled at %s",
+		e.Order.Exchange, e.Order.OrderSide, e.Order.ID, e.Type,
+		e.Order.BaseCurrency, e.Order.QuoteCurrency, e.Order.Status,
+		e.Order.ExecutedAmount, e.Order.Amount, price)
+}
+
+// ManagedOrder is an order placed by the bot. OrderID is its local order
+// manager ID and Strategy the strategy which placed it, if any.
+type ManagedOrder struct {
+	OrderID  int
+	Strategy string
+	Created  time.Time
+	exchange.OrderDetail
+}
+
+// OrderManager records the orders placed by the bot and keeps them up to date
+// by polling their exchanges, emitting events as orders fill or are
+// cancelled. Orders are held in the orders package store.
+type OrderManager struct {
+	PollInterval time.Duration
+	OnEvent      func(OrderEvent)
+
+	m sync.Mutex
+}
+
+// NewOrderManager returns a new order manager
+func NewOrderManager() *OrderManager {
+	return &OrderManager{PollInterval: orderManagerPollInterval}
+}
+
+// Submit submits an order through the exchanges package and records it when
+// it is placed, returning its local order ID. Orders the exchange's declared
+// order capabilities do not support are rejected with an
+// exchange.OrderCapabilityError before reaching the exchange, and orders
+// exceeding the unreserved balance with orders.ErrInsufficientFunds. Exchange
+// rejections with a known reason are returned as an *orders.Rejection, see
+// submitWithRetry. Orders are
+// persisted to the database with each event when their exchange persists its
+// data, and orders and fills are recorded to the trade journal.
+func (m *OrderManager) Submit(exch exchange.IBotExchange, o exchange.OrderSubmission) (exchange.SubmitOrderResponse, int, error) {
+	return m.submit("", exch, o)
+}
+
+// SubmitStrategyOrder submits an order on behalf of a strategy once its
+// throttle limits allow it and returns its local order ID
+func (m *OrderManager) SubmitStrategyOrder(strategy string, exch exchange.IBotExchange, o exchange.OrderSubmission) (int, error) {
+	err := orders.AllowOrder(strategy, exch.GetName(), o.Pair)
+	if err != nil {
+		return 0, err
+	}
+	resp, id, err := m.submit(strategy, exch, o)
+	if err != nil {
+		return 0, err
+	}
+	if !resp.IsOrderPlaced {
+		return 0, fmt.Errorf("%s %s order was not placed", exch.GetName(), o.Side)
+	}
+	return id, nil
+}
+
+// submit submits and records an order placed by strategy, if any
+func (m *OrderManager) submit(strategy string, exch exchange.IBotExchange, o exchange.OrderSubmission) (exchange.SubmitOrderResponse, int, error) {
+	err := exch.GetCapabilities().ValidateOrder(exch.GetName(), o.Asset(), o)
+	if err != nil {
+		return exchange.SubmitOrderResponse{}, 0, err
+	}
+	currency, pendingID, err := reserveSubmission(exch, o)
+	if err != nil {
+		return exchange.SubmitOrderResponse{}, 0, err
+	}
+	resp, err := submitWithRetry(exch, o)
+	if err != nil || !resp.IsOrderPlaced {
+		if currency != "" {
+			orders.Reserved.Release(exch.GetName(), currency, pendingID)
+		}
+		return resp, 0, err
+	}
+	id := m.record(strategy, exch.GetName(), o, resp)
+	tracked := orders.GetOrderByOrderID(id)
+	if currency != "" {
+		err = tracked.HoldReservation(currency, pendingID)
+		if err != nil {
+			log.Printf("Order manager failed to hold %s order %s reservation. Err: %s",
+				exch.GetName(), resp.OrderID, err)
+		}
+	}
+	managed := managedOrder(tracked)
+	persistOrder(managed)
+	journalOrder(managed)
+	return resp, id, nil
+}
+
+// orderRounder is implemented by exchanges through exchange.Base
+type orderRounder interface {
+	RoundOrder(p pair.CurrencyPair, side exchange.OrderSide, price, amount float64) (float64, float64)
+}
+
+// submitWithRetry submits an order, normalising an exchange rejection with
+// orders.NormaliseRejection and resubmitting it up to orderSubmitRetries times
+// when resubmitting can succeed. Rate limited orders are resubmitted after a
+// doubling backoff, as are orders rejected while the exchange is unavailable
+// when they carry a client ID and the exchange declares IdempotentClientID, as
+// the order may have been placed. Precision rejections are resubmitted with
+// the order rounded to the exchange's increments when rounding changes it.
+// Other rejections, including price band and minimum notional rejections
+// which need a new price or amount from the caller, are returned with their
+// retry advice. Rejections with an unknown reason are returned as the raw
+// error.
+func submitWithRetry(exch exchange.IBotExchange, o exchange.OrderSubmission) (exchange.SubmitOrderResponse, error) {
+	backoff := orderRetryBackoff
+	for attempt := 0; ; attempt++ {
+		resp, err := exchange.SubmitOrderRequest(exch, o)
+		if err == nil {
+			return resp, nil
+		}
+		r := orders.NormaliseRejection(exch.GetName(), err)
+		if r.Reason == orders.RejectUnknown {
+			return resp, err
+		}
+		if !r.Retryable || attempt == orderSubmitRetries {
+			return resp, r
+		}
+
+		switch r.Reason {
+		case orders.RejectRateLimited:
+			time.Sleep(backoff)
+			backoff *= 2
+		case orders.RejectExchangeUnavailable:
+			if o.ClientID == "" || !exch.GetCapabilities().IdempotentClientID {
+				return resp, r
+			}
+			time.Sleep(backoff)
+			backoff *= 2
+		case orders.RejectInvalidPrecision:
+			rounded, ok := roundRejectedOrder(exch, o)
+			if !ok {
+				return resp, r
+			}
+			o = rounded
+		default:
+			return resp, r
+		}
+		log.Printf("Order manager resubmitting %s %s order rejected for %s.\n",
+			exch.GetName(), o.Side, r.Reason)
+	}
+}
+
+// roundRejectedOrder rounds the price and amount of an order rejected for its
+// precision to the exchange's increments, returning false when rounding
+// leaves it unchanged
+func roundRejectedOrder(exch exchange.IBotExchange, o exchange.OrderSubmission) (exchange.OrderSubmission, bool) {
+	rounder, ok := exch.(orderRounder)
+	if !ok {
+		return o, false
+	}
+	price, amount := rounder.RoundOrder(o.Pair, o.Side, o.Price, o.BaseAmount)
+	if price <= 0 {
+		price = o.Price
+	}
+	if amount <= 0 {
+		amount = o.BaseAmount
+	}
+	if price == o.Price && amount == o.BaseAmount {
+		return o, false
+	}
+	o.Price, o.BaseAmount = price, amount
+	return o, true
+}
+
+// reserveSubmission reserves the funds required by an order before it is sent
+// so concurrent submissions cannot oversubscribe the exchange balance. A
+// missing or stale cached balance is refreshed from the exchange first.
+// Derivatives orders are margined rather than paid from a spot balance and
+// reserve nothing.
+func reserveSubmission(exch exchange.IBotExchange, o exchange.OrderSubmission) (string, string, error) {
+	if o.Asset() != assets.Spot {
+		return "", "", nil
+	}
+	exchName := exch.GetName()
+	currency, amount := orders.SubmissionFunds(o)
+	if amount <= 0 {
+		return "", "", nil
+	}
+	if orders.Reserved.IsStale(exchName, currency) {
+		info, err := exch.GetAccountInfo()
+		if err != nil {
+			return "", "", fmt.Errorf("%s unable to refresh balances before reserving %s: %s",
+				exchName, currency, err)
+		}
+		for i := range info.Currencies {
+			if info.Currencies[i].AssetType != "" {
+				continue
+			}
+			orders.Reserved.UpdateBalance(exchName, info.Currencies[i].CurrencyName,
+				info.Currencies[i].TotalValue)
+		}
+	}
+	return orders.Reserved.ReserveSubmission(exchName, o)
+}
+
+// Record adds a placed order to the order manager and returns its local order
+// ID. Orders sized by their quote amount are recorded with the base amount
+// they convert to at their price, market orders sized by their quote amount
+// take their amount from the first poll.
+func (m *OrderManager) Record(exchName string, o exchange.OrderSubmission, resp exchange.SubmitOrderResponse) int {
+	return m.record("", exchName, o, resp)
+}
+
+// record adds an order placed by strategy, if any, to the order manager
+func (m *OrderManager) record(strategy, exchName string, o exchange.OrderSubmission, resp exchange.SubmitOrderResponse) int {
+	amount := o.BaseAmount
+	if amount == 0 && o.Price > 0 {
+		amount = o.QuoteAmount / o.Price
+	}
+	return orders.TrackStrategyOrder(strategy, exchName, resp.OrderID, o.Pair, o.Side, o.Type,
+		amount, o.Price)
+}
+
+// Orders returns the orders placed by the bot filtered by exchange and
+// status, either of which may be empty to match all orders
+func (m *OrderManager) Orders(exchName, status string) []ManagedOrder {
+	var result []ManagedOrder
+	for _, o := range orders.Orders.All() {
+		if exchName != "" && common.StringToUpper(o.Exchange) != common.StringToUpper(exchName) {
+			continue
+		}
+		managed := managedOrder(o)
+		if status != "" && managed.Status != common.StringToUpper(status) {
+			continue
+		}
+		result = append(result, managed)
+	}
+	return result
+}
+
+// Poll updates the open orders of the exchanges from the exchanges and
+// returns the events observed. Orders are polled with GetOrderInfo when their
+// exchange order ID is numeric and the exchange supports it, otherwise from
+// the exchange's open orders and order history, see pollOrderLists.
+func (m *OrderManager) Poll(exchs []exchange.IBotExchange) []OrderEvent {
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	var events []OrderEvent
+	for _, exch := range exchs {
+		if exch == nil || !exch.IsEnabled() || !exch.GetAuthenticatedAPISupport() {
+			continue
+		}
+		var unresolved []*orders.Order
+		for _, o := range orders.GetOrdersByExchange(exch.GetName()) {
+			if !o.IsOpen() {
+				continue
+			}
+			id, err := strconv.ParseInt(o.ExchangeOrderID, 10, 64)
+			if err != nil {
+				unresolved = append(unresolved, o)
+				continue
+			}
+			detail, err := exch.GetOrderInfo(id)
+			if err == common.ErrFunctionNotSupported {
+				unresolved = append(unresolved, o)
+				continue
+			}
+			if err != nil {
+				log.Printf("Order manager failed to get %s order %s. Err: %s",
+					exch.GetName(), o.ExchangeOrderID, err)
+				continue
+			}
+			if e, ok := applyOrderDetail(o, detail); ok {
+				events = append(events, e)
+			}
+		}
+		events = append(events, pollOrderLists(exch, unresolved)...)
+	}
+
+	for i := range events {
+		persistOrder(events[i].Order)
+		journalFill(events[i])
+		if m.OnEvent != nil {
+			m.OnEvent(events[i])
+		}
+	}
+	return events
+}
+
+// Run polls the bot's exchanges until the bot shuts down
+func (m *OrderManager) Run() {
+	log.Println("Starting order manager routine.")
+	for {
+		m.Poll(bot.exchanges)
+		time.Sleep(m.PollInterval)
+	}
+}
+
+// pollOrderLists updates orders which cannot be looked up by ID from the
+// exchange's open orders and order history since the oldest of them was
+// placed. Orders found in neither list cannot be tracked, their reservations
+// are released so they cannot block further submissions.
+func pollOrderLists(exch exchange.IBotExchange, tracked []*orders.Order) []OrderEvent {
+	if len(tracked) == 0 {
+		return nil
+	}
+	req := exchange.GetOrdersRequest{StartTime: time.Now()}
+	pairs := make(map[string]bool)
+	for _, o := range tracked {
+		if p := o.Pair.Pair().String(); !pairs[p] {
+			pairs[p] = true
+			req.Currencies = append(req.Currencies, o.Pair)
+		}
+		if o.Created.Before(req.StartTime) {
+			req.StartTime = o.Created
+		}
+	}
+
+	details := make(map[string]exchange.OrderDetail)
+	for _, list := range []func(exchange.GetOrdersRequest) ([]exchange.OrderDetail, error){
+		exch.GetActiveOrders, exch.GetOrderHistory,
+	} {
+		result, err := list(req)
+		if err == common.ErrFunctionNotSupported {
+			continue
+		}
+		if err != nil {
+			log.Printf("Order manager failed to get %s orders. Err: %s",
+				exch.GetName(), err)
+			return nil
+		}
+		for i := range result {
+			details[result[i].ID] = result[i]
+		}
+	}
+
+	var events []OrderEvent
+	for _, o := range tracked {
+		detail, ok := details[o.ExchangeOrderID]
+		if !ok {
+			if o.ReleaseReservation() {
+				log.Printf("Order manager unable to track %s order %s, releasing its reservation.\n",
+					exch.GetName(), o.ExchangeOrderID)
+			}
+			continue
+		}
+		if e, ok := applyOrderDetail(o, detail); ok {
+			events = append(events, e)
+		}
+	}
+	return events
+}
+
+// applyOrderDetail applies an exchange order detail to a tracked order and
+// returns the event it caused, if any
+func applyOrderDetail(o *orders.Order, d exchange.OrderDetail) (OrderEvent, bool) {
+	before := o.Detail()
+	err := o.ApplyOrderUpdate(d)
+	if err != nil {
+		log.Printf("Order manager failed to update %s order %s. Err: %s",
+			o.Exchange, o.ExchangeOrderID, err)
+		return OrderEvent{}, false
+	}
+	if isCancelledStatus(d.Status) && o.IsOpen() {
+		o.MarkCancelled()
+	}
+
+	after := managedOrder(o)
+	e := OrderEvent{Order: after}
+	if filled := after.ExecutedAmount - before.ExecutedAmount; filled > 0 {
+		e.FillAmount = filled
+		e.FillPrice = (after.AverageExecutedPrice*after.ExecutedAmount -
+			before.AverageExecutedPrice*before.ExecutedAmount) / filled
+	}
+	switch {
+	case after.Status == before.Status && e.FillAmount == 0:
+		return OrderEvent{}, false
+	case after.Status == orders.StatusFilled:
+		e.Type = OrderEventFill
+	case after.Status == orders.StatusCancelled || after.Status == orders.StatusPartiallyCancelled:
+		e.Type = OrderEventCancel
+	case e.FillAmount > 0:
+		e.Type = OrderEventPartialFill
+	default:
+		return OrderEvent{}, false
+	}
+	return e, true
+}
+
+// isCancelledStatus returns whether an exchange order status reports the
+// order as cancelled, exchanges spell this in varying case as cancelled or
+// canceled
+func isCancelledStatus(status string) bool {
+	return common.StringContains(common.StringToLower(status), "cancel")
+}
+
+// managedOrder returns a tracked order as a managed order
+func managedOrder(o *orders.Order) ManagedOrder {
+	return ManagedOrder{
+		OrderID:     o.OrderID,
+		Strategy:    o.Strategy,
+		Created:     o.Created,
+		OrderDetail: o.Detail(),
+	}
+}
+
+// orderEventAlert logs and relays order manager events to the communication
+// mediums
+func orderEventAlert(e OrderEvent) {
+	log.Println(e)
+	bot.comms.PushEvent(base.Event{Type: e.Type, TradeDetails: e.Format(bot.config.GetDisplayLocale())})
+}
@@ -0,0 +1,407 @@
+package main
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/currency"
+	"github.com/thrasher-/gocryptotrader/currency/locale"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	"github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/assets"
+	"github.com/thrasher-/gocryptotrader/exchanges/orders"
+	"github.com/thrasher-/gocryptotrader/journal"
+)
+
+// testOrderExchange returns order details queued by order ID
+type testOrderExchange struct {
+	exchange.IBotExchange
+	details map[int64]exchange.OrderDetail
+}
+
+func (e *testOrderExchange) GetName() string {
+	return "OrderManagerTest"
+}
+
+func (e *testOrderExchange) IsEnabled() bool {
+	return true
+}
+
+func (e *testOrderExchange) GetAuthenticatedAPISupport() bool {
+	return true
+}
+
+func (e *testOrderExchange) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
+	return e.details[orderID], nil
+}
+
+func (e *testOrderExchange) GetCapabilities() exchange.Features {
+	return exchange.Features{
+		CanSubmitOrder: true,
+		Orders: map[string]exchange.OrderCapabilities{
+			assets.Spot: {
+				OrderTypes:  []exchange.OrderType{exchange.Limit},
+				TimeInForce: []exchange.TimeInForce{exchange.GTC},
+			},
+		},
+	}
+}
+
+func TestOrderManagerSubmitCapabilities(t *testing.T) {
+	m := NewOrderManager()
+	p := pair.NewCurrencyPair("BTC", "USD")
+	// The exchange has no SubmitOrder, so unsupported orders must be rejected
+	// before reaching it
+	for _, o := range []exchange.OrderSubmission{
+		{Pair: p, Side: exchange.Buy, Type: exchange.Market, BaseAmount: 1},
+		{Pair: p, Side: exchange.Buy, Type: exchange.Limit, BaseAmount: 1, Price: 1, TimeInForce: exchange.IOC},
+		{AssetType: assets.Futures, Pair: p, Side: exchange.Buy, Type: exchange.Limit, BaseAmount: 1, Price: 1},
+	} {
+		_, _, err := m.Submit(&testOrderExchange{}, o)
+		if _, ok := err.(*exchange.OrderCapabilityError); !ok {
+			t.Errorf("Test failed. Submit() expected capability error for %+v got %v", o, err)
+		}
+	}
+}
+
+// testStrategyOrderExchange places every order submitted
+type testStrategyOrderExchange struct {
+	testOrderExchange
+	submitted int
+}
+
+func (e *testStrategyOrderExchange) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	e.submitted++
+	return exchange.SubmitOrderResponse{IsOrderPlaced: true, OrderID: strconv.Itoa(e.submitted)}, nil
+}
+
+func (e *testStrategyOrderExchange) GetAccountInfo() (exchange.AccountInfo, error) {
+	return exchange.AccountInfo{ExchangeName: e.GetName(), Currencies: []exchange.AccountCurrencyInfo{
+		{CurrencyName: "USD", TotalValue: 1000},
+	}}, nil
+}
+
+func TestOrderManagerSubmitStrategyOrder(t *testing.T) {
+	m := NewOrderManager()
+	exch := &testStrategyOrderExchange{}
+	err := orders.SetThrottle("ordermanagertest", orders.ThrottleLimits{MaxOpenOrders: 1})
+	if err != nil {
+		t.Fatal("Test failed. SetThrottle() error", err)
+	}
+	defer orders.RemoveThrottle("ordermanagertest")
+
+	o := exchange.OrderSubmission{Pair: pair.NewCurrencyPair("BTC", "USD"), Side: exchange.Buy,
+		Type: exchange.Limit, BaseAmount: 1, Price: 100}
+	id, err := m.SubmitStrategyOrder("ordermanagertest", exch, o)
+	if err != nil {
+		t.Fatal("Test failed. SubmitStrategyOrder() error", err)
+	}
+	defer orders.DeleteOrder(id)
+	if tracked := orders.GetOrderByOrderID(id); tracked == nil || tracked.Strategy != "ordermanagertest" {
+		t.Error("Test failed. SubmitStrategyOrder() expected order tracked under the strategy", tracked)
+	}
+	if _, err = m.SubmitStrategyOrder("ordermanagertest", exch, o); err == nil ||
+		!strings.Contains(err.Error(), orders.ErrMaxOpenOrders.Error()) || exch.submitted != 1 {
+		t.Error("Test failed. SubmitStrategyOrder() expected throttled order", err)
+	}
+}
+
+// testReservationExchange places orders against a 150 USD balance, rejecting
+// orders while reject is set
+type testReservationExchange struct {
+	testStrategyOrderExchange
+	reject bool
+}
+
+func (e *testReservationExchange) GetName() string {
+	return "OrderReservationTest"
+}
+
+func (e *testReservationExchange) GetAccountInfo() (exchange.AccountInfo, error) {
+	return exchange.AccountInfo{ExchangeName: e.GetName(), Currencies: []exchange.AccountCurrencyInfo{
+		{CurrencyName: "USD", TotalValue: 150},
+	}}, nil
+}
+
+func (e *testReservationExchange) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	if e.reject {
+		return exchange.SubmitOrderResponse{}, errors.New("insufficient balance")
+	}
+	return e.testStrategyOrderExchange.SubmitOrder(p, side, orderType, amount, price, clientID)
+}
+
+func TestOrderManagerReservations(t *testing.T) {
+	exch := &testReservationExchange{}
+	exch.details = make(map[int64]exchange.OrderDetail)
+	m := NewOrderManager()
+	available := func() float64 {
+		free, err := orders.Reserved.Available(exch.GetName(), "USD")
+		if err != nil {
+			t.Fatal("Test failed. Available() error", err)
+		}
+		return free
+	}
+
+	order := exchange.OrderSubmission{Pair: pair.NewCurrencyPair("BTC", "USD"), Side: exchange.Buy,
+		Type: exchange.Limit, BaseAmount: 1, Price: 100}
+	exch.reject = true
+	if _, _, err := m.Submit(exch, order); err == nil || available() != 150 {
+		t.Error("Test failed. Submit() expected rejected order reservation released", err, available())
+	}
+
+	exch.reject = false
+	resp, id, err := m.Submit(exch, order)
+	if err != nil {
+		t.Fatal("Test failed. Submit() error", err)
+	}
+	defer orders.DeleteOrder(id)
+	if reserved := orders.Reserved.GetReservation(exch.GetName(), "USD", resp.OrderID); reserved != 100 || available() != 50 {
+		t.Error("Test failed. Submit() expected reservation held under the exchange order ID", reserved, available())
+	}
+	if _, _, err = m.Submit(exch, order); err == nil ||
+		!strings.Contains(err.Error(), orders.ErrInsufficientFunds.Error()) || exch.submitted != 1 {
+		t.Error("Test failed. Submit() expected order exceeding the unreserved balance rejected", err)
+	}
+
+	orderID, _ := strconv.ParseInt(resp.OrderID, 10, 64)
+	exch.details[orderID] = exchange.OrderDetail{Amount: 1, ExecutedAmount: 0.4, AverageExecutedPrice: 100, Status: "open"}
+	m.Poll([]exchange.IBotExchange{exch})
+	if reserved := orders.Reserved.GetReservation(exch.GetName(), "USD", resp.OrderID); reserved != 60 || available() != 50 {
+		t.Error("Test failed. Poll() expected fill to consume the reservation", reserved, available())
+	}
+
+	exch.details[orderID] = exchange.OrderDetail{Amount: 1, ExecutedAmount: 0.4, AverageExecutedPrice: 100, Status: "cancelled"}
+	m.Poll([]exchange.IBotExchange{exch})
+	if reserved := orders.Reserved.GetReservation(exch.GetName(), "USD", resp.OrderID); reserved != 0 || available() != 110 {
+		t.Error("Test failed. Poll() expected cancellation to release the reservation", reserved, available())
+	}
+}
+
+// testRejectionExchange rejects submitted orders with its queued errors and
+// rounds amounts to two decimal places
+type testRejectionExchange struct {
+	testStrategyOrderExchange
+	errs       []error
+	amounts    []float64
+	idempotent bool
+}
+
+func (e *testRejectionExchange) GetName() string {
+	return "OrderRejectionTest"
+}
+
+func (e *testRejectionExchange) GetCapabilities() exchange.Features {
+	f := e.testStrategyOrderExchange.GetCapabilities()
+	f.IdempotentClientID = e.idempotent
+	return f
+}
+
+func (e *testRejectionExchange) RoundOrder(p pair.CurrencyPair, side exchange.OrderSide, price, amount float64) (float64, float64) {
+	return price, float64(int64(amount*100)) / 100
+}
+
+func (e *testRejectionExchange) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	e.amounts = append(e.amounts, amount)
+	if len(e.errs) > 0 {
+		err := e.errs[0]
+		e.errs = e.errs[1:]
+		return exchange.SubmitOrderResponse{}, err
+	}
+	return e.testStrategyOrderExchange.SubmitOrder(p, side, orderType, amount, price, clientID)
+}
+
+func TestSubmitWithRetry(t *testing.T) {
+	backoff := orderRetryBackoff
+	orderRetryBackoff = time.Millisecond
+	defer func() {
+		orderRetryBackoff = backoff
+	}()
+	order := exchange.OrderSubmission{Pair: pair.NewCurrencyPair("BTC", "USD"), Side: exchange.Buy,
+		Type: exchange.Limit, BaseAmount: 0.123, Price: 100}
+
+	exch := &testRejectionExchange{errs: []error{
+		errors.New("unsuccessful HTTP status code: 429"),
+		errors.New("amount precision exceeded"),
+	}}
+	resp, err := submitWithRetry(exch, order)
+	if err != nil || !resp.IsOrderPlaced || len(exch.amounts) != 3 || exch.amounts[2] != 0.12 {
+		t.Error("Test failed. submitWithRetry() expected rate limited and precision rejections retried", err, exch.amounts)
+	}
+
+	exch = &testRejectionExchange{errs: []error{errors.New("insufficient balance")}}
+	_, err = submitWithRetry(exch, order)
+	if r, ok := err.(*orders.Rejection); !ok || r.Reason != orders.RejectInsufficientBalance || len(exch.amounts) != 1 {
+		t.Error("Test failed. submitWithRetry() expected insufficient balance rejection not retried", err)
+	}
+
+	exch = &testRejectionExchange{errs: []error{errors.New("unsuccessful HTTP status code: 503")}}
+	if _, err = submitWithRetry(exch, order); err == nil || len(exch.amounts) != 1 {
+		t.Error("Test failed. submitWithRetry() expected unavailable rejection without a client ID not retried", err)
+	}
+	order.ClientID = "client"
+	exch = &testRejectionExchange{errs: []error{errors.New("unsuccessful HTTP status code: 503")}}
+	if _, err = submitWithRetry(exch, order); err == nil || len(exch.amounts) != 1 {
+		t.Error("Test failed. submitWithRetry() expected unavailable rejection not retried without idempotent client IDs", err)
+	}
+	exch = &testRejectionExchange{errs: []error{errors.New("unsuccessful HTTP status code: 503")}, idempotent: true}
+	if _, err = submitWithRetry(exch, order); err != nil || len(exch.amounts) != 2 {
+		t.Error("Test failed. submitWithRetry() expected unavailable rejection with a client ID retried", err)
+	}
+
+	exch = &testRejectionExchange{errs: []error{errors.New("filter failure: MIN_NOTIONAL")}}
+	_, err = submitWithRetry(exch, order)
+	if r, ok := err.(*orders.Rejection); !ok || r.Advice != orders.RetryWithAdjustedAmount || len(exch.amounts) != 1 {
+		t.Error("Test failed. submitWithRetry() expected minimum notional rejection returned with its advice", err)
+	}
+
+	unknown := errors.New("something odd")
+	exch = &testRejectionExchange{errs: []error{unknown}}
+	if _, err = submitWithRetry(exch, order); err != unknown {
+		t.Error("Test failed. submitWithRetry() expected unknown rejection returned as is", err)
+	}
+}
+
+func TestOrderManagerPoll(t *testing.T) {
+	exch := &testOrderExchange{details: make(map[int64]exchange.OrderDetail)}
+	p := pair.NewCurrencyPair("BTC", "USD")
+	m := NewOrderManager()
+	bot.journal = journal.New()
+	defer func() { bot.journal = nil }()
+	session, err := bot.journal.StartSession("")
+	if err != nil {
+		t.Fatal("Test failed. StartSession() error", err)
+	}
+	var received []OrderEvent
+	m.OnEvent = func(e OrderEvent) {
+		received = append(received, e)
+	}
+
+	order := exchange.OrderSubmission{Pair: p, Side: exchange.Buy, Type: exchange.Limit, BaseAmount: 2, Price: 100}
+	filled := m.Record(exch.GetName(), order, exchange.SubmitOrderResponse{IsOrderPlaced: true, OrderID: "1"})
+	cancelled := m.Record(exch.GetName(), order, exchange.SubmitOrderResponse{IsOrderPlaced: true, OrderID: "2"})
+	defer orders.DeleteOrder(filled)
+	defer orders.DeleteOrder(cancelled)
+
+	if events := m.Poll([]exchange.IBotExchange{exch}); len(events) != 0 {
+		t.Error("Test failed. Poll() unexpected events without updates", events)
+	}
+
+	exch.details[1] = exchange.OrderDetail{ExecutedAmount: 1, AverageExecutedPrice: 100}
+	exch.details[2] = exchange.OrderDetail{Status: "CANCELED"}
+	events := m.Poll([]exchange.IBotExchange{exch})
+	if len(events) != 2 || events[0].Type != OrderEventPartialFill ||
+		events[1].Type != OrderEventCancel || events[1].Order.Status != orders.StatusCancelled {
+		t.Error("Test failed. Poll() unexpected events", events)
+	}
+
+	exch.details[1] = exchange.OrderDetail{ExecutedAmount: 2, AverageExecutedPrice: 99}
+	events = m.Poll([]exchange.IBotExchange{exch})
+	if len(events) != 1 || events[0].Type != OrderEventFill || events[0].Order.OrderID != filled ||
+		events[0].FillAmount != 1 || events[0].FillPrice != 98 {
+		t.Error("Test failed. Poll() unexpected fill events", events)
+	}
+	if len(received) != 3 {
+		t.Error("Test failed. Poll() OnEvent not called for each event", received)
+	}
+
+	if o := m.Orders("orderManagerTest", orders.StatusFilled); len(o) != 1 || o[0].AverageExecutedPrice != 99 {
+		t.Error("Test failed. Orders() unexpected filled orders", o)
+	}
+	if o := m.Orders("", ""); len(o) < 2 {
+		t.Error("Test failed. Orders() expected all orders", o)
+	}
+
+	entries, err := bot.journal.Entries(session.ID)
+	if err != nil || len(entries) != 2 || entries[0].Price != 100 || entries[1].Price != 98 {
+		t.Error("Test failed. Poll() unexpected journal fills", entries, err)
+	}
+}
+
+// testOrderListExchange only reports orders through its open orders
+type testOrderListExchange struct {
+	testOrderExchange
+	active []exchange.OrderDetail
+}
+
+func (e *testOrderListExchange) GetName() string {
+	return "OrderListTest"
+}
+
+func (e *testOrderListExchange) GetOrderInfo(orderID int64) (exchange.OrderDetail, error) {
+	return exchange.OrderDetail{}, common.ErrFunctionNotSupported
+}
+
+func (e *testOrderListExchange) GetActiveOrders(req exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	return e.active, nil
+}
+
+func (e *testOrderListExchange) GetOrderHistory(req exchange.GetOrdersRequest) ([]exchange.OrderDetail, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
+func TestOrderManagerPollOrderLists(t *testing.T) {
+	exch := &testOrderListExchange{}
+	m := NewOrderManager()
+	order := exchange.OrderSubmission{Pair: pair.NewCurrencyPair("BTC", "USD"), Side: exchange.Buy,
+		Type: exchange.Limit, BaseAmount: 2, Price: 100}
+	listed := m.Record(exch.GetName(), order, exchange.SubmitOrderResponse{IsOrderPlaced: true, OrderID: "listed"})
+	missing := m.Record(exch.GetName(), order, exchange.SubmitOrderResponse{IsOrderPlaced: true, OrderID: "42"})
+	defer orders.DeleteOrder(listed)
+	defer orders.DeleteOrder(missing)
+
+	orders.Reserved.UpdateBalance(exch.GetName(), "USD", 1000)
+	err := orders.Reserved.Reserve(exch.GetName(), "USD", "pending-missing", 200)
+	if err != nil {
+		t.Fatal("Test failed. Reserve() error", err)
+	}
+	err = orders.GetOrderByOrderID(missing).HoldReservation("USD", "pending-missing")
+	if err != nil {
+		t.Fatal("Test failed. HoldReservation() error", err)
+	}
+
+	exch.active = []exchange.OrderDetail{{ID: "listed", Amount: 2, ExecutedAmount: 1, AverageExecutedPrice: 100}}
+	events := m.Poll([]exchange.IBotExchange{exch})
+	if len(events) != 1 || events[0].Order.OrderID != listed || events[0].Type != OrderEventPartialFill {
+		t.Error("Test failed. Poll() expected order updated from the open orders", events)
+	}
+	if reserved := orders.Reserved.GetReservation(exch.GetName(), "USD", "42"); reserved != 0 {
+		t.Error("Test failed. Poll() expected untracked order reservation released", reserved)
+	}
+	if free, _ := orders.Reserved.Available(exch.GetName(), "USD"); free != 1000 {
+		t.Error("Test failed. Poll() expected all funds available", free)
+	}
+}
+
+func TestOrderEventFormat(t *testing.T) {
+	fiat := currency.FiatCurrencies
+	currency.FiatCurrencies = []string{"EUR"}
+	defer func() { currency.FiatCurrencies = fiat }()
+
+	e := OrderEvent{
+		Type: OrderEventFill,
+		Order: ManagedOrder{OrderDetail: exchange.OrderDetail{
+			Exchange:             "OrderManagerTest",
+			ID:                   "1",
+			BaseCurrency:         "BTC",
+			QuoteCurrency:        "EUR",
+			OrderSide:            "BUY",
+			Status:               "FILLED",
+			Amount:               1,
+			ExecutedAmount:       1,
+			AverageExecutedPrice: 54321.5,
+		}},
+	}
+	expected := "OrderManagerTest BUY order 1 order_fill BTCEUR FILLED: 1 of 1 filled at 54.321,50 €"
+	if s := e.Format(locale.Locales["de-DE"]); s != expected {
+		t.Errorf("Test Failed - Format() expected %q received %q", expected, s)
+	}
+
+	e.Order.QuoteCurrency = "USDT"
+	if s := e.Format(locale.Locales["de-DE"]); s != e.String() {
+		t.Errorf("Test Failed - Format() expected crypto quoted price unformatted %q", s)
+	}
+}
@@ -0,0 +1,437 @@
+package main
+
+import (
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	"github.com/thrasher-/gocryptotrader/db"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+const (
+	// withdrawalHistoryInterval is how often the withdrawal history of
+	// exchanges with persistence enabled is fetched
+	withdrawalHistoryInterval = time.Hour
+	// orderbookRecordInterval is the least time between the recorded
+	// orderbook snapshots of a pair, websocket books update far more often
+	orderbookRecordInterval = 10 * time.Second
+	// orderbookRecordDepth is the number of levels of each side recorded
+	orderbookRecordDepth = 20
+)
+
+var (
+	orderbookRecorded   = make(map[string]time.Time)
+	orderbookRecordedMu sync.Mutex
+)
+
+// SetupDatabase opens the configured database, persistence is disabled if it
+// cannot be opened
+func SetupDatabase() {
+	c := bot.config.Database
+	var err error
+	bot.db, err = db.Open(db.Config{
+		Driver:   c.Driver,
+		Database: c.Database,
+		Host:     c.Host,
+		Port:     c.Port,
+		Username: c.Username,
+		Password: c.Password,
+		SSLMode:  c.SSLMode,
+	})
+	if err != nil {
+		log.Printf("Failed to open database, persistence disabled. Err: %s", err)
+		return
+	}
+	log.Printf("Database support enabled. Driver: %s.\n", c.Driver)
+}
+
+// persistenceEnabled returns whether the data of an exchange is persisted to
+// the database
+func persistenceEnabled(exchName string) bool {
+	if bot.db == nil {
+		return false
+	}
+	exchCfg, err := bot.config.GetExchangeConfig(exchName)
+	return err == nil && exchCfg.PersistData
+}
+
+// recordingEnabled returns whether an exchange persists a type of market data
+// for a currency pair, dataType is the data's db table. Exchanges without a
+// recording config persist the tickers and trades of every pair.
+func recordingEnabled(exchName string, p pair.CurrencyPair, dataType string) bool {
+	if bot.db == nil {
+		return false
+	}
+	exchCfg, err := bot.config.GetExchangeConfig(exchName)
+	if err != nil || !exchCfg.PersistData {
+		return false
+	}
+	return recordsPair(&exchCfg, p, dataType)
+}
+
+// recordsPair returns whether an exchange config records a type of market
+// data for a currency pair
+func recordsPair(exchCfg *config.ExchangeConfig, p pair.CurrencyPair, dataType string) bool {
+	r := exchCfg.Recording
+	if r == nil {
+		return dataType == db.TableTickers || dataType == db.TableTrades
+	}
+
+	var enabled bool
+	switch dataType {
+	case db.TableTickers:
+		enabled = r.Tickers
+	case db.TableTrades:
+		enabled = r.Trades
+	case db.TableOrderbooks:
+		enabled = r.Orderbooks
+	case db.TableCandles:
+		enabled = r.Candles
+	}
+	if !enabled || r.Pairs == "" {
+		return enabled
+	}
+
+	var delimiter, index string
+	if exchCfg.ConfigCurrencyPairFormat != nil {
+		delimiter = exchCfg.ConfigCurrencyPairFormat.Delimiter
+		index = exchCfg.ConfigCurrencyPairFormat.Index
+	}
+	pairs := pair.FormatPairs(common.SplitStrings(r.Pairs, ","), delimiter, index)
+	return pair.Contains(pairs, p, false)
+}
+
+// persistTicker stores a ticker snapshot if the exchange records the pair's
+// tickers
+func persistTicker(exchName, assetType string, p pair.CurrencyPair, price ticker.Price) {
+	if !recordingEnabled(exchName, p, db.TableTickers) {
+		return
+	}
+	timestamp := price.LastUpdated
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+	err := bot.db.InsertTicker(db.Ticker{
+		Exchange:  exchName,
+		Pair:      p.Pair().String(),
+		AssetType: assetType,
+		Last:      price.Last,
+		High:      price.High,
+		Low:       price.Low,
+		Bid:       price.Bid,
+		Ask:       price.Ask,
+		Volume:    price.Volume,
+		Timestamp: timestamp,
+	})
+	if err != nil {
+		log.Printf("Failed to store %s ticker. Err: %s", exchName, err)
+	}
+}
+
+// persistWebsocketTicker stores a websocket ticker update if the exchange
+// persists its data
+func persistWebsocketTicker(t exchange.TickerData) {
+	persistTicker(t.Exchange, t.AssetType, t.Pair, ticker.Price{
+		LastUpdated: t.Timestamp,
+		Last:        t.ClosePrice,
+		High:        t.HighPrice,
+		Low:         t.LowPrice,
+		Volume:      t.Quantity,
+	})
+}
+
+// persistTrade stores a websocket trade if the exchange records the pair's
+// trades
+func persistTrade(t exchange.TradeData) {
+	if !recordingEnabled(t.Exchange, t.CurrencyPair, db.TableTrades) {
+		return
+	}
+	err := bot.db.InsertTrade(db.Trade{
+		Exchange:  t.Exchange,
+		Pair:      t.CurrencyPair.Pair().String(),
+		AssetType: t.AssetType,
+		Side:      t.Side,
+		Price:     t.Price,
+		Amount:    t.Amount,
+		Timestamp: t.Timestamp,
+	})
+	if err != nil {
+		log.Printf("Failed to store %s trade. Err: %s", t.Exchange, err)
+	}
+}
+
+// persistOrderbook stores a snapshot of the top levels of an orderbook if the
+// exchange records the pair's orderbooks, at most one every
+// orderbookRecordInterval
+func persistOrderbook(exchName string, book orderbook.Base) {
+	if !recordingEnabled(exchName, book.Pair, db.TableOrderbooks) {
+		return
+	}
+	now := time.Now()
+	key := exchName + " " + book.Pair.Pair().String() + " " + book.AssetType
+	orderbookRecordedMu.Lock()
+	if now.Sub(orderbookRecorded[key]) < orderbookRecordInterval {
+		orderbookRecordedMu.Unlock()
+		return
+	}
+	orderbookRecorded[key] = now
+	orderbookRecordedMu.Unlock()
+
+	timestamp := book.LastUpdated
+	if timestamp.IsZero() {
+		timestamp = now
+	}
+	err := bot.db.InsertOrderbook(db.Orderbook{
+		Exchange:  exchName,
+		Pair:      book.Pair.Pair().String(),
+		AssetType: book.AssetType,
+		Bids:      orderbookLevels(book.Bids),
+		Asks:      orderbookLevels(book.Asks),
+		Timestamp: timestamp,
+	})
+	if err != nil {
+		log.Printf("Failed to store %s orderbook. Err: %s", exchName, err)
+	}
+}
+
+// orderbookLevels returns the first orderbookRecordDepth levels of a side of
+// an orderbook
+func orderbookLevels(items []orderbook.Item) []db.OrderbookLevel {
+	if len(items) > orderbookRecordDepth {
+		items = items[:orderbookRecordDepth]
+	}
+	levels := make([]db.OrderbookLevel, len(items))
+	for i := range items {
+		levels[i] = db.OrderbookLevel{Price: items[i].Price, Amount: items[i].Amount}
+	}
+	return levels
+}
+
+// persistKline stores a websocket candle if the exchange records the pair's
+// candles, updates of a candle replace it until it closes
+func persistKline(k exchange.KlineData) {
+	if !recordingEnabled(k.Exchange, k.Pair, db.TableCandles) {
+		return
+	}
+	interval, err := time.ParseDuration(k.Interval)
+	if err != nil || interval <= 0 {
+		return
+	}
+	err = bot.db.UpsertCandles([]db.Candle{{
+		Exchange:  k.Exchange,
+		Pair:      k.Pair.Pair().String(),
+		AssetType: k.AssetType,
+		Interval:  interval,
+		Timestamp: k.StartTime,
+		Open:      k.OpenPrice,
+		High:      k.HighPrice,
+		Low:       k.LowPrice,
+		Close:     k.ClosePrice,
+		Volume:    k.Volume,
+	}})
+	if err != nil {
+		log.Printf("Failed to store %s candle. Err: %s", k.Exchange, err)
+	}
+}
+
+// persistOrder stores the state of an order placed by the bot if the exchange
+// persists its data
+func persistOrder(o ManagedOrder) {
+	if !persistenceEnabled(o.Exchange) {
+		return
+	}
+	err := bot.db.UpsertOrder(db.Order{
+		Exchange:             o.Exchange,
+		OrderID:              o.ID,
+		Pair:                 o.BaseCurrency + o.QuoteCurrency,
+		Side:                 o.OrderSide,
+		Type:                 o.OrderType,
+		Status:               o.Status,
+		Price:                o.Price,
+		Amount:               o.Amount,
+		ExecutedAmount:       o.ExecutedAmount,
+		AverageExecutedPrice: o.AverageExecutedPrice,
+		Strategy:             o.Strategy,
+		Created:              o.Created,
+	})
+	if err != nil {
+		log.Printf("Failed to store %s order %s. Err: %s", o.Exchange, o.ID, err)
+	}
+}
+
+// persistWithdrawals stores the withdrawals in the funding history of an
+// exchange. Withdrawals are identified by their transfer ID, or transaction ID
+// when the exchange has no transfer ID, those with neither are skipped.
+func persistWithdrawals(exch exchange.IBotExchange) error {
+	history, err := exch.GetFundingHistory()
+	if err != nil {
+		return err
+	}
+	for i := range history {
+		if !common.StringContains(common.StringToLower(history[i].TransferType), "withdraw") {
+			continue
+		}
+		id := history[i].CryptoTxID
+		if history[i].TransferID != 0 {
+			id = strconv.FormatInt(history[i].TransferID, 10)
+		}
+		if id == "" {
+			continue
+		}
+		err = bot.db.InsertWithdrawal(db.Withdrawal{
+			Exchange:     exch.GetName(),
+			WithdrawalID: id,
+			Currency:     history[i].Currency,
+			Amount:       history[i].Amount,
+			Fee:          history[i].Fee,
+			Address:      history[i].CryptoToAddress,
+			TxID:         history[i].CryptoTxID,
+			Status:       history[i].Status,
+			Timestamp:    history[i].Timestamp,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WithdrawalHistoryRoutine stores the withdrawal history of enabled
+// authenticated exchanges which persist their data
+func WithdrawalHistoryRoutine() {
+	log.Println("Starting withdrawal history routine.")
+	for {
+		for _, exch := range bot.exchanges {
+			if exch == nil || !exch.IsEnabled() || !exch.GetAuthenticatedAPISupport() ||
+				!exch.GetCapabilities().CanGetFundingHistory || !persistenceEnabled(exch.GetName()) {
+				continue
+			}
+			err := persistWithdrawals(exch)
+			if err != nil {
+				log.Printf("Failed to store %s withdrawal history. Err: %s",
+					exch.GetName(), err)
+			}
+		}
+		time.Sleep(withdrawalHistoryInterval)
+	}
+}
+
+// pruneMarketData deletes the market data past its retention window and
+// compacts the database when rows were deleted and compaction is enabled.
+// With an archive interval, trades and orderbook snapshots are archived before
+// they are pruned and their retention is rounded down to the interval so each
+// archived interval is complete. It returns the number of rows deleted.
+func pruneMarketData(s db.Storage, r config.RetentionConfig, now time.Time) (int64, error) {
+	var archive time.Duration
+	if r.ArchiveInterval != "" {
+		var err error
+		archive, err = time.ParseDuration(r.ArchiveInterval)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	var total int64
+	for table, retention := range map[string]string{
+		db.TableTickers:    r.Tickers,
+		db.TableTrades:     r.Trades,
+		db.TableOrderbooks: r.Orderbooks,
+		db.TableCandles:    r.Candles,
+	} {
+		if retention == "" {
+			continue
+		}
+		d, err := time.ParseDuration(retention)
+		if err != nil {
+			return total, err
+		}
+		before := now.Add(-d)
+		if archive > 0 && (table == db.TableTrades || table == db.TableOrderbooks) {
+			before = before.Truncate(archive)
+			if err = archiveMarketData(s, table, archive, before); err != nil {
+				return total, err
+			}
+		}
+		deleted, err := s.Prune(table, before)
+		if err != nil {
+			return total, err
+		}
+		total += deleted
+	}
+	if total > 0 && r.Compact {
+		return total, s.Compact()
+	}
+	return total, nil
+}
+
+// archiveMarketData stores the candles and summaries at an interval of the
+// trades or orderbook snapshots stored before a time. Candles already stored
+// at the interval, such as those streamed from the exchange, are kept.
+func archiveMarketData(s db.Storage, table string, interval time.Duration, before time.Time) error {
+	if table == db.TableOrderbooks {
+		books, err := s.Orderbooks("", "", time.Time{}, before)
+		if err != nil || len(books) == 0 {
+			return err
+		}
+		return s.UpsertOrderbookSummaries(db.ArchiveOrderbooks(books, interval))
+	}
+
+	trades, err := s.Trades("", "", time.Time{}, before)
+	if err != nil || len(trades) == 0 {
+		return err
+	}
+	candles, summaries := db.ArchiveTrades(trades, interval)
+	stored, err := s.Candles("", "", interval, candles[0].Timestamp, before)
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]bool, len(stored))
+	for i := range stored {
+		existing[archiveKey(&stored[i])] = true
+	}
+	var archived []db.Candle
+	for i := range candles {
+		if !existing[archiveKey(&candles[i])] {
+			archived = append(archived, candles[i])
+		}
+	}
+	if len(archived) > 0 {
+		if err = s.UpsertCandles(archived); err != nil {
+			return err
+		}
+	}
+	return s.UpsertTradeSummaries(summaries)
+}
+
+// archiveKey identifies the exchange currency pair and open time of a candle
+func archiveKey(c *db.Candle) string {
+	return c.Exchange + " " + c.Pair + " " + c.Timestamp.UTC().Format(time.RFC3339)
+}
+
+// DataRetentionRoutine archives and prunes market data past its retention
+// window every prune interval
+func DataRetentionRoutine() {
+	r := bot.config.Database.Retention
+	interval, err := time.ParseDuration(r.PruneInterval)
+	if err != nil || interval <= 0 {
+		log.Printf("Data retention routine disabled, invalid prune interval %q.", r.PruneInterval)
+		return
+	}
+	log.Println("Starting data retention routine.")
+	for {
+		deleted, err := pruneMarketData(bot.db, r, time.Now())
+		if err != nil {
+			log.Printf("Failed to prune market data. Err: %s", err)
+		} else if deleted > 0 {
+			log.Printf("Pruned %d market data rows.", deleted)
+		}
+		time.Sleep(interval)
+	}
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	"github.com/thrasher-/gocryptotrader/db"
+)
+
+func TestRecordsPair(t *testing.T) {
+	btcusd := pair.NewCurrencyPair("BTC", "USD")
+	ethusd := pair.NewCurrencyPair("ETH", "USD")
+	exchCfg := config.ExchangeConfig{
+		ConfigCurrencyPairFormat: &config.CurrencyPairFormatConfig{Delimiter: "-", Uppercase: true},
+	}
+
+	if !recordsPair(&exchCfg, ethusd, db.TableTrades) || recordsPair(&exchCfg, ethusd, db.TableOrderbooks) {
+		t.Error("Test failed. recordsPair expected tickers and trades of every pair without a recording config")
+	}
+
+	exchCfg.Recording = &config.RecordingConfig{Pairs: "BTC-USD", Orderbooks: true}
+	if !recordsPair(&exchCfg, btcusd, db.TableOrderbooks) {
+		t.Error("Test failed. recordsPair expected BTC-USD orderbooks recorded")
+	}
+	if recordsPair(&exchCfg, ethusd, db.TableOrderbooks) {
+		t.Error("Test failed. recordsPair expected unlisted pairs not recorded")
+	}
+	if recordsPair(&exchCfg, btcusd, db.TableTrades) {
+		t.Error("Test failed. recordsPair expected disabled data types not recorded")
+	}
+}
+
+// pruneStorage records the tables pruned
+type pruneStorage struct {
+	db.Storage
+	pruned    map[string]time.Time
+	compacted bool
+}
+
+func (p *pruneStorage) Prune(table string, before time.Time) (int64, error) {
+	p.pruned[table] = before
+	return 2, nil
+}
+
+func (p *pruneStorage) Compact() error {
+	p.compacted = true
+	return nil
+}
+
+func TestPruneMarketData(t *testing.T) {
+	s := &pruneStorage{pruned: make(map[string]time.Time)}
+	now := time.Now()
+	deleted, err := pruneMarketData(s, config.RetentionConfig{Trades: "24h", Orderbooks: "1h", Compact: true}, now)
+	if err != nil || deleted != 4 {
+		t.Fatal("Test failed. pruneMarketData unexpected result", deleted, err)
+	}
+	if len(s.pruned) != 2 || !s.pruned[db.TableTrades].Equal(now.Add(-24*time.Hour)) ||
+		!s.pruned[db.TableOrderbooks].Equal(now.Add(-time.Hour)) {
+		t.Error("Test failed. pruneMarketData unexpected tables pruned", s.pruned)
+	}
+	if !s.compacted {
+		t.Error("Test failed. pruneMarketData expected the database compacted")
+	}
+
+	s = &pruneStorage{pruned: make(map[string]time.Time)}
+	if _, err = pruneMarketData(s, config.RetentionConfig{Compact: true}, now); err != nil || len(s.pruned) != 0 || s.compacted {
+		t.Error("Test failed. pruneMarketData expected nothing pruned without retention", s.pruned, err)
+	}
+}
+
+func TestArchiveMarketData(t *testing.T) {
+	s, err := db.Open(db.Config{Driver: db.DriverSQLite, Database: ":memory:"})
+	if err != nil {
+		t.Skip("sqlite unavailable", err)
+	}
+	defer s.Close()
+
+	now := time.Date(2018, 9, 20, 12, 0, 30, 0, time.UTC)
+	for i, offset := range []time.Duration{-3 * time.Minute, -150 * time.Second, -90 * time.Second, -10 * time.Second} {
+		err = s.InsertTrade(db.Trade{Exchange: "Bitstamp", Pair: "BTCUSD", Side: "buy",
+			Price: 100 + float64(i), Amount: 1, Timestamp: now.Add(offset)})
+		if err != nil {
+			t.Fatal("Test failed. InsertTrade error", err)
+		}
+	}
+	err = s.InsertOrderbook(db.Orderbook{Exchange: "Bitstamp", Pair: "BTCUSD", Timestamp: now.Add(-2 * time.Minute),
+		Bids: []db.OrderbookLevel{{Price: 99, Amount: 1}}, Asks: []db.OrderbookLevel{{Price: 101, Amount: 1}}})
+	if err != nil {
+		t.Fatal("Test failed. InsertOrderbook error", err)
+	}
+	// An exchange candle of the second interval is kept
+	exchangeCandle := db.Candle{Exchange: "Bitstamp", Pair: "BTCUSD", Interval: time.Minute,
+		Timestamp: now.Add(-150 * time.Second).Truncate(time.Minute), Close: 500}
+	if err = s.UpsertCandles([]db.Candle{exchangeCandle}); err != nil {
+		t.Fatal("Test failed. UpsertCandles error", err)
+	}
+
+	deleted, err := pruneMarketData(s, config.RetentionConfig{Trades: "1m", Orderbooks: "1m", ArchiveInterval: "1m"}, now)
+	if err != nil || deleted != 3 {
+		t.Fatal("Test failed. pruneMarketData unexpected result", deleted, err)
+	}
+	// Retention ends within the third interval, its trades are kept until it
+	// is complete
+	if trades, _ := s.Trades("", "", time.Time{}, time.Time{}); len(trades) != 2 {
+		t.Error("Test failed. pruneMarketData expected the incomplete interval's trades kept", trades)
+	}
+
+	candles, err := s.Candles("Bitstamp", "BTCUSD", time.Minute, time.Time{}, time.Time{})
+	if err != nil || len(candles) != 2 || candles[0].Close != 100 || candles[1].Close != 500 {
+		t.Error("Test failed. pruneMarketData unexpected archived candles", candles, err)
+	}
+	summaries, err := s.TradeSummaries("Bitstamp", "BTCUSD", time.Minute, time.Time{}, time.Time{})
+	if err != nil || len(summaries) != 2 || summaries[1].Trades != 1 || summaries[1].VWAP != 101 {
+		t.Error("Test failed. pruneMarketData unexpected trade summaries", summaries, err)
+	}
+	books, err := s.OrderbookSummaries("Bitstamp", "BTCUSD", time.Minute, time.Time{}, time.Time{})
+	if err != nil || len(books) != 1 || books[0].MidClose != 100 || books[0].AverageSpread != 2 {
+		t.Error("Test failed. pruneMarketData unexpected orderbook summaries", books, err)
+	}
+}
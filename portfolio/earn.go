@@ -0,0 +1,115 @@
+package portfolio
+
+import (
+	"time"
+)
+
+// secondsPerYear is used to annualise interest accrued by earn products
+const secondsPerYear = 365 * 24 * 60 * 60
+
+// EarnProduct is an exchange savings or staking product balance tracked over
+// time. AccruedInterest is the cumulative interest reported by the exchange,
+// Interest is the interest accrued since tracking began at Since and
+// TimeWeightedBalance is the average balance over the same period.
+// RealisedAPR annualises Interest against TimeWeightedBalance, APR is the
+// product's current rate.
+type EarnProduct struct {
+	Exchange            string    `json:"exchange"`
+	ProductID           string    `json:"product_id"`
+	ProductType         string    `json:"product_type"`
+	Coin                string    `json:"coin"`
+	Balance             float64   `json:"balance"`
+	APR                 float64   `json:"apr"`
+	AccruedInterest     float64   `json:"accrued_interest"`
+	Interest            float64   `json:"interest"`
+	TimeWeightedBalance float64   `json:"time_weighted_balance"`
+	RealisedAPR         float64   `json:"realised_apr"`
+	Since               time.Time `json:"since"`
+	Updated             time.Time `json:"updated"`
+
+	balanceSeconds float64
+}
+
+// accrue updates the product with a new balance at t
+func (e *EarnProduct) accrue(update EarnProduct, t time.Time) {
+	if elapsed := t.Sub(e.Updated).Seconds(); elapsed > 0 {
+		e.balanceSeconds += e.Balance * elapsed
+		e.Updated = t
+	}
+	// Cumulative interest which decreases has been reset by the exchange, so
+	// only increases are accrued
+	if update.AccruedInterest > e.AccruedInterest {
+		e.Interest += update.AccruedInterest - e.AccruedInterest
+	}
+	e.AccruedInterest = update.AccruedInterest
+	e.Balance = update.Balance
+	e.APR = update.APR
+	e.ProductType = update.ProductType
+
+	e.TimeWeightedBalance = e.Balance
+	e.RealisedAPR = 0
+	if tracked := e.Updated.Sub(e.Since).Seconds(); tracked > 0 {
+		e.TimeWeightedBalance = e.balanceSeconds / tracked
+		if e.TimeWeightedBalance > 0 {
+			e.RealisedAPR = e.Interest / e.TimeWeightedBalance * secondsPerYear / tracked
+		}
+	}
+}
+
+// UpdateEarnProducts updates the earn products of an exchange at t. Tracked
+// products of the exchange which are not supplied or have no balance are
+// removed.
+func (p *Base) UpdateEarnProducts(exchangeName string, products []EarnProduct, t time.Time) {
+	var updated []EarnProduct
+	for x := range p.Earn {
+		if p.Earn[x].Exchange != exchangeName {
+			updated = append(updated, p.Earn[x])
+		}
+	}
+
+	for _, product := range products {
+		if product.Balance <= 0 {
+			continue
+		}
+		tracked := EarnProduct{
+			Exchange:        exchangeName,
+			ProductID:       product.ProductID,
+			Coin:            product.Coin,
+			AccruedInterest: product.AccruedInterest,
+			Since:           t,
+			Updated:         t,
+		}
+		for x := range p.Earn {
+			if p.Earn[x].Exchange == exchangeName &&
+				p.Earn[x].ProductID == product.ProductID &&
+				p.Earn[x].Coin == product.Coin {
+				tracked = p.Earn[x]
+				break
+			}
+		}
+		tracked.accrue(product, t)
+		updated = append(updated, tracked)
+	}
+	p.Earn = updated
+}
+
+// GetEarnProducts returns the tracked earn products of an exchange, or of
+// every exchange when exchangeName is empty
+func (p *Base) GetEarnProducts(exchangeName string) []EarnProduct {
+	var result []EarnProduct
+	for x := range p.Earn {
+		if exchangeName == "" || p.Earn[x].Exchange == exchangeName {
+			result = append(result, p.Earn[x])
+		}
+	}
+	return result
+}
+
+// GetEarnPortfolio returns the earn product balances by coin
+func (p *Base) GetEarnPortfolio() map[string]float64 {
+	result := make(map[string]float64)
+	for x := range p.Earn {
+		result[p.Earn[x].Coin] += p.Earn[x].Balance
+	}
+	return result
+}
@@ -0,0 +1,78 @@
+package portfolio
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestUpdateEarnProducts(t *testing.T) {
+	var p Base
+	start := time.Date(2018, 9, 1, 0, 0, 0, 0, time.UTC)
+	p.UpdateEarnProducts("Binance", []EarnProduct{
+		{ProductID: "USDT001", ProductType: "Flexible", Coin: "USDT", Balance: 1000, AccruedInterest: 5, APR: 0.1},
+		{ProductID: "BNB001", ProductType: "Locked", Coin: "BNB", Balance: 0},
+	}, start)
+	p.UpdateEarnProducts("OKX", []EarnProduct{
+		{ProductID: "savings-USDT", ProductType: "Flexible", Coin: "USDT", Balance: 500, APR: 0.05},
+	}, start)
+
+	products := p.GetEarnProducts("Binance")
+	if len(products) != 1 || products[0].Interest != 0 || products[0].TimeWeightedBalance != 1000 {
+		t.Fatal("Test Failed - UpdateEarnProducts() incorrect initial product", products)
+	}
+
+	// Balance doubles half way through a year, accruing 150 interest
+	p.UpdateEarnProducts("Binance", []EarnProduct{
+		{ProductID: "USDT001", ProductType: "Flexible", Coin: "USDT", Balance: 2000, AccruedInterest: 55, APR: 0.1},
+	}, start.Add(secondsPerYear/2*time.Second))
+	p.UpdateEarnProducts("Binance", []EarnProduct{
+		{ProductID: "USDT001", ProductType: "Flexible", Coin: "USDT", Balance: 2150, AccruedInterest: 155, APR: 0.1},
+	}, start.Add(secondsPerYear*time.Second))
+
+	products = p.GetEarnProducts("Binance")
+	if len(products) != 1 {
+		t.Fatal("Test Failed - GetEarnProducts() expected one product", products)
+	}
+	e := products[0]
+	if e.Interest != 150 || e.AccruedInterest != 155 || e.Balance != 2150 {
+		t.Error("Test Failed - UpdateEarnProducts() incorrect interest", e)
+	}
+	if e.TimeWeightedBalance != 1500 {
+		t.Error("Test Failed - UpdateEarnProducts() incorrect time weighted balance", e.TimeWeightedBalance)
+	}
+	if math.Abs(e.RealisedAPR-0.1) > 1e-9 {
+		t.Error("Test Failed - UpdateEarnProducts() incorrect realised APR", e.RealisedAPR)
+	}
+
+	if len(p.GetEarnProducts("")) != 2 {
+		t.Error("Test Failed - GetEarnProducts() expected products of every exchange")
+	}
+	if p.GetEarnPortfolio()["USDT"] != 2650 {
+		t.Error("Test Failed - GetEarnPortfolio() incorrect balance", p.GetEarnPortfolio())
+	}
+
+	p.UpdateEarnProducts("Binance", nil, start.Add(2*secondsPerYear*time.Second))
+	if len(p.GetEarnProducts("Binance")) != 0 || len(p.GetEarnProducts("OKX")) != 1 {
+		t.Error("Test Failed - UpdateEarnProducts() expected redeemed products removed")
+	}
+}
+
+func TestGetPortfolioSummaryEarn(t *testing.T) {
+	var p Base
+	p.AddExchangeAddress("Binance", "USDT", 100)
+	p.UpdateEarnProducts("Binance", []EarnProduct{
+		{ProductID: "USDT001", Coin: "USDT", Balance: 300},
+	}, time.Now())
+
+	summary := p.GetPortfolioSummary()
+	if len(summary.Totals) != 1 || summary.Totals[0].Balance != 400 {
+		t.Error("Test Failed - GetPortfolioSummary() expected earn balance in totals", summary.Totals)
+	}
+	if len(summary.Earn) != 1 || summary.Earn[0].Percentage != 75 {
+		t.Error("Test Failed - GetPortfolioSummary() incorrect earn coins", summary.Earn)
+	}
+	if len(summary.EarnProducts) != 1 || summary.EarnProducts[0].ProductID != "USDT001" {
+		t.Error("Test Failed - GetPortfolioSummary() incorrect earn products", summary.EarnProducts)
+	}
+}
@@ -280,6 +280,7 @@ func getPercentageSpecific(input float64, target string, totals map[string]float
 func (p *Base) GetPortfolioSummary() Summary {
 	personalHoldings := p.GetPersonalPortfolio()
 	exchangeHoldings := p.GetExchangePortfolio()
+	earnHoldings := p.GetEarnPortfolio()
 	totalCoins := make(map[string]float64)
 
 	for x, y := range personalHoldings {
@@ -295,6 +296,10 @@ func (p *Base) GetPortfolioSummary() Summary {
 		}
 	}
 
+	for x, y := range earnHoldings {
+		totalCoins[x] += y
+	}
+
 	var portfolioOutput Summary
 	for x, y := range totalCoins {
 		coins := Coin{Coin: x, Balance: y}
@@ -319,6 +324,17 @@ func (p *Base) GetPortfolioSummary() Summary {
 		portfolioOutput.Online = append(portfolioOutput.Online, coins)
 	}
 
+	for x, y := range earnHoldings {
+		coins := Coin{
+			Coin:       x,
+			Balance:    y,
+			Percentage: getPercentage(earnHoldings, x, totalCoins),
+		}
+		portfolioOutput.Earn = append(portfolioOutput.Earn, coins)
+	}
+	portfolioOutput.EarnProducts = p.GetEarnProducts("")
+	portfolioOutput.Staking = p.GetStakingPositions("")
+
 	var portfolioExchanges []string
 	for _, x := range p.Addresses {
 		if x.Description == PortfolioAddressExchange {
@@ -3,6 +3,8 @@ package portfolio
 // Base holds the portfolio base addresses
 type Base struct {
 	Addresses []Address
+	Earn      []EarnProduct     `json:"-"`
+	Staking   []StakingPosition `json:"-"`
 }
 
 // Address sub type holding address information for portfolio
@@ -114,4 +116,7 @@ type Summary struct {
 	OfflineSummary map[string][]OfflineCoinSummary         `json:"offline_summary"`
 	Online         []Coin                                  `json:"coins_online"`
 	OnlineSummary  map[string]map[string]OnlineCoinSummary `json:"online_summary"`
+	Earn           []Coin                                  `json:"coins_earn"`
+	EarnProducts   []EarnProduct                           `json:"earn_products"`
+	Staking        []StakingPosition                       `json:"staking_positions"`
 }
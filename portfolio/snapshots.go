@@ -0,0 +1,166 @@
+package portfolio
+
+import (
+	"bufio"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+)
+
+// SnapshotFile is the default file name of the valuation snapshots in the
+// data directory
+const SnapshotFile = "portfolio_snapshots.json"
+
+// DefaultMaxSnapshots is the number of snapshots kept when no limit is set,
+// thirty days of snapshots every five minutes
+const DefaultMaxSnapshots = 8640
+
+// Snapshots persists the portfolio's valuations to an append only file of
+// JSON lines, keeping the latest max. The file is rewritten with the kept
+// snapshots once it holds twice as many. Snapshots with an empty path are
+// held in memory only.
+type Snapshots struct {
+	path      string
+	max       int
+	written   int
+	snapshots []Valuation
+	m         sync.Mutex
+}
+
+// NewSnapshots returns in memory snapshots keeping the latest max
+func NewSnapshots(max int) *Snapshots {
+	if max <= 0 {
+		max = DefaultMaxSnapshots
+	}
+	return &Snapshots{max: max}
+}
+
+// OpenSnapshots loads the snapshots at path, creating the file when it does
+// not exist
+func OpenSnapshots(path string, max int) (*Snapshots, error) {
+	s := NewSnapshots(max)
+	s.path = path
+	f, err := os.OpenFile(path, os.O_RDONLY|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1024*1024)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var v Valuation
+		err = common.JSONDecode(scanner.Bytes(), &v)
+		if err != nil {
+			return nil, err
+		}
+		s.written++
+		s.append(v)
+	}
+	return s, scanner.Err()
+}
+
+// Add records a valuation
+func (s *Snapshots) Add(v Valuation) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.append(v)
+	if s.path == "" {
+		return nil
+	}
+	if s.written >= 2*s.max {
+		return s.rewrite()
+	}
+
+	data, err := common.JSONEncode(v)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if _, err = f.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	s.written++
+	return nil
+}
+
+// Latest returns the most recent snapshot
+func (s *Snapshots) Latest() (Valuation, bool) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if len(s.snapshots) == 0 {
+		return Valuation{}, false
+	}
+	return s.snapshots[len(s.snapshots)-1], true
+}
+
+// Between returns the snapshots taken from start until end in time order, a
+// zero end is now
+func (s *Snapshots) Between(start, end time.Time) []Valuation {
+	s.m.Lock()
+	defer s.m.Unlock()
+	var result []Valuation
+	for i := range s.snapshots {
+		t := s.snapshots[i].Time
+		if t.Before(start) || (!end.IsZero() && !t.Before(end)) {
+			continue
+		}
+		result = append(result, s.snapshots[i])
+	}
+	return result
+}
+
+// append adds a snapshot in time order, dropping the oldest beyond max. The
+// mutex must be held by the caller.
+func (s *Snapshots) append(v Valuation) {
+	i := len(s.snapshots)
+	for i > 0 && s.snapshots[i-1].Time.After(v.Time) {
+		i--
+	}
+	s.snapshots = append(s.snapshots, Valuation{})
+	copy(s.snapshots[i+1:], s.snapshots[i:])
+	s.snapshots[i] = v
+	if len(s.snapshots) > s.max {
+		s.snapshots = append([]Valuation(nil), s.snapshots[len(s.snapshots)-s.max:]...)
+	}
+}
+
+// rewrite replaces the file with the kept snapshots. The mutex must be held
+// by the caller.
+func (s *Snapshots) rewrite() error {
+	tmp := s.path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	for i := range s.snapshots {
+		data, err := common.JSONEncode(s.snapshots[i])
+		if err != nil {
+			f.Close()
+			return err
+		}
+		w.Write(append(data, '\n'))
+	}
+	if err = w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	if err = os.Rename(tmp, s.path); err != nil {
+		return err
+	}
+	s.written = len(s.snapshots)
+	return nil
+}
@@ -0,0 +1,68 @@
+package portfolio
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSnapshots(t *testing.T) {
+	dir, err := ioutil.TempDir("", "portfolio")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, SnapshotFile)
+
+	s, err := OpenSnapshots(path, 3)
+	if err != nil {
+		t.Fatal("Test Failed - OpenSnapshots() error", err)
+	}
+	if _, ok := s.Latest(); ok {
+		t.Error("Test Failed - Latest() expected no snapshots")
+	}
+
+	start := time.Date(2018, 9, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 7; i++ {
+		err = s.Add(Valuation{Time: start.Add(time.Duration(i) * time.Hour), Total: float64(i)})
+		if err != nil {
+			t.Fatal("Test Failed - Add() error", err)
+		}
+	}
+
+	// Reopening keeps the latest three snapshots
+	s, err = OpenSnapshots(path, 3)
+	if err != nil {
+		t.Fatal("Test Failed - OpenSnapshots() error", err)
+	}
+	latest, ok := s.Latest()
+	if !ok || latest.Total != 6 {
+		t.Error("Test Failed - Latest() incorrect snapshot", latest)
+	}
+	if between := s.Between(start, start.Add(6*time.Hour)); len(between) != 2 || between[0].Total != 4 {
+		t.Error("Test Failed - Between() incorrect snapshots", between)
+	}
+	if all := s.Between(time.Time{}, time.Time{}); len(all) != 3 {
+		t.Error("Test Failed - Between() expected all snapshots", all)
+	}
+
+	// The file is rewritten with the kept snapshots once it holds twice as many
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Count(string(data), "\n"); lines != 3 {
+		t.Error("Test Failed - Add() expected file rewritten, lines:", lines)
+	}
+
+	// Snapshots recorded out of order are kept in time order
+	m := NewSnapshots(0)
+	m.Add(Valuation{Time: start.Add(time.Hour), Total: 1})
+	m.Add(Valuation{Time: start, Total: 0})
+	if latest, _ = m.Latest(); latest.Total != 1 {
+		t.Error("Test Failed - Add() expected time order", latest)
+	}
+}
@@ -0,0 +1,74 @@
+package portfolio
+
+import (
+	"time"
+)
+
+// StakingPosition is a tracked exchange staking position. Unlock is zero for
+// positions which can be unstaked at any time. Staked balances are valued by
+// the earn products of the exchange, positions track their unlock dates.
+type StakingPosition struct {
+	Exchange   string    `json:"exchange"`
+	PositionID string    `json:"position_id"`
+	ProductID  string    `json:"product_id"`
+	Coin       string    `json:"coin"`
+	Amount     float64   `json:"amount"`
+	APR        float64   `json:"apr"`
+	Start      time.Time `json:"start"`
+	Unlock     time.Time `json:"unlock,omitempty"`
+
+	reminded bool
+}
+
+// UpdateStakingPositions replaces the tracked staking positions of an
+// exchange, positions which have already been reminded of their unlock are
+// not reminded again
+func (p *Base) UpdateStakingPositions(exchangeName string, positions []StakingPosition) {
+	var updated []StakingPosition
+	for x := range p.Staking {
+		if p.Staking[x].Exchange != exchangeName {
+			updated = append(updated, p.Staking[x])
+		}
+	}
+
+	for _, position := range positions {
+		position.Exchange = exchangeName
+		for x := range p.Staking {
+			if p.Staking[x].Exchange == exchangeName &&
+				p.Staking[x].PositionID == position.PositionID {
+				position.reminded = p.Staking[x].reminded &&
+					p.Staking[x].Unlock.Equal(position.Unlock)
+				break
+			}
+		}
+		updated = append(updated, position)
+	}
+	p.Staking = updated
+}
+
+// GetStakingPositions returns the tracked staking positions of an exchange, or
+// of every exchange when exchangeName is empty
+func (p *Base) GetStakingPositions(exchangeName string) []StakingPosition {
+	var result []StakingPosition
+	for x := range p.Staking {
+		if exchangeName == "" || p.Staking[x].Exchange == exchangeName {
+			result = append(result, p.Staking[x])
+		}
+	}
+	return result
+}
+
+// GetUnlockReminders returns the staking positions which unlock within window
+// of now and have not been returned before
+func (p *Base) GetUnlockReminders(now time.Time, window time.Duration) []StakingPosition {
+	var result []StakingPosition
+	for x := range p.Staking {
+		if p.Staking[x].reminded || p.Staking[x].Unlock.IsZero() ||
+			p.Staking[x].Unlock.Sub(now) > window {
+			continue
+		}
+		p.Staking[x].reminded = true
+		result = append(result, p.Staking[x])
+	}
+	return result
+}
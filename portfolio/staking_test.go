@@ -0,0 +1,45 @@
+package portfolio
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStakingPositions(t *testing.T) {
+	var p Base
+	now := time.Date(2018, 9, 1, 0, 0, 0, 0, time.UTC)
+	p.UpdateStakingPositions("Binance", []StakingPosition{
+		{PositionID: "1", Coin: "BNB", Amount: 10, Unlock: now.Add(12 * time.Hour)},
+		{PositionID: "2", Coin: "BNB", Amount: 5, Unlock: now.Add(72 * time.Hour)},
+		{PositionID: "3", Coin: "BNB", Amount: 1},
+	})
+	p.UpdateStakingPositions("OKX", []StakingPosition{
+		{PositionID: "A", Coin: "ETH", Amount: 2, Unlock: now.Add(time.Hour)},
+	})
+
+	if len(p.GetStakingPositions("Binance")) != 3 || len(p.GetStakingPositions("")) != 4 {
+		t.Fatal("Test Failed - UpdateStakingPositions() incorrect positions", p.Staking)
+	}
+
+	reminders := p.GetUnlockReminders(now, 24*time.Hour)
+	if len(reminders) != 2 || reminders[0].PositionID != "1" || reminders[1].Exchange != "OKX" {
+		t.Fatal("Test Failed - GetUnlockReminders() incorrect reminders", reminders)
+	}
+	if len(p.GetUnlockReminders(now, 24*time.Hour)) != 0 {
+		t.Error("Test Failed - GetUnlockReminders() expected reminders returned once")
+	}
+
+	// Reminded positions keep their state across updates unless their unlock
+	// date changes
+	p.UpdateStakingPositions("Binance", []StakingPosition{
+		{PositionID: "1", Coin: "BNB", Amount: 10, Unlock: now.Add(12 * time.Hour)},
+		{PositionID: "2", Coin: "BNB", Amount: 5, Unlock: now.Add(20 * time.Hour)},
+	})
+	reminders = p.GetUnlockReminders(now, 24*time.Hour)
+	if len(reminders) != 1 || reminders[0].PositionID != "2" {
+		t.Error("Test Failed - GetUnlockReminders() incorrect reminders after update", reminders)
+	}
+	if len(p.GetStakingPositions("Binance")) != 2 {
+		t.Error("Test Failed - UpdateStakingPositions() expected unstaked position removed")
+	}
+}
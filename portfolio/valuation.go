@@ -0,0 +1,108 @@
+package portfolio
+
+import (
+	"sort"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+)
+
+// Valuation holdings group names
+const (
+	ValuationOnChain = "On-chain"
+	ValuationEarn    = "Earn"
+)
+
+// PriceFunc returns the value of an amount of a coin in the valuation's base
+// currency
+type PriceFunc func(coin string, amount float64) (float64, error)
+
+// CoinValuation is the balance of a coin in a group of holdings and its value
+// in the base currency
+type CoinValuation struct {
+	Coin    string  `json:"coin"`
+	Balance float64 `json:"balance"`
+	Value   float64 `json:"value"`
+}
+
+// HoldingsValuation is the value of a group of holdings, an exchange account,
+// the personal on-chain addresses or the earn products
+type HoldingsValuation struct {
+	Name  string          `json:"name"`
+	Value float64         `json:"value"`
+	Coins []CoinValuation `json:"coins"`
+}
+
+// Valuation is the value of the portfolio in BaseCurrency at Time, in total
+// and by exchange. Unpriced holds the coins which could not be valued, they
+// are included at a value of zero.
+type Valuation struct {
+	Time         time.Time           `json:"time"`
+	BaseCurrency string              `json:"baseCurrency"`
+	Total        float64             `json:"total"`
+	Exchanges    []HoldingsValuation `json:"exchanges"`
+	OnChain      HoldingsValuation   `json:"onChain"`
+	Earn         HoldingsValuation   `json:"earn"`
+	Unpriced     []string            `json:"unpriced,omitempty"`
+}
+
+// Exchange returns the valuation of an exchange's holdings
+func (v *Valuation) Exchange(exchangeName string) (HoldingsValuation, bool) {
+	for i := range v.Exchanges {
+		if v.Exchanges[i].Name == exchangeName {
+			return v.Exchanges[i], true
+		}
+	}
+	return HoldingsValuation{}, false
+}
+
+// Value values the portfolio's exchange balances, personal addresses and earn
+// products in base with price
+func (p *Base) Value(base string, price PriceFunc, t time.Time) Valuation {
+	v := Valuation{Time: t, BaseCurrency: common.StringToUpper(base)}
+	unpriced := make(map[string]bool)
+	value := func(name string, holdings map[string]float64) HoldingsValuation {
+		h := HoldingsValuation{Name: name}
+		for coin, balance := range holdings {
+			c := CoinValuation{Coin: coin, Balance: balance}
+			amount, err := price(coin, balance)
+			if err != nil {
+				unpriced[common.StringToUpper(coin)] = true
+			} else {
+				c.Value = amount
+				h.Value += amount
+			}
+			h.Coins = append(h.Coins, c)
+		}
+		sort.Slice(h.Coins, func(i, j int) bool {
+			return h.Coins[i].Coin < h.Coins[j].Coin
+		})
+		v.Total += h.Value
+		return h
+	}
+
+	exchanges := make(map[string]map[string]float64)
+	for _, x := range p.Addresses {
+		if x.Description != PortfolioAddressExchange {
+			continue
+		}
+		if exchanges[x.Address] == nil {
+			exchanges[x.Address] = make(map[string]float64)
+		}
+		exchanges[x.Address][x.CoinType] += x.Balance
+	}
+	for name, holdings := range exchanges {
+		v.Exchanges = append(v.Exchanges, value(name, holdings))
+	}
+	sort.Slice(v.Exchanges, func(i, j int) bool {
+		return v.Exchanges[i].Name < v.Exchanges[j].Name
+	})
+	v.OnChain = value(ValuationOnChain, p.GetPersonalPortfolio())
+	v.Earn = value(ValuationEarn, p.GetEarnPortfolio())
+
+	for coin := range unpriced {
+		v.Unpriced = append(v.Unpriced, coin)
+	}
+	sort.Strings(v.Unpriced)
+	return v
+}
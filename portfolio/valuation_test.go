@@ -0,0 +1,51 @@
+package portfolio
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestValue(t *testing.T) {
+	p := Base{
+		Addresses: []Address{
+			{Address: "Bitstamp", CoinType: "BTC", Balance: 1, Description: PortfolioAddressExchange},
+			{Address: "Bitstamp", CoinType: "USD", Balance: 500, Description: PortfolioAddressExchange},
+			{Address: "Kraken", CoinType: "XYZ", Balance: 10, Description: PortfolioAddressExchange},
+			{Address: "1JCe8z4jJVNXSjohjM4i9Hh813dLCNx2Sy", CoinType: "BTC", Balance: 2, Description: PortfolioAddressPersonal},
+			{Address: "3Nxwenay9Z8Lc9JBiywExpnEFiLp6Afp8v", CoinType: "BTC", Balance: 0.5, Description: PortfolioAddressPersonal},
+		},
+		Earn: []EarnProduct{{Exchange: "Binance", ProductID: "USDT001", Coin: "USDT", Balance: 1000}},
+	}
+	prices := map[string]float64{"BTC": 6000, "USD": 1, "USDT": 1}
+	price := func(coin string, amount float64) (float64, error) {
+		p, ok := prices[coin]
+		if !ok {
+			return 0, errors.New("no price")
+		}
+		return p * amount, nil
+	}
+
+	now := time.Now()
+	v := p.Value("usd", price, now)
+	if v.BaseCurrency != "USD" || !v.Time.Equal(now) || v.Total != 22500 {
+		t.Errorf("Test Failed - Value() incorrect total %+v", v)
+	}
+	bitstamp, ok := v.Exchange("Bitstamp")
+	if !ok || bitstamp.Value != 6500 || len(bitstamp.Coins) != 2 || bitstamp.Coins[0].Coin != "BTC" {
+		t.Error("Test Failed - Value() incorrect Bitstamp valuation", bitstamp)
+	}
+	kraken, ok := v.Exchange("Kraken")
+	if !ok || kraken.Value != 0 || len(v.Exchanges) != 2 {
+		t.Error("Test Failed - Value() incorrect Kraken valuation", kraken)
+	}
+	if v.OnChain.Value != 15000 || v.OnChain.Coins[0].Balance != 2.5 || v.Earn.Value != 1000 {
+		t.Errorf("Test Failed - Value() incorrect on-chain or earn valuation %+v %+v", v.OnChain, v.Earn)
+	}
+	if len(v.Unpriced) != 1 || v.Unpriced[0] != "XYZ" {
+		t.Error("Test Failed - Value() expected XYZ unpriced", v.Unpriced)
+	}
+	if _, ok = v.Exchange("Huobi"); ok {
+		t.Error("Test Failed - Exchange() expected no Huobi valuation")
+	}
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/portfolio"
+)
+
+const portfolioSyncInterval = time.Minute * 5
+
+// ErrNoPortfolioValuation is returned when the portfolio has not been valued
+var ErrNoPortfolioValuation = errors.New("portfolio has not been valued")
+
+// PortfolioSync periodically pulls the balances of every enabled exchange
+// with authenticated API support into the portfolio, merging them with its
+// on-chain addresses and earn products, and values it in BaseCurrency with
+// Price. Each valuation is recorded in Snapshots when set.
+type PortfolioSync struct {
+	Interval     time.Duration
+	BaseCurrency string
+	Portfolio    *portfolio.Base
+	Price        portfolio.PriceFunc
+	Snapshots    *portfolio.Snapshots
+
+	latest portfolio.Valuation
+	m      sync.Mutex
+}
+
+// NewPortfolioSync returns a new portfolio sync valuing p in base
+func NewPortfolioSync(p *portfolio.Base, base string, price portfolio.PriceFunc, snapshots *portfolio.Snapshots) *PortfolioSync {
+	return &PortfolioSync{
+		Interval:     portfolioSyncInterval,
+		BaseCurrency: common.StringToUpper(base),
+		Portfolio:    p,
+		Price:        price,
+		Snapshots:    snapshots,
+	}
+}
+
+// Sync updates the portfolio with the balances of exchs and values it.
+// Exchanges whose balances cannot be retrieved keep their last known
+// balances. The valuation is returned along with any error recording it.
+func (s *PortfolioSync) Sync(exchs []exchange.IBotExchange) (portfolio.Valuation, error) {
+	var accounts []exchange.AccountInfo
+	for _, exch := range exchs {
+		if exch == nil || !exch.IsEnabled() || !exch.GetAuthenticatedAPISupport() {
+			continue
+		}
+		info, err := exch.GetAccountInfo()
+		if err != nil {
+			log.Printf("Portfolio sync failed to get %s account info. Err: %s",
+				exch.GetName(), err)
+			continue
+		}
+		if info.ExchangeName == "" {
+			info.ExchangeName = exch.GetName()
+		}
+		accounts = append(accounts, info)
+	}
+	SeedExchangeAccountInfo(accounts)
+
+	v := s.Portfolio.Value(s.BaseCurrency, s.Price, time.Now())
+	s.m.Lock()
+	s.latest = v
+	s.m.Unlock()
+	if s.Snapshots == nil {
+		return v, nil
+	}
+	return v, s.Snapshots.Add(v)
+}
+
+// Latest returns the most recent valuation
+func (s *PortfolioSync) Latest() (portfolio.Valuation, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.latest.Time.IsZero() {
+		return portfolio.Valuation{}, ErrNoPortfolioValuation
+	}
+	return s.latest, nil
+}
+
+// Run syncs the portfolio with the bot's exchanges every interval
+func (s *PortfolioSync) Run() {
+	log.Println("Starting portfolio sync routine.")
+	for {
+		v, err := s.Sync(bot.exchanges)
+		if err != nil {
+			log.Printf("Portfolio sync failed to record valuation snapshot. Err: %s", err)
+		}
+		if len(v.Unpriced) > 0 {
+			log.Printf("Portfolio sync unable to value %s in %s.",
+				common.JoinStrings(v.Unpriced, ", "), v.BaseCurrency)
+		}
+		time.Sleep(s.Interval)
+	}
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/portfolio"
+)
+
+// testPortfolioExchange returns a queued account balance
+type testPortfolioExchange struct {
+	exchange.IBotExchange
+	name string
+	info exchange.AccountInfo
+	err  error
+}
+
+func (e *testPortfolioExchange) GetName() string {
+	return e.name
+}
+
+func (e *testPortfolioExchange) IsEnabled() bool {
+	return true
+}
+
+func (e *testPortfolioExchange) GetAuthenticatedAPISupport() bool {
+	return true
+}
+
+func (e *testPortfolioExchange) GetAccountInfo() (exchange.AccountInfo, error) {
+	return e.info, e.err
+}
+
+func testPortfolioPrice(coin string, amount float64) (float64, error) {
+	switch coin {
+	case "USD":
+		return amount, nil
+	case "BTC":
+		return amount * 6000, nil
+	}
+	return 0, errors.New("no price")
+}
+
+func TestPortfolioSync(t *testing.T) {
+	p := portfolio.GetPortfolio()
+	addresses := p.Addresses
+	defer func() { p.Addresses = addresses }()
+	p.Addresses = []portfolio.Address{
+		{Address: "PortfolioSyncB", CoinType: "BTC", Balance: 2, Description: portfolio.PortfolioAddressExchange},
+		{Address: "1JCe8z4jJVNXSjohjM4i9Hh813dLCNx2Sy", CoinType: "BTC", Balance: 1, Description: portfolio.PortfolioAddressPersonal},
+	}
+
+	a := &testPortfolioExchange{name: "PortfolioSyncA", info: exchange.AccountInfo{
+		Currencies: []exchange.AccountCurrencyInfo{
+			{CurrencyName: "USD", TotalValue: 900, Hold: 100},
+			{CurrencyName: "XYZ", TotalValue: 5},
+		},
+	}}
+	b := &testPortfolioExchange{name: "PortfolioSyncB", err: errors.New("invalid API key")}
+
+	s := NewPortfolioSync(p, "usd", testPortfolioPrice, portfolio.NewSnapshots(0))
+	if _, err := s.Latest(); err != ErrNoPortfolioValuation {
+		t.Error("Test failed. Latest() expected no valuation error", err)
+	}
+	v, err := s.Sync([]exchange.IBotExchange{a, b, nil})
+	if err != nil {
+		t.Fatal("Test failed. Sync() error", err)
+	}
+	if v.BaseCurrency != "USD" || v.Total != 19000 || len(v.Exchanges) != 2 ||
+		len(v.Unpriced) != 1 || v.Unpriced[0] != "XYZ" {
+		t.Errorf("Test failed. Sync() incorrect valuation %+v", v)
+	}
+	// The exchange which failed keeps its last known balance
+	if h, ok := v.Exchange("PortfolioSyncB"); !ok || h.Value != 12000 {
+		t.Error("Test failed. Sync() expected last known PortfolioSyncB balance", h)
+	}
+	if h, ok := v.Exchange("PortfolioSyncA"); !ok || h.Value != 1000 {
+		t.Error("Test failed. Sync() expected PortfolioSyncA balance", h)
+	}
+
+	latest, err := s.Latest()
+	if err != nil || latest.Total != v.Total {
+		t.Error("Test failed. Latest() incorrect valuation", latest, err)
+	}
+	if snapshot, ok := s.Snapshots.Latest(); !ok || snapshot.Total != v.Total {
+		t.Error("Test failed. Sync() expected snapshot recorded", snapshot)
+	}
+}
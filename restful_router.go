@@ -100,6 +100,78 @@ func NewRouter(exchanges []exchange.IBotExchange) *mux.Router {
 			"/exchanges/{exchangeName}/orderbook/latest/{currency}",
 			RESTGetOrderbook,
 		},
+		Route{
+			"AllExchangesAvailability",
+			"GET",
+			"/exchanges/availability",
+			RESTGetAvailability,
+		},
+		Route{
+			"IndividualExchangeAvailability",
+			"GET",
+			"/exchanges/{exchangeName}/availability",
+			RESTGetAvailability,
+		},
+		Route{
+			"DataQuality",
+			"GET",
+			"/exchanges/dataquality",
+			RESTGetDataQuality,
+		},
+		Route{
+			"OrderLatency",
+			"GET",
+			"/exchanges/latency",
+			RESTGetOrderLatency,
+		},
+		Route{
+			"AllExchangesOrders",
+			"GET",
+			"/exchanges/orders",
+			RESTGetOrders,
+		},
+		Route{
+			"IndividualExchangeOrders",
+			"GET",
+			"/exchanges/{exchangeName}/orders",
+			RESTGetOrders,
+		},
+		Route{
+			"Volatility",
+			"GET",
+			"/exchanges/{exchangeName}/volatility/{currency}",
+			RESTGetVolatility,
+		},
+		Route{
+			"JournalSessions",
+			"GET",
+			"/journal/sessions",
+			RESTGetJournalSessions,
+		},
+		Route{
+			"StartJournalSession",
+			"POST",
+			"/journal/sessions",
+			RESTStartJournalSession,
+		},
+		Route{
+			"ExportJournalSession",
+			"GET",
+			"/journal/sessions/{session}/export",
+			RESTExportJournalSession,
+		},
+		Route{
+			"AnnotateJournalEntry",
+			"POST",
+			"/journal/entries/{id}",
+			RESTAnnotateJournalEntry,
+		},
+		Route{
+			"Metrics",
+			"GET",
+			"/metrics",
+			RESTGetMetrics,
+		},
 		Route{
 			"ws",
 			"GET",
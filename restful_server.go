@@ -4,11 +4,17 @@ import (
 	"encoding/json"
 	"log"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	"github.com/thrasher-/gocryptotrader/dust"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/latency"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/quality"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
 )
 
@@ -180,6 +186,264 @@ func RESTGetPortfolio(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// defaultAvailabilityWindow is the report window when none is requested
+const defaultAvailabilityWindow = time.Hour * 24
+
+// availabilityWindow returns the report window of a request, either start and
+// end in RFC3339 or a window duration ending now
+func availabilityWindow(r *http.Request) (time.Time, time.Time, error) {
+	query := r.URL.Query()
+	end := time.Now()
+	if v := query.Get("end"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		end = t
+	}
+	if v := query.Get("start"); v != "" {
+		start, err := time.Parse(time.RFC3339, v)
+		return start, end, err
+	}
+
+	window := defaultAvailabilityWindow
+	if v := query.Get("window"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		window = d
+	}
+	return end.Add(-window), end, nil
+}
+
+// RESTGetAvailability returns the availability reports of every exchange, or
+// of a single exchange, over the requested window
+func RESTGetAvailability(w http.ResponseWriter, r *http.Request) {
+	if bot.availability == nil {
+		http.Error(w, "availability journal not enabled", http.StatusServiceUnavailable)
+		return
+	}
+	start, end, err := availabilityWindow(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var response interface{}
+	if exchangeName := mux.Vars(r)["exchangeName"]; exchangeName != "" {
+		if GetExchangeByName(exchangeName) == nil {
+			http.Error(w, exchange.ErrExchangeNotFound, http.StatusNotFound)
+			return
+		}
+		response, err = bot.availability.Report(exchangeName, start, end)
+	} else {
+		response, err = bot.availability.Reports(start, end)
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = RESTfulJSONResponse(w, r, response)
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// DataQualityResponse holds the data quality counters of each exchange and
+// the feeds which are quarantined
+type DataQualityResponse struct {
+	Counters    []quality.Counter    `json:"counters"`
+	Quarantined []quality.FeedStatus `json:"quarantined"`
+}
+
+// RESTGetDataQuality returns the data quality counters and quarantined feeds
+func RESTGetDataQuality(w http.ResponseWriter, r *http.Request) {
+	err := RESTfulJSONResponse(w, r, DataQualityResponse{
+		Counters:    quality.Default.Counters(),
+		Quarantined: quality.Default.Feeds(),
+	})
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// RESTGetOrderLatency returns the order lifecycle latency distributions of
+// each exchange
+func RESTGetOrderLatency(w http.ResponseWriter, r *http.Request) {
+	err := RESTfulJSONResponse(w, r, latency.Default.Stats())
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// RESTGetOrders returns the orders placed by the bot, optionally for a single
+// exchange and filtered by the status query parameter
+func RESTGetOrders(w http.ResponseWriter, r *http.Request) {
+	exchangeName := mux.Vars(r)["exchangeName"]
+	if exchangeName != "" && GetExchangeByName(exchangeName) == nil {
+		http.Error(w, exchange.ErrExchangeNotFound, http.StatusNotFound)
+		return
+	}
+	err := RESTfulJSONResponse(w, r,
+		bot.orderManager.Orders(exchangeName, r.URL.Query().Get("status")))
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// RESTGetVolatility returns the rolling realized volatility of an exchange
+// currency pair
+func RESTGetVolatility(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	req, err := volatilityRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	req.Exchange = vars["exchangeName"]
+	req.Pair = pair.NewCurrencyPairFromString(vars["currency"])
+	response, err := GetVolatility(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	err = RESTfulJSONResponse(w, r, response)
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// JournalAnnotation is the body of a trade journal entry annotation request,
+// either field may be empty
+type JournalAnnotation struct {
+	Text string   `json:"text"`
+	Tags []string `json:"tags"`
+}
+
+// RESTGetJournalSessions returns the trade journal sessions
+func RESTGetJournalSessions(w http.ResponseWriter, r *http.Request) {
+	if bot.journal == nil {
+		http.Error(w, "trade journal not enabled", http.StatusServiceUnavailable)
+		return
+	}
+	err := RESTfulJSONResponse(w, r, bot.journal.Sessions())
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// RESTStartJournalSession ends the current trade journal session and starts a
+// new session, named by the optional name query parameter
+func RESTStartJournalSession(w http.ResponseWriter, r *http.Request) {
+	if bot.journal == nil {
+		http.Error(w, "trade journal not enabled", http.StatusServiceUnavailable)
+		return
+	}
+	s, err := bot.journal.StartSession(r.URL.Query().Get("name"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	err = RESTfulJSONResponse(w, r, s)
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// RESTExportJournalSession exports a trade journal session's entries and
+// performance report, as CSV when the format query parameter is csv and JSON
+// otherwise
+func RESTExportJournalSession(w http.ResponseWriter, r *http.Request) {
+	if bot.journal == nil {
+		http.Error(w, "trade journal not enabled", http.StatusServiceUnavailable)
+		return
+	}
+	session := mux.Vars(r)["session"]
+	if _, err := bot.journal.Entries(session); err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	var err error
+	if r.URL.Query().Get("format") == "csv" {
+		w.Header().Set("Content-Type", "text/csv")
+		w.Header().Set("Content-Disposition", "attachment; filename=\""+session+".csv\"")
+		w.WriteHeader(http.StatusOK)
+		err = bot.journal.ExportCSV(w, session)
+	} else {
+		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+		w.WriteHeader(http.StatusOK)
+		err = bot.journal.ExportJSON(w, session)
+	}
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// RESTAnnotateJournalEntry adds an annotation and strategy tags to a trade
+// journal entry and returns the entry
+func RESTAnnotateJournalEntry(w http.ResponseWriter, r *http.Request) {
+	if bot.journal == nil {
+		http.Error(w, "trade journal not enabled", http.StatusServiceUnavailable)
+		return
+	}
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "invalid journal entry id", http.StatusBadRequest)
+		return
+	}
+	var body JournalAnnotation
+	err = json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if _, err = bot.journal.Entry(id); err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if len(body.Tags) > 0 {
+		err = bot.journal.Tag(id, body.Tags...)
+	}
+	if err == nil && body.Text != "" {
+		err = bot.journal.Annotate(id, body.Text)
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	entry, err := bot.journal.Entry(id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	err = RESTfulJSONResponse(w, r, entry)
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// RESTGetMetrics returns the bot metrics in the Prometheus text exposition
+// format
+func RESTGetMetrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	w.WriteHeader(http.StatusOK)
+	err := quality.Default.WriteMetrics(w)
+	if err == nil {
+		err = latency.Default.WriteMetrics(w)
+	}
+	if err == nil {
+		err = orderbook.Sequences.WriteMetrics(w)
+	}
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
 // RESTGetTicker returns ticker info for a given currency, exchange and
 // asset type
 func RESTGetTicker(w http.ResponseWriter, r *http.Request) {
@@ -276,12 +540,61 @@ func GetAllEnabledExchangeAccountInfo() AllEnabledExchangeAccounts {
 					individualBot.GetName(), err)
 				continue
 			}
+			dust.Identify(individualBot, &individualExchange, exchangeDustThresholds(individualBot.GetName()))
 			response.Data = append(response.Data, individualExchange)
 		}
 	}
 	return response
 }
 
+// GetAllEnabledExchangeEarnBalances returns the savings and staking product
+// balances of every enabled exchange which supports them, by exchange name
+func GetAllEnabledExchangeEarnBalances() map[string][]exchange.EarnBalance {
+	response := make(map[string][]exchange.EarnBalance)
+	for _, individualBot := range bot.exchanges {
+		if individualBot == nil || !individualBot.IsEnabled() ||
+			!individualBot.GetAuthenticatedAPISupport() {
+			continue
+		}
+		earn, ok := individualBot.(exchange.EarnBalanceGetter)
+		if !ok {
+			continue
+		}
+		balances, err := earn.GetEarnBalances()
+		if err != nil {
+			log.Printf("Error encountered retrieving exchange earn balances for %s. Error %s",
+				individualBot.GetName(), err)
+			continue
+		}
+		response[individualBot.GetName()] = balances
+	}
+	return response
+}
+
+// GetAllEnabledExchangeStakingPositions returns the staking positions of
+// every enabled exchange which supports staking, by exchange name
+func GetAllEnabledExchangeStakingPositions() map[string][]exchange.StakingPosition {
+	response := make(map[string][]exchange.StakingPosition)
+	for _, individualBot := range bot.exchanges {
+		if individualBot == nil || !individualBot.IsEnabled() ||
+			!individualBot.GetAuthenticatedAPISupport() {
+			continue
+		}
+		staking, ok := individualBot.(exchange.StakingExchange)
+		if !ok {
+			continue
+		}
+		positions, err := staking.GetStakingPositions()
+		if err != nil {
+			log.Printf("Error encountered retrieving exchange staking positions for %s. Error %s",
+				individualBot.GetName(), err)
+			continue
+		}
+		response[individualBot.GetName()] = positions
+	}
+	return response
+}
+
 // RESTGetAllEnabledAccountInfo via get request returns JSON response of account
 // info
 func RESTGetAllEnabledAccountInfo(w http.ResponseWriter, r *http.Request) {
@@ -8,8 +8,11 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/thrasher-/gocryptotrader/analytics"
 	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
 )
 
 func loadConfig(t *testing.T) *config.Config {
@@ -50,3 +53,58 @@ func TestConfigAllJsonResponse(t *testing.T) {
 		t.Error("Test failed. Json not equal to config")
 	}
 }
+
+func TestRESTGetAvailability(t *testing.T) {
+	req := httptest.NewRequest("GET", "http://localhost:9050/exchanges/availability", nil)
+	w := httptest.NewRecorder()
+	RESTGetAvailability(w, req)
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("Test failed. Expected status %d, received %d",
+			http.StatusServiceUnavailable, w.Code)
+	}
+}
+
+func TestAvailabilityWindow(t *testing.T) {
+	req := httptest.NewRequest("GET", "http://localhost:9050/exchanges/availability?window=1h", nil)
+	start, end, err := availabilityWindow(req)
+	if err != nil {
+		t.Error("Test failed. availabilityWindow() error", err)
+	}
+	if end.Sub(start) != time.Hour {
+		t.Error("Test failed. availabilityWindow() incorrect window", end.Sub(start))
+	}
+
+	req = httptest.NewRequest("GET", "http://localhost:9050/exchanges/availability?start=bad", nil)
+	_, _, err = availabilityWindow(req)
+	if err == nil {
+		t.Error("Test failed. availabilityWindow() expected error for invalid start")
+	}
+}
+
+func TestVolatilityRequest(t *testing.T) {
+	req := httptest.NewRequest("GET", "http://localhost:9050/exchanges/Bitstamp/volatility/BTCUSD?interval=4h&window=6&estimator=parkinson", nil)
+	v, err := volatilityRequest(req)
+	if err != nil {
+		t.Fatal("Test failed. volatilityRequest() error", err)
+	}
+	if v.Interval != kline.FourHour || v.Window != 6 || v.Estimator != analytics.Parkinson ||
+		v.End.Sub(v.Start) != 29*4*time.Hour {
+		t.Error("Test failed. volatilityRequest() incorrect request", v)
+	}
+
+	req = httptest.NewRequest("GET", "http://localhost:9050/exchanges/Bitstamp/volatility/BTCUSD?window=x", nil)
+	if _, err = volatilityRequest(req); err == nil {
+		t.Error("Test failed. volatilityRequest() expected error for invalid window")
+	}
+}
+
+func TestRESTGetMetrics(t *testing.T) {
+	req := httptest.NewRequest("GET", "http://localhost:9050/metrics", nil)
+	w := httptest.NewRecorder()
+	RESTGetMetrics(w, req)
+	if w.Code != http.StatusOK ||
+		!strings.Contains(w.Body.String(), "gocryptotrader_data_quality_checks_total") ||
+		!strings.Contains(w.Body.String(), "gocryptotrader_order_latency_seconds") {
+		t.Error("Test failed. RESTGetMetrics() unexpected response", w.Code, w.Body.String())
+	}
+}
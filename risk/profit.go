@@ -0,0 +1,192 @@
+package risk
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+)
+
+// Errors returned by the profit guard
+var (
+	ErrNoLegs                = errors.New("no legs to execute")
+	ErrInvalidInput          = errors.New("input amount must be greater than zero")
+	ErrInsufficientLiquidity = errors.New("orderbook has insufficient liquidity for leg")
+	ErrInsufficientProfit    = errors.New("expected net profit below minimum")
+	ErrNoOrderbookFetcher    = errors.New("no orderbook fetcher set")
+)
+
+// ConversionLeg is a single order of a multi-leg execution, such as an
+// arbitrage, triangulation or conversion. Buys spend the quote currency for
+// the base currency, sells spend the base currency for the quote currency.
+// FeeRate is deducted from the currency received.
+type ConversionLeg struct {
+	Exchange  string
+	Pair      pair.CurrencyPair
+	AssetType string
+	Side      exchange.OrderSide
+	FeeRate   float64
+}
+
+// LegFill is the expected fill of a leg against the live orderbook, Amount
+// is in the base currency and Price is the volume weighted fill price
+type LegFill struct {
+	ConversionLeg
+	Amount float64
+	Price  float64
+	Input  float64
+	Output float64
+}
+
+// ProfitCheck is the expected outcome of executing legs, in the currency the
+// first leg spends
+type ProfitCheck struct {
+	Input         float64
+	Output        float64
+	Profit        float64
+	ProfitPercent float64
+	Fills         []LegFill
+}
+
+// OrderbookFetcher returns a fresh orderbook for an exchange currency pair
+type OrderbookFetcher func(exchangeName string, p pair.CurrencyPair, assetType string) (orderbook.Base, error)
+
+// LegSubmitter sends the order of a validated leg
+type LegSubmitter func(fill LegFill) error
+
+// ProfitGuard re-validates multi-leg executions against live orderbooks
+// immediately before orders are sent, aborting when the expected net profit
+// has fallen below the minimums. MinProfit is in the currency the first leg
+// spends, so executions at a loss are always aborted, and a zero
+// MinProfitPercent disables it.
+type ProfitGuard struct {
+	MinProfit        float64
+	MinProfitPercent float64
+	Fetch            OrderbookFetcher
+}
+
+// LiveOrderbooks returns an OrderbookFetcher which requests orderbooks from
+// the exchanges rather than the cached orderbooks
+func LiveOrderbooks(exchanges []exchange.IBotExchange) OrderbookFetcher {
+	return func(exchangeName string, p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+		for _, exch := range exchanges {
+			if exch != nil && exch.GetName() == exchangeName {
+				return exch.UpdateOrderbook(p, assetType)
+			}
+		}
+		return orderbook.Base{}, fmt.Errorf("exchange %s not found", exchangeName)
+	}
+}
+
+// Check walks each leg through its live orderbook, spending the output of the
+// previous leg, and returns the expected outcome. ErrInsufficientProfit is
+// returned with the outcome when the net profit is below the minimums.
+func (g *ProfitGuard) Check(legs []ConversionLeg, input float64) (ProfitCheck, error) {
+	result := ProfitCheck{Input: input}
+	if len(legs) == 0 {
+		return result, ErrNoLegs
+	}
+	if input <= 0 {
+		return result, ErrInvalidInput
+	}
+	if g.Fetch == nil {
+		return result, ErrNoOrderbookFetcher
+	}
+
+	amount := input
+	for i := range legs {
+		ob, err := g.Fetch(legs[i].Exchange, legs[i].Pair, legs[i].AssetType)
+		if err != nil {
+			return result, err
+		}
+		fill, err := fillLeg(legs[i], &ob, amount)
+		if err != nil {
+			return result, err
+		}
+		result.Fills = append(result.Fills, fill)
+		amount = fill.Output
+	}
+
+	result.Output = amount
+	result.Profit = result.Output - result.Input
+	result.ProfitPercent = result.Profit / result.Input * 100
+	if result.Profit < g.MinProfit ||
+		(g.MinProfitPercent != 0 && result.ProfitPercent < g.MinProfitPercent) {
+		return result, ErrInsufficientProfit
+	}
+	return result, nil
+}
+
+// Execute checks the legs are still profitable and submits each leg in order,
+// stopping at the first failed submission
+func (g *ProfitGuard) Execute(legs []ConversionLeg, input float64, submit LegSubmitter) (ProfitCheck, error) {
+	result, err := g.Check(legs, input)
+	if err != nil {
+		return result, err
+	}
+	for i := range result.Fills {
+		err = submit(result.Fills[i])
+		if err != nil {
+			return result, fmt.Errorf("leg %d %s %s failed: %s", i+1,
+				result.Fills[i].Exchange, result.Fills[i].Pair.Pair(), err)
+		}
+	}
+	return result, nil
+}
+
+// fillLeg spends amount against the side of the orderbook a leg takes, buys
+// spend the quote currency against the asks and sells spend the base currency
+// against the bids
+func fillLeg(leg ConversionLeg, ob *orderbook.Base, amount float64) (LegFill, error) {
+	fill := LegFill{ConversionLeg: leg, Input: amount}
+	var levels []orderbook.Item
+	switch leg.Side {
+	case exchange.Buy:
+		levels = ob.Asks
+	case exchange.Sell:
+		levels = ob.Bids
+	default:
+		return fill, ErrUnsupportedSide
+	}
+
+	var base, quote float64
+	remaining := amount
+	for x := range levels {
+		if remaining <= 0 {
+			break
+		}
+		if levels[x].Price <= 0 || levels[x].Amount <= 0 {
+			continue
+		}
+		levelBase := levels[x].Amount
+		if leg.Side == exchange.Buy {
+			if levelBase*levels[x].Price > remaining {
+				levelBase = remaining / levels[x].Price
+			}
+			remaining -= levelBase * levels[x].Price
+		} else {
+			if levelBase > remaining {
+				levelBase = remaining
+			}
+			remaining -= levelBase
+		}
+		base += levelBase
+		quote += levelBase * levels[x].Price
+	}
+
+	// Allow for floating point error when the book is exactly consumed
+	if remaining > amount*1e-9 {
+		return fill, ErrInsufficientLiquidity
+	}
+
+	fill.Amount = base
+	fill.Price = quote / base
+	if leg.Side == exchange.Buy {
+		fill.Output = base * (1 - leg.FeeRate)
+	} else {
+		fill.Output = quote * (1 - leg.FeeRate)
+	}
+	return fill, nil
+}
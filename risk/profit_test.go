@@ -0,0 +1,84 @@
+package risk
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+)
+
+func testBooks(books map[string]orderbook.Base) OrderbookFetcher {
+	return func(exchangeName string, p pair.CurrencyPair, assetType string) (orderbook.Base, error) {
+		ob, ok := books[exchangeName]
+		if !ok {
+			return ob, errors.New("no orderbook")
+		}
+		return ob, nil
+	}
+}
+
+func TestProfitGuardCheck(t *testing.T) {
+	btcusd := pair.NewCurrencyPair("BTC", "USD")
+	books := map[string]orderbook.Base{
+		"A": {Asks: []orderbook.Item{{Price: 100, Amount: 1}, {Price: 101, Amount: 1}}},
+		"B": {Bids: []orderbook.Item{{Price: 103, Amount: 5}}},
+	}
+	legs := []ConversionLeg{
+		{Exchange: "A", Pair: btcusd, Side: exchange.Buy},
+		{Exchange: "B", Pair: btcusd, Side: exchange.Sell, FeeRate: 0.001},
+	}
+	g := ProfitGuard{MinProfit: 1, Fetch: testBooks(books)}
+
+	// 201 USD buys 1 BTC at 100 and 1 BTC at 101, which sell for 206 less fees
+	result, err := g.Check(legs, 201)
+	if err != nil {
+		t.Fatal("Test Failed - Check() error", err)
+	}
+	if result.Fills[0].Amount != 2 || result.Fills[0].Price != 100.5 {
+		t.Error("Test Failed - Check() incorrect buy fill", result.Fills[0])
+	}
+	if math.Abs(result.Output-205.794) > 1e-9 || math.Abs(result.Profit-4.794) > 1e-9 {
+		t.Error("Test Failed - Check() incorrect profit", result.Output, result.Profit)
+	}
+
+	g.MinProfitPercent = 5
+	if _, err = g.Check(legs, 201); err != ErrInsufficientProfit {
+		t.Error("Test Failed - Check() expected insufficient profit", err)
+	}
+	if _, err = g.Check(legs, 500); err != ErrInsufficientLiquidity {
+		t.Error("Test Failed - Check() expected insufficient liquidity", err)
+	}
+	if _, err = g.Check(nil, 100); err != ErrNoLegs {
+		t.Error("Test Failed - Check() expected no legs error", err)
+	}
+}
+
+func TestProfitGuardExecute(t *testing.T) {
+	btcusd := pair.NewCurrencyPair("BTC", "USD")
+	books := map[string]orderbook.Base{
+		"A": {Asks: []orderbook.Item{{Price: 100, Amount: 1}}},
+		"B": {Bids: []orderbook.Item{{Price: 99, Amount: 1}}},
+	}
+	legs := []ConversionLeg{
+		{Exchange: "A", Pair: btcusd, Side: exchange.Buy},
+		{Exchange: "B", Pair: btcusd, Side: exchange.Sell},
+	}
+	g := ProfitGuard{Fetch: testBooks(books)}
+
+	var submitted int
+	submit := func(fill LegFill) error {
+		submitted++
+		return nil
+	}
+	if _, err := g.Execute(legs, 100, submit); err != ErrInsufficientProfit || submitted != 0 {
+		t.Error("Test Failed - Execute() expected no orders for a loss", err, submitted)
+	}
+
+	books["B"] = orderbook.Base{Bids: []orderbook.Item{{Price: 102, Amount: 1}}}
+	if _, err := g.Execute(legs, 100, submit); err != nil || submitted != 2 {
+		t.Error("Test Failed - Execute() expected both legs submitted", err, submitted)
+	}
+}
@@ -0,0 +1,169 @@
+// Package risk provides pre-trade risk checks which orders are validated
+// against before being submitted to an exchange
+package risk
+
+import (
+	"errors"
+	"math"
+
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+)
+
+// Errors returned by the pre-trade risk checks
+var (
+	ErrInvalidOrder       = errors.New("order amount and price must be greater than zero")
+	ErrMaxOrderAmount     = errors.New("order amount exceeds maximum order amount")
+	ErrMaxOrderNotional   = errors.New("order notional exceeds maximum order notional")
+	ErrMaxPosition        = errors.New("order would exceed maximum position")
+	ErrMaxOpenOrders      = errors.New("maximum open orders reached")
+	ErrUnsupportedSide    = errors.New("unsupported order side")
+	ErrNoAllowableAmount  = errors.New("no amount can be placed within risk limits")
+	ErrNegativeRiskLimits = errors.New("risk limits cannot be negative")
+	ErrMaxBookFraction    = errors.New("order exceeds maximum fraction of orderbook liquidity")
+	ErrInvalidBookLimits  = errors.New("maximum book fraction must not exceed one")
+)
+
+// Limits holds pre-trade risk limits, a zero value disables the limit.
+// MaxPosition is the maximum absolute position in the base currency a filled
+// order is allowed to leave. MaxBookFraction is the maximum fraction of the
+// orderbook liquidity within DepthBps basis points of the mid price an order
+// may take, the whole side of the book is used when DepthBps is zero.
+type Limits struct {
+	MaxOrderAmount   float64
+	MaxOrderNotional float64
+	MaxPosition      float64
+	MaxOpenOrders    int
+	MaxBookFraction  float64
+	DepthBps         float64
+}
+
+// Order holds the details of a proposed order and the current state required
+// to check it against the limits. Position is signed, positive when long.
+// Orderbook is the book the order trades against, the book liquidity limit
+// is skipped without one.
+type Order struct {
+	Side       exchange.OrderSide
+	Amount     float64
+	Price      float64
+	Position   float64
+	OpenOrders int
+	Orderbook  *orderbook.Base
+}
+
+// Validate checks the limits are not negative
+func (l *Limits) Validate() error {
+	if l.MaxOrderAmount < 0 || l.MaxOrderNotional < 0 || l.MaxPosition < 0 ||
+		l.MaxOpenOrders < 0 || l.MaxBookFraction < 0 || l.DepthBps < 0 {
+		return ErrNegativeRiskLimits
+	}
+	if l.MaxBookFraction > 1 {
+		return ErrInvalidBookLimits
+	}
+	return nil
+}
+
+// CheckOrder returns an error if the order breaches any limit
+func (l *Limits) CheckOrder(o Order) error {
+	if o.Amount <= 0 || o.Price <= 0 {
+		return ErrInvalidOrder
+	}
+
+	direction, err := sideDirection(o.Side)
+	if err != nil {
+		return err
+	}
+
+	if l.MaxOpenOrders > 0 && o.OpenOrders >= l.MaxOpenOrders {
+		return ErrMaxOpenOrders
+	}
+	if l.MaxOrderAmount > 0 && o.Amount > l.MaxOrderAmount {
+		return ErrMaxOrderAmount
+	}
+	if l.MaxOrderNotional > 0 && o.Amount*o.Price > l.MaxOrderNotional {
+		return ErrMaxOrderNotional
+	}
+	if l.MaxPosition > 0 &&
+		math.Abs(o.Position+direction*o.Amount) > l.MaxPosition &&
+		math.Abs(o.Position+direction*o.Amount) > math.Abs(o.Position) {
+		return ErrMaxPosition
+	}
+	allowed, ok, err := l.bookAmount(o)
+	if err != nil {
+		return err
+	}
+	if ok && o.Amount > allowed {
+		return ErrMaxBookFraction
+	}
+	return nil
+}
+
+// AllowedAmount returns the order amount reduced to the largest amount which
+// satisfies the limits, so callers can resize rather than reject an order
+func (l *Limits) AllowedAmount(o Order) (float64, error) {
+	if o.Amount <= 0 || o.Price <= 0 {
+		return 0, ErrInvalidOrder
+	}
+
+	direction, err := sideDirection(o.Side)
+	if err != nil {
+		return 0, err
+	}
+
+	if l.MaxOpenOrders > 0 && o.OpenOrders >= l.MaxOpenOrders {
+		return 0, ErrMaxOpenOrders
+	}
+
+	amount := o.Amount
+	if l.MaxOrderAmount > 0 && amount > l.MaxOrderAmount {
+		amount = l.MaxOrderAmount
+	}
+	if l.MaxOrderNotional > 0 && amount*o.Price > l.MaxOrderNotional {
+		amount = l.MaxOrderNotional / o.Price
+	}
+	if l.MaxPosition > 0 {
+		// Room is the amount which can be traded in the order direction
+		// before the position limit is reached
+		room := l.MaxPosition - direction*o.Position
+		if amount > room {
+			amount = room
+		}
+	}
+	allowed, ok, err := l.bookAmount(o)
+	if err != nil {
+		return 0, err
+	}
+	if ok && amount > allowed {
+		amount = allowed
+	}
+
+	if amount <= 0 {
+		return 0, ErrNoAllowableAmount
+	}
+	return amount, nil
+}
+
+// bookAmount returns the largest amount the book liquidity limit allows and
+// whether the limit applies to the order
+func (l *Limits) bookAmount(o Order) (float64, bool, error) {
+	if l.MaxBookFraction <= 0 || o.Orderbook == nil {
+		return 0, false, nil
+	}
+	liquidity, err := o.Orderbook.LiquidityWithin(o.Side == exchange.Buy, l.DepthBps)
+	if err != nil {
+		return 0, false, err
+	}
+	return liquidity * l.MaxBookFraction, true, nil
+}
+
+// sideDirection returns 1 for buys and -1 for sells
+func sideDirection(side exchange.OrderSide) (float64, error) {
+	switch side {
+	case exchange.Buy:
+		return 1, nil
+	case exchange.Sell:
+		return -1, nil
+	default:
+		return 0, ErrUnsupportedSide
+	}
+}
@@ -0,0 +1,108 @@
+package risk
+
+import (
+	"testing"
+
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+)
+
+func TestValidate(t *testing.T) {
+	l := Limits{MaxOrderAmount: 1}
+	if err := l.Validate(); err != nil {
+		t.Error("Test Failed - Validate() error", err)
+	}
+	l.MaxOpenOrders = -1
+	if err := l.Validate(); err != ErrNegativeRiskLimits {
+		t.Error("Test Failed - Validate() expected negative limits error", err)
+	}
+}
+
+func TestCheckOrder(t *testing.T) {
+	l := Limits{
+		MaxOrderAmount:   2,
+		MaxOrderNotional: 150,
+		MaxPosition:      3,
+		MaxOpenOrders:    2,
+	}
+
+	tester := []struct {
+		Order Order
+		Err   error
+	}{
+		{Order{Side: exchange.Buy, Amount: 1, Price: 100}, nil},
+		{Order{Side: exchange.Buy, Amount: 0, Price: 100}, ErrInvalidOrder},
+		{Order{Side: exchange.OrderSide("LONG"), Amount: 1, Price: 100}, ErrUnsupportedSide},
+		{Order{Side: exchange.Buy, Amount: 3, Price: 10}, ErrMaxOrderAmount},
+		{Order{Side: exchange.Sell, Amount: 2, Price: 100}, ErrMaxOrderNotional},
+		{Order{Side: exchange.Buy, Amount: 1, Price: 10, OpenOrders: 2}, ErrMaxOpenOrders},
+		{Order{Side: exchange.Buy, Amount: 1, Price: 10, Position: 2.5}, ErrMaxPosition},
+		{Order{Side: exchange.Sell, Amount: 1, Price: 10, Position: -2.5}, ErrMaxPosition},
+		// Reducing a position already over the limit is allowed
+		{Order{Side: exchange.Sell, Amount: 1, Price: 10, Position: 5}, nil},
+	}
+
+	for i := range tester {
+		if err := l.CheckOrder(tester[i].Order); err != tester[i].Err {
+			t.Errorf("Test Failed - CheckOrder() test %d expected %v received %v",
+				i, tester[i].Err, err)
+		}
+	}
+}
+
+func TestAllowedAmount(t *testing.T) {
+	l := Limits{MaxOrderAmount: 2, MaxOrderNotional: 150, MaxPosition: 3}
+
+	amount, err := l.AllowedAmount(Order{Side: exchange.Buy, Amount: 5, Price: 10})
+	if err != nil || amount != 2 {
+		t.Error("Test Failed - AllowedAmount() expected 2", amount, err)
+	}
+	amount, err = l.AllowedAmount(Order{Side: exchange.Buy, Amount: 2, Price: 100})
+	if err != nil || amount != 1.5 {
+		t.Error("Test Failed - AllowedAmount() expected 1.5", amount, err)
+	}
+	amount, err = l.AllowedAmount(Order{Side: exchange.Buy, Amount: 2, Price: 10, Position: 2})
+	if err != nil || amount != 1 {
+		t.Error("Test Failed - AllowedAmount() expected 1", amount, err)
+	}
+	_, err = l.AllowedAmount(Order{Side: exchange.Buy, Amount: 2, Price: 10, Position: 3})
+	if err != ErrNoAllowableAmount {
+		t.Error("Test Failed - AllowedAmount() expected no allowable amount error", err)
+	}
+}
+
+func TestBookLiquidityLimit(t *testing.T) {
+	book := &orderbook.Base{
+		Bids: []orderbook.Item{{Price: 99, Amount: 4}, {Price: 95, Amount: 100}},
+		Asks: []orderbook.Item{{Price: 101, Amount: 2}, {Price: 102, Amount: 2}, {Price: 110, Amount: 100}},
+	}
+	l := Limits{MaxBookFraction: 0.5, DepthBps: 250}
+	if err := l.Validate(); err != nil {
+		t.Error("Test Failed - Validate() error", err)
+	}
+
+	if err := l.CheckOrder(Order{Side: exchange.Buy, Amount: 2, Price: 101, Orderbook: book}); err != nil {
+		t.Error("Test Failed - CheckOrder() error", err)
+	}
+	err := l.CheckOrder(Order{Side: exchange.Buy, Amount: 3, Price: 101, Orderbook: book})
+	if err != ErrMaxBookFraction {
+		t.Error("Test Failed - CheckOrder() expected book fraction error", err)
+	}
+	if err = l.CheckOrder(Order{Side: exchange.Buy, Amount: 3, Price: 101}); err != nil {
+		t.Error("Test Failed - CheckOrder() book limit applied without a book", err)
+	}
+
+	amount, err := l.AllowedAmount(Order{Side: exchange.Sell, Amount: 10, Price: 99, Orderbook: book})
+	if err != nil || amount != 2 {
+		t.Error("Test Failed - AllowedAmount() incorrect book limited amount", amount, err)
+	}
+	_, err = l.AllowedAmount(Order{Side: exchange.Sell, Amount: 1, Price: 99, Orderbook: &orderbook.Base{}})
+	if err != orderbook.ErrNoMidPrice {
+		t.Error("Test Failed - AllowedAmount() expected no mid price error", err)
+	}
+
+	l.MaxBookFraction = 2
+	if err = l.Validate(); err != ErrInvalidBookLimits {
+		t.Error("Test Failed - Validate() expected invalid book limits error", err)
+	}
+}
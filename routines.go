@@ -1,29 +1,31 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
+	"strings"
 	"sync"
 	"time"
 
-	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/availability"
+	"github.com/thrasher-/gocryptotrader/communications/base"
+	"github.com/thrasher-/gocryptotrader/config"
 	"github.com/thrasher-/gocryptotrader/currency"
 	"github.com/thrasher-/gocryptotrader/currency/pair"
-	"github.com/thrasher-/gocryptotrader/currency/symbol"
 	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/abbo"
 	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/quality"
+	"github.com/thrasher-/gocryptotrader/exchanges/request"
 	"github.com/thrasher-/gocryptotrader/exchanges/stats"
 	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-/gocryptotrader/portfolio"
 )
 
 func printCurrencyFormat(price float64) string {
-	displaySymbol, err := symbol.GetSymbolByCurrencyName(bot.config.Currency.FiatDisplayCurrency)
-	if err != nil {
-		log.Printf("Failed to get display symbol: %s", err)
-	}
-
-	return fmt.Sprintf("%s%.8f", displaySymbol, price)
+	return bot.config.GetDisplayLocale().FormatPrice(price, bot.config.Currency.FiatDisplayCurrency)
 }
 
 func printConvertCurrencyFormat(origCurrency string, origPrice float64) string {
@@ -33,22 +35,11 @@ func printConvertCurrencyFormat(origCurrency string, origPrice float64) string {
 		log.Printf("Failed to convert currency: %s", err)
 	}
 
-	displaySymbol, err := symbol.GetSymbolByCurrencyName(displayCurrency)
-	if err != nil {
-		log.Printf("Failed to get display symbol: %s", err)
-	}
-
-	origSymbol, err := symbol.GetSymbolByCurrencyName(origCurrency)
-	if err != nil {
-		log.Printf("Failed to get original currency symbol: %s", err)
-	}
-
-	return fmt.Sprintf("%s%.2f %s (%s%.2f %s)",
-		displaySymbol,
-		conv,
+	l := bot.config.GetDisplayLocale()
+	return fmt.Sprintf("%s %s (%s %s)",
+		l.FormatFiat(conv, displayCurrency),
 		displayCurrency,
-		origSymbol,
-		origPrice,
+		l.FormatFiat(origPrice, origCurrency),
 		origCurrency,
 	)
 }
@@ -63,6 +54,12 @@ func printTickerSummary(result ticker.Price, p pair.CurrencyPair, assetType, exc
 	}
 
 	stats.Add(exchangeName, p, assetType, result.Last, result.Volume)
+	if result.Bid > 0 || result.Ask > 0 {
+		_, err = abbo.Default.Update(exchangeName, p, assetType, result.Bid, result.Ask)
+		if err != nil {
+			log.Printf("Failed to update %s ABBO. Error: %s", p.Pair().String(), err)
+		}
+	}
 	if currency.IsFiatCurrency(p.SecondCurrency.String()) && p.SecondCurrency.String() != bot.config.Currency.FiatDisplayCurrency {
 		origCurrency := p.SecondCurrency.Upper().String()
 		log.Printf("%s %s %s: TICKER: Last %s Ask %s Bid %s High %s Low %s Volume %.8f",
@@ -189,8 +186,17 @@ func TickerUpdaterRoutine() {
 				if bot.exchanges[x] == nil {
 					return
 				}
+				l := exchangeLock(bot.exchanges[x].GetName())
+				l.RLock()
+				defer l.RUnlock()
+				if !bot.exchanges[x].IsEnabled() ||
+					!bot.exchanges[x].GetCapabilities().CanGetTicker {
+					return
+				}
+				if bot.sync != nil && !bot.sync.PollREST(bot.exchanges[x], SyncTicker, time.Now()) {
+					return
+				}
 				exchangeName := bot.exchanges[x].GetName()
-				enabledCurrencies := bot.exchanges[x].GetEnabledCurrencies()
 				supportsBatching := bot.exchanges[x].SupportsRESTTickerBatchUpdates()
 				assetTypes, err := exchange.GetExchangeAssetTypes(exchangeName)
 				if err != nil {
@@ -207,8 +213,14 @@ func TickerUpdaterRoutine() {
 					} else {
 						result, err = exch.GetTickerPrice(c, assetType)
 					}
+					if err == nil {
+						err = quality.Default.CheckTicker(exchangeName, c, assetType, result)
+					}
 					printTickerSummary(result, c, assetType, exchangeName, err)
 					if err == nil {
+						matchSimulatedOrders(exch)
+						strategyTick(exchangeName, assetType, result)
+						persistTicker(exchangeName, assetType, c, result)
 						bot.comms.StageTickerData(exchangeName, assetType, result)
 						if bot.config.Webserver.Enabled {
 							relayWebsocketEvent(result, "ticker_update", assetType, exchangeName)
@@ -217,6 +229,7 @@ func TickerUpdaterRoutine() {
 				}
 
 				for y := range assetTypes {
+					enabledCurrencies := bot.exchanges[x].GetEnabledPairs(assetTypes[y])
 					for z := range enabledCurrencies {
 						if supportsBatching && z > 0 {
 							processTicker(bot.exchanges[x], false, enabledCurrencies[z], assetTypes[y])
@@ -233,6 +246,310 @@ func TickerUpdaterRoutine() {
 	}
 }
 
+// EarnBalanceUpdaterRoutine fetches the savings and staking product balances
+// of all enabled exchanges and updates the portfolio interest accrual
+func EarnBalanceUpdaterRoutine() {
+	log.Println("Starting earn balance updater routine.")
+	for {
+		SeedExchangeEarnBalances(GetAllEnabledExchangeEarnBalances(), time.Now())
+		time.Sleep(time.Minute * 10)
+	}
+}
+
+// stakingUnlockReminderWindow is how long before a staking position unlocks
+// that a reminder is sent
+const stakingUnlockReminderWindow = time.Hour * 24
+
+// StakingUpdaterRoutine fetches the staking positions of all enabled exchanges
+// and notifies enabled communication mediums of upcoming unlock dates
+func StakingUpdaterRoutine() {
+	log.Println("Starting staking updater routine.")
+	for {
+		SeedExchangeStakingPositions(GetAllEnabledExchangeStakingPositions())
+		reminders := portfolio.GetPortfolio().GetUnlockReminders(time.Now(),
+			stakingUnlockReminderWindow)
+		for _, r := range reminders {
+			message := fmt.Sprintf("%s staking position %s of %f %s unlocks at %s",
+				r.Exchange, r.PositionID, r.Amount, r.Coin, r.Unlock.Format(time.RFC1123))
+			log.Println(message)
+			bot.comms.PushEvent(base.Event{Type: "staking_unlock", TradeDetails: message})
+		}
+		time.Sleep(time.Minute * 10)
+	}
+}
+
+// deprecationReminderWindow is how long before an exchange endpoint is shut
+// down that a final reminder is sent
+const deprecationReminderWindow = time.Hour * 24 * 7
+
+// deprecationSent is the sunset date a deprecation notice was last sent with
+// and whether its reminder has been sent
+type deprecationSent struct {
+	sunset   time.Time
+	reminded bool
+}
+
+// deprecationNotices returns the deprecation notices to send, those not yet
+// sent or whose sunset date has changed, and a reminder for those shutting
+// down within the reminder window
+func deprecationNotices(notices []request.Deprecation, sent map[string]deprecationSent, now time.Time) []string {
+	var messages []string
+	for _, d := range notices {
+		last, ok := sent[d.Key()]
+		switch {
+		case !ok || !last.sunset.Equal(d.Sunset):
+			sent[d.Key()] = deprecationSent{sunset: d.Sunset}
+			messages = append(messages, d.String())
+		case !last.reminded && !d.Sunset.IsZero() &&
+			d.Sunset.Sub(now) <= deprecationReminderWindow:
+			sent[d.Key()] = deprecationSent{sunset: d.Sunset, reminded: true}
+			messages = append(messages, "Reminder: "+d.String())
+		}
+	}
+	return messages
+}
+
+// DeprecationMonitorRoutine notifies enabled communication mediums of the
+// deprecated endpoints and scheduled API shutdowns exchanges report
+func DeprecationMonitorRoutine() {
+	log.Println("Starting API deprecation monitor routine.")
+	sent := make(map[string]deprecationSent)
+	for {
+		for _, message := range deprecationNotices(request.GetDeprecations(), sent, time.Now()) {
+			log.Println(message)
+			bot.comms.PushEvent(base.Event{Type: "api_deprecation", TradeDetails: message})
+		}
+		time.Sleep(time.Minute * 10)
+	}
+}
+
+// AccountTierUpdaterRoutine detects the account tier of enabled authenticated
+// exchanges, applies the rate limits of the tier when it changes and saves it
+// to the exchange config. Exchanges whose fee lookups report the tier may have
+// applied it already, so the config is compared rather than the applied tier.
+func AccountTierUpdaterRoutine() {
+	log.Println("Starting account tier updater routine.")
+	for {
+		for _, exch := range bot.exchanges {
+			if exch == nil || !exch.IsEnabled() || !exch.GetAuthenticatedAPISupport() {
+				continue
+			}
+			if _, ok := exch.(exchange.AccountTierDetector); !ok {
+				continue
+			}
+			tier, changed, err := exchange.UpdateAccountTier(exch)
+			if err != nil {
+				log.Printf("Error encountered detecting %s account tier. Error %s",
+					exch.GetName(), err)
+				continue
+			}
+			if changed {
+				log.Printf("%s account tier changed to %s, rate limits updated.",
+					exch.GetName(), tier)
+			}
+			exchCfg, err := bot.config.GetExchangeConfig(exch.GetName())
+			if err != nil {
+				log.Println(err)
+				continue
+			}
+			if strings.EqualFold(exchCfg.AccountTier, tier) {
+				continue
+			}
+			exchCfg.AccountTier = tier
+			err = bot.config.UpdateExchangeConfig(exchCfg)
+			if err != nil {
+				log.Println(err)
+			}
+		}
+		time.Sleep(time.Hour)
+	}
+}
+
+// apiKeyExpirySent is the expiry date an API key alert was last sent for and
+// whether it was the expired alert
+type apiKeyExpirySent struct {
+	expiry  time.Time
+	expired bool
+}
+
+// apiKeyExpiryNotice returns the alert to send for an exchange's API key
+// expiry, once per expiry date when it is within alertDays and again once it
+// has expired, and whether the key has expired
+func apiKeyExpiryNotice(exchName string, expiry time.Time, sent map[string]apiKeyExpirySent, now time.Time, alertDays int) (string, bool) {
+	if expiry.IsZero() {
+		return "", false
+	}
+	last, ok := sent[exchName]
+	if ok && !last.expiry.Equal(expiry) {
+		ok = false
+	}
+
+	if !now.Before(expiry) {
+		if ok && last.expired {
+			return "", true
+		}
+		sent[exchName] = apiKeyExpirySent{expiry: expiry, expired: true}
+		return fmt.Sprintf("%s API key expired on %s, authenticated API support disabled.",
+			exchName, expiry.Format(time.RFC1123)), true
+	}
+	if ok || expiry.Sub(now) > time.Hour*24*time.Duration(alertDays) {
+		return "", false
+	}
+	sent[exchName] = apiKeyExpirySent{expiry: expiry}
+	return fmt.Sprintf("%s API key expires in %d days on %s, rotate it to keep authenticated API support.",
+		exchName, int(expiry.Sub(now).Hours()/24), expiry.Format(time.RFC1123)), false
+}
+
+// APIKeyExpiryRoutine checks the API key expiry of enabled authenticated
+// exchanges every interval, alerting alertDays before a key expires and
+// disabling the exchange's authenticated API support once it has
+func APIKeyExpiryRoutine(interval time.Duration, alertDays int) {
+	log.Println("Starting API key expiry routine.")
+	sent := make(map[string]apiKeyExpirySent)
+	for {
+		for _, exch := range bot.exchanges {
+			if exch == nil || !exch.IsEnabled() || !exch.GetAuthenticatedAPISupport() {
+				continue
+			}
+			expiry, err := updateAPIKeyDates(exch)
+			if err != nil {
+				log.Printf("Error encountered checking %s API key expiry. Error %s",
+					exch.GetName(), err)
+				continue
+			}
+			message, expired := apiKeyExpiryNotice(exch.GetName(), expiry, sent, time.Now(), alertDays)
+			if expired {
+				exchange.DisableAuthenticatedAPISupport(exch)
+			}
+			if message != "" {
+				log.Println(message)
+				bot.comms.PushEvent(base.Event{Type: "api_key_expiry", TradeDetails: message})
+			}
+		}
+		time.Sleep(interval)
+	}
+}
+
+// updateAPIKeyDates queries the API key metadata of exchanges exposing it,
+// recording its creation and expiry dates in the exchange config, and returns
+// the key's expiry. Configured dates are kept when the exchange does not
+// report them or the query fails.
+func updateAPIKeyDates(exch exchange.IBotExchange) (time.Time, error) {
+	exchCfg, err := bot.config.GetExchangeConfig(exch.GetName())
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	info, err := exchange.GetAPIKeyInfo(exch)
+	switch {
+	case err == exchange.ErrAPIKeyExpiryUnsupported:
+	case err != nil:
+		log.Printf("Error encountered querying %s API key metadata, using configured dates. Error %s",
+			exch.GetName(), err)
+	default:
+		created, expiry := exchCfg.APIKeyCreated, exchCfg.APIKeyExpiry
+		if !info.Created.IsZero() {
+			exchCfg.APIKeyCreated = info.Created.UTC().Format(time.RFC3339)
+		}
+		if !info.Expiry.IsZero() {
+			exchCfg.APIKeyExpiry = info.Expiry.UTC().Format(time.RFC3339)
+		}
+		if exchCfg.APIKeyCreated != created || exchCfg.APIKeyExpiry != expiry {
+			err = bot.config.UpdateExchangeConfig(exchCfg)
+			if err != nil {
+				log.Println(err)
+			}
+		}
+	}
+	return config.ParseAPIKeyDate(exchCfg.APIKeyExpiry)
+}
+
+// HealthMonitorRoutine checks enabled exchanges respond every interval,
+// updating their health and journaling the outcomes for availability reporting
+func HealthMonitorRoutine(interval, timeout time.Duration) {
+	log.Println("Starting health monitor routine.")
+	var wg sync.WaitGroup
+	for {
+		for _, exch := range bot.exchanges {
+			if exch == nil || !exch.IsEnabled() {
+				continue
+			}
+			wg.Add(1)
+			go func(exch exchange.IBotExchange) {
+				defer wg.Done()
+				check := checkExchangeHealth(exch, timeout)
+				if !check.Up {
+					log.Printf("%s health check failed. Error: %s", check.Exchange, check.Error)
+				}
+				bot.health.Update(check)
+				if bot.availability == nil {
+					return
+				}
+				err := bot.availability.Record(check)
+				if err != nil {
+					log.Printf("Failed to record %s health check. Error: %s", check.Exchange, err)
+				}
+			}(exch)
+		}
+		wg.Wait()
+		time.Sleep(interval)
+	}
+}
+
+// checkExchangeHealth checks the system status of an exchange, or updates the
+// ticker of its first enabled currency pair when it has no status endpoint. A
+// check is down when it fails or takes longer than timeout.
+func checkExchangeHealth(exch exchange.IBotExchange, timeout time.Duration) availability.Check {
+	check := availability.Check{Exchange: exch.GetName(), Time: time.Now()}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var err error
+	if s, ok := exch.(exchange.SystemStatusChecker); ok {
+		err = exchange.CheckSystemStatusContext(ctx, s)
+	} else {
+		enabledCurrencies := exch.GetEnabledCurrencies()
+		var assetTypes []string
+		assetTypes, err = exchange.GetExchangeAssetTypes(check.Exchange)
+		if err != nil || len(enabledCurrencies) == 0 || len(assetTypes) == 0 {
+			check.Error = "no enabled currency pairs to check"
+			return check
+		}
+		_, err = exchange.UpdateTickerContext(ctx, exch, enabledCurrencies[0], assetTypes[0])
+	}
+	check.Latency = time.Since(check.Time)
+	if err == nil && check.Latency > timeout {
+		// Requests to exchanges without context support run to completion
+		err = context.DeadlineExceeded
+	}
+	if err != nil {
+		check.Error = err.Error()
+		return check
+	}
+	check.Up = true
+	return check
+}
+
+// simulatedExchange is implemented by exchanges through exchange.Base
+type simulatedExchange interface {
+	MatchSimulatedOrders()
+}
+
+// matchSimulatedOrders fills an exchange's resting paper orders against its
+// latest market data
+func matchSimulatedOrders(exch exchange.IBotExchange) {
+	if s, ok := exch.(simulatedExchange); ok {
+		s.MatchSimulatedOrders()
+	}
+}
+
+// dataQualityAlert logs and relays data quality quarantine alerts to the
+// communication mediums
+func dataQualityAlert(a quality.Alert) {
+	log.Println(a)
+	bot.comms.PushEvent(base.Event{Type: "data_quality", TradeDetails: a.String()})
+}
+
 // OrderbookUpdaterRoutine fetches and updates the orderbooks for all enabled
 // currency pairs and exchanges
 func OrderbookUpdaterRoutine() {
@@ -247,8 +564,17 @@ func OrderbookUpdaterRoutine() {
 				if bot.exchanges[x] == nil {
 					return
 				}
+				l := exchangeLock(bot.exchanges[x].GetName())
+				l.RLock()
+				defer l.RUnlock()
+				if !bot.exchanges[x].IsEnabled() ||
+					!bot.exchanges[x].GetCapabilities().CanGetOrderbook {
+					return
+				}
+				if bot.sync != nil && !bot.sync.PollREST(bot.exchanges[x], SyncOrderbook, time.Now()) {
+					return
+				}
 				exchangeName := bot.exchanges[x].GetName()
-				enabledCurrencies := bot.exchanges[x].GetEnabledCurrencies()
 				assetTypes, err := exchange.GetExchangeAssetTypes(exchangeName)
 				if err != nil {
 					log.Printf("failed to get %s exchange asset types. Error: %s",
@@ -258,8 +584,14 @@ func OrderbookUpdaterRoutine() {
 
 				processOrderbook := func(exch exchange.IBotExchange, c pair.CurrencyPair, assetType string) {
 					result, err := exch.UpdateOrderbook(c, assetType)
+					if err == nil {
+						err = quality.Default.CheckOrderbook(exchangeName, c, assetType, result)
+					}
 					printOrderbookSummary(result, c, assetType, exchangeName, err)
 					if err == nil {
+						matchSimulatedOrders(exch)
+						strategyOrderbook(exchangeName, result)
+						persistOrderbook(exchangeName, result)
 						bot.comms.StageOrderbookData(exchangeName, assetType, result)
 						if bot.config.Webserver.Enabled {
 							relayWebsocketEvent(result, "orderbook_update", assetType, exchangeName)
@@ -268,6 +600,7 @@ func OrderbookUpdaterRoutine() {
 				}
 
 				for y := range assetTypes {
+					enabledCurrencies := bot.exchanges[x].GetEnabledPairs(assetTypes[y])
 					for z := range enabledCurrencies {
 						processOrderbook(bot.exchanges[x], enabledCurrencies[z], assetTypes[y])
 					}
@@ -285,30 +618,46 @@ func WebsocketRoutine(verbose bool) {
 	log.Println("Connecting exchange websocket services...")
 
 	for i := range bot.exchanges {
-		go func(i int) {
-			if verbose {
-				log.Printf("Establishing websocket connection for %s",
-					bot.exchanges[i].GetName())
-			}
+		go StartWebsocket(bot.exchanges[i], verbose)
+	}
+}
 
-			ws, err := bot.exchanges[i].GetWebsocket()
-			if err != nil {
-				return
-			}
+// websocketHandlers holds the websockets with a running data handler, so an
+// exchange's websocket restarted at runtime is handled once
+var (
+	websocketHandlers   = make(map[*exchange.Websocket]bool)
+	websocketHandlersMu sync.Mutex
+)
 
-			// Data handler routine
-			go WebsocketDataHandler(ws, verbose)
+// StartWebsocket starts the data handler of an exchange's websocket, if not
+// already running, and connects it
+func StartWebsocket(exch exchange.IBotExchange, verbose bool) {
+	if verbose {
+		log.Printf("Establishing websocket connection for %s",
+			exch.GetName())
+	}
 
-			err = ws.Connect()
-			if err != nil {
-				switch err.Error() {
-				case exchange.WebsocketNotEnabled:
-					// Store in memory if enabled in future
-				default:
-					log.Println(err)
-				}
-			}
-		}(i)
+	ws, err := exch.GetWebsocket()
+	if err != nil {
+		return
+	}
+
+	// Data handler routine
+	websocketHandlersMu.Lock()
+	if !websocketHandlers[ws] {
+		websocketHandlers[ws] = true
+		go WebsocketDataHandler(ws, verbose)
+	}
+	websocketHandlersMu.Unlock()
+
+	err = ws.Connect()
+	if err != nil {
+		switch err.Error() {
+		case exchange.WebsocketNotEnabled:
+			// Store in memory if enabled in future
+		default:
+			log.Println(err)
+		}
 	}
 }
 
@@ -362,10 +711,33 @@ func streamDiversion(ws *exchange.Websocket, verbose bool) {
 				log.Printf("exchange %s websocket feed disconnected, switching to REST functionality",
 					ws.GetName())
 			}
+
+		case change := <-ws.StateChange:
+			websocketStateAlert(change)
 		}
 	}
 }
 
+// websocketStateAlert logs websocket connection state transitions and relays
+// dropped, restored and abandoned connections to the communication mediums
+func websocketStateAlert(c exchange.WebsocketStateChange) {
+	message := fmt.Sprintf("exchange %s websocket %s -> %s", c.Exchange, c.From, c.To)
+	if c.Attempt > 0 {
+		message += fmt.Sprintf(" attempt %d", c.Attempt)
+	}
+	if c.Err != nil {
+		message += fmt.Sprintf(": %s", c.Err)
+	}
+	log.Println(message)
+
+	dropped := c.To == exchange.WebsocketReconnecting && c.Attempt == 0
+	restored := c.To == exchange.WebsocketConnected && c.Attempt > 0
+	abandoned := c.To == exchange.WebsocketDisconnected && c.Err != nil
+	if dropped || restored || abandoned {
+		bot.comms.PushEvent(base.Event{Type: "websocket_state", TradeDetails: message})
+	}
+}
+
 // WebsocketDataHandler handles websocket data coming from a websocket feed
 // associated with an exchange
 func WebsocketDataHandler(ws *exchange.Websocket, verbose bool) {
@@ -395,8 +767,8 @@ func WebsocketDataHandler(ws *exchange.Websocket, verbose bool) {
 
 			case error:
 				switch {
-				case common.StringContains(data.(error).Error(), "close 1006"):
-					go WebsocketReconnect(ws, verbose)
+				case exchange.IsConnectionDropped(data.(error)):
+					ws.Reconnect(data.(error))
 					continue
 				default:
 					log.Fatalf("routines.go exchange %s websocket error - %s", ws.GetName(), data)
@@ -407,21 +779,64 @@ func WebsocketDataHandler(ws *exchange.Websocket, verbose bool) {
 				if verbose {
 					log.Println("Websocket trades Updated:   ", data.(exchange.TradeData))
 				}
+				persistTrade(data.(exchange.TradeData))
 
 			case exchange.TickerData:
 				// Ticker data
+				t := data.(exchange.TickerData)
 				if verbose {
-					log.Println("Websocket Ticker Updated:   ", data.(exchange.TickerData))
+					log.Println("Websocket Ticker Updated:   ", t)
+				}
+				if bot.sync != nil {
+					if !bot.sync.WebsocketUpdate(t.Exchange, SyncTicker, time.Now()) {
+						continue
+					}
+					// Keeps the stored ticker current while REST polling is
+					// paused
+					if t.AssetType == "" {
+						t.AssetType = ticker.Spot
+					}
+					ticker.ProcessTicker(t.Exchange, t.Pair, websocketTickerPrice(t), t.AssetType)
+				}
+				persistWebsocketTicker(t)
+				strategyWebsocketTick(t)
+			case exchange.MarkPriceData:
+				// Mark price data
+				if verbose {
+					log.Println("Websocket Mark Price Updated:", data.(exchange.MarkPriceData))
+				}
+				if bot.liquidation != nil {
+					bot.liquidation.UpdateMarkPrice(data.(exchange.MarkPriceData))
+				}
+				if bot.margin != nil {
+					bot.margin.UpdateMarkPrice(data.(exchange.MarkPriceData))
 				}
 			case exchange.KlineData:
 				// Kline data
 				if verbose {
 					log.Println("Websocket Kline Updated:    ", data.(exchange.KlineData))
 				}
+				persistKline(data.(exchange.KlineData))
 			case exchange.WebsocketOrderbookUpdate:
 				// Orderbook data
+				update := data.(exchange.WebsocketOrderbookUpdate)
 				if verbose {
-					log.Println("Websocket Orderbook Updated:", data.(exchange.WebsocketOrderbookUpdate))
+					log.Println("Websocket Orderbook Updated:", update)
+				}
+				if bot.sync != nil && !bot.sync.WebsocketUpdate(update.Exchange, SyncOrderbook, time.Now()) {
+					continue
+				}
+				result, err := orderbook.GetOrderbook(update.Exchange, update.Pair, update.Asset)
+				if err == nil {
+					err = quality.Default.CheckOrderbook(update.Exchange, update.Pair, update.Asset, result)
+				}
+				if err != nil && err != quality.ErrFeedQuarantined {
+					log.Printf("Websocket %s orderbook error: %s", update.Exchange, err)
+				}
+				if err == nil {
+					matchSimulatedOrders(GetExchangeByName(update.Exchange))
+					strategyOrderbook(update.Exchange, result)
+					persistOrderbook(update.Exchange, result)
 				}
 			default:
 				if verbose {
@@ -431,32 +846,3 @@ func WebsocketDataHandler(ws *exchange.Websocket, verbose bool) {
 		}
 	}
 }
-
-// WebsocketReconnect tries to reconnect to a websocket stream
-func WebsocketReconnect(ws *exchange.Websocket, verbose bool) {
-	if verbose {
-		log.Printf("Websocket reconnection requested for %s", ws.GetName())
-	}
-
-	err := ws.Shutdown()
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	wg.Add(1)
-	defer wg.Done()
-
-	ticker := time.NewTicker(3 * time.Second)
-	for {
-		select {
-		case <-shutdowner:
-			return
-
-		case <-ticker.C:
-			err = ws.Connect()
-			if err == nil {
-				return
-			}
-		}
-	}
-}
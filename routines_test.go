@@ -0,0 +1,78 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/exchanges/request"
+)
+
+func TestDeprecationNotices(t *testing.T) {
+	now := time.Date(2018, 10, 1, 0, 0, 0, 0, time.UTC)
+	notices := []request.Deprecation{
+		{Exchange: "Binance", Endpoint: "GET /api/v1/ticker", Sunset: now.Add(time.Hour * 24 * 30)},
+		{Exchange: "Bitmex", Endpoint: "announcement", Message: "v1 deprecated"},
+	}
+	sent := make(map[string]deprecationSent)
+
+	if messages := deprecationNotices(notices, sent, now); len(messages) != 2 {
+		t.Fatal("Test failed. deprecationNotices expected new notices sent", messages)
+	}
+	if messages := deprecationNotices(notices, sent, now); len(messages) != 0 {
+		t.Error("Test failed. deprecationNotices expected sent notices skipped", messages)
+	}
+
+	// A reminder is sent once the sunset date is within the reminder window
+	later := now.Add(time.Hour * 24 * 25)
+	messages := deprecationNotices(notices, sent, later)
+	if len(messages) != 1 || !strings.HasPrefix(messages[0], "Reminder: Binance") {
+		t.Error("Test failed. deprecationNotices expected a reminder", messages)
+	}
+	if messages = deprecationNotices(notices, sent, later); len(messages) != 0 {
+		t.Error("Test failed. deprecationNotices expected one reminder", messages)
+	}
+
+	// A changed sunset date is sent again
+	notices[0].Sunset = now.Add(time.Hour * 24 * 60)
+	if messages = deprecationNotices(notices, sent, later); len(messages) != 1 {
+		t.Error("Test failed. deprecationNotices expected a changed sunset date sent", messages)
+	}
+}
+
+func TestAPIKeyExpiryNotice(t *testing.T) {
+	now := time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)
+	expiry := now.Add(time.Hour * 24 * 20)
+	sent := make(map[string]apiKeyExpirySent)
+
+	if message, expired := apiKeyExpiryNotice("Bybit", time.Time{}, sent, now, 14); message != "" || expired {
+		t.Error("Test failed. apiKeyExpiryNotice expected keys without expiry skipped", message)
+	}
+	if message, _ := apiKeyExpiryNotice("Bybit", expiry, sent, now, 14); message != "" {
+		t.Error("Test failed. apiKeyExpiryNotice expected no alert outside the alert window", message)
+	}
+
+	// An alert is sent once the expiry is within the alert window
+	later := now.Add(time.Hour * 24 * 10)
+	message, expired := apiKeyExpiryNotice("Bybit", expiry, sent, later, 14)
+	if !strings.HasPrefix(message, "Bybit API key expires in 10 days") || expired {
+		t.Error("Test failed. apiKeyExpiryNotice expected an expiry alert", message)
+	}
+	if message, _ = apiKeyExpiryNotice("Bybit", expiry, sent, later, 14); message != "" {
+		t.Error("Test failed. apiKeyExpiryNotice expected one expiry alert", message)
+	}
+
+	// A rotated key with a new expiry is alerted again
+	expiry = expiry.Add(time.Hour * 24)
+	if message, _ = apiKeyExpiryNotice("Bybit", expiry, sent, later, 14); message == "" {
+		t.Error("Test failed. apiKeyExpiryNotice expected a changed expiry alerted")
+	}
+
+	message, expired = apiKeyExpiryNotice("Bybit", expiry, sent, expiry, 14)
+	if !strings.HasPrefix(message, "Bybit API key expired") || !expired {
+		t.Error("Test failed. apiKeyExpiryNotice expected an expired alert", message)
+	}
+	if message, expired = apiKeyExpiryNotice("Bybit", expiry, sent, expiry, 14); message != "" || !expired {
+		t.Error("Test failed. apiKeyExpiryNotice expected one expired alert", message)
+	}
+}
@@ -0,0 +1,1002 @@
+package main
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/base64"
+	"log"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/availability"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/abbo"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/orders"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-/gocryptotrader/execution"
+	"github.com/thrasher-/gocryptotrader/gctrpc"
+	"github.com/thrasher-/gocryptotrader/portfolio"
+	"github.com/thrasher-/gocryptotrader/withdraw"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// RPCServer implements the gctrpc GoCryptoTrader service on the bot engine
+type RPCServer struct{}
+
+// StartRPCServer starts the gRPC management server
+func StartRPCServer() {
+	cfg := bot.config.GRPC
+	listener, err := net.Listen("tcp", cfg.ListenAddress)
+	if err != nil {
+		log.Printf("Failed to start gRPC server. Err: %s", err)
+		return
+	}
+
+	opts := []grpc.ServerOption{grpc.UnaryInterceptor(authenticateRPC)}
+	if cfg.TLSCertFile != "" {
+		creds, err := credentials.NewServerTLSFromFile(cfg.TLSCertFile, cfg.TLSKeyFile)
+		if err != nil {
+			log.Printf("Failed to load gRPC TLS certificate. Err: %s", err)
+			return
+		}
+		opts = append(opts, grpc.Creds(creds))
+	} else {
+		log.Println("WARNING -- gRPC server TLS disabled, credentials are sent unencrypted.")
+	}
+
+	server := grpc.NewServer(opts...)
+	gctrpc.RegisterGoCryptoTraderServer(server, &RPCServer{})
+	log.Printf("gRPC server support enabled. Listen address: %s", cfg.ListenAddress)
+	go func() {
+		err = server.Serve(listener)
+		if err != nil {
+			log.Printf("gRPC server stopped. Err: %s", err)
+		}
+	}()
+}
+
+// authenticateRPC checks each request carries the configured basic auth
+// credentials in its authorization metadata
+func authenticateRPC(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || len(md["authorization"]) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "authorization metadata required")
+	}
+
+	auth := md["authorization"][0]
+	if !strings.HasPrefix(auth, "Basic ") {
+		return nil, status.Error(codes.Unauthenticated, "basic authorization required")
+	}
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(auth, "Basic "))
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid authorization")
+	}
+
+	expected := bot.config.GRPC.Username + ":" + bot.config.GRPC.Password
+	if subtle.ConstantTimeCompare(decoded, []byte(expected)) != 1 {
+		return nil, status.Error(codes.Unauthenticated, "invalid username or password")
+	}
+	return handler(ctx, req)
+}
+
+// rpcExchange returns a loaded exchange or a not found status
+func rpcExchange(exchangeName string) (exchange.IBotExchange, error) {
+	exch := GetExchangeByName(exchangeName)
+	if exch == nil {
+		return nil, status.Errorf(codes.NotFound, "exchange %s not loaded", exchangeName)
+	}
+	return exch, nil
+}
+
+// rpcCapability returns an Unimplemented error when an exchange doesn't
+// support a request, so unsupported requests aren't sent to the exchange
+func rpcCapability(exch exchange.IBotExchange, supported bool, request string) error {
+	if !supported {
+		return status.Errorf(codes.Unimplemented, "%s does not support %s", exch.GetName(), request)
+	}
+	return nil
+}
+
+// rpcPair converts a gctrpc currency pair
+func rpcPair(p *gctrpc.CurrencyPair) (pair.CurrencyPair, error) {
+	if p == nil || p.Base == "" || p.Quote == "" {
+		return pair.CurrencyPair{}, status.Error(codes.InvalidArgument, "currency pair base and quote required")
+	}
+	c := pair.NewCurrencyPair(p.Base, p.Quote)
+	c.Delimiter = p.Delimiter
+	return c, nil
+}
+
+// rpcAssetType returns the asset type of a request, defaulting to spot
+func rpcAssetType(assetType string) string {
+	if assetType == "" {
+		return ticker.Spot
+	}
+	return assetType
+}
+
+// GetExchanges returns the names of the loaded exchanges, or of every
+// configured exchange
+func (s *RPCServer) GetExchanges(ctx context.Context, r *gctrpc.GetExchangesRequest) (*gctrpc.GetExchangesResponse, error) {
+	var names []string
+	if r.All {
+		for x := range bot.config.Exchanges {
+			names = append(names, bot.config.Exchanges[x].Name)
+		}
+	} else {
+		for x := range bot.exchanges {
+			names = append(names, bot.exchanges[x].GetName())
+		}
+	}
+	return &gctrpc.GetExchangesResponse{Exchanges: names}, nil
+}
+
+// EnableExchange loads and starts a configured exchange
+func (s *RPCServer) EnableExchange(ctx context.Context, r *gctrpc.GenericExchangeNameRequest) (*gctrpc.GenericResponse, error) {
+	err := LoadExchange(r.Exchange, false, nil)
+	switch err {
+	case nil:
+		return &gctrpc.GenericResponse{Status: "enabled"}, nil
+	case ErrExchangeNotFound:
+		return nil, status.Error(codes.NotFound, err.Error())
+	case ErrExchangeAlreadyLoaded:
+		return nil, status.Error(codes.AlreadyExists, err.Error())
+	default:
+		return nil, err
+	}
+}
+
+// DisableExchange stops and unloads an exchange
+func (s *RPCServer) DisableExchange(ctx context.Context, r *gctrpc.GenericExchangeNameRequest) (*gctrpc.GenericResponse, error) {
+	exch, err := rpcExchange(r.Exchange)
+	if err != nil {
+		return nil, err
+	}
+	err = UnloadExchange(exch.GetName())
+	if err != nil {
+		return nil, err
+	}
+	return &gctrpc.GenericResponse{Status: "disabled"}, nil
+}
+
+// StartExchange starts a stopped exchange's subsystems
+func (s *RPCServer) StartExchange(ctx context.Context, r *gctrpc.GenericExchangeNameRequest) (*gctrpc.GenericResponse, error) {
+	exch, err := rpcExchange(r.Exchange)
+	if err != nil {
+		return nil, err
+	}
+	err = StartExchange(exch.GetName())
+	if err == ErrExchangeAlreadyStarted {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &gctrpc.GenericResponse{Status: "started"}, nil
+}
+
+// StopExchange stops an exchange's subsystems while keeping it loaded
+func (s *RPCServer) StopExchange(ctx context.Context, r *gctrpc.GenericExchangeNameRequest) (*gctrpc.GenericResponse, error) {
+	exch, err := rpcExchange(r.Exchange)
+	if err != nil {
+		return nil, err
+	}
+	err = StopExchange(exch.GetName())
+	if err == ErrExchangeAlreadyStopped {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &gctrpc.GenericResponse{Status: "stopped"}, nil
+}
+
+// RestartExchange stops an exchange, reloads its config and starts it again
+func (s *RPCServer) RestartExchange(ctx context.Context, r *gctrpc.GenericExchangeNameRequest) (*gctrpc.GenericResponse, error) {
+	exch, err := rpcExchange(r.Exchange)
+	if err != nil {
+		return nil, err
+	}
+	err = RestartExchange(exch.GetName())
+	if err != nil {
+		return nil, err
+	}
+	return &gctrpc.GenericResponse{Status: "restarted"}, nil
+}
+
+// GetExchangeCapabilities returns the wrapper methods and websocket streams a
+// loaded exchange supports
+func (s *RPCServer) GetExchangeCapabilities(ctx context.Context, r *gctrpc.GenericExchangeNameRequest) (*gctrpc.GetExchangeCapabilitiesResponse, error) {
+	exch, err := rpcExchange(r.Exchange)
+	if err != nil {
+		return nil, err
+	}
+	resp := &gctrpc.GetExchangeCapabilitiesResponse{Exchange: exch.GetName()}
+	for _, c := range exch.GetCapabilities().Capabilities() {
+		resp.Capabilities = append(resp.Capabilities, string(c))
+	}
+	return resp, nil
+}
+
+// GetExchangeHealth returns the health monitor status of an exchange, or of
+// every checked exchange when the exchange name is empty
+func (s *RPCServer) GetExchangeHealth(ctx context.Context, r *gctrpc.GenericExchangeNameRequest) (*gctrpc.GetExchangeHealthResponse, error) {
+	if bot.health == nil {
+		return nil, status.Error(codes.FailedPrecondition, "health monitor not started")
+	}
+
+	statuses := bot.health.Statuses()
+	if r.Exchange != "" {
+		st, ok := bot.health.Status(r.Exchange)
+		if !ok {
+			return nil, status.Errorf(codes.NotFound, "exchange %s not checked", r.Exchange)
+		}
+		statuses = []availability.Status{st}
+	}
+
+	resp := &gctrpc.GetExchangeHealthResponse{}
+	for i := range statuses {
+		resp.Exchanges = append(resp.Exchanges, rpcExchangeHealth(&statuses[i]))
+	}
+	return resp, nil
+}
+
+// rpcExchangeHealth converts the health status of an exchange, including
+// whether its order submission is paused
+func rpcExchangeHealth(st *availability.Status) *gctrpc.ExchangeHealth {
+	h := &gctrpc.ExchangeHealth{
+		Exchange:            st.Exchange,
+		Degraded:            st.Degraded,
+		ConsecutiveFailures: int64(st.ConsecutiveFailures),
+		Checks:              int64(st.Checks),
+		Failures:            int64(st.Failures),
+		LastCheck:           st.LastCheck.Unix(),
+		LastLatencyMs:       int64(st.LastLatency / time.Millisecond),
+		AverageLatencyMs:    int64(st.AverageLatency / time.Millisecond),
+		LastError:           st.LastError,
+	}
+	if st.Degraded {
+		h.DegradedSince = st.DegradedSince.Unix()
+	}
+	h.PauseReason, h.OrderSubmissionPaused = exchange.OrderSubmissionPaused(st.Exchange)
+	return h
+}
+
+// GetTicker fetches the latest ticker of a currency pair
+func (s *RPCServer) GetTicker(ctx context.Context, r *gctrpc.GetTickerRequest) (*gctrpc.TickerResponse, error) {
+	exch, err := rpcExchange(r.Exchange)
+	if err != nil {
+		return nil, err
+	}
+	p, err := rpcPair(r.Pair)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := exchange.UpdateTickerContext(ctx, exch, p, rpcAssetType(r.AssetType))
+	if err != nil {
+		return nil, err
+	}
+	return &gctrpc.TickerResponse{
+		Pair:        r.Pair,
+		LastUpdated: t.LastUpdated.Unix(),
+		Last:        t.Last,
+		High:        t.High,
+		Low:         t.Low,
+		Bid:         t.Bid,
+		Ask:         t.Ask,
+		Volume:      t.Volume,
+	}, nil
+}
+
+// GetOrderbook fetches the latest orderbook of a currency pair
+func (s *RPCServer) GetOrderbook(ctx context.Context, r *gctrpc.GetOrderbookRequest) (*gctrpc.OrderbookResponse, error) {
+	exch, err := rpcExchange(r.Exchange)
+	if err != nil {
+		return nil, err
+	}
+	p, err := rpcPair(r.Pair)
+	if err != nil {
+		return nil, err
+	}
+
+	assetType := rpcAssetType(r.AssetType)
+	ob, err := exchange.UpdateOrderbookContext(ctx, exch, p, assetType)
+	if err != nil {
+		return nil, err
+	}
+	return &gctrpc.OrderbookResponse{
+		Pair:        r.Pair,
+		Bids:        rpcOrderbookItems(ob.Bids),
+		Asks:        rpcOrderbookItems(ob.Asks),
+		LastUpdated: ob.LastUpdated.Unix(),
+		AssetType:   assetType,
+	}, nil
+}
+
+// rpcOrderbookItems converts orderbook items to gctrpc orderbook items
+func rpcOrderbookItems(items []orderbook.Item) []*gctrpc.OrderbookItem {
+	result := make([]*gctrpc.OrderbookItem, len(items))
+	for i := range items {
+		result[i] = &gctrpc.OrderbookItem{
+			Amount: items[i].Amount,
+			Price:  items[i].Price,
+			Id:     items[i].ID,
+		}
+	}
+	return result
+}
+
+// GetAccountInfo fetches the account balances of an exchange
+func (s *RPCServer) GetAccountInfo(ctx context.Context, r *gctrpc.GenericExchangeNameRequest) (*gctrpc.GetAccountInfoResponse, error) {
+	exch, err := rpcExchange(r.Exchange)
+	if err != nil {
+		return nil, err
+	}
+	if err = rpcCapability(exch, exch.GetCapabilities().CanGetAccountInfo, "account info"); err != nil {
+		return nil, err
+	}
+
+	info, err := exchange.GetAccountInfoContext(ctx, exch)
+	if err != nil {
+		return nil, err
+	}
+	resp := &gctrpc.GetAccountInfoResponse{Exchange: exch.GetName()}
+	for x := range info.Currencies {
+		resp.Currencies = append(resp.Currencies, &gctrpc.AccountCurrencyInfo{
+			Currency:   info.Currencies[x].CurrencyName,
+			TotalValue: info.Currencies[x].TotalValue,
+			Hold:       info.Currencies[x].Hold,
+		})
+	}
+	return resp, nil
+}
+
+// rpcOrderSide parses an order side case insensitively
+func rpcOrderSide(side string) (exchange.OrderSide, error) {
+	for _, s := range []exchange.OrderSide{exchange.Buy, exchange.Sell} {
+		if strings.EqualFold(side, string(s)) {
+			return s, nil
+		}
+	}
+	return "", status.Errorf(codes.InvalidArgument, "unsupported order side %s", side)
+}
+
+// rpcOrderType parses an order type case insensitively
+func rpcOrderType(orderType string) (exchange.OrderType, error) {
+	for _, t := range []exchange.OrderType{exchange.Limit, exchange.Market, exchange.ImmediateOrCancel} {
+		if strings.EqualFold(orderType, string(t)) {
+			return t, nil
+		}
+	}
+	return "", status.Errorf(codes.InvalidArgument, "unsupported order type %s", orderType)
+}
+
+// SubmitOrder submits an order sized in either the base or quote currency
+func (s *RPCServer) SubmitOrder(ctx context.Context, r *gctrpc.SubmitOrderRequest) (*gctrpc.SubmitOrderResponse, error) {
+	exch, err := rpcExchange(r.Exchange)
+	if err != nil {
+		return nil, err
+	}
+	if err = rpcCapability(exch, exch.GetCapabilities().CanSubmitOrder, "order submission"); err != nil {
+		return nil, err
+	}
+	p, err := rpcPair(r.Pair)
+	if err != nil {
+		return nil, err
+	}
+	side, err := rpcOrderSide(r.Side)
+	if err != nil {
+		return nil, err
+	}
+	orderType, err := rpcOrderType(r.OrderType)
+	if err != nil {
+		return nil, err
+	}
+
+	order := exchange.OrderSubmission{
+		Pair:        p,
+		Side:        side,
+		Type:        orderType,
+		BaseAmount:  r.BaseAmount,
+		QuoteAmount: r.QuoteAmount,
+		Price:       r.Price,
+		ClientID:    r.ClientId,
+	}
+	err = order.Validate()
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	// The submission runs to completion once sent
+	if err = ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	resp, _, err := bot.orderManager.Submit(exch, order)
+	if err != nil {
+		return nil, rpcOrderError(err)
+	}
+	return &gctrpc.SubmitOrderResponse{
+		OrderPlaced: resp.IsOrderPlaced,
+		OrderId:     resp.OrderID,
+	}, nil
+}
+
+// CancelOrder cancels an open order
+func (s *RPCServer) CancelOrder(ctx context.Context, r *gctrpc.CancelOrderRequest) (*gctrpc.GenericResponse, error) {
+	exch, err := rpcExchange(r.Exchange)
+	if err != nil {
+		return nil, err
+	}
+	if err = rpcCapability(exch, exch.GetCapabilities().CanCancelOrder, "order cancellation"); err != nil {
+		return nil, err
+	}
+	if r.OrderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "order ID required")
+	}
+
+	cancel := exchange.OrderCancellation{
+		AccountID:     r.AccountId,
+		OrderID:       r.OrderId,
+		WalletAddress: r.WalletAddress,
+	}
+	if r.Pair != nil {
+		cancel.CurrencyPair, err = rpcPair(r.Pair)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if r.Side != "" {
+		cancel.Side, err = rpcOrderSide(r.Side)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !exchange.Cancellable(exch) {
+		// The cancellation runs to completion once sent
+		if err = ctx.Err(); err != nil {
+			return nil, err
+		}
+		ctx = context.Background()
+	}
+	err = exchange.CancelOrderContext(ctx, exch, cancel)
+	if err != nil {
+		return nil, err
+	}
+	return &gctrpc.GenericResponse{Status: "cancelled"}, nil
+}
+
+// GetArbitrageOpportunities returns the ranked opportunities of the arbitrage
+// scanner's last scan
+func (s *RPCServer) GetArbitrageOpportunities(ctx context.Context, r *gctrpc.GetArbitrageOpportunitiesRequest) (*gctrpc.GetArbitrageOpportunitiesResponse, error) {
+	if bot.arbitrage == nil {
+		return nil, status.Error(codes.FailedPrecondition, "arbitrage scanner disabled")
+	}
+	var filter string
+	if r.Pair != nil {
+		p, err := rpcPair(r.Pair)
+		if err != nil {
+			return nil, err
+		}
+		filter = abbo.CanonicalPair(p).Pair().String()
+	}
+
+	resp := &gctrpc.GetArbitrageOpportunitiesResponse{}
+	for _, o := range bot.arbitrage.Latest() {
+		if filter != "" && o.Pair.Pair().String() != filter {
+			continue
+		}
+		if r.Exchange != "" && !strings.EqualFold(o.BuyExchange, r.Exchange) &&
+			!strings.EqualFold(o.SellExchange, r.Exchange) {
+			continue
+		}
+		if r.Limit > 0 && len(resp.Opportunities) == int(r.Limit) {
+			break
+		}
+		resp.Opportunities = append(resp.Opportunities, &gctrpc.ArbitrageOpportunity{
+			Pair: &gctrpc.CurrencyPair{
+				Delimiter: o.Pair.Delimiter,
+				Base:      o.Pair.FirstCurrency.String(),
+				Quote:     o.Pair.SecondCurrency.String(),
+			},
+			BuyExchange:   o.BuyExchange,
+			BuyPrice:      o.BuyPrice,
+			SellExchange:  o.SellExchange,
+			SellPrice:     o.SellPrice,
+			Amount:        o.Amount,
+			SpreadPercent: o.SpreadPercent,
+			Costs:         o.Costs,
+			Profit:        o.Profit,
+			ProfitPercent: o.ProfitPercent,
+			Time:          o.Time.Unix(),
+		})
+	}
+	return resp, nil
+}
+
+// GetPortfolioValuation returns the portfolio sync's latest valuation of the
+// portfolio
+func (s *RPCServer) GetPortfolioValuation(ctx context.Context, r *gctrpc.GetPortfolioValuationRequest) (*gctrpc.PortfolioValuation, error) {
+	if bot.portfolioSync == nil {
+		return nil, status.Error(codes.FailedPrecondition, "portfolio sync disabled")
+	}
+	v, err := bot.portfolioSync.Latest()
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	if r.Exchange != "" {
+		if _, ok := rpcExchangeValuation(&v, r.Exchange); !ok {
+			return nil, status.Errorf(codes.NotFound, "no %s holdings in portfolio", r.Exchange)
+		}
+	}
+	return rpcPortfolioValuation(&v, r.Exchange), nil
+}
+
+// GetPortfolioSnapshots returns the recorded valuations of the portfolio
+func (s *RPCServer) GetPortfolioSnapshots(ctx context.Context, r *gctrpc.GetPortfolioSnapshotsRequest) (*gctrpc.GetPortfolioSnapshotsResponse, error) {
+	if bot.portfolioSync == nil || bot.portfolioSync.Snapshots == nil {
+		return nil, status.Error(codes.FailedPrecondition, "portfolio sync disabled")
+	}
+	var end time.Time
+	if r.End != 0 {
+		end = time.Unix(r.End, 0)
+	}
+	resp := &gctrpc.GetPortfolioSnapshotsResponse{}
+	snapshots := bot.portfolioSync.Snapshots.Between(time.Unix(r.Start, 0), end)
+	for i := range snapshots {
+		resp.Snapshots = append(resp.Snapshots, rpcPortfolioValuation(&snapshots[i], r.Exchange))
+	}
+	return resp, nil
+}
+
+// rpcExchangeValuation returns the valuation of an exchange's holdings,
+// matching its name case insensitively
+func rpcExchangeValuation(v *portfolio.Valuation, exchName string) (portfolio.HoldingsValuation, bool) {
+	for i := range v.Exchanges {
+		if strings.EqualFold(v.Exchanges[i].Name, exchName) {
+			return v.Exchanges[i], true
+		}
+	}
+	return portfolio.HoldingsValuation{}, false
+}
+
+// rpcPortfolioValuation converts a portfolio valuation, limiting its exchange
+// valuations to exchName when set
+func rpcPortfolioValuation(v *portfolio.Valuation, exchName string) *gctrpc.PortfolioValuation {
+	holdings := func(h *portfolio.HoldingsValuation) *gctrpc.HoldingsValuation {
+		resp := &gctrpc.HoldingsValuation{Name: h.Name, Value: h.Value}
+		for _, c := range h.Coins {
+			resp.Coins = append(resp.Coins, &gctrpc.CoinValuation{
+				Coin:    c.Coin,
+				Balance: c.Balance,
+				Value:   c.Value,
+			})
+		}
+		return resp
+	}
+
+	resp := &gctrpc.PortfolioValuation{
+		Time:         v.Time.Unix(),
+		BaseCurrency: v.BaseCurrency,
+		Total:        v.Total,
+		OnChain:      holdings(&v.OnChain),
+		Earn:         holdings(&v.Earn),
+		Unpriced:     v.Unpriced,
+	}
+	for i := range v.Exchanges {
+		if exchName != "" && !strings.EqualFold(v.Exchanges[i].Name, exchName) {
+			continue
+		}
+		resp.Exchanges = append(resp.Exchanges, holdings(&v.Exchanges[i]))
+	}
+	return resp
+}
+
+// GetWithdrawalAddresses returns the withdrawal address book entries with the
+// amounts withdrawn within their limit windows
+func (s *RPCServer) GetWithdrawalAddresses(ctx context.Context, r *gctrpc.GetWithdrawalAddressesRequest) (*gctrpc.GetWithdrawalAddressesResponse, error) {
+	if bot.addressBook == nil {
+		return nil, status.Error(codes.FailedPrecondition, "withdrawal address book disabled")
+	}
+	resp := &gctrpc.GetWithdrawalAddressesResponse{}
+	for _, a := range bot.addressBook.List() {
+		if (r.Exchange != "" && !strings.EqualFold(a.Exchange, r.Exchange)) ||
+			(r.Currency != "" && !strings.EqualFold(a.Currency.String(), r.Currency)) {
+			continue
+		}
+		resp.Addresses = append(resp.Addresses, rpcWithdrawalAddress(&a))
+	}
+	return resp, nil
+}
+
+// AddWithdrawalAddress adds an unverified withdrawal address book entry
+func (s *RPCServer) AddWithdrawalAddress(ctx context.Context, r *gctrpc.WithdrawalAddress) (*gctrpc.WithdrawalAddress, error) {
+	if bot.addressBook == nil {
+		return nil, status.Error(codes.FailedPrecondition, "withdrawal address book disabled")
+	}
+	a, err := bot.addressBook.Add(rpcAddressBookEntry(r))
+	if err != nil {
+		return nil, rpcAddressBookError(err)
+	}
+	return rpcWithdrawalAddress(&a), nil
+}
+
+// UpdateWithdrawalAddress replaces the label, destination and limits of a
+// withdrawal address book entry
+func (s *RPCServer) UpdateWithdrawalAddress(ctx context.Context, r *gctrpc.WithdrawalAddress) (*gctrpc.WithdrawalAddress, error) {
+	if bot.addressBook == nil {
+		return nil, status.Error(codes.FailedPrecondition, "withdrawal address book disabled")
+	}
+	a, err := bot.addressBook.Update(rpcAddressBookEntry(r))
+	if err != nil {
+		return nil, rpcAddressBookError(err)
+	}
+	return rpcWithdrawalAddress(&a), nil
+}
+
+// RemoveWithdrawalAddress removes a withdrawal address book entry
+func (s *RPCServer) RemoveWithdrawalAddress(ctx context.Context, r *gctrpc.WithdrawalAddressRequest) (*gctrpc.GenericResponse, error) {
+	if bot.addressBook == nil {
+		return nil, status.Error(codes.FailedPrecondition, "withdrawal address book disabled")
+	}
+	err := bot.addressBook.Remove(r.Id)
+	if err != nil {
+		return nil, rpcAddressBookError(err)
+	}
+	return &gctrpc.GenericResponse{Status: "removed"}, nil
+}
+
+// LockWithdrawalAddress locks or unlocks withdrawals to an address
+func (s *RPCServer) LockWithdrawalAddress(ctx context.Context, r *gctrpc.SetWithdrawalAddressFlagRequest) (*gctrpc.WithdrawalAddress, error) {
+	if bot.addressBook == nil {
+		return nil, status.Error(codes.FailedPrecondition, "withdrawal address book disabled")
+	}
+	a, err := bot.addressBook.Lock(r.Id, r.Value)
+	if err != nil {
+		return nil, rpcAddressBookError(err)
+	}
+	return rpcWithdrawalAddress(&a), nil
+}
+
+// VerifyWithdrawalAddress marks an address as verified or unverified
+func (s *RPCServer) VerifyWithdrawalAddress(ctx context.Context, r *gctrpc.SetWithdrawalAddressFlagRequest) (*gctrpc.WithdrawalAddress, error) {
+	if bot.addressBook == nil {
+		return nil, status.Error(codes.FailedPrecondition, "withdrawal address book disabled")
+	}
+	a, err := bot.addressBook.Verify(r.Id, r.Value)
+	if err != nil {
+		return nil, rpcAddressBookError(err)
+	}
+	return rpcWithdrawalAddress(&a), nil
+}
+
+// rpcAddressBookEntry converts a requested withdrawal address book entry
+func rpcAddressBookEntry(r *gctrpc.WithdrawalAddress) withdraw.Address {
+	return withdraw.Address{
+		ID:           r.Id,
+		Label:        r.Label,
+		Exchange:     r.Exchange,
+		Currency:     pair.CurrencyItem(r.Currency),
+		Chain:        r.Chain,
+		Address:      r.Address,
+		DailyLimit:   r.DailyLimit,
+		MonthlyLimit: r.MonthlyLimit,
+	}
+}
+
+// rpcWithdrawalAddress converts a withdrawal address book entry along with
+// its usage
+func rpcWithdrawalAddress(a *withdraw.Address) *gctrpc.WithdrawalAddress {
+	daily, monthly, _ := bot.addressBook.Usage(a.ID, time.Now())
+	return &gctrpc.WithdrawalAddress{
+		Id:           a.ID,
+		Label:        a.Label,
+		Exchange:     a.Exchange,
+		Currency:     a.Currency.String(),
+		Chain:        a.Chain,
+		Address:      a.Address,
+		DailyLimit:   a.DailyLimit,
+		MonthlyLimit: a.MonthlyLimit,
+		Locked:       a.Locked,
+		Verified:     a.Verified,
+		Added:        a.Added.Unix(),
+		DailyUsed:    daily,
+		MonthlyUsed:  monthly,
+	}
+}
+
+// rpcOrderError returns the gRPC status of an order submission error
+func rpcOrderError(err error) error {
+	switch e := err.(type) {
+	case *exchange.OrderCapabilityError:
+		return status.Error(codes.Unimplemented, err.Error())
+	case *orders.Rejection:
+		switch e.Reason {
+		case orders.RejectRateLimited:
+			return status.Error(codes.ResourceExhausted, err.Error())
+		case orders.RejectExchangeUnavailable:
+			return status.Error(codes.Unavailable, err.Error())
+		default:
+			return status.Error(codes.FailedPrecondition, err.Error())
+		}
+	default:
+		return err
+	}
+}
+
+// rpcAddressBookError returns the gRPC status of an address book error
+func rpcAddressBookError(err error) error {
+	switch err {
+	case withdraw.ErrInvalidAddress:
+		return status.Error(codes.InvalidArgument, err.Error())
+	case withdraw.ErrAddressNotFound:
+		return status.Error(codes.NotFound, err.Error())
+	case withdraw.ErrAddressExists:
+		return status.Error(codes.AlreadyExists, err.Error())
+	default:
+		return err
+	}
+}
+
+// StartExecution starts slicing a parent order into child orders with the
+// TWAP, VWAP or iceberg execution algorithm
+func (s *RPCServer) StartExecution(ctx context.Context, r *gctrpc.StartExecutionRequest) (*gctrpc.Execution, error) {
+	if bot.execution == nil {
+		return nil, status.Error(codes.FailedPrecondition, "execution manager disabled")
+	}
+	exch, err := rpcExchange(r.Exchange)
+	if err != nil {
+		return nil, err
+	}
+	if err = rpcCapability(exch, exch.GetCapabilities().CanSubmitOrder, "order submission"); err != nil {
+		return nil, err
+	}
+	p, err := rpcPair(r.Pair)
+	if err != nil {
+		return nil, err
+	}
+	side, err := rpcOrderSide(r.Side)
+	if err != nil {
+		return nil, err
+	}
+
+	params := execution.Params{
+		Algorithm:     r.Algorithm,
+		Exchange:      exch.GetName(),
+		Pair:          p,
+		Side:          side,
+		Amount:        r.Amount,
+		Price:         r.Price,
+		Slices:        int(r.Slices),
+		VisibleAmount: r.VisibleAmount,
+		Randomization: r.Randomization,
+		MaxDeviation:  r.MaxDeviation,
+		VolumeProfile: r.VolumeProfile,
+	}
+	if r.Duration != "" {
+		params.Duration, err = time.ParseDuration(r.Duration)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid duration %s", r.Duration)
+		}
+	}
+	if err = params.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	e, err := bot.execution.Start(params)
+	if err != nil {
+		return nil, err
+	}
+	return rpcExecution(&e), nil
+}
+
+// GetExecutions returns the executions started since the bot started,
+// optionally filtered by status
+func (s *RPCServer) GetExecutions(ctx context.Context, r *gctrpc.GetExecutionsRequest) (*gctrpc.GetExecutionsResponse, error) {
+	if bot.execution == nil {
+		return nil, status.Error(codes.FailedPrecondition, "execution manager disabled")
+	}
+	resp := &gctrpc.GetExecutionsResponse{}
+	for _, e := range bot.execution.Executions() {
+		if r.Status != "" && !strings.EqualFold(e.Status, r.Status) {
+			continue
+		}
+		resp.Executions = append(resp.Executions, rpcExecution(&e))
+	}
+	return resp, nil
+}
+
+// CancelExecution stops an execution and cancels its open child orders
+func (s *RPCServer) CancelExecution(ctx context.Context, r *gctrpc.CancelExecutionRequest) (*gctrpc.GenericResponse, error) {
+	if bot.execution == nil {
+		return nil, status.Error(codes.FailedPrecondition, "execution manager disabled")
+	}
+	err := bot.execution.Cancel(int(r.Id))
+	switch err {
+	case nil:
+		return &gctrpc.GenericResponse{Status: "cancelled"}, nil
+	case execution.ErrNotFound:
+		return nil, status.Error(codes.NotFound, err.Error())
+	case execution.ErrFinished:
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return nil, err
+	}
+}
+
+// rpcExecution converts the state of an execution
+func rpcExecution(e *execution.Execution) *gctrpc.Execution {
+	resp := &gctrpc.Execution{
+		Id:        int64(e.ID),
+		Algorithm: e.Params.Algorithm,
+		Exchange:  e.Params.Exchange,
+		Pair: &gctrpc.CurrencyPair{
+			Delimiter: e.Params.Pair.Delimiter,
+			Base:      e.Params.Pair.FirstCurrency.String(),
+			Quote:     e.Params.Pair.SecondCurrency.String(),
+		},
+		Side:           string(e.Params.Side),
+		Amount:         e.Params.Amount,
+		Price:          e.Params.Price,
+		Status:         e.Status,
+		Started:        e.Started.Unix(),
+		ReferencePrice: e.ReferencePrice,
+		FilledAmount:   e.FilledAmount,
+		AveragePrice:   e.AveragePrice,
+	}
+	if e.Err != nil {
+		resp.Error = e.Err.Error()
+	}
+	if !e.Finished.IsZero() {
+		resp.Finished = e.Finished.Unix()
+	}
+	for _, id := range e.Children {
+		resp.ChildOrderIds = append(resp.ChildOrderIds, int64(id))
+	}
+	return resp
+}
+
+// RequestWithdrawal requests a withdrawal to a whitelisted or address book
+// address, it is only sent once approved. When withdrawal batching is enabled
+// the withdrawal is queued and requested for approval along with the other
+// withdrawals to its destination.
+func (s *RPCServer) RequestWithdrawal(ctx context.Context, r *gctrpc.RequestWithdrawalRequest) (*gctrpc.Withdrawal, error) {
+	if bot.withdrawals == nil {
+		return nil, status.Error(codes.FailedPrecondition, "withdrawals disabled")
+	}
+	exch, err := rpcExchange(r.Exchange)
+	if err != nil {
+		return nil, err
+	}
+	if bot.batches != nil {
+		q := withdraw.Request{
+			Exchange: exch.GetName(),
+			Currency: pair.CurrencyItem(strings.ToUpper(r.Currency)),
+			Address:  r.Address,
+			Amount:   r.Amount,
+		}
+		q.ID, err = bot.batches.Queue(q)
+		if err != nil {
+			return nil, rpcWithdrawalError(err)
+		}
+		return rpcQueuedWithdrawal(&q), nil
+	}
+	w, err := bot.withdrawals.Request(exch.GetName(), pair.CurrencyItem(r.Currency), r.Address, r.Amount, time.Now())
+	if err != nil {
+		return nil, rpcWithdrawalError(err)
+	}
+	return rpcWithdrawal(&w), nil
+}
+
+// GetWithdrawals returns the withdrawals requested since the bot started,
+// optionally filtered by status
+func (s *RPCServer) GetWithdrawals(ctx context.Context, r *gctrpc.GetWithdrawalsRequest) (*gctrpc.GetWithdrawalsResponse, error) {
+	if bot.withdrawals == nil {
+		return nil, status.Error(codes.FailedPrecondition, "withdrawals disabled")
+	}
+	resp := &gctrpc.GetWithdrawalsResponse{}
+	for _, w := range bot.withdrawals.Withdrawals() {
+		if r.Status != "" && !strings.EqualFold(w.Status, r.Status) {
+			continue
+		}
+		resp.Withdrawals = append(resp.Withdrawals, rpcWithdrawal(&w))
+	}
+	if bot.batches != nil && (r.Status == "" || strings.EqualFold(r.Status, withdraw.StatusQueued)) {
+		for _, q := range bot.batches.Pending() {
+			resp.Withdrawals = append(resp.Withdrawals, rpcQueuedWithdrawal(&q))
+		}
+	}
+	return resp, nil
+}
+
+// ApproveWithdrawal revalidates a pending withdrawal and sends it
+func (s *RPCServer) ApproveWithdrawal(ctx context.Context, r *gctrpc.ApproveWithdrawalRequest) (*gctrpc.Withdrawal, error) {
+	if bot.withdrawals == nil {
+		return nil, status.Error(codes.FailedPrecondition, "withdrawals disabled")
+	}
+	w, err := bot.withdrawals.Approve(r.Id, time.Now())
+	if err == nil {
+		return rpcWithdrawal(&w), nil
+	}
+	if w.Status == withdraw.StatusFailed {
+		return nil, err
+	}
+	return nil, rpcWithdrawalError(err)
+}
+
+// RejectWithdrawal rejects a pending withdrawal or removes a withdrawal queued
+// for batching
+func (s *RPCServer) RejectWithdrawal(ctx context.Context, r *gctrpc.RejectWithdrawalRequest) (*gctrpc.Withdrawal, error) {
+	if bot.withdrawals == nil {
+		return nil, status.Error(codes.FailedPrecondition, "withdrawals disabled")
+	}
+	if bot.batches != nil {
+		for _, q := range bot.batches.Pending() {
+			if q.ID != r.Id || bot.batches.Cancel(q.ID) != nil {
+				continue
+			}
+			w := rpcQueuedWithdrawal(&q)
+			w.Status = withdraw.StatusRejected
+			w.Reason = r.Reason
+			w.Updated = time.Now().Unix()
+			return w, nil
+		}
+	}
+	w, err := bot.withdrawals.Reject(r.Id, r.Reason, time.Now())
+	if err != nil {
+		return nil, rpcWithdrawalError(err)
+	}
+	return rpcWithdrawal(&w), nil
+}
+
+// rpcWithdrawal converts a withdrawal of the approval workflow
+func rpcWithdrawal(w *withdraw.Withdrawal) *gctrpc.Withdrawal {
+	return &gctrpc.Withdrawal{
+		Id:        w.ID,
+		Exchange:  w.Exchange,
+		Currency:  w.Currency.String(),
+		Address:   w.Address,
+		Amount:    w.Amount,
+		Status:    w.Status,
+		Reason:    w.Reason,
+		TxId:      w.TxID,
+		Requested: w.Requested.Unix(),
+		Updated:   w.Updated.Unix(),
+	}
+}
+
+// rpcQueuedWithdrawal converts a withdrawal queued for batching
+func rpcQueuedWithdrawal(q *withdraw.Request) *gctrpc.Withdrawal {
+	return &gctrpc.Withdrawal{
+		Id:        q.ID,
+		Exchange:  q.Exchange,
+		Currency:  q.Currency.String(),
+		Address:   q.Address,
+		Amount:    q.Amount,
+		Status:    withdraw.StatusQueued,
+		Requested: q.Requested.Unix(),
+		Updated:   q.Requested.Unix(),
+	}
+}
+
+// rpcWithdrawalError maps approval workflow errors to gRPC status codes,
+// withdrawals denied by the whitelist, address book or daily limits fail
+// their precondition
+func rpcWithdrawalError(err error) error {
+	switch err {
+	case withdraw.ErrInvalidRequest:
+		return status.Error(codes.InvalidArgument, err.Error())
+	case withdraw.ErrRequestNotFound:
+		return status.Error(codes.NotFound, err.Error())
+	default:
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+}
@@ -0,0 +1,473 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/arbitrage"
+	"github.com/thrasher-/gocryptotrader/availability"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/orders"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-/gocryptotrader/execution"
+	"github.com/thrasher-/gocryptotrader/gctrpc"
+	"github.com/thrasher-/gocryptotrader/portfolio"
+	"github.com/thrasher-/gocryptotrader/withdraw"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestAuthenticateRPC(t *testing.T) {
+	SetupTest(t)
+	bot.config.GRPC.Username = "admin"
+	bot.config.GRPC.Password = "Password"
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+	authenticate := func(auth string) error {
+		ctx := context.Background()
+		if auth != "" {
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", auth))
+		}
+		_, err := authenticateRPC(ctx, nil, &grpc.UnaryServerInfo{}, handler)
+		return err
+	}
+
+	if err := authenticate(""); status.Code(err) != codes.Unauthenticated {
+		t.Error("Test Failed - authenticateRPC() missing metadata error", err)
+	}
+	badAuth := "Basic " + base64.StdEncoding.EncodeToString([]byte("admin:wrong"))
+	if err := authenticate(badAuth); status.Code(err) != codes.Unauthenticated {
+		t.Error("Test Failed - authenticateRPC() invalid password error", err)
+	}
+	auth := "Basic " + base64.StdEncoding.EncodeToString([]byte("admin:Password"))
+	if err := authenticate(auth); err != nil {
+		t.Error("Test Failed - authenticateRPC() error", err)
+	}
+}
+
+func TestRPCOrderSide(t *testing.T) {
+	side, err := rpcOrderSide("sell")
+	if err != nil || side != exchange.Sell {
+		t.Error("Test Failed - rpcOrderSide() error", err)
+	}
+	_, err = rpcOrderSide("short")
+	if status.Code(err) != codes.InvalidArgument {
+		t.Error("Test Failed - rpcOrderSide() unsupported side error", err)
+	}
+	orderType, err := rpcOrderType("LIMIT")
+	if err != nil || orderType != exchange.Limit {
+		t.Error("Test Failed - rpcOrderType() error", err)
+	}
+}
+
+type testArbitrageExchange struct {
+	exchange.IBotExchange
+	name string
+}
+
+func (e *testArbitrageExchange) GetName() string { return e.name }
+
+func (e *testArbitrageExchange) IsEnabled() bool { return true }
+
+func (e *testArbitrageExchange) GetEnabledPairs(assetType string) []pair.CurrencyPair {
+	return []pair.CurrencyPair{pair.NewCurrencyPair("BTC", "USD"), pair.NewCurrencyPair("LTC", "USD")}
+}
+
+func TestGetArbitrageOpportunities(t *testing.T) {
+	bot.arbitrage = nil
+	s := &RPCServer{}
+	_, err := s.GetArbitrageOpportunities(context.Background(), &gctrpc.GetArbitrageOpportunitiesRequest{})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Error("Test Failed - GetArbitrageOpportunities() expected disabled error", err)
+	}
+
+	exchs := []exchange.IBotExchange{
+		&testArbitrageExchange{name: "RPCArbitrageA"},
+		&testArbitrageExchange{name: "RPCArbitrageB"},
+	}
+	for i, p := range exchs[0].GetEnabledPairs(ticker.Spot) {
+		ticker.ProcessTicker("RPCArbitrageA", p, ticker.Price{Bid: 99, Ask: 100 + float64(i)}, ticker.Spot)
+		ticker.ProcessTicker("RPCArbitrageB", p, ticker.Price{Bid: 103, Ask: 104}, ticker.Spot)
+	}
+	bot.arbitrage = arbitrage.NewScanner(1, 0, 0, func(exchName string, p pair.CurrencyPair) (arbitrage.Fees, error) {
+		return arbitrage.Fees{TakerRate: 0.001}, nil
+	})
+	defer func() { bot.arbitrage = nil }()
+	bot.arbitrage.Scan(exchs)
+
+	resp, err := s.GetArbitrageOpportunities(context.Background(), &gctrpc.GetArbitrageOpportunitiesRequest{})
+	if err != nil || len(resp.Opportunities) != 2 ||
+		resp.Opportunities[0].Pair.Base != "BTC" || resp.Opportunities[0].BuyExchange != "RPCArbitrageA" {
+		t.Fatal("Test Failed - GetArbitrageOpportunities() incorrect opportunities", resp, err)
+	}
+
+	resp, err = s.GetArbitrageOpportunities(context.Background(), &gctrpc.GetArbitrageOpportunitiesRequest{
+		Pair:     &gctrpc.CurrencyPair{Base: "LTC", Quote: "USD"},
+		Exchange: "rpcarbitrageb",
+	})
+	if err != nil || len(resp.Opportunities) != 1 || resp.Opportunities[0].Pair.Base != "LTC" {
+		t.Error("Test Failed - GetArbitrageOpportunities() expected filtered opportunity", resp, err)
+	}
+
+	resp, err = s.GetArbitrageOpportunities(context.Background(), &gctrpc.GetArbitrageOpportunitiesRequest{Limit: 1})
+	if err != nil || len(resp.Opportunities) != 1 {
+		t.Error("Test Failed - GetArbitrageOpportunities() expected limit applied", resp, err)
+	}
+}
+
+func TestGetExchangeHealth(t *testing.T) {
+	bot.health = nil
+	s := &RPCServer{}
+	_, err := s.GetExchangeHealth(context.Background(), &gctrpc.GenericExchangeNameRequest{})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Error("Test Failed - GetExchangeHealth() expected not started error", err)
+	}
+
+	bot.health = availability.NewMonitor(2)
+	bot.health.OnChange = func(st availability.Status) {
+		exchange.PauseOrderSubmission(st.Exchange, "exchange degraded")
+	}
+	defer func() {
+		bot.health = nil
+		exchange.ResumeOrderSubmission("RPCHealthB")
+	}()
+	now := time.Now()
+	bot.health.Update(availability.Check{Exchange: "RPCHealthA", Time: now, Up: true, Latency: 150 * time.Millisecond})
+	for i := 0; i < 2; i++ {
+		bot.health.Update(availability.Check{Exchange: "RPCHealthB", Time: now, Error: "timeout"})
+	}
+
+	resp, err := s.GetExchangeHealth(context.Background(), &gctrpc.GenericExchangeNameRequest{})
+	if err != nil || len(resp.Exchanges) != 2 || resp.Exchanges[0].AverageLatencyMs != 150 ||
+		resp.Exchanges[0].Degraded || resp.Exchanges[0].DegradedSince != 0 {
+		t.Error("Test Failed - GetExchangeHealth() incorrect statuses", resp, err)
+	}
+	resp, err = s.GetExchangeHealth(context.Background(), &gctrpc.GenericExchangeNameRequest{Exchange: "RPCHealthB"})
+	if err != nil || len(resp.Exchanges) != 1 || !resp.Exchanges[0].Degraded ||
+		!resp.Exchanges[0].OrderSubmissionPaused || resp.Exchanges[0].LastError != "timeout" {
+		t.Error("Test Failed - GetExchangeHealth() expected degraded exchange", resp, err)
+	}
+	_, err = s.GetExchangeHealth(context.Background(), &gctrpc.GenericExchangeNameRequest{Exchange: "Huobi"})
+	if status.Code(err) != codes.NotFound {
+		t.Error("Test Failed - GetExchangeHealth() expected not found error", err)
+	}
+}
+
+func TestGetPortfolioValuation(t *testing.T) {
+	bot.portfolioSync = nil
+	s := &RPCServer{}
+	_, err := s.GetPortfolioValuation(context.Background(), &gctrpc.GetPortfolioValuationRequest{})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Error("Test Failed - GetPortfolioValuation() expected disabled error", err)
+	}
+
+	bot.portfolioSync = NewPortfolioSync(&portfolio.Base{}, "USD", testPortfolioPrice, portfolio.NewSnapshots(0))
+	defer func() { bot.portfolioSync = nil }()
+	_, err = s.GetPortfolioValuation(context.Background(), &gctrpc.GetPortfolioValuationRequest{})
+	if status.Code(err) != codes.Unavailable {
+		t.Error("Test Failed - GetPortfolioValuation() expected not valued error", err)
+	}
+
+	start := time.Now().Add(-time.Hour)
+	for i, exchName := range []string{"RPCPortfolioA", "RPCPortfolioB"} {
+		bot.portfolioSync.Portfolio.Addresses = append(bot.portfolioSync.Portfolio.Addresses, portfolio.Address{
+			Address: exchName, CoinType: "BTC", Balance: 1, Description: portfolio.PortfolioAddressExchange,
+		})
+		v := bot.portfolioSync.Portfolio.Value("USD", testPortfolioPrice, start.Add(time.Duration(i)*time.Minute))
+		bot.portfolioSync.latest = v
+		bot.portfolioSync.Snapshots.Add(v)
+	}
+
+	resp, err := s.GetPortfolioValuation(context.Background(), &gctrpc.GetPortfolioValuationRequest{Exchange: "rpcportfoliob"})
+	if err != nil || resp.Total != 12000 || len(resp.Exchanges) != 1 ||
+		resp.Exchanges[0].Name != "RPCPortfolioB" || resp.Exchanges[0].Coins[0].Value != 6000 {
+		t.Error("Test Failed - GetPortfolioValuation() incorrect valuation", resp, err)
+	}
+	_, err = s.GetPortfolioValuation(context.Background(), &gctrpc.GetPortfolioValuationRequest{Exchange: "Huobi"})
+	if status.Code(err) != codes.NotFound {
+		t.Error("Test Failed - GetPortfolioValuation() expected not found error", err)
+	}
+
+	snapshots, err := s.GetPortfolioSnapshots(context.Background(), &gctrpc.GetPortfolioSnapshotsRequest{
+		Start: start.Add(30 * time.Second).Unix(),
+	})
+	if err != nil || len(snapshots.Snapshots) != 1 || snapshots.Snapshots[0].Total != 12000 {
+		t.Error("Test Failed - GetPortfolioSnapshots() incorrect snapshots", snapshots, err)
+	}
+	snapshots, err = s.GetPortfolioSnapshots(context.Background(), &gctrpc.GetPortfolioSnapshotsRequest{
+		Exchange: "RPCPortfolioA",
+	})
+	if err != nil || len(snapshots.Snapshots) != 2 || len(snapshots.Snapshots[1].Exchanges) != 1 {
+		t.Error("Test Failed - GetPortfolioSnapshots() expected filtered snapshots", snapshots, err)
+	}
+}
+
+func TestWithdrawalAddresses(t *testing.T) {
+	bot.addressBook = nil
+	s := &RPCServer{}
+	_, err := s.GetWithdrawalAddresses(context.Background(), &gctrpc.GetWithdrawalAddressesRequest{})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Error("Test Failed - GetWithdrawalAddresses() expected disabled error", err)
+	}
+
+	bot.addressBook, err = withdraw.NewAddressBook(nil, "hunter2")
+	if err != nil {
+		t.Fatal("Test Failed - NewAddressBook() error", err)
+	}
+	defer func() { bot.addressBook = nil }()
+
+	_, err = s.AddWithdrawalAddress(context.Background(), &gctrpc.WithdrawalAddress{Exchange: "Binance"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Error("Test Failed - AddWithdrawalAddress() expected invalid argument error", err)
+	}
+	a, err := s.AddWithdrawalAddress(context.Background(), &gctrpc.WithdrawalAddress{
+		Label: "cold wallet", Exchange: "Binance", Currency: "btc", Address: "bc1qcold", DailyLimit: 1,
+	})
+	if err != nil || a.Id != 1 || a.Currency != "BTC" || a.Verified {
+		t.Fatal("Test Failed - AddWithdrawalAddress() unexpected entry", a, err)
+	}
+	_, err = s.AddWithdrawalAddress(context.Background(), &gctrpc.WithdrawalAddress{
+		Exchange: "Binance", Currency: "BTC", Address: "bc1qcold",
+	})
+	if status.Code(err) != codes.AlreadyExists {
+		t.Error("Test Failed - AddWithdrawalAddress() expected already exists error", err)
+	}
+	_, err = s.AddWithdrawalAddress(context.Background(), &gctrpc.WithdrawalAddress{
+		Exchange: "Kraken", Currency: "ETH", Address: "0xabc",
+	})
+	if err != nil {
+		t.Fatal("Test Failed - AddWithdrawalAddress() error", err)
+	}
+
+	if a, err = s.VerifyWithdrawalAddress(context.Background(), &gctrpc.SetWithdrawalAddressFlagRequest{Id: 1, Value: true}); err != nil || !a.Verified {
+		t.Error("Test Failed - VerifyWithdrawalAddress() error", a, err)
+	}
+	if a, err = s.LockWithdrawalAddress(context.Background(), &gctrpc.SetWithdrawalAddressFlagRequest{Id: 1, Value: true}); err != nil || !a.Locked {
+		t.Error("Test Failed - LockWithdrawalAddress() error", a, err)
+	}
+	a.MonthlyLimit = 10
+	if a, err = s.UpdateWithdrawalAddress(context.Background(), a); err != nil || a.MonthlyLimit != 10 || !a.Locked || !a.Verified {
+		t.Error("Test Failed - UpdateWithdrawalAddress() expected flags kept", a, err)
+	}
+
+	bot.addressBook.Record("Binance", "BTC", "bc1qcold", 0.5, time.Now().Add(-time.Minute))
+	resp, err := s.GetWithdrawalAddresses(context.Background(), &gctrpc.GetWithdrawalAddressesRequest{Exchange: "binance"})
+	if err != nil || len(resp.Addresses) != 1 || resp.Addresses[0].DailyUsed != 0.5 {
+		t.Error("Test Failed - GetWithdrawalAddresses() expected filtered entries with usage", resp, err)
+	}
+
+	if _, err = s.RemoveWithdrawalAddress(context.Background(), &gctrpc.WithdrawalAddressRequest{Id: 1}); err != nil {
+		t.Error("Test Failed - RemoveWithdrawalAddress() error", err)
+	}
+	_, err = s.RemoveWithdrawalAddress(context.Background(), &gctrpc.WithdrawalAddressRequest{Id: 1})
+	if status.Code(err) != codes.NotFound {
+		t.Error("Test Failed - RemoveWithdrawalAddress() expected not found error", err)
+	}
+}
+
+// testExecutionExchange is an exchange accepting order submissions
+type testExecutionExchange struct {
+	exchange.IBotExchange
+}
+
+func (e *testExecutionExchange) GetName() string {
+	return "RPCExecution"
+}
+
+func (e *testExecutionExchange) GetCapabilities() exchange.Features {
+	return exchange.Features{CanSubmitOrder: true}
+}
+
+// testExecutionOrderer tracks the submitted child orders
+type testExecutionOrderer struct{}
+
+func (o *testExecutionOrderer) SubmitStrategyOrder(strategy string, exch exchange.IBotExchange, s exchange.OrderSubmission) (int, error) {
+	return orders.TrackOrder(exch.GetName(), "rpcexecution", s.Pair, s.Side, s.Type, s.BaseAmount, s.Price), nil
+}
+
+func TestExecutions(t *testing.T) {
+	bot.execution = nil
+	s := &RPCServer{}
+	_, err := s.GetExecutions(context.Background(), &gctrpc.GetExecutionsRequest{})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Error("Test Failed - GetExecutions() expected disabled error", err)
+	}
+
+	bot.exchanges = append(bot.exchanges, &testExecutionExchange{})
+	bot.execution = execution.NewManager(&testExecutionOrderer{}, GetExchangeByName, ticker.GetTicker)
+	defer func() {
+		bot.exchanges = bot.exchanges[:len(bot.exchanges)-1]
+		bot.execution = nil
+	}()
+
+	req := &gctrpc.StartExecutionRequest{
+		Algorithm: "twap",
+		Exchange:  "rpcexecution",
+		Pair:      &gctrpc.CurrencyPair{Base: "BTC", Quote: "USD"},
+		Side:      "buy",
+		Amount:    1,
+		Duration:  "1h",
+		Slices:    4,
+	}
+	_, err = s.StartExecution(context.Background(), &gctrpc.StartExecutionRequest{
+		Algorithm: "twap", Exchange: "RPCExecutionMissing", Pair: req.Pair, Side: "buy", Amount: 1,
+	})
+	if status.Code(err) != codes.NotFound {
+		t.Error("Test Failed - StartExecution() expected not found error", err)
+	}
+	_, err = s.StartExecution(context.Background(), &gctrpc.StartExecutionRequest{
+		Algorithm: "twap", Exchange: "rpcexecution", Pair: req.Pair, Side: "buy", Amount: 1, Duration: "soon",
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Error("Test Failed - StartExecution() expected invalid duration error", err)
+	}
+	_, err = s.StartExecution(context.Background(), &gctrpc.StartExecutionRequest{
+		Algorithm: "iceberg", Exchange: "rpcexecution", Pair: req.Pair, Side: "buy", Amount: 1,
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Error("Test Failed - StartExecution() expected invalid params error", err)
+	}
+
+	e, err := s.StartExecution(context.Background(), req)
+	if err != nil || e.Algorithm != execution.TWAP || e.Exchange != "RPCExecution" ||
+		e.Status != execution.StatusRunning || e.Pair.Base != "BTC" || e.Started == 0 {
+		t.Fatal("Test Failed - StartExecution() unexpected execution", e, err)
+	}
+	if _, err = s.CancelExecution(context.Background(), &gctrpc.CancelExecutionRequest{Id: e.Id}); err != nil {
+		t.Error("Test Failed - CancelExecution() error", err)
+	}
+	_, err = s.CancelExecution(context.Background(), &gctrpc.CancelExecutionRequest{Id: e.Id + 1})
+	if status.Code(err) != codes.NotFound {
+		t.Error("Test Failed - CancelExecution() expected not found error", err)
+	}
+
+	var resp *gctrpc.GetExecutionsResponse
+	for i := 0; i < 100; i++ {
+		resp, err = s.GetExecutions(context.Background(), &gctrpc.GetExecutionsRequest{Status: "cancelled"})
+		if err != nil || len(resp.Executions) == 1 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err != nil || len(resp.Executions) != 1 || resp.Executions[0].Finished == 0 ||
+		len(resp.Executions[0].ChildOrderIds) != 1 || resp.Executions[0].FilledAmount != 0 {
+		t.Fatal("Test Failed - GetExecutions() expected cancelled execution", resp, err)
+	}
+	_, err = s.CancelExecution(context.Background(), &gctrpc.CancelExecutionRequest{Id: e.Id})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Error("Test Failed - CancelExecution() expected finished error", err)
+	}
+}
+
+// testWithdrawalExchange is an exchange accepting withdrawals
+type testWithdrawalExchange struct {
+	exchange.IBotExchange
+}
+
+func (e *testWithdrawalExchange) GetName() string {
+	return "RPCWithdrawal"
+}
+
+func (e *testWithdrawalExchange) WithdrawCryptocurrencyFunds(address string, c pair.CurrencyItem, amount float64) (string, error) {
+	return "TX1", nil
+}
+
+func TestWithdrawals(t *testing.T) {
+	bot.withdrawals = nil
+	s := &RPCServer{}
+	_, err := s.GetWithdrawals(context.Background(), &gctrpc.GetWithdrawalsRequest{})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Error("Test Failed - GetWithdrawals() expected disabled error", err)
+	}
+
+	bot.exchanges = append(bot.exchanges, &testWithdrawalExchange{})
+	bot.withdrawals = withdraw.NewApprovals(withdraw.ApprovalConfig{
+		Whitelist:   []withdraw.WhitelistEntry{{Currency: "BTC", Address: "bc1qcold"}},
+		DailyLimits: map[string]float64{"BTC": 1},
+	}, nil, GetExchangeByName)
+	defer func() {
+		bot.exchanges = bot.exchanges[:len(bot.exchanges)-1]
+		bot.withdrawals = nil
+	}()
+
+	_, err = s.RequestWithdrawal(context.Background(), &gctrpc.RequestWithdrawalRequest{
+		Exchange: "RPCWithdrawalMissing", Currency: "BTC", Address: "bc1qcold", Amount: 0.5,
+	})
+	if status.Code(err) != codes.NotFound {
+		t.Error("Test Failed - RequestWithdrawal() expected not found error", err)
+	}
+	_, err = s.RequestWithdrawal(context.Background(), &gctrpc.RequestWithdrawalRequest{
+		Exchange: "rpcwithdrawal", Currency: "BTC", Address: "bc1qcold",
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Error("Test Failed - RequestWithdrawal() expected invalid argument error", err)
+	}
+	_, err = s.RequestWithdrawal(context.Background(), &gctrpc.RequestWithdrawalRequest{
+		Exchange: "rpcwithdrawal", Currency: "BTC", Address: "bc1qhot", Amount: 0.5,
+	})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Error("Test Failed - RequestWithdrawal() expected not whitelisted error", err)
+	}
+
+	w, err := s.RequestWithdrawal(context.Background(), &gctrpc.RequestWithdrawalRequest{
+		Exchange: "rpcwithdrawal", Currency: "btc", Address: "bc1qcold", Amount: 0.5,
+	})
+	if err != nil || w.Exchange != "RPCWithdrawal" || w.Currency != "BTC" || w.Status != withdraw.StatusPending {
+		t.Fatal("Test Failed - RequestWithdrawal() unexpected withdrawal", w, err)
+	}
+	if w, err = s.ApproveWithdrawal(context.Background(), &gctrpc.ApproveWithdrawalRequest{Id: w.Id}); err != nil ||
+		w.Status != withdraw.StatusSubmitted || w.TxId != "TX1" {
+		t.Error("Test Failed - ApproveWithdrawal() unexpected withdrawal", w, err)
+	}
+	_, err = s.RejectWithdrawal(context.Background(), &gctrpc.RejectWithdrawalRequest{Id: w.Id})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Error("Test Failed - RejectWithdrawal() expected not pending error", err)
+	}
+	_, err = s.ApproveWithdrawal(context.Background(), &gctrpc.ApproveWithdrawalRequest{Id: 10})
+	if status.Code(err) != codes.NotFound {
+		t.Error("Test Failed - ApproveWithdrawal() expected not found error", err)
+	}
+
+	w, err = s.RequestWithdrawal(context.Background(), &gctrpc.RequestWithdrawalRequest{
+		Exchange: "rpcwithdrawal", Currency: "BTC", Address: "bc1qcold", Amount: 0.25,
+	})
+	if err != nil {
+		t.Fatal("Test Failed - RequestWithdrawal() error", err)
+	}
+	if w, err = s.RejectWithdrawal(context.Background(), &gctrpc.RejectWithdrawalRequest{Id: w.Id, Reason: "unexpected"}); err != nil ||
+		w.Status != withdraw.StatusRejected || w.Reason != "unexpected" {
+		t.Error("Test Failed - RejectWithdrawal() unexpected withdrawal", w, err)
+	}
+
+	resp, err := s.GetWithdrawals(context.Background(), &gctrpc.GetWithdrawalsRequest{Status: "submitted"})
+	if err != nil || len(resp.Withdrawals) != 1 || resp.Withdrawals[0].Id != 1 {
+		t.Error("Test Failed - GetWithdrawals() expected the submitted withdrawal", resp, err)
+	}
+
+	bot.batches, err = withdraw.NewManager(withdraw.Config{MinBatchAmount: 1}, bot.withdrawals)
+	if err != nil {
+		t.Fatal("Test Failed - NewManager() error", err)
+	}
+	defer func() { bot.batches = nil }()
+	queued, err := s.RequestWithdrawal(context.Background(), &gctrpc.RequestWithdrawalRequest{
+		Exchange: "rpcwithdrawal", Currency: "btc", Address: "bc1qcold", Amount: 0.1,
+	})
+	if err != nil || queued.Status != withdraw.StatusQueued || queued.Id <= w.Id {
+		t.Fatal("Test Failed - RequestWithdrawal() expected withdrawal queued for batching", queued, err)
+	}
+	if resp, err = s.GetWithdrawals(context.Background(), &gctrpc.GetWithdrawalsRequest{Status: "queued"}); err != nil ||
+		len(resp.Withdrawals) != 1 || resp.Withdrawals[0].Id != queued.Id {
+		t.Error("Test Failed - GetWithdrawals() expected the queued withdrawal", resp, err)
+	}
+	if w, err = s.RejectWithdrawal(context.Background(), &gctrpc.RejectWithdrawalRequest{Id: queued.Id}); err != nil ||
+		w.Status != withdraw.StatusRejected || len(bot.batches.Pending()) != 0 {
+		t.Error("Test Failed - RejectWithdrawal() expected queued withdrawal removed", w, err)
+	}
+}
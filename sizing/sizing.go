@@ -0,0 +1,155 @@
+// Package sizing computes order sizes from account equity so strategies size
+// positions consistently, by a fixed fraction of equity at risk, by
+// targeting a volatility or by a capped Kelly fraction
+package sizing
+
+import (
+	"errors"
+	"math"
+)
+
+// Errors returned when sizing positions
+var (
+	ErrInvalidEquity     = errors.New("equity must be greater than zero")
+	ErrInvalidPrice      = errors.New("price must be greater than zero")
+	ErrInvalidFraction   = errors.New("fraction must be between zero and one")
+	ErrInvalidStop       = errors.New("stop price must differ from the entry price")
+	ErrInvalidVolatility = errors.New("volatility must be greater than zero")
+	ErrInvalidWinRate    = errors.New("win rate must be between zero and one")
+	ErrInvalidPayoff     = errors.New("payoff ratio must be greater than zero")
+	ErrInvalidLeverage   = errors.New("leverage cannot be negative")
+)
+
+// Sizer returns the amount of the base currency to order at a price for an
+// account with equity in the quote currency
+type Sizer interface {
+	Size(equity, price float64) (float64, error)
+}
+
+// Caps limits the size of a position as a fraction of equity and a maximum
+// amount, a zero value disables the cap
+type Caps struct {
+	MaxFraction float64
+	MaxAmount   float64
+}
+
+// apply caps an amount at the price
+func (c Caps) apply(amount, equity, price float64) float64 {
+	if c.MaxFraction > 0 {
+		amount = math.Min(amount, equity*c.MaxFraction/price)
+	}
+	if c.MaxAmount > 0 {
+		amount = math.Min(amount, c.MaxAmount)
+	}
+	return amount
+}
+
+func validate(equity, price float64) error {
+	if equity <= 0 {
+		return ErrInvalidEquity
+	}
+	if price <= 0 {
+		return ErrInvalidPrice
+	}
+	return nil
+}
+
+// FixedFractional sizes positions so a move from the entry price to the stop
+// price loses Fraction of equity. Without a stop price the position's
+// notional is Fraction of equity.
+type FixedFractional struct {
+	Fraction float64
+	Stop     float64
+	Caps
+}
+
+// Size returns the amount to order at the entry price
+func (f *FixedFractional) Size(equity, price float64) (float64, error) {
+	if err := validate(equity, price); err != nil {
+		return 0, err
+	}
+	if f.Fraction <= 0 || f.Fraction > 1 {
+		return 0, ErrInvalidFraction
+	}
+	risk := price
+	if f.Stop != 0 {
+		risk = math.Abs(price - f.Stop)
+		if risk == 0 || f.Stop < 0 {
+			return 0, ErrInvalidStop
+		}
+	}
+	return f.apply(equity*f.Fraction/risk, equity, price), nil
+}
+
+// VolatilityTarget sizes positions so the position's annualised volatility
+// is Target of equity given the instrument's annualised Volatility, such as
+// the latest realized volatility from the analytics package. The notional is
+// capped at MaxLeverage times equity when set.
+type VolatilityTarget struct {
+	Target      float64
+	Volatility  float64
+	MaxLeverage float64
+	Caps
+}
+
+// Size returns the amount to order at the price
+func (v *VolatilityTarget) Size(equity, price float64) (float64, error) {
+	if err := validate(equity, price); err != nil {
+		return 0, err
+	}
+	if v.Target <= 0 || v.Volatility <= 0 {
+		return 0, ErrInvalidVolatility
+	}
+	if v.MaxLeverage < 0 {
+		return 0, ErrInvalidLeverage
+	}
+	notional := equity * v.Target / v.Volatility
+	if v.MaxLeverage > 0 {
+		notional = math.Min(notional, equity*v.MaxLeverage)
+	}
+	return v.apply(notional/price, equity, price), nil
+}
+
+// KellyFraction returns the fraction of equity the Kelly criterion stakes on
+// a strategy winning WinRate of its trades with winners PayoffRatio times the
+// size of losers. A negative fraction means the strategy has no edge.
+func KellyFraction(winRate, payoffRatio float64) (float64, error) {
+	if winRate < 0 || winRate > 1 {
+		return 0, ErrInvalidWinRate
+	}
+	if payoffRatio <= 0 {
+		return 0, ErrInvalidPayoff
+	}
+	return winRate - (1-winRate)/payoffRatio, nil
+}
+
+// Kelly sizes positions by the Kelly fraction scaled by Multiplier, such as
+// 0.5 for half Kelly, and positions are not opened without an edge. The
+// full Kelly fraction is used when Multiplier is zero.
+type Kelly struct {
+	WinRate     float64
+	PayoffRatio float64
+	Multiplier  float64
+	Caps
+}
+
+// Size returns the amount to order at the price
+func (k *Kelly) Size(equity, price float64) (float64, error) {
+	if err := validate(equity, price); err != nil {
+		return 0, err
+	}
+	fraction, err := KellyFraction(k.WinRate, k.PayoffRatio)
+	if err != nil {
+		return 0, err
+	}
+	if k.Multiplier < 0 || k.Multiplier > 1 {
+		return 0, ErrInvalidFraction
+	}
+	if k.Multiplier > 0 {
+		fraction *= k.Multiplier
+	}
+	if fraction <= 0 {
+		return 0, nil
+	}
+	return k.apply(equity*math.Min(fraction, 1)/price, equity, price), nil
+}
@@ -0,0 +1,94 @@
+package sizing
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFixedFractional(t *testing.T) {
+	f := FixedFractional{Fraction: 0.01, Stop: 95}
+	amount, err := f.Size(10000, 100)
+	if err != nil || amount != 20 {
+		t.Error("Test Failed - FixedFractional Size() incorrect amount", amount, err)
+	}
+
+	f.MaxFraction = 0.5
+	if amount, _ = f.Size(10000, 100); amount != 20 {
+		t.Error("Test Failed - FixedFractional Size() incorrect capped amount", amount)
+	}
+	f.MaxFraction = 0.1
+	if amount, _ = f.Size(10000, 100); amount != 10 {
+		t.Error("Test Failed - FixedFractional Size() incorrect capped amount", amount)
+	}
+
+	f = FixedFractional{Fraction: 0.25}
+	if amount, _ = f.Size(10000, 100); amount != 25 {
+		t.Error("Test Failed - FixedFractional Size() incorrect amount without stop", amount)
+	}
+
+	if _, err = (&FixedFractional{Fraction: 0.01, Stop: 100}).Size(10000, 100); err != ErrInvalidStop {
+		t.Error("Test Failed - FixedFractional Size() expected invalid stop error", err)
+	}
+	if _, err = (&FixedFractional{Fraction: 2}).Size(10000, 100); err != ErrInvalidFraction {
+		t.Error("Test Failed - FixedFractional Size() expected invalid fraction error", err)
+	}
+	if _, err = f.Size(0, 100); err != ErrInvalidEquity {
+		t.Error("Test Failed - FixedFractional Size() expected invalid equity error", err)
+	}
+	if _, err = f.Size(10000, 0); err != ErrInvalidPrice {
+		t.Error("Test Failed - FixedFractional Size() expected invalid price error", err)
+	}
+}
+
+func TestVolatilityTarget(t *testing.T) {
+	v := VolatilityTarget{Target: 0.2, Volatility: 0.8}
+	amount, err := v.Size(10000, 100)
+	if err != nil || amount != 25 {
+		t.Error("Test Failed - VolatilityTarget Size() incorrect amount", amount, err)
+	}
+
+	v.Volatility = 0.05
+	v.MaxLeverage = 2
+	if amount, _ = v.Size(10000, 100); amount != 200 {
+		t.Error("Test Failed - VolatilityTarget Size() leverage not capped", amount)
+	}
+	v.MaxAmount = 150
+	if amount, _ = v.Size(10000, 100); amount != 150 {
+		t.Error("Test Failed - VolatilityTarget Size() amount not capped", amount)
+	}
+
+	if _, err = (&VolatilityTarget{Target: 0.2}).Size(10000, 100); err != ErrInvalidVolatility {
+		t.Error("Test Failed - VolatilityTarget Size() expected invalid volatility error", err)
+	}
+	if _, err = (&VolatilityTarget{Target: 0.2, Volatility: 1, MaxLeverage: -1}).Size(10000, 100); err != ErrInvalidLeverage {
+		t.Error("Test Failed - VolatilityTarget Size() expected invalid leverage error", err)
+	}
+}
+
+func TestKelly(t *testing.T) {
+	f, err := KellyFraction(0.6, 1)
+	if err != nil || math.Abs(f-0.2) > 1e-12 {
+		t.Error("Test Failed - KellyFraction() incorrect fraction", f, err)
+	}
+	if _, err = KellyFraction(1.5, 1); err != ErrInvalidWinRate {
+		t.Error("Test Failed - KellyFraction() expected invalid win rate error", err)
+	}
+	if _, err = KellyFraction(0.5, 0); err != ErrInvalidPayoff {
+		t.Error("Test Failed - KellyFraction() expected invalid payoff error", err)
+	}
+
+	k := Kelly{WinRate: 0.6, PayoffRatio: 1, Multiplier: 0.5}
+	amount, err := k.Size(10000, 100)
+	if err != nil || math.Abs(amount-10) > 1e-9 {
+		t.Error("Test Failed - Kelly Size() incorrect half Kelly amount", amount, err)
+	}
+	k.MaxFraction = 0.05
+	if amount, _ = k.Size(10000, 100); math.Abs(amount-5) > 1e-9 {
+		t.Error("Test Failed - Kelly Size() amount not capped", amount)
+	}
+
+	k = Kelly{WinRate: 0.4, PayoffRatio: 1}
+	if amount, err = k.Size(10000, 100); err != nil || amount != 0 {
+		t.Error("Test Failed - Kelly Size() expected no position without an edge", amount, err)
+	}
+}
@@ -0,0 +1,348 @@
+package statement
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency/locale"
+)
+
+// Statement formats
+const (
+	FormatHTML = "html"
+	FormatPDF  = "pdf"
+)
+
+const (
+	dateFormat     = "2006-01-02"
+	dateTimeFormat = "2006-01-02 15:04 MST"
+)
+
+// statementHTML is the template of HTML statements, which are also the body
+// of emailed statements so styles are inline
+const statementHTML = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="UTF-8">
+<title>Account statement {{date .Start}} to {{lastDay .End}}</title>
+</head>
+<body style="font-family: Helvetica, Arial, sans-serif; font-size: 13px; color: #222;">
+<h1 style="font-size: 20px;">Account statement</h1>
+<p>Period: {{date .Start}} to {{lastDay .End}}<br>
+Generated: {{dateTime .Generated}}<br>
+Base currency: {{.BaseCurrency}}</p>
+
+<h2 style="font-size: 16px;">Balances</h2>
+<table cellpadding="4" style="border-collapse: collapse;">
+<tr style="text-align: left; border-bottom: 1px solid #999;"><th>Exchange</th><th>Currency</th><th>Total</th><th>On hold</th><th>Value</th></tr>
+{{range .Balances}}<tr><td>{{.Exchange}}</td><td>{{.Currency}}</td><td align="right">{{number .Total}}</td><td align="right">{{number .Hold}}</td><td align="right">{{if .Valued}}{{fiat .Value}}{{else}}-{{end}}</td></tr>
+{{else}}<tr><td colspan="5">No balances</td></tr>
+{{end}}<tr style="border-top: 1px solid #999;"><th colspan="4" style="text-align: left;">Total value</th><th align="right">{{fiat .TotalValue}}</th></tr>
+</table>
+
+<h2 style="font-size: 16px;">Trading</h2>
+<table cellpadding="4" style="border-collapse: collapse;">
+<tr><td>Orders placed</td><td align="right">{{.Summary.Orders}}</td></tr>
+<tr><td>Fills</td><td align="right">{{.Summary.Fills}}</td></tr>
+<tr><td>Volume</td><td align="right">{{number .Summary.Volume}}</td></tr>
+<tr><td>Fees</td><td align="right">{{number .Summary.Fees}}</td></tr>
+<tr><td>Realised profit</td><td align="right">{{number .Summary.RealisedProfit}}</td></tr>
+<tr><td>Net profit</td><td align="right">{{number .Summary.NetProfit}}</td></tr>
+</table>
+<p style="color: #666;">Volume, fees and profit are in the quote currencies of the pairs traded.</p>
+
+<h3 style="font-size: 14px;">Trades</h3>
+<table cellpadding="4" style="border-collapse: collapse;">
+<tr style="text-align: left; border-bottom: 1px solid #999;"><th>Time</th><th>Exchange</th><th>Pair</th><th>Side</th><th>Price</th><th>Amount</th><th>Fee</th></tr>
+{{range .Trades}}<tr><td>{{dateTime .Time}}</td><td>{{.Exchange}}</td><td>{{.Pair}}</td><td>{{.Side}}</td><td align="right">{{number .Price}}</td><td align="right">{{number .Amount}}</td><td align="right">{{number .Fee}}</td></tr>
+{{else}}<tr><td colspan="7">No trades</td></tr>
+{{end}}</table>
+
+<h2 style="font-size: 16px;">Funding</h2>
+<table cellpadding="4" style="border-collapse: collapse;">
+<tr style="text-align: left; border-bottom: 1px solid #999;"><th>Time</th><th>Exchange</th><th>Type</th><th>Currency</th><th>Amount</th><th>Fee</th><th>Status</th></tr>
+{{range .Funding}}<tr><td>{{dateTime .Timestamp}}</td><td>{{.ExchangeName}}</td><td>{{.TransferType}}</td><td>{{.Currency}}</td><td align="right">{{number .Amount}}</td><td align="right">{{number (add .Fee .NetworkFee)}}</td><td>{{.Status}}</td></tr>
+{{else}}<tr><td colspan="7">No deposits or withdrawals</td></tr>
+{{end}}</table>
+<table cellpadding="4" style="border-collapse: collapse;">
+<tr><td>Deposits</td><td align="right">{{fiat .Deposits}}</td></tr>
+<tr><td>Withdrawals</td><td align="right">{{fiat .Withdrawals}}</td></tr>
+<tr><td>Funding fees</td><td align="right">{{fiat .FundingFees}}</td></tr>
+</table>
+{{if .Errors}}
+<h2 style="font-size: 16px;">Not included</h2>
+<ul>{{range .Errors}}<li>{{.}}</li>{{end}}</ul>
+{{end}}
+</body>
+</html>
+`
+
+// Render writes a statement in format with amounts written in locale l
+func Render(w io.Writer, s *Statement, l locale.Locale, format string) error {
+	switch format {
+	case FormatHTML:
+		return RenderHTML(w, s, l)
+	case FormatPDF:
+		return RenderPDF(w, s, l)
+	}
+	return ErrUnknownFormat
+}
+
+// RenderHTML writes a statement as an HTML document with amounts written in
+// locale l
+func RenderHTML(w io.Writer, s *Statement, l locale.Locale) error {
+	t, err := template.New("statement").Funcs(template.FuncMap{
+		"date":     func(t time.Time) string { return t.Format(dateFormat) },
+		"dateTime": func(t time.Time) string { return t.Format(dateTimeFormat) },
+		"lastDay":  func(t time.Time) string { return t.Add(-time.Nanosecond).Format(dateFormat) },
+		"fiat":     func(v float64) string { return l.FormatFiat(v, s.BaseCurrency) },
+		"number":   func(v float64) string { return formatAmount(l, v) },
+		"add":      func(a, b float64) float64 { return a + b },
+	}).Parse(statementHTML)
+	if err != nil {
+		return err
+	}
+	return t.Execute(w, s)
+}
+
+// RenderPDF writes a statement as a PDF document with amounts written in
+// locale l. Currencies are written by their codes as the document only uses
+// the standard Courier fonts.
+func RenderPDF(w io.Writer, s *Statement, l locale.Locale) error {
+	base := func(v float64) string { return l.FormatNumber(v, locale.Decimals(s.BaseCurrency)) }
+	var d pdfDocument
+
+	d.heading("Account statement")
+	d.line(fmt.Sprintf("Period: %s to %s", s.Start.Format(dateFormat), s.End.Add(-time.Nanosecond).Format(dateFormat)))
+	d.line("Generated: " + s.Generated.Format(dateTimeFormat))
+	d.line("Base currency: " + s.BaseCurrency)
+
+	d.line("")
+	d.heading("Balances")
+	d.line(columns("Exchange", 16, "Currency", 10, "Total", -20, "On hold", -20, "Value "+s.BaseCurrency, -20))
+	for _, b := range s.Balances {
+		value := "-"
+		if b.Valued {
+			value = base(b.Value)
+		}
+		d.line(columns(b.Exchange, 16, b.Currency, 10, formatAmount(l, b.Total), -20,
+			formatAmount(l, b.Hold), -20, value, -20))
+	}
+	if len(s.Balances) == 0 {
+		d.line("No balances")
+	}
+	d.line(columns("Total value", 66, base(s.TotalValue), -20))
+
+	d.line("")
+	d.heading("Trading")
+	d.line(columns("Orders placed", 20, strconv.Itoa(s.Summary.Orders), -20))
+	d.line(columns("Fills", 20, strconv.Itoa(s.Summary.Fills), -20))
+	d.line(columns("Volume", 20, formatAmount(l, s.Summary.Volume), -20))
+	d.line(columns("Fees", 20, formatAmount(l, s.Summary.Fees), -20))
+	d.line(columns("Realised profit", 20, formatAmount(l, s.Summary.RealisedProfit), -20))
+	d.line(columns("Net profit", 20, formatAmount(l, s.Summary.NetProfit), -20))
+	d.line("Volume, fees and profit are in the quote currencies of the pairs traded.")
+
+	d.line("")
+	d.heading("Trades")
+	d.line(columns("Time", 17, "Exchange", 14, "Pair", 10, "Side", 5, "Price", -16, "Amount", -16, "Fee", -12))
+	for _, e := range s.Trades {
+		d.line(columns(e.Time.Format("2006-01-02 15:04"), 17, e.Exchange, 14, e.Pair, 10, e.Side, 5,
+			formatAmount(l, e.Price), -16, formatAmount(l, e.Amount), -16, formatAmount(l, e.Fee), -12))
+	}
+	if len(s.Trades) == 0 {
+		d.line("No trades")
+	}
+
+	d.line("")
+	d.heading("Funding")
+	d.line(columns("Time", 17, "Exchange", 14, "Type", 11, "Currency", 9, "Amount", -16, "Fee", -12, "Status", -10))
+	for _, f := range s.Funding {
+		d.line(columns(f.Timestamp.Format("2006-01-02 15:04"), 17, f.ExchangeName, 14, f.TransferType, 11,
+			f.Currency, 9, formatAmount(l, f.Amount), -16, formatAmount(l, f.Fee+f.NetworkFee), -12, f.Status, -10))
+	}
+	if len(s.Funding) == 0 {
+		d.line("No deposits or withdrawals")
+	}
+	d.line(columns("Deposits "+s.BaseCurrency, 20, base(s.Deposits), -20))
+	d.line(columns("Withdrawals "+s.BaseCurrency, 20, base(s.Withdrawals), -20))
+	d.line(columns("Funding fees "+s.BaseCurrency, 20, base(s.FundingFees), -20))
+
+	if len(s.Errors) > 0 {
+		d.line("")
+		d.heading("Not included")
+		for _, e := range s.Errors {
+			d.line(e)
+		}
+	}
+	return d.write(w)
+}
+
+// formatAmount writes an amount with at least two and up to eight decimal
+// places
+func formatAmount(l locale.Locale, v float64) string {
+	decimals := 2
+	s := strconv.FormatFloat(math.Abs(v), 'f', -1, 64)
+	if i := strings.IndexByte(s, '.'); i != -1 && len(s)-i-1 > decimals {
+		decimals = len(s) - i - 1
+		if decimals > locale.MaxPriceDecimals {
+			decimals = locale.MaxPriceDecimals
+		}
+	}
+	return l.FormatNumber(v, decimals)
+}
+
+// columns lays out fields in fixed width columns, each field followed by its
+// width which is negative for right aligned columns. Fields wider than their
+// column are truncated.
+func columns(fields ...interface{}) string {
+	var b strings.Builder
+	for i := 0; i+1 < len(fields); i += 2 {
+		text, _ := fields[i].(string)
+		width, _ := fields[i+1].(int)
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		right := width < 0
+		if right {
+			width = -width
+		}
+		runes := []rune(text)
+		if len(runes) > width {
+			runes = runes[:width]
+		}
+		pad := strings.Repeat(" ", width-len(runes))
+		if right {
+			b.WriteString(pad + string(runes))
+		} else {
+			b.WriteString(string(runes) + pad)
+		}
+	}
+	return strings.TrimRight(b.String(), " ")
+}
+
+// PDF page layout in points, A4 with Courier text
+const (
+	pdfPageWidth    = 595
+	pdfPageHeight   = 842
+	pdfMargin       = 36
+	pdfFontSize     = 7
+	pdfHeadingSize  = 10
+	pdfLeading      = 10
+	pdfLinesPerPage = (pdfPageHeight - 2*pdfMargin) / pdfLeading
+	// pdfLineWidth is the number of Courier characters, 0.6em wide, which fit
+	// across a page
+	pdfLineWidth = (pdfPageWidth - 2*pdfMargin) * 10 / (6 * pdfFontSize)
+)
+
+// pdfLine is a line of text, headings are set in bold
+type pdfLine struct {
+	text    string
+	heading bool
+}
+
+// pdfDocument lays out lines of text on pages of a PDF document
+type pdfDocument struct {
+	lines []pdfLine
+}
+
+func (d *pdfDocument) line(text string) {
+	if r := []rune(text); len(r) > pdfLineWidth {
+		text = string(r[:pdfLineWidth])
+	}
+	d.lines = append(d.lines, pdfLine{text: text})
+}
+
+func (d *pdfDocument) heading(text string) {
+	d.lines = append(d.lines, pdfLine{text: text, heading: true})
+}
+
+// write writes the document. Objects 1 to 4 are the catalog, page tree and
+// fonts, followed by each page and its content stream.
+func (d *pdfDocument) write(w io.Writer) error {
+	var pages [][]pdfLine
+	for i := 0; i < len(d.lines); i += pdfLinesPerPage {
+		end := i + pdfLinesPerPage
+		if end > len(d.lines) {
+			end = len(d.lines)
+		}
+		pages = append(pages, d.lines[i:end])
+	}
+	if len(pages) == 0 {
+		pages = append(pages, nil)
+	}
+
+	var buf bytes.Buffer
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	buf.WriteString("%PDF-1.4\n")
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+2*i)
+	}
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Courier-Bold /Encoding /WinAnsiEncoding >>")
+
+	for i, lines := range pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pdfPageWidth, pdfPageHeight, 6+2*i))
+
+		var content bytes.Buffer
+		content.WriteString("BT\n")
+		fmt.Fprintf(&content, "%d TL\n%d %d Td\n", pdfLeading, pdfMargin, pdfPageHeight-pdfMargin)
+		for _, l := range lines {
+			if l.heading {
+				fmt.Fprintf(&content, "/F2 %d Tf\n", pdfHeadingSize)
+			} else {
+				fmt.Fprintf(&content, "/F1 %d Tf\n", pdfFontSize)
+			}
+			fmt.Fprintf(&content, "T* (%s) Tj\n", pdfText(l.text))
+		}
+		content.WriteString("ET")
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", content.Len(), content.String()))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, o := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", o)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// pdfText escapes text for a PDF string in WinAnsiEncoding, characters outside
+// of it are replaced with a question mark
+func pdfText(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '€':
+			b.WriteString(`\200`)
+		case r >= ' ' && r < 0x7f:
+			b.WriteRune(r)
+		case r >= 0xa0 && r <= 0xff:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,139 @@
+package statement
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency/locale"
+)
+
+// DefaultCheckInterval is how often the scheduler checks whether the previous
+// month's statement is due
+const DefaultCheckInterval = time.Hour
+
+// Mailer sends an HTML email to its recipients, such as the SMTP
+// communications service
+type Mailer interface {
+	Send(subject, alert string) error
+}
+
+// Scheduler publishes the statement of each calendar month once it has ended,
+// storing it in Directory in each of Formats and emailing it with Mailer when
+// set. Months are in Location, UTC when it is nil. A month whose statement is
+// already stored is not published again, so restarting the bot does not
+// publish it twice.
+type Scheduler struct {
+	Generator *Generator
+	Locale    locale.Locale
+	Formats   []string
+	Directory string
+	Mailer    Mailer
+	Location  *time.Location
+
+	last time.Time
+}
+
+// FileName returns the name of the stored statement of the month starting
+// at start
+func FileName(start time.Time, format string) string {
+	return fmt.Sprintf("statement-%s.%s", start.Format("2006-01"), format)
+}
+
+// Publish generates the statement of the period from start until end, stores
+// it in each format and emails it
+func (s *Scheduler) Publish(start, end time.Time) (Statement, error) {
+	if s.Directory == "" && s.Mailer == nil {
+		return Statement{}, ErrNothingToDeliver
+	}
+	st, err := s.Generator.Generate(start, end)
+	if err != nil {
+		return st, err
+	}
+
+	if s.Directory != "" {
+		if err = os.MkdirAll(s.Directory, 0700); err != nil {
+			return st, err
+		}
+		for _, format := range s.Formats {
+			var b bytes.Buffer
+			if err = Render(&b, &st, s.Locale, format); err != nil {
+				return st, err
+			}
+			err = ioutil.WriteFile(filepath.Join(s.Directory, FileName(start, format)), b.Bytes(), 0600)
+			if err != nil {
+				return st, err
+			}
+		}
+	}
+
+	if s.Mailer != nil {
+		var b bytes.Buffer
+		if err = RenderHTML(&b, &st, s.Locale); err != nil {
+			return st, err
+		}
+		subject := "GoCryptoTrader account statement " + start.Format("January 2006")
+		if err = s.Mailer.Send(subject, b.String()); err != nil {
+			return st, err
+		}
+	}
+	return st, nil
+}
+
+// Check publishes the previous month's statement if it has not been
+// published, and returns whether it was
+func (s *Scheduler) Check(now time.Time) (bool, error) {
+	loc := s.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	end, _ := Month(now.In(loc))
+	start := end.AddDate(0, -1, 0)
+	if s.published(start) {
+		return false, nil
+	}
+	if _, err := s.Publish(start, end); err != nil {
+		return false, err
+	}
+	s.last = start
+	return true, nil
+}
+
+// published returns whether the statement of the month starting at start has
+// been published by this scheduler or is stored
+func (s *Scheduler) published(start time.Time) bool {
+	if !s.last.IsZero() && !s.last.Before(start) {
+		return true
+	}
+	if s.Directory == "" || len(s.Formats) == 0 {
+		return false
+	}
+	_, err := os.Stat(filepath.Join(s.Directory, FileName(start, s.Formats[0])))
+	return err == nil
+}
+
+// Run checks whether a statement is due every interval until stop is closed
+func (s *Scheduler) Run(interval time.Duration, stop <-chan struct{}) {
+	if interval <= 0 {
+		interval = DefaultCheckInterval
+	}
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		published, err := s.Check(time.Now())
+		if err != nil {
+			log.Printf("Failed to publish account statement. Err: %s", err)
+		} else if published {
+			log.Printf("Published account statement of %s.", s.last.Format("January 2006"))
+		}
+		select {
+		case <-stop:
+			return
+		case <-t.C:
+		}
+	}
+}
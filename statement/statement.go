@@ -0,0 +1,254 @@
+// Package statement generates monthly account statements of the bot's
+// exchange accounts, with their balances, the trades and fees recorded in the
+// trade journal, realised profit and loss and the deposits and withdrawals
+// made, rendered to HTML or PDF and stored or emailed on a schedule.
+package statement
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/currency"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-/gocryptotrader/journal"
+)
+
+// Errors returned when generating statements
+var (
+	ErrNoPrice          = errors.New("no price to value currency")
+	ErrUnknownFormat    = errors.New("unknown statement format, use html or pdf")
+	ErrInvalidPeriod    = errors.New("statement period must end after it starts")
+	ErrNoBaseCurrency   = errors.New("statement base currency not set")
+	ErrNothingToDeliver = errors.New("statements are neither stored nor emailed")
+)
+
+// Valuer returns the value of an amount of a currency in the statement's base
+// currency
+type Valuer func(currency string, amount float64) (float64, error)
+
+// Balance is an exchange account's balance of a currency when the statement
+// was generated. Value is in the base currency and is zero when the currency
+// could not be valued.
+type Balance struct {
+	Exchange string  `json:"exchange"`
+	Currency string  `json:"currency"`
+	Total    float64 `json:"total"`
+	Hold     float64 `json:"hold"`
+	Value    float64 `json:"value"`
+	Valued   bool    `json:"valued"`
+}
+
+// Statement is an account statement for the period from Start until End.
+// Balances are as at Generated, Summary is the journal's performance of the
+// trades in the period in their quote currencies and Funding the deposits and
+// withdrawals made, with their totals valued in the base currency. Errors
+// holds the exchanges which could not be included.
+type Statement struct {
+	Start        time.Time              `json:"start"`
+	End          time.Time              `json:"end"`
+	Generated    time.Time              `json:"generated"`
+	BaseCurrency string                 `json:"baseCurrency"`
+	Balances     []Balance              `json:"balances"`
+	TotalValue   float64                `json:"totalValue"`
+	Trades       []journal.Entry        `json:"trades"`
+	Summary      journal.Summary        `json:"summary"`
+	Funding      []exchange.FundHistory `json:"funding"`
+	Deposits     float64                `json:"deposits"`
+	Withdrawals  float64                `json:"withdrawals"`
+	FundingFees  float64                `json:"fundingFees"`
+	Errors       []string               `json:"errors,omitempty"`
+}
+
+// Generator generates statements of the enabled exchanges returned by
+// Exchanges which have authenticated API support. Trades are taken from
+// Journal, statements are generated without trades when it is nil.
+type Generator struct {
+	BaseCurrency string
+	Exchanges    func() []exchange.IBotExchange
+	Journal      *journal.Journal
+	Value        Valuer
+}
+
+// Month returns the start of the calendar month of t and the start of the
+// following month in t's location
+func Month(t time.Time) (start, end time.Time) {
+	start = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	return start, start.AddDate(0, 1, 0)
+}
+
+// Generate returns the statement of the period from start until end.
+// Exchanges whose balances or funding history cannot be retrieved are noted
+// in the statement's errors rather than failing it.
+func (g *Generator) Generate(start, end time.Time) (Statement, error) {
+	if !end.After(start) {
+		return Statement{}, ErrInvalidPeriod
+	}
+	if g.BaseCurrency == "" {
+		return Statement{}, ErrNoBaseCurrency
+	}
+
+	s := Statement{
+		Start:        start,
+		End:          end,
+		Generated:    time.Now(),
+		BaseCurrency: common.StringToUpper(g.BaseCurrency),
+	}
+	if g.Journal != nil {
+		s.Summary, s.Trades = g.Journal.Period(start, end)
+		trades := s.Trades[:0]
+		for i := range s.Trades {
+			if s.Trades[i].Kind == journal.KindFill {
+				trades = append(trades, s.Trades[i])
+			}
+		}
+		s.Trades = trades
+	}
+
+	var exchs []exchange.IBotExchange
+	if g.Exchanges != nil {
+		exchs = g.Exchanges()
+	}
+	for _, exch := range exchs {
+		if exch == nil || !exch.IsEnabled() || !exch.GetAuthenticatedAPISupport() {
+			continue
+		}
+		g.addBalances(&s, exch)
+		g.addFunding(&s, exch)
+	}
+
+	sort.Slice(s.Balances, func(i, j int) bool {
+		if s.Balances[i].Exchange != s.Balances[j].Exchange {
+			return s.Balances[i].Exchange < s.Balances[j].Exchange
+		}
+		return s.Balances[i].Currency < s.Balances[j].Currency
+	})
+	sort.SliceStable(s.Funding, func(i, j int) bool {
+		return s.Funding[i].Timestamp.Before(s.Funding[j].Timestamp)
+	})
+	return s, nil
+}
+
+// addBalances adds an exchange's non zero balances to the statement
+func (g *Generator) addBalances(s *Statement, exch exchange.IBotExchange) {
+	info, err := exch.GetAccountInfo()
+	if err != nil {
+		s.Errors = append(s.Errors, fmt.Sprintf("%s balances: %s", exch.GetName(), err))
+		return
+	}
+	for _, c := range info.Currencies {
+		if c.TotalValue == 0 && c.Hold == 0 {
+			continue
+		}
+		b := Balance{
+			Exchange: exch.GetName(),
+			Currency: common.StringToUpper(c.CurrencyName),
+			Total:    c.TotalValue,
+			Hold:     c.Hold,
+		}
+		if value, err := g.value(b.Currency, b.Total); err == nil {
+			b.Value, b.Valued = value, true
+			s.TotalValue += value
+		}
+		s.Balances = append(s.Balances, b)
+	}
+}
+
+// addFunding adds an exchange's deposits and withdrawals in the statement
+// period. Exchanges which do not support funding history are skipped.
+func (g *Generator) addFunding(s *Statement, exch exchange.IBotExchange) {
+	history, err := exch.GetFundingHistory()
+	if err == common.ErrNotYetImplemented || err == common.ErrFunctionNotSupported {
+		return
+	}
+	if err != nil {
+		s.Errors = append(s.Errors, fmt.Sprintf("%s funding history: %s", exch.GetName(), err))
+		return
+	}
+	for i := range history {
+		f := history[i]
+		if f.Timestamp.Before(s.Start) || !f.Timestamp.Before(s.End) {
+			continue
+		}
+		if f.ExchangeName == "" {
+			f.ExchangeName = exch.GetName()
+		}
+		s.Funding = append(s.Funding, f)
+
+		value, err := g.value(f.Currency, f.Amount)
+		if err != nil {
+			continue
+		}
+		switch {
+		case IsDeposit(&f):
+			s.Deposits += value
+		case IsWithdrawal(&f):
+			s.Withdrawals += value
+		}
+		if fees, err := g.value(f.Currency, f.Fee+f.NetworkFee); err == nil {
+			s.FundingFees += fees
+		}
+	}
+}
+
+func (g *Generator) value(curr string, amount float64) (float64, error) {
+	if g.Value == nil {
+		return 0, ErrNoPrice
+	}
+	return g.Value(curr, amount)
+}
+
+// IsDeposit returns whether a funding history item is a deposit
+func IsDeposit(f *exchange.FundHistory) bool {
+	return strings.Contains(common.StringToLower(f.TransferType), "deposit")
+}
+
+// IsWithdrawal returns whether a funding history item is a withdrawal
+func IsWithdrawal(f *exchange.FundHistory) bool {
+	return strings.Contains(common.StringToLower(f.TransferType), "withdraw")
+}
+
+// TickerValuer returns a Valuer converting fiat currencies with the forex
+// rates and valuing cryptocurrencies at the last price of their spot ticker
+// against the base currency on any of the exchanges returned by exchs. When
+// there is no such ticker, the USD or USDT ticker is used and converted to the
+// base currency, with USDT taken at par.
+func TickerValuer(exchs func() []exchange.IBotExchange, base string) Valuer {
+	base = common.StringToUpper(base)
+	quotes := []string{base}
+	if base != "USD" {
+		quotes = append(quotes, "USD")
+	}
+	quotes = append(quotes, "USDT")
+
+	return func(curr string, amount float64) (float64, error) {
+		curr = common.StringToUpper(curr)
+		if curr == base || amount == 0 {
+			return amount, nil
+		}
+		if currency.IsFiatCurrency(curr) {
+			return currency.ConvertCurrency(amount, curr, base)
+		}
+		for _, quote := range quotes {
+			for _, exch := range exchs() {
+				if exch == nil {
+					continue
+				}
+				t, err := ticker.GetTicker(exch.GetName(), pair.NewCurrencyPair(curr, quote), ticker.Spot)
+				if err != nil || t.Last <= 0 {
+					continue
+				}
+				if quote == base {
+					return amount * t.Last, nil
+				}
+				return currency.ConvertCurrency(amount*t.Last, "USD", base)
+			}
+		}
+		return 0, ErrNoPrice
+	}
+}
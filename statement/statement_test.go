@@ -0,0 +1,245 @@
+package statement
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/currency/locale"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-/gocryptotrader/journal"
+)
+
+type testExchange struct {
+	exchange.IBotExchange
+	name    string
+	info    exchange.AccountInfo
+	funding []exchange.FundHistory
+	err     error
+}
+
+func (e *testExchange) GetName() string { return e.name }
+
+func (e *testExchange) IsEnabled() bool { return true }
+
+func (e *testExchange) GetAuthenticatedAPISupport() bool { return true }
+
+func (e *testExchange) GetAccountInfo() (exchange.AccountInfo, error) { return e.info, e.err }
+
+func (e *testExchange) GetFundingHistory() ([]exchange.FundHistory, error) {
+	if e.funding == nil {
+		return nil, common.ErrNotYetImplemented
+	}
+	return e.funding, nil
+}
+
+type testMailer struct {
+	subjects []string
+	bodies   []string
+}
+
+func (m *testMailer) Send(subject, alert string) error {
+	m.subjects = append(m.subjects, subject)
+	m.bodies = append(m.bodies, alert)
+	return nil
+}
+
+var (
+	testStart = time.Date(2018, 9, 1, 0, 0, 0, 0, time.UTC)
+	testEnd   = time.Date(2018, 10, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func testGenerator(t *testing.T) *Generator {
+	j := journal.New()
+	if _, err := j.StartSession(""); err != nil {
+		t.Fatal("Test Failed - StartSession() error", err)
+	}
+	for _, e := range []journal.Entry{
+		{Kind: journal.KindFill, Time: testStart.Add(-time.Hour), Exchange: "StatementA", Pair: "BTCUSD", Side: "BUY", Price: 6000, Amount: 1},
+		{Kind: journal.KindOrder, Time: testStart.Add(time.Hour), Exchange: "StatementA", OrderID: "1"},
+		{Kind: journal.KindFill, Time: testStart.Add(time.Hour), Exchange: "StatementA", OrderID: "1", Pair: "BTCUSD", Side: "SELL", Price: 6500, Amount: 0.5, Fee: 3.25},
+	} {
+		if _, err := j.Record(e); err != nil {
+			t.Fatal("Test Failed - Record() error", err)
+		}
+	}
+
+	a := &testExchange{
+		name: "StatementA",
+		info: exchange.AccountInfo{Currencies: []exchange.AccountCurrencyInfo{
+			{CurrencyName: "usd", TotalValue: 3250},
+			{CurrencyName: "BTC", TotalValue: 0.5, Hold: 0.1},
+			{CurrencyName: "XYZ", TotalValue: 10},
+			{CurrencyName: "LTC"},
+		}},
+		funding: []exchange.FundHistory{
+			{Timestamp: testStart.Add(-time.Hour), TransferType: "deposit", Currency: "USD", Amount: 1000},
+			{Timestamp: testStart.Add(2 * time.Hour), TransferType: "Deposit", Currency: "BTC", Amount: 1},
+			{Timestamp: testStart.Add(time.Hour), TransferType: "WITHDRAWAL", Currency: "USD", Amount: 500, Fee: 5},
+		},
+	}
+	b := &testExchange{name: "StatementB", err: errors.New("invalid API key")}
+	prices := map[string]float64{"USD": 1, "BTC": 6500}
+
+	return &Generator{
+		BaseCurrency: "usd",
+		Exchanges:    func() []exchange.IBotExchange { return []exchange.IBotExchange{b, a, nil} },
+		Journal:      j,
+		Value: func(curr string, amount float64) (float64, error) {
+			price, ok := prices[curr]
+			if !ok {
+				return 0, ErrNoPrice
+			}
+			return amount * price, nil
+		},
+	}
+}
+
+func TestMonth(t *testing.T) {
+	start, end := Month(time.Date(2018, 12, 15, 10, 0, 0, 0, time.UTC))
+	if !start.Equal(time.Date(2018, 12, 1, 0, 0, 0, 0, time.UTC)) ||
+		!end.Equal(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Error("Test Failed - Month() unexpected period", start, end)
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	g := testGenerator(t)
+	s, err := g.Generate(testStart, testEnd)
+	if err != nil {
+		t.Fatal("Test Failed - Generate() error", err)
+	}
+	if s.BaseCurrency != "USD" || len(s.Balances) != 3 || s.Balances[0].Currency != "BTC" ||
+		s.Balances[0].Value != 3250 || s.Balances[2].Currency != "XYZ" || s.Balances[2].Valued ||
+		s.TotalValue != 6500 {
+		t.Errorf("Test Failed - Generate() unexpected balances %+v %v", s.Balances, s.TotalValue)
+	}
+	if len(s.Trades) != 1 || s.Summary.Orders != 1 || s.Summary.RealisedProfit != 250 {
+		t.Errorf("Test Failed - Generate() unexpected trades %+v %+v", s.Trades, s.Summary)
+	}
+	if len(s.Funding) != 2 || s.Funding[0].TransferType != "WITHDRAWAL" || s.Funding[0].ExchangeName != "StatementA" ||
+		s.Deposits != 6500 || s.Withdrawals != 500 || s.FundingFees != 5 {
+		t.Errorf("Test Failed - Generate() unexpected funding %+v", s)
+	}
+	if len(s.Errors) != 1 || !strings.Contains(s.Errors[0], "StatementB") {
+		t.Error("Test Failed - Generate() expected StatementB error", s.Errors)
+	}
+
+	if _, err = g.Generate(testEnd, testStart); err != ErrInvalidPeriod {
+		t.Error("Test Failed - Generate() expected invalid period error", err)
+	}
+}
+
+func TestRender(t *testing.T) {
+	s, err := testGenerator(t).Generate(testStart, testEnd)
+	if err != nil {
+		t.Fatal("Test Failed - Generate() error", err)
+	}
+
+	var b bytes.Buffer
+	if err = Render(&b, &s, locale.Locales["de-DE"], FormatHTML); err != nil {
+		t.Fatal("Test Failed - Render() error", err)
+	}
+	for _, expected := range []string{"2018-09-01 to 2018-09-30", "6.500,00 $", "3.250,00", "invalid API key"} {
+		if !strings.Contains(b.String(), expected) {
+			t.Errorf("Test Failed - RenderHTML() expected %q in output", expected)
+		}
+	}
+
+	b.Reset()
+	if err = Render(&b, &s, locale.Default, FormatPDF); err != nil {
+		t.Fatal("Test Failed - Render() error", err)
+	}
+	pdf := b.String()
+	if !strings.HasPrefix(pdf, "%PDF-1.4") || !strings.HasSuffix(pdf, "%%EOF\n") ||
+		!strings.Contains(pdf, "/Count 1") || !strings.Contains(pdf, "6,500.00") {
+		t.Error("Test Failed - RenderPDF() unexpected output", pdf)
+	}
+	// startxref is the offset of the cross reference table
+	trailer := strings.Split(pdf[strings.LastIndex(pdf, "startxref"):], "\n")
+	offset, err := strconv.Atoi(trailer[1])
+	if err != nil || !strings.HasPrefix(pdf[offset:], "xref\n0 7\n") {
+		t.Error("Test Failed - RenderPDF() incorrect cross reference offset", trailer, err)
+	}
+
+	if err = Render(&b, &s, locale.Default, "docx"); err != ErrUnknownFormat {
+		t.Error("Test Failed - Render() expected unknown format error", err)
+	}
+	if text := pdfText(`a(b)\ €₩é`); text != `a\(b\)\\ \200?\351` {
+		t.Error("Test Failed - pdfText() unexpected escaping", text)
+	}
+}
+
+func TestScheduler(t *testing.T) {
+	dir, err := ioutil.TempDir("", "statements")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	m := &testMailer{}
+	s := &Scheduler{
+		Generator: testGenerator(t),
+		Locale:    locale.Default,
+		Formats:   []string{FormatPDF, FormatHTML},
+		Directory: filepath.Join(dir, "statements"),
+		Mailer:    m,
+	}
+	now := time.Date(2018, 10, 2, 8, 0, 0, 0, time.UTC)
+	published, err := s.Check(now)
+	if err != nil || !published {
+		t.Fatal("Test Failed - Check() expected statement published", err)
+	}
+	for _, format := range s.Formats {
+		if _, err = os.Stat(filepath.Join(s.Directory, "statement-2018-09."+format)); err != nil {
+			t.Error("Test Failed - Check() expected statement stored", err)
+		}
+	}
+	if len(m.subjects) != 1 || m.subjects[0] != "GoCryptoTrader account statement September 2018" ||
+		!strings.Contains(m.bodies[0], "$6,500.00") {
+		t.Error("Test Failed - Check() expected statement emailed", m.subjects)
+	}
+
+	if published, err = s.Check(now.Add(time.Hour)); err != nil || published {
+		t.Error("Test Failed - Check() expected statement published once", err)
+	}
+	// A restarted scheduler finds the stored statement
+	restarted := *s
+	restarted.last = time.Time{}
+	if published, err = restarted.Check(now); err != nil || published {
+		t.Error("Test Failed - Check() expected stored statement not republished", err)
+	}
+	if published, err = restarted.Check(now.AddDate(0, 1, 0)); err != nil || !published || len(m.subjects) != 2 {
+		t.Error("Test Failed - Check() expected next month's statement published", err)
+	}
+
+	if _, err = (&Scheduler{Generator: s.Generator}).Publish(testStart, testEnd); err != ErrNothingToDeliver {
+		t.Error("Test Failed - Publish() expected nothing to deliver error", err)
+	}
+}
+
+func TestTickerValuer(t *testing.T) {
+	a := &testExchange{name: "StatementTicker"}
+	ticker.ProcessTicker(a.name, pair.NewCurrencyPair("BTC", "USD"), ticker.Price{Last: 6500}, ticker.Spot)
+	value := TickerValuer(func() []exchange.IBotExchange { return []exchange.IBotExchange{a} }, "usd")
+
+	v, err := value("btc", 2)
+	if err != nil || v != 13000 {
+		t.Error("Test Failed - TickerValuer() unexpected BTC value", v, err)
+	}
+	if v, err = value("USD", 5); err != nil || v != 5 {
+		t.Error("Test Failed - TickerValuer() unexpected base currency value", v, err)
+	}
+	if _, err = value("XYZ", 1); err != ErrNoPrice {
+		t.Error("Test Failed - TickerValuer() expected no price error", err)
+	}
+}
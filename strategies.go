@@ -0,0 +1,54 @@
+package main
+
+import (
+	"github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-/gocryptotrader/strategy"
+)
+
+// strategyTick passes a ticker update to the running strategies
+func strategyTick(exchName, assetType string, p ticker.Price) {
+	if bot.strategies == nil {
+		return
+	}
+	bot.strategies.OnTick(strategy.Tick{Exchange: exchName, AssetType: assetType, Price: p})
+}
+
+// strategyWebsocketTick passes a websocket ticker update to the running
+// strategies
+func strategyWebsocketTick(t exchange.TickerData) {
+	strategyTick(t.Exchange, t.AssetType, ticker.Price{
+		Pair:         t.Pair,
+		CurrencyPair: t.Pair.Pair().String(),
+		LastUpdated:  t.Timestamp,
+		Last:         t.ClosePrice,
+		High:         t.HighPrice,
+		Low:          t.LowPrice,
+		Volume:       t.Quantity,
+	})
+}
+
+// strategyOrderbook passes an orderbook update to the running strategies
+func strategyOrderbook(exchName string, ob orderbook.Base) {
+	if bot.strategies == nil {
+		return
+	}
+	bot.strategies.OnOrderbook(strategy.Orderbook{Exchange: exchName, Base: ob})
+}
+
+// strategyOrderEvent passes an order manager event to the strategy which
+// placed the order
+func strategyOrderEvent(e OrderEvent) {
+	if bot.strategies == nil {
+		return
+	}
+	bot.strategies.OnOrderEvent(strategy.OrderEvent{
+		Type:       e.Type,
+		OrderID:    e.Order.OrderID,
+		Strategy:   e.Order.Strategy,
+		FillAmount: e.FillAmount,
+		FillPrice:  e.FillPrice,
+		Order:      e.Order.OrderDetail,
+	})
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	"github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/strategy"
+)
+
+// testStrategy records the data passed to it by the engine
+type testStrategy struct {
+	strategy.Base
+	ticks   []strategy.Tick
+	books   []strategy.Orderbook
+	candles []strategy.Candle
+	events  []strategy.OrderEvent
+}
+
+func (s *testStrategy) Name() string {
+	return "enginetest"
+}
+
+func (s *testStrategy) OnTick(t strategy.Tick) error {
+	s.ticks = append(s.ticks, t)
+	return nil
+}
+
+func (s *testStrategy) OnOrderbook(ob strategy.Orderbook) error {
+	s.books = append(s.books, ob)
+	return nil
+}
+
+func (s *testStrategy) OnCandle(c strategy.Candle) error {
+	s.candles = append(s.candles, c)
+	return nil
+}
+
+func (s *testStrategy) OnOrderEvent(e strategy.OrderEvent) error {
+	s.events = append(s.events, e)
+	return nil
+}
+
+func TestStrategyRouting(t *testing.T) {
+	bot.strategies = nil
+	strategyOrderEvent(OrderEvent{})
+
+	s := &testStrategy{}
+	bot.strategies = strategy.NewRunner(kline.OneMin)
+	defer func() { bot.strategies = nil }()
+	if err := bot.strategies.Add(s); err != nil {
+		t.Fatal("Test failed. Add() error", err)
+	}
+
+	p := pair.NewCurrencyPair("BTC", "USD")
+	start := time.Now().Truncate(time.Minute)
+	for i, price := range []float64{100, 101} {
+		strategyWebsocketTick(exchange.TickerData{Exchange: "StrategyTest", AssetType: "SPOT", Pair: p,
+			ClosePrice: price, Timestamp: start.Add(time.Duration(i) * time.Minute)})
+	}
+	if len(s.ticks) != 2 || s.ticks[1].Last != 101 || len(s.candles) != 1 || s.candles[0].Close != 100 {
+		t.Error("Test failed. strategyWebsocketTick() incorrect data passed", s.ticks, s.candles)
+	}
+
+	strategyOrderbook("StrategyTest", orderbook.Base{Pair: p})
+	if len(s.books) != 1 || s.books[0].Exchange != "StrategyTest" {
+		t.Error("Test failed. strategyOrderbook() incorrect orderbook passed", s.books)
+	}
+
+	e := OrderEvent{Type: OrderEventFill, FillAmount: 1, Order: ManagedOrder{OrderID: 3, Strategy: "enginetest"}}
+	strategyOrderEvent(e)
+	e.Order.Strategy = "other"
+	strategyOrderEvent(e)
+	if len(s.events) != 1 || s.events[0].OrderID != 3 || s.events[0].Type != OrderEventFill {
+		t.Error("Test failed. strategyOrderEvent() incorrect events passed", s.events)
+	}
+}
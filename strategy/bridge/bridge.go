@@ -0,0 +1,501 @@
+// Package bridge serves the strategy runner over WebSocket so strategies
+// written in languages such as Python or JavaScript can trade through the
+// engine. Each connection registers as a strategy and exchanges JSON-RPC 2.0
+// messages with the bridge, calling methods to read market data and place
+// orders and receiving the runner's tickers, orderbooks, candles and order
+// events as notifications. Orders are submitted through the runner's Orderer
+// so they are tracked and throttled under the strategy's name.
+package bridge
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/assets"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/orders"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-/gocryptotrader/strategy"
+)
+
+// Connection settings, notifications are dropped once a strategy has
+// sendBuffer messages waiting to be written
+const (
+	sendBuffer     = 256
+	writeTimeout   = time.Second * 10
+	maxMessageSize = 1 << 20
+)
+
+// Errors reported to the runner when a notification is not sent
+var (
+	ErrClientTooSlow = errors.New("notification dropped as the strategy is not reading its connection")
+	ErrClientClosed  = errors.New("strategy connection closed")
+)
+
+// Server accepts strategy connections. Exchange returns a loaded exchange by
+// name and Token, when set, must be sent by strategies to register.
+type Server struct {
+	Runner   *strategy.Runner
+	Orderer  exchange.Orderer
+	Exchange exchange.ExchangeGetter
+	Token    string
+
+	upgrader websocket.Upgrader
+}
+
+// NewServer returns a bridge registering strategies with runner and placing
+// their orders through orderer
+func NewServer(runner *strategy.Runner, orderer exchange.Orderer, exchangeByName exchange.ExchangeGetter, token string) *Server {
+	return &Server{
+		Runner:   runner,
+		Orderer:  orderer,
+		Exchange: exchangeByName,
+		Token:    token,
+	}
+}
+
+// ListenAndServe accepts strategy connections on addr
+func (s *Server) ListenAndServe(addr string) error {
+	return http.ListenAndServe(addr, s)
+}
+
+// ServeHTTP upgrades a request to a WebSocket connection and serves it until
+// it is closed, removing its strategy from the runner
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	c := &client{
+		server: s,
+		conn:   conn,
+		send:   make(chan []byte, sendBuffer),
+		done:   make(chan struct{}),
+	}
+	go c.writeMessages()
+	c.readMessages()
+}
+
+// client is a strategy connection, it implements strategy.Strategy by
+// forwarding the runner's data as notifications once registered
+type client struct {
+	server     *Server
+	conn       *websocket.Conn
+	send       chan []byte
+	done       chan struct{}
+	name       string
+	registered bool
+	m          sync.Mutex
+}
+
+// Name returns the name the strategy registered with
+func (c *client) Name() string {
+	c.m.Lock()
+	defer c.m.Unlock()
+	return c.name
+}
+
+// OnTick sends a ticker update notification
+func (c *client) OnTick(t strategy.Tick) error {
+	return c.notify(NotifyTick, tickerNotification(t.Exchange, t.AssetType, &t.Price))
+}
+
+// OnOrderbook sends an orderbook update notification
+func (c *client) OnOrderbook(ob strategy.Orderbook) error {
+	return c.notify(NotifyOrderbook, orderbookNotification(ob.Exchange, &ob.Base, 0))
+}
+
+// OnCandle sends a closed candle notification
+func (c *client) OnCandle(cd strategy.Candle) error {
+	return c.notify(NotifyCandle, Candle{
+		Exchange:  cd.Exchange,
+		AssetType: cd.AssetType,
+		Pair:      cd.Pair.Display("-", true).String(),
+		Interval:  cd.Interval.Short(),
+		Time:      unixMilli(cd.Time),
+		Open:      cd.Open,
+		High:      cd.High,
+		Low:       cd.Low,
+		Close:     cd.Close,
+		Volume:    cd.Volume,
+	})
+}
+
+// OnOrderEvent sends an order event notification
+func (c *client) OnOrderEvent(e strategy.OrderEvent) error {
+	return c.notify(NotifyOrderEvent, OrderEvent{
+		Type:            e.Type,
+		OrderID:         e.OrderID,
+		ExchangeOrderID: e.Order.ID,
+		Exchange:        e.Order.Exchange,
+		Side:            common.StringToLower(e.Order.OrderSide),
+		Status:          e.Order.Status,
+		FillAmount:      e.FillAmount,
+		FillPrice:       e.FillPrice,
+		Amount:          e.Order.Amount,
+		ExecutedAmount:  e.Order.ExecutedAmount,
+		AveragePrice:    e.Order.AverageExecutedPrice,
+	})
+}
+
+// notify queues a notification without blocking the runner
+func (c *client) notify(method string, params interface{}) error {
+	b, err := json.Marshal(Notification{JSONRPC: Version, Method: method, Params: params})
+	if err != nil {
+		return err
+	}
+	select {
+	case <-c.done:
+		return ErrClientClosed
+	default:
+	}
+	select {
+	case c.send <- b:
+		return nil
+	default:
+		return ErrClientTooSlow
+	}
+}
+
+// readMessages handles the strategy's requests in order until the connection
+// is closed
+func (c *client) readMessages() {
+	defer func() {
+		close(c.done)
+		c.conn.Close()
+		if c.registered {
+			c.server.Runner.Remove(c.Name())
+		}
+	}()
+
+	c.conn.SetReadLimit(maxMessageSize)
+	for {
+		_, data, err := c.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		resp := c.handle(data)
+		if resp == nil {
+			continue
+		}
+		b, err := json.Marshal(resp)
+		if err != nil {
+			b, _ = json.Marshal(Response{JSONRPC: Version, ID: resp.ID,
+				Error: newError(CodeServerError, "unable to encode result: %s", err)})
+		}
+		c.send <- b
+	}
+}
+
+// writeMessages writes queued messages until the connection is closed,
+// discarding them once a write fails
+func (c *client) writeMessages() {
+	failed := false
+	for {
+		select {
+		case b := <-c.send:
+			if failed {
+				continue
+			}
+			c.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+			if err := c.conn.WriteMessage(websocket.TextMessage, b); err != nil {
+				failed = true
+				c.conn.Close()
+			}
+		case <-c.done:
+			return
+		}
+	}
+}
+
+// handle calls the method of a request and returns its response, nil for
+// notifications
+func (c *client) handle(data []byte) *Response {
+	var req Request
+	if err := json.Unmarshal(data, &req); err != nil {
+		return &Response{JSONRPC: Version, Error: newError(CodeParseError, "parse error: %s", err)}
+	}
+
+	var result interface{}
+	var rpcErr *Error
+	if req.JSONRPC != Version || req.Method == "" {
+		rpcErr = newError(CodeInvalidRequest, "invalid request")
+	} else {
+		result, rpcErr = c.call(req.Method, req.Params)
+	}
+	if req.ID == nil {
+		return nil
+	}
+	if rpcErr != nil {
+		return &Response{JSONRPC: Version, ID: req.ID, Error: rpcErr}
+	}
+	return &Response{JSONRPC: Version, ID: req.ID, Result: result}
+}
+
+// handlers are the methods strategies may call
+var handlers = map[string]func(c *client, params json.RawMessage) (interface{}, *Error){
+	MethodRegister:     (*client).register,
+	MethodGetTicker:    (*client).getTicker,
+	MethodGetOrderbook: (*client).getOrderbook,
+	MethodSubmitOrder:  (*client).submitOrder,
+	MethodCancelOrder:  (*client).cancelOrder,
+}
+
+// call calls a method, every method other than register requires the
+// connection to be registered
+func (c *client) call(method string, params json.RawMessage) (interface{}, *Error) {
+	handler, ok := handlers[method]
+	if !ok {
+		return nil, newError(CodeMethodNotFound, "method %s not found", method)
+	}
+	if method != MethodRegister && !c.registered {
+		return nil, newError(CodeNotRegistered, "strategy not registered")
+	}
+	return handler(c, params)
+}
+
+// register adds the connection to the runner as a strategy
+func (c *client) register(params json.RawMessage) (interface{}, *Error) {
+	var p RegisterParams
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	if c.registered {
+		return nil, newError(CodeInvalidRequest, "already registered as %s", c.Name())
+	}
+	if c.server.Token != "" &&
+		subtle.ConstantTimeCompare([]byte(p.Token), []byte(c.server.Token)) != 1 {
+		return nil, newError(CodeUnauthorized, "invalid token")
+	}
+	if p.Name == "" {
+		return nil, newError(CodeInvalidParams, "strategy name required")
+	}
+
+	c.m.Lock()
+	c.name = p.Name
+	c.m.Unlock()
+	if err := c.server.Runner.Add(c, p.Exchanges...); err != nil {
+		return nil, newError(CodeServerError, "%s", err)
+	}
+	c.registered = true
+	if p.Exchanges == nil {
+		p.Exchanges = []string{}
+	}
+	return RegisterResult{Name: p.Name, Exchanges: p.Exchanges}, nil
+}
+
+// getTicker returns the latest ticker of an exchange pair
+func (c *client) getTicker(params json.RawMessage) (interface{}, *Error) {
+	var p MarketParams
+	exch, cp, err := c.market(params, &p)
+	if err != nil {
+		return nil, err
+	}
+	t, tickerErr := ticker.GetTicker(exch.GetName(), cp, p.AssetType)
+	if tickerErr != nil {
+		return nil, newError(CodeServerError, "%s", tickerErr)
+	}
+	return tickerNotification(exch.GetName(), p.AssetType, &t), nil
+}
+
+// getOrderbook returns the latest orderbook of an exchange pair
+func (c *client) getOrderbook(params json.RawMessage) (interface{}, *Error) {
+	var p MarketParams
+	exch, cp, err := c.market(params, &p)
+	if err != nil {
+		return nil, err
+	}
+	ob, obErr := orderbook.GetOrderbook(exch.GetName(), cp, p.AssetType)
+	if obErr != nil {
+		return nil, newError(CodeServerError, "%s", obErr)
+	}
+	result := orderbookNotification(exch.GetName(), &ob, p.Depth)
+	result.AssetType = p.AssetType
+	return result, nil
+}
+
+// submitOrder places an order on behalf of the strategy
+func (c *client) submitOrder(params json.RawMessage) (interface{}, *Error) {
+	var p SubmitOrderParams
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	exch, err := c.exchange(p.Exchange)
+	if err != nil {
+		return nil, err
+	}
+	cp, err := parsePair(p.Pair)
+	if err != nil {
+		return nil, err
+	}
+
+	o := exchange.OrderSubmission{
+		Pair:        cp,
+		BaseAmount:  p.Amount,
+		QuoteAmount: p.QuoteAmount,
+		Price:       p.Price,
+		ClientID:    p.ClientID,
+	}
+	switch common.StringToLower(p.Side) {
+	case "buy":
+		o.Side = exchange.Buy
+	case "sell":
+		o.Side = exchange.Sell
+	default:
+		return nil, newError(CodeInvalidParams, "unsupported order side %q", p.Side)
+	}
+	switch common.StringToLower(p.Type) {
+	case "limit":
+		o.Type = exchange.Limit
+	case "market":
+		o.Type = exchange.Market
+	default:
+		return nil, newError(CodeInvalidParams, "unsupported order type %q", p.Type)
+	}
+	if validateErr := o.Validate(); validateErr != nil {
+		return nil, newError(CodeInvalidParams, "%s", validateErr)
+	}
+
+	id, submitErr := c.server.Orderer.SubmitStrategyOrder(c.Name(), exch, o)
+	if submitErr != nil {
+		return nil, newError(CodeServerError, "%s", submitErr)
+	}
+	result := OrderResult{OrderID: id, Status: "placed"}
+	if placed := orders.GetOrderByOrderID(id); placed != nil {
+		result.ExchangeOrderID = placed.ExchangeOrderID
+	}
+	return result, nil
+}
+
+// cancelOrder cancels an order placed by the strategy
+func (c *client) cancelOrder(params json.RawMessage) (interface{}, *Error) {
+	var p CancelOrderParams
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	o := orders.GetOrderByOrderID(p.OrderID)
+	if o == nil || o.Strategy != c.Name() {
+		return nil, newError(CodeInvalidParams, "order %d not placed by strategy", p.OrderID)
+	}
+	exch, err := c.exchange(o.Exchange)
+	if err != nil {
+		return nil, err
+	}
+
+	cancelErr := exch.CancelOrder(exchange.OrderCancellation{
+		OrderID:      o.ExchangeOrderID,
+		CurrencyPair: o.Pair,
+		Side:         o.Side,
+	})
+	if cancelErr != nil {
+		return nil, newError(CodeServerError, "%s", cancelErr)
+	}
+	return OrderResult{OrderID: o.OrderID, ExchangeOrderID: o.ExchangeOrderID, Status: "cancelled"}, nil
+}
+
+// market decodes market params, defaulting the asset type to SPOT, and
+// returns their exchange and pair
+func (c *client) market(params json.RawMessage, p *MarketParams) (exchange.IBotExchange, pair.CurrencyPair, *Error) {
+	if err := decodeParams(params, p); err != nil {
+		return nil, pair.CurrencyPair{}, err
+	}
+	exch, err := c.exchange(p.Exchange)
+	if err != nil {
+		return nil, pair.CurrencyPair{}, err
+	}
+	cp, err := parsePair(p.Pair)
+	if err != nil {
+		return nil, pair.CurrencyPair{}, err
+	}
+	if p.AssetType == "" {
+		p.AssetType = assets.Spot
+	}
+	p.AssetType = common.StringToUpper(p.AssetType)
+	return exch, cp, nil
+}
+
+// exchange returns a loaded exchange by name
+func (c *client) exchange(name string) (exchange.IBotExchange, *Error) {
+	exch := c.server.Exchange(name)
+	if name == "" || exch == nil {
+		return nil, newError(CodeInvalidParams, "exchange %s not loaded", name)
+	}
+	return exch, nil
+}
+
+// decodeParams decodes the params of a request
+func decodeParams(params json.RawMessage, v interface{}) *Error {
+	if len(params) == 0 {
+		return newError(CodeInvalidParams, "params required")
+	}
+	if err := json.Unmarshal(params, v); err != nil {
+		return newError(CodeInvalidParams, "invalid params: %s", err)
+	}
+	return nil
+}
+
+// parsePair parses a currency pair delimited by a dash or underscore, such as
+// BTC-USD
+func parsePair(s string) (pair.CurrencyPair, *Error) {
+	if !strings.ContainsAny(s, "-_") || strings.HasPrefix(s, "-") || strings.HasPrefix(s, "_") ||
+		strings.HasSuffix(s, "-") || strings.HasSuffix(s, "_") {
+		return pair.CurrencyPair{}, newError(CodeInvalidParams, "invalid pair %q, use pairs such as BTC-USD", s)
+	}
+	return pair.NewCurrencyPairFromString(common.StringToUpper(s)), nil
+}
+
+// tickerNotification converts a ticker price
+func tickerNotification(exchName, assetType string, t *ticker.Price) Ticker {
+	return Ticker{
+		Exchange:  exchName,
+		AssetType: assetType,
+		Pair:      t.Pair.Display("-", true).String(),
+		Last:      t.Last,
+		High:      t.High,
+		Low:       t.Low,
+		Bid:       t.Bid,
+		Ask:       t.Ask,
+		Volume:    t.Volume,
+		Time:      unixMilli(t.LastUpdated),
+	}
+}
+
+// orderbookNotification converts an orderbook, keeping depth levels of each
+// side when positive
+func orderbookNotification(exchName string, ob *orderbook.Base, depth int) Orderbook {
+	levels := func(items []orderbook.Item) []Level {
+		if depth > 0 && len(items) > depth {
+			items = items[:depth]
+		}
+		result := make([]Level, len(items))
+		for i := range items {
+			result[i] = Level{Price: items[i].Price, Amount: items[i].Amount}
+		}
+		return result
+	}
+	return Orderbook{
+		Exchange:  exchName,
+		AssetType: ob.AssetType,
+		Pair:      ob.Pair.Display("-", true).String(),
+		Bids:      levels(ob.Bids),
+		Asks:      levels(ob.Asks),
+		Time:      unixMilli(ob.LastUpdated),
+	}
+}
+
+// unixMilli returns a time in unix milliseconds, zero for the zero time
+func unixMilli(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano() / int64(time.Millisecond)
+}
@@ -0,0 +1,188 @@
+package bridge
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/orders"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-/gocryptotrader/strategy"
+)
+
+// testExchange records cancelled orders
+type testExchange struct {
+	exchange.IBotExchange
+	cancelled []exchange.OrderCancellation
+}
+
+func (e *testExchange) GetName() string { return "Bridge" }
+
+func (e *testExchange) CancelOrder(o exchange.OrderCancellation) error {
+	e.cancelled = append(e.cancelled, o)
+	return nil
+}
+
+// testOrderer places orders in the order store under the strategy's name
+type testOrderer struct {
+	submitted []exchange.OrderSubmission
+}
+
+func (o *testOrderer) SubmitStrategyOrder(strategyName string, exch exchange.IBotExchange, s exchange.OrderSubmission) (int, error) {
+	o.submitted = append(o.submitted, s)
+	id := orders.NewOrder(exch.GetName(), s.BaseAmount, s.Price)
+	placed := orders.GetOrderByOrderID(id)
+	placed.ExchangeOrderID = "bridge-1"
+	placed.Pair = s.Pair
+	placed.Side = s.Side
+	placed.Strategy = strategyName
+	return id, nil
+}
+
+// testClient is a strategy connected to the bridge
+type testClient struct {
+	t    *testing.T
+	conn *websocket.Conn
+	id   int
+}
+
+// call sends a request and returns the next response, skipping notifications
+func (c *testClient) call(method string, params interface{}) Response {
+	c.id++
+	raw, _ := json.Marshal(params)
+	err := c.conn.WriteJSON(Request{JSONRPC: Version, ID: json.RawMessage(strconv.Itoa(c.id)), Method: method, Params: raw})
+	if err != nil {
+		c.t.Fatal("Test Failed - WriteJSON() error", err)
+	}
+	for {
+		var resp struct {
+			Response
+			Method string `json:"method"`
+		}
+		if err = c.conn.ReadJSON(&resp); err != nil {
+			c.t.Fatal("Test Failed - ReadJSON() error", err)
+		}
+		if resp.Method == "" {
+			return resp.Response
+		}
+	}
+}
+
+// next returns the next notification
+func (c *testClient) next() Notification {
+	var n struct {
+		Notification
+		Params json.RawMessage `json:"params"`
+	}
+	c.conn.SetReadDeadline(time.Now().Add(time.Second))
+	if err := c.conn.ReadJSON(&n); err != nil {
+		c.t.Fatal("Test Failed - ReadJSON() error", err)
+	}
+	n.Notification.Params = n.Params
+	return n.Notification
+}
+
+func TestBridge(t *testing.T) {
+	exch := &testExchange{}
+	orderer := &testOrderer{}
+	runner := strategy.NewRunner(0)
+	server := NewServer(runner, orderer, func(name string) exchange.IBotExchange {
+		if strings.EqualFold(name, "bridge") {
+			return exch
+		}
+		return nil
+	}, "hunter2")
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(ts.URL, "http"), nil)
+	if err != nil {
+		t.Fatal("Test Failed - Dial() error", err)
+	}
+	defer conn.Close()
+	c := &testClient{t: t, conn: conn}
+
+	market := MarketParams{Exchange: "bridge", Pair: "btc-usd"}
+	if resp := c.call(MethodGetTicker, market); resp.Error == nil || resp.Error.Code != CodeNotRegistered {
+		t.Error("Test Failed - getTicker expected not registered error", resp.Error)
+	}
+	if resp := c.call("getBalance", nil); resp.Error == nil || resp.Error.Code != CodeMethodNotFound {
+		t.Error("Test Failed - getBalance expected method not found error", resp.Error)
+	}
+	if resp := c.call(MethodRegister, RegisterParams{Name: "py"}); resp.Error == nil || resp.Error.Code != CodeUnauthorized {
+		t.Error("Test Failed - register expected unauthorized error", resp.Error)
+	}
+	resp := c.call(MethodRegister, RegisterParams{Name: "py", Token: "hunter2", Exchanges: []string{"Bridge"}})
+	if resp.Error != nil || runner.Strategies()[0] != "py" {
+		t.Fatal("Test Failed - register error", resp.Error, runner.Strategies())
+	}
+
+	p := pair.NewCurrencyPair("BTC", "USD")
+	ticker.ProcessTicker("Bridge", p, ticker.Price{Pair: p, Last: 100}, "SPOT")
+	resp = c.call(MethodGetTicker, market)
+	var tick Ticker
+	raw, _ := json.Marshal(resp.Result)
+	json.Unmarshal(raw, &tick)
+	if resp.Error != nil || tick.Last != 100 || tick.Pair != "BTC-USD" || tick.AssetType != "SPOT" {
+		t.Error("Test Failed - getTicker incorrect ticker", resp.Error, tick)
+	}
+
+	runner.OnTick(strategy.Tick{Exchange: "Bridge", AssetType: "SPOT", Price: ticker.Price{Pair: p, Last: 101}})
+	runner.OnTick(strategy.Tick{Exchange: "Other", AssetType: "SPOT", Price: ticker.Price{Pair: p, Last: 1}})
+	n := c.next()
+	raw, _ = n.Params.(json.RawMessage)
+	json.Unmarshal(raw, &tick)
+	if n.Method != NotifyTick || tick.Last != 101 {
+		t.Error("Test Failed - OnTick() expected tick notification", n.Method, tick)
+	}
+
+	resp = c.call(MethodSubmitOrder, SubmitOrderParams{Exchange: "Bridge", Pair: "BTC-USD", Side: "buy", Type: "market"})
+	if resp.Error == nil || resp.Error.Code != CodeInvalidParams {
+		t.Error("Test Failed - submitOrder expected amount error", resp.Error)
+	}
+	resp = c.call(MethodSubmitOrder, SubmitOrderParams{Exchange: "Bridge", Pair: "BTC-USD", Side: "buy",
+		Type: "limit", Amount: 1, Price: 100})
+	var result OrderResult
+	raw, _ = json.Marshal(resp.Result)
+	json.Unmarshal(raw, &result)
+	if resp.Error != nil || result.ExchangeOrderID != "bridge-1" || orderer.submitted[0].Side != exchange.Buy {
+		t.Fatal("Test Failed - submitOrder incorrect result", resp.Error, result)
+	}
+
+	resp = c.call(MethodCancelOrder, CancelOrderParams{OrderID: result.OrderID})
+	if resp.Error != nil || len(exch.cancelled) != 1 || exch.cancelled[0].OrderID != "bridge-1" {
+		t.Error("Test Failed - cancelOrder expected cancellation", resp.Error, exch.cancelled)
+	}
+
+	conn.Close()
+	for i := 0; i < 100 && len(runner.Strategies()) > 0; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if len(runner.Strategies()) != 0 {
+		t.Error("Test Failed - expected strategy removed on disconnect", runner.Strategies())
+	}
+}
+
+func TestHandle(t *testing.T) {
+	c := &client{server: &Server{}}
+	resp := c.handle([]byte(`{"jsonrpc":"2.0","id":1,`))
+	if resp == nil || resp.Error.Code != CodeParseError || string(resp.ID) != "" {
+		t.Error("Test Failed - handle() expected parse error", resp)
+	}
+	resp = c.handle([]byte(`{"jsonrpc":"1.0","id":"a","method":"register"}`))
+	if resp == nil || resp.Error.Code != CodeInvalidRequest || string(resp.ID) != `"a"` {
+		t.Error("Test Failed - handle() expected invalid request", resp)
+	}
+	if resp = c.handle([]byte(`{"jsonrpc":"2.0","method":"getTicker"}`)); resp != nil {
+		t.Error("Test Failed - handle() expected notifications not answered", resp)
+	}
+	if _, err := parsePair("BTCUSD"); err == nil {
+		t.Error("Test Failed - parsePair() expected undelimited pair error")
+	}
+}
@@ -0,0 +1,194 @@
+package bridge
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Version is the JSON-RPC version of every message
+const Version = "2.0"
+
+// Methods strategies call on the bridge
+const (
+	MethodRegister     = "register"
+	MethodGetTicker    = "getTicker"
+	MethodGetOrderbook = "getOrderbook"
+	MethodSubmitOrder  = "submitOrder"
+	MethodCancelOrder  = "cancelOrder"
+)
+
+// Notifications the bridge sends to registered strategies
+const (
+	NotifyTick       = "tick"
+	NotifyOrderbook  = "orderbook"
+	NotifyCandle     = "candle"
+	NotifyOrderEvent = "orderEvent"
+)
+
+// Error codes of the JSON-RPC 2.0 specification and the bridge's own codes.
+// CodeServerError is returned when the engine or exchange rejects a request.
+const (
+	CodeParseError     = -32700
+	CodeInvalidRequest = -32600
+	CodeMethodNotFound = -32601
+	CodeInvalidParams  = -32602
+	CodeServerError    = -32000
+	CodeUnauthorized   = -32001
+	CodeNotRegistered  = -32002
+)
+
+// Request is a method call from a strategy, a request without an ID is a
+// notification and is not answered
+type Request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// Response answers a request with either its result or an error
+type Response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *Error          `json:"error,omitempty"`
+}
+
+// Notification is market data or an order event sent to a strategy
+type Notification struct {
+	JSONRPC string      `json:"jsonrpc"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params"`
+}
+
+// Error is a JSON-RPC error object
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Error implements the error interface
+func (e *Error) Error() string {
+	return fmt.Sprintf("%d %s", e.Code, e.Message)
+}
+
+// newError returns an error object with a formatted message
+func newError(code int, format string, args ...interface{}) *Error {
+	return &Error{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+// RegisterParams registers the connection as a strategy receiving the data of
+// Exchanges, or of every exchange when empty. Token is required when the
+// bridge has one set.
+type RegisterParams struct {
+	Name      string   `json:"name"`
+	Token     string   `json:"token,omitempty"`
+	Exchanges []string `json:"exchanges,omitempty"`
+}
+
+// RegisterResult is the strategy registered
+type RegisterResult struct {
+	Name      string   `json:"name"`
+	Exchanges []string `json:"exchanges"`
+}
+
+// MarketParams selects the ticker or orderbook of an exchange pair such as
+// BTC-USD, AssetType defaults to SPOT and Depth limits the orderbook levels
+// returned when positive
+type MarketParams struct {
+	Exchange  string `json:"exchange"`
+	Pair      string `json:"pair"`
+	AssetType string `json:"assetType,omitempty"`
+	Depth     int    `json:"depth,omitempty"`
+}
+
+// Ticker is a ticker update of an exchange pair, Time is in unix milliseconds
+type Ticker struct {
+	Exchange  string  `json:"exchange"`
+	AssetType string  `json:"assetType"`
+	Pair      string  `json:"pair"`
+	Last      float64 `json:"last"`
+	High      float64 `json:"high"`
+	Low       float64 `json:"low"`
+	Bid       float64 `json:"bid"`
+	Ask       float64 `json:"ask"`
+	Volume    float64 `json:"volume"`
+	Time      int64   `json:"time"`
+}
+
+// Level is a price level of an orderbook
+type Level struct {
+	Price  float64 `json:"price"`
+	Amount float64 `json:"amount"`
+}
+
+// Orderbook is an orderbook update of an exchange pair, Time is in unix
+// milliseconds
+type Orderbook struct {
+	Exchange  string  `json:"exchange"`
+	AssetType string  `json:"assetType"`
+	Pair      string  `json:"pair"`
+	Bids      []Level `json:"bids"`
+	Asks      []Level `json:"asks"`
+	Time      int64   `json:"time"`
+}
+
+// Candle is a closed candle of an exchange pair, Interval is a duration such
+// as 1m and Time the candle's open time in unix milliseconds
+type Candle struct {
+	Exchange  string  `json:"exchange"`
+	AssetType string  `json:"assetType"`
+	Pair      string  `json:"pair"`
+	Interval  string  `json:"interval"`
+	Time      int64   `json:"time"`
+	Open      float64 `json:"open"`
+	High      float64 `json:"high"`
+	Low       float64 `json:"low"`
+	Close     float64 `json:"close"`
+	Volume    float64 `json:"volume"`
+}
+
+// SubmitOrderParams is an order placed on behalf of the strategy. Side is buy
+// or sell and Type limit or market, exactly one of Amount in the base
+// currency and QuoteAmount in the quote currency is set.
+type SubmitOrderParams struct {
+	Exchange    string  `json:"exchange"`
+	Pair        string  `json:"pair"`
+	Side        string  `json:"side"`
+	Type        string  `json:"type"`
+	Amount      float64 `json:"amount,omitempty"`
+	QuoteAmount float64 `json:"quoteAmount,omitempty"`
+	Price       float64 `json:"price,omitempty"`
+	ClientID    string  `json:"clientID,omitempty"`
+}
+
+// CancelOrderParams cancels an order placed by the strategy by its local
+// order ID
+type CancelOrderParams struct {
+	OrderID int `json:"orderID"`
+}
+
+// OrderResult is an order placed or cancelled by the strategy. OrderID is the
+// engine's local order ID which order events refer to.
+type OrderResult struct {
+	OrderID         int    `json:"orderID"`
+	ExchangeOrderID string `json:"exchangeOrderID"`
+	Status          string `json:"status"`
+}
+
+// OrderEvent is a fill or cancellation of an order placed by the strategy.
+// FillAmount and FillPrice are the amount executed since the previous event
+// and its average price.
+type OrderEvent struct {
+	Type            string  `json:"type"`
+	OrderID         int     `json:"orderID"`
+	ExchangeOrderID string  `json:"exchangeOrderID"`
+	Exchange        string  `json:"exchange"`
+	Side            string  `json:"side"`
+	Status          string  `json:"status"`
+	FillAmount      float64 `json:"fillAmount"`
+	FillPrice       float64 `json:"fillPrice"`
+	Amount          float64 `json:"amount"`
+	ExecutedAmount  float64 `json:"executedAmount"`
+	AveragePrice    float64 `json:"averagePrice"`
+}
@@ -0,0 +1,385 @@
+// Package marketmaker provides a basic market making strategy which keeps a
+// two-sided quote around a reference price. Quotes are skewed away from the
+// current inventory so fills tend to bring the position back towards flat.
+package marketmaker
+
+import (
+	"errors"
+	"math"
+	"sync"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/orders"
+	"github.com/thrasher-/gocryptotrader/risk"
+	"github.com/thrasher-/gocryptotrader/strategy"
+)
+
+// StrategyName is the default strategy name market maker orders are
+// throttled under by the order manager
+const StrategyName = "marketmaker"
+
+// Errors returned by the market maker
+var (
+	ErrInvalidSpread    = errors.New("spread must be greater than zero and less than one")
+	ErrInvalidOrderSize = errors.New("order size must be greater than zero")
+	ErrInvalidSkew      = errors.New("skew requires a maximum inventory greater than zero")
+	ErrInvalidReference = errors.New("reference price must be greater than zero")
+	ErrEmptyOrderbook   = errors.New("orderbook requires a bid and ask to derive a reference price")
+	ErrUnknownOrder     = errors.New("order is not a current quote")
+)
+
+// Config holds the quoting parameters. Spread is the total distance between
+// the bid and ask as a fraction of the reference price. SkewFactor is the
+// fraction of the half spread both quotes are shifted by when the inventory
+// reaches MaxInventory. RequoteThreshold is the fractional move of the
+// reference price which triggers a requote, zero requotes on every update.
+type Config struct {
+	Spread           float64
+	OrderSize        float64
+	MaxInventory     float64
+	SkewFactor       float64
+	RequoteThreshold float64
+}
+
+// Validate checks the quoting parameters
+func (c *Config) Validate() error {
+	if c.Spread <= 0 || c.Spread >= 1 {
+		return ErrInvalidSpread
+	}
+	if c.OrderSize <= 0 {
+		return ErrInvalidOrderSize
+	}
+	if c.SkewFactor < 0 || (c.SkewFactor > 0 && c.MaxInventory <= 0) {
+		return ErrInvalidSkew
+	}
+	if c.MaxInventory < 0 || c.RequoteThreshold < 0 {
+		return errors.New("maximum inventory and requote threshold cannot be negative")
+	}
+	return nil
+}
+
+// Quote holds the prices and amounts of a two-sided quote, a zero amount
+// means that side is not quoted
+type Quote struct {
+	Bid       float64
+	BidAmount float64
+	Ask       float64
+	AskAmount float64
+}
+
+// CalculateQuote returns the quote around the reference price for the
+// inventory. A long inventory lowers both prices and shrinks the bid, a short
+// inventory raises both prices and shrinks the ask, no new inventory is
+// quoted once MaxInventory is reached.
+func CalculateQuote(c *Config, reference, inventory float64) Quote {
+	half := reference * c.Spread / 2
+	q := Quote{
+		Bid:       reference - half,
+		BidAmount: c.OrderSize,
+		Ask:       reference + half,
+		AskAmount: c.OrderSize,
+	}
+	if c.MaxInventory <= 0 {
+		return q
+	}
+
+	ratio := math.Max(-1, math.Min(1, inventory/c.MaxInventory))
+	skew := ratio * c.SkewFactor * half
+	q.Bid -= skew
+	q.Ask -= skew
+	if ratio > 0 {
+		q.BidAmount *= 1 - ratio
+	} else {
+		q.AskAmount *= 1 + ratio
+	}
+	return q
+}
+
+// MarketMaker maintains a two-sided quote for a currency pair on an exchange
+// through the order manager, checking each quote against the risk limits.
+// Quotes are subject to the order throttle limits of Strategy.
+type MarketMaker struct {
+	Config
+	Limits    risk.Limits
+	Exchange  exchange.IBotExchange
+	Pair      pair.CurrencyPair
+	Inventory float64
+	Strategy  string
+
+	reference float64
+	bid       *orders.Order
+	ask       *orders.Order
+	paused    bool
+	m         sync.Mutex
+}
+
+// New returns a new market maker for the exchange and currency pair
+func New(exch exchange.IBotExchange, p pair.CurrencyPair, c Config, limits risk.Limits) (*MarketMaker, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	if err := limits.Validate(); err != nil {
+		return nil, err
+	}
+	return &MarketMaker{
+		Config:   c,
+		Limits:   limits,
+		Exchange: exch,
+		Pair:     p,
+		Strategy: StrategyName,
+	}, nil
+}
+
+// OnReference requotes if the reference price has moved by at least the
+// requote threshold since the last quote or nothing is currently quoted. The
+// reference price is only recorded while paused.
+func (m *MarketMaker) OnReference(reference float64) error {
+	if reference <= 0 {
+		return ErrInvalidReference
+	}
+
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	if m.paused {
+		m.reference = reference
+		return nil
+	}
+	if m.bid != nil || m.ask != nil {
+		move := math.Abs(reference-m.reference) / m.reference
+		if move == 0 || move < m.RequoteThreshold {
+			return nil
+		}
+	}
+	return m.requote(reference)
+}
+
+// OnOrderbook uses the orderbook mid price as the reference price
+func (m *MarketMaker) OnOrderbook(ob orderbook.Base) error {
+	if len(ob.Bids) == 0 || len(ob.Asks) == 0 {
+		return ErrEmptyOrderbook
+	}
+	return m.OnReference((ob.Bids[0].Price + ob.Asks[0].Price) / 2)
+}
+
+// AddFill applies a fill to one of the current quotes by its local order ID,
+// updates the inventory and requotes at the last reference price so the new
+// quote reflects the inventory skew
+func (m *MarketMaker) AddFill(orderID int, f orders.Fill) error {
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	o, direction, err := m.quote(orderID)
+	if err != nil {
+		return err
+	}
+	if err = o.AddFill(f); err != nil {
+		return err
+	}
+	m.Inventory += direction * f.Amount
+	return m.requote(m.reference)
+}
+
+// OnFilled updates the inventory with a fill already applied to its tracked
+// order, such as by the order manager. A fill of a current quote requotes at
+// the last reference price, a late fill of a replaced quote only moves the
+// inventory.
+func (m *MarketMaker) OnFilled(orderID int, side exchange.OrderSide, amount float64) error {
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	_, direction, err := m.quote(orderID)
+	if err == nil {
+		m.Inventory += direction * amount
+		return m.requote(m.reference)
+	}
+	switch side {
+	case exchange.Buy:
+		m.Inventory += amount
+	case exchange.Sell:
+		m.Inventory -= amount
+	default:
+		return err
+	}
+	return nil
+}
+
+// quote returns the current quote with a local order ID and the direction its
+// fills move the inventory. The mutex must be held by the caller.
+func (m *MarketMaker) quote(orderID int) (*orders.Order, float64, error) {
+	switch {
+	case m.bid != nil && m.bid.OrderID == orderID:
+		return m.bid, 1, nil
+	case m.ask != nil && m.ask.OrderID == orderID:
+		return m.ask, -1, nil
+	}
+	return nil, 0, ErrUnknownOrder
+}
+
+// Quotes returns the currently quoted bid and ask orders, either may be nil
+func (m *MarketMaker) Quotes() (bid, ask *orders.Order) {
+	m.m.Lock()
+	defer m.m.Unlock()
+	return m.bid, m.ask
+}
+
+// Stop cancels the remainder of both quotes
+func (m *MarketMaker) Stop() error {
+	m.m.Lock()
+	defer m.m.Unlock()
+	return m.cancelQuotes()
+}
+
+// Pause cancels the remainder of both quotes and stops quoting until resumed,
+// such as while awaiting funds
+func (m *MarketMaker) Pause() error {
+	m.m.Lock()
+	defer m.m.Unlock()
+	m.paused = true
+	return m.cancelQuotes()
+}
+
+// Resume resumes quoting at the last reference price
+func (m *MarketMaker) Resume() error {
+	m.m.Lock()
+	defer m.m.Unlock()
+	if !m.paused {
+		return nil
+	}
+	m.paused = false
+	if m.reference <= 0 {
+		return nil
+	}
+	return m.requote(m.reference)
+}
+
+// Paused returns whether quoting is paused
+func (m *MarketMaker) Paused() bool {
+	m.m.Lock()
+	defer m.m.Unlock()
+	return m.paused
+}
+
+// requote cancels the current quotes and places new quotes around the
+// reference price. The mutex must be held by the caller.
+func (m *MarketMaker) requote(reference float64) error {
+	if err := m.cancelQuotes(); err != nil {
+		return err
+	}
+	m.reference = reference
+
+	q := CalculateQuote(&m.Config, reference, m.Inventory)
+	var err error
+	m.bid, err = m.place(exchange.Buy, q.Bid, q.BidAmount, 0)
+	if err != nil {
+		return err
+	}
+	openOrders := 0
+	if m.bid != nil {
+		openOrders++
+	}
+	m.ask, err = m.place(exchange.Sell, q.Ask, q.AskAmount, openOrders)
+	return err
+}
+
+// place resizes the side to the risk limits and submits it, returning nil if
+// nothing can be quoted. The mutex must be held by the caller.
+func (m *MarketMaker) place(side exchange.OrderSide, price, amount float64, openOrders int) (*orders.Order, error) {
+	if amount <= 0 {
+		return nil, nil
+	}
+
+	amount, err := m.Limits.AllowedAmount(risk.Order{
+		Side:       side,
+		Amount:     amount,
+		Price:      price,
+		Position:   m.Inventory,
+		OpenOrders: openOrders,
+	})
+	if err == risk.ErrNoAllowableAmount {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := orders.SubmitStrategyOrder(m.Strategy, m.Exchange, m.Pair, side,
+		exchange.Limit, amount, price, "")
+	if err != nil {
+		return nil, err
+	}
+	return orders.GetOrderByOrderID(id), nil
+}
+
+// cancelQuotes cancels the remainder of both quotes. The mutex must be held
+// by the caller.
+func (m *MarketMaker) cancelQuotes() error {
+	if err := m.cancel(m.bid); err != nil {
+		return err
+	}
+	m.bid = nil
+	if err := m.cancel(m.ask); err != nil {
+		return err
+	}
+	m.ask = nil
+	return nil
+}
+
+// cancel cancels the remainder of a quote if it is still open
+func (m *MarketMaker) cancel(o *orders.Order) error {
+	if o == nil {
+		return nil
+	}
+	err := o.CancelRemainder(m.Exchange.CancelOrder)
+	if err != nil && err != orders.ErrNothingRemaining {
+		return err
+	}
+	return nil
+}
+
+// Strategy runs a market maker in the strategy runner under its Strategy
+// name, quoting around the mid price of the pair's orderbook updates and
+// updating the inventory from the fills the order manager reports
+type Strategy struct {
+	strategy.Base
+	Maker *MarketMaker
+}
+
+// NewStrategy returns a market maker for the exchange and currency pair which
+// runs in the strategy runner, named StrategyName when name is empty
+func NewStrategy(name string, exch exchange.IBotExchange, p pair.CurrencyPair, c Config, limits risk.Limits) (*Strategy, error) {
+	m, err := New(exch, p, c, limits)
+	if err != nil {
+		return nil, err
+	}
+	if name != "" {
+		m.Strategy = name
+	}
+	return &Strategy{Maker: m}, nil
+}
+
+// Name returns the strategy name
+func (s *Strategy) Name() string {
+	return s.Maker.Strategy
+}
+
+// OnOrderbook requotes from an orderbook update of the market maker's pair
+func (s *Strategy) OnOrderbook(ob strategy.Orderbook) error {
+	if common.StringToUpper(ob.Exchange) != common.StringToUpper(s.Maker.Exchange.GetName()) ||
+		!ob.Pair.Equal(s.Maker.Pair, true) {
+		return nil
+	}
+	return s.Maker.OnOrderbook(ob.Base)
+}
+
+// OnOrderEvent updates the inventory with the fills of the market maker's
+// quotes
+func (s *Strategy) OnOrderEvent(e strategy.OrderEvent) error {
+	if e.FillAmount <= 0 {
+		return nil
+	}
+	return s.Maker.OnFilled(e.OrderID, exchange.OrderSide(e.Order.OrderSide), e.FillAmount)
+}
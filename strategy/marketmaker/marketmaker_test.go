@@ -0,0 +1,209 @@
+package marketmaker
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/orders"
+	"github.com/thrasher-/gocryptotrader/risk"
+	"github.com/thrasher-/gocryptotrader/strategy"
+)
+
+// testExchange records orders submitted and cancelled through the
+// IBotExchange interface
+type testExchange struct {
+	exchange.IBotExchange
+	submitted int
+	cancelled []string
+}
+
+func (e *testExchange) GetName() string {
+	return "MarketMakerTest"
+}
+
+func (e *testExchange) SubmitOrder(p pair.CurrencyPair, side exchange.OrderSide, orderType exchange.OrderType, amount, price float64, clientID string) (exchange.SubmitOrderResponse, error) {
+	e.submitted++
+	return exchange.SubmitOrderResponse{
+		IsOrderPlaced: true,
+		OrderID:       fmt.Sprintf("%d", e.submitted),
+	}, nil
+}
+
+func (e *testExchange) CancelOrder(order exchange.OrderCancellation) error {
+	e.cancelled = append(e.cancelled, order.OrderID)
+	return nil
+}
+
+func TestValidate(t *testing.T) {
+	tester := []struct {
+		Config Config
+		Err    error
+	}{
+		{Config{Spread: 0.01, OrderSize: 1}, nil},
+		{Config{Spread: 0, OrderSize: 1}, ErrInvalidSpread},
+		{Config{Spread: 0.01}, ErrInvalidOrderSize},
+		{Config{Spread: 0.01, OrderSize: 1, SkewFactor: 1}, ErrInvalidSkew},
+	}
+	for i := range tester {
+		if err := tester[i].Config.Validate(); err != tester[i].Err {
+			t.Errorf("Test Failed - Validate() test %d expected %v received %v",
+				i, tester[i].Err, err)
+		}
+	}
+}
+
+func TestCalculateQuote(t *testing.T) {
+	c := Config{Spread: 0.02, OrderSize: 2, MaxInventory: 10, SkewFactor: 1}
+
+	q := CalculateQuote(&c, 100, 0)
+	if q.Bid != 99 || q.Ask != 101 || q.BidAmount != 2 || q.AskAmount != 2 {
+		t.Error("Test Failed - CalculateQuote() incorrect flat quote", q)
+	}
+
+	q = CalculateQuote(&c, 100, 5)
+	if q.Bid != 98.5 || q.Ask != 100.5 || q.BidAmount != 1 || q.AskAmount != 2 {
+		t.Error("Test Failed - CalculateQuote() incorrect long quote", q)
+	}
+
+	q = CalculateQuote(&c, 100, -20)
+	if q.Bid != 100 || q.Ask != 102 || q.BidAmount != 2 || q.AskAmount != 0 {
+		t.Error("Test Failed - CalculateQuote() incorrect short quote", q)
+	}
+}
+
+func TestMarketMaker(t *testing.T) {
+	exch := &testExchange{}
+	m, err := New(exch, pair.NewCurrencyPair("BTC", "USD"),
+		Config{Spread: 0.02, OrderSize: 1, MaxInventory: 2, SkewFactor: 1, RequoteThreshold: 0.001},
+		risk.Limits{MaxPosition: 1.5})
+	if err != nil {
+		t.Fatal("Test Failed - New() error", err)
+	}
+
+	err = m.OnOrderbook(orderbook.Base{
+		Bids: []orderbook.Item{{Price: 99.9, Amount: 1}},
+		Asks: []orderbook.Item{{Price: 100.1, Amount: 1}},
+	})
+	if err != nil {
+		t.Fatal("Test Failed - OnOrderbook() error", err)
+	}
+	bid, ask := m.Quotes()
+	if bid == nil || ask == nil || bid.Price != 99 || ask.Price != 101 {
+		t.Fatal("Test Failed - OnOrderbook() incorrect quotes")
+	}
+
+	// Moves below the requote threshold keep the current quotes
+	if err = m.OnReference(100.05); err != nil {
+		t.Fatal("Test Failed - OnReference() error", err)
+	}
+	if exch.submitted != 2 || len(exch.cancelled) != 0 {
+		t.Error("Test Failed - OnReference() requoted below threshold")
+	}
+	if err = m.OnReference(101); err != nil {
+		t.Fatal("Test Failed - OnReference() error", err)
+	}
+	if exch.submitted != 4 || len(exch.cancelled) != 2 {
+		t.Error("Test Failed - OnReference() did not requote", exch.submitted, exch.cancelled)
+	}
+
+	bid, _ = m.Quotes()
+	if err = m.AddFill(bid.OrderID, orders.Fill{Amount: 1, Price: bid.Price}); err != nil {
+		t.Fatal("Test Failed - AddFill() error", err)
+	}
+	if m.Inventory != 1 {
+		t.Error("Test Failed - AddFill() incorrect inventory", m.Inventory)
+	}
+
+	// The bid is skewed lower and resized to the remaining position limit
+	bid, ask = m.Quotes()
+	if bid == nil || bid.Amount != 0.5 || math.Abs(bid.Price-99.485) > 1e-9 ||
+		math.Abs(ask.Price-101.505) > 1e-9 {
+		t.Error("Test Failed - AddFill() incorrect skewed quotes", bid.Detail(), ask.Detail())
+	}
+	if err = m.AddFill(-1, orders.Fill{Amount: 1, Price: 1}); err != ErrUnknownOrder {
+		t.Error("Test Failed - AddFill() expected unknown order error", err)
+	}
+
+	if err = m.Stop(); err != nil {
+		t.Fatal("Test Failed - Stop() error", err)
+	}
+	if bid, ask = m.Quotes(); bid != nil || ask != nil {
+		t.Error("Test Failed - Stop() quotes still open")
+	}
+}
+
+func TestPauseResume(t *testing.T) {
+	exch := &testExchange{}
+	m, err := New(exch, pair.NewCurrencyPair("BTC", "USD"),
+		Config{Spread: 0.02, OrderSize: 1}, risk.Limits{})
+	if err != nil {
+		t.Fatal("Test Failed - New() error", err)
+	}
+	if err = m.OnReference(100); err != nil {
+		t.Fatal("Test Failed - OnReference() error", err)
+	}
+
+	if err = m.Pause(); err != nil {
+		t.Fatal("Test Failed - Pause() error", err)
+	}
+	if bid, ask := m.Quotes(); bid != nil || ask != nil || !m.Paused() {
+		t.Error("Test Failed - Pause() quotes still open")
+	}
+	if err = m.OnReference(110); err != nil {
+		t.Fatal("Test Failed - OnReference() error", err)
+	}
+	if exch.submitted != 2 {
+		t.Error("Test Failed - OnReference() quoted while paused", exch.submitted)
+	}
+
+	if err = m.Resume(); err != nil {
+		t.Fatal("Test Failed - Resume() error", err)
+	}
+	bid, ask := m.Quotes()
+	if m.Paused() || bid == nil || ask == nil || bid.Price != 108.9 || ask.Price != 111.1 {
+		t.Error("Test Failed - Resume() did not requote at the last reference price")
+	}
+	m.Stop()
+}
+
+func TestStrategy(t *testing.T) {
+	exch := &testExchange{}
+	btcusd := pair.NewCurrencyPair("BTC", "USD")
+	s, err := NewStrategy("mm-test", exch, btcusd, Config{Spread: 0.02, OrderSize: 1}, risk.Limits{})
+	if err != nil {
+		t.Fatal("Test Failed - NewStrategy() error", err)
+	}
+	var _ strategy.Strategy = s
+	if s.Name() != "mm-test" || s.Maker.Strategy != "mm-test" {
+		t.Error("Test Failed - NewStrategy() incorrect name", s.Name())
+	}
+
+	book := orderbook.Base{
+		Pair: btcusd,
+		Bids: []orderbook.Item{{Price: 99, Amount: 1}},
+		Asks: []orderbook.Item{{Price: 101, Amount: 1}},
+	}
+	if err = s.OnOrderbook(strategy.Orderbook{Exchange: "Other", Base: book}); err != nil || exch.submitted != 0 {
+		t.Error("Test Failed - OnOrderbook() quoted another exchange's orderbook", err)
+	}
+	if err = s.OnOrderbook(strategy.Orderbook{Exchange: exch.GetName(), Base: book}); err != nil || exch.submitted != 2 {
+		t.Fatal("Test Failed - OnOrderbook() expected quotes", err, exch.submitted)
+	}
+
+	bid, _ := s.Maker.Quotes()
+	err = s.OnOrderEvent(strategy.OrderEvent{OrderID: bid.OrderID, FillAmount: 0.5, Order: bid.Detail()})
+	if err != nil || s.Maker.Inventory != 0.5 || exch.submitted != 4 {
+		t.Error("Test Failed - OnOrderEvent() expected inventory updated and requoted", err, s.Maker.Inventory)
+	}
+
+	// A late fill of the replaced bid only moves the inventory
+	err = s.OnOrderEvent(strategy.OrderEvent{OrderID: bid.OrderID, FillAmount: 0.25, Order: bid.Detail()})
+	if err != nil || s.Maker.Inventory != 0.75 || exch.submitted != 4 {
+		t.Error("Test Failed - OnOrderEvent() incorrect late fill", err, s.Maker.Inventory)
+	}
+	s.Maker.Stop()
+}
@@ -0,0 +1,245 @@
+// Package rsi provides a reference strategy trading the relative strength
+// index of an exchange pair's candles. It buys when the RSI falls below the
+// oversold threshold and sells the position when it rises above the
+// overbought threshold, placing market orders through the order manager.
+package rsi
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/orders"
+	"github.com/thrasher-/gocryptotrader/strategy"
+)
+
+// StrategyName is the default strategy name RSI orders are placed and
+// throttled under
+const StrategyName = "rsi"
+
+// Default RSI parameters, Wilder's original period and thresholds
+const (
+	DefaultPeriod     = 14
+	DefaultOversold   = 30
+	DefaultOverbought = 70
+)
+
+// Errors returned by the RSI strategy
+var (
+	ErrInvalidPeriod     = errors.New("RSI period must be at least two")
+	ErrInvalidThresholds = errors.New("RSI thresholds must be between 0 and 100 with oversold below overbought")
+	ErrInvalidOrderSize  = errors.New("order size must be greater than zero")
+	ErrNoOrderer         = errors.New("no orderer supplied")
+)
+
+// Config holds the strategy parameters. OrderSize is the base currency
+// amount bought when the RSI is below Oversold.
+type Config struct {
+	Period     int
+	Oversold   float64
+	Overbought float64
+	OrderSize  float64
+}
+
+// Validate checks the strategy parameters
+func (c *Config) Validate() error {
+	if c.Period < 2 {
+		return ErrInvalidPeriod
+	}
+	if c.Oversold <= 0 || c.Overbought >= 100 || c.Oversold >= c.Overbought {
+		return ErrInvalidThresholds
+	}
+	if c.OrderSize <= 0 {
+		return ErrInvalidOrderSize
+	}
+	return nil
+}
+
+// Indicator calculates the relative strength index of a series of closes
+// with Wilder's smoothing. The first Period changes are averaged, later
+// changes are smoothed into the averages.
+type Indicator struct {
+	Period int
+
+	closes  int
+	prev    float64
+	avgGain float64
+	avgLoss float64
+}
+
+// Add adds a close and returns the RSI, false until Period changes have been
+// added
+func (i *Indicator) Add(close float64) (float64, bool) {
+	i.closes++
+	if i.closes == 1 {
+		i.prev = close
+		return 0, false
+	}
+
+	var gain, loss float64
+	if change := close - i.prev; change > 0 {
+		gain = change
+	} else {
+		loss = -change
+	}
+	i.prev = close
+
+	period := float64(i.Period)
+	changes := i.closes - 1
+	if changes <= i.Period {
+		i.avgGain += gain / period
+		i.avgLoss += loss / period
+		if changes < i.Period {
+			return 0, false
+		}
+	} else {
+		i.avgGain = (i.avgGain*(period-1) + gain) / period
+		i.avgLoss = (i.avgLoss*(period-1) + loss) / period
+	}
+
+	if i.avgLoss == 0 {
+		if i.avgGain == 0 {
+			return 50, true
+		}
+		return 100, true
+	}
+	return 100 - 100/(1+i.avgGain/i.avgLoss), true
+}
+
+// Strategy trades the RSI of an exchange pair's closed candles. It holds at
+// most one position of OrderSize and waits for each order to fill or be
+// cancelled before placing another.
+type Strategy struct {
+	strategy.Base
+	Config
+	Exchange exchange.IBotExchange
+	Pair     pair.CurrencyPair
+	Orderer  exchange.Orderer
+
+	name        string
+	indicator   Indicator
+	value       float64
+	position    float64
+	pending     int
+	pendingSide exchange.OrderSide
+	m           sync.Mutex
+}
+
+// New returns a new RSI strategy trading an exchange pair through orderer.
+// Zero parameters take their defaults and the strategy is named StrategyName
+// when name is empty.
+func New(name string, exch exchange.IBotExchange, p pair.CurrencyPair, c Config, orderer exchange.Orderer) (*Strategy, error) {
+	if c.Period == 0 {
+		c.Period = DefaultPeriod
+	}
+	if c.Oversold == 0 && c.Overbought == 0 {
+		c.Oversold, c.Overbought = DefaultOversold, DefaultOverbought
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	if orderer == nil {
+		return nil, ErrNoOrderer
+	}
+	if name == "" {
+		name = StrategyName
+	}
+	return &Strategy{
+		Config:    c,
+		Exchange:  exch,
+		Pair:      p,
+		Orderer:   orderer,
+		name:      name,
+		indicator: Indicator{Period: c.Period},
+	}, nil
+}
+
+// Name returns the strategy name
+func (s *Strategy) Name() string {
+	return s.name
+}
+
+// Value returns the last RSI and whether enough candles have closed to
+// calculate it
+func (s *Strategy) Value() (float64, bool) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.value, s.indicator.closes > s.Period
+}
+
+// Position returns the base currency amount held by the strategy
+func (s *Strategy) Position() float64 {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.position
+}
+
+// OnCandle updates the RSI with a closed candle of the strategy's pair and
+// buys when oversold or sells the position when overbought
+func (s *Strategy) OnCandle(c strategy.Candle) error {
+	if common.StringToUpper(c.Exchange) != common.StringToUpper(s.Exchange.GetName()) ||
+		!c.Pair.Equal(s.Pair, true) {
+		return nil
+	}
+
+	s.m.Lock()
+	defer s.m.Unlock()
+	value, ok := s.indicator.Add(c.Close)
+	if !ok {
+		return nil
+	}
+	s.value = value
+	if s.pending != 0 {
+		return nil
+	}
+	switch {
+	case value < s.Oversold && s.position == 0:
+		return s.submit(exchange.Buy, s.OrderSize)
+	case value > s.Overbought && s.position > 0:
+		return s.submit(exchange.Sell, s.position)
+	}
+	return nil
+}
+
+// OnOrderEvent updates the position with the fills of the strategy's pending
+// order, allowing a new order once it is filled or cancelled
+func (s *Strategy) OnOrderEvent(e strategy.OrderEvent) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if e.OrderID == 0 || e.OrderID != s.pending {
+		return nil
+	}
+	if s.pendingSide == exchange.Buy {
+		s.position += e.FillAmount
+	} else {
+		s.position -= e.FillAmount
+		if s.position < 0 {
+			s.position = 0
+		}
+	}
+	switch e.Order.Status {
+	case orders.StatusFilled, orders.StatusCancelled, orders.StatusPartiallyCancelled:
+		s.pending = 0
+		s.pendingSide = ""
+	}
+	return nil
+}
+
+// submit places a market order through the orderer. The mutex must be held
+// by the caller.
+func (s *Strategy) submit(side exchange.OrderSide, amount float64) error {
+	id, err := s.Orderer.SubmitStrategyOrder(s.name, s.Exchange, exchange.OrderSubmission{
+		Pair:       s.Pair,
+		Side:       side,
+		Type:       exchange.Market,
+		BaseAmount: amount,
+	})
+	if err != nil {
+		return err
+	}
+	s.pending = id
+	s.pendingSide = side
+	return nil
+}
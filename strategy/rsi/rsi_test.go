@@ -0,0 +1,121 @@
+package rsi
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-/gocryptotrader/exchanges/orders"
+	"github.com/thrasher-/gocryptotrader/strategy"
+)
+
+type testExchange struct {
+	exchange.IBotExchange
+}
+
+func (e *testExchange) GetName() string {
+	return "RSITest"
+}
+
+// testOrderer records the orders submitted by the strategy
+type testOrderer struct {
+	submitted []exchange.OrderSubmission
+}
+
+func (o *testOrderer) SubmitStrategyOrder(s string, exch exchange.IBotExchange, sub exchange.OrderSubmission) (int, error) {
+	o.submitted = append(o.submitted, sub)
+	return len(o.submitted), nil
+}
+
+func TestValidate(t *testing.T) {
+	tester := []struct {
+		Config Config
+		Err    error
+	}{
+		{Config{Period: 14, Oversold: 30, Overbought: 70, OrderSize: 1}, nil},
+		{Config{Period: 1, Oversold: 30, Overbought: 70, OrderSize: 1}, ErrInvalidPeriod},
+		{Config{Period: 14, Oversold: 70, Overbought: 30, OrderSize: 1}, ErrInvalidThresholds},
+		{Config{Period: 14, Oversold: 30, Overbought: 70}, ErrInvalidOrderSize},
+	}
+	for i := range tester {
+		if err := tester[i].Config.Validate(); err != tester[i].Err {
+			t.Errorf("Test Failed - Validate() test %d expected %v received %v",
+				i, tester[i].Err, err)
+		}
+	}
+}
+
+func TestIndicator(t *testing.T) {
+	i := Indicator{Period: 2}
+	for _, close := range []float64{10, 11} {
+		if _, ok := i.Add(close); ok {
+			t.Error("Test Failed - Add() expected no value before period changes")
+		}
+	}
+	if v, ok := i.Add(10); !ok || v != 50 {
+		t.Error("Test Failed - Add() expected 50", v, ok)
+	}
+	if v, _ := i.Add(12); math.Abs(v-83.3333) > 0.0001 {
+		t.Error("Test Failed - Add() expected smoothed value 83.3333", v)
+	}
+
+	rising := Indicator{Period: 3}
+	var v float64
+	for close := 1.0; close <= 5; close++ {
+		v, _ = rising.Add(close)
+	}
+	if v != 100 {
+		t.Error("Test Failed - Add() expected 100 for rising closes", v)
+	}
+}
+
+func TestStrategy(t *testing.T) {
+	o := &testOrderer{}
+	p := pair.NewCurrencyPair("BTC", "USD")
+	s, err := New("", &testExchange{}, p, Config{Period: 2, OrderSize: 0.5}, o)
+	if err != nil {
+		t.Fatal("Test Failed - New() error", err)
+	}
+	if s.Name() != StrategyName || s.Oversold != DefaultOversold || s.Overbought != DefaultOverbought {
+		t.Error("Test Failed - New() expected defaults", s.Name(), s.Config)
+	}
+
+	start := time.Now().Truncate(time.Minute)
+	closes := []float64{100, 90, 80, 85}
+	for i, close := range closes {
+		err = s.OnCandle(strategy.Candle{Exchange: "rsitest", Pair: p,
+			Candle: kline.Candle{Time: start.Add(time.Duration(i) * time.Minute), Close: close}})
+		if err != nil {
+			t.Fatal("Test Failed - OnCandle() error", err)
+		}
+	}
+	// The oversold buy is pending while the fourth candle closes
+	if len(o.submitted) != 1 || o.submitted[0].Side != exchange.Buy ||
+		o.submitted[0].Type != exchange.Market || o.submitted[0].BaseAmount != 0.5 {
+		t.Fatal("Test Failed - OnCandle() expected single oversold buy", o.submitted)
+	}
+	if v, ok := s.Value(); !ok || math.Abs(v-100.0/3) > 0.0001 {
+		t.Error("Test Failed - Value() incorrect RSI", v, ok)
+	}
+
+	s.OnOrderEvent(strategy.OrderEvent{OrderID: 2, FillAmount: 1, Order: exchange.OrderDetail{Status: orders.StatusFilled}})
+	s.OnOrderEvent(strategy.OrderEvent{OrderID: 1, FillAmount: 0.5, Order: exchange.OrderDetail{Status: orders.StatusFilled}})
+	if s.Position() != 0.5 {
+		t.Error("Test Failed - OnOrderEvent() expected position of 0.5", s.Position())
+	}
+
+	err = s.OnCandle(strategy.Candle{Exchange: "rsitest", Pair: pair.NewCurrencyPair("LTC", "USD"),
+		Candle: kline.Candle{Time: start.Add(4 * time.Minute), Close: 1000}})
+	if err != nil || len(o.submitted) != 1 {
+		t.Error("Test Failed - OnCandle() expected other pair ignored", err)
+	}
+	err = s.OnCandle(strategy.Candle{Exchange: "RSITest", Pair: p,
+		Candle: kline.Candle{Time: start.Add(4 * time.Minute), Close: 100}})
+	if err != nil || len(o.submitted) != 2 || o.submitted[1].Side != exchange.Sell ||
+		o.submitted[1].BaseAmount != 0.5 {
+		t.Error("Test Failed - OnCandle() expected overbought sell of the position", o.submitted, err)
+	}
+}
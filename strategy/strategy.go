@@ -0,0 +1,277 @@
+// Package strategy defines the interface user trading strategies implement
+// and a runner which passes the tickers, orderbooks, candles and order events
+// of the bot's exchanges to them. Strategies place orders through an Orderer,
+// the engine's order manager, so their orders are tracked, throttled and
+// journaled like any other order placed by the bot.
+package strategy
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+// DefaultCandleInterval is the interval of the candles built from tickers
+// when the runner has none set
+const DefaultCandleInterval = kline.OneMin
+
+// Errors returned by the strategy runner
+var (
+	ErrNoStrategy       = errors.New("no strategy supplied")
+	ErrStrategyExists   = errors.New("strategy with the same name is already running")
+	ErrStrategyNotFound = errors.New("strategy not found")
+)
+
+// Tick is a ticker update of an exchange pair
+type Tick struct {
+	Exchange  string
+	AssetType string
+	ticker.Price
+}
+
+// Orderbook is an orderbook update of an exchange pair
+type Orderbook struct {
+	Exchange string
+	orderbook.Base
+}
+
+// Candle is a closed candle of an exchange pair
+type Candle struct {
+	Exchange  string
+	AssetType string
+	Pair      pair.CurrencyPair
+	Interval  kline.Interval
+	kline.Candle
+}
+
+// OrderEvent is a fill or cancellation of an order placed by a strategy.
+// FillAmount and FillPrice are the amount executed since the previous event
+// and its average price.
+type OrderEvent struct {
+	Type       string
+	OrderID    int
+	Strategy   string
+	FillAmount float64
+	FillPrice  float64
+	Order      exchange.OrderDetail
+}
+
+// Strategy receives the market data of the exchanges it is added to the
+// runner for and the events of the orders it places. Handlers are called
+// sequentially, an error returned is reported to the runner's OnError and
+// does not stop the strategy.
+type Strategy interface {
+	Name() string
+	OnTick(t Tick) error
+	OnOrderbook(ob Orderbook) error
+	OnCandle(c Candle) error
+	OnOrderEvent(e OrderEvent) error
+}
+
+// Base ignores every event, strategies embed it to only implement the
+// handlers they need
+type Base struct{}
+
+// OnTick ignores the ticker update
+func (Base) OnTick(t Tick) error { return nil }
+
+// OnOrderbook ignores the orderbook update
+func (Base) OnOrderbook(ob Orderbook) error { return nil }
+
+// OnCandle ignores the candle
+func (Base) OnCandle(c Candle) error { return nil }
+
+// OnOrderEvent ignores the order event
+func (Base) OnOrderEvent(e OrderEvent) error { return nil }
+
+// registration is a strategy and the exchanges it receives data from, all
+// exchanges when empty
+type registration struct {
+	strategy  Strategy
+	exchanges []string
+}
+
+// Runner passes market data and order events to its strategies. Candles of
+// CandleInterval are built from the last price of the tickers it receives and
+// passed to the strategies once closed. Order events are only passed to the
+// strategy which placed the order.
+type Runner struct {
+	CandleInterval kline.Interval
+	OnError        func(name string, err error)
+
+	strategies []registration
+	candles    map[string]*Candle
+	m          sync.Mutex
+}
+
+// NewRunner returns a new strategy runner building candles of interval
+func NewRunner(interval kline.Interval) *Runner {
+	if interval <= 0 {
+		interval = DefaultCandleInterval
+	}
+	return &Runner{
+		CandleInterval: interval,
+		candles:        make(map[string]*Candle),
+	}
+}
+
+// Add adds a strategy receiving the data of exchanges, or of every exchange
+// when none are given
+func (r *Runner) Add(s Strategy, exchanges ...string) error {
+	if s == nil {
+		return ErrNoStrategy
+	}
+	r.m.Lock()
+	defer r.m.Unlock()
+	for i := range r.strategies {
+		if r.strategies[i].strategy.Name() == s.Name() {
+			return ErrStrategyExists
+		}
+	}
+	r.strategies = append(r.strategies, registration{strategy: s, exchanges: exchanges})
+	return nil
+}
+
+// Remove stops passing data to a strategy
+func (r *Runner) Remove(name string) error {
+	r.m.Lock()
+	defer r.m.Unlock()
+	for i := range r.strategies {
+		if r.strategies[i].strategy.Name() == name {
+			r.strategies = append(r.strategies[:i], r.strategies[i+1:]...)
+			return nil
+		}
+	}
+	return ErrStrategyNotFound
+}
+
+// Strategies returns the names of the running strategies
+func (r *Runner) Strategies() []string {
+	r.m.Lock()
+	defer r.m.Unlock()
+	names := make([]string, 0, len(r.strategies))
+	for i := range r.strategies {
+		names = append(names, r.strategies[i].strategy.Name())
+	}
+	return names
+}
+
+// OnTick passes a ticker update to the strategies and adds its last price to
+// the pair's current candle, passing the candle to the strategies once a
+// ticker falls in a later interval
+func (r *Runner) OnTick(t Tick) {
+	for _, s := range r.subscribed(t.Exchange) {
+		r.report(s, s.OnTick(t))
+	}
+	if closed := r.addPrice(&t); closed != nil {
+		r.OnCandle(*closed)
+	}
+}
+
+// OnOrderbook passes an orderbook update to the strategies
+func (r *Runner) OnOrderbook(ob Orderbook) {
+	for _, s := range r.subscribed(ob.Exchange) {
+		r.report(s, s.OnOrderbook(ob))
+	}
+}
+
+// OnCandle passes a closed candle to the strategies
+func (r *Runner) OnCandle(c Candle) {
+	for _, s := range r.subscribed(c.Exchange) {
+		r.report(s, s.OnCandle(c))
+	}
+}
+
+// OnOrderEvent passes an order event to the strategy which placed the order
+func (r *Runner) OnOrderEvent(e OrderEvent) {
+	if e.Strategy == "" {
+		return
+	}
+	r.m.Lock()
+	var s Strategy
+	for i := range r.strategies {
+		if r.strategies[i].strategy.Name() == e.Strategy {
+			s = r.strategies[i].strategy
+			break
+		}
+	}
+	r.m.Unlock()
+	if s != nil {
+		r.report(s, s.OnOrderEvent(e))
+	}
+}
+
+// subscribed returns the strategies receiving an exchange's data. Strategies
+// are called without the mutex held so they may submit orders.
+func (r *Runner) subscribed(exchName string) []Strategy {
+	r.m.Lock()
+	defer r.m.Unlock()
+	var result []Strategy
+	for i := range r.strategies {
+		if len(r.strategies[i].exchanges) == 0 {
+			result = append(result, r.strategies[i].strategy)
+			continue
+		}
+		for _, name := range r.strategies[i].exchanges {
+			if common.StringToUpper(name) == common.StringToUpper(exchName) {
+				result = append(result, r.strategies[i].strategy)
+				break
+			}
+		}
+	}
+	return result
+}
+
+// addPrice adds a ticker's last price to its pair's current candle and
+// returns the candle it closed, if any. Tickers older than the current candle
+// are ignored.
+func (r *Runner) addPrice(t *Tick) *Candle {
+	if t.Last <= 0 {
+		return nil
+	}
+	updated := t.LastUpdated
+	if updated.IsZero() {
+		updated = time.Now()
+	}
+	start := updated.Truncate(r.CandleInterval.Duration())
+	key := t.Exchange + t.AssetType + t.Pair.Pair().String()
+
+	r.m.Lock()
+	defer r.m.Unlock()
+	c, ok := r.candles[key]
+	switch {
+	case ok && start.Equal(c.Time):
+		if t.Last > c.High {
+			c.High = t.Last
+		}
+		if t.Last < c.Low {
+			c.Low = t.Last
+		}
+		c.Close = t.Last
+		return nil
+	case ok && start.Before(c.Time):
+		return nil
+	}
+	r.candles[key] = &Candle{
+		Exchange:  t.Exchange,
+		AssetType: t.AssetType,
+		Pair:      t.Pair,
+		Interval:  r.CandleInterval,
+		Candle:    kline.Candle{Time: start, Open: t.Last, High: t.Last, Low: t.Last, Close: t.Last},
+	}
+	return c
+}
+
+// report passes a strategy's error to OnError
+func (r *Runner) report(s Strategy, err error) {
+	if err != nil && r.OnError != nil {
+		r.OnError(s.Name(), err)
+	}
+}
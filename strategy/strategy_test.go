@@ -0,0 +1,112 @@
+package strategy
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+// testStrategy records the data passed to it
+type testStrategy struct {
+	Base
+	name    string
+	ticks   []Tick
+	books   []Orderbook
+	candles []Candle
+	events  []OrderEvent
+}
+
+func (s *testStrategy) Name() string { return s.name }
+
+func (s *testStrategy) OnTick(t Tick) error {
+	s.ticks = append(s.ticks, t)
+	return nil
+}
+
+func (s *testStrategy) OnOrderbook(ob Orderbook) error {
+	s.books = append(s.books, ob)
+	return errors.New("orderbook error")
+}
+
+func (s *testStrategy) OnCandle(c Candle) error {
+	s.candles = append(s.candles, c)
+	return nil
+}
+
+func (s *testStrategy) OnOrderEvent(e OrderEvent) error {
+	s.events = append(s.events, e)
+	return nil
+}
+
+func TestRunnerAdd(t *testing.T) {
+	r := NewRunner(0)
+	if r.CandleInterval != DefaultCandleInterval {
+		t.Error("Test Failed - NewRunner() expected default candle interval", r.CandleInterval)
+	}
+	if err := r.Add(nil); err != ErrNoStrategy {
+		t.Error("Test Failed - Add() expected no strategy error", err)
+	}
+	if err := r.Add(&testStrategy{name: "a"}); err != nil {
+		t.Error("Test Failed - Add() error", err)
+	}
+	if err := r.Add(&testStrategy{name: "a"}); err != ErrStrategyExists {
+		t.Error("Test Failed - Add() expected strategy exists error", err)
+	}
+	if err := r.Remove("b"); err != ErrStrategyNotFound {
+		t.Error("Test Failed - Remove() expected not found error", err)
+	}
+	if err := r.Remove("a"); err != nil || len(r.Strategies()) != 0 {
+		t.Error("Test Failed - Remove() error", err, r.Strategies())
+	}
+}
+
+func TestRunner(t *testing.T) {
+	r := NewRunner(kline.OneMin)
+	var errs []string
+	r.OnError = func(name string, err error) {
+		errs = append(errs, name+": "+err.Error())
+	}
+	all := &testStrategy{name: "all"}
+	bitstamp := &testStrategy{name: "bitstamp"}
+	r.Add(all)
+	r.Add(bitstamp, "Bitstamp")
+
+	start := time.Now().Truncate(time.Minute)
+	p := pair.NewCurrencyPair("BTC", "USD")
+	for i, price := range []float64{100, 105, 95, 101} {
+		r.OnTick(Tick{Exchange: "bitstamp", AssetType: ticker.Spot, Price: ticker.Price{
+			Pair: p, Last: price, LastUpdated: start.Add(time.Duration(i) * 20 * time.Second)}})
+	}
+	r.OnTick(Tick{Exchange: "Kraken", AssetType: ticker.Spot, Price: ticker.Price{
+		Pair: p, Last: 200, LastUpdated: start}})
+	// A stale ticker is ignored by the candle
+	r.OnTick(Tick{Exchange: "bitstamp", AssetType: ticker.Spot, Price: ticker.Price{
+		Pair: p, Last: 1, LastUpdated: start.Add(-time.Minute)}})
+
+	if len(all.ticks) != 6 || len(bitstamp.ticks) != 5 {
+		t.Error("Test Failed - OnTick() incorrect ticks passed", len(all.ticks), len(bitstamp.ticks))
+	}
+	if len(bitstamp.candles) != 1 {
+		t.Fatal("Test Failed - OnTick() expected a closed candle", bitstamp.candles)
+	}
+	c := bitstamp.candles[0]
+	if !c.Time.Equal(start) || c.Open != 100 || c.High != 105 || c.Low != 95 || c.Close != 95 ||
+		c.Interval != kline.OneMin || c.Exchange != "bitstamp" {
+		t.Errorf("Test Failed - OnTick() incorrect candle %+v", c)
+	}
+
+	r.OnOrderbook(Orderbook{Exchange: "Kraken"})
+	if len(all.books) != 1 || len(bitstamp.books) != 0 || len(errs) != 1 || errs[0] != "all: orderbook error" {
+		t.Error("Test Failed - OnOrderbook() incorrect orderbooks passed", errs)
+	}
+
+	r.OnOrderEvent(OrderEvent{OrderID: 1, Strategy: "bitstamp"})
+	r.OnOrderEvent(OrderEvent{OrderID: 2})
+	if len(bitstamp.events) != 1 || len(all.events) != 0 {
+		t.Error("Test Failed - OnOrderEvent() expected event passed to the placing strategy")
+	}
+}
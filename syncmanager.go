@@ -0,0 +1,210 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/communications/base"
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+// Data types kept updated by the sync manager
+const (
+	SyncTicker    = "ticker"
+	SyncOrderbook = "orderbook"
+)
+
+const syncDefaultWebsocketTimeout = 30 * time.Second
+
+// syncKey identifies a data type of an exchange
+type syncKey struct {
+	exchange string
+	dataType string
+}
+
+// SyncManager selects whether each exchange's tickers and orderbooks are kept
+// updated by REST polling or websocket updates. Data types synced by websocket
+// with REST fallback are polled over REST while the exchange's websocket is
+// disconnected or has sent no update of the data type within
+// WebsocketTimeout, and return to websocket once updates resume.
+type SyncManager struct {
+	WebsocketTimeout time.Duration
+	// OnFallback is called when a data type synced by websocket with REST
+	// fallback falls back to REST polling or returns to websocket
+	OnFallback func(exchName, dataType string, fallback bool)
+
+	connected func(exchange.IBotExchange) bool
+	sources   map[syncKey]string
+	updated   map[syncKey]time.Time
+	fallback  map[syncKey]bool
+	m         sync.Mutex
+}
+
+// NewSyncManager returns a sync manager syncing every data type over REST
+// until sources are set
+func NewSyncManager(websocketTimeout time.Duration) *SyncManager {
+	if websocketTimeout <= 0 {
+		websocketTimeout = syncDefaultWebsocketTimeout
+	}
+	return &SyncManager{
+		WebsocketTimeout: websocketTimeout,
+		connected:        websocketConnected,
+		sources:          make(map[syncKey]string),
+		updated:          make(map[syncKey]time.Time),
+		fallback:         make(map[syncKey]bool),
+	}
+}
+
+// key returns the case insensitive key of an exchange's data type
+func (s *SyncManager) key(exchName, dataType string) syncKey {
+	return syncKey{exchange: common.StringToLower(exchName), dataType: dataType}
+}
+
+// SetSource sets the sync source of an exchange's data type
+func (s *SyncManager) SetSource(exchName, dataType, source string) error {
+	source = common.StringToUpper(source)
+	switch source {
+	case config.SyncSourceREST, config.SyncSourceWebsocket, config.SyncSourceWebsocketFallback:
+	default:
+		return fmt.Errorf("%s invalid %s sync source %s", exchName, dataType, source)
+	}
+
+	s.m.Lock()
+	defer s.m.Unlock()
+	k := s.key(exchName, dataType)
+	s.sources[k] = source
+	delete(s.updated, k)
+	delete(s.fallback, k)
+	return nil
+}
+
+// Source returns the sync source of an exchange's data type, REST when unset
+func (s *SyncManager) Source(exchName, dataType string) string {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.source(s.key(exchName, dataType))
+}
+
+// source returns the sync source of a key, s.m must be held
+func (s *SyncManager) source(k syncKey) string {
+	if source, ok := s.sources[k]; ok {
+		return source
+	}
+	return config.SyncSourceREST
+}
+
+// WebsocketUpdate records a websocket update of an exchange's data type
+// received at now and returns whether it should be processed, websocket
+// updates of data types synced over REST are ignored
+func (s *SyncManager) WebsocketUpdate(exchName, dataType string, now time.Time) bool {
+	s.m.Lock()
+	defer s.m.Unlock()
+	k := s.key(exchName, dataType)
+	if s.source(k) == config.SyncSourceREST {
+		return false
+	}
+	s.updated[k] = now
+	return true
+}
+
+// PollREST returns whether an exchange's data type should be polled over REST
+// at now, calling OnFallback when a websocket with REST fallback goes down or
+// recovers
+func (s *SyncManager) PollREST(exch exchange.IBotExchange, dataType string, now time.Time) bool {
+	connected := s.connected(exch)
+
+	s.m.Lock()
+	k := s.key(exch.GetName(), dataType)
+	switch s.source(k) {
+	case config.SyncSourceREST:
+		s.m.Unlock()
+		return true
+	case config.SyncSourceWebsocket:
+		s.m.Unlock()
+		return false
+	}
+
+	// REST is polled without alerting until the websocket's first update,
+	// websockets are still connecting when the bot starts
+	updated, ok := s.updated[k]
+	if !ok {
+		s.m.Unlock()
+		return true
+	}
+	fallback := !connected || now.Sub(updated) > s.WebsocketTimeout
+	previous := s.fallback[k]
+	s.fallback[k] = fallback
+	s.m.Unlock()
+
+	if previous != fallback && s.OnFallback != nil {
+		s.OnFallback(exch.GetName(), dataType, fallback)
+	}
+	return fallback
+}
+
+// websocketConnected returns whether an exchange's websocket is enabled and
+// connected
+func websocketConnected(exch exchange.IBotExchange) bool {
+	ws, err := exch.GetWebsocket()
+	return err == nil && ws != nil && ws.IsEnabled() && ws.IsConnected()
+}
+
+// websocketTickerPrice converts a websocket ticker update, keeping the bid and
+// ask of the stored ticker as websocket ticker updates don't carry them
+func websocketTickerPrice(t exchange.TickerData) ticker.Price {
+	price := ticker.Price{
+		Pair:         t.Pair,
+		CurrencyPair: t.Pair.Pair().String(),
+		LastUpdated:  t.Timestamp,
+		Last:         t.ClosePrice,
+		High:         t.HighPrice,
+		Low:          t.LowPrice,
+		Volume:       t.Quantity,
+	}
+	if stored, err := ticker.GetTicker(t.Exchange, t.Pair, t.AssetType); err == nil {
+		price.Bid = stored.Bid
+		price.Ask = stored.Ask
+	}
+	return price
+}
+
+// SetupSyncManager sets the configured ticker and orderbook sync sources of
+// each exchange. Data types without a configured source are synced over REST,
+// websocket sources are opted into per exchange.
+func SetupSyncManager() {
+	timeout, _ := time.ParseDuration(bot.config.SyncManager.WebsocketTimeout)
+	bot.sync = NewSyncManager(timeout)
+	bot.sync.OnFallback = syncFallbackAlert
+
+	for _, exch := range bot.config.Exchanges {
+		sources := map[string]string{
+			SyncTicker:    exch.TickerSync,
+			SyncOrderbook: exch.OrderbookSync,
+		}
+		for dataType, source := range sources {
+			if source == "" {
+				continue
+			}
+			if err := bot.sync.SetSource(exch.Name, dataType, source); err != nil {
+				log.Printf("Sync manager: %s, defaulting to REST.", err)
+			}
+		}
+	}
+}
+
+// syncFallbackAlert logs and relays a websocket synced data type falling back
+// to REST polling or returning to websocket
+func syncFallbackAlert(exchName, dataType string, fallback bool) {
+	message := fmt.Sprintf("%s %s sync returned to websocket", exchName, dataType)
+	if fallback {
+		message = fmt.Sprintf("%s %s sync fell back to REST polling as the websocket is down or stale",
+			exchName, dataType)
+	}
+	log.Println(message)
+	bot.comms.PushEvent(base.Event{Type: "sync_fallback", TradeDetails: message})
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/config"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+// testSyncExchange is an exchange without websocket support
+type testSyncExchange struct {
+	exchange.IBotExchange
+}
+
+func (e *testSyncExchange) GetName() string {
+	return "SyncTest"
+}
+
+func (e *testSyncExchange) GetWebsocket() (*exchange.Websocket, error) {
+	return nil, exchange.ErrWebsocketNotConnected
+}
+
+func TestSyncManagerSources(t *testing.T) {
+	s := NewSyncManager(0)
+	if s.WebsocketTimeout != syncDefaultWebsocketTimeout {
+		t.Error("Test failed. NewSyncManager expected default websocket timeout", s.WebsocketTimeout)
+	}
+	if err := s.SetSource("SyncTest", SyncTicker, "carrier pigeon"); err == nil {
+		t.Error("Test failed. SetSource expected invalid source error")
+	}
+	if err := s.SetSource("SyncTest", SyncTicker, "websocket"); err != nil {
+		t.Error("Test failed. SetSource error", err)
+	}
+	if s.Source("synctest", SyncTicker) != config.SyncSourceWebsocket ||
+		s.Source("SyncTest", SyncOrderbook) != config.SyncSourceREST {
+		t.Error("Test failed. Source incorrect sources")
+	}
+
+	exch := &testSyncExchange{}
+	now := time.Now()
+	if s.PollREST(exch, SyncTicker, now) || !s.PollREST(exch, SyncOrderbook, now) {
+		t.Error("Test failed. PollREST expected websocket tickers and REST orderbooks")
+	}
+	if !s.WebsocketUpdate("SyncTest", SyncTicker, now) || s.WebsocketUpdate("SyncTest", SyncOrderbook, now) {
+		t.Error("Test failed. WebsocketUpdate expected REST synced orderbook updates ignored")
+	}
+
+}
+
+func TestSetupSyncManager(t *testing.T) {
+	cfg, syncManager := bot.config, bot.sync
+	defer func() {
+		bot.config, bot.sync = cfg, syncManager
+	}()
+	bot.config = &config.Config{Exchanges: []config.ExchangeConfig{
+		{Name: "SyncTest", Websocket: true, OrderbookSync: config.SyncSourceWebsocketFallback},
+	}}
+
+	SetupSyncManager()
+	if bot.sync.Source("SyncTest", SyncTicker) != config.SyncSourceREST ||
+		bot.sync.Source("SyncTest", SyncOrderbook) != config.SyncSourceWebsocketFallback {
+		t.Error("Test failed. SetupSyncManager expected REST unless websocket is configured")
+	}
+}
+
+func TestSyncManagerFallback(t *testing.T) {
+	s := NewSyncManager(time.Minute)
+	connected := true
+	s.connected = func(exchange.IBotExchange) bool { return connected }
+	var alerts []bool
+	s.OnFallback = func(exchName, dataType string, fallback bool) {
+		alerts = append(alerts, fallback)
+	}
+	s.SetSource("SyncTest", SyncTicker, config.SyncSourceWebsocketFallback)
+
+	exch := &testSyncExchange{}
+	now := time.Now()
+	if !s.PollREST(exch, SyncTicker, now) {
+		t.Error("Test failed. PollREST expected REST until the first websocket update")
+	}
+	s.WebsocketUpdate("SyncTest", SyncTicker, now)
+	if s.PollREST(exch, SyncTicker, now.Add(time.Second)) {
+		t.Error("Test failed. PollREST expected websocket once updated")
+	}
+	if !s.PollREST(exch, SyncTicker, now.Add(2*time.Minute)) {
+		t.Error("Test failed. PollREST expected fallback on stale websocket")
+	}
+	s.WebsocketUpdate("SyncTest", SyncTicker, now.Add(2*time.Minute))
+	if s.PollREST(exch, SyncTicker, now.Add(2*time.Minute)) {
+		t.Error("Test failed. PollREST expected websocket once updates resume")
+	}
+	connected = false
+	if !s.PollREST(exch, SyncTicker, now.Add(2*time.Minute)) {
+		t.Error("Test failed. PollREST expected fallback on disconnected websocket")
+	}
+
+	if len(alerts) != 3 || !alerts[0] || alerts[1] || !alerts[2] {
+		t.Error("Test failed. OnFallback incorrect alerts", alerts)
+	}
+}
+
+func TestWebsocketTickerPrice(t *testing.T) {
+	p := pair.NewCurrencyPair("BTC", "USD")
+	ticker.ProcessTicker("SyncTickerTest", p, ticker.Price{Bid: 99, Ask: 101, Last: 100}, ticker.Spot)
+
+	price := websocketTickerPrice(exchange.TickerData{
+		Exchange: "SyncTickerTest", Pair: p, AssetType: ticker.Spot, ClosePrice: 102, HighPrice: 105,
+	})
+	if price.Last != 102 || price.High != 105 || price.Bid != 99 || price.Ask != 101 || price.CurrencyPair != "BTCUSD" {
+		t.Error("Test failed. websocketTickerPrice incorrect price", price)
+	}
+}
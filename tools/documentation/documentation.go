@@ -13,6 +13,8 @@ import (
 )
 
 const (
+	availabilityPath                = "..%s..%savailability%s"
+	backtestPath                    = "..%s..%sbacktest%s"
 	commonPath                      = "..%s..%scommon%s"
 	communicationsPath              = "..%s..%scommunications%s"
 	communicationsBasePath          = "..%s..%scommunications%sbase%s"
@@ -28,21 +30,51 @@ const (
 	currencyFXCurrencylayerPath     = "..%s..%scurrency%sforexprovider%scurrencylayer%s"
 	currencyFXFixerPath             = "..%s..%scurrency%sforexprovider%sfixer.io%s"
 	currencyFXOpenExchangeRatesPath = "..%s..%scurrency%sforexprovider%sopenexchangerates%s"
+	currencyLocalePath              = "..%s..%scurrency%slocale%s"
 	currencyPairPath                = "..%s..%scurrency%spair%s"
 	currencySymbolPath              = "..%s..%scurrency%ssymbol%s"
 	currencyTranslationPath         = "..%s..%scurrency%stranslation%s"
 	eventsPath                      = "..%s..%sevents%s"
+	exchangesCalendarPath           = "..%s..%sexchanges%scalendar%s"
+	exchangesFeesPath               = "..%s..%sexchanges%sfees%s"
 	exchangesPath                   = "..%s..%sexchanges%s"
 	exchangesNoncePath              = "..%s..%sexchanges%snonce%s"
+	exchangesKlinePath              = "..%s..%sexchanges%skline%s"
 	exchangesOrderbookPath          = "..%s..%sexchanges%sorderbook%s"
 	exchangesStatsPath              = "..%s..%sexchanges%sstats%s"
 	exchangesTickerPath             = "..%s..%sexchanges%sticker%s"
+	exchangesTradePath              = "..%s..%sexchanges%strade%s"
 	exchangesOrdersPath             = "..%s..%sexchanges%sorders%s"
 	exchangesRequestPath            = "..%s..%sexchanges%srequest%s"
+	exchangesConformancePath        = "..%s..%sexchanges%sconformance%s"
+	exchangesABBOPath               = "..%s..%sexchanges%sabbo%s"
+	exchangesQualityPath            = "..%s..%sexchanges%squality%s"
+	exchangesLatencyPath            = "..%s..%sexchanges%slatency%s"
+	exchangesPaperPath              = "..%s..%sexchanges%spaper%s"
+	exchangesAssetsPath             = "..%s..%sexchanges%sassets%s"
+	gctrpcPath                      = "..%s..%sgctrpc%s"
+	dbPath                          = "..%s..%sdb%s"
+	journalPath                     = "..%s..%sjournal%s"
 	portfolioPath                   = "..%s..%sportfolio%s"
+	riskPath                        = "..%s..%srisk%s"
+	analyticsPath                   = "..%s..%sanalytics%s"
+	arbitragePath                   = "..%s..%sarbitrage%s"
+	basisPath                       = "..%s..%sbasis%s"
+	dustPath                        = "..%s..%sdust%s"
+	executionPath                   = "..%s..%sexecution%s"
+	hedgePath                       = "..%s..%shedge%s"
+	liquidationPath                 = "..%s..%sliquidation%s"
+	marginPath                      = "..%s..%smargin%s"
+	statementPath                   = "..%s..%sstatement%s"
+	sizingPath                      = "..%s..%ssizing%s"
+	strategyPath                    = "..%s..%sstrategy%s"
+	strategyBridgePath              = "..%s..%sstrategy%sbridge%s"
+	strategyMarketMakerPath         = "..%s..%sstrategy%smarketmaker%s"
+	strategyRSIPath                 = "..%s..%sstrategy%srsi%s"
 	testdataPath                    = "..%s..%stestdata%s"
 	toolsPath                       = "..%s..%stools%s"
 	webPath                         = "..%s..%sweb%s"
+	withdrawPath                    = "..%s..%swithdraw%s"
 	rootPath                        = "..%s..%s"
 
 	// exchange packages
@@ -57,6 +89,8 @@ const (
 	bittrex       = "..%s..%sexchanges%sbittrex%s"
 	btcc          = "..%s..%sexchanges%sbtcc%s"
 	btcmarkets    = "..%s..%sexchanges%sbtcmarkets%s"
+	bybit         = "..%s..%sexchanges%sbybit%s"
+	coinbase      = "..%s..%sexchanges%scoinbase%s"
 	coinbasepro   = "..%s..%sexchanges%scoinbasepro%s"
 	coinut        = "..%s..%sexchanges%scoinut%s"
 	exmo          = "..%s..%sexchanges%sexmo%s"
@@ -67,12 +101,16 @@ const (
 	huobihadax    = "..%s..%sexchanges%shuobihadax%s"
 	itbit         = "..%s..%sexchanges%sitbit%s"
 	kraken        = "..%s..%sexchanges%skraken%s"
+	kucoin        = "..%s..%sexchanges%skucoin%s"
 	lakebtc       = "..%s..%sexchanges%slakebtc%s"
 	liqui         = "..%s..%sexchanges%sliqui%s"
 	localbitcoins = "..%s..%sexchanges%slocalbitcoins%s"
+	mexc          = "..%s..%sexchanges%smexc%s"
 	okcoin        = "..%s..%sexchanges%sokcoin%s"
 	okex          = "..%s..%sexchanges%sokex%s"
+	okx           = "..%s..%sexchanges%sokx%s"
 	poloniex      = "..%s..%sexchanges%spoloniex%s"
+	simulator     = "..%s..%sexchanges%ssimulator%s"
 	wex           = "..%s..%sexchanges%swex%s"
 	yobit         = "..%s..%sexchanges%syobit%s"
 	zb            = "..%s..%sexchanges%szb%s"
@@ -183,6 +221,8 @@ func updateReadme() error {
 
 // addPaths adds paths to different potential README.md files in the codebase
 func addPaths() {
+	codebasePaths["availability"] = fmt.Sprintf(availabilityPath, path, path, path)
+	codebasePaths["backtest"] = fmt.Sprintf(backtestPath, path, path, path)
 	codebasePaths["common"] = fmt.Sprintf(commonPath, path, path, path)
 
 	codebasePaths["communications comms"] = fmt.Sprintf(communicationsPath, path, path, path)
@@ -201,6 +241,7 @@ func addPaths() {
 	codebasePaths["currency forexprovider currencylayer"] = fmt.Sprintf(currencyFXCurrencylayerPath, path, path, path, path, path)
 	codebasePaths["currency forexprovider fixer"] = fmt.Sprintf(currencyFXFixerPath, path, path, path, path, path)
 	codebasePaths["currency forexprovider openexchangerates"] = fmt.Sprintf(currencyFXOpenExchangeRatesPath, path, path, path, path, path)
+	codebasePaths["currency locale"] = fmt.Sprintf(currencyLocalePath, path, path, path, path)
 	codebasePaths["currency pair"] = fmt.Sprintf(currencyPairPath, path, path, path, path)
 	codebasePaths["currency symbol"] = fmt.Sprintf(currencySymbolPath, path, path, path, path)
 	codebasePaths["currency translation"] = fmt.Sprintf(currencyTranslationPath, path, path, path, path)
@@ -208,18 +249,47 @@ func addPaths() {
 	codebasePaths["events"] = fmt.Sprintf(eventsPath, path, path, path)
 
 	codebasePaths["portfolio"] = fmt.Sprintf(portfolioPath, path, path, path)
+	codebasePaths["risk"] = fmt.Sprintf(riskPath, path, path, path)
+	codebasePaths["analytics"] = fmt.Sprintf(analyticsPath, path, path, path)
+	codebasePaths["arbitrage"] = fmt.Sprintf(arbitragePath, path, path, path)
+	codebasePaths["basis"] = fmt.Sprintf(basisPath, path, path, path)
+	codebasePaths["dust"] = fmt.Sprintf(dustPath, path, path, path)
+	codebasePaths["execution"] = fmt.Sprintf(executionPath, path, path, path)
+	codebasePaths["hedge"] = fmt.Sprintf(hedgePath, path, path, path)
+	codebasePaths["liquidation"] = fmt.Sprintf(liquidationPath, path, path, path)
+	codebasePaths["margin"] = fmt.Sprintf(marginPath, path, path, path)
+	codebasePaths["statement"] = fmt.Sprintf(statementPath, path, path, path)
+	codebasePaths["sizing"] = fmt.Sprintf(sizingPath, path, path, path)
+	codebasePaths["strategy"] = fmt.Sprintf(strategyPath, path, path, path)
+	codebasePaths["strategy bridge"] = fmt.Sprintf(strategyBridgePath, path, path, path, path)
+	codebasePaths["strategy marketmaker"] = fmt.Sprintf(strategyMarketMakerPath, path, path, path, path)
+	codebasePaths["strategy rsi"] = fmt.Sprintf(strategyRSIPath, path, path, path, path)
 	codebasePaths["testdata"] = fmt.Sprintf(testdataPath, path, path, path)
 	codebasePaths["tools"] = fmt.Sprintf(toolsPath, path, path, path)
 	codebasePaths["web"] = fmt.Sprintf(webPath, path, path, path)
+	codebasePaths["withdraw"] = fmt.Sprintf(withdrawPath, path, path, path)
 	codebasePaths["root"] = fmt.Sprintf(rootPath, path, path)
 
 	codebasePaths["exchanges"] = fmt.Sprintf(exchangesPath, path, path, path)
+	codebasePaths["exchanges calendar"] = fmt.Sprintf(exchangesCalendarPath, path, path, path, path)
+	codebasePaths["exchanges fees"] = fmt.Sprintf(exchangesFeesPath, path, path, path, path)
 	codebasePaths["exchanges nonce"] = fmt.Sprintf(exchangesNoncePath, path, path, path, path)
+	codebasePaths["exchanges kline"] = fmt.Sprintf(exchangesKlinePath, path, path, path, path)
 	codebasePaths["exchanges orderbook"] = fmt.Sprintf(exchangesOrderbookPath, path, path, path, path)
 	codebasePaths["exchanges stats"] = fmt.Sprintf(exchangesStatsPath, path, path, path, path)
 	codebasePaths["exchanges ticker"] = fmt.Sprintf(exchangesTickerPath, path, path, path, path)
+	codebasePaths["exchanges trade"] = fmt.Sprintf(exchangesTradePath, path, path, path, path)
 	codebasePaths["exchanges orders"] = fmt.Sprintf(exchangesOrdersPath, path, path, path, path)
 	codebasePaths["exchanges request"] = fmt.Sprintf(exchangesRequestPath, path, path, path, path)
+	codebasePaths["exchanges conformance"] = fmt.Sprintf(exchangesConformancePath, path, path, path, path)
+	codebasePaths["exchanges abbo"] = fmt.Sprintf(exchangesABBOPath, path, path, path, path)
+	codebasePaths["exchanges quality"] = fmt.Sprintf(exchangesQualityPath, path, path, path, path)
+	codebasePaths["exchanges latency"] = fmt.Sprintf(exchangesLatencyPath, path, path, path, path)
+	codebasePaths["exchanges paper"] = fmt.Sprintf(exchangesPaperPath, path, path, path, path)
+	codebasePaths["exchanges assets"] = fmt.Sprintf(exchangesAssetsPath, path, path, path, path)
+	codebasePaths["gctrpc"] = fmt.Sprintf(gctrpcPath, path, path, path)
+	codebasePaths["db"] = fmt.Sprintf(dbPath, path, path, path)
+	codebasePaths["journal"] = fmt.Sprintf(journalPath, path, path, path)
 
 	codebasePaths["exchanges alphapoint"] = fmt.Sprintf(alphapoint, path, path, path, path)
 	codebasePaths["exchanges anx"] = fmt.Sprintf(anx, path, path, path, path)
@@ -232,8 +302,10 @@ func addPaths() {
 	codebasePaths["exchanges bittrex"] = fmt.Sprintf(bittrex, path, path, path, path)
 	codebasePaths["exchanges btcc"] = fmt.Sprintf(btcc, path, path, path, path)
 	codebasePaths["exchanges btcmarkets"] = fmt.Sprintf(btcmarkets, path, path, path, path)
+	codebasePaths["exchanges bybit"] = fmt.Sprintf(bybit, path, path, path, path)
 	codebasePaths["exchanges coinut"] = fmt.Sprintf(coinut, path, path, path, path)
 	codebasePaths["exchanges exmo"] = fmt.Sprintf(exmo, path, path, path, path)
+	codebasePaths["exchanges coinbase"] = fmt.Sprintf(coinbase, path, path, path, path)
 	codebasePaths["exchanges coinbasepro"] = fmt.Sprintf(coinbasepro, path, path, path, path)
 	codebasePaths["exchanges gateio"] = fmt.Sprintf(gateio, path, path, path, path)
 	codebasePaths["exchanges gemini"] = fmt.Sprintf(gemini, path, path, path, path)
@@ -242,12 +314,16 @@ func addPaths() {
 	codebasePaths["exchanges huobihadax"] = fmt.Sprintf(huobihadax, path, path, path, path)
 	codebasePaths["exchanges itbit"] = fmt.Sprintf(itbit, path, path, path, path)
 	codebasePaths["exchanges kraken"] = fmt.Sprintf(kraken, path, path, path, path)
+	codebasePaths["exchanges kucoin"] = fmt.Sprintf(kucoin, path, path, path, path)
 	codebasePaths["exchanges lakebtc"] = fmt.Sprintf(lakebtc, path, path, path, path)
 	codebasePaths["exchanges liqui"] = fmt.Sprintf(liqui, path, path, path, path)
 	codebasePaths["exchanges localbitcoins"] = fmt.Sprintf(localbitcoins, path, path, path, path)
+	codebasePaths["exchanges mexc"] = fmt.Sprintf(mexc, path, path, path, path)
 	codebasePaths["exchanges okcoin"] = fmt.Sprintf(okcoin, path, path, path, path)
 	codebasePaths["exchanges okex"] = fmt.Sprintf(okex, path, path, path, path)
+	codebasePaths["exchanges okx"] = fmt.Sprintf(okx, path, path, path, path)
 	codebasePaths["exchanges poloniex"] = fmt.Sprintf(poloniex, path, path, path, path)
+	codebasePaths["exchanges simulator"] = fmt.Sprintf(simulator, path, path, path, path)
 	codebasePaths["exchanges wex"] = fmt.Sprintf(wex, path, path, path, path)
 	codebasePaths["exchanges yobit"] = fmt.Sprintf(yobit, path, path, path, path)
 	codebasePaths["exchanges zb"] = fmt.Sprintf(zb, path, path, path, path)
@@ -301,18 +377,36 @@ func getslashFromName(packageName string) string {
 }
 
 var globS = []string{
+	fmt.Sprintf("availability_templates%s*", common.GetOSPathSlash()),
+	fmt.Sprintf("backtest_templates%s*", common.GetOSPathSlash()),
 	fmt.Sprintf("common_templates%s*", common.GetOSPathSlash()),
 	fmt.Sprintf("communications_templates%s*", common.GetOSPathSlash()),
 	fmt.Sprintf("config_templates%s*", common.GetOSPathSlash()),
 	fmt.Sprintf("currency_templates%s*", common.GetOSPathSlash()),
 	fmt.Sprintf("events_templates%s*", common.GetOSPathSlash()),
 	fmt.Sprintf("exchanges_templates%s*", common.GetOSPathSlash()),
+	fmt.Sprintf("gctrpc_templates%s*", common.GetOSPathSlash()),
+	fmt.Sprintf("db_templates%s*", common.GetOSPathSlash()),
+	fmt.Sprintf("journal_templates%s*", common.GetOSPathSlash()),
 	fmt.Sprintf("portfolio_templates%s*", common.GetOSPathSlash()),
+	fmt.Sprintf("risk_templates%s*", common.GetOSPathSlash()),
+	fmt.Sprintf("analytics_templates%s*", common.GetOSPathSlash()),
+	fmt.Sprintf("arbitrage_templates%s*", common.GetOSPathSlash()),
+	fmt.Sprintf("basis_templates%s*", common.GetOSPathSlash()),
+	fmt.Sprintf("dust_templates%s*", common.GetOSPathSlash()),
+	fmt.Sprintf("execution_templates%s*", common.GetOSPathSlash()),
+	fmt.Sprintf("hedge_templates%s*", common.GetOSPathSlash()),
+	fmt.Sprintf("liquidation_templates%s*", common.GetOSPathSlash()),
+	fmt.Sprintf("margin_templates%s*", common.GetOSPathSlash()),
+	fmt.Sprintf("statement_templates%s*", common.GetOSPathSlash()),
+	fmt.Sprintf("sizing_templates%s*", common.GetOSPathSlash()),
 	fmt.Sprintf("root_templates%s*", common.GetOSPathSlash()),
+	fmt.Sprintf("strategy_templates%s*", common.GetOSPathSlash()),
 	fmt.Sprintf("sub_templates%s*", common.GetOSPathSlash()),
 	fmt.Sprintf("testdata_templates%s*", common.GetOSPathSlash()),
 	fmt.Sprintf("tools_templates%s*", common.GetOSPathSlash()),
 	fmt.Sprintf("web_templates%s*", common.GetOSPathSlash()),
+	fmt.Sprintf("withdraw_templates%s*", common.GetOSPathSlash()),
 }
 
 // addTemplates adds all the template files
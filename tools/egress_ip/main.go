@@ -0,0 +1,75 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"strings"
+
+	"github.com/thrasher-/gocryptotrader/config"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+)
+
+func main() {
+	var inFile, exchangeName string
+
+	defaultCfg, err := config.GetFilePath("")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	flag.StringVar(&inFile, "infile", defaultCfg, "The config input file to process.")
+	flag.StringVar(&exchangeName, "exchange", "", "Only report the egress IP for this exchange.")
+	flag.Parse()
+
+	log.Println("GoCryptoTrader: egress IP tool.")
+
+	var cfg config.Config
+	err = cfg.LoadConfig(inFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Println("Loaded config file.")
+
+	for i := range cfg.Exchanges {
+		exch := cfg.Exchanges[i]
+		if !exch.Enabled {
+			continue
+		}
+		if exchangeName != "" && !strings.EqualFold(exch.Name, exchangeName) {
+			continue
+		}
+
+		// Build the exchange's HTTP client the same way the exchange Setup
+		// does so the proxy and local address are honoured
+		b := exchange.Base{Name: exch.Name}
+		timeout := exch.HTTPTimeout
+		if timeout <= 0 {
+			timeout = exchange.DefaultHTTPTimeout
+		}
+		b.SetHTTPClientTimeout(timeout)
+
+		err = b.SetClientProxyAddress(exch.ProxyAddress)
+		if err != nil {
+			log.Printf("%s: %s", exch.Name, err)
+			continue
+		}
+		err = b.SetLocalAddress(exch.LocalAddress)
+		if err != nil {
+			log.Printf("%s: %s", exch.Name, err)
+			continue
+		}
+
+		local := "default"
+		if b.GetLocalAddress() != nil {
+			local = b.GetLocalAddress().String()
+		}
+
+		ip, err := b.GetEgressIP()
+		if err != nil {
+			log.Printf("%s: local address %s, unable to determine egress IP: %s",
+				exch.Name, local, err)
+			continue
+		}
+		log.Printf("%s: local address %s, egress IP %s", exch.Name, local, ip)
+	}
+}
@@ -8,7 +8,7 @@ import (
 	"github.com/thrasher-/gocryptotrader/common"
 	"github.com/thrasher-/gocryptotrader/config"
 	"github.com/thrasher-/gocryptotrader/currency"
-	"github.com/thrasher-/gocryptotrader/currency/symbol"
+	"github.com/thrasher-/gocryptotrader/currency/locale"
 	"github.com/thrasher-/gocryptotrader/exchanges/bitfinex"
 	"github.com/thrasher-/gocryptotrader/portfolio"
 )
@@ -16,24 +16,20 @@ import (
 var (
 	priceMap        map[string]float64
 	displayCurrency string
+	displayLocale   locale.Locale
 )
 
 func printSummary(msg string, amount float64) {
 	log.Println()
-	log.Println(fmt.Sprintf("%s in USD: $%.2f", msg, amount))
+	log.Println(fmt.Sprintf("%s in USD: %s", msg, displayLocale.FormatFiat(amount, "USD")))
 
 	if displayCurrency != "USD" {
 		conv, err := currency.ConvertCurrency(amount, "USD", displayCurrency)
 		if err != nil {
 			log.Println(err)
 		} else {
-			symb, err := symbol.GetSymbolByCurrencyName(displayCurrency)
-			if err != nil {
-				log.Println(fmt.Sprintf("%s in %s: %.2f", msg, displayCurrency, conv))
-			} else {
-				log.Println(fmt.Sprintf("%s in %s: %s%.2f", msg, displayCurrency, symb, conv))
-			}
-
+			log.Println(fmt.Sprintf("%s in %s: %s", msg, displayCurrency,
+				displayLocale.FormatFiat(conv, displayCurrency)))
 		}
 	}
 	log.Println()
@@ -44,8 +40,8 @@ func getOnlineOfflinePortfolio(coins []portfolio.Coin, online bool) {
 	for _, x := range coins {
 		value := priceMap[x.Coin] * x.Balance
 		totals += value
-		log.Printf("\t%v %v Subtotal: $%.2f Coin percentage: %.2f%%\n", x.Coin,
-			x.Balance, value, x.Percentage)
+		log.Printf("\t%v %v Subtotal: %s Coin percentage: %.2f%%\n", x.Coin,
+			x.Balance, displayLocale.FormatFiat(value, "USD"), x.Percentage)
 	}
 	if !online {
 		printSummary("\tOffline balance", totals)
@@ -75,7 +71,8 @@ func main() {
 	}
 	log.Println("Loaded config file.")
 
-	displayCurrency = cfg.FiatDisplayCurrency
+	displayCurrency = cfg.Currency.FiatDisplayCurrency
+	displayLocale = cfg.GetDisplayLocale()
 	port := portfolio.Base{}
 	port.SeedPortfolio(cfg.Portfolio)
 	result := port.GetPortfolioSummary()
@@ -142,7 +139,9 @@ func main() {
 	log.Println()
 	log.Println("PORTFOLIO TOTALS:")
 	for x, y := range portfolioMap {
-		log.Printf("\t%s Amount: %f Subtotal: $%.2f USD (1 %s = $%.2f USD). Percentage of portfolio %.3f%%", x, y.Balance, y.Subtotal, x, y.Subtotal/y.Balance, y.Subtotal/total*100/1)
+		log.Printf("\t%s Amount: %f Subtotal: %s USD (1 %s = %s USD). Percentage of portfolio %.3f%%",
+			x, y.Balance, displayLocale.FormatFiat(y.Subtotal, "USD"), x,
+			displayLocale.FormatPrice(y.Subtotal/y.Balance, "USD"), y.Subtotal/total*100/1)
 	}
 	printSummary("\tTotal balance", total)
 
@@ -160,8 +159,8 @@ func main() {
 		for z := range y {
 			value := priceMap[x] * y[z].Balance
 			totals += value
-			log.Printf("\t %s Amount: %f Subtotal: $%.2f Coin percentage: %.2f%%\n",
-				y[z].Address, y[z].Balance, value, y[z].Percentage)
+			log.Printf("\t %s Amount: %f Subtotal: %s Coin percentage: %.2f%%\n",
+				y[z].Address, y[z].Balance, displayLocale.FormatFiat(value, "USD"), y[z].Percentage)
 		}
 		printSummary(fmt.Sprintf("\t %s balance", x), totals)
 	}
@@ -173,8 +172,8 @@ func main() {
 		for z, w := range y {
 			value := priceMap[z] * w.Balance
 			totals += value
-			log.Printf("\t %s Amount: %f Subtotal $%.2f Coin percentage: %.2f%%",
-				z, w.Balance, value, w.Percentage)
+			log.Printf("\t %s Amount: %f Subtotal %s Coin percentage: %.2f%%",
+				z, w.Balance, displayLocale.FormatFiat(value, "USD"), w.Percentage)
 		}
 		printSummary("\t Exchange balance", totals)
 	}
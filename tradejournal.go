@@ -0,0 +1,105 @@
+package main
+
+import (
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/exchanges/fees"
+	"github.com/thrasher-/gocryptotrader/journal"
+)
+
+// errJournalClosed is returned when the trade journal could not be opened
+var errJournalClosed = errors.New("trade journal not open")
+
+// SetupJournal opens the trade journal in the data directory and starts a new
+// session for this run of the bot
+func SetupJournal() {
+	var err error
+	bot.journal, err = journal.Open(bot.dataDir + common.GetOSPathSlash() + journal.JournalFile)
+	if err != nil {
+		log.Printf("Failed to open trade journal. Err: %s", err)
+		return
+	}
+	s, err := bot.journal.StartSession("")
+	if err != nil {
+		log.Printf("Failed to start trade journal session. Err: %s", err)
+		return
+	}
+	log.Printf("Trade journal session %s started.\n", s.ID)
+	fees.SetVolumeSource(journalVolume)
+}
+
+// journalVolume returns an exchange's trading volume over the fee volume
+// period from the fills in the trade journal of the pairs quoted in currency
+func journalVolume(exchName, currency string) (float64, error) {
+	if bot.journal == nil {
+		return 0, errJournalClosed
+	}
+	now := time.Now()
+	_, entries := bot.journal.Period(now.Add(-fees.VolumePeriod), now)
+	currency = common.StringToUpper(currency)
+	var volume float64
+	for i := range entries {
+		e := &entries[i]
+		if e.Kind != journal.KindFill || !strings.EqualFold(e.Exchange, exchName) ||
+			!strings.HasSuffix(common.StringToUpper(e.Pair), currency) {
+			continue
+		}
+		volume += e.Price * e.Amount
+	}
+	return volume, nil
+}
+
+// journalOrder records an order placed by the bot to the trade journal,
+// tagged with the strategy which placed it
+func journalOrder(o ManagedOrder) {
+	if bot.journal == nil {
+		return
+	}
+	_, err := bot.journal.Record(journal.Entry{
+		Kind:     journal.KindOrder,
+		Exchange: o.Exchange,
+		OrderID:  o.ID,
+		Pair:     o.BaseCurrency + o.QuoteCurrency,
+		Side:     o.OrderSide,
+		Price:    o.Price,
+		Amount:   o.Amount,
+		Tags:     strategyTags(o.Strategy),
+	})
+	if err != nil {
+		log.Printf("Failed to journal %s order %s. Err: %s", o.Exchange, o.ID, err)
+	}
+}
+
+// journalFill records the amount an order executed since the previous poll
+// to the trade journal
+func journalFill(e OrderEvent) {
+	if bot.journal == nil || e.FillAmount <= 0 {
+		return
+	}
+	_, err := bot.journal.Record(journal.Entry{
+		Kind:     journal.KindFill,
+		Exchange: e.Order.Exchange,
+		OrderID:  e.Order.ID,
+		Pair:     e.Order.BaseCurrency + e.Order.QuoteCurrency,
+		Side:     e.Order.OrderSide,
+		Price:    e.FillPrice,
+		Amount:   e.FillAmount,
+		Tags:     strategyTags(e.Order.Strategy),
+	})
+	if err != nil {
+		log.Printf("Failed to journal %s fill of order %s. Err: %s",
+			e.Order.Exchange, e.Order.ID, err)
+	}
+}
+
+// strategyTags returns the journal tags of a strategy
+func strategyTags(strategy string) []string {
+	if strategy == "" {
+		return nil
+	}
+	return []string{strategy}
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/exchanges/fees"
+	"github.com/thrasher-/gocryptotrader/journal"
+)
+
+func TestJournalVolume(t *testing.T) {
+	if _, err := journalVolume("Huobi", "USDT"); err != errJournalClosed {
+		t.Error("Test failed. journalVolume expected journal closed error", err)
+	}
+
+	bot.journal = journal.New()
+	defer func() { bot.journal = nil }()
+	if _, err := bot.journal.StartSession(""); err != nil {
+		t.Fatal("Test failed. StartSession() error", err)
+	}
+	now := time.Now()
+	for _, e := range []journal.Entry{
+		{Kind: journal.KindFill, Time: now.Add(-time.Hour), Exchange: "Huobi", Pair: "BTCUSDT", Price: 6000, Amount: 0.5},
+		{Kind: journal.KindFill, Time: now.Add(-24 * time.Hour), Exchange: "huobi", Pair: "ETHUSDT", Price: 200, Amount: 10},
+		{Kind: journal.KindFill, Time: now.Add(-fees.VolumePeriod - time.Hour), Exchange: "Huobi", Pair: "BTCUSDT", Price: 6000, Amount: 1},
+		{Kind: journal.KindFill, Time: now.Add(-time.Hour), Exchange: "Huobi", Pair: "ETHBTC", Price: 0.03, Amount: 10},
+		{Kind: journal.KindFill, Time: now.Add(-time.Hour), Exchange: "Bithumb", Pair: "BTCUSDT", Price: 6000, Amount: 1},
+		{Kind: journal.KindOrder, Time: now.Add(-time.Hour), Exchange: "Huobi", Pair: "BTCUSDT", Price: 6000, Amount: 1},
+	} {
+		if _, err := bot.journal.Record(e); err != nil {
+			t.Fatal("Test failed. Record() error", err)
+		}
+	}
+
+	volume, err := journalVolume("Huobi", "usdt")
+	if err != nil || volume != 5000 {
+		t.Error("Test failed. journalVolume unexpected volume", volume, err)
+	}
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/analytics"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	"github.com/thrasher-/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-/gocryptotrader/exchanges/ticker"
+)
+
+// Volatility request defaults
+const (
+	defaultVolatilityInterval = kline.OneHour
+	defaultVolatilityWindow   = 24
+	// defaultVolatilityPoints is the number of rolling volatility values
+	// returned when no start time is requested
+	defaultVolatilityPoints = 24
+)
+
+// VolatilityRequest selects the candles and estimator of a realized
+// volatility calculation. Window is the number of candles per value.
+type VolatilityRequest struct {
+	Exchange  string
+	Pair      pair.CurrencyPair
+	AssetType string
+	Interval  kline.Interval
+	Window    int
+	Estimator string
+	Start     time.Time
+	End       time.Time
+}
+
+// VolatilityResponse holds the rolling realized volatility of a currency pair
+type VolatilityResponse struct {
+	Exchange  string                 `json:"exchange"`
+	Pair      string                 `json:"pair"`
+	Estimator string                 `json:"estimator"`
+	Interval  string                 `json:"interval"`
+	Window    int                    `json:"window"`
+	Latest    float64                `json:"latest"`
+	Series    []analytics.Volatility `json:"series"`
+}
+
+// GetVolatility returns the rolling realized volatility of an exchange
+// currency pair from its historic candles
+func GetVolatility(req VolatilityRequest) (VolatilityResponse, error) {
+	exch := GetExchangeByName(req.Exchange)
+	if exch == nil {
+		return VolatilityResponse{}, ErrExchangeNotFound
+	}
+	if req.AssetType == "" {
+		req.AssetType = ticker.Spot
+	}
+	candles, err := exch.GetHistoricCandles(req.Pair, req.AssetType, req.Interval,
+		req.Start, req.End)
+	if err != nil {
+		return VolatilityResponse{}, err
+	}
+	series, err := analytics.RollingVolatility(req.Estimator, candles, req.Interval, req.Window)
+	if err != nil {
+		return VolatilityResponse{}, err
+	}
+	return VolatilityResponse{
+		Exchange:  exch.GetName(),
+		Pair:      req.Pair.Pair().String(),
+		Estimator: req.Estimator,
+		Interval:  req.Interval.Short(),
+		Window:    req.Window,
+		Latest:    series[len(series)-1].Volatility,
+		Series:    series,
+	}, nil
+}
+
+// volatilityRequest parses the interval, window, estimator and time range
+// query parameters of a volatility request
+func volatilityRequest(r *http.Request) (VolatilityRequest, error) {
+	query := r.URL.Query()
+	req := VolatilityRequest{
+		Interval:  defaultVolatilityInterval,
+		Window:    defaultVolatilityWindow,
+		Estimator: analytics.CloseToClose,
+		End:       time.Now(),
+	}
+	if v := query.Get("interval"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return req, err
+		}
+		req.Interval = kline.Interval(d)
+	}
+	if v := query.Get("window"); v != "" {
+		window, err := strconv.Atoi(v)
+		if err != nil {
+			return req, err
+		}
+		req.Window = window
+	}
+	if v := query.Get("estimator"); v != "" {
+		req.Estimator = v
+	}
+	if v := query.Get("end"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return req, err
+		}
+		req.End = t
+	}
+	if v := query.Get("start"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return req, err
+		}
+		req.Start = t
+	} else {
+		candles := time.Duration(req.Window + defaultVolatilityPoints - 1)
+		req.Start = req.End.Add(-req.Interval.Duration() * candles)
+	}
+	req.AssetType = query.Get("assetType")
+	return req, nil
+}
@@ -0,0 +1,430 @@
+package withdraw
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	"github.com/thrasher-/gocryptotrader/db"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	// DailyWindow and MonthlyWindow are the rolling windows the daily and
+	// monthly limits of an address apply to
+	DailyWindow   = 24 * time.Hour
+	MonthlyWindow = 30 * 24 * time.Hour
+
+	saltLength = 16
+)
+
+// Errors returned by the address book
+var (
+	ErrAddressBookKeyEmpty  = errors.New("address book encryption key is empty")
+	ErrInvalidAddress       = errors.New("address book entry requires an exchange, currency and address with limits which are not negative")
+	ErrAddressNotFound      = errors.New("address book entry not found")
+	ErrAddressExists        = errors.New("address is already in the address book")
+	ErrAddressNotListed     = errors.New("withdrawal address is not in the address book")
+	ErrAddressLocked        = errors.New("withdrawal address is locked")
+	ErrAddressUnverified    = errors.New("withdrawal address is not verified")
+	ErrDailyLimitExceeded   = errors.New("withdrawal exceeds the daily limit of the address")
+	ErrMonthlyLimitExceeded = errors.New("withdrawal exceeds the monthly limit of the address")
+	ErrCiphertextInvalid    = errors.New("address book entry ciphertext is too short")
+)
+
+// Address is an address book entry, a withdrawal destination of a currency
+// on an exchange. Chain is the network withdrawn on where the currency is
+// available on several. Limits are in the currency, zero is unlimited.
+// Withdrawals are only authorised to verified addresses which are not locked.
+type Address struct {
+	ID           int64             `json:"id"`
+	Label        string            `json:"label"`
+	Exchange     string            `json:"exchange"`
+	Currency     pair.CurrencyItem `json:"currency"`
+	Chain        string            `json:"chain"`
+	Address      string            `json:"address"`
+	DailyLimit   float64           `json:"dailyLimit"`
+	MonthlyLimit float64           `json:"monthlyLimit"`
+	Locked       bool              `json:"locked"`
+	Verified     bool              `json:"verified"`
+	Added        time.Time         `json:"added"`
+}
+
+// matches returns whether the entry is the destination of a withdrawal
+func (a *Address) matches(exchName string, currency pair.CurrencyItem, address string) bool {
+	return strings.EqualFold(a.Exchange, exchName) &&
+		strings.EqualFold(currency.String(), a.Currency.String()) &&
+		a.Address == address
+}
+
+// usage is an amount withdrawn to an address
+type usage struct {
+	exchange string
+	currency pair.CurrencyItem
+	address  string
+	amount   float64
+	time     time.Time
+}
+
+// AddressBook holds the withdrawal addresses and the amounts withdrawn to
+// them. Entries are encrypted with AES-GCM before they are stored, under a key
+// derived from the passphrase, and the book is kept in memory only when the
+// store is nil.
+type AddressBook struct {
+	store      db.WithdrawalAddressRepository
+	passphrase []byte
+	salt       []byte
+	keys       map[string][]byte
+
+	entries []Address
+	usage   []usage
+	nextID  int64
+	m       sync.Mutex
+}
+
+// NewAddressBook returns an address book loaded from the store
+func NewAddressBook(store db.WithdrawalAddressRepository, passphrase string) (*AddressBook, error) {
+	if passphrase == "" {
+		return nil, ErrAddressBookKeyEmpty
+	}
+	salt := make([]byte, saltLength)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, err
+	}
+	b := &AddressBook{
+		store:      store,
+		passphrase: []byte(passphrase),
+		salt:       salt,
+		keys:       make(map[string][]byte),
+	}
+	if store == nil {
+		return b, nil
+	}
+
+	stored, err := store.WithdrawalAddresses()
+	if err != nil {
+		return nil, err
+	}
+	for i := range stored {
+		var a Address
+		if err = b.decrypt(stored[i].Data, &a); err != nil {
+			return nil, fmt.Errorf("address book entry %d: %s", stored[i].ID, err)
+		}
+		b.entries = append(b.entries, a)
+		if a.ID > b.nextID {
+			b.nextID = a.ID
+		}
+	}
+	return b, nil
+}
+
+// Add adds an unverified entry and returns it with its ID, the added time is
+// set to now if not supplied
+func (b *AddressBook) Add(a Address) (Address, error) {
+	if err := validAddress(&a); err != nil {
+		return Address{}, err
+	}
+	if a.Added.IsZero() {
+		a.Added = time.Now()
+	}
+	a.Verified = false
+
+	b.m.Lock()
+	defer b.m.Unlock()
+	if b.find(a.Exchange, a.Currency, a.Address) >= 0 {
+		return Address{}, ErrAddressExists
+	}
+	a.ID = b.nextID + 1
+	if err := b.save(&a); err != nil {
+		return Address{}, err
+	}
+	b.nextID = a.ID
+	b.entries = append(b.entries, a)
+	return a, nil
+}
+
+// Update replaces the label, destination and limits of an entry. Its locked
+// and verified flags are kept, except that a changed destination must be
+// verified again.
+func (b *AddressBook) Update(a Address) (Address, error) {
+	if err := validAddress(&a); err != nil {
+		return Address{}, err
+	}
+
+	b.m.Lock()
+	defer b.m.Unlock()
+	x := b.index(a.ID)
+	if x < 0 {
+		return Address{}, ErrAddressNotFound
+	}
+	if y := b.find(a.Exchange, a.Currency, a.Address); y >= 0 && y != x {
+		return Address{}, ErrAddressExists
+	}
+	old := b.entries[x]
+	a.Added = old.Added
+	a.Locked = old.Locked
+	a.Verified = old.Verified && old.matches(a.Exchange, a.Currency, a.Address) && old.Chain == a.Chain
+	if err := b.save(&a); err != nil {
+		return Address{}, err
+	}
+	b.entries[x] = a
+	return a, nil
+}
+
+// Remove removes an entry
+func (b *AddressBook) Remove(id int64) error {
+	b.m.Lock()
+	defer b.m.Unlock()
+	x := b.index(id)
+	if x < 0 {
+		return ErrAddressNotFound
+	}
+	if b.store != nil {
+		if err := b.store.DeleteWithdrawalAddress(id); err != nil {
+			return err
+		}
+	}
+	b.entries = append(b.entries[:x], b.entries[x+1:]...)
+	return nil
+}
+
+// Lock sets whether withdrawals to an entry are blocked
+func (b *AddressBook) Lock(id int64, locked bool) (Address, error) {
+	return b.modify(id, func(a *Address) { a.Locked = locked })
+}
+
+// Verify sets whether an entry has been verified as a destination owned by
+// the user
+func (b *AddressBook) Verify(id int64, verified bool) (Address, error) {
+	return b.modify(id, func(a *Address) { a.Verified = verified })
+}
+
+// Get returns an entry by ID
+func (b *AddressBook) Get(id int64) (Address, error) {
+	b.m.Lock()
+	defer b.m.Unlock()
+	x := b.index(id)
+	if x < 0 {
+		return Address{}, ErrAddressNotFound
+	}
+	return b.entries[x], nil
+}
+
+// List returns a copy of the entries in ID order
+func (b *AddressBook) List() []Address {
+	b.m.Lock()
+	defer b.m.Unlock()
+	entries := make([]Address, len(b.entries))
+	copy(entries, b.entries)
+	sort.Slice(entries, func(i, j int) bool { return entries[i].ID < entries[j].ID })
+	return entries
+}
+
+// Usage returns the amounts withdrawn to an entry within the daily and
+// monthly windows ending at now
+func (b *AddressBook) Usage(id int64, now time.Time) (daily, monthly float64, err error) {
+	b.m.Lock()
+	defer b.m.Unlock()
+	x := b.index(id)
+	if x < 0 {
+		return 0, 0, ErrAddressNotFound
+	}
+	daily, monthly = b.used(&b.entries[x], now)
+	return daily, monthly, nil
+}
+
+// Authorise returns an error unless amount may be withdrawn to an address at
+// now, which requires a verified and unlocked entry with enough of its daily
+// and monthly limits remaining
+func (b *AddressBook) Authorise(exchName string, currency pair.CurrencyItem, address string, amount float64, now time.Time) error {
+	b.m.Lock()
+	defer b.m.Unlock()
+	x := b.find(exchName, currency, address)
+	if x < 0 {
+		return ErrAddressNotListed
+	}
+	a := &b.entries[x]
+	if a.Locked {
+		return ErrAddressLocked
+	}
+	if !a.Verified {
+		return ErrAddressUnverified
+	}
+	daily, monthly := b.used(a, now)
+	if a.DailyLimit > 0 && daily+amount > a.DailyLimit {
+		return fmt.Errorf("%s: %v of %v %s remaining", ErrDailyLimitExceeded,
+			a.DailyLimit-daily, a.DailyLimit, a.Currency)
+	}
+	if a.MonthlyLimit > 0 && monthly+amount > a.MonthlyLimit {
+		return fmt.Errorf("%s: %v of %v %s remaining", ErrMonthlyLimitExceeded,
+			a.MonthlyLimit-monthly, a.MonthlyLimit, a.Currency)
+	}
+	return nil
+}
+
+// Record records an amount withdrawn to an address at a time, counting it
+// against the limits of the address. Usage older than the monthly window is
+// discarded.
+func (b *AddressBook) Record(exchName string, currency pair.CurrencyItem, address string, amount float64, when time.Time) {
+	b.m.Lock()
+	defer b.m.Unlock()
+	cutoff := when.Add(-MonthlyWindow)
+	kept := b.usage[:0]
+	for i := range b.usage {
+		if b.usage[i].time.After(cutoff) {
+			kept = append(kept, b.usage[i])
+		}
+	}
+	b.usage = append(kept, usage{
+		exchange: exchName,
+		currency: currency,
+		address:  address,
+		amount:   amount,
+		time:     when,
+	})
+}
+
+// modify applies a change to an entry and stores it
+func (b *AddressBook) modify(id int64, change func(a *Address)) (Address, error) {
+	b.m.Lock()
+	defer b.m.Unlock()
+	x := b.index(id)
+	if x < 0 {
+		return Address{}, ErrAddressNotFound
+	}
+	a := b.entries[x]
+	change(&a)
+	if err := b.save(&a); err != nil {
+		return Address{}, err
+	}
+	b.entries[x] = a
+	return a, nil
+}
+
+// used returns the amounts withdrawn to an entry within the daily and monthly
+// windows ending at now. The mutex must be held by the caller.
+func (b *AddressBook) used(a *Address, now time.Time) (daily, monthly float64) {
+	for i := range b.usage {
+		u := &b.usage[i]
+		if !a.matches(u.exchange, u.currency, u.address) || u.time.After(now) {
+			continue
+		}
+		age := now.Sub(u.time)
+		if age < MonthlyWindow {
+			monthly += u.amount
+		}
+		if age < DailyWindow {
+			daily += u.amount
+		}
+	}
+	return daily, monthly
+}
+
+// index returns the index of an entry by ID or -1
+func (b *AddressBook) index(id int64) int {
+	for i := range b.entries {
+		if b.entries[i].ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// find returns the index of the entry of a destination or -1
+func (b *AddressBook) find(exchName string, currency pair.CurrencyItem, address string) int {
+	for i := range b.entries {
+		if b.entries[i].matches(exchName, currency, address) {
+			return i
+		}
+	}
+	return -1
+}
+
+// save encrypts and stores an entry
+func (b *AddressBook) save(a *Address) error {
+	if b.store == nil {
+		return nil
+	}
+	data, err := b.encrypt(a)
+	if err != nil {
+		return err
+	}
+	return b.store.UpsertWithdrawalAddress(db.WithdrawalAddress{ID: a.ID, Data: data})
+}
+
+// encrypt returns an entry sealed with AES-GCM, prefixed with the salt of its
+// key and the nonce
+func (b *AddressBook) encrypt(a *Address) ([]byte, error) {
+	plaintext, err := common.JSONEncode(a)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := b.cipher(b.salt)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	data := append(append([]byte{}, b.salt...), nonce...)
+	return gcm.Seal(data, nonce, plaintext, nil), nil
+}
+
+// decrypt opens an entry sealed by encrypt
+func (b *AddressBook) decrypt(data []byte, a *Address) error {
+	if len(data) < saltLength {
+		return ErrCiphertextInvalid
+	}
+	gcm, err := b.cipher(data[:saltLength])
+	if err != nil {
+		return err
+	}
+	data = data[saltLength:]
+	if len(data) < gcm.NonceSize() {
+		return ErrCiphertextInvalid
+	}
+	plaintext, err := gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], nil)
+	if err != nil {
+		return err
+	}
+	return common.JSONDecode(plaintext, a)
+}
+
+// cipher returns the AES-GCM cipher of the key derived with a salt, derived
+// keys are cached as scrypt is deliberately slow
+func (b *AddressBook) cipher(salt []byte) (cipher.AEAD, error) {
+	key, ok := b.keys[string(salt)]
+	if !ok {
+		var err error
+		key, err = scrypt.Key(b.passphrase, salt, 32768, 8, 1, 32)
+		if err != nil {
+			return nil, err
+		}
+		b.keys[string(salt)] = key
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// validAddress checks an entry and normalises its currency
+func validAddress(a *Address) error {
+	if a.Exchange == "" || a.Currency == "" || a.Address == "" ||
+		a.DailyLimit < 0 || a.MonthlyLimit < 0 {
+		return ErrInvalidAddress
+	}
+	a.Currency = pair.CurrencyItem(strings.ToUpper(a.Currency.String()))
+	return nil
+}
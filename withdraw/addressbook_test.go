@@ -0,0 +1,197 @@
+package withdraw
+
+import (
+	"bytes"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/db"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+)
+
+type testAddressStore struct {
+	addresses map[int64]db.WithdrawalAddress
+}
+
+func (t *testAddressStore) UpsertWithdrawalAddress(a db.WithdrawalAddress) error {
+	t.addresses[a.ID] = a
+	return nil
+}
+
+func (t *testAddressStore) WithdrawalAddresses() ([]db.WithdrawalAddress, error) {
+	var result []db.WithdrawalAddress
+	for _, a := range t.addresses {
+		result = append(result, a)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result, nil
+}
+
+func (t *testAddressStore) DeleteWithdrawalAddress(id int64) error {
+	delete(t.addresses, id)
+	return nil
+}
+
+func TestAddressBook(t *testing.T) {
+	if _, err := NewAddressBook(nil, ""); err != ErrAddressBookKeyEmpty {
+		t.Error("Test Failed - NewAddressBook() expected empty key error", err)
+	}
+
+	store := &testAddressStore{addresses: make(map[int64]db.WithdrawalAddress)}
+	b, err := NewAddressBook(store, "hunter2")
+	if err != nil {
+		t.Fatal("Test Failed - NewAddressBook() error", err)
+	}
+	if _, err = b.Add(Address{Exchange: "Binance", Currency: "BTC"}); err != ErrInvalidAddress {
+		t.Error("Test Failed - Add() expected invalid address error", err)
+	}
+
+	a, err := b.Add(Address{Label: "cold wallet", Exchange: "Binance", Currency: "btc",
+		Chain: "BTC", Address: "bc1qcold", DailyLimit: 1, MonthlyLimit: 5, Verified: true})
+	if err != nil {
+		t.Fatal("Test Failed - Add() error", err)
+	}
+	if a.ID != 1 || a.Verified || a.Currency != "BTC" || a.Added.IsZero() {
+		t.Errorf("Test Failed - Add() expected unverified entry 1 received %+v", a)
+	}
+	if _, err = b.Add(Address{Exchange: "binance", Currency: "BTC", Address: "bc1qcold"}); err != ErrAddressExists {
+		t.Error("Test Failed - Add() expected duplicate address error", err)
+	}
+	if bytes.Contains(store.addresses[1].Data, []byte("bc1qcold")) {
+		t.Error("Test Failed - Add() expected stored entry encrypted")
+	}
+
+	if a, err = b.Verify(a.ID, true); err != nil || !a.Verified {
+		t.Error("Test Failed - Verify() error", a, err)
+	}
+	a.Label = "cold storage"
+	if a, err = b.Update(a); err != nil || !a.Verified || a.Label != "cold storage" {
+		t.Error("Test Failed - Update() expected label changed and verification kept", a, err)
+	}
+	a.Address = "bc1qnew"
+	if a, err = b.Update(a); err != nil || a.Verified {
+		t.Error("Test Failed - Update() expected changed address to require verification", a, err)
+	}
+	if _, err = b.Lock(a.ID, true); err != nil {
+		t.Error("Test Failed - Lock() error", err)
+	}
+
+	loaded, err := NewAddressBook(store, "hunter2")
+	if err != nil {
+		t.Fatal("Test Failed - NewAddressBook() loading error", err)
+	}
+	entries := loaded.List()
+	if len(entries) != 1 || entries[0].Address != "bc1qnew" || !entries[0].Locked ||
+		entries[0].Label != "cold storage" {
+		t.Errorf("Test Failed - NewAddressBook() unexpected loaded entries %+v", entries)
+	}
+	if a, err = loaded.Add(Address{Exchange: "Kraken", Currency: "ETH", Address: "0xabc"}); err != nil || a.ID != 2 {
+		t.Error("Test Failed - Add() expected IDs to continue after loaded entries", a, err)
+	}
+	if _, err = NewAddressBook(store, "wrong"); err == nil {
+		t.Error("Test Failed - NewAddressBook() expected wrong key error")
+	}
+
+	if err = loaded.Remove(1); err != nil {
+		t.Error("Test Failed - Remove() error", err)
+	}
+	if _, err = loaded.Get(1); err != ErrAddressNotFound {
+		t.Error("Test Failed - Get() expected removed entry not found", err)
+	}
+	if _, ok := store.addresses[1]; ok {
+		t.Error("Test Failed - Remove() expected stored entry deleted")
+	}
+}
+
+func TestAuthorise(t *testing.T) {
+	b, err := NewAddressBook(nil, "hunter2")
+	if err != nil {
+		t.Fatal("Test Failed - NewAddressBook() error", err)
+	}
+	now := time.Date(2018, 7, 31, 0, 0, 0, 0, time.UTC)
+	if err = b.Authorise("Binance", "BTC", "addr1", 1, now); err != ErrAddressNotListed {
+		t.Error("Test Failed - Authorise() expected unlisted address error", err)
+	}
+
+	a, err := b.Add(Address{Exchange: "Binance", Currency: "BTC", Address: "addr1", DailyLimit: 1, MonthlyLimit: 2})
+	if err != nil {
+		t.Fatal("Test Failed - Add() error", err)
+	}
+	if err = b.Authorise("binance", "btc", "addr1", 0.5, now); err != ErrAddressUnverified {
+		t.Error("Test Failed - Authorise() expected unverified address error", err)
+	}
+	b.Verify(a.ID, true)
+	b.Lock(a.ID, true)
+	if err = b.Authorise("Binance", "BTC", "addr1", 0.5, now); err != ErrAddressLocked {
+		t.Error("Test Failed - Authorise() expected locked address error", err)
+	}
+	b.Lock(a.ID, false)
+	if err = b.Authorise("Binance", "BTC", "addr1", 0.5, now); err != nil {
+		t.Error("Test Failed - Authorise() error", err)
+	}
+
+	b.Record("Binance", "BTC", "addr1", 0.75, now.Add(-time.Hour))
+	b.Record("Binance", "BTC", "addr1", 0.5, now.Add(-10*DailyWindow))
+	b.Record("Binance", "BTC", "addr1", 5, now.Add(-MonthlyWindow-time.Hour))
+	if daily, monthly, _ := b.Usage(a.ID, now); daily != 0.75 || monthly != 1.25 {
+		t.Error("Test Failed - Usage() unexpected usage", daily, monthly)
+	}
+	if err = b.Authorise("Binance", "BTC", "addr1", 0.5, now); err == nil {
+		t.Error("Test Failed - Authorise() expected daily limit error")
+	}
+	if err = b.Authorise("Binance", "BTC", "addr1", 0.25, now.Add(DailyWindow)); err != nil {
+		t.Error("Test Failed - Authorise() expected daily limit to roll", err)
+	}
+	if err = b.Authorise("Binance", "BTC", "addr1", 0.8, now.Add(DailyWindow)); err == nil {
+		t.Error("Test Failed - Authorise() expected monthly limit error")
+	}
+}
+
+func TestManagerAddressBook(t *testing.T) {
+	b, err := NewAddressBook(nil, "hunter2")
+	if err != nil {
+		t.Fatal("Test Failed - NewAddressBook() error", err)
+	}
+	a, _ := b.Add(Address{Exchange: "Binance", Currency: "BTC", Address: "addr1", DailyLimit: 1})
+	b.Verify(a.ID, true)
+
+	exch := &testExchange{}
+	approvals := NewApprovals(ApprovalConfig{}, nil, func(string) exchange.IBotExchange { return exch })
+	approvals.Addresses = b
+	m, err := NewManager(Config{}, approvals)
+	if err != nil {
+		t.Fatal("Test Failed - NewManager() error", err)
+	}
+	if _, err = m.Queue(Request{Exchange: "Binance", Currency: "BTC", Address: "addr2", Amount: 0.1}); err != ErrAddressNotWhitelisted {
+		t.Error("Test Failed - Queue() expected unlisted address error", err)
+	}
+	if _, err = m.Queue(Request{Exchange: "Binance", Currency: "BTC", Address: "addr1", Amount: 0.6, Urgent: true}); err != nil {
+		t.Fatal("Test Failed - Queue() error", err)
+	}
+	if _, err = m.Queue(Request{Exchange: "Binance", Currency: "BTC", Address: "addr1", Amount: 0.6}); err == nil {
+		t.Error("Test Failed - Queue() expected queued amounts counted against the daily limit")
+	}
+
+	now := time.Now()
+	results := m.Process(now)
+	if len(results) != 1 || results[0].Err != nil || len(exch.withdrawals) != 0 {
+		t.Fatalf("Test Failed - Process() unexpected results %v", results)
+	}
+	if _, err = approvals.Approve(results[0].Withdrawal.ID, now); err != nil || len(exch.withdrawals) != 1 {
+		t.Fatal("Test Failed - Approve() error", err)
+	}
+	if daily, _, _ := b.Usage(a.ID, now); daily != 0.6 {
+		t.Error("Test Failed - Approve() expected withdrawal recorded", daily)
+	}
+
+	// Entries locked after being queued are denied approval
+	if _, err = m.Queue(Request{Exchange: "Binance", Currency: "BTC", Address: "addr1", Amount: 0.2, Urgent: true}); err != nil {
+		t.Fatal("Test Failed - Queue() error", err)
+	}
+	b.Lock(a.ID, true)
+	results = m.Process(now)
+	if len(results) != 1 || results[0].Err != ErrAddressLocked || len(exch.withdrawals) != 1 || len(m.Pending()) != 0 {
+		t.Errorf("Test Failed - Process() expected locked address denied %v", results)
+	}
+}
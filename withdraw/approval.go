@@ -0,0 +1,422 @@
+package withdraw
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	"github.com/thrasher-/gocryptotrader/db"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+)
+
+// Statuses of a withdrawal through the approval workflow. Denied requests
+// failed validation and are only recorded, approved withdrawals are being
+// submitted to their exchange. Queued withdrawals are held by the withdrawal
+// manager until their batch is requested.
+const (
+	StatusQueued    = "QUEUED"
+	StatusDenied    = "DENIED"
+	StatusPending   = "PENDING"
+	StatusRejected  = "REJECTED"
+	StatusApproved  = "APPROVED"
+	StatusSubmitted = "SUBMITTED"
+	StatusFailed    = "FAILED"
+)
+
+// Errors returned by the approval workflow
+var (
+	ErrAddressNotWhitelisted      = errors.New("withdrawal address is not whitelisted")
+	ErrCurrencyDailyLimitExceeded = errors.New("withdrawal exceeds the daily limit of the currency")
+	ErrNotPending                 = errors.New("withdrawal is not pending approval")
+)
+
+// WhitelistEntry is a destination address withdrawals of a currency may be
+// sent to, entries without an exchange apply to every exchange
+type WhitelistEntry struct {
+	Exchange string
+	Currency pair.CurrencyItem
+	Address  string
+}
+
+// matches returns whether the entry is the destination of a withdrawal
+func (e *WhitelistEntry) matches(w *Withdrawal) bool {
+	return (e.Exchange == "" || strings.EqualFold(e.Exchange, w.Exchange)) &&
+		strings.EqualFold(e.Currency.String(), w.Currency.String()) &&
+		e.Address == w.Address
+}
+
+// ApprovalConfig holds the whitelisted destinations and the daily limits,
+// keyed by uppercase currency, of the approval workflow. Currencies without a
+// limit are not limited.
+type ApprovalConfig struct {
+	Whitelist   []WhitelistEntry
+	DailyLimits map[string]float64
+}
+
+// Withdrawal is a withdrawal through the approval workflow. Reason holds why
+// it was denied, rejected or failed and TxID the exchange's ID once submitted.
+type Withdrawal struct {
+	ID        int64
+	Exchange  string
+	Currency  pair.CurrencyItem
+	Address   string
+	Amount    float64
+	Status    string
+	Reason    string
+	TxID      string
+	Requested time.Time
+	Updated   time.Time
+}
+
+// Approvals is a two step withdrawal workflow, withdrawals are requested and
+// only submitted to their exchange once approved. Destinations must be
+// whitelisted or authorised by the address book when Addresses is set, and
+// withdrawals of a currency are kept within its rolling daily limit. Every
+// step is recorded to the store when it is set.
+type Approvals struct {
+	ApprovalConfig
+	Addresses *AddressBook
+	// OnUpdate is called each time a withdrawal is requested, rejected,
+	// submitted or fails
+	OnUpdate func(Withdrawal)
+
+	store       db.WithdrawalAttemptRepository
+	getExchange exchange.ExchangeGetter
+	withdrawals []Withdrawal
+	nextID      int64
+	m           sync.Mutex
+}
+
+// NewApprovals returns a new withdrawal approval workflow recording attempts
+// to store
+func NewApprovals(c ApprovalConfig, store db.WithdrawalAttemptRepository, getExchange exchange.ExchangeGetter) *Approvals {
+	limits := make(map[string]float64, len(c.DailyLimits))
+	for currency, limit := range c.DailyLimits {
+		limits[strings.ToUpper(currency)] = limit
+	}
+	c.DailyLimits = limits
+	return &Approvals{ApprovalConfig: c, store: store, getExchange: getExchange}
+}
+
+// Load restores the withdrawals of recorded attempts, given in the order they
+// were made, so withdrawals left pending stay approvable and those submitted
+// count against the daily limits after a restart. Denied attempts are skipped.
+func (a *Approvals) Load(attempts []db.WithdrawalAttempt) {
+	a.m.Lock()
+	defer a.m.Unlock()
+	for i := range attempts {
+		r := &attempts[i]
+		if r.RequestID == 0 || r.Status == StatusDenied {
+			continue
+		}
+		x := a.index(r.RequestID)
+		if x < 0 {
+			a.withdrawals = append(a.withdrawals, Withdrawal{
+				ID:        r.RequestID,
+				Exchange:  r.Exchange,
+				Currency:  pair.CurrencyItem(r.Currency),
+				Address:   r.Address,
+				Amount:    r.Amount,
+				Requested: r.Timestamp,
+			})
+			x = len(a.withdrawals) - 1
+		}
+		a.withdrawals[x].Status = r.Status
+		a.withdrawals[x].Reason = r.Reason
+		a.withdrawals[x].TxID = r.TxID
+		a.withdrawals[x].Updated = r.Timestamp
+		if r.RequestID > a.nextID {
+			a.nextID = r.RequestID
+		}
+	}
+	sort.Slice(a.withdrawals, func(i, j int) bool { return a.withdrawals[i].ID < a.withdrawals[j].ID })
+}
+
+// Request validates a withdrawal and adds it pending approval. Withdrawals
+// which fail validation are recorded as denied and not added.
+func (a *Approvals) Request(exchName string, currency pair.CurrencyItem, address string, amount float64, now time.Time) (Withdrawal, error) {
+	w := Withdrawal{
+		Exchange:  exchName,
+		Currency:  pair.CurrencyItem(strings.ToUpper(currency.String())),
+		Address:   address,
+		Amount:    amount,
+		Status:    StatusPending,
+		Requested: now,
+		Updated:   now,
+	}
+
+	a.m.Lock()
+	err := a.validate(&w, now)
+	if err != nil {
+		a.m.Unlock()
+		w.Status = StatusDenied
+		w.Reason = err.Error()
+		if recordErr := a.record(&w); recordErr != nil {
+			return w, recordErr
+		}
+		return w, err
+	}
+	w.ID = a.allocateID()
+	if err = a.record(&w); err != nil {
+		a.m.Unlock()
+		return Withdrawal{}, err
+	}
+	a.withdrawals = append(a.withdrawals, w)
+	a.m.Unlock()
+
+	a.updated(w)
+	return w, nil
+}
+
+// Approve revalidates a pending withdrawal and submits it to its exchange,
+// returning it as submitted or failed along with the error it failed with.
+// Withdrawals which no longer pass validation are left pending and the denied
+// approval is recorded.
+func (a *Approvals) Approve(id int64, now time.Time) (Withdrawal, error) {
+	a.m.Lock()
+	x := a.index(id)
+	if x < 0 {
+		a.m.Unlock()
+		return Withdrawal{}, ErrRequestNotFound
+	}
+	if a.withdrawals[x].Status != StatusPending {
+		a.m.Unlock()
+		return a.withdrawals[x], ErrNotPending
+	}
+	w := a.withdrawals[x]
+	w.Updated = now
+	if err := a.validate(&w, now); err != nil {
+		denied := w
+		denied.Status = StatusDenied
+		denied.Reason = err.Error()
+		if recordErr := a.record(&denied); recordErr != nil {
+			err = fmt.Errorf("%s, recording the denial: %s", err, recordErr)
+		}
+		a.m.Unlock()
+		return a.withdrawals[x], err
+	}
+	w.Status = StatusApproved
+	if err := a.record(&w); err != nil {
+		a.m.Unlock()
+		return a.withdrawals[x], err
+	}
+	a.withdrawals[x] = w
+	a.m.Unlock()
+
+	exch := a.getExchange(w.Exchange)
+	if exch == nil {
+		return a.fail(id, fmt.Errorf("%s %s", w.Exchange, ErrExchangeNotFound), now)
+	}
+	txID, err := exch.WithdrawCryptocurrencyFunds(w.Address, w.Currency, w.Amount)
+	if err != nil {
+		return a.fail(id, err, now)
+	}
+	if a.Addresses != nil {
+		a.Addresses.Record(w.Exchange, w.Currency, w.Address, w.Amount, now)
+	}
+
+	a.m.Lock()
+	x = a.index(id)
+	a.withdrawals[x].Status = StatusSubmitted
+	a.withdrawals[x].TxID = txID
+	a.withdrawals[x].Updated = now
+	w = a.withdrawals[x]
+	err = a.record(&w)
+	a.m.Unlock()
+
+	a.updated(w)
+	if err != nil {
+		return w, fmt.Errorf("withdrawal %d submitted as %s but not recorded: %s", id, txID, err)
+	}
+	return w, nil
+}
+
+// Reject rejects a pending withdrawal
+func (a *Approvals) Reject(id int64, reason string, now time.Time) (Withdrawal, error) {
+	a.m.Lock()
+	x := a.index(id)
+	if x < 0 {
+		a.m.Unlock()
+		return Withdrawal{}, ErrRequestNotFound
+	}
+	if a.withdrawals[x].Status != StatusPending {
+		a.m.Unlock()
+		return a.withdrawals[x], ErrNotPending
+	}
+	w := a.withdrawals[x]
+	w.Status = StatusRejected
+	w.Reason = reason
+	w.Updated = now
+	if err := a.record(&w); err != nil {
+		a.m.Unlock()
+		return a.withdrawals[x], err
+	}
+	a.withdrawals[x] = w
+	a.m.Unlock()
+
+	a.updated(w)
+	return w, nil
+}
+
+// Withdrawal returns a withdrawal by ID
+func (a *Approvals) Withdrawal(id int64) (Withdrawal, error) {
+	a.m.Lock()
+	defer a.m.Unlock()
+	x := a.index(id)
+	if x < 0 {
+		return Withdrawal{}, ErrRequestNotFound
+	}
+	return a.withdrawals[x], nil
+}
+
+// Withdrawals returns a copy of the withdrawals in ID order
+func (a *Approvals) Withdrawals() []Withdrawal {
+	a.m.Lock()
+	defer a.m.Unlock()
+	withdrawals := make([]Withdrawal, len(a.withdrawals))
+	copy(withdrawals, a.withdrawals)
+	return withdrawals
+}
+
+// fail marks a withdrawal as failed with err and records it
+func (a *Approvals) fail(id int64, err error, now time.Time) (Withdrawal, error) {
+	a.m.Lock()
+	x := a.index(id)
+	a.withdrawals[x].Status = StatusFailed
+	a.withdrawals[x].Reason = err.Error()
+	a.withdrawals[x].Updated = now
+	w := a.withdrawals[x]
+	if recordErr := a.record(&w); recordErr != nil {
+		err = fmt.Errorf("%s, recording the failure: %s", err, recordErr)
+	}
+	a.m.Unlock()
+
+	a.updated(w)
+	return w, err
+}
+
+// validate returns an error unless a withdrawal may be made at now, which
+// requires a loaded exchange, an authorised destination and enough of the
+// currency's daily limit remaining. The mutex must be held by the caller.
+func (a *Approvals) validate(w *Withdrawal, now time.Time) error {
+	if w.Exchange == "" || w.Currency == "" || w.Address == "" || w.Amount <= 0 {
+		return ErrInvalidRequest
+	}
+	if a.getExchange(w.Exchange) == nil {
+		return fmt.Errorf("%s %s", w.Exchange, ErrExchangeNotFound)
+	}
+	if err := a.authorise(w, now); err != nil {
+		return err
+	}
+
+	limit, ok := a.DailyLimits[w.Currency.String()]
+	if !ok {
+		return nil
+	}
+	used := a.used(w, now)
+	if used+w.Amount > limit {
+		return fmt.Errorf("%s: %v of %v %s remaining", ErrCurrencyDailyLimitExceeded,
+			limit-used, limit, w.Currency)
+	}
+	return nil
+}
+
+// authorise returns an error unless the destination of a withdrawal is
+// authorised by the address book or whitelisted. Address book entries which
+// are locked, unverified or over their limits are not authorised even when
+// also whitelisted.
+func (a *Approvals) authorise(w *Withdrawal, now time.Time) error {
+	if a.Addresses != nil {
+		err := a.Addresses.Authorise(w.Exchange, w.Currency, w.Address, w.Amount, now)
+		if err != ErrAddressNotListed {
+			return err
+		}
+	}
+	for i := range a.Whitelist {
+		if a.Whitelist[i].matches(w) {
+			return nil
+		}
+	}
+	return ErrAddressNotWhitelisted
+}
+
+// used returns the amount of a withdrawal's currency counted against its
+// daily limit at now, the other withdrawals pending, being submitted or
+// submitted within the daily window. The mutex must be held by the caller.
+func (a *Approvals) used(w *Withdrawal, now time.Time) float64 {
+	cutoff := now.Add(-DailyWindow)
+	var used float64
+	for i := range a.withdrawals {
+		o := &a.withdrawals[i]
+		if o.ID == w.ID || !strings.EqualFold(o.Currency.String(), w.Currency.String()) {
+			continue
+		}
+		switch o.Status {
+		case StatusPending, StatusApproved:
+		case StatusSubmitted:
+			if !o.Updated.After(cutoff) {
+				continue
+			}
+		default:
+			continue
+		}
+		used += o.Amount
+	}
+	return used
+}
+
+// newID returns a withdrawal ID unused by the workflow, the withdrawal
+// manager queues requests under these IDs so queued and requested
+// withdrawals are never confused
+func (a *Approvals) newID() int64 {
+	a.m.Lock()
+	defer a.m.Unlock()
+	return a.allocateID()
+}
+
+// allocateID returns the next withdrawal ID. The mutex must be held by the
+// caller.
+func (a *Approvals) allocateID() int64 {
+	a.nextID++
+	return a.nextID
+}
+
+// index returns the position of a withdrawal or -1. The mutex must be held by
+// the caller.
+func (a *Approvals) index(id int64) int {
+	for i := range a.withdrawals {
+		if a.withdrawals[i].ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// record stores a withdrawal attempt when the workflow has a store
+func (a *Approvals) record(w *Withdrawal) error {
+	if a.store == nil {
+		return nil
+	}
+	return a.store.InsertWithdrawalAttempt(db.WithdrawalAttempt{
+		RequestID: w.ID,
+		Exchange:  w.Exchange,
+		Currency:  w.Currency.String(),
+		Address:   w.Address,
+		Amount:    w.Amount,
+		Status:    w.Status,
+		Reason:    w.Reason,
+		TxID:      w.TxID,
+		Timestamp: w.Updated,
+	})
+}
+
+// updated calls OnUpdate with a withdrawal
+func (a *Approvals) updated(w Withdrawal) {
+	if a.OnUpdate != nil {
+		a.OnUpdate(w)
+	}
+}
@@ -0,0 +1,231 @@
+package withdraw
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	"github.com/thrasher-/gocryptotrader/db"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+)
+
+// testAttempts records withdrawal attempts in memory, failing when err is set
+type testAttempts struct {
+	attempts []db.WithdrawalAttempt
+	err      error
+}
+
+func (s *testAttempts) InsertWithdrawalAttempt(a db.WithdrawalAttempt) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.attempts = append(s.attempts, a)
+	return nil
+}
+
+func (s *testAttempts) WithdrawalAttempts(exchName string, start, end time.Time) ([]db.WithdrawalAttempt, error) {
+	return s.attempts, nil
+}
+
+// statuses returns the statuses of the recorded attempts
+func (s *testAttempts) statuses() string {
+	var statuses []string
+	for i := range s.attempts {
+		statuses = append(statuses, s.attempts[i].Status)
+	}
+	return strings.Join(statuses, ",")
+}
+
+func newTestApprovals() (*Approvals, *testExchange, *testAttempts) {
+	exch := &testExchange{}
+	store := &testAttempts{}
+	a := NewApprovals(ApprovalConfig{
+		Whitelist: []WhitelistEntry{
+			{Currency: "BTC", Address: "addr1"},
+			{Exchange: "Kraken", Currency: "LTC", Address: "addr2"},
+		},
+		DailyLimits: map[string]float64{"btc": 1},
+	}, store, func(name string) exchange.IBotExchange {
+		if name == "Binance" || name == "Kraken" {
+			return exch
+		}
+		return nil
+	})
+	return a, exch, store
+}
+
+func TestApprovalsRequest(t *testing.T) {
+	a, _, store := newTestApprovals()
+	now := time.Date(2019, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		exchange string
+		currency string
+		address  string
+		amount   float64
+		err      error
+	}{
+		{"Binance", "BTC", "addr1", 0, ErrInvalidRequest},
+		{"Binance", "LTC", "addr2", 1, ErrAddressNotWhitelisted},
+		{"Binance", "BTC", "addr3", 1, ErrAddressNotWhitelisted},
+		{"Binance", "BTC", "addr1", 1.5, ErrCurrencyDailyLimitExceeded},
+		{"Bitfinex", "BTC", "addr1", 1, ErrExchangeNotFound},
+	}
+	for i := range tests {
+		_, err := a.Request(tests[i].exchange, pair.CurrencyItem(tests[i].currency), tests[i].address, tests[i].amount, now)
+		if err == nil || !strings.Contains(err.Error(), tests[i].err.Error()) {
+			t.Errorf("Test Failed - Request() %d expected %v, received %v", i, tests[i].err, err)
+		}
+	}
+	if len(a.Withdrawals()) != 0 || store.statuses() != "DENIED,DENIED,DENIED,DENIED,DENIED" {
+		t.Error("Test Failed - Request() expected denied requests recorded only", store.attempts)
+	}
+
+	w, err := a.Request("Kraken", "ltc", "addr2", 10, now)
+	if err != nil || w.ID != 1 || w.Status != StatusPending || w.Currency != "LTC" {
+		t.Fatal("Test Failed - Request() unexpected withdrawal", w, err)
+	}
+	if _, err = a.Request("Binance", "BTC", "addr1", 0.6, now); err != nil {
+		t.Fatal("Test Failed - Request() error", err)
+	}
+	// Pending withdrawals count against the daily limit
+	if _, err = a.Request("Binance", "BTC", "addr1", 0.6, now); err == nil ||
+		!strings.Contains(err.Error(), ErrCurrencyDailyLimitExceeded.Error()) {
+		t.Error("Test Failed - Request() expected pending withdrawals counted against the limit", err)
+	}
+
+	store.err = errors.New("database down")
+	if _, err = a.Request("Kraken", "LTC", "addr2", 1, now); err != store.err {
+		t.Error("Test Failed - Request() expected unrecorded withdrawals refused", err)
+	}
+	if withdrawals := a.Withdrawals(); len(withdrawals) != 2 {
+		t.Error("Test Failed - Withdrawals() unexpected withdrawals", withdrawals)
+	}
+}
+
+func TestApprovalsApprove(t *testing.T) {
+	a, exch, store := newTestApprovals()
+	var updates []Withdrawal
+	a.OnUpdate = func(w Withdrawal) { updates = append(updates, w) }
+	now := time.Date(2019, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	w, err := a.Request("Binance", "BTC", "addr1", 0.7, now)
+	if err != nil {
+		t.Fatal("Test Failed - Request() error", err)
+	}
+	if w, err = a.Approve(w.ID, now); err != nil || w.Status != StatusSubmitted || w.TxID != "TX1" {
+		t.Fatal("Test Failed - Approve() unexpected withdrawal", w, err)
+	}
+	if len(exch.withdrawals) != 1 || store.statuses() != "PENDING,APPROVED,SUBMITTED" ||
+		store.attempts[2].TxID != "TX1" || len(updates) != 2 {
+		t.Error("Test Failed - Approve() expected withdrawal submitted and recorded", exch.withdrawals, store.attempts, updates)
+	}
+	if _, err = a.Approve(w.ID, now); err != ErrNotPending {
+		t.Error("Test Failed - Approve() expected not pending error", err)
+	}
+	if _, err = a.Approve(10, now); err != ErrRequestNotFound {
+		t.Error("Test Failed - Approve() expected not found error", err)
+	}
+
+	// Submitted withdrawals count against the limit within the daily window
+	if _, err = a.Request("Binance", "BTC", "addr1", 0.5, now.Add(time.Hour)); err == nil {
+		t.Error("Test Failed - Request() expected daily limit exceeded")
+	}
+	later := now.Add(DailyWindow)
+	w, err = a.Request("Binance", "BTC", "addr1", 0.5, later)
+	if err != nil {
+		t.Fatal("Test Failed - Request() error after the daily window", err)
+	}
+
+	exch.fail = true
+	if w, err = a.Approve(w.ID, later); err == nil || w.Status != StatusFailed || w.Reason != err.Error() {
+		t.Error("Test Failed - Approve() expected failed withdrawal", w, err)
+	}
+
+	w, err = a.Request("Kraken", "LTC", "addr2", 1, later)
+	if err != nil {
+		t.Fatal("Test Failed - Request() error", err)
+	}
+	if w, err = a.Reject(w.ID, "unexpected", later); err != nil || w.Status != StatusRejected || w.Reason != "unexpected" {
+		t.Error("Test Failed - Reject() unexpected withdrawal", w, err)
+	}
+	if _, err = a.Reject(w.ID, "", later); err != ErrNotPending {
+		t.Error("Test Failed - Reject() expected not pending error", err)
+	}
+	if w, err = a.Withdrawal(w.ID); err != nil || w.Status != StatusRejected {
+		t.Error("Test Failed - Withdrawal() unexpected withdrawal", w, err)
+	}
+}
+
+func TestApprovalsAddressBook(t *testing.T) {
+	a, exch, store := newTestApprovals()
+	book, err := NewAddressBook(nil, "hunter2")
+	if err != nil {
+		t.Fatal("Test Failed - NewAddressBook() error", err)
+	}
+	a.Addresses = book
+	now := time.Now()
+
+	entry, err := book.Add(Address{Exchange: "Binance", Currency: "ETH", Address: "0xabc", DailyLimit: 2})
+	if err != nil {
+		t.Fatal("Test Failed - Add() error", err)
+	}
+	if _, err = a.Request("Binance", "ETH", "0xabc", 1, now); err != ErrAddressUnverified {
+		t.Error("Test Failed - Request() expected unverified address error", err)
+	}
+	if _, err = book.Verify(entry.ID, true); err != nil {
+		t.Fatal("Test Failed - Verify() error", err)
+	}
+	w, err := a.Request("Binance", "ETH", "0xabc", 1.5, now)
+	if err != nil {
+		t.Fatal("Test Failed - Request() error", err)
+	}
+
+	// Approvals are revalidated, a locked address is left pending
+	if _, err = book.Lock(entry.ID, true); err != nil {
+		t.Fatal("Test Failed - Lock() error", err)
+	}
+	if w, err = a.Approve(w.ID, now); err != ErrAddressLocked || w.Status != StatusPending {
+		t.Error("Test Failed - Approve() expected locked address left pending", w, err)
+	}
+	if _, err = book.Lock(entry.ID, false); err != nil {
+		t.Fatal("Test Failed - Lock() error", err)
+	}
+	if w, err = a.Approve(w.ID, now); err != nil || w.Status != StatusSubmitted || len(exch.withdrawals) != 1 {
+		t.Fatal("Test Failed - Approve() unexpected withdrawal", w, err)
+	}
+	if daily, _, _ := book.Usage(entry.ID, now); daily != 1.5 {
+		t.Error("Test Failed - Approve() expected the withdrawal recorded in the address book", daily)
+	}
+	if store.statuses() != "DENIED,PENDING,DENIED,APPROVED,SUBMITTED" {
+		t.Error("Test Failed - unexpected recorded attempts", store.statuses())
+	}
+}
+
+func TestApprovalsLoad(t *testing.T) {
+	a, _, _ := newTestApprovals()
+	now := time.Now()
+	a.Load([]db.WithdrawalAttempt{
+		{RequestID: 2, Exchange: "Binance", Currency: "BTC", Address: "addr1", Amount: 0.8, Status: StatusPending, Timestamp: now.Add(-time.Hour)},
+		{RequestID: 0, Exchange: "Binance", Currency: "BTC", Address: "addr3", Amount: 1, Status: StatusDenied, Timestamp: now.Add(-time.Hour)},
+		{RequestID: 2, Exchange: "Binance", Currency: "BTC", Address: "addr1", Amount: 0.8, Status: StatusApproved, Timestamp: now},
+		{RequestID: 2, Exchange: "Binance", Currency: "BTC", Address: "addr1", Amount: 0.8, Status: StatusSubmitted, TxID: "TX1", Timestamp: now},
+		{RequestID: 3, Exchange: "Kraken", Currency: "LTC", Address: "addr2", Amount: 1, Status: StatusPending, Timestamp: now},
+	})
+	withdrawals := a.Withdrawals()
+	if len(withdrawals) != 2 || withdrawals[0].Status != StatusSubmitted || withdrawals[0].TxID != "TX1" ||
+		!withdrawals[0].Requested.Equal(now.Add(-time.Hour)) || withdrawals[1].Status != StatusPending {
+		t.Fatal("Test Failed - Load() unexpected withdrawals", withdrawals)
+	}
+	if _, err := a.Request("Binance", "BTC", "addr1", 0.5, now); err == nil {
+		t.Error("Test Failed - Request() expected loaded withdrawals counted against the limit")
+	}
+	if w, err := a.Request("Kraken", "LTC", "addr2", 1, now); err != nil || w.ID != 4 {
+		t.Error("Test Failed - Request() expected IDs to follow the loaded withdrawals", w, err)
+	}
+	if w, err := a.Approve(3, now); err != nil || w.Status != StatusSubmitted {
+		t.Error("Test Failed - Approve() expected loaded pending withdrawal approvable", w, err)
+	}
+}
@@ -0,0 +1,165 @@
+package withdraw
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/common"
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+)
+
+// Public network fee APIs
+var (
+	BitcoinFeeURL  = "https://mempool.space/api/v1/fees/recommended"
+	EthereumGasURL = "https://api.etherscan.io/api?module=gastracker&action=gasoracle"
+)
+
+// DefaultFeeCacheDuration is how long a fetched network fee is reused
+const DefaultFeeCacheDuration = time.Minute
+
+// ErrNoFeeSource is returned when no network fee source is registered for a
+// currency
+var ErrNoFeeSource = errors.New("no network fee source for currency")
+
+// FeeSource returns the current network fee of a chain in its native unit,
+// sat/vB for bitcoin and gwei for ethereum
+type FeeSource func() (float64, error)
+
+// BitcoinFee returns the bitcoin fee rate in sat/vB for confirmation within
+// an hour
+func BitcoinFee() (float64, error) {
+	var resp struct {
+		FastestFee  float64 `json:"fastestFee"`
+		HalfHourFee float64 `json:"halfHourFee"`
+		HourFee     float64 `json:"hourFee"`
+	}
+	if err := common.SendHTTPGetRequest(BitcoinFeeURL, true, false, &resp); err != nil {
+		return 0, err
+	}
+	if resp.HourFee <= 0 {
+		return 0, errors.New("bitcoin fee API returned no hour fee")
+	}
+	return resp.HourFee, nil
+}
+
+// EthereumGasPrice returns the safe ethereum gas price in gwei
+func EthereumGasPrice() (float64, error) {
+	var resp struct {
+		Status  string `json:"status"`
+		Message string `json:"message"`
+		Result  struct {
+			SafeGasPrice string `json:"SafeGasPrice"`
+		} `json:"result"`
+	}
+	if err := common.SendHTTPGetRequest(EthereumGasURL, true, false, &resp); err != nil {
+		return 0, err
+	}
+	if resp.Status != "1" {
+		return 0, fmt.Errorf("ethereum gas API error: %s", resp.Message)
+	}
+	return strconv.ParseFloat(resp.Result.SafeGasPrice, 64)
+}
+
+// cachedFee holds the last fetched fee of a currency
+type cachedFee struct {
+	fee     float64
+	fetched time.Time
+}
+
+// FeeOracle tracks current network fees and decides whether they are low
+// enough to withdraw. Its Condition method is used as the withdrawal
+// manager's FeeCondition, so non-urgent withdrawals are held while the fee is
+// above the currency's threshold until their maximum delay passes.
+type FeeOracle struct {
+	CacheDuration time.Duration
+
+	sources    map[string]FeeSource
+	thresholds map[string]float64
+	cache      map[string]cachedFee
+	m          sync.Mutex
+}
+
+// NewFeeOracle returns a new fee oracle with the bitcoin and ethereum fee
+// sources registered and no thresholds set
+func NewFeeOracle() *FeeOracle {
+	o := &FeeOracle{
+		CacheDuration: DefaultFeeCacheDuration,
+		sources:       make(map[string]FeeSource),
+		thresholds:    make(map[string]float64),
+		cache:         make(map[string]cachedFee),
+	}
+	o.SetSource("BTC", BitcoinFee)
+	o.SetSource("ETH", EthereumGasPrice)
+	return o
+}
+
+// SetSource registers the network fee source for a currency, tokens on a
+// chain can share the chain's source
+func (o *FeeOracle) SetSource(currency pair.CurrencyItem, source FeeSource) {
+	o.m.Lock()
+	defer o.m.Unlock()
+	key := strings.ToUpper(currency.String())
+	o.sources[key] = source
+	delete(o.cache, key)
+}
+
+// SetThreshold sets the fee at or below which withdrawals of a currency are
+// released, zero removes the threshold
+func (o *FeeOracle) SetThreshold(currency pair.CurrencyItem, threshold float64) {
+	o.m.Lock()
+	defer o.m.Unlock()
+	key := strings.ToUpper(currency.String())
+	if threshold <= 0 {
+		delete(o.thresholds, key)
+		return
+	}
+	o.thresholds[key] = threshold
+}
+
+// Fee returns the current network fee of a currency, reusing the last
+// fetched fee within the cache duration
+func (o *FeeOracle) Fee(currency pair.CurrencyItem) (float64, error) {
+	key := strings.ToUpper(currency.String())
+	o.m.Lock()
+	source, ok := o.sources[key]
+	cached, cachedOK := o.cache[key]
+	o.m.Unlock()
+
+	if !ok {
+		return 0, ErrNoFeeSource
+	}
+	if cachedOK && time.Since(cached.fetched) < o.CacheDuration {
+		return cached.fee, nil
+	}
+
+	fee, err := source()
+	if err != nil {
+		return 0, err
+	}
+	o.m.Lock()
+	o.cache[key] = cachedFee{fee: fee, fetched: time.Now()}
+	o.m.Unlock()
+	return fee, nil
+}
+
+// Condition reports whether the network fee of a currency is at or below its
+// threshold. Currencies without a threshold are always released, while a
+// failure to fetch the fee holds withdrawals.
+func (o *FeeOracle) Condition(currency pair.CurrencyItem, now time.Time) bool {
+	o.m.Lock()
+	threshold, ok := o.thresholds[strings.ToUpper(currency.String())]
+	o.m.Unlock()
+	if !ok {
+		return true
+	}
+
+	fee, err := o.Fee(currency)
+	if err != nil {
+		return false
+	}
+	return fee <= threshold
+}
@@ -0,0 +1,292 @@
+// Package withdraw provides a withdrawal manager which queues cryptocurrency
+// withdrawals and consolidates small withdrawals of the same currency to the
+// same destination into a single batch, so only one network fee is paid, and
+// an approval workflow which only sends withdrawals once they are approved.
+// Batches are requested through the approval workflow, they are never sent
+// without approval.
+package withdraw
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+)
+
+// Errors returned by the withdrawal manager
+var (
+	ErrInvalidRequest   = errors.New("withdrawal requires an exchange, currency, address and amount greater than zero")
+	ErrRequestNotFound  = errors.New("withdrawal request not found")
+	ErrExchangeNotFound = errors.New("exchange not found")
+	ErrNegativeConfig   = errors.New("withdrawal batching values cannot be negative")
+	ErrNoApprovals      = errors.New("withdrawal manager requires an approval workflow")
+)
+
+// Request is a queued withdrawal. Urgent requests are released on the next
+// process run along with any other queued requests to the same destination.
+// MaxDelay overrides the manager's maximum delay for the request.
+type Request struct {
+	ID        int64
+	Exchange  string
+	Currency  pair.CurrencyItem
+	Address   string
+	Amount    float64
+	Urgent    bool
+	MaxDelay  time.Duration
+	Requested time.Time
+}
+
+// Batch is a consolidated withdrawal of one or more requests of the same
+// currency from the same exchange to the same address
+type Batch struct {
+	Exchange string
+	Currency pair.CurrencyItem
+	Address  string
+	Amount   float64
+	Requests []Request
+}
+
+// FeeSaving returns the network fees saved by consolidating the batch given
+// the fee charged per withdrawal
+func (b *Batch) FeeSaving(fee float64) float64 {
+	if len(b.Requests) < 2 {
+		return 0
+	}
+	return fee * float64(len(b.Requests)-1)
+}
+
+// Result holds the outcome of requesting approval of a batch, Withdrawal is
+// the pending withdrawal or the denied attempt when Err is set
+type Result struct {
+	Batch      Batch
+	Withdrawal Withdrawal
+	Err        error
+}
+
+// Config holds the batching parameters. A batch is held until its amount
+// reaches MinBatchAmount and network fees are low, unless a request is urgent
+// or the oldest request has waited MaxDelay. Zero values disable the limit.
+type Config struct {
+	MinBatchAmount float64
+	MaxDelay       time.Duration
+}
+
+// Validate checks the batching parameters
+func (c *Config) Validate() error {
+	if c.MinBatchAmount < 0 || c.MaxDelay < 0 {
+		return ErrNegativeConfig
+	}
+	return nil
+}
+
+// FeeCondition reports whether network fees for a currency are currently low
+// enough to withdraw, for chains with dynamic fees
+type FeeCondition func(currency pair.CurrencyItem, now time.Time) bool
+
+// DefaultProcessInterval is how often Run releases batches when no interval
+// is given
+const DefaultProcessInterval = 10 * time.Minute
+
+// Manager queues withdrawals and releases them as consolidated batches, which
+// are requested through its approval workflow and only sent once approved.
+// Withdrawals are only queued to destinations the workflow authorises, under
+// IDs allocated by the workflow.
+type Manager struct {
+	Config
+	FeeCondition FeeCondition
+	// OnResult is called with the outcome of each batch requested by Run
+	OnResult func(Result)
+
+	approvals *Approvals
+	queue     []Request
+	m         sync.Mutex
+}
+
+// NewManager returns a new withdrawal manager requesting batches through
+// approvals
+func NewManager(c Config, approvals *Approvals) (*Manager, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	if approvals == nil {
+		return nil, ErrNoApprovals
+	}
+	return &Manager{Config: c, approvals: approvals}, nil
+}
+
+// Queue adds a withdrawal request and returns its ID, the request time is set
+// to now if not supplied
+func (m *Manager) Queue(r Request) (int64, error) {
+	if r.Exchange == "" || r.Currency == "" || r.Address == "" || r.Amount <= 0 {
+		return 0, ErrInvalidRequest
+	}
+	if r.MaxDelay < 0 {
+		return 0, ErrNegativeConfig
+	}
+	if r.Requested.IsZero() {
+		r.Requested = time.Now()
+	}
+
+	m.m.Lock()
+	defer m.m.Unlock()
+	pending := Withdrawal{Exchange: r.Exchange, Currency: r.Currency, Address: r.Address, Amount: r.Amount}
+	for i := range m.queue {
+		if sameDestination(&m.queue[i], &r) {
+			pending.Amount += m.queue[i].Amount
+		}
+	}
+	if err := m.approvals.authorise(&pending, time.Now()); err != nil {
+		return 0, err
+	}
+	r.ID = m.approvals.newID()
+	m.queue = append(m.queue, r)
+	return r.ID, nil
+}
+
+// Cancel removes a queued withdrawal request
+func (m *Manager) Cancel(id int64) error {
+	m.m.Lock()
+	defer m.m.Unlock()
+	for i := range m.queue {
+		if m.queue[i].ID == id {
+			m.queue = append(m.queue[:i], m.queue[i+1:]...)
+			return nil
+		}
+	}
+	return ErrRequestNotFound
+}
+
+// Pending returns a copy of the queued withdrawal requests
+func (m *Manager) Pending() []Request {
+	m.m.Lock()
+	defer m.m.Unlock()
+	pending := make([]Request, len(m.queue))
+	copy(pending, m.queue)
+	return pending
+}
+
+// Ready removes and returns the batches which should be withdrawn at now
+func (m *Manager) Ready(now time.Time) []Batch {
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	var ready []Batch
+	var held []Request
+	for _, b := range consolidate(m.queue) {
+		if m.release(&b, now) {
+			ready = append(ready, b)
+			continue
+		}
+		held = append(held, b.Requests...)
+	}
+	sort.Slice(held, func(i, j int) bool { return held[i].ID < held[j].ID })
+	m.queue = held
+	return ready
+}
+
+// Process requests approval of the batches which are ready at now. Batches
+// the approval workflow denies, for instance over a daily limit or to a
+// locked address, are recorded as denied and dropped, while batches which
+// could not be recorded are returned to the queue.
+func (m *Manager) Process(now time.Time) []Result {
+	var results []Result
+	for _, b := range m.Ready(now) {
+		r := Result{Batch: b}
+		r.Withdrawal, r.Err = m.approvals.Request(b.Exchange, b.Currency, b.Address, b.Amount, now)
+		if r.Err != nil && r.Withdrawal.Status != StatusDenied {
+			m.requeue(b.Requests)
+		}
+		results = append(results, r)
+	}
+	return results
+}
+
+// Run requests approval of the batches which are ready every interval until
+// stop is closed
+func (m *Manager) Run(interval time.Duration, stop <-chan struct{}) {
+	if interval <= 0 {
+		interval = DefaultProcessInterval
+	}
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		for _, r := range m.Process(time.Now()) {
+			if m.OnResult != nil {
+				m.OnResult(r)
+			}
+		}
+		select {
+		case <-stop:
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// release returns whether a batch should be withdrawn at now. The mutex must
+// be held by the caller.
+func (m *Manager) release(b *Batch, now time.Time) bool {
+	for i := range b.Requests {
+		if b.Requests[i].Urgent {
+			return true
+		}
+		delay := b.Requests[i].MaxDelay
+		if delay == 0 {
+			delay = m.MaxDelay
+		}
+		if delay > 0 && now.Sub(b.Requests[i].Requested) >= delay {
+			return true
+		}
+	}
+	if b.Amount < m.MinBatchAmount {
+		return false
+	}
+	return m.FeeCondition == nil || m.FeeCondition(b.Currency, now)
+}
+
+// requeue returns requests to the queue in ID order
+func (m *Manager) requeue(requests []Request) {
+	m.m.Lock()
+	defer m.m.Unlock()
+	m.queue = append(m.queue, requests...)
+	sort.Slice(m.queue, func(i, j int) bool { return m.queue[i].ID < m.queue[j].ID })
+}
+
+// sameDestination returns whether two requests withdraw the same currency from
+// the same exchange to the same address
+func sameDestination(a, b *Request) bool {
+	return strings.EqualFold(a.Exchange, b.Exchange) &&
+		strings.EqualFold(a.Currency.String(), b.Currency.String()) &&
+		a.Address == b.Address
+}
+
+// consolidate groups requests by exchange, currency and destination address,
+// requests within a batch are ordered oldest first
+func consolidate(requests []Request) []Batch {
+	var batches []Batch
+	index := make(map[string]int)
+	for i := range requests {
+		key := strings.ToLower(requests[i].Exchange) + "|" +
+			strings.ToUpper(requests[i].Currency.String()) + "|" + requests[i].Address
+		x, ok := index[key]
+		if !ok {
+			x = len(batches)
+			index[key] = x
+			batches = append(batches, Batch{
+				Exchange: requests[i].Exchange,
+				Currency: requests[i].Currency,
+				Address:  requests[i].Address,
+			})
+		}
+		batches[x].Amount += requests[i].Amount
+		batches[x].Requests = append(batches[x].Requests, requests[i])
+	}
+	for i := range batches {
+		r := batches[i].Requests
+		sort.Slice(r, func(a, b int) bool { return r[a].Requested.Before(r[b].Requested) })
+	}
+	return batches
+}
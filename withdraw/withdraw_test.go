@@ -0,0 +1,225 @@
+package withdraw
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/thrasher-/gocryptotrader/currency/pair"
+	exchange "github.com/thrasher-/gocryptotrader/exchanges"
+)
+
+type testExchange struct {
+	exchange.IBotExchange
+	withdrawals []float64
+	fail        bool
+}
+
+func (t *testExchange) WithdrawCryptocurrencyFunds(address string, c pair.CurrencyItem, amount float64) (string, error) {
+	if t.fail {
+		return "", errors.New("withdrawals suspended")
+	}
+	t.withdrawals = append(t.withdrawals, amount)
+	return "TX1", nil
+}
+
+func TestManager(t *testing.T) {
+	approvals := NewApprovals(ApprovalConfig{Whitelist: []WhitelistEntry{
+		{Currency: "BTC", Address: "addr1"},
+		{Currency: "BTC", Address: "addr2"},
+		{Currency: "LTC", Address: "addr1"},
+	}}, nil, func(string) exchange.IBotExchange { return &testExchange{} })
+	if _, err := NewManager(Config{MinBatchAmount: -1}, approvals); err != ErrNegativeConfig {
+		t.Error("Test Failed - NewManager() expected negative config error", err)
+	}
+	if _, err := NewManager(Config{}, nil); err != ErrNoApprovals {
+		t.Error("Test Failed - NewManager() expected approvals required error", err)
+	}
+
+	m, err := NewManager(Config{MinBatchAmount: 1, MaxDelay: time.Hour}, approvals)
+	if err != nil {
+		t.Fatal("Test Failed - NewManager() error", err)
+	}
+	if _, err = m.Queue(Request{Exchange: "Binance", Currency: "BTC"}); err != ErrInvalidRequest {
+		t.Error("Test Failed - Queue() expected invalid request error", err)
+	}
+
+	start := time.Date(2018, 7, 1, 0, 0, 0, 0, time.UTC)
+	queue := []Request{
+		{Exchange: "Binance", Currency: "BTC", Address: "addr1", Amount: 0.4, Requested: start},
+		{Exchange: "binance", Currency: "btc", Address: "addr1", Amount: 0.3, Requested: start.Add(time.Minute)},
+		{Exchange: "Binance", Currency: "BTC", Address: "addr2", Amount: 0.1, Requested: start},
+		{Exchange: "Binance", Currency: "LTC", Address: "addr1", Amount: 5, Requested: start},
+	}
+	for i := range queue {
+		if _, err = m.Queue(queue[i]); err != nil {
+			t.Fatal("Test Failed - Queue() error", err)
+		}
+	}
+
+	lowFees := false
+	m.FeeCondition = func(c pair.CurrencyItem, now time.Time) bool { return lowFees }
+	if ready := m.Ready(start.Add(time.Minute)); len(ready) != 0 {
+		t.Errorf("Test Failed - Ready() expected no batches while fees are high %v", ready)
+	}
+
+	lowFees = true
+	ready := m.Ready(start.Add(time.Minute))
+	if len(ready) != 1 || ready[0].Currency != "LTC" {
+		t.Fatalf("Test Failed - Ready() expected LTC batch received %v", ready)
+	}
+
+	id, err := m.Queue(Request{Exchange: "Binance", Currency: "BTC", Address: "addr1",
+		Amount: 0.3, Requested: start.Add(2 * time.Minute)})
+	if err != nil {
+		t.Fatal("Test Failed - Queue() error", err)
+	}
+	ready = m.Ready(start.Add(2 * time.Minute))
+	if len(ready) != 1 || ready[0].Amount != 1 || len(ready[0].Requests) != 3 ||
+		ready[0].FeeSaving(0.0005) != 0.001 {
+		t.Fatalf("Test Failed - Ready() expected consolidated BTC batch received %v", ready)
+	}
+	if m.Cancel(id) != ErrRequestNotFound {
+		t.Error("Test Failed - Cancel() expected released request to be removed")
+	}
+
+	// The remaining small withdrawal is released once it reaches the max delay
+	if ready = m.Ready(start.Add(time.Hour)); len(ready) != 1 || ready[0].Address != "addr2" {
+		t.Errorf("Test Failed - Ready() expected max delay release received %v", ready)
+	}
+}
+
+func TestProcess(t *testing.T) {
+	a, exch, store := newTestApprovals()
+	m, err := NewManager(Config{MinBatchAmount: 10}, a)
+	if err != nil {
+		t.Fatal("Test Failed - NewManager() error", err)
+	}
+	if _, err = m.Queue(Request{Exchange: "Binance", Currency: "BTC", Address: "addr9", Amount: 0.1}); err != ErrAddressNotWhitelisted {
+		t.Error("Test Failed - Queue() expected unauthorised destination error", err)
+	}
+
+	if _, err = m.Queue(Request{Exchange: "Binance", Currency: "BTC", Address: "addr1", Amount: 0.4}); err != nil {
+		t.Fatal("Test Failed - Queue() error", err)
+	}
+	if results := m.Process(time.Now()); len(results) != 0 {
+		t.Error("Test Failed - Process() expected small withdrawal to be held", results)
+	}
+
+	if _, err = m.Queue(Request{Exchange: "Binance", Currency: "BTC", Address: "addr1", Amount: 0.3, Urgent: true}); err != nil {
+		t.Fatal("Test Failed - Queue() error", err)
+	}
+	results := m.Process(time.Now())
+	if len(results) != 1 || results[0].Err != nil || results[0].Withdrawal.Status != StatusPending ||
+		results[0].Withdrawal.Amount != 0.7 || len(exch.withdrawals) != 0 || len(m.Pending()) != 0 {
+		t.Fatal("Test Failed - Process() expected consolidated withdrawal pending approval", results)
+	}
+	if _, err = a.Approve(results[0].Withdrawal.ID, time.Now()); err != nil ||
+		len(exch.withdrawals) != 1 || exch.withdrawals[0] != 0.7 {
+		t.Error("Test Failed - Approve() expected batch sent once approved", err, exch.withdrawals)
+	}
+
+	// Batches over the daily limit are denied and dropped
+	if _, err = m.Queue(Request{Exchange: "Binance", Currency: "BTC", Address: "addr1", Amount: 0.5, Urgent: true}); err != nil {
+		t.Fatal("Test Failed - Queue() error", err)
+	}
+	results = m.Process(time.Now())
+	if len(results) != 1 || results[0].Err == nil || results[0].Withdrawal.Status != StatusDenied ||
+		len(m.Pending()) != 0 {
+		t.Error("Test Failed - Process() expected batch over the daily limit denied", results)
+	}
+
+	// Batches which cannot be recorded are returned to the queue
+	store.err = errors.New("database unavailable")
+	if _, err = m.Queue(Request{Exchange: "Kraken", Currency: "LTC", Address: "addr2", Amount: 1, Urgent: true}); err != nil {
+		t.Fatal("Test Failed - Queue() error", err)
+	}
+	results = m.Process(time.Now())
+	if len(results) != 1 || results[0].Err == nil || len(m.Pending()) != 1 {
+		t.Error("Test Failed - Process() expected unrecorded batch requeued", results)
+	}
+	if statuses := store.statuses(); statuses != "PENDING,APPROVED,SUBMITTED,DENIED" {
+		t.Error("Test Failed - Process() unexpected recorded attempts", statuses)
+	}
+}
+
+func TestFeeOracle(t *testing.T) {
+	o := NewFeeOracle()
+	fee := 30.0
+	calls := 0
+	o.SetSource("BTC", func() (float64, error) {
+		calls++
+		return fee, nil
+	})
+	o.SetSource("XRP", func() (float64, error) {
+		return 0, errors.New("fee API unavailable")
+	})
+
+	if !o.Condition("BTC", time.Now()) {
+		t.Error("Test Failed - Condition() expected release without threshold")
+	}
+	if _, err := o.Fee("DOGE"); err != ErrNoFeeSource {
+		t.Error("Test Failed - Fee() expected no fee source error", err)
+	}
+
+	o.SetThreshold("btc", 10)
+	o.SetThreshold("XRP", 1)
+	if o.Condition("BTC", time.Now()) {
+		t.Error("Test Failed - Condition() expected hold above threshold")
+	}
+	if o.Condition("XRP", time.Now()) {
+		t.Error("Test Failed - Condition() expected hold on fee error")
+	}
+
+	fee = 5
+	if o.Condition("BTC", time.Now()) || calls != 1 {
+		t.Error("Test Failed - Condition() expected cached fee to be used", calls)
+	}
+	o.CacheDuration = 0
+	if !o.Condition("BTC", time.Now()) || calls != 2 {
+		t.Error("Test Failed - Condition() expected release below threshold", calls)
+	}
+
+	a, _, _ := newTestApprovals()
+	m, err := NewManager(Config{MaxDelay: 24 * time.Hour}, a)
+	if err != nil {
+		t.Fatal("Test Failed - NewManager() error", err)
+	}
+	m.FeeCondition = o.Condition
+	fee = 50
+	start := time.Now()
+	if _, err = m.Queue(Request{Exchange: "Binance", Currency: "BTC", Address: "addr1",
+		Amount: 1, MaxDelay: time.Hour, Requested: start}); err != nil {
+		t.Fatal("Test Failed - Queue() error", err)
+	}
+	if ready := m.Ready(start.Add(time.Minute)); len(ready) != 0 {
+		t.Error("Test Failed - Ready() expected withdrawal held on high fees", ready)
+	}
+	if ready := m.Ready(start.Add(time.Hour)); len(ready) != 1 {
+		t.Error("Test Failed - Ready() expected request max delay override", ready)
+	}
+}
+
+func TestNetworkFeeSources(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/btc" {
+			w.Write([]byte(`{"fastestFee":20,"halfHourFee":15,"hourFee":12,"economyFee":5,"minimumFee":1}`))
+			return
+		}
+		w.Write([]byte(`{"status":"1","message":"OK","result":{"LastBlock":"6000000","SafeGasPrice":"8","ProposeGasPrice":"10","FastGasPrice":"15"}}`))
+	}))
+	defer server.Close()
+
+	btcURL, ethURL := BitcoinFeeURL, EthereumGasURL
+	BitcoinFeeURL, EthereumGasURL = server.URL+"/btc", server.URL+"/eth"
+	defer func() { BitcoinFeeURL, EthereumGasURL = btcURL, ethURL }()
+
+	if fee, err := BitcoinFee(); err != nil || fee != 12 {
+		t.Error("Test Failed - BitcoinFee() unexpected result", fee, err)
+	}
+	if fee, err := EthereumGasPrice(); err != nil || fee != 8 {
+		t.Error("Test Failed - EthereumGasPrice() unexpected result", fee, err)
+	}
+}